@@ -350,6 +350,54 @@ func TestNEP17MultiTransfer(t *testing.T) {
 	})
 }
 
+func TestNEP17TransferToContact(t *testing.T) {
+	privs, _ := testcli.GenerateKeys(t, 2)
+
+	e := testcli.NewExecutor(t, true)
+	walletPath := filepath.Join(t.TempDir(), "wallet.json")
+	data, err := os.ReadFile(testcli.ValidatorWallet)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(walletPath, data, 0644))
+
+	e.Run(t, "neo-go", "wallet", "contact", "add", "--wallet", walletPath,
+		"--name", "friend:alice", "--address", privs[0].Address())
+	e.Run(t, "neo-go", "wallet", "contact", "add", "--wallet", walletPath,
+		"--name", "bob", "--address", privs[1].Address())
+
+	args := []string{
+		"neo-go", "wallet", "nep17", "transfer",
+		"--rpc-endpoint", "http://" + e.RPC.Addresses()[0],
+		"--wallet", walletPath,
+		"--from", testcli.ValidatorAddr,
+		"--token", "NEO",
+		"--amount", "1",
+		"--force",
+	}
+	t.Run("unknown contact", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "neither a valid address nor a known contact", append(args, "--to", "friend:carol")...)
+	})
+
+	e.In.WriteString("one\r")
+	e.Run(t, append(args, "--to", "friend:alice")...)
+	e.CheckTxPersisted(t)
+	b, _ := e.Chain.GetGoverningTokenBalance(privs[0].GetScriptHash())
+	require.Equal(t, big.NewInt(1), b)
+
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "wallet", "nep17", "multitransfer",
+		"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+		"--wallet", walletPath,
+		"--from", testcli.ValidatorAddr,
+		"--force",
+		"NEO:friend:alice:2",
+		"NEO:bob:3")
+	e.CheckTxPersisted(t)
+	b, _ = e.Chain.GetGoverningTokenBalance(privs[0].GetScriptHash())
+	require.Equal(t, big.NewInt(3), b)
+	b, _ = e.Chain.GetGoverningTokenBalance(privs[1].GetScriptHash())
+	require.Equal(t, big.NewInt(3), b)
+}
+
 func TestNEP17ImportToken(t *testing.T) {
 	e := testcli.NewExecutor(t, true)
 	tmpDir := t.TempDir()
@@ -213,20 +213,28 @@ func GetRPCWithInvoker(gctx context.Context, ctx *cli.Context, signers []transac
 }
 
 // GetConfigFromContext looks at the path and the mode flags in the given config and
-// returns an appropriate config.
+// returns an appropriate config. Address settings of the config are applied
+// to the process.
 func GetConfigFromContext(ctx *cli.Context) (config.Config, error) {
 	var (
+		cfg          config.Config
+		err          error
 		configFile   = ctx.String("config-file")
 		relativePath = ctx.String("relative-path")
 	)
 	if len(configFile) != 0 {
-		return config.LoadFile(configFile, relativePath)
+		cfg, err = config.LoadFile(configFile, relativePath)
+	} else {
+		var configPath = config.DefaultConfigPath
+		if argCp := ctx.String("config-path"); argCp != "" {
+			configPath = argCp
+		}
+		cfg, err = config.Load(configPath, GetNetwork(ctx), relativePath)
 	}
-	var configPath = config.DefaultConfigPath
-	if argCp := ctx.String("config-path"); argCp != "" {
-		configPath = argCp
+	if err == nil {
+		cfg.ProtocolConfiguration.ApplyAddressSettings()
 	}
-	return config.Load(configPath, GetNetwork(ctx), relativePath)
+	return cfg, err
 }
 
 var (
@@ -340,10 +348,10 @@ func GetRPCWithActor(gctx context.Context, ctx *cli.Context, signers []actor.Sig
 	return c, a, nil
 }
 
-// GetAccFromContext returns account and wallet from context. If address is not set, default address is used.
-func GetAccFromContext(ctx *cli.Context) (*wallet.Account, *wallet.Wallet, error) {
-	var addr util.Uint160
-
+// GetWalletFromContext opens the wallet specified in the context (either
+// directly or via wallet config) without unlocking any accounts. The password
+// from the wallet config is returned as well if it's used.
+func GetWalletFromContext(ctx *cli.Context) (*wallet.Wallet, *string, error) {
 	wPath := ctx.String("wallet")
 	walletConfigPath := ctx.String("wallet-config")
 	if len(wPath) != 0 && len(walletConfigPath) != 0 {
@@ -366,6 +374,17 @@ func GetAccFromContext(ctx *cli.Context) (*wallet.Account, *wallet.Wallet, error
 	if err != nil {
 		return nil, nil, err
 	}
+	return wall, pass, nil
+}
+
+// GetAccFromContext returns account and wallet from context. If address is not set, default address is used.
+func GetAccFromContext(ctx *cli.Context) (*wallet.Account, *wallet.Wallet, error) {
+	var addr util.Uint160
+
+	wall, pass, err := GetWalletFromContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
 	addrFlag := ctx.Generic("address").(*flags.Address)
 	if addrFlag.IsSet {
 		addr = addrFlag.Uint160()
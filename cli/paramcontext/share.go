@@ -0,0 +1,291 @@
+package paramcontext
+
+import (
+	"bytes"
+	gocontext "context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/services/helpers/neofs"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	"github.com/nspcc-dev/neofs-sdk-go/client"
+	cid "github.com/nspcc-dev/neofs-sdk-go/container/id"
+	"github.com/nspcc-dev/neofs-sdk-go/object"
+	"github.com/nspcc-dev/neofs-sdk-go/user"
+)
+
+const (
+	// maxRemoteContextSize is the maximum size of a context fetched via HTTP
+	// or NeoFS.
+	maxRemoteContextSize = 16 << 20
+
+	// contextHashAttribute is the NeoFS object attribute containing the
+	// verifiable item hash of the stored context.
+	contextHashAttribute = "ContextHash"
+	// participantAttribute is the NeoFS object attribute containing the
+	// participant id of the stored context.
+	participantAttribute = "Participant"
+)
+
+// isURL checks whether the location is an HTTP(S) endpoint rather than a
+// local (or mounted shared) directory.
+func isURL(location string) bool {
+	u, err := url.Parse(location)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https")
+}
+
+// isNeoFS checks whether the location is a NeoFS container
+// (neofs:<container> URL).
+func isNeoFS(location string) bool {
+	return strings.HasPrefix(location, neofs.URIScheme+":")
+}
+
+// neoFSContainer parses the container ID from the NeoFS location.
+func neoFSContainer(location string, fsEndpoint string) (cid.ID, error) {
+	var cnr cid.ID
+	if fsEndpoint == "" {
+		return cnr, errors.New("NeoFS endpoint is required for NeoFS location")
+	}
+	if err := cnr.DecodeString(strings.TrimPrefix(location, neofs.URIScheme+":")); err != nil {
+		return cnr, fmt.Errorf("%w: %w", neofs.ErrInvalidContainer, err)
+	}
+	return cnr, nil
+}
+
+// contextFileName returns the name of the context file published to the
+// shared directory by the given participant.
+func contextFileName(c *context.ParameterContext, id string) string {
+	return c.Verifiable.Hash().StringLE() + "." + id + ".json"
+}
+
+// Publish stores the parameter context at the shared location. If location is
+// an HTTP(S) URL, the context is POSTed there as JSON. If it's a NeoFS
+// container (neofs:<container> URL), the context is stored as an object with
+// the verifiable item hash and the given participant id attributes using
+// fsEndpoint node. Otherwise location is treated as a directory where the
+// context is saved to a file named after the verifiable item hash and the
+// given participant id (so that co-signers don't overwrite each other's
+// files).
+func Publish(ctx gocontext.Context, c *context.ParameterContext, location string, fsEndpoint string, id string) error {
+	if isNeoFS(location) {
+		return publishNeoFS(ctx, c, location, fsEndpoint, id)
+	}
+	if !isURL(location) {
+		if err := os.MkdirAll(location, 0755); err != nil {
+			return fmt.Errorf("can't create directory: %w", err)
+		}
+		return Save(c, filepath.Join(location, contextFileName(c, id)))
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("can't marshal transaction: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, location, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("can't publish context: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("can't publish context: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// Collect fetches all contexts published at the shared location for the same
+// verifiable item as c and merges their signatures into c. For HTTP(S)
+// locations the endpoint is expected to return either a single context or a
+// JSON array of contexts on GET, NeoFS locations are searched for objects
+// stored by Publish using fsEndpoint node. It returns the number of signatures
+// added.
+func Collect(ctx gocontext.Context, c *context.ParameterContext, location string, fsEndpoint string) (int, error) {
+	var (
+		ctxs []*context.ParameterContext
+		err  error
+	)
+	if isNeoFS(location) {
+		ctxs, err = fetchNeoFS(ctx, c, location, fsEndpoint)
+	} else if isURL(location) {
+		ctxs, err = fetch(ctx, location)
+	} else {
+		ctxs, err = readDir(c, location)
+	}
+	if err != nil {
+		return 0, err
+	}
+	var added int
+	for _, other := range ctxs {
+		if !other.Verifiable.Hash().Equals(c.Verifiable.Hash()) {
+			continue
+		}
+		n, err := c.Merge(other)
+		added += n
+		if err != nil {
+			return added, fmt.Errorf("can't merge context: %w", err)
+		}
+	}
+	return added, nil
+}
+
+func readDir(c *context.ParameterContext, dir string) ([]*context.ParameterContext, error) {
+	prefix := c.Verifiable.Hash().StringLE() + "."
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("can't read directory: %w", err)
+	}
+	var res []*context.ParameterContext
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), prefix) || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		pc, err := Read(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Name(), err)
+		}
+		res = append(res, pc)
+	}
+	return res, nil
+}
+
+func fetch(ctx gocontext.Context, location string) ([]*context.ParameterContext, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("can't fetch contexts: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("can't fetch contexts: unexpected status %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteContextSize))
+	if err != nil {
+		return nil, fmt.Errorf("can't fetch contexts: %w", err)
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var res []*context.ParameterContext
+		if err := json.Unmarshal(data, &res); err != nil {
+			return nil, fmt.Errorf("can't parse transaction: %w", err)
+		}
+		return res, nil
+	}
+	pc := new(context.ParameterContext)
+	if err := json.Unmarshal(data, pc); err != nil {
+		return nil, fmt.Errorf("can't parse transaction: %w", err)
+	}
+	return []*context.ParameterContext{pc}, nil
+}
+
+func publishNeoFS(ctx gocontext.Context, c *context.ParameterContext, location string, fsEndpoint string, id string) error {
+	cnr, err := neoFSContainer(location, fsEndpoint)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("can't marshal transaction: %w", err)
+	}
+	priv, err := keys.NewPrivateKey()
+	if err != nil {
+		return err
+	}
+	fsc, err := neofs.GetClient(ctx, fsEndpoint, 0)
+	if err != nil {
+		return err
+	}
+	defer fsc.Close()
+
+	var (
+		hdr    object.Object
+		signer = user.NewAutoIDSignerRFC6979(priv.PrivateKey)
+	)
+	hdr.SetContainerID(cnr)
+	hdr.SetOwner(signer.UserID())
+	hdr.SetAttributes(
+		*object.NewAttribute(object.AttributeFileName, contextFileName(c, id)),
+		*object.NewAttribute(contextHashAttribute, c.Verifiable.Hash().StringLE()),
+		*object.NewAttribute(participantAttribute, id),
+	)
+	w, err := fsc.ObjectPutInit(ctx, hdr, signer, client.PrmObjectPutInit{})
+	if err != nil {
+		return fmt.Errorf("can't publish context: %w", err)
+	}
+	if _, err = w.Write(data); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("can't publish context: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return fmt.Errorf("can't publish context: %w", err)
+	}
+	return nil
+}
+
+func fetchNeoFS(ctx gocontext.Context, c *context.ParameterContext, location string, fsEndpoint string) ([]*context.ParameterContext, error) {
+	cnr, err := neoFSContainer(location, fsEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	priv, err := keys.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	fsc, err := neofs.GetClient(ctx, fsEndpoint, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer fsc.Close()
+
+	var (
+		prm     client.PrmObjectSearch
+		filters = object.NewSearchFilters()
+	)
+	filters.AddFilter(contextHashAttribute, c.Verifiable.Hash().StringLE(), object.MatchStringEqual)
+	prm.SetFilters(filters)
+	ids, err := neofs.ObjectSearch(ctx, fsc, priv, cnr.String(), prm)
+	if err != nil {
+		return nil, fmt.Errorf("can't fetch contexts: %w", err)
+	}
+	var res []*context.ParameterContext
+	for _, id := range ids {
+		u, err := url.Parse(fmt.Sprintf("%s:%s/%s", neofs.URIScheme, cnr, id))
+		if err != nil {
+			return nil, err
+		}
+		rc, err := neofs.GetWithClient(ctx, fsc, priv, u, false)
+		if err != nil {
+			return nil, fmt.Errorf("can't fetch context %s: %w", id, err)
+		}
+		data, err := io.ReadAll(io.LimitReader(rc, maxRemoteContextSize))
+		_ = rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("can't fetch context %s: %w", id, err)
+		}
+		pc := new(context.ParameterContext)
+		if err := json.Unmarshal(data, pc); err != nil {
+			return nil, fmt.Errorf("can't parse context %s: %w", id, err)
+		}
+		res = append(res, pc)
+	}
+	return res, nil
+}
@@ -1,15 +1,22 @@
 package server_test
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/testcli"
 	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 	"gopkg.in/yaml.v3"
 )
 
@@ -156,3 +163,119 @@ func TestDBDumpRestoreIncremental(t *testing.T) {
 	// Restore second 15 blocks from incremental dump.
 	e.Run(t, append(restoreBaseArgs, "--in", incDump, "-n", "--count", "15")...)
 }
+
+func TestDBRestoreParallel(t *testing.T) {
+	tmpDir := t.TempDir()
+	chainPath := filepath.Join(tmpDir, "neogotestchain")
+	dumpPath := filepath.Join(tmpDir, "testdump.acc")
+
+	cfg, err := config.LoadFile(filepath.Join("..", "..", "config", "protocol.unit_testnet.yml"))
+	require.NoError(t, err, "could not load config")
+	cfg.ApplicationConfiguration.DBConfiguration.Type = dbconfig.LevelDB
+	cfg.ApplicationConfiguration.DBConfiguration.LevelDBOptions.DataDirectoryPath = chainPath
+	out, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+
+	cfgPath := filepath.Join(tmpDir, "protocol.unit_testnet.yml")
+	require.NoError(t, os.WriteFile(cfgPath, out, os.ModePerm))
+
+	e := testcli.NewExecutor(t, false)
+
+	restoreArgs := []string{"neo-go", "db", "restore", "--unittest",
+		"--config-path", tmpDir, "--in", inDump, "-j", "4", "--progress", "7"}
+
+	// Partial restore.
+	e.Run(t, append(restoreArgs, "--count", "20")...)
+
+	// Resume till the end.
+	e.Run(t, restoreArgs...)
+
+	e.Run(t, "neo-go", "db", "dump", "--unittest", "--config-path", tmpDir, "--out", dumpPath)
+
+	d1, err := os.ReadFile(inDump)
+	require.NoError(t, err)
+	d2, err := os.ReadFile(dumpPath)
+	require.NoError(t, err)
+	require.Equal(t, d1, d2, "dumps differ")
+}
+
+func TestDBRestoreCheckpoints(t *testing.T) {
+	tmpDir := t.TempDir()
+	chainPath := filepath.Join(tmpDir, "neogotestchain")
+	cpPath := filepath.Join(tmpDir, "checkpoints.json")
+
+	cfg, err := config.LoadFile(filepath.Join("..", "..", "config", "protocol.unit_testnet.yml"))
+	require.NoError(t, err, "could not load config")
+	cfg.ApplicationConfiguration.DBConfiguration.Type = dbconfig.LevelDB
+	cfg.ApplicationConfiguration.DBConfiguration.LevelDBOptions.DataDirectoryPath = chainPath
+	out, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+
+	cfgPath := filepath.Join(tmpDir, "protocol.unit_testnet.yml")
+	require.NoError(t, os.WriteFile(cfgPath, out, os.ModePerm))
+
+	e := testcli.NewExecutor(t, false)
+
+	restoreArgs := []string{"neo-go", "db", "restore", "--unittest",
+		"--config-path", tmpDir, "--in", inDump}
+
+	// Get trusted state roots from the regularly restored chain.
+	e.Run(t, restoreArgs...)
+	st, err := storage.NewStore(cfg.ApplicationConfiguration.DBConfiguration)
+	require.NoError(t, err)
+	chain, err := core.NewBlockchain(st, cfg.Blockchain(), zap.NewNop())
+	require.NoError(t, err)
+	go chain.Run()
+	var roots []state.MPTRoot
+	for i := uint32(0); i <= chain.BlockHeight(); i += 10 {
+		sr, err := chain.GetStateModule().GetStateRoot(i)
+		require.NoError(t, err)
+		roots = append(roots, *sr)
+	}
+	height := chain.BlockHeight()
+	if height%10 != 0 {
+		sr, err := chain.GetStateModule().GetStateRoot(height)
+		require.NoError(t, err)
+		roots = append(roots, *sr)
+	}
+	chain.Close()
+	require.NoError(t, os.RemoveAll(chainPath))
+
+	writeCheckpoints := func(t *testing.T, roots []state.MPTRoot) {
+		data, err := json.Marshal(roots)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(cpPath, data, os.ModePerm))
+	}
+
+	t.Run("interval without checkpoints", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "--checkpoint-interval can only be used with --checkpoints", append(restoreArgs, "--checkpoint-interval", "10")...)
+	})
+	t.Run("missing file", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "can't read checkpoints", append(restoreArgs, "--checkpoints", filepath.Join(tmpDir, "unknown.json"))...)
+	})
+	t.Run("missing checkpoint", func(t *testing.T) {
+		writeCheckpoints(t, roots)
+		e.RunWithErrorCheckExit(t, "no checkpoint for block 7", append(restoreArgs, "--checkpoints", cpPath, "--checkpoint-interval", "7")...)
+	})
+	t.Run("missing last checkpoint", func(t *testing.T) {
+		writeCheckpoints(t, roots[:len(roots)-1])
+		e.RunWithErrorCheckExit(t, "no checkpoint for the last restored block "+strconv.Itoa(int(height)), append(restoreArgs, "--checkpoints", cpPath)...)
+	})
+	t.Run("root mismatch", func(t *testing.T) {
+		bad := slices.Clone(roots)
+		bad[2].Root = util.Uint256{1, 2, 3}
+		writeCheckpoints(t, bad)
+		e.RunWithErrorCheckExit(t, "state root doesn't match the trusted one", append(restoreArgs, "--checkpoints", cpPath, "--checkpoint-interval", "10")...)
+	})
+
+	writeCheckpoints(t, roots)
+	e.Run(t, append(restoreArgs, "--checkpoints", cpPath, "--checkpoint-interval", "10", "-j", "4")...)
+
+	dumpPath := filepath.Join(tmpDir, "testdump.acc")
+	e.Run(t, "neo-go", "db", "dump", "--unittest", "--config-path", tmpDir, "--out", dumpPath)
+	d1, err := os.ReadFile(inDump)
+	require.NoError(t, err)
+	d2, err := os.ReadFile(dumpPath)
+	require.NoError(t, err)
+	require.Equal(t, d1, d2, "dumps differ")
+}
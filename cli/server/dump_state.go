@@ -0,0 +1,205 @@
+package server
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/urfave/cli/v2"
+)
+
+// stateSnapshot is a JSON representation of contract storage snapshot.
+type stateSnapshot struct {
+	Hash   util.Uint160    `json:"hash"`
+	ID     int32           `json:"id"`
+	Name   string          `json:"name"`
+	Height uint32          `json:"height"`
+	Items  []snapshotEntry `json:"items"`
+}
+
+// snapshotEntry is a single encoded storage item.
+type snapshotEntry struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// dumpState saves storage of the requested contracts (all contracts by
+// default) at the specified height into a set of files (one per contract).
+func dumpState(ctx *cli.Context) error {
+	if ctx.IsSet("start") || ctx.IsSet("count") {
+		return cli.Exit("--start and --count can't be used with --state", 1)
+	}
+	var format = ctx.String("format")
+	if format != "json" && format != "csv" {
+		return cli.Exit(fmt.Sprintf("unknown output format: %s", format), 1)
+	}
+	var encode func([]byte) string
+	switch enc := ctx.String("encoding"); enc {
+	case "base64":
+		encode = base64.StdEncoding.EncodeToString
+	case "hex":
+		encode = hex.EncodeToString
+	default:
+		return cli.Exit(fmt.Sprintf("unknown encoding: %s", enc), 1)
+	}
+	var hashes []util.Uint160
+	for _, s := range ctx.StringSlice("contract") {
+		h, err := flags.ParseAddress(s)
+		if err != nil {
+			return cli.Exit(fmt.Sprintf("invalid contract hash %s: %s", s, err), 1)
+		}
+		hashes = append(hashes, h)
+	}
+	out := ctx.String("out")
+	if out == "" {
+		return cli.Exit("output directory is not specified", 1)
+	}
+
+	cfg, err := options.GetConfigFromContext(ctx)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	log, _, logCloser, err := options.HandleLoggingParams(ctx.Bool("debug"), cfg.ApplicationConfiguration)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	if logCloser != nil {
+		defer func() { _ = logCloser() }()
+	}
+
+	chain, prometheus, pprof, err := initBCWithMetrics(cfg, log)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		pprof.ShutDown()
+		prometheus.ShutDown()
+		chain.Close()
+	}()
+
+	var height = chain.BlockHeight()
+	if ctx.IsSet("height") {
+		height = uint32(ctx.Uint("height"))
+		if height > chain.BlockHeight() {
+			return cli.Exit(fmt.Errorf("chain is not that high (%d) to dump state at %d", chain.BlockHeight(), height), 1)
+		}
+	}
+	root, err := chain.GetStateModule().GetStateRoot(height)
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("failed to get state root for height %d: %s", height, err), 1)
+	}
+	contracts, err := getHistoricalContracts(chain, root.Root, hashes)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	if _, err = os.Stat(out); os.IsNotExist(err) {
+		if err = os.MkdirAll(out, os.ModePerm); err != nil {
+			return cli.Exit(fmt.Sprintf("failed to create directory %s: %s", out, err), 1)
+		}
+	}
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("failed to check directory %s: %s", out, err), 1)
+	}
+
+	for _, cs := range contracts {
+		snap := &stateSnapshot{
+			Hash:   cs.Hash,
+			ID:     cs.ID,
+			Name:   cs.Manifest.Name,
+			Height: height,
+			Items:  []snapshotEntry{},
+		}
+		chain.GetStateModule().SeekStates(root.Root, makeStateKey(cs.ID, nil), func(k, v []byte) bool {
+			snap.Items = append(snap.Items, snapshotEntry{Key: encode(k), Value: encode(v)})
+			return true
+		})
+		filePath := filepath.Join(out, cs.Hash.StringLE()+"."+format)
+		if err = saveSnapshotToFile(snap, format, filePath); err != nil {
+			return cli.Exit(fmt.Sprintf("failed to save contract %s state to file %s: %s", cs.Hash.StringLE(), filePath, err), 1)
+		}
+	}
+	return nil
+}
+
+// getHistoricalContracts returns states of the specified contracts (or all
+// contracts if none are specified) from the state with the given root.
+func getHistoricalContracts(chain *core.Blockchain, root util.Uint256, hashes []util.Uint160) ([]*state.Contract, error) {
+	var (
+		res []*state.Contract
+		sm  = chain.GetStateModule()
+	)
+	if len(hashes) == 0 {
+		var err error
+		sm.SeekStates(root, makeStateKey(native.ManagementContractID, []byte{native.PrefixContract}), func(_, v []byte) bool {
+			cs := new(state.Contract)
+			if err = stackitem.DeserializeConvertible(v, cs); err != nil {
+				err = fmt.Errorf("failed to deserialize contract state: %w", err)
+				return false
+			}
+			res = append(res, cs)
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+	for _, h := range hashes {
+		csBytes, err := sm.GetState(root, makeStateKey(native.ManagementContractID, native.MakeContractKey(h)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get contract %s state: %w", h.StringLE(), err)
+		}
+		cs := new(state.Contract)
+		if err = stackitem.DeserializeConvertible(csBytes, cs); err != nil {
+			return nil, fmt.Errorf("failed to deserialize contract %s state: %w", h.StringLE(), err)
+		}
+		res = append(res, cs)
+	}
+	return res, nil
+}
+
+// makeStateKey returns MPT key for the given contract ID and storage key.
+func makeStateKey(id int32, key []byte) []byte {
+	skey := make([]byte, 4+len(key))
+	binary.LittleEndian.PutUint32(skey, uint32(id))
+	copy(skey[4:], key)
+	return skey
+}
+
+func saveSnapshotToFile(snap *stateSnapshot, format string, filePath string) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if format == "json" {
+		enc := json.NewEncoder(file)
+		enc.SetIndent("", "\t")
+		return enc.Encode(snap)
+	}
+	w := csv.NewWriter(file)
+	if err = w.Write([]string{"key", "value"}); err != nil {
+		return err
+	}
+	for _, it := range snap.Items {
+		if err = w.Write([]string{it.Key, it.Value}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
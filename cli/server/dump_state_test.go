@@ -0,0 +1,127 @@
+package server_test
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDumpState(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg, err := config.LoadFile(filepath.Join("..", "..", "config", "protocol.unit_testnet.yml"))
+	require.NoError(t, err, "could not load config")
+	cfg.ApplicationConfiguration.DBConfiguration.Type = dbconfig.LevelDB
+	cfg.ApplicationConfiguration.DBConfiguration.LevelDBOptions.DataDirectoryPath = filepath.Join(tmpDir, "neogotestchain")
+	out, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+
+	cfgPath := filepath.Join(tmpDir, "protocol.unit_testnet.yml")
+	require.NoError(t, os.WriteFile(cfgPath, out, os.ModePerm))
+
+	e := testcli.NewExecutor(t, false)
+	e.Run(t, "neo-go", "db", "restore", "--config-file", cfgPath, "--in", inDump)
+
+	type snapshot struct {
+		Hash   util.Uint160 `json:"hash"`
+		ID     int32        `json:"id"`
+		Name   string       `json:"name"`
+		Height uint32       `json:"height"`
+		Items  []struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		} `json:"items"`
+	}
+	readSnapshot := func(t *testing.T, path string) snapshot {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		var s snapshot
+		require.NoError(t, json.Unmarshal(data, &s))
+		return s
+	}
+
+	t.Run("missing output directory", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "output directory is not specified",
+			"neo-go", "db", "dump", "--config-file", cfgPath, "--state")
+	})
+	t.Run("start with state", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "--start and --count can't be used with --state",
+			"neo-go", "db", "dump", "--config-file", cfgPath, "--state", "--out", tmpDir, "--start", "1")
+	})
+	t.Run("bad format", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "unknown output format: xml",
+			"neo-go", "db", "dump", "--config-file", cfgPath, "--state", "--out", tmpDir, "--format", "xml")
+	})
+	t.Run("bad encoding", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "unknown encoding: base58",
+			"neo-go", "db", "dump", "--config-file", cfgPath, "--state", "--out", tmpDir, "--encoding", "base58")
+	})
+	t.Run("bad contract", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "db", "dump", "--config-file", cfgPath, "--state", "--out", tmpDir, "--contract", "qwerty")
+	})
+	t.Run("unknown contract", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "db", "dump", "--config-file", cfgPath, "--state", "--out", tmpDir,
+			"--contract", util.Uint160{1, 2, 3}.StringLE())
+	})
+	t.Run("too high", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "db", "dump", "--config-file", cfgPath, "--state", "--out", tmpDir, "--height", "100500")
+	})
+
+	t.Run("all contracts", func(t *testing.T) {
+		outDir := filepath.Join(tmpDir, "all")
+		e.Run(t, "neo-go", "db", "dump", "--config-file", cfgPath, "--state", "--out", outDir)
+
+		entries, err := os.ReadDir(outDir)
+		require.NoError(t, err)
+		require.Greater(t, len(entries), len(nativenames.All))
+
+		s := readSnapshot(t, filepath.Join(outDir, nativehashes.GasToken.StringLE()+".json"))
+		require.Equal(t, nativehashes.GasToken, s.Hash)
+		require.Equal(t, nativenames.Gas, s.Name)
+		require.NotEmpty(t, s.Items)
+	})
+	t.Run("single contract at height, hex", func(t *testing.T) {
+		outDir := filepath.Join(tmpDir, "gas")
+		e.Run(t, "neo-go", "db", "dump", "--config-file", cfgPath, "--state", "--out", outDir,
+			"--height", "0", "--contract", nativehashes.GasToken.StringLE(), "--encoding", "hex")
+
+		entries, err := os.ReadDir(outDir)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(entries))
+
+		s := readSnapshot(t, filepath.Join(outDir, nativehashes.GasToken.StringLE()+".json"))
+		require.Equal(t, uint32(0), s.Height)
+		require.NotEmpty(t, s.Items)
+		for _, it := range s.Items {
+			_, err := hex.DecodeString(it.Key)
+			require.NoError(t, err)
+			_, err = hex.DecodeString(it.Value)
+			require.NoError(t, err)
+		}
+	})
+	t.Run("csv", func(t *testing.T) {
+		outDir := filepath.Join(tmpDir, "csv")
+		e.Run(t, "neo-go", "db", "dump", "--config-file", cfgPath, "--state", "--out", outDir,
+			"--contract", nativehashes.GasToken.StringLE(), "--format", "csv")
+
+		f, err := os.Open(filepath.Join(outDir, nativehashes.GasToken.StringLE()+".csv"))
+		require.NoError(t, err)
+		defer f.Close()
+		records, err := csv.NewReader(f).ReadAll()
+		require.NoError(t, err)
+		require.Greater(t, len(records), 1)
+		require.Equal(t, []string{"key", "value"}, records[0])
+	})
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -18,21 +19,29 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/chaindump"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	corestate "github.com/nspcc-dev/neo-go/pkg/core/stateroot"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/network"
+	"github.com/nspcc-dev/neo-go/pkg/services/blockuploader"
 	"github.com/nspcc-dev/neo-go/pkg/services/metrics"
 	"github.com/nspcc-dev/neo-go/pkg/services/notary"
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle"
 	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv"
+	"github.com/nspcc-dev/neo-go/pkg/services/shadowcheck"
 	"github.com/nspcc-dev/neo-go/pkg/services/stateroot"
+	"github.com/nspcc-dev/neo-go/pkg/services/webhook"
 	"github.com/urfave/cli/v2"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
+// defaultRestoreProgressInterval is the default number of blocks between
+// restore progress reports.
+const defaultRestoreProgressInterval = 10000
+
 // NewCommands returns 'node' command.
 func NewCommands() []*cli.Command {
 	cfgFlags := []cli.Flag{options.Config, options.ConfigFile, options.RelativePath}
@@ -60,6 +69,31 @@ func NewCommands() []*cli.Command {
 			Usage:   "Output file (stdout if not given)",
 		},
 	)
+	var cfgDumpFlags = slices.Clone(cfgCountOutFlags)
+	cfgDumpFlags = append(cfgDumpFlags,
+		&cli.BoolFlag{
+			Name:  "state",
+			Usage: "Dump contract storage snapshots into the output directory instead of blocks",
+		},
+		&cli.UintFlag{
+			Name:  "height",
+			Usage: "Height of the state to dump (default: current chain height), used with --state",
+		},
+		&cli.StringSliceFlag{
+			Name:  "contract",
+			Usage: "Hash or address of the contract to dump state of (default: all contracts), used with --state",
+		},
+		&cli.StringFlag{
+			Name:  "format",
+			Value: "json",
+			Usage: "Output format of the state snapshots (json or csv), used with --state",
+		},
+		&cli.StringFlag{
+			Name:  "encoding",
+			Value: "base64",
+			Usage: "Encoding of the storage keys and values (base64 or hex), used with --state",
+		},
+	)
 	var cfgCountInFlags = slices.Clone(cfgWithCountFlags)
 	cfgCountInFlags = append(cfgCountInFlags,
 		&cli.StringFlag{
@@ -76,6 +110,24 @@ func NewCommands() []*cli.Command {
 			Aliases: []string{"n"},
 			Usage:   "Use if dump is incremental",
 		},
+		&cli.UintFlag{
+			Name:    "workers",
+			Aliases: []string{"j"},
+			Usage:   "Number of goroutines decoding blocks in parallel with adding them to the chain (default or 0: serial restore)",
+		},
+		&cli.UintFlag{
+			Name:  "progress",
+			Usage: "Report restore progress every specified number of blocks (0: don't report)",
+			Value: defaultRestoreProgressInterval,
+		},
+		&cli.StringFlag{
+			Name:  "checkpoints",
+			Usage: "JSON file with trusted state roots to check restored blocks against (it must include the last restored block), block witnesses are not verified if set",
+		},
+		&cli.UintFlag{
+			Name:  "checkpoint-interval",
+			Usage: "Require trusted state roots for every specified number of restored blocks (default or 0: no requirement)",
+		},
 	)
 	var cfgHeightFlags = slices.Clone(cfgFlags)
 	cfgHeightFlags = append(cfgHeightFlags, &cli.UintFlag{
@@ -97,10 +149,10 @@ func NewCommands() []*cli.Command {
 			Subcommands: []*cli.Command{
 				{
 					Name:      "dump",
-					Usage:     "Dump blocks (starting with the genesis or specified block) to the file",
-					UsageText: "neo-go db dump [-o file] [-s start] [-c count] [--config-path path] [-p/-m/-t] [--config-file file]",
+					Usage:     "Dump blocks (starting with the genesis or specified block) to the file or contract storage snapshots (with --state) to the directory",
+					UsageText: "neo-go db dump [-o file] [-s start] [-c count] [--state -o directory [--height height] [--contract hash]... [--format json|csv] [--encoding base64|hex]] [--config-path path] [-p/-m/-t] [--config-file file]",
 					Action:    dumpDB,
-					Flags:     cfgCountOutFlags,
+					Flags:     cfgDumpFlags,
 				},
 				{
 					Name:      "dump-bin",
@@ -112,7 +164,7 @@ func NewCommands() []*cli.Command {
 				{
 					Name:      "restore",
 					Usage:     "Restore blocks from the file",
-					UsageText: "neo-go db restore [-i file] [--dump] [-n] [-c count] [--config-path path] [-p/-m/-t] [--config-file file]",
+					UsageText: "neo-go db restore [-i file] [--dump] [-n] [-c count] [-j workers] [--progress blocks] [--checkpoints file [--checkpoint-interval blocks]] [--config-path path] [-p/-m/-t] [--config-file file]",
 					Action:    restoreDB,
 					Flags:     cfgCountInFlags,
 				},
@@ -145,7 +197,7 @@ func initBCWithMetrics(cfg config.Config, log *zap.Logger) (*core.Blockchain, *m
 	if err != nil {
 		return nil, nil, nil, cli.Exit(err, 1)
 	}
-	prometheus := metrics.NewPrometheusService(cfg.ApplicationConfiguration.Prometheus, log)
+	prometheus := metrics.NewPrometheusService(cfg.ApplicationConfiguration.Prometheus.BasicService, log)
 	pprof := metrics.NewPprofService(cfg.ApplicationConfiguration.Pprof, log)
 
 	go chain.Run()
@@ -165,6 +217,9 @@ func dumpDB(ctx *cli.Context) error {
 	if err := cmdargs.EnsureNone(ctx); err != nil {
 		return err
 	}
+	if ctx.Bool("state") {
+		return dumpState(ctx)
+	}
 	cfg, err := options.GetConfigFromContext(ctx)
 	if err != nil {
 		return cli.Exit(err, 1)
@@ -249,6 +304,19 @@ func restoreDB(ctx *cli.Context) error {
 		cfg.ApplicationConfiguration.SaveStorageBatch = true
 	}
 
+	var checkpoints []state.MPTRoot
+	if cpFile := ctx.String("checkpoints"); cpFile != "" {
+		checkpoints, err = readCheckpoints(cpFile)
+		if err != nil {
+			return cli.Exit(err, 1)
+		}
+		// Resulting state is checked against checkpoints, so witnesses
+		// can be skipped.
+		cfg.ApplicationConfiguration.SkipBlockVerification = true
+	} else if ctx.Uint("checkpoint-interval") != 0 {
+		return cli.Exit("--checkpoint-interval can only be used with --checkpoints", 1)
+	}
+
 	chain, prometheus, pprof, err := initBCWithMetrics(cfg, log)
 	if err != nil {
 		return err
@@ -283,6 +351,16 @@ func restoreDB(ctx *cli.Context) error {
 	if count == 0 {
 		count = allBlocks - skip
 	}
+	if checkpoints != nil {
+		from := start + skip
+		if from == 0 {
+			from = 1 // Genesis is never restored.
+		}
+		if err := checkCheckpointsInterval(checkpoints, from, start+skip+count-1, uint32(ctx.Uint("checkpoint-interval"))); err != nil {
+			return cli.Exit(err, 1)
+		}
+		chain.SetTrustedStateRoots(checkpoints)
+	}
 	log.Info("initialize restore",
 		zap.Uint32("start", start),
 		zap.Uint32("height", chain.BlockHeight()),
@@ -327,13 +405,89 @@ func restoreDB(ctx *cli.Context) error {
 		}
 	}
 
-	err = chaindump.Restore(chain, reader, skip, count, f)
+	if interval := uint32(ctx.Uint("progress")); interval != 0 {
+		var (
+			handler  = f
+			restored uint32
+			total    = count
+			started  = time.Now()
+		)
+		// Genesis is never restored, so it's not counted.
+		if start+skip == 0 && total != 0 {
+			total--
+		}
+		f = func(b *block.Block) error {
+			if b.Index == 0 {
+				return handler(b)
+			}
+			restored++
+			if restored%interval == 0 || restored == total {
+				log.Info("restore progress",
+					zap.Uint32("height", b.Index),
+					zap.Uint32("restored", restored),
+					zap.Uint32("total", total),
+					zap.Float64("blocksPerSecond", float64(restored)/time.Since(started).Seconds()))
+			}
+			return handler(b)
+		}
+	}
+
+	if workers := int(ctx.Uint("workers")); workers != 0 {
+		err = chaindump.RestoreParallel(chain, reader, skip, count, chaindump.RestoreOptions{Workers: workers}, f)
+	} else {
+		err = chaindump.Restore(chain, reader, skip, count, f)
+	}
 	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return cli.Exit(fmt.Errorf("restore interrupted at height %d, run the same command to resume", chain.BlockHeight()), 1)
+		}
 		return cli.Exit(fmt.Errorf("wrong dump file or settings mismatch: %w", err), 1)
 	}
 	return nil
 }
 
+// readCheckpoints reads a JSON array of trusted state roots (in the format
+// used by getstateroot RPC call) from the given file.
+func readCheckpoints(name string) ([]state.MPTRoot, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("can't read checkpoints: %w", err)
+	}
+	var roots []state.MPTRoot
+	if err := json.Unmarshal(data, &roots); err != nil {
+		return nil, fmt.Errorf("can't parse checkpoints: %w", err)
+	}
+	if len(roots) == 0 {
+		return nil, errors.New("no checkpoints in the file")
+	}
+	return roots, nil
+}
+
+// checkCheckpointsInterval ensures that there is a checkpoint for every block
+// in the [from, to] range with an index that is a multiple of interval and for
+// the last block of this range, so that blocks restored after the last
+// interval checkpoint are checked as well.
+func checkCheckpointsInterval(roots []state.MPTRoot, from, to, interval uint32) error {
+	if from > to {
+		return nil
+	}
+	known := make(map[uint32]struct{}, len(roots))
+	for _, r := range roots {
+		known[r.Index] = struct{}{}
+	}
+	if interval != 0 {
+		for i := (from + interval - 1) / interval * interval; i <= to; i += interval {
+			if _, ok := known[i]; !ok {
+				return fmt.Errorf("no checkpoint for block %d", i)
+			}
+		}
+	}
+	if _, ok := known[to]; !ok {
+		return fmt.Errorf("no checkpoint for the last restored block %d", to)
+	}
+	return nil
+}
+
 func resetDB(ctx *cli.Context) error {
 	if err := cmdargs.EnsureNone(ctx); err != nil {
 		return err
@@ -407,7 +561,9 @@ func mkConsensus(config config.Consensus, tpb time.Duration, chain *core.Blockch
 		RequestTx:             serv.RequestTx,
 		StopTxFlow:            serv.StopTxFlow,
 		Wallet:                config.UnlockWallet,
+		ExternalSigner:        config.ExternalSigner,
 		TimePerBlock:          tpb,
+		Devnet:                config.Devnet,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("can't initialize Consensus module: %w", err)
@@ -417,6 +573,16 @@ func mkConsensus(config config.Consensus, tpb time.Duration, chain *core.Blockch
 	return srv, nil
 }
 
+// setBlockMiner passes devnet block producer (if the consensus service runs
+// in devnet mode) to the RPC server.
+func setBlockMiner(rpcServer *rpcsrv.Server, srv consensus.Service) {
+	var m rpcsrv.BlockMiner
+	if miner, ok := srv.(consensus.Miner); ok {
+		m = miner
+	}
+	rpcServer.SetBlockMiner(m)
+}
+
 func mkP2PNotary(config config.P2PNotary, chain *core.Blockchain, serv *network.Server, log *zap.Logger) (*notary.Notary, error) {
 	if !config.Enabled {
 		return nil, nil
@@ -444,6 +610,44 @@ func mkP2PNotary(config config.P2PNotary, chain *core.Blockchain, serv *network.
 	return n, nil
 }
 
+func mkBlockUploader(config config.NeoFSBlockUploader, chain *core.Blockchain, serv *network.Server, log *zap.Logger) (*blockuploader.Service, error) {
+	if !config.Enabled {
+		return nil, nil
+	}
+	bu, err := blockuploader.New(chain, config, log)
+	if err != nil {
+		return nil, fmt.Errorf("can't initialize NeoFS BlockUploader service: %w", err)
+	}
+	serv.AddService(bu)
+	return bu, nil
+}
+
+func mkWebhook(config config.Webhook, chain *core.Blockchain, serv *network.Server, log *zap.Logger) (*webhook.Service, error) {
+	if !config.Enabled {
+		return nil, nil
+	}
+	wh, err := webhook.New(chain, config, log)
+	if err != nil {
+		return nil, fmt.Errorf("can't initialize webhook service: %w", err)
+	}
+	serv.AddService(wh)
+	return wh, nil
+}
+
+// mkShadowValidation creates shadow validation service, it's not added to the
+// network server since it must work during synchronization, so it's up to the
+// caller to start it.
+func mkShadowValidation(config config.ShadowValidation, chain *core.Blockchain, log *zap.Logger) (*shadowcheck.Service, error) {
+	if !config.Enabled {
+		return nil, nil
+	}
+	sv, err := shadowcheck.New(chain, config, log)
+	if err != nil {
+		return nil, fmt.Errorf("can't initialize shadow validation service: %w", err)
+	}
+	return sv, nil
+}
+
 func startServer(ctx *cli.Context) error {
 	if err := cmdargs.EnsureNone(ctx); err != nil {
 		return err
@@ -484,6 +688,7 @@ func startServer(ctx *cli.Context) error {
 	if err != nil {
 		return cli.Exit(fmt.Errorf("failed to create network server: %w", err), 1)
 	}
+	prometheus.SetHealthChecker(metrics.NewHealthChecker(cfg.ApplicationConfiguration.Prometheus.Health, chain, chain.GetStateModule(), serv))
 	srMod := chain.GetStateModule().(*corestate.Module) // Take full responsibility here.
 	sr, err := stateroot.New(serverConfig.StateRootCfg, srMod, log, chain, serv.BroadcastExtensible)
 	if err != nil {
@@ -503,8 +708,24 @@ func startServer(ctx *cli.Context) error {
 	if err != nil {
 		return cli.Exit(err, 1)
 	}
+	blockUploader, err := mkBlockUploader(cfg.ApplicationConfiguration.NeoFSBlockUploader, chain, serv, log)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	webhookSrv, err := mkWebhook(cfg.ApplicationConfiguration.Webhook, chain, serv, log)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	shadowSrv, err := mkShadowValidation(cfg.ApplicationConfiguration.ShadowValidation, chain, log)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	if shadowSrv != nil {
+		shadowSrv.Start()
+	}
 	errChan := make(chan error)
 	rpcServer := rpcsrv.New(chain, cfg.ApplicationConfiguration.RPC, serv, oracleSrv, log, errChan)
+	setBlockMiner(rpcServer, dbftSrv)
 	serv.AddService(rpcServer)
 	setNeoGoVersion(config.Version)
 	serv.Start()
@@ -561,27 +782,40 @@ Main:
 					logLevel.SetLevel(newLogLevel)
 					log.Warn("using new logging level", zap.Stringer("level", newLogLevel))
 				}
-				serv.DelService(rpcServer)
-				rpcServer.Shutdown()
-				rpcServer = rpcsrv.New(chain, cfgnew.ApplicationConfiguration.RPC, serv, oracleSrv, log, errChan)
-				serv.AddService(rpcServer)
-				if !cfgnew.ApplicationConfiguration.RPC.StartWhenSynchronized || serv.IsInSync() {
-					// Here similar to the initial run (see above for-loop), so async.
-					go rpcServer.Start()
+				// Limits are applied to the running RPC server, connected
+				// clients are only dropped if other settings are changed.
+				if rpcServer.Reconfigure(cfgnew.ApplicationConfiguration.RPC) {
+					log.Info("RPC server limits reloaded")
+				} else {
+					serv.DelService(rpcServer)
+					rpcServer.Shutdown()
+					rpcServer = rpcsrv.New(chain, cfgnew.ApplicationConfiguration.RPC, serv, oracleSrv, log, errChan)
+					setBlockMiner(rpcServer, dbftSrv)
+					serv.AddService(rpcServer)
+					if !cfgnew.ApplicationConfiguration.RPC.StartWhenSynchronized || serv.IsInSync() {
+						// Here similar to the initial run (see above for-loop), so async.
+						go rpcServer.Start()
+					}
 				}
-				pprof.ShutDown()
-				pprof = metrics.NewPprofService(cfgnew.ApplicationConfiguration.Pprof, log)
-				err = pprof.Start()
-				if err != nil {
-					shutdownErr = fmt.Errorf("failed to start Pprof service: %w", err)
-					cancel() // Fatal error, like for RPC server.
+				if !basicServiceEqual(cfg.ApplicationConfiguration.Pprof, cfgnew.ApplicationConfiguration.Pprof) {
+					pprof.ShutDown()
+					pprof = metrics.NewPprofService(cfgnew.ApplicationConfiguration.Pprof, log)
+					err = pprof.Start()
+					if err != nil {
+						shutdownErr = fmt.Errorf("failed to start Pprof service: %w", err)
+						cancel() // Fatal error, like for RPC server.
+					}
 				}
-				prometheus.ShutDown()
-				prometheus = metrics.NewPrometheusService(cfgnew.ApplicationConfiguration.Prometheus, log)
-				err = prometheus.Start()
-				if err != nil {
-					shutdownErr = fmt.Errorf("failed to start Prometheus service: %w", err)
-					cancel() // Fatal error, like for RPC server.
+				if !basicServiceEqual(cfg.ApplicationConfiguration.Prometheus.BasicService, cfgnew.ApplicationConfiguration.Prometheus.BasicService) ||
+					cfg.ApplicationConfiguration.Prometheus.Health != cfgnew.ApplicationConfiguration.Prometheus.Health {
+					prometheus.ShutDown()
+					prometheus = metrics.NewPrometheusService(cfgnew.ApplicationConfiguration.Prometheus.BasicService, log)
+					prometheus.SetHealthChecker(metrics.NewHealthChecker(cfgnew.ApplicationConfiguration.Prometheus.Health, chain, chain.GetStateModule(), serv))
+					err = prometheus.Start()
+					if err != nil {
+						shutdownErr = fmt.Errorf("failed to start Prometheus service: %w", err)
+						cancel() // Fatal error, like for RPC server.
+					}
 				}
 			case sigusr1:
 				if oracleSrv != nil {
@@ -626,8 +860,58 @@ Main:
 				if serv.IsInSync() {
 					sr.Start()
 				}
+				if blockUploader != nil {
+					serv.DelService(blockUploader)
+					blockUploader.Shutdown()
+				}
+				blockUploader, err = mkBlockUploader(cfgnew.ApplicationConfiguration.NeoFSBlockUploader, chain, serv, log)
+				if err != nil {
+					log.Error("failed to create NeoFS BlockUploader service", zap.Error(err))
+					break // Keep going.
+				}
+				if blockUploader != nil && serv.IsInSync() {
+					blockUploader.Start()
+				}
+				if webhookSrv != nil {
+					serv.DelService(webhookSrv)
+					webhookSrv.Shutdown()
+				}
+				webhookSrv, err = mkWebhook(cfgnew.ApplicationConfiguration.Webhook, chain, serv, log)
+				if err != nil {
+					log.Error("failed to create webhook service", zap.Error(err))
+					break // Keep going.
+				}
+				if webhookSrv != nil && serv.IsInSync() {
+					webhookSrv.Start()
+				}
+				if shadowSrv != nil {
+					shadowSrv.Shutdown()
+				}
+				shadowSrv, err = mkShadowValidation(cfgnew.ApplicationConfiguration.ShadowValidation, chain, log)
+				if err != nil {
+					log.Error("failed to create shadow validation service", zap.Error(err))
+					break // Continue working.
+				}
+				if shadowSrv != nil {
+					shadowSrv.Start()
+				}
 			case sigusr2:
+				if dbftSrv != nil && cfgnew.ApplicationConfiguration.Consensus.Enabled &&
+					cfg.ApplicationConfiguration.Consensus.Devnet == cfgnew.ApplicationConfiguration.Consensus.Devnet &&
+					!cfg.ApplicationConfiguration.Consensus.ExternalSigner.IsEnabled() &&
+					!cfgnew.ApplicationConfiguration.Consensus.ExternalSigner.IsEnabled() {
+					// Key rotation doesn't require service restart, the
+					// wallet is reread even if its path is the same.
+					// External signers are handled by service restart.
+					err = dbftSrv.ReloadWallet(cfgnew.ApplicationConfiguration.Consensus.UnlockWallet)
+					if err != nil {
+						log.Error("failed to reload consensus wallet, the old one is used", zap.Error(err))
+						cfgnew.ApplicationConfiguration.Consensus.UnlockWallet = cfg.ApplicationConfiguration.Consensus.UnlockWallet
+					}
+					break
+				}
 				if dbftSrv != nil {
+					rpcServer.SetBlockMiner(nil)
 					serv.DelConsensusService(dbftSrv)
 					dbftSrv.Shutdown()
 				}
@@ -636,6 +920,7 @@ Main:
 					log.Error("failed to create consensus service", zap.Error(err))
 					break // Whatever happens, I'll leave it all to chance.
 				}
+				setBlockMiner(rpcServer, dbftSrv)
 				if dbftSrv != nil && serv.IsInSync() {
 					dbftSrv.Start()
 				}
@@ -643,6 +928,9 @@ Main:
 			cfg = cfgnew
 		case <-grace.Done():
 			signal.Stop(sigCh)
+			if shadowSrv != nil {
+				shadowSrv.Shutdown()
+			}
 			serv.Shutdown()
 			break Main
 		}
@@ -655,12 +943,20 @@ Main:
 	return nil
 }
 
+// basicServiceEqual checks whether two service configurations are the same.
+func basicServiceEqual(a, b config.BasicService) bool {
+	return a.Enabled == b.Enabled && slices.Equal(a.Addresses, b.Addresses)
+}
+
 // initBlockChain initializes BlockChain with preselected DB.
 func initBlockChain(cfg config.Config, log *zap.Logger) (*core.Blockchain, storage.Store, error) {
 	store, err := storage.NewStore(cfg.ApplicationConfiguration.DBConfiguration)
 	if err != nil {
 		return nil, nil, cli.Exit(fmt.Errorf("could not initialize storage: %w", err), 1)
 	}
+	if js, ok := store.(*storage.JournaledStore); ok && js.Recovery() != storage.JournalOK {
+		log.Warn("DB journal check after unclean shutdown", zap.Stringer("result", js.Recovery()))
+	}
 
 	chain, err := core.NewBlockchain(store, cfg.Blockchain(), log)
 	if err != nil {
@@ -0,0 +1,343 @@
+package smartcontract
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/services/helpers/neofs"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neofs-sdk-go/client"
+	cid "github.com/nspcc-dev/neofs-sdk-go/container/id"
+	"github.com/nspcc-dev/neofs-sdk-go/object"
+	"github.com/nspcc-dev/neofs-sdk-go/user"
+	"golang.org/x/tools/go/packages"
+)
+
+// Verification bundle is a zip archive containing everything needed to
+// reproduce contract NEF file: bundle metadata, contract configuration file
+// and sources of all packages from the contract module used by the contract
+// (along with go.mod and go.sum files).
+const (
+	bundleMetaFile   = "bundle.json"
+	bundleConfigFile = "config.yml"
+	bundleSourceDir  = "src"
+)
+
+// bundleMeta is the verification bundle description stored in bundleMetaFile.
+type bundleMeta struct {
+	// Compiler is the compiler NEF file was produced by, the same one must be
+	// used to reproduce it.
+	Compiler string `json:"compiler"`
+	// Name is the contract name.
+	Name string `json:"name"`
+	// Source is the contract file or package path relative to bundleSourceDir.
+	Source string `json:"source"`
+	// Checksum is the checksum of the NEF file.
+	Checksum uint32 `json:"checksum"`
+}
+
+// collectBundleSources returns the contract module root directory and
+// the list of files (relative to it) required to build the contract from
+// the given source file or directory. Only packages located inside the module
+// root are included, other dependencies are expected to be fetched by Go
+// tooling using go.mod and go.sum.
+func collectBundleSources(src string) (string, []string, error) {
+	absSrc, err := filepath.Abs(src)
+	if err != nil {
+		return "", nil, err
+	}
+	dir, pattern := absSrc, "pattern="+absSrc
+	if strings.HasSuffix(absSrc, ".go") {
+		dir, pattern = filepath.Dir(absSrc), "file="+absSrc
+	}
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports |
+			packages.NeedDeps | packages.NeedModule,
+		Dir: dir,
+	}, pattern)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load contract packages: %w", err)
+	}
+	if len(pkgs) != 1 {
+		return "", nil, fmt.Errorf("expected single contract package, got %d", len(pkgs))
+	}
+	if len(pkgs[0].Errors) != 0 {
+		return "", nil, pkgs[0].Errors[0]
+	}
+	if pkgs[0].Module == nil {
+		return "", nil, errors.New("contract is not a part of Go module")
+	}
+	root := pkgs[0].Module.Dir
+
+	var (
+		files   []string
+		fileErr error
+	)
+	addFile := func(name string) {
+		rel, err := filepath.Rel(root, name)
+		if err != nil || !filepath.IsLocal(rel) {
+			return
+		}
+		if _, err := os.Stat(name); err != nil {
+			if !errors.Is(err, os.ErrNotExist) && fileErr == nil {
+				fileErr = err
+			}
+			return
+		}
+		files = append(files, filepath.ToSlash(rel))
+	}
+	packages.Visit(pkgs, nil, func(p *packages.Package) {
+		if p.Module == nil {
+			return
+		}
+		if rel, err := filepath.Rel(root, p.Module.Dir); err != nil || !filepath.IsLocal(rel) {
+			return
+		}
+		for _, f := range p.GoFiles {
+			addFile(f)
+		}
+		if p.Module.GoMod != "" {
+			addFile(p.Module.GoMod)
+			addFile(filepath.Join(filepath.Dir(p.Module.GoMod), "go.sum"))
+		}
+	})
+	if fileErr != nil {
+		return "", nil, fileErr
+	}
+	slices.Sort(files)
+	return root, slices.Compact(files), nil
+}
+
+// createBundle creates verification bundle for the contract built from the
+// given sources and configuration file (optional) into the given NEF file.
+func createBundle(src string, confFile string, name string, nefFile *nef.File) ([]byte, error) {
+	root, files, err := collectBundleSources(src)
+	if err != nil {
+		return nil, err
+	}
+	absSrc, err := filepath.Abs(src)
+	if err != nil {
+		return nil, err
+	}
+	relSrc, err := filepath.Rel(root, absSrc)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := json.Marshal(bundleMeta{
+		Compiler: nefFile.Compiler,
+		Name:     name,
+		Source:   filepath.ToSlash(relSrc),
+		Checksum: nefFile.Checksum,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	add := func(name string, data []byte) error {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate})
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+	if err := add(bundleMetaFile, meta); err != nil {
+		return nil, err
+	}
+	if confFile != "" {
+		data, err := os.ReadFile(confFile)
+		if err != nil {
+			return nil, err
+		}
+		if err := add(bundleConfigFile, data); err != nil {
+			return nil, err
+		}
+	}
+	for _, f := range files {
+		data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(f)))
+		if err != nil {
+			return nil, err
+		}
+		if err := add(path.Join(bundleSourceDir, f), data); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// extractBundle unpacks the verification bundle into the given directory and
+// returns its metadata.
+func extractBundle(data []byte, dir string) (*bundleMeta, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid bundle: %w", err)
+	}
+	var meta *bundleMeta
+	for _, f := range zr.File {
+		if !filepath.IsLocal(f.Name) || strings.HasSuffix(f.Name, "/") {
+			return nil, fmt.Errorf("invalid bundle file name: %q", f.Name)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from bundle: %w", f.Name, err)
+		}
+		if f.Name == bundleMetaFile {
+			meta = new(bundleMeta)
+			if err := json.Unmarshal(content, meta); err != nil {
+				return nil, fmt.Errorf("invalid bundle metadata: %w", err)
+			}
+			continue
+		}
+		name := filepath.Join(dir, filepath.FromSlash(f.Name))
+		if err := os.MkdirAll(filepath.Dir(name), os.ModePerm); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(name, content, 0o644); err != nil {
+			return nil, err
+		}
+	}
+	if meta == nil {
+		return nil, errors.New("invalid bundle: no metadata")
+	}
+	if !filepath.IsLocal(meta.Source) {
+		return nil, fmt.Errorf("invalid bundle source path: %q", meta.Source)
+	}
+	return meta, nil
+}
+
+// buildBundle compiles the contract from the verification bundle using the
+// given compiler options (output files are written if specified there) and
+// returns bundle metadata and the resulting NEF file. It fails if the
+// compiler differs from the one specified in the bundle or if the NEF file
+// doesn't match the bundle checksum.
+func buildBundle(data []byte, o *compiler.Options) (*bundleMeta, []byte, error) {
+	tmp, err := os.MkdirTemp("", "neo-go-bundle")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	meta, err := extractBundle(data, tmp)
+	if err != nil {
+		return nil, nil, err
+	}
+	if current := "neo-go-" + config.Version; meta.Compiler != current {
+		return nil, nil, fmt.Errorf("bundle is built with %s, can't be reproduced with %s", meta.Compiler, current)
+	}
+	confFile := filepath.Join(tmp, bundleConfigFile)
+	if _, err := os.Stat(confFile); err == nil {
+		conf, err := ParseContractConfig(confFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		applyContractConfig(o, conf)
+	} else if o.ManifestFile != "" || o.DebugInfo != "" || o.BindingsFile != "" {
+		return nil, nil, errNoConfFile
+	}
+	if o.Outfile == "" {
+		o.Outfile = filepath.Join(tmp, "contract.nef")
+	}
+	src := filepath.Join(tmp, bundleSourceDir, filepath.FromSlash(meta.Source))
+	if _, err := compiler.CompileAndSave(src, o); err != nil {
+		return nil, nil, err
+	}
+	nefBytes, err := os.ReadFile(o.Outfile + "." + o.Ext) // Normalized by CompileAndSave.
+	if err != nil {
+		return nil, nil, err
+	}
+	nefFile, err := nef.FileFromBytes(nefBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	if nefFile.Checksum != meta.Checksum {
+		return nil, nil, fmt.Errorf("NEF checksum mismatch: bundle has %d, built %d", meta.Checksum, nefFile.Checksum)
+	}
+	return meta, nefBytes, nil
+}
+
+// readBundle reads the verification bundle from the file or NeoFS object
+// (neofs:<container>/<object> URL) using the given NeoFS node.
+func readBundle(ctx context.Context, location string, fsEndpoint string) ([]byte, error) {
+	if !strings.HasPrefix(location, neofs.URIScheme+":") {
+		return os.ReadFile(location)
+	}
+	if fsEndpoint == "" {
+		return nil, errors.New("NeoFS endpoint is required to fetch the bundle")
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bundle URL: %w", err)
+	}
+	priv, err := keys.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	rc, err := neofs.Get(ctx, priv, u, fsEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the bundle: %w", err)
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// uploadBundle puts the verification bundle into the NeoFS container and
+// returns resulting object URL.
+func uploadBundle(ctx context.Context, fsEndpoint string, containerID string, priv *keys.PrivateKey, name string, compilerVersion string, data []byte) (string, error) {
+	var cnr cid.ID
+	if err := cnr.DecodeString(containerID); err != nil {
+		return "", fmt.Errorf("failed to decode container ID: %w", err)
+	}
+	c, err := neofs.GetClient(ctx, fsEndpoint, 0)
+	if err != nil {
+		return "", err
+	}
+	defer c.Close()
+
+	var (
+		hdr    object.Object
+		signer = user.NewAutoIDSignerRFC6979(priv.PrivateKey)
+	)
+	hdr.SetContainerID(cnr)
+	hdr.SetOwner(signer.UserID())
+	hdr.SetAttributes(
+		*object.NewAttribute(object.AttributeFileName, name+".bundle.zip"),
+		*object.NewAttribute("Contract", name),
+		*object.NewAttribute("Compiler", compilerVersion),
+	)
+	w, err := c.ObjectPutInit(ctx, hdr, signer, client.PrmObjectPutInit{})
+	if err != nil {
+		return "", fmt.Errorf("failed to initiate object upload: %w", err)
+	}
+	if _, err = w.Write(data); err != nil {
+		_ = w.Close()
+		return "", fmt.Errorf("failed to write object data: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return "", fmt.Errorf("failed to close object writer: %w", err)
+	}
+	return fmt.Sprintf("%s:%s/%s", neofs.URIScheme, cnr, w.GetResult().StoredObjectID()), nil
+}
@@ -0,0 +1,269 @@
+package smartcontract
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/urfave/cli/v2"
+)
+
+// calledMethods is a set of methods called for some contract, it's not known
+// (any method can be called) if some method name can't be determined
+// statically.
+type calledMethods struct {
+	known   bool
+	methods []string
+}
+
+func (c *calledMethods) add(method string) {
+	if method == "" {
+		c.known = false
+		c.methods = nil
+		return
+	}
+	if c.known && !slices.Contains(c.methods, method) {
+		c.methods = append(c.methods, method)
+		slices.Sort(c.methods)
+	}
+}
+
+func (c *calledMethods) toWildStrings() manifest.WildStrings {
+	if !c.known {
+		return manifest.WildStrings{}
+	}
+	return manifest.WildStrings{Value: c.methods}
+}
+
+func checkPermissions(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	nf, _, err := readNEFFile(ctx.String("in"))
+	if err != nil {
+		return cli.Exit(fmt.Errorf("can't read NEF file: %w", err), 1)
+	}
+	mPath := ctx.String("manifest")
+	m, _, err := readManifest(mPath, util.Uint160{})
+	if err != nil {
+		return cli.Exit(fmt.Errorf("can't read contract manifest: %w", err), 1)
+	}
+	calls, err := vm.FindContractCalls(nf.Script, nf.Tokens)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to analyze script: %w", err), 1)
+	}
+
+	issues := permissionIssues(calls, m.Permissions)
+	if len(issues) == 0 {
+		fmt.Fprintln(ctx.App.Writer, "No issues found.")
+	}
+	for _, issue := range issues {
+		fmt.Fprintln(ctx.App.Writer, issue)
+	}
+	if !ctx.Bool("rewrite") {
+		return nil
+	}
+
+	m.Permissions = minimalPermissions(calls, m.Permissions)
+	if err := m.IsValid(util.Uint160{}, true); err != nil {
+		return cli.Exit(fmt.Errorf("rewritten manifest is invalid: %w", err), 1)
+	}
+	rawM, err := json.Marshal(m)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("can't marshal manifest: %w", err), 1)
+	}
+	err = os.WriteFile(mPath, rawM, os.ModePerm)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("can't write manifest file: %w", err), 1)
+	}
+	printPermissions(ctx.App.Writer, m.Permissions)
+	return nil
+}
+
+// permissionIssues compares contract calls with manifest permissions and
+// returns human-readable descriptions of problems found. Group permissions
+// can't be checked without the manifests of called contracts, so they're
+// considered to allow any call of the methods listed in them.
+func permissionIssues(calls []vm.ContractCall, perms manifest.Permissions) []string {
+	var issues []string
+	for _, c := range calls {
+		if c.Hash == nil || c.Method == "" {
+			issues = append(issues, fmt.Sprintf("call at %d: %s can't be determined statically",
+				c.Offset, dynamicCallPart(c)))
+		}
+		if !slices.ContainsFunc(perms, func(p manifest.Permission) bool { return mayAllow(p, c) }) {
+			issues = append(issues, fmt.Sprintf("call at %d: %s is not allowed by any permission",
+				c.Offset, describeCall(c)))
+		}
+	}
+	for i, p := range perms {
+		var (
+			matched    []vm.ContractCall
+			hashes     []util.Uint160
+			anyHash    bool
+			anyMethod  bool
+			methodsSet []string
+		)
+		for _, c := range calls {
+			if !mayAllow(p, c) {
+				continue
+			}
+			matched = append(matched, c)
+			if c.Hash == nil {
+				anyHash = true
+			} else {
+				hashes = append(hashes, *c.Hash)
+			}
+			if c.Method == "" {
+				anyMethod = true
+			} else if !slices.Contains(methodsSet, c.Method) {
+				methodsSet = append(methodsSet, c.Method)
+			}
+		}
+		desc := fmt.Sprintf("permission #%d (%s)", i, describePermission(p))
+		if len(matched) == 0 {
+			issues = append(issues, desc+" is not used")
+			continue
+		}
+		slices.Sort(methodsSet)
+		if p.Contract.Type == manifest.PermissionWildcard && !anyHash {
+			hashes = util.SortUnique(hashes)
+			var hs = make([]string, 0, len(hashes))
+			for _, h := range hashes {
+				hs = append(hs, h.StringLE())
+			}
+			issues = append(issues, fmt.Sprintf("%s allows any contract, but only these are called: %s",
+				desc, strings.Join(hs, ", ")))
+		}
+		if anyMethod {
+			continue
+		}
+		if p.Methods.IsWildcard() {
+			issues = append(issues, fmt.Sprintf("%s allows any method, but only these are called: %s",
+				desc, strings.Join(methodsSet, ", ")))
+			continue
+		}
+		var unused []string
+		for _, method := range p.Methods.Value {
+			if !slices.Contains(methodsSet, method) {
+				unused = append(unused, method)
+			}
+		}
+		if len(unused) != 0 {
+			issues = append(issues, fmt.Sprintf("%s allows methods that are not called: %s",
+				desc, strings.Join(unused, ", ")))
+		}
+	}
+	return issues
+}
+
+// minimalPermissions returns the minimal set of permissions needed for the
+// given calls. Group permissions are kept as is and calls with unknown hash
+// that can be allowed by them don't lead to wildcard permissions.
+func minimalPermissions(calls []vm.ContractCall, perms manifest.Permissions) manifest.Permissions {
+	var (
+		res     manifest.Permissions
+		hashes  []util.Uint160
+		byHash  = make(map[util.Uint160]*calledMethods)
+		anyHash *calledMethods
+	)
+	for _, p := range perms {
+		if p.Contract.Type == manifest.PermissionGroup {
+			res = append(res, p)
+		}
+	}
+	for _, c := range calls {
+		var cm *calledMethods
+		if c.Hash != nil {
+			cm = byHash[*c.Hash]
+			if cm == nil {
+				cm = &calledMethods{known: true}
+				byHash[*c.Hash] = cm
+				hashes = append(hashes, *c.Hash)
+			}
+		} else {
+			if slices.ContainsFunc(res, func(p manifest.Permission) bool { return mayAllow(p, c) }) {
+				continue
+			}
+			if anyHash == nil {
+				anyHash = &calledMethods{known: true}
+			}
+			cm = anyHash
+		}
+		cm.add(c.Method)
+	}
+	for _, h := range util.SortUnique(hashes) {
+		p := manifest.NewPermission(manifest.PermissionHash, h)
+		p.Methods = byHash[h].toWildStrings()
+		res = append(res, *p)
+	}
+	if anyHash != nil {
+		p := manifest.NewPermission(manifest.PermissionWildcard)
+		p.Methods = anyHash.toWildStrings()
+		res = append(res, *p)
+	}
+	if res == nil {
+		res = manifest.Permissions{}
+	}
+	return res
+}
+
+// mayAllow checks whether the permission can allow the call, calls with
+// unknown hash or method are allowed by any permission that can match them.
+func mayAllow(p manifest.Permission, c vm.ContractCall) bool {
+	if c.Hash != nil && p.Contract.Type == manifest.PermissionHash && !p.Contract.Hash().Equals(*c.Hash) {
+		return false
+	}
+	return c.Method == "" || p.Methods.Contains(c.Method)
+}
+
+func describePermission(p manifest.Permission) string {
+	var contract = "*"
+	switch p.Contract.Type {
+	case manifest.PermissionHash:
+		contract = p.Contract.Hash().StringLE()
+	case manifest.PermissionGroup:
+		contract = "group " + p.Contract.Group().StringCompressed()
+	}
+	var methods = "*"
+	if !p.Methods.IsWildcard() {
+		methods = "[" + strings.Join(p.Methods.Value, ", ") + "]"
+	}
+	return contract + ": " + methods
+}
+
+func describeCall(c vm.ContractCall) string {
+	var contract, method = "unknown contract", "unknown method"
+	if c.Hash != nil {
+		contract = "contract " + c.Hash.StringLE()
+	}
+	if c.Method != "" {
+		method = "method '" + c.Method + "'"
+	}
+	return method + " of " + contract
+}
+
+func dynamicCallPart(c vm.ContractCall) string {
+	switch {
+	case c.Hash == nil && c.Method == "":
+		return "contract hash and method"
+	case c.Hash == nil:
+		return "contract hash"
+	default:
+		return "method"
+	}
+}
+
+func printPermissions(w io.Writer, perms manifest.Permissions) {
+	fmt.Fprintln(w, "Manifest permissions are rewritten to:")
+	for _, p := range perms {
+		fmt.Fprintln(w, describePermission(p))
+	}
+}
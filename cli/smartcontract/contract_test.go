@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 	"testing"
@@ -351,6 +352,18 @@ func TestContractInitAndCompile(t *testing.T) {
 		e.CheckNextLine(t, "^[0-9a-hA-H]+$")
 	})
 
+	t.Run("size and cost report", func(t *testing.T) {
+		e.Run(t, append(cmd, "--report")...)
+		e.CheckNextLine(t, "^Method +Offset +Size +Share +Instructions +Syscalls +Cost")
+		e.CheckNextLine(t, `^\S+\.\S+ +[0-9]+ +[0-9]+ +[0-9.]+% +[0-9]+ +[0-9]+ +[0-9]+`)
+		line, err := e.Out.ReadString('\n')
+		for err == nil && !strings.HasPrefix(line, "Total") {
+			line, err = e.Out.ReadString('\n')
+		}
+		require.NoError(t, err)
+		e.CheckEOF(t)
+	})
+
 	t.Run("autocomplete outputs", func(t *testing.T) {
 		cfg, err := os.ReadFile(cfgPath)
 		require.NoError(t, err)
@@ -365,6 +378,50 @@ func TestContractInitAndCompile(t *testing.T) {
 	})
 }
 
+func TestContractInitTemplates(t *testing.T) {
+	// For proper contract init. The actual version as it will be replaced.
+	smartcontract.ModVersion = "v0.0.0"
+
+	tmpDir := t.TempDir()
+	e := testcli.NewExecutor(t, false)
+
+	t.Run("unknown template", func(t *testing.T) {
+		ctrPath := filepath.Join(tmpDir, "unknown")
+		e.RunWithErrorCheckExit(t, "unknown template nep42", "neo-go", "contract", "init", "--name", ctrPath, "--template", "nep42")
+		require.NoDirExists(t, ctrPath)
+	})
+
+	for _, tmpl := range []string{"nep11-nd", "nep11-d", "oracle", "notary"} {
+		t.Run(tmpl, func(t *testing.T) {
+			ctrPath := filepath.Join(tmpDir, strings.ReplaceAll(tmpl, "-", ""))
+			e.Run(t, "neo-go", "contract", "init", "--name", ctrPath, "--template", tmpl)
+
+			files, err := os.ReadDir(ctrPath)
+			require.NoError(t, err)
+			var names []string
+			for _, f := range files {
+				names = append(names, f.Name())
+			}
+			require.Equal(t, []string{"Makefile", "go.mod", "main.go", "main_test.go", "neo-go.yml"}, names)
+
+			// Main module is required by tests only, drop it to compile
+			// the contract with local pkg/interop.
+			goMod, err := os.ReadFile(filepath.Join(ctrPath, "go.mod"))
+			require.NoError(t, err)
+			require.Contains(t, string(goMod), "\tgithub.com/nspcc-dev/neo-go latest\n")
+			goMod = bytes.Replace(goMod, []byte("\tgithub.com/nspcc-dev/neo-go latest\n"), nil, 1)
+			require.NoError(t, os.WriteFile(filepath.Join(ctrPath, "go.mod"), goMod, os.ModePerm))
+			require.NoError(t, updateGoMod(ctrPath, "myimport.com/"+tmpl, "../../pkg/interop"))
+
+			e.Run(t, "neo-go", "contract", "compile", "--in", ctrPath,
+				"--config", filepath.Join(ctrPath, "neo-go.yml"),
+				"--out", filepath.Join(ctrPath, "contract.nef"),
+				"--manifest", filepath.Join(ctrPath, "contract.manifest.json"))
+			require.FileExists(t, filepath.Join(ctrPath, "contract.nef"))
+		})
+	}
+}
+
 // Checks that error is returned if GAS available for test-invoke exceeds
 // GAS needed to be consumed.
 func TestDeployBigContract(t *testing.T) {
@@ -548,7 +605,21 @@ func TestContractManifestGroups(t *testing.T) {
 		"--out", nefName, "--manifest", manifestName)
 
 	t.Run("missing wallet", func(t *testing.T) {
-		e.RunWithErrorCheck(t, `Required flags "sender, address, nef, manifest" not set`, "neo-go", "contract", "manifest", "add-group")
+		e.RunWithErrorCheck(t, `Required flags "address, manifest" not set`, "neo-go", "contract", "manifest", "add-group")
+	})
+	t.Run("no hash", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "either contract hash or sender and NEF file must be specified", "neo-go", "contract", "manifest", "add-group",
+			"--wallet", testcli.TestWalletPath, "--address", testcli.TestWalletAccount, "--manifest", manifestName)
+	})
+	t.Run("hash and sender", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "either contract hash or sender and NEF file must be specified", "neo-go", "contract", "manifest", "add-group",
+			"--wallet", testcli.TestWalletPath, "--address", testcli.TestWalletAccount, "--manifest", manifestName,
+			"--sender", testcli.TestWalletAccount, "--hash", util.Uint160{1, 2, 3}.StringLE())
+	})
+	t.Run("no NEF", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "both sender and NEF file are required to calculate contract hash", "neo-go", "contract", "manifest", "add-group",
+			"--wallet", testcli.TestWalletPath, "--address", testcli.TestWalletAccount, "--manifest", manifestName,
+			"--sender", testcli.TestWalletAccount)
 	})
 	t.Run("invalid wallet", func(t *testing.T) {
 		e.RunWithError(t, "neo-go", "contract", "manifest", "add-group",
@@ -607,6 +678,32 @@ func TestContractManifestGroups(t *testing.T) {
 	e.Run(t, append(cmd, "--wallet", testcli.TestWalletPath,
 		"--sender", testcli.ValidatorAddr, "--address", testcli.TestWalletAccount)...)
 
+	t.Run("by hash", func(t *testing.T) {
+		mCopy := filepath.Join(tmpDir, "copy.manifest.json")
+		data, err := os.ReadFile(manifestName)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(mCopy, data, os.ModePerm))
+
+		// Existing group is signed for another hash.
+		h := util.Uint160{1, 2, 3}
+		e.In.WriteString(testcli.ValidatorPass + "\r")
+		e.RunWithErrorCheckExit(t, "incorrect group signature", "neo-go", "contract", "manifest", "add-group",
+			"--manifest", mCopy, "--hash", h.StringLE(),
+			"--wallet", testcli.ValidatorWallet, "--address", testcli.ValidatorAddr)
+
+		// Replaced.
+		e.In.WriteString("testpass\r")
+		e.Run(t, "neo-go", "contract", "manifest", "add-group",
+			"--manifest", mCopy, "--hash", h.StringLE(),
+			"--wallet", testcli.TestWalletPath, "--address", testcli.TestWalletAccount)
+		data, err = os.ReadFile(mCopy)
+		require.NoError(t, err)
+		m := new(manifest.Manifest)
+		require.NoError(t, json.Unmarshal(data, m))
+		require.NoError(t, m.IsValid(h, true))
+		require.Equal(t, 1, len(m.Groups))
+	})
+
 	e.In.WriteString(testcli.ValidatorPass + "\r")
 	e.Run(t, "neo-go", "contract", "deploy",
 		"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
@@ -1061,6 +1158,76 @@ func TestContractInspect(t *testing.T) {
 		e.Run(t, append(cmd, "--in", nefName)...)
 		require.True(t, strings.Contains(e.Out.String(), "SYSCALL"))
 	})
+	t.Run("analyze", func(t *testing.T) {
+		e.RunWithErrorCheck(t, "manifest is required to analyze .nef file", append(cmd, "--in", nefName, "--analyze")...)
+		e.RunWithError(t, append(cmd, "--in", nefName, "--analyze", "--manifest", filepath.Join(tmpDir, "not.exists"))...)
+		e.Run(t, append(cmd, "--in", nefName, "--analyze", "--manifest", manifestName)...)
+		require.True(t, strings.Contains(e.Out.String(), "SYSCALL"))
+		e.Run(t, append(cmd, "--in", srcPath, "--compile", "--analyze")...)
+		require.True(t, strings.Contains(e.Out.String(), "SYSCALL"))
+	})
+}
+
+func TestContractCheckPermissions(t *testing.T) {
+	e := testcli.NewExecutor(t, false)
+	tmpDir := t.TempDir()
+
+	nefName := filepath.Join(tmpDir, "deploy.nef")
+	manifestName := filepath.Join(tmpDir, "deploy.manifest.json")
+	e.Run(t, "neo-go", "contract", "compile",
+		"--in", "testdata/deploy/main.go",
+		"--config", "testdata/deploy/neo-go.yml",
+		"--out", nefName, "--manifest", manifestName)
+
+	cmd := []string{"neo-go", "contract", "check-permissions"}
+	t.Run("missing flags", func(t *testing.T) {
+		e.RunWithErrorCheck(t, `Required flags "in, manifest" not set`, cmd...)
+		e.RunWithError(t, append(cmd, "--in", filepath.Join(tmpDir, "not.exists"), "--manifest", manifestName)...)
+		e.RunWithError(t, append(cmd, "--in", nefName, "--manifest", filepath.Join(tmpDir, "not.exists"))...)
+		e.RunWithError(t, append(cmd, "--in", nefName, "--manifest", manifestName, "something")...)
+	})
+
+	t.Run("check", func(t *testing.T) {
+		e.Run(t, append(cmd, "--in", nefName, "--manifest", manifestName)...)
+		e.CheckNextLine(t, `call at \d+: contract hash can't be determined statically`)
+		e.CheckEOF(t)
+	})
+
+	m := new(manifest.Manifest)
+	raw, err := os.ReadFile(manifestName)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(raw, m))
+	m.Permissions[0].Methods = manifest.WildStrings{}
+	m.Permissions = append(m.Permissions, *manifest.NewPermission(manifest.PermissionWildcard))
+	m.Permissions[1].Methods.Add("foo")
+	raw, err = json.Marshal(m)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(manifestName, raw, os.ModePerm))
+
+	t.Run("broad and unused permissions", func(t *testing.T) {
+		e.Run(t, append(cmd, "--in", nefName, "--manifest", manifestName)...)
+		e.CheckNextLine(t, `call at \d+: contract hash can't be determined statically`)
+		e.CheckNextLine(t, `permission #0 \(fffdc93764dbaddd97c48f252a53ea4643faa3fd: \*\) allows any method, but only these are called: update`)
+		e.CheckNextLine(t, `permission #1 \(\*: \[foo\]\) is not used`)
+		e.CheckEOF(t)
+	})
+	t.Run("rewrite", func(t *testing.T) {
+		e.Run(t, append(cmd, "--in", nefName, "--manifest", manifestName, "--rewrite")...)
+		e.CheckNextLine(t, `call at \d+: contract hash can't be determined statically`)
+		e.CheckNextLine(t, `permission #0 .* allows any method`)
+		e.CheckNextLine(t, `permission #1 \(\*: \[foo\]\) is not used`)
+		e.CheckNextLine(t, "Manifest permissions are rewritten to:")
+		e.CheckNextLine(t, `^\*: \[update\]$`)
+		e.CheckEOF(t)
+
+		raw, err := os.ReadFile(manifestName)
+		require.NoError(t, err)
+		m := new(manifest.Manifest)
+		require.NoError(t, json.Unmarshal(raw, m))
+		require.Equal(t, 1, len(m.Permissions))
+		require.Equal(t, manifest.PermissionWildcard, m.Permissions[0].Contract.Type)
+		require.Equal(t, []string{"update"}, m.Permissions[0].Methods.Value)
+	})
 }
 
 func TestCompileExamples(t *testing.T) {
@@ -1181,3 +1348,82 @@ func TestContractCompile_NEFSizeCheck(t *testing.T) {
 	e.RunWithError(t, "neo-go", "contract", "compile", "--in", in)
 	require.NoFileExists(t, filepath.Join(tmpDir, "main.nef"))
 }
+
+func TestDeployVerificationBundle(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+	tmpDir := t.TempDir()
+
+	nefName := filepath.Join(tmpDir, "deploy.nef")
+	manifestName := filepath.Join(tmpDir, "deploy.manifest.json")
+	bundleName := filepath.Join(tmpDir, "deploy.bundle.zip")
+	e.Run(t, "neo-go", "contract", "compile",
+		"--in", "testdata/deploy/main.go",
+		"--config", "testdata/deploy/neo-go.yml",
+		"--out", nefName, "--manifest", manifestName)
+
+	deployCmd := []string{"neo-go", "contract", "deploy",
+		"--rpc-endpoint", "http://" + e.RPC.Addresses()[0],
+		"--wallet", testcli.ValidatorWallet, "--address", testcli.ValidatorAddr,
+		"--in", nefName, "--manifest", manifestName, "--force",
+		"--bundle-source", "testdata/deploy/main.go"}
+	t.Run("no destination", func(t *testing.T) {
+		e.In.WriteString(testcli.ValidatorPass + "\r")
+		e.RunWithErrorCheckExit(t, "verification bundle destination is not specified, use --bundle or --container", deployCmd...)
+	})
+	t.Run("no container", func(t *testing.T) {
+		e.In.WriteString(testcli.ValidatorPass + "\r")
+		e.RunWithErrorCheckExit(t, "both --fs-rpc-endpoint and --container are required to upload verification bundle",
+			append(deployCmd, "--fs-rpc-endpoint", "localhost:8080")...)
+	})
+	t.Run("mismatch", func(t *testing.T) {
+		e.In.WriteString(testcli.ValidatorPass + "\r")
+		e.RunWithErrorCheckExit(t, "failed to build contract from verification bundle: NEF checksum mismatch",
+			append(deployCmd, "--bundle-source", "testdata/deploy/updated.go", "--bundle", bundleName)...)
+		_, err := os.Stat(bundleName)
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	deployCmd = append(deployCmd, "--bundle-config", "testdata/deploy/neo-go.yml", "--bundle", bundleName)
+	e.In.WriteString(testcli.ValidatorPass + "\r")
+	e.Run(t, deployCmd...)
+	e.CheckNextLine(t, "^Verification bundle: "+regexp.QuoteMeta(bundleName))
+	e.CheckTxPersisted(t, "Sent invocation transaction ")
+
+	t.Run("verify", func(t *testing.T) {
+		builtNef := filepath.Join(tmpDir, "built.nef")
+		builtManifest := filepath.Join(tmpDir, "built.manifest.json")
+		e.Run(t, "neo-go", "contract", "compile", "--bundle", "--in", bundleName, "--verify", nefName,
+			"--out", builtNef, "--manifest", builtManifest)
+		e.CheckNextLine(t, "^Contract .* is reproduced with neo-go-.*, NEF checksum: [0-9]+$")
+		e.CheckEOF(t)
+
+		expected, err := os.ReadFile(nefName)
+		require.NoError(t, err)
+		actual, err := os.ReadFile(builtNef)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+		expected, err = os.ReadFile(manifestName)
+		require.NoError(t, err)
+		actual, err = os.ReadFile(builtManifest)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	})
+	t.Run("verify mismatch", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "testdata/verify.nef doesn't match the contract built from the bundle",
+			"neo-go", "contract", "compile", "--bundle", "--in", bundleName, "--verify", "testdata/verify.nef")
+	})
+	t.Run("verify without bundle", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "--verify can only be used with --bundle",
+			"neo-go", "contract", "compile", "--in", "testdata/deploy/main.go", "--verify", nefName)
+	})
+	t.Run("bundle with config", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "--config can't be used with --bundle",
+			"neo-go", "contract", "compile", "--bundle", "--in", bundleName, "--config", "testdata/deploy/neo-go.yml")
+	})
+	t.Run("bad bundle", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "contract", "compile", "--bundle", "--in", nefName)
+	})
+	t.Run("NeoFS without endpoint", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "contract", "compile", "--bundle", "--in", "neofs:8cdx/Ejz6")
+	})
+}
@@ -41,6 +41,13 @@ var generatorFlags = []cli.Flag{
 	},
 }
 
+var rpcGeneratorFlags = append([]cli.Flag{
+	&cli.StringFlag{
+		Name:  "nns-name",
+		Usage: "NNS domain name to resolve contract hash from at runtime (can't be used with --hash)",
+	},
+}, generatorFlags...)
+
 var generateWrapperCmd = &cli.Command{
 	Name:      "generate-wrapper",
 	Usage:     "Generate wrapper to use in other contracts",
@@ -58,9 +65,16 @@ var generateWrapperCmd = &cli.Command{
 var generateRPCWrapperCmd = &cli.Command{
 	Name:      "generate-rpcwrapper",
 	Usage:     "Generate RPC wrapper to use for data reads",
-	UsageText: "neo-go contract generate-rpcwrapper --manifest <file.json> --out <file.go> [--hash <hash>] [--config <config>]",
-	Action:    contractGenerateRPCWrapper,
-	Flags:     generatorFlags,
+	UsageText: "neo-go contract generate-rpcwrapper --manifest <file.json> --out <file.go> [--hash <hash> | --nns-name <name>] [--config <config>]",
+	Description: `Generates a Go wrapper to use contract via RPC. If the --hash
+   flag is provided, the wrapper uses it for all invocations. Otherwise
+   contract hash is to be passed to constructors at runtime, if --nns-name
+   flag (or 'nnsname' configuration option) is provided additional
+   constructors that resolve contract hash via NNS are generated (resolved
+   hashes are cached).
+`,
+	Action: contractGenerateRPCWrapper,
+	Flags:  rpcGeneratorFlags,
 }
 
 func contractGenerateWrapper(ctx *cli.Context) error {
@@ -107,6 +121,9 @@ func contractGenerateSomething(ctx *cli.Context, cb func(binding.Config) error)
 	}
 	cfg.Manifest = m
 	cfg.Hash = h
+	if ctx.IsSet("nns-name") {
+		cfg.NNSName = ctx.String("nns-name")
+	}
 
 	f, err := os.Create(ctx.String("out"))
 	if err != nil {
@@ -422,6 +422,64 @@ func (c *ContractReader) Get() (*big.Int, error) {
 `, string(data))
 }
 
+func TestGenerateRPCBindingsNNS(t *testing.T) {
+	m := manifest.NewManifest("NNS resolved")
+	m.ABI.Methods = append(m.ABI.Methods,
+		manifest.Method{
+			Name:       "get",
+			Parameters: []manifest.Parameter{},
+			ReturnType: smartcontract.IntegerType,
+			Safe:       true,
+		},
+		manifest.Method{
+			Name:       "put",
+			Parameters: []manifest.Parameter{{Name: "v", Type: smartcontract.IntegerType}},
+			ReturnType: smartcontract.VoidType,
+		},
+	)
+
+	manifestFile := filepath.Join(t.TempDir(), "manifest.json")
+	outFile := filepath.Join(t.TempDir(), "out.go")
+
+	rawManifest, err := json.Marshal(m)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(manifestFile, rawManifest, os.ModePerm))
+
+	e := testcli.NewExecutor(t, false)
+	e.RunWithErrorCheckExit(t, "can't be used together", "", "contract", "generate-rpcwrapper",
+		"--manifest", manifestFile,
+		"--out", outFile,
+		"--hash", util.Uint160{1, 2, 3}.StringLE(),
+		"--nns-name", "resolved.neo",
+	)
+
+	e.Run(t, []string{"", "contract", "generate-rpcwrapper",
+		"--manifest", manifestFile,
+		"--out", outFile,
+		"--nns-name", "resolved.neo",
+	}...)
+
+	data, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `const NNSName = "resolved.neo"`)
+	require.Contains(t, string(data), `"github.com/nspcc-dev/neo-go/pkg/rpcclient/nns"`)
+	require.Contains(t, string(data), "func NewReader(invoker Invoker, hash util.Uint160) *ContractReader {")
+	require.Contains(t, string(data), "func NewReaderFromNNS(invoker Invoker, nnsHash util.Uint160) (*ContractReader, error) {")
+	require.Contains(t, string(data), "func New(actor Actor, hash util.Uint160) *Contract {")
+	require.Contains(t, string(data), "func NewFromNNS(actor Actor, nnsHash util.Uint160) (*Contract, error) {")
+	require.Contains(t, string(data), "func ResolveHash(invoker nns.CallInvoker, nnsHash util.Uint160) (util.Uint160, error) {")
+
+	e.Run(t, []string{"", "contract", "generate-rpcwrapper",
+		"--manifest", manifestFile,
+		"--out", outFile,
+		"--nns-name", `quoted".neo\`,
+	}...)
+
+	data, err = os.ReadFile(outFile)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `const NNSName = "quoted\".neo\\"`)
+}
+
 // rewriteExpectedOutputs denotes whether expected output files should be rewritten
 // for TestGenerateRPCBindings and TestAssistedRPCBindings.
 const rewriteExpectedOutputs = false
@@ -468,6 +526,9 @@ func TestGenerateRPCBindings(t *testing.T) {
 	checkBinding(filepath.Join("testdata", "nonepiter", "iter.manifest.json"),
 		"0x00112233445566778899aabbccddeeff00112233",
 		filepath.Join("testdata", "nonepiter", "iter.go"))
+	checkBinding(filepath.Join("testdata", "lifecycle", "lifecycle.manifest.json"),
+		"0x00112233445566778899aabbccddeeff00112233",
+		filepath.Join("testdata", "lifecycle", "lifecycle.go"))
 
 	require.False(t, rewriteExpectedOutputs)
 }
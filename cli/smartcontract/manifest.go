@@ -2,6 +2,7 @@ package smartcontract
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 
@@ -19,10 +20,16 @@ func manifestAddGroup(ctx *cli.Context) error {
 	if err := cmdargs.EnsureNone(ctx); err != nil {
 		return err
 	}
-	sender := ctx.Generic("sender").(*flags.Address)
-	nf, _, err := readNEFFile(ctx.String("nef"))
-	if err != nil {
-		return cli.Exit(fmt.Errorf("can't read NEF file: %w", err), 1)
+	var (
+		sender   = ctx.Generic("sender").(*flags.Address)
+		contract = ctx.Generic("hash").(*flags.Address)
+		nefPath  = ctx.String("nef")
+	)
+	if contract.IsSet == (sender.IsSet || nefPath != "") {
+		return cli.Exit(errors.New("either contract hash or sender and NEF file must be specified"), 1)
+	}
+	if !contract.IsSet && (!sender.IsSet || nefPath == "") {
+		return cli.Exit(errors.New("both sender and NEF file are required to calculate contract hash"), 1)
 	}
 
 	mPath := ctx.String("manifest")
@@ -31,7 +38,16 @@ func manifestAddGroup(ctx *cli.Context) error {
 		return cli.Exit(fmt.Errorf("can't read contract manifest: %w", err), 1)
 	}
 
-	h := state.CreateContractHash(sender.Uint160(), nf.Checksum, m.Name)
+	var h util.Uint160
+	if contract.IsSet {
+		h = contract.Uint160()
+	} else {
+		nf, _, err := readNEFFile(nefPath)
+		if err != nil {
+			return cli.Exit(fmt.Errorf("can't read NEF file: %w", err), 1)
+		}
+		h = state.CreateContractHash(sender.Uint160(), nf.Checksum, m.Name)
+	}
 
 	gAcc, w, err := options.GetAccFromContext(ctx)
 	if err != nil {
@@ -39,22 +55,9 @@ func manifestAddGroup(ctx *cli.Context) error {
 	}
 	defer w.Close()
 
-	var found bool
-
-	sig := gAcc.PrivateKey().Sign(h.BytesBE())
-	pub := gAcc.PublicKey()
-	for i := range m.Groups {
-		if m.Groups[i].PublicKey.Equal(pub) {
-			m.Groups[i].Signature = sig
-			found = true
-			break
-		}
-	}
-	if !found {
-		m.Groups = append(m.Groups, manifest.Group{
-			PublicKey: pub,
-			Signature: sig,
-		})
+	m.AddGroup(h, gAcc.PrivateKey())
+	if err := m.IsValid(h, true); err != nil {
+		return cli.Exit(fmt.Errorf("manifest is invalid for contract %s: %w", h.StringLE(), err), 1)
 	}
 
 	rawM, err := json.Marshal(m)
@@ -1,12 +1,15 @@
 package smartcontract
 
 import (
+	"bytes"
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/nspcc-dev/neo-go/cli/cmdargs"
@@ -16,6 +19,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/compiler"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
@@ -49,6 +53,11 @@ var (
 		Aliases: []string{addressFlagAlias},
 		Usage:   "Address to use as transaction signee (and gas source)",
 	}
+	fsEndpointFlag = &cli.StringFlag{
+		Name:    "fs-rpc-endpoint",
+		Aliases: []string{"fsr"},
+		Usage:   "NeoFS storage node RPC address used for verification bundle",
+	}
 )
 
 // ModVersion contains `pkg/interop` module version
@@ -115,13 +124,30 @@ func NewCommands() []*cli.Command {
 			Usage:    "Manifest input file (*.manifest.json)",
 			Action:   cmdargs.EnsureNotEmpty("manifest"),
 		},
+		&cli.StringFlag{
+			Name:  "bundle-source",
+			Usage: "Contract source (*.go file or directory) to create verification bundle from",
+		},
+		&cli.StringFlag{
+			Name:  "bundle-config",
+			Usage: "Contract configuration file (*.yml) the contract was compiled with, included into verification bundle",
+		},
+		&cli.StringFlag{
+			Name:  "bundle",
+			Usage: "Output file for verification bundle",
+		},
+		fsEndpointFlag,
+		&cli.StringFlag{
+			Name:    "container",
+			Aliases: []string{"cid"},
+			Usage:   "NeoFS container ID to upload verification bundle to",
+		},
 	}...)
 	manifestAddGroupFlags := append([]cli.Flag{
 		&flags.AddressFlag{
-			Name:     "sender",
-			Aliases:  []string{"s"},
-			Required: true,
-			Usage:    "Deploy transaction sender",
+			Name:    "sender",
+			Aliases: []string{"s"},
+			Usage:   "Deploy transaction sender (used with --nef to calculate contract hash)",
 		},
 		&flags.AddressFlag{
 			Name:     addressFlagName, // use the same name for handler code unification.
@@ -130,11 +156,13 @@ func NewCommands() []*cli.Command {
 			Usage:    "Account to sign group with",
 		},
 		&cli.StringFlag{
-			Name:     "nef",
-			Aliases:  []string{"n"},
-			Required: true,
-			Usage:    "Path to the NEF file",
-			Action:   cmdargs.EnsureNotEmpty("nef"),
+			Name:    "nef",
+			Aliases: []string{"n"},
+			Usage:   "Path to the NEF file (used with --sender to calculate contract hash)",
+		},
+		&flags.AddressFlag{
+			Name:  "hash",
+			Usage: "Contract hash (for already deployed contracts, instead of --sender and --nef)",
 		},
 		&cli.StringFlag{
 			Name:     "manifest",
@@ -151,14 +179,26 @@ func NewCommands() []*cli.Command {
 			{
 				Name:      "compile",
 				Usage:     "Compile a smart contract to a .nef file",
-				UsageText: "neo-go contract compile -i path [-o nef] [-v] [-d] [-m manifest] [-c yaml] [--bindings file] [--no-standards] [--no-events] [--no-permissions] [--guess-eventtypes]",
+				UsageText: "neo-go contract compile -i path [-o nef] [-v] [-d] [-m manifest] [-c yaml] [--bindings file] [--no-standards] [--no-events] [--no-permissions] [--guess-eventtypes] [--cache-dir dir] [--report] [--bundle [--verify nef] [--fs-rpc-endpoint node]]",
 				Description: `Compiles given smart contract to a .nef file and emits other associated
    information (manifest, bindings configuration, debug information files) if
    asked to. If none of --out, --manifest, --config, --bindings flags are specified,
    then the output filenames for these flags will be guessed using the contract
    name or path provided via --in option by trimming/adding corresponding suffixes
    to the common part of the path. In the latter case the configuration filepath
-   will be guessed from the --in option using the same rule.
+   will be guessed from the --in option using the same rule. With --report
+   flag a table with emitted script size and static execution cost (the price
+   of executing every instruction once, syscalls and contract calls excluded)
+   of every method is printed, methods are sorted by size.
+
+   With --bundle flag --in is a verification bundle (a file or NeoFS object
+   specified as neofs:<container>/<object> URL, --fs-rpc-endpoint is required
+   then) created by 'contract deploy', sources and configuration are taken
+   from it. The contract is compiled with the same compiler version the bundle
+   was created with and the command fails if the resulting NEF file doesn't
+   match the bundle. With --verify the NEF file is also compared byte-for-byte
+   with the given one (like the one deployed to the network). Output files are
+   only written if explicitly specified.
 `,
 				Action: contractCompile,
 				Flags: []cli.Flag{
@@ -184,6 +224,10 @@ func NewCommands() []*cli.Command {
 						Aliases: []string{"d"},
 						Usage:   "Emit debug info in a separate file",
 					},
+					&cli.StringFlag{
+						Name:  "sourcemap",
+						Usage: "Emit source map (offset to source code mapping with variable slots) in a separate file",
+					},
 					&cli.StringFlag{
 						Name:    "manifest",
 						Aliases: []string{"m"},
@@ -214,17 +258,44 @@ func NewCommands() []*cli.Command {
 						Name:  "bindings",
 						Usage: "Output file for smart-contract bindings configuration",
 					},
+					&cli.StringFlag{
+						Name:  "cache-dir",
+						Usage: "Directory for compilation cache, unchanged contracts are not recompiled",
+					},
+					&cli.BoolFlag{
+						Name:  "report",
+						Usage: "Print script size and estimated execution cost of every method",
+					},
+					&cli.BoolFlag{
+						Name:  "bundle",
+						Usage: "Compile the contract from the verification bundle specified by --in",
+					},
+					&cli.StringFlag{
+						Name:  "verify",
+						Usage: "NEF file to compare the contract built from the verification bundle with",
+					},
+					fsEndpointFlag,
 				},
 			},
 			{
 				Name:      "deploy",
 				Usage:     "Deploy a smart contract (.nef with description)",
-				UsageText: "neo-go contract deploy -r endpoint -w wallet [-a address] [-g gas] [-e sysgas] --in contract.nef --manifest contract.manifest.json [--out file] [--force] [--await] [data]",
+				UsageText: "neo-go contract deploy -r endpoint -w wallet [-a address] [-g gas] [-e sysgas] --in contract.nef --manifest contract.manifest.json [--out file] [--force] [--await] [--bundle-source path [--bundle-config yaml] [--bundle file] [--fs-rpc-endpoint node --container cid]] [data]",
 				Description: `Deploys given contract into the chain. The gas parameter is for additional
    gas to be added as a network fee to prioritize the transaction. The data 
    parameter is an optional parameter to be passed to '_deploy' method. When
    --await flag is specified, it waits for the transaction to be included 
    in a block.
+
+   When --bundle-source is specified, a verification bundle allowing anyone
+   to reproduce the NEF file is created from the contract sources (all the
+   packages of the contract Go module it uses along with go.mod and go.sum),
+   configuration file given via --bundle-config and compiler version. The
+   contract is rebuilt from the bundle before deployment to ensure it matches
+   the NEF file byte-for-byte, then the bundle is saved to the file specified
+   by --bundle and/or uploaded to the NeoFS container specified by
+   --container using --fs-rpc-endpoint node and the sender key. The bundle
+   can be checked with 'contract compile --bundle'.
 `,
 				Action: contractDeploy,
 				Flags:  deployFlags,
@@ -280,8 +351,20 @@ func NewCommands() []*cli.Command {
 			{
 				Name:      "init",
 				Usage:     "Initialize a new smart-contract in a directory with boiler plate code",
-				UsageText: "neo-go contract init -n name [--skip-details]",
-				Action:    initSmartContract,
+				UsageText: "neo-go contract init -n name [--skip-details] [-t template]",
+				Description: `Creates a directory with the given name and puts contract source code,
+   its configuration file and go.mod into it. Contract source code is
+   generated from one of the following templates:
+    * default: minimal hello-world contract
+    * nep11-nd: non-divisible NEP-11 token
+    * nep11-d: divisible NEP-11 token
+    * oracle: contract making oracle requests and handling responses
+    * notary: contract with actions requiring multiple signatures that
+      can be collected with Notary subsystem
+   All templates except the default one also include tests (based on neotest
+   package) and Makefile to build and test the contract.
+`,
+				Action: initSmartContract,
 				Flags: []cli.Flag{
 					&cli.StringFlag{
 						Name:     "name",
@@ -295,19 +378,43 @@ func NewCommands() []*cli.Command {
 						Aliases: []string{"skip"},
 						Usage:   "Skip filling in the projects and contract details",
 					},
+					&cli.StringFlag{
+						Name:    "template",
+						Aliases: []string{"t"},
+						Value:   defaultTemplate,
+						Usage:   "Project template to use (" + strings.Join(templateNames(), ", ") + ")",
+					},
 				},
 			},
 			{
 				Name:      "inspect",
 				Usage:     "Creates a user readable dump of the program instructions",
-				UsageText: "neo-go contract inspect -i file [-c]",
-				Action:    inspect,
+				UsageText: "neo-go contract inspect -i file [-c] [--analyze [-m manifest]]",
+				Description: `Dumps contract script instructions in a human-readable form.
+
+   If --analyze flag is given, the script is also checked for unreachable
+   instructions, invalid jumps and evaluation stack depth mismatches, issues
+   found are printed after the dump (it's not an error to have them). Method
+   offsets are taken from the manifest for .nef files (it's required then) and
+   from the debug information when Go code is compiled.
+`,
+				Action: inspect,
 				Flags: []cli.Flag{
 					&cli.BoolFlag{
 						Name:    "compile",
 						Aliases: []string{"c"},
 						Usage:   "Compile input file (it should be go code then)",
 					},
+					&cli.BoolFlag{
+						Name:    "analyze",
+						Aliases: []string{"a"},
+						Usage:   "Analyze script for unreachable code, invalid jumps and stack imbalance",
+					},
+					&cli.StringFlag{
+						Name:    "manifest",
+						Aliases: []string{"m"},
+						Usage:   "Manifest file used to get method offsets for analysis of .nef file",
+					},
 					&cli.StringFlag{
 						Name:     "in",
 						Aliases:  []string{"i"},
@@ -344,6 +451,46 @@ func NewCommands() []*cli.Command {
 					},
 				},
 			},
+			{
+				Name:      "check-permissions",
+				Usage:     "Checks manifest permissions against contract calls done by the script",
+				UsageText: "neo-go contract check-permissions -i nef -m manifest [--rewrite]",
+				Description: `Finds contract calls done by the NEF script (System.Contract.Call
+   syscalls and CALLT instructions) and compares them with the manifest
+   permissions. Calls not allowed by any permission, calls with contract hash
+   or method that can't be determined statically, unused permissions and
+   permissions allowing more contracts or methods than the script calls are
+   reported (it's not an error to have them).
+
+   If --rewrite flag is given, manifest permissions are replaced with the
+   minimal set needed for the calls found and the manifest file is updated.
+   Group permissions can't be checked without the manifests of called
+   contracts, so they're kept as is. Safe methods don't need permissions
+   at all, but this can't be determined from the script either, so they're
+   treated as any other method.
+`,
+				Action: checkPermissions,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "in",
+						Aliases:  []string{"i"},
+						Required: true,
+						Usage:    "Path to NEF file",
+						Action:   cmdargs.EnsureNotEmpty("in"),
+					},
+					&cli.StringFlag{
+						Name:     "manifest",
+						Aliases:  []string{"m"},
+						Required: true,
+						Usage:    "Path to manifest file",
+						Action:   cmdargs.EnsureNotEmpty("manifest"),
+					},
+					&cli.BoolFlag{
+						Name:  "rewrite",
+						Usage: "Rewrite manifest permissions to the minimal set needed",
+					},
+				},
+			},
 			{
 				Name:  "manifest",
 				Usage: "Manifest-related commands",
@@ -351,9 +498,16 @@ func NewCommands() []*cli.Command {
 					{
 						Name:      "add-group",
 						Usage:     "Adds group to the manifest",
-						UsageText: "neo-go contract manifest add-group -w wallet [--wallet-config path] -n nef -m manifest -a address -s address",
-						Action:    manifestAddGroup,
-						Flags:     manifestAddGroupFlags,
+						UsageText: "neo-go contract manifest add-group -w wallet [--wallet-config path] -m manifest -a address {-n nef -s address | --hash hash}",
+						Description: `Signs contract hash with the key of the given account and
+   adds the resulting group to the manifest (or updates the signature if the
+   group with the same key is already there). Contract hash is either
+   calculated from the deployment transaction sender and NEF file (for
+   contracts that are not yet deployed) or specified directly with --hash.
+   All manifest groups are checked to be valid for this hash after that.
+`,
+						Action: manifestAddGroup,
+						Flags:  manifestAddGroupFlags,
 					},
 				},
 			},
@@ -367,6 +521,13 @@ func initSmartContract(ctx *cli.Context) error {
 		return err
 	}
 	contractName := ctx.String("name")
+	tmplName := ctx.String("template")
+	if tmplName == "" {
+		tmplName = defaultTemplate
+	}
+	if !slices.Contains(templateNames(), tmplName) {
+		return cli.Exit(fmt.Errorf("unknown template %s", tmplName), 1)
+	}
 
 	// Check if the file already exists, if yes, exit
 	if _, err := os.Stat(contractName); err == nil {
@@ -375,13 +536,49 @@ func initSmartContract(ctx *cli.Context) error {
 
 	basePath := contractName
 	contractName = filepath.Base(contractName)
-	fileName := "main.go"
 
 	// create base directory
 	if err := os.Mkdir(basePath, os.ModePerm); err != nil {
 		return cli.Exit(err, 1)
 	}
 
+	ver := ModVersion
+	if ver == "" {
+		ver = "latest"
+	}
+	deps := "\tgithub.com/nspcc-dev/neo-go/pkg/interop " + ver + "\n"
+	if tmplName != defaultTemplate {
+		// Tests need the main module.
+		deps = "\tgithub.com/nspcc-dev/neo-go " + neoGoModVersion() + "\n" + deps
+	}
+	gm := []byte("module " + contractName + `
+
+go 1.22
+
+require (
+` + deps + `)`)
+	if err := os.WriteFile(filepath.Join(basePath, "go.mod"), gm, 0644); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	var err error
+	if tmplName == defaultTemplate {
+		err = writeDefaultTemplate(basePath, contractName)
+	} else {
+		err = writeTemplate(basePath, tmplName, contractName)
+	}
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	fmt.Fprintf(ctx.App.Writer, "Successfully initialized smart contract [%s]\n", contractName)
+
+	return nil
+}
+
+// writeDefaultTemplate writes hello-world contract and its configuration to
+// the base directory.
+func writeDefaultTemplate(basePath string, contractName string) error {
 	m := ProjectConfig{
 		Name:               contractName,
 		SourceURL:          "http://example.com/",
@@ -404,42 +601,26 @@ func initSmartContract(ctx *cli.Context) error {
 	}
 	b, err := yaml.Marshal(m)
 	if err != nil {
-		return cli.Exit(err, 1)
+		return err
 	}
 	if err := os.WriteFile(filepath.Join(basePath, "neo-go.yml"), b, 0644); err != nil {
-		return cli.Exit(err, 1)
-	}
-
-	ver := ModVersion
-	if ver == "" {
-		ver = "latest"
-	}
-
-	gm := []byte("module " + contractName + `
-
-go 1.22
-
-require (
-	github.com/nspcc-dev/neo-go/pkg/interop ` + ver + `
-)`)
-	if err := os.WriteFile(filepath.Join(basePath, "go.mod"), gm, 0644); err != nil {
-		return cli.Exit(err, 1)
+		return err
 	}
 
 	data := []byte(fmt.Sprintf(smartContractTmpl, contractName))
-	if err := os.WriteFile(filepath.Join(basePath, fileName), data, 0644); err != nil {
-		return cli.Exit(err, 1)
-	}
-
-	fmt.Fprintf(ctx.App.Writer, "Successfully initialized smart contract [%s]\n", contractName)
-
-	return nil
+	return os.WriteFile(filepath.Join(basePath, "main.go"), data, 0644)
 }
 
 func contractCompile(ctx *cli.Context) error {
 	if err := cmdargs.EnsureNone(ctx); err != nil {
 		return err
 	}
+	if ctx.Bool("bundle") {
+		return compileBundle(ctx)
+	}
+	if ctx.String("verify") != "" {
+		return cli.Exit("--verify can only be used with --bundle", 1)
+	}
 	src := ctx.String("in")
 	manifestFile := ctx.String("manifest")
 	confFile := ctx.String("config")
@@ -478,6 +659,7 @@ func contractCompile(ctx *cli.Context) error {
 		Outfile: out,
 
 		DebugInfo:    debugFile,
+		SourceMap:    ctx.String("sourcemap"),
 		ManifestFile: manifestFile,
 		BindingsFile: bindings,
 
@@ -486,6 +668,11 @@ func contractCompile(ctx *cli.Context) error {
 		NoPermissionsCheck: ctx.Bool("no-permissions"),
 
 		GuessEventTypes: ctx.Bool("guess-eventtypes"),
+
+		CacheDir: ctx.String("cache-dir"),
+	}
+	if ctx.Bool("report") {
+		o.Report = ctx.App.Writer
 	}
 
 	if len(confFile) != 0 {
@@ -493,17 +680,7 @@ func contractCompile(ctx *cli.Context) error {
 		if err != nil {
 			return err
 		}
-		o.Name = conf.Name
-		o.SourceURL = conf.SourceURL
-		o.ContractEvents = conf.Events
-		o.DeclaredNamedTypes = conf.NamedTypes
-		o.ContractSupportedStandards = conf.SupportedStandards
-		o.Permissions = make([]manifest.Permission, len(conf.Permissions))
-		for i := range conf.Permissions {
-			o.Permissions[i] = manifest.Permission(conf.Permissions[i])
-		}
-		o.SafeMethods = conf.SafeMethods
-		o.Overloads = conf.Overloads
+		applyContractConfig(o, conf)
 	}
 
 	result, err := compiler.CompileAndSave(src, o)
@@ -517,6 +694,73 @@ func contractCompile(ctx *cli.Context) error {
 	return nil
 }
 
+// compileBundle compiles the contract from the verification bundle and
+// checks the result.
+func compileBundle(ctx *cli.Context) error {
+	if ctx.String("config") != "" {
+		return cli.Exit("--config can't be used with --bundle", 1)
+	}
+	if ctx.String("cache-dir") != "" {
+		return cli.Exit("--cache-dir can't be used with --bundle", 1)
+	}
+	gctx, cancel := context.WithTimeout(ctx.Context, options.DefaultTimeout)
+	defer cancel()
+	data, err := readBundle(gctx, ctx.String("in"), ctx.String("fs-rpc-endpoint"))
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to read bundle: %w", err), 1)
+	}
+	o := &compiler.Options{
+		Outfile: ctx.String("out"),
+
+		DebugInfo:    ctx.String("debug"),
+		SourceMap:    ctx.String("sourcemap"),
+		ManifestFile: ctx.String("manifest"),
+		BindingsFile: ctx.String("bindings"),
+
+		NoStandardCheck:    ctx.Bool("no-standards"),
+		NoEventsCheck:      ctx.Bool("no-events"),
+		NoPermissionsCheck: ctx.Bool("no-permissions"),
+
+		GuessEventTypes: ctx.Bool("guess-eventtypes"),
+	}
+	if ctx.Bool("report") {
+		o.Report = ctx.App.Writer
+	}
+	meta, nefBytes, err := buildBundle(data, o)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to build bundle: %w", err), 1)
+	}
+	if v := ctx.String("verify"); v != "" {
+		expected, err := os.ReadFile(v)
+		if err != nil {
+			return cli.Exit(fmt.Errorf("failed to read NEF file: %w", err), 1)
+		}
+		if !bytes.Equal(expected, nefBytes) {
+			return cli.Exit(fmt.Errorf("%s doesn't match the contract built from the bundle", v), 1)
+		}
+	}
+	if ctx.Bool("verbose") {
+		fmt.Fprintln(ctx.App.Writer, hex.EncodeToString(nefBytes))
+	}
+	fmt.Fprintf(ctx.App.Writer, "Contract %s is reproduced with %s, NEF checksum: %d\n", meta.Name, meta.Compiler, meta.Checksum)
+	return nil
+}
+
+// applyContractConfig sets compiler options from the contract configuration.
+func applyContractConfig(o *compiler.Options, conf ProjectConfig) {
+	o.Name = conf.Name
+	o.SourceURL = conf.SourceURL
+	o.ContractEvents = conf.Events
+	o.DeclaredNamedTypes = conf.NamedTypes
+	o.ContractSupportedStandards = conf.SupportedStandards
+	o.Permissions = make([]manifest.Permission, len(conf.Permissions))
+	for i := range conf.Permissions {
+		o.Permissions[i] = manifest.Permission(conf.Permissions[i])
+	}
+	o.SafeMethods = conf.SafeMethods
+	o.Overloads = conf.Overloads
+}
+
 func calcHash(ctx *cli.Context) error {
 	if err := cmdargs.EnsureNone(ctx); err != nil {
 		return err
@@ -740,15 +984,20 @@ func inspect(ctx *cli.Context) error {
 	}
 	in := ctx.String("in")
 	compile := ctx.Bool("compile")
+	analyze := ctx.Bool("analyze")
 	var (
-		b   []byte
-		err error
+		b       []byte
+		entries []int
 	)
 	if compile {
-		b, err = compiler.Compile(in, nil)
+		f, di, err := compiler.CompileWithOptions(in, nil, nil)
 		if err != nil {
 			return cli.Exit(fmt.Errorf("failed to compile: %w", err), 1)
 		}
+		b = f.Script
+		for _, m := range di.Methods {
+			entries = append(entries, int(m.Range.Start))
+		}
 	} else {
 		f, err := os.ReadFile(in)
 		if err != nil {
@@ -759,11 +1008,35 @@ func inspect(ctx *cli.Context) error {
 			return cli.Exit(fmt.Errorf("failed to restore .nef file: %w", err), 1)
 		}
 		b = nefFile.Script
+		if analyze {
+			if !ctx.IsSet("manifest") {
+				return cli.Exit("manifest is required to analyze .nef file", 1)
+			}
+			m, _, err := readManifest(ctx.String("manifest"), util.Uint160{})
+			if err != nil {
+				return cli.Exit(fmt.Errorf("failed to read manifest file: %w", err), 1)
+			}
+			for _, method := range m.ABI.Methods {
+				entries = append(entries, method.Offset)
+			}
+		}
 	}
 	v := vm.New()
 	v.LoadScript(b)
 	v.PrintOps(ctx.App.Writer)
 
+	if analyze {
+		issues, err := vm.AnalyzeScript(b, entries...)
+		if err != nil {
+			return cli.Exit(fmt.Errorf("failed to analyze script: %w", err), 1)
+		}
+		if len(issues) == 0 {
+			fmt.Fprintln(ctx.App.Writer, "No issues found.")
+		}
+		for _, issue := range issues {
+			fmt.Fprintln(ctx.App.Writer, issue)
+		}
+	}
 	return nil
 }
 
@@ -799,6 +1072,12 @@ func contractDeploy(ctx *cli.Context) error {
 	defer w.Close()
 	sender := acc.ScriptHash()
 
+	if ctx.String("bundle-source") != "" {
+		if err := publishBundle(ctx, nefFile, f, m.Name, acc.PrivateKey()); err != nil {
+			return err
+		}
+	}
+
 	cosigners, sgnErr := cmdargs.GetSignersFromContext(ctx, signOffset)
 	if sgnErr != nil {
 		return err
@@ -819,6 +1098,49 @@ func contractDeploy(ctx *cli.Context) error {
 	return nil
 }
 
+// publishBundle creates verification bundle for the deployed contract, checks
+// that it reproduces the NEF file and saves it to the file and/or NeoFS.
+func publishBundle(ctx *cli.Context, nefFile *nef.File, nefBytes []byte, name string, priv *keys.PrivateKey) error {
+	var (
+		out        = ctx.String("bundle")
+		fsEndpoint = ctx.String("fs-rpc-endpoint")
+		container  = ctx.String("container")
+	)
+	if (fsEndpoint == "") != (container == "") {
+		return cli.Exit("both --fs-rpc-endpoint and --container are required to upload verification bundle", 1)
+	}
+	if out == "" && container == "" {
+		return cli.Exit("verification bundle destination is not specified, use --bundle or --container", 1)
+	}
+	data, err := createBundle(ctx.String("bundle-source"), ctx.String("bundle-config"), name, nefFile)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to create verification bundle: %w", err), 1)
+	}
+	_, built, err := buildBundle(data, &compiler.Options{NoStandardCheck: true, NoEventsCheck: true, NoPermissionsCheck: true})
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to build contract from verification bundle: %w", err), 1)
+	}
+	if !bytes.Equal(built, nefBytes) {
+		return cli.Exit("NEF file built from verification bundle doesn't match the deployed one", 1)
+	}
+	if out != "" {
+		if err := os.WriteFile(out, data, 0o644); err != nil {
+			return cli.Exit(fmt.Errorf("failed to write verification bundle: %w", err), 1)
+		}
+		fmt.Fprintf(ctx.App.Writer, "Verification bundle: %s\n", out)
+	}
+	if container != "" {
+		gctx, cancel := context.WithTimeout(ctx.Context, options.DefaultTimeout)
+		defer cancel()
+		addr, err := uploadBundle(gctx, fsEndpoint, container, priv, name, nefFile.Compiler, data)
+		if err != nil {
+			return cli.Exit(fmt.Errorf("failed to upload verification bundle: %w", err), 1)
+		}
+		fmt.Fprintf(ctx.App.Writer, "Verification bundle: %s\n", addr)
+	}
+	return nil
+}
+
 // ParseContractConfig reads contract configuration file (.yaml) and returns unmarshalled ProjectConfig.
 func ParseContractConfig(confFile string) (ProjectConfig, error) {
 	conf := ProjectConfig{}
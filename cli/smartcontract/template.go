@@ -0,0 +1,89 @@
+package smartcontract
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+)
+
+// defaultTemplate is the name of the template used by `init` command by
+// default, it's a minimal hello-world contract.
+const defaultTemplate = "default"
+
+// templatesFS contains project templates for `init` command, every directory
+// is a template with files named as the resulting ones with ".tmpl" suffix.
+// Makefile.tmpl is shared between all of them.
+//
+//go:embed templates
+var templatesFS embed.FS
+
+// templateData is used to fill project templates.
+type templateData struct {
+	// Name is the contract (and Go package) name.
+	Name string
+	// Symbol is the token symbol derived from Name.
+	Symbol string
+}
+
+// templateNames returns the list of available project templates.
+func templateNames() []string {
+	var names = []string{defaultTemplate}
+	entries, _ := fs.ReadDir(templatesFS, "templates") // Can't fail for embedded FS.
+	for _, e := range entries {
+		if e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
+
+// writeTemplate writes all files of the given (non-default) project template
+// to the base directory.
+func writeTemplate(basePath string, name string, contractName string) error {
+	data := templateData{
+		Name: contractName,
+		Symbol: strings.Map(func(r rune) rune {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				return -1
+			}
+			return unicode.ToUpper(r)
+		}, contractName),
+	}
+	files, err := fs.Glob(templatesFS, "templates/"+name+"/*.tmpl")
+	if err != nil {
+		return err
+	}
+	files = append(files, "templates/Makefile.tmpl")
+	for _, f := range files {
+		tmpl, err := template.ParseFS(templatesFS, f)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", f, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return fmt.Errorf("failed to execute %s: %w", f, err)
+		}
+		out := filepath.Join(basePath, strings.TrimSuffix(filepath.Base(f), ".tmpl"))
+		if err := os.WriteFile(out, buf.Bytes(), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// neoGoModVersion returns the version of the main NeoGo module suitable to be
+// used in go.mod.
+func neoGoModVersion() string {
+	if config.Version == "" || strings.Contains(config.Version, "-") {
+		return "latest" // Development builds have no module version.
+	}
+	return "v" + strings.TrimPrefix(config.Version, "v")
+}
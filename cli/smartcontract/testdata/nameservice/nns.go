@@ -7,7 +7,9 @@ import (
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/nep11"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
@@ -369,6 +371,32 @@ func SetAdminEventsFromApplicationLog(log *result.ApplicationLog) ([]*SetAdminEv
 	return res, nil
 }
 
+// SetAdminEventFromNotification converts the provided [state.NotificationEvent]
+// to SetAdminEvent or returns an error if it's not a "SetAdmin" event
+// or it can't be converted. Notification sender is not checked.
+func SetAdminEventFromNotification(ne state.NotificationEvent) (*SetAdminEvent, error) {
+	if ne.Name != "SetAdmin" {
+		return nil, fmt.Errorf("unexpected event name: %s", ne.Name)
+	}
+	res := new(SetAdminEvent)
+	err := res.FromStackItem(ne.Item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize SetAdminEvent from stackitem: %w", err)
+	}
+	return res, nil
+}
+
+// NewSetAdminEventFilter creates a notification filter for "SetAdmin"
+// events emitted by the contract with Hash that can be used
+// for WSClient.ReceiveExecutionNotifications subscription.
+func NewSetAdminEventFilter() *neorpc.NotificationFilter {
+	var (
+		hash = Hash
+		name = "SetAdmin"
+	)
+	return &neorpc.NotificationFilter{Contract: &hash, Name: &name}
+}
+
 // FromStackItem converts provided [stackitem.Array] to SetAdminEvent or
 // returns an error if it's not possible to do to so.
 func (e *SetAdminEvent) FromStackItem(item *stackitem.Array) error {
@@ -462,6 +490,32 @@ func RenewEventsFromApplicationLog(log *result.ApplicationLog) ([]*RenewEvent, e
 	return res, nil
 }
 
+// RenewEventFromNotification converts the provided [state.NotificationEvent]
+// to RenewEvent or returns an error if it's not a "Renew" event
+// or it can't be converted. Notification sender is not checked.
+func RenewEventFromNotification(ne state.NotificationEvent) (*RenewEvent, error) {
+	if ne.Name != "Renew" {
+		return nil, fmt.Errorf("unexpected event name: %s", ne.Name)
+	}
+	res := new(RenewEvent)
+	err := res.FromStackItem(ne.Item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize RenewEvent from stackitem: %w", err)
+	}
+	return res, nil
+}
+
+// NewRenewEventFilter creates a notification filter for "Renew"
+// events emitted by the contract with Hash that can be used
+// for WSClient.ReceiveExecutionNotifications subscription.
+func NewRenewEventFilter() *neorpc.NotificationFilter {
+	var (
+		hash = Hash
+		name = "Renew"
+	)
+	return &neorpc.NotificationFilter{Contract: &hash, Name: &name}
+}
+
 // FromStackItem converts provided [stackitem.Array] to RenewEvent or
 // returns an error if it's not possible to do to so.
 func (e *RenewEvent) FromStackItem(item *stackitem.Array) error {
@@ -6,8 +6,10 @@ package nextoken
 import (
 	"errors"
 	"fmt"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/nep17"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
@@ -273,6 +275,32 @@ func OnMintEventsFromApplicationLog(log *result.ApplicationLog) ([]*OnMintEvent,
 	return res, nil
 }
 
+// OnMintEventFromNotification converts the provided [state.NotificationEvent]
+// to OnMintEvent or returns an error if it's not a "OnMint" event
+// or it can't be converted. Notification sender is not checked.
+func OnMintEventFromNotification(ne state.NotificationEvent) (*OnMintEvent, error) {
+	if ne.Name != "OnMint" {
+		return nil, fmt.Errorf("unexpected event name: %s", ne.Name)
+	}
+	res := new(OnMintEvent)
+	err := res.FromStackItem(ne.Item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize OnMintEvent from stackitem: %w", err)
+	}
+	return res, nil
+}
+
+// NewOnMintEventFilter creates a notification filter for "OnMint"
+// events emitted by the contract with Hash that can be used
+// for WSClient.ReceiveExecutionNotifications subscription.
+func NewOnMintEventFilter() *neorpc.NotificationFilter {
+	var (
+		hash = Hash
+		name = "OnMint"
+	)
+	return &neorpc.NotificationFilter{Contract: &hash, Name: &name}
+}
+
 // FromStackItem converts provided [stackitem.Array] to OnMintEvent or
 // returns an error if it's not possible to do to so.
 func (e *OnMintEvent) FromStackItem(item *stackitem.Array) error {
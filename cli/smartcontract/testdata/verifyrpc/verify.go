@@ -6,7 +6,9 @@ package verify
 import (
 	"errors"
 	"fmt"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -106,6 +108,32 @@ func HelloWorldEventsFromApplicationLog(log *result.ApplicationLog) ([]*HelloWor
 	return res, nil
 }
 
+// HelloWorldEventFromNotification converts the provided [state.NotificationEvent]
+// to HelloWorldEvent or returns an error if it's not a "Hello world!" event
+// or it can't be converted. Notification sender is not checked.
+func HelloWorldEventFromNotification(ne state.NotificationEvent) (*HelloWorldEvent, error) {
+	if ne.Name != "Hello world!" {
+		return nil, fmt.Errorf("unexpected event name: %s", ne.Name)
+	}
+	res := new(HelloWorldEvent)
+	err := res.FromStackItem(ne.Item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize HelloWorldEvent from stackitem: %w", err)
+	}
+	return res, nil
+}
+
+// NewHelloWorldEventFilter creates a notification filter for "Hello world!"
+// events emitted by the contract with Hash that can be used
+// for WSClient.ReceiveExecutionNotifications subscription.
+func NewHelloWorldEventFilter() *neorpc.NotificationFilter {
+	var (
+		hash = Hash
+		name = "Hello world!"
+	)
+	return &neorpc.NotificationFilter{Contract: &hash, Name: &name}
+}
+
 // FromStackItem converts provided [stackitem.Array] to HelloWorldEvent or
 // returns an error if it's not possible to do to so.
 func (e *HelloWorldEvent) FromStackItem(item *stackitem.Array) error {
@@ -23,7 +23,51 @@ func NewCommands() []*cli.Command {
 	rpcFlag := *rpcFlagOriginal
 	rpcFlag.Required = false
 	txDumpFlags := append([]cli.Flag{&rpcFlag}, options.RPC[1:]...)
-	txSendFlags := append(txDumpFlags, txctx.AwaitFlag)
+	txSendFlags := append(txDumpFlags, txctx.AwaitFlag, &flags.AddressFlag{
+		Name:    "address",
+		Aliases: []string{"a"},
+		Usage:   "Account to sign notary request with (for notary-assisted transactions)",
+	})
+	txSendFlags = append(txSendFlags, options.Wallet...)
+	txCreateFlags := append([]cli.Flag{
+		&cli.StringFlag{
+			Name:    "in",
+			Aliases: []string{"i"},
+			Usage:   "Input NEF file with the transaction script",
+		},
+		&cli.StringFlag{
+			Name:  "script",
+			Usage: "Base64- or hex-encoded transaction script",
+		},
+		&cli.StringFlag{
+			Name:     "out",
+			Required: true,
+			Usage:    "File (JSON) to put signature context with a transaction to",
+			Action:   cmdargs.EnsureNotEmpty("out"),
+		},
+		&cli.BoolFlag{
+			Name:  "notary",
+			Usage: "Create notary-assisted transaction",
+		},
+		txctx.GasFlag,
+		txctx.SysGasFlag,
+	}, options.RPC...)
+	txCreateFlags = append(txCreateFlags, options.Wallet...)
+	txSignFlags := append([]cli.Flag{
+		&cli.StringFlag{
+			Name:     "in",
+			Aliases:  []string{"i"},
+			Required: true,
+			Usage:    "Input file with the transaction context",
+			Action:   cmdargs.EnsureNotEmpty("in"),
+		},
+		txctx.OutFlag,
+		&flags.AddressFlag{
+			Name:    "address",
+			Aliases: []string{"a"},
+			Usage:   "Address to use",
+		},
+	}, options.Wallet...)
 	txCancelFlags := append([]cli.Flag{
 		&flags.AddressFlag{
 			Name:    "address",
@@ -34,6 +78,27 @@ func NewCommands() []*cli.Command {
 		txctx.AwaitFlag,
 	}, options.RPC...)
 	txCancelFlags = append(txCancelFlags, options.Wallet...)
+	notaryPoolFlags := append([]cli.Flag{
+		&flags.AddressFlag{
+			Name:    "address",
+			Aliases: []string{"a"},
+			Usage:   "Show only requests with fallbacks owned by the given address",
+		},
+	}, options.RPC...)
+	notaryCancelFlags := append([]cli.Flag{
+		&flags.AddressFlag{
+			Name:    "address",
+			Aliases: []string{"a"},
+			Usage:   "Address to sign notary request and fallback transaction with (must have a Notary deposit)",
+		},
+		&cli.UintFlag{
+			Name:  "nvb",
+			Usage: "NotValidBefore height of the new fallback transaction (default: the earliest possible)",
+		},
+		txctx.GasFlag,
+		txctx.AwaitFlag,
+	}, options.RPC...)
+	notaryCancelFlags = append(notaryCancelFlags, options.Wallet...)
 	uploadBinFlags := append([]cli.Flag{
 		&cli.StringSliceFlag{
 			Name:     "fs-rpc-endpoint",
@@ -117,17 +182,70 @@ func NewCommands() []*cli.Command {
 				},
 				{
 					Name:      "sendtx",
+					Aliases:   []string{"txbroadcast"},
 					Usage:     "Send complete transaction stored in a context file",
-					UsageText: "sendtx [-r <endpoint>] [--await] <file.in>",
+					UsageText: "sendtx [-r <endpoint>] [--await] [--wallet <wallet> [--wallet-config <path>] --address <address>] <file.in>",
 					Description: `Sends the transaction from the given context file to the given RPC node if it's
    completely signed and ready. This command expects a ContractParametersContext
    JSON file for input, it can't handle binary (or hex- or base64-encoded)
    transactions. If the --await flag is included, the command waits for the
    transaction to be included in a block before exiting.
+
+   Notary-assisted transactions (see 'txcreate --notary') are sent in a notary
+   request signed by the account given with the --address flag (it must have
+   a Notary deposit), the request contains all signatures from the context, but
+   no more than one for every multisignature signer, so other parties should
+   send their own requests. The request is processed by the notary service that
+   completes the transaction when all signatures are collected.
 `,
 					Action: sendTx,
 					Flags:  txSendFlags,
 				},
+				{
+					Name:      "txcreate",
+					Usage:     "Create unsigned transaction for offline signing",
+					UsageText: "txcreate -r <endpoint> --wallet <wallet> [--wallet-config <path>] (--in <file.nef> | --script <script>) --out <file.out> [--notary] [--gas <gas>] [--sysgas <sysgas>] <sender> [<signer>...]",
+					Description: `Creates a transaction with the given script and signers and saves it into
+   a ContractParametersContext JSON file that can then be signed with 'txsign'
+   (on a machine without network access), merged with 'txcombine' and sent with
+   'txbroadcast'. The script is either taken from the NEF file or given as
+   base64- or hex-encoded string. The first signer is the sender of the
+   transaction, all signer accounts must be present in the wallet, but they
+   don't need to have keys (see 'wallet strip-keys'), only verification scripts
+   are needed to calculate fees. See 'contract testinvokefunction' for signer
+   syntax. The transaction is valid for the maximum allowed number of blocks.
+
+   If --notary flag is given, a notary-assisted transaction is created (with
+   Notary contract signer and NotaryAssisted attribute), its signatures are
+   collected by the notary service from notary requests sent with 'txbroadcast'
+   by any of the parties.
+`,
+					Action: txCreate,
+					Flags:  txCreateFlags,
+				},
+				{
+					Name:      "txsign",
+					Usage:     "Sign transaction stored in a context file without network access",
+					UsageText: "txsign --wallet <wallet> [--wallet-config <path>] [--address <address>] --in <file.in> [--out <file.out>]",
+					Description: `Adds a signature of the given account (the default one if not specified) to
+   the transaction in the context file. It doesn't need any network access.
+   The resulting context is saved into the --out file or printed if there is
+   none.
+`,
+					Action: txSign,
+					Flags:  txSignFlags,
+				},
+				{
+					Name:      "txcombine",
+					Usage:     "Merge signatures from several context files",
+					UsageText: "txcombine [--out <file.out>] <file1.in> <file2.in> [<file3.in>...]",
+					Description: `Merges signatures from all given context files (that must contain the
+   same transaction) into one context that is saved into the --out file or
+   printed if there is none. Signatures are checked before being added.
+`,
+					Action: txCombine,
+					Flags:  []cli.Flag{txctx.OutFlag},
+				},
 				{
 					Name:      "canceltx",
 					Usage:     "Cancel transaction by sending conflicting transaction",
@@ -148,6 +266,40 @@ func NewCommands() []*cli.Command {
 					Action: cancelTx,
 					Flags:  txCancelFlags,
 				},
+				{
+					Name:      "notary-pool",
+					Usage:     "List notary requests from the node's notary request pool",
+					UsageText: "notary-pool -r <endpoint> [--address <address>]",
+					Description: `Prints the current height and hashes of main transactions of notary requests
+   from the RPC node's notary request pool along with their fallback
+   transactions. For every fallback its owner (the account that has sent the
+   request and pays for the fallback) and validity range are shown: fallback
+   can be accepted starting from the first height (unless the main transaction
+   is completed before it) and both transactions expire after the second one.
+`,
+					Action: notaryPool,
+					Flags:  notaryPoolFlags,
+				},
+				{
+					Name:      "cancel-notary-request",
+					Usage:     "Cancel or replace pending notary request by sending an updated fallback",
+					UsageText: "cancel-notary-request -r <endpoint> --wallet <wallet> [--address <account>] [--wallet-config <path>] [--nvb <height>] [--gas <gas>] [--await] <main txid>",
+					Description: `Sends a new notary request for the main transaction with the given hash
+   (that must be in the notary request pool of the RPC node and must be signed
+   by the given account) with an updated fallback transaction. By default the
+   fallback is valid starting from the next block (or the earliest height
+   allowed by MaxNotValidBeforeDelta), so it's accepted as soon as possible
+   (unless the main transaction is completed before that) which makes the main
+   transaction invalid (fallback conflicts with it), thus cancelling the
+   request. --nvb can be used to set some other NotValidBefore height for the
+   fallback which allows to replace the request with another one that has a
+   different fallback validity period. --gas adds the given amount to the
+   fallback network fee. Hash of the new fallback transaction is printed. With
+   --await the command waits for main or fallback transaction to be accepted.
+`,
+					Action: cancelNotaryRequest,
+					Flags:  notaryCancelFlags,
+				},
 				{
 					Name:      "txdump",
 					Usage:     "Dump transaction stored in file",
@@ -0,0 +1,176 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/cli/txctx"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/notary"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/urfave/cli/v2"
+)
+
+func notaryPool(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, exitErr := options.GetRPCClient(gctx, ctx)
+	if exitErr != nil {
+		return exitErr
+	}
+	defer c.Close()
+
+	height, err := c.GetBlockCount()
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to get block count: %w", err), 1)
+	}
+	pool, err := c.GetRawNotaryPool()
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to get notary pool: %w", err), 1)
+	}
+	var filter *util.Uint160
+	if addr := ctx.Generic("address").(*flags.Address); addr.IsSet {
+		h := addr.Uint160()
+		filter = &h
+	}
+	mains := util.MapKeys(pool.Hashes)
+
+	fmt.Fprintf(ctx.App.Writer, "Height:\t%d\n", height-1)
+	for _, mainHash := range mains {
+		var lines []string
+		for _, fbHash := range pool.Hashes[mainHash] {
+			fb, err := c.GetRawNotaryTransaction(fbHash)
+			if err != nil {
+				// Can be removed from the pool in the meantime.
+				continue
+			}
+			owner := fb.Signers[1].Account
+			if filter != nil && !owner.Equals(*filter) {
+				continue
+			}
+			nvb := fb.GetAttributes(transaction.NotValidBeforeT)[0].Value.(*transaction.NotValidBefore).Height
+			lines = append(lines, fmt.Sprintf("  Fallback:\t%s (owner %s, valid from %d until %d)",
+				fbHash.StringLE(), address.Uint160ToString(owner), nvb, fb.ValidUntilBlock))
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		fmt.Fprintf(ctx.App.Writer, "Main:\t%s\n", mainHash.StringLE())
+		for _, l := range lines {
+			fmt.Fprintln(ctx.App.Writer, l)
+		}
+	}
+	return nil
+}
+
+func cancelNotaryRequest(ctx *cli.Context) error {
+	args := ctx.Args().Slice()
+	if len(args) == 0 {
+		return cli.Exit("main transaction hash is missing", 1)
+	} else if len(args) > 1 {
+		return cli.Exit("only one transaction hash is accepted", 1)
+	}
+	mainHash, err := util.Uint256DecodeStringLE(strings.TrimPrefix(args[0], "0x"))
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("invalid tx hash: %s", args[0]), 1)
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	acc, w, err := options.GetAccFromContext(ctx)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to get account from context to sign the fallback transaction: %w", err), 1)
+	}
+	defer w.Close()
+
+	c, exitErr := options.GetRPCClient(gctx, ctx)
+	if exitErr != nil {
+		return exitErr
+	}
+	defer c.Close()
+
+	mainTx, err := c.GetRawNotaryTransaction(mainHash)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to get main transaction from the notary pool: %w", err), 1)
+	}
+	if !mainTx.HasSigner(acc.ScriptHash()) {
+		return cli.Exit(fmt.Errorf("account %s is not a signer of the main transaction", acc.Address), 1)
+	}
+	nAct, err := notary.NewActor(c, []actor.SignerAccount{{
+		Signer: transaction.Signer{
+			Account: acc.ScriptHash(),
+			Scopes:  transaction.None,
+		},
+		Account: acc,
+	}}, acc)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to create notary actor: %w", err), 1)
+	}
+	nvbDelta, err := notary.NewReader(nAct).GetMaxNotValidBeforeDelta()
+	if err != nil {
+		return cli.Exit(fmt.Errorf("can't get MaxNVBDelta: %w", err), 1)
+	}
+	var minNVB uint32
+	if mainTx.ValidUntilBlock > nvbDelta {
+		minNVB = mainTx.ValidUntilBlock - nvbDelta
+	}
+	height, err := c.GetBlockCount()
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to get block count: %w", err), 1)
+	}
+	// Notary request pool doesn't accept fallbacks that are valid too far in
+	// the future.
+	maxNVB := min(mainTx.ValidUntilBlock-1, height-1+nvbDelta)
+	nvb := uint32(ctx.Uint("nvb"))
+	if !ctx.IsSet("nvb") {
+		nvb = max(height, minNVB)
+	} else if nvb < minNVB || nvb > maxNVB {
+		return cli.Exit(fmt.Errorf("NotValidBefore must be in [%d, %d] range", minNVB, maxNVB), 1)
+	}
+
+	fbTx, err := nAct.FbActor.MakeUnsignedRun([]byte{byte(opcode.RET)}, nil)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to create fallback transaction: %w", err), 1)
+	}
+	// Attributes are NotaryAssisted, NotValidBefore and Conflicts (see
+	// notary.NewDefaultActorOptions), new values are created to avoid
+	// overwriting the defaults.
+	fbTx.Attributes[1].Value = &transaction.NotValidBefore{Height: nvb}
+	fbTx.Attributes[2].Value = &transaction.Conflicts{Hash: mainHash}
+	fbTx.ValidUntilBlock = mainTx.ValidUntilBlock
+	fbTx.NetworkFee += int64(flags.Fixed8FromContext(ctx, "gas"))
+	if err := nAct.FbActor.Sign(fbTx); err != nil {
+		return cli.Exit(fmt.Errorf("failed to sign fallback transaction: %w", err), 1)
+	}
+	fbTx.Scripts[0].InvocationScript = append([]byte{byte(opcode.PUSHDATA1), keys.SignatureLen}, make([]byte, keys.SignatureLen)...)
+
+	_, fbHash, vub, err := nAct.SendRequestExactly(mainTx, fbTx)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to submit notary request: %w", err), 1)
+	}
+	var res *state.AppExecResult
+	if ctx.Bool("await") {
+		res, err = nAct.Wait(mainHash, fbHash, vub, nil)
+		if err != nil {
+			return cli.Exit(fmt.Errorf("failed to await main/ fallback transaction %s/ %s: %w", mainHash.StringLE(), fbHash.StringLE(), err), 1)
+		}
+		if mainHash.Equals(res.Container) {
+			return cli.Exit(fmt.Errorf("main transaction %s is accepted", mainHash.StringLE()), 1)
+		}
+	}
+	txctx.DumpTransactionInfo(ctx.App.Writer, fbHash, res)
+	return nil
+}
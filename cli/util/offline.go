@@ -0,0 +1,245 @@
+package util
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/cli/paramcontext"
+	"github.com/nspcc-dev/neo-go/cli/txctx"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/notary"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/urfave/cli/v2"
+)
+
+func txCreate(ctx *cli.Context) error {
+	var (
+		out       = ctx.String("out")
+		gas       = flags.Fixed8FromContext(ctx, "gas")
+		sysgas    = flags.Fixed8FromContext(ctx, "sysgas")
+		useNotary = ctx.Bool("notary")
+	)
+	script, err := getTxScript(ctx)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	signers, exitErr := cmdargs.GetSignersFromContext(ctx, 0)
+	if exitErr != nil {
+		return exitErr
+	}
+	if len(signers) == 0 {
+		return cli.Exit("at least one signer (sender) is required", 1)
+	}
+	wall, _, err := options.GetWalletFromContext(ctx)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	defer wall.Close()
+
+	var (
+		sas   = make([]actor.SignerAccount, 0, len(signers)+1)
+		nKeys int
+	)
+	for i, s := range signers {
+		acc := wall.GetAccount(s.Account)
+		if acc == nil || acc.Contract == nil {
+			return cli.Exit(fmt.Errorf("no account was found in the wallet for signer #%d (%s)", i, address.Uint160ToString(s.Account)), 1)
+		}
+		sas = append(sas, actor.SignerAccount{Signer: s, Account: acc})
+		if acc.Contract.Deployed {
+			continue
+		}
+		if _, pubs, ok := vm.ParseMultiSigContract(acc.Contract.Script); ok {
+			nKeys += len(pubs)
+		} else {
+			nKeys++
+		}
+	}
+	var attrs []transaction.Attribute
+	if useNotary {
+		if nKeys > 255 {
+			return cli.Exit("notary subsystem can't handle more than 255 signatures", 1)
+		}
+		sas = append(sas, actor.SignerAccount{
+			Signer: transaction.Signer{
+				Account: notary.Hash,
+				Scopes:  transaction.None,
+			},
+			Account: notary.FakeContractAccount(notary.Hash),
+		})
+		attrs = append(attrs, transaction.Attribute{
+			Type:  transaction.NotaryAssistedT,
+			Value: &transaction.NotaryAssisted{NKeys: uint8(nKeys)},
+		})
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, act, exitErr := options.GetRPCWithActor(gctx, ctx, sas)
+	if exitErr != nil {
+		return exitErr
+	}
+	defer c.Close()
+
+	tx, err := act.MakeUnsignedRun(script, attrs)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to create transaction: %w", err), 1)
+	}
+	tx.SystemFee += int64(sysgas)
+	tx.NetworkFee += int64(gas)
+	if useNotary {
+		// Notary service needs some time to collect signatures.
+		nvbDelta, err := notary.NewReader(invoker.New(c, nil)).GetMaxNotValidBeforeDelta()
+		if err != nil {
+			return cli.Exit(fmt.Errorf("can't get MaxNVBDelta: %w", err), 1)
+		}
+		tx.ValidUntilBlock += nvbDelta
+	} else {
+		// Make a long-lived transaction, it's to be signed manually.
+		ver := act.GetVersion()
+		tx.ValidUntilBlock += (ver.Protocol.MaxValidUntilBlockIncrement - uint32(ver.Protocol.ValidatorsCount)) - 2
+	}
+
+	pc := context.NewParameterContext(context.TransactionType, act.GetNetwork(), tx)
+	for _, sa := range sas[:len(signers)] {
+		pc.AddContract(sa.Signer.Account, sa.Account.Contract)
+	}
+	if useNotary {
+		// Notary witness is a stub to be replaced by the notary service.
+		pc.Items[notary.Hash] = &context.Item{
+			Parameters: []smartcontract.Parameter{{
+				Type:  smartcontract.SignatureType,
+				Value: make([]byte, keys.SignatureLen),
+			}},
+			Signatures: make(map[string][]byte),
+		}
+	}
+	if err := paramcontext.Save(pc, out); err != nil {
+		return cli.Exit(err, 1)
+	}
+	txctx.DumpTransactionInfo(ctx.App.Writer, tx.Hash(), nil)
+	return nil
+}
+
+// getTxScript returns the transaction script either from the NEF file or from
+// the base64- or hex-encoded command line flag.
+func getTxScript(ctx *cli.Context) ([]byte, error) {
+	var (
+		in = ctx.String("in")
+		s  = ctx.String("script")
+	)
+	switch {
+	case in != "" && s != "":
+		return nil, errors.New("--in flag conflicts with --script flag")
+	case in != "":
+		b, err := os.ReadFile(in)
+		if err != nil {
+			return nil, err
+		}
+		nefFile, err := nef.FileFromBytes(b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore .nef file: %w", err)
+		}
+		return nefFile.Script, nil
+	case s != "":
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			b, err = hex.DecodeString(s)
+		}
+		if err != nil {
+			return nil, errors.New("unknown script encoding: base64 or hex are supported")
+		}
+		return b, nil
+	default:
+		return nil, errors.New("no script given, use either --in or --script flag")
+	}
+}
+
+func txSign(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	pc, err := paramcontext.Read(ctx.String("in"))
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	tx, ok := pc.Verifiable.(*transaction.Transaction)
+	if !ok {
+		return cli.Exit("verifiable item is not a transaction", 1)
+	}
+	acc, wall, err := options.GetAccFromContext(ctx)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	defer wall.Close()
+
+	if !tx.HasSigner(acc.ScriptHash()) {
+		return cli.Exit("tx signers don't contain provided account", 1)
+	}
+	if !acc.CanSign() {
+		return cli.Exit("can't sign transactions with the given account", 1)
+	}
+	sign := acc.SignHashable(pc.Network, pc.Verifiable)
+	if err := pc.AddSignature(acc.ScriptHash(), acc.Contract, acc.PublicKey(), sign); err != nil {
+		return cli.Exit(fmt.Errorf("can't add signature: %w", err), 1)
+	}
+	return saveOrPrintContext(ctx, pc)
+}
+
+func txCombine(ctx *cli.Context) error {
+	args := ctx.Args().Slice()
+	if len(args) < 2 {
+		return cli.Exit("at least two input files are required", 1)
+	}
+	pc, err := paramcontext.Read(args[0])
+	if err != nil {
+		return cli.Exit(fmt.Errorf("%s: %w", args[0], err), 1)
+	}
+	var added int
+	for _, name := range args[1:] {
+		other, err := paramcontext.Read(name)
+		if err != nil {
+			return cli.Exit(fmt.Errorf("%s: %w", name, err), 1)
+		}
+		n, err := pc.Merge(other)
+		if err != nil {
+			return cli.Exit(fmt.Errorf("%s: %w", name, err), 1)
+		}
+		added += n
+	}
+	fmt.Fprintf(ctx.App.ErrWriter, "Added %d signature(s)\n", added)
+	return saveOrPrintContext(ctx, pc)
+}
+
+// saveOrPrintContext saves the context to the file specified with --out flag
+// or prints it if there is none.
+func saveOrPrintContext(ctx *cli.Context, pc *context.ParameterContext) error {
+	out := ctx.String("out")
+	if out == "" {
+		txt, err := json.MarshalIndent(pc, " ", "     ")
+		if err != nil {
+			return cli.Exit(fmt.Errorf("can't display resulting context: %w", err), 1)
+		}
+		fmt.Fprintln(ctx.App.Writer, string(txt))
+		return nil
+	}
+	if err := paramcontext.Save(pc, out); err != nil {
+		return cli.Exit(fmt.Errorf("can't save resulting context: %w", err), 1)
+	}
+	return nil
+}
@@ -1,13 +1,25 @@
 package util
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 
+	"github.com/nspcc-dev/neo-go/cli/flags"
 	"github.com/nspcc-dev/neo-go/cli/options"
 	"github.com/nspcc-dev/neo-go/cli/paramcontext"
 	"github.com/nspcc-dev/neo-go/cli/txctx"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/notary"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/waiter"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/urfave/cli/v2"
 )
 
@@ -23,8 +35,15 @@ func sendTx(ctx *cli.Context) error {
 	if err != nil {
 		return cli.Exit(err, 1)
 	}
+	tx, ok := pc.Verifiable.(*transaction.Transaction)
+	if !ok {
+		return cli.Exit("verifiable item is not a transaction", 1)
+	}
+	if tx.HasAttribute(transaction.NotaryAssistedT) {
+		return sendNotaryRequest(ctx, pc)
+	}
 
-	tx, err := pc.GetCompleteTransaction()
+	tx, err = pc.GetCompleteTransaction()
 	if err != nil {
 		return cli.Exit(fmt.Errorf("failed to complete transaction: %w", err), 1)
 	}
@@ -51,3 +70,106 @@ func sendTx(ctx *cli.Context) error {
 	txctx.DumpTransactionInfo(ctx.App.Writer, res, aer)
 	return nil
 }
+
+// sendNotaryRequest sends notary-assisted transaction from the given context
+// in a notary request signed by the account from the wallet.
+func sendNotaryRequest(ctx *cli.Context, pc *context.ParameterContext) error {
+	if !ctx.Generic("address").(*flags.Address).IsSet {
+		return cli.Exit("notary-assisted transaction can only be sent in a notary request, specify an account to sign it with the --address flag", 1)
+	}
+	mainTx, err := getNotaryMainTx(pc)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	acc, wall, err := options.GetAccFromContext(ctx)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	defer wall.Close()
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, exitErr := options.GetRPCClient(gctx, ctx)
+	if exitErr != nil {
+		return cli.Exit(fmt.Errorf("failed to create RPC client: %w", exitErr), 1)
+	}
+	nAct, err := notary.NewActor(c, []actor.SignerAccount{{
+		Signer: transaction.Signer{
+			Account: acc.ScriptHash(),
+			Scopes:  transaction.None,
+		},
+		Account: acc,
+	}}, acc)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to create notary actor: %w", err), 1)
+	}
+	mainHash, fbHash, vub, err := nAct.Notarize(mainTx, nil)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to submit notary request to RPC node: %w", err), 1)
+	}
+	var aer *state.AppExecResult
+	if ctx.Bool("await") {
+		aer, err = nAct.Wait(mainHash, fbHash, vub, err)
+		if err != nil {
+			return cli.Exit(fmt.Errorf("failed to await transaction %s: %w", mainHash.StringLE(), err), 1)
+		}
+		if !aer.Container.Equals(mainHash) {
+			return cli.Exit(fmt.Errorf("%w: %s", notary.ErrFallbackAccepted, aer.Container.StringLE()), 1)
+		}
+	}
+	txctx.DumpTransactionInfo(ctx.App.Writer, mainHash, aer)
+	return nil
+}
+
+// getNotaryMainTx returns notary-assisted transaction from the context with
+// witnesses suitable for notary request: every signature-based witness has a
+// verification script and at most one signature, the notary contract witness
+// is a stub.
+func getNotaryMainTx(pc *context.ParameterContext) (*transaction.Transaction, error) {
+	tx := pc.Verifiable.(*transaction.Transaction)
+	tx.Scripts = make([]transaction.Witness, len(tx.Signers))
+	for i, s := range tx.Signers {
+		if s.Account.Equals(notary.Hash) {
+			tx.Scripts[i].InvocationScript = append([]byte{byte(opcode.PUSHDATA1), keys.SignatureLen}, make([]byte, keys.SignatureLen)...)
+			continue
+		}
+		item, ok := pc.Items[s.Account]
+		if !ok {
+			return nil, fmt.Errorf("no verification script for signer #%d", i)
+		}
+		if len(item.Script) == 0 {
+			w, err := pc.GetWitness(s.Account)
+			if err != nil {
+				return nil, fmt.Errorf("can't create witness for signer #%d: %w", i, err)
+			}
+			tx.Scripts[i] = *w
+			continue
+		}
+		var sig []byte
+		if vm.IsSignatureContract(item.Script) {
+			if len(item.Parameters) == 1 && item.Parameters[0].Value != nil {
+				sig = item.Parameters[0].Value.([]byte)
+			}
+		} else if _, _, ok := vm.ParseMultiSigContract(item.Script); ok {
+			// Notary request can only contain one signature per witness.
+			pubs := make([]string, 0, len(item.Signatures))
+			for pub := range item.Signatures {
+				pubs = append(pubs, pub)
+			}
+			slices.Sort(pubs)
+			if len(pubs) > 0 {
+				sig = item.Signatures[pubs[0]]
+			}
+		} else {
+			return nil, errors.New("only signature, multisignature and contract witnesses are supported for notary requests")
+		}
+		if sig != nil {
+			bw := io.NewBufBinWriter()
+			emit.Bytes(bw.BinWriter, sig)
+			tx.Scripts[i].InvocationScript = bw.Bytes()
+		}
+		tx.Scripts[i].VerificationScript = item.Script
+	}
+	return tx, nil
+}
@@ -1,16 +1,32 @@
 package util_test
 
 import (
+	"context"
+	"encoding/base64"
 	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/nspcc-dev/neo-go/cli/paramcontext"
 	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/gas"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/notary"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/require"
@@ -210,3 +226,247 @@ func TestUploadBin(t *testing.T) {
 	e.In.WriteString("one\r")
 	e.RunWithErrorCheckExit(t, "failed to dial NeoFS pool", append(args, "--cid", "9iVfUg8aDHKjPC4LhQXEkVUM4HDkR7UCXYLs8NQwYfSG", "--wallet", testcli.ValidatorWallet, "--rpc-endpoint", "http://"+e.RPC.Addresses()[0])...)
 }
+
+func TestUtilOfflineTx(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+
+	privs, pubs := testcli.GenerateKeys(t, 3)
+	script, err := smartcontract.CreateMultiSigRedeemScript(2, pubs)
+	require.NoError(t, err)
+	multisigHash := hash.Hash160(script)
+	multisigAddr := address.Uint160ToString(multisigHash)
+
+	tmpDir := t.TempDir()
+	wallet1Path := filepath.Join(tmpDir, "multiWallet1.json")
+	wallet2Path := filepath.Join(tmpDir, "multiWallet2.json")
+	addAccount := func(w string, wif string) {
+		e.Run(t, "neo-go", "wallet", "init", "--wallet", w)
+		e.In.WriteString("acc\rpass\rpass\r")
+		e.Run(t, "neo-go", "wallet", "import-multisig",
+			"--wallet", w,
+			"--wif", wif,
+			"--min", "2",
+			pubs[0].StringCompressed(),
+			pubs[1].StringCompressed(),
+			pubs[2].StringCompressed())
+	}
+	addAccount(wallet1Path, privs[0].WIF())
+	addAccount(wallet2Path, privs[1].WIF())
+
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "wallet", "nep17", "multitransfer",
+		"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+		"--wallet", testcli.ValidatorWallet,
+		"--from", testcli.ValidatorAddr,
+		"--force",
+		"NEO:"+multisigAddr+":4",
+		"GAS:"+multisigAddr+":1")
+	e.CheckTxPersisted(t)
+
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	txScript, err := smartcontract.CreateCallWithAssertScript(nativehashes.NeoToken, "transfer",
+		multisigHash, priv.GetScriptHash(), 1, nil)
+	require.NoError(t, err)
+	scriptB64 := base64.StdEncoding.EncodeToString(txScript)
+
+	var (
+		ctxPath  = filepath.Join(tmpDir, "tx.json")
+		sig1Path = filepath.Join(tmpDir, "tx.sig1.json")
+		sig2Path = filepath.Join(tmpDir, "tx.sig2.json")
+		fullPath = filepath.Join(tmpDir, "tx.full.json")
+	)
+	createArgs := []string{"neo-go", "util", "txcreate",
+		"--rpc-endpoint", "http://" + e.RPC.Addresses()[0],
+		"--wallet", wallet1Path, "--out", ctxPath}
+
+	t.Run("txcreate bad cases", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "no script given", append(createArgs, multisigAddr)...)
+		e.RunWithErrorCheckExit(t, "unknown script encoding", append(createArgs, "--script", "not a script", multisigAddr)...)
+		e.RunWithErrorCheckExit(t, "at least one signer", append(createArgs, "--script", scriptB64)...)
+		e.RunWithErrorCheckExit(t, "no account was found in the wallet", append(createArgs, "--script", scriptB64, testcli.ValidatorAddr)...)
+	})
+
+	e.Run(t, append(createArgs, "--script", scriptB64, multisigAddr)...)
+	txHash, err := util.Uint256DecodeStringLE(e.GetNextLine(t))
+	require.NoError(t, err)
+	e.CheckEOF(t)
+
+	t.Run("txsign bad cases", func(t *testing.T) {
+		e.RunWithErrorCheck(t, `Required flag "in" not set`, "neo-go", "util", "txsign", "--wallet", wallet2Path)
+		e.In.WriteString("one\r")
+		e.RunWithErrorCheckExit(t, "tx signers don't contain provided account", "neo-go", "util", "txsign",
+			"--wallet", testcli.ValidatorWallet, "--address", testcli.ValidatorAddr, "--in", ctxPath)
+	})
+
+	e.In.WriteString("pass\r")
+	e.Run(t, "neo-go", "util", "txsign", "--wallet", wallet1Path, "--address", multisigAddr,
+		"--in", ctxPath, "--out", sig1Path)
+	e.In.WriteString("pass\r")
+	e.Run(t, "neo-go", "util", "txsign", "--wallet", wallet2Path, "--address", multisigAddr,
+		"--in", ctxPath, "--out", sig2Path)
+
+	t.Run("incomplete", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "failed to complete transaction", "neo-go", "util", "txbroadcast",
+			"--rpc-endpoint", "http://"+e.RPC.Addresses()[0], sig1Path)
+	})
+	t.Run("txcombine bad cases", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "at least two input files are required", "neo-go", "util", "txcombine", sig1Path)
+		e.RunWithError(t, "neo-go", "util", "txcombine", sig1Path, filepath.Join(tmpDir, "missing.json"))
+	})
+
+	e.Run(t, "neo-go", "util", "txcombine", "--out", fullPath, ctxPath, sig1Path, sig2Path)
+	require.Contains(t, e.Err.String(), "Added 2 signature(s)")
+	e.CheckEOF(t)
+
+	e.Run(t, "neo-go", "util", "txbroadcast", "--rpc-endpoint", "http://"+e.RPC.Addresses()[0], fullPath)
+	e.CheckTxPersisted(t)
+	tx, _ := e.GetTransaction(t, txHash)
+	require.Equal(t, multisigHash, tx.Sender())
+
+	t.Run("notary", func(t *testing.T) {
+		notaryPath := filepath.Join(tmpDir, "notary.json")
+		e.Run(t, append(createArgs[:len(createArgs)-1], notaryPath, "--notary", "--script", scriptB64, multisigAddr)...)
+		e.GetNextLine(t)
+		e.CheckEOF(t)
+
+		pc, err := paramcontext.Read(notaryPath)
+		require.NoError(t, err)
+		tx := pc.Verifiable.(*transaction.Transaction)
+		require.Equal(t, []util.Uint160{multisigHash, nativehashes.Notary},
+			[]util.Uint160{tx.Signers[0].Account, tx.Signers[1].Account})
+		require.Equal(t, &transaction.NotaryAssisted{NKeys: 3},
+			tx.GetAttributes(transaction.NotaryAssistedT)[0].Value)
+		require.Equal(t, script, pc.Items[multisigHash].Script)
+
+		e.RunWithErrorCheckExit(t, "specify an account to sign it with the --address flag", "neo-go", "util", "txbroadcast",
+			"--rpc-endpoint", "http://"+e.RPC.Addresses()[0], notaryPath)
+	})
+}
+
+func TestUtilNotaryRequests(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+	rpcAddr := "http://" + e.RPC.Addresses()[0]
+
+	c, err := rpcclient.New(context.Background(), rpcAddr, rpcclient.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+	t.Cleanup(c.Close)
+
+	w, err := wallet.NewWalletFromFile(testcli.ValidatorWallet)
+	require.NoError(t, err)
+	t.Cleanup(w.Close)
+	validatorAcc := w.GetAccount(testcli.ValidatorHash)
+	require.NoError(t, validatorAcc.Decrypt(testcli.ValidatorPass, w.Scrypt))
+	// Notary requests are sent by simple signature accounts, so the
+	// validator's single-key one is used instead of the default multisig.
+	acc := w.GetAccount(testcli.ValidatorPriv.GetScriptHash())
+	require.NoError(t, acc.Decrypt(testcli.ValidatorPass, w.Scrypt))
+
+	accHash := acc.ScriptHash()
+
+	act, err := actor.NewSimple(c, validatorAcc)
+	require.NoError(t, err)
+	_, err = act.WaitSuccess(gas.New(act).Transfer(act.Sender(), acc.ScriptHash(), big.NewInt(10_0000_0000), nil))
+	require.NoError(t, err)
+	_, err = act.WaitSuccess(notary.New(act).Deposit(act.Sender(), big.NewInt(10_0000_0000),
+		&notary.OnNEP17PaymentData{Account: &accHash, Till: e.Chain.BlockHeight() + 10000}))
+	require.NoError(t, err)
+
+	nAct, err := notary.NewActor(c, []actor.SignerAccount{{
+		Signer: transaction.Signer{
+			Account: acc.ScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		},
+		Account: acc,
+	}}, acc)
+	require.NoError(t, err)
+	mainHash, fbHash, vub, err := nAct.Notarize(nAct.MakeRun([]byte{byte(opcode.PUSH1)}))
+	require.NoError(t, err)
+	fb, err := c.GetRawNotaryTransaction(fbHash)
+	require.NoError(t, err)
+	fbNVB := fb.GetAttributes(transaction.NotValidBeforeT)[0].Value.(*transaction.NotValidBefore).Height
+
+	checkPool := func(t *testing.T, fallbacks ...string) {
+		require.Regexp(t, `^Height:\t\d+$`, e.GetNextLine(t))
+		if len(fallbacks) > 0 {
+			e.CheckNextLine(t, `^Main:\t`+mainHash.StringLE()+`$`)
+		}
+		var lines []string
+		for range fallbacks {
+			lines = append(lines, e.GetNextLine(t))
+		}
+		for _, fb := range fallbacks {
+			require.Contains(t, lines, fb)
+		}
+		e.CheckEOF(t)
+	}
+	fbLine := func(h util.Uint256, nvb uint32) string {
+		return fmt.Sprintf("  Fallback:\t%s (owner %s, valid from %d until %d)", h.StringLE(), acc.Address, nvb, vub)
+	}
+
+	t.Run("notary-pool", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "additional arguments given", "neo-go", "util", "notary-pool", "--rpc-endpoint", rpcAddr, "something")
+
+		e.Run(t, "neo-go", "util", "notary-pool", "--rpc-endpoint", rpcAddr)
+		checkPool(t, fbLine(fbHash, fbNVB))
+
+		e.Run(t, "neo-go", "util", "notary-pool", "--rpc-endpoint", rpcAddr, "--address", acc.Address)
+		checkPool(t, fbLine(fbHash, fbNVB))
+
+		e.Run(t, "neo-go", "util", "notary-pool", "--rpc-endpoint", rpcAddr, "--address", testcli.TestWalletAccount)
+		checkPool(t)
+	})
+
+	cancelArgs := []string{"neo-go", "util", "cancel-notary-request", "--rpc-endpoint", rpcAddr,
+		"--wallet", testcli.ValidatorWallet, "--address", acc.Address}
+	t.Run("bad cases", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "main transaction hash is missing", cancelArgs...)
+		e.RunWithErrorCheckExit(t, "only one transaction hash is accepted", append(cancelArgs, mainHash.StringLE(), fbHash.StringLE())...)
+		e.RunWithErrorCheckExit(t, "invalid tx hash", append(cancelArgs, "notahash")...)
+
+		e.In.WriteString(testcli.ValidatorPass + "\r")
+		e.RunWithErrorCheckExit(t, "failed to get main transaction from the notary pool", append(cancelArgs, util.Uint256{1, 2, 3}.StringLE())...)
+
+		e.In.WriteString(testcli.ValidatorPass + "\r")
+		e.RunWithErrorCheckExit(t, "NotValidBefore must be in", append(cancelArgs, "--nvb", strconv.FormatUint(uint64(vub-1), 10), mainHash.StringLE())...)
+
+		e.In.WriteString("testpass\r")
+		e.RunWithErrorCheckExit(t, "is not a signer of the main transaction", "neo-go", "util", "cancel-notary-request",
+			"--rpc-endpoint", rpcAddr, "--wallet", testcli.TestWalletPath, "--address", testcli.TestWalletAccount, mainHash.StringLE())
+	})
+
+	nvbDelta, err := notary.NewReader(act).GetMaxNotValidBeforeDelta()
+	require.NoError(t, err)
+	var replaceHash util.Uint256
+	t.Run("replace", func(t *testing.T) {
+		nvb := vub - nvbDelta
+		e.In.WriteString(testcli.ValidatorPass + "\r")
+		e.Run(t, append(cancelArgs, "--nvb", strconv.FormatUint(uint64(nvb), 10), mainHash.StringLE())...)
+		replaceHash, err = util.Uint256DecodeStringLE(e.GetNextLine(t))
+		require.NoError(t, err)
+		e.CheckEOF(t)
+
+		e.Run(t, "neo-go", "util", "notary-pool", "--rpc-endpoint", rpcAddr)
+		checkPool(t, fbLine(fbHash, fbNVB), fbLine(replaceHash, nvb))
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		e.In.WriteString(testcli.ValidatorPass + "\r")
+		e.Run(t, append(cancelArgs, "--gas", "0.1", mainHash.StringLE())...)
+		cancelHash, err := util.Uint256DecodeStringLE(e.GetNextLine(t))
+		require.NoError(t, err)
+		e.CheckEOF(t)
+
+		cancelFb, err := c.GetRawNotaryTransaction(cancelHash)
+		require.NoError(t, err)
+		require.Equal(t, vub, cancelFb.ValidUntilBlock)
+		require.Equal(t, []transaction.Attribute{{Type: transaction.ConflictsT, Value: &transaction.Conflicts{Hash: mainHash}}},
+			cancelFb.GetAttributes(transaction.ConflictsT))
+		nvb := cancelFb.GetAttributes(transaction.NotValidBeforeT)[0].Value.(*transaction.NotValidBefore).Height
+		require.LessOrEqual(t, nvb, max(e.Chain.BlockHeight()+1, vub-nvbDelta))
+
+		e.Run(t, "neo-go", "util", "notary-pool", "--rpc-endpoint", rpcAddr)
+		checkPool(t, fbLine(fbHash, fbNVB), fbLine(replaceHash, vub-nvbDelta), fbLine(cancelHash, nvb))
+	})
+}
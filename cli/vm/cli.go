@@ -57,6 +57,7 @@ const (
 	exitFuncKey         = "exitFunc"
 	readlineInstanceKey = "readlineKey"
 	printLogoKey        = "printLogoKey"
+	debugInfoKey        = "debugInfo"
 )
 
 // Various flag names.
@@ -103,18 +104,21 @@ var commands = []*cli.Command{
 	{
 		Name:      "break",
 		Usage:     "Place a breakpoint",
-		UsageText: `break <ip>`,
-		Description: `<ip> is mandatory parameter.
+		UsageText: `break <ip> | <file>:<line>`,
+		Description: `<ip> or <file>:<line> is mandatory parameter. Source code location
+can be used if debug info is loaded (see 'loadgo' and 'loaddebug'), file
+can be specified by its name or path suffix.
 
 Example:
-> break 12`,
+> break 12
+> break contract.go:42`,
 		Action: handleBreak,
 	},
 	{
 		Name:      "delete",
 		Usage:     "Remove a breakpoint",
-		UsageText: `delete <ip>`,
-		Description: `<ip> is mandatory parameter.
+		UsageText: `delete <ip> | <file>:<line>`,
+		Description: `<ip> or <file>:<line> is mandatory parameter (see 'break').
 
 Example:
 > delete 12`,
@@ -246,6 +250,22 @@ Example:
 > loadtx /path/to/file`,
 		Action: handleLoadTx,
 	},
+	{
+		Name:      "loaddebug",
+		Usage:     "Load debug info for the loaded contract to enable source-level debugging",
+		UsageText: `loaddebug <file>`,
+		Description: `Load debug info produced by the compiler (see 'contract compile --debug')
+   for the contract loaded with 'loadnef' or 'loaddeployed' commands to enable
+   source-level debugging commands ('list', 'vars', 'sstep', 'snext' and
+   source breakpoints). 'loadgo' loads debug info automatically. Debug info
+   should match the loaded script.
+
+<file> is mandatory parameter.
+
+Example:
+> loaddebug /path/to/contract.debug.json`,
+		Action: handleLoadDebug,
+	},
 	{
 		Name:      "loaddeployed",
 		Usage:     "Load deployed contract into the VM from chain optionally attaching to it provided signers with scopes",
@@ -340,6 +360,44 @@ Example:
 > stepover`,
 		Action: handleStepOver,
 	},
+	{
+		Name:      "sstep",
+		Usage:     "Step to the next Go statement entering function calls",
+		UsageText: "sstep",
+		Description: `Execute instructions up to the start of the next Go statement (possibly
+in the function called) or breakpoint. Requires debug info to be loaded.
+
+Example:
+> sstep`,
+		Action: handleSourceStep,
+	},
+	{
+		Name:      "snext",
+		Usage:     "Step to the next Go statement stepping over function calls",
+		UsageText: "snext",
+		Description: `Execute instructions up to the start of the next Go statement in the
+current (or calling) function or breakpoint. Requires debug info to be loaded.
+
+Example:
+> snext`,
+		Action: handleSourceNext,
+	},
+	{
+		Name:        "list",
+		Usage:       "Show source code of the current Go statement",
+		UsageText:   "list",
+		Description: "Show source code of the current Go statement with some surrounding lines. Requires debug info to be loaded.",
+		Action:      handleList,
+	},
+	{
+		Name:      "vars",
+		Usage:     "Show values of Go variables of the current function",
+		UsageText: "vars",
+		Description: `Show values of the current function arguments and local variables as well
+as values of contract static variables decoded using types from the debug info.
+Requires debug info to be loaded.`,
+		Action: handleVars,
+	},
 	{
 		Name:        "ops",
 		Usage:       "Dump opcodes of the current loaded program",
@@ -523,6 +581,7 @@ func NewWithConfig(printLogotype bool, onExit func(int), c *readline.Config, cfg
 		exitFuncKey:         exitF,
 		readlineInstanceKey: l,
 		printLogoKey:        printLogotype,
+		debugInfoKey:        (*debugInfo)(nil),
 	}
 	changePrompt(vmcli.shell)
 	return &vmcli, nil
@@ -606,14 +665,16 @@ func handleBreak(c *cli.Context) error {
 	if !checkVMIsReady(c.App) {
 		return nil
 	}
-	n, err := getInstructionParameter(c)
+	bps, err := getBreakPointsParameter(c)
 	if err != nil {
 		return err
 	}
 
 	v := getVMFromContext(c.App)
-	v.AddBreakPoint(n)
-	fmt.Fprintf(c.App.Writer, "breakpoint added at instruction %d\n", n)
+	for _, n := range bps {
+		v.AddBreakPoint(n)
+		fmt.Fprintf(c.App.Writer, "breakpoint added at instruction %d\n", n)
+	}
 	return nil
 }
 
@@ -621,14 +682,16 @@ func handleRemoveBreak(c *cli.Context) error {
 	if !checkVMIsReady(c.App) {
 		return nil
 	}
-	n, err := getInstructionParameter(c)
+	bps, err := getBreakPointsParameter(c)
 	if err != nil {
 		return err
 	}
 
 	v := getVMFromContext(c.App)
-	v.RemoveBreakPoint(n)
-	fmt.Fprintf(c.App.Writer, "breakpoint removed at instruction %d\n", n)
+	for _, n := range bps {
+		v.RemoveBreakPoint(n)
+		fmt.Fprintf(c.App.Writer, "breakpoint removed at instruction %d\n", n)
+	}
 	return nil
 }
 
@@ -932,6 +995,7 @@ func handleLoadGo(c *cli.Context) error {
 		Manifest: *m,
 	}
 	setContractStateInContext(c.App, cs)
+	setDebugInfoInContext(c.App, newDebugInfo(di, ne.Script))
 
 	v := getVMFromContext(c.App)
 	fmt.Fprintf(c.App.Writer, "READY: loaded %d instructions\n", v.Context().LenInstr())
@@ -1085,9 +1149,10 @@ func resetInteropContext(app *cli.App, tx *transaction.Transaction, height ...ui
 	return nil
 }
 
-// resetContractState removes loaded contract state from app context.
+// resetContractState removes loaded contract state and debug info from app context.
 func resetContractState(app *cli.App) {
 	setContractStateInContext(app, nil)
+	setDebugInfoInContext(app, nil)
 }
 
 // resetState resets state of the app (clear interop context and manifest) so that it's ready
@@ -1198,6 +1263,9 @@ func runVMWithHandling(c *cli.Context) {
 		if ctx.NextIP() < ctx.LenInstr() {
 			i, op := ctx.NextInstr()
 			message = fmt.Sprintf("at breakpoint %d (%s)", i, op)
+			if loc := sourceLocation(c.App); loc != "" {
+				message += "\n" + loc
+			}
 		} else {
 			message = "execution has finished"
 		}
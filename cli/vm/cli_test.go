@@ -313,6 +313,8 @@ func TestRun_WithNewVMContextAndBreakpoints(t *testing.T) {
 		e.checkNextLine(t, "READY: loaded \\d* instructions")
 		e.checkNextLine(t, "breakpoint added at instruction 8")
 		e.checkNextLine(t, "at breakpoint 8 (PUSH5)*")
+		e.checkNextLine(t, "at .*vmtestcontract.go:3")
+		e.checkNextLineExact(t, "\tvar c = a + b\n")
 		e.checkStack(t, 13)
 	})
 	t.Run("contract with init", func(t *testing.T) {
@@ -337,6 +339,8 @@ func TestRun_WithNewVMContextAndBreakpoints(t *testing.T) {
 		e.checkNextLine(t, "READY: loaded \\d* instructions")
 		e.checkNextLine(t, "breakpoint added at instruction 10")
 		e.checkNextLine(t, "at breakpoint 10 (ADD)*")
+		e.checkNextLine(t, "at .*vmtestcontract.go:4")
+		e.checkNextLineExact(t, "\tvar c = a + b\n")
 		e.checkStack(t, 13)
 	})
 	t.Run("contract breakpoints", func(t *testing.T) {
@@ -987,6 +991,94 @@ func TestStepIntoOverOut(t *testing.T) {
 	e.checkStack(t, 5)
 }
 
+func TestSourceDebugging(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package kek
+func Main(a, b int) int {
+	c := a + b
+	d := double(c)
+	return d
+}
+func double(x int) int {
+	return x * 2
+}`
+	filename := prepareLoadgoSrc(t, tmpDir, src)
+	script := hex.EncodeToString([]byte{byte(opcode.PUSH1), byte(opcode.RET)})
+
+	e := newTestVMCLI(t)
+	e.runProgWithTimeout(t, 10*time.Second,
+		"sstep",
+		"loadhex "+script,
+		"sstep",
+		"break vmtestcontract.go:3",
+		"loadgo "+filename,
+		"break vmtestcontract.go:100",
+		"break vmtestcontract.go:3",
+		"run main 3 5",
+		"vars",
+		"snext",
+		"sstep",
+		"vars",
+		"list",
+		"snext",
+		"vars",
+		"run")
+
+	e.checkNextLine(t, "Error:.*no program loaded")
+	e.checkNextLine(t, "READY: loaded 2 instructions")
+	e.checkError(t, errNoDebugInfo)
+	e.checkError(t, errNoDebugInfo)
+	e.checkNextLine(t, "READY: loaded \\d+ instructions")
+	e.checkError(t, ErrInvalidParameter)
+	e.checkNextLine(t, "breakpoint added at instruction \\d+")
+	e.checkNextLine(t, "at breakpoint \\d+ \\(.*\\)")
+	e.checkNextLine(t, "at .*vmtestcontract.go:3")
+	e.checkNextLineExact(t, "\tc := a + b\n")
+
+	e.checkNextLine(t, "Method: .*Main")
+	e.checkNextLine(t, "Arguments:")
+	e.checkNextLineExact(t, "  a (Integer) = 3\n")
+	e.checkNextLineExact(t, "  b (Integer) = 5\n")
+	e.checkNextLine(t, "Locals:")
+	e.checkNextLineExact(t, "  c (Integer) = nil\n")
+	e.checkNextLineExact(t, "  d (Integer) = nil\n")
+
+	e.checkNextLine(t, "instruction pointer at \\d+")
+	e.checkNextLine(t, "at .*vmtestcontract.go:4")
+	e.checkNextLineExact(t, "\td := double(c)\n")
+
+	e.checkNextLine(t, "instruction pointer at \\d+")
+	e.checkNextLine(t, "at .*vmtestcontract.go:8")
+	e.checkNextLineExact(t, "\treturn x * 2\n")
+
+	e.checkNextLine(t, "Method: .*double")
+	e.checkNextLine(t, "Arguments:")
+	e.checkNextLineExact(t, "  x (Integer) = 8\n")
+
+	e.checkNextLine(t, ".*vmtestcontract.go:8")
+	for i := 5; i <= 9; i++ {
+		var mark = "  "
+		if i == 8 {
+			mark = "=>"
+		}
+		e.checkNextLineExact(t, fmt.Sprintf("%s %4d\t%s\n", mark, i, strings.Split(src, "\n")[i-1]))
+	}
+
+	e.checkNextLine(t, "instruction pointer at \\d+")
+	e.checkNextLine(t, "at .*vmtestcontract.go:5")
+	e.checkNextLineExact(t, "\treturn d\n")
+
+	e.checkNextLine(t, "Method: .*Main")
+	e.checkNextLine(t, "Arguments:")
+	e.checkNextLineExact(t, "  a (Integer) = 3\n")
+	e.checkNextLineExact(t, "  b (Integer) = 5\n")
+	e.checkNextLine(t, "Locals:")
+	e.checkNextLineExact(t, "  c (Integer) = 8\n")
+	e.checkNextLineExact(t, "  d (Integer) = 16\n")
+
+	e.checkStack(t, 16)
+}
+
 // `Parse` output is written via `tabwriter` so if any problems
 // are encountered in this test, try to replace ' ' with '\\s+'.
 func TestParse(t *testing.T) {
@@ -0,0 +1,418 @@
+package vm
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/urfave/cli/v2"
+)
+
+// sourceContextLines is the number of source code lines printed before and
+// after the current statement by 'list' command.
+const sourceContextLines = 3
+
+// errNoDebugInfo is returned by source-level commands if no debug info is loaded.
+var errNoDebugInfo = errors.New("no debug info loaded, use 'loadgo' or 'loaddebug' commands")
+
+// debugInfo is the compiler debug information for the loaded contract used for
+// source-level debugging.
+type debugInfo struct {
+	di *compiler.DebugInfo
+	// hash is the script hash the debug info corresponds to.
+	hash util.Uint160
+	// lastCtx and lastMatch cache the result of the last matches call.
+	lastCtx   *vm.Context
+	lastMatch bool
+	// sources contains source files lines for documents.
+	sources map[int][]string
+}
+
+func newDebugInfo(di *compiler.DebugInfo, script []byte) *debugInfo {
+	return &debugInfo{
+		di:      di,
+		hash:    hash.Hash160(script),
+		sources: make(map[int][]string),
+	}
+}
+
+func getDebugInfoFromContext(app *cli.App) *debugInfo {
+	return app.Metadata[debugInfoKey].(*debugInfo)
+}
+
+func setDebugInfoInContext(app *cli.App, di *debugInfo) {
+	app.Metadata[debugInfoKey] = di
+}
+
+// matches checks whether the debug info corresponds to the script executed in
+// the given context.
+func (d *debugInfo) matches(ctx *vm.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	if ctx != d.lastCtx {
+		d.lastCtx = ctx
+		d.lastMatch = hash.Hash160(ctx.Program()).Equals(d.hash)
+	}
+	return d.lastMatch
+}
+
+// method returns the method containing the given instruction.
+func (d *debugInfo) method(ip int) *compiler.MethodDebugInfo {
+	for i := range d.di.Methods {
+		m := &d.di.Methods[i]
+		if int(m.Range.Start) <= ip && ip <= int(m.Range.End) {
+			return m
+		}
+	}
+	return nil
+}
+
+// seqPoint returns the sequence point the given instruction belongs to.
+func (d *debugInfo) seqPoint(ip int) *compiler.DebugSeqPoint {
+	m := d.method(ip)
+	if m == nil {
+		return nil
+	}
+	var sp *compiler.DebugSeqPoint
+	for i := range m.SeqPoints {
+		if m.SeqPoints[i].Opcode <= ip && (sp == nil || m.SeqPoints[i].Opcode >= sp.Opcode) {
+			sp = &m.SeqPoints[i]
+		}
+	}
+	return sp
+}
+
+// statementAt returns the sequence point starting exactly at the given
+// instruction (if any).
+func (d *debugInfo) statementAt(ip int) *compiler.DebugSeqPoint {
+	sp := d.seqPoint(ip)
+	if sp == nil || sp.Opcode != ip {
+		return nil
+	}
+	return sp
+}
+
+// document returns the name of the document with the given index.
+func (d *debugInfo) document(i int) string {
+	if i < 0 || i >= len(d.di.Documents) {
+		return "<unknown>"
+	}
+	return d.di.Documents[i]
+}
+
+// sourceLines returns source file lines for the document with the given
+// index, nil is returned if the file can't be read.
+func (d *debugInfo) sourceLines(i int) []string {
+	lines, ok := d.sources[i]
+	if !ok {
+		data, err := os.ReadFile(d.document(i))
+		if err == nil {
+			lines = strings.Split(string(data), "\n")
+		}
+		d.sources[i] = lines
+	}
+	return lines
+}
+
+// breakpoints returns the list of instructions corresponding to the first
+// statement on the given line for every method.
+func (d *debugInfo) breakpoints(file string, line int) []int {
+	file = filepath.ToSlash(file)
+	var docs []int
+	for i, doc := range d.di.Documents {
+		doc = filepath.ToSlash(doc)
+		if doc == file || strings.HasSuffix(doc, "/"+file) {
+			docs = append(docs, i)
+		}
+	}
+	var res []int
+	for _, m := range d.di.Methods {
+		var off = -1
+		for _, sp := range m.SeqPoints {
+			if sp.StartLine == line && slices.Contains(docs, sp.Document) && (off == -1 || sp.Opcode < off) {
+				off = sp.Opcode
+			}
+		}
+		if off != -1 {
+			res = append(res, off)
+		}
+	}
+	slices.Sort(res)
+	return slices.Compact(res)
+}
+
+// sourceLocation returns a human-readable source location of the next
+// instruction in the current context, empty string is returned if there is no
+// debug info for it.
+func sourceLocation(app *cli.App) string {
+	d := getDebugInfoFromContext(app)
+	v := getVMFromContext(app)
+	if d == nil || !v.Ready() || !d.matches(v.Context()) {
+		return ""
+	}
+	sp := d.seqPoint(v.Context().NextIP())
+	if sp == nil {
+		return ""
+	}
+	res := fmt.Sprintf("at %s:%d", d.document(sp.Document), sp.StartLine)
+	if lines := d.sourceLines(sp.Document); sp.StartLine <= len(lines) {
+		res += "\n\t" + strings.TrimSpace(lines[sp.StartLine-1])
+	}
+	return res
+}
+
+// getBreakPointsParameter parses instruction pointer or file:line parameter.
+func getBreakPointsParameter(c *cli.Context) ([]int, error) {
+	args := c.Args().Slice()
+	if len(args) != 1 {
+		return nil, fmt.Errorf("%w: <ip> or <file>:<line>", ErrMissingParameter)
+	}
+	i := strings.LastIndexByte(args[0], ':')
+	if i == -1 {
+		n, err := getInstructionParameter(c)
+		if err != nil {
+			return nil, err
+		}
+		return []int{n}, nil
+	}
+	line, err := strconv.Atoi(args[0][i+1:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidParameter, err)
+	}
+	d := getDebugInfoFromContext(c.App)
+	if d == nil {
+		return nil, errNoDebugInfo
+	}
+	res := d.breakpoints(args[0][:i], line)
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w: no code at %s", ErrInvalidParameter, args[0])
+	}
+	return res, nil
+}
+
+func handleLoadDebug(c *cli.Context) error {
+	if !checkVMIsReady(c.App) {
+		return nil
+	}
+	args := c.Args().Slice()
+	if len(args) != 1 {
+		return fmt.Errorf("%w: <file>", ErrMissingParameter)
+	}
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	di := new(compiler.DebugInfo)
+	err = json.Unmarshal(data, di)
+	if err != nil {
+		return fmt.Errorf("failed to decode debug info: %w", err)
+	}
+	var script = getVMFromContext(c.App).Context().Program()
+	if cs := getContractStateFromContext(c.App); cs != nil {
+		script = cs.NEF.Script
+	}
+	d := newDebugInfo(di, script)
+	if !d.hash.Equals(di.Hash) {
+		return fmt.Errorf("%w: debug info is for %s script, while %s is loaded", ErrInvalidParameter, di.Hash.StringLE(), d.hash.StringLE())
+	}
+	setDebugInfoInContext(c.App, d)
+	fmt.Fprintf(c.App.Writer, "loaded debug info for %d methods\n", len(di.Methods))
+	return nil
+}
+
+func handleList(c *cli.Context) error {
+	if !checkVMIsReady(c.App) {
+		return nil
+	}
+	d := getDebugInfoFromContext(c.App)
+	if d == nil {
+		return errNoDebugInfo
+	}
+	ctx := getVMFromContext(c.App).Context()
+	if !d.matches(ctx) {
+		return errors.New("no debug info for the current context")
+	}
+	sp := d.seqPoint(ctx.NextIP())
+	if sp == nil {
+		return errors.New("no source code for the current instruction")
+	}
+	fmt.Fprintf(c.App.Writer, "%s:%d\n", d.document(sp.Document), sp.StartLine)
+	lines := d.sourceLines(sp.Document)
+	if lines == nil {
+		return fmt.Errorf("failed to read %s", d.document(sp.Document))
+	}
+	for i := max(1, sp.StartLine-sourceContextLines); i <= min(len(lines), sp.EndLine+sourceContextLines); i++ {
+		var mark = "  "
+		if sp.StartLine <= i && i <= sp.EndLine {
+			mark = "=>"
+		}
+		fmt.Fprintf(c.App.Writer, "%s %4d\t%s\n", mark, i, lines[i-1])
+	}
+	return nil
+}
+
+func handleVars(c *cli.Context) error {
+	if !checkVMIsReady(c.App) {
+		return nil
+	}
+	d := getDebugInfoFromContext(c.App)
+	if d == nil {
+		return errNoDebugInfo
+	}
+	ctx := getVMFromContext(c.App).Context()
+	if !d.matches(ctx) {
+		return errors.New("no debug info for the current context")
+	}
+	m := d.method(ctx.NextIP())
+	if m == nil {
+		return errors.New("no method for the current instruction")
+	}
+	fmt.Fprintf(c.App.Writer, "Method: %s\n", m.ID)
+	if len(m.Parameters) != 0 {
+		var (
+			args = ctx.ArgumentsSlot()
+			off  int
+		)
+		if args != nil && args.Size() > len(m.Parameters) {
+			// Receiver is passed as the first argument.
+			off = args.Size() - len(m.Parameters)
+		}
+		fmt.Fprintln(c.App.Writer, "Arguments:")
+		for i, p := range m.Parameters {
+			dumpVariable(c, args, i+off, p.Name, p.Type)
+		}
+	}
+	if len(m.Variables) != 0 {
+		fmt.Fprintln(c.App.Writer, "Locals:")
+		dumpVariables(c, ctx.LocalsSlot(), m.Variables, m.VariableSlots)
+	}
+	if len(d.di.StaticVariables) != 0 {
+		fmt.Fprintln(c.App.Writer, "Statics:")
+		dumpVariables(c, ctx.StaticsSlot(), d.di.StaticVariables, d.di.StaticVariableSlots)
+	}
+	return nil
+}
+
+// dumpVariables prints values of debug info variables ("name,type" strings).
+// Slot indexes are only known if debug info is generated by loadgo, so
+// declaration order is used otherwise (it's the same for simple functions).
+func dumpVariables(c *cli.Context, s *vm.Slot, vars []string, slots []int) {
+	for i, v := range vars {
+		name, typ, _ := strings.Cut(v, ",")
+		var index = i
+		if len(slots) == len(vars) {
+			index = slots[i]
+		}
+		dumpVariable(c, s, index, name, typ)
+	}
+}
+
+func dumpVariable(c *cli.Context, s *vm.Slot, index int, name string, typ string) {
+	var value = "<unknown>"
+	if s != nil && index >= 0 && index < s.Size() {
+		value = formatValue(s.Get(index), typ)
+	}
+	fmt.Fprintf(c.App.Writer, "  %s (%s) = %s\n", name, typ, value)
+}
+
+// formatValue converts stack item to a Go-like representation using the
+// type from the debug info.
+func formatValue(item stackitem.Item, typ string) string {
+	switch it := item.(type) {
+	case stackitem.Null:
+		return "nil"
+	case *stackitem.BigInteger:
+		return it.Big().String()
+	case stackitem.Bool:
+		return strconv.FormatBool(bool(it))
+	case *stackitem.ByteArray, *stackitem.Buffer:
+		b, _ := it.TryBytes()
+		switch {
+		case typ == "Hash160" && len(b) == util.Uint160Size:
+			u, _ := util.Uint160DecodeBytesBE(b)
+			return "0x" + u.StringLE()
+		case typ == "ByteString" && utf8.Valid(b):
+			return strconv.Quote(string(b))
+		default:
+			return "0x" + hex.EncodeToString(b)
+		}
+	default:
+		data, err := stackitem.ToJSONWithTypes(item)
+		if err != nil {
+			return fmt.Sprintf("<%s>", item.Type())
+		}
+		return string(data)
+	}
+}
+
+func handleSourceStep(c *cli.Context) error {
+	return sourceStep(c, false)
+}
+
+func handleSourceNext(c *cli.Context) error {
+	return sourceStep(c, true)
+}
+
+// sourceStep executes instructions up to the next Go statement start (possibly
+// in another function unless over is set) or a breakpoint.
+func sourceStep(c *cli.Context, over bool) error {
+	if !checkVMIsReady(c.App) {
+		return nil
+	}
+	d := getDebugInfoFromContext(c.App)
+	if d == nil {
+		return errNoDebugInfo
+	}
+	var (
+		v          = getVMFromContext(c.App)
+		startDepth = len(v.Istack())
+		startSP    *compiler.DebugSeqPoint
+	)
+	if d.matches(v.Context()) {
+		startSP = d.seqPoint(v.Context().NextIP())
+	}
+	for {
+		err := v.StepInto()
+		if err != nil {
+			return err
+		}
+		ctx := v.Context()
+		if v.HasStopped() || ctx == nil || ctx.NextIP() >= ctx.LenInstr() {
+			break
+		}
+		if slices.Contains(ctx.BreakPoints(), ctx.NextIP()) {
+			break
+		}
+		depth := len(v.Istack())
+		if (over && depth > startDepth) || !d.matches(ctx) {
+			continue
+		}
+		sp := d.statementAt(ctx.NextIP())
+		if sp != nil && (sp != startSP || depth != startDepth) {
+			break
+		}
+	}
+	if v.HasHalted() {
+		fmt.Fprintln(c.App.Writer, v.DumpEStack())
+	} else {
+		_ = handleIP(c)
+		if loc := sourceLocation(c.App); loc != "" {
+			fmt.Fprintln(c.App.Writer, loc)
+		}
+	}
+	changePrompt(c.App)
+	return nil
+}
@@ -38,6 +38,10 @@ func startVMPrompt(ctx *cli.Context) error {
 	if cfg.ApplicationConfiguration.DBConfiguration.Type != dbconfig.InMemoryDB {
 		cfg.ApplicationConfiguration.DBConfiguration.LevelDBOptions.ReadOnly = true
 		cfg.ApplicationConfiguration.DBConfiguration.BoltDBOptions.ReadOnly = true
+		for i := range cfg.ApplicationConfiguration.DBConfiguration.Partitions {
+			cfg.ApplicationConfiguration.DBConfiguration.Partitions[i].LevelDBOptions.ReadOnly = true
+			cfg.ApplicationConfiguration.DBConfiguration.Partitions[i].BoltDBOptions.ReadOnly = true
+		}
 	}
 
 	p, err := NewWithConfig(true, os.Exit, &readline.Config{}, cfg)
@@ -0,0 +1,227 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/urfave/cli/v2"
+)
+
+func newContactCommands() []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:      "add",
+			Usage:     "Add a named address to the wallet address book",
+			UsageText: "neo-go wallet contact add -w wallet [--wallet-config path] --name <name> --address <address>",
+			Description: `Adds a named external address to the wallet address book. Contact name
+   can be used instead of an address in the --to flag of transfer commands
+   as well as in multitransfer recipients. Names are arbitrary non-empty
+   strings (like 'alice' or 'friend:alice'), they must be unique within the
+   wallet.
+`,
+			Action: addContact,
+			Flags: []cli.Flag{
+				walletPathFlag,
+				walletConfigFlag,
+				&cli.StringFlag{
+					Name:     "name",
+					Aliases:  []string{"n"},
+					Required: true,
+					Usage:    "Contact name",
+					Action:   cmdargs.EnsureNotEmpty("name"),
+				},
+				&flags.AddressFlag{
+					Name:     "address",
+					Aliases:  []string{"a"},
+					Required: true,
+					Usage:    "Contact address or hash in LE form",
+				},
+			},
+		},
+		{
+			Name:      "remove",
+			Usage:     "Remove a named address from the wallet address book",
+			UsageText: "neo-go wallet contact remove -w wallet [--wallet-config path] --name <name>",
+			Action:    removeContact,
+			Flags: []cli.Flag{
+				walletPathFlag,
+				walletConfigFlag,
+				&cli.StringFlag{
+					Name:     "name",
+					Aliases:  []string{"n"},
+					Required: true,
+					Usage:    "Contact name",
+				},
+			},
+		},
+		{
+			Name:      "list",
+			Usage:     "List the wallet address book",
+			UsageText: "neo-go wallet contact list -w wallet [--wallet-config path]",
+			Action:    listContacts,
+			Flags: []cli.Flag{
+				walletPathFlag,
+				walletConfigFlag,
+			},
+		},
+	}
+}
+
+func addContact(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	wall, _, err := openWallet(ctx, true)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	defer wall.Close()
+
+	addr := ctx.Generic("address").(*flags.Address)
+	if err := wall.AddContact(wallet.NewContact(ctx.String("name"), addr.Uint160())); err != nil {
+		return cli.Exit(fmt.Errorf("can't add contact: %w", err), 1)
+	}
+	if err := wall.Save(); err != nil {
+		return cli.Exit(fmt.Errorf("error while saving wallet: %w", err), 1)
+	}
+	return nil
+}
+
+func removeContact(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	wall, _, err := openWallet(ctx, true)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	defer wall.Close()
+
+	if err := wall.RemoveContact(ctx.String("name")); err != nil {
+		return cli.Exit(fmt.Errorf("can't remove contact: %w", err), 1)
+	}
+	if err := wall.Save(); err != nil {
+		return cli.Exit(fmt.Errorf("error while saving wallet: %w", err), 1)
+	}
+	return nil
+}
+
+func listContacts(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	wall, _, err := readWallet(ctx)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	defer wall.Close()
+
+	for _, c := range wall.Extra.Contacts {
+		fmt.Fprintf(ctx.App.Writer, "%s\t%s\n", c.Name, c.Address())
+	}
+	return nil
+}
+
+func setAccountLabel(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	wall, _, err := openWallet(ctx, true)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	defer wall.Close()
+
+	addr := ctx.Generic("address").(*flags.Address)
+	acc := wall.GetAccount(addr.Uint160())
+	if acc == nil {
+		return cli.Exit("account wasn't found", 1)
+	}
+	acc.Label = ctx.String("name")
+	if err := wall.Save(); err != nil {
+		return cli.Exit(fmt.Errorf("error while saving wallet: %w", err), 1)
+	}
+	return nil
+}
+
+func setAccountTags(ctx *cli.Context) error {
+	wall, _, err := openWallet(ctx, true)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	defer wall.Close()
+
+	addr := ctx.Generic("address").(*flags.Address)
+	acc := wall.GetAccount(addr.Uint160())
+	if acc == nil {
+		return cli.Exit("account wasn't found", 1)
+	}
+	tags := ctx.Args().Slice()
+	for _, tag := range tags {
+		if tag == "" {
+			return cli.Exit("empty tag", 1)
+		}
+	}
+	if err := wall.SetAccountTags(acc.Address, tags); err != nil {
+		return cli.Exit(err, 1)
+	}
+	if err := wall.Save(); err != nil {
+		return cli.Exit(fmt.Errorf("error while saving wallet: %w", err), 1)
+	}
+	return nil
+}
+
+func listAccountTags(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	wall, _, err := readWallet(ctx)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	defer wall.Close()
+
+	accs := wall.Accounts
+	if tag := ctx.String("tag"); tag != "" {
+		accs = wall.GetAccountsByTag(tag)
+	}
+	for _, acc := range accs {
+		fmt.Fprintf(ctx.App.Writer, "%s\t%s\t%s\n", acc.Address, acc.Label,
+			strings.Join(wall.GetAccountTags(acc.Address), ","))
+	}
+	return nil
+}
+
+// resolveAddress parses the given string as an address (or LE script hash),
+// a name from the wallet address book or a label of the wallet account (in
+// this order).
+func resolveAddress(wall *wallet.Wallet, s string) (util.Uint160, error) {
+	if s == "" {
+		return util.Uint160{}, errors.New("empty address")
+	}
+	if h, err := flags.ParseAddress(s); err == nil {
+		return h, nil
+	}
+	if c := wall.GetContact(s); c != nil {
+		return c.Hash, nil
+	}
+	var found *wallet.Account
+	for _, acc := range wall.Accounts {
+		if acc.Label != s {
+			continue
+		}
+		if found != nil {
+			return util.Uint160{}, fmt.Errorf("'%s' matches multiple wallet accounts, use address instead", s)
+		}
+		found = acc
+	}
+	if found == nil {
+		return util.Uint160{}, fmt.Errorf("'%s' is neither a valid address nor a known contact or account label", s)
+	}
+	return found.ScriptHash(), nil
+}
@@ -3,6 +3,7 @@ package wallet
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/cli/cmdargs"
 	"github.com/nspcc-dev/neo-go/cli/flags"
@@ -12,6 +13,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/waiter"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
 	"github.com/urfave/cli/v2"
 )
 
@@ -71,32 +73,167 @@ func signStoredTransaction(ctx *cli.Context) error {
 		}
 	}
 	if rpcNode != "" {
-		tx, err = pc.GetCompleteTransaction()
+		tx, aer, err = sendContext(ctx, pc)
 		if err != nil {
-			return cli.Exit(fmt.Errorf("failed to complete transaction: %w", err), 1)
+			return cli.Exit(err, 1)
 		}
+	}
 
-		gctx, cancel := options.GetTimeoutContext(ctx)
-		defer cancel()
+	txctx.DumpTransactionInfo(ctx.App.Writer, tx.Hash(), aer)
+	return nil
+}
 
-		var err error // `GetRPCClient` returns specialized type.
-		c, err := options.GetRPCClient(gctx, ctx)
+// sendContext completes the transaction from the given context and sends it
+// via RPC, awaiting its execution if requested.
+func sendContext(ctx *cli.Context, pc *context.ParameterContext) (*transaction.Transaction, *state.AppExecResult, error) {
+	var aer *state.AppExecResult
+
+	tx, err := pc.GetCompleteTransaction()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to complete transaction: %w", err)
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, err := options.GetRPCClient(gctx, ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC client: %w", err)
+	}
+	res, err := c.SendRawTransaction(tx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to submit transaction to RPC node: %w", err)
+	}
+	if ctx.Bool("await") {
+		version, err := c.GetVersion()
+		aer, err = waiter.New(c, version).Wait(res, tx.ValidUntilBlock, err)
 		if err != nil {
-			return cli.Exit(fmt.Errorf("failed to create RPC client: %w", err), 1)
+			return nil, nil, fmt.Errorf("failed to await transaction %s: %w", res.StringLE(), err)
 		}
-		res, err := c.SendRawTransaction(tx)
+	}
+	return tx, aer, nil
+}
+
+func publishStoredTransaction(ctx *cli.Context) error {
+	var (
+		location = ctx.String("location")
+		addrFlag = ctx.Generic("address").(*flags.Address)
+		id       = "context"
+	)
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+
+	pc, err := paramcontext.Read(ctx.String("in"))
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	tx, ok := pc.Verifiable.(*transaction.Transaction)
+	if !ok {
+		return cli.Exit("verifiable item is not a transaction", 1)
+	}
+
+	if addrFlag.IsSet {
+		acc, _, err := options.GetAccFromContext(ctx)
 		if err != nil {
-			return cli.Exit(fmt.Errorf("failed to submit transaction to RPC node: %w", err), 1)
+			return cli.Exit(err, 1)
 		}
-		if ctx.Bool("await") {
-			version, err := c.GetVersion()
-			aer, err = waiter.New(c, version).Wait(res, tx.ValidUntilBlock, err)
-			if err != nil {
-				return cli.Exit(fmt.Errorf("failed to await transaction %s: %w", res.StringLE(), err), 1)
+		if !tx.HasSigner(acc.ScriptHash()) {
+			return cli.Exit("tx signers don't contain provided account", 1)
+		}
+		if !acc.CanSign() {
+			return cli.Exit("can't sign transactions with the given account", 1)
+		}
+		pub := acc.PublicKey()
+		sign := acc.SignHashable(pc.Network, pc.Verifiable)
+		if err := pc.AddSignature(acc.ScriptHash(), acc.Contract, pub, sign); err != nil {
+			return cli.Exit(fmt.Errorf("can't add signature: %w", err), 1)
+		}
+		id = pub.StringCompressed()
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+	if err := paramcontext.Publish(gctx, pc, location, ctx.String("fs-rpc-endpoint"), id); err != nil {
+		return cli.Exit(err, 1)
+	}
+	fmt.Fprintln(ctx.App.Writer, tx.Hash().StringLE())
+	return nil
+}
+
+func collectStoredTransaction(ctx *cli.Context) error {
+	var (
+		out      = ctx.String("out")
+		rpcNode  = ctx.String(options.RPCEndpointFlag)
+		location = ctx.String("location")
+		wait     = ctx.Duration("wait")
+		interval = ctx.Duration("interval")
+		aer      *state.AppExecResult
+	)
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	if interval <= 0 {
+		return cli.Exit("poll interval must be positive", 1)
+	}
+
+	pc, err := paramcontext.Read(ctx.String("in"))
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	if _, ok := pc.Verifiable.(*transaction.Transaction); !ok {
+		return cli.Exit("verifiable item is not a transaction", 1)
+	}
+
+	var (
+		deadline = time.Now().Add(wait)
+		tx       *transaction.Transaction
+	)
+	for {
+		gctx, cancel := options.GetTimeoutContext(ctx)
+		n, err := paramcontext.Collect(gctx, pc, location, ctx.String("fs-rpc-endpoint"))
+		cancel()
+		if err != nil {
+			return cli.Exit(fmt.Errorf("can't collect signatures: %w", err), 1)
+		}
+		if n > 0 {
+			fmt.Fprintf(ctx.App.ErrWriter, "Collected %d new signature(s)\n", n)
+		}
+		tx, err = pc.GetCompleteTransaction()
+		if err == nil {
+			break
+		}
+		if !time.Now().Add(interval).Before(deadline) {
+			if out != "" {
+				if err := paramcontext.Save(pc, out); err != nil {
+					return cli.Exit(fmt.Errorf("can't save resulting context: %w", err), 1)
+				}
 			}
+			return cli.Exit(fmt.Errorf("not enough signatures: %w", err), 1)
 		}
+		time.Sleep(interval)
 	}
 
+	// Not saving and not sending, print.
+	if out == "" && rpcNode == "" {
+		txt, err := json.MarshalIndent(pc, " ", "     ")
+		if err != nil {
+			return cli.Exit(fmt.Errorf("can't display resulting context: %w", err), 1)
+		}
+		fmt.Fprintln(ctx.App.Writer, string(txt))
+		return nil
+	}
+	if out != "" {
+		if err := paramcontext.Save(pc, out); err != nil {
+			return cli.Exit(fmt.Errorf("can't save resulting context: %w", err), 1)
+		}
+	}
+	if rpcNode != "" {
+		tx, aer, err = sendContext(ctx, pc)
+		if err != nil {
+			return cli.Exit(err, 1)
+		}
+	}
 	txctx.DumpTransactionInfo(ctx.App.Writer, tx.Hash(), aer)
 	return nil
 }
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/testcli"
@@ -163,6 +164,45 @@ func TestSignMultisigTx(t *testing.T) {
 		require.Equal(t, vmstate.Halt.String(), res.State, res.FaultException)
 	})
 
+	t.Run("publish and collect", func(t *testing.T) {
+		shared := t.TempDir()
+		outPath := filepath.Join(t.TempDir(), "collected.json")
+
+		e.RunWithErrorCheck(t, `Required flag "location" not set`, "neo-go", "wallet", "multisig", "publish",
+			"--in", txPath)
+		e.RunWithErrorCheckExit(t, "NeoFS endpoint is required for NeoFS location", "neo-go", "wallet", "multisig", "publish",
+			"--in", txPath, "--location", "neofs:"+strings.Repeat("1", 44))
+		e.RunWithErrorCheckExit(t, "container ID is invalid", "neo-go", "wallet", "multisig", "collect",
+			"--in", txPath, "--location", "neofs:bad", "--fs-rpc-endpoint", "localhost:8080")
+
+		// Initial context with the first signature only.
+		e.Run(t, "neo-go", "wallet", "multisig", "publish",
+			"--in", txPath, "--location", shared)
+		e.Out.Reset()
+
+		// Not enough signatures yet, but the context is saved.
+		e.RunWithError(t, "neo-go", "wallet", "multisig", "collect",
+			"--in", txPath, "--location", shared, "--out", outPath)
+		_, err := os.Stat(outPath)
+		require.NoError(t, err)
+
+		e.In.WriteString("pass\r")
+		e.Run(t, "neo-go", "wallet", "multisig", "publish",
+			"--wallet", wallet2Path, "--address", multisigAddr,
+			"--in", txPath, "--location", shared)
+		e.Out.Reset()
+
+		e.Run(t, "neo-go", "wallet", "multisig", "collect",
+			"--in", txPath, "--location", shared, "--out", outPath)
+		e.Out.Reset()
+		pc := new(context.ParameterContext)
+		data, err := os.ReadFile(outPath)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(data, pc))
+		_, err = pc.GetCompleteTransaction()
+		require.NoError(t, err)
+	})
+
 	t.Run("console output", func(t *testing.T) {
 		oldIn, err := os.ReadFile(txPath)
 		require.NoError(t, err)
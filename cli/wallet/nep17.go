@@ -577,10 +577,13 @@ func multiTransferNEP17(ctx *cli.Context) error {
 	cache := make(map[string]*wallet.Token)
 	for i := range cosignersSepPos {
 		arg := ctx.Args().Get(i)
-		ss := strings.SplitN(arg, ":", 3)
-		if len(ss) != 3 {
+		// Address book contact names can contain ':', so the address is
+		// everything between the token and the amount.
+		first, last := strings.Index(arg, ":"), strings.LastIndex(arg, ":")
+		if first < 0 || first == last {
 			return cli.Exit("send format must be '<token>:<addr>:<amount>", 1)
 		}
+		ss := []string{arg[:first], arg[first+1 : last], arg[last+1:]}
 		token, ok := cache[ss[0]]
 		if !ok {
 			token, err = getMatchingToken(ctx, wall, ss[0], manifest.NEP17StandardName)
@@ -592,9 +595,9 @@ func multiTransferNEP17(ctx *cli.Context) error {
 			}
 		}
 		cache[ss[0]] = token
-		addr, err := address.StringToUint160(ss[1])
+		addr, err := resolveAddress(wall, ss[1])
 		if err != nil {
-			return cli.Exit(fmt.Errorf("invalid address: '%s'", ss[1]), 1)
+			return cli.Exit(fmt.Errorf("invalid address: %w", err), 1)
 		}
 		amount, err := fixedn.FromString(ss[2], int(token.Decimals))
 		if err != nil {
@@ -633,6 +636,10 @@ func transferNEP(ctx *cli.Context, standard string) error {
 	if err != nil {
 		return cli.Exit(err, 1)
 	}
+	to, err := resolveAddress(wall, ctx.String("to"))
+	if err != nil {
+		return cli.Exit(fmt.Errorf("invalid recipient: %w", err), 1)
+	}
 	acc, err := options.GetUnlockedAccount(wall, from, pass)
 	if err != nil {
 		return cli.Exit(err, 1)
@@ -659,8 +666,6 @@ func transferNEP(ctx *cli.Context, standard string) error {
 		return exitErr
 	}
 
-	toFlag := ctx.Generic("to").(*flags.Address)
-	to := toFlag.Uint160()
 	token, err := getMatchingToken(ctx, wall, ctx.String("token"), standard)
 	if err != nil {
 		token, err = getMatchingTokenRPC(ctx, c, from, ctx.String("token"), standard)
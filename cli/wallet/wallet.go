@@ -9,7 +9,9 @@ import (
 	"math/big"
 	"os"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/cli/cmdargs"
 	"github.com/nspcc-dev/neo-go/cli/flags"
@@ -25,6 +27,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/hd"
 	"github.com/urfave/cli/v2"
 )
 
@@ -76,9 +79,9 @@ var (
 		Name:  "from",
 		Usage: "Address to send an asset from",
 	}
-	toAddrFlag = &flags.AddressFlag{
+	toAddrFlag = &cli.StringFlag{
 		Name:     "to",
-		Usage:    "Address to send an asset to",
+		Usage:    "Address (or address book contact name, or wallet account label) to send an asset to",
 		Required: true,
 	}
 )
@@ -119,6 +122,49 @@ func NewCommands() []*cli.Command {
 	rpcFlag.Required = false
 	signFlags = append(signFlags, &rpcFlag)
 	signFlags = append(signFlags, options.RPC[1:]...)
+	locationFlag := &cli.StringFlag{
+		Name:     "location",
+		Aliases:  []string{"l"},
+		Required: true,
+		Usage:    "Shared location (directory, HTTP(S) URL or neofs:<container> URL) for contexts",
+		Action:   cmdargs.EnsureNotEmpty("location"),
+	}
+	fsEndpointFlag := &cli.StringFlag{
+		Name:    "fs-rpc-endpoint",
+		Aliases: []string{"fsr"},
+		Usage:   "NeoFS storage node RPC address used for neofs:<container> location",
+	}
+	publishFlags := []cli.Flag{
+		walletPathFlag,
+		walletConfigFlag,
+		inFlag,
+		locationFlag,
+		&flags.AddressFlag{
+			Name:    "address",
+			Aliases: []string{"a"},
+			Usage:   "Address to sign with before publishing",
+		},
+		fsEndpointFlag,
+		options.RPC[1],
+	}
+	collectFlags := []cli.Flag{
+		txctx.OutFlag,
+		txctx.AwaitFlag,
+		inFlag,
+		locationFlag,
+		&cli.DurationFlag{
+			Name:  "wait",
+			Usage: "Time to wait for co-signers, single pass if zero",
+		},
+		&cli.DurationFlag{
+			Name:  "interval",
+			Value: 5 * time.Second,
+			Usage: "Interval between location polls",
+		},
+		fsEndpointFlag,
+		&rpcFlag,
+	}
+	collectFlags = append(collectFlags, options.RPC[1:]...)
 	return []*cli.Command{{
 		Name:  "wallet",
 		Usage: "Create, open and manage a Neo wallet",
@@ -133,8 +179,15 @@ func NewCommands() []*cli.Command {
 			{
 				Name:      "init",
 				Usage:     "Create a new wallet",
-				UsageText: "neo-go wallet init -w wallet [--wallet-config path] [-a]",
-				Action:    createWallet,
+				UsageText: "neo-go wallet init -w wallet [--wallet-config path] [-a] [--hd [--restore]]",
+				Description: `Creates a new wallet. If --hd flag is given, the wallet is set up for
+   hierarchical deterministic account derivation from a BIP-39 mnemonic
+   (along m/44'/888'/0'/0/i path), a new mnemonic is generated and printed
+   (back it up, it's the only way to recover accounts), or, with --restore,
+   an existing one is requested. The first (#0) HD account is derived then,
+   other ones can be derived with 'wallet account derive' command.
+`,
+				Action: createWallet,
 				Flags: []cli.Flag{
 					walletPathFlag,
 					walletConfigFlag,
@@ -143,8 +196,97 @@ func NewCommands() []*cli.Command {
 						Aliases: []string{"a"},
 						Usage:   "Create a new account",
 					},
+					&cli.BoolFlag{
+						Name:  "hd",
+						Usage: "Create HD wallet from a BIP-39 mnemonic",
+					},
+					&cli.BoolFlag{
+						Name:  "restore",
+						Usage: "Use an existing mnemonic for HD wallet instead of generating a new one",
+					},
 				},
 			},
+			{
+				Name:  "account",
+				Usage: "Account labels, tags and HD account management",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "label",
+						Usage:     "Set the label of the wallet account",
+						UsageText: "neo-go wallet account label -w wallet [--wallet-config path] --address <address> [--name name]",
+						Action:    setAccountLabel,
+						Flags: []cli.Flag{
+							walletPathFlag,
+							walletConfigFlag,
+							&flags.AddressFlag{
+								Name:     "address",
+								Aliases:  []string{"a"},
+								Required: true,
+								Usage:    "Account address or hash in LE form",
+							},
+							&cli.StringFlag{
+								Name:    "name",
+								Aliases: []string{"n"},
+								Usage:   "New account label (empty to remove it)",
+							},
+						},
+					},
+					{
+						Name:      "tag",
+						Usage:     "Set tags of the wallet account",
+						UsageText: "neo-go wallet account tag -w wallet [--wallet-config path] --address <address> [<tag>...]",
+						Description: `Replaces the list of tags of the wallet account with the given one,
+   all tags are removed if no tags are given. Tags are stored in the wallet
+   extra section and can be used to group accounts, see 'wallet account tags'.
+`,
+						Action: setAccountTags,
+						Flags: []cli.Flag{
+							walletPathFlag,
+							walletConfigFlag,
+							&flags.AddressFlag{
+								Name:     "address",
+								Aliases:  []string{"a"},
+								Required: true,
+								Usage:    "Account address or hash in LE form",
+							},
+						},
+					},
+					{
+						Name:      "tags",
+						Usage:     "List wallet accounts with their labels and tags",
+						UsageText: "neo-go wallet account tags -w wallet [--wallet-config path] [--tag tag]",
+						Action:    listAccountTags,
+						Flags: []cli.Flag{
+							walletPathFlag,
+							walletConfigFlag,
+							&cli.StringFlag{
+								Name:  "tag",
+								Usage: "Only list accounts with the given tag",
+							},
+						},
+					},
+					{
+						Name:      "derive",
+						Usage:     "Derive an HD account with the given index and add it to the wallet",
+						UsageText: "neo-go wallet account derive -w wallet [--wallet-config path] [--name name] <index>",
+						Action:    deriveAccount,
+						Flags: []cli.Flag{
+							walletPathFlag,
+							walletConfigFlag,
+							&cli.StringFlag{
+								Name:    "name",
+								Aliases: []string{"n"},
+								Usage:   "Optional account name",
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:        "contact",
+				Usage:       "Wallet address book management",
+				Subcommands: newContactCommands(),
+			},
 			{
 				Name:      "change-password",
 				Usage:     "Change password for accounts",
@@ -338,6 +480,48 @@ func NewCommands() []*cli.Command {
 				Action: signStoredTransaction,
 				Flags:  signFlags,
 			},
+			{
+				Name:  "multisig",
+				Usage: "Coordinate multisignature collection via a shared location",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "publish",
+						Usage:     "Publish (optionally cosigned) context to a shared location",
+						UsageText: "publish [-w wallet] [--wallet-config path] [--address <address>] --in <file.in> --location <dir|url|neofs:container> [--fs-rpc-endpoint node] [-s timeout]",
+						Description: `Publishes the given (in file.in) transaction signing context to the
+   shared location so that other co-signers can fetch it. If an address is given,
+   the context is signed with the corresponding wallet account first. Location
+   can be either a directory (local or mounted shared one), an HTTP(S) URL or a
+   NeoFS container (neofs:<container> URL, --fs-rpc-endpoint is required). In
+   the first case the context is stored in a separate file named after the
+   transaction hash and signer's public key, in the second one it's POSTed to
+   the URL as JSON, in the third one it's stored as an object with the
+   transaction hash and signer's public key attributes (so the container must
+   be writable for everyone). Transaction hash is printed on success.
+`,
+						Action: publishStoredTransaction,
+						Flags:  publishFlags,
+					},
+					{
+						Name:      "collect",
+						Usage:     "Collect signatures from a shared location and complete transaction",
+						UsageText: "collect --in <file.in> --location <dir|url|neofs:container> [--fs-rpc-endpoint node] [--wait <duration>] [--interval <duration>] [--out <file.out>] [-r <endpoint>] [--await]",
+						Description: `Fetches all contexts published at the shared location for the
+   transaction from file.in, checks and merges their signatures. If --wait is
+   given, the location is polled (every --interval) until enough signatures are
+   collected to complete the transaction or until the wait time expires.
+   HTTP(S) location is expected to return either a single context or a JSON
+   array of contexts on GET, NeoFS container is searched for objects with the
+   transaction hash attribute. Resulting context is saved to file.out (if given,
+   even when it's still incomplete) or printed to the console (if neither
+   file.out nor RPC endpoint is given). If an RPC endpoint is given the complete
+   transaction is sent to the network (and awaited with --await).
+`,
+						Action: collectStoredTransaction,
+						Flags:  collectFlags,
+					},
+				},
+			},
 			{
 				Name:      "strip-keys",
 				Usage:     "Remove private keys for all accounts",
@@ -894,6 +1078,9 @@ func createWallet(ctx *cli.Context) error {
 		path = cfg.Path
 		pass = &cfg.Password
 	}
+	if ctx.Bool("restore") && !ctx.Bool("hd") {
+		return cli.Exit("--restore flag can only be used with --hd", 1)
+	}
 	wall, err := wallet.NewWallet(path)
 	if err != nil {
 		return cli.Exit(err, 1)
@@ -902,7 +1089,12 @@ func createWallet(ctx *cli.Context) error {
 		return cli.Exit(err, 1)
 	}
 
-	if ctx.Bool("account") {
+	if ctx.Bool("hd") {
+		if err := initHDWallet(ctx, wall, pass); err != nil {
+			return cli.Exit(err, 1)
+		}
+		defer wall.Close()
+	} else if ctx.Bool("account") {
 		if err := createAccount(wall, pass); err != nil {
 			return cli.Exit(err, 1)
 		}
@@ -914,6 +1106,83 @@ func createWallet(ctx *cli.Context) error {
 	return nil
 }
 
+// initHDWallet sets up HD key for the wallet from a new or existing (if
+// --restore flag is given) mnemonic and adds the first HD account to it.
+func initHDWallet(ctx *cli.Context, wall *wallet.Wallet, pass *string) error {
+	var (
+		mnemonic string
+		err      error
+	)
+	if ctx.Bool("restore") {
+		mnemonic, err = input.ReadPassword("Enter mnemonic > ")
+		if err != nil {
+			return fmt.Errorf("Error reading mnemonic: %w", err)
+		}
+	} else {
+		mnemonic, err = hd.NewMnemonic(hd.DefaultEntropyBits)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(ctx.App.Writer, "Mnemonic (write it down and keep in a safe place, it's the only way to restore accounts):\n%s\n", mnemonic)
+	}
+	var phrase string
+	if pass == nil {
+		phrase, err = readNewPassword()
+		if err != nil {
+			return err
+		}
+	} else {
+		phrase = *pass
+	}
+	if err := wall.InitHD(mnemonic, phrase); err != nil {
+		return err
+	}
+	acc, err := wall.DeriveAccount(0, phrase)
+	if err != nil {
+		return err
+	}
+	return addAccountAndSave(wall, acc)
+}
+
+func deriveAccount(ctx *cli.Context) error {
+	args := ctx.Args().Slice()
+	if len(args) != 1 {
+		return cli.Exit("exactly one account index is required", 1)
+	}
+	index, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("invalid account index: %w", err), 1)
+	}
+	wall, pass, err := openWallet(ctx, true)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	defer wall.Close()
+	if wall.Extra.HD == nil {
+		return cli.Exit("wallet has no HD key, it can be created with 'wallet init --hd'", 1)
+	}
+
+	var phrase string
+	if pass == nil {
+		phrase, err = input.ReadPassword(EnterPasswordPrompt)
+		if err != nil {
+			return cli.Exit(fmt.Errorf("Error reading password: %w", err), 1)
+		}
+	} else {
+		phrase = *pass
+	}
+	acc, err := wall.DeriveAccount(uint32(index), phrase)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	acc.Label = ctx.String("name")
+	if err := addAccountAndSave(wall, acc); err != nil {
+		return cli.Exit(err, 1)
+	}
+	fmt.Fprintln(ctx.App.Writer, acc.Address)
+	return nil
+}
+
 func readAccountInfo() (string, string, error) {
 	name, err := readAccountName()
 	if err != nil {
@@ -69,6 +69,87 @@ func TestWalletAccountRemove(t *testing.T) {
 	require.Equal(t, w.Accounts[1], actual.Accounts[0])
 }
 
+func TestWalletContacts(t *testing.T) {
+	tmpDir := t.TempDir()
+	e := testcli.NewExecutor(t, false)
+
+	walletPath := filepath.Join(tmpDir, "wallet.json")
+	e.Run(t, "neo-go", "wallet", "init", "--wallet", walletPath)
+
+	addArgs := []string{"neo-go", "wallet", "contact", "add", "--wallet", walletPath}
+	t.Run("missing name", func(t *testing.T) {
+		e.RunWithErrorCheck(t, `Required flag "name" not set`, append(addArgs, "--address", testcli.ValidatorAddr)...)
+	})
+	t.Run("invalid address", func(t *testing.T) {
+		e.RunWithErrorCheck(t, "invalid value", append(addArgs, "--name", "alice", "--address", "NotAnAddress")...)
+	})
+
+	e.Run(t, append(addArgs, "--name", "friend:alice", "--address", testcli.ValidatorAddr)...)
+	e.Run(t, append(addArgs, "--name", "bob", "--address", testcli.TestWalletMultiAccount1)...)
+	t.Run("duplicate", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "already exists", append(addArgs, "--name", "bob", "--address", testcli.ValidatorAddr)...)
+	})
+
+	e.Run(t, "neo-go", "wallet", "contact", "list", "--wallet", walletPath)
+	e.CheckNextLine(t, "^friend:alice\t"+testcli.ValidatorAddr+"$")
+	e.CheckNextLine(t, "^bob\t"+testcli.TestWalletMultiAccount1+"$")
+	e.CheckEOF(t)
+
+	t.Run("remove unknown", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "contact wasn't found", "neo-go", "wallet", "contact", "remove", "--wallet", walletPath, "--name", "carol")
+	})
+	e.Run(t, "neo-go", "wallet", "contact", "remove", "--wallet", walletPath, "--name", "bob")
+
+	w, err := wallet.NewWalletFromFile(walletPath)
+	require.NoError(t, err)
+	require.Equal(t, []*wallet.Contact{wallet.NewContact("friend:alice", testcli.ValidatorHash)}, w.Extra.Contacts)
+}
+
+func TestWalletAccountLabelsAndTags(t *testing.T) {
+	tmpDir := t.TempDir()
+	e := testcli.NewExecutor(t, false)
+
+	walletPath := filepath.Join(tmpDir, "wallet.json")
+	e.In.WriteString("acc1\r")
+	e.In.WriteString("pass\r")
+	e.In.WriteString("pass\r")
+	e.Run(t, "neo-go", "wallet", "init", "--wallet", walletPath, "--account")
+	e.In.WriteString("acc2\r")
+	e.In.WriteString("pass\r")
+	e.In.WriteString("pass\r")
+	e.Run(t, "neo-go", "wallet", "create", "--wallet", walletPath)
+
+	w, err := wallet.NewWalletFromFile(walletPath)
+	require.NoError(t, err)
+	addr1, addr2 := w.Accounts[0].Address, w.Accounts[1].Address
+
+	t.Run("unknown account", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "account wasn't found", "neo-go", "wallet", "account", "label",
+			"--wallet", walletPath, "--address", testcli.ValidatorAddr, "--name", "cold")
+		e.RunWithErrorCheckExit(t, "account wasn't found", "neo-go", "wallet", "account", "tag",
+			"--wallet", walletPath, "--address", testcli.ValidatorAddr, "cold")
+	})
+	e.Run(t, "neo-go", "wallet", "account", "label", "--wallet", walletPath, "--address", addr1, "--name", "main")
+	e.Run(t, "neo-go", "wallet", "account", "tag", "--wallet", walletPath, "--address", addr1, "cold", "savings")
+	e.Run(t, "neo-go", "wallet", "account", "tag", "--wallet", walletPath, "--address", addr2, "savings")
+
+	e.Run(t, "neo-go", "wallet", "account", "tags", "--wallet", walletPath)
+	e.CheckNextLine(t, "^"+addr1+"\tmain\tcold,savings$")
+	e.CheckNextLine(t, "^"+addr2+"\tacc2\tsavings$")
+	e.CheckEOF(t)
+
+	e.Run(t, "neo-go", "wallet", "account", "tags", "--wallet", walletPath, "--tag", "cold")
+	e.CheckNextLine(t, "^"+addr1+"\tmain\tcold,savings$")
+	e.CheckEOF(t)
+
+	// No tags remove all of them.
+	e.Run(t, "neo-go", "wallet", "account", "tag", "--wallet", walletPath, "--address", addr1)
+	w, err = wallet.NewWalletFromFile(walletPath)
+	require.NoError(t, err)
+	require.Equal(t, "main", w.Accounts[0].Label)
+	require.Equal(t, map[string][]string{addr2: {"savings"}}, w.Extra.Tags)
+}
+
 func TestWalletChangePassword(t *testing.T) {
 	tmpDir := t.TempDir()
 	e := testcli.NewExecutor(t, false)
@@ -542,6 +623,92 @@ func TestWalletInit(t *testing.T) {
 	})
 }
 
+func TestWalletInitHD(t *testing.T) {
+	e := testcli.NewExecutor(t, false)
+	tmp := t.TempDir()
+
+	t.Run("restore without hd", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "--restore flag can only be used with --hd", "neo-go", "wallet", "init", "--wallet", filepath.Join(tmp, "bad.json"), "--restore")
+	})
+
+	walletPath := filepath.Join(tmp, "wallet.json")
+	e.In.WriteString("pass\r")
+	e.In.WriteString("pass\r")
+	e.Run(t, "neo-go", "wallet", "init", "--wallet", walletPath, "--hd")
+	e.CheckNextLine(t, "^Mnemonic")
+	mnemonic := e.GetNextLine(t)
+	require.Len(t, strings.Fields(mnemonic), 24)
+
+	w, err := wallet.NewWalletFromFile(walletPath)
+	require.NoError(t, err)
+	require.NotNil(t, w.Extra.HD)
+	require.Equal(t, 1, len(w.Accounts))
+	acc0 := w.Accounts[0].Address
+
+	deriveArgs := []string{"neo-go", "wallet", "account", "derive", "--wallet", walletPath}
+	t.Run("no index", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "exactly one account index is required", deriveArgs...)
+	})
+	t.Run("invalid index", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "invalid account index", append(deriveArgs, "one")...)
+	})
+	t.Run("hardened index", func(t *testing.T) {
+		e.In.WriteString("pass\r")
+		e.RunWithErrorCheckExit(t, "invalid account index", append(deriveArgs, "2147483648")...)
+	})
+	t.Run("wrong password", func(t *testing.T) {
+		e.In.WriteString("wrong\r")
+		e.RunWithErrorCheckExit(t, "can't decrypt HD key", append(deriveArgs, "1")...)
+	})
+	t.Run("already derived", func(t *testing.T) {
+		e.In.WriteString("pass\r")
+		e.RunWithErrorCheckExit(t, "is already in wallet", append(deriveArgs, "0")...)
+	})
+	t.Run("not HD wallet", func(t *testing.T) {
+		plainPath := filepath.Join(tmp, "plain.json")
+		e.Run(t, "neo-go", "wallet", "init", "--wallet", plainPath)
+		e.RunWithErrorCheckExit(t, "wallet has no HD key", "neo-go", "wallet", "account", "derive", "--wallet", plainPath, "1")
+	})
+
+	e.In.WriteString("pass\r")
+	e.Run(t, append(deriveArgs, "--name", "second", "1")...)
+	acc1 := e.GetNextLine(t)
+	w, err = wallet.NewWalletFromFile(walletPath)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(w.Accounts))
+	require.Equal(t, acc1, w.Accounts[1].Address)
+	require.Equal(t, "second", w.Accounts[1].Label)
+
+	t.Run("restore", func(t *testing.T) {
+		restoredPath := filepath.Join(tmp, "restored.json")
+		configPath := filepath.Join(tmp, "config.yaml")
+		res, err := yaml.Marshal(config.Wallet{
+			Path:     restoredPath,
+			Password: "newpass",
+		})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(configPath, res, 0666))
+
+		e.In.WriteString(mnemonic + "\r")
+		e.Run(t, "neo-go", "wallet", "init", "--wallet-config", configPath, "--hd", "--restore")
+		e.Run(t, "neo-go", "wallet", "account", "derive", "--wallet-config", configPath, "1")
+		e.CheckNextLine(t, acc1)
+
+		w, err := wallet.NewWalletFromFile(restoredPath)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(w.Accounts))
+		require.Equal(t, acc0, w.Accounts[0].Address)
+		require.Equal(t, acc1, w.Accounts[1].Address)
+		require.NoError(t, w.Accounts[1].Decrypt("newpass", w.Scrypt))
+	})
+	t.Run("restore invalid mnemonic", func(t *testing.T) {
+		e.In.WriteString("abandon abandon abandon\r")
+		e.In.WriteString("pass\r")
+		e.In.WriteString("pass\r")
+		e.RunWithErrorCheckExit(t, "invalid mnemonic", "neo-go", "wallet", "init", "--wallet", filepath.Join(tmp, "invalid.json"), "--hd", "--restore")
+	})
+}
+
 func TestWalletExport(t *testing.T) {
 	e := testcli.NewExecutor(t, false)
 
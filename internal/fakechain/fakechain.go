@@ -41,12 +41,14 @@ type FakeChain struct {
 
 // FakeStateSync implements the StateSync interface.
 type FakeStateSync struct {
-	IsActiveFlag      atomic.Bool
-	IsInitializedFlag atomic.Bool
-	RequestHeaders    atomic.Bool
-	InitFunc          func(h uint32) error
-	TraverseFunc      func(root util.Uint256, process func(node mpt.Node, nodeBytes []byte) bool) error
-	AddMPTNodesFunc   func(nodes [][]byte) error
+	IsActiveFlag        atomic.Bool
+	IsInitializedFlag   atomic.Bool
+	RequestHeaders      atomic.Bool
+	RequestMPTNodes     atomic.Bool
+	InitFunc            func(h uint32) error
+	TraverseFunc        func(root util.Uint256, process func(node mpt.Node, nodeBytes []byte) bool) error
+	AddMPTNodesFunc     func(nodes [][]byte) error
+	RequestMPTNodesFunc func(limit int) []util.Uint256
 }
 
 // NewFakeChain returns a new FakeChain structure.
@@ -448,9 +450,7 @@ func (s *FakeStateSync) Init(currChainHeight uint32) error {
 func (s *FakeStateSync) NeedHeaders() bool { return s.RequestHeaders.Load() }
 
 // NeedMPTNodes implements the StateSync interface.
-func (s *FakeStateSync) NeedMPTNodes() bool {
-	panic("TODO")
-}
+func (s *FakeStateSync) NeedMPTNodes() bool { return s.RequestMPTNodes.Load() }
 
 // Traverse implements the StateSync interface.
 func (s *FakeStateSync) Traverse(root util.Uint256, process func(node mpt.Node, nodeBytes []byte) bool) error {
@@ -460,7 +460,10 @@ func (s *FakeStateSync) Traverse(root util.Uint256, process func(node mpt.Node,
 	panic("TODO")
 }
 
-// GetUnknownMPTNodesBatch implements the StateSync interface.
-func (s *FakeStateSync) GetUnknownMPTNodesBatch(limit int) []util.Uint256 {
+// RequestMPTNodesBatch implements the StateSync interface.
+func (s *FakeStateSync) RequestMPTNodesBatch(limit int) []util.Uint256 {
+	if s.RequestMPTNodesFunc != nil {
+		return s.RequestMPTNodesFunc(limit)
+	}
 	panic("TODO")
 }
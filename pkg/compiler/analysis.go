@@ -717,11 +717,19 @@ func isSyscall(fun *funcScope) bool {
 	if fun.selector == nil || fun.pkg == nil || !isInteropPath(fun.pkg.Path()) {
 		return false
 	}
-	return fun.pkg.Name() == "neogointernal" && (strings.HasPrefix(fun.name, "Syscall") ||
+	return isAsm(fun) || fun.pkg.Name() == "neogointernal" && (strings.HasPrefix(fun.name, "Syscall") ||
 		strings.HasPrefix(fun.name, "Opcode") || strings.HasPrefix(fun.name, "CallWithToken"))
 }
 
-const interopPrefix = "github.com/nspcc-dev/neo-go/pkg/interop"
+// isAsm returns true if the function is one of asm package intrinsics.
+func isAsm(fun *funcScope) bool {
+	return fun.pkg != nil && fun.pkg.Path() == asmPath
+}
+
+const (
+	interopPrefix = "github.com/nspcc-dev/neo-go/pkg/interop"
+	asmPath       = interopPrefix + "/asm"
+)
 
 func isInteropPath(s string) bool {
 	return strings.HasPrefix(s, interopPrefix)
@@ -753,7 +761,7 @@ func canInline(s string, name string, isBuiltin bool) bool {
 	if !isInteropPath(s) {
 		return false
 	}
-	return !strings.HasPrefix(s[len(interopPrefix):], "/neogointernal") &&
+	return !strings.HasPrefix(s[len(interopPrefix):], "/neogointernal") && s != asmPath &&
 		!(strings.HasPrefix(s[len(interopPrefix):], "/util") && name == "FromAddress") &&
 		!(strings.HasPrefix(s[len(interopPrefix):], "/lib/address") && name == "ToHash160" && isBuiltin)
 }
@@ -0,0 +1,222 @@
+package compiler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"golang.org/x/tools/go/packages"
+)
+
+// cacheFormatVersion is a part of the cache key, it must be incremented
+// whenever the cached data layout or the compiler output for the same sources
+// changes.
+const cacheFormatVersion = 1
+
+// cacheFileExt is an extension of compilation cache entries.
+const cacheFileExt = "cache"
+
+// cachedProgram is a compilation cache entry. DebugInfo contains a lot of
+// compiler-internal data not included into its JSON representation, so gob
+// is used for serialization.
+type cachedProgram struct {
+	NEF   []byte
+	Debug *DebugInfo
+}
+
+// compileCached compiles the program using compilation cache located in
+// o.CacheDir. The cache key is a hash of all Go source files of the program
+// including its dependencies, the compiler version and the options affecting
+// compilation, so any change in any of the packages used invalidates the
+// entry. Cache is not used if the compiler version can't be determined.
+func compileCached(name string, o *Options) (*nef.File, *DebugInfo, error) {
+	if compilerVersion() == "" {
+		return CompileWithOptions(name, nil, o)
+	}
+	key, err := cacheKey(name, o)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to calculate cache key: %w", err)
+	}
+	entry := filepath.Join(o.CacheDir, key+"."+cacheFileExt)
+	if f, di, err := loadCached(entry); err == nil {
+		return f, di, nil
+	}
+	f, di, err := CompileWithOptions(name, nil, o)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := storeCached(entry, f, di); err != nil {
+		return nil, nil, fmt.Errorf("failed to store compilation cache entry: %w", err)
+	}
+	return f, di, nil
+}
+
+// cacheKey returns a hex-encoded hash of everything that affects the result of
+// the compilation.
+func cacheKey(name string, o *Options) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d\n%s\n", cacheFormatVersion, compilerVersion())
+
+	opts := *o
+	opts.Ext = ""
+	opts.Outfile = ""
+	opts.DebugInfo = ""
+	opts.SourceMap = ""
+	opts.ManifestFile = ""
+	opts.BindingsFile = ""
+	opts.CacheDir = ""
+	data, err := json.Marshal(opts)
+	if err != nil {
+		return "", err
+	}
+	h.Write(data)
+
+	absName, err := filepath.Abs(name)
+	if err != nil {
+		return "", err
+	}
+	// Only file lists are needed here, so this is much cheaper than the
+	// complete load with type checking.
+	conf := &packages.Config{
+		Mode: packages.NeedName |
+			packages.NeedFiles |
+			packages.NeedImports |
+			packages.NeedDeps,
+		Dir: absName,
+	}
+	pattern := "pattern=" + absName
+	if strings.HasSuffix(absName, ".go") {
+		conf.Dir = filepath.Dir(absName)
+		pattern = "file=" + absName
+	}
+	prog, err := packages.Load(conf, pattern)
+	if err != nil {
+		return "", err
+	}
+	var files []string
+	packages.Visit(prog, nil, func(p *packages.Package) {
+		files = append(files, p.GoFiles...)
+	})
+	slices.Sort(files)
+	files = slices.Compact(files)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "\n%s\n%d\n", file, len(data))
+		h.Write(data)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// neoGoModule is the path of the module providing the compiler.
+const neoGoModule = "github.com/nspcc-dev/neo-go"
+
+// compilerVersion returns a string identifying the compiler build, it's the
+// module version for released versions, VCS revision for clean local builds
+// and the executable hash otherwise ("(devel)" version doesn't change along
+// with the code). It returns an empty string if the build can't be
+// identified, compilation cache is not used in this case.
+var compilerVersion = sync.OnceValue(func() string {
+	bi, ok := debug.ReadBuildInfo()
+	if ok {
+		var m = &bi.Main
+		if m.Path != neoGoModule {
+			m = nil
+			for _, dep := range bi.Deps {
+				if dep.Path == neoGoModule {
+					m = dep
+					if dep.Replace != nil {
+						m = dep.Replace
+					}
+					break
+				}
+			}
+		}
+		if m != nil && m.Version != "" && m.Version != "(devel)" && !strings.HasSuffix(m.Version, "+dirty") {
+			return m.Path + "@" + m.Version
+		}
+		if m == &bi.Main {
+			var revision, modified string
+			for _, s := range bi.Settings {
+				switch s.Key {
+				case "vcs.revision":
+					revision = s.Value
+				case "vcs.modified":
+					modified = s.Value
+				}
+			}
+			if revision != "" && modified == "false" {
+				return m.Path + "@" + revision
+			}
+		}
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+	data, err := os.ReadFile(exe)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return "executable " + hex.EncodeToString(sum[:])
+})
+
+func loadCached(entry string) (*nef.File, *DebugInfo, error) {
+	data, err := os.ReadFile(entry)
+	if err != nil {
+		return nil, nil, err
+	}
+	var cp cachedProgram
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&cp); err != nil {
+		return nil, nil, err
+	}
+	f, err := nef.FileFromBytes(cp.NEF)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &f, cp.Debug, nil
+}
+
+func storeCached(entry string, f *nef.File, di *DebugInfo) error {
+	nefBytes, err := f.Bytes()
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(cachedProgram{NEF: nefBytes, Debug: di}); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(entry), os.ModePerm); err != nil {
+		return err
+	}
+	// Write to a temporary file first, so that concurrent compilers never
+	// see partially written entries.
+	tmp, err := os.CreateTemp(filepath.Dir(entry), filepath.Base(entry)+".*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(buf.Bytes())
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), entry)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+	return err
+}
@@ -0,0 +1,68 @@
+package compiler_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileAndSaveCache(t *testing.T) {
+	const src = `package cached
+	func Main(a int) int {
+		var b = a + %d
+		return b
+	}
+`
+	var (
+		tmp      = t.TempDir()
+		cacheDir = filepath.Join(tmp, "cache")
+		in       = filepath.Join(tmp, "src.go")
+	)
+
+	compile := func(t *testing.T, ret int) map[string][]byte {
+		require.NoError(t, os.WriteFile(in, []byte(fmt.Sprintf(src, ret)), os.ModePerm))
+		out := t.TempDir()
+		o := &compiler.Options{
+			Outfile:      filepath.Join(out, "contract.nef"),
+			DebugInfo:    filepath.Join(out, "contract.debug.json"),
+			ManifestFile: filepath.Join(out, "contract.manifest.json"),
+			BindingsFile: filepath.Join(out, "contract.bindings.yml"),
+			Name:         "cached",
+			CacheDir:     cacheDir,
+		}
+		// CompileAndSave trims the extension from o.Outfile.
+		files := []string{o.Outfile, o.DebugInfo, o.ManifestFile, o.BindingsFile}
+		_, err := compiler.CompileAndSave(in, o)
+		require.NoError(t, err)
+
+		res := make(map[string][]byte)
+		for _, f := range files {
+			data, err := os.ReadFile(f)
+			require.NoError(t, err)
+			res[filepath.Base(f)] = data
+		}
+		return res
+	}
+	entries := func(t *testing.T) int {
+		des, err := os.ReadDir(cacheDir)
+		require.NoError(t, err)
+		return len(des)
+	}
+
+	first := compile(t, 1)
+	require.Equal(t, 1, entries(t))
+
+	// Cache hit produces exactly the same output.
+	second := compile(t, 1)
+	require.Equal(t, 1, entries(t))
+	require.Equal(t, first, second)
+
+	// Any source change invalidates the entry.
+	third := compile(t, 2)
+	require.Equal(t, 2, entries(t))
+	require.NotEqual(t, first["contract.nef"], third["contract.nef"])
+}
@@ -0,0 +1,16 @@
+package compiler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompilerVersion(t *testing.T) {
+	// Test binaries don't have VCS information and have "(devel)" version,
+	// so the executable hash is used.
+	v := compilerVersion()
+	require.True(t, strings.HasPrefix(v, "executable "), v)
+	require.Equal(t, v, compilerVersion())
+}
@@ -14,6 +14,7 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -55,10 +56,16 @@ type codegen struct {
 	globals map[string]int
 	// staticVariables contains global (static in NDX-DN11) variable names and types.
 	staticVariables []string
+	// staticVariableSlots contains static slot indexes of staticVariables.
+	staticVariableSlots []int
 	// initVariables contains variables local to `_initialize` method.
 	initVariables []string
+	// initVariableSlots contains local slot indexes of initVariables.
+	initVariableSlots []int
 	// deployVariables contains variables local to `_initialize` method.
 	deployVariables []string
+	// deployVariableSlots contains local slot indexes of deployVariables.
+	deployVariableSlots []int
 
 	// A mapping from label's names to their ids.
 	labels map[labelWithType]uint16
@@ -545,8 +552,10 @@ func (c *codegen) convertFuncDecl(file ast.Node, decl *ast.FuncDecl, pkg *types.
 
 	if isInit {
 		c.initVariables = append(c.initVariables, f.variables...)
+		c.initVariableSlots = append(c.initVariableSlots, f.variableSlots...)
 	} else if isDeploy {
 		c.deployVariables = append(c.deployVariables, f.variables...)
+		c.deployVariableSlots = append(c.deployVariableSlots, f.variableSlots...)
 	}
 
 	f.rng.End = uint16(c.prog.Len() - 1)
@@ -684,12 +693,12 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 			switch t := n.Lhs[i].(type) {
 			case *ast.Ident:
 				if n.Tok == token.DEFINE {
-					if !multiRet {
-						c.registerDebugVariable(t.Name, n.Rhs[i])
-					}
 					if t.Name != "_" {
 						c.scope.newLocal(t.Name)
 					}
+					if !multiRet {
+						c.registerDebugVariable(t.Name, n.Rhs[i])
+					}
 				}
 				if !isAssignOp && (i == 0 || !multiRet) {
 					ast.Walk(c, n.Rhs[i])
@@ -1749,6 +1758,10 @@ func (c *codegen) getCallToken(hash util.Uint160, method string, pcount int, has
 }
 
 func (c *codegen) convertSyscall(f *funcScope, expr *ast.CallExpr) {
+	if isAsm(f) {
+		c.convertAsm(f, expr)
+		return
+	}
 	var callArgs = expr.Args[1:]
 
 	if strings.HasPrefix(f.name, "CallWithToken") {
@@ -1816,6 +1829,81 @@ func (c *codegen) convertSyscall(f *funcScope, expr *ast.CallExpr) {
 	}
 }
 
+// convertAsm emits code for asm package intrinsics.
+func (c *codegen) convertAsm(f *funcScope, expr *ast.CallExpr) {
+	switch f.name {
+	case "Push":
+		if expr.Ellipsis.IsValid() {
+			c.prog.Err = errors.New("asm.Push doesn't support variadic arguments")
+			return
+		}
+		for _, arg := range expr.Args {
+			ast.Walk(c, arg)
+		}
+	case "Pop":
+		// The item is already on the stack.
+	case "Syscall":
+		tv := c.typeAndValueOf(expr.Args[0])
+		if tv.Value == nil || !isString(tv.Type) {
+			c.prog.Err = errors.New("asm.Syscall name must be a constant string")
+			return
+		}
+		name := constant.StringVal(tv.Value)
+		if _, err := interopnames.FromID(interopnames.ToID([]byte(name))); err != nil {
+			c.prog.Err = fmt.Errorf("unknown syscall: %s", name)
+			return
+		}
+		if name == interopnames.SystemContractCall || name == interopnames.SystemRuntimeNotify {
+			c.prog.Err = fmt.Errorf("%s can't be emitted via asm.Syscall", name)
+			return
+		}
+		emit.Syscall(c.prog.BinWriter, name)
+	case "Emit":
+		tv := c.typeAndValueOf(expr.Args[0])
+		if tv.Value == nil || !isString(tv.Type) {
+			c.prog.Err = errors.New("asm.Emit opcode must be a constant string")
+			return
+		}
+		op, err := opcode.FromString(constant.StringVal(tv.Value))
+		if err != nil {
+			c.prog.Err = fmt.Errorf("invalid opcode: %s", constant.StringVal(tv.Value))
+			return
+		}
+		switch {
+		case opcode.JMP <= op && op <= opcode.CALLL, op == opcode.CALLT, // Jumps and calls.
+			op == opcode.PUSHA, op == opcode.TRY, op == opcode.TRYL, op == opcode.ENDTRY,
+			op == opcode.ENDTRYL, op == opcode.ENDFINALLY, op == opcode.RET,
+			op == opcode.INITSLOT, op == opcode.INITSSLOT, op == opcode.SYSCALL:
+			c.prog.Err = fmt.Errorf("%s can't be emitted via asm.Emit", op)
+			return
+		}
+		if expr.Ellipsis.IsValid() {
+			c.prog.Err = errors.New("asm.Emit operand must be a list of constant bytes")
+			return
+		}
+		var instr = []byte{byte(op)}
+		for _, arg := range expr.Args[1:] {
+			tv := c.typeAndValueOf(arg)
+			if tv.Value == nil {
+				c.prog.Err = errors.New("asm.Emit operand must be a list of constant bytes")
+				return
+			}
+			b, ok := constant.Uint64Val(constant.ToInt(tv.Value))
+			if !ok || b > 255 {
+				c.prog.Err = fmt.Errorf("invalid %s operand byte", op)
+				return
+			}
+			instr = append(instr, byte(b))
+		}
+		ctx := vm.NewContext(instr)
+		if _, _, err := ctx.Next(); err != nil || ctx.NextIP() != len(instr) {
+			c.prog.Err = fmt.Errorf("invalid %s operand", op)
+			return
+		}
+		emit.Instruction(c.prog.BinWriter, op, instr[1:])
+	}
+}
+
 // emitSliceHelper emits 3 items on stack: slice, its first index, and its size.
 func (c *codegen) emitSliceHelper(e ast.Expr) {
 	if !isByteSlice(c.typeOf(e)) {
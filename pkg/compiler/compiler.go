@@ -38,6 +38,9 @@ type Options struct {
 	// The name of the output for debug info.
 	DebugInfo string
 
+	// The name of the output for source map (see SourceMap).
+	SourceMap string
+
 	// The name of the output for contract manifest file.
 	ManifestFile string
 
@@ -90,6 +93,16 @@ type Options struct {
 
 	// BindingsFile contains configuration for smart-contract bindings generator.
 	BindingsFile string
+
+	// Report is a writer for contract size and cost report (see Report), it's
+	// not generated if nil.
+	Report io.Writer
+
+	// CacheDir is a directory for compilation cache used by CompileAndSave.
+	// Cache entries are keyed by the hash of all program sources (including
+	// dependencies), the compiler version and options, so unchanged contracts
+	// are not recompiled. Cache is not used if empty.
+	CacheDir string
 }
 
 // HybridEvent represents the description of event emitted by the contract squashed
@@ -103,10 +116,12 @@ type HybridEvent struct {
 
 // HybridParameter contains the manifest's event parameter description united with
 // the extended type description for this parameter. It is applied for the smart
-// contract configuration file only.
+// contract configuration file only. Indexed parameters are listed in the
+// manifest's Extra (see [manifest.IndexedEventsField]).
 type HybridParameter struct {
 	manifest.Parameter `yaml:",inline"`
 	ExtendedType       *binding.ExtendedType `yaml:"extendedtype,omitempty"`
+	Indexed            bool                  `yaml:"indexed,omitempty"`
 }
 
 type buildInfo struct {
@@ -263,7 +278,16 @@ func CompileAndSave(src string, o *Options) ([]byte, error) {
 	if len(o.Ext) == 0 {
 		o.Ext = fileExt
 	}
-	f, di, err := CompileWithOptions(src, nil, o)
+	var (
+		f   *nef.File
+		di  *DebugInfo
+		err error
+	)
+	if o.CacheDir != "" {
+		f, di, err = compileCached(src, o)
+	} else {
+		f, di, err = CompileWithOptions(src, nil, o)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error while trying to compile smart contract file: %w", err)
 	}
@@ -283,7 +307,7 @@ func CompileAndSave(src string, o *Options) ([]byte, error) {
 	if err != nil {
 		return f.Script, err
 	}
-	if o.DebugInfo == "" && o.ManifestFile == "" && o.BindingsFile == "" {
+	if o.DebugInfo == "" && o.SourceMap == "" && o.ManifestFile == "" && o.BindingsFile == "" && o.Report == nil {
 		return f.Script, nil
 	}
 
@@ -314,6 +338,30 @@ func CompileAndSave(src string, o *Options) ([]byte, error) {
 		}
 	}
 
+	if o.SourceMap != "" {
+		sm, err := di.SourceMap(f.Script)
+		if err != nil {
+			return f.Script, fmt.Errorf("failed to create source map: %w", err)
+		}
+		data, err := json.Marshal(sm)
+		if err != nil {
+			return f.Script, err
+		}
+		if err := os.WriteFile(o.SourceMap, data, os.ModePerm); err != nil {
+			return f.Script, err
+		}
+	}
+
+	if o.Report != nil {
+		r, err := di.Report(f.Script)
+		if err != nil {
+			return f.Script, fmt.Errorf("failed to create report: %w", err)
+		}
+		if err := r.Print(o.Report); err != nil {
+			return f.Script, err
+		}
+	}
+
 	if o.BindingsFile != "" {
 		cfg := binding.NewConfig()
 		cfg.Package = di.MainPkg
@@ -280,6 +280,59 @@ func TestEventWarnings(t *testing.T) {
 	})
 }
 
+func TestNotifyEventChecks(t *testing.T) {
+	src := `package payable
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop"
+			"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		)
+		type TransferEvent struct {
+			From   interop.Hash160
+			Amount int
+		}
+		func Main() { runtime.NotifyEvent("Transfer", TransferEvent{}) }`
+
+	_, di, err := compiler.CompileWithOptions("eventTest.go", strings.NewReader(src), nil)
+	require.NoError(t, err)
+
+	newOptions := func(params ...compiler.HybridParameter) *compiler.Options {
+		return &compiler.Options{
+			ContractEvents: []compiler.HybridEvent{{Name: "Transfer", Parameters: params}},
+			Name:           "payable",
+		}
+	}
+	t.Run("missing from config", func(t *testing.T) {
+		_, err = compiler.CreateManifest(di, &compiler.Options{Name: "payable"})
+		require.Error(t, err)
+	})
+	t.Run("wrong parameter number", func(t *testing.T) {
+		_, err = compiler.CreateManifest(di, newOptions(
+			compiler.HybridParameter{Parameter: manifest.NewParameter("from", smartcontract.Hash160Type)}))
+		require.Error(t, err)
+	})
+	t.Run("wrong parameter type", func(t *testing.T) {
+		// Unlike runtime.Notify, no conversion is made for structure fields.
+		_, err = compiler.CreateManifest(di, newOptions(
+			compiler.HybridParameter{Parameter: manifest.NewParameter("from", smartcontract.Hash160Type)},
+			compiler.HybridParameter{Parameter: manifest.NewParameter("amount", smartcontract.StringType)}))
+		require.Error(t, err)
+	})
+	t.Run("good", func(t *testing.T) {
+		_, err = compiler.CreateManifest(di, newOptions(
+			compiler.HybridParameter{Parameter: manifest.NewParameter("from", smartcontract.Hash160Type)},
+			compiler.HybridParameter{Parameter: manifest.NewParameter("amount", smartcontract.IntegerType)}))
+		require.NoError(t, err)
+	})
+	t.Run("in Verify", func(t *testing.T) {
+		src := `package payable
+			import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+			type Event struct { A int }
+			func Verify() bool { runtime.NotifyEvent("Event", Event{}); return true }`
+		_, _, err := compiler.CompileWithOptions("eventTest.go", strings.NewReader(src), nil)
+		require.Error(t, err)
+	})
+}
+
 func TestNotifyInVerify(t *testing.T) {
 	srcTmpl := `package payable
 		import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
@@ -41,6 +41,10 @@ type DebugInfo struct {
 	InvokedContracts map[util.Uint160][]string `json:"-"`
 	// StaticVariables contains a list of static variable names and types.
 	StaticVariables []string `json:"static-variables"`
+	// StaticVariableSlots contains static slot indexes of StaticVariables
+	// (-1 if the index is unknown). They're not a part of the debug info
+	// file, but they're used for source maps.
+	StaticVariableSlots []int `json:"-"`
 }
 
 // MethodDebugInfo represents smart-contract's method debug information.
@@ -68,6 +72,9 @@ type MethodDebugInfo struct {
 	// ReturnTypeSC is a return type to use in manifest.
 	ReturnTypeSC smartcontract.ParamType `json:"-"`
 	Variables    []string                `json:"variables"`
+	// VariableSlots contains local slot indexes of Variables (-1 if the
+	// index is unknown).
+	VariableSlots []int `json:"-"`
 	// SeqPoints is a map between source lines and byte-code instruction offsets.
 	SeqPoints []DebugSeqPoint `json:"sequence-points"`
 }
@@ -146,11 +153,12 @@ func (c *codegen) saveSequencePoint(n ast.Node) {
 
 func (c *codegen) emitDebugInfo(contract []byte) *DebugInfo {
 	d := &DebugInfo{
-		Hash:            hash.Hash160(contract),
-		MainPkg:         c.mainPkg.Name,
-		Events:          []EventDebugInfo{},
-		Documents:       c.documents,
-		StaticVariables: c.staticVariables,
+		Hash:                hash.Hash160(contract),
+		MainPkg:             c.mainPkg.Name,
+		Events:              []EventDebugInfo{},
+		Documents:           c.documents,
+		StaticVariables:     c.staticVariables,
+		StaticVariableSlots: c.staticVariableSlots,
 	}
 	if c.initEndOffset > 0 {
 		d.Methods = append(d.Methods, MethodDebugInfo{
@@ -165,10 +173,11 @@ func (c *codegen) emitDebugInfo(contract []byte) *DebugInfo {
 				Start: 0,
 				End:   uint16(c.initEndOffset),
 			},
-			ReturnType:   "Void",
-			ReturnTypeSC: smartcontract.VoidType,
-			SeqPoints:    c.sequencePoints["init"],
-			Variables:    c.initVariables,
+			ReturnType:    "Void",
+			ReturnTypeSC:  smartcontract.VoidType,
+			SeqPoints:     c.sequencePoints["init"],
+			Variables:     c.initVariables,
+			VariableSlots: c.initVariableSlots,
 		})
 	}
 	if c.deployEndOffset >= 0 {
@@ -196,10 +205,11 @@ func (c *codegen) emitDebugInfo(contract []byte) *DebugInfo {
 					TypeSC: smartcontract.BoolType,
 				},
 			},
-			ReturnType:   "Void",
-			ReturnTypeSC: smartcontract.VoidType,
-			SeqPoints:    c.sequencePoints[manifest.MethodDeploy],
-			Variables:    c.deployVariables,
+			ReturnType:    "Void",
+			ReturnTypeSC:  smartcontract.VoidType,
+			SeqPoints:     c.sequencePoints[manifest.MethodDeploy],
+			Variables:     c.deployVariables,
+			VariableSlots: c.deployVariableSlots,
 		})
 	}
 
@@ -223,11 +233,20 @@ func (c *codegen) emitDebugInfo(contract []byte) *DebugInfo {
 
 func (c *codegen) registerDebugVariable(name string, expr ast.Expr) {
 	_, vt, _, _ := c.scAndVMTypeFromExpr(expr, nil)
+	var slot = unspecifiedVarIndex
 	if c.scope == nil {
+		if i, ok := c.globals[c.getIdentName("", name)]; ok {
+			slot = i
+		}
 		c.staticVariables = append(c.staticVariables, name+","+vt.String())
+		c.staticVariableSlots = append(c.staticVariableSlots, slot)
 		return
 	}
+	if vi := c.scope.vars.getVarInfo(name); vi != nil && vi.refType == varLocal {
+		slot = vi.index
+	}
 	c.scope.variables = append(c.scope.variables, name+","+vt.String())
+	c.scope.variableSlots = append(c.scope.variableSlots, slot)
 }
 
 func (c *codegen) methodInfoFromScope(name string, scope *funcScope, exts map[string]binding.ExtendedType) *MethodDebugInfo {
@@ -266,6 +285,7 @@ func (c *codegen) methodInfoFromScope(name string, scope *funcScope, exts map[st
 		ReturnTypeSC:       st,
 		SeqPoints:          c.sequencePoints[name],
 		Variables:          scope.variables,
+		VariableSlots:      scope.variableSlots,
 	}
 }
 
@@ -600,10 +620,14 @@ func (di *DebugInfo) ConvertToManifest(o *Options) (*manifest.Manifest, error) {
 		result.SupportedStandards = o.ContractSupportedStandards
 	}
 	events := make([]manifest.Event, len(o.ContractEvents))
+	indexed := make(map[string][]string)
 	for i, e := range o.ContractEvents {
 		params := make([]manifest.Parameter, len(e.Parameters))
 		for j, p := range e.Parameters {
 			params[j] = p.Parameter
+			if p.Indexed {
+				indexed[e.Name] = append(indexed[e.Name], p.Name)
+			}
 		}
 		events[i] = manifest.Event{
 			Name:       o.ContractEvents[i].Name,
@@ -617,6 +641,14 @@ func (di *DebugInfo) ConvertToManifest(o *Options) (*manifest.Manifest, error) {
 	if result.ABI.Events == nil {
 		result.ABI.Events = make([]manifest.Event, 0)
 	}
+	if len(indexed) != 0 {
+		if err := result.SetIndexedEvents(indexed); err != nil {
+			return nil, err
+		}
+		if _, err := result.IndexedEvents(); err != nil {
+			return nil, fmt.Errorf("invalid indexed events: %w", err)
+		}
+	}
 	result.Permissions = o.Permissions
 	for name, emitName := range o.Overloads {
 		m := result.ABI.GetMethod(name, -1)
@@ -1,6 +1,7 @@
 package compiler
 
 import (
+	"bytes"
 	"encoding/json"
 	"strings"
 	"testing"
@@ -412,3 +413,150 @@ func TestManifestOverload(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestManifestIndexedEvents(t *testing.T) {
+	src := `package foo
+	import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	func Main() {
+		runtime.Notify("Event", []byte{1}, 2, []any{})
+	}`
+
+	_, di, err := CompileWithOptions("foo.go", strings.NewReader(src), nil)
+	require.NoError(t, err)
+
+	newOpts := func(indexed ...bool) *Options {
+		e := HybridEvent{
+			Name: "Event",
+			Parameters: []HybridParameter{
+				{Parameter: manifest.NewParameter("key", smartcontract.ByteArrayType)},
+				{Parameter: manifest.NewParameter("value", smartcontract.IntegerType)},
+				{Parameter: manifest.NewParameter("data", smartcontract.ArrayType)},
+			},
+		}
+		for i := range indexed {
+			e.Parameters[i].Indexed = indexed[i]
+		}
+		return &Options{ContractEvents: []HybridEvent{e}}
+	}
+
+	m, err := di.ConvertToManifest(newOpts())
+	require.NoError(t, err)
+	require.Equal(t, "null", string(m.Extra))
+
+	m, err = di.ConvertToManifest(newOpts(true, true))
+	require.NoError(t, err)
+	res, err := m.IndexedEvents()
+	require.NoError(t, err)
+	require.Equal(t, map[string][]int{"Event": {0, 1}}, res)
+
+	_, err = di.ConvertToManifest(newOpts(false, false, true))
+	require.ErrorContains(t, err, "can't be indexed")
+}
+
+func TestDebugInfo_SourceMap(t *testing.T) {
+	src := `package foo
+var staticVar int
+func init() { staticVar = 1 }
+func Main(a int) int {
+	b := a + 1
+	var c int
+	c = b * 2
+	return c
+}`
+
+	ne, d, err := CompileWithOptions("foo.go", strings.NewReader(src), nil)
+	require.NoError(t, err)
+
+	sm, err := d.SourceMap(ne.Script)
+	require.NoError(t, err)
+	require.Equal(t, SourceMapVersion, sm.Version)
+	require.Equal(t, d.Hash, sm.Hash)
+	require.Equal(t, d.Documents, sm.Documents)
+	require.Equal(t, []SourceMapSlot{{Index: 0, Name: "staticVar", Type: "Integer"}}, sm.StaticVariables)
+
+	var mainIndex = -1
+	for i, m := range sm.Methods {
+		if m.Name == "foo.main" {
+			mainIndex = i
+		}
+	}
+	require.NotEqual(t, -1, mainIndex)
+	main := sm.Methods[mainIndex]
+	require.Equal(t, []SourceMapSlot{{Index: 0, Name: "a", Type: "Integer"}}, main.Arguments)
+	require.Equal(t, []SourceMapSlot{
+		{Index: 0, Name: "b", Type: "Integer"},
+		{Index: 1, Name: "c", Type: "Integer"},
+	}, main.Locals)
+
+	var mainMappings []SourceMapMapping
+	for i, m := range sm.Mappings {
+		if i > 0 {
+			require.Greater(t, m.Offset, sm.Mappings[i-1].Offset)
+		}
+		require.GreaterOrEqual(t, m.Offset, sm.Methods[m.Method].Start)
+		require.LessOrEqual(t, m.Offset, sm.Methods[m.Method].End)
+		if m.Method == mainIndex {
+			mainMappings = append(mainMappings, m)
+		}
+	}
+	require.NotEmpty(t, mainMappings)
+	require.Equal(t, 5, mainMappings[0].Line)
+	require.Equal(t, 8, mainMappings[len(mainMappings)-1].Line)
+	require.Equal(t, main.End, mainMappings[len(mainMappings)-1].Offset)
+
+	t.Run("bad script", func(t *testing.T) {
+		_, err := d.SourceMap([]byte{0xff})
+		require.Error(t, err)
+	})
+
+	testserdes.MarshalUnmarshalJSON(t, sm, new(SourceMap))
+}
+
+func TestDebugInfo_Report(t *testing.T) {
+	src := `package foo
+import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+func Main(a int) int {
+	runtime.Log("main")
+	return helper(a) + helper(a+1) + helper(a+2)
+}
+func helper(a int) int {
+	return a * 2
+}`
+
+	ne, d, err := CompileWithOptions("foo.go", strings.NewReader(src), nil)
+	require.NoError(t, err)
+
+	r, err := d.Report(ne.Script)
+	require.NoError(t, err)
+	require.Equal(t, len(ne.Script), r.Size)
+	require.Equal(t, len(d.Methods), len(r.Methods))
+
+	var (
+		size int
+		cost int64
+	)
+	for i, m := range r.Methods {
+		if i > 0 {
+			require.LessOrEqual(t, m.Size, r.Methods[i-1].Size)
+		}
+		require.Equal(t, m.End-m.Start+1, m.Size)
+		size += m.Size
+		cost += m.Cost
+	}
+	require.LessOrEqual(t, size, r.Size)
+	require.LessOrEqual(t, cost, r.Cost)
+	require.Equal(t, "foo.main", r.Methods[0].Name)
+	require.Equal(t, 1, r.Methods[0].Syscalls)
+	require.Positive(t, r.Methods[0].Cost)
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, r.Print(buf))
+	require.Contains(t, buf.String(), "foo.main")
+	require.Contains(t, buf.String(), "foo.helper")
+	require.Contains(t, buf.String(), "Total")
+
+	t.Run("bad script", func(t *testing.T) {
+		_, err := d.Report([]byte{0xff})
+		require.Error(t, err)
+	})
+}
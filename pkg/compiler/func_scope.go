@@ -31,6 +31,8 @@ type funcScope struct {
 	rng DebugRange
 	// Variables together with it's type in neo-vm.
 	variables []string
+	// Local slot indexes of variables.
+	variableSlots []int
 
 	// deferStack is a stack containing encountered `defer` statements.
 	deferStack []deferInfo
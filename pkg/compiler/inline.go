@@ -1,6 +1,7 @@
 package compiler
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/constant"
@@ -140,7 +141,7 @@ func (c *codegen) processStdlibCall(f *funcScope, args []ast.Expr, hasEllipsis b
 	}
 
 	var eventParams []*stackitem.Type
-	if f.pkg.Path() == interopPrefix+"/runtime" && (f.name == "Notify" || f.name == "Log") {
+	if f.pkg.Path() == interopPrefix+"/runtime" && (f.name == "Notify" || f.name == "NotifyEvent" || f.name == "Log") {
 		eventParams = c.processNotify(f, args, hasEllipsis)
 	}
 
@@ -162,6 +163,10 @@ func (c *codegen) processNotify(f *funcScope, args []ast.Expr, hasEllipsis bool)
 	if f.name == "Log" {
 		return nil
 	}
+	if f.name == "NotifyEvent" {
+		c.processNotifyEvent(args)
+		return nil
+	}
 
 	// Sometimes event name is stored in a var. Or sometimes event args are provided
 	// via ellipses (`slice...`).
@@ -238,6 +243,64 @@ func (c *codegen) processNotify(f *funcScope, args []ast.Expr, hasEllipsis bool)
 	return nil
 }
 
+// processNotifyEvent saves the event emitted via runtime.NotifyEvent, its
+// parameters are taken from the event structure fields. No conversion code is
+// emitted for them, so they're checked against the contract configuration
+// after compilation as is.
+func (c *codegen) processNotifyEvent(args []ast.Expr) {
+	tv := c.typeAndValueOf(args[0])
+	if tv.Value == nil {
+		c.prog.Err = errors.New("runtime.NotifyEvent event name must be a constant string")
+		return
+	}
+	name := constant.StringVal(tv.Value)
+	if len(name) > runtime.MaxEventNameLen {
+		c.prog.Err = fmt.Errorf("event name '%s' should be less than %d",
+			name, runtime.MaxEventNameLen)
+		return
+	}
+	t := c.typeOf(args[1])
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		c.prog.Err = fmt.Errorf("runtime.NotifyEvent expects a structure for event '%s', got %s", name, t)
+		return
+	}
+
+	var (
+		params   = make([]DebugParam, 0, st.NumFields())
+		extMap   = make(map[string]binding.ExtendedType)
+		declared []HybridParameter
+	)
+	if c.buildInfo.options != nil {
+		for _, e := range c.buildInfo.options.ContractEvents {
+			if e.Name == name && len(e.Parameters) == st.NumFields() {
+				declared = e.Parameters
+			}
+		}
+	}
+	for i := range st.NumFields() {
+		scT, vt, over, extT := c.scAndVMTypeFromType(st.Field(i).Type(), extMap)
+		p := DebugParam{
+			Name:         st.Field(i).Name(),
+			Type:         vt.String(),
+			RealType:     over,
+			ExtendedType: extT,
+			TypeSC:       scT,
+		}
+		if declared != nil {
+			p.Name = declared[i].Name
+		}
+		params = append(params, p)
+	}
+	c.emittedEvents[name] = append(c.emittedEvents[name], EmittedEventInfo{
+		ExtTypes: extMap,
+		Params:   params,
+	})
+}
+
 func (c *codegen) processContractCall(f *funcScope, args []ast.Expr) {
 	var u util.Uint160
 
@@ -33,6 +33,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 )
@@ -622,6 +623,61 @@ func TestCallWithVersion(t *testing.T) {
 	})
 }
 
+func TestGasBudget(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	src := `package foo
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop/lib/budget"
+			"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		)
+		func Fill(n int, reserve int) int {
+			ctx := storage.GetContext()
+			g := budget.NewGuard(reserve)
+			var i int
+			for i = 0; i < n && g.Next(); i++ {
+				storage.Put(ctx, []byte{byte(i)}, i)
+			}
+			storage.Put(ctx, "count", i)
+			return i
+		}
+		func Ensure(minimum int) bool {
+			budget.EnsureGas(minimum)
+			return budget.HasGas(minimum)
+		}`
+	ctr := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(src), &compiler.Options{Name: "Helper"})
+	e.DeployContract(t, ctr, nil)
+	c := e.CommitteeInvoker(ctr.Hash)
+
+	invoke := func(t *testing.T, sysFee int64, method string, args ...any) *state.AppExecResult {
+		tx := e.NewUnsignedTx(t, ctr.Hash, method, args...)
+		e.SignTx(t, tx, sysFee, c.Signers...)
+		e.AddNewBlock(t, tx)
+		return e.GetTxExecResult(t, tx.Hash())
+	}
+	t.Run("Guard", func(t *testing.T) {
+		res := invoke(t, 10_0000_0000, "fill", 100, 0)
+		require.Equal(t, vmstate.Halt, res.VMState, res.FaultException)
+		require.EqualValues(t, 100, res.Stack[0].Value().(*big.Int).Int64())
+
+		// Not enough GAS for all items, but the loop stops before FAULT.
+		res = invoke(t, 5_000_000, "fill", 100, 1_000_000)
+		require.Equal(t, vmstate.Halt, res.VMState, res.FaultException)
+		n := res.Stack[0].Value().(*big.Int).Int64()
+		require.True(t, n > 0 && n < 100, n)
+		require.True(t, res.GasConsumed <= 5_000_000-1_000_000+res.GasConsumed/n, res.GasConsumed)
+	})
+	t.Run("EnsureGas", func(t *testing.T) {
+		res := invoke(t, 10_000_000, "ensure", 1)
+		require.Equal(t, vmstate.Halt, res.VMState, res.FaultException)
+		require.Equal(t, true, res.Stack[0].Value())
+
+		res = invoke(t, 10_000_000, "ensure", 10_000_000)
+		require.Equal(t, vmstate.Fault, res.VMState)
+		require.Contains(t, res.FaultException, "insufficient GAS")
+	})
+}
+
 func TestForcedNotifyArgumentsConversion(t *testing.T) {
 	const methodWithEllipsis = "withEllipsis"
 	const methodWithoutEllipsis = "withoutEllipsis"
@@ -0,0 +1,117 @@
+package compiler
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// Report contains emitted script size and estimated execution cost of every
+// contract method. It allows to find methods that make the contract bigger
+// or more expensive than expected.
+type Report struct {
+	// Size is the total script size.
+	Size int
+	// Cost is the total static cost of all script instructions (see
+	// MethodReport.Cost).
+	Cost int64
+	// Methods are method reports sorted by size in descending order.
+	Methods []MethodReport
+}
+
+// MethodReport contains emitted script size and estimated execution cost of
+// a single method.
+type MethodReport struct {
+	// Name is the method name in "{namespace}.{name}" format.
+	Name string
+	// Start is the offset of the first method instruction.
+	Start int
+	// End is the offset of the last method instruction.
+	End int
+	// Size is the size of method code in bytes.
+	Size int
+	// Instructions is the number of method instructions.
+	Instructions int
+	// Syscalls is the number of SYSCALL instructions in the method.
+	Syscalls int
+	// Cost is the static method cost in GAS fractions calculated as the price
+	// of executing every method instruction once with the default execution
+	// fee factor. Prices of syscalls and contract calls are not included,
+	// they depend on the runtime state, as well as the number of loop
+	// iterations and the set of executed branches, so the real cost of
+	// method invocation can differ significantly.
+	Cost int64
+}
+
+// Report creates a size and cost report for the given compiled contract script
+// using the debug information.
+func (di *DebugInfo) Report(script []byte) (*Report, error) {
+	r := &Report{
+		Size:    len(script),
+		Methods: make([]MethodReport, 0, len(di.Methods)),
+	}
+	for _, m := range di.Methods {
+		r.Methods = append(r.Methods, MethodReport{
+			Name:  m.Name.Namespace + "." + m.Name.Name,
+			Start: int(m.Range.Start),
+			End:   int(m.Range.End),
+		})
+	}
+
+	ctx := vm.NewContext(script)
+	for ctx.NextIP() < len(script) {
+		op, _, err := ctx.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse script at %d: %w", ctx.IP(), err)
+		}
+		var (
+			offset = ctx.IP()
+			cost   = fee.Opcode(interop.DefaultBaseExecFee, op)
+		)
+		r.Cost += cost
+		for i := range r.Methods {
+			m := &r.Methods[i]
+			if offset < m.Start || offset > m.End {
+				continue
+			}
+			m.Size += ctx.NextIP() - offset
+			m.Instructions++
+			m.Cost += cost
+			if op == opcode.SYSCALL {
+				m.Syscalls++
+			}
+			break
+		}
+	}
+	slices.SortStableFunc(r.Methods, func(a, b MethodReport) int {
+		return cmp.Compare(b.Size, a.Size)
+	})
+	return r, nil
+}
+
+// Print writes the report to w as a human-readable table.
+func (r *Report) Print(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "Method\tOffset\tSize\tShare\tInstructions\tSyscalls\tCost")
+	for _, m := range r.Methods {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%d\t%d\t%d\n", m.Name, m.Start, m.Size,
+			share(m.Size, r.Size), m.Instructions, m.Syscalls, m.Cost)
+	}
+	_, _ = fmt.Fprintf(tw, "Total\t\t%d\t\t\t\t%d\n", r.Size, r.Cost)
+	return tw.Flush()
+}
+
+// share returns n as a percentage of total.
+func share(n, total int) string {
+	if total == 0 {
+		return "0.0%"
+	}
+	return fmt.Sprintf("%.1f%%", float64(n)*100/float64(total))
+}
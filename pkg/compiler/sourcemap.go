@@ -0,0 +1,171 @@
+package compiler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+)
+
+// SourceMapVersion is the version of source map format produced by the compiler.
+const SourceMapVersion = 1
+
+// SourceMap is a standalone contract source map that can be used by external
+// debuggers and tools. Unlike DebugInfo (that follows the format used by
+// Neo debuggers and only contains sequence points for some instructions) it
+// maps every script instruction that belongs to some method to the source code
+// location and contains slot indexes of arguments, local and static variables.
+// It's serialized into JSON like this:
+//
+//	{
+//	  "version": 1,
+//	  "hash": "0x...",
+//	  "documents": ["/path/to/contract.go"],
+//	  "methods": [
+//	    {
+//	      "name": "contract.Method",
+//	      "start": 0,
+//	      "end": 42,
+//	      "arguments": [{"index": 0, "name": "a", "type": "Integer"}],
+//	      "locals": [{"index": 0, "name": "b", "type": "ByteString"}]
+//	    }
+//	  ],
+//	  "static-variables": [{"index": 0, "name": "c", "type": "Integer"}],
+//	  "mappings": [
+//	    {"offset": 3, "method": 0, "document": 0, "line": 5, "column": 2, "end-line": 5, "end-column": 12}
+//	  ]
+//	}
+//
+// Offsets are script instruction offsets, method and document fields of
+// mappings are indexes in the methods and documents lists, lines and columns
+// are 1-based (columns are counted in bytes). Mappings are sorted by offset,
+// instructions outside any method or preceding the first sequence point of the
+// method are not mapped. Slot index is -1 if it can't be determined (it's the
+// case for blank identifiers).
+type SourceMap struct {
+	Version         int                `json:"version"`
+	Hash            util.Uint160       `json:"hash"`
+	Documents       []string           `json:"documents"`
+	Methods         []SourceMapMethod  `json:"methods"`
+	StaticVariables []SourceMapSlot    `json:"static-variables"`
+	Mappings        []SourceMapMapping `json:"mappings"`
+}
+
+// SourceMapMethod describes a single contract method in the source map.
+type SourceMapMethod struct {
+	// Name is the method name in "{namespace}.{name}" format.
+	Name string `json:"name"`
+	// Start is the offset of the first method instruction.
+	Start int `json:"start"`
+	// End is the offset of the last method instruction.
+	End int `json:"end"`
+	// Arguments are method arguments (including receiver if any).
+	Arguments []SourceMapSlot `json:"arguments"`
+	// Locals are local variables of the method.
+	Locals []SourceMapSlot `json:"locals"`
+}
+
+// SourceMapSlot is a variable stored in some VM slot.
+type SourceMapSlot struct {
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+}
+
+// SourceMapMapping maps a single script instruction to the source code.
+type SourceMapMapping struct {
+	Offset    int `json:"offset"`
+	Method    int `json:"method"`
+	Document  int `json:"document"`
+	Line      int `json:"line"`
+	Column    int `json:"column"`
+	EndLine   int `json:"end-line"`
+	EndColumn int `json:"end-column"`
+}
+
+// SourceMap creates a source map for the given compiled contract script using
+// the debug information.
+func (di *DebugInfo) SourceMap(script []byte) (*SourceMap, error) {
+	sm := &SourceMap{
+		Version:         SourceMapVersion,
+		Hash:            di.Hash,
+		Documents:       di.Documents,
+		Methods:         make([]SourceMapMethod, 0, len(di.Methods)),
+		StaticVariables: variablesToSlots(di.StaticVariables, di.StaticVariableSlots),
+		Mappings:        []SourceMapMapping{},
+	}
+	if sm.Documents == nil {
+		sm.Documents = []string{}
+	}
+	for _, m := range di.Methods {
+		var (
+			args = make([]SourceMapSlot, 0, len(m.Parameters)+1)
+			off  int
+		)
+		if !m.IsFunction {
+			// Receiver is passed as the first argument, its name is
+			// not known from the debug info.
+			args = append(args, SourceMapSlot{Index: 0, Name: "_", Type: "Any"})
+			off = 1
+		}
+		for i, p := range m.Parameters {
+			args = append(args, SourceMapSlot{Index: i + off, Name: p.Name, Type: p.Type})
+		}
+		sm.Methods = append(sm.Methods, SourceMapMethod{
+			Name:      m.Name.Namespace + "." + m.Name.Name,
+			Start:     int(m.Range.Start),
+			End:       int(m.Range.End),
+			Arguments: args,
+			Locals:    variablesToSlots(m.Variables, m.VariableSlots),
+		})
+	}
+
+	ctx := vm.NewContext(script)
+	for ctx.NextIP() < len(script) {
+		_, _, err := ctx.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse script at %d: %w", ctx.IP(), err)
+		}
+		var offset = ctx.IP()
+		for i, m := range di.Methods {
+			if offset < int(m.Range.Start) || offset > int(m.Range.End) {
+				continue
+			}
+			var sp *DebugSeqPoint
+			for j := range m.SeqPoints {
+				if m.SeqPoints[j].Opcode <= offset && (sp == nil || m.SeqPoints[j].Opcode >= sp.Opcode) {
+					sp = &m.SeqPoints[j]
+				}
+			}
+			if sp != nil {
+				sm.Mappings = append(sm.Mappings, SourceMapMapping{
+					Offset:    offset,
+					Method:    i,
+					Document:  sp.Document,
+					Line:      sp.StartLine,
+					Column:    sp.StartCol,
+					EndLine:   sp.EndLine,
+					EndColumn: sp.EndCol,
+				})
+			}
+			break
+		}
+	}
+	return sm, nil
+}
+
+// variablesToSlots converts debug info variables ("name,type" strings) to
+// source map slots.
+func variablesToSlots(vars []string, slots []int) []SourceMapSlot {
+	var res = make([]SourceMapSlot, 0, len(vars))
+	for i, v := range vars {
+		name, typ, _ := strings.Cut(v, ",")
+		var s = SourceMapSlot{Index: unspecifiedVarIndex, Name: name, Type: typ}
+		if i < len(slots) {
+			s.Index = slots[i]
+		}
+		res = append(res, s)
+	}
+	return res
+}
@@ -2,6 +2,7 @@ package compiler_test
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math/big"
@@ -19,6 +20,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -221,6 +223,54 @@ func TestNotify(t *testing.T) {
 	})
 }
 
+func TestNotifyEvent(t *testing.T) {
+	src := `package foo
+	import (
+		"github.com/nspcc-dev/neo-go/pkg/interop"
+		"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	)
+	type Event struct {
+		From   interop.Hash160
+		Amount int
+	}
+	func Main(arg int) {
+		runtime.NotifyEvent("Event", Event{From: interop.Hash160("abc"), Amount: arg})
+		runtime.NotifyEvent("Event", &Event{Amount: arg + 1})
+	}`
+
+	v, s, _ := vmAndCompileInterop(t, src)
+	v.Estack().PushVal(11)
+
+	require.NoError(t, v.Run())
+	require.Equal(t, 2, len(s.events))
+	assert.Equal(t, "Event", s.events[0].Name)
+	assert.Equal(t, []stackitem.Item{stackitem.NewByteArray([]byte("abc")), stackitem.NewBigInteger(big.NewInt(11))}, s.events[0].Item.Value())
+	assert.Equal(t, "Event", s.events[1].Name)
+	assert.Equal(t, []stackitem.Item{stackitem.Null{}, stackitem.NewBigInteger(big.NewInt(12))}, s.events[1].Item.Value())
+
+	t.Run("not a structure", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		func Main(arg int) {
+			runtime.NotifyEvent("Event", arg)
+		}`
+
+		_, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
+		require.ErrorContains(t, err, "expects a structure")
+	})
+	t.Run("variable name", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		type Event struct { A int }
+		func Main(name string) {
+			runtime.NotifyEvent(name, Event{})
+		}`
+
+		_, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
+		require.ErrorContains(t, err, "must be a constant string")
+	})
+}
+
 func TestSyscallInGlobalInit(t *testing.T) {
 	src := `package foo
 		import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
@@ -383,6 +433,72 @@ func TestOpcode(t *testing.T) {
 	})
 }
 
+func TestAsm(t *testing.T) {
+	t.Run("push, emit, pop", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/asm"
+		func mulAdd(a, b, c int) int {
+			asm.Push(a, b)
+			asm.Emit("MUL")
+			asm.Push(c)
+			asm.Emit("ADD")
+			return asm.Pop().(int)
+		}
+		func Main() int {
+			asm.Emit("NOP")
+			asm.Emit("PUSHINT8", 7)
+			asm.Pop() // Dropped.
+			asm.Emit("PUSHDATA1", 2, 'h', 'i')
+			asm.Emit("CONVERT", 0x21) // Integer.
+			c := asm.Pop().(int)
+			return mulAdd(5, 8, c)
+		}`
+		eval(t, src, big.NewInt(5*8+0x6968))
+	})
+	t.Run("syscall", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/asm"
+		func Main() int {
+			asm.Syscall("System.Runtime.GetTime")
+			return asm.Pop().(int)
+		}`
+		b, err := compiler.Compile("foo.go", strings.NewReader(src))
+		require.NoError(t, err)
+		id := make([]byte, 4)
+		binary.LittleEndian.PutUint32(id, interopnames.ToID([]byte(interopnames.SystemRuntimeGetTime)))
+		require.True(t, bytes.Contains(b, append([]byte{byte(opcode.SYSCALL)}, id...)))
+	})
+	errCases := map[string]string{
+		"unknown opcode":       `asm.Emit("BAD")`,
+		"non-constant opcode":  `op := "NOP"; asm.Emit(op)`,
+		"jump":                 `asm.Emit("JMP", 2)`,
+		"ret":                  `asm.Emit("RET")`,
+		"slot":                 `asm.Emit("INITSLOT", 1, 0)`,
+		"raw syscall":          `asm.Emit("SYSCALL", 1, 2, 3, 4)`,
+		"callt":                `asm.Emit("CALLT", 0, 0)`,
+		"missing operand":      `asm.Emit("PUSHINT16", 1)`,
+		"excessive operand":    `asm.Emit("ADD", 1)`,
+		"bad pushdata":         `asm.Emit("PUSHDATA1", 3, 1)`,
+		"non-constant operand": `b := byte(1); asm.Emit("PUSHINT8", b)`,
+		"big operand":          `asm.Emit("PUSHINT8", 256)`,
+		"unknown syscall":      `asm.Syscall("System.Bad")`,
+		"contract call":        `asm.Syscall("System.Contract.Call")`,
+		"notification":         `asm.Syscall("System.Runtime.Notify")`,
+		"variadic push":        `xs := []any{1, 2}; asm.Push(xs...)`,
+	}
+	for name, code := range errCases {
+		t.Run(name, func(t *testing.T) {
+			src := `package foo
+			import "github.com/nspcc-dev/neo-go/pkg/interop/asm"
+			func Main() {
+				` + code + `
+			}`
+			_, err := compiler.Compile("foo.go", strings.NewReader(src))
+			require.Error(t, err)
+		})
+	}
+}
+
 func TestInteropTypesComparison(t *testing.T) {
 	typeCheck := func(t *testing.T, typeName string, typeLen int) {
 		t.Run(typeName, func(t *testing.T) {
@@ -21,19 +21,23 @@ type ApplicationConfiguration struct {
 	P2P P2P `yaml:"P2P"`
 
 	Pprof      BasicService `yaml:"Pprof"`
-	Prometheus BasicService `yaml:"Prometheus"`
+	Prometheus Prometheus   `yaml:"Prometheus"`
 
-	Relay             bool                `yaml:"Relay"`
-	Consensus         Consensus           `yaml:"Consensus"`
-	RPC               RPC                 `yaml:"RPC"`
-	Oracle            OracleConfiguration `yaml:"Oracle"`
-	P2PNotary         P2PNotary           `yaml:"P2PNotary"`
-	StateRoot         StateRoot           `yaml:"StateRoot"`
-	NeoFSBlockFetcher NeoFSBlockFetcher   `yaml:"NeoFSBlockFetcher"`
+	Relay              bool                `yaml:"Relay"`
+	Consensus          Consensus           `yaml:"Consensus"`
+	RPC                RPC                 `yaml:"RPC"`
+	Oracle             OracleConfiguration `yaml:"Oracle"`
+	P2PNotary          P2PNotary           `yaml:"P2PNotary"`
+	StateRoot          StateRoot           `yaml:"StateRoot"`
+	NeoFSBlockFetcher  NeoFSBlockFetcher   `yaml:"NeoFSBlockFetcher"`
+	NeoFSBlockUploader NeoFSBlockUploader  `yaml:"NeoFSBlockUploader"`
+	Webhook            Webhook             `yaml:"Webhook"`
+	ShadowValidation   ShadowValidation    `yaml:"ShadowValidation"`
 }
 
 // EqualsButServices returns true when the o is the same as a except for services
-// (Oracle, P2PNotary, Pprof, Prometheus, RPC and StateRoot sections)
+// (NeoFSBlockUploader, Oracle, P2PNotary, Pprof, Prometheus, RPC,
+// ShadowValidation, StateRoot and Webhook sections)
 // and LogLevel field.
 func (a *ApplicationConfiguration) EqualsButServices(o *ApplicationConfiguration) bool {
 	if len(a.P2P.Addresses) != len(o.P2P.Addresses) {
@@ -48,7 +52,7 @@ func (a *ApplicationConfiguration) EqualsButServices(o *ApplicationConfiguration
 	}
 	if a.P2P.AttemptConnPeers != o.P2P.AttemptConnPeers ||
 		a.P2P.BroadcastFactor != o.P2P.BroadcastFactor ||
-		a.DBConfiguration != o.DBConfiguration ||
+		!a.DBConfiguration.Equals(o.DBConfiguration) ||
 		a.P2P.DialTimeout != o.P2P.DialTimeout ||
 		a.P2P.ExtensiblePoolSize != o.P2P.ExtensiblePoolSize ||
 		a.LogPath != o.LogPath ||
@@ -150,5 +154,35 @@ func (a *ApplicationConfiguration) Validate() error {
 	if err := a.NeoFSBlockFetcher.Validate(); err != nil {
 		return fmt.Errorf("invalid NeoFSBlockFetcher config: %w", err)
 	}
+	if err := a.NeoFSBlockUploader.Validate(); err != nil {
+		return fmt.Errorf("invalid NeoFSBlockUploader config: %w", err)
+	}
+	if err := a.RPC.ValidateInvokeTiers(); err != nil {
+		return fmt.Errorf("invalid RPC invoke tiers: %w", err)
+	}
+	if err := a.RPC.StateArchive.Validate(); err != nil {
+		return fmt.Errorf("invalid RPC StateArchive config: %w", err)
+	}
+	if err := a.ShadowValidation.Validate(); err != nil {
+		return fmt.Errorf("invalid ShadowValidation config: %w", err)
+	}
+	if err := a.Webhook.Validate(); err != nil {
+		return fmt.Errorf("invalid Webhook config: %w", err)
+	}
+	if err := a.Oracle.Validate(); err != nil {
+		return fmt.Errorf("invalid Oracle config: %w", err)
+	}
+	if err := a.Prometheus.Health.Validate(); err != nil {
+		return fmt.Errorf("invalid Prometheus health check config: %w", err)
+	}
+	if err := a.Consensus.ExternalSigner.Validate(); err != nil {
+		return fmt.Errorf("invalid Consensus external signer config: %w", err)
+	}
+	if err := a.Consensus.Devnet.Validate(); err != nil {
+		return fmt.Errorf("invalid Consensus devnet config: %w", err)
+	}
+	if err := a.P2PNotary.ExternalSigner.Validate(); err != nil {
+		return fmt.Errorf("invalid P2PNotary external signer config: %w", err)
+	}
 	return nil
 }
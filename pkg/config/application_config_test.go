@@ -218,3 +218,168 @@ func TestNeoFSBlockFetcherValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestNeoFSBlockUploaderValidation(t *testing.T) {
+	cfg := NeoFSBlockUploader{}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Enabled = true
+	require.ErrorContains(t, cfg.Validate(), "container ID is not set")
+
+	cfg.ContainerID = "invalid-container-id"
+	require.ErrorContains(t, cfg.Validate(), "invalid container ID")
+
+	cfg.ContainerID = "9iVfUg8aDHKjPC4LhQXEkVUM4HDkR7UCXYLs8NQwYfSG"
+	require.ErrorContains(t, cfg.Validate(), "addresses are not set")
+
+	cfg.Addresses = []string{"127.0.0.1"}
+	require.ErrorContains(t, cfg.Validate(), "wallet is not set")
+
+	cfg.UnlockWallet.Path = "wallet.json"
+	require.NoError(t, cfg.Validate())
+}
+
+func TestRPCStateArchiveValidation(t *testing.T) {
+	cfg := RPCStateArchive{}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Enabled = true
+	require.ErrorContains(t, cfg.Validate(), "container ID is not set")
+
+	cfg.ContainerID = "invalid-container-id"
+	require.ErrorContains(t, cfg.Validate(), "invalid container ID")
+
+	cfg.ContainerID = "9iVfUg8aDHKjPC4LhQXEkVUM4HDkR7UCXYLs8NQwYfSG"
+	require.ErrorContains(t, cfg.Validate(), "addresses are not set")
+
+	cfg.Addresses = []string{"127.0.0.1"}
+	cfg.CacheSize = -1
+	require.ErrorContains(t, cfg.Validate(), "negative cache size")
+
+	cfg.CacheSize = 0
+	require.NoError(t, cfg.Validate())
+}
+
+func TestRPCInvokeTiersValidation(t *testing.T) {
+	cfg := RPC{}
+	require.NoError(t, cfg.ValidateInvokeTiers())
+
+	cfg.MaxInvokeTime = -time.Second
+	require.ErrorContains(t, cfg.ValidateInvokeTiers(), "negative MaxInvokeTime")
+
+	cfg.MaxInvokeTime = time.Second
+	cfg.InvokeTiers = []RPCInvokeTier{{Name: "gold"}}
+	require.ErrorContains(t, cfg.ValidateInvokeTiers(), "no API keys")
+
+	cfg.InvokeTiers[0].APIKeys = []string{""}
+	require.ErrorContains(t, cfg.ValidateInvokeTiers(), "empty API key")
+
+	cfg.InvokeTiers[0].APIKeys = []string{"key1"}
+	cfg.InvokeTiers[0].MaxOpcodesInvoke = -1
+	require.ErrorContains(t, cfg.ValidateInvokeTiers(), "negative limit")
+
+	cfg.InvokeTiers[0].MaxOpcodesInvoke = 0
+	cfg.InvokeTiers = append(cfg.InvokeTiers, RPCInvokeTier{Name: "silver", APIKeys: []string{"key2", "key1"}})
+	require.ErrorContains(t, cfg.ValidateInvokeTiers(), "API key is already used by gold tier")
+
+	cfg.InvokeTiers[1].APIKeys = []string{"key2"}
+	require.NoError(t, cfg.ValidateInvokeTiers())
+}
+
+func TestOracleConfigurationValidation(t *testing.T) {
+	cfg := OracleConfiguration{}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Policies = []OraclePolicy{{
+		URLPattern:          `^https://api\.example\.com/`,
+		MaxResponseSize:     1024,
+		AllowedContentTypes: []string{"application/json"},
+		RequireFilter:       true,
+		ValidationFilter:    "$.data",
+	}}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Policies = append(cfg.Policies, OraclePolicy{})
+	require.ErrorContains(t, cfg.Validate(), "policy #1: empty URLPattern")
+
+	cfg.Policies[1] = OraclePolicy{URLPattern: "(unclosed"}
+	require.ErrorContains(t, cfg.Validate(), "policy #1: invalid URLPattern")
+
+	cfg.Policies[1] = OraclePolicy{URLPattern: ".*", MaxResponseSize: -1}
+	require.ErrorContains(t, cfg.Validate(), "policy #1: negative MaxResponseSize")
+
+	a := ApplicationConfiguration{Oracle: cfg}
+	require.ErrorContains(t, a.Validate(), "invalid Oracle config")
+}
+
+func TestOracleProtocolsValidation(t *testing.T) {
+	cfg := OracleConfiguration{Protocols: map[string]OracleProtocol{
+		"ipfs": {Gateway: "https://ipfs.io/ipfs/", Timeout: time.Second, MaxResponseSize: 1024},
+	}}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Protocols["Bad_Scheme"] = OracleProtocol{}
+	require.ErrorContains(t, cfg.Validate(), "invalid protocol scheme")
+	delete(cfg.Protocols, "Bad_Scheme")
+
+	cfg.Protocols["https"] = OracleProtocol{}
+	require.ErrorContains(t, cfg.Validate(), "built-in handler can't be replaced")
+	delete(cfg.Protocols, "https")
+
+	cfg.Protocols["ftps"] = OracleProtocol{Timeout: -1}
+	require.ErrorContains(t, cfg.Validate(), "protocol ftps: negative Timeout")
+
+	cfg.Protocols["ftps"] = OracleProtocol{MaxResponseSize: -1}
+	require.ErrorContains(t, cfg.Validate(), "protocol ftps: negative MaxResponseSize")
+}
+
+func TestWebhookValidation(t *testing.T) {
+	cfg := Webhook{}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Enabled = true
+	require.ErrorContains(t, cfg.Validate(), "no endpoints set")
+
+	cfg.Endpoints = []WebhookEndpoint{{URL: "ftp://example.com/hook"}}
+	require.ErrorContains(t, cfg.Validate(), "endpoint #0: unsupported scheme")
+
+	cfg.Endpoints[0].URL = "https://example.com/hook"
+	require.ErrorContains(t, cfg.Validate(), "neither addresses nor contracts are set")
+
+	cfg.Contracts = []string{"ef4073a0f2b305a38ec4050e4d3d28bc40ea63f5"}
+	cfg.RetryInterval = -time.Second
+	require.ErrorContains(t, cfg.Validate(), "negative durations")
+
+	cfg.RetryInterval = time.Second
+	cfg.MaxRetries = -1
+	require.ErrorContains(t, cfg.Validate(), "negative MaxRetries")
+
+	cfg.MaxRetries = 3
+	require.NoError(t, cfg.Validate())
+
+	cfg.QueueSize = -1
+	a := ApplicationConfiguration{Webhook: cfg}
+	require.ErrorContains(t, a.Validate(), "invalid Webhook config")
+}
+
+func TestShadowValidationValidation(t *testing.T) {
+	cfg := ShadowValidation{}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Enabled = true
+	require.ErrorContains(t, cfg.Validate(), "reference RPC is not set")
+
+	cfg.ReferenceRPC = "ws://localhost:10332/ws"
+	require.ErrorContains(t, cfg.Validate(), "unsupported scheme")
+
+	cfg.ReferenceRPC = "http://localhost:10332"
+	cfg.Samples = -1
+	require.ErrorContains(t, cfg.Validate(), "negative Samples")
+
+	cfg.Samples = 0
+	require.NoError(t, cfg.Validate())
+
+	cfg.Timeout = -time.Second
+	a := ApplicationConfiguration{ShadowValidation: cfg}
+	require.ErrorContains(t, a.Validate(), "invalid ShadowValidation config")
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	cid "github.com/nspcc-dev/neofs-sdk-go/container/id"
+)
+
+// NeoFSBlockUploader represents the configuration for the NeoFS BlockUploader service.
+type NeoFSBlockUploader struct {
+	InternalService    `yaml:",inline"`
+	Timeout            time.Duration `yaml:"Timeout"`
+	ContainerID        string        `yaml:"ContainerID"`
+	Addresses          []string      `yaml:"Addresses"`
+	BlockAttribute     string        `yaml:"BlockAttribute"`
+	IndexFileAttribute string        `yaml:"IndexFileAttribute"`
+	IndexFileSize      uint32        `yaml:"IndexFileSize"`
+	WorkersCount       int           `yaml:"WorkersCount"`
+	StateAttribute     string        `yaml:"StateAttribute"`
+	StateDumpInterval  uint32        `yaml:"StateDumpInterval"`
+}
+
+// Validate checks NeoFSBlockUploader for internal consistency and ensures
+// that all required fields are properly set. It returns an error if the
+// configuration is invalid or if the ContainerID cannot be properly decoded.
+func (cfg *NeoFSBlockUploader) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.ContainerID == "" {
+		return errors.New("container ID is not set")
+	}
+	var containerID cid.ID
+	err := containerID.DecodeString(cfg.ContainerID)
+	if err != nil {
+		return fmt.Errorf("invalid container ID: %w", err)
+	}
+	if len(cfg.Addresses) == 0 {
+		return errors.New("addresses are not set")
+	}
+	if cfg.UnlockWallet.Path == "" {
+		return errors.New("wallet is not set")
+	}
+	return nil
+}
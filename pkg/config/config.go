@@ -155,8 +155,13 @@ func updateRelativePaths(relativePath string, config *Config) {
 	updatePath(&config.ApplicationConfiguration.LogPath)
 	updatePath(&config.ApplicationConfiguration.DBConfiguration.BoltDBOptions.FilePath)
 	updatePath(&config.ApplicationConfiguration.DBConfiguration.LevelDBOptions.DataDirectoryPath)
+	for i := range config.ApplicationConfiguration.DBConfiguration.Partitions {
+		updatePath(&config.ApplicationConfiguration.DBConfiguration.Partitions[i].BoltDBOptions.FilePath)
+		updatePath(&config.ApplicationConfiguration.DBConfiguration.Partitions[i].LevelDBOptions.DataDirectoryPath)
+	}
 	updatePath(&config.ApplicationConfiguration.Consensus.UnlockWallet.Path)
 	updatePath(&config.ApplicationConfiguration.P2PNotary.UnlockWallet.Path)
 	updatePath(&config.ApplicationConfiguration.Oracle.UnlockWallet.Path)
 	updatePath(&config.ApplicationConfiguration.StateRoot.UnlockWallet.Path)
+	updatePath(&config.ApplicationConfiguration.RPC.ResultSigning.UnlockWallet.Path)
 }
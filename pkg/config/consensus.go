@@ -1,4 +1,40 @@
 package config
 
+import (
+	"errors"
+	"time"
+)
+
 // Consensus contains consensus service configuration.
-type Consensus InternalService
+type Consensus struct {
+	Enabled      bool   `yaml:"Enabled"`
+	UnlockWallet Wallet `yaml:"UnlockWallet"`
+	// ExternalSigner allows to use a remote signing service for block and
+	// consensus payload signatures, UnlockWallet keys (if any) are used as
+	// a fallback then.
+	ExternalSigner ExternalSigner `yaml:"ExternalSigner"`
+	// Devnet replaces dBFT with a single-node block producer.
+	Devnet Devnet `yaml:"Devnet"`
+}
+
+// Devnet configures single-node block production mode intended for local
+// development and testing. In this mode blocks are sealed by the node itself
+// without any dBFT message exchange, so it must have keys for enough
+// validators to sign blocks.
+type Devnet struct {
+	Enabled bool `yaml:"Enabled"`
+	// Interval is a period between produced blocks, zero value means that
+	// blocks are only produced on demand.
+	Interval time.Duration `yaml:"Interval"`
+	// InstantSeal makes the node produce a block as soon as there are
+	// transactions in the memory pool.
+	InstantSeal bool `yaml:"InstantSeal"`
+}
+
+// Validate checks Devnet for internal consistency.
+func (d Devnet) Validate() error {
+	if d.Interval < 0 {
+		return errors.New("negative Interval")
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+// ExternalSigner stores configuration of a remote signing service that
+// holds node keys instead of a local wallet (it can be an HSM frontend,
+// for example). The signer is used if Endpoint is not empty.
+type ExternalSigner struct {
+	// Endpoint is an HTTP(S) URL of the signing service.
+	Endpoint string `yaml:"Endpoint"`
+	// PublicKeys is a list of hex-encoded compressed public keys that
+	// the signing service has private keys for.
+	PublicKeys []string `yaml:"PublicKeys"`
+	// Timeout is a timeout for a single signing request.
+	Timeout time.Duration `yaml:"Timeout"`
+}
+
+// IsEnabled returns whether the external signer is configured.
+func (e ExternalSigner) IsEnabled() bool {
+	return e.Endpoint != ""
+}
+
+// Validate checks ExternalSigner for internal consistency.
+func (e ExternalSigner) Validate() error {
+	if !e.IsEnabled() {
+		return nil
+	}
+	if len(e.PublicKeys) == 0 {
+		return errors.New("no PublicKeys")
+	}
+	for i, s := range e.PublicKeys {
+		if _, err := keys.NewPublicKeyFromString(s); err != nil {
+			return fmt.Errorf("invalid public key #%d: %w", i, err)
+		}
+	}
+	if e.Timeout < 0 {
+		return errors.New("negative Timeout")
+	}
+	return nil
+}
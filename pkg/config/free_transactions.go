@@ -0,0 +1,49 @@
+package config
+
+import (
+	"errors"
+)
+
+// FreeTransactions contains settings of the free (zero-fee) transactions
+// extension. Transactions with zero system and network fees are accepted if
+// their sender has enough GAS deposited to the native Notary contract, a part
+// of this deposit is slashed for every free transaction that fails. It is
+// NeoGo extension intended for private networks only, it requires
+// P2PSigExtensions to be enabled.
+type FreeTransactions struct {
+	// Enabled turns free transactions processing on.
+	Enabled bool `yaml:"Enabled"`
+	// Deposit is the amount of sender's Notary deposit (in GAS fractions)
+	// that is reserved for every free transaction in the memory pool and
+	// slashed if this transaction fails.
+	Deposit int64 `yaml:"Deposit"`
+	// VerificationGAS is the amount of GAS (in fractions) available for free
+	// transaction witnesses verification.
+	VerificationGAS int64 `yaml:"VerificationGAS"`
+	// ExecutionGAS is the amount of GAS (in fractions) available for free
+	// transaction script execution. It's counted against MaxBlockSystemFee.
+	ExecutionGAS int64 `yaml:"ExecutionGAS"`
+}
+
+// Validate checks FreeTransactions settings for internal consistency.
+func (f *FreeTransactions) Validate(p *ProtocolConfiguration) error {
+	if !f.Enabled {
+		return nil
+	}
+	if !p.P2PSigExtensions {
+		return errors.New("FreeTransactions require P2PSigExtensions to be enabled")
+	}
+	if f.Deposit <= 0 {
+		return errors.New("FreeTransactions: Deposit must be positive")
+	}
+	if f.VerificationGAS <= 0 {
+		return errors.New("FreeTransactions: VerificationGAS must be positive")
+	}
+	if f.ExecutionGAS <= 0 {
+		return errors.New("FreeTransactions: ExecutionGAS must be positive")
+	}
+	if p.MaxBlockSystemFee > 0 && f.ExecutionGAS > p.MaxBlockSystemFee {
+		return errors.New("FreeTransactions: ExecutionGAS can't exceed MaxBlockSystemFee")
+	}
+	return nil
+}
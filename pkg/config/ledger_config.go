@@ -1,9 +1,19 @@
 package config
 
+import "time"
+
 // Ledger contains core node-specific settings that are not
 // a part of the ProtocolConfiguration (which is common for every node on the
 // network).
 type Ledger struct {
+	// GarbageCollectionBatchDelay is the pause made by the background garbage
+	// collector after every GarbageCollectionBatchSize keys processed, it
+	// allows to throttle old data removal. Zero (default) means no pause.
+	GarbageCollectionBatchDelay time.Duration `yaml:"GarbageCollectionBatchDelay"`
+	// GarbageCollectionBatchSize is the maximum number of keys processed by
+	// the background garbage collector at once, persist can't happen while
+	// a batch is being processed.
+	GarbageCollectionBatchSize int `yaml:"GarbageCollectionBatchSize"`
 	// GarbageCollectionPeriod sets the number of blocks to wait before
 	// starting the next MPT garbage collection cycle when RemoveUntraceableBlocks
 	// option is used.
@@ -12,6 +22,16 @@ type Ledger struct {
 	// If true, DB size will be smaller, but older roots won't be accessible.
 	// This value should remain the same for the same database.
 	KeepOnlyLatestState bool `yaml:"KeepOnlyLatestState"`
+	// KeepRecentStateRoots is the number of the latest state roots which MPT
+	// nodes are kept in the storage when KeepOnlyLatestState is enabled. It
+	// allows to get proofs and states for recent roots. Zero (default) means
+	// that only the current state is stored.
+	KeepRecentStateRoots uint32 `yaml:"KeepRecentStateRoots"`
+	// MPTNodeCacheSize is the size (in megabytes) of in-memory cache of
+	// deserialized MPT nodes. It's only used when the whole MPT history is
+	// stored (KeepOnlyLatestState and RemoveUntraceableBlocks are disabled).
+	// Zero (default) disables the cache.
+	MPTNodeCacheSize int `yaml:"MPTNodeCacheSize"`
 	// RemoveUntraceableBlocks specifies if old data should be removed.
 	RemoveUntraceableBlocks bool `yaml:"RemoveUntraceableBlocks"`
 	// RemoveUntraceableHeaders is used in addition to RemoveUntraceableBlocks
@@ -24,6 +44,29 @@ type Ledger struct {
 	SkipBlockVerification bool `yaml:"SkipBlockVerification"`
 	// SaveInvocations enables smart contract invocation data saving.
 	SaveInvocations bool `yaml:"SaveInvocations"`
+	// IndexEvents enables indexing of notifications by values of event
+	// parameters marked as indexed in contract manifests.
+	IndexEvents bool `yaml:"IndexEvents"`
+	// IndexEventContainers enables indexing of transactions (and blocks) by
+	// names of events emitted by them.
+	IndexEventContainers bool `yaml:"IndexEventContainers"`
+	// IndexTransfers enables indexing of NEP-11/NEP-17 transfer logs by
+	// asset and counterparty.
+	IndexTransfers bool `yaml:"IndexTransfers"`
+	// TrackStorageUsage enables maintaining per-contract storage usage
+	// statistics (number of items, their size and last modification block).
+	TrackStorageUsage bool `yaml:"TrackStorageUsage"`
+	// TransactionFilter enables in-memory filter of stored transaction hashes
+	// used to avoid DB reads for transaction existence checks.
+	TransactionFilter bool `yaml:"TransactionFilter"`
+	// VerificationWorkers is the number of goroutines verifying transaction
+	// witnesses of received blocks in parallel. Zero (default) and one mean
+	// sequential verification.
+	VerificationWorkers int `yaml:"VerificationWorkers"`
+	// TransferLogRetention is the period NEP-11/NEP-17 transfer logs are kept
+	// for (based on block timestamps), older transfers are removed every
+	// GarbageCollectionPeriod blocks. Zero (default) means no limit.
+	TransferLogRetention time.Duration `yaml:"TransferLogRetention"`
 }
 
 // Blockchain is a set of settings for core.Blockchain to use, it includes protocol
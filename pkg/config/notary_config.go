@@ -1,4 +1,10 @@
 package config
 
 // P2PNotary stores configuration for Notary node service.
-type P2PNotary InternalService
+type P2PNotary struct {
+	Enabled      bool   `yaml:"Enabled"`
+	UnlockWallet Wallet `yaml:"UnlockWallet"`
+	// ExternalSigner allows to use a remote signing service instead of
+	// the UnlockWallet for Notary signatures.
+	ExternalSigner ExternalSigner `yaml:"ExternalSigner"`
+}
@@ -1,6 +1,11 @@
 package config
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
 
 // OracleConfiguration is a config for the oracle module.
 type OracleConfiguration struct {
@@ -15,6 +20,10 @@ type OracleConfiguration struct {
 	RequestTimeout        time.Duration      `yaml:"RequestTimeout"`
 	ResponseTimeout       time.Duration      `yaml:"ResponseTimeout"`
 	UnlockWallet          Wallet             `yaml:"UnlockWallet"`
+	Policies              []OraclePolicy     `yaml:"Policies"`
+	// Protocols contains handler configurations for additional URL schemes
+	// (beyond built-in https and neofs), map keys are schemes.
+	Protocols map[string]OracleProtocol `yaml:"Protocols"`
 }
 
 // NeoFSConfiguration is a config for the NeoFS service.
@@ -22,3 +31,87 @@ type NeoFSConfiguration struct {
 	Nodes   []string      `yaml:"Nodes"`
 	Timeout time.Duration `yaml:"Timeout"`
 }
+
+// OraclePolicy is a set of additional checks applied to the responses for
+// requests with URLs matching URLPattern. These checks are performed before
+// the response is signed, a response that doesn't pass them is replaced with
+// an error one.
+type OraclePolicy struct {
+	// URLPattern is a regular expression matched against the whole request
+	// URL. The first matching policy is used for every request.
+	URLPattern string `yaml:"URLPattern"`
+	// MaxResponseSize is the maximum allowed response size in bytes, zero
+	// means the protocol limit.
+	MaxResponseSize int `yaml:"MaxResponseSize"`
+	// AllowedContentTypes is a list of allowed MIME types for https
+	// responses, it's checked in addition to the global one.
+	AllowedContentTypes []string `yaml:"AllowedContentTypes"`
+	// RequireFilter rejects requests without filter and responses for which
+	// the request filter selects nothing.
+	RequireFilter bool `yaml:"RequireFilter"`
+	// ValidationFilter is a JSONPath expression that must select at least
+	// one element from the response.
+	ValidationFilter string `yaml:"ValidationFilter"`
+}
+
+// OracleProtocol is a configuration of the oracle handler for an additional
+// URL scheme.
+type OracleProtocol struct {
+	// Handler is the name of the protocol handler type registered in the
+	// oracle service, "gateway" is used if not set.
+	Handler string `yaml:"Handler"`
+	// Gateway is an HTTP(S) URL prefix used by the "gateway" handler, the
+	// request URL without the scheme is appended to it.
+	Gateway string `yaml:"Gateway"`
+	// AllowPrivateHost allows the handler to connect to private network
+	// addresses (it's useful for internal gateways).
+	AllowPrivateHost bool `yaml:"AllowPrivateHost"`
+	// Timeout is the request timeout, RequestTimeout is used if not set.
+	Timeout time.Duration `yaml:"Timeout"`
+	// MaxResponseSize is the maximum allowed response size in bytes, zero
+	// means the protocol limit.
+	MaxResponseSize int `yaml:"MaxResponseSize"`
+	// Parameters contains additional handler-specific settings.
+	Parameters map[string]string `yaml:"Parameters"`
+}
+
+// schemePattern matches valid URL schemes (RFC 3986, section 3.1).
+var schemePattern = regexp.MustCompile(`^[a-z][a-z0-9+.-]*$`)
+
+// Validate checks OracleConfiguration for internal consistency.
+func (c *OracleConfiguration) Validate() error {
+	for i, p := range c.Policies {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("policy #%d: %w", i, err)
+		}
+	}
+	for scheme, p := range c.Protocols {
+		if !schemePattern.MatchString(scheme) {
+			return fmt.Errorf("invalid protocol scheme %q", scheme)
+		}
+		if scheme == "https" || scheme == "neofs" {
+			return fmt.Errorf("protocol %s: built-in handler can't be replaced", scheme)
+		}
+		if p.Timeout < 0 {
+			return fmt.Errorf("protocol %s: negative Timeout", scheme)
+		}
+		if p.MaxResponseSize < 0 {
+			return fmt.Errorf("protocol %s: negative MaxResponseSize", scheme)
+		}
+	}
+	return nil
+}
+
+// Validate checks OraclePolicy for internal consistency.
+func (p *OraclePolicy) Validate() error {
+	if len(p.URLPattern) == 0 {
+		return errors.New("empty URLPattern")
+	}
+	if _, err := regexp.Compile(p.URLPattern); err != nil {
+		return fmt.Errorf("invalid URLPattern: %w", err)
+	}
+	if p.MaxResponseSize < 0 {
+		return errors.New("negative MaxResponseSize")
+	}
+	return nil
+}
@@ -11,9 +11,39 @@ type P2P struct {
 	BroadcastFactor    int           `yaml:"BroadcastFactor"`
 	DialTimeout        time.Duration `yaml:"DialTimeout"`
 	ExtensiblePoolSize int           `yaml:"ExtensiblePoolSize"`
-	MaxPeers           int           `yaml:"MaxPeers"`
-	MinPeers           int           `yaml:"MinPeers"`
-	PingInterval       time.Duration `yaml:"PingInterval"`
-	PingTimeout        time.Duration `yaml:"PingTimeout"`
-	ProtoTickInterval  time.Duration `yaml:"ProtoTickInterval"`
+	// KnownNotaryRequestCacheSize is the number of recently received P2P
+	// notary request hashes remembered to avoid processing them again.
+	KnownNotaryRequestCacheSize int `yaml:"KnownNotaryRequestCacheSize"`
+	// KnownTxCacheSize is the number of recently received transaction hashes
+	// remembered to avoid requesting and processing them again.
+	KnownTxCacheSize int `yaml:"KnownTxCacheSize"`
+	MaxPeers         int `yaml:"MaxPeers"`
+	MinPeers         int `yaml:"MinPeers"`
+	// PeerListPath is the file good peer addresses are saved to, so that
+	// they can be used to reconnect after node restart. Peers are not saved
+	// if it's empty.
+	PeerListPath      string        `yaml:"PeerListPath"`
+	PingInterval      time.Duration `yaml:"PingInterval"`
+	PingTimeout       time.Duration `yaml:"PingTimeout"`
+	ProtoTickInterval time.Duration `yaml:"ProtoTickInterval"`
+	// Reputation contains peer reputation tracking settings.
+	Reputation PeerReputation `yaml:"Reputation"`
+	// SeedRefreshInterval is the interval between DNS seed resolutions (and
+	// peer list saves).
+	SeedRefreshInterval time.Duration `yaml:"SeedRefreshInterval"`
+	// TxRebroadcastInterval is the interval between announcements of
+	// transactions relayed via this node until they're accepted into a block
+	// or expire. Rebroadcasting is disabled if it's zero.
+	TxRebroadcastInterval time.Duration `yaml:"TxRebroadcastInterval"`
+}
+
+// PeerReputation holds peer reputation tracking settings.
+type PeerReputation struct {
+	// Enabled turns on peer scoring and banning.
+	Enabled bool `yaml:"Enabled"`
+	// BanDuration is the time persistently misbehaving peers are banned for.
+	BanDuration time.Duration `yaml:"BanDuration"`
+	// BanListPath is the file banned peers are saved to, so that bans survive
+	// node restarts. Bans are kept in memory only if it's empty.
+	BanListPath string `yaml:"BanListPath"`
 }
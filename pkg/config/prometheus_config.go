@@ -0,0 +1,44 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// Prometheus is the configuration of Prometheus metrics service.
+type Prometheus struct {
+	BasicService `yaml:",inline"`
+	// Health contains thresholds for /health and /ready endpoints served
+	// along with metrics.
+	Health HealthCheck `yaml:"Health"`
+}
+
+// HealthCheck contains thresholds used by node health (liveness) and readiness
+// checks. Zero values disable the corresponding checks.
+type HealthCheck struct {
+	// MaxBlockAge is the maximum time since the node has accepted the last
+	// block, the node is not healthy if its chain doesn't grow longer than
+	// that.
+	MaxBlockAge time.Duration `yaml:"MaxBlockAge"`
+	// MaxHeaderLag is the maximum difference between header and block
+	// heights for the node to be ready.
+	MaxHeaderLag uint32 `yaml:"MaxHeaderLag"`
+	// MinPeers is the minimum number of connected peers for the node to be
+	// ready.
+	MinPeers int `yaml:"MinPeers"`
+	// MaxStateRootLag is the maximum difference between local and validated
+	// state root heights for the node to be ready. It's only checked if
+	// state roots are validated (validated height is not zero).
+	MaxStateRootLag uint32 `yaml:"MaxStateRootLag"`
+}
+
+// Validate checks HealthCheck for internal consistency.
+func (h *HealthCheck) Validate() error {
+	if h.MaxBlockAge < 0 {
+		return errors.New("negative MaxBlockAge")
+	}
+	if h.MinPeers < 0 {
+		return errors.New("negative MinPeers")
+	}
+	return nil
+}
@@ -9,18 +9,35 @@ import (
 	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 )
 
 // ProtocolConfiguration represents the protocol config.
 type (
 	ProtocolConfiguration struct {
+		// AddressVersion is the version byte of addresses, zero value means
+		// the standard one (0x35).
+		AddressVersion byte `yaml:"AddressVersion"`
+		// AddressBech32HRP enables experimental bech32m addresses with the
+		// given human-readable part (in addition to the regular ones).
+		AddressBech32HRP string `yaml:"AddressBech32HRP"`
 		// CommitteeHistory stores committee size change history (height: size).
 		CommitteeHistory map[uint32]uint32 `yaml:"CommitteeHistory"`
+		// ContractFeeExemptions enables native Policy methods allowing the
+		// committee to reduce system fee for specific contract methods.
+		ContractFeeExemptions bool `yaml:"ContractFeeExemptions"`
 		// Genesis stores genesis-related settings including a set of NeoGo
 		// extensions that should be included into genesis block or be enabled
 		// at the moment of native contracts initialization.
 		Genesis Genesis `yaml:"Genesis"`
+		// FreeTransactions contains settings of the free (zero-fee)
+		// transactions extension for private networks.
+		FreeTransactions FreeTransactions `yaml:"FreeTransactions"`
+		// GovernanceEvents enables additional native NEO contract
+		// notifications about committee, validators and candidate votes
+		// changes.
+		GovernanceEvents bool `yaml:"GovernanceEvents"`
 
 		Magic       netmode.Magic `yaml:"Magic"`
 		MemPoolSize int           `yaml:"MemPoolSize"`
@@ -85,6 +102,14 @@ func (p *ProtocolConfiguration) Validate() error {
 	if p.TimePerBlock%time.Millisecond != 0 {
 		return errors.New("TimePerBlock must be an integer number of milliseconds")
 	}
+	if p.AddressVersion == address.NEO2Prefix {
+		return errors.New("AddressVersion can't be the Neo Legacy one")
+	}
+	if p.AddressBech32HRP != "" {
+		if err := address.ValidateBech32HRP(p.AddressBech32HRP); err != nil {
+			return fmt.Errorf("invalid AddressBech32HRP: %w", err)
+		}
+	}
 	for name := range p.Hardforks {
 		if !IsHardforkValid(name) {
 			return fmt.Errorf("Hardforks configuration section contains unexpected hardfork: %s", name)
@@ -108,6 +133,9 @@ func (p *ProtocolConfiguration) Validate() error {
 			shouldBeDisabled = true
 		}
 	}
+	if err := p.FreeTransactions.Validate(p); err != nil {
+		return err
+	}
 	if p.ValidatorsCount != 0 && len(p.ValidatorsHistory) != 0 || p.ValidatorsCount == 0 && len(p.ValidatorsHistory) == 0 {
 		return errors.New("configuration should either have one of ValidatorsCount or ValidatorsHistory, not both")
 	}
@@ -211,10 +239,31 @@ func (p *ProtocolConfiguration) ShouldUpdateCommitteeAt(height uint32) bool {
 	return height%uint32(p.GetCommitteeSize(height)) == 0
 }
 
+// GetAddressVersion returns the version byte of addresses.
+func (p *ProtocolConfiguration) GetAddressVersion() byte {
+	if p.AddressVersion == 0 {
+		return address.NEO3Prefix
+	}
+	return p.AddressVersion
+}
+
+// ApplyAddressSettings sets address version and bech32m human-readable part
+// used by the address package according to the configuration. These are
+// process-wide settings.
+func (p *ProtocolConfiguration) ApplyAddressSettings() {
+	address.Prefix = p.GetAddressVersion()
+	address.Bech32HRP = p.AddressBech32HRP
+}
+
 // Equals allows to compare two ProtocolConfiguration instances, returns true if
 // they're equal.
 func (p *ProtocolConfiguration) Equals(o *ProtocolConfiguration) bool {
-	if p.InitialGASSupply != o.InitialGASSupply ||
+	if p.AddressBech32HRP != o.AddressBech32HRP ||
+		p.AddressVersion != o.AddressVersion ||
+		p.ContractFeeExemptions != o.ContractFeeExemptions ||
+		p.FreeTransactions != o.FreeTransactions ||
+		p.GovernanceEvents != o.GovernanceEvents ||
+		p.InitialGASSupply != o.InitialGASSupply ||
 		p.Magic != o.Magic ||
 		p.MaxBlockSize != o.MaxBlockSize ||
 		p.MaxBlockSystemFee != o.MaxBlockSystemFee ||
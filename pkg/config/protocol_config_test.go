@@ -150,6 +150,64 @@ func TestProtocolConfigurationValidation(t *testing.T) {
 	require.Contains(t, err.Error(), "configuration should either have one of ValidatorsCount or ValidatorsHistory, not both")
 }
 
+func TestProtocolConfigurationValidation_Address(t *testing.T) {
+	p := &ProtocolConfiguration{
+		StandbyCommittee: []string{"02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2"},
+		ValidatorsCount:  1,
+	}
+	require.NoError(t, p.Validate())
+	require.EqualValues(t, 0x35, p.GetAddressVersion())
+
+	p.AddressVersion = 0x17
+	require.Error(t, p.Validate())
+
+	p.AddressVersion = 0x42
+	require.NoError(t, p.Validate())
+	require.EqualValues(t, 0x42, p.GetAddressVersion())
+
+	p.AddressBech32HRP = "Neo"
+	require.Error(t, p.Validate())
+
+	p.AddressBech32HRP = "neo"
+	require.NoError(t, p.Validate())
+}
+
+func TestProtocolConfigurationValidation_FreeTransactions(t *testing.T) {
+	p := &ProtocolConfiguration{
+		StandbyCommittee: []string{
+			"02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2",
+		},
+		ValidatorsCount:   1,
+		MaxBlockSystemFee: 1000,
+		FreeTransactions: FreeTransactions{
+			Enabled:         true,
+			Deposit:         100,
+			VerificationGAS: 10,
+			ExecutionGAS:    100,
+		},
+	}
+	err := p.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "P2PSigExtensions")
+
+	p.P2PSigExtensions = true
+	require.NoError(t, p.Validate())
+
+	p.FreeTransactions.ExecutionGAS = 1001
+	require.Error(t, p.Validate())
+	p.FreeTransactions.ExecutionGAS = 0
+	require.Error(t, p.Validate())
+	p.FreeTransactions.ExecutionGAS = 100
+	p.FreeTransactions.VerificationGAS = 0
+	require.Error(t, p.Validate())
+	p.FreeTransactions.VerificationGAS = 10
+	p.FreeTransactions.Deposit = -1
+	require.Error(t, p.Validate())
+
+	p.FreeTransactions.Enabled = false
+	require.NoError(t, p.Validate())
+}
+
 func TestProtocolConfigurationValidation_Hardforks(t *testing.T) {
 	p := &ProtocolConfiguration{
 		Hardforks: map[string]uint32{
@@ -296,6 +354,17 @@ func TestProtocolConfigurationEquals(t *testing.T) {
 	require.True(t, p.Equals(o))
 	p.ValidatorsHistory = map[uint32]uint32{112: 0}
 	require.False(t, p.Equals(o))
+
+	p.ValidatorsHistory = nil
+	o.ValidatorsHistory = nil
+
+	p.AddressVersion = 0x42
+	require.False(t, p.Equals(o))
+	o.AddressVersion = 0x42
+	require.True(t, p.Equals(o))
+
+	p.AddressBech32HRP = "neo"
+	require.False(t, p.Equals(o))
 }
 
 func TestGenesisExtensionsMarshalYAML(t *testing.T) {
@@ -1,7 +1,12 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	cid "github.com/nspcc-dev/neofs-sdk-go/container/id"
 )
 
 type (
@@ -9,22 +14,80 @@ type (
 	RPC struct {
 		BasicService         `yaml:",inline"`
 		EnableCORSWorkaround bool `yaml:"EnableCORSWorkaround"`
+		// InvokeTiers override test invocation limits for clients
+		// authenticated with API keys.
+		InvokeTiers []RPCInvokeTier `yaml:"InvokeTiers"`
 		// MaxGasInvoke is the maximum amount of GAS which
 		// can be spent during an RPC call.
-		MaxGasInvoke              fixedn.Fixed8 `yaml:"MaxGasInvoke"`
-		MaxIteratorResultItems    int           `yaml:"MaxIteratorResultItems"`
-		MaxFindResultItems        int           `yaml:"MaxFindResultItems"`
-		MaxFindStorageResultItems int           `yaml:"MaxFindStoragePageSize"`
-		MaxNEP11Tokens            int           `yaml:"MaxNEP11Tokens"`
-		MaxRequestBodyBytes       int           `yaml:"MaxRequestBodyBytes"`
-		MaxRequestHeaderBytes     int           `yaml:"MaxRequestHeaderBytes"`
-		MaxWebSocketClients       int           `yaml:"MaxWebSocketClients"`
-		SessionEnabled            bool          `yaml:"SessionEnabled"`
-		SessionExpirationTime     int           `yaml:"SessionExpirationTime"`
-		SessionBackedByMPT        bool          `yaml:"SessionBackedByMPT"`
-		SessionPoolSize           int           `yaml:"SessionPoolSize"`
-		StartWhenSynchronized     bool          `yaml:"StartWhenSynchronized"`
-		TLSConfig                 TLS           `yaml:"TLSConfig"`
+		MaxGasInvoke              fixedn.Fixed8   `yaml:"MaxGasInvoke"`
+		MaxInvokeTime             time.Duration   `yaml:"MaxInvokeTime"`
+		MaxIteratorResultItems    int             `yaml:"MaxIteratorResultItems"`
+		MaxFindResultItems        int             `yaml:"MaxFindResultItems"`
+		MaxFindStorageResultItems int             `yaml:"MaxFindStoragePageSize"`
+		MaxNEP11Tokens            int             `yaml:"MaxNEP11Tokens"`
+		MaxOpcodesInvoke          int64           `yaml:"MaxOpcodesInvoke"`
+		MaxRequestBodyBytes       int             `yaml:"MaxRequestBodyBytes"`
+		MaxRequestHeaderBytes     int             `yaml:"MaxRequestHeaderBytes"`
+		MaxWebSocketClients       int             `yaml:"MaxWebSocketClients"`
+		Redaction                 RPCRedaction    `yaml:"Redaction"`
+		ResultSigning             RPCSigning      `yaml:"ResultSigning"`
+		SessionEnabled            bool            `yaml:"SessionEnabled"`
+		SessionExpirationTime     int             `yaml:"SessionExpirationTime"`
+		SessionBackedByMPT        bool            `yaml:"SessionBackedByMPT"`
+		SessionPoolSize           int             `yaml:"SessionPoolSize"`
+		StartWhenSynchronized     bool            `yaml:"StartWhenSynchronized"`
+		StateArchive              RPCStateArchive `yaml:"StateArchive"`
+		TLSConfig                 TLS             `yaml:"TLSConfig"`
+	}
+
+	// RPCInvokeTier specifies test invocation limits for RPC clients using
+	// one of the given API keys (passed in "Authorization: Bearer <key>"
+	// HTTP header). Zero limits are taken from the RPC configuration.
+	RPCInvokeTier struct {
+		Name                   string        `yaml:"Name"`
+		APIKeys                []string      `yaml:"APIKeys"`
+		MaxGasInvoke           fixedn.Fixed8 `yaml:"MaxGasInvoke"`
+		MaxInvokeTime          time.Duration `yaml:"MaxInvokeTime"`
+		MaxIteratorResultItems int           `yaml:"MaxIteratorResultItems"`
+		MaxOpcodesInvoke       int64         `yaml:"MaxOpcodesInvoke"`
+	}
+
+	// RPCRedaction specifies response data hidden from RPC clients, it
+	// allows to avoid exposing network topology details via public
+	// endpoints.
+	RPCRedaction struct {
+		// PeerAddresses hides addresses and ports of peers in getpeers
+		// responses, other peer data is still returned.
+		PeerAddresses bool `yaml:"PeerAddresses"`
+		// SeedList hides the seed list in getversion responses and
+		// seed nodes in getpeers responses.
+		SeedList bool `yaml:"SeedList"`
+	}
+
+	// RPCSigning specifies RPC methods which results are signed by the node
+	// key, it allows clients trusting this key to detect responses tampered
+	// with by intermediaries.
+	RPCSigning struct {
+		// Methods is a list of RPC methods to sign results of, no results
+		// are signed if it's empty.
+		Methods []string `yaml:"Methods"`
+		// UnlockWallet is the wallet containing the signing key, the first
+		// account that can be decrypted with the password is used.
+		UnlockWallet Wallet `yaml:"UnlockWallet"`
+	}
+
+	// RPCStateArchive configures on-demand reconstruction of historic states
+	// removed from the node DB (see RemoveUntraceableBlocks) for historic
+	// invocations. States are reconstructed from the state dumps stored in
+	// NeoFS container by NeoFSBlockUploader service.
+	RPCStateArchive struct {
+		Enabled        bool          `yaml:"Enabled"`
+		Timeout        time.Duration `yaml:"Timeout"`
+		ContainerID    string        `yaml:"ContainerID"`
+		Addresses      []string      `yaml:"Addresses"`
+		StateAttribute string        `yaml:"StateAttribute"`
+		// CacheSize is the number of reconstructed states kept in memory.
+		CacheSize int `yaml:"CacheSize"`
 	}
 
 	// TLS describes SSL/TLS configuration.
@@ -34,3 +97,53 @@ type (
 		KeyFile      string `yaml:"KeyFile"`
 	}
 )
+
+// ValidateInvokeTiers checks RPC invocation limits for internal consistency,
+// every API key must belong to a single tier.
+func (cfg *RPC) ValidateInvokeTiers() error {
+	if cfg.MaxInvokeTime < 0 {
+		return errors.New("negative MaxInvokeTime")
+	}
+	var keys = make(map[string]string)
+	for i, t := range cfg.InvokeTiers {
+		if len(t.APIKeys) == 0 {
+			return fmt.Errorf("tier %d (%s): no API keys", i, t.Name)
+		}
+		if t.MaxGasInvoke < 0 || t.MaxInvokeTime < 0 || t.MaxIteratorResultItems < 0 || t.MaxOpcodesInvoke < 0 {
+			return fmt.Errorf("tier %d (%s): negative limit", i, t.Name)
+		}
+		for _, k := range t.APIKeys {
+			if k == "" {
+				return fmt.Errorf("tier %d (%s): empty API key", i, t.Name)
+			}
+			if prev, ok := keys[k]; ok {
+				return fmt.Errorf("tier %d (%s): API key is already used by %s tier", i, t.Name, prev)
+			}
+			keys[k] = t.Name
+		}
+	}
+	return nil
+}
+
+// Validate checks RPCStateArchive for internal consistency and ensures that
+// all required fields are properly set.
+func (cfg *RPCStateArchive) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.ContainerID == "" {
+		return errors.New("container ID is not set")
+	}
+	var containerID cid.ID
+	err := containerID.DecodeString(cfg.ContainerID)
+	if err != nil {
+		return fmt.Errorf("invalid container ID: %w", err)
+	}
+	if len(cfg.Addresses) == 0 {
+		return errors.New("addresses are not set")
+	}
+	if cfg.CacheSize < 0 {
+		return errors.New("negative cache size")
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ShadowValidation represents the configuration of the service comparing
+// local state with a reference node.
+type ShadowValidation struct {
+	Enabled bool `yaml:"Enabled"`
+	// ReferenceRPC is the RPC endpoint of a trusted node with state root
+	// support (StateService plugin for C# nodes).
+	ReferenceRPC string `yaml:"ReferenceRPC"`
+	// Interval is the number of blocks between state root comparisons.
+	Interval uint32 `yaml:"Interval"`
+	// Timeout is a single request timeout.
+	Timeout time.Duration `yaml:"Timeout"`
+	// Samples is the number of storage items per contract compared when
+	// diverging block is found.
+	Samples int `yaml:"Samples"`
+}
+
+// Validate checks ShadowValidation for internal consistency.
+func (cfg *ShadowValidation) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.ReferenceRPC == "" {
+		return errors.New("reference RPC is not set")
+	}
+	u, err := url.Parse(cfg.ReferenceRPC)
+	if err != nil {
+		return fmt.Errorf("invalid reference RPC: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid reference RPC: unsupported scheme %q", u.Scheme)
+	}
+	if cfg.Timeout < 0 {
+		return errors.New("negative Timeout")
+	}
+	if cfg.Samples < 0 {
+		return errors.New("negative Samples")
+	}
+	return nil
+}
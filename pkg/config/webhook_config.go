@@ -0,0 +1,80 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Webhook represents the configuration of the webhook notification service.
+type Webhook struct {
+	Enabled bool `yaml:"Enabled"`
+	// Addresses is a list of watched accounts (in Neo address format),
+	// transactions signed by them and NEP-11/NEP-17 transfers involving
+	// them are reported.
+	Addresses []string `yaml:"Addresses"`
+	// Contracts is a list of watched contract hashes (LE hex), all of
+	// their notifications are reported.
+	Contracts []string `yaml:"Contracts"`
+	// Mempool enables notifications for transactions added to the memory
+	// pool.
+	Mempool bool `yaml:"Mempool"`
+	// MempoolPollInterval is the interval between memory pool checks.
+	MempoolPollInterval time.Duration `yaml:"MempoolPollInterval"`
+	// Endpoints is a list of URLs every event is posted to.
+	Endpoints []WebhookEndpoint `yaml:"Endpoints"`
+	// Timeout is a single request timeout.
+	Timeout time.Duration `yaml:"Timeout"`
+	// MaxRetries is the number of additional delivery attempts made for
+	// every failed request.
+	MaxRetries int `yaml:"MaxRetries"`
+	// RetryInterval is the delay before the first retry, it's doubled
+	// for every subsequent one.
+	RetryInterval time.Duration `yaml:"RetryInterval"`
+	// QueueSize is the maximum number of events waiting for delivery to a
+	// single endpoint, new events are dropped when it's exceeded.
+	QueueSize int `yaml:"QueueSize"`
+}
+
+// WebhookEndpoint is a single webhook receiver.
+type WebhookEndpoint struct {
+	URL string `yaml:"URL"`
+	// Secret is an HMAC-SHA256 key used to sign requests, they're not
+	// signed if it's empty.
+	Secret string `yaml:"Secret"`
+}
+
+// Validate checks Webhook for internal consistency. Watched addresses and
+// contracts are parsed by the service itself, since address format depends
+// on the protocol configuration.
+func (cfg *Webhook) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if len(cfg.Endpoints) == 0 {
+		return errors.New("no endpoints set")
+	}
+	for i, e := range cfg.Endpoints {
+		u, err := url.Parse(e.URL)
+		if err != nil {
+			return fmt.Errorf("endpoint #%d: %w", i, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("endpoint #%d: unsupported scheme %q", i, u.Scheme)
+		}
+	}
+	if len(cfg.Addresses) == 0 && len(cfg.Contracts) == 0 {
+		return errors.New("neither addresses nor contracts are set")
+	}
+	if cfg.MempoolPollInterval < 0 || cfg.Timeout < 0 || cfg.RetryInterval < 0 {
+		return errors.New("negative durations are not allowed")
+	}
+	if cfg.MaxRetries < 0 {
+		return errors.New("negative MaxRetries")
+	}
+	if cfg.QueueSize < 0 {
+		return errors.New("negative QueueSize")
+	}
+	return nil
+}
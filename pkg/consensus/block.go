@@ -24,8 +24,10 @@ var _ dbft.Block[util.Uint256] = (*neoBlock)(nil)
 
 // Sign implements the block.Block interface.
 func (n *neoBlock) Sign(key dbft.PrivateKey) error {
-	k := key.(*keys.PrivateKey)
-	sig := k.SignHashable(uint32(n.network), &n.Block)
+	sig, _, err := signHashable(key, uint32(n.network), &n.Block)
+	if err != nil {
+		return err
+	}
 	n.signature = sig
 	return nil
 }
@@ -3,11 +3,14 @@ package consensus
 import (
 	"github.com/nspcc-dev/dbft"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	npayload "github.com/nspcc-dev/neo-go/pkg/network/payload"
 )
 
 // commit represents dBFT Commit message.
 type commit struct {
-	signature [signatureSize]byte
+	signature      [signatureSize]byte
+	sealingEnabled bool
+	share          []byte
 }
 
 // signatureSize is an rfc6989 signature size in bytes
@@ -19,11 +22,17 @@ var _ dbft.Commit = (*commit)(nil)
 // EncodeBinary implements the io.Serializable interface.
 func (c *commit) EncodeBinary(w *io.BinWriter) {
 	w.WriteBytes(c.signature[:])
+	if c.sealingEnabled {
+		w.WriteVarBytes(c.share)
+	}
 }
 
 // DecodeBinary implements the io.Serializable interface.
 func (c *commit) DecodeBinary(r *io.BinReader) {
 	r.ReadBytes(c.signature[:])
+	if c.sealingEnabled {
+		c.share = r.ReadVarBytes(npayload.MaxSize)
+	}
 }
 
 // Signature implements the payload.Commit interface.
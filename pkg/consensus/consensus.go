@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -21,6 +22,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	npayload "github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/services/helpers/extsigner"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
@@ -52,6 +54,7 @@ type Ledger interface {
 	UnsubscribeFromBlocks(ch chan *coreb.Block)
 	GetBaseExecFee() int64
 	CalculateAttributesFee(tx *transaction.Transaction) int64
+	TxGasLimit(tx *transaction.Transaction) int64
 	interop.Ledger
 	mempool.Feer
 }
@@ -78,6 +81,13 @@ type Service interface {
 	OnPayload(p *npayload.Extensible) error
 	// OnTransaction is a callback to notify the Service about a newly received transaction.
 	OnTransaction(tx *transaction.Transaction)
+	// ReloadWallet replaces the wallet used by the service with the one
+	// specified by the given configuration. The wallet is checked the same
+	// way it's checked on service creation, the old one is kept in case of
+	// any error. New keys are used starting from the next dBFT round (new
+	// block or view change). It returns an error if the service uses an
+	// external signer.
+	ReloadWallet(cfg config.Wallet) error
 }
 
 type service struct {
@@ -96,7 +106,15 @@ type service struct {
 	// on block addition under the high load.
 	blockEvents  chan *coreb.Block
 	lastProposal []util.Uint256
-	wallet       *wallet.Wallet
+	// walletLock protects wallet, retiredWallets and Config.Wallet that can
+	// be changed by ReloadWallet.
+	walletLock sync.RWMutex
+	wallet     *wallet.Wallet
+	// retiredWallets are wallets replaced by ReloadWallet, they're closed
+	// when the next round starts.
+	retiredWallets []*wallet.Wallet
+	// signer is an external signer preferred over the wallet if set.
+	signer BlockSigner
 	// started is a flag set with Start method that runs an event handling
 	// goroutine.
 	started  atomic.Bool
@@ -133,6 +151,20 @@ type Config struct {
 	// Wallet is a local-node wallet configuration. If the path is empty, then
 	// no wallet will be initialized and the service will be in watch-only mode.
 	Wallet config.Wallet
+	// ExternalSigner is a remote signer configuration preferred over the
+	// Wallet if enabled, Wallet keys are used only if the signer fails.
+	ExternalSigner config.ExternalSigner
+	// Signer is an optional external signer to be used the same way as
+	// ExternalSigner. It takes precedence over ExternalSigner configuration.
+	Signer BlockSigner
+	// Devnet is a devnet mode configuration. If enabled, the service
+	// produces blocks by itself instead of running dBFT, it also implements
+	// the Miner interface in this case.
+	Devnet config.Devnet
+	// Sealer is an optional committed-then-revealed ordering extension, see
+	// Sealer documentation for details. It changes consensus messages format,
+	// so all consensus nodes must have it either enabled or disabled.
+	Sealer Sealer
 }
 
 // NewService returns a new consensus.Service instance.
@@ -145,6 +177,14 @@ func NewService(cfg Config) (Service, error) {
 		return nil, errors.New("empty logger")
 	}
 
+	if cfg.Devnet.Enabled {
+		d, err := newDevnet(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return d, nil
+	}
+
 	srv := &service{
 		Config: cfg,
 
@@ -160,17 +200,16 @@ func NewService(cfg Config) (Service, error) {
 
 	var err error
 
-	if len(cfg.Wallet.Path) > 0 {
-		if srv.wallet, err = wallet.NewWalletFromFile(cfg.Wallet.Path); err != nil {
+	srv.signer = cfg.Signer
+	if srv.signer == nil && cfg.ExternalSigner.IsEnabled() {
+		if srv.signer, err = extsigner.NewRemote(cfg.ExternalSigner); err != nil {
 			return nil, err
 		}
-
-		// Check that the wallet password is correct for at least one account.
-		var ok = slices.ContainsFunc(srv.wallet.Accounts, func(acc *wallet.Account) bool {
-			return acc.Decrypt(srv.Config.Wallet.Password, srv.wallet.Scrypt) == nil
-		})
-		if !ok {
-			return nil, errors.New("no account with provided password was found")
+	}
+	// Wallet is used as a fallback if the external signer is set.
+	if len(cfg.Wallet.Path) > 0 {
+		if srv.wallet, err = openWallet(cfg.Wallet); err != nil {
+			return nil, err
 		}
 	}
 
@@ -236,6 +275,7 @@ func (s *service) newPayload(c *dbft.Context[util.Uint256], t dbft.MessageType,
 	cp.message.ValidatorIndex = byte(c.MyIndex)
 	cp.message.ViewNumber = c.ViewNumber
 	cp.message.Type = messageType(t)
+	cp.message.sealingEnabled = s.Sealer != nil
 	if pr, ok := msg.(*prepareRequest); ok {
 		pr.prevHash = s.dbft.PrevHash
 		pr.version = coreb.VersionInitial
@@ -263,6 +303,14 @@ func (s *service) newPrepareRequest(ts uint64, nonce uint64, transactionsHashes
 			panic(err)
 		}
 	}
+	if s.Sealer != nil {
+		r.sealingEnabled = true
+		sealed, err := s.Sealer.Seal(s.dbft.BlockIndex, transactionsHashes)
+		if err != nil {
+			s.log.Warn("can't seal proposal", zap.Uint32("index", s.dbft.BlockIndex), zap.Error(err))
+		}
+		r.sealed = sealed
+	}
 	return r
 }
 
@@ -283,6 +331,15 @@ func (s *service) newChangeView(newViewNumber byte, reason dbft.ChangeViewReason
 func (s *service) newCommit(signature []byte) dbft.Commit {
 	c := new(commit)
 	copy(c.signature[:], signature)
+	if s.Sealer != nil {
+		var err error
+		c.sealingEnabled = true
+		c.share, err = s.Sealer.Share(s.dbft.BlockIndex, s.sealedProposal())
+		if err != nil {
+			s.log.Warn("can't create commit share", zap.Uint32("index", s.dbft.BlockIndex), zap.Error(err))
+			c.share = nil
+		}
+	}
 	return c
 }
 
@@ -295,6 +352,7 @@ func (s *service) newRecoveryRequest(ts uint64) dbft.RecoveryRequest {
 func (s *service) newRecoveryMessage() dbft.RecoveryMessage[util.Uint256] {
 	return &recoveryMessage{
 		stateRootEnabled: s.ProtocolConfiguration.StateRootInHeader,
+		sealingEnabled:   s.Sealer != nil,
 	}
 }
 
@@ -319,9 +377,12 @@ func (s *service) Shutdown() {
 		s.log.Info("stopping consensus service")
 		close(s.quit)
 		<-s.finished
+		s.walletLock.Lock()
+		s.closeRetiredWallets()
 		if s.wallet != nil {
 			s.wallet.Close()
 		}
+		s.walletLock.Unlock()
 	}
 	_ = s.log.Sync()
 }
@@ -432,34 +493,109 @@ func (s *service) validatePayload(p *Payload) bool {
 	return p.Sender == h
 }
 
-func (s *service) getKeyPair(pubs []dbft.PublicKey) (int, dbft.PrivateKey, dbft.PublicKey) {
+// openWallet opens the wallet and checks that the wallet password is correct
+// for at least one account.
+func openWallet(cfg config.Wallet) (*wallet.Wallet, error) {
+	w, err := wallet.NewWalletFromFile(cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+	var ok = slices.ContainsFunc(w.Accounts, func(acc *wallet.Account) bool {
+		return acc.Decrypt(cfg.Password, w.Scrypt) == nil
+	})
+	if !ok {
+		w.Close()
+		return nil, errors.New("no account with provided password was found")
+	}
+	return w, nil
+}
+
+// ReloadWallet implements the Service interface.
+func (s *service) ReloadWallet(cfg config.Wallet) error {
+	if s.signer != nil {
+		return errors.New("wallet can't be reloaded when external signer is used")
+	}
+	if len(cfg.Path) == 0 {
+		return errors.New("no wallet path specified")
+	}
+	w, err := openWallet(cfg)
+	if err != nil {
+		return err
+	}
+	// Keys of the old wallet can still be used by dBFT until the end of the
+	// current round, so it's closed by getKeyPair when the next one starts.
+	s.walletLock.Lock()
 	if s.wallet != nil {
-		for i := range pubs {
-			sh := pubs[i].(*keys.PublicKey).GetScriptHash()
-			acc := s.wallet.GetAccount(sh)
-			if acc == nil {
-				continue
-			}
+		s.retiredWallets = append(s.retiredWallets, s.wallet)
+	}
+	s.wallet = w
+	s.Config.Wallet = cfg
+	s.walletLock.Unlock()
+	s.log.Info("consensus wallet reloaded, new keys will be used starting from the next round",
+		zap.String("path", cfg.Path))
+	return nil
+}
 
-			if !acc.CanSign() {
-				err := acc.Decrypt(s.Config.Wallet.Password, s.wallet.Scrypt)
-				if err != nil {
-					s.log.Fatal("can't unlock account", zap.String("address", address.Uint160ToString(sh)))
-					break
-				}
-			}
+// closeRetiredWallets closes wallets replaced by ReloadWallet. It must be
+// called with walletLock held.
+func (s *service) closeRetiredWallets() {
+	for _, w := range s.retiredWallets {
+		w.Close()
+	}
+	s.retiredWallets = nil
+}
 
-			return i, acc.PrivateKey(), acc.PublicKey()
+func (s *service) getKeyPair(pubs []dbft.PublicKey) (int, dbft.PrivateKey, dbft.PublicKey) {
+	s.walletLock.Lock()
+	defer s.walletLock.Unlock()
+	// New round is started, keys from the previous one are not used anymore.
+	s.closeRetiredWallets()
+	if s.signer != nil {
+		available := s.signer.PublicKeys()
+		for i := range pubs {
+			pub := pubs[i].(*keys.PublicKey)
+			if slices.ContainsFunc(available, pub.Equal) {
+				return i, &externalKey{signer: s.signer, pub: pub, fallback: s.getWalletKey(pub)}, pub
+			}
+		}
+	}
+	for i := range pubs {
+		pub := pubs[i].(*keys.PublicKey)
+		if priv := s.getWalletKey(pub); priv != nil {
+			return i, priv, pub
 		}
 	}
 	return -1, nil, nil
 }
 
+// getWalletKey returns the wallet private key corresponding to the given
+// public key or nil if there is no such key. It must be called with walletLock
+// held.
+func (s *service) getWalletKey(pub *keys.PublicKey) *keys.PrivateKey {
+	if s.wallet == nil {
+		return nil
+	}
+	sh := pub.GetScriptHash()
+	acc := s.wallet.GetAccount(sh)
+	if acc == nil {
+		return nil
+	}
+	if !acc.CanSign() {
+		err := acc.Decrypt(s.Config.Wallet.Password, s.wallet.Scrypt)
+		if err != nil {
+			s.log.Fatal("can't unlock account", zap.String("address", address.Uint160ToString(sh)))
+			return nil
+		}
+	}
+	return acc.PrivateKey()
+}
+
 func (s *service) payloadFromExtensible(ep *npayload.Extensible) *Payload {
 	return &Payload{
 		Extensible: *ep,
 		message: message{
 			stateRootEnabled: s.ProtocolConfiguration.StateRootInHeader,
+			sealingEnabled:   s.Sealer != nil,
 		},
 	}
 }
@@ -495,8 +631,11 @@ func (s *service) OnTransaction(tx *transaction.Transaction) {
 }
 
 func (s *service) broadcast(p dbft.ConsensusPayload[util.Uint256]) {
-	if err := p.(*Payload).Sign(s.dbft.Priv.(*keys.PrivateKey)); err != nil {
+	if err := p.(*Payload).sign(s.dbft.Priv); err != nil {
+		// Unsigned payload is useless for other nodes, dBFT will recover
+		// via timeouts (change view) or recovery messages.
 		s.log.Warn("can't sign consensus payload", zap.Error(err))
+		return
 	}
 
 	ep := &p.(*Payload).Extensible
@@ -547,7 +686,7 @@ func (s *service) verifyBlock(b dbft.Block[util.Uint256]) bool {
 	for _, tx := range coreb.Transactions {
 		var err error
 
-		fee += tx.SystemFee
+		fee += s.Chain.TxGasLimit(tx)
 		if mainPool.ContainsKey(tx.Hash()) {
 			err = pool.Add(tx, s.Chain)
 			if err == nil {
@@ -584,6 +723,7 @@ var (
 	errInvalidVersion           = errors.New("invalid Version")
 	errInvalidStateRoot         = errors.New("state root mismatch")
 	errInvalidTransactionsCount = errors.New("invalid transactions count")
+	errInvalidSeal              = errors.New("invalid sealed proposal")
 )
 
 func (s *service) verifyRequest(p dbft.ConsensusPayload[util.Uint256]) error {
@@ -605,6 +745,11 @@ func (s *service) verifyRequest(p dbft.ConsensusPayload[util.Uint256]) error {
 	if len(req.TransactionHashes()) > int(s.ProtocolConfiguration.MaxTransactionsPerBlock) {
 		return fmt.Errorf("%w: max = %d, got %d", errInvalidTransactionsCount, s.ProtocolConfiguration.MaxTransactionsPerBlock, len(req.TransactionHashes()))
 	}
+	if s.Sealer != nil {
+		if err := s.Sealer.VerifySeal(s.dbft.BlockIndex, req.transactionHashes, req.sealed); err != nil {
+			return fmt.Errorf("%w: %w", errInvalidSeal, err)
+		}
+	}
 	// Save lastProposal for getVerified().
 	s.lastProposal = req.transactionHashes
 
@@ -630,10 +775,34 @@ func (s *service) processBlock(b dbft.Block[util.Uint256]) error {
 			s.log.Warn("error on enqueue block", zap.Error(err))
 		}
 	}
+	if s.Sealer != nil {
+		s.Sealer.Reveal(bb, s.sealedProposal(), s.commitShares())
+	}
 	s.postBlock(bb)
 	return nil
 }
 
+// sealedProposal returns Sealer data of the current PrepareRequest (if any).
+func (s *service) sealedProposal() []byte {
+	if p := s.dbft.PreparationPayloads[s.dbft.PrimaryIndex]; p != nil && p.Type() == dbft.PrepareRequestType {
+		return p.GetPrepareRequest().(*prepareRequest).sealed
+	}
+	return nil
+}
+
+// commitShares returns Sealer shares from the current view Commits indexed by
+// validator.
+func (s *service) commitShares() [][]byte {
+	dctx := s.dbft.Context
+	shares := make([][]byte, len(dctx.Validators))
+	for i := range shares {
+		if p := dctx.CommitPayloads[i]; p != nil && p.ViewNumber() == dctx.ViewNumber {
+			shares[i] = p.GetCommit().(*commit).share
+		}
+	}
+	return shares
+}
+
 func (s *service) postBlock(b *coreb.Block) {
 	s.lastTimestamp = max(s.lastTimestamp, b.Timestamp)
 	s.lastProposal = nil
@@ -1,6 +1,7 @@
 package consensus
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	coreb "github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
@@ -20,9 +22,11 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	npayload "github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
@@ -42,6 +46,144 @@ func TestNewService(t *testing.T) {
 	require.Equal(t, tx, txx[0])
 }
 
+func TestService_ReloadWallet(t *testing.T) {
+	srv := newTestService(t)
+	pubs := make([]dbft.PublicKey, 4)
+	for i := range pubs {
+		_, pubs[i] = getTestValidator(i)
+	}
+
+	idx, _, pub := srv.getKeyPair(pubs)
+	require.NotEqual(t, -1, idx)
+	require.Equal(t, pubs[idx], pub)
+
+	require.Error(t, srv.ReloadWallet(config.Wallet{}))
+	require.Error(t, srv.ReloadWallet(config.Wallet{Path: "./testdata/wallet2.json", Password: "bad"}))
+	require.Error(t, srv.ReloadWallet(config.Wallet{Path: "./testdata/unknown.json", Password: "two"}))
+
+	// Old wallet is still used.
+	newIdx, _, _ := srv.getKeyPair(pubs)
+	require.Equal(t, idx, newIdx)
+
+	oldAcc := srv.wallet.GetAccount(pubs[idx].(*keys.PublicKey).GetScriptHash())
+	require.True(t, oldAcc.CanSign())
+	require.NoError(t, srv.ReloadWallet(config.Wallet{Path: "./testdata/wallet2.json", Password: "two"}))
+	// Old keys can still be used until the next round.
+	require.True(t, oldAcc.CanSign())
+
+	newIdx, _, pub = srv.getKeyPair(pubs)
+	require.NotEqual(t, -1, newIdx)
+	require.NotEqual(t, idx, newIdx)
+	require.Equal(t, pubs[newIdx], pub)
+	require.False(t, oldAcc.CanSign())
+	require.Nil(t, srv.retiredWallets)
+}
+
+// testSigner is an external signer holding private keys in memory.
+type testSigner struct {
+	keys []*keys.PrivateKey
+	err  error
+}
+
+func (s testSigner) PublicKeys() keys.PublicKeys {
+	var res keys.PublicKeys
+	for _, k := range s.keys {
+		res = append(res, k.PublicKey())
+	}
+	return res
+}
+
+func (s testSigner) SignHash(pub *keys.PublicKey, h util.Uint256) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	for _, k := range s.keys {
+		if k.PublicKey().Equal(pub) {
+			return k.SignHash(h), nil
+		}
+	}
+	return nil, errors.New("unknown key")
+}
+
+func TestService_ExternalSigner(t *testing.T) {
+	bc := newTestChain(t, false)
+	priv, _ := getTestValidator(2)
+	signer := &testSigner{keys: []*keys.PrivateKey{priv}}
+
+	var broadcasted []*npayload.Extensible
+	srv, err := NewService(Config{
+		Logger:                zaptest.NewLogger(t),
+		Broadcast:             func(p *npayload.Extensible) { broadcasted = append(broadcasted, p) },
+		Chain:                 bc,
+		BlockQueue:            testBlockQueuer{bc: bc},
+		ProtocolConfiguration: bc.GetConfig().ProtocolConfiguration,
+		RequestTx:             func(...util.Uint256) {},
+		StopTxFlow:            func() {},
+		TimePerBlock:          bc.GetConfig().TimePerBlock,
+		// Wallet is used as a fallback if signer is set.
+		Wallet: config.Wallet{
+			Path:     "./testdata/wallet1.json",
+			Password: "one",
+		},
+		Signer: signer,
+	})
+	require.NoError(t, err)
+	s := srv.(*service)
+	require.Error(t, s.ReloadWallet(config.Wallet{Path: "./testdata/wallet2.json", Password: "two"}))
+
+	pubs := make([]dbft.PublicKey, 4)
+	for i := range pubs {
+		_, pubs[i] = getTestValidator(i)
+	}
+	idx, key, pub := s.getKeyPair(pubs)
+	require.Equal(t, 2, idx)
+	require.Equal(t, priv.PublicKey(), pub)
+
+	b := &neoBlock{network: netmode.UnitTestNet}
+	require.NoError(t, b.Sign(key))
+	require.NoError(t, b.Verify(pub, b.Signature()))
+
+	s.dbft.Priv = key
+	p := NewPayload(netmode.UnitTestNet, false)
+	p.message.ValidatorIndex = 2
+	p.Sender = priv.GetScriptHash()
+	p.payload = &prepareRequest{}
+	s.broadcast(p)
+	require.Len(t, broadcasted, 1)
+	require.True(t, s.validatePayload(p))
+	require.Equal(t, priv.PublicKey().GetVerificationScript(), p.Witness.VerificationScript)
+
+	t.Run("signer failure", func(t *testing.T) {
+		signer.err = errors.New("timeout")
+		t.Cleanup(func() { signer.err = nil })
+
+		// Wallet contains the same key.
+		require.NotNil(t, key.(*externalKey).fallback)
+		require.NoError(t, b.Sign(key))
+		require.NoError(t, b.Verify(pub, b.Signature()))
+		s.broadcast(p)
+		require.Len(t, broadcasted, 2)
+		require.True(t, s.validatePayload(p))
+
+		s.dbft.Priv = &externalKey{signer: signer, pub: priv.PublicKey()}
+		require.Error(t, b.Sign(s.dbft.Priv))
+		s.broadcast(p)
+		require.Len(t, broadcasted, 2)
+	})
+	t.Run("no keys", func(t *testing.T) {
+		idx, _, _ := s.getKeyPair(pubs[:2])
+		require.Equal(t, -1, idx)
+	})
+	t.Run("invalid remote config", func(t *testing.T) {
+		_, err := NewService(Config{
+			Logger:         zaptest.NewLogger(t),
+			Chain:          bc,
+			ExternalSigner: config.ExternalSigner{Endpoint: "http://localhost:0"},
+		})
+		require.Error(t, err)
+	})
+}
+
 func TestNewWatchingService(t *testing.T) {
 	bc := newTestChain(t, false)
 	srv, err := NewService(Config{
@@ -477,6 +619,57 @@ func TestVerifyBlock(t *testing.T) {
 	})
 }
 
+func TestVerifyBlockFreeTransactions(t *testing.T) {
+	const (
+		maxBlockSysFee = 10_0000_0000
+		deposit        = 1_0000_0000
+	)
+	cfg, err := config.Load("../../config", netmode.UnitTestNet)
+	require.NoError(t, err)
+	cfg.ProtocolConfiguration.MaxBlockSystemFee = maxBlockSysFee
+	cfg.ProtocolConfiguration.FreeTransactions = config.FreeTransactions{
+		Enabled:         true,
+		Deposit:         deposit,
+		VerificationGAS: 1_0000_0000,
+		ExecutionGAS:    maxBlockSysFee/2 + 1,
+	}
+	bc, err := core.NewBlockchain(storage.NewMemoryStore(), cfg.Blockchain(), zaptest.NewLogger(t))
+	require.NoError(t, err)
+	go bc.Run()
+	t.Cleanup(bc.Close)
+	srv := newTestServiceWithChain(t, bc)
+
+	script, err := smartcontract.CreateCallWithAssertScript(nativehashes.GasToken, "transfer",
+		neoOwner.BytesBE(), nativehashes.Notary.BytesBE(), 3*deposit, []any{nil, 100})
+	require.NoError(t, err)
+	tx := transaction.New(script, 1_0000_0000)
+	tx.ValidUntilBlock = 1
+	addSender(t, tx)
+	tx.Signers[0].Scopes = transaction.CalledByEntry
+	signTx(t, bc, tx)
+	require.NoError(t, bc.AddBlock(testchain.NewBlock(t, bc, 1, 0, tx)))
+	aer, err := bc.GetAppExecResults(tx.Hash(), trigger.Application)
+	require.NoError(t, err)
+	require.Equal(t, vmstate.Halt, aer[0].VMState, aer[0].FaultException)
+
+	newFreeTx := func(nonce uint32) *transaction.Transaction {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.Nonce = nonce
+		tx.ValidUntilBlock = bc.BlockHeight() + 2
+		addSender(t, tx)
+		signFreeTx(t, tx)
+		return tx
+	}
+
+	srv.lastTimestamp = 1
+	b := testchain.NewBlock(t, bc, 1, 0, newFreeTx(1))
+	require.True(t, srv.verifyBlock(&neoBlock{Block: *b}))
+
+	// System fee is zero for both, but ExecutionGAS is counted for each.
+	b = testchain.NewBlock(t, bc, 1, 0, newFreeTx(1), newFreeTx(2))
+	require.False(t, srv.verifyBlock(&neoBlock{Block: *b}))
+}
+
 func shouldReceive(t *testing.T, ch chan Payload) {
 	select {
 	case <-ch:
@@ -576,22 +769,22 @@ func addSender(t *testing.T, txs ...*transaction.Transaction) {
 }
 
 func signTx(t *testing.T, bc Ledger, txs ...*transaction.Transaction) {
-	validators := make([]*keys.PublicKey, 4)
-	privNetKeys := make([]*keys.PrivateKey, 4)
-	for i := range 4 {
-		privNetKeys[i] = testchain.PrivateKey(i)
-		validators[i] = privNetKeys[i].PublicKey()
-	}
-	privNetKeys = privNetKeys[:3]
-	rawScript, err := smartcontract.CreateMultiSigRedeemScript(3, validators)
-	require.NoError(t, err)
+	rawScript, _ := testMultisig(t)
 	for _, tx := range txs {
 		size := io.GetVarSize(tx)
 		netFee, sizeDelta := fee.Calculate(bc.GetBaseExecFee(), rawScript)
 		tx.NetworkFee += +netFee
 		size += sizeDelta
 		tx.NetworkFee += int64(size)*bc.FeePerByte() + bc.CalculateAttributesFee(tx)
+	}
+	signFreeTx(t, txs...)
+}
 
+// signFreeTx adds multisig witness to the given transactions without paying
+// network fee for it.
+func signFreeTx(t *testing.T, txs ...*transaction.Transaction) {
+	rawScript, privNetKeys := testMultisig(t)
+	for _, tx := range txs {
 		buf := io.NewBufBinWriter()
 		for _, key := range privNetKeys {
 			signature := key.SignHashable(uint32(testchain.Network()), tx)
@@ -604,3 +797,17 @@ func signTx(t *testing.T, bc Ledger, txs ...*transaction.Transaction) {
 		}}
 	}
 }
+
+// testMultisig returns the verification script of validators multisig account
+// and the keys to sign with it.
+func testMultisig(t *testing.T) ([]byte, []*keys.PrivateKey) {
+	validators := make([]*keys.PublicKey, 4)
+	privNetKeys := make([]*keys.PrivateKey, 4)
+	for i := range 4 {
+		privNetKeys[i] = testchain.PrivateKey(i)
+		validators[i] = privNetKeys[i].PublicKey()
+	}
+	rawScript, err := smartcontract.CreateMultiSigRedeemScript(3, validators)
+	require.NoError(t, err)
+	return rawScript, privNetKeys[:3]
+}
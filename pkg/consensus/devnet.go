@@ -0,0 +1,390 @@
+package consensus
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nspcc-dev/dbft"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	coreb "github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	npayload "github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/services/helpers/extsigner"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"go.uber.org/zap"
+)
+
+// devnetBlockTimeout is the maximum time devnet service waits for the
+// produced block to be accepted by the chain.
+const devnetBlockTimeout = 10 * time.Second
+
+// devnetPoolCheckInterval is the period of memory pool checks in devnet
+// instant seal mode. Transactions arriving within this period are put into
+// the same block.
+const devnetPoolCheckInterval = 50 * time.Millisecond
+
+// Miner is implemented by the Service returned from NewService when devnet
+// mode is enabled (see config.Devnet). It allows to produce blocks on demand.
+type Miner interface {
+	// Mine produces count blocks one by one (each of them containing
+	// transactions from the memory pool, if any) and returns their hashes.
+	// It waits for every block to be accepted by the chain.
+	Mine(count int) ([]util.Uint256, error)
+}
+
+// devnet is a single-node block producer used instead of dBFT in devnet
+// mode. It doesn't exchange any consensus messages, blocks are signed with
+// the keys of local wallet (or external signer) that must contain enough
+// validator keys to create a proper multisignature witness.
+type devnet struct {
+	Config
+
+	log *zap.Logger
+	// walletLock protects wallet and Config.Wallet that can be changed
+	// by ReloadWallet.
+	walletLock sync.RWMutex
+	wallet     *wallet.Wallet
+	signer     BlockSigner
+
+	requests    chan mineRequest
+	blockEvents chan *coreb.Block
+	started     atomic.Bool
+	quit        chan struct{}
+	finished    chan struct{}
+	// lastTimestamp is the timestamp of the latest block in the chain.
+	lastTimestamp uint64
+}
+
+// mineRequest is a request to produce count blocks.
+type mineRequest struct {
+	count  int
+	result chan mineResult
+}
+
+// mineResult is a result of mineRequest processing.
+type mineResult struct {
+	hashes []util.Uint256
+	err    error
+}
+
+var _ Miner = (*devnet)(nil)
+
+func newDevnet(cfg Config) (*devnet, error) {
+	d := &devnet{
+		Config: cfg,
+
+		log:         cfg.Logger,
+		requests:    make(chan mineRequest),
+		blockEvents: make(chan *coreb.Block, 1),
+		quit:        make(chan struct{}),
+		finished:    make(chan struct{}),
+	}
+
+	var err error
+
+	d.signer = cfg.Signer
+	if d.signer == nil && cfg.ExternalSigner.IsEnabled() {
+		if d.signer, err = extsigner.NewRemote(cfg.ExternalSigner); err != nil {
+			return nil, err
+		}
+	}
+	if d.signer == nil && len(cfg.Wallet.Path) == 0 {
+		return nil, errors.New("devnet mode requires a wallet or an external signer")
+	}
+	// Wallet is used as a fallback if the external signer is set.
+	if len(cfg.Wallet.Path) > 0 {
+		if d.wallet, err = openWallet(cfg.Wallet); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+// Name implements the Service interface.
+func (d *devnet) Name() string {
+	return "consensus"
+}
+
+// Start implements the Service interface.
+func (d *devnet) Start() {
+	if d.started.CompareAndSwap(false, true) {
+		d.log.Info("starting consensus service in devnet mode",
+			zap.Duration("interval", d.Devnet.Interval),
+			zap.Bool("instant seal", d.Devnet.InstantSeal))
+		b, _ := d.Chain.GetBlock(d.Chain.CurrentBlockHash()) // Can't fail, we have some current block!
+		d.lastTimestamp = b.Timestamp
+		d.Chain.SubscribeForBlocks(d.blockEvents)
+		go d.eventLoop()
+	}
+}
+
+// Shutdown implements the Service interface.
+func (d *devnet) Shutdown() {
+	if d.started.CompareAndSwap(true, false) {
+		d.log.Info("stopping consensus service")
+		close(d.quit)
+		<-d.finished
+		d.walletLock.Lock()
+		if d.wallet != nil {
+			d.wallet.Close()
+		}
+		d.walletLock.Unlock()
+	}
+	_ = d.log.Sync()
+}
+
+// OnPayload implements the Service interface. Consensus payloads are ignored
+// in devnet mode.
+func (d *devnet) OnPayload(*npayload.Extensible) error {
+	return nil
+}
+
+// OnTransaction implements the Service interface. Transactions are taken
+// directly from the memory pool in devnet mode.
+func (d *devnet) OnTransaction(*transaction.Transaction) {}
+
+// ReloadWallet implements the Service interface.
+func (d *devnet) ReloadWallet(cfg config.Wallet) error {
+	if d.signer != nil {
+		return errors.New("wallet can't be reloaded when external signer is used")
+	}
+	if len(cfg.Path) == 0 {
+		return errors.New("no wallet path specified")
+	}
+	w, err := openWallet(cfg)
+	if err != nil {
+		return err
+	}
+	d.walletLock.Lock()
+	old := d.wallet
+	d.wallet = w
+	d.Config.Wallet = cfg
+	d.walletLock.Unlock()
+	// Blocks are signed synchronously, so the old wallet is not used anymore.
+	old.Close()
+	d.log.Info("consensus wallet reloaded", zap.String("path", cfg.Path))
+	return nil
+}
+
+// Mine implements the Miner interface.
+func (d *devnet) Mine(count int) ([]util.Uint256, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("invalid number of blocks: %d", count)
+	}
+	if !d.started.Load() {
+		return nil, errors.New("service is not running")
+	}
+	req := mineRequest{count: count, result: make(chan mineResult, 1)}
+	select {
+	case d.requests <- req:
+	case <-d.finished:
+		return nil, errors.New("service is stopped")
+	}
+	res := <-req.result
+	return res.hashes, res.err
+}
+
+func (d *devnet) eventLoop() {
+	var tick, poolTick <-chan time.Time
+	if d.Devnet.Interval > 0 {
+		ticker := time.NewTicker(d.Devnet.Interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	if d.Devnet.InstantSeal {
+		ticker := time.NewTicker(devnetPoolCheckInterval)
+		defer ticker.Stop()
+		poolTick = ticker.C
+	}
+events:
+	for {
+		select {
+		case <-d.quit:
+			break events
+		case <-tick:
+			d.produceBlockAndLog()
+		case <-poolTick:
+			if d.Chain.GetMemPool().Count() > 0 {
+				d.produceBlockAndLog()
+			}
+		case req := <-d.requests:
+			var res mineResult
+			for range req.count {
+				h, err := d.produceBlock()
+				if err != nil {
+					res.err = err
+					break
+				}
+				res.hashes = append(res.hashes, h)
+			}
+			req.result <- res
+		case b := <-d.blockEvents:
+			d.lastTimestamp = max(d.lastTimestamp, b.Timestamp)
+		}
+	}
+	d.Chain.UnsubscribeFromBlocks(d.blockEvents)
+drainLoop:
+	for {
+		select {
+		case <-d.blockEvents:
+		default:
+			break drainLoop
+		}
+	}
+	close(d.blockEvents)
+	close(d.finished)
+}
+
+// produceBlockAndLog produces a block logging the result.
+func (d *devnet) produceBlockAndLog() {
+	h, err := d.produceBlock()
+	if err != nil {
+		d.log.Warn("failed to produce block", zap.Error(err))
+		return
+	}
+	d.log.Debug("block produced", zap.Stringer("hash", h))
+}
+
+// produceBlock creates a new block, passes it to the block queue and waits
+// for it to be accepted by the chain.
+func (d *devnet) produceBlock() (util.Uint256, error) {
+	b, err := d.newBlock()
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	if err = d.BlockQueue.PutBlock(b); err != nil {
+		return util.Uint256{}, fmt.Errorf("failed to enqueue block %d: %w", b.Index, err)
+	}
+	timer := time.NewTimer(devnetBlockTimeout)
+	defer timer.Stop()
+	for d.Chain.BlockHeight() < b.Index {
+		select {
+		case nb := <-d.blockEvents:
+			d.lastTimestamp = max(d.lastTimestamp, nb.Timestamp)
+		case <-timer.C:
+			return util.Uint256{}, fmt.Errorf("block %d is not accepted in %s", b.Index, devnetBlockTimeout)
+		case <-d.quit:
+			return util.Uint256{}, errors.New("service is stopped")
+		}
+	}
+	if h := d.Chain.GetHeaderHash(b.Index); !h.Equals(b.Hash()) {
+		return util.Uint256{}, fmt.Errorf("block %d was replaced by %s", b.Index, h.StringLE())
+	}
+	d.lastTimestamp = max(d.lastTimestamp, b.Timestamp)
+	return b.Hash(), nil
+}
+
+// newBlock creates a new signed block with transactions from the memory pool.
+func (d *devnet) newBlock() (*coreb.Block, error) {
+	validators, err := d.Chain.GetNextBlockValidators()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validators: %w", err)
+	}
+	var (
+		m      = smartcontract.GetDefaultHonestNodeCount(len(validators))
+		sorted = slices.Clone(validators)
+		privs  = make([]dbft.PrivateKey, 0, m)
+		pubs   = make([]*keys.PublicKey, 0, m)
+	)
+	slices.SortFunc(sorted, (*keys.PublicKey).Cmp)
+	for _, pub := range sorted {
+		if len(privs) == m {
+			break
+		}
+		priv, err := d.getKey(pub)
+		if err != nil {
+			return nil, err
+		}
+		if priv != nil {
+			privs = append(privs, priv)
+			pubs = append(pubs, pub)
+		}
+	}
+	if len(privs) < m {
+		return nil, fmt.Errorf("not enough validator keys: %d out of %d required", len(privs), m)
+	}
+
+	index := d.Chain.BlockHeight() + 1
+	b := &coreb.Block{
+		Header: coreb.Header{
+			Version:      coreb.VersionInitial,
+			PrevHash:     d.Chain.CurrentBlockHash(),
+			Timestamp:    max(uint64(time.Now().UnixMilli()), d.lastTimestamp+1),
+			Nonce:        rand.Uint64(),
+			Index:        index,
+			PrimaryIndex: byte(slices.IndexFunc(validators, pubs[0].Equal)),
+		},
+	}
+	if d.ProtocolConfiguration.StateRootInHeader {
+		sr, err := d.Chain.GetStateRoot(index - 1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get state root: %w", err)
+		}
+		b.StateRootEnabled = true
+		b.PrevStateRoot = sr.Root
+	}
+	nextScript, err := smartcontract.CreateDefaultMultiSigRedeemScript(d.Chain.ComputeNextBlockValidators())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create next consensus script: %w", err)
+	}
+	b.NextConsensus = hash.Hash160(nextScript)
+	if txes := d.Chain.GetMemPool().GetVerifiedTransactions(); len(txes) > 0 {
+		b.Transactions = d.Chain.ApplyPolicyToTxSet(txes)
+	}
+	b.RebuildMerkleRoot()
+
+	verif, err := smartcontract.CreateMultiSigRedeemScript(m, sorted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create multisignature script: %w", err)
+	}
+	buf := io.NewBufBinWriter()
+	for _, priv := range privs {
+		sig, _, err := signHashable(priv, uint32(d.ProtocolConfiguration.Magic), b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign block: %w", err)
+		}
+		emit.Bytes(buf.BinWriter, sig)
+	}
+	b.Script = transaction.Witness{
+		InvocationScript:   buf.Bytes(),
+		VerificationScript: verif,
+	}
+	return b, nil
+}
+
+// getKey returns a private key for the given public key if it's available
+// (either in the external signer or in the wallet) and nil otherwise. Wallet
+// key is used as a fallback for the external signer one.
+func (d *devnet) getKey(pub *keys.PublicKey) (dbft.PrivateKey, error) {
+	d.walletLock.RLock()
+	defer d.walletLock.RUnlock()
+	var priv *keys.PrivateKey
+	if d.wallet != nil {
+		if acc := d.wallet.GetAccount(pub.GetScriptHash()); acc != nil {
+			if !acc.CanSign() {
+				if err := acc.Decrypt(d.Config.Wallet.Password, d.wallet.Scrypt); err != nil {
+					return nil, fmt.Errorf("can't unlock account %s: %w", pub.StringCompressed(), err)
+				}
+			}
+			priv = acc.PrivateKey()
+		}
+	}
+	if d.signer != nil && slices.ContainsFunc(d.signer.PublicKeys(), pub.Equal) {
+		return &externalKey{signer: d.signer, pub: pub, fallback: priv}, nil
+	}
+	if priv == nil {
+		return nil, nil
+	}
+	return priv, nil
+}
@@ -0,0 +1,140 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	npayload "github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func newTestDevnet(t *testing.T, bc *core.Blockchain, cfg config.Devnet, signer BlockSigner) Miner {
+	cfg.Enabled = true
+	srv, err := NewService(Config{
+		Logger:                zaptest.NewLogger(t),
+		Broadcast:             func(*npayload.Extensible) {},
+		Chain:                 bc,
+		BlockQueue:            testBlockQueuer{bc: bc},
+		ProtocolConfiguration: bc.GetConfig().ProtocolConfiguration,
+		RequestTx:             func(...util.Uint256) {},
+		StopTxFlow:            func() {},
+		TimePerBlock:          bc.GetConfig().TimePerBlock,
+		Wallet: config.Wallet{
+			Path:     "./testdata/wallet1.json",
+			Password: "one",
+		},
+		Signer: signer,
+		Devnet: cfg,
+	})
+	require.NoError(t, err)
+	t.Cleanup(srv.Shutdown)
+	m, ok := srv.(Miner)
+	require.True(t, ok)
+	return m
+}
+
+// newTestDevnetSigner returns a signer with enough validator keys to sign
+// blocks of the test chain.
+func newTestDevnetSigner() *testSigner {
+	signer := &testSigner{}
+	for i := range 3 {
+		priv, _ := getTestValidator(i)
+		signer.keys = append(signer.keys, priv)
+	}
+	return signer
+}
+
+func TestDevnet_Mine(t *testing.T) {
+	for _, stateRootInHeader := range []bool{false, true} {
+		bc := newTestChain(t, stateRootInHeader)
+		m := newTestDevnet(t, bc, config.Devnet{}, newTestDevnetSigner())
+
+		_, err := m.Mine(1)
+		require.ErrorContains(t, err, "not running")
+
+		m.(Service).Start()
+		_, err = m.Mine(0)
+		require.Error(t, err)
+
+		hashes, err := m.Mine(2)
+		require.NoError(t, err)
+		require.Len(t, hashes, 2)
+		require.EqualValues(t, 2, bc.BlockHeight())
+		require.Equal(t, bc.CurrentBlockHash(), hashes[1])
+		require.Equal(t, bc.GetHeaderHash(1), hashes[0])
+
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 100000)
+		tx.ValidUntilBlock = 10
+		addSender(t, tx)
+		signTx(t, bc, tx)
+		require.NoError(t, bc.PoolTx(tx))
+
+		hashes, err = m.Mine(1)
+		require.NoError(t, err)
+		b, err := bc.GetBlock(hashes[0])
+		require.NoError(t, err)
+		require.Len(t, b.Transactions, 1)
+		require.Equal(t, tx.Hash(), b.Transactions[0].Hash())
+		require.Equal(t, 0, bc.GetMemPool().Count())
+	}
+}
+
+func TestDevnet_NotEnoughKeys(t *testing.T) {
+	bc := newTestChain(t, false)
+	m := newTestDevnet(t, bc, config.Devnet{}, nil)
+	m.(Service).Start()
+
+	_, err := m.Mine(1)
+	require.ErrorContains(t, err, "not enough validator keys")
+	require.EqualValues(t, 0, bc.BlockHeight())
+}
+
+func TestDevnet_Interval(t *testing.T) {
+	bc := newTestChain(t, false)
+	m := newTestDevnet(t, bc, config.Devnet{Interval: 10 * time.Millisecond}, newTestDevnetSigner())
+	m.(Service).Start()
+
+	require.Eventually(t, func() bool { return bc.BlockHeight() >= 3 }, 5*time.Second, 10*time.Millisecond)
+
+	// On-demand blocks can be mixed with regular ones.
+	h := bc.BlockHeight()
+	hashes, err := m.Mine(1)
+	require.NoError(t, err)
+	require.Len(t, hashes, 1)
+	require.Greater(t, bc.BlockHeight(), h)
+}
+
+func TestDevnet_InstantSeal(t *testing.T) {
+	bc := newTestChain(t, false)
+	m := newTestDevnet(t, bc, config.Devnet{InstantSeal: true}, newTestDevnetSigner())
+	m.(Service).Start()
+
+	// No transactions, no blocks.
+	time.Sleep(3 * devnetPoolCheckInterval)
+	require.EqualValues(t, 0, bc.BlockHeight())
+
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 100000)
+	tx.ValidUntilBlock = 10
+	addSender(t, tx)
+	signTx(t, bc, tx)
+	require.NoError(t, bc.PoolTx(tx))
+
+	require.Eventually(t, func() bool { return bc.BlockHeight() == 1 }, 5*time.Second, 10*time.Millisecond)
+	b, err := bc.GetBlock(bc.CurrentBlockHash())
+	require.NoError(t, err)
+	require.Len(t, b.Transactions, 1)
+	require.Equal(t, tx.Hash(), b.Transactions[0].Hash())
+
+	// Empty pool doesn't trigger new blocks, but on-demand ones can be produced.
+	time.Sleep(3 * devnetPoolCheckInterval)
+	require.EqualValues(t, 1, bc.BlockHeight())
+	_, err = m.Mine(1)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, bc.BlockHeight())
+}
@@ -24,6 +24,8 @@ type (
 		payload io.Serializable
 		// stateRootEnabled specifies if state root is exchanged during consensus.
 		stateRootEnabled bool
+		// sealingEnabled specifies if Sealer data is exchanged during consensus.
+		sealingEnabled bool
 	}
 
 	// Payload is a type for consensus-related messages.
@@ -121,13 +123,21 @@ func (p *Payload) EncodeBinary(w *io.BinWriter) {
 // Sign signs payload using the private key.
 // It also sets corresponding verification and invocation scripts.
 func (p *Payload) Sign(key *keys.PrivateKey) error {
+	return p.sign(key)
+}
+
+// sign signs payload using the key returned from getKeyPair.
+func (p *Payload) sign(key dbft.PrivateKey) error {
 	p.encodeData()
-	sig := key.SignHashable(uint32(p.network), &p.Extensible)
+	sig, pub, err := signHashable(key, uint32(p.network), &p.Extensible)
+	if err != nil {
+		return err
+	}
 
 	buf := io.NewBufBinWriter()
 	emit.Bytes(buf.BinWriter, sig)
 	p.Witness.InvocationScript = buf.Bytes()
-	p.Witness.VerificationScript = key.PublicKey().GetVerificationScript()
+	p.Witness.VerificationScript = pub.GetVerificationScript()
 
 	return nil
 }
@@ -175,11 +185,12 @@ func (m *message) DecodeBinary(r *io.BinReader) {
 		if m.stateRootEnabled {
 			r.stateRootEnabled = true
 		}
+		r.sealingEnabled = m.sealingEnabled
 		m.payload = r
 	case prepareResponseType:
 		m.payload = new(prepareResponse)
 	case commitType:
-		m.payload = new(commit)
+		m.payload = &commit{sealingEnabled: m.sealingEnabled}
 	case recoveryRequestType:
 		m.payload = new(recoveryRequest)
 	case recoveryMessageType:
@@ -187,6 +198,7 @@ func (m *message) DecodeBinary(r *io.BinReader) {
 		if m.stateRootEnabled {
 			r.stateRootEnabled = true
 		}
+		r.sealingEnabled = m.sealingEnabled
 		m.payload = r
 	default:
 		r.Err = fmt.Errorf("invalid type: 0x%02x", byte(m.Type))
@@ -4,6 +4,7 @@ import (
 	"github.com/nspcc-dev/dbft"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	npayload "github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 )
 
@@ -16,6 +17,8 @@ type prepareRequest struct {
 	transactionHashes []util.Uint256
 	stateRootEnabled  bool
 	stateRoot         util.Uint256
+	sealingEnabled    bool
+	sealed            []byte
 }
 
 var _ dbft.PrepareRequest[util.Uint256] = (*prepareRequest)(nil)
@@ -33,6 +36,9 @@ func (p *prepareRequest) EncodeBinary(w *io.BinWriter) {
 	if p.stateRootEnabled {
 		w.WriteBytes(p.stateRoot[:])
 	}
+	if p.sealingEnabled {
+		w.WriteVarBytes(p.sealed)
+	}
 }
 
 // DecodeBinary implements the io.Serializable interface.
@@ -45,6 +51,9 @@ func (p *prepareRequest) DecodeBinary(r *io.BinReader) {
 	if p.stateRootEnabled {
 		r.ReadBytes(p.stateRoot[:])
 	}
+	if p.sealingEnabled {
+		p.sealed = r.ReadVarBytes(npayload.MaxSize)
+	}
 }
 
 // Timestamp implements the payload.PrepareRequest interface.
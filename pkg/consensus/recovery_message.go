@@ -2,6 +2,8 @@ package consensus
 
 import (
 	"errors"
+	"fmt"
+	"math"
 
 	"github.com/nspcc-dev/dbft"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
@@ -18,6 +20,7 @@ type (
 		commitPayloads      []*commitCompact
 		changeViewPayloads  []*changeViewCompact
 		stateRootEnabled    bool
+		sealingEnabled      bool
 		prepareRequest      *message
 	}
 
@@ -33,6 +36,9 @@ type (
 		ValidatorIndex   uint8
 		Signature        [signatureSize]byte
 		InvocationScript []byte
+		Share            []byte
+
+		sealingEnabled bool
 	}
 
 	preparationCompact struct {
@@ -49,7 +55,7 @@ func (m *recoveryMessage) DecodeBinary(r *io.BinReader) {
 
 	var hasReq = r.ReadBool()
 	if hasReq {
-		m.prepareRequest = &message{stateRootEnabled: m.stateRootEnabled}
+		m.prepareRequest = &message{stateRootEnabled: m.stateRootEnabled, sealingEnabled: m.sealingEnabled}
 		m.prepareRequest.DecodeBinary(r)
 		if r.Err == nil && m.prepareRequest.Type != prepareRequestType {
 			r.Err = errors.New("recovery message PrepareRequest has wrong type")
@@ -70,7 +76,21 @@ func (m *recoveryMessage) DecodeBinary(r *io.BinReader) {
 	}
 
 	r.ReadArray(&m.preparationPayloads)
-	r.ReadArray(&m.commitPayloads)
+	if !m.sealingEnabled {
+		r.ReadArray(&m.commitPayloads)
+		return
+	}
+	// Commits are to be decoded with shares, so ReadArray can't be used.
+	n := r.ReadVarUint()
+	if n > math.MaxUint8+1 { // Validator index is a byte.
+		r.Err = fmt.Errorf("array is too big (%d)", n)
+		return
+	}
+	m.commitPayloads = make([]*commitCompact, n)
+	for i := range m.commitPayloads {
+		m.commitPayloads[i] = &commitCompact{sealingEnabled: true}
+		m.commitPayloads[i].DecodeBinary(r)
+	}
 }
 
 // EncodeBinary implements the io.Serializable interface.
@@ -116,6 +136,9 @@ func (p *commitCompact) DecodeBinary(r *io.BinReader) {
 	p.ValidatorIndex = r.ReadB()
 	r.ReadBytes(p.Signature[:])
 	p.InvocationScript = r.ReadVarBytes(1024)
+	if p.sealingEnabled {
+		p.Share = r.ReadVarBytes(npayload.MaxSize)
+	}
 }
 
 // EncodeBinary implements the io.Serializable interface.
@@ -124,6 +147,9 @@ func (p *commitCompact) EncodeBinary(w *io.BinWriter) {
 	w.WriteB(p.ValidatorIndex)
 	w.WriteBytes(p.Signature[:])
 	w.WriteVarBytes(p.InvocationScript)
+	if p.sealingEnabled {
+		w.WriteVarBytes(p.Share)
+	}
 }
 
 // DecodeBinary implements the io.Serializable interface.
@@ -149,6 +175,7 @@ func (m *recoveryMessage) AddPayload(p dbft.ConsensusPayload[util.Uint256]) {
 			ViewNumber:       p.ViewNumber(),
 			payload:          p.GetPrepareRequest().(*prepareRequest),
 			stateRootEnabled: m.stateRootEnabled,
+			sealingEnabled:   m.sealingEnabled,
 		}
 		h := p.Hash()
 		m.preparationHash = &h
@@ -174,11 +201,14 @@ func (m *recoveryMessage) AddPayload(p dbft.ConsensusPayload[util.Uint256]) {
 			InvocationScript:   p.(*Payload).Witness.InvocationScript,
 		})
 	case dbft.CommitType:
+		c := p.GetCommit().(*commit)
 		m.commitPayloads = append(m.commitPayloads, &commitCompact{
 			ValidatorIndex:   validator,
 			ViewNumber:       p.ViewNumber(),
-			Signature:        p.GetCommit().(*commit).signature,
+			Signature:        c.signature,
 			InvocationScript: p.(*Payload).Witness.InvocationScript,
+			Share:            c.share,
+			sealingEnabled:   m.sealingEnabled,
 		})
 	default:
 	}
@@ -266,7 +296,11 @@ func (m *recoveryMessage) GetCommits(p dbft.ConsensusPayload[util.Uint256], vali
 	ps := make([]dbft.ConsensusPayload[util.Uint256], len(m.commitPayloads))
 
 	for i, c := range m.commitPayloads {
-		cc := fromPayload(commitType, p.(*Payload), &commit{signature: c.Signature})
+		cc := fromPayload(commitType, p.(*Payload), &commit{
+			signature:      c.Signature,
+			sealingEnabled: m.sealingEnabled,
+			share:          c.Share,
+		})
 		cc.message.ValidatorIndex = c.ValidatorIndex
 		cc.Sender = validators[c.ValidatorIndex].(*keys.PublicKey).GetScriptHash()
 		cc.Witness.InvocationScript = c.InvocationScript
@@ -308,6 +342,7 @@ func fromPayload(t messageType, recovery *Payload, p io.Serializable) *Payload {
 			ViewNumber:       recovery.message.ViewNumber,
 			payload:          p,
 			stateRootEnabled: recovery.stateRootEnabled,
+			sealingEnabled:   recovery.sealingEnabled,
 		},
 		network: recovery.network,
 	}
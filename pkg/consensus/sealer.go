@@ -0,0 +1,43 @@
+package consensus
+
+import (
+	coreb "github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Sealer is an optional consensus extension allowing to commit to the block
+// contents first and reveal some data required to process them only after
+// the block is accepted by consensus nodes (committed-then-revealed ordering).
+// A typical use case is MEV-resistant ordering where transactions carry
+// encrypted payloads: the primary attaches sealed proposal data to the
+// PrepareRequest, every validator attaches its share (like a decryption key
+// share) to the Commit and the complete set of shares is passed to Reveal
+// once the block is ready, so that nobody can learn payloads before their
+// order is fixed.
+//
+// It's a NeoGo-specific extension that changes PrepareRequest, Commit and
+// RecoveryMessage formats, so it can only be used in private networks where
+// all consensus nodes use compatible Sealer implementations. Sealer is not
+// used in devnet mode.
+type Sealer interface {
+	// Seal is called by the primary node when it creates PrepareRequest for
+	// the block with the given index and transactions, the result is attached
+	// to the PrepareRequest. An error makes the proposal invalid (empty sealed
+	// data is sent), so it's rejected by other nodes leading to view change.
+	Seal(index uint32, hashes []util.Uint256) ([]byte, error)
+	// VerifySeal is called by backup nodes for every received PrepareRequest,
+	// an error rejects the proposal.
+	VerifySeal(index uint32, hashes []util.Uint256, sealed []byte) error
+	// Share is called when the node creates a Commit for the block with the
+	// given index and sealed proposal data, the result is attached to the
+	// Commit. An error is logged and no share is sent then.
+	Share(index uint32, sealed []byte) ([]byte, error)
+	// Reveal is called once the block is accepted by consensus, it receives
+	// the block, sealed proposal data and commit shares indexed by validator
+	// (nil for validators that haven't sent a Commit for the current view).
+	// Shares are not verified by the consensus service, so Reveal is
+	// expected to check them. It's only called by nodes that have completed
+	// the consensus round themselves, nodes receiving the block via regular
+	// network exchange don't have shares.
+	Reveal(b *coreb.Block, sealed []byte, shares [][]byte)
+}
@@ -0,0 +1,186 @@
+package consensus
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/dbft"
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	coreb "github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+// testSealer makes sealed data from a fixed prefix, block index and the
+// number of transactions, it returns the same share for every validator.
+type testSealer struct {
+	sealErr error
+	share   []byte
+
+	revealed *coreb.Block
+	sealed   []byte
+	shares   [][]byte
+}
+
+var sealPrefix = []byte("sealed")
+
+func (s *testSealer) Seal(index uint32, hashes []util.Uint256) ([]byte, error) {
+	if s.sealErr != nil {
+		return nil, s.sealErr
+	}
+	return append(bytes.Clone(sealPrefix), byte(index), byte(len(hashes))), nil
+}
+
+func (s *testSealer) VerifySeal(index uint32, hashes []util.Uint256, sealed []byte) error {
+	if !bytes.Equal(sealed, append(bytes.Clone(sealPrefix), byte(index), byte(len(hashes)))) {
+		return errors.New("bad seal")
+	}
+	return nil
+}
+
+func (s *testSealer) Share(index uint32, sealed []byte) ([]byte, error) {
+	if len(sealed) == 0 {
+		return nil, errors.New("no sealed data")
+	}
+	return s.share, nil
+}
+
+func (s *testSealer) Reveal(b *coreb.Block, sealed []byte, shares [][]byte) {
+	s.revealed, s.sealed, s.shares = b, sealed, shares
+}
+
+func TestSealing_Serializable(t *testing.T) {
+	check := func(t *testing.T, mt messageType, msg io.Serializable) {
+		p := NewPayload(netmode.UnitTestNet, false)
+		p.message.Type = mt
+		p.message.sealingEnabled = true
+		p.payload = msg
+		p.encodeData()
+
+		actual := &Payload{
+			Extensible: p.Extensible,
+			message:    message{sealingEnabled: true},
+			network:    netmode.UnitTestNet,
+		}
+		require.NoError(t, actual.decodeData())
+		require.Equal(t, p.message, actual.message)
+
+		// Sealing-unaware nodes can't decode these messages properly.
+		actual = &Payload{Extensible: p.Extensible, network: netmode.UnitTestNet}
+		if actual.decodeData() == nil {
+			require.NotEqual(t, p.message.payload, actual.message.payload)
+		}
+	}
+
+	req := randomPrepareRequest(t)
+	req.sealingEnabled = true
+	req.sealed = random.Bytes(100)
+	check(t, prepareRequestType, req)
+
+	c := &commit{sealingEnabled: true, share: random.Bytes(33)}
+	random.Fill(c.signature[:])
+	check(t, commitType, c)
+
+	rec := randomRecoveryMessage(t)
+	rec.sealingEnabled = true
+	rec.prepareRequest.sealingEnabled = true
+	rec.prepareRequest.payload.(*prepareRequest).sealingEnabled = true
+	rec.prepareRequest.payload.(*prepareRequest).sealed = random.Bytes(10)
+	for _, cc := range rec.commitPayloads {
+		cc.sealingEnabled = true
+		cc.Share = random.Bytes(5)
+	}
+	check(t, recoveryMessageType, rec)
+
+	t.Run("too many commits", func(t *testing.T) {
+		rec := &recoveryMessage{sealingEnabled: true}
+		w := io.NewBufBinWriter()
+		w.WriteArray([]*changeViewCompact{})
+		w.WriteBool(false)
+		w.WriteVarUint(0)
+		w.WriteArray([]*preparationCompact{})
+		w.WriteVarUint(257)
+		require.Error(t, testserdes.DecodeBinary(w.Bytes(), rec))
+	})
+}
+
+func TestService_Sealer(t *testing.T) {
+	bc := newTestChain(t, false)
+	srv := newTestServiceWithChain(t, bc)
+	sealer := &testSealer{share: []byte{1, 2, 3}}
+	srv.Sealer = sealer
+	srv.dbft.Start(0)
+	t.Cleanup(srv.dbft.Timer.Stop)
+
+	hashes := []util.Uint256{random.Uint256(), random.Uint256()}
+	t.Run("seal", func(t *testing.T) {
+		req := srv.newPrepareRequest(0, 0, hashes).(*prepareRequest)
+		require.True(t, req.sealingEnabled)
+		require.NoError(t, sealer.VerifySeal(srv.dbft.BlockIndex, hashes, req.sealed))
+
+		sealer.sealErr = errors.New("no keys")
+		req = srv.newPrepareRequest(0, 0, hashes).(*prepareRequest)
+		require.True(t, req.sealingEnabled)
+		require.Nil(t, req.sealed)
+		sealer.sealErr = nil
+	})
+
+	priv, _ := getTestValidator(1)
+	prevHash := srv.Chain.CurrentBlockHash()
+	newRequest := func(t *testing.T, sealed []byte) *Payload {
+		p := srv.newPayload(&srv.dbft.Context, dbft.PrepareRequestType, &prepareRequest{
+			transactionHashes: hashes,
+			sealingEnabled:    true,
+			sealed:            sealed,
+		}).(*Payload)
+		p.payload.(*prepareRequest).prevHash = prevHash
+		p.message.ValidatorIndex = 1
+		require.NoError(t, p.Sign(priv))
+		return p
+	}
+	t.Run("verify", func(t *testing.T) {
+		require.ErrorIs(t, srv.verifyRequest(newRequest(t, []byte{1})), errInvalidSeal)
+		require.ErrorIs(t, srv.verifyRequest(newRequest(t, nil)), errInvalidSeal)
+		sealed, err := sealer.Seal(srv.dbft.BlockIndex, hashes)
+		require.NoError(t, err)
+		require.NoError(t, srv.verifyRequest(newRequest(t, sealed)))
+	})
+
+	sealed, err := sealer.Seal(srv.dbft.BlockIndex, hashes)
+	require.NoError(t, err)
+	t.Run("share", func(t *testing.T) {
+		srv.dbft.PreparationPayloads[srv.dbft.PrimaryIndex] = nil
+		c := srv.newCommit(make([]byte, signatureSize)).(*commit)
+		require.True(t, c.sealingEnabled)
+		require.Nil(t, c.share)
+
+		srv.dbft.PreparationPayloads[srv.dbft.PrimaryIndex] = newRequest(t, sealed)
+		c = srv.newCommit(make([]byte, signatureSize)).(*commit)
+		require.Equal(t, sealer.share, c.share)
+	})
+
+	t.Run("reveal", func(t *testing.T) {
+		srv.dbft.PreparationPayloads[srv.dbft.PrimaryIndex] = newRequest(t, sealed)
+		cp := srv.newPayload(&srv.dbft.Context, dbft.CommitType, srv.newCommit(make([]byte, signatureSize)))
+		cp.(*Payload).message.ValidatorIndex = 2
+		srv.dbft.CommitPayloads[2] = cp
+
+		b := testchain.NewBlock(t, bc, 1, 0)
+		require.NoError(t, srv.processBlock(&neoBlock{Block: *b}))
+		require.Equal(t, b.Hash(), sealer.revealed.Hash())
+		require.Equal(t, sealed, sealer.sealed)
+		require.Equal(t, len(srv.dbft.Validators), len(sealer.shares))
+		for i := range sealer.shares {
+			if i == 2 {
+				require.Equal(t, sealer.share, sealer.shares[i])
+			} else {
+				require.Nil(t, sealer.shares[i])
+			}
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package consensus
+
+import (
+	"fmt"
+
+	"github.com/nspcc-dev/dbft"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/services/helpers/extsigner"
+)
+
+// BlockSigner is an external signer used by the consensus service to sign
+// consensus payloads and blocks instead of the local wallet. Any
+// extsigner.Signer (remote service, HSM, etc.) can be used as BlockSigner.
+type BlockSigner = extsigner.Signer
+
+// externalKey is a dbft.PrivateKey used when signatures are produced by the
+// external signer. If the signer fails and the same key is available in the
+// local wallet, fallback key is used.
+type externalKey struct {
+	signer   BlockSigner
+	pub      *keys.PublicKey
+	fallback *keys.PrivateKey
+}
+
+// signHashable signs the given item using the key returned from getKeyPair
+// (either a local *keys.PrivateKey or an externalKey). It returns the
+// signature and the public key corresponding to it.
+func signHashable(key dbft.PrivateKey, net uint32, hh hash.Hashable) ([]byte, *keys.PublicKey, error) {
+	switch k := key.(type) {
+	case *keys.PrivateKey:
+		return k.SignHashable(net, hh), k.PublicKey(), nil
+	case *externalKey:
+		sig, err := k.signer.SignHash(k.pub, hash.NetSha256(net, hh))
+		if err != nil {
+			if k.fallback != nil {
+				return k.fallback.SignHashable(net, hh), k.pub, nil
+			}
+			return nil, nil, fmt.Errorf("external signer: %w", err)
+		}
+		return sig, k.pub, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported key type %T", key)
+	}
+}
@@ -17,6 +17,8 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
 	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/require"
 )
@@ -33,6 +35,38 @@ func BenchmarkBlockchain_VerifyWitness(t *testing.B) {
 	}
 }
 
+func BenchmarkBlockchain_InvokeNEP17Transfer(t *testing.B) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	gasHash := e.NativeHash(t, nativenames.Gas)
+	to := random.Uint160()
+
+	b := smartcontract.NewBuilder()
+	for range 100 {
+		b.InvokeWithAssert(gasHash, "transfer", acc.ScriptHash(), to, 1, nil)
+	}
+	script, err := b.Script()
+	require.NoError(t, err)
+	tx := transaction.New(script, 0)
+	tx.Signers = []transaction.Signer{{Account: acc.ScriptHash(), Scopes: transaction.CalledByEntry}}
+
+	t.ReportAllocs()
+	t.ResetTimer()
+	for range t.N {
+		t.StopTimer()
+		ic, err := bc.GetTestVM(trigger.Application, tx, nil)
+		require.NoError(t, err)
+		ic.VM.GasLimit = -1
+		ic.VM.LoadScriptWithFlags(tx.Script, callflag.All)
+		t.StartTimer()
+		err = ic.VM.Run()
+		t.StopTimer()
+		require.NoError(t, err)
+		ic.Finalize()
+		t.StartTimer()
+	}
+}
+
 func BenchmarkBlockchain_ForEachNEP17Transfer(t *testing.B) {
 	var stores = map[string]func(testing.TB) storage.Store{
 		"MemPS": func(t testing.TB) storage.Store {
@@ -39,6 +39,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
 	"go.uber.org/zap"
@@ -52,6 +53,7 @@ const (
 	// multisignature account during native GAS contract initialization.
 	DefaultInitialGAS                      = 52000000_00000000
 	defaultGCPeriod                        = 10000
+	defaultGCBatchSize                     = 10000
 	defaultMemPoolSize                     = 50000
 	defaultP2PNotaryRequestPayloadPoolSize = 1000
 	defaultMaxBlockSize                    = 262144
@@ -125,11 +127,28 @@ var (
 	// conflicts with other transaction in the chain or pool according to
 	// Conflicts attribute.
 	ErrHasConflicts = errors.New("has conflicts")
+	// ErrTrustedStateRootMismatch is returned when the state root computed
+	// for the block being stored differs from the trusted one set via
+	// SetTrustedStateRoots.
+	ErrTrustedStateRootMismatch = errors.New("state root doesn't match the trusted one")
+	// ErrStateRemoved is returned when historic state requested is outdated
+	// and is already removed from the storage.
+	ErrStateRemoved = errors.New("state is outdated and removed from the storage")
 )
 var (
 	persistInterval = 1 * time.Second
 )
 
+// ExecutionObserver is a callback invoked before each VM instruction is
+// executed during block processing (OnPersist, transactions and PostPersist
+// executions). It gets the interop context of the current execution (with
+// its VM, container and block), script hash of the executing contract, the
+// instruction offset and opcode. Observers are called synchronously from the
+// block processing routine, so they must be fast and must not change the
+// context or VM state; they can't call Blockchain methods that need
+// addLock (like AddBlock) either.
+type ExecutionObserver func(ic *interop.Context, scriptHash util.Uint160, offset int, op opcode.Opcode)
+
 // Blockchain represents the blockchain. It maintans internal state representing
 // the state of the ledger that can be accessed in various ways and changed by
 // adding new blocks or headers.
@@ -184,6 +203,15 @@ type Blockchain struct {
 	// by the time it runs, so we use a tiny little cache to sync block
 	// removal (performed in storeBlock()) with transfer/MPT GC (tryRunGC())
 	gcBlockTimes *lru.Cache[uint32, uint64]
+	// gcLock prevents persist from happening while the background garbage
+	// collector removes a batch of keys.
+	gcLock sync.Mutex
+	// gcCh wakes gcLoop up after every persist.
+	gcCh chan struct{}
+	// gcToExitCh is closed when gcLoop exits.
+	gcToExitCh chan struct{}
+	// gcHeight is the persisted height the last GC cycle was run at.
+	gcHeight atomic.Uint32
 
 	// Stop synchronization mechanisms.
 	stopCh      chan struct{}
@@ -218,6 +246,15 @@ type Blockchain struct {
 	events  chan bcEvent
 	subCh   chan any
 	unsubCh chan any
+
+	// execObservers are execution observers registered via
+	// RegisterExecutionObserver, protected by addLock.
+	execObservers []ExecutionObserver
+
+	// trustedRoots are index->root checkpoints set via SetTrustedStateRoots
+	// that locally computed state roots are checked against, protected by
+	// addLock.
+	trustedRoots map[uint32]util.Uint256
 }
 
 // StateRoot represents local state root module.
@@ -247,6 +284,8 @@ type transferData struct {
 	Info  state.TokenTransferInfo
 	Log11 state.TokenTransferLog
 	Log17 state.TokenTransferLog
+	// Indexed is the number of transfers added to the transfer index.
+	Indexed uint32
 }
 
 // NewBlockchain returns a new blockchain object the will use the
@@ -315,6 +354,9 @@ func NewBlockchain(s storage.Store, cfg config.Blockchain, log *zap.Logger) (*Bl
 	if cfg.RemoveUntraceableHeaders && !cfg.RemoveUntraceableBlocks {
 		return nil, errors.New("RemoveUntraceableHeaders is enabled, but RemoveUntraceableBlocks is not")
 	}
+	if cfg.KeepRecentStateRoots != 0 && !cfg.KeepOnlyLatestState {
+		return nil, errors.New("KeepRecentStateRoots is set, but KeepOnlyLatestState is not enabled")
+	}
 	if cfg.Hardforks == nil {
 		cfg.Hardforks = map[string]uint32{}
 		for _, hf := range config.StableHardforks {
@@ -334,10 +376,23 @@ func NewBlockchain(s storage.Store, cfg config.Blockchain, log *zap.Logger) (*Bl
 	}
 
 	// Local config consistency checks.
-	if cfg.Ledger.RemoveUntraceableBlocks && cfg.Ledger.GarbageCollectionPeriod == 0 {
+	if cfg.Ledger.TransferLogRetention < 0 {
+		return nil, errors.New("negative TransferLogRetention")
+	}
+	if cfg.Ledger.MPTNodeCacheSize < 0 {
+		return nil, errors.New("negative MPTNodeCacheSize")
+	}
+	if cfg.Ledger.VerificationWorkers < 0 {
+		return nil, errors.New("negative VerificationWorkers")
+	}
+	if (cfg.Ledger.RemoveUntraceableBlocks || cfg.Ledger.TransferLogRetention > 0) && cfg.Ledger.GarbageCollectionPeriod == 0 {
 		cfg.Ledger.GarbageCollectionPeriod = defaultGCPeriod
 		log.Info("GarbageCollectionPeriod is not set or wrong, using default value", zap.Uint32("GarbageCollectionPeriod", cfg.Ledger.GarbageCollectionPeriod))
 	}
+	if (cfg.Ledger.RemoveUntraceableBlocks || cfg.Ledger.TransferLogRetention > 0) && cfg.Ledger.GarbageCollectionBatchSize <= 0 {
+		cfg.Ledger.GarbageCollectionBatchSize = defaultGCBatchSize
+		log.Info("GarbageCollectionBatchSize is not set or wrong, using default value", zap.Int("GarbageCollectionBatchSize", cfg.Ledger.GarbageCollectionBatchSize))
+	}
 	bc := &Blockchain{
 		config:      cfg,
 		dao:         dao.NewSimple(s, cfg.StateRootInHeader),
@@ -345,6 +400,8 @@ func NewBlockchain(s storage.Store, cfg config.Blockchain, log *zap.Logger) (*Bl
 		store:       s,
 		stopCh:      make(chan struct{}),
 		runToExitCh: make(chan struct{}),
+		gcCh:        make(chan struct{}, 1),
+		gcToExitCh:  make(chan struct{}),
 		memPool:     mempool.New(cfg.MemPoolSize, 0, false, updateMempoolMetrics),
 		log:         log,
 		events:      make(chan bcEvent),
@@ -353,8 +410,16 @@ func NewBlockchain(s storage.Store, cfg config.Blockchain, log *zap.Logger) (*Bl
 		contracts:   *native.NewContracts(cfg.ProtocolConfiguration),
 	}
 
+	if cfg.Ledger.TransactionFilter {
+		// Must be done before any other DAO is derived from bc.dao.
+		bc.dao.InitTransactionFilter()
+	}
+
 	bc.persistCond = sync.NewCond(&bc.lock)
 	bc.gcBlockTimes, _ = lru.New[uint32, uint64](defaultBlockTimesCache) // Never errors for positive size
+	if cfg.Ledger.MPTNodeCacheSize != 0 && (cfg.Ledger.KeepOnlyLatestState || cfg.Ledger.RemoveUntraceableBlocks) {
+		log.Warn("MPTNodeCacheSize is ignored, MPT node cache is only used with complete MPT history")
+	}
 	bc.stateRoot = stateroot.NewModule(cfg, bc.VerifyWitness, bc.log, bc.dao.Store)
 	bc.contracts.Designate.StateRootService = bc.stateRoot
 
@@ -452,6 +517,11 @@ func (bc *Blockchain) init() error {
 			Magic:                      uint32(bc.config.Magic),
 			Value:                      version,
 			SaveInvocations:            bc.config.SaveInvocations,
+			IndexEvents:                bc.config.IndexEvents,
+			TrackStorageUsage:          bc.config.TrackStorageUsage,
+			IndexEventContainers:       bc.config.IndexEventContainers,
+			IndexTransfers:             bc.config.IndexTransfers,
+			KeepRecentStateRoots:       bc.config.Ledger.KeepRecentStateRoots,
 		}
 		bc.dao.PutVersion(ver)
 		bc.dao.Version = ver
@@ -493,6 +563,32 @@ func (bc *Blockchain) init() error {
 		return fmt.Errorf("SaveInvocations setting mismatch (old=%v, new=%v)",
 			ver.SaveInvocations, bc.config.SaveInvocations)
 	}
+	if ver.IndexEvents != bc.config.IndexEvents {
+		return fmt.Errorf("IndexEvents setting mismatch (old=%v, new=%v)",
+			ver.IndexEvents, bc.config.IndexEvents)
+	}
+	if ver.TrackStorageUsage != bc.config.TrackStorageUsage {
+		return fmt.Errorf("TrackStorageUsage setting mismatch (old=%v, new=%v)",
+			ver.TrackStorageUsage, bc.config.TrackStorageUsage)
+	}
+	if ver.IndexEventContainers != bc.config.IndexEventContainers {
+		return fmt.Errorf("IndexEventContainers setting mismatch (old=%v, new=%v)",
+			ver.IndexEventContainers, bc.config.IndexEventContainers)
+	}
+	if ver.IndexTransfers != bc.config.IndexTransfers {
+		return fmt.Errorf("IndexTransfers setting mismatch (old=%v, new=%v)",
+			ver.IndexTransfers, bc.config.IndexTransfers)
+	}
+	if ver.KeepRecentStateRoots != bc.config.Ledger.KeepRecentStateRoots {
+		// It can only be enabled for an existing DB, nodes of older roots
+		// are already removed and the new setting is to be used from now on.
+		if ver.KeepRecentStateRoots != 0 {
+			return fmt.Errorf("KeepRecentStateRoots setting mismatch (old=%v, new=%v)",
+				ver.KeepRecentStateRoots, bc.config.Ledger.KeepRecentStateRoots)
+		}
+		ver.KeepRecentStateRoots = bc.config.Ledger.KeepRecentStateRoots
+		bc.dao.PutVersion(ver)
+	}
 	bc.dao.Version = ver
 	bc.persistent.Version = ver
 
@@ -646,7 +742,7 @@ func (bc *Blockchain) jumpToStateInternal(p uint32, stage stateChangeStage) erro
 			if err != nil {
 				return fmt.Errorf("failed to remove outdated state data for the genesis block: %w", err)
 			}
-			prefixes := []byte{byte(storage.STNEP11Transfers), byte(storage.STNEP17Transfers), byte(storage.STTokenTransferInfo)}
+			prefixes := []byte{byte(storage.STNEP11Transfers), byte(storage.STNEP17Transfers), byte(storage.STTokenTransferInfo), byte(storage.IXTransfers)}
 			for i := range prefixes {
 				cache.Store.Seek(storage.SeekRange{Prefix: prefixes[i : i+1]}, func(k, v []byte) bool {
 					cache.Store.Delete(k)
@@ -654,6 +750,12 @@ func (bc *Blockchain) jumpToStateInternal(p uint32, stage stateChangeStage) erro
 				})
 			}
 		}
+		if bc.config.Ledger.TrackStorageUsage {
+			err := rebuildStorageUsage(cache, p)
+			if err != nil {
+				return fmt.Errorf("failed to rebuild storage usage: %w", err)
+			}
+		}
 		// Update SYS-prefixed info.
 		block, err := bc.dao.GetBlock(bc.GetHeaderHash(p))
 		if err != nil {
@@ -1020,6 +1122,21 @@ func (bc *Blockchain) resetStateInternal(height uint32, stage stateChangeStage)
 		if err != nil {
 			return fmt.Errorf("failed to strip transfer log / transfer info: %w", err)
 		}
+		if bc.config.Ledger.IndexEvents {
+			resetEventIndex(upperCache, height)
+		}
+		if bc.config.Ledger.IndexEventContainers {
+			resetEventContainers(upperCache, height)
+		}
+		if bc.config.Ledger.IndexTransfers {
+			resetTransferIndex(upperCache, height)
+		}
+		if bc.config.Ledger.TrackStorageUsage {
+			err = rebuildStorageUsage(upperCache, height)
+			if err != nil {
+				return fmt.Errorf("failed to rebuild storage usage: %w", err)
+			}
+		}
 
 		upperCache.Store.Put(resetStageKey, []byte{stateResetBit | byte(transfersReset)})
 		bc.log.Info("state root information and NEP transfers are reset", zap.Duration("took", time.Since(p)))
@@ -1123,6 +1240,7 @@ func (bc *Blockchain) Run() {
 	persistTimer := time.NewTimer(persistInterval)
 	defer func() {
 		persistTimer.Stop()
+		<-bc.gcToExitCh
 		if _, err := bc.persist(); err != nil {
 			bc.log.Warn("failed to persist", zap.Error(err))
 		}
@@ -1133,22 +1251,28 @@ func (bc *Blockchain) Run() {
 		close(bc.runToExitCh)
 	}()
 	go bc.notificationDispatcher()
+	gcEnabled := bc.config.Ledger.RemoveUntraceableBlocks || bc.config.Ledger.TransferLogRetention > 0
+	if gcEnabled {
+		bc.gcHeight.Store(atomic.LoadUint32(&bc.persistedHeight))
+		go bc.gcLoop()
+	} else {
+		close(bc.gcToExitCh)
+	}
 	for {
 		select {
 		case <-bc.stopCh:
 			return
 		case <-persistTimer.C:
-			var oldPersisted uint32
-
-			if bc.config.Ledger.RemoveUntraceableBlocks {
-				oldPersisted = atomic.LoadUint32(&bc.persistedHeight)
-			}
 			dur, err := bc.persist()
 			if err != nil {
 				bc.log.Error("failed to persist blockchain", zap.Error(err))
 			}
-			if bc.config.Ledger.RemoveUntraceableBlocks {
-				dur += bc.tryRunGC(oldPersisted)
+			if gcEnabled {
+				updateGCBacklogMetric(atomic.LoadUint32(&bc.persistedHeight) - bc.gcHeight.Load())
+				select {
+				case bc.gcCh <- struct{}{}:
+				default: // GC is running already, it'll be woken up again after the next persist.
+				}
 			}
 			interval := persistInterval - dur
 			interval = max(interval, time.Microsecond) // Reset doesn't work with zero or negative value.
@@ -1157,10 +1281,32 @@ func (bc *Blockchain) Run() {
 	}
 }
 
-func (bc *Blockchain) tryRunGC(oldHeight uint32) time.Duration {
-	var dur time.Duration
+// gcLoop runs old data removal in background after persist, so that it
+// doesn't delay persist and block processing.
+func (bc *Blockchain) gcLoop() {
+	defer close(bc.gcToExitCh)
+	for {
+		select {
+		case <-bc.stopCh:
+			return
+		case <-bc.gcCh:
+			var (
+				oldHeight = bc.gcHeight.Load()
+				newHeight = atomic.LoadUint32(&bc.persistedHeight)
+			)
+			if bc.config.Ledger.RemoveUntraceableBlocks {
+				bc.tryRunGC(oldHeight, newHeight)
+			}
+			if bc.config.Ledger.TransferLogRetention > 0 {
+				bc.tryRunTransferRetention(oldHeight, newHeight)
+			}
+			bc.gcHeight.Store(newHeight)
+			updateGCBacklogMetric(atomic.LoadUint32(&bc.persistedHeight) - newHeight)
+		}
+	}
+}
 
-	newHeight := atomic.LoadUint32(&bc.persistedHeight)
+func (bc *Blockchain) tryRunGC(oldHeight, newHeight uint32) {
 	var tgtBlock = int64(newHeight)
 
 	tgtBlock -= int64(bc.config.MaxTraceableBlocks)
@@ -1177,10 +1323,187 @@ func (bc *Blockchain) tryRunGC(oldHeight uint32) time.Duration {
 	oldHeight /= bc.config.Ledger.GarbageCollectionPeriod
 	newHeight /= bc.config.Ledger.GarbageCollectionPeriod
 	if tgtBlock > int64(bc.config.Ledger.GarbageCollectionPeriod) && newHeight != oldHeight {
-		dur = bc.removeOldTransfers(uint32(tgtBlock))
-		dur += bc.stateRoot.GC(uint32(tgtBlock), bc.store)
+		_ = bc.removeOldTransfers(uint32(tgtBlock))
+		if bc.config.Ledger.IndexEvents {
+			_ = bc.removeOldEventIndex(uint32(tgtBlock))
+		}
+		if bc.config.Ledger.IndexEventContainers {
+			_ = bc.removeOldEventContainers(uint32(tgtBlock))
+		}
+		_ = bc.stateRoot.GC(uint32(tgtBlock), bc.gcStore())
 	}
-	return dur
+}
+
+// tryRunTransferRetention removes transfer logs that are older than the
+// configured TransferLogRetention once per GarbageCollectionPeriod blocks.
+func (bc *Blockchain) tryRunTransferRetention(oldHeight, newHeight uint32) {
+	if oldHeight/bc.config.Ledger.GarbageCollectionPeriod == newHeight/bc.config.Ledger.GarbageCollectionPeriod {
+		return
+	}
+	hdr, err := bc.GetHeader(bc.GetHeaderHash(newHeight))
+	if err != nil {
+		bc.log.Error("failed to get block header for transfer log retention", zap.Uint32("index", newHeight), zap.Error(err))
+		return
+	}
+	retention := uint64(bc.config.Ledger.TransferLogRetention.Milliseconds())
+	if hdr.Timestamp <= retention {
+		return
+	}
+	bc.log.Info("starting transfer log retention", zap.Uint32("index", newHeight))
+	_ = bc.removeTransfersBefore(hdr.Timestamp - retention)
+}
+
+// gcStore returns the Store to be used for old data removal.
+func (bc *Blockchain) gcStore() storage.Store {
+	return batchedGCStore{Store: bc.store, bc: bc}
+}
+
+// errGCInterrupted is returned from batchedGCStore.SeekGC if the Blockchain is
+// being closed.
+var errGCInterrupted = errors.New("interrupted by blockchain shutdown")
+
+// batchedGCStore is a storage.Store wrapper that implements SeekGC in batches
+// of GarbageCollectionBatchSize keys, every batch is processed under gcLock,
+// so persist is delayed by no more than one batch processing time.
+type batchedGCStore struct {
+	storage.Store
+	bc *Blockchain
+}
+
+// SeekGC implements the storage.Store interface. It stops with an error if the
+// Blockchain is being closed.
+func (s batchedGCStore) SeekGC(rng storage.SeekRange, keep func(k, v []byte) bool) error {
+	var (
+		batchSize = s.bc.config.Ledger.GarbageCollectionBatchSize
+		delay     = s.bc.config.Ledger.GarbageCollectionBatchDelay
+	)
+	for {
+		var (
+			deleted = make(map[string][]byte)
+			visited int
+			next    []byte
+		)
+		s.bc.gcLock.Lock()
+		s.Store.Seek(rng, func(k, v []byte) bool {
+			if batchSize > 0 && visited == batchSize {
+				next = bytes.Clone(k[len(rng.Prefix):])
+				return false
+			}
+			visited++
+			if !keep(k, v) {
+				deleted[string(k)] = nil
+			}
+			return true
+		})
+		var err error
+		if len(deleted) != 0 {
+			err = s.Store.PutChangeSet(deleted, nil)
+		}
+		s.bc.gcLock.Unlock()
+		if err != nil || next == nil {
+			return err
+		}
+		rng.Start = next
+		select {
+		case <-s.bc.stopCh:
+			return errGCInterrupted
+		default:
+		}
+		if delay > 0 {
+			select {
+			case <-s.bc.stopCh:
+				return errGCInterrupted
+			case <-time.After(delay):
+			}
+		}
+	}
+}
+
+// resetEventIndex removes event index entries for blocks newer than the given
+// height.
+func resetEventIndex(cache *dao.Simple, height uint32) {
+	cache.Store.Seek(storage.SeekRange{
+		Prefix: []byte{byte(storage.IXEventIndex)},
+	}, func(k, v []byte) bool {
+		if binary.BigEndian.Uint32(k[1+2*util.Uint160Size:]) > height {
+			cache.Store.Delete(bytes.Clone(k))
+		}
+		return true
+	})
+}
+
+// eventContainersIndex returns the block index of the given event container
+// index key.
+func eventContainersIndex(k []byte) uint32 {
+	return binary.BigEndian.Uint32(k[1+util.Uint160Size+1+int(k[1+util.Uint160Size]):])
+}
+
+// resetEventContainers removes event container index entries for blocks newer
+// than the given height.
+func resetEventContainers(cache *dao.Simple, height uint32) {
+	cache.Store.Seek(storage.SeekRange{
+		Prefix: []byte{byte(storage.IXEventContainers)},
+	}, func(k, v []byte) bool {
+		if eventContainersIndex(k) > height {
+			cache.Store.Delete(bytes.Clone(k))
+		}
+		return true
+	})
+}
+
+// resetTransferIndex removes transfer index entries for blocks newer than the
+// given height.
+func resetTransferIndex(cache *dao.Simple, height uint32) {
+	cache.Store.Seek(storage.SeekRange{
+		Prefix: []byte{byte(storage.IXTransfers)},
+	}, func(k, v []byte) bool {
+		if _, index := dao.TransferIndexPosition(k); index > height {
+			cache.Store.Delete(bytes.Clone(k))
+		}
+		return true
+	})
+}
+
+// rebuildStorageUsage recalculates storage usage statistics of all contracts
+// from their current storage which is the state as of the given height.
+// Modification heights can't be restored, so contracts changed after the given
+// height get it as the last modification height.
+func rebuildStorageUsage(d *dao.Simple, height uint32) error {
+	var usage = make(map[int32]*state.StorageUsage)
+	d.Store.Seek(storage.SeekRange{Prefix: []byte{byte(d.Version.StoragePrefix)}}, func(k, v []byte) bool {
+		id := int32(binary.LittleEndian.Uint32(k[1:]))
+		u, ok := usage[id]
+		if !ok {
+			u = new(state.StorageUsage)
+			usage[id] = u
+		}
+		u.Keys++
+		u.KeysSize += uint64(len(k) - 5)
+		u.ValuesSize += uint64(len(v))
+		return true
+	})
+	d.Store.Seek(storage.SeekRange{Prefix: []byte{byte(storage.STStorageUsage)}}, func(k, v []byte) bool {
+		id := int32(binary.BigEndian.Uint32(k[1:]))
+		if _, ok := usage[id]; !ok {
+			usage[id] = new(state.StorageUsage) // Removed by PutStorageUsage.
+		}
+		return true
+	})
+	for id, u := range usage {
+		old, err := d.GetStorageUsage(id)
+		if err != nil {
+			return err
+		}
+		u.LastModified = old.LastModified
+		if old.Keys == 0 || old.LastModified > height { // Changed after the given height.
+			u.LastModified = height
+		}
+		err = d.PutStorageUsage(id, u)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // resetTransfers is a helper function that strips the top newest NEP17 and NEP11 transfer logs
@@ -1322,19 +1645,86 @@ func appendTokenTransferInfo(transferData *state.TokenTransferInfo,
 
 func (bc *Blockchain) removeOldTransfers(index uint32) time.Duration {
 	bc.log.Info("starting transfer data garbage collection", zap.Uint32("index", index))
+	ts, ok := bc.gcBlockTimes.Get(index)
+	if !ok {
+		bc.log.Error("failed to get block timestamp transfer GC", zap.Uint32("index", index))
+		return 0
+	}
+	return bc.removeTransfersBefore(ts)
+}
+
+// removeOldEventIndex removes event index entries for blocks older than the
+// given one.
+func (bc *Blockchain) removeOldEventIndex(index uint32) time.Duration {
 	var (
-		err     error
 		kept    int64
 		removed int64
 		start   = time.Now()
-		ts, ok  = bc.gcBlockTimes.Get(index)
 	)
+	bc.log.Info("starting event index garbage collection", zap.Uint32("index", index))
+	err := bc.gcStore().SeekGC(storage.SeekRange{
+		Prefix: []byte{byte(storage.IXEventIndex)},
+	}, func(k, v []byte) bool {
+		if binary.BigEndian.Uint32(k[1+2*util.Uint160Size:]) < index {
+			removed++
+			return false
+		}
+		kept++
+		return true
+	})
+	dur := time.Since(start)
+	if err != nil {
+		bc.log.Error("failed to flush event index GC changeset", zap.Duration("time", dur), zap.Error(err))
+	} else {
+		bc.log.Info("finished event index garbage collection",
+			zap.Int64("removed", removed),
+			zap.Int64("kept", kept),
+			zap.Duration("time", dur))
+	}
+	return dur
+}
 
-	if !ok {
-		dur := time.Since(start)
-		bc.log.Error("failed to get block timestamp transfer GC", zap.Duration("time", dur), zap.Uint32("index", index))
-		return dur
+// removeOldEventContainers removes event container index entries for blocks
+// older than the given one.
+func (bc *Blockchain) removeOldEventContainers(index uint32) time.Duration {
+	var (
+		kept    int64
+		removed int64
+		start   = time.Now()
+	)
+	bc.log.Info("starting event container index garbage collection", zap.Uint32("index", index))
+	err := bc.gcStore().SeekGC(storage.SeekRange{
+		Prefix: []byte{byte(storage.IXEventContainers)},
+	}, func(k, v []byte) bool {
+		if eventContainersIndex(k) < index {
+			removed++
+			return false
+		}
+		kept++
+		return true
+	})
+	dur := time.Since(start)
+	if err != nil {
+		bc.log.Error("failed to flush event container index GC changeset", zap.Duration("time", dur), zap.Error(err))
+	} else {
+		bc.log.Info("finished event container index garbage collection",
+			zap.Int64("removed", removed),
+			zap.Int64("kept", kept),
+			zap.Duration("time", dur))
 	}
+	return dur
+}
+
+// removeTransfersBefore removes transfer log batches that only contain
+// transfers made not later than the given timestamp. The newest batch of
+// every account is always kept, so transfer info stays consistent.
+func (bc *Blockchain) removeTransfersBefore(ts uint64) time.Duration {
+	var (
+		err     error
+		kept    int64
+		removed int64
+		start   = time.Now()
+	)
 
 	prefixes := []byte{byte(storage.STNEP11Transfers), byte(storage.STNEP17Transfers)}
 
@@ -1342,7 +1732,7 @@ func (bc *Blockchain) removeOldTransfers(index uint32) time.Duration {
 		var acc util.Uint160
 		var canDrop bool
 
-		err = bc.store.SeekGC(storage.SeekRange{
+		err = bc.gcStore().SeekGC(storage.SeekRange{
 			Prefix:    prefixes[i : i+1],
 			Backwards: true, // From new to old.
 		}, func(k, v []byte) bool {
@@ -1368,6 +1758,18 @@ func (bc *Blockchain) removeOldTransfers(index uint32) time.Duration {
 			break
 		}
 	}
+	if err == nil && bc.config.Ledger.IndexTransfers {
+		err = bc.gcStore().SeekGC(storage.SeekRange{
+			Prefix: []byte{byte(storage.IXTransfers)},
+		}, func(k, v []byte) bool {
+			if trTs, _ := dao.TransferIndexPosition(k); trTs <= ts {
+				removed++
+				return false
+			}
+			kept++
+			return true
+		})
+	}
 	dur := time.Since(start)
 	if err != nil {
 		bc.log.Error("failed to flush transfer data GC changeset", zap.Duration("time", dur), zap.Error(err))
@@ -1537,7 +1939,8 @@ func (bc *Blockchain) AddBlock(block *block.Block) error {
 			return errors.New("invalid block: MerkleRoot mismatch")
 		}
 		mp = mempool.New(len(block.Transactions), 0, false, nil)
-		for _, tx := range block.Transactions {
+		witnesses := bc.verifyBlockTxWitnesses(block)
+		for i, tx := range block.Transactions {
 			var err error
 			// Transactions are verified before adding them
 			// into the pool, so there is no point in doing
@@ -1547,6 +1950,8 @@ func (bc *Blockchain) AddBlock(block *block.Block) error {
 				if err == nil {
 					continue
 				}
+			} else if witnesses != nil {
+				err = bc.verifyAndPoolTxWitnessed(tx, mp, bc, witnesses[i])
 			} else {
 				err = bc.verifyAndPoolTx(tx, mp, bc)
 			}
@@ -1644,6 +2049,7 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 			txCnt        int
 			baer1, baer2 *state.AppExecResult
 			transCache   = make(map[util.Uint160]transferData)
+			indexCache   = make(map[util.Uint160]map[string][]int)
 		)
 		kvcache.StoreAsCurrentBlock(block)
 		if bc.config.Ledger.RemoveUntraceableBlocks {
@@ -1690,6 +2096,19 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 			if aer.Execution.VMState == vmstate.Halt {
 				for j := range aer.Execution.Events {
 					bc.handleNotification(&aer.Execution.Events[j], kvcache, transCache, block, aer.Container)
+					if bc.config.Ledger.IndexEvents {
+						err = bc.indexNotification(kvcache, indexCache, block.Index, aer, j)
+						if err != nil {
+							break
+						}
+					}
+				}
+				if err != nil {
+					err = fmt.Errorf("failed to index notification: %w", err)
+					break
+				}
+				if bc.config.Ledger.IndexEventContainers {
+					indexEventContainers(kvcache, block.Index, aer)
 				}
 			}
 		}
@@ -1730,8 +2149,9 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 	for _, tx := range block.Transactions {
 		systemInterop := bc.newInteropContext(trigger.Application, cache, block, tx)
 		systemInterop.ReuseVM(v)
+		bc.setExecObservers(systemInterop)
 		v.LoadScriptWithFlags(tx.Script, callflag.All)
-		v.GasLimit = tx.SystemFee
+		v.GasLimit = bc.TxGasLimit(tx)
 
 		err := systemInterop.Exec()
 		var faultException string
@@ -1749,6 +2169,16 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 				zap.Uint32("block", block.Index),
 				zap.Error(err))
 			faultException = err.Error()
+			if bc.isFreeTx(tx) {
+				events, err := bc.slashFreeTx(cache, block, tx)
+				if err != nil {
+					// Release goroutines, don't care about errors, we already have one.
+					close(aerchan)
+					<-aerdone
+					return fmt.Errorf("failed to slash free transaction deposit: %w", err)
+				}
+				systemInterop.Notifications = append(systemInterop.Notifications, events...)
+			}
 		}
 		aer := &state.AppExecResult{
 			Container: tx.Hash(),
@@ -1776,6 +2206,14 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 	appExecResults = append(appExecResults, aer)
 	aerchan <- aer
 	close(aerchan)
+	if bc.config.Ledger.TrackStorageUsage {
+		err = bc.updateStorageUsage(cache, block.Index)
+		if err != nil {
+			// Release goroutines, don't care about errors, we already have one.
+			<-aerdone
+			return fmt.Errorf("failed to update storage usage: %w", err)
+		}
+	}
 	b := mpt.MapToMPTBatch(cache.Store.GetStorageChanges())
 	mpt, sr, err := bc.stateRoot.AddMPTBatch(block.Index, b, cache.Store)
 	if err != nil {
@@ -1786,6 +2224,11 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 		// because changes applied are the ones from HALTed transactions.
 		return fmt.Errorf("error while trying to apply MPT changes: %w", err)
 	}
+	if root, ok := bc.trustedRoots[sr.Index]; ok && root != sr.Root {
+		// Release goroutines, don't care about errors, we already have one.
+		<-aerdone
+		return fmt.Errorf("%w: block %d, expected %s, got %s", ErrTrustedStateRootMismatch, sr.Index, root.StringBE(), sr.Root.StringBE())
+	}
 	if bc.config.StateRootInHeader && bc.HeaderHeight() > sr.Index {
 		h, err := bc.GetHeader(bc.GetHeaderHash(sr.Index + 1))
 		if err != nil {
@@ -1889,8 +2332,7 @@ func (bc *Blockchain) updateExtensibleWhitelist(height uint32) error {
 		bc.updateExtensibleList(&newList, stateVals)
 	}
 
-	slices.SortFunc(newList, util.Uint160.Compare)
-	bc.extensible.Store(newList)
+	bc.extensible.Store(util.SortUnique(newList))
 	return nil
 }
 
@@ -1914,6 +2356,7 @@ func (bc *Blockchain) runPersist(script []byte, block *block.Block, cache *dao.S
 	} else {
 		systemInterop.ReuseVM(v)
 	}
+	bc.setExecObservers(systemInterop)
 	v.LoadScriptWithFlags(script, callflag.All)
 	if err := systemInterop.Exec(); err != nil {
 		return nil, v, fmt.Errorf("VM has failed: %w", err)
@@ -1932,6 +2375,19 @@ func (bc *Blockchain) runPersist(script []byte, block *block.Block, cache *dao.S
 	}, v, nil
 }
 
+// setExecObservers sets VM hook calling registered execution observers for
+// the given context if there are any.
+func (bc *Blockchain) setExecObservers(ic *interop.Context) {
+	if len(bc.execObservers) == 0 {
+		return
+	}
+	ic.VM.SetOnExecHook(func(scriptHash util.Uint160, offset int, op opcode.Opcode) {
+		for _, o := range bc.execObservers {
+			o(ic, scriptHash, offset, op)
+		}
+	})
+}
+
 func (bc *Blockchain) handleNotification(note *state.NotificationEvent, d *dao.Simple,
 	transCache map[util.Uint160]transferData, b *block.Block, h util.Uint256) {
 	if note.Name != "Transfer" {
@@ -1963,6 +2419,167 @@ func (bc *Blockchain) handleNotification(note *state.NotificationEvent, d *dao.S
 	bc.processTokenTransfer(d, transCache, h, b, note.ScriptHash, from, to, amount, id)
 }
 
+// updateStorageUsage updates storage usage statistics of contracts according
+// to the storage changes made by the block with the given index.
+func (bc *Blockchain) updateStorageUsage(cache *dao.Simple, index uint32) error {
+	var (
+		prefix = byte(cache.Version.StoragePrefix)
+		usage  = make(map[int32]*state.StorageUsage)
+	)
+	for k, v := range cache.Store.GetStorageChanges() {
+		if k[0] != prefix {
+			continue
+		}
+		id := int32(binary.LittleEndian.Uint32([]byte(k[1:5])))
+		u, ok := usage[id]
+		if !ok {
+			var err error
+			u, err = cache.GetStorageUsage(id)
+			if err != nil {
+				return err
+			}
+			u.LastModified = index
+			usage[id] = u
+		}
+		keyLen := uint64(len(k) - 5)
+		old, err := bc.dao.Store.Get([]byte(k))
+		if err == nil {
+			u.Keys--
+			u.KeysSize -= keyLen
+			u.ValuesSize -= uint64(len(old))
+		} else if !errors.Is(err, storage.ErrKeyNotFound) {
+			return err
+		}
+		if v != nil {
+			u.Keys++
+			u.KeysSize += keyLen
+			u.ValuesSize += uint64(len(v))
+		}
+	}
+	for id, u := range usage {
+		err := cache.PutStorageUsage(id, u)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetStorageUsage returns storage usage statistics of the contract with the
+// given ID. It requires TrackStorageUsage setting to be enabled.
+func (bc *Blockchain) GetStorageUsage(id int32) (*state.StorageUsage, error) {
+	if !bc.config.Ledger.TrackStorageUsage {
+		return nil, errors.New("storage usage tracking is disabled")
+	}
+	return bc.dao.GetStorageUsage(id)
+}
+
+// indexedEvents returns positions of indexed parameters for events of the
+// given contract, results are cached in the given map.
+func (bc *Blockchain) indexedEvents(d *dao.Simple, cache map[util.Uint160]map[string][]int, h util.Uint160) map[string][]int {
+	res, ok := cache[h]
+	if !ok {
+		if md := bc.contracts.ByHash(h); md != nil {
+			// Native contract manifest extras don't depend on hardforks, so
+			// the latest (always available) descriptor is used.
+			latest := config.Hardforks[len(config.Hardforks)-1]
+			res, _ = md.Metadata().HFSpecificContractMD(&latest).Manifest.IndexedEvents()
+		} else if cs, err := native.GetContract(d, h); err == nil {
+			res, _ = cs.Manifest.IndexedEvents() // Malformed indexed events description is ignored.
+		}
+		cache[h] = res
+	}
+	return res
+}
+
+// indexNotification adds the n-th notification of the given execution result
+// to the event index if its contract declares any indexed parameters for it.
+// Parameters with non-primitive values are not indexed.
+func (bc *Blockchain) indexNotification(d *dao.Simple, cache map[util.Uint160]map[string][]int,
+	index uint32, aer *state.AppExecResult, n int) error {
+	ne := &aer.Execution.Events[n]
+	params := bc.indexedEvents(d, cache, ne.ScriptHash)[ne.Name]
+	if len(params) == 0 {
+		return nil
+	}
+	arr := ne.Item.Value().([]stackitem.Item)
+	var (
+		positions = make([]int, 0, len(params))
+		values    = make([][]byte, 0, len(params))
+	)
+	for _, p := range params {
+		if p >= len(arr) {
+			continue
+		}
+		v, err := arr[p].TryBytes()
+		if err != nil {
+			continue
+		}
+		positions = append(positions, p)
+		values = append(values, v)
+	}
+	if len(positions) == 0 {
+		return nil
+	}
+	return d.PutEventIndex(index, aer.Container, aer.Execution.Trigger, uint32(n), ne, positions, values)
+}
+
+// indexEventContainers adds the container of the given execution result to
+// the event container index of every event it emitted.
+func indexEventContainers(d *dao.Simple, index uint32, aer *state.AppExecResult) {
+	type event struct {
+		contract util.Uint160
+		name     string
+	}
+	var (
+		events []event
+		counts = make(map[event]uint32)
+	)
+	for i := range aer.Execution.Events {
+		e := event{contract: aer.Execution.Events[i].ScriptHash, name: aer.Execution.Events[i].Name}
+		if counts[e] == 0 {
+			events = append(events, e)
+		}
+		counts[e]++
+	}
+	for _, e := range events {
+		d.PutEventContainer(e.contract, e.name, &state.EventContainer{
+			BlockIndex:    index,
+			Container:     aer.Container,
+			Trigger:       aer.Execution.Trigger,
+			Notifications: counts[e],
+		})
+	}
+}
+
+// ForEachEventContainer executes f for every transaction (or block) that
+// emitted the given contract's event, from the oldest one to the newest one,
+// until f returns false. Iteration starts from the block with the given index
+// or right after the given position (as returned by f before) if it's not nil.
+// It requires IndexEventContainers setting to be enabled.
+func (bc *Blockchain) ForEachEventContainer(contract util.Uint160, event string, index uint32, start []byte,
+	f func(pos []byte, c *state.EventContainer) bool) error {
+	if !bc.config.Ledger.IndexEventContainers {
+		return errors.New("event container index is disabled")
+	}
+	return bc.dao.SeekEventContainers(contract, event, index, start, f)
+}
+
+// ForEachIndexedNotification executes f for every notification of the given
+// contract's event having the given value of the parameter at the given
+// position (see [manifest.Manifest.IndexedEvents]), from the newest
+// notification to the oldest one, until f returns false. Iteration starts right
+// after the given position (as returned by f before), nil position means
+// starting from the newest notification. It requires IndexEvents setting to be
+// enabled.
+func (bc *Blockchain) ForEachIndexedNotification(contract util.Uint160, event string, param int, value []byte,
+	start []byte, f func(pos []byte, ne *state.ContainedNotificationEvent) bool) error {
+	if !bc.config.Ledger.IndexEvents {
+		return errors.New("event index is disabled")
+	}
+	return bc.dao.SeekEventIndex(contract, event, param, value, start, f)
+}
+
 func parseUint160(itm stackitem.Item) (util.Uint160, error) {
 	_, ok := itm.(stackitem.Null) // Minting or burning.
 	if ok {
@@ -2020,6 +2637,9 @@ func (bc *Blockchain) processTokenTransfer(cache *dao.Simple, transCache map[uti
 	if !from.Equals(util.Uint160{}) {
 		_ = nep17xfer.Amount.Neg(nep17xfer.Amount)
 		err := appendTokenTransfer(cache, transCache, from, transfer, id, b.Index, b.Timestamp, isNEP11)
+		if err == nil && bc.config.Ledger.IndexTransfers {
+			err = indexTokenTransfer(cache, transCache, from, nep17xfer, transfer, isNEP11)
+		}
 		_ = nep17xfer.Amount.Neg(nep17xfer.Amount)
 		if err != nil {
 			return
@@ -2027,8 +2647,25 @@ func (bc *Blockchain) processTokenTransfer(cache *dao.Simple, transCache map[uti
 	}
 	if !to.Equals(util.Uint160{}) {
 		nep17xfer.Counterparty = from
-		_ = appendTokenTransfer(cache, transCache, to, transfer, id, b.Index, b.Timestamp, isNEP11) // Nothing useful we can do.
+		err := appendTokenTransfer(cache, transCache, to, transfer, id, b.Index, b.Timestamp, isNEP11)
+		if err == nil && bc.config.Ledger.IndexTransfers {
+			_ = indexTokenTransfer(cache, transCache, to, nep17xfer, transfer, isNEP11) // Nothing useful we can do.
+		}
+	}
+}
+
+// indexTokenTransfer adds the transfer (already appended to the account's
+// log) to the transfer index.
+func indexTokenTransfer(cache *dao.Simple, transCache map[util.Uint160]transferData, addr util.Uint160,
+	tr *state.NEP17Transfer, transfer io.Serializable, isNEP11 bool) error {
+	transferData := transCache[addr]
+	err := cache.PutTransferIndex(addr, isNEP11, tr, transferData.Indexed, transfer)
+	if err != nil {
+		return err
 	}
+	transferData.Indexed++
+	transCache[addr] = transferData
+	return nil
 }
 
 func appendTokenTransfer(cache *dao.Simple, transCache map[util.Uint160]transferData, addr util.Uint160, transfer io.Serializable,
@@ -2084,6 +2721,42 @@ func appendTokenTransfer(cache *dao.Simple, transCache map[util.Uint160]transfer
 	return nil
 }
 
+// ForEachNEP17TransferFiltered is similar to ForEachNEP17Transfer, but only
+// transfers matching the given filter are passed to f. Transfer index is used
+// if IndexTransfers setting is enabled, otherwise the whole log is traversed.
+func (bc *Blockchain) ForEachNEP17TransferFiltered(acc util.Uint160, filter state.TransferFilter, newestTimestamp uint64, f func(*state.NEP17Transfer) (bool, error)) error {
+	if filter.IsEmpty() {
+		return bc.dao.SeekNEP17TransferLog(acc, newestTimestamp, f)
+	}
+	if bc.config.Ledger.IndexTransfers {
+		return bc.dao.SeekNEP17TransferIndex(acc, filter, newestTimestamp, f)
+	}
+	return bc.dao.SeekNEP17TransferLog(acc, newestTimestamp, func(t *state.NEP17Transfer) (bool, error) {
+		if !filter.Matches(t) {
+			return true, nil
+		}
+		return f(t)
+	})
+}
+
+// ForEachNEP11TransferFiltered is similar to ForEachNEP11Transfer, but only
+// transfers matching the given filter are passed to f. Transfer index is used
+// if IndexTransfers setting is enabled, otherwise the whole log is traversed.
+func (bc *Blockchain) ForEachNEP11TransferFiltered(acc util.Uint160, filter state.TransferFilter, newestTimestamp uint64, f func(*state.NEP11Transfer) (bool, error)) error {
+	if filter.IsEmpty() {
+		return bc.dao.SeekNEP11TransferLog(acc, newestTimestamp, f)
+	}
+	if bc.config.Ledger.IndexTransfers {
+		return bc.dao.SeekNEP11TransferIndex(acc, filter, newestTimestamp, f)
+	}
+	return bc.dao.SeekNEP11TransferLog(acc, newestTimestamp, func(t *state.NEP11Transfer) (bool, error) {
+		if !filter.Matches(&t.NEP17Transfer) {
+			return true, nil
+		}
+		return f(t)
+	})
+}
+
 // ForEachNEP17Transfer executes f for each NEP-17 transfer in log starting from
 // the transfer with the newest timestamp up to the oldest transfer. It continues
 // iteration until false is returned from f. The last non-nil error is returned.
@@ -2184,7 +2857,9 @@ func (bc *Blockchain) persist() (time.Duration, error) {
 		err       error
 	)
 
+	bc.gcLock.Lock()
 	persisted, err = bc.dao.Persist()
+	bc.gcLock.Unlock()
 	if err != nil {
 		return 0, err
 	}
@@ -2371,6 +3046,40 @@ func (bc *Blockchain) GetConfig() config.Blockchain {
 	return bc.config
 }
 
+// RegisterExecutionObserver adds the given observer to the list of callbacks
+// invoked for every VM instruction executed while processing new blocks, it
+// allows to collect any custom execution traces (like GAS per opcode or
+// syscall statistics) without VM modifications. Test invocations are not
+// observed. If there is a block being processed, the call waits for it to be
+// stored, observers are applied starting from the next block.
+func (bc *Blockchain) RegisterExecutionObserver(o ExecutionObserver) {
+	bc.addLock.Lock()
+	defer bc.addLock.Unlock()
+	bc.execObservers = append(bc.execObservers, o)
+}
+
+// SetTrustedStateRoots sets the list of trusted state roots (checkpoints)
+// that are compared with the locally computed ones when blocks are stored,
+// block with a state root different from the trusted one for its index is
+// rejected (with ErrTrustedStateRootMismatch) and not stored. Coupled with
+// SkipBlockVerification setting it allows to quickly import blocks from a
+// trusted source without witness checks still making sure the resulting
+// state is correct. Subsequent calls replace the previous list, nil removes
+// all checkpoints. If there is a block being processed, the call waits for
+// it to be stored.
+func (bc *Blockchain) SetTrustedStateRoots(roots []state.MPTRoot) {
+	bc.addLock.Lock()
+	defer bc.addLock.Unlock()
+	if len(roots) == 0 {
+		bc.trustedRoots = nil
+		return
+	}
+	bc.trustedRoots = make(map[uint32]util.Uint256, len(roots))
+	for _, r := range roots {
+		bc.trustedRoots[r.Index] = r.Root
+	}
+}
+
 // SubscribeForBlocks adds given channel to new block event broadcasting, so when
 // there is a new block added to the chain you'll receive it via this channel.
 // Make sure it's read from regularly as not reading these events might affect
@@ -2544,7 +3253,7 @@ func (bc *Blockchain) ApplyPolicyToTxSet(txes []*transaction.Transaction) []*tra
 	)
 	for i, tx := range txes {
 		blockSize += uint32(tx.Size())
-		blockSysFee += tx.SystemFee
+		blockSysFee += bc.TxGasLimit(tx)
 		if blockSize > maxBlockSize || blockSysFee > maxBlockSysFee {
 			txes = txes[:i]
 			break
@@ -2597,6 +3306,69 @@ var (
 // verifyAndPoolTx verifies whether a transaction is bonafide or not and tries
 // to add it to the mempool given.
 func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.Pool, feer mempool.Feer, data ...any) error {
+	return bc.verifyAndPoolTxWitnessed(t, pool, feer, nil, data...)
+}
+
+// txVerificationGas returns the amount of GAS available for the transaction
+// witnesses verification, it's negative if the transaction network fee is
+// not sufficient.
+func (bc *Blockchain) txVerificationGas(t *transaction.Transaction) int64 {
+	if bc.isFreeTx(t) {
+		return bc.config.FreeTransactions.VerificationGAS
+	}
+	return t.NetworkFee - int64(t.Size())*bc.FeePerByte() - bc.CalculateAttributesFee(t)
+}
+
+// verifyBlockTxWitnesses verifies witnesses of block transactions not present
+// in the memory pool using VerificationWorkers goroutines. It returns the
+// verification result for every transaction (nil for transactions from the
+// pool and transactions with insufficient network fee, they're checked by
+// verifyAndPoolTx) or nil if parallel verification is disabled.
+func (bc *Blockchain) verifyBlockTxWitnesses(b *block.Block) []*witnessResult {
+	workers := min(bc.config.VerificationWorkers, len(b.Transactions))
+	if workers <= 1 {
+		return nil
+	}
+	var (
+		res  = make([]*witnessResult, len(b.Transactions))
+		jobs = make(chan int)
+		wg   sync.WaitGroup
+	)
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				tx := b.Transactions[i]
+				gas := bc.txVerificationGas(tx)
+				if gas < 0 {
+					continue
+				}
+				res[i] = &witnessResult{gas: gas, err: bc.verifyTxWitnesses(tx, nil, false, gas)}
+			}
+		}()
+	}
+	for i, tx := range b.Transactions {
+		if !bc.memPool.ContainsKey(tx.Hash()) {
+			jobs <- i
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return res
+}
+
+// witnessResult is the result of transaction witnesses verification performed
+// with the given GAS limit.
+type witnessResult struct {
+	gas int64
+	err error
+}
+
+// verifyAndPoolTxWitnessed is the same as verifyAndPoolTx, but it uses the
+// given witnesses verification result (if it's not nil and it's obtained with
+// the same GAS limit) instead of verifying them.
+func (bc *Blockchain) verifyAndPoolTxWitnessed(t *transaction.Transaction, pool *mempool.Pool, feer mempool.Feer, witnessed *witnessResult, data ...any) error {
 	// This code can technically be moved out of here, because it doesn't
 	// really require a chain lock.
 	err := vm.IsScriptCorrect(t.Script, nil)
@@ -2621,10 +3393,18 @@ func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.
 	if size > transaction.MaxTransactionSize {
 		return fmt.Errorf("%w: (%d > MaxTransactionSize %d)", ErrTxTooBig, size, transaction.MaxTransactionSize)
 	}
-	needNetworkFee := int64(size)*bc.FeePerByte() + bc.CalculateAttributesFee(t)
-	netFee := t.NetworkFee - needNetworkFee
-	if netFee < 0 {
-		return fmt.Errorf("%w: net fee is %v, need %v", ErrTxSmallNetworkFee, t.NetworkFee, needNetworkFee)
+	var netFee int64
+	if bc.isFreeTx(t) {
+		if err := bc.verifyFreeTx(t); err != nil {
+			return err
+		}
+		netFee = bc.config.FreeTransactions.VerificationGAS
+	} else {
+		needNetworkFee := int64(size)*bc.FeePerByte() + bc.CalculateAttributesFee(t)
+		netFee = t.NetworkFee - needNetworkFee
+		if netFee < 0 {
+			return fmt.Errorf("%w: net fee is %v, need %v", ErrTxSmallNetworkFee, t.NetworkFee, needNetworkFee)
+		}
 	}
 	// check that current tx wasn't included in the conflicts attributes of some other transaction which is already in the chain
 	if err := bc.dao.HasTransaction(t.Hash(), t.Signers, height, bc.config.MaxTraceableBlocks); err != nil {
@@ -2637,7 +3417,11 @@ func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.
 			return err
 		}
 	}
-	err = bc.verifyTxWitnesses(t, nil, isPartialTx, netFee)
+	if witnessed != nil && witnessed.gas == netFee && !isPartialTx {
+		err = witnessed.err
+	} else {
+		err = bc.verifyTxWitnesses(t, nil, isPartialTx, netFee)
+	}
 	if err != nil {
 		return err
 	}
@@ -2665,6 +3449,78 @@ func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.
 	return nil
 }
 
+// isFreeTx checks whether the given transaction is a free (zero-fee) one that
+// should be processed according to FreeTransactions settings.
+func (bc *Blockchain) isFreeTx(t *transaction.Transaction) bool {
+	return bc.config.FreeTransactions.Enabled && t.SystemFee == 0 && t.NetworkFee == 0
+}
+
+// TxGasLimit returns the amount of GAS available for the transaction script
+// execution, it's the system fee for regular transactions and
+// FreeTransactions.ExecutionGAS for free ones. This amount is counted against
+// MaxBlockSystemFee.
+func (bc *Blockchain) TxGasLimit(t *transaction.Transaction) int64 {
+	if bc.isFreeTx(t) {
+		return bc.config.FreeTransactions.ExecutionGAS
+	}
+	return t.SystemFee
+}
+
+// verifyFreeTx checks whether the free transaction sender has a Notary deposit
+// that is big enough and can't be withdrawn before the transaction expires.
+func (bc *Blockchain) verifyFreeTx(t *transaction.Transaction) error {
+	if t.HasAttribute(transaction.NotaryAssistedT) {
+		return fmt.Errorf("%w: free transaction can't be notary-assisted", ErrInvalidAttribute)
+	}
+	deposit := bc.contracts.Notary.GetDepositFor(bc.dao, t.Sender())
+	if deposit == nil || deposit.Amount.Cmp(big.NewInt(bc.config.FreeTransactions.Deposit)) < 0 {
+		return fmt.Errorf("%w: deposit is too small for a free transaction", ErrInsufficientFunds)
+	}
+	if deposit.Till < t.ValidUntilBlock {
+		return fmt.Errorf("%w: deposit is locked till %d, while transaction is valid until %d",
+			ErrInsufficientFunds, deposit.Till, t.ValidUntilBlock)
+	}
+	return nil
+}
+
+// slashFreeTx burns a part of the failed free transaction sender's deposit and
+// returns the notifications emitted.
+func (bc *Blockchain) slashFreeTx(cache *dao.Simple, block *block.Block, tx *transaction.Transaction) ([]state.NotificationEvent, error) {
+	ic := bc.newInteropContext(trigger.Application, cache, block, tx)
+	slashed, err := bc.contracts.Notary.SlashDeposit(ic, tx.Sender(), bc.config.FreeTransactions.Deposit)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ic.DAO.Persist(); err != nil {
+		return nil, fmt.Errorf("can't save changes: %w", err)
+	}
+	bc.log.Info("free transaction failed, sender deposit is slashed",
+		zap.String("tx", tx.Hash().StringLE()),
+		zap.String("sender", tx.Sender().StringLE()),
+		zap.Int64("amount", slashed))
+	return ic.Notifications, nil
+}
+
+// FreeTxDeposit returns the amount of sender's Notary deposit reserved for
+// every free transaction in the memory pool (zero if free transactions are
+// disabled). It implements mempool.DepositFeer interface.
+func (bc *Blockchain) FreeTxDeposit() int64 {
+	if !bc.config.FreeTransactions.Enabled {
+		return 0
+	}
+	return bc.config.FreeTransactions.Deposit
+}
+
+// GetFreeTxDepositBalance returns the Notary deposit of the given account that
+// can be used for free transactions. It implements mempool.DepositFeer
+// interface.
+func (bc *Blockchain) GetFreeTxDepositBalance(acc util.Uint160) *big.Int {
+	if !bc.P2PSigExtensionsEnabled() {
+		return big.NewInt(0)
+	}
+	return bc.contracts.Notary.BalanceOf(bc.dao, acc)
+}
+
 // CalculateAttributesFee returns network fee for all transaction attributes that should be
 // paid according to native Policy.
 func (bc *Blockchain) CalculateAttributesFee(tx *transaction.Transaction) int64 {
@@ -2892,7 +3748,7 @@ func (bc *Blockchain) GetTestVM(t trigger.Type, tx *transaction.Transaction, b *
 
 // GetTestHistoricVM returns an interop context with VM set up for a test run.
 func (bc *Blockchain) GetTestHistoricVM(t trigger.Type, tx *transaction.Transaction, nextBlockHeight uint32) (*interop.Context, error) {
-	if bc.config.Ledger.KeepOnlyLatestState {
+	if bc.config.Ledger.KeepOnlyLatestState && bc.config.Ledger.KeepRecentStateRoots == 0 {
 		return nil, errors.New("only latest state is supported")
 	}
 	b, err := bc.getFakeNextBlock(nextBlockHeight)
@@ -2900,12 +3756,37 @@ func (bc *Blockchain) GetTestHistoricVM(t trigger.Type, tx *transaction.Transact
 		return nil, fmt.Errorf("failed to create fake block for height %d: %w", nextBlockHeight, err)
 	}
 	var mode = mpt.ModeAll
-	if bc.config.Ledger.RemoveUntraceableBlocks {
+	if bc.config.Ledger.KeepOnlyLatestState {
+		// State for the block N is based on the stateroot of N-1.
+		if b.Index+bc.config.Ledger.KeepRecentStateRoots <= bc.BlockHeight()+1 {
+			return nil, fmt.Errorf("%w: height %d", ErrStateRemoved, b.Index)
+		}
+		// Inactive nodes of recent roots are still needed.
+		mode = mpt.ModeLatest
+	} else if bc.config.Ledger.RemoveUntraceableBlocks {
 		if b.Index < bc.BlockHeight()-bc.config.MaxTraceableBlocks {
-			return nil, fmt.Errorf("state for height %d is outdated and removed from the storage", b.Index)
+			return nil, fmt.Errorf("%w: height %d", ErrStateRemoved, b.Index)
 		}
 		mode |= mpt.ModeGCFlag
 	}
+	return bc.getTestHistoricVM(t, tx, b, mode, bc.dao.Store)
+}
+
+// GetTestHistoricVMWithStore is similar to GetTestHistoricVM, but it uses MPT
+// nodes from the given store instead of the node's DB, so it can be used for
+// states that are already removed from the DB (see ErrStateRemoved). The store
+// must contain all MPT nodes (as storage.DataMPT items) of the state for
+// nextBlockHeight-1 height, state root for this height is taken from the
+// node's DB.
+func (bc *Blockchain) GetTestHistoricVMWithStore(t trigger.Type, tx *transaction.Transaction, nextBlockHeight uint32, mptStore storage.Store) (*interop.Context, error) {
+	b, err := bc.getFakeNextBlock(nextBlockHeight)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fake block for height %d: %w", nextBlockHeight, err)
+	}
+	return bc.getTestHistoricVM(t, tx, b, mpt.ModeAll, mptStore)
+}
+
+func (bc *Blockchain) getTestHistoricVM(t trigger.Type, tx *transaction.Transaction, b *block.Block, mode mpt.TrieMode, mptStore storage.Store) (*interop.Context, error) {
 	if b.Index < 1 || b.Index > bc.BlockHeight()+1 {
 		return nil, fmt.Errorf("unsupported historic chain's height: requested state for %d, chain height %d", b.Index, bc.blockHeight)
 	}
@@ -2914,7 +3795,7 @@ func (bc *Blockchain) GetTestHistoricVM(t trigger.Type, tx *transaction.Transact
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve stateroot for height %d: %w", b.Index, err)
 	}
-	s := mpt.NewTrieStore(sr.Root, mode, storage.NewPrivateMemCachedStore(bc.dao.Store))
+	s := mpt.NewTrieStore(sr.Root, mode, storage.NewPrivateMemCachedStore(mptStore))
 	dTrie := dao.NewSimple(s, bc.config.StateRootInHeader)
 	dTrie.Version = bc.dao.Version
 	// Initialize native cache before passing DAO to interop context constructor, because
@@ -2947,15 +3828,40 @@ var (
 	ErrVerificationFailed          = errors.New("signature check failed")
 	ErrInvalidInvocationScript     = errors.New("invalid invocation script")
 	ErrInvalidSignature            = fmt.Errorf("%w: invalid signature", ErrVerificationFailed)
+	ErrVerificationScriptFailed    = fmt.Errorf("%w: vm execution has failed", ErrVerificationFailed)
+	ErrInvalidVerificationResult   = fmt.Errorf("%w: invalid verification result", ErrVerificationFailed)
 	ErrInvalidVerificationScript   = errors.New("invalid verification script")
 	ErrUnknownVerificationContract = errors.New("unknown verification contract")
 	ErrInvalidVerificationContract = errors.New("verification contract is missing `verify` method or `verify` method has unexpected return value")
 )
 
+// WitnessVerificationError is returned when some witness check fails, it
+// contains failure details in addition to the underlying error.
+type WitnessVerificationError struct {
+	// Index is the index of the failed witness.
+	Index int
+	// ScriptHash is the hash of the account witness is checked for.
+	ScriptHash util.Uint160
+	// GasConsumed is the amount of GAS spent on the failed witness check.
+	GasConsumed int64
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *WitnessVerificationError) Error() string {
+	return fmt.Sprintf("witness #%d: %s", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *WitnessVerificationError) Unwrap() error {
+	return e.Err
+}
+
 // InitVerificationContext initializes context for witness check.
 func (bc *Blockchain) InitVerificationContext(ic *interop.Context, hash util.Uint160, witness *transaction.Witness) error {
 	if len(witness.VerificationScript) != 0 {
-		if witness.ScriptHash() != hash {
+		if !witness.ScriptHash().ConstantTimeEquals(hash) {
 			return fmt.Errorf("%w: expected %s, got %s", ErrWitnessHashMismatch, hash.StringLE(), witness.ScriptHash().StringLE())
 		}
 		if bc.contracts.ByHash(hash) != nil {
@@ -3017,23 +3923,23 @@ func (bc *Blockchain) verifyHashAgainstScript(hash util.Uint160, witness *transa
 	}
 	err := interopCtx.Exec()
 	if vm.HasFailed() {
-		return 0, fmt.Errorf("%w: vm execution has failed: %w", ErrVerificationFailed, err)
+		return vm.GasConsumed(), fmt.Errorf("%w: %w", ErrVerificationScriptFailed, err)
 	}
 	estack := vm.Estack()
 	if estack.Len() > 0 {
 		resEl := estack.Pop()
 		res, err := resEl.Item().TryBool()
 		if err != nil {
-			return 0, fmt.Errorf("%w: invalid return value", ErrVerificationFailed)
+			return vm.GasConsumed(), fmt.Errorf("%w: invalid return value", ErrInvalidVerificationResult)
 		}
 		if vm.Estack().Len() != 0 {
-			return 0, fmt.Errorf("%w: expected exactly one returned value", ErrVerificationFailed)
+			return vm.GasConsumed(), fmt.Errorf("%w: expected exactly one returned value", ErrInvalidVerificationResult)
 		}
 		if !res {
 			return vm.GasConsumed(), ErrInvalidSignature
 		}
 	} else {
-		return 0, fmt.Errorf("%w: no result returned from the script", ErrVerificationFailed)
+		return vm.GasConsumed(), fmt.Errorf("%w: no result returned from the script", ErrInvalidVerificationResult)
 	}
 	return vm.GasConsumed(), nil
 }
@@ -3050,7 +3956,11 @@ func (bc *Blockchain) verifyTxWitnesses(t *transaction.Transaction, block *block
 	interopCtx := bc.newInteropContext(trigger.Verification, bc.dao, block, t)
 	var gasLimit int64
 	if len(verificationFee) == 0 {
-		gasLimit = t.NetworkFee - int64(t.Size())*bc.FeePerByte() - bc.CalculateAttributesFee(t)
+		if bc.isFreeTx(t) {
+			gasLimit = bc.config.FreeTransactions.VerificationGAS
+		} else {
+			gasLimit = t.NetworkFee - int64(t.Size())*bc.FeePerByte() - bc.CalculateAttributesFee(t)
+		}
 	} else {
 		gasLimit = verificationFee[0]
 	}
@@ -3058,7 +3968,12 @@ func (bc *Blockchain) verifyTxWitnesses(t *transaction.Transaction, block *block
 		gasConsumed, err := bc.verifyHashAgainstScript(t.Signers[i].Account, &t.Scripts[i], interopCtx, gasLimit)
 		if err != nil &&
 			!(i == 0 && isPartialTx && errors.Is(err, ErrInvalidSignature)) { // it's OK for partially-filled transaction with dummy first witness.
-			return fmt.Errorf("witness #%d: %w", i, err)
+			return &WitnessVerificationError{
+				Index:       i,
+				ScriptHash:  t.Signers[i].Account,
+				GasConsumed: gasConsumed,
+				Err:         err,
+			}
 		}
 		gasLimit -= gasConsumed
 	}
@@ -3069,8 +3984,15 @@ func (bc *Blockchain) verifyTxWitnesses(t *transaction.Transaction, block *block
 // verifyHeaderWitnesses is a block-specific implementation of VerifyWitnesses logic.
 func (bc *Blockchain) verifyHeaderWitnesses(currHeader, prevHeader *block.Header) error {
 	hash := prevHeader.NextConsensus
-	_, err := bc.VerifyWitness(hash, currHeader, &currHeader.Script, HeaderVerificationGasLimit)
-	return err
+	gasConsumed, err := bc.VerifyWitness(hash, currHeader, &currHeader.Script, HeaderVerificationGasLimit)
+	if err != nil {
+		return &WitnessVerificationError{
+			ScriptHash:  hash,
+			GasConsumed: gasConsumed,
+			Err:         err,
+		}
+	}
+	return nil
 }
 
 // GoverningTokenHash returns the governing token (NEO) native contract hash.
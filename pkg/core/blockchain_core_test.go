@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"math/big"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -499,3 +500,103 @@ func TestBlockchainRestoreStateRootInHeader(t *testing.T) {
 		}
 	})
 }
+
+func TestBlockchain_RemoveTransfersBefore(t *testing.T) {
+	_, err := initTestChainNoCheck(t, nil, func(c *config.Config) {
+		c.ApplicationConfiguration.TransferLogRetention = -time.Second
+	})
+	require.Error(t, err)
+
+	bc := initTestChain(t, nil, func(c *config.Config) {
+		c.ApplicationConfiguration.TransferLogRetention = time.Hour
+	})
+	require.NotZero(t, bc.config.Ledger.GarbageCollectionPeriod)
+
+	var (
+		acc1 = util.Uint160{1, 2, 3}
+		acc2 = util.Uint160{3, 2, 1}
+		lg   = &state.TokenTransferLog{Raw: []byte{1}}
+	)
+	for i, ts := range []uint64{0, 100, 200, 300} {
+		bc.dao.PutTokenTransferLog(acc1, ts, uint32(i), false, lg)
+		bc.dao.PutTokenTransferLog(acc1, ts, uint32(i), true, lg)
+	}
+	bc.dao.PutTokenTransferLog(acc2, 100, 0, false, lg) // The only (current) batch.
+	_, err = bc.dao.Persist()
+	require.NoError(t, err)
+
+	bc.removeTransfersBefore(250)
+
+	check := func(acc util.Uint160, ts uint64, index uint32, isNEP11 bool, exists bool) {
+		lg, err := bc.dao.GetTokenTransferLog(acc, ts, index, isNEP11)
+		require.NoError(t, err)
+		require.Equal(t, exists, len(lg.Raw) != 0, "%s %d %d %t", acc.StringLE(), ts, index, isNEP11)
+	}
+	for _, isNEP11 := range []bool{false, true} {
+		check(acc1, 0, 0, isNEP11, false)
+		check(acc1, 100, 1, isNEP11, false)
+		check(acc1, 200, 2, isNEP11, true) // May contain transfers after 250.
+		check(acc1, 300, 3, isNEP11, true)
+	}
+	check(acc2, 100, 0, false, true)
+
+	t.Run("index", func(t *testing.T) {
+		bc := initTestChain(t, nil, func(c *config.Config) {
+			c.ApplicationConfiguration.TransferLogRetention = time.Hour
+			c.ApplicationConfiguration.IndexTransfers = true
+		})
+		var asset int32 = 1
+		for i, ts := range []uint64{100, 200, 300} {
+			tr := &state.NEP17Transfer{Asset: asset, Amount: big.NewInt(1), Block: uint32(i), Timestamp: ts}
+			require.NoError(t, bc.dao.PutTransferIndex(acc1, false, tr, 0, tr))
+		}
+		_, err = bc.dao.Persist()
+		require.NoError(t, err)
+
+		bc.removeTransfersBefore(200)
+
+		var actual []uint64
+		require.NoError(t, bc.dao.SeekNEP17TransferIndex(acc1, state.TransferFilter{Asset: &asset}, 1000, func(tr *state.NEP17Transfer) (bool, error) {
+			actual = append(actual, tr.Timestamp)
+			return true, nil
+		}))
+		require.Equal(t, []uint64{300}, actual)
+	})
+}
+
+func TestBlockchain_BatchedGCStore(t *testing.T) {
+	bc := initTestChain(t, nil, func(c *config.Config) {
+		c.ApplicationConfiguration.RemoveUntraceableBlocks = true
+		c.ApplicationConfiguration.GarbageCollectionBatchSize = 2
+	})
+	prefix := []byte{byte(storage.IXEventIndex)}
+	keys := make(map[string][]byte)
+	for i := range byte(7) {
+		keys[string(append(prefix, i))] = []byte{i}
+	}
+	require.NoError(t, bc.store.PutChangeSet(keys, nil))
+
+	for _, backwards := range []bool{false, true} {
+		var visited []byte
+		err := bc.gcStore().SeekGC(storage.SeekRange{Prefix: prefix, Backwards: backwards}, func(k, v []byte) bool {
+			visited = append(visited, v[0])
+			return v[0]%2 == 0 || backwards
+		})
+		require.NoError(t, err)
+		if !backwards {
+			require.Equal(t, []byte{0, 1, 2, 3, 4, 5, 6}, visited)
+		} else {
+			require.Equal(t, []byte{6, 4, 2, 0}, visited)
+		}
+	}
+
+	close(bc.stopCh)
+	err := bc.gcStore().SeekGC(storage.SeekRange{Prefix: prefix}, func(k, v []byte) bool { return false })
+	require.Error(t, err)
+	var left int
+	bc.store.Seek(storage.SeekRange{Prefix: prefix}, func(k, v []byte) bool {
+		left++
+		return true
+	})
+	require.Equal(t, 2, left) // The first batch only.
+}
@@ -1,12 +1,15 @@
 package core_test
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -22,8 +25,10 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
@@ -39,8 +44,10 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
 	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/notary"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
@@ -621,6 +628,79 @@ func TestBlockchain_AddBadBlock(t *testing.T) {
 	})
 }
 
+func TestBlockchain_AddBlockParallelVerification(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	neoHash := e.NativeHash(t, nativenames.Neo)
+
+	newTxs := func(bad ...int) []*transaction.Transaction {
+		var txs []*transaction.Transaction
+		for i := range 6 {
+			tx := e.NewUnsignedTx(t, neoHash, "transfer", acc.ScriptHash(), util.Uint160{1, 2, 3}, 1, nil)
+			e.SignTx(t, tx, -1, acc)
+			if slices.Contains(bad, i) {
+				tx.Scripts[0].InvocationScript[10] ^= 0xff
+			}
+			txs = append(txs, tx)
+		}
+		return txs
+	}
+	for _, workers := range []int{0, 4} {
+		t.Run(strconv.Itoa(workers), func(t *testing.T) {
+			bc2, _ := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+				c.VerifyTransactions = true
+				c.VerificationWorkers = workers
+			})
+			txs := newTxs(2, 4)
+			b := e.NewUnsignedBlock(t, txs...)
+			e.SignBlock(b)
+			err := bc2.AddBlock(b)
+			require.ErrorContains(t, err, txs[2].Hash().StringLE())
+			require.ErrorIs(t, err, core.ErrInvalidSignature)
+			require.EqualValues(t, 0, bc2.BlockHeight())
+
+			b = e.NewUnsignedBlock(t, newTxs()...)
+			e.SignBlock(b)
+			require.NoError(t, bc2.AddBlock(b))
+			require.EqualValues(t, 1, bc2.BlockHeight())
+		})
+	}
+}
+
+func TestBlockchain_SetTrustedStateRoots(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	neoHash := e.NativeHash(t, nativenames.Neo)
+
+	var blocks []*block.Block
+	for range 3 {
+		tx := e.NewTx(t, []neotest.Signer{acc}, neoHash, "transfer", acc.ScriptHash(), util.Uint160{1, 2, 3}, 1, nil)
+		blocks = append(blocks, e.AddNewBlock(t, tx))
+	}
+	var roots []state.MPTRoot
+	for _, b := range blocks {
+		sr, err := bc.GetStateModule().GetStateRoot(b.Index)
+		require.NoError(t, err)
+		roots = append(roots, *sr)
+	}
+
+	bc2, _ := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+		c.SkipBlockVerification = true
+	})
+	bad := slices.Clone(roots)
+	bad[1].Root = util.Uint256{1, 2, 3}
+	bc2.SetTrustedStateRoots(bad)
+	require.NoError(t, bc2.AddBlock(blocks[0]))
+	require.ErrorIs(t, bc2.AddBlock(blocks[1]), core.ErrTrustedStateRootMismatch)
+	require.Equal(t, blocks[0].Index, bc2.BlockHeight())
+	require.Equal(t, roots[0].Root, bc2.GetStateModule().CurrentLocalStateRoot())
+
+	bc2.SetTrustedStateRoots(roots)
+	require.NoError(t, bc2.AddBlock(blocks[1]))
+	require.NoError(t, bc2.AddBlock(blocks[2]))
+	require.Equal(t, roots[2].Root, bc2.GetStateModule().CurrentLocalStateRoot())
+}
+
 func TestBlockchain_GetHeader(t *testing.T) {
 	bc, acc := chain.NewSingle(t)
 	e := neotest.NewExecutor(t, bc, acc, acc)
@@ -1082,6 +1162,7 @@ func TestBlockchain_RemoveUntraceable(t *testing.T) {
 		bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
 			c.MaxTraceableBlocks = 2
 			c.Ledger.GarbageCollectionPeriod = 2
+			c.Ledger.GarbageCollectionBatchSize = 1
 			c.Ledger.RemoveUntraceableBlocks = true
 		})
 		e := neotest.NewExecutor(t, bc, acc, acc)
@@ -1150,6 +1231,107 @@ func TestBlockchain_RemoveUntraceable(t *testing.T) {
 	})
 }
 
+func TestBlockchain_KeepRecentStateRoots(t *testing.T) {
+	const keep = 3
+	neoCommitteeKey := []byte{0xfb, 0xff, 0xff, 0xff, 0x0e}
+
+	t.Run("without KeepOnlyLatestState", func(t *testing.T) {
+		_, _, _, err := chain.NewMultiWithCustomConfigAndStoreNoCheck(t, func(c *config.Blockchain) {
+			c.Ledger.KeepRecentStateRoots = keep
+		}, storage.NewMemoryStore())
+		require.ErrorContains(t, err, "KeepOnlyLatestState is not enabled")
+	})
+
+	bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+		c.Ledger.KeepOnlyLatestState = true
+		c.Ledger.KeepRecentStateRoots = keep
+	})
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	neoValidatorInvoker := e.ValidatorInvoker(e.NativeHash(t, nativenames.Neo))
+	sm := bc.GetStateModule()
+
+	var roots []util.Uint256
+	for range 2 * keep {
+		neoValidatorInvoker.Invoke(t, true, "transfer", acc.ScriptHash(), util.Uint160{1, 2, 3}, 1, nil)
+		sr, err := sm.GetStateRoot(bc.BlockHeight())
+		require.NoError(t, err)
+		roots = append(roots, sr.Root)
+	}
+	for i, r := range roots {
+		_, err := sm.GetState(r, neoCommitteeKey)
+		if i < len(roots)-keep {
+			require.Error(t, err, i)
+		} else {
+			require.NoError(t, err, i)
+		}
+	}
+
+	// The oldest available state is used for the next block.
+	_, err := bc.GetTestHistoricVM(trigger.Application, nil, bc.BlockHeight()-keep+2)
+	require.NoError(t, err)
+	_, err = bc.GetTestHistoricVM(trigger.Application, nil, bc.BlockHeight()-keep+1)
+	require.ErrorIs(t, err, core.ErrStateRemoved)
+
+	t.Run("restart", func(t *testing.T) {
+		path := t.TempDir()
+		open := func(keep uint32) error {
+			ps, _ := newLevelDBForTestingWithPath(t, path)
+			bc, _, _, err := chain.NewMultiWithCustomConfigAndStoreNoCheck(t, func(c *config.Blockchain) {
+				c.Ledger.KeepOnlyLatestState = true
+				c.Ledger.KeepRecentStateRoots = keep
+			}, ps)
+			if err != nil {
+				require.NoError(t, ps.Close())
+				return err
+			}
+			go bc.Run()
+			bc.Close()
+			return nil
+		}
+		require.NoError(t, open(0))
+		require.NoError(t, open(keep)) // Can be enabled for an existing DB.
+		require.NoError(t, open(keep))
+		require.ErrorContains(t, open(keep+1), "KeepRecentStateRoots setting mismatch")
+		require.ErrorContains(t, open(0), "KeepRecentStateRoots setting mismatch")
+	})
+}
+
+func TestBlockchain_GetTestHistoricVMWithStore(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	neoValidatorInvoker := e.ValidatorInvoker(e.NativeHash(t, nativenames.Neo))
+	sm := bc.GetStateModule()
+
+	neoValidatorInvoker.Invoke(t, true, "transfer", acc.ScriptHash(), util.Uint160{1, 2, 3}, 1, nil)
+	h := bc.BlockHeight()
+	sr, err := sm.GetStateRoot(h)
+	require.NoError(t, err)
+	neoValidatorInvoker.Invoke(t, true, "transfer", acc.ScriptHash(), util.Uint160{1, 2, 3}, 1, nil)
+
+	// Rebuild the state for h from its items only.
+	mptStore := storage.NewMemoryStore()
+	tr := mpt.NewTrie(nil, mpt.ModeAll, storage.NewMemCachedStore(mptStore))
+	sm.SeekStates(sr.Root, nil, func(k, v []byte) bool {
+		require.NoError(t, tr.Put(bytes.Clone(k), bytes.Clone(v)))
+		return true
+	})
+	tr.Flush(0)
+	require.Equal(t, sr.Root, tr.StateRoot())
+	_, err = tr.Store.Persist()
+	require.NoError(t, err)
+
+	_, err = bc.GetTestHistoricVMWithStore(trigger.Application, nil, h+1, storage.NewMemoryStore())
+	require.Error(t, err)
+
+	ic, err := bc.GetTestHistoricVMWithStore(trigger.Application, nil, h+1, mptStore)
+	require.NoError(t, err)
+	script, err := smartcontract.CreateCallScript(nativehashes.NeoToken, "balanceOf", util.Uint160{1, 2, 3})
+	require.NoError(t, err)
+	ic.VM.LoadScriptWithFlags(script, callflag.All)
+	require.NoError(t, ic.VM.Run())
+	require.Equal(t, big.NewInt(1), ic.VM.Estack().Pop().BigInt())
+}
+
 func TestBlockchain_InvalidNotification(t *testing.T) {
 	bc, acc := chain.NewSingle(t)
 	e := neotest.NewExecutor(t, bc, acc, acc)
@@ -2571,6 +2753,78 @@ func TestBlockchain_GenesisTransactionExtension(t *testing.T) {
 	require.Equal(t, 0, int(lub))
 }
 
+func TestBlockchain_FreeTransactions(t *testing.T) {
+	const deposit = 1_0000_0000
+	bc, validator, committee := chain.NewMultiWithCustomConfig(t, func(c *config.Blockchain) {
+		c.P2PSigExtensions = true
+		c.FreeTransactions = config.FreeTransactions{
+			Enabled:         true,
+			Deposit:         deposit,
+			VerificationGAS: 1_0000_0000,
+			ExecutionGAS:    1_0000_0000,
+		}
+	})
+	e := neotest.NewExecutor(t, bc, validator, committee)
+	acc := e.NewAccount(t)
+	notaryHash := e.NativeHash(t, nativenames.Notary)
+	gasInvoker := e.NewInvoker(e.NativeHash(t, nativenames.Gas), acc)
+
+	newFreeTx := func(t *testing.T, script []byte) *transaction.Transaction {
+		tx := transaction.New(script, 0)
+		tx.Nonce = neotest.Nonce()
+		tx.ValidUntilBlock = bc.BlockHeight() + 5
+		tx.Signers = []transaction.Signer{{
+			Account: acc.ScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		}}
+		require.NoError(t, acc.SignTx(netmode.UnitTestNet, tx))
+		return tx
+	}
+	goodScript := []byte{byte(opcode.PUSH1)}
+
+	// No deposit.
+	require.ErrorIs(t, bc.VerifyTx(newFreeTx(t, goodScript)), core.ErrInsufficientFunds)
+
+	till := bc.BlockHeight() + 20
+	gasInvoker.Invoke(t, true, "transfer", acc.ScriptHash(), notaryHash, 2*deposit, &notary.OnNEP17PaymentData{Till: till})
+	require.Equal(t, int64(2*deposit), bc.GetFreeTxDepositBalance(acc.ScriptHash()).Int64())
+
+	t.Run("deposit expires", func(t *testing.T) {
+		tx := transaction.New(goodScript, 0)
+		tx.ValidUntilBlock = till + 1
+		tx.Signers = []transaction.Signer{{Account: acc.ScriptHash()}}
+		require.NoError(t, acc.SignTx(netmode.UnitTestNet, tx))
+		require.ErrorIs(t, bc.VerifyTx(tx), core.ErrInsufficientFunds)
+	})
+
+	t.Run("good", func(t *testing.T) {
+		balance := bc.GetUtilityTokenBalance(acc.ScriptHash())
+		tx1 := newFreeTx(t, goodScript)
+		tx2 := newFreeTx(t, goodScript)
+		require.NoError(t, bc.PoolTx(tx1))
+		require.NoError(t, bc.PoolTx(tx2))
+		// The whole deposit is reserved by pooled transactions.
+		require.ErrorIs(t, bc.PoolTx(newFreeTx(t, goodScript)), core.ErrMemPoolConflict)
+
+		e.AddNewBlock(t, tx1, tx2)
+		e.CheckHalt(t, tx1.Hash(), stackitem.Make(1))
+		e.CheckHalt(t, tx2.Hash(), stackitem.Make(1))
+		e.CheckGASBalance(t, acc.ScriptHash(), balance)
+		require.Equal(t, int64(2*deposit), bc.GetFreeTxDepositBalance(acc.ScriptHash()).Int64())
+	})
+
+	t.Run("slashed", func(t *testing.T) {
+		tx := newFreeTx(t, []byte{byte(opcode.ABORT)})
+		require.NoError(t, bc.PoolTx(tx))
+		e.AddNewBlock(t, tx)
+		aer := e.GetTxExecResult(t, tx.Hash())
+		require.Equal(t, vmstate.Fault, aer.VMState)
+		require.Equal(t, int64(deposit), bc.GetFreeTxDepositBalance(acc.ScriptHash()).Int64())
+		require.Equal(t, 1, len(aer.Events))
+		require.Equal(t, "Transfer", aer.Events[0].Name)
+	})
+}
+
 // TestNativenames ensures that nativenames.All contains all expected native contract names
 // in the right order.
 func TestNativenames(t *testing.T) {
@@ -2762,3 +3016,343 @@ func TestRuntimeNotifyRefcounting(t *testing.T) {
 		require.Equal(t, expected, aer[0].Events[i])
 	}
 }
+
+func TestBlockchain_RegisterExecutionObserver(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	var (
+		triggers = make(map[trigger.Type]int)
+		syscalls int
+		gasOps   = make(map[util.Uint160]int)
+	)
+	bc.RegisterExecutionObserver(func(ic *interop.Context, scriptHash util.Uint160, offset int, op opcode.Opcode) {
+		require.NotNil(t, ic.Block)
+		triggers[ic.Trigger]++
+		if op == opcode.SYSCALL {
+			syscalls++
+		}
+		gasOps[scriptHash]++
+	})
+
+	gasHash := e.NativeHash(t, nativenames.Gas)
+	tx := e.NewTx(t, []neotest.Signer{acc}, gasHash, "transfer", acc.ScriptHash(), util.Uint160{1, 2, 3}, 1, nil)
+	e.AddNewBlock(t, tx)
+	e.CheckHalt(t, tx.Hash())
+
+	require.NotZero(t, triggers[trigger.OnPersist])
+	require.NotZero(t, triggers[trigger.PostPersist])
+	require.NotZero(t, triggers[trigger.Application])
+	require.NotZero(t, syscalls)
+	require.NotZero(t, gasOps[gasHash])
+
+	// Every instruction is observed (PUSH1 and implicit RET).
+	before := triggers[trigger.Application]
+	e.InvokeScriptCheckHALT(t, []byte{byte(opcode.PUSH1)}, []neotest.Signer{acc}, stackitem.Make(1))
+	require.Equal(t, before+2, triggers[trigger.Application])
+}
+
+func TestBlockchain_IndexEvents(t *testing.T) {
+	cfg := func(c *config.Blockchain) {
+		c.Ledger.IndexEvents = true
+	}
+	db, path := newLevelDBForTestingWithPath(t, t.TempDir())
+	bc, acc := chain.NewSingleWithCustomConfigAndStore(t, cfg, db, false)
+	go bc.Run()
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	src := `package test
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		)
+		func Emit(key string, value int) {
+			runtime.Notify("Event", key, value, []any{})
+		}`
+	c := neotest.CompileSource(t, acc.ScriptHash(), strings.NewReader(src), &compiler.Options{
+		Name: "test_contract",
+		ContractEvents: []compiler.HybridEvent{
+			{
+				Name: "Event",
+				Parameters: []compiler.HybridParameter{
+					{Parameter: manifest.NewParameter("key", smartcontract.StringType), Indexed: true},
+					{Parameter: manifest.NewParameter("value", smartcontract.IntegerType), Indexed: true},
+					{Parameter: manifest.NewParameter("data", smartcontract.ArrayType)},
+				},
+			},
+		},
+	})
+	e.DeployContract(t, c, nil)
+
+	cInv := e.NewInvoker(c.Hash, acc)
+	var hashes []util.Uint256
+	for i := range 3 {
+		hashes = append(hashes, cInv.Invoke(t, stackitem.Null{}, "emit", "a", i%2))
+	}
+	hashes = append(hashes, cInv.Invoke(t, stackitem.Null{}, "emit", "b", 1))
+
+	check := func(t *testing.T, param int, value []byte, expected ...util.Uint256) {
+		var (
+			actual []util.Uint256
+			pos    []byte
+		)
+		// Iterate one by one to check positions.
+		for {
+			var found bool
+			err := bc.ForEachIndexedNotification(c.Hash, "Event", param, value, pos, func(p []byte, ne *state.ContainedNotificationEvent) bool {
+				require.Equal(t, c.Hash, ne.ScriptHash)
+				require.Equal(t, "Event", ne.Name)
+				actual = append(actual, ne.Container)
+				pos, found = p, true
+				return false
+			})
+			require.NoError(t, err)
+			if !found {
+				break
+			}
+		}
+		require.Equal(t, expected, actual)
+	}
+	check(t, 0, []byte("a"), hashes[2], hashes[1], hashes[0])
+	check(t, 0, []byte("b"), hashes[3])
+	check(t, 0, []byte("c"))
+	check(t, 1, []byte{}, hashes[2], hashes[0])
+	check(t, 1, []byte{1}, hashes[3], hashes[1])
+	check(t, 2, []byte{})
+
+	// Index entries for the removed blocks are dropped on state reset.
+	bc.Close()
+	db, _ = newLevelDBForTestingWithPath(t, path)
+	defer db.Close()
+	bc, _ = chain.NewSingleWithCustomConfigAndStore(t, cfg, db, false)
+	require.NoError(t, bc.Reset(3))
+	check(t, 0, []byte("a"), hashes[1], hashes[0])
+	check(t, 0, []byte("b"))
+	check(t, 1, []byte{1}, hashes[1])
+
+	t.Run("disabled", func(t *testing.T) {
+		bc, _ := chain.NewSingle(t)
+		err := bc.ForEachIndexedNotification(c.Hash, "Event", 0, []byte("a"), nil, func([]byte, *state.ContainedNotificationEvent) bool {
+			return true
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestBlockchain_TrackStorageUsage(t *testing.T) {
+	cfg := func(c *config.Blockchain) {
+		c.Ledger.TrackStorageUsage = true
+	}
+	db, path := newLevelDBForTestingWithPath(t, t.TempDir())
+	bc, acc := chain.NewSingleWithCustomConfigAndStore(t, cfg, db, false)
+	go bc.Run()
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	src := `package test
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		)
+		func Put(key, value []byte) {
+			storage.Put(storage.GetContext(), key, value)
+		}
+		func Del(key []byte) {
+			storage.Delete(storage.GetContext(), key)
+		}`
+	c := neotest.CompileSource(t, acc.ScriptHash(), strings.NewReader(src), &compiler.Options{
+		Name: "test_contract",
+	})
+	e.DeployContract(t, c, nil)
+	id := e.Chain.GetContractState(c.Hash).ID
+
+	check := func(t *testing.T, keys, keysSize, valuesSize uint64, lastModified uint32) {
+		u, err := bc.GetStorageUsage(id)
+		require.NoError(t, err)
+		require.Equal(t, &state.StorageUsage{
+			Keys:         keys,
+			KeysSize:     keysSize,
+			ValuesSize:   valuesSize,
+			LastModified: lastModified,
+		}, u)
+	}
+	check(t, 0, 0, 0, 0)
+
+	cInv := e.NewInvoker(c.Hash, acc)
+	cInv.Invoke(t, stackitem.Null{}, "put", []byte("k1"), []byte("value"))
+	check(t, 1, 2, 5, bc.BlockHeight())
+	cInv.Invoke(t, stackitem.Null{}, "put", []byte("key2"), []byte("v"))
+	check(t, 2, 6, 6, bc.BlockHeight())
+	h := bc.BlockHeight()
+	cInv.Invoke(t, stackitem.Null{}, "put", []byte("k1"), []byte("val"))
+	check(t, 2, 6, 4, bc.BlockHeight())
+	cInv.Invoke(t, stackitem.Null{}, "del", []byte("k1"))
+	check(t, 1, 4, 1, bc.BlockHeight())
+	cInv.Invoke(t, stackitem.Null{}, "del", []byte("k3"))
+	check(t, 1, 4, 1, bc.BlockHeight())
+
+	// Put and delete in the same block.
+	tx1 := cInv.PrepareInvoke(t, "put", []byte("k3"), []byte("value"))
+	tx2 := cInv.PrepareInvoke(t, "del", []byte("k3"))
+	e.AddNewBlock(t, tx1, tx2)
+	e.CheckHalt(t, tx1.Hash())
+	e.CheckHalt(t, tx2.Hash())
+	check(t, 1, 4, 1, bc.BlockHeight())
+
+	cInv.Invoke(t, stackitem.Null{}, "del", []byte("key2"))
+	check(t, 0, 0, 0, 0)
+
+	// Statistics is recalculated on state reset.
+	bc.Close()
+	db, _ = newLevelDBForTestingWithPath(t, path)
+	defer db.Close()
+	bc, _ = chain.NewSingleWithCustomConfigAndStore(t, cfg, db, false)
+	require.NoError(t, bc.Reset(h))
+	check(t, 2, 6, 6, h)
+
+	t.Run("disabled", func(t *testing.T) {
+		bc, _ := chain.NewSingle(t)
+		_, err := bc.GetStorageUsage(id)
+		require.Error(t, err)
+	})
+}
+
+func TestBlockchain_IndexEventContainers(t *testing.T) {
+	cfg := func(c *config.Blockchain) {
+		c.Ledger.IndexEventContainers = true
+	}
+	db, path := newLevelDBForTestingWithPath(t, t.TempDir())
+	bc, acc := chain.NewSingleWithCustomConfigAndStore(t, cfg, db, false)
+	go bc.Run()
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	src := `package test
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		)
+		func Emit(n int) {
+			for i := 0; i < n; i++ {
+				runtime.Notify("Event", i)
+			}
+		}`
+	c := neotest.CompileSource(t, acc.ScriptHash(), strings.NewReader(src), &compiler.Options{
+		Name: "test_contract",
+		ContractEvents: []compiler.HybridEvent{
+			{
+				Name: "Event",
+				Parameters: []compiler.HybridParameter{
+					{Parameter: manifest.NewParameter("i", smartcontract.IntegerType)},
+				},
+			},
+		},
+	})
+	e.DeployContract(t, c, nil)
+
+	cInv := e.NewInvoker(c.Hash, acc)
+	var (
+		hashes []util.Uint256
+		height = bc.BlockHeight() // Every invocation is in a separate block.
+	)
+	for i := range 3 {
+		hashes = append(hashes, cInv.Invoke(t, stackitem.Null{}, "emit", i+1))
+	}
+	cInv.Invoke(t, stackitem.Null{}, "emit", 0)
+
+	check := func(t *testing.T, index uint32, expected ...util.Uint256) {
+		var (
+			actual []util.Uint256
+			pos    []byte
+		)
+		// Iterate one by one to check positions.
+		for {
+			var found bool
+			err := bc.ForEachEventContainer(c.Hash, "Event", index, pos, func(p []byte, ec *state.EventContainer) bool {
+				require.Equal(t, trigger.Application, ec.Trigger)
+				require.Equal(t, uint32(slices.Index(hashes, ec.Container)+1), ec.Notifications)
+				actual = append(actual, ec.Container)
+				pos, found = p, true
+				return false
+			})
+			require.NoError(t, err)
+			if !found {
+				break
+			}
+		}
+		require.Equal(t, expected, actual)
+	}
+	check(t, 0, hashes...)
+	check(t, height+2, hashes[1:]...)
+	check(t, bc.BlockHeight())
+
+	// Index entries for the removed blocks are dropped on state reset.
+	bc.Close()
+	db, _ = newLevelDBForTestingWithPath(t, path)
+	defer db.Close()
+	bc, _ = chain.NewSingleWithCustomConfigAndStore(t, cfg, db, false)
+	require.NoError(t, bc.Reset(height+2))
+	check(t, 0, hashes[:2]...)
+
+	t.Run("disabled", func(t *testing.T) {
+		bc, _ := chain.NewSingle(t)
+		err := bc.ForEachEventContainer(c.Hash, "Event", 0, nil, func([]byte, *state.EventContainer) bool {
+			return true
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestBlockchain_IndexTransfers(t *testing.T) {
+	cfg := func(c *config.Blockchain) {
+		c.Ledger.IndexTransfers = true
+	}
+	db, path := newLevelDBForTestingWithPath(t, t.TempDir())
+	bc, acc := chain.NewSingleWithCustomConfigAndStore(t, cfg, db, false)
+	go bc.Run()
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	var (
+		gasHash = e.NativeHash(t, nativenames.Gas)
+		neoHash = e.NativeHash(t, nativenames.Neo)
+		gasID   = e.NativeID(t, nativenames.Gas)
+		neoID   = e.NativeID(t, nativenames.Neo)
+		r1      = util.Uint160{1}
+		r2      = util.Uint160{2}
+	)
+	e.ValidatorInvoker(gasHash).Invoke(t, true, "transfer", acc.ScriptHash(), r1, 1, nil)
+	e.ValidatorInvoker(neoHash).Invoke(t, true, "transfer", acc.ScriptHash(), r2, 2, nil)
+	height := bc.BlockHeight()
+	// Several transfers with the same counterparty in a single block.
+	e.AddNewBlock(t,
+		e.NewTx(t, []neotest.Signer{acc}, gasHash, "transfer", acc.ScriptHash(), r1, 3, nil),
+		e.NewTx(t, []neotest.Signer{acc}, gasHash, "transfer", acc.ScriptHash(), r1, 4, nil),
+		e.NewTx(t, []neotest.Signer{acc}, neoHash, "transfer", acc.ScriptHash(), r1, 5, nil))
+
+	check := func(t *testing.T, bc *core.Blockchain, filter state.TransferFilter) {
+		var expected, actual []state.NEP17Transfer
+		require.NoError(t, bc.ForEachNEP17Transfer(acc.ScriptHash(), math.MaxUint64, func(tr *state.NEP17Transfer) (bool, error) {
+			if filter.Matches(tr) {
+				expected = append(expected, *tr)
+			}
+			return true, nil
+		}))
+		require.NoError(t, bc.ForEachNEP17TransferFiltered(acc.ScriptHash(), filter, math.MaxUint64, func(tr *state.NEP17Transfer) (bool, error) {
+			actual = append(actual, *tr)
+			return true, nil
+		}))
+		require.NotEmpty(t, actual)
+		require.Equal(t, expected, actual)
+	}
+	checkAll := func(t *testing.T, bc *core.Blockchain) {
+		check(t, bc, state.TransferFilter{Asset: &gasID})
+		check(t, bc, state.TransferFilter{Asset: &neoID})
+		check(t, bc, state.TransferFilter{Counterparty: &r1})
+		check(t, bc, state.TransferFilter{Counterparty: &r2})
+		check(t, bc, state.TransferFilter{Asset: &gasID, Counterparty: &r1})
+	}
+	checkAll(t, bc)
+
+	// Index entries for the removed blocks are dropped on state reset.
+	bc.Close()
+	db, _ = newLevelDBForTestingWithPath(t, path)
+	defer db.Close()
+	bc, _ = chain.NewSingleWithCustomConfigAndStore(t, cfg, db, false)
+	require.NoError(t, bc.Reset(height))
+	checkAll(t, bc)
+}
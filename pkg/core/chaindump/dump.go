@@ -1,7 +1,10 @@
 package chaindump
 
 import (
+	"errors"
 	"fmt"
+	"runtime"
+	"sync"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
@@ -91,3 +94,165 @@ func Restore(bc DumperRestorer, r *io.BinReader, skip, count uint32, f func(b *b
 	}
 	return nil
 }
+
+// DefaultRestoreQueueSize is the default number of blocks read and decoded
+// ahead of the one being added to the chain by RestoreParallel.
+const DefaultRestoreQueueSize = 256
+
+// RestoreOptions contains RestoreParallel parameters.
+type RestoreOptions struct {
+	// Workers is the number of goroutines decoding and checking blocks,
+	// runtime.GOMAXPROCS is used if not set.
+	Workers int
+	// QueueSize is the maximum number of blocks read ahead of the one being
+	// added to the chain, DefaultRestoreQueueSize is used if not set.
+	QueueSize int
+}
+
+// decodedBlock is a RestoreParallel decoding stage result.
+type decodedBlock struct {
+	b   *block.Block
+	err error
+}
+
+// decodeJob is a RestoreParallel decoding stage task.
+type decodeJob struct {
+	buf []byte
+	res chan<- decodedBlock
+}
+
+// RestoreParallel is the same as Restore, but it pipelines block reading,
+// decoding and checking (which is done by several goroutines) and adding
+// blocks to the chain (which is done sequentially). Blocks are checked for
+// MerkleRoot consistency (this also precalculates all transaction hashes)
+// before they're added to the chain. f is called after addition of every
+// block in the same goroutine RestoreParallel is called from. r is not used
+// after RestoreParallel returns.
+func RestoreParallel(bc DumperRestorer, r *io.BinReader, skip, count uint32, opts RestoreOptions, f func(b *block.Block) error) error {
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.GOMAXPROCS(0)
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = DefaultRestoreQueueSize
+	}
+
+	var (
+		stateRootInHeader = bc.GetConfig().StateRootInHeader
+		done              = make(chan struct{})
+		jobs              = make(chan decodeJob, opts.QueueSize)
+		results           = make(chan chan decodedBlock, opts.QueueSize)
+		readErr           error
+		readerDone        = make(chan struct{})
+		wg                sync.WaitGroup
+	)
+	// Reader goroutine is waited for too, so that r is not used after
+	// return. It checks for done between blocks, so it stops after the
+	// current block is read.
+	defer func() {
+		close(done)
+		wg.Wait()
+		<-readerDone
+	}()
+
+	for range opts.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case j, ok := <-jobs:
+					if !ok {
+						return
+					}
+					j.res <- decodeBlock(j.buf, stateRootInHeader)
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(readerDone)
+		defer close(results)
+		defer close(jobs)
+		var skipBuf []byte
+		for i := uint32(0); i < skip+count; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			var size = r.ReadU32LE()
+			if r.Err != nil {
+				readErr = r.Err
+				return
+			}
+			var buf []byte
+			if i < skip {
+				if uint32(cap(skipBuf)) < size {
+					skipBuf = make([]byte, size)
+				}
+				buf = skipBuf[:size]
+			} else {
+				buf = make([]byte, size)
+			}
+			r.ReadBytes(buf)
+			if r.Err != nil {
+				readErr = r.Err
+				return
+			}
+			if i < skip {
+				continue
+			}
+			var res = make(chan decodedBlock, 1)
+			select {
+			case results <- res:
+			case <-done:
+				return
+			}
+			select {
+			case jobs <- decodeJob{buf: buf, res: res}:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var i = skip
+	for res := range results {
+		var d = <-res
+		if d.err != nil {
+			return fmt.Errorf("failed to decode block %d: %w", i, d.err)
+		}
+		if d.b.Index != 0 || i != 0 || skip != 0 {
+			err := bc.AddBlock(d.b)
+			if err != nil {
+				return fmt.Errorf("failed to add block %d: %w", i, err)
+			}
+		}
+		if f != nil {
+			if err := f(d.b); err != nil {
+				return err
+			}
+		}
+		i++
+	}
+	// results is closed by the reader, so readErr can be safely accessed.
+	return readErr
+}
+
+// decodeBlock decodes the block and checks its MerkleRoot.
+func decodeBlock(buf []byte, stateRootInHeader bool) decodedBlock {
+	b := block.New(stateRootInHeader)
+	r := io.NewBinReaderFromBuf(buf)
+	b.DecodeBinary(r)
+	if r.Err != nil {
+		return decodedBlock{err: r.Err}
+	}
+	if !b.MerkleRoot.Equals(b.ComputeMerkleRoot()) {
+		return decodedBlock{err: errors.New("MerkleRoot mismatch")}
+	}
+	_ = b.Hash()
+	return decodedBlock{b: b}
+}
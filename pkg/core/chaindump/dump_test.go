@@ -1,8 +1,11 @@
 package chaindump_test
 
 import (
+	"bytes"
 	"errors"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/internal/basicchain"
 	"github.com/nspcc-dev/neo-go/pkg/config"
@@ -89,4 +92,76 @@ func testDumpAndRestore(t *testing.T, dumpF, restoreF func(c *config.Blockchain)
 			require.Equal(t, bc.BlockHeight()-1, lastIndex)
 		})
 	})
+	t.Run("parallel", func(t *testing.T) {
+		bc2, _, _ := chain.NewMultiWithCustomConfig(t, dumpF)
+
+		r := io.NewBinReaderFromBuf(buf)
+		require.Error(t, chaindump.RestoreParallel(bc2, r, 2, 1, chaindump.RestoreOptions{}, nil))
+
+		var restored uint32
+		f := func(b *block.Block) error {
+			restored++
+			require.Equal(t, bc2.BlockHeight(), b.Index)
+			return nil
+		}
+		r = io.NewBinReaderFromBuf(buf)
+		opts := chaindump.RestoreOptions{Workers: 3, QueueSize: 2}
+		require.NoError(t, chaindump.RestoreParallel(bc2, r, 0, 3, opts, f))
+		require.Equal(t, uint32(2), bc2.BlockHeight())
+		require.Equal(t, uint32(3), restored)
+
+		// Resume from the current height.
+		r = io.NewBinReaderFromBuf(buf)
+		require.NoError(t, chaindump.RestoreParallel(bc2, r, 3, bc.BlockHeight()-2, opts, f))
+		require.Equal(t, bc.BlockHeight(), bc2.BlockHeight())
+		require.Equal(t, bc.CurrentBlockHash(), bc2.CurrentBlockHash())
+
+		t.Run("stop", func(t *testing.T) {
+			bc3, _, _ := chain.NewMultiWithCustomConfig(t, dumpF)
+			errStopped := errors.New("stopped")
+			r := io.NewBinReaderFromBuf(buf)
+			err := chaindump.RestoreParallel(bc3, r, 0, bc.BlockHeight()+1, opts, func(b *block.Block) error {
+				if b.Index == 3 {
+					return errStopped
+				}
+				return nil
+			})
+			require.ErrorIs(t, err, errStopped)
+			require.Equal(t, uint32(3), bc3.BlockHeight())
+		})
+		t.Run("reader is released", func(t *testing.T) {
+			bc3, _, _ := chain.NewMultiWithCustomConfig(t, dumpF)
+			errStopped := errors.New("stopped")
+			cr := &checkedReader{r: bytes.NewReader(buf)}
+			err := chaindump.RestoreParallel(bc3, io.NewBinReaderFromIO(cr), 0, bc.BlockHeight()+1, opts, func(b *block.Block) error {
+				return errStopped
+			})
+			require.ErrorIs(t, err, errStopped)
+			cr.returned.Store(true)
+			time.Sleep(10 * time.Millisecond)
+			require.False(t, cr.late.Load())
+		})
+		t.Run("truncated", func(t *testing.T) {
+			bc3, _, _ := chain.NewMultiWithCustomConfig(t, dumpF)
+			r := io.NewBinReaderFromBuf(buf[:len(buf)-1])
+			err := chaindump.RestoreParallel(bc3, r, 0, bc.BlockHeight()+1, opts, nil)
+			require.Error(t, err)
+			require.Equal(t, bc.BlockHeight()-1, bc3.BlockHeight())
+		})
+	})
+}
+
+// checkedReader is an io.Reader that remembers whether it was used after
+// returned is set.
+type checkedReader struct {
+	r        *bytes.Reader
+	returned atomic.Bool
+	late     atomic.Bool
+}
+
+func (c *checkedReader) Read(p []byte) (int, error) {
+	if c.returned.Load() {
+		c.late.Store(true)
+	}
+	return c.r.Read(p)
 }
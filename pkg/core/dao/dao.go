@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	iocore "io"
+	"math"
 	"math/big"
 	"sync"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
 	"github.com/nspcc-dev/neo-go/pkg/io"
@@ -33,6 +35,9 @@ var (
 	// ErrInternalDBInconsistency is returned when the format of the retrieved DAO
 	// record is unexpected.
 	ErrInternalDBInconsistency = errors.New("internal DB inconsistency")
+	// ErrInvalidEventIndexPosition is returned when the event index position
+	// passed to SeekEventIndex or SeekEventContainers has wrong format.
+	ErrInvalidEventIndexPosition = errors.New("invalid event index position")
 )
 
 // conflictRecordValueLen is the length of value of transaction conflict record.
@@ -51,6 +56,9 @@ type Simple struct {
 	// and retrieve multi-tier native contract cache. The lowest Simple has its
 	// nativeCachePS set to nil.
 	nativeCachePS *Simple
+	// txFilter is an optional filter of stored transaction hashes shared by
+	// all derived DAOs, see InitTransactionFilter.
+	txFilter *txFilter
 
 	private bool
 	serCtx  *stackitem.SerializationContext
@@ -95,6 +103,7 @@ func (dao *Simple) GetWrapped() *Simple {
 	d := NewSimple(dao.Store, dao.Version.StateRootInHeader)
 	d.Version = dao.Version
 	d.nativeCachePS = dao
+	d.txFilter = dao.txFilter
 	return d
 }
 
@@ -102,10 +111,11 @@ func (dao *Simple) GetWrapped() *Simple {
 // MemCachedStore around the current DAO Store.
 func (dao *Simple) GetPrivate() *Simple {
 	d := &Simple{
-		Version: dao.Version,
-		keyBuf:  dao.keyBuf,
-		dataBuf: dao.dataBuf,
-		serCtx:  dao.serCtx,
+		Version:  dao.Version,
+		keyBuf:   dao.keyBuf,
+		dataBuf:  dao.dataBuf,
+		serCtx:   dao.serCtx,
+		txFilter: dao.txFilter,
 	} // Inherit everything...
 	d.Store = storage.NewPrivateMemCachedStore(dao.Store) // except storage, wrap another layer.
 	d.private = true
@@ -251,6 +261,138 @@ func (dao *Simple) PutTokenTransferLog(acc util.Uint160, start uint64, index uin
 
 // -- end transfer log.
 
+// -- start transfer index.
+
+// Transfer index kind bits, the kind is stored right after the key prefix.
+const (
+	transferIndexNEP11        byte = 1 << iota
+	transferIndexCounterparty      // Indexed by counterparty (by asset otherwise).
+)
+
+// transferIndexPositionLen is the length of transfer position in the transfer
+// index key: timestamp, block index and the number of transfer of the account
+// in this block.
+const transferIndexPositionLen = 8 + 4 + 4
+
+// makeTransferIndexPrefix returns the transfer index key prefix for the given
+// account and asset ID or counterparty.
+func makeTransferIndexPrefix(acc util.Uint160, isNEP11 bool, asset int32, counterparty *util.Uint160) []byte {
+	key := make([]byte, 2, 2+2*util.Uint160Size+transferIndexPositionLen)
+	key[0] = byte(storage.IXTransfers)
+	if isNEP11 {
+		key[1] |= transferIndexNEP11
+	}
+	if counterparty != nil {
+		key[1] |= transferIndexCounterparty
+	}
+	key = append(key, acc.BytesBE()...)
+	if counterparty != nil {
+		return append(key, counterparty.BytesBE()...)
+	}
+	return binary.BigEndian.AppendUint32(key, uint32(asset))
+}
+
+// TransferIndexPosition returns the timestamp and the block index of the given
+// transfer index key.
+func TransferIndexPosition(k []byte) (uint64, uint32) {
+	pos := k[len(k)-transferIndexPositionLen:]
+	return binary.BigEndian.Uint64(pos), binary.BigEndian.Uint32(pos[8:])
+}
+
+// PutTransferIndex adds the given account's transfer to the transfer index by
+// asset and counterparty. seq is the number of the account's transfer in the
+// block, it's used to distinguish transfers with the same asset or
+// counterparty made in the same block.
+func (dao *Simple) PutTransferIndex(acc util.Uint160, isNEP11 bool, tr *state.NEP17Transfer, seq uint32, transfer io.Serializable) error {
+	buf := io.NewBufBinWriter()
+	transfer.EncodeBinary(buf.BinWriter)
+	if buf.Err != nil {
+		return buf.Err
+	}
+	v := buf.Bytes()
+	for _, prefix := range [][]byte{
+		makeTransferIndexPrefix(acc, isNEP11, tr.Asset, nil),
+		makeTransferIndexPrefix(acc, isNEP11, 0, &tr.Counterparty),
+	} {
+		key := binary.BigEndian.AppendUint64(prefix, tr.Timestamp)
+		key = binary.BigEndian.AppendUint32(key, tr.Block)
+		key = binary.BigEndian.AppendUint32(key, seq)
+		dao.Store.Put(key, v)
+	}
+	return nil
+}
+
+// SeekNEP17TransferIndex is similar to SeekNEP17TransferLog, but it only passes
+// transfers matching the given (non-empty) filter to f using the transfer
+// index.
+func (dao *Simple) SeekNEP17TransferIndex(acc util.Uint160, filter state.TransferFilter, newestTimestamp uint64, f func(*state.NEP17Transfer) (bool, error)) error {
+	return dao.seekTransferIndex(acc, false, filter, newestTimestamp, func(r *io.BinReader) (bool, error) {
+		t := new(state.NEP17Transfer)
+		t.DecodeBinary(r)
+		if r.Err != nil {
+			return false, r.Err
+		}
+		if !filter.Matches(t) {
+			return true, nil
+		}
+		return f(t)
+	})
+}
+
+// SeekNEP11TransferIndex is similar to SeekNEP11TransferLog, but it only passes
+// transfers matching the given (non-empty) filter to f using the transfer
+// index.
+func (dao *Simple) SeekNEP11TransferIndex(acc util.Uint160, filter state.TransferFilter, newestTimestamp uint64, f func(*state.NEP11Transfer) (bool, error)) error {
+	return dao.seekTransferIndex(acc, true, filter, newestTimestamp, func(r *io.BinReader) (bool, error) {
+		t := new(state.NEP11Transfer)
+		t.DecodeBinary(r)
+		if r.Err != nil {
+			return false, r.Err
+		}
+		if !filter.Matches(&t.NEP17Transfer) {
+			return true, nil
+		}
+		return f(t)
+	})
+}
+
+// seekTransferIndex iterates over transfer index entries from the newest to the
+// oldest one using counterparty index if counterparty is set in the filter
+// and asset index otherwise.
+func (dao *Simple) seekTransferIndex(acc util.Uint160, isNEP11 bool, filter state.TransferFilter, newestTimestamp uint64,
+	f func(r *io.BinReader) (bool, error)) error {
+	var (
+		prefix  []byte
+		seekErr error
+	)
+	if filter.Counterparty != nil {
+		prefix = makeTransferIndexPrefix(acc, isNEP11, 0, filter.Counterparty)
+	} else if filter.Asset != nil {
+		prefix = makeTransferIndexPrefix(acc, isNEP11, *filter.Asset, nil)
+	} else {
+		return errors.New("empty transfer filter")
+	}
+	// Start is padded to include all transfers made at newestTimestamp.
+	dao.Store.Seek(storage.SeekRange{
+		Prefix:    prefix,
+		Start:     binary.BigEndian.AppendUint64(binary.BigEndian.AppendUint64(nil, newestTimestamp), math.MaxUint64),
+		Backwards: true,
+	}, func(k, v []byte) bool {
+		if len(k) != len(prefix)+transferIndexPositionLen {
+			seekErr = ErrInternalDBInconsistency
+			return false
+		}
+		cont, err := f(io.NewBinReaderFromBuf(v))
+		if err != nil {
+			seekErr = err
+		}
+		return cont
+	})
+	return seekErr
+}
+
+// -- end transfer index.
+
 // -- start notification event.
 
 func (dao *Simple) makeExecutableKey(hash util.Uint256) []byte {
@@ -350,6 +492,158 @@ func decodeTxAndExecResult(buf []byte) (uint32, *transaction.Transaction, *state
 
 // -- end notification event.
 
+// -- start event index.
+
+// eventIndexPositionLen is the length of notification position in the event
+// index key: block index, container hash, trigger and notification number.
+const eventIndexPositionLen = 4 + util.Uint256Size + 1 + 4
+
+// makeEventIndexPrefix returns the event index key prefix for the given
+// contract's event parameter value. Values are hashed along with the event name
+// and parameter position to have fixed-length keys.
+func makeEventIndexPrefix(contract util.Uint160, event string, param int, value []byte) []byte {
+	w := io.NewBufBinWriter()
+	w.WriteString(event)
+	w.WriteB(byte(param))
+	w.WriteBytes(value)
+	key := make([]byte, 1+2*util.Uint160Size, 1+2*util.Uint160Size+eventIndexPositionLen)
+	key[0] = byte(storage.IXEventIndex)
+	copy(key[1:], contract.BytesBE())
+	copy(key[1+util.Uint160Size:], hash.Hash160(w.Bytes()).BytesBE())
+	return key
+}
+
+// PutEventIndex adds the n-th notification emitted by the given container
+// with the given trigger in the block with the given index to the event index
+// for every given parameter position and value.
+func (dao *Simple) PutEventIndex(index uint32, container util.Uint256, trig trigger.Type, n uint32,
+	ne *state.NotificationEvent, params []int, paramValues [][]byte) error {
+	buf := dao.getDataBuf()
+	ne.EncodeBinaryWithContext(buf.BinWriter, dao.GetItemCtx())
+	if buf.Err != nil {
+		return buf.Err
+	}
+	value := buf.Bytes()
+	for i := range params {
+		key := makeEventIndexPrefix(ne.ScriptHash, ne.Name, params[i], paramValues[i])
+		key = binary.BigEndian.AppendUint32(key, index)
+		key = append(key, container.BytesBE()...)
+		key = append(key, byte(trig))
+		key = binary.BigEndian.AppendUint32(key, n)
+		dao.Store.Put(key, value)
+	}
+	return nil
+}
+
+// SeekEventIndex executes f for every notification of the given contract's
+// event having the given value of the parameter at the given position, from
+// the newest notification to the oldest one, until f returns false. Iteration
+// starts right after the given position (as passed to f before), nil position
+// means starting from the newest notification.
+func (dao *Simple) SeekEventIndex(contract util.Uint160, event string, param int, value []byte, start []byte,
+	f func(pos []byte, ne *state.ContainedNotificationEvent) bool) error {
+	if start != nil && len(start) != eventIndexPositionLen {
+		return ErrInvalidEventIndexPosition
+	}
+	var (
+		prefix  = makeEventIndexPrefix(contract, event, param, value)
+		seekErr error
+	)
+	dao.Store.Seek(storage.SeekRange{
+		Prefix:    prefix,
+		Start:     start,
+		Backwards: true,
+	}, func(k, v []byte) bool {
+		pos := k[len(prefix):]
+		if len(pos) != eventIndexPositionLen {
+			seekErr = ErrInternalDBInconsistency
+			return false
+		}
+		if bytes.Equal(pos, start) {
+			return true
+		}
+		ne := &state.ContainedNotificationEvent{}
+		r := io.NewBinReaderFromBuf(v)
+		ne.NotificationEvent.DecodeBinary(r)
+		if r.Err != nil {
+			seekErr = r.Err
+			return false
+		}
+		ne.Container, _ = util.Uint256DecodeBytesBE(pos[4 : 4+util.Uint256Size])
+		return f(bytes.Clone(pos), ne)
+	})
+	return seekErr
+}
+
+// -- end event index.
+
+// -- start event container index.
+
+// eventContainerPositionLen is the length of container position in the event
+// container index key: block index, container hash and trigger.
+const eventContainerPositionLen = 4 + util.Uint256Size + 1
+
+// makeEventContainersPrefix returns the event container index key prefix for
+// the given contract's event.
+func makeEventContainersPrefix(contract util.Uint160, event string) []byte {
+	key := make([]byte, 1+util.Uint160Size+1, 1+util.Uint160Size+1+len(event)+eventContainerPositionLen)
+	key[0] = byte(storage.IXEventContainers)
+	copy(key[1:], contract.BytesBE())
+	key[1+util.Uint160Size] = byte(len(event))
+	return append(key, event...)
+}
+
+// PutEventContainer adds the container to the event container index of the
+// given contract's event.
+func (dao *Simple) PutEventContainer(contract util.Uint160, event string, c *state.EventContainer) {
+	key := makeEventContainersPrefix(contract, event)
+	key = binary.BigEndian.AppendUint32(key, c.BlockIndex)
+	key = append(key, c.Container.BytesBE()...)
+	key = append(key, byte(c.Trigger))
+	dao.Store.Put(key, binary.LittleEndian.AppendUint32(nil, c.Notifications))
+}
+
+// SeekEventContainers executes f for every container that emitted the given
+// contract's event, from the oldest one to the newest one, until f returns
+// false. Iteration starts right after the given position (as passed to f
+// before), nil position means starting from the given block index.
+func (dao *Simple) SeekEventContainers(contract util.Uint160, event string, index uint32, start []byte,
+	f func(pos []byte, c *state.EventContainer) bool) error {
+	if start != nil && len(start) != eventContainerPositionLen {
+		return ErrInvalidEventIndexPosition
+	}
+	var (
+		prefix  = makeEventContainersPrefix(contract, event)
+		seekErr error
+	)
+	if start == nil {
+		start = binary.BigEndian.AppendUint32(nil, index)
+	}
+	dao.Store.Seek(storage.SeekRange{
+		Prefix: prefix,
+		Start:  start,
+	}, func(k, v []byte) bool {
+		pos := k[len(prefix):]
+		if len(pos) != eventContainerPositionLen || len(v) != 4 {
+			seekErr = ErrInternalDBInconsistency
+			return false
+		}
+		if bytes.Equal(pos, start) {
+			return true
+		}
+		c := &state.EventContainer{
+			BlockIndex:    binary.BigEndian.Uint32(pos),
+			Trigger:       trigger.Type(pos[4+util.Uint256Size]),
+			Notifications: binary.LittleEndian.Uint32(v),
+		}
+		c.Container, _ = util.Uint256DecodeBytesBE(pos[4 : 4+util.Uint256Size])
+		return f(bytes.Clone(pos), c)
+	})
+	return seekErr
+}
+
+// -- end event container index.
+
 // -- start storage item.
 
 // GetStorageItem returns StorageItem if it exists in the given store.
@@ -414,6 +708,39 @@ func (dao *Simple) makeStorageItemKey(id int32, key []byte) []byte {
 
 // -- end storage item.
 
+// -- start storage usage.
+
+func (dao *Simple) makeStorageUsageKey(id int32) []byte {
+	key := dao.getKeyBuf(1 + 4)
+	key[0] = byte(storage.STStorageUsage)
+	binary.BigEndian.PutUint32(key[1:], uint32(id))
+	return key
+}
+
+// GetStorageUsage returns storage usage statistics of the contract with the
+// given ID. Zero statistics is returned for contracts without storage items.
+func (dao *Simple) GetStorageUsage(id int32) (*state.StorageUsage, error) {
+	u := new(state.StorageUsage)
+	err := dao.GetAndDecode(u, dao.makeStorageUsageKey(id))
+	if err != nil && !errors.Is(err, storage.ErrKeyNotFound) {
+		return nil, err
+	}
+	return u, nil
+}
+
+// PutStorageUsage saves storage usage statistics of the contract with the
+// given ID, statistics for contracts without storage items is deleted.
+func (dao *Simple) PutStorageUsage(id int32, u *state.StorageUsage) error {
+	key := dao.makeStorageUsageKey(id)
+	if u.Keys == 0 {
+		dao.Store.Delete(key)
+		return nil
+	}
+	return dao.putWithBuffer(u, key, dao.getDataBuf())
+}
+
+// -- end storage usage.
+
 // -- other.
 
 // GetBlock returns Block by the given hash if it exists in the store.
@@ -449,6 +776,11 @@ type Version struct {
 	Magic                      uint32
 	Value                      string
 	SaveInvocations            bool
+	IndexEvents                bool
+	TrackStorageUsage          bool
+	IndexEventContainers       bool
+	IndexTransfers             bool
+	KeepRecentStateRoots       uint32
 }
 
 const (
@@ -457,6 +789,15 @@ const (
 	p2pStateExchangeExtensionsBit
 	keepOnlyLatestStateBit
 	saveInvocationsBit
+	indexEventsBit
+	trackStorageUsageBit
+	indexEventContainersBit
+)
+
+// Flags of the second mask byte stored after the magic (the first one is
+// full).
+const (
+	indexTransfersBit = 1 << iota
 )
 
 // FromBytes decodes v from a byte-slice.
@@ -485,11 +826,20 @@ func (v *Version) FromBytes(data []byte) error {
 	v.P2PStateExchangeExtensions = data[i+2]&p2pStateExchangeExtensionsBit != 0
 	v.KeepOnlyLatestState = data[i+2]&keepOnlyLatestStateBit != 0
 	v.SaveInvocations = data[i+2]&saveInvocationsBit != 0
+	v.IndexEvents = data[i+2]&indexEventsBit != 0
+	v.TrackStorageUsage = data[i+2]&trackStorageUsageBit != 0
+	v.IndexEventContainers = data[i+2]&indexEventContainersBit != 0
 
 	m := i + 3
-	if len(data) == m+4 {
+	if len(data) == m+4 || len(data) == m+5 || len(data) == m+9 {
 		v.Magic = binary.LittleEndian.Uint32(data[m:])
 	}
+	if len(data) == m+5 || len(data) == m+9 {
+		v.IndexTransfers = data[m+4]&indexTransfersBit != 0
+	}
+	if len(data) == m+9 {
+		v.KeepRecentStateRoots = binary.LittleEndian.Uint32(data[m+5:])
+	}
 	return nil
 }
 
@@ -511,8 +861,28 @@ func (v *Version) Bytes() []byte {
 	if v.SaveInvocations {
 		mask |= saveInvocationsBit
 	}
+	if v.IndexEvents {
+		mask |= indexEventsBit
+	}
+	if v.TrackStorageUsage {
+		mask |= trackStorageUsageBit
+	}
+	if v.IndexEventContainers {
+		mask |= indexEventContainersBit
+	}
 	res := append([]byte(v.Value), '\x00', byte(v.StoragePrefix), mask)
 	res = binary.LittleEndian.AppendUint32(res, v.Magic)
+	mask = 0
+	if v.IndexTransfers {
+		mask |= indexTransfersBit
+	}
+	if mask != 0 || v.KeepRecentStateRoots != 0 {
+		// Not written if empty for compatibility with older versions.
+		res = append(res, mask)
+	}
+	if v.KeepRecentStateRoots != 0 {
+		res = binary.LittleEndian.AppendUint32(res, v.KeepRecentStateRoots)
+	}
 	return res
 }
 
@@ -704,6 +1074,9 @@ func (dao *Simple) StoreHeaderHashes(hashes []util.Uint256, height uint32) error
 // checked against the maxTraceableBlocks setting if signers are omitted.
 // HasTransaction does not consider the case of block executable.
 func (dao *Simple) HasTransaction(hash util.Uint256, signers []transaction.Signer, currentIndex uint32, maxTraceableBlocks uint32) error {
+	if dao.txFilter != nil && !dao.txFilter.mayContain(hash) {
+		return nil
+	}
 	key := dao.makeExecutableKey(hash)
 	bytes, err := dao.Store.Get(key)
 	if err != nil {
@@ -883,6 +1256,9 @@ func (dao *Simple) StoreAsTransaction(tx *transaction.Transaction, index uint32,
 	}
 	val := buf.Bytes()
 	dao.Store.Put(key, val)
+	if dao.txFilter != nil {
+		dao.txFilter.add(tx.Hash())
+	}
 
 	val = val[:conflictRecordValueLen] // storage.ExecTransaction (1 byte) + index (4 bytes)
 	attrs := tx.GetAttributes(transaction.ConflictsT)
@@ -902,6 +1278,9 @@ func (dao *Simple) StoreAsTransaction(tx *transaction.Transaction, index uint32,
 		}
 
 		dao.Store.Put(key, val)
+		if dao.txFilter != nil {
+			dao.txFilter.add(hash)
+		}
 
 		// Conflicting signers.
 		sKey := make([]byte, len(key)+util.Uint160Size)
@@ -2,6 +2,7 @@ package dao
 
 import (
 	"encoding/binary"
+	"math/big"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/random"
@@ -150,6 +151,33 @@ func TestGetVersion(t *testing.T) {
 		_, err := dao.GetVersion()
 		require.Error(t, err)
 	})
+	t.Run("extended flags", func(t *testing.T) {
+		dao := NewSimple(storage.NewMemoryStore(), false)
+		expected := Version{
+			StoragePrefix:  0x42,
+			Magic:          42,
+			IndexTransfers: true,
+			Value:          "testVersion",
+		}
+		dao.PutVersion(expected)
+		actual, err := dao.GetVersion()
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	})
+	t.Run("recent state roots", func(t *testing.T) {
+		dao := NewSimple(storage.NewMemoryStore(), false)
+		expected := Version{
+			StoragePrefix:        0x42,
+			KeepOnlyLatestState:  true,
+			Magic:                42,
+			KeepRecentStateRoots: 5,
+			Value:                "testVersion",
+		}
+		dao.PutVersion(expected)
+		actual, err := dao.GetVersion()
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	})
 	t.Run("old format", func(t *testing.T) {
 		dao := NewSimple(storage.NewMemoryStore(), false)
 		dao.Store.Put([]byte{byte(storage.SYSVersion)}, []byte("0.1.2"))
@@ -460,3 +488,170 @@ func TestPutGetStateSyncCurrentBlockHeight(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expected, actual)
 }
+
+func TestEventIndex(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), false)
+	contract := random.Uint160()
+	newEvent := func(key, value string) *state.NotificationEvent {
+		return &state.NotificationEvent{
+			ScriptHash: contract,
+			Name:       "Event",
+			Item:       stackitem.NewArray([]stackitem.Item{stackitem.Make(key), stackitem.Make(value)}),
+		}
+	}
+	type entry struct {
+		index     uint32
+		container util.Uint256
+		ne        *state.NotificationEvent
+	}
+	var entries []entry
+	for i, kv := range [][2]string{{"a", "x"}, {"a", "y"}, {"b", "x"}} {
+		e := entry{index: uint32(i + 1), container: random.Uint256(), ne: newEvent(kv[0], kv[1])}
+		require.NoError(t, dao.PutEventIndex(e.index, e.container, trigger.Application, 0, e.ne,
+			[]int{0, 1}, [][]byte{[]byte(kv[0]), []byte(kv[1])}))
+		entries = append(entries, e)
+	}
+
+	check := func(t *testing.T, param int, value string, expected ...entry) {
+		var (
+			actual []entry
+			pos    []byte
+		)
+		for {
+			var found bool
+			require.NoError(t, dao.SeekEventIndex(contract, "Event", param, []byte(value), pos, func(p []byte, ne *state.ContainedNotificationEvent) bool {
+				actual = append(actual, entry{
+					index:     binary.BigEndian.Uint32(p),
+					container: ne.Container,
+					ne:        &ne.NotificationEvent,
+				})
+				pos, found = p, true
+				return false
+			}))
+			if !found {
+				break
+			}
+		}
+		require.Equal(t, expected, actual)
+	}
+	check(t, 0, "a", entries[1], entries[0])
+	check(t, 0, "b", entries[2])
+	check(t, 1, "x", entries[2], entries[0])
+	check(t, 1, "y", entries[1])
+	check(t, 1, "a")
+	check(t, 0, "x")
+
+	err := dao.SeekEventIndex(contract, "Event", 0, []byte("a"), []byte{1, 2, 3}, func([]byte, *state.ContainedNotificationEvent) bool {
+		return true
+	})
+	require.Error(t, err)
+}
+
+func TestEventContainers(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), false)
+	contract := random.Uint160()
+	var containers []*state.EventContainer
+	for i := range 3 {
+		c := &state.EventContainer{
+			BlockIndex:    uint32(i + 1),
+			Container:     random.Uint256(),
+			Trigger:       trigger.Application,
+			Notifications: uint32(i + 1),
+		}
+		dao.PutEventContainer(contract, "Event", c)
+		containers = append(containers, c)
+	}
+	dao.PutEventContainer(contract, "Even", &state.EventContainer{BlockIndex: 2, Container: random.Uint256()})
+	dao.PutEventContainer(random.Uint160(), "Event", &state.EventContainer{BlockIndex: 2, Container: random.Uint256()})
+
+	check := func(t *testing.T, event string, index uint32, expected ...*state.EventContainer) {
+		var (
+			actual []*state.EventContainer
+			pos    []byte
+		)
+		for {
+			var found bool
+			require.NoError(t, dao.SeekEventContainers(contract, event, index, pos, func(p []byte, c *state.EventContainer) bool {
+				actual = append(actual, c)
+				pos, found = p, true
+				return false
+			}))
+			if !found {
+				break
+			}
+		}
+		require.Equal(t, expected, actual)
+	}
+	check(t, "Event", 0, containers...)
+	check(t, "Event", 2, containers[1:]...)
+	check(t, "Event", 4)
+	check(t, "Unknown", 0)
+
+	err := dao.SeekEventContainers(contract, "Event", 0, []byte{1, 2, 3}, func([]byte, *state.EventContainer) bool {
+		return true
+	})
+	require.Error(t, err)
+}
+
+func TestTransferIndex(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), false)
+	var (
+		acc       = random.Uint160()
+		cp1, cp2  = random.Uint160(), random.Uint160()
+		gas, neo  = int32(-6), int32(-5)
+		transfers []*state.NEP17Transfer
+	)
+	for i, tr := range []struct {
+		asset int32
+		cp    util.Uint160
+		block uint32
+	}{{gas, cp1, 1}, {neo, cp2, 1}, {gas, cp2, 2}, {gas, cp2, 2}, {neo, cp1, 3}} {
+		transfers = append(transfers, &state.NEP17Transfer{
+			Asset:        tr.asset,
+			Counterparty: tr.cp,
+			Amount:       big.NewInt(int64(i + 1)),
+			Block:        tr.block,
+			Timestamp:    uint64(tr.block) * 1000,
+			Tx:           random.Uint256(),
+		})
+	}
+	var seq = make(map[uint32]uint32)
+	for _, tr := range transfers {
+		require.NoError(t, dao.PutTransferIndex(acc, false, tr, seq[tr.Block], tr))
+		seq[tr.Block]++
+	}
+	// Other accounts and NEP-11 transfers don't interfere.
+	require.NoError(t, dao.PutTransferIndex(random.Uint160(), false, transfers[0], 0, transfers[0]))
+	nep11 := &state.NEP11Transfer{NEP17Transfer: *transfers[0], ID: []byte{1}}
+	require.NoError(t, dao.PutTransferIndex(acc, true, &nep11.NEP17Transfer, 0, nep11))
+
+	check := func(t *testing.T, filter state.TransferFilter, ts uint64, expected ...int) {
+		var actual []*state.NEP17Transfer
+		require.NoError(t, dao.SeekNEP17TransferIndex(acc, filter, ts, func(tr *state.NEP17Transfer) (bool, error) {
+			actual = append(actual, tr)
+			return true, nil
+		}))
+		var exp []*state.NEP17Transfer
+		for _, i := range expected {
+			exp = append(exp, transfers[i])
+		}
+		require.Equal(t, exp, actual)
+	}
+	check(t, state.TransferFilter{Asset: &gas}, 3000, 3, 2, 0)
+	check(t, state.TransferFilter{Asset: &neo}, 3000, 4, 1)
+	check(t, state.TransferFilter{Counterparty: &cp2}, 3000, 3, 2, 1)
+	check(t, state.TransferFilter{Counterparty: &cp2}, 1000, 1)
+	check(t, state.TransferFilter{Asset: &gas, Counterparty: &cp1}, 3000, 0)
+	check(t, state.TransferFilter{Asset: &neo, Counterparty: &cp1}, 2000)
+
+	var actual []*state.NEP11Transfer
+	require.NoError(t, dao.SeekNEP11TransferIndex(acc, state.TransferFilter{Counterparty: &cp1}, 3000, func(tr *state.NEP11Transfer) (bool, error) {
+		actual = append(actual, tr)
+		return true, nil
+	}))
+	require.Equal(t, []*state.NEP11Transfer{nep11}, actual)
+
+	require.Error(t, dao.SeekNEP17TransferIndex(acc, state.TransferFilter{}, 3000, func(*state.NEP17Transfer) (bool, error) {
+		return true, nil
+	}))
+}
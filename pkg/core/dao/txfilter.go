@@ -0,0 +1,104 @@
+package dao
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+const (
+	// txFilterBitsPerItem and txFilterHashes give about 1% false positive
+	// rate for the expected number of items.
+	txFilterBitsPerItem = 10
+	txFilterHashes      = 7
+	// txFilterMinItems is the minimum number of items the filter is sized for,
+	// it leaves some room for the new blocks.
+	txFilterMinItems = 1 << 20
+)
+
+// txFilter is a bloom filter over the hashes of stored transactions and
+// conflict records. Items are never removed from it, so it can only produce
+// false positives for deleted transactions which is fine for its purpose.
+type txFilter struct {
+	lock sync.RWMutex
+	bits []uint64
+}
+
+func newTxFilter(items int) *txFilter {
+	items = max(2*items, txFilterMinItems)
+	return &txFilter{
+		bits: make([]uint64, (items*txFilterBitsPerItem+63)/64),
+	}
+}
+
+// positions calls fn for every bit position of the given hash. Hashes are
+// uniformly distributed already, so double hashing over their parts is used.
+// It returns false if fn returns false for some position.
+func (f *txFilter) positions(h util.Uint256, fn func(uint64) bool) bool {
+	var (
+		m  = uint64(len(f.bits) * 64)
+		h1 = binary.LittleEndian.Uint64(h[0:8])
+		h2 = binary.LittleEndian.Uint64(h[8:16]) | 1
+	)
+	for i := range uint64(txFilterHashes) {
+		if !fn((h1 + i*h2) % m) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *txFilter) add(h util.Uint256) {
+	f.lock.Lock()
+	f.positions(h, func(pos uint64) bool {
+		f.bits[pos/64] |= 1 << (pos % 64)
+		return true
+	})
+	f.lock.Unlock()
+}
+
+// mayContain returns false if the hash is definitely not in the filter.
+func (f *txFilter) mayContain(h util.Uint256) bool {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return f.positions(h, func(pos uint64) bool {
+		return f.bits[pos/64]&(1<<(pos%64)) != 0
+	})
+}
+
+// InitTransactionFilter builds an in-memory filter over all transactions and
+// conflict records stored in the DAO. HasTransaction uses it to skip DB reads
+// for transactions that are definitely not stored. The filter is shared with
+// all DAOs derived from this one via GetWrapped and GetPrivate and updated
+// with every StoreAsTransaction call, so it must be initialized before any
+// derived DAO is created. It traverses all stored blocks and transactions,
+// which can take some time for big databases.
+func (dao *Simple) InitTransactionFilter() {
+	var (
+		count int
+		rng   = storage.SeekRange{Prefix: []byte{byte(storage.DataExecutable)}}
+		isTx  = func(k, v []byte) bool {
+			// Conflicting signer records have longer keys.
+			return len(k) == 1+util.Uint256Size && len(v) > 0 && v[0] == storage.ExecTransaction
+		}
+	)
+	dao.Store.Seek(rng, func(k, v []byte) bool {
+		if isTx(k, v) {
+			count++
+		}
+		return true
+	})
+	f := newTxFilter(count)
+	dao.Store.Seek(rng, func(k, v []byte) bool {
+		if isTx(k, v) {
+			h, err := util.Uint256DecodeBytesBE(k[1:])
+			if err == nil {
+				f.add(h)
+			}
+		}
+		return true
+	})
+	dao.txFilter = f
+}
@@ -0,0 +1,58 @@
+package dao
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionFilter(t *testing.T) {
+	newTx := func(nonce uint32, conflicts ...util.Uint256) *transaction.Transaction {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 1)
+		tx.Nonce = nonce
+		tx.Signers = append(tx.Signers, transaction.Signer{Account: util.Uint160{1, 2, 3}})
+		tx.Scripts = append(tx.Scripts, transaction.Witness{})
+		for _, h := range conflicts {
+			tx.Attributes = append(tx.Attributes, transaction.Attribute{
+				Type:  transaction.ConflictsT,
+				Value: &transaction.Conflicts{Hash: h},
+			})
+		}
+		return tx
+	}
+
+	st := storage.NewMemoryStore()
+	d := NewSimple(st, false)
+	conflictsH := random.Uint256()
+	tx1 := newTx(1, conflictsH)
+	require.NoError(t, d.StoreAsTransaction(tx1, 1, nil))
+	_, err := d.Persist()
+	require.NoError(t, err)
+
+	d = NewSimple(st, false)
+	d.InitTransactionFilter()
+	require.NotNil(t, d.txFilter)
+	require.True(t, d.txFilter.mayContain(tx1.Hash()))
+	require.True(t, d.txFilter.mayContain(conflictsH))
+	require.ErrorIs(t, d.HasTransaction(tx1.Hash(), nil, 0, 0), ErrAlreadyExists)
+	require.ErrorIs(t, d.HasTransaction(conflictsH, nil, 0, 0), ErrHasConflicts)
+
+	missing := newTx(2)
+	require.False(t, d.txFilter.mayContain(missing.Hash()))
+	require.NoError(t, d.HasTransaction(missing.Hash(), nil, 0, 0))
+
+	t.Run("derived DAO", func(t *testing.T) {
+		tx2 := newTx(3)
+		for _, derived := range []*Simple{d.GetWrapped(), d.GetPrivate()} {
+			require.NoError(t, derived.StoreAsTransaction(tx2, 2, nil))
+			require.ErrorIs(t, derived.HasTransaction(tx2.Hash(), nil, 0, 0), ErrAlreadyExists)
+			// The filter is shared with the parent DAO.
+			require.True(t, d.txFilter.mayContain(tx2.Hash()))
+		}
+	})
+}
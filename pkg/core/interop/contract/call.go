@@ -15,6 +15,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
@@ -22,6 +23,7 @@ import (
 
 type policyChecker interface {
 	IsBlocked(*dao.Simple, util.Uint160) bool
+	GetFeeExemption(*dao.Simple, util.Uint160, string) uint8
 }
 
 // LoadToken calls method specified by the token id.
@@ -110,12 +112,16 @@ func callInternal(ic *interop.Context, cs *state.Contract, name string, f callfl
 // callExFromNative calls a contract with flags using the provided calling hash.
 func callExFromNative(ic *interop.Context, caller util.Uint160, cs *state.Contract,
 	name string, args []stackitem.Item, f callflag.CallFlag, hasReturn bool, isDynamic bool, callFromNative bool) error {
+	var feeDiscount uint8
 	for _, nc := range ic.Natives {
 		if nc.Metadata().Name == nativenames.Policy {
 			var pch = nc.(policyChecker)
 			if pch.IsBlocked(ic.DAO, cs.Hash) {
 				return fmt.Errorf("contract %s is blocked", cs.Hash.StringLE())
 			}
+			if ic.Trigger == trigger.Application {
+				feeDiscount = pch.GetFeeExemption(ic.DAO, cs.Hash, name)
+			}
 			break
 		}
 	}
@@ -166,6 +172,9 @@ func callExFromNative(ic *interop.Context, caller util.Uint160, cs *state.Contra
 	}
 	ic.VM.LoadNEFMethod(&cs.NEF, &cs.Manifest, caller, cs.Hash, f,
 		hasReturn, methodOff, initOff, onUnload)
+	if ctx := ic.VM.Context(); feeDiscount > ctx.FeeDiscount() {
+		ctx.SetFeeDiscount(feeDiscount)
+	}
 
 	for e, i := ic.VM.Estack(), len(args)-1; i >= 0; i-- {
 		e.PushItem(args[i])
@@ -9,7 +9,6 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
-	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
@@ -76,7 +75,9 @@ func GetInvocationCounter(ic *interop.Context) error {
 
 // GetAddressVersion returns the address version of the current protocol.
 func GetAddressVersion(ic *interop.Context) error {
-	ic.VM.Estack().PushItem(stackitem.NewBigInteger(big.NewInt(int64(address.NEO3Prefix))))
+	cfg := ic.Chain.GetConfig()
+	v := cfg.GetAddressVersion()
+	ic.VM.Estack().PushItem(stackitem.NewBigInteger(big.NewInt(int64(v))))
 	return nil
 }
 
@@ -12,3 +12,14 @@ type Feer interface {
 	GetUtilityTokenBalance(util.Uint160) *big.Int
 	BlockHeight() uint32
 }
+
+// DepositFeer is an optional Feer extension that allows to accept free
+// (zero-fee) transactions against sender deposits. If Feer passed to the
+// Pool implements it and FreeTxDeposit returns a positive value, every
+// transaction with zero system and network fees reserves this amount from its
+// sender's deposit while it's in the Pool.
+type DepositFeer interface {
+	Feer
+	FreeTxDeposit() int64
+	GetFreeTxDepositBalance(util.Uint160) *big.Int
+}
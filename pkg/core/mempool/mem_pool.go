@@ -48,10 +48,14 @@ type item struct {
 type items []item
 
 // utilityBalanceAndFees stores the sender's balance and overall fees of
-// the sender's transactions which are currently in the mempool.
+// the sender's transactions which are currently in the mempool. For free
+// (zero-fee) transactions the sender's deposit and the number of such
+// transactions are stored (see DepositFeer).
 type utilityBalanceAndFees struct {
 	balance uint256.Int
 	feeSum  uint256.Int
+	deposit uint256.Int
+	freeTxs uint64
 }
 
 // Pool stores the unconfirmed transactions.
@@ -114,6 +118,11 @@ func (mp *Pool) Count() int {
 	return mp.count()
 }
 
+// Capacity returns the maximum number of transactions the pool can hold.
+func (mp *Pool) Capacity() int {
+	return mp.capacity
+}
+
 // count is an internal unlocked version of Count.
 func (mp *Pool) count() int {
 	return len(mp.verifiedTxes)
@@ -163,22 +172,74 @@ func (mp *Pool) tryAddSendersFee(tx *transaction.Transaction, feer Feer, needChe
 	payer := tx.Signers[mp.payerIndex].Account
 	senderFee, ok := mp.fees[payer]
 	if !ok {
-		_ = senderFee.balance.SetFromBig(feer.GetUtilityTokenBalance(payer))
+		senderFee = getSenderFee(payer, feer)
 		mp.fees[payer] = senderFee
 	}
-	if needCheck {
-		newFeeSum, err := checkBalance(tx, senderFee)
-		if err != nil {
-			return false
-		}
-		senderFee.feeSum = newFeeSum
-	} else {
-		senderFee.feeSum.AddUint64(&senderFee.feeSum, uint64(tx.SystemFee+tx.NetworkFee))
+	if needCheck && checkFees(tx, feer, senderFee) != nil {
+		return false
+	}
+	senderFee.feeSum.AddUint64(&senderFee.feeSum, uint64(tx.SystemFee+tx.NetworkFee))
+	if isFreeTx(tx) {
+		senderFee.freeTxs++
 	}
 	mp.fees[payer] = senderFee
 	return true
 }
 
+// getSenderFee returns the initial balance state of the sender.
+func getSenderFee(payer util.Uint160, feer Feer) utilityBalanceAndFees {
+	var senderFee utilityBalanceAndFees
+	_ = senderFee.balance.SetFromBig(feer.GetUtilityTokenBalance(payer))
+	if df, reserve := freeTxDeposit(feer); reserve > 0 {
+		_ = senderFee.deposit.SetFromBig(df.GetFreeTxDepositBalance(payer))
+	}
+	return senderFee
+}
+
+// isFreeTx checks whether the transaction has zero fees.
+func isFreeTx(tx *transaction.Transaction) bool {
+	return tx.SystemFee == 0 && tx.NetworkFee == 0
+}
+
+// freeTxDeposit returns the amount of deposit reserved for every free
+// transaction if the given Feer supports them.
+func freeTxDeposit(feer Feer) (DepositFeer, int64) {
+	df, ok := feer.(DepositFeer)
+	if !ok {
+		return nil, 0
+	}
+	return df, df.FreeTxDeposit()
+}
+
+// checkFees checks whether the sender is able to pay for the transaction (or
+// has enough deposit for free one) taking into account other sender's
+// transactions in the mempool.
+func checkFees(tx *transaction.Transaction, feer Feer, balance utilityBalanceAndFees) error {
+	if isFreeTx(tx) {
+		if _, reserve := freeTxDeposit(feer); reserve > 0 {
+			return checkDeposit(reserve, balance)
+		}
+	}
+	_, err := checkBalance(tx, balance)
+	return err
+}
+
+// checkDeposit returns an error in case the sender's deposit is not enough to
+// reserve the given amount for one more free transaction.
+func checkDeposit(reserve int64, balance utilityBalanceAndFees) error {
+	var need uint256.Int
+
+	need.SetUint64(uint64(reserve))
+	if balance.deposit.Cmp(&need) < 0 {
+		return ErrInsufficientFunds
+	}
+	need.Mul(&need, uint256.NewInt(balance.freeTxs+1))
+	if balance.deposit.Cmp(&need) < 0 {
+		return ErrConflict
+	}
+	return nil
+}
+
 // checkBalance returns a new cumulative fee balance for the account or an error in
 // case the sender doesn't have enough GAS to pay for the transaction.
 func checkBalance(tx *transaction.Transaction, balance utilityBalanceAndFees) (uint256.Int, error) {
@@ -348,6 +409,9 @@ func (mp *Pool) removeFromMapWithFeesAndAttrs(itm item) {
 	payer := itm.txn.Signers[mp.payerIndex].Account
 	senderFee := mp.fees[payer]
 	senderFee.feeSum.SubUint64(&senderFee.feeSum, uint64(itm.txn.SystemFee+itm.txn.NetworkFee))
+	if isFreeTx(itm.txn) {
+		senderFee.freeTxs--
+	}
 	mp.fees[payer] = senderFee
 	// remove all conflicting hashes from mp.conflicts list
 	mp.removeConflictsOf(itm.txn)
@@ -524,7 +588,7 @@ func (mp *Pool) checkTxConflicts(tx *transaction.Transaction, fee Feer) ([]*tran
 	payer := tx.Signers[mp.payerIndex].Account
 	actualSenderFee, ok := mp.fees[payer]
 	if !ok {
-		actualSenderFee.balance.SetFromBig(fee.GetUtilityTokenBalance(payer))
+		actualSenderFee = getSenderFee(payer, fee)
 	}
 
 	var expectedSenderFee utilityBalanceAndFees
@@ -578,9 +642,12 @@ func (mp *Pool) checkTxConflicts(tx *transaction.Transaction, fee Feer) ([]*tran
 	for _, conflictingTx := range conflictsToBeRemoved {
 		if conflictingTx.Signers[mp.payerIndex].Account.Equals(payer) {
 			expectedSenderFee.feeSum.SubUint64(&expectedSenderFee.feeSum, uint64(conflictingTx.SystemFee+conflictingTx.NetworkFee))
+			if isFreeTx(conflictingTx) {
+				expectedSenderFee.freeTxs--
+			}
 		}
 	}
-	_, err := checkBalance(tx, expectedSenderFee)
+	err := checkFees(tx, fee, expectedSenderFee)
 	return conflictsToBeRemoved, err
 }
 
@@ -321,6 +321,57 @@ func TestMemPoolFees(t *testing.T) {
 	require.Equal(t, 0, len(mp.fees))
 }
 
+type DepositFeerStub struct {
+	FeerStub
+	reserve int64
+	deposit int64
+}
+
+func (fs *DepositFeerStub) FreeTxDeposit() int64 {
+	return fs.reserve
+}
+
+func (fs *DepositFeerStub) GetFreeTxDepositBalance(util.Uint160) *big.Int {
+	return big.NewInt(fs.deposit)
+}
+
+func TestMemPoolFreeTransactions(t *testing.T) {
+	mp := New(10, 0, false, nil)
+	fs := &DepositFeerStub{reserve: 10, deposit: 25}
+	sender0 := util.Uint160{1, 2, 3}
+	sender1 := util.Uint160{3, 2, 1}
+	newTx := func(nonce uint32, sender util.Uint160) *transaction.Transaction {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.Nonce = nonce
+		tx.Signers = []transaction.Signer{{Account: sender}}
+		return tx
+	}
+
+	tx1, tx2, tx3 := newTx(1, sender0), newTx(2, sender0), newTx(3, sender0)
+	require.NoError(t, mp.Add(tx1, fs))
+	require.NoError(t, mp.Add(tx2, fs))
+	require.False(t, mp.Verify(tx3, fs))
+	require.ErrorIs(t, mp.Add(tx3, fs), ErrConflict)
+	require.Equal(t, uint64(2), mp.fees[sender0].freeTxs)
+
+	mp.Remove(tx1.Hash())
+	require.Equal(t, uint64(1), mp.fees[sender0].freeTxs)
+	require.NoError(t, mp.Add(tx3, fs))
+
+	// Only one transaction fits into the new deposit.
+	fs.deposit = 15
+	mp.RemoveStale(func(*transaction.Transaction) bool { return true }, fs)
+	require.Equal(t, 1, mp.Count())
+	require.Equal(t, uint64(1), mp.fees[sender0].freeTxs)
+
+	fs.deposit = 5
+	require.ErrorIs(t, mp.Add(newTx(4, sender1), fs), ErrInsufficientFunds)
+
+	// Free transactions are not limited if Feer doesn't support deposits.
+	require.NoError(t, mp.Add(newTx(5, sender1), &FeerStub{}))
+	require.NoError(t, mp.Add(newTx(6, sender1), &FeerStub{}))
+}
+
 func TestMempoolItemsOrder(t *testing.T) {
 	sender0 := util.Uint160{1, 2, 3}
 	balance := big.NewInt(10000000)
@@ -3,8 +3,16 @@ package mpt
 import (
 	"bytes"
 	"slices"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
 )
 
+// parallelBatchMinItems is the minimum number of batch items to be put into
+// some subtrie concurrently with other subtries. Smaller batches are not worth
+// the synchronization overhead.
+var parallelBatchMinItems = 512
+
 // Batch is a batch of storage changes.
 // It stores key-value pairs in a sorted state.
 type Batch struct {
@@ -38,15 +46,131 @@ func MapToMPTBatch(m map[string][]byte) Batch {
 // This is due to the fact that we can remove multiple children from the branch node simultaneously
 // and won't strip the resulting branch node.
 // However, it is used mostly after block processing to update MPT, and error is not expected.
+// Large batches are split by key prefixes and independent subtries are updated
+// concurrently, in this case elements following the failed one can also be
+// processed (and counted).
 func (t *Trie) PutBatch(b Batch) (int, error) {
 	if len(b.kv) == 0 {
 		return 0, nil
 	}
-	r, n, err := t.putBatch(b.kv)
+	var (
+		r   Node
+		n   int
+		err error
+	)
+	if len(b.kv) >= 2*parallelBatchMinItems {
+		r, n, err = t.putBatchParallel(t.root, b.kv)
+	} else {
+		r, n, err = t.putBatch(b.kv)
+	}
 	t.root = r
 	return n, err
 }
 
+// putBatchParallel is the same as putBatchIntoNode, but it updates children of
+// branch nodes concurrently (each one using a separate trie with its own
+// reference counters merged into t afterwards) if there are enough items to
+// put into them.
+func (t *Trie) putBatchParallel(curr Node, kv []keyValue) (Node, int, error) {
+	if hn, ok := curr.(*HashNode); ok {
+		n, err := t.getFromStore(hn.hash)
+		if err != nil {
+			return curr, 0, err
+		}
+		curr = n
+	}
+	switch n := curr.(type) {
+	case *BranchNode:
+		return t.addToBranchParallel(n, kv)
+	case *ExtensionNode:
+		common := lcpMany(kv)
+		if len(lcp(common, n.key)) == len(n.key) {
+			t.removeRef(n.Hash(), n.bytes)
+			stripPrefix(len(n.key), kv)
+			sub, num, err := t.putBatchParallel(n.next, kv)
+			if err == nil {
+				sub, err = t.mergeExtension(n.key, sub)
+			}
+			return sub, num, err
+		}
+	}
+	return t.putBatchIntoNode(curr, kv)
+}
+
+// addToBranchParallel is the same as addToBranch for the branch node that is
+// in trie, but large groups of items are put into its children concurrently.
+func (t *Trie) addToBranchParallel(b *BranchNode, kv []keyValue) (Node, int, error) {
+	type subResult struct {
+		trie *Trie
+		node Node
+		num  int
+		err  error
+	}
+	var (
+		results [childrenCount]*subResult
+		wg      sync.WaitGroup
+	)
+	t.removeRef(b.Hash(), b.bytes)
+	n, err := t.iterateBatch(kv, func(c byte, kv []keyValue) (int, error) {
+		if len(kv) < parallelBatchMinItems {
+			child, n, err := t.putBatchIntoNode(b.Children[c], kv)
+			b.Children[c] = child
+			return n, err
+		}
+		res := &subResult{trie: &Trie{
+			Store:     t.Store,
+			mode:      t.mode,
+			refcount:  make(map[util.Uint256]*cachedNode),
+			nodeCache: t.nodeCache,
+		}}
+		results[c] = res
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res.node, res.num, res.err = res.trie.putBatchParallel(b.Children[c], kv)
+		}()
+		return 0, nil
+	})
+	wg.Wait()
+	for c, res := range results {
+		if res == nil {
+			continue
+		}
+		b.Children[c] = res.node
+		n += res.num
+		if err == nil {
+			err = res.err
+		}
+		t.mergeRefs(res.trie)
+	}
+	if n != 0 {
+		b.invalidateCache()
+	}
+	nd, bErr := t.stripBranch(b)
+	if err == nil {
+		err = bErr
+	}
+	return nd, n, err
+}
+
+// mergeRefs adds reference counter changes made in sub to t.
+func (t *Trie) mergeRefs(sub *Trie) {
+	for h, sn := range sub.refcount {
+		node := t.refcount[h]
+		if node == nil {
+			t.refcount[h] = sn
+			continue
+		}
+		node.refcount += sn.refcount
+		if node.bytes == nil {
+			node.bytes = sn.bytes
+		}
+		if node.initial == 0 {
+			node.initial = sn.initial
+		}
+	}
+}
+
 func (t *Trie) putBatch(kv []keyValue) (Node, int, error) {
 	return t.putBatchIntoNode(t.root, kv)
 }
@@ -1,8 +1,11 @@
 package mpt
 
 import (
+	"bytes"
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"math/rand"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
@@ -364,3 +367,97 @@ func printNode(prefix string, n Node) {
 		fmt.Printf("%s leaf-> %s\n", prefix, hex.EncodeToString(tn.value))
 	}
 }
+
+func dumpStore(st *storage.MemCachedStore) map[string][]byte {
+	var m = make(map[string][]byte)
+	st.Seek(storage.SeekRange{Prefix: []byte{byte(storage.DataMPT)}}, func(k, v []byte) bool {
+		m[string(k)] = bytes.Clone(v)
+		return true
+	})
+	return m
+}
+
+func FuzzTrie_PutBatchParallel(f *testing.F) {
+	for i := range 10 {
+		f.Add(int64(i), uint16(100+i*300))
+	}
+	f.Fuzz(func(t *testing.T, seed int64, count uint16) {
+		old := parallelBatchMinItems
+		parallelBatchMinItems = 4
+		t.Cleanup(func() { parallelBatchMinItems = old })
+
+		var (
+			r    = rand.New(rand.NewSource(seed))
+			keys []string
+			// Keys similar to contract storage ones: a few contract ID
+			// prefixes and random suffixes.
+			newKey = func() string {
+				k := make([]byte, 5+r.Intn(6))
+				k[0] = 'a'
+				binary.LittleEndian.PutUint32(k[1:], uint32(r.Intn(5)-2))
+				r.Read(k[5:])
+				return string(k)
+			}
+			newValue = func() []byte {
+				v := make([]byte, 1+r.Intn(8))
+				r.Read(v)
+				return v
+			}
+		)
+		for _, mode := range []TrieMode{ModeAll, ModeLatest, ModeGC} {
+			var (
+				seq = NewTrie(nil, mode, newTestStore())
+				par = NewTrie(nil, mode, newTestStore())
+			)
+			put := func(m map[string][]byte, index uint32) {
+				root, n1, err1 := seq.putBatch(MapToMPTBatch(m).kv)
+				seq.root = root
+				n2, err2 := par.PutBatch(MapToMPTBatch(m))
+				require.NoError(t, err1)
+				require.NoError(t, err2)
+				require.Equal(t, n1, n2)
+				require.Equal(t, seq.StateRoot(), par.StateRoot())
+				if mode.GC() {
+					require.ElementsMatch(t, seq.FlushDeactivated(index), par.FlushDeactivated(index))
+				} else {
+					seq.Flush(index)
+					par.Flush(index)
+				}
+				require.Equal(t, dumpStore(seq.Store), dumpStore(par.Store))
+			}
+
+			// Initial state.
+			m := make(map[string][]byte)
+			for range int(count) {
+				k := newKey()
+				m[k] = newValue()
+				keys = append(keys, k)
+			}
+			put(m, 1)
+
+			// Updates, deletions (including missing keys) and additions.
+			m = make(map[string][]byte)
+			for _, k := range keys {
+				switch r.Intn(4) {
+				case 0:
+					m[k] = newValue()
+				case 1:
+					m[k] = nil
+				}
+			}
+			for range int(count) / 4 {
+				m[newKey()] = newValue()
+				m[newKey()] = nil
+			}
+			put(m, 2)
+
+			// Remove all initial keys.
+			m = make(map[string][]byte)
+			for _, k := range keys {
+				m[k] = nil
+			}
+			put(m, 3)
+			keys = keys[:0]
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package mpt
+
+import (
+	"container/list"
+	"slices"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// nodeCacheEntryOverhead is an approximate size of memory used by a cached
+// node in addition to its serialized representation.
+const nodeCacheEntryOverhead = 256
+
+// NodeCache is an LRU cache of decoded nodes limited by their (approximate)
+// size, it can be shared by tries using the same storage. Nodes are identified
+// by their hashes, so it's only used for tries storing everything (ModeAll)
+// where node data is never changed or removed.
+type NodeCache struct {
+	lock    sync.Mutex
+	size    int
+	maxSize int
+	lst     *list.List
+	elems   map[util.Uint256]*list.Element
+}
+
+type nodeCacheEntry struct {
+	hash util.Uint256
+	node Node
+	size int
+}
+
+// NewNodeCache creates a NodeCache with the given maximum size (in bytes), nil
+// (no cache) is returned for non-positive sizes.
+func NewNodeCache(size int) *NodeCache {
+	if size <= 0 {
+		return nil
+	}
+	return &NodeCache{
+		maxSize: size,
+		lst:     list.New(),
+		elems:   make(map[util.Uint256]*list.Element),
+	}
+}
+
+// get returns a copy of the cached node with the given hash, so that the
+// caller can change it.
+func (c *NodeCache) get(h util.Uint256) (Node, bool) {
+	c.lock.Lock()
+	e, ok := c.elems[h]
+	if ok {
+		c.lst.MoveToFront(e)
+	}
+	c.lock.Unlock()
+	if !ok {
+		nodeCacheMisses.Inc()
+		return nil, false
+	}
+	nodeCacheHits.Inc()
+	return copyNode(e.Value.(*nodeCacheEntry).node), true
+}
+
+// add puts a copy of the given node into the cache evicting the least
+// recently used nodes if needed.
+func (c *NodeCache) add(h util.Uint256, n Node) {
+	var size = len(n.Bytes()) + nodeCacheEntryOverhead
+	if size > c.maxSize {
+		return
+	}
+	n = copyNode(n)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, ok := c.elems[h]; ok {
+		return
+	}
+	for c.size+size > c.maxSize {
+		e := c.lst.Back()
+		entry := c.lst.Remove(e).(*nodeCacheEntry)
+		delete(c.elems, entry.hash)
+		c.size -= entry.size
+	}
+	c.elems[h] = c.lst.PushFront(&nodeCacheEntry{hash: h, node: n, size: size})
+	c.size += size
+}
+
+// copyNode returns a shallow copy of the given deserialized node that can be
+// modified without affecting the original. Child nodes of deserialized nodes
+// are never modified (they're replaced), so they're shared.
+func copyNode(n Node) Node {
+	switch n := n.(type) {
+	case *BranchNode:
+		c := *n
+		return &c
+	case *ExtensionNode:
+		c := *n
+		c.key = slices.Clip(c.key) // Appending to it must reallocate.
+		return &c
+	case *LeafNode:
+		c := *n
+		return &c
+	case *HashNode:
+		c := *n
+		return &c
+	default:
+		return n
+	}
+}
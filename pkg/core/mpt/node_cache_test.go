@@ -0,0 +1,84 @@
+package mpt
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeCache(t *testing.T) {
+	c := NewNodeCache(1024 * 1024)
+	newTrie := func(root Node, mode TrieMode, st *storage.MemCachedStore) *Trie {
+		tr := NewTrie(root, mode, st)
+		tr.SetNodeCache(c)
+		return tr
+	}
+
+	st := newTestStore()
+	tr := newTrie(nil, ModeAll, st)
+	for i := range byte(32) {
+		require.NoError(t, tr.Put([]byte{i, 0xAB}, []byte{i}))
+	}
+	tr.Flush(0)
+	root := tr.StateRoot()
+
+	tr1 := newTrie(NewHashNode(root), ModeAll, st)
+	tr1.testHas(t, []byte{1, 0xAB}, []byte{1})
+	require.NotZero(t, len(c.elems))
+
+	// Nodes taken from the cache can be changed without affecting the cache.
+	tr2 := newTrie(NewHashNode(root), ModeAll, newTestStore())
+	tr2.testHas(t, []byte{1, 0xAB}, []byte{1})
+	require.NoError(t, tr2.Put([]byte{1, 0xAB}, []byte{2}))
+	require.NoError(t, tr2.Put([]byte{1, 0xAC}, []byte{3}))
+	tr2.testHas(t, []byte{1, 0xAB}, []byte{2})
+
+	tr3 := newTrie(NewHashNode(root), ModeAll, newTestStore())
+	tr3.testHas(t, []byte{1, 0xAB}, []byte{1})
+	_, err := tr3.Get([]byte{1, 0xAC})
+	require.ErrorIs(t, err, ErrNotFound)
+	require.Equal(t, root, tr3.StateRoot())
+
+	t.Run("per trie", func(t *testing.T) {
+		// Tries without the cache (or with another one) don't see its nodes.
+		tr := NewTrie(NewHashNode(root), ModeAll, newTestStore())
+		_, err := tr.Get([]byte{1, 0xAB})
+		require.Error(t, err)
+
+		other := NewNodeCache(1024 * 1024)
+		tr.SetNodeCache(other)
+		_, err = tr.Get([]byte{1, 0xAB})
+		require.Error(t, err)
+		require.Zero(t, len(other.elems))
+	})
+	t.Run("disabled", func(t *testing.T) {
+		require.Nil(t, NewNodeCache(0))
+		tr := NewTrie(NewHashNode(root), ModeAll, st)
+		tr.SetNodeCache(nil)
+		tr.testHas(t, []byte{1, 0xAB}, []byte{1})
+	})
+	t.Run("refcounting", func(t *testing.T) {
+		c := NewNodeCache(1024 * 1024)
+		st := newTestStore()
+		tr := NewTrie(nil, ModeLatest, st)
+		require.NoError(t, tr.Put([]byte{1}, []byte{1}))
+		tr.Flush(0)
+
+		tr1 := NewTrie(NewHashNode(tr.StateRoot()), ModeLatest, st)
+		tr1.SetNodeCache(c)
+		tr1.testHas(t, []byte{1}, []byte{1})
+		require.Zero(t, len(c.elems))
+	})
+	t.Run("eviction", func(t *testing.T) {
+		c := NewNodeCache(2 * nodeCacheEntryOverhead)
+		tr1 := NewTrie(NewHashNode(root), ModeAll, st)
+		tr1.SetNodeCache(c)
+		for i := range byte(32) {
+			tr1.testHas(t, []byte{i, 0xAB}, []byte{i})
+		}
+		require.LessOrEqual(t, c.size, c.maxSize)
+		require.Equal(t, c.lst.Len(), len(c.elems))
+		require.NotZero(t, len(c.elems))
+	})
+}
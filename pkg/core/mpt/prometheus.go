@@ -0,0 +1,32 @@
+package mpt
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics for monitoring service.
+var (
+	// nodeCacheHits prometheus metric.
+	nodeCacheHits = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Help:      "Number of MPT nodes found in the node cache",
+			Name:      "mpt_node_cache_hits_total",
+			Namespace: "neogo",
+		},
+	)
+	// nodeCacheMisses prometheus metric.
+	nodeCacheMisses = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Help:      "Number of MPT nodes missing in the node cache",
+			Name:      "mpt_node_cache_misses_total",
+			Namespace: "neogo",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		nodeCacheHits,
+		nodeCacheMisses,
+	)
+}
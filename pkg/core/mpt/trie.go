@@ -32,9 +32,10 @@ const (
 type Trie struct {
 	Store *storage.MemCachedStore
 
-	root     Node
-	mode     TrieMode
-	refcount map[util.Uint256]*cachedNode
+	root      Node
+	mode      TrieMode
+	refcount  map[util.Uint256]*cachedNode
+	nodeCache *NodeCache
 }
 
 type cachedNode struct {
@@ -73,6 +74,13 @@ func NewTrie(root Node, mode TrieMode, store *storage.MemCachedStore) *Trie {
 	}
 }
 
+// SetNodeCache sets the decoded node cache used by the trie, nodes are only
+// read from the storage and deserialized if they're missing in it. The cache
+// is only used with ModeAll, nil disables it.
+func (t *Trie) SetNodeCache(c *NodeCache) {
+	t.nodeCache = c
+}
+
 // Get returns the value for the provided key in t.
 func (t *Trie) Get(key []byte) ([]byte, error) {
 	if len(key) > MaxKeyLength {
@@ -407,6 +415,22 @@ func makeStorageKey(mptKey util.Uint256) []byte {
 // new node to the storage. Normally, flush should be called with every StateRoot persist, i.e.
 // after every block.
 func (t *Trie) Flush(index uint32) {
+	_ = t.flush(index, false)
+}
+
+// FlushDeactivated is the same as Flush, but it also returns hashes of the
+// nodes that are no longer referenced after this flush. These nodes are marked
+// as inactive (with the given index) and kept in the storage, so it can only be
+// used in GC mode.
+func (t *Trie) FlushDeactivated(index uint32) []util.Uint256 {
+	if !t.mode.GC() {
+		panic("`FlushDeactivated` is called, but GC is disabled")
+	}
+	return t.flush(index, true)
+}
+
+func (t *Trie) flush(index uint32, collect bool) []util.Uint256 {
+	var deactivated []util.Uint256
 	key := makeStorageKey(util.Uint256{})
 	for h, node := range t.refcount {
 		if node.refcount != 0 {
@@ -418,6 +442,9 @@ func (t *Trie) Flush(index uint32) {
 				node.initial = t.updateRefCount(h, key, index)
 				if node.initial == 0 {
 					delete(t.refcount, h)
+					if collect {
+						deactivated = append(deactivated, h)
+					}
 				}
 			} else if node.refcount > 0 {
 				t.Store.Put(key, node.bytes)
@@ -427,6 +454,7 @@ func (t *Trie) Flush(index uint32) {
 			delete(t.refcount, h)
 		}
 	}
+	return deactivated
 }
 
 func IsActiveValue(v []byte) bool {
@@ -511,6 +539,15 @@ func (t *Trie) removeRef(h util.Uint256, bs []byte) {
 }
 
 func (t *Trie) getFromStore(h util.Uint256) (Node, error) {
+	var c *NodeCache
+	if t.mode == ModeAll {
+		c = t.nodeCache
+	}
+	if c != nil {
+		if n, ok := c.get(h); ok {
+			return n, nil
+		}
+	}
 	data, err := getFromStore(makeStorageKey(h), t.mode, t.Store)
 	if err != nil {
 		return nil, err
@@ -533,6 +570,9 @@ func (t *Trie) getFromStore(h util.Uint256) (Node, error) {
 		}
 	}
 	n.Node.(flushedNode).setCache(data, h)
+	if c != nil {
+		c.add(h, n.Node)
+	}
 	return n.Node, nil
 }
 
@@ -34,6 +34,12 @@ func NewTrieStore(root util.Uint256, mode TrieMode, backed storage.Store) *TrieS
 	}
 }
 
+// SetNodeCache sets the decoded node cache used by the underlying trie, see
+// [Trie.SetNodeCache].
+func (m *TrieStore) SetNodeCache(c *NodeCache) {
+	m.trie.SetNodeCache(c)
+}
+
 // Get implements the Store interface. It can return [errors.ErrUnsupported]
 // for unsupported operations.
 func (m *TrieStore) Get(key []byte) ([]byte, error) {
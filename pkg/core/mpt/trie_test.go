@@ -1,6 +1,7 @@
 package mpt
 
 import (
+	"encoding/binary"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/random"
@@ -327,6 +328,24 @@ func TestTrie_Flush(t *testing.T) {
 	}
 }
 
+func TestTrie_FlushDeactivated(t *testing.T) {
+	require.Panics(t, func() { _ = NewTrie(nil, ModeLatest, newTestStore()).FlushDeactivated(0) })
+
+	tr := NewTrie(nil, ModeGC, newTestStore())
+	require.NoError(t, tr.Put([]byte{0x11}, []byte("value")))
+	require.Empty(t, tr.FlushDeactivated(1))
+	oldRoot := tr.StateRoot()
+
+	require.NoError(t, tr.Put([]byte{0x11}, []byte("other")))
+	stale := tr.FlushDeactivated(2)
+	require.Contains(t, stale, oldRoot)
+
+	v, err := tr.Store.Get(makeStorageKey(oldRoot))
+	require.NoError(t, err)
+	require.False(t, IsActiveValue(v))
+	require.Equal(t, uint32(2), binary.LittleEndian.Uint32(v[len(v)-4:]))
+}
+
 func TestTrie_Delete(t *testing.T) {
 	t.Run("No GC", func(t *testing.T) {
 		testTrieDelete(t, false)
@@ -74,7 +74,7 @@ func NewContracts(cfg config.ProtocolConfiguration) *Contracts {
 
 	gas := newGAS(int64(cfg.InitialGASSupply), cfg.P2PSigExtensions)
 	neo := newNEO(cfg)
-	policy := newPolicy(cfg.P2PSigExtensions)
+	policy := newPolicy(cfg.P2PSigExtensions, cfg.ContractFeeExemptions)
 	neo.GAS = gas
 	neo.Policy = policy
 	gas.NEO = neo
@@ -724,11 +724,7 @@ func (m *Management) PostPersist(ic *interop.Context) error {
 // is returned.
 func (m *Management) GetNEP11Contracts(d *dao.Simple) []util.Uint160 {
 	cache := d.GetROCache(m.ID).(*ManagementCache)
-	result := make([]util.Uint160, 0, len(cache.nep11))
-	for h := range cache.nep11 {
-		result = append(result, h)
-	}
-	return result
+	return util.MapKeys(cache.nep11)
 }
 
 // GetNEP17Contracts returns hashes of all deployed contracts that support NEP-17 standard. The list
@@ -736,11 +732,7 @@ func (m *Management) GetNEP11Contracts(d *dao.Simple) []util.Uint160 {
 // is returned.
 func (m *Management) GetNEP17Contracts(d *dao.Simple) []util.Uint160 {
 	cache := d.GetROCache(m.ID).(*ManagementCache)
-	result := make([]util.Uint160, 0, len(cache.nep17))
-	for h := range cache.nep17 {
-		result = append(result, h)
-	}
-	return result
+	return util.MapKeys(cache.nep17)
 }
 
 // Initialize implements the Contract interface.
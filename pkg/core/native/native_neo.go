@@ -116,6 +116,16 @@ var (
 	// prefixCommittee is a key used to store committee.
 	prefixCommittee = []byte{14}
 
+	// governanceIndexedEvents is the manifest Extra of NEO contract with
+	// governance events enabled, it makes NEO governance notifications
+	// searchable by candidate key and voter account on nodes with event
+	// indexing enabled (see manifest.IndexedEventsField).
+	governanceIndexedEvents = []byte(`{"` + manifest.IndexedEventsField + `":{` +
+		`"CandidateStateChanged":["pubkey"],` +
+		`"CandidateVotesChanged":["pubkey"],` +
+		`"CommitteeMemberChanged":["pubkey"],` +
+		`"Vote":["account","from","to"]}}`)
+
 	bigCommitteeRewardRatio  = big.NewInt(committeeRewardRatio)
 	bigVoterRewardRatio      = big.NewInt(voterRewardRatio)
 	bigVoterRewardFactor     = big.NewInt(voterRewardFactor)
@@ -174,6 +184,9 @@ func newNEO(cfg config.ProtocolConfiguration) *NEO {
 		if hf.Cmp(config.HFEchidna) >= 0 {
 			m.SupportedStandards = append(m.SupportedStandards, manifest.NEP27StandardName)
 		}
+		if cfg.GovernanceEvents {
+			m.Extra = governanceIndexedEvents
+		}
 	})
 	nep17.symbol = "NEO"
 	nep17.decimals = 0
@@ -298,6 +311,30 @@ func newNEO(cfg config.ProtocolConfiguration) *NEO {
 	eMD = newEvent(eDesc, config.HFCockatrice)
 	n.AddEvent(eMD)
 
+	if cfg.GovernanceEvents {
+		eDesc = newEventDescriptor("CandidateVotesChanged",
+			manifest.NewParameter("pubkey", smartcontract.PublicKeyType),
+			manifest.NewParameter("delta", smartcontract.IntegerType),
+			manifest.NewParameter("votes", smartcontract.IntegerType),
+		)
+		eMD = newEvent(eDesc)
+		n.AddEvent(eMD)
+
+		eDesc = newEventDescriptor("CommitteeMemberChanged",
+			manifest.NewParameter("pubkey", smartcontract.PublicKeyType),
+			manifest.NewParameter("joined", smartcontract.BoolType),
+		)
+		eMD = newEvent(eDesc)
+		n.AddEvent(eMD)
+
+		eDesc = newEventDescriptor("NextValidatorsChanged",
+			manifest.NewParameter("old", smartcontract.ArrayType),
+			manifest.NewParameter("new", smartcontract.ArrayType),
+		)
+		eMD = newEvent(eDesc)
+		n.AddEvent(eMD)
+	}
+
 	return n
 }
 
@@ -469,6 +506,10 @@ func (n *NEO) OnPersist(ic *interop.Context) error {
 				break
 			}
 		}
+		var (
+			prevCommittee  = cache.committee
+			prevValidators = cache.nextValidators
+		)
 
 		cache.nextValidators = cache.newEpochNextValidators
 		cache.committee = cache.newEpochCommittee
@@ -483,6 +524,9 @@ func (n *NEO) OnPersist(ic *interop.Context) error {
 				oldCommittee, newCommittee,
 			}))
 		}
+		if n.cfg.GovernanceEvents {
+			n.notifyGovernanceChanges(ic, prevCommittee, cache.committee, prevValidators, cache.nextValidators)
+		}
 	}
 	return nil
 }
@@ -596,7 +640,7 @@ func (n *NEO) increaseBalance(ic *interop.Context, h util.Uint160, si *state.Sto
 		*si = acc.Bytes(ic.DAO.GetItemCtx())
 		return postF, nil
 	}
-	if err := n.ModifyAccountVotes(acc, ic.DAO, amount, false); err != nil {
+	if err := n.modifyAccountVotes(ic, acc, amount, false); err != nil {
 		return nil, err
 	}
 	if acc.VoteTo != nil {
@@ -1008,7 +1052,7 @@ func (n *NEO) VoteInternal(ic *interop.Context, h util.Uint160, pub *keys.Public
 	if err != nil {
 		return err
 	}
-	if err := n.ModifyAccountVotes(acc, ic.DAO, new(big.Int).Neg(&acc.Balance), false); err != nil {
+	if err := n.modifyAccountVotes(ic, acc, new(big.Int).Neg(&acc.Balance), false); err != nil {
 		return err
 	}
 	if pub != nil && pub != acc.VoteTo {
@@ -1016,7 +1060,7 @@ func (n *NEO) VoteInternal(ic *interop.Context, h util.Uint160, pub *keys.Public
 	}
 	oldVote := acc.VoteTo
 	acc.VoteTo = pub
-	if err := n.ModifyAccountVotes(acc, ic.DAO, &acc.Balance, true); err != nil {
+	if err := n.modifyAccountVotes(ic, acc, &acc.Balance, true); err != nil {
 		return err
 	}
 	if pub == nil {
@@ -1047,25 +1091,98 @@ func keyToStackItem(k *keys.PublicKey) stackitem.Item {
 // ModifyAccountVotes modifies votes of the specified account by value (can be negative).
 // typ specifies if this modify is occurring during transfer or vote (with old or new validator).
 func (n *NEO) ModifyAccountVotes(acc *state.NEOBalance, d *dao.Simple, value *big.Int, isNewVote bool) error {
+	_, err := n.modifyCandidateVotes(acc, d, value, isNewVote)
+	return err
+}
+
+// modifyAccountVotes is the same as ModifyAccountVotes, but it also emits
+// CandidateVotesChanged notification if governance events are enabled.
+func (n *NEO) modifyAccountVotes(ic *interop.Context, acc *state.NEOBalance, value *big.Int, isNewVote bool) error {
+	votes, err := n.modifyCandidateVotes(acc, ic.DAO, value, isNewVote)
+	if err != nil || votes == nil || value.Sign() == 0 || !n.cfg.GovernanceEvents {
+		return err
+	}
+	ic.AddNotification(n.Hash, "CandidateVotesChanged", stackitem.NewArray([]stackitem.Item{
+		stackitem.NewByteArray(acc.VoteTo.Bytes()),
+		stackitem.NewBigInteger(value),
+		stackitem.NewBigInteger(votes),
+	}))
+	return nil
+}
+
+// modifyCandidateVotes changes votes of the candidate the account votes for
+// and returns the resulting number of candidate votes (nil if the account
+// doesn't vote).
+func (n *NEO) modifyCandidateVotes(acc *state.NEOBalance, d *dao.Simple, value *big.Int, isNewVote bool) (*big.Int, error) {
 	cache := d.GetRWCache(n.ID).(*NeoCache)
 	cache.votesChanged = true
 	if acc.VoteTo != nil {
 		key := makeValidatorKey(acc.VoteTo)
 		si := d.GetStorageItem(n.ID, key)
 		if si == nil {
-			return errors.New("invalid validator")
+			return nil, errors.New("invalid validator")
 		}
 		cd := new(candidate).FromBytes(si)
 		cd.Votes.Add(&cd.Votes, value)
 		if !isNewVote {
 			ok := n.dropCandidateIfZero(d, cache, acc.VoteTo, cd)
 			if ok {
-				return nil
+				return &cd.Votes, nil
 			}
 		}
-		return putConvertibleToDAO(n.ID, d, key, cd)
+		return &cd.Votes, putConvertibleToDAO(n.ID, d, key, cd)
 	}
-	return nil
+	return nil, nil
+}
+
+// notifyGovernanceChanges emits CommitteeMemberChanged notifications for
+// every committee member leaving or joining the committee and
+// NextValidatorsChanged notification if validators change at the committee
+// update.
+func (n *NEO) notifyGovernanceChanges(ic *interop.Context, oldCommittee, newCommittee keysWithVotes,
+	oldValidators, newValidators keys.PublicKeys) {
+	var (
+		oldMembers = make(map[string]struct{}, len(oldCommittee))
+		newMembers = make(map[string]struct{}, len(newCommittee))
+	)
+	for i := range oldCommittee {
+		oldMembers[oldCommittee[i].Key] = struct{}{}
+	}
+	for i := range newCommittee {
+		newMembers[newCommittee[i].Key] = struct{}{}
+	}
+	for i := range oldCommittee {
+		if _, ok := newMembers[oldCommittee[i].Key]; !ok {
+			ic.AddNotification(n.Hash, "CommitteeMemberChanged", stackitem.NewArray([]stackitem.Item{
+				stackitem.NewByteArray([]byte(oldCommittee[i].Key)),
+				stackitem.NewBool(false),
+			}))
+		}
+	}
+	for i := range newCommittee {
+		if _, ok := oldMembers[newCommittee[i].Key]; !ok {
+			ic.AddNotification(n.Hash, "CommitteeMemberChanged", stackitem.NewArray([]stackitem.Item{
+				stackitem.NewByteArray([]byte(newCommittee[i].Key)),
+				stackitem.NewBool(true),
+			}))
+		}
+	}
+	if !slices.EqualFunc(oldValidators, newValidators, (*keys.PublicKey).Equal) {
+		ic.AddNotification(n.Hash, "NextValidatorsChanged", stackitem.NewArray([]stackitem.Item{
+			pubKeysToNotificationItem(oldValidators),
+			pubKeysToNotificationItem(newValidators),
+		}))
+	}
+}
+
+// pubKeysToNotificationItem converts public keys to a stackitem.Item suitable
+// for use in a notification.
+func pubKeysToNotificationItem(pubs keys.PublicKeys) stackitem.Item {
+	arr := make([]stackitem.Item, len(pubs))
+	for i := range pubs {
+		arr[i] = stackitem.NewByteArray(pubs[i].Bytes())
+	}
+	return stackitem.NewArray(arr)
 }
 
 func (n *NEO) getCandidates(d *dao.Simple, sortByKey bool, maxNum int) ([]keyWithVotes, error) {
@@ -13,6 +13,7 @@ import (
 	"github.com/nspcc-dev/neo-go/internal/contracts"
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
@@ -185,6 +186,127 @@ func TestNEO_CommitteeEvents(t *testing.T) {
 	}
 }
 
+func TestNEO_GovernanceEvents(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		c := newNeoCommitteeClient(t, 0)
+		cs := c.Chain.GetContractState(c.Hash)
+		require.Nil(t, cs.Manifest.ABI.GetEvent("CandidateVotesChanged"))
+		require.Nil(t, cs.Manifest.ABI.GetEvent("CommitteeMemberChanged"))
+		require.Nil(t, cs.Manifest.ABI.GetEvent("NextValidatorsChanged"))
+	})
+
+	bc, validators, committee := chain.NewMultiWithCustomConfig(t, func(cfg *config.Blockchain) {
+		cfg.GovernanceEvents = true
+		cfg.Ledger.IndexEvents = true
+	})
+	e := neotest.NewExecutor(t, bc, validators, committee)
+	e.ValidatorInvoker(e.NativeHash(t, nativenames.Gas)).Invoke(t, true, "transfer", e.Validator.ScriptHash(), e.CommitteeHash, 100_0000_0000, nil)
+	neoValidatorsInvoker := e.ValidatorInvoker(e.NativeHash(t, nativenames.Neo))
+	neoHash := neoValidatorsInvoker.Hash
+
+	cs := bc.GetContractState(neoHash)
+	for _, name := range []string{"CandidateVotesChanged", "CommitteeMemberChanged", "NextValidatorsChanged"} {
+		require.NotNil(t, cs.Manifest.ABI.GetEvent(name), name)
+	}
+	indexed, err := cs.Manifest.IndexedEvents()
+	require.NoError(t, err)
+	require.Equal(t, []int{0}, indexed["CandidateVotesChanged"])
+	require.Equal(t, []int{0}, indexed["CommitteeMemberChanged"])
+	require.Equal(t, []int{0, 1, 2}, indexed["Vote"])
+
+	eventsOf := func(t *testing.T, events []state.NotificationEvent, name string) []stackitem.Item {
+		var res []stackitem.Item
+		for _, ev := range events {
+			if ev.ScriptHash == neoHash && ev.Name == name {
+				res = append(res, ev.Item)
+			}
+		}
+		return res
+	}
+
+	cfg := bc.GetConfig()
+	committeeSize := cfg.GetCommitteeSize(0)
+	validatorsCount := cfg.GetNumOfCNs(0)
+
+	voters := make([]neotest.Signer, committeeSize)
+	candidates := make([]neotest.Signer, committeeSize)
+	for i := range committeeSize {
+		voters[i] = e.NewAccount(t, 10_0000_0000)
+		candidates[i] = e.NewAccount(t, 2000_0000_0000) // enough for one registration
+	}
+	txes := make([]*transaction.Transaction, 0, committeeSize*3)
+	votes := make([]int64, committeeSize)
+	for i := range committeeSize {
+		votes[i] = int64(committeeSize-i) * 1000000
+		txes = append(txes, neoValidatorsInvoker.PrepareInvoke(t, "transfer", e.Validator.ScriptHash(), voters[i].ScriptHash(), votes[i], nil))
+		txes = append(txes, neoValidatorsInvoker.WithSigners(candidates[i]).PrepareInvoke(t, "registerCandidate", candidates[i].(neotest.SingleSigner).Account().PublicKey().Bytes()))
+		txes = append(txes, neoValidatorsInvoker.WithSigners(voters[i]).PrepareInvoke(t, "vote", voters[i].ScriptHash(), candidates[i].(neotest.SingleSigner).Account().PublicKey().Bytes()))
+	}
+	neoValidatorsInvoker.AddNewBlock(t, txes...)
+	for i, tx := range txes {
+		e.CheckHalt(t, tx.Hash(), stackitem.Make(true))
+		if i%3 != 2 {
+			continue
+		}
+		aer := e.GetTxExecResult(t, tx.Hash())
+		require.Equal(t, []stackitem.Item{stackitem.NewArray([]stackitem.Item{
+			stackitem.Make(candidates[i/3].(neotest.SingleSigner).Account().PublicKey().Bytes()),
+			stackitem.Make(votes[i/3]),
+			stackitem.Make(votes[i/3]),
+		})}, eventsOf(t, aer.Events, "CandidateVotesChanged"))
+	}
+
+	// Voter's balance change affects candidate votes.
+	voterInvoker := neoValidatorsInvoker.WithSigners(voters[0])
+	h := voterInvoker.Invoke(t, true, "transfer", voters[0].ScriptHash(), e.Validator.ScriptHash(), 1000, nil)
+	aer := e.GetTxExecResult(t, h)
+	candidateKey := candidates[0].(neotest.SingleSigner).Account().PublicKey().Bytes()
+	require.Equal(t, []stackitem.Item{stackitem.NewArray([]stackitem.Item{
+		stackitem.Make(candidateKey),
+		stackitem.Make(-1000),
+		stackitem.Make(votes[0] - 1000),
+	})}, eventsOf(t, aer.Events, "CandidateVotesChanged"))
+
+	// Candidate votes changes are indexed by candidate key.
+	var found []util.Uint256
+	require.NoError(t, bc.ForEachIndexedNotification(neoHash, "CandidateVotesChanged", 0, candidateKey, nil,
+		func(_ []byte, ne *state.ContainedNotificationEvent) bool {
+			found = append(found, ne.Container)
+			return true
+		}))
+	require.Equal(t, []util.Uint256{h, txes[2].Hash()}, found)
+
+	// Advance the chain to trigger committee recalculation.
+	for bc.BlockHeight()%uint32(committeeSize) != 0 {
+		neoValidatorsInvoker.AddNewBlock(t)
+	}
+	aers, err := bc.GetAppExecResults(bc.CurrentBlockHash(), trigger.OnPersist)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(aers))
+
+	oldCommittee, err := keys.NewPublicKeysFromStrings(cfg.StandbyCommittee)
+	require.NoError(t, err)
+	expected := make([]stackitem.Item, 0, 2*committeeSize)
+	for _, k := range oldCommittee {
+		expected = append(expected, stackitem.NewArray([]stackitem.Item{stackitem.Make(k.Bytes()), stackitem.Make(false)}))
+	}
+	newValidators := make([]stackitem.Item, 0, validatorsCount)
+	for i, c := range candidates {
+		pub := c.(neotest.SingleSigner).Account().PublicKey()
+		expected = append(expected, stackitem.NewArray([]stackitem.Item{stackitem.Make(pub.Bytes()), stackitem.Make(true)}))
+		if i < validatorsCount {
+			newValidators = append(newValidators, stackitem.Make(pub.Bytes()))
+		}
+	}
+	require.ElementsMatch(t, expected, eventsOf(t, aers[0].Events, "CommitteeMemberChanged"))
+
+	validatorsChanged := eventsOf(t, aers[0].Events, "NextValidatorsChanged")
+	require.Equal(t, 1, len(validatorsChanged))
+	arr := validatorsChanged[0].Value().([]stackitem.Item)
+	require.Equal(t, validatorsCount, len(arr[0].Value().([]stackitem.Item)))
+	require.ElementsMatch(t, newValidators, arr[1].Value().([]stackitem.Item))
+}
+
 func TestNEO_Vote(t *testing.T) {
 	neoCommitteeInvoker := newNeoCommitteeClient(t, 100_0000_0000)
 	neoValidatorsInvoker := neoCommitteeInvoker.WithSigners(neoCommitteeInvoker.Validator)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
@@ -15,6 +16,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
 )
 
 func newPolicyClient(t *testing.T) *neotest.ContractInvoker {
@@ -158,3 +160,57 @@ func TestPolicy_BlockedAccounts(t *testing.T) {
 		helperInvoker.Invoke(t, true, "do")
 	})
 }
+
+func TestPolicy_FeeExemptions(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		c := newPolicyClient(t)
+		c.InvokeFail(t, "method not found: getFeeExemption/2", "getFeeExemption", util.Uint160{1, 2, 3}, "do")
+	})
+
+	c := newCustomNativeClient(t, nativenames.Policy, func(cfg *config.Blockchain) {
+		cfg.ContractFeeExemptions = true
+	})
+	e := c.Executor
+	randomInvoker := c.WithSigners(c.NewAccount(t))
+	committeeInvoker := c.WithSigners(c.Committee)
+
+	helper := neotest.CompileFile(t, c.CommitteeHash, "./helpers/policyhelper", "./helpers/policyhelper/policyhelper.yml")
+	e.DeployContract(t, helper, nil)
+	helperInvoker := e.CommitteeInvoker(helper.Hash)
+	gasConsumed := func() int64 {
+		h := helperInvoker.Invoke(t, true, "do")
+		return e.GetTxExecResult(t, h).GasConsumed
+	}
+
+	t.Run("not signed by committee", func(t *testing.T) {
+		randomInvoker.InvokeFail(t, "invalid committee signature", "setFeeExemption", helper.Hash, "do", 99)
+	})
+	t.Run("invalid discount", func(t *testing.T) {
+		committeeInvoker.InvokeFail(t, "discount must be between 0 and 99", "setFeeExemption", helper.Hash, "do", 100)
+	})
+	t.Run("invalid method", func(t *testing.T) {
+		committeeInvoker.InvokeFail(t, "method name length must be between 1 and 64", "setFeeExemption", helper.Hash, "", 99)
+	})
+
+	full := gasConsumed()
+	randomInvoker.Invoke(t, 0, "getFeeExemption", helper.Hash, "do")
+
+	committeeInvoker.Invoke(t, stackitem.Null{}, "setFeeExemption", helper.Hash, "do", 99)
+	randomInvoker.Invoke(t, 99, "getFeeExemption", helper.Hash, "do")
+	free := gasConsumed()
+	require.Less(t, free, full)
+	require.Positive(t, free)
+
+	committeeInvoker.Invoke(t, stackitem.Null{}, "setFeeExemption", helper.Hash, "do", 50)
+	randomInvoker.Invoke(t, 50, "getFeeExemption", helper.Hash, "do")
+	half := gasConsumed()
+	require.Less(t, free, half)
+	require.Less(t, half, full)
+
+	// Other methods are not affected.
+	randomInvoker.Invoke(t, 0, "getFeeExemption", helper.Hash, "other")
+
+	committeeInvoker.Invoke(t, stackitem.Null{}, "setFeeExemption", helper.Hash, "do", 0)
+	randomInvoker.Invoke(t, 0, "getFeeExemption", helper.Hash, "do")
+	require.Equal(t, full, gasConsumed())
+}
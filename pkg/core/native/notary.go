@@ -357,6 +357,31 @@ func (n *Notary) ExpirationOf(dao *dao.Simple, acc util.Uint160) uint32 {
 	return deposit.Till
 }
 
+// SlashDeposit burns up to the given amount of GAS from the deposit of the
+// specified account. It's used to punish senders of failed free transactions,
+// the amount actually burnt is returned.
+func (n *Notary) SlashDeposit(ic *interop.Context, acc util.Uint160, amount int64) (int64, error) {
+	deposit := n.GetDepositFor(ic.DAO, acc)
+	if deposit == nil || amount <= 0 {
+		return 0, nil
+	}
+	slashed := big.NewInt(amount)
+	if deposit.Amount.Cmp(slashed) < 0 {
+		slashed.Set(deposit.Amount)
+	}
+	deposit.Amount.Sub(deposit.Amount, slashed)
+	if deposit.Amount.Sign() == 0 {
+		n.removeDepositFor(ic.DAO, acc)
+	} else {
+		err := n.putDepositFor(ic.DAO, deposit, acc)
+		if err != nil {
+			return 0, fmt.Errorf("failed to update deposit for %s: %w", acc.StringBE(), err)
+		}
+	}
+	n.GAS.burn(ic, n.Hash, slashed)
+	return slashed.Int64(), nil
+}
+
 // verify checks whether the transaction was signed by one of the notaries.
 func (n *Notary) verify(ic *interop.Context, args []stackitem.Item) stackitem.Item {
 	sig, err := args[0].TryBytes()
@@ -19,6 +19,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 )
 
@@ -48,6 +49,12 @@ const (
 	blockedAccountPrefix = 15
 	// attributeFeePrefix is a prefix used to store attribute fee.
 	attributeFeePrefix = 20
+	// feeExemptionPrefix is a prefix used to store contract method fee
+	// exemptions (NeoGo extension).
+	feeExemptionPrefix = 64
+
+	// maxFeeExemptionMethodLen is the maximum length of the exempted method name.
+	maxFeeExemptionMethodLen = 64
 )
 
 var (
@@ -67,6 +74,8 @@ type Policy struct {
 
 	// p2pSigExtensionsEnabled defines whether the P2P signature extensions logic is relevant.
 	p2pSigExtensionsEnabled bool
+	// feeExemptionsEnabled defines whether contract fee exemptions extension is enabled.
+	feeExemptionsEnabled bool
 }
 
 type PolicyCache struct {
@@ -76,6 +85,15 @@ type PolicyCache struct {
 	storagePrice       uint32
 	attributeFee       map[transaction.AttrType]uint32
 	blockedAccounts    []util.Uint160
+	// feeExemptions contains system fee discounts (in percents) for
+	// contract methods.
+	feeExemptions map[feeExemptionKey]uint8
+}
+
+// feeExemptionKey identifies exempted contract method.
+type feeExemptionKey struct {
+	hash   util.Uint160
+	method string
 }
 
 var (
@@ -94,13 +112,15 @@ func copyPolicyCache(src, dst *PolicyCache) {
 	*dst = *src
 	dst.attributeFee = maps.Clone(src.attributeFee)
 	dst.blockedAccounts = slices.Clone(src.blockedAccounts)
+	dst.feeExemptions = maps.Clone(src.feeExemptions)
 }
 
 // newPolicy returns Policy native contract.
-func newPolicy(p2pSigExtensionsEnabled bool) *Policy {
+func newPolicy(p2pSigExtensionsEnabled bool, feeExemptionsEnabled bool) *Policy {
 	p := &Policy{
 		ContractMD:              *interop.NewContractMD(nativenames.Policy, policyContractID),
 		p2pSigExtensionsEnabled: p2pSigExtensionsEnabled,
+		feeExemptionsEnabled:    feeExemptionsEnabled,
 	}
 	defer p.BuildHFSpecificMD(p.ActiveIn())
 
@@ -157,6 +177,21 @@ func newPolicy(p2pSigExtensionsEnabled bool) *Policy {
 	md = newMethodAndPrice(p.unblockAccount, 1<<15, callflag.States)
 	p.AddMethod(md, desc)
 
+	if feeExemptionsEnabled {
+		desc = newDescriptor("getFeeExemption", smartcontract.IntegerType,
+			manifest.NewParameter("contract", smartcontract.Hash160Type),
+			manifest.NewParameter("method", smartcontract.StringType))
+		md = newMethodAndPrice(p.getFeeExemption, 1<<15, callflag.ReadStates)
+		p.AddMethod(md, desc)
+
+		desc = newDescriptor("setFeeExemption", smartcontract.VoidType,
+			manifest.NewParameter("contract", smartcontract.Hash160Type),
+			manifest.NewParameter("method", smartcontract.StringType),
+			manifest.NewParameter("discount", smartcontract.IntegerType))
+		md = newMethodAndPrice(p.setFeeExemption, 1<<15, callflag.States)
+		p.AddMethod(md, desc)
+	}
+
 	return p
 }
 
@@ -183,6 +218,9 @@ func (p *Policy) Initialize(ic *interop.Context, hf *config.Hardfork, newMD *int
 		attributeFee:       map[transaction.AttrType]uint32{},
 		blockedAccounts:    make([]util.Uint160, 0),
 	}
+	if p.feeExemptionsEnabled {
+		cache.feeExemptions = make(map[feeExemptionKey]uint8)
+	}
 	if p.p2pSigExtensionsEnabled {
 		setIntWithKey(p.ID, ic.DAO, []byte{attributeFeePrefix, byte(transaction.NotaryAssistedT)}, defaultNotaryAssistedFee)
 		cache.attributeFee[transaction.NotaryAssistedT] = defaultNotaryAssistedFee
@@ -241,6 +279,32 @@ func (p *Policy) fillCacheFromDAO(cache *PolicyCache, d *dao.Simple) error {
 	if fErr != nil {
 		return fmt.Errorf("failed to initialize attribute fees: %w", fErr)
 	}
+
+	if !p.feeExemptionsEnabled {
+		return nil
+	}
+	cache.feeExemptions = make(map[feeExemptionKey]uint8)
+	d.Seek(p.ID, storage.SeekRange{Prefix: []byte{feeExemptionPrefix}}, func(k, v []byte) bool {
+		if len(k) <= util.Uint160Size {
+			fErr = fmt.Errorf("unexpected fee exemption key len %d (%s)", len(k), hex.EncodeToString(k))
+			return false
+		}
+		hash, err := util.Uint160DecodeBytesBE(k[:util.Uint160Size])
+		if err != nil {
+			fErr = fmt.Errorf("failed to decode exempted contract hash: %w", err)
+			return false
+		}
+		value := bigint.FromBytes(v)
+		if value == nil {
+			fErr = fmt.Errorf("unexpected fee exemption value format: key=%s, value=%s", hex.EncodeToString(k), hex.EncodeToString(v))
+			return false
+		}
+		cache.feeExemptions[feeExemptionKey{hash: hash, method: string(k[util.Uint160Size:])}] = uint8(value.Int64())
+		return true
+	})
+	if fErr != nil {
+		return fmt.Errorf("failed to initialize fee exemptions: %w", fErr)
+	}
 	return nil
 }
 
@@ -454,6 +518,70 @@ func (p *Policy) unblockAccount(ic *interop.Context, args []stackitem.Item) stac
 	return stackitem.NewBool(true)
 }
 
+// getFeeExemption is a Policy contract method that returns system fee discount
+// (in percents) for the given contract method, it's zero if there is none.
+func (p *Policy) getFeeExemption(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	hash := toUint160(args[0])
+	method := toString(args[1])
+	return stackitem.NewBigInteger(big.NewInt(int64(p.GetFeeExemption(ic.DAO, hash, method))))
+}
+
+// GetFeeExemption returns system fee discount (in percents) for the given
+// contract method, it's zero if there is none or if fee exemptions extension is
+// disabled. Like IsBlocked, it falls back to the DB when Policy cache is not
+// available yet.
+func (p *Policy) GetFeeExemption(d *dao.Simple, hash util.Uint160, method string) uint8 {
+	if !p.feeExemptionsEnabled {
+		return 0
+	}
+	cache := d.GetROCache(p.ID)
+	if cache == nil {
+		si := d.GetStorageItem(p.ID, makeFeeExemptionKey(hash, method))
+		if si == nil {
+			return 0
+		}
+		return uint8(bigint.FromBytes(si).Int64())
+	}
+	return cache.(*PolicyCache).feeExemptions[feeExemptionKey{hash: hash, method: method}]
+}
+
+// setFeeExemption is a Policy contract method that sets system fee discount
+// (in percents) for the given contract method, zero discount removes the
+// exemption.
+func (p *Policy) setFeeExemption(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	hash := toUint160(args[0])
+	method := toString(args[1])
+	discount := toUint8(args[2])
+	if len(method) == 0 || len(method) > maxFeeExemptionMethodLen {
+		panic(fmt.Errorf("method name length must be between 1 and %d", maxFeeExemptionMethodLen))
+	}
+	if discount > vm.MaxFeeDiscount {
+		panic(fmt.Errorf("discount must be between 0 and %d", vm.MaxFeeDiscount))
+	}
+	if !p.NEO.checkCommittee(ic) {
+		panic("invalid committee signature")
+	}
+	var (
+		key   = makeFeeExemptionKey(hash, method)
+		cache = ic.DAO.GetRWCache(p.ID).(*PolicyCache)
+	)
+	if discount == 0 {
+		ic.DAO.DeleteStorageItem(p.ID, key)
+		delete(cache.feeExemptions, feeExemptionKey{hash: hash, method: method})
+	} else {
+		setIntWithKey(p.ID, ic.DAO, key, int64(discount))
+		cache.feeExemptions[feeExemptionKey{hash: hash, method: method}] = discount
+	}
+	return stackitem.Null{}
+}
+
+func makeFeeExemptionKey(hash util.Uint160, method string) []byte {
+	key := make([]byte, 0, 1+util.Uint160Size+len(method))
+	key = append(key, feeExemptionPrefix)
+	key = append(key, hash.BytesBE()...)
+	return append(key, method...)
+}
+
 // CheckPolicy checks whether a transaction conforms to the current policy restrictions,
 // like not being signed by a blocked account or not exceeding the block-level system
 // fee limit.
@@ -38,6 +38,14 @@ var (
 			Namespace: "neogo",
 		},
 	)
+	// gcBacklog prometheus metric.
+	gcBacklog = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Number of persisted blocks old data removal wasn't yet run for",
+			Name:      "gc_backlog",
+			Namespace: "neogo",
+		},
+	)
 	// mempoolUnsortedTx prometheus metric.
 	mempoolUnsortedTx = prometheus.NewGauge(
 		prometheus.GaugeOpts{
@@ -54,6 +62,7 @@ func init() {
 		persistedHeight,
 		estimatedPersistVelocity,
 		headerHeight,
+		gcBacklog,
 		mempoolUnsortedTx,
 	)
 }
@@ -70,6 +79,10 @@ func updateHeaderHeightMetric(hHeight uint32) {
 	headerHeight.Set(float64(hHeight))
 }
 
+func updateGCBacklogMetric(blocks uint32) {
+	gcBacklog.Set(float64(blocks))
+}
+
 func updateBlockHeightMetric(bHeight uint32) {
 	blockHeight.Set(float64(bHeight))
 }
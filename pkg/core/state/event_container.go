@@ -0,0 +1,58 @@
+package state
+
+import (
+	"encoding/json"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// EventContainer is an entry of the event container index, it describes a
+// container (transaction or block) that emitted some contract event.
+type EventContainer struct {
+	// BlockIndex is the index of the block the container belongs to.
+	BlockIndex uint32
+	// Container is the transaction (or block for OnPersist/PostPersist
+	// triggers) hash.
+	Container util.Uint256
+	// Trigger is the trigger of the execution that emitted the event.
+	Trigger trigger.Type
+	// Notifications is the number of notifications of the event emitted by
+	// the container's execution.
+	Notifications uint32
+}
+
+// eventContainerAux is an auxiliary struct for EventContainer JSON marshalling.
+type eventContainerAux struct {
+	BlockIndex    uint32       `json:"blockindex"`
+	Container     util.Uint256 `json:"container"`
+	Trigger       string       `json:"trigger"`
+	Notifications uint32       `json:"notifications"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (c EventContainer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&eventContainerAux{
+		BlockIndex:    c.BlockIndex,
+		Container:     c.Container,
+		Trigger:       c.Trigger.String(),
+		Notifications: c.Notifications,
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (c *EventContainer) UnmarshalJSON(data []byte) error {
+	aux := new(eventContainerAux)
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	trig, err := trigger.FromString(aux.Trigger)
+	if err != nil {
+		return err
+	}
+	c.BlockIndex = aux.BlockIndex
+	c.Container = aux.Container
+	c.Trigger = trig
+	c.Notifications = aux.Notifications
+	return nil
+}
@@ -0,0 +1,22 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventContainer_MarshalUnmarshalJSON(t *testing.T) {
+	c := &EventContainer{
+		BlockIndex:    123,
+		Container:     random.Uint256(),
+		Trigger:       trigger.PostPersist,
+		Notifications: 2,
+	}
+	testserdes.MarshalUnmarshalJSON(t, c, new(EventContainer))
+
+	require.Error(t, new(EventContainer).UnmarshalJSON([]byte(`{"trigger":"Unknown"}`)))
+}
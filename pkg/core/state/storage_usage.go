@@ -0,0 +1,34 @@
+package state
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/io"
+)
+
+// StorageUsage contains contract storage usage statistics.
+type StorageUsage struct {
+	// Keys is the number of storage items.
+	Keys uint64 `json:"keys"`
+	// KeysSize is the total size of storage item keys (without contract ID).
+	KeysSize uint64 `json:"keyssize"`
+	// ValuesSize is the total size of storage item values.
+	ValuesSize uint64 `json:"valuessize"`
+	// LastModified is the index of the last block that changed contract
+	// storage.
+	LastModified uint32 `json:"lastmodified"`
+}
+
+// DecodeBinary implements the io.Serializable interface.
+func (u *StorageUsage) DecodeBinary(r *io.BinReader) {
+	u.Keys = r.ReadU64LE()
+	u.KeysSize = r.ReadU64LE()
+	u.ValuesSize = r.ReadU64LE()
+	u.LastModified = r.ReadU32LE()
+}
+
+// EncodeBinary implements the io.Serializable interface.
+func (u *StorageUsage) EncodeBinary(w *io.BinWriter) {
+	w.WriteU64LE(u.Keys)
+	w.WriteU64LE(u.KeysSize)
+	w.WriteU64LE(u.ValuesSize)
+	w.WriteU32LE(u.LastModified)
+}
@@ -0,0 +1,17 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+)
+
+func TestStorageUsageSerializable(t *testing.T) {
+	u := &StorageUsage{
+		Keys:         3,
+		KeysSize:     42,
+		ValuesSize:   100500,
+		LastModified: 123,
+	}
+	testserdes.EncodeDecodeBinary(t, u, new(StorageUsage))
+}
@@ -45,6 +45,26 @@ type NEP11Transfer struct {
 	ID []byte
 }
 
+// TransferFilter specifies properties transfers are filtered by, nil fields
+// match any value.
+type TransferFilter struct {
+	// Asset is a token contract ID.
+	Asset *int32
+	// Counterparty is the address of the other side of the transfer.
+	Counterparty *util.Uint160
+}
+
+// IsEmpty returns true if the filter matches any transfer.
+func (f TransferFilter) IsEmpty() bool {
+	return f.Asset == nil && f.Counterparty == nil
+}
+
+// Matches checks whether the transfer matches the filter.
+func (f TransferFilter) Matches(t *NEP17Transfer) bool {
+	return (f.Asset == nil || *f.Asset == t.Asset) &&
+		(f.Counterparty == nil || f.Counterparty.Equals(t.Counterparty))
+}
+
 // TokenTransferInfo stores a map of the contract IDs to the balance's last updated
 // block trackers along with the information about NEP-17 and NEP-11 transfer batch.
 type TokenTransferInfo struct {
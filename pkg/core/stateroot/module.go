@@ -32,9 +32,16 @@ type (
 		network  netmode.Magic
 		srInHead bool
 		mode     mpt.TrieMode
-		mpt      *mpt.Trie
-		verifier VerifierFunc
-		log      *zap.Logger
+		// keepRoots is the number of the latest state roots kept in the
+		// storage when only the latest state is stored, see
+		// KeepRecentStateRoots setting.
+		keepRoots uint32
+		mpt       *mpt.Trie
+		// nodeCache is the decoded MPT node cache shared by all tries
+		// of the module, nil if disabled.
+		nodeCache *mpt.NodeCache
+		verifier  VerifierFunc
+		log       *zap.Logger
 
 		currentLocal    atomic.Value
 		localHeight     atomic.Uint32
@@ -56,27 +63,49 @@ type (
 
 // NewModule returns new instance of stateroot module.
 func NewModule(cfg config.Blockchain, verif VerifierFunc, log *zap.Logger, s *storage.MemCachedStore) *Module {
-	var mode mpt.TrieMode
+	var (
+		mode      mpt.TrieMode
+		keepRoots uint32
+	)
 	if cfg.Ledger.KeepOnlyLatestState {
 		mode |= mpt.ModeLatest
+		if cfg.Ledger.KeepRecentStateRoots > 0 {
+			// Unreferenced nodes are marked as inactive and removed
+			// when they're no longer needed for recent roots.
+			mode |= mpt.ModeGC
+			keepRoots = cfg.Ledger.KeepRecentStateRoots
+		}
 	}
 	if cfg.Ledger.RemoveUntraceableBlocks {
 		mode |= mpt.ModeGC
 	}
+	var nodeCache *mpt.NodeCache
+	if mode == mpt.ModeAll {
+		nodeCache = mpt.NewNodeCache(cfg.Ledger.MPTNodeCacheSize * 1024 * 1024)
+	}
 	return &Module{
-		network:  cfg.Magic,
-		srInHead: cfg.StateRootInHeader,
-		mode:     mode,
-		verifier: verif,
-		log:      log,
-		Store:    s,
+		network:   cfg.Magic,
+		srInHead:  cfg.StateRootInHeader,
+		mode:      mode,
+		keepRoots: keepRoots,
+		nodeCache: nodeCache,
+		verifier:  verif,
+		log:       log,
+		Store:     s,
 	}
 }
 
+// newTrie creates a new MPT using the node cache of the module.
+func (s *Module) newTrie(root mpt.Node, mode mpt.TrieMode, store *storage.MemCachedStore) *mpt.Trie {
+	tr := mpt.NewTrie(root, mode, store)
+	tr.SetNodeCache(s.nodeCache)
+	return tr
+}
+
 // GetState returns value at the specified key fom the MPT with the specified root.
 func (s *Module) GetState(root util.Uint256, key []byte) ([]byte, error) {
 	// Allow accessing old values, it's RO thing.
-	tr := mpt.NewTrie(mpt.NewHashNode(root), s.mode&^mpt.ModeGCFlag, storage.NewMemCachedStore(s.Store))
+	tr := s.newTrie(mpt.NewHashNode(root), s.mode&^mpt.ModeGCFlag, storage.NewMemCachedStore(s.Store))
 	return tr.Get(key)
 }
 
@@ -89,7 +118,7 @@ func (s *Module) GetState(root util.Uint256, key []byte) ([]byte, error) {
 // element) mpt.ErrNotFound is returned.
 func (s *Module) FindStates(root util.Uint256, prefix, start []byte, maxNum int) ([]storage.KeyValue, error) {
 	// Allow accessing old values, it's RO thing.
-	tr := mpt.NewTrie(mpt.NewHashNode(root), s.mode&^mpt.ModeGCFlag, storage.NewMemCachedStore(s.Store))
+	tr := s.newTrie(mpt.NewHashNode(root), s.mode&^mpt.ModeGCFlag, storage.NewMemCachedStore(s.Store))
 	return tr.Find(prefix, start, maxNum)
 }
 
@@ -104,6 +133,7 @@ func (s *Module) FindStates(root util.Uint256, prefix, start []byte, maxNum int)
 func (s *Module) SeekStates(root util.Uint256, prefix []byte, cont func(k, v []byte) bool) {
 	// Allow accessing old values, it's RO thing.
 	store := mpt.NewTrieStore(root, s.mode&^mpt.ModeGCFlag, storage.NewMemCachedStore(s.Store))
+	store.SetNodeCache(s.nodeCache)
 
 	// Tiny hack to satisfy TrieStore with the given prefix. This
 	// storage.STStorage prefix is a stub that will be stripped by the
@@ -122,7 +152,7 @@ func (s *Module) SeekStates(root util.Uint256, prefix []byte, cont func(k, v []b
 // GetStateProof returns proof of having key in the MPT with the specified root.
 func (s *Module) GetStateProof(root util.Uint256, key []byte) ([][]byte, error) {
 	// Allow accessing old values, it's RO thing.
-	tr := mpt.NewTrie(mpt.NewHashNode(root), s.mode&^mpt.ModeGCFlag, storage.NewMemCachedStore(s.Store))
+	tr := s.newTrie(mpt.NewHashNode(root), s.mode&^mpt.ModeGCFlag, storage.NewMemCachedStore(s.Store))
 	return tr.GetProof(key)
 }
 
@@ -184,7 +214,7 @@ func (s *Module) Init(height uint32) error {
 	}
 
 	if s.mpt == nil {
-		s.mpt = mpt.NewTrie(nil, s.mode, s.Store)
+		s.mpt = s.newTrie(nil, s.mode, s.Store)
 	}
 	r, err := s.getStateRoot(makeStateRootKey(height))
 	if err != nil {
@@ -196,7 +226,7 @@ func (s *Module) Init(height uint32) error {
 	}
 	s.currentLocal.Store(r.Root)
 	s.localHeight.Store(r.Index)
-	s.mpt = mpt.NewTrie(mpt.NewHashNode(r.Root), s.mode, s.Store)
+	s.mpt = s.newTrie(mpt.NewHashNode(r.Root), s.mode, s.Store)
 	return nil
 }
 
@@ -233,7 +263,7 @@ func (s *Module) JumpToState(sr *state.MPTRoot) {
 
 	s.currentLocal.Store(sr.Root)
 	s.localHeight.Store(sr.Index)
-	s.mpt = mpt.NewTrie(mpt.NewHashNode(sr.Root), s.mode, s.Store)
+	s.mpt = s.newTrie(mpt.NewHashNode(sr.Root), s.mode, s.Store)
 }
 
 // ResetState resets MPT state to the given height.
@@ -291,7 +321,7 @@ func (s *Module) ResetState(height uint32, cache *storage.MemCachedStore) error
 
 	s.currentLocal.Store(sr.Root)
 	s.localHeight.Store(sr.Index)
-	s.mpt = mpt.NewTrie(mpt.NewHashNode(sr.Root), s.mode, s.Store)
+	s.mpt = s.newTrie(mpt.NewHashNode(sr.Root), s.mode, s.Store)
 
 	// Do not reset MPT nodes, leave the trie state itself as is.
 	return nil
@@ -339,7 +369,11 @@ func (s *Module) AddMPTBatch(index uint32, b mpt.Batch, cache *storage.MemCached
 	if _, err := mpt.PutBatch(b); err != nil {
 		return nil, nil, err
 	}
-	mpt.Flush(index)
+	if s.keepRoots == 0 {
+		mpt.Flush(index)
+	} else {
+		s.flushRecent(&mpt, index, cache)
+	}
 	sr := &state.MPTRoot{
 		Index: index,
 		Root:  mpt.StateRoot(),
@@ -348,6 +382,48 @@ func (s *Module) AddMPTBatch(index uint32, b mpt.Batch, cache *storage.MemCached
 	return &mpt, sr, nil
 }
 
+// flushRecent flushes the trie keeping nodes deactivated at the given height
+// in the storage and removes the ones that are no longer needed for the
+// s.keepRoots latest state roots.
+func (s *Module) flushRecent(tr *mpt.Trie, index uint32, cache *storage.MemCachedStore) {
+	stale := tr.FlushDeactivated(index)
+	if len(stale) != 0 {
+		data := make([]byte, 0, len(stale)*util.Uint256Size)
+		for _, h := range stale {
+			data = append(data, h[:]...)
+		}
+		cache.Put(makeStaleNodesKey(index), data)
+	}
+	if index+1 < s.keepRoots {
+		return
+	}
+	// Nodes deactivated at this height belong to the root that is not kept anymore.
+	old := index + 1 - s.keepRoots
+	key := makeStaleNodesKey(old)
+	data, err := cache.Get(key)
+	if err != nil {
+		return
+	}
+	nodeKey := make([]byte, 1+util.Uint256Size)
+	nodeKey[0] = byte(storage.DataMPT)
+	for i := 0; i+util.Uint256Size <= len(data); i += util.Uint256Size {
+		copy(nodeKey[1:], data[i:])
+		v, err := cache.Get(nodeKey)
+		// Node can be reactivated or removed by GC since then.
+		if err == nil && !mpt.IsActiveValue(v) && binary.LittleEndian.Uint32(v[len(v)-4:]) == old {
+			cache.Delete(nodeKey)
+		}
+	}
+	cache.Delete(key)
+}
+
+// KeepRecentStateRoots returns the number of the latest state roots which
+// states are kept in the storage when KeepOnlyLatestState setting is enabled
+// (zero means that only the current state is available).
+func (s *Module) KeepRecentStateRoots() uint32 {
+	return s.keepRoots
+}
+
 // UpdateCurrentLocal updates local caches using provided state root.
 func (s *Module) UpdateCurrentLocal(mpt *mpt.Trie, sr *state.MPTRoot) {
 	s.mpt = mpt
@@ -19,6 +19,7 @@ var (
 const (
 	prefixLocal     = 0x02
 	prefixValidated = 0x03
+	prefixStale     = 0x04
 )
 
 func (s *Module) addLocalStateRoot(store *storage.MemCachedStore, sr *state.MPTRoot) {
@@ -48,6 +49,16 @@ func (s *Module) getStateRoot(key []byte) (*state.MPTRoot, error) {
 	return sr, r.Err
 }
 
+// makeStaleNodesKey returns the key of the list of MPT nodes deactivated at
+// the given height.
+func makeStaleNodesKey(index uint32) []byte {
+	key := make([]byte, 6)
+	key[0] = byte(storage.DataMPTAux)
+	key[1] = prefixStale
+	binary.BigEndian.PutUint32(key[2:], index)
+	return key
+}
+
 func makeStateRootKey(index uint32) []byte {
 	key := make([]byte, 5)
 	key[0] = byte(storage.DataMPTAux)
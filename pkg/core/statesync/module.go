@@ -10,6 +10,13 @@ includes the following stages:
 2. Fetching MPT nodes for height P stating from the corresponding state root.
 3. Fetching blocks starting from height P-MaxTraceableBlocks (or 0) up to P.
 
+The state root for height P is taken from the header P+1 (StateRootInHeader
+is required for state exchange), so it's as trusted as the header chain signed
+by consensus nodes and every MPT node fetched is checked against it. MPT nodes
+are fetched from multiple peers in parallel, every batch of nodes returned by
+RequestMPTNodesBatch is meant to be requested from a single peer and it's not
+returned again until MPTRequestTimeout expires.
+
 Steps 2 and 3 are being performed in parallel. Once all the data are collected
 and stored in the db, an atomic state jump is occurred to the state sync point P.
 Further node operation process is performed using standard sync mechanism until
@@ -22,6 +29,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
@@ -60,6 +68,10 @@ const (
 	blocksSynced
 )
 
+// MPTRequestTimeout is the time MPT nodes returned by RequestMPTNodesBatch are
+// expected to be received in. They can be requested again after it.
+const MPTRequestTimeout = 10 * time.Second
+
 // Ledger is the interface required from Blockchain for Module to operate.
 type Ledger interface {
 	AddHeaders(...*block.Header) error
@@ -508,3 +520,15 @@ func (s *Module) GetUnknownMPTNodesBatch(limit int) []util.Uint256 {
 
 	return s.mptpool.GetBatch(limit)
 }
+
+// RequestMPTNodesBatch returns set of currently unknown MPT nodes (`limit` at
+// max) that are not being requested by the previous RequestMPTNodesBatch calls
+// and marks them as requested, so that different nodes can be fetched from
+// different peers in parallel. Nodes that are not received during
+// MPTRequestTimeout are returned again.
+func (s *Module) RequestMPTNodesBatch(limit int) []util.Uint256 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.mptpool.GetUnrequestedBatch(limit, MPTRequestTimeout)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"slices"
 	"sync"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/util"
 )
@@ -13,12 +14,16 @@ import (
 type Pool struct {
 	lock   sync.RWMutex
 	hashes map[util.Uint256][][]byte
+	// requested contains the time of the last request for nodes that are
+	// being fetched.
+	requested map[util.Uint256]time.Time
 }
 
 // NewPool returns new MPT node hashes pool.
 func NewPool() *Pool {
 	return &Pool{
-		hashes: make(map[util.Uint256][][]byte),
+		hashes:    make(map[util.Uint256][][]byte),
+		requested: make(map[util.Uint256]time.Time),
 	}
 }
 
@@ -69,12 +74,38 @@ func (mp *Pool) GetBatch(limit int) []util.Uint256 {
 	return result
 }
 
+// GetUnrequestedBatch returns set of unknown MPT nodes hashes (`limit` at max)
+// that weren't returned by GetUnrequestedBatch during the last `timeout` and
+// marks them as requested. It allows to fetch different nodes from different
+// peers in parallel and to request nodes again if they're not received in time.
+func (mp *Pool) GetUnrequestedBatch(limit int, timeout time.Duration) []util.Uint256 {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+
+	var (
+		now    = time.Now()
+		result = make([]util.Uint256, 0, min(limit, len(mp.hashes)))
+	)
+	for h := range mp.hashes {
+		if len(result) == limit {
+			break
+		}
+		if t, ok := mp.requested[h]; ok && now.Sub(t) < timeout {
+			continue
+		}
+		mp.requested[h] = now
+		result = append(result, h)
+	}
+	return result
+}
+
 // Remove removes MPT node from the pool by the specified hash.
 func (mp *Pool) Remove(hash util.Uint256) {
 	mp.lock.Lock()
 	defer mp.lock.Unlock()
 
 	delete(mp.hashes, hash)
+	delete(mp.requested, hash)
 }
 
 // Add adds path to the set of paths for the specified node.
@@ -100,6 +131,7 @@ func (mp *Pool) Update(remove map[util.Uint256][][]byte, add map[util.Uint256][]
 		}
 		if len(old) == 0 {
 			delete(mp.hashes, h)
+			delete(mp.requested, h)
 		} else {
 			mp.hashes[h] = old
 		}
@@ -3,6 +3,7 @@ package statesync
 import (
 	"encoding/hex"
 	"testing"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -104,6 +105,31 @@ func TestPool_GetBatch(t *testing.T) {
 	})
 }
 
+func TestPool_GetUnrequestedBatch(t *testing.T) {
+	mp := NewPool()
+	for range 5 {
+		mp.Add(random.Uint256(), []byte{0x01})
+	}
+
+	first := mp.GetUnrequestedBatch(3, time.Hour)
+	require.Len(t, first, 3)
+	second := mp.GetUnrequestedBatch(3, time.Hour)
+	require.Len(t, second, 2)
+	for _, h := range second {
+		require.NotContains(t, first, h)
+	}
+	require.Empty(t, mp.GetUnrequestedBatch(3, time.Hour))
+
+	// Received nodes are not requested anymore, others can be requested again
+	// after timeout.
+	mp.Remove(first[0])
+	require.Len(t, mp.GetUnrequestedBatch(5, 0), 4)
+
+	// Node that is added again after removal is not considered to be requested.
+	mp.Add(first[0], []byte{0x01})
+	require.Equal(t, []util.Uint256{first[0]}, mp.GetUnrequestedBatch(5, time.Hour))
+}
+
 func TestPool_UpdateUsingSliceFromPool(t *testing.T) {
 	mp := NewPool()
 	p1, _ := hex.DecodeString("0f0a0f0f0f0f0f0f0104020b02080c0a06050e070b050404060206060d07080602030b04040b050e040406030f0708060c05")
@@ -0,0 +1,232 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
+)
+
+// Value encodings used by CompressedStore, every stored value is prefixed
+// with one of these.
+const (
+	valueRaw byte = iota
+	valueSnappy
+	valueZstd
+)
+
+// minCompressedSize is the minimum value size CompressedStore tries to
+// compress, smaller values are stored as is.
+const minCompressedSize = 64
+
+// compressionMarker is the SYSCompression value of DBs with compressed
+// values.
+var compressionMarker = []byte{1}
+
+// ErrCompressionMismatch is returned when DB is opened with compression
+// settings incompatible with the way it was created.
+var ErrCompressionMismatch = errors.New("DB compression mismatch")
+
+// CompressedStore is a Store wrapper that compresses values before passing
+// them to the underlying Store and decompresses them on retrieval. Every
+// value is prefixed with a byte specifying its encoding, so the algorithm can
+// be changed for an existing DB (old values are still readable, new ones are
+// compressed with the new algorithm). Values that don't become smaller after
+// compression are stored as is. DBs with compressed values are marked with
+// SYSCompression key, so that they can't be opened without compression and
+// vice versa.
+type CompressedStore struct {
+	Store
+
+	encoding byte
+	enc      *zstd.Encoder
+	dec      *zstd.Decoder
+}
+
+// NewCompressedStore creates a CompressedStore using the given Store and
+// compression algorithm (one of dbconfig.Compression* constants except for
+// dbconfig.CompressionNone). It returns ErrCompressionMismatch if the Store
+// contains uncompressed data.
+func NewCompressedStore(s Store, algo string) (*CompressedStore, error) {
+	cs := &CompressedStore{Store: s}
+	switch algo {
+	case dbconfig.CompressionSnappy:
+		cs.encoding = valueSnappy
+	case dbconfig.CompressionZstd:
+		cs.encoding = valueZstd
+	default:
+		return nil, fmt.Errorf("unknown compression algorithm %q", algo)
+	}
+	marked, empty, err := compressionState(s)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		if !empty {
+			return nil, fmt.Errorf("%w: DB contains uncompressed data, it must be resynchronized to enable compression", ErrCompressionMismatch)
+		}
+		err = s.PutChangeSet(map[string][]byte{string([]byte{byte(SYSCompression)}): compressionMarker}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to mark DB as compressed: %w", err)
+		}
+	}
+	cs.enc, err = zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	cs.dec, err = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+	if err != nil {
+		_ = cs.enc.Close()
+		return nil, err
+	}
+	return cs, nil
+}
+
+// compressionState checks whether the Store is marked as compressed and
+// whether it's empty (doesn't contain SYSVersion key written on chain
+// initialization).
+func compressionState(s Store) (bool, bool, error) {
+	v, err := s.Get([]byte{byte(SYSCompression)})
+	if err == nil {
+		if !bytes.Equal(v, compressionMarker) {
+			return false, false, fmt.Errorf("%w: unknown compression marker %x", ErrCompressionMismatch, v)
+		}
+		return true, false, nil
+	}
+	if !errors.Is(err, ErrKeyNotFound) {
+		return false, false, err
+	}
+	_, err = s.Get([]byte{byte(SYSVersion)})
+	if errors.Is(err, ErrKeyNotFound) {
+		return false, true, nil
+	}
+	return false, false, err
+}
+
+// checkUncompressed returns ErrCompressionMismatch if the Store is marked as
+// compressed.
+func checkUncompressed(s Store) error {
+	marked, _, err := compressionState(s)
+	if err != nil {
+		return err
+	}
+	if marked {
+		return fmt.Errorf("%w: DB contains compressed data, compression must be enabled", ErrCompressionMismatch)
+	}
+	return nil
+}
+
+// Get implements the Store interface.
+func (s *CompressedStore) Get(key []byte) ([]byte, error) {
+	v, err := s.Store.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return s.decode(v)
+}
+
+// PutChangeSet implements the Store interface.
+func (s *CompressedStore) PutChangeSet(puts map[string][]byte, stor map[string][]byte) error {
+	return s.Store.PutChangeSet(s.encodeMap(puts), s.encodeMap(stor))
+}
+
+// Seek implements the Store interface. Iteration stops at the first value
+// that can't be decoded since there is no way to return an error from it, Get
+// can be used to retrieve the error for this key.
+func (s *CompressedStore) Seek(rng SeekRange, f func(k, v []byte) bool) {
+	s.Store.Seek(rng, func(k, v []byte) bool {
+		if isCompressionMarker(k) {
+			return true
+		}
+		dv, err := s.decode(v)
+		if err != nil {
+			return false
+		}
+		return f(k, dv)
+	})
+}
+
+// SeekGC implements the Store interface.
+func (s *CompressedStore) SeekGC(rng SeekRange, keep func(k, v []byte) bool) error {
+	var decErr error
+	err := s.Store.SeekGC(rng, func(k, v []byte) bool {
+		if isCompressionMarker(k) || decErr != nil {
+			return true
+		}
+		dv, err := s.decode(v)
+		if err != nil {
+			decErr = fmt.Errorf("failed to decode value of %x: %w", k, err)
+			return true
+		}
+		return keep(k, dv)
+	})
+	return errors.Join(decErr, err)
+}
+
+// Close implements the Store interface.
+func (s *CompressedStore) Close() error {
+	s.dec.Close()
+	return errors.Join(s.enc.Close(), s.Store.Close())
+}
+
+func isCompressionMarker(k []byte) bool {
+	return len(k) == 1 && k[0] == byte(SYSCompression)
+}
+
+// encodeMap returns a copy of the changeset with encoded values.
+func (s *CompressedStore) encodeMap(m map[string][]byte) map[string][]byte {
+	if m == nil {
+		return nil
+	}
+	res := make(map[string][]byte, len(m))
+	for k, v := range m {
+		if v != nil {
+			v = s.encode(v)
+		}
+		res[k] = v
+	}
+	return res
+}
+
+// encode returns the value prefixed with its encoding.
+func (s *CompressedStore) encode(v []byte) []byte {
+	if len(v) >= minCompressedSize {
+		var res []byte
+		switch s.encoding {
+		case valueSnappy:
+			buf := make([]byte, 1+snappy.MaxEncodedLen(len(v)))
+			res = buf[:1+len(snappy.Encode(buf[1:], v))]
+		case valueZstd:
+			res = s.enc.EncodeAll(v, make([]byte, 1, 1+len(v)))
+		}
+		if len(res) < len(v)+1 {
+			res[0] = s.encoding
+			return res
+		}
+	}
+	res := make([]byte, 1+len(v))
+	res[0] = valueRaw
+	copy(res[1:], v)
+	return res
+}
+
+// decode returns the value without encoding prefix decompressing it if
+// needed.
+func (s *CompressedStore) decode(v []byte) ([]byte, error) {
+	if len(v) == 0 {
+		return nil, errors.New("missing value encoding")
+	}
+	switch v[0] {
+	case valueRaw:
+		return v[1:], nil
+	case valueSnappy:
+		return snappy.Decode(nil, v[1:])
+	case valueZstd:
+		return s.dec.DecodeAll(v[1:], nil)
+	default:
+		return nil, fmt.Errorf("unknown value encoding %d", v[0])
+	}
+}
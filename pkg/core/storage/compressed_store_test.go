@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func newCompressedStoreForTesting(t testing.TB) Store {
+	cs, err := NewCompressedStore(newLevelDBForTesting(t), dbconfig.CompressionZstd)
+	require.NoError(t, err)
+	return cs
+}
+
+func TestCompressedStore(t *testing.T) {
+	var (
+		cfg = dbconfig.DBConfiguration{
+			Type:           dbconfig.LevelDB,
+			LevelDBOptions: dbconfig.LevelDBOptions{DataDirectoryPath: filepath.Join(t.TempDir(), "db")},
+		}
+		big     = bytes.Repeat([]byte{1, 2, 3, 4}, 1024)
+		random  = []byte{0x8f, 0x1b, 0x33, 0xd0}
+		version = string([]byte{byte(SYSVersion)})
+	)
+	open := func(t *testing.T, algo string) Store {
+		cfg.Compression = algo
+		s, err := NewStore(cfg)
+		require.NoError(t, err)
+		return s
+	}
+	check := func(t *testing.T, s Store, key string, expected []byte) {
+		v, err := s.Get([]byte(key))
+		require.NoError(t, err)
+		require.Equal(t, expected, v)
+	}
+
+	s := open(t, dbconfig.CompressionSnappy)
+	require.NoError(t, s.PutChangeSet(map[string][]byte{version: {1}, "big": big, "small": random, "empty": {}}, nil))
+	require.NoError(t, s.Close())
+
+	// Raw data is prefixed and compressed.
+	ldb, err := NewLevelDBStore(cfg.LevelDBOptions)
+	require.NoError(t, err)
+	raw, err := ldb.Get([]byte("big"))
+	require.NoError(t, err)
+	require.Equal(t, valueSnappy, raw[0])
+	require.Less(t, len(raw), len(big))
+	raw, err = ldb.Get([]byte("small"))
+	require.NoError(t, err)
+	require.Equal(t, append([]byte{valueRaw}, random...), raw)
+	require.NoError(t, ldb.Close())
+
+	t.Run("no compression", func(t *testing.T) {
+		cfg.Compression = dbconfig.CompressionNone
+		_, err := NewStore(cfg)
+		require.ErrorIs(t, err, ErrCompressionMismatch)
+	})
+
+	// Algorithm can be changed.
+	s = open(t, dbconfig.CompressionZstd)
+	check(t, s, "big", big)
+	check(t, s, "empty", []byte{})
+	require.NoError(t, s.PutChangeSet(map[string][]byte{"big2": big}, nil))
+	var keys []string
+	s.Seek(SeekRange{}, func(k, v []byte) bool {
+		keys = append(keys, string(k))
+		return true
+	})
+	require.ElementsMatch(t, []string{version, "big", "big2", "empty", "small"}, keys)
+	require.NoError(t, s.SeekGC(SeekRange{Prefix: []byte("big")}, func(k, v []byte) bool {
+		require.Equal(t, big, v)
+		return string(k) == "big2"
+	}))
+	require.NoError(t, s.Close())
+
+	s = open(t, dbconfig.CompressionSnappy)
+	check(t, s, "big2", big)
+	_, err = s.Get([]byte("big"))
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.NoError(t, s.Close())
+
+	t.Run("broken value", func(t *testing.T) {
+		mem := NewMemoryStore()
+		s, err := NewCompressedStore(mem, dbconfig.CompressionSnappy)
+		require.NoError(t, err)
+		require.NoError(t, s.PutChangeSet(map[string][]byte{"ka": random, "kc": random}, nil))
+		require.NoError(t, mem.PutChangeSet(map[string][]byte{"kb": {valueSnappy, 0xff}}, nil))
+
+		var keys []string
+		require.NotPanics(t, func() {
+			s.Seek(SeekRange{Prefix: []byte("k")}, func(k, v []byte) bool {
+				keys = append(keys, string(k))
+				return true
+			})
+		})
+		require.Equal(t, []string{"ka"}, keys)
+		_, err = s.Get([]byte("kb"))
+		require.Error(t, err)
+		require.Error(t, s.SeekGC(SeekRange{Prefix: []byte("k")}, func(k, v []byte) bool { return true }))
+	})
+
+	t.Run("uncompressed DB", func(t *testing.T) {
+		c := dbconfig.DBConfiguration{Type: dbconfig.InMemoryDB}
+		s, err := NewStore(c)
+		require.NoError(t, err)
+		require.NoError(t, s.PutChangeSet(map[string][]byte{version: {1}}, nil))
+		_, err = NewCompressedStore(s, dbconfig.CompressionZstd)
+		require.ErrorIs(t, err, ErrCompressionMismatch)
+
+		_, err = NewCompressedStore(s, "lzma")
+		require.ErrorContains(t, err, "unknown compression algorithm")
+	})
+}
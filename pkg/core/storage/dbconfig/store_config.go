@@ -3,6 +3,34 @@ Package dbconfig is a micropackage that contains storage DB configuration option
 */
 package dbconfig
 
+import "slices"
+
+// Data kinds that can be stored in separate DB partitions.
+const (
+	// PartitionMPT is MPT nodes and auxiliary MPT data (state roots and
+	// heights).
+	PartitionMPT = "MPT"
+	// PartitionStorage is contract storage items and storage usage statistics.
+	PartitionStorage = "Storage"
+	// PartitionTransfers is NEP-11/NEP-17 transfer logs and token transfer
+	// info.
+	PartitionTransfers = "Transfers"
+	// PartitionIndexes is notification and event container indexes.
+	PartitionIndexes = "Indexes"
+)
+
+// Value compression algorithms.
+const (
+	// CompressionNone disables compression.
+	CompressionNone = ""
+	// CompressionSnappy is Snappy compression, it's fast, but its ratio is
+	// relatively low.
+	CompressionSnappy = "snappy"
+	// CompressionZstd is Zstandard compression, it's slower than Snappy,
+	// but gives better ratio.
+	CompressionZstd = "zstd"
+)
+
 type (
 	// DBConfiguration describes configuration for DB. Supported types:
 	// [LevelDB], [BoltDB] or [InMemoryDB] (not recommended for production usage).
@@ -10,11 +38,34 @@ type (
 		Type           string         `yaml:"Type"`
 		LevelDBOptions LevelDBOptions `yaml:"LevelDBOptions"`
 		BoltDBOptions  BoltDBOptions  `yaml:"BoltDBOptions"`
+		// JournalPath is the journal file path for journaled persist mode,
+		// every changeset is written to it before it's applied to the DB
+		// (see storage.JournaledStore). It's mandatory for Partitions and
+		// can't be used without them.
+		JournalPath string `yaml:"JournalPath"`
+		// Partitions allow to keep some kinds of data in separate DBs, the
+		// main DB stores everything else.
+		Partitions []DBPartition `yaml:"Partitions"`
+		// Compression enables compression of stored values with the given
+		// algorithm (see Compression* constants), it applies to all
+		// partitions.
+		Compression string `yaml:"Compression"`
+	}
+	// DBPartition describes a separate DB for some kinds of data.
+	DBPartition struct {
+		// Data is the list of data kinds (like [PartitionMPT]) stored in the
+		// partition.
+		Data           []string       `yaml:"Data"`
+		Type           string         `yaml:"Type"`
+		LevelDBOptions LevelDBOptions `yaml:"LevelDBOptions"`
+		BoltDBOptions  BoltDBOptions  `yaml:"BoltDBOptions"`
 	}
 	// LevelDBOptions configuration for LevelDB.
 	LevelDBOptions struct {
 		DataDirectoryPath string `yaml:"DataDirectoryPath"`
 		ReadOnly          bool   `yaml:"ReadOnly"`
+		// WriteBufferSize is the size (in megabytes) of LevelDB memtable.
+		WriteBufferSize int `yaml:"WriteBufferSize"`
 	}
 	// BoltDBOptions configuration for BoltDB.
 	BoltDBOptions struct {
@@ -22,3 +73,18 @@ type (
 		ReadOnly bool   `yaml:"ReadOnly"`
 	}
 )
+
+// Equals checks whether two DB configurations are the same.
+func (c DBConfiguration) Equals(o DBConfiguration) bool {
+	return c.Type == o.Type &&
+		c.LevelDBOptions == o.LevelDBOptions &&
+		c.BoltDBOptions == o.BoltDBOptions &&
+		c.JournalPath == o.JournalPath &&
+		c.Compression == o.Compression &&
+		slices.EqualFunc(c.Partitions, o.Partitions, func(a, b DBPartition) bool {
+			return a.Type == b.Type &&
+				a.LevelDBOptions == b.LevelDBOptions &&
+				a.BoltDBOptions == b.BoltDBOptions &&
+				slices.Equal(a.Data, b.Data)
+		})
+}
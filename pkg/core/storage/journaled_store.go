@@ -0,0 +1,201 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/io"
+)
+
+// JournalRecovery is the result of journal check performed when
+// JournaledStore is opened.
+type JournalRecovery byte
+
+const (
+	// JournalOK means that the last journaled changeset is completely applied
+	// to the DB (or there is no journaled changeset at all).
+	JournalOK JournalRecovery = iota
+	// JournalTorn means that the last journal record is incomplete, it was
+	// being written when the node stopped, so the changeset wasn't applied to
+	// the DB and the record is discarded.
+	JournalTorn
+	// JournalReplayed means that the last journaled changeset wasn't
+	// committed, so it might be applied to the DB partially and it was
+	// applied again.
+	JournalReplayed
+)
+
+// journalCommitted is the marker appended to the journal record once its
+// changeset is applied to the DB.
+const journalCommitted = 1
+
+// JournaledStore is a Store wrapper that writes every changeset to the
+// journal file before passing it to the underlying Store and marks it as
+// committed after that. It allows to complete the last changeset after an
+// unclean shutdown if the underlying Store can't apply it atomically (like
+// PartitionedStore). SeekGC changes are passed to the underlying Store
+// directly, they never touch keys of uncommitted changesets and partially
+// performed GC only leaves some garbage in the DB.
+type JournaledStore struct {
+	Store
+
+	lock     sync.Mutex
+	file     *os.File
+	recovery JournalRecovery
+}
+
+// NewJournaledStore creates a JournaledStore using the given Store and journal
+// file path. If the last journaled changeset is not committed, it's applied to
+// the Store again, the result of this check can be retrieved with Recovery.
+func NewJournaledStore(s Store, path string) (*JournaledStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	js := &JournaledStore{Store: s}
+	if len(data) != 0 {
+		js.recovery, err = js.recover(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to recover journaled changeset: %w", err)
+		}
+	}
+	js.file, err = os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	return js, nil
+}
+
+// Recovery returns the result of journal check performed when the store was
+// opened.
+func (s *JournaledStore) Recovery() JournalRecovery {
+	return s.recovery
+}
+
+// PutChangeSet implements the Store interface. The changeset is only passed to
+// the underlying Store after it's journaled.
+func (s *JournaledStore) PutChangeSet(puts map[string][]byte, stor map[string][]byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	w := io.NewBufBinWriter()
+	w.WriteU32LE(0) // Body length placeholder.
+	for _, m := range []map[string][]byte{puts, stor} {
+		w.WriteVarUint(uint64(len(m)))
+		for k, v := range m {
+			w.WriteVarBytes([]byte(k))
+			w.WriteBool(v != nil)
+			w.WriteVarBytes(v)
+		}
+	}
+	if w.Err != nil {
+		return fmt.Errorf("failed to encode journal record: %w", w.Err)
+	}
+	record := w.Bytes()
+	binary.LittleEndian.PutUint32(record, uint32(len(record)-4))
+	recordHash := sha256.Sum256(record[4:])
+	record = append(record, recordHash[:]...)
+	err := s.writeJournal(record)
+	if err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	err = s.Store.PutChangeSet(puts, stor)
+	if err != nil {
+		return err
+	}
+	// The marker is not synced, losing it only leads to reapplying the
+	// changeset on start.
+	_, err = s.file.WriteAt([]byte{journalCommitted}, int64(len(record)))
+	if err != nil {
+		return fmt.Errorf("failed to commit journal record: %w", err)
+	}
+	return nil
+}
+
+// writeJournal replaces journal contents with the given record and syncs the
+// file to disk.
+func (s *JournaledStore) writeJournal(record []byte) error {
+	err := s.file.Truncate(0)
+	if err != nil {
+		return err
+	}
+	_, err = s.file.WriteAt(record, 0)
+	if err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+// recover checks the given journal record and applies its changeset to the
+// underlying Store again if it's not committed.
+func (s *JournaledStore) recover(record []byte) (JournalRecovery, error) {
+	if len(record) < 4 {
+		return JournalTorn, nil
+	}
+	n := uint64(binary.LittleEndian.Uint32(record))
+	if uint64(len(record)) < 4+n+sha256.Size {
+		return JournalTorn, nil
+	}
+	body := record[4 : 4+n]
+	if sha256.Sum256(body) != [sha256.Size]byte(record[4+n:]) {
+		return JournalTorn, nil
+	}
+	if tail := record[4+n+sha256.Size:]; len(tail) != 0 {
+		if len(tail) == 1 && tail[0] == journalCommitted {
+			return JournalOK, nil
+		}
+		return 0, errors.New("malformed journal record: invalid commit marker")
+	}
+
+	var (
+		r    = io.NewBinReaderFromBuf(body)
+		maps [2]map[string][]byte
+	)
+	for i := range maps {
+		l := r.ReadVarUint()
+		maps[i] = make(map[string][]byte)
+		for j := uint64(0); j < l && r.Err == nil; j++ {
+			k := r.ReadVarBytes()
+			exists := r.ReadBool()
+			v := r.ReadVarBytes()
+			if !exists {
+				v = nil
+			} else if v == nil {
+				v = []byte{}
+			}
+			maps[i][string(k)] = v
+		}
+	}
+	if r.Err != nil {
+		return 0, fmt.Errorf("malformed journal record: %w", r.Err)
+	}
+	err := s.Store.PutChangeSet(maps[0], maps[1])
+	if err != nil {
+		return 0, err
+	}
+	return JournalReplayed, nil
+}
+
+// Close implements the Store interface.
+func (s *JournaledStore) Close() error {
+	err := s.file.Close()
+	return errors.Join(err, s.Store.Close())
+}
+
+// String implements the fmt.Stringer interface.
+func (r JournalRecovery) String() string {
+	switch r {
+	case JournalOK:
+		return "ok"
+	case JournalTorn:
+		return "torn journal record discarded"
+	case JournalReplayed:
+		return "uncommitted changeset applied again"
+	default:
+		return fmt.Sprintf("unknown (%d)", byte(r))
+	}
+}
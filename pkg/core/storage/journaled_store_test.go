@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func newJournaledStoreForTesting(t testing.TB) Store {
+	js, err := NewJournaledStore(newLevelDBForTesting(t), filepath.Join(t.TempDir(), "journal"))
+	require.NoError(t, err)
+	return js
+}
+
+func TestJournaledStore(t *testing.T) {
+	var (
+		dir         = t.TempDir()
+		journalPath = filepath.Join(dir, "journal")
+		dbOpts      = dbconfig.LevelDBOptions{DataDirectoryPath: filepath.Join(dir, "db")}
+		stKey       = string([]byte{byte(STStorage), 1})
+	)
+	open := func(t *testing.T, expected JournalRecovery) *JournaledStore {
+		ls, err := NewLevelDBStore(dbOpts)
+		require.NoError(t, err)
+		js, err := NewJournaledStore(ls, journalPath)
+		require.NoError(t, err)
+		require.Equal(t, expected, js.Recovery())
+		return js
+	}
+	check := func(t *testing.T, s Store, key string, expected []byte) {
+		v, err := s.Get([]byte(key))
+		if expected == nil {
+			require.ErrorIs(t, err, ErrKeyNotFound)
+		} else {
+			require.NoError(t, err)
+			require.Equal(t, expected, v)
+		}
+	}
+
+	s := open(t, JournalOK)
+	require.NoError(t, s.PutChangeSet(map[string][]byte{"a": {1}, "b": {2}, "c": {}}, map[string][]byte{stKey: {3}}))
+	require.NoError(t, s.Close())
+
+	// Changeset is committed.
+	s = open(t, JournalOK)
+	check(t, s, "a", []byte{1})
+	check(t, s, "c", []byte{})
+	check(t, s, stKey, []byte{3})
+	require.NoError(t, s.PutChangeSet(map[string][]byte{"a": {4}, "b": nil, "c": nil, "d": {5}}, map[string][]byte{stKey: nil}))
+	journal, err := os.ReadFile(journalPath)
+	require.NoError(t, err)
+	require.Equal(t, byte(journalCommitted), journal[len(journal)-1])
+	journal = journal[:len(journal)-1]
+
+	// GC doesn't affect committed changesets.
+	require.NoError(t, s.SeekGC(SeekRange{}, func(k, v []byte) bool { return string(k) != "d" }))
+	require.NoError(t, s.Close())
+	s = open(t, JournalOK)
+	check(t, s, "d", nil)
+
+	// Uncommitted changeset is applied again.
+	require.NoError(t, s.Store.PutChangeSet(map[string][]byte{"a": {1}, "b": {2}, "c": {}}, map[string][]byte{stKey: {3}}))
+	require.NoError(t, s.Close())
+	require.NoError(t, os.WriteFile(journalPath, journal, 0o644))
+	s = open(t, JournalReplayed)
+	check(t, s, "a", []byte{4})
+	check(t, s, "b", nil)
+	check(t, s, "c", nil)
+	check(t, s, "d", []byte{5})
+	check(t, s, stKey, nil)
+	require.NoError(t, s.Store.PutChangeSet(map[string][]byte{"a": {1}}, nil))
+	require.NoError(t, s.Close())
+
+	// Torn record is discarded.
+	require.NoError(t, os.WriteFile(journalPath, journal[:len(journal)-1], 0o644))
+	s = open(t, JournalTorn)
+	check(t, s, "a", []byte{1})
+	require.NoError(t, s.Close())
+
+	// Journal is reset on open.
+	s = open(t, JournalOK)
+	require.NoError(t, s.Close())
+}
+
+func TestNewStoreJournal(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewStore(dbconfig.DBConfiguration{
+		Type:           dbconfig.LevelDB,
+		LevelDBOptions: dbconfig.LevelDBOptions{DataDirectoryPath: filepath.Join(dir, "db")},
+		JournalPath:    filepath.Join(dir, "journal"),
+	})
+	require.ErrorContains(t, err, "JournalPath can only be used with partitions")
+	require.Nil(t, s)
+	require.NoFileExists(t, filepath.Join(dir, "journal"))
+}
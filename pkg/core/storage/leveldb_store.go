@@ -26,6 +26,9 @@ func NewLevelDBStore(cfg dbconfig.LevelDBOptions) (*LevelDBStore, error) {
 		opts.ReadOnly = true
 		opts.ErrorIfMissing = true
 	}
+	if cfg.WriteBufferSize > 0 {
+		opts.WriteBuffer = cfg.WriteBufferSize * opt.MiB
+	}
 	opts.Filter = filter.NewBloomFilter(10)
 	db, err := leveldb.OpenFile(cfg.DataDirectoryPath, opts)
 	if err != nil {
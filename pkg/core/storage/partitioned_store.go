@@ -0,0 +1,204 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
+)
+
+// partitionPrefixes maps data kinds that can be stored in separate partitions
+// to their key prefixes.
+var partitionPrefixes = map[string][]KeyPrefix{
+	dbconfig.PartitionMPT:       {DataMPT, DataMPTAux},
+	dbconfig.PartitionStorage:   {STStorage, STTempStorage, STStorageUsage},
+	dbconfig.PartitionTransfers: {STNEP11Transfers, STNEP17Transfers, STTokenTransferInfo, IXTransfers},
+	dbconfig.PartitionIndexes:   {IXEventIndex, IXEventContainers},
+}
+
+// PartitionedStore is a Store that keeps data with some key prefixes in
+// separate Stores (partitions) and everything else in the main Store, so
+// different kinds of data can be placed on different disks. Keys are routed
+// by their first byte. Changesets are split between partitions and applied
+// to each of them independently (partitions first, then the main Store), so
+// they're not atomic unless wrapped into JournaledStore.
+type PartitionedStore struct {
+	main   Store
+	routes [256]Store
+	stores []Store // All distinct stores, main is the last one.
+}
+
+// NewPartitionedStore creates a PartitionedStore from the main Store and a set
+// of partitions with key prefixes routed to them. It takes ownership of all
+// Stores, they're closed when PartitionedStore is closed.
+func NewPartitionedStore(main Store, partitions map[KeyPrefix]Store) *PartitionedStore {
+	s := &PartitionedStore{main: main}
+	for i := range s.routes {
+		s.routes[i] = main
+	}
+	for p, ps := range partitions {
+		s.routes[p] = ps
+	}
+	for i := range s.routes {
+		if s.routes[i] != main && !slices.Contains(s.stores, s.routes[i]) {
+			s.stores = append(s.stores, s.routes[i])
+		}
+	}
+	s.stores = append(s.stores, main)
+	return s
+}
+
+// newPartitionedStore opens partition DBs specified in the configuration and
+// creates a PartitionedStore with them, the main Store is closed on failure.
+func newPartitionedStore(main Store, cfgs []dbconfig.DBPartition) (*PartitionedStore, error) {
+	var (
+		err        error
+		opened     []Store
+		partitions = make(map[KeyPrefix]Store)
+	)
+	for i, cfg := range cfgs {
+		if len(cfg.Data) == 0 {
+			err = fmt.Errorf("partition #%d has no data", i)
+			break
+		}
+		var ps Store
+		ps, err = NewStore(dbconfig.DBConfiguration{
+			Type:           cfg.Type,
+			LevelDBOptions: cfg.LevelDBOptions,
+			BoltDBOptions:  cfg.BoltDBOptions,
+		})
+		if err != nil {
+			err = fmt.Errorf("partition #%d: %w", i, err)
+			break
+		}
+		opened = append(opened, ps)
+		for _, d := range cfg.Data {
+			prefixes, ok := partitionPrefixes[d]
+			if !ok {
+				err = fmt.Errorf("partition #%d: unknown data kind %s", i, d)
+				break
+			}
+			if _, ok := partitions[prefixes[0]]; ok {
+				err = fmt.Errorf("partition #%d: %s data is already stored in another partition", i, d)
+				break
+			}
+			for _, p := range prefixes {
+				partitions[p] = ps
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+	if err != nil {
+		for _, s := range opened {
+			err = errors.Join(err, s.Close())
+		}
+		return nil, errors.Join(err, main.Close())
+	}
+	return NewPartitionedStore(main, partitions), nil
+}
+
+// route returns the Store for the given key.
+func (s *PartitionedStore) route(key []byte) Store {
+	if len(key) == 0 {
+		return s.main
+	}
+	return s.routes[key[0]]
+}
+
+// Get implements the Store interface.
+func (s *PartitionedStore) Get(key []byte) ([]byte, error) {
+	return s.route(key).Get(key)
+}
+
+// PutChangeSet implements the Store interface.
+func (s *PartitionedStore) PutChangeSet(puts map[string][]byte, stor map[string][]byte) error {
+	type changeSet struct {
+		puts map[string][]byte
+		stor map[string][]byte
+	}
+	var sets = make(map[Store]*changeSet, len(s.stores))
+	for _, ps := range s.stores {
+		sets[ps] = &changeSet{puts: make(map[string][]byte), stor: make(map[string][]byte)}
+	}
+	for k, v := range puts {
+		sets[s.route([]byte(k))].puts[k] = v
+	}
+	for k, v := range stor {
+		sets[s.route([]byte(k))].stor[k] = v
+	}
+	for _, ps := range s.stores {
+		cs := sets[ps]
+		if len(cs.puts) == 0 && len(cs.stor) == 0 {
+			continue
+		}
+		if err := ps.PutChangeSet(cs.puts, cs.stor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Seek implements the Store interface. Seeking with an empty prefix iterates
+// over all partitions (it's done prefix by prefix to keep the order of keys).
+func (s *PartitionedStore) Seek(rng SeekRange, f func(k, v []byte) bool) {
+	if len(rng.Prefix) != 0 {
+		s.route(rng.Prefix).Seek(rng, f)
+		return
+	}
+	var (
+		stop bool
+		wrap = func(k, v []byte) bool {
+			stop = !f(k, v)
+			return !stop
+		}
+	)
+	for i := range s.routes {
+		b := byte(i)
+		if rng.Backwards {
+			b = byte(len(s.routes) - 1 - i)
+		}
+		var prng = SeekRange{
+			Prefix:      []byte{b},
+			Backwards:   rng.Backwards,
+			SearchDepth: rng.SearchDepth,
+		}
+		if len(rng.Start) != 0 {
+			if rng.Start[0] == b {
+				prng.Start = rng.Start[1:]
+			} else if (rng.Start[0] > b) != rng.Backwards {
+				continue
+			}
+		}
+		s.routes[b].Seek(prng, wrap)
+		if stop {
+			return
+		}
+	}
+}
+
+// SeekGC implements the Store interface. Seeking with an empty prefix is
+// performed for every partition.
+func (s *PartitionedStore) SeekGC(rng SeekRange, keep func(k, v []byte) bool) error {
+	if len(rng.Prefix) != 0 {
+		return s.route(rng.Prefix).SeekGC(rng, keep)
+	}
+	for _, ps := range s.stores {
+		if err := ps.SeekGC(rng, keep); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close implements the Store interface, it closes all partitions and the main
+// Store.
+func (s *PartitionedStore) Close() error {
+	var err error
+	for _, ps := range s.stores {
+		err = errors.Join(err, ps.Close())
+	}
+	return err
+}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func newPartitionedStoreForTesting(t testing.TB) Store {
+	return NewPartitionedStore(newLevelDBForTesting(t), map[KeyPrefix]Store{
+		'2': newMemoryStoreForTesting(t),
+	})
+}
+
+func TestPartitionedStore(t *testing.T) {
+	var (
+		dir = t.TempDir()
+		cfg = dbconfig.DBConfiguration{
+			Type:           dbconfig.LevelDB,
+			LevelDBOptions: dbconfig.LevelDBOptions{DataDirectoryPath: filepath.Join(dir, "main")},
+			JournalPath:    filepath.Join(dir, "journal"),
+			Partitions: []dbconfig.DBPartition{{
+				Data:          []string{dbconfig.PartitionMPT, dbconfig.PartitionIndexes},
+				Type:          dbconfig.BoltDB,
+				BoltDBOptions: dbconfig.BoltDBOptions{FilePath: filepath.Join(dir, "mpt.bolt")},
+			}},
+		}
+		keys = [][]byte{
+			{byte(DataExecutable), 1},
+			{byte(DataMPT), 1},
+			{byte(DataMPTAux), 1},
+			{byte(STStorage), 1},
+			{byte(IXEventIndex), 1},
+			{byte(SYSVersion), 1},
+		}
+	)
+	s, err := NewStore(cfg)
+	require.NoError(t, err)
+	js, ok := s.(*JournaledStore)
+	require.True(t, ok)
+	ps, ok := js.Store.(*PartitionedStore)
+	require.True(t, ok)
+
+	puts := make(map[string][]byte)
+	for _, k := range keys {
+		puts[string(k)] = k
+	}
+	require.NoError(t, ps.PutChangeSet(puts, nil))
+	for _, k := range keys {
+		v, err := ps.Get(k)
+		require.NoError(t, err)
+		require.Equal(t, k, v)
+	}
+
+	seek := func(t *testing.T, s Store, rng SeekRange) [][]byte {
+		var res [][]byte
+		s.Seek(rng, func(k, v []byte) bool {
+			res = append(res, bytes.Clone(k))
+			return true
+		})
+		return res
+	}
+	// Data is stored in proper DBs.
+	require.Equal(t, [][]byte{keys[1], keys[2], keys[4]}, seek(t, ps.routes[DataMPT], SeekRange{}))
+	require.Equal(t, [][]byte{keys[0], keys[3], keys[5]}, seek(t, ps.main, SeekRange{}))
+
+	t.Run("seek", func(t *testing.T) {
+		require.Equal(t, keys, seek(t, ps, SeekRange{}))
+		require.Equal(t, keys[2:], seek(t, ps, SeekRange{Start: []byte{byte(DataMPTAux)}}))
+		require.Equal(t, [][]byte{keys[2], keys[1], keys[0]}, seek(t, ps, SeekRange{Start: []byte{byte(DataMPTAux)}, Backwards: true}))
+		require.Equal(t, [][]byte{keys[1]}, seek(t, ps, SeekRange{Prefix: []byte{byte(DataMPT)}}))
+
+		var res [][]byte
+		ps.Seek(SeekRange{Backwards: true}, func(k, v []byte) bool {
+			res = append(res, bytes.Clone(k))
+			return len(res) < 3
+		})
+		require.Equal(t, [][]byte{keys[5], keys[4], keys[3]}, res)
+	})
+	t.Run("seekGC", func(t *testing.T) {
+		require.NoError(t, ps.SeekGC(SeekRange{}, func(k, v []byte) bool {
+			return k[0] != byte(DataMPTAux) && k[0] != byte(DataExecutable)
+		}))
+		require.Equal(t, [][]byte{keys[1], keys[3], keys[4], keys[5]}, seek(t, ps, SeekRange{}))
+	})
+	require.NoError(t, js.Close())
+
+	t.Run("no journal", func(t *testing.T) {
+		cfg := cfg
+		cfg.JournalPath = ""
+		_, err := NewStore(cfg)
+		require.ErrorContains(t, err, "partitions require JournalPath")
+	})
+	t.Run("bad partitions", func(t *testing.T) {
+		for name, parts := range map[string][]dbconfig.DBPartition{
+			"no data":      {{Type: dbconfig.InMemoryDB}},
+			"unknown data": {{Type: dbconfig.InMemoryDB, Data: []string{"Unknown"}}},
+			"unknown type": {{Type: "unknown", Data: []string{dbconfig.PartitionMPT}}},
+			"duplicate":    {{Type: dbconfig.InMemoryDB, Data: []string{dbconfig.PartitionMPT}}, {Type: dbconfig.InMemoryDB, Data: []string{dbconfig.PartitionMPT}}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				cfg.Partitions = parts
+				_, err := NewStore(cfg)
+				require.Error(t, err)
+			})
+		}
+	})
+}
@@ -27,7 +27,11 @@ const (
 	STNEP11Transfers               KeyPrefix = 0x72
 	STNEP17Transfers               KeyPrefix = 0x73
 	STTokenTransferInfo            KeyPrefix = 0x74
+	STStorageUsage                 KeyPrefix = 0x75
 	IXHeaderHashList               KeyPrefix = 0x80
+	IXEventIndex                   KeyPrefix = 0x81
+	IXEventContainers              KeyPrefix = 0x82
+	IXTransfers                    KeyPrefix = 0x83
 	SYSCurrentBlock                KeyPrefix = 0xc0
 	SYSCurrentHeader               KeyPrefix = 0xc1
 	SYSStateSyncCurrentBlockHeight KeyPrefix = 0xc2
@@ -39,6 +43,8 @@ const (
 	// unfinished state reset and to 0 on unfinished state jump).
 	SYSStateChangeStage KeyPrefix = 0xc4
 	SYSVersion          KeyPrefix = 0xf0
+	// SYSCompression marks DBs with compressed values (see CompressedStore).
+	SYSCompression KeyPrefix = 0xf1
 )
 
 // Executable subtypes.
@@ -123,6 +129,16 @@ func seekRangeToPrefixes(sr SeekRange) *util.Range {
 func NewStore(cfg dbconfig.DBConfiguration) (Store, error) {
 	var store Store
 	var err error
+	// Partitions are written independently, so only the journal can make
+	// their changes consistent after an unclean shutdown.
+	if len(cfg.Partitions) != 0 && cfg.JournalPath == "" {
+		return nil, errors.New("partitions require JournalPath")
+	}
+	// Single LevelDB and BoltDB stores apply changesets atomically, so the
+	// journal is only needed for partitions.
+	if len(cfg.Partitions) == 0 && cfg.JournalPath != "" {
+		return nil, errors.New("JournalPath can only be used with partitions")
+	}
 	switch cfg.Type {
 	case dbconfig.LevelDB:
 		store, err = NewLevelDBStore(cfg.LevelDBOptions)
@@ -133,6 +149,32 @@ func NewStore(cfg dbconfig.DBConfiguration) (Store, error) {
 	default:
 		return nil, fmt.Errorf("unknown storage: %s", cfg.Type)
 	}
+	if err == nil && len(cfg.Partitions) != 0 {
+		store, err = newPartitionedStore(store, cfg.Partitions)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err == nil {
+		if cfg.Compression != dbconfig.CompressionNone {
+			var cs *CompressedStore
+			cs, err = NewCompressedStore(store, cfg.Compression)
+			if err != nil {
+				return nil, errors.Join(err, store.Close())
+			}
+			store = cs
+		} else if err = checkUncompressed(store); err != nil {
+			return nil, errors.Join(err, store.Close())
+		}
+	}
+	if err == nil && len(cfg.Partitions) != 0 {
+		var js *JournaledStore
+		js, err = NewJournaledStore(store, cfg.JournalPath)
+		if err != nil {
+			return nil, errors.Join(err, store.Close())
+		}
+		store = js
+	}
 	return store, err
 }
 
@@ -226,9 +226,12 @@ func testStoreSeekGC(t *testing.T, s Store) {
 func TestAllDBs(t *testing.T) {
 	var DBs = []dbSetup{
 		{"BoltDB", newBoltStoreForTesting},
+		{"Compressed", newCompressedStoreForTesting},
+		{"Journaled", newJournaledStoreForTesting},
 		{"LevelDB", newLevelDBForTesting},
 		{"MemCached", newMemCachedStoreForTesting},
 		{"Memory", newMemoryStoreForTesting},
+		{"Partitioned", newPartitionedStoreForTesting},
 	}
 	var tests = []dbTestFunction{testStoreGetNonExistent, testStoreSeek,
 		testStoreSeekGC}
@@ -2,6 +2,7 @@ package address
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/nspcc-dev/neo-go/pkg/encoding/base58"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -15,7 +16,8 @@ const (
 )
 
 // Prefix is the byte used to prepend to addresses when encoding them, it can
-// be changed and defaults to 53 (0x35), the standard NEO prefix.
+// be changed (see AddressVersion protocol setting) and defaults to 53 (0x35),
+// the standard NEO prefix.
 var Prefix = NEO3Prefix
 
 // Uint160ToString returns the "NEO address" from the given Uint160.
@@ -26,8 +28,11 @@ func Uint160ToString(u util.Uint160) string {
 }
 
 // StringToUint160 attempts to decode the given NEO address string
-// into a Uint160.
+// into a Uint160. Bech32m addresses are also accepted if Bech32HRP is set.
 func StringToUint160(s string) (u util.Uint160, err error) {
+	if Bech32HRP != "" && strings.HasPrefix(strings.ToLower(s), Bech32HRP+"1") {
+		return DecodeBech32(Bech32HRP, s)
+	}
 	b, err := base58.CheckDecode(s)
 	if err != nil {
 		return u, err
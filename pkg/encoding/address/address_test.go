@@ -1,6 +1,7 @@
 package address
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -55,3 +56,76 @@ func TestPrefixFirstLetter(t *testing.T) {
 	}
 	require.EqualValues(t, 'N', Uint160ToString(u)[0])
 }
+
+func TestCustomPrefix(t *testing.T) {
+	defer func(p byte) { Prefix = p }(Prefix)
+
+	u := util.Uint160{1, 2, 3}
+	Prefix = 0x42
+	addr := Uint160ToString(u)
+	actual, err := StringToUint160(addr)
+	require.NoError(t, err)
+	require.Equal(t, u, actual)
+
+	Prefix = NEO3Prefix
+	_, err = StringToUint160(addr)
+	require.Error(t, err)
+}
+
+func TestBech32(t *testing.T) {
+	defer func(hrp string) { Bech32HRP = hrp }(Bech32HRP)
+
+	u := util.Uint160{1, 2, 3, 0xff}
+	_, err := Uint160ToBech32(u)
+	require.Error(t, err)
+
+	Bech32HRP = "neo"
+	addr, err := Uint160ToBech32(u)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(addr, "neo1"))
+
+	actual, err := StringToUint160(addr)
+	require.NoError(t, err)
+	require.Equal(t, u, actual)
+
+	actual, err = StringToUint160(strings.ToUpper(addr))
+	require.NoError(t, err)
+	require.Equal(t, u, actual)
+
+	// Regular addresses are still accepted.
+	actual, err = StringToUint160(Uint160ToString(u))
+	require.NoError(t, err)
+	require.Equal(t, u, actual)
+
+	t.Run("bad checksum", func(t *testing.T) {
+		bad := []byte(addr)
+		if bad[len(bad)-1] == 'q' {
+			bad[len(bad)-1] = 'p'
+		} else {
+			bad[len(bad)-1] = 'q'
+		}
+		_, err := StringToUint160(string(bad))
+		require.Error(t, err)
+	})
+	t.Run("mixed case", func(t *testing.T) {
+		_, err := StringToUint160("NEO" + addr[3:])
+		require.Error(t, err)
+	})
+	t.Run("wrong HRP", func(t *testing.T) {
+		other, err := EncodeBech32("tneo", u)
+		require.NoError(t, err)
+		_, err = DecodeBech32("neo", other)
+		require.Error(t, err)
+	})
+	t.Run("invalid HRP", func(t *testing.T) {
+		_, err := EncodeBech32("", u)
+		require.Error(t, err)
+		_, err = EncodeBech32("Neo", u)
+		require.Error(t, err)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		Bech32HRP = ""
+		_, err := StringToUint160(addr)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,166 @@
+package address
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Bech32HRP is the human-readable part of experimental bech32m (BIP-350)
+// addresses. If it's not empty, StringToUint160 accepts bech32m addresses with
+// this HRP in addition to the regular Base58Check ones. It's empty by default.
+var Bech32HRP string
+
+const (
+	// bech32Charset is the alphabet of bech32 data part.
+	bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	// bech32mConst is the checksum constant of bech32m.
+	bech32mConst = 0x2bc830a3
+	// bech32MaxLen is the maximum length of bech32 string.
+	bech32MaxLen = 90
+)
+
+// Uint160ToBech32 returns bech32m representation of the given Uint160 with
+// Bech32HRP human-readable part. It returns an error if Bech32HRP is not set.
+func Uint160ToBech32(u util.Uint160) (string, error) {
+	if Bech32HRP == "" {
+		return "", errors.New("bech32m addresses are disabled")
+	}
+	return EncodeBech32(Bech32HRP, u)
+}
+
+// EncodeBech32 returns bech32m representation of the given Uint160 with the
+// given human-readable part.
+func EncodeBech32(hrp string, u util.Uint160) (string, error) {
+	if err := ValidateBech32HRP(hrp); err != nil {
+		return "", err
+	}
+	data := convertBits(u.BytesBE(), 8, 5, true)
+	data = append(data, bech32Checksum(hrp, data)...)
+
+	var sb strings.Builder
+	sb.Grow(len(hrp) + 1 + len(data))
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, b := range data {
+		sb.WriteByte(bech32Charset[b])
+	}
+	return sb.String(), nil
+}
+
+// DecodeBech32 decodes bech32m address with the given human-readable part
+// into Uint160.
+func DecodeBech32(hrp string, s string) (util.Uint160, error) {
+	var u util.Uint160
+	if len(s) > bech32MaxLen {
+		return u, errors.New("bech32 string is too long")
+	}
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return u, errors.New("mixed case bech32 string")
+	}
+	s = strings.ToLower(s)
+	sep := strings.LastIndexByte(s, '1')
+	if sep < 1 || sep+7 > len(s) {
+		return u, errors.New("invalid bech32 separator position")
+	}
+	if s[:sep] != hrp {
+		return u, fmt.Errorf("wrong human-readable part %q", s[:sep])
+	}
+	data := make([]byte, 0, len(s)-sep-1)
+	for i := sep + 1; i < len(s); i++ {
+		c := strings.IndexByte(bech32Charset, s[i])
+		if c < 0 {
+			return u, fmt.Errorf("invalid bech32 character %q", s[i])
+		}
+		data = append(data, byte(c))
+	}
+	if bech32Polymod(bech32Values(hrp, data)) != bech32mConst {
+		return u, errors.New("invalid bech32m checksum")
+	}
+	b := convertBits(data[:len(data)-6], 5, 8, false)
+	if b == nil {
+		return u, errors.New("invalid bech32 data padding")
+	}
+	return util.Uint160DecodeBytesBE(b)
+}
+
+// ValidateBech32HRP checks that the given string can be used as a
+// human-readable part of bech32m addresses.
+func ValidateBech32HRP(hrp string) error {
+	if len(hrp) == 0 || len(hrp) > 83 {
+		return errors.New("human-readable part length must be between 1 and 83")
+	}
+	for i := range len(hrp) {
+		if hrp[i] < 33 || hrp[i] > 126 || (hrp[i] >= 'A' && hrp[i] <= 'Z') {
+			return fmt.Errorf("invalid human-readable part character %q", hrp[i])
+		}
+	}
+	return nil
+}
+
+func bech32Polymod(values []byte) uint32 {
+	var gen = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := range gen {
+			if (top>>i)&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+// bech32Values returns expanded human-readable part followed by data.
+func bech32Values(hrp string, data []byte) []byte {
+	res := make([]byte, 0, len(hrp)*2+1+len(data))
+	for i := range len(hrp) {
+		res = append(res, hrp[i]>>5)
+	}
+	res = append(res, 0)
+	for i := range len(hrp) {
+		res = append(res, hrp[i]&31)
+	}
+	return append(res, data...)
+}
+
+func bech32Checksum(hrp string, data []byte) []byte {
+	values := append(bech32Values(hrp, data), 0, 0, 0, 0, 0, 0)
+	mod := bech32Polymod(values) ^ bech32mConst
+	res := make([]byte, 6)
+	for i := range res {
+		res[i] = byte(mod>>(5*(5-i))) & 31
+	}
+	return res
+}
+
+// convertBits regroups bits of data from `from` to `to` bits per element, it
+// returns nil if padding is invalid (for pad == false).
+func convertBits(data []byte, from, to uint, pad bool) []byte {
+	var (
+		acc  uint32
+		bits uint
+		res  = make([]byte, 0, len(data)*int(from)/int(to)+1)
+		maxv = uint32(1)<<to - 1
+	)
+	for _, v := range data {
+		acc = acc<<from | uint32(v)
+		bits += from
+		for bits >= to {
+			bits -= to
+			res = append(res, byte(acc>>bits&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			res = append(res, byte(acc<<(to-bits)&maxv))
+		}
+	} else if bits >= from || acc<<(to-bits)&maxv != 0 {
+		return nil
+	}
+	return res
+}
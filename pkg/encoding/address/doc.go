@@ -1,4 +1,6 @@
 /*
 Package address implements conversion of a script hash to/from a Neo address.
+Base58Check addresses with configurable version byte are used by default,
+experimental bech32m representation can also be enabled.
 */
 package address
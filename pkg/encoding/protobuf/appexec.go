@@ -0,0 +1,191 @@
+package protobuf
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+)
+
+// MarshalAppExecResult encodes the application execution result into the
+// AppExecResult message.
+func MarshalAppExecResult(aer *state.AppExecResult) ([]byte, error) {
+	var b []byte
+	b = appendBytes(b, 1, aer.Container.BytesBE())
+	b = appendVarint(b, 2, uint64(aer.Trigger))
+	b = appendVarint(b, 3, uint64(aer.VMState))
+	b = appendVarint(b, 4, uint64(aer.GasConsumed))
+	for i, item := range aer.Stack {
+		data, err := MarshalStackItem(item)
+		if err != nil {
+			return nil, fmt.Errorf("stack item %d: %w", i, err)
+		}
+		b = appendMessage(b, 5, data)
+	}
+	for i := range aer.Events {
+		data, err := marshalNotification(&aer.Events[i])
+		if err != nil {
+			return nil, fmt.Errorf("notification %d: %w", i, err)
+		}
+		b = appendMessage(b, 6, data)
+	}
+	b = appendString(b, 7, aer.FaultException)
+	for i := range aer.Invocations {
+		data, err := marshalInvocation(&aer.Invocations[i])
+		if err != nil {
+			return nil, fmt.Errorf("invocation %d: %w", i, err)
+		}
+		b = appendMessage(b, 8, data)
+	}
+	return b, nil
+}
+
+// UnmarshalAppExecResult decodes the application execution result from the
+// AppExecResult message.
+func UnmarshalAppExecResult(data []byte) (*state.AppExecResult, error) {
+	var aer = &state.AppExecResult{
+		Execution: state.Execution{
+			Stack:  []stackitem.Item{},
+			Events: []state.NotificationEvent{},
+		},
+	}
+	err := parse(data, func(f field) error {
+		var err error
+		switch f.num {
+		case 1:
+			aer.Container, err = f.uint256()
+		case 2:
+			var t uint8
+			t, err = f.uint8()
+			aer.Trigger = trigger.Type(t)
+		case 3:
+			var s uint8
+			s, err = f.uint8()
+			aer.VMState = vmstate.State(s)
+		case 4:
+			aer.GasConsumed, err = f.int64()
+		case 5:
+			var item stackitem.Item
+			item, err = unmarshalSubItem(f, maxNesting)
+			aer.Stack = append(aer.Stack, item)
+		case 6:
+			var ntf state.NotificationEvent
+			ntf, err = unmarshalNotification(f)
+			aer.Events = append(aer.Events, ntf)
+		case 7:
+			aer.FaultException, err = f.string()
+		case 8:
+			var inv state.ContractInvocation
+			inv, err = unmarshalInvocation(f)
+			aer.Invocations = append(aer.Invocations, inv)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return aer, nil
+}
+
+// marshalArray encodes an optional Array item.
+func marshalArray(arr *stackitem.Array) ([]byte, error) {
+	if arr == nil {
+		return nil, nil
+	}
+	return MarshalStackItem(arr)
+}
+
+// unmarshalArray decodes an Array item.
+func unmarshalArray(f field) (*stackitem.Array, error) {
+	item, err := unmarshalSubItem(f, maxNesting)
+	if err != nil {
+		return nil, err
+	}
+	arr, ok := item.(*stackitem.Array)
+	if !ok {
+		return nil, fmt.Errorf("expected Array, got %s", item.Type())
+	}
+	return arr, nil
+}
+
+func marshalNotification(ntf *state.NotificationEvent) ([]byte, error) {
+	var b []byte
+	b = appendBytes(b, 1, ntf.ScriptHash.BytesBE())
+	b = appendString(b, 2, ntf.Name)
+	item, err := marshalArray(ntf.Item)
+	if err != nil {
+		return nil, err
+	}
+	if item != nil {
+		b = appendMessage(b, 3, item)
+	}
+	return b, nil
+}
+
+func unmarshalNotification(f field) (state.NotificationEvent, error) {
+	var ntf state.NotificationEvent
+	data, err := f.bytes()
+	if err != nil {
+		return ntf, err
+	}
+	err = parse(data, func(f field) error {
+		var err error
+		switch f.num {
+		case 1:
+			ntf.ScriptHash, err = f.uint160()
+		case 2:
+			ntf.Name, err = f.string()
+		case 3:
+			ntf.Item, err = unmarshalArray(f)
+		}
+		return err
+	})
+	if err == nil && ntf.Item == nil {
+		err = errors.New("notification without state")
+	}
+	return ntf, err
+}
+
+func marshalInvocation(inv *state.ContractInvocation) ([]byte, error) {
+	var b []byte
+	b = appendBytes(b, 1, inv.Hash.BytesBE())
+	b = appendString(b, 2, inv.Method)
+	args, err := marshalArray(inv.Arguments)
+	if err != nil {
+		return nil, err
+	}
+	if args != nil {
+		b = appendMessage(b, 3, args)
+	}
+	b = appendVarint(b, 4, uint64(inv.ArgumentsCount))
+	b = appendBool(b, 5, inv.Truncated)
+	return b, nil
+}
+
+func unmarshalInvocation(f field) (state.ContractInvocation, error) {
+	var inv state.ContractInvocation
+	data, err := f.bytes()
+	if err != nil {
+		return inv, err
+	}
+	err = parse(data, func(f field) error {
+		var err error
+		switch f.num {
+		case 1:
+			inv.Hash, err = f.uint160()
+		case 2:
+			inv.Method, err = f.string()
+		case 3:
+			inv.Arguments, err = unmarshalArray(f)
+		case 4:
+			inv.ArgumentsCount, err = f.uint32()
+		case 5:
+			inv.Truncated, err = f.bool()
+		}
+		return err
+	})
+	return inv, err
+}
@@ -0,0 +1,131 @@
+package protobuf
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+)
+
+// MarshalHeader encodes the block header into the Header message.
+func MarshalHeader(h *block.Header) []byte {
+	var b []byte
+	b = appendBytes(b, 1, h.Hash().BytesBE())
+	b = appendVarint(b, 2, uint64(h.Version))
+	b = appendBytes(b, 3, h.PrevHash.BytesBE())
+	b = appendBytes(b, 4, h.MerkleRoot.BytesBE())
+	b = appendVarint(b, 5, h.Timestamp)
+	b = appendVarint(b, 6, h.Nonce)
+	b = appendVarint(b, 7, uint64(h.Index))
+	b = appendVarint(b, 8, uint64(h.PrimaryIndex))
+	b = appendBytes(b, 9, h.NextConsensus.BytesBE())
+	b = appendMessage(b, 10, marshalWitness(&h.Script))
+	b = appendBool(b, 11, h.StateRootEnabled)
+	if h.StateRootEnabled {
+		b = appendBytes(b, 12, h.PrevStateRoot.BytesBE())
+	}
+	return b
+}
+
+// UnmarshalHeader decodes the block header from the Header message.
+// ErrHashMismatch is returned if the message contains a hash different from
+// the one of the decoded header.
+func UnmarshalHeader(data []byte) (*block.Header, error) {
+	var (
+		hash []byte
+		h    = new(block.Header)
+	)
+	err := parse(data, func(f field) error {
+		var err error
+		switch f.num {
+		case 1:
+			hash, err = f.bytes()
+		case 2:
+			h.Version, err = f.uint32()
+		case 3:
+			h.PrevHash, err = f.uint256()
+		case 4:
+			h.MerkleRoot, err = f.uint256()
+		case 5:
+			h.Timestamp, err = f.uint64()
+		case 6:
+			h.Nonce, err = f.uint64()
+		case 7:
+			h.Index, err = f.uint32()
+		case 8:
+			h.PrimaryIndex, err = f.uint8()
+		case 9:
+			h.NextConsensus, err = f.uint160()
+		case 10:
+			h.Script, err = unmarshalWitness(f)
+		case 11:
+			h.StateRootEnabled, err = f.bool()
+		case 12:
+			h.PrevStateRoot, err = f.uint256()
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(hash) != 0 && string(hash) != string(h.Hash().BytesBE()) {
+		return nil, fmt.Errorf("%w: block %s", ErrHashMismatch, h.Hash().StringLE())
+	}
+	return h, nil
+}
+
+// MarshalBlock encodes the block into the Block message.
+func MarshalBlock(b *block.Block) ([]byte, error) {
+	res := appendMessage(nil, 1, MarshalHeader(&b.Header))
+	for i, tx := range b.Transactions {
+		data, err := MarshalTransaction(tx)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		res = appendMessage(res, 2, data)
+	}
+	return res, nil
+}
+
+// UnmarshalBlock decodes the block from the Block message. Block and
+// transaction hashes are checked if present in the message.
+func UnmarshalBlock(data []byte) (*block.Block, error) {
+	var (
+		b         = &block.Block{Transactions: []*transaction.Transaction{}}
+		hasHeader bool
+	)
+	err := parse(data, func(f field) error {
+		switch f.num {
+		case 1:
+			data, err := f.bytes()
+			if err != nil {
+				return err
+			}
+			h, err := UnmarshalHeader(data)
+			if err != nil {
+				return err
+			}
+			b.Header = *h
+			hasHeader = true
+		case 2:
+			data, err := f.bytes()
+			if err != nil {
+				return err
+			}
+			tx, err := UnmarshalTransaction(data)
+			if err != nil {
+				return fmt.Errorf("transaction %d: %w", len(b.Transactions), err)
+			}
+			b.Transactions = append(b.Transactions, tx)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !hasHeader {
+		return nil, errors.New("no block header")
+	}
+	return b, nil
+}
@@ -0,0 +1,188 @@
+/*
+Package protobuf implements protobuf serialization of transactions, blocks,
+application execution results and stack items. It allows to export node data
+to services written in other languages without reimplementing Neo binary or
+JSON formats, messages are defined in the neo.proto file shipped with this
+package which can be used to generate code for any language supported by
+protobuf. Protobuf encoding is not deterministic in general, so hashes are
+always calculated from the Neo binary representation.
+*/
+package protobuf
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ErrHashMismatch is returned when the hash specified in a message differs
+// from the one calculated for the decoded structure.
+var ErrHashMismatch = errors.New("hash mismatch")
+
+// maxNesting limits the depth of nested messages accepted by decoders.
+const maxNesting = 64
+
+// appendVarint appends non-zero varint field.
+func appendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+// appendBool appends non-false bool field.
+func appendBool(b []byte, num protowire.Number, v bool) []byte {
+	return appendVarint(b, num, protowire.EncodeBool(v))
+}
+
+// appendBytes appends non-empty bytes field.
+func appendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	return appendMessage(b, num, v)
+}
+
+// appendString appends non-empty string field.
+func appendString(b []byte, num protowire.Number, v string) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, v)
+}
+
+// appendMessage appends length-delimited field even if it's empty, it's used
+// for embedded messages and repeated fields.
+func appendMessage(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+// field is a decoded protobuf field.
+type field struct {
+	num protowire.Number
+	typ protowire.Type
+	u   uint64
+	b   []byte
+}
+
+// parse calls f for every varint and length-delimited field of the message,
+// fields of other types are skipped.
+func parse(b []byte, f func(field) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		var fl = field{num: num, typ: typ}
+		switch typ {
+		case protowire.VarintType:
+			fl.u, n = protowire.ConsumeVarint(b)
+		case protowire.BytesType:
+			fl.b, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		if typ != protowire.VarintType && typ != protowire.BytesType {
+			continue
+		}
+		if err := f(fl); err != nil {
+			return fmt.Errorf("field %d: %w", num, err)
+		}
+	}
+	return nil
+}
+
+func (f field) wireTypeError() error {
+	return fmt.Errorf("unexpected wire type %d", f.typ)
+}
+
+func (f field) uint64() (uint64, error) {
+	if f.typ != protowire.VarintType {
+		return 0, f.wireTypeError()
+	}
+	return f.u, nil
+}
+
+func (f field) int64() (int64, error) {
+	u, err := f.uint64()
+	return int64(u), err
+}
+
+func (f field) uint32() (uint32, error) {
+	u, err := f.uint64()
+	if err == nil && u > 0xffffffff {
+		err = fmt.Errorf("value %d overflows uint32", u)
+	}
+	return uint32(u), err
+}
+
+func (f field) uint8() (uint8, error) {
+	u, err := f.uint64()
+	if err == nil && u > 0xff {
+		err = fmt.Errorf("value %d overflows uint8", u)
+	}
+	return uint8(u), err
+}
+
+func (f field) bool() (bool, error) {
+	u, err := f.uint64()
+	return protowire.DecodeBool(u), err
+}
+
+func (f field) bytes() ([]byte, error) {
+	if f.typ != protowire.BytesType {
+		return nil, f.wireTypeError()
+	}
+	return f.b, nil
+}
+
+// clonedBytes returns a copy of the field data, so that the result doesn't
+// reference the input buffer.
+func (f field) clonedBytes() ([]byte, error) {
+	b, err := f.bytes()
+	if err != nil || len(b) == 0 {
+		return nil, err
+	}
+	return append([]byte{}, b...), nil
+}
+
+func (f field) string() (string, error) {
+	b, err := f.bytes()
+	return string(b), err
+}
+
+func (f field) uint160() (util.Uint160, error) {
+	b, err := f.bytes()
+	if err != nil {
+		return util.Uint160{}, err
+	}
+	return util.Uint160DecodeBytesBE(b)
+}
+
+func (f field) uint256() (util.Uint256, error) {
+	b, err := f.bytes()
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	return util.Uint256DecodeBytesBE(b)
+}
+
+func (f field) publicKey() (*keys.PublicKey, error) {
+	b, err := f.bytes()
+	if err != nil {
+		return nil, err
+	}
+	return keys.NewPublicKeyFromBytes(b, elliptic.P256())
+}
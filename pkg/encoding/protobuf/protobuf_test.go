@@ -0,0 +1,298 @@
+package protobuf
+
+import (
+	"math/big"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func newTestTx(t *testing.T) *transaction.Transaction {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	group := priv.PublicKey()
+
+	scriptHash := random.Uint160()
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 123)
+	tx.NetworkFee = 456
+	tx.ValidUntilBlock = 1000
+	tx.Signers = []transaction.Signer{
+		{Account: random.Uint160(), Scopes: transaction.CalledByEntry},
+		{
+			Account:          random.Uint160(),
+			Scopes:           transaction.CustomContracts | transaction.CustomGroups | transaction.Rules,
+			AllowedContracts: []util.Uint160{random.Uint160()},
+			AllowedGroups:    []*keys.PublicKey{group},
+			Rules: []transaction.WitnessRule{
+				{
+					Action: transaction.WitnessAllow,
+					Condition: &transaction.ConditionAnd{
+						(*transaction.ConditionBoolean)(new(bool)),
+						(*transaction.ConditionScriptHash)(&scriptHash),
+						(*transaction.ConditionGroup)(group),
+					},
+				},
+				{
+					Action: transaction.WitnessDeny,
+					Condition: &transaction.ConditionOr{
+						&transaction.ConditionCalledByEntry{},
+						(*transaction.ConditionCalledByContract)(&scriptHash),
+						(*transaction.ConditionCalledByGroup)(group),
+					},
+				},
+				{
+					Action:    transaction.WitnessAllow,
+					Condition: &transaction.ConditionNot{Condition: &transaction.ConditionCalledByEntry{}},
+				},
+			},
+		},
+	}
+	tx.Attributes = []transaction.Attribute{
+		{Type: transaction.HighPriority},
+		{Type: transaction.OracleResponseT, Value: &transaction.OracleResponse{ID: 1, Code: transaction.Success, Result: []byte{1, 2, 3}}},
+		{Type: transaction.NotValidBeforeT, Value: &transaction.NotValidBefore{Height: 10}},
+		{Type: transaction.ConflictsT, Value: &transaction.Conflicts{Hash: random.Uint256()}},
+		{Type: transaction.NotaryAssistedT, Value: &transaction.NotaryAssisted{NKeys: 3}},
+		{Type: transaction.ReservedLowerBound + 1, Value: &transaction.Reserved{Value: []byte{4, 5}}},
+	}
+	tx.Scripts = []transaction.Witness{
+		{InvocationScript: []byte{1, 2}, VerificationScript: []byte{3, 4}},
+		{InvocationScript: []byte{5}, VerificationScript: []byte{}},
+	}
+	return tx
+}
+
+func TestTransaction(t *testing.T) {
+	tx := newTestTx(t)
+	data, err := MarshalTransaction(tx)
+	require.NoError(t, err)
+
+	actual, err := UnmarshalTransaction(data)
+	require.NoError(t, err)
+	require.Equal(t, tx.Hash(), actual.Hash())
+	require.Equal(t, tx.Bytes(), actual.Bytes())
+
+	t.Run("hash mismatch", func(t *testing.T) {
+		// The last field value wins, so the bad hash overrides the real one.
+		bad := protowire.AppendTag(slices.Clone(data), 1, protowire.BytesType)
+		bad = protowire.AppendBytes(bad, random.Uint256().BytesBE())
+		_, err := UnmarshalTransaction(bad)
+		require.ErrorIs(t, err, ErrHashMismatch)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := UnmarshalTransaction(data[:len(data)-1])
+		require.Error(t, err)
+
+		// Version with a wrong wire type.
+		bad := protowire.AppendTag(nil, 2, protowire.BytesType)
+		bad = protowire.AppendBytes(bad, []byte{1})
+		_, err = UnmarshalTransaction(bad)
+		require.Error(t, err)
+
+		// Unknown attribute type.
+		attr := protowire.AppendTag(nil, 1, protowire.VarintType)
+		attr = protowire.AppendVarint(attr, 0x42)
+		bad = protowire.AppendTag(nil, 8, protowire.BytesType)
+		bad = protowire.AppendBytes(bad, attr)
+		_, err = UnmarshalTransaction(bad)
+		require.Error(t, err)
+	})
+}
+
+func TestBlock(t *testing.T) {
+	b := &block.Block{
+		Header: block.Header{
+			Version:          0,
+			PrevHash:         random.Uint256(),
+			Timestamp:        1234567890,
+			Nonce:            42,
+			Index:            100,
+			PrimaryIndex:     2,
+			NextConsensus:    random.Uint160(),
+			Script:           transaction.Witness{InvocationScript: []byte{1}, VerificationScript: []byte{2}},
+			StateRootEnabled: true,
+			PrevStateRoot:    random.Uint256(),
+		},
+		Transactions: []*transaction.Transaction{newTestTx(t), newTestTx(t)},
+	}
+	b.RebuildMerkleRoot()
+
+	data, err := MarshalBlock(b)
+	require.NoError(t, err)
+	actual, err := UnmarshalBlock(data)
+	require.NoError(t, err)
+	require.Equal(t, b.Hash(), actual.Hash())
+	expected, err := testserdes.EncodeBinary(b)
+	require.NoError(t, err)
+	got, err := testserdes.EncodeBinary(actual)
+	require.NoError(t, err)
+	require.Equal(t, expected, got)
+
+	_, err = UnmarshalBlock(nil)
+	require.Error(t, err)
+}
+
+func TestStackItem(t *testing.T) {
+	m := stackitem.NewMap()
+	m.Add(stackitem.Make("key"), stackitem.Make(1))
+	m.Add(stackitem.Make(true), stackitem.Null{})
+	items := []stackitem.Item{
+		stackitem.Null{},
+		stackitem.NewBool(true),
+		stackitem.NewBool(false),
+		stackitem.NewBigInteger(big.NewInt(0)),
+		stackitem.NewBigInteger(big.NewInt(-12345)),
+		stackitem.NewBigInteger(new(big.Int).Lsh(big.NewInt(1), 200)),
+		stackitem.NewByteArray([]byte{1, 2, 3}),
+		stackitem.NewBuffer([]byte{4, 5}),
+		stackitem.NewBuffer([]byte{}),
+		stackitem.NewArray([]stackitem.Item{}),
+		stackitem.NewArray([]stackitem.Item{stackitem.Make(1), stackitem.Make("str")}),
+		stackitem.NewStruct([]stackitem.Item{stackitem.NewStruct([]stackitem.Item{stackitem.Make(2)})}),
+		m,
+	}
+	for _, item := range items {
+		data, err := MarshalStackItem(item)
+		require.NoError(t, err)
+		actual, err := UnmarshalStackItem(data)
+		require.NoError(t, err)
+		require.Equal(t, item.Type(), actual.Type())
+		expected, err := stackitem.ToJSONWithTypes(item)
+		require.NoError(t, err)
+		got, err := stackitem.ToJSONWithTypes(actual)
+		require.NoError(t, err)
+		require.Equal(t, expected, got)
+	}
+
+	t.Run("recursive", func(t *testing.T) {
+		arr := stackitem.NewArray(nil)
+		arr.Append(arr)
+		_, err := MarshalStackItem(arr)
+		require.ErrorIs(t, err, stackitem.ErrRecursive)
+
+		// The same item can be used several times without recursion.
+		item := stackitem.Make(1)
+		inner := stackitem.NewArray([]stackitem.Item{item})
+		_, err = MarshalStackItem(stackitem.NewArray([]stackitem.Item{inner, inner, item}))
+		require.NoError(t, err)
+	})
+	t.Run("unserializable", func(t *testing.T) {
+		_, err := MarshalStackItem(stackitem.NewInterop(nil))
+		require.ErrorIs(t, err, stackitem.ErrUnserializable)
+		_, err = MarshalStackItem(stackitem.NewPointer(0, []byte{}))
+		require.ErrorIs(t, err, stackitem.ErrUnserializable)
+	})
+	t.Run("too deep", func(t *testing.T) {
+		var item stackitem.Item = stackitem.Null{}
+		for range maxNesting {
+			item = stackitem.NewArray([]stackitem.Item{item})
+		}
+		data, err := MarshalStackItem(item)
+		require.NoError(t, err)
+		_, err = UnmarshalStackItem(data)
+		require.Error(t, err)
+	})
+	t.Run("integer bounds", func(t *testing.T) {
+		minInt := new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), stackitem.MaxBigIntegerSizeBits-1))
+		require.Equal(t, maxIntegerLen, len(minInt.String()))
+		data, err := MarshalStackItem(stackitem.NewBigInteger(minInt))
+		require.NoError(t, err)
+		_, err = UnmarshalStackItem(data)
+		require.NoError(t, err)
+
+		intData := func(s string) []byte {
+			return appendString(appendVarint(nil, 1, uint64(stackitem.IntegerT)), 3, s)
+		}
+		// Out of range, but not too long.
+		_, err = UnmarshalStackItem(intData(new(big.Int).Sub(minInt, big.NewInt(1)).String()))
+		require.Error(t, err)
+		_, err = UnmarshalStackItem(intData("1" + strings.Repeat("0", maxIntegerLen)))
+		require.ErrorContains(t, err, "too long")
+	})
+	t.Run("invalid map key", func(t *testing.T) {
+		key, err := MarshalStackItem(stackitem.NewArray(nil))
+		require.NoError(t, err)
+		value, err := MarshalStackItem(stackitem.Null{})
+		require.NoError(t, err)
+		entry := appendMessage(appendMessage(nil, 1, key), 2, value)
+		data := appendMessage(appendVarint(nil, 1, uint64(stackitem.MapT)), 6, entry)
+		_, err = UnmarshalStackItem(data)
+		require.Error(t, err)
+	})
+}
+
+func TestAppExecResult(t *testing.T) {
+	aer := &state.AppExecResult{
+		Container: random.Uint256(),
+		Execution: state.Execution{
+			Trigger:     trigger.Application,
+			VMState:     vmstate.Fault,
+			GasConsumed: 100500,
+			Stack:       []stackitem.Item{stackitem.Make(1), stackitem.Make("result")},
+			Events: []state.NotificationEvent{
+				{
+					ScriptHash: random.Uint160(),
+					Name:       "Transfer",
+					Item:       stackitem.NewArray([]stackitem.Item{stackitem.Null{}, stackitem.Make([]byte{1}), stackitem.Make(5)}),
+				},
+				{
+					ScriptHash: random.Uint160(),
+					Name:       "Empty",
+					Item:       stackitem.NewArray([]stackitem.Item{}),
+				},
+			},
+			FaultException: "at instruction 1 (ABORT): ABORT is executed",
+			Invocations: []state.ContractInvocation{
+				{
+					Hash:           random.Uint160(),
+					Method:         "transfer",
+					Arguments:      stackitem.NewArray([]stackitem.Item{stackitem.Make(1)}),
+					ArgumentsCount: 1,
+				},
+				{
+					Hash:           random.Uint160(),
+					Method:         "big",
+					ArgumentsCount: 100,
+					Truncated:      true,
+				},
+			},
+		},
+	}
+	data, err := MarshalAppExecResult(aer)
+	require.NoError(t, err)
+	actual, err := UnmarshalAppExecResult(data)
+	require.NoError(t, err)
+
+	expected, err := aer.MarshalJSON()
+	require.NoError(t, err)
+	got, err := actual.MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, string(expected), string(got))
+	require.Equal(t, aer.Container, actual.Container)
+	require.Equal(t, aer.Invocations[1], actual.Invocations[1])
+	expected, err = stackitem.ToJSONWithTypes(aer.Invocations[0].Arguments)
+	require.NoError(t, err)
+	got, err = stackitem.ToJSONWithTypes(actual.Invocations[0].Arguments)
+	require.NoError(t, err)
+	require.Equal(t, expected, got)
+
+	t.Run("notification without state", func(t *testing.T) {
+		ntf := appendString(nil, 2, "Event")
+		_, err := UnmarshalAppExecResult(appendMessage(nil, 6, ntf))
+		require.Error(t, err)
+	})
+}
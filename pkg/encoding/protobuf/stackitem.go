@@ -0,0 +1,194 @@
+package protobuf
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// maxIntegerLen is the maximum length of the decimal representation of an
+// Integer item, it's the length of the minimum value allowed by
+// stackitem.MaxBigIntegerSizeBits (-2^255).
+const maxIntegerLen = len("-57896044618658097711785492504343953926634992332820282019728792003956564819968")
+
+// MarshalStackItem encodes the stack item into the StackItem message.
+// Pointer and Interop items can't be serialized, stackitem.ErrUnserializable
+// is returned for them, stackitem.ErrRecursive is returned for recursive
+// items.
+func MarshalStackItem(item stackitem.Item) ([]byte, error) {
+	return marshalStackItem(item, make(map[stackitem.Item]bool))
+}
+
+// marshalStackItem encodes item, path contains the containers currently
+// being encoded to detect recursion.
+func marshalStackItem(item stackitem.Item, path map[stackitem.Item]bool) ([]byte, error) {
+	var b []byte
+	if item == nil {
+		return nil, fmt.Errorf("%w: nil", stackitem.ErrUnserializable)
+	}
+	b = appendVarint(b, 1, uint64(item.Type()))
+	switch it := item.(type) {
+	case stackitem.Null:
+	case stackitem.Bool:
+		b = appendBool(b, 2, bool(it))
+	case *stackitem.BigInteger:
+		b = appendString(b, 3, it.Big().String())
+	case *stackitem.ByteArray, *stackitem.Buffer:
+		b = appendBytes(b, 4, it.Value().([]byte))
+	case *stackitem.Array, *stackitem.Struct:
+		if path[item] {
+			return nil, stackitem.ErrRecursive
+		}
+		path[item] = true
+		for _, sub := range it.Value().([]stackitem.Item) {
+			data, err := marshalStackItem(sub, path)
+			if err != nil {
+				return nil, err
+			}
+			b = appendMessage(b, 5, data)
+		}
+		delete(path, item)
+	case *stackitem.Map:
+		if path[item] {
+			return nil, stackitem.ErrRecursive
+		}
+		path[item] = true
+		for _, e := range it.Value().([]stackitem.MapElement) {
+			key, err := marshalStackItem(e.Key, path)
+			if err != nil {
+				return nil, err
+			}
+			value, err := marshalStackItem(e.Value, path)
+			if err != nil {
+				return nil, err
+			}
+			var entry []byte
+			entry = appendMessage(entry, 1, key)
+			entry = appendMessage(entry, 2, value)
+			b = appendMessage(b, 6, entry)
+		}
+		delete(path, item)
+	default:
+		return nil, fmt.Errorf("%w: %s", stackitem.ErrUnserializable, item.Type())
+	}
+	return b, nil
+}
+
+// UnmarshalStackItem decodes the stack item from the StackItem message.
+func UnmarshalStackItem(data []byte) (stackitem.Item, error) {
+	return unmarshalStackItem(data, maxNesting)
+}
+
+func unmarshalStackItem(data []byte, maxDepth int) (stackitem.Item, error) {
+	if maxDepth <= 0 {
+		return nil, errors.New("too many stack item nesting levels")
+	}
+	var (
+		typ     uint8
+		boolean bool
+		integer string
+		bytes   []byte
+		items   = []stackitem.Item{}
+		entries []stackitem.MapElement
+	)
+	err := parse(data, func(f field) error {
+		var err error
+		switch f.num {
+		case 1:
+			typ, err = f.uint8()
+		case 2:
+			boolean, err = f.bool()
+		case 3:
+			integer, err = f.string()
+		case 4:
+			bytes, err = f.clonedBytes()
+		case 5:
+			var item stackitem.Item
+			item, err = unmarshalSubItem(f, maxDepth-1)
+			items = append(items, item)
+		case 6:
+			var e stackitem.MapElement
+			e, err = unmarshalMapEntry(f, maxDepth-1)
+			entries = append(entries, e)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch t := stackitem.Type(typ); t {
+	case stackitem.AnyT:
+		return stackitem.Null{}, nil
+	case stackitem.BooleanT:
+		return stackitem.NewBool(boolean), nil
+	case stackitem.IntegerT:
+		var bi = new(big.Int)
+		if len(integer) > maxIntegerLen {
+			return nil, fmt.Errorf("integer is too long: %d", len(integer))
+		}
+		if integer != "" {
+			var ok bool
+			if bi, ok = bi.SetString(integer, 10); !ok {
+				return nil, fmt.Errorf("invalid integer %q", integer)
+			}
+		}
+		if err := stackitem.CheckIntegerSize(bi); err != nil {
+			return nil, err
+		}
+		return stackitem.NewBigInteger(bi), nil
+	case stackitem.ByteArrayT:
+		return stackitem.NewByteArray(bytes), nil
+	case stackitem.BufferT:
+		if bytes == nil {
+			bytes = []byte{}
+		}
+		return stackitem.NewBuffer(bytes), nil
+	case stackitem.ArrayT:
+		return stackitem.NewArray(items), nil
+	case stackitem.StructT:
+		return stackitem.NewStruct(items), nil
+	case stackitem.MapT:
+		m := stackitem.NewMap()
+		for _, e := range entries {
+			if err := stackitem.IsValidMapKey(e.Key); err != nil {
+				return nil, err
+			}
+			m.Add(e.Key, e.Value)
+		}
+		return m, nil
+	default:
+		return nil, fmt.Errorf("%w: type %s", stackitem.ErrUnserializable, t)
+	}
+}
+
+func unmarshalSubItem(f field, maxDepth int) (stackitem.Item, error) {
+	data, err := f.bytes()
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalStackItem(data, maxDepth)
+}
+
+func unmarshalMapEntry(f field, maxDepth int) (stackitem.MapElement, error) {
+	var e stackitem.MapElement
+	data, err := f.bytes()
+	if err != nil {
+		return e, err
+	}
+	err = parse(data, func(f field) error {
+		var err error
+		switch f.num {
+		case 1:
+			e.Key, err = unmarshalSubItem(f, maxDepth)
+		case 2:
+			e.Value, err = unmarshalSubItem(f, maxDepth)
+		}
+		return err
+	})
+	if err == nil && (e.Key == nil || e.Value == nil) {
+		err = errors.New("incomplete map entry")
+	}
+	return e, err
+}
@@ -0,0 +1,415 @@
+package protobuf
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// MarshalTransaction encodes the transaction into the Transaction message.
+func MarshalTransaction(tx *transaction.Transaction) ([]byte, error) {
+	var b []byte
+	b = appendBytes(b, 1, tx.Hash().BytesBE())
+	b = appendVarint(b, 2, uint64(tx.Version))
+	b = appendVarint(b, 3, uint64(tx.Nonce))
+	b = appendVarint(b, 4, uint64(tx.SystemFee))
+	b = appendVarint(b, 5, uint64(tx.NetworkFee))
+	b = appendVarint(b, 6, uint64(tx.ValidUntilBlock))
+	for i := range tx.Signers {
+		s, err := marshalSigner(&tx.Signers[i])
+		if err != nil {
+			return nil, fmt.Errorf("signer %d: %w", i, err)
+		}
+		b = appendMessage(b, 7, s)
+	}
+	for i := range tx.Attributes {
+		a, err := marshalAttribute(&tx.Attributes[i])
+		if err != nil {
+			return nil, fmt.Errorf("attribute %d: %w", i, err)
+		}
+		b = appendMessage(b, 8, a)
+	}
+	b = appendBytes(b, 9, tx.Script)
+	for i := range tx.Scripts {
+		b = appendMessage(b, 10, marshalWitness(&tx.Scripts[i]))
+	}
+	return b, nil
+}
+
+// UnmarshalTransaction decodes the transaction from the Transaction message.
+// ErrHashMismatch is returned if the message contains a hash different from
+// the one of the decoded transaction.
+func UnmarshalTransaction(data []byte) (*transaction.Transaction, error) {
+	var (
+		hash []byte
+		tx   = &transaction.Transaction{
+			Attributes: []transaction.Attribute{},
+			Signers:    []transaction.Signer{},
+			Scripts:    []transaction.Witness{},
+		}
+	)
+	err := parse(data, func(f field) error {
+		var err error
+		switch f.num {
+		case 1:
+			hash, err = f.bytes()
+		case 2:
+			tx.Version, err = f.uint8()
+		case 3:
+			tx.Nonce, err = f.uint32()
+		case 4:
+			tx.SystemFee, err = f.int64()
+		case 5:
+			tx.NetworkFee, err = f.int64()
+		case 6:
+			tx.ValidUntilBlock, err = f.uint32()
+		case 7:
+			var s transaction.Signer
+			s, err = unmarshalSigner(f)
+			tx.Signers = append(tx.Signers, s)
+		case 8:
+			var a transaction.Attribute
+			a, err = unmarshalAttribute(f)
+			tx.Attributes = append(tx.Attributes, a)
+		case 9:
+			tx.Script, err = f.clonedBytes()
+		case 10:
+			var w transaction.Witness
+			w, err = unmarshalWitness(f)
+			tx.Scripts = append(tx.Scripts, w)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(hash) != 0 && string(hash) != string(tx.Hash().BytesBE()) {
+		return nil, fmt.Errorf("%w: transaction %s", ErrHashMismatch, tx.Hash().StringLE())
+	}
+	return tx, nil
+}
+
+func marshalWitness(w *transaction.Witness) []byte {
+	var b []byte
+	b = appendBytes(b, 1, w.InvocationScript)
+	b = appendBytes(b, 2, w.VerificationScript)
+	return b
+}
+
+func unmarshalWitness(f field) (transaction.Witness, error) {
+	var w transaction.Witness
+	data, err := f.bytes()
+	if err != nil {
+		return w, err
+	}
+	err = parse(data, func(f field) error {
+		var err error
+		switch f.num {
+		case 1:
+			w.InvocationScript, err = f.clonedBytes()
+		case 2:
+			w.VerificationScript, err = f.clonedBytes()
+		}
+		return err
+	})
+	return w, err
+}
+
+func marshalSigner(s *transaction.Signer) ([]byte, error) {
+	var b []byte
+	b = appendBytes(b, 1, s.Account.BytesBE())
+	b = appendVarint(b, 2, uint64(s.Scopes))
+	for _, c := range s.AllowedContracts {
+		b = appendMessage(b, 3, c.BytesBE())
+	}
+	for _, g := range s.AllowedGroups {
+		b = appendMessage(b, 4, g.Bytes())
+	}
+	for i := range s.Rules {
+		c, err := marshalCondition(s.Rules[i].Condition)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		var r []byte
+		r = appendVarint(r, 1, uint64(s.Rules[i].Action))
+		r = appendMessage(r, 2, c)
+		b = appendMessage(b, 5, r)
+	}
+	return b, nil
+}
+
+func unmarshalSigner(f field) (transaction.Signer, error) {
+	var s transaction.Signer
+	data, err := f.bytes()
+	if err != nil {
+		return s, err
+	}
+	err = parse(data, func(f field) error {
+		var err error
+		switch f.num {
+		case 1:
+			s.Account, err = f.uint160()
+		case 2:
+			var scopes uint8
+			scopes, err = f.uint8()
+			s.Scopes = transaction.WitnessScope(scopes)
+		case 3:
+			var c util.Uint160
+			c, err = f.uint160()
+			s.AllowedContracts = append(s.AllowedContracts, c)
+		case 4:
+			var g *keys.PublicKey
+			g, err = f.publicKey()
+			s.AllowedGroups = append(s.AllowedGroups, g)
+		case 5:
+			var r transaction.WitnessRule
+			r, err = unmarshalRule(f)
+			s.Rules = append(s.Rules, r)
+		}
+		return err
+	})
+	return s, err
+}
+
+func unmarshalRule(f field) (transaction.WitnessRule, error) {
+	var r transaction.WitnessRule
+	data, err := f.bytes()
+	if err != nil {
+		return r, err
+	}
+	err = parse(data, func(f field) error {
+		var err error
+		switch f.num {
+		case 1:
+			var action uint8
+			action, err = f.uint8()
+			r.Action = transaction.WitnessAction(action)
+		case 2:
+			r.Condition, err = unmarshalCondition(f, transaction.MaxConditionNesting)
+		}
+		return err
+	})
+	if err == nil && r.Condition == nil {
+		err = errors.New("rule without condition")
+	}
+	return r, err
+}
+
+func marshalCondition(c transaction.WitnessCondition) ([]byte, error) {
+	var b []byte
+	b = appendVarint(b, 1, uint64(c.Type()))
+	switch c := c.(type) {
+	case *transaction.ConditionBoolean:
+		b = appendBool(b, 2, bool(*c))
+	case *transaction.ConditionNot:
+		sub, err := marshalCondition(c.Condition)
+		if err != nil {
+			return nil, err
+		}
+		b = appendMessage(b, 3, sub)
+	case *transaction.ConditionAnd:
+		return appendConditions(b, *c)
+	case *transaction.ConditionOr:
+		return appendConditions(b, *c)
+	case *transaction.ConditionScriptHash:
+		b = appendBytes(b, 4, (*util.Uint160)(c).BytesBE())
+	case *transaction.ConditionCalledByContract:
+		b = appendBytes(b, 4, (*util.Uint160)(c).BytesBE())
+	case *transaction.ConditionGroup:
+		b = appendBytes(b, 5, (*keys.PublicKey)(c).Bytes())
+	case *transaction.ConditionCalledByGroup:
+		b = appendBytes(b, 5, (*keys.PublicKey)(c).Bytes())
+	case *transaction.ConditionCalledByEntry:
+	default:
+		return nil, fmt.Errorf("unknown witness condition type %s", c.Type())
+	}
+	return b, nil
+}
+
+func appendConditions(b []byte, cs []transaction.WitnessCondition) ([]byte, error) {
+	for _, c := range cs {
+		sub, err := marshalCondition(c)
+		if err != nil {
+			return nil, err
+		}
+		b = appendMessage(b, 3, sub)
+	}
+	return b, nil
+}
+
+func unmarshalCondition(f field, maxDepth int) (transaction.WitnessCondition, error) {
+	if maxDepth <= 0 {
+		return nil, errors.New("too many witness condition nesting levels")
+	}
+	data, err := f.bytes()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		typ      uint8
+		boolean  bool
+		subs     []transaction.WitnessCondition
+		hash     field
+		group    field
+		hasHash  bool
+		hasGroup bool
+	)
+	err = parse(data, func(f field) error {
+		var err error
+		switch f.num {
+		case 1:
+			typ, err = f.uint8()
+		case 2:
+			boolean, err = f.bool()
+		case 3:
+			var sub transaction.WitnessCondition
+			sub, err = unmarshalCondition(f, maxDepth-1)
+			subs = append(subs, sub)
+		case 4:
+			hash, hasHash = f, true
+		case 5:
+			group, hasGroup = f, true
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch t := transaction.WitnessConditionType(typ); t {
+	case transaction.WitnessBoolean:
+		return (*transaction.ConditionBoolean)(&boolean), nil
+	case transaction.WitnessNot:
+		if len(subs) != 1 {
+			return nil, fmt.Errorf("expected 1 expression for Not condition, got %d", len(subs))
+		}
+		return &transaction.ConditionNot{Condition: subs[0]}, nil
+	case transaction.WitnessAnd:
+		return (*transaction.ConditionAnd)(&subs), nil
+	case transaction.WitnessOr:
+		return (*transaction.ConditionOr)(&subs), nil
+	case transaction.WitnessScriptHash, transaction.WitnessCalledByContract:
+		if !hasHash {
+			return nil, fmt.Errorf("%s condition without hash", t)
+		}
+		h, err := hash.uint160()
+		if err != nil {
+			return nil, err
+		}
+		if t == transaction.WitnessScriptHash {
+			return (*transaction.ConditionScriptHash)(&h), nil
+		}
+		return (*transaction.ConditionCalledByContract)(&h), nil
+	case transaction.WitnessGroup, transaction.WitnessCalledByGroup:
+		if !hasGroup {
+			return nil, fmt.Errorf("%s condition without group", t)
+		}
+		g, err := group.publicKey()
+		if err != nil {
+			return nil, err
+		}
+		if t == transaction.WitnessGroup {
+			return (*transaction.ConditionGroup)(g), nil
+		}
+		return (*transaction.ConditionCalledByGroup)(g), nil
+	case transaction.WitnessCalledByEntry:
+		return new(transaction.ConditionCalledByEntry), nil
+	default:
+		return nil, fmt.Errorf("unknown witness condition type %d", typ)
+	}
+}
+
+func marshalAttribute(a *transaction.Attribute) ([]byte, error) {
+	var b []byte
+	b = appendVarint(b, 1, uint64(a.Type))
+	switch v := a.Value.(type) {
+	case nil:
+		if a.Type != transaction.HighPriority {
+			return nil, fmt.Errorf("no value for %s attribute", a.Type)
+		}
+	case *transaction.OracleResponse:
+		b = appendVarint(b, 2, v.ID)
+		b = appendVarint(b, 3, uint64(v.Code))
+		b = appendBytes(b, 4, v.Result)
+	case *transaction.NotValidBefore:
+		b = appendVarint(b, 5, uint64(v.Height))
+	case *transaction.Conflicts:
+		b = appendBytes(b, 6, v.Hash.BytesBE())
+	case *transaction.NotaryAssisted:
+		b = appendVarint(b, 7, uint64(v.NKeys))
+	case *transaction.Reserved:
+		b = appendBytes(b, 8, v.Value)
+	default:
+		return nil, fmt.Errorf("unknown attribute value %T", v)
+	}
+	return b, nil
+}
+
+func unmarshalAttribute(f field) (transaction.Attribute, error) {
+	var (
+		a        transaction.Attribute
+		typ      uint8
+		oracle   transaction.OracleResponse
+		height   uint32
+		conflict transaction.Conflicts
+		nkeys    uint8
+		reserved transaction.Reserved
+	)
+	data, err := f.bytes()
+	if err != nil {
+		return a, err
+	}
+	err = parse(data, func(f field) error {
+		var err error
+		switch f.num {
+		case 1:
+			typ, err = f.uint8()
+		case 2:
+			oracle.ID, err = f.uint64()
+		case 3:
+			var code uint8
+			code, err = f.uint8()
+			oracle.Code = transaction.OracleResponseCode(code)
+		case 4:
+			oracle.Result, err = f.clonedBytes()
+		case 5:
+			height, err = f.uint32()
+		case 6:
+			conflict.Hash, err = f.uint256()
+		case 7:
+			nkeys, err = f.uint8()
+		case 8:
+			reserved.Value, err = f.clonedBytes()
+		}
+		return err
+	})
+	if err != nil {
+		return a, err
+	}
+	a.Type = transaction.AttrType(typ)
+	switch a.Type {
+	case transaction.HighPriority:
+	case transaction.OracleResponseT:
+		if oracle.Result == nil {
+			oracle.Result = []byte{}
+		}
+		a.Value = &oracle
+	case transaction.NotValidBeforeT:
+		a.Value = &transaction.NotValidBefore{Height: height}
+	case transaction.ConflictsT:
+		a.Value = &conflict
+	case transaction.NotaryAssistedT:
+		a.Value = &transaction.NotaryAssisted{NKeys: nkeys}
+	default:
+		if a.Type < transaction.ReservedLowerBound {
+			return a, fmt.Errorf("unknown attribute type 0x%02x", typ)
+		}
+		if reserved.Value == nil {
+			reserved.Value = []byte{}
+		}
+		a.Value = &reserved
+	}
+	return a, nil
+}
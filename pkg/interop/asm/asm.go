@@ -0,0 +1,54 @@
+/*
+Package asm provides a way to emit NeoVM instructions directly from the smart
+contract code. It's intended for hot code paths where the code generated by
+the compiler can be improved manually (or for instructions the compiler
+doesn't use), so use it with care: the compiler doesn't check the stack
+effect of emitted instructions.
+
+Functions of this package are compiler intrinsics, they're not calls, so Push
+evaluates its arguments and leaves them on the stack, Emit and Syscall emit a
+single instruction and Pop takes the result of previous instructions from the
+stack. Everything pushed onto the stack must be popped (or consumed by the
+emitted instructions) before the end of the block, the compiler relies on
+stack state, for example:
+
+	func add(a, b int) int {
+		asm.Push(a, b)
+		asm.Emit("ADD")
+		return asm.Pop().(int)
+	}
+
+Instructions affecting control flow or slots managed by the compiler (jumps,
+calls, try blocks, RET, INITSLOT and INITSSLOT) can't be emitted. Contract
+calls and notifications can't be emitted as well (both via CALLT and SYSCALL)
+since they need to be visible for manifest and permission checks, use
+contract.Call and runtime.Notify for that.
+*/
+package asm
+
+// Emit emits the given opcode (like "ADD") with the given operand that is
+// expected to be of the proper length for this opcode (for example, one byte
+// for "ISTYPE" or data length prefix followed by data for "PUSHDATA1"). Both
+// opcode and operand bytes must be constants.
+func Emit(op string, operand ...byte) {
+}
+
+// Syscall emits SYSCALL instruction for the system call with the given name
+// (like "System.Runtime.GetTime") which must be a constant. It doesn't check
+// or push any parameters, use Push for that.
+func Syscall(name string) {
+}
+
+// Push evaluates the given values and pushes them onto the stack in the order
+// given, so the last one is at the top of the stack.
+func Push(values ...any) {
+}
+
+// Pop returns the item at the top of the stack. It doesn't emit any code,
+// the item must be pushed onto the stack by previous instructions. Use it in
+// assignments and return statements only, other parts of complex expressions
+// (like other function call arguments) can be on the stack at the time it's
+// evaluated.
+func Pop() any {
+	return nil
+}
@@ -0,0 +1,68 @@
+/*
+Package budget provides GAS budgeting utilities for contracts. They're based on
+`System.Runtime.GasLeft` syscall and allow long-running contracts (iterating
+over storage for example) to stop gracefully before running out of GAS instead
+of FAULTing in the middle of some state change. Notice that unlimited executions
+(like test invocations without GAS limit) always have enough GAS.
+*/
+package budget
+
+import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+
+// Guard is a loop guard tracking the maximum amount of GAS spent by a single
+// loop iteration. It allows to stop iterating when the remaining GAS is not
+// enough for one more iteration of the same cost, keeping the specified
+// reserve for the code following the loop.
+type Guard struct {
+	reserve int
+	last    int
+	maxStep int
+}
+
+// HasGas returns true if at least minimum amount of GAS is available for the
+// current execution.
+func HasGas(minimum int) bool {
+	left := runtime.GasLeft()
+	return left < 0 || left >= minimum
+}
+
+// EnsureGas panics if less than minimum amount of GAS is available for the
+// current execution. It's useful to check for the GAS required by some
+// operation in advance, so that it's not started at all when there is not
+// enough GAS to complete it.
+func EnsureGas(minimum int) {
+	if !HasGas(minimum) {
+		panic("insufficient GAS")
+	}
+}
+
+// NewGuard returns a new loop Guard keeping the reserve amount of GAS. It
+// should be created right before the loop, so that the cost of the first
+// iteration is properly accounted for.
+func NewGuard(reserve int) *Guard {
+	return &Guard{
+		reserve: reserve,
+		last:    runtime.GasLeft(),
+	}
+}
+
+// Next returns true if the next loop iteration can be performed, that is if
+// the remaining GAS is enough for the most expensive iteration seen so far
+// plus the reserve. It should be called once at the beginning of each
+// iteration, like:
+//
+//	g := budget.NewGuard(reserve)
+//	for iterator.Next(it) && g.Next() {
+//		...
+//	}
+func (g *Guard) Next() bool {
+	left := runtime.GasLeft()
+	if left < 0 {
+		return true
+	}
+	if step := g.last - left; step > g.maxStep {
+		g.maxStep = step
+	}
+	g.last = left
+	return left-g.maxStep >= g.reserve
+}
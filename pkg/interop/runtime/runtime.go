@@ -70,6 +70,17 @@ func Notify(name string, args ...any) {
 	neogointernal.Syscall2NoReturn("System.Runtime.Notify", name, args)
 }
 
+// NotifyEvent is a typed version of Notify, it sends a notification with the
+// given name and arguments taken from fields of the given structure (in order
+// of their declaration). The name must be a constant string. Unlike Notify it
+// never converts arguments to types of event parameters specified in the
+// contract configuration, instead the compiler checks that the number and
+// types of structure fields match them exactly. This function uses
+// `System.Runtime.Notify` syscall.
+func NotifyEvent(name string, event any) {
+	neogointernal.Syscall2NoReturn("System.Runtime.Notify", name, event)
+}
+
 // GetAddressVersion returns the address version of the current protocol. The
 // address version represents the byte used to prepend to Neo addresses when
 // encoding them. The default value for Neo3 is 53 (0x35). This function uses
@@ -103,8 +114,9 @@ func GetTrigger() byte {
 	return neogointernal.Syscall0("System.Runtime.GetTrigger").(byte)
 }
 
-// GasLeft returns the amount of gas available for the current execution.
-// This function uses `System.Runtime.GasLeft` syscall.
+// GasLeft returns the amount of gas available for the current execution
+// (-1 for unlimited executions), see lib/budget package for GAS budgeting
+// helpers based on it. This function uses `System.Runtime.GasLeft` syscall.
 func GasLeft() int {
 	return neogointernal.Syscall0("System.Runtime.GasLeft").(int)
 }
@@ -1,6 +1,8 @@
 package neorpc
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 )
@@ -10,6 +12,24 @@ type Error struct {
 	Code    int64  `json:"code"`
 	Message string `json:"message"`
 	Data    string `json:"data,omitempty"`
+	// Verification contains witness check failure details (if any), see
+	// VerificationFailure. It's transmitted as a part of the "data" member
+	// along with Data, see MarshalJSON.
+	Verification *VerificationFailure `json:"-"`
+}
+
+// errorAux is used for JSON marshaling/unmarshaling of Error.
+type errorAux struct {
+	Code    int64           `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// verificationData is a structured "data" member of Error containing witness
+// check failure details.
+type verificationData struct {
+	Message string `json:"message"`
+	*VerificationFailure
 }
 
 // Standard RPC error codes defined by the JSON-RPC 2.0 specification.
@@ -138,9 +158,40 @@ const (
 	ErrExecutionFailedCode = -608
 )
 
+// ErrorCategory is a class of RPC errors determined by the error code range.
+type ErrorCategory byte
+
+// Error categories.
+const (
+	// CategoryUnknown is used for codes not belonging to any known range.
+	CategoryUnknown ErrorCategory = iota
+	// CategoryJSONRPC is used for standard JSON-RPC 2.0 errors (-32768...-32000).
+	CategoryJSONRPC
+	// CategoryMissingItem is used for missing items errors (-199...-100).
+	CategoryMissingItem
+	// CategoryWallet is used for wallet errors (-399...-300).
+	CategoryWallet
+	// CategoryVerification is used for inventory verification errors (-599...-500).
+	CategoryVerification
+	// CategoryService is used for node configuration and service errors (-699...-600).
+	CategoryService
+)
+
 var (
+	// ErrParse represents an error with code [BadRequestCode].
+	// Invalid JSON was received by the server.
+	ErrParse = NewErrorWithCode(BadRequestCode, "Parse error")
+	// ErrInvalidRequest represents an error with code [InvalidRequestCode].
+	// The JSON sent is not a valid request object.
+	ErrInvalidRequest = NewErrorWithCode(InvalidRequestCode, "Invalid request")
+	// ErrMethodNotFound represents an error with code [MethodNotFoundCode].
+	// The method does not exist or is not available.
+	ErrMethodNotFound = NewErrorWithCode(MethodNotFoundCode, "Method not found")
 	// ErrInvalidParams represents a generic "Invalid params" error.
 	ErrInvalidParams = NewInvalidParamsError("Invalid params")
+	// ErrInternalServer represents an error with code [InternalServerErrorCode].
+	// Internal RPC server error.
+	ErrInternalServer = NewErrorWithCode(InternalServerErrorCode, "Internal error")
 
 	// ErrUnknownBlock represents an error with code [ErrUnknownBlockCode].
 	// Call that accepts as a parameter or searches for a header or a block as a part of its job can't find it.
@@ -255,6 +306,58 @@ var (
 	ErrExecutionFailed = NewErrorWithCode(ErrExecutionFailedCode, "Execution failed")
 )
 
+// knownErrors contains all standard errors by their codes.
+var knownErrors = make(map[int64]*Error)
+
+func init() {
+	for _, e := range []*Error{
+		ErrParse, ErrInvalidRequest, ErrMethodNotFound, ErrInvalidParams, ErrInternalServer,
+
+		ErrUnknownBlock, ErrUnknownContract, ErrUnknownTransaction, ErrUnknownStorageItem,
+		ErrUnknownScriptContainer, ErrUnknownStateRoot, ErrUnknownSession, ErrUnknownIterator,
+		ErrUnknownHeight,
+
+		ErrInsufficientFundsWallet, ErrWalletFeeLimit, ErrNoOpenedWallet, ErrWalletNotFound,
+		ErrWalletNotSupported,
+
+		ErrVerificationFailed, ErrAlreadyExists, ErrMempoolCapReached, ErrAlreadyInPool,
+		ErrInsufficientNetworkFee, ErrPolicyFailed, ErrInvalidScript, ErrInvalidAttribute,
+		ErrInvalidSignature, ErrInvalidSize, ErrExpiredTransaction, ErrInsufficientFunds,
+		ErrInvalidVerificationFunction,
+
+		ErrSessionsDisabled, ErrOracleDisabled, ErrOracleRequestFinished, ErrOracleRequestNotFound,
+		ErrOracleNotDesignatedNode, ErrUnsupportedState, ErrInvalidProof, ErrExecutionFailed,
+	} {
+		knownErrors[e.Code] = e
+	}
+}
+
+// ErrorByCode returns the standard error (like [ErrInsufficientFunds]) with
+// the given code or nil if the code is not known. Returned error must not be
+// modified.
+func ErrorByCode(code int64) *Error {
+	return knownErrors[code]
+}
+
+// MapError extracts *Error from the given error chain, it returns nil if
+// there is none. Errors with known codes and no message get the standard one,
+// so that the result is always meaningful. Use errors.Is with standard errors
+// (like [ErrInsufficientFunds]) to check for the specific code or
+// [Error.Category] to check for the class of errors.
+func MapError(err error) *Error {
+	var e *Error
+	if !errors.As(err, &e) {
+		return nil
+	}
+	known := ErrorByCode(e.Code)
+	if known == nil || len(e.Message) != 0 {
+		return e
+	}
+	res := *e
+	res.Message = known.Message
+	return &res
+}
+
 // NewError is an Error constructor that takes Error contents from its parameters.
 func NewError(code int64, message string, data string) *Error {
 	return &Error{
@@ -306,6 +409,67 @@ func WrapErrorWithData(e *Error, data string) *Error {
 	return NewError(e.Code, e.Message, data)
 }
 
+// WrapErrorWithVerification returns copy of the given error with the specified
+// data and witness check failure details. It does not modify the source error.
+func WrapErrorWithVerification(e *Error, data string, v *VerificationFailure) *Error {
+	res := NewError(e.Code, e.Message, data)
+	res.Verification = v
+	return res
+}
+
+// MarshalJSON implements the json.Marshaler interface. Data is encoded as
+// a string "data" member unless Verification is set, in which case "data" is
+// an object with "message" field containing Data and witness check failure
+// details (see VerificationFailure).
+func (e *Error) MarshalJSON() ([]byte, error) {
+	var (
+		aux = errorAux{Code: e.Code, Message: e.Message}
+		v   any
+		err error
+	)
+	switch {
+	case e.Verification != nil:
+		v = verificationData{Message: e.Data, VerificationFailure: e.Verification}
+	case len(e.Data) != 0:
+		v = e.Data
+	}
+	if v != nil {
+		aux.Data, err = json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. String "data" is
+// stored in Data, witness check failure details are stored in Verification
+// and any other "data" value is stored in Data as is (in JSON form).
+func (e *Error) UnmarshalJSON(data []byte) error {
+	var aux errorAux
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*e = Error{Code: aux.Code, Message: aux.Message}
+	raw := bytes.TrimSpace(aux.Data)
+	switch {
+	case len(raw) == 0 || bytes.Equal(raw, []byte("null")):
+	case raw[0] == '"':
+		return json.Unmarshal(raw, &e.Data)
+	case raw[0] == '{':
+		var vd verificationData
+		if json.Unmarshal(raw, &vd) == nil && vd.VerificationFailure != nil && vd.Reason != "" {
+			e.Data = vd.Message
+			e.Verification = vd.VerificationFailure
+			return nil
+		}
+		fallthrough
+	default:
+		e.Data = string(raw)
+	}
+	return nil
+}
+
 // Error implements the error interface.
 func (e *Error) Error() string {
 	if len(e.Data) == 0 {
@@ -314,6 +478,23 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("%s (%d) - %s", e.Message, e.Code, e.Data)
 }
 
+// Category returns the category of the error based on its code.
+func (e *Error) Category() ErrorCategory {
+	switch c := e.Code; {
+	case c >= -32768 && c <= -32000:
+		return CategoryJSONRPC
+	case c >= -199 && c <= -100:
+		return CategoryMissingItem
+	case c >= -399 && c <= -300:
+		return CategoryWallet
+	case c >= -599 && c <= -500:
+		return CategoryVerification
+	case c >= -699 && c <= -600:
+		return CategoryService
+	}
+	return CategoryUnknown
+}
+
 // Is denotes whether the error matches the target one.
 func (e *Error) Is(target error) bool {
 	var clTarget *Error
@@ -1,11 +1,13 @@
 package neorpc
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/fs"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/stretchr/testify/require"
 )
 
@@ -33,3 +35,96 @@ func TestError_ErrorsIs(t *testing.T) {
 	// Invalid target type:
 	require.False(t, errors.Is(wrapped, NewInvalidParamsError("invalid params")))
 }
+
+func TestError_Verification(t *testing.T) {
+	err := WrapErrorWithVerification(ErrInvalidSignature, "witness #1: invalid signature", &VerificationFailure{
+		Reason:      VerificationFailureInvalidSignature,
+		Witness:     1,
+		ScriptHash:  util.Uint160{1, 2, 3},
+		GasConsumed: 1000,
+	})
+	require.Nil(t, ErrInvalidSignature.Verification)
+	require.True(t, errors.Is(err, ErrInvalidSignature))
+
+	data, jErr := json.Marshal(err)
+	require.NoError(t, jErr)
+	require.JSONEq(t, `{"code":-508,"message":"Invalid signature","data":{"message":"witness #1: invalid signature",
+"reason":"invalidsignature","witness":1,"scripthash":"0x0000000000000000000000000000000000030201","gasconsumed":"1000"}}`, string(data))
+
+	actual := new(Error)
+	require.NoError(t, json.Unmarshal(data, actual))
+	require.Equal(t, err, actual)
+
+	data, jErr = json.Marshal(ErrInvalidSignature)
+	require.NoError(t, jErr)
+	require.JSONEq(t, `{"code":-508,"message":"Invalid signature"}`, string(data))
+
+	data, jErr = json.Marshal(WrapErrorWithData(ErrInvalidSignature, "some data"))
+	require.NoError(t, jErr)
+	require.JSONEq(t, `{"code":-508,"message":"Invalid signature","data":"some data"}`, string(data))
+}
+
+func TestError_UnmarshalJSON(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		data     string
+		expected *Error
+	}{
+		{"no data", `{"code":-508,"message":"Invalid signature"}`, NewError(-508, "Invalid signature", "")},
+		{"null data", `{"code":-508,"message":"Invalid signature","data":null}`, NewError(-508, "Invalid signature", "")},
+		{"string data", `{"code":-508,"message":"Invalid signature","data":"some data"}`, NewError(-508, "Invalid signature", "some data")},
+		{"object data", `{"code":-508,"message":"Invalid signature","data":{"some":"data"}}`, NewError(-508, "Invalid signature", `{"some":"data"}`)},
+		{"array data", `{"code":-508,"message":"Invalid signature","data":[1,2]}`, NewError(-508, "Invalid signature", `[1,2]`)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := new(Error)
+			require.NoError(t, json.Unmarshal([]byte(tc.data), actual))
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+	require.Error(t, json.Unmarshal([]byte(`{"code":"-508"}`), new(Error)))
+}
+
+func TestErrorByCode(t *testing.T) {
+	require.Equal(t, ErrInsufficientFunds, ErrorByCode(ErrInsufficientFundsCode))
+	require.Equal(t, ErrMethodNotFound, ErrorByCode(MethodNotFoundCode))
+	require.Nil(t, ErrorByCode(-100500))
+
+	for code, e := range knownErrors {
+		require.Equal(t, code, e.Code)
+		require.NotEmpty(t, e.Message)
+		require.NotEqual(t, CategoryUnknown, e.Category(), e.Message)
+	}
+}
+
+func TestError_Category(t *testing.T) {
+	require.Equal(t, CategoryJSONRPC, ErrParse.Category())
+	require.Equal(t, CategoryJSONRPC, NewError(-32000, "server error", "").Category())
+	require.Equal(t, CategoryMissingItem, ErrUnknownHeight.Category())
+	require.Equal(t, CategoryWallet, ErrNoOpenedWallet.Category())
+	require.Equal(t, CategoryVerification, ErrPolicyFailed.Category())
+	require.Equal(t, CategoryService, ErrExecutionFailed.Category())
+	require.Equal(t, CategoryUnknown, NewError(1, "custom", "").Category())
+}
+
+func TestMapError(t *testing.T) {
+	require.Nil(t, MapError(nil))
+	require.Nil(t, MapError(errors.New("some error")))
+
+	// Server message is kept.
+	err := NewError(ErrInsufficientFundsCode, "Not enough GAS", "some data")
+	actual := MapError(fmt.Errorf("wrapped: %w", err))
+	require.Equal(t, err, actual)
+	require.ErrorIs(t, actual, ErrInsufficientFunds)
+	require.NotErrorIs(t, actual, ErrPolicyFailed)
+
+	// Standard message is used if there is none.
+	err = NewError(ErrPolicyFailedCode, "", "blocked account")
+	actual = MapError(err)
+	require.Equal(t, NewError(ErrPolicyFailedCode, ErrPolicyFailed.Message, "blocked account"), actual)
+	require.Empty(t, err.Message)
+
+	// Unknown codes are left as is.
+	err = NewError(-100500, "", "")
+	require.Equal(t, err, MapError(err))
+}
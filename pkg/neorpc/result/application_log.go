@@ -15,6 +15,9 @@ type ApplicationLog struct {
 	Container     util.Uint256
 	IsTransaction bool
 	Executions    []state.Execution
+	// Truncated is set if some notifications of any execution are not
+	// included into the log because of paging (see Page).
+	Truncated bool
 }
 
 // applicationLogAux is an auxiliary struct for ApplicationLog JSON marshalling.
@@ -22,12 +25,14 @@ type applicationLogAux struct {
 	TxHash     *util.Uint256     `json:"txid,omitempty"`
 	BlockHash  *util.Uint256     `json:"blockhash,omitempty"`
 	Executions []json.RawMessage `json:"executions"`
+	Truncated  bool              `json:"truncated,omitempty"`
 }
 
 // MarshalJSON implements the json.Marshaler interface.
 func (l ApplicationLog) MarshalJSON() ([]byte, error) {
 	result := &applicationLogAux{
 		Executions: make([]json.RawMessage, len(l.Executions)),
+		Truncated:  l.Truncated,
 	}
 	if l.IsTransaction {
 		result.TxHash = &l.Container
@@ -57,6 +62,7 @@ func (l *ApplicationLog) UnmarshalJSON(data []byte) error {
 	} else {
 		return errors.New("no block or transaction hash")
 	}
+	l.Truncated = aux.Truncated
 	l.Executions = make([]state.Execution, len(aux.Executions))
 	for i := range l.Executions {
 		err := json.Unmarshal(aux.Executions[i], &l.Executions[i])
@@ -82,3 +88,18 @@ func NewApplicationLog(hash util.Uint256, aers []state.AppExecResult, trig trigg
 	}
 	return result
 }
+
+// Page leaves at most limit (no limit if 0) notifications starting from the
+// given offset in every execution of the log, Truncated is set if there are
+// more notifications after the page in any of them.
+func (l *ApplicationLog) Page(offset, limit int) {
+	for i := range l.Executions {
+		events := l.Executions[i].Events
+		events = events[min(offset, len(events)):]
+		if limit != 0 && len(events) > limit {
+			events = events[:limit]
+			l.Truncated = true
+		}
+		l.Executions[i].Events = events
+	}
+}
@@ -0,0 +1,57 @@
+package result
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplicationLog_Page(t *testing.T) {
+	newLog := func(counts ...int) *ApplicationLog {
+		l := &ApplicationLog{Container: random.Uint256(), IsTransaction: true}
+		for _, n := range counts {
+			events := make([]state.NotificationEvent, n)
+			for i := range events {
+				events[i] = state.NotificationEvent{
+					ScriptHash: random.Uint160(),
+					Name:       "Event",
+					Item:       stackitem.NewArray([]stackitem.Item{stackitem.Make(i)}),
+				}
+			}
+			l.Executions = append(l.Executions, state.Execution{
+				Trigger: trigger.Application,
+				VMState: vmstate.Halt,
+				Stack:   []stackitem.Item{},
+				Events:  events,
+			})
+		}
+		return l
+	}
+
+	l := newLog(5, 2)
+	exp := newLog()
+	exp.Container = l.Container
+	exp.Executions = []state.Execution{l.Executions[0], l.Executions[1]}
+	exp.Executions[0].Events = l.Executions[0].Events[1:3]
+	exp.Executions[1].Events = l.Executions[1].Events[1:]
+	exp.Truncated = true
+	l.Page(1, 2)
+	require.Equal(t, exp, l)
+
+	data, err := json.Marshal(l)
+	require.NoError(t, err)
+	actual := new(ApplicationLog)
+	require.NoError(t, json.Unmarshal(data, actual))
+	require.True(t, actual.Truncated)
+
+	l = newLog(3)
+	l.Page(3, 0)
+	require.Empty(t, l.Executions[0].Events)
+	require.False(t, l.Truncated)
+}
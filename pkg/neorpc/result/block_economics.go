@@ -0,0 +1,40 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// BlockEconomics is a result of getblockeconomics RPC call. It summarizes GAS
+// flows of a block based on the GAS Transfer notifications emitted by native
+// contracts during block and transaction executions. All amounts are in GAS
+// fractions.
+type BlockEconomics struct {
+	Hash  util.Uint256 `json:"hash"`
+	Index uint32       `json:"index"`
+	// SystemFee is the sum of block transactions system fees.
+	SystemFee int64 `json:"sysfee,string"`
+	// NetworkFee is the sum of block transactions network fees.
+	NetworkFee int64 `json:"netfee,string"`
+	// Minted is the total amount of GAS minted in the block.
+	Minted int64 `json:"minted,string"`
+	// Burned is the total amount of GAS burned in the block.
+	Burned int64 `json:"burned,string"`
+	// BurnedFees is the amount of GAS burned as transaction fees (paid by
+	// transaction senders).
+	BurnedFees int64 `json:"burnedfees,string"`
+	// PrimaryReward is the network fee reward of the primary validator.
+	PrimaryReward *GASPayout `json:"primaryreward,omitempty"`
+	// CommitteeReward is the reward of the committee member.
+	CommitteeReward *GASPayout `json:"committeereward,omitempty"`
+	// NotaryRewards are the rewards of notary nodes for notary-assisted
+	// transactions.
+	NotaryRewards []GASPayout `json:"notaryrewards"`
+	// OracleRewards are the rewards of oracle nodes for oracle responses.
+	OracleRewards []GASPayout `json:"oraclerewards"`
+}
+
+// GASPayout is an amount of GAS minted to some account.
+type GASPayout struct {
+	Account util.Uint160 `json:"account"`
+	Amount  int64        `json:"amount,string"`
+}
@@ -0,0 +1,153 @@
+package result
+
+import (
+	"encoding/json"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+)
+
+// BlockExecutionSummary is a trimmed version of the application logs of
+// some block and all of its transactions. It contains execution results
+// without stacks and notification contents, so it's cheap to fetch and full
+// logs can be requested with getapplicationlog when needed.
+type BlockExecutionSummary struct {
+	Hash  util.Uint256 `json:"hash"`
+	Index uint32       `json:"index"`
+	// GasConsumed is the total amount of GAS consumed by all block and
+	// transaction executions.
+	GasConsumed int64 `json:"gasconsumed,string"`
+	// Notifications is the total number of notifications emitted by all
+	// block and transaction executions.
+	Notifications int `json:"notifications"`
+	// Executions are OnPersist and PostPersist block executions.
+	Executions []ExecutionSummary `json:"executions"`
+	// Transactions are transaction executions in the order of block
+	// transactions.
+	Transactions []TransactionExecutionSummary `json:"transactions"`
+}
+
+// ExecutionSummary is a trimmed version of state.Execution.
+type ExecutionSummary struct {
+	Trigger        trigger.Type
+	VMState        vmstate.State
+	GasConsumed    int64
+	Notifications  int
+	FaultException *string
+}
+
+// TransactionExecutionSummary is a trimmed version of the transaction
+// application log.
+type TransactionExecutionSummary struct {
+	Hash util.Uint256 `json:"txid"`
+	ExecutionSummary
+}
+
+// executionSummaryAux is an auxiliary struct for ExecutionSummary JSON
+// marshalling.
+type executionSummaryAux struct {
+	Trigger        string  `json:"trigger"`
+	VMState        string  `json:"vmstate"`
+	GasConsumed    int64   `json:"gasconsumed,string"`
+	Notifications  int     `json:"notifications"`
+	FaultException *string `json:"exception,omitempty"`
+}
+
+// transactionExecutionSummaryAux is an auxiliary struct for
+// TransactionExecutionSummary JSON marshalling.
+type transactionExecutionSummaryAux struct {
+	Hash util.Uint256 `json:"txid"`
+	executionSummaryAux
+}
+
+// NewExecutionSummary creates an ExecutionSummary from the given execution.
+func NewExecutionSummary(e *state.Execution) ExecutionSummary {
+	var exception *string
+	if e.FaultException != "" {
+		exception = &e.FaultException
+	}
+	return ExecutionSummary{
+		Trigger:        e.Trigger,
+		VMState:        e.VMState,
+		GasConsumed:    e.GasConsumed,
+		Notifications:  len(e.Events),
+		FaultException: exception,
+	}
+}
+
+// Add appends the given block or transaction execution to the summary and
+// updates the totals.
+func (s *BlockExecutionSummary) Add(aer *state.AppExecResult) {
+	es := NewExecutionSummary(&aer.Execution)
+	s.GasConsumed += es.GasConsumed
+	s.Notifications += es.Notifications
+	if aer.Trigger == trigger.Application {
+		s.Transactions = append(s.Transactions, TransactionExecutionSummary{
+			Hash:             aer.Container,
+			ExecutionSummary: es,
+		})
+	} else {
+		s.Executions = append(s.Executions, es)
+	}
+}
+
+func (e ExecutionSummary) toAux() executionSummaryAux {
+	return executionSummaryAux{
+		Trigger:        e.Trigger.String(),
+		VMState:        e.VMState.String(),
+		GasConsumed:    e.GasConsumed,
+		Notifications:  e.Notifications,
+		FaultException: e.FaultException,
+	}
+}
+
+func (e *ExecutionSummary) fromAux(aux *executionSummaryAux) error {
+	trig, err := trigger.FromString(aux.Trigger)
+	if err != nil {
+		return err
+	}
+	st, err := vmstate.FromString(aux.VMState)
+	if err != nil {
+		return err
+	}
+	e.Trigger = trig
+	e.VMState = st
+	e.GasConsumed = aux.GasConsumed
+	e.Notifications = aux.Notifications
+	e.FaultException = aux.FaultException
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e ExecutionSummary) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.toAux())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (e *ExecutionSummary) UnmarshalJSON(data []byte) error {
+	aux := new(executionSummaryAux)
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	return e.fromAux(aux)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t TransactionExecutionSummary) MarshalJSON() ([]byte, error) {
+	return json.Marshal(transactionExecutionSummaryAux{
+		Hash:                t.Hash,
+		executionSummaryAux: t.ExecutionSummary.toAux(),
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *TransactionExecutionSummary) UnmarshalJSON(data []byte) error {
+	aux := new(transactionExecutionSummaryAux)
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	t.Hash = aux.Hash
+	return t.ExecutionSummary.fromAux(&aux.executionSummaryAux)
+}
@@ -0,0 +1,33 @@
+package result
+
+type (
+	// BlockTimeStats represents a result of getblocktimestats RPC call.
+	BlockTimeStats struct {
+		// Height is the index of the last block.
+		Height uint32 `json:"height"`
+		// Timestamp is the last block timestamp in milliseconds.
+		Timestamp uint64 `json:"timestamp"`
+		// Intervals contains the intervals between the most recent blocks in
+		// milliseconds (newest first) for the smallest requested window.
+		Intervals []uint64 `json:"intervals"`
+		// Windows contains block time statistics for the requested windows
+		// in the order they were requested.
+		Windows []BlockTimeWindow `json:"windows"`
+	}
+
+	// BlockTimeWindow contains block time statistics (in milliseconds) for
+	// the given number of the most recent blocks.
+	BlockTimeWindow struct {
+		// Blocks is the requested window size.
+		Blocks int `json:"blocks"`
+		// Count is the number of intervals used, it's less than Blocks if
+		// the chain is not high enough.
+		Count   int    `json:"count"`
+		Average uint64 `json:"average"`
+		Min     uint64 `json:"min"`
+		Max     uint64 `json:"max"`
+		Median  uint64 `json:"median"`
+		P90     uint64 `json:"p90"`
+		P99     uint64 `json:"p99"`
+	}
+)
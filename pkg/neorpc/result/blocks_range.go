@@ -0,0 +1,13 @@
+package result
+
+// BlocksRange represents the result of `getblocksrange` RPC handler.
+type BlocksRange struct {
+	// Start is the index of the first block.
+	Start uint32 `json:"start"`
+	// Count is the number of blocks returned, it can be less than the
+	// requested one.
+	Count uint32 `json:"count"`
+	// Blocks contains serialized blocks each prefixed with its size (4
+	// bytes, little-endian), the same format is used by chain dumps.
+	Blocks []byte `json:"blocks"`
+}
@@ -0,0 +1,14 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// ContractStorageUsage represents the result of `getcontractstorageusage` RPC
+// handler.
+type ContractStorageUsage struct {
+	ID   int32        `json:"id"`
+	Hash util.Uint160 `json:"hash"`
+	state.StorageUsage
+}
@@ -0,0 +1,13 @@
+package result
+
+import "github.com/nspcc-dev/neo-go/pkg/core/state"
+
+// FindEventContainers represents the result of `findeventcontainers` RPC
+// handler.
+type FindEventContainers struct {
+	// Containers are ordered from the oldest to the newest one.
+	Containers []state.EventContainer `json:"containers"`
+	// Next is an opaque cursor that can be used to request the next page of
+	// containers. It's omitted if there are no more containers.
+	Next string `json:"next,omitempty"`
+}
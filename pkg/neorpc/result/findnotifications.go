@@ -0,0 +1,12 @@
+package result
+
+import "github.com/nspcc-dev/neo-go/pkg/core/state"
+
+// FindNotifications represents the result of `findnotifications` RPC handler.
+type FindNotifications struct {
+	// Notifications are ordered from the newest to the oldest one.
+	Notifications []state.ContainedNotificationEvent `json:"notifications"`
+	// Next is an opaque cursor that can be used to request the next page of
+	// notifications. It's omitted if there are no more notifications.
+	Next string `json:"next,omitempty"`
+}
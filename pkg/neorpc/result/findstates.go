@@ -5,6 +5,9 @@ type FindStates struct {
 	FirstProof *ProofWithKey `json:"firstProof,omitempty"`
 	LastProof  *ProofWithKey `json:"lastProof,omitempty"`
 	Truncated  bool          `json:"truncated"`
+	// Next is an opaque cursor that can be used to request the next page of
+	// items (NeoGo extension). It's omitted if there are no more items.
+	Next string `json:"next,omitempty"`
 }
 
 type KeyValue struct {
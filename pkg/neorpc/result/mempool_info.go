@@ -0,0 +1,42 @@
+package result
+
+import "github.com/nspcc-dev/neo-go/pkg/util"
+
+type (
+	// MempoolInfo represents a result of getmempoolinfo RPC call.
+	MempoolInfo struct {
+		Height   uint32 `json:"height"`
+		Count    int    `json:"count"`
+		Capacity int    `json:"capacity"`
+		// Size is the total size of pooled transactions in bytes.
+		Size int `json:"size"`
+		// Conflicting is the number of pooled transactions having Conflicts
+		// attributes.
+		Conflicting int `json:"conflicting"`
+		// NotaryRequests is the number of requests in the P2P notary request
+		// pool (always zero if P2PSigExtensions are disabled).
+		NotaryRequests int `json:"notaryrequests"`
+		// FeeHistogram contains the number and size of pooled transactions
+		// grouped by their fee per byte, buckets are ordered by the minimum
+		// fee per byte, the last one has no upper bound.
+		FeeHistogram []MempoolFeeBucket `json:"feehistogram"`
+		// Senders contains the number of pooled transactions per sender
+		// ordered from the sender with the largest number of transactions.
+		Senders []MempoolSender `json:"senders"`
+	}
+
+	// MempoolFeeBucket is a fee histogram bucket containing transactions with
+	// fee per byte not less than MinFeePerByte and less than MinFeePerByte of
+	// the next bucket.
+	MempoolFeeBucket struct {
+		MinFeePerByte int64 `json:"minfeeperbyte"`
+		Count         int   `json:"count"`
+		Size          int   `json:"size"`
+	}
+
+	// MempoolSender contains the number of pooled transactions of the sender.
+	MempoolSender struct {
+		Account util.Uint160 `json:"account"`
+		Count   int          `json:"count"`
+	}
+)
@@ -47,6 +47,10 @@ type NEP11Transfers struct {
 	Sent     []NEP11Transfer `json:"sent"`
 	Received []NEP11Transfer `json:"received"`
 	Address  string          `json:"address"`
+	// Next is an opaque cursor that can be used to request the next page of
+	// transfers, it's only set by NeoGo servers if there are more transfers
+	// within the requested time frame.
+	Next string `json:"next,omitempty"`
 }
 
 // NEP11Transfer represents single NEP-11 transfer event.
@@ -66,6 +70,10 @@ type NEP17Transfers struct {
 	Sent     []NEP17Transfer `json:"sent"`
 	Received []NEP17Transfer `json:"received"`
 	Address  string          `json:"address"`
+	// Next is an opaque cursor that can be used to request the next page of
+	// transfers, it's only set by NeoGo servers if there are more transfers
+	// within the requested time frame.
+	Next string `json:"next,omitempty"`
 }
 
 // NEP17Transfer represents single NEP17 transfer event.
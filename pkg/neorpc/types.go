@@ -6,6 +6,8 @@ parameters used for specific requests/responses.
 package neorpc
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -13,6 +15,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 )
 
@@ -59,7 +62,19 @@ type (
 	// response: http://www.jsonrpc.org/specification#response_object.
 	Response struct {
 		HeaderAndError
-		Result json.RawMessage `json:"result,omitempty"`
+		Result    json.RawMessage  `json:"result,omitempty"`
+		Signature *ResultSignature `json:"signature,omitempty"`
+	}
+
+	// ResultSignature is a NeoGo-specific response extension, it contains the
+	// signature of the response made by the node key (for the methods
+	// configured to be signed). The signature covers the request (method,
+	// parameters and ID), the chain height the response was made at and the
+	// result, see ResultSignatureHash.
+	ResultSignature struct {
+		PublicKey *keys.PublicKey `json:"publickey"`
+		Height    uint32          `json:"height"`
+		Signature []byte          `json:"signature"`
 	}
 
 	// Notification is a type used to represent wire format of events, they're
@@ -78,6 +93,63 @@ type (
 	}
 )
 
+// Verify checks that the given result of the given request is signed by the
+// key specified in ResultSignature. Key trust and Height freshness are to be
+// checked by the caller.
+func (s *ResultSignature) Verify(req *Request, result json.RawMessage) bool {
+	if s.PublicKey == nil || req == nil {
+		return false
+	}
+	params, err := json.Marshal(req.Params)
+	if err != nil {
+		return false
+	}
+	id, err := json.Marshal(req.ID)
+	if err != nil {
+		return false
+	}
+	digest, err := ResultSignatureHash(req.Method, params, id, s.Height, result)
+	if err != nil {
+		return false
+	}
+	return s.PublicKey.Verify(s.Signature, digest[:])
+}
+
+// ResultSignatureHash returns the hash that is signed in ResultSignature for
+// the given request method, parameters and ID (JSON), chain height and result
+// (JSON). JSON data is used in its compact form, missing parameters are the
+// same as an empty array. The hash is SHA-256 of the method (var-sized string),
+// SHA-256 of the parameters, ID (var-sized), height (uint32 LE) and result
+// (var-sized).
+func ResultSignatureHash(method string, params json.RawMessage, id json.RawMessage, height uint32, result json.RawMessage) ([sha256.Size]byte, error) {
+	var p, i, r bytes.Buffer
+	if len(params) != 0 {
+		if err := json.Compact(&p, params); err != nil {
+			return [sha256.Size]byte{}, fmt.Errorf("params: %w", err)
+		}
+	}
+	if p.Len() == 0 || p.String() == "null" {
+		p.Reset()
+		p.WriteString("[]")
+	}
+	if err := json.Compact(&i, id); err != nil {
+		return [sha256.Size]byte{}, fmt.Errorf("id: %w", err)
+	}
+	if err := json.Compact(&r, result); err != nil {
+		return [sha256.Size]byte{}, fmt.Errorf("result: %w", err)
+	}
+	var (
+		w = io.NewBufBinWriter()
+		h = sha256.Sum256(p.Bytes())
+	)
+	w.WriteString(method)
+	w.WriteBytes(h[:])
+	w.WriteVarBytes(i.Bytes())
+	w.WriteU32LE(height)
+	w.WriteVarBytes(r.Bytes())
+	return sha256.Sum256(w.Bytes()), nil
+}
+
 // signerWithWitnessAux is an auxiliary struct for JSON marshalling. We need it because of
 // DisallowUnknownFields JSON marshaller setting.
 type signerWithWitnessAux struct {
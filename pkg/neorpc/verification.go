@@ -0,0 +1,60 @@
+package neorpc
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// VerificationFailureReason is a machine-readable reason of witness check
+// failure.
+type VerificationFailureReason string
+
+// Witness check failure reasons.
+const (
+	// VerificationFailureUnknown is used for failures that can't be
+	// classified.
+	VerificationFailureUnknown VerificationFailureReason = "unknown"
+	// VerificationFailureHashMismatch is used when verification script hash
+	// doesn't match the account witness is checked for.
+	VerificationFailureHashMismatch VerificationFailureReason = "hashmismatch"
+	// VerificationFailureNativeContract is used when native contract witness
+	// has non-empty verification script.
+	VerificationFailureNativeContract VerificationFailureReason = "nativecontract"
+	// VerificationFailureInvalidInvocationScript is used when invocation
+	// script is incorrect.
+	VerificationFailureInvalidInvocationScript VerificationFailureReason = "invalidinvocationscript"
+	// VerificationFailureInvalidVerificationScript is used when verification
+	// script is incorrect.
+	VerificationFailureInvalidVerificationScript VerificationFailureReason = "invalidverificationscript"
+	// VerificationFailureUnknownContract is used when witness has no
+	// verification script and there is no contract deployed for the account.
+	VerificationFailureUnknownContract VerificationFailureReason = "unknowncontract"
+	// VerificationFailureInvalidContract is used when verification contract
+	// doesn't have a proper verify method.
+	VerificationFailureInvalidContract VerificationFailureReason = "invalidcontract"
+	// VerificationFailureExecutionFailed is used when verification script
+	// execution has failed (including running out of GAS).
+	VerificationFailureExecutionFailed VerificationFailureReason = "executionfailed"
+	// VerificationFailureInvalidResult is used when verification script
+	// returns anything other than a single boolean value.
+	VerificationFailureInvalidResult VerificationFailureReason = "invalidresult"
+	// VerificationFailureInvalidSignature is used when verification script
+	// returns false.
+	VerificationFailureInvalidSignature VerificationFailureReason = "invalidsignature"
+)
+
+// VerificationFailure contains machine-readable details of witness check
+// failure. It's returned by NeoGo servers in the "data" member of Error for
+// sendrawtransaction, submitblock and submitnotaryrequest calls if
+// inventory verification fails because of some witness. It's a NeoGo
+// extension, so it's never returned by C# nodes.
+type VerificationFailure struct {
+	// Reason is the failure reason.
+	Reason VerificationFailureReason `json:"reason"`
+	// Witness is the index of the failed witness (it's always 0 for block
+	// header witness).
+	Witness int `json:"witness"`
+	// ScriptHash is the hash of the account witness is checked for.
+	ScriptHash util.Uint160 `json:"scripthash"`
+	// GasConsumed is the amount of GAS spent on the failed witness check.
+	GasConsumed int64 `json:"gasconsumed,string"`
+}
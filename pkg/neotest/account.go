@@ -1,9 +1,11 @@
 package neotest
 
-var _nonce uint32
+import "sync/atomic"
+
+var _nonce atomic.Uint32
 
 // Nonce returns a unique number that can be used as a nonce for new transactions.
+// It's safe for concurrent use.
 func Nonce() uint32 {
-	_nonce++
-	return _nonce
+	return _nonce.Add(1)
 }
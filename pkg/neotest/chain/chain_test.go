@@ -1,8 +1,13 @@
 package chain
 
 import (
+	"math/big"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
 	"github.com/stretchr/testify/require"
 )
@@ -19,3 +24,46 @@ func TestNewMulti(t *testing.T) {
 	c := e.CommitteeInvoker(bc.UtilityTokenHash()).WithSigners(vAcc)
 	c.Invoke(t, true, "transfer", e.Validator.ScriptHash(), e.Committee.ScriptHash(), amount, nil)
 }
+
+// TestParallel checks that independent chains can be used by parallel tests,
+// it's supposed to be run with the race detector enabled.
+func TestParallel(t *testing.T) {
+	const src = `package foo
+	import "github.com/nspcc-dev/neo-go/pkg/interop/storage"
+	func Inc() int {
+		ctx := storage.GetContext()
+		var counter int
+		if v := storage.Get(ctx, "counter"); v != nil {
+			counter = v.(int)
+		}
+		counter++
+		storage.Put(ctx, "counter", counter)
+		return counter
+	}`
+
+	for i := range 4 {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			var bc *core.Blockchain
+			var vAcc, cAcc neotest.Signer
+			if i%2 == 0 {
+				bc, vAcc = NewSingle(t)
+				cAcc = vAcc
+			} else {
+				bc, vAcc, cAcc = NewMulti(t)
+			}
+			e := neotest.NewExecutor(t, bc, vAcc, cAcc)
+			ctr := neotest.CompileSource(t, e.Validator.ScriptHash(), strings.NewReader(src), &compiler.Options{Name: "foo"})
+			e.DeployContract(t, ctr, nil)
+
+			c := e.ValidatorInvoker(ctr.Hash)
+			for j := 1; j <= 3; j++ {
+				c.Invoke(t, j, "inc")
+			}
+			acc := e.NewAccount(t)
+			require.Equal(t, uint32(5), bc.BlockHeight())
+			e.CheckGASBalance(t, acc.ScriptHash(), big.NewInt(100_0000_0000))
+		})
+	}
+}
@@ -3,5 +3,12 @@ Package chain contains functions creating new test blockchain instances.
 Different configurations can be used, but all chains created here use
 well-known keys. Most of the time, a single-node chain is the best choice to use
 unless you specifically need multiple validators and a large committee.
+Every chain created has its own in-memory storage (unless Options.Store is
+specified), so chains can be created and used by parallel tests independently.
+
+Protocol-level behavior (consensus rounds, view changes, etc.) can be tested
+with NewNetwork that creates four nodes running real consensus services and
+interacting with each other in memory. Nodes can be disconnected and connected
+back to simulate failures.
 */
 package chain
@@ -0,0 +1,390 @@
+package chain
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/consensus"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+)
+
+// NetworkTimePerBlock is the default TimePerBlock setting used for Network
+// chains. It's small to make consensus rounds (and view changes) fast.
+const NetworkTimePerBlock = 200 * time.Millisecond
+
+// Network is an in-process dBFT network of four validator nodes. Every node
+// has its own blockchain instance configured the same way NewMulti does it
+// (except for TimePerBlock which is NetworkTimePerBlock by default) and runs a
+// real consensus service. Consensus payloads, transactions and blocks are
+// delivered between nodes directly in memory, so protocol-level behavior can
+// be tested without real network connections. Blocks are only produced by
+// consensus, so neotest.Executor can be used to create transactions, but not
+// to add blocks to Network chains.
+type Network struct {
+	// Nodes contains all network nodes in the validator index order.
+	Nodes []*Node
+	// Validator is the validators multisignature Signer.
+	Validator neotest.Signer
+	// Committee is the committee multisignature Signer.
+	Committee neotest.Signer
+
+	lock    sync.RWMutex
+	stopped bool
+}
+
+// Node is a single Network node.
+type Node struct {
+	// Chain is the node blockchain.
+	Chain *core.Blockchain
+	// Consensus is the node consensus service.
+	Consensus consensus.Service
+
+	net       *Network
+	index     int
+	log       *zap.Logger
+	connected atomic.Bool
+
+	inboxLock sync.Mutex
+	inbox     []any
+	wake      chan struct{}
+	quit      chan struct{}
+	done      chan struct{}
+}
+
+// nodeSigner is a consensus.BlockSigner using a single private key.
+type nodeSigner struct {
+	priv *keys.PrivateKey
+}
+
+// NewNetwork creates and starts a new four-node Network, it's stopped
+// automatically when the test completes.
+func NewNetwork(t testing.TB) *Network {
+	return NewNetworkWithOptions(t, nil)
+}
+
+// NewNetworkWithOptions creates and starts a new four-node Network using the
+// specified options for every node chain. Store and SkipRun options are not
+// supported since every node needs its own storage and running chain.
+func NewNetworkWithOptions(t testing.TB, options *Options) *Network {
+	var opts Options
+	if options != nil {
+		opts = *options
+	}
+	require.Nil(t, opts.Store, "Store can't be shared between nodes")
+	require.False(t, opts.SkipRun, "network chains are always running")
+
+	logger := opts.Logger
+	if logger == nil {
+		logger = zaptest.NewLogger(t)
+	}
+	hook := opts.BlockchainConfigHook
+	opts.BlockchainConfigHook = func(cfg *config.Blockchain) {
+		cfg.TimePerBlock = NetworkTimePerBlock
+		if hook != nil {
+			hook(cfg)
+		}
+	}
+
+	n := &Network{Nodes: make([]*Node, len(multiValidatorAcc))}
+	for i := range n.Nodes {
+		opts.Logger = logger.With(zap.Int("node", i))
+		bc, validator, committee := NewMultiWithOptions(t, &opts)
+		n.Validator, n.Committee = validator, committee
+
+		node := &Node{
+			Chain: bc,
+			net:   n,
+			index: i,
+			log:   opts.Logger,
+			wake:  make(chan struct{}, 1),
+			quit:  make(chan struct{}),
+			done:  make(chan struct{}),
+		}
+		node.connected.Store(true)
+		srv, err := consensus.NewService(consensus.Config{
+			Logger:                node.log,
+			Broadcast:             node.broadcast,
+			Chain:                 bc,
+			BlockQueue:            node,
+			ProtocolConfiguration: bc.GetConfig().ProtocolConfiguration,
+			RequestTx:             node.requestTx,
+			StopTxFlow:            func() {},
+			TimePerBlock:          bc.GetConfig().TimePerBlock,
+			Signer:                nodeSigner{priv: multiValidatorAcc[i].PrivateKey()},
+		})
+		require.NoError(t, err)
+		node.Consensus = srv
+		n.Nodes[i] = node
+	}
+	for _, node := range n.Nodes {
+		go node.run()
+		node.Consensus.Start()
+	}
+	t.Cleanup(n.stop)
+	return n
+}
+
+// PublicKeys implements consensus.BlockSigner interface.
+func (s nodeSigner) PublicKeys() keys.PublicKeys {
+	return keys.PublicKeys{s.priv.PublicKey()}
+}
+
+// SignHash implements consensus.BlockSigner interface.
+func (s nodeSigner) SignHash(pub *keys.PublicKey, h util.Uint256) ([]byte, error) {
+	if !pub.Equal(s.priv.PublicKey()) {
+		return nil, errors.New("unknown key")
+	}
+	return s.priv.SignHash(h), nil
+}
+
+// stop stops consensus services of all nodes, chains are closed by their own
+// cleanup functions.
+func (n *Network) stop() {
+	n.lock.Lock()
+	n.stopped = true
+	n.lock.Unlock()
+	for _, node := range n.Nodes {
+		close(node.quit)
+		<-node.done
+	}
+	for _, node := range n.Nodes {
+		node.Consensus.Shutdown()
+	}
+}
+
+// peers returns connected nodes except the given one (they're not returned
+// at all if the given node is disconnected itself).
+func (n *Network) peers(node *Node) []*Node {
+	var res []*Node
+	if !node.connected.Load() {
+		return nil
+	}
+	for _, p := range n.Nodes {
+		if p != node && p.connected.Load() {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// SendTx adds the given transaction to memory pools of all connected nodes
+// and passes it to their consensus services.
+func (n *Network) SendTx(t testing.TB, tx *transaction.Transaction) {
+	var added bool
+	for _, node := range n.Nodes {
+		if node.connected.Load() && node.addTx(tx) {
+			added = true
+		}
+	}
+	require.True(t, added, "transaction is not accepted by any node")
+}
+
+// WaitHeight waits for all connected nodes to reach the specified height
+// (within 20 TimePerBlock intervals).
+func (n *Network) WaitHeight(t testing.TB, height uint32) {
+	tpb := n.Nodes[0].Chain.GetConfig().TimePerBlock
+	require.Eventually(t, func() bool {
+		for _, node := range n.Nodes {
+			if node.connected.Load() && node.Chain.BlockHeight() < height {
+				return false
+			}
+		}
+		return true
+	}, 20*tpb, tpb/10, "height %d is not reached", height)
+}
+
+// WaitTx waits for the specified transaction to be accepted by the first
+// connected node and returns its application execution result.
+func (n *Network) WaitTx(t testing.TB, h util.Uint256) *state.AppExecResult {
+	var (
+		node = n.Nodes[0]
+		tpb  = node.Chain.GetConfig().TimePerBlock
+	)
+	for _, nd := range n.Nodes {
+		if nd.connected.Load() {
+			node = nd
+			break
+		}
+	}
+	require.Eventually(t, func() bool {
+		_, height, err := node.Chain.GetTransaction(h)
+		return err == nil && height != math.MaxUint32
+	}, 20*tpb, tpb/10, "transaction %s is not accepted", h.StringLE())
+	aers, err := node.Chain.GetAppExecResults(h, trigger.Application)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(aers))
+	return &aers[0]
+}
+
+// Index returns the node index in the Network.
+func (node *Node) Index() int {
+	return node.index
+}
+
+// Disconnect disconnects the node from all other nodes, it neither receives
+// nor sends anything until Connect is called.
+func (node *Node) Disconnect() {
+	node.connected.Store(false)
+}
+
+// Connect reconnects the disconnected node to other nodes, it synchronizes
+// the node with the highest connected peer.
+func (node *Node) Connect() {
+	node.connected.Store(true)
+	var best *Node
+	for _, p := range node.net.peers(node) {
+		if best == nil || p.Chain.BlockHeight() > best.Chain.BlockHeight() {
+			best = p
+		}
+	}
+	if best == nil {
+		return
+	}
+	for h := node.Chain.BlockHeight() + 1; h <= best.Chain.BlockHeight(); h++ {
+		b, err := best.Chain.GetBlock(best.Chain.GetHeaderHash(h))
+		if err != nil {
+			node.log.Warn("can't get block for synchronization", zap.Uint32("height", h), zap.Error(err))
+			return
+		}
+		node.addBlock(b)
+	}
+}
+
+// PutBlock implements consensus.BlockQueuer interface, it adds the block to
+// the node chain and relays it to connected nodes.
+func (node *Node) PutBlock(b *block.Block) error {
+	node.addBlock(b)
+	for _, p := range node.net.peers(node) {
+		p.addBlock(b)
+	}
+	return nil
+}
+
+// addBlock adds the block to the node chain if it's the next one.
+func (node *Node) addBlock(b *block.Block) {
+	if b.Index != node.Chain.BlockHeight()+1 {
+		return
+	}
+	if err := node.Chain.AddBlock(b); err != nil && b.Index > node.Chain.BlockHeight() {
+		node.log.Warn("can't add block", zap.Uint32("index", b.Index), zap.Error(err))
+	}
+}
+
+// addTx adds the transaction to the node memory pool and consensus service,
+// it returns false if the transaction can't be added to the pool.
+func (node *Node) addTx(tx *transaction.Transaction) bool {
+	err := node.Chain.PoolTx(tx)
+	if err != nil && !errors.Is(err, core.ErrAlreadyExists) && !errors.Is(err, core.ErrAlreadyInPool) {
+		node.log.Debug("can't pool transaction", zap.Stringer("hash", tx.Hash()), zap.Error(err))
+		return false
+	}
+	node.push(tx)
+	return true
+}
+
+// broadcast sends the consensus payload to all connected nodes.
+func (node *Node) broadcast(p *payload.Extensible) {
+	for _, peer := range node.net.peers(node) {
+		// Every node gets its own copy, just like with real network.
+		cp, err := copyExtensible(p)
+		if err != nil {
+			node.log.Warn("can't copy consensus payload", zap.Error(err))
+			return
+		}
+		peer.push(cp)
+	}
+}
+
+// requestTx looks for the requested transactions in memory pools of connected
+// nodes.
+func (node *Node) requestTx(hashes ...util.Uint256) {
+	for _, h := range hashes {
+		for _, p := range node.net.peers(node) {
+			if tx, ok := p.Chain.GetMemPool().TryGetValue(h); ok {
+				node.push(tx)
+				break
+			}
+		}
+	}
+}
+
+// push queues an item (consensus payload or transaction) for the node.
+func (node *Node) push(item any) {
+	node.net.lock.RLock()
+	defer node.net.lock.RUnlock()
+	if node.net.stopped {
+		return
+	}
+	node.inboxLock.Lock()
+	node.inbox = append(node.inbox, item)
+	node.inboxLock.Unlock()
+	select {
+	case node.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run delivers queued items to the node consensus service in order.
+func (node *Node) run() {
+	defer close(node.done)
+	for {
+		select {
+		case <-node.quit:
+			return
+		case <-node.wake:
+		}
+		node.inboxLock.Lock()
+		items := node.inbox
+		node.inbox = nil
+		node.inboxLock.Unlock()
+		for _, item := range items {
+			select {
+			case <-node.quit:
+				return
+			default:
+			}
+			switch it := item.(type) {
+			case *payload.Extensible:
+				if node.connected.Load() {
+					_ = node.Consensus.OnPayload(it)
+				}
+			case *transaction.Transaction:
+				if _, ok := node.Chain.GetMemPool().TryGetValue(it.Hash()); !ok {
+					if err := node.Chain.PoolTx(it); err != nil {
+						continue
+					}
+				}
+				node.Consensus.OnTransaction(it)
+			}
+		}
+	}
+}
+
+func copyExtensible(p *payload.Extensible) (*payload.Extensible, error) {
+	buf := io.NewBufBinWriter()
+	p.EncodeBinary(buf.BinWriter)
+	if buf.Err != nil {
+		return nil, buf.Err
+	}
+	res := new(payload.Extensible)
+	r := io.NewBinReaderFromBuf(buf.Bytes())
+	res.DecodeBinary(r)
+	return res, r.Err
+}
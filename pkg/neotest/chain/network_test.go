@@ -0,0 +1,53 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNetwork(t *testing.T) {
+	n := NewNetworkWithOptions(t, &Options{Logger: zap.NewNop()})
+	n.WaitHeight(t, 2)
+
+	e := neotest.NewExecutor(t, n.Nodes[0].Chain, n.Validator, n.Committee)
+	tx := e.NewTx(t, []neotest.Signer{n.Validator}, e.NativeHash(t, "GasToken"), "transfer",
+		n.Validator.ScriptHash(), util.Uint160{1, 2, 3}, 1, nil)
+	n.SendTx(t, tx)
+	aer := n.WaitTx(t, tx.Hash())
+	require.Equal(t, vmstate.Halt, aer.VMState, aer.FaultException)
+
+	h := n.Nodes[0].Chain.BlockHeight()
+	n.WaitHeight(t, h+1)
+	for _, node := range n.Nodes[1:] {
+		require.Equal(t, n.Nodes[0].Chain.GetHeaderHash(h), node.Chain.GetHeaderHash(h))
+	}
+
+	t.Run("view change", func(t *testing.T) {
+		// The network has enough nodes to continue without one of them, but
+		// rounds where it's the primary require a view change.
+		var (
+			node  = n.Nodes[0]
+			peer  = n.Nodes[1]
+			count = uint32(len(n.Nodes))
+		)
+		node.Disconnect()
+		// The block being relayed at the moment can still be produced by
+		// the disconnected node, so skip it.
+		start := peer.Chain.BlockHeight() + 1
+		n.WaitHeight(t, start+count)
+		for i := start + 1; i <= start+count; i++ {
+			b, err := peer.Chain.GetBlock(peer.Chain.GetHeaderHash(i))
+			require.NoError(t, err)
+			require.NotEqual(t, byte(node.Index()), b.PrimaryIndex)
+		}
+		require.LessOrEqual(t, node.Chain.BlockHeight(), start)
+
+		node.Connect()
+		n.WaitHeight(t, peer.Chain.BlockHeight()+1)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"io"
 	"os"
+	"sync"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/cli/smartcontract"
@@ -24,9 +25,45 @@ type Contract struct {
 	DebugInfo *compiler.DebugInfo
 }
 
-// contracts caches the compiled contracts from FS across multiple tests. The key is a
-// concatenation of the source file path and the config file path split by | symbol.
-var contracts = make(map[string]*Contract)
+var (
+	// contractsLock protects contracts from concurrent access by parallel tests.
+	contractsLock sync.Mutex
+	// contracts caches the compiled contracts from FS across multiple tests. The key is a
+	// concatenation of the source file path and the config file path split by | symbol.
+	contracts = make(map[string]*Contract)
+
+	// versionOnce ensures config.Version is set only once, so that parallel
+	// tests don't race on it.
+	versionOnce sync.Once
+)
+
+// setVersion sets the node version used by nef.NewFile.
+func setVersion() {
+	versionOnce.Do(func() {
+		// nef.NewFile() cares about version a lot.
+		config.Version = "neotest"
+	})
+}
+
+// getCached returns a contract from the cache if it's there.
+func getCached(key string) (*Contract, bool) {
+	contractsLock.Lock()
+	defer contractsLock.Unlock()
+	c, ok := contracts[key]
+	return c, ok
+}
+
+// putCached stores the contract in the cache, if the same contract was already
+// stored by some concurrent test, the first one is returned.
+func putCached(key string, c *Contract) *Contract {
+	contractsLock.Lock()
+	defer contractsLock.Unlock()
+	if old, ok := contracts[key]; ok {
+		return old
+	}
+	contracts[key] = c
+	return c
+}
 
 // CompileSource compiles a contract from the reader and returns its NEF, manifest and hash.
 // Compiled contract will have "contract.go" used for its file name and coverage
@@ -34,8 +71,7 @@ var contracts = make(map[string]*Contract)
 // coverage ([*Executor.DisableCoverage]) when you're deploying contracts
 // compiled via this function.
 func CompileSource(t testing.TB, sender util.Uint160, src io.Reader, opts *compiler.Options) *Contract {
-	// nef.NewFile() cares about version a lot.
-	config.Version = "neotest"
+	setVersion()
 
 	ne, di, err := compiler.CompileWithOptions("contract.go", src, opts)
 	require.NoError(t, err)
@@ -55,12 +91,11 @@ func CompileSource(t testing.TB, sender util.Uint160, src io.Reader, opts *compi
 // It uses contracts cashes.
 func CompileFile(t testing.TB, sender util.Uint160, srcPath string, configPath string) *Contract {
 	cacheKey := srcPath + "|" + configPath
-	if c, ok := contracts[cacheKey]; ok {
+	if c, ok := getCached(cacheKey); ok {
 		return c
 	}
 
-	// nef.NewFile() cares about version a lot.
-	config.Version = "neotest"
+	setVersion()
 
 	ne, di, err := compiler.CompileWithOptions(srcPath, nil, nil)
 	require.NoError(t, err)
@@ -89,24 +124,30 @@ func CompileFile(t testing.TB, sender util.Uint160, srcPath string, configPath s
 		Manifest:  m,
 		DebugInfo: di,
 	}
-	contracts[cacheKey] = c
-	return c
+	return putCached(cacheKey, c)
 }
 
 // ReadNEF loads a contract from the specified NEF and manifest files.
 func ReadNEF(t testing.TB, sender util.Uint160, nefPath, manifestPath string) *Contract {
 	cacheKey := sender.StringLE() + "|" + nefPath + "|" + manifestPath
-	if c, ok := contracts[cacheKey]; ok {
+	if c, ok := getCached(cacheKey); ok {
 		return c
 	}
 
 	nefBytes, err := os.ReadFile(nefPath)
 	require.NoError(t, err)
 
-	ne, err := nef.FileFromBytes(nefBytes)
+	manifestBytes, err := os.ReadFile(manifestPath)
 	require.NoError(t, err)
 
-	manifestBytes, err := os.ReadFile(manifestPath)
+	c := ContractFromBytes(t, sender, nefBytes, manifestBytes)
+	return putCached(cacheKey, c)
+}
+
+// ContractFromBytes creates a contract from the serialized NEF file and JSON
+// manifest, it's similar to ReadNEF, but doesn't read any files.
+func ContractFromBytes(t testing.TB, sender util.Uint160, nefBytes, manifestBytes []byte) *Contract {
+	ne, err := nef.FileFromBytes(nefBytes)
 	require.NoError(t, err)
 
 	m := new(manifest.Manifest)
@@ -117,12 +158,9 @@ func ReadNEF(t testing.TB, sender util.Uint160, nefPath, manifestPath string) *C
 	err = m.IsValid(hash, true)
 	require.NoError(t, err)
 
-	c := &Contract{
+	return &Contract{
 		Hash:     hash,
 		NEF:      &ne,
 		Manifest: m,
 	}
-
-	contracts[cacheKey] = c
-	return c
 }
@@ -27,6 +27,12 @@ const (
 	goCoverModeFlag = "test.covermode"
 	// disableNeotestCover is name of the environmental variable used to explicitly disable neotest coverage.
 	disableNeotestCover = "DISABLE_NEOTEST_COVER"
+	// neotestCoverLcov is name of the environmental variable specifying the file
+	// contract coverage is written to in lcov format.
+	neotestCoverLcov = "NEOTEST_COVER_LCOV"
+	// neotestCoverCobertura is name of the environmental variable specifying the
+	// file contract coverage is written to in Cobertura XML format.
+	neotestCoverCobertura = "NEOTEST_COVER_COBERTURA"
 )
 
 const (
@@ -45,6 +51,10 @@ var (
 	coverageEnabled bool
 	// coverProfile specifies the file all coverage data is written to, unless empty.
 	coverProfile = ""
+	// lcovProfile and coberturaProfile specify the files coverage data is
+	// written to in lcov and Cobertura formats, unless empty.
+	lcovProfile      = ""
+	coberturaProfile = ""
 	// coverMode is the mode of go coverage collection.
 	coverMode = goCoverModeSet
 )
@@ -101,9 +111,12 @@ func isCoverageEnabled(t testing.TB) bool {
 		}
 	})
 
-	coverageEnabled = !disabledByEnvironment && goToolCoverageEnabled
+	lcovProfile = os.Getenv(neotestCoverLcov)
+	coberturaProfile = os.Getenv(neotestCoverCobertura)
 
-	if coverageEnabled {
+	coverageEnabled = !disabledByEnvironment && (goToolCoverageEnabled || lcovProfile != "" || coberturaProfile != "")
+
+	if coverageEnabled && goToolCoverageEnabled {
 		if coverMode != goCoverModeSet {
 			t.Fatalf("coverage: only '%s' cover mode is currently supported (#3587), got '%s'", goCoverModeSet, coverMode)
 		}
@@ -129,31 +142,57 @@ var coverageHook vm.OnExecHook = func(scriptHash util.Uint160, offset int, opcod
 func reportCoverage(t testing.TB) {
 	coverageLock.Lock()
 	defer coverageLock.Unlock()
-	f, err := os.Create(coverProfile)
-	if err != nil {
-		t.Fatalf("coverage: can't create file '%s' to write coverage report", coverProfile)
+	cover := processCover()
+	for _, r := range []struct {
+		file  string
+		write func(io.Writer, map[documentName][]*coverBlock) error
+	}{
+		{coverProfile, writeCoverageReport},
+		{lcovProfile, writeLcovReport},
+		{coberturaProfile, writeCoberturaReport},
+	} {
+		if r.file == "" {
+			continue
+		}
+		f, err := os.Create(r.file)
+		if err != nil {
+			t.Fatalf("coverage: can't create file '%s' to write coverage report", r.file)
+		}
+		err = r.write(f, cover)
+		if err == nil {
+			err = f.Close()
+		} else {
+			_ = f.Close()
+		}
+		if err != nil {
+			t.Fatalf("coverage: can't write coverage report to '%s': %v", r.file, err)
+		}
 	}
-	defer f.Close()
-	writeCoverageReport(f)
 }
 
-func writeCoverageReport(w io.Writer) {
-	fmt.Fprintf(w, "mode: %s\n", coverMode)
-	cover := processCover()
+func writeCoverageReport(w io.Writer, cover map[documentName][]*coverBlock) error {
+	_, err := fmt.Fprintf(w, "mode: %s\n", coverMode)
+	if err != nil {
+		return err
+	}
 	for name, blocks := range cover {
 		for _, b := range blocks {
 			var counts = b.counts
 			if coverMode == goCoverModeSet && counts > 0 {
 				counts = 1
 			}
-			fmt.Fprintf(w, "%s:%d.%d,%d.%d %d %d\n", name,
+			_, err = fmt.Fprintf(w, "%s:%d.%d,%d.%d %d %d\n", name,
 				b.startLine, b.startCol,
 				b.endLine, b.endCol,
 				b.stmts,
 				counts,
 			)
+			if err != nil {
+				return err
+			}
 		}
 	}
+	return nil
 }
 
 func processCover() map[documentName][]*coverBlock {
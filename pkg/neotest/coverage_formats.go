@@ -0,0 +1,181 @@
+package neotest
+
+import (
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// lineHits is the number of executions of a single source line.
+type lineHits struct {
+	line uint
+	hits uint
+}
+
+// coberturaDocType is the DOCTYPE of Cobertura XML report.
+const coberturaDocType = `<!DOCTYPE coverage SYSTEM "http://cobertura.sourceforge.net/xml/coverage-04.dtd">`
+
+// Cobertura XML report structure, only line coverage is reported.
+type (
+	coberturaCoverage struct {
+		XMLName         xml.Name           `xml:"coverage"`
+		LineRate        string             `xml:"line-rate,attr"`
+		BranchRate      string             `xml:"branch-rate,attr"`
+		LinesCovered    int                `xml:"lines-covered,attr"`
+		LinesValid      int                `xml:"lines-valid,attr"`
+		BranchesCovered int                `xml:"branches-covered,attr"`
+		BranchesValid   int                `xml:"branches-valid,attr"`
+		Complexity      int                `xml:"complexity,attr"`
+		Version         string             `xml:"version,attr"`
+		Timestamp       int64              `xml:"timestamp,attr"`
+		Packages        []coberturaPackage `xml:"packages>package"`
+	}
+	coberturaPackage struct {
+		Name       string           `xml:"name,attr"`
+		LineRate   string           `xml:"line-rate,attr"`
+		BranchRate string           `xml:"branch-rate,attr"`
+		Complexity int              `xml:"complexity,attr"`
+		Classes    []coberturaClass `xml:"classes>class"`
+	}
+	coberturaClass struct {
+		Name       string          `xml:"name,attr"`
+		Filename   string          `xml:"filename,attr"`
+		LineRate   string          `xml:"line-rate,attr"`
+		BranchRate string          `xml:"branch-rate,attr"`
+		Complexity int             `xml:"complexity,attr"`
+		Methods    struct{}        `xml:"methods"`
+		Lines      []coberturaLine `xml:"lines>line"`
+	}
+	coberturaLine struct {
+		Number uint `xml:"number,attr"`
+		Hits   uint `xml:"hits,attr"`
+	}
+)
+
+// documentLines converts coverage blocks of a single document into per-line
+// execution counts sorted by line number. A line covered by several blocks
+// gets the maximum number of executions among them.
+func documentLines(blocks []*coverBlock) []lineHits {
+	var lines = make(map[uint]uint)
+	for _, b := range blocks {
+		for l := b.startLine; l <= b.endLine; l++ {
+			lines[l] = max(lines[l], b.counts)
+		}
+	}
+	var res = make([]lineHits, 0, len(lines))
+	for l, hits := range lines {
+		res = append(res, lineHits{line: l, hits: hits})
+	}
+	slices.SortFunc(res, func(a, b lineHits) int { return cmp.Compare(a.line, b.line) })
+	return res
+}
+
+// sortedKeys returns sorted keys of the given map.
+func sortedKeys[V any](m map[string]V) []string {
+	var res = make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	slices.Sort(res)
+	return res
+}
+
+// countCovered returns the number of lines executed at least once.
+func countCovered(lines []lineHits) int {
+	var n int
+	for _, l := range lines {
+		if l.hits > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// lineRate formats the share of covered lines for Cobertura report.
+func lineRate(covered, valid int) string {
+	if valid == 0 {
+		return "1"
+	}
+	return strconv.FormatFloat(float64(covered)/float64(valid), 'f', 4, 64)
+}
+
+// writeLcovReport writes coverage data in lcov tracefile format.
+func writeLcovReport(w io.Writer, cover map[documentName][]*coverBlock) error {
+	for _, name := range sortedKeys(cover) {
+		lines := documentLines(cover[name])
+		if _, err := fmt.Fprintf(w, "TN:\nSF:%s\n", name); err != nil {
+			return err
+		}
+		for _, l := range lines {
+			if _, err := fmt.Fprintf(w, "DA:%d,%d\n", l.line, l.hits); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "LF:%d\nLH:%d\nend_of_record\n", len(lines), countCovered(lines)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCoberturaReport writes coverage data in Cobertura XML format, every
+// source directory is a package and every file is a class there.
+func writeCoberturaReport(w io.Writer, cover map[documentName][]*coverBlock) error {
+	var (
+		res = coberturaCoverage{
+			BranchRate: "0",
+			Timestamp:  time.Now().UnixMilli(),
+		}
+		packages = make(map[string]*coberturaPackage)
+		pkgStats = make(map[string][2]int)
+	)
+	for _, name := range sortedKeys(cover) {
+		var (
+			lines   = documentLines(cover[name])
+			covered = countCovered(lines)
+			dir     = path.Dir(name)
+			class   = coberturaClass{
+				Name:       path.Base(name),
+				Filename:   name,
+				LineRate:   lineRate(covered, len(lines)),
+				BranchRate: "0",
+				Lines:      make([]coberturaLine, 0, len(lines)),
+			}
+		)
+		for _, l := range lines {
+			class.Lines = append(class.Lines, coberturaLine{Number: l.line, Hits: l.hits})
+		}
+		p, ok := packages[dir]
+		if !ok {
+			p = &coberturaPackage{Name: dir, BranchRate: "0"}
+			packages[dir] = p
+		}
+		p.Classes = append(p.Classes, class)
+		st := pkgStats[dir]
+		pkgStats[dir] = [2]int{st[0] + covered, st[1] + len(lines)}
+		res.LinesCovered += covered
+		res.LinesValid += len(lines)
+	}
+	for _, dir := range sortedKeys(packages) {
+		p := packages[dir]
+		p.LineRate = lineRate(pkgStats[dir][0], pkgStats[dir][1])
+		res.Packages = append(res.Packages, *p)
+	}
+	res.LineRate = lineRate(res.LinesCovered, res.LinesValid)
+
+	if _, err := io.WriteString(w, xml.Header+coberturaDocType+"\n"); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "\t")
+	if err := enc.Encode(res); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
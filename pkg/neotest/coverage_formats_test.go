@@ -0,0 +1,67 @@
+package neotest
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testCover() map[documentName][]*coverBlock {
+	return map[documentName][]*coverBlock{
+		"contracts/token/token.go": {
+			{startLine: 3, startCol: 2, endLine: 3, endCol: 10, stmts: 1, counts: 2},
+			{startLine: 4, startCol: 2, endLine: 5, endCol: 3, stmts: 2, counts: 0},
+			{startLine: 5, startCol: 4, endLine: 5, endCol: 8, stmts: 1, counts: 1},
+		},
+		"contracts/nft/nft.go": {
+			{startLine: 10, startCol: 1, endLine: 10, endCol: 5, stmts: 1, counts: 0},
+		},
+	}
+}
+
+func TestWriteLcovReport(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeLcovReport(&buf, testCover()))
+	require.Equal(t, strings.Join([]string{
+		"TN:",
+		"SF:contracts/nft/nft.go",
+		"DA:10,0",
+		"LF:1",
+		"LH:0",
+		"end_of_record",
+		"TN:",
+		"SF:contracts/token/token.go",
+		"DA:3,2",
+		"DA:4,0",
+		"DA:5,1",
+		"LF:3",
+		"LH:2",
+		"end_of_record",
+	}, "\n")+"\n", buf.String())
+}
+
+func TestWriteCoberturaReport(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeCoberturaReport(&buf, testCover()))
+	require.True(t, strings.HasPrefix(buf.String(), xml.Header+coberturaDocType))
+
+	var res coberturaCoverage
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &res))
+	require.Equal(t, 2, res.LinesCovered)
+	require.Equal(t, 4, res.LinesValid)
+	require.Equal(t, "0.5000", res.LineRate)
+	require.Len(t, res.Packages, 2)
+	require.Equal(t, "contracts/nft", res.Packages[0].Name)
+	require.Equal(t, "0.0000", res.Packages[0].LineRate)
+
+	p := res.Packages[1]
+	require.Equal(t, "contracts/token", p.Name)
+	require.Len(t, p.Classes, 1)
+	require.Equal(t, "token.go", p.Classes[0].Name)
+	require.Equal(t, "contracts/token/token.go", p.Classes[0].Filename)
+	require.Equal(t, "0.6667", p.Classes[0].LineRate)
+	require.Equal(t, []coberturaLine{{3, 2}, {4, 0}, {5, 1}}, p.Classes[0].Lines)
+}
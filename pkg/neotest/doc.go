@@ -16,6 +16,20 @@ Higher-order methods provided in Executor and ContractInvoker hide the details
 of transaction creation for the most part, but there are lower-level methods as
 well that can be used for specific tasks.
 
+Contracts don't have to be compiled from sources, precompiled NEF and manifest
+can be used via ReadNEF (files) or ContractFromBytes and contracts deployed to
+some real network can be fetched via RPC with FetchContract. It's also possible
+to test against real contract data: FetchFixture retrieves the contract along
+with its storage (or some part of it) and DeployFixture deploys it with the
+same storage contents. Fixtures can be saved with WriteFixture and read with
+ReadFixture to avoid network access in tests.
+
+Tests using neotest can be run in parallel (with t.Parallel) as long as every
+test creates its own blockchain instance and Executor, chains don't share any
+state and package-level data (like compiled contracts cache, nonces and
+coverage data) is protected from concurrent access. Executor and its invokers
+are not supposed to be shared between parallel tests.
+
 It's recommended to have a separate folder/package for tests, because having
 them in the same package with the smart contract iself can lead to unxpected
 results if smart contract has any init() functions. If that's the case they
@@ -29,5 +43,12 @@ itself will not be saved because it will be replaced with contracts coverage ins
 In case `go test` coverage is wanted DISABLE_NEOTEST_COVER=1 variable can be set.
 Coverage is gathered by capturing VM instructions during test contract execution and
 mapping them to the contract source code using the DebugInfo information.
+
+Contract coverage can also be saved in lcov and Cobertura XML formats (line
+coverage only) for CI systems and coverage services by setting
+NEOTEST_COVER_LCOV and NEOTEST_COVER_COBERTURA variables to the files these
+reports should be written to. They're written in addition to the `go test`
+cover profile, but they also enable coverage collection when `go test` is
+running without coverage.
 */
 package neotest
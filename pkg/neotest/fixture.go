@@ -0,0 +1,240 @@
+package neotest
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// fixtureBatchItems is the maximum number of storage items put into
+	// a single fixture loading transaction.
+	fixtureBatchItems = 256
+	// fixtureBatchSize is the maximum size of storage items put into a single
+	// fixture loading transaction.
+	fixtureBatchSize = 32 * 1024
+)
+
+// Fixture is a contract with (a part of) its storage, usually taken from some
+// real network (see FetchFixture), that can be deployed with the same storage
+// contents via [Executor.DeployFixture] to test against production data.
+type Fixture struct {
+	NEF      *nef.File
+	Manifest *manifest.Manifest
+	Storage  []result.KeyValue
+}
+
+// fixtureAux is an auxiliary structure for Fixture JSON marshaling.
+type fixtureAux struct {
+	NEF      []byte             `json:"nef"`
+	Manifest *manifest.Manifest `json:"manifest"`
+	Storage  []result.KeyValue  `json:"storage"`
+}
+
+// FetchContract fetches the NEF and manifest of the contract with the given
+// hash from the RPC node at endpoint and returns a contract to be deployed by
+// the sender. Manifest groups are dropped since they're only valid for the
+// original contract hash. Contracts are cached, so only the first test using
+// the contract requests it from the node.
+func FetchContract(t testing.TB, sender util.Uint160, endpoint string, hash util.Uint160) *Contract {
+	cacheKey := sender.StringLE() + "|" + endpoint + "|" + hash.StringLE()
+	if c, ok := getCached(cacheKey); ok {
+		return c
+	}
+
+	c, err := rpcclient.New(context.Background(), endpoint, rpcclient.Options{})
+	require.NoError(t, err)
+	defer c.Close()
+
+	cs := fetchContractState(t, c, hash)
+	return putCached(cacheKey, &Contract{
+		Hash:     state.CreateContractHash(sender, cs.NEF.Checksum, cs.Manifest.Name),
+		NEF:      &cs.NEF,
+		Manifest: &cs.Manifest,
+	})
+}
+
+// FetchFixture fetches the NEF, manifest and storage items of the contract
+// with the given hash from the RPC node at endpoint. Only storage items with
+// the given key prefixes are fetched if any are specified, the whole contract
+// storage is fetched otherwise. Items are retrieved with several findstorage
+// calls, so they can belong to different blocks if the node is processing new
+// blocks at the time.
+func FetchFixture(t testing.TB, endpoint string, hash util.Uint160, prefixes ...[]byte) *Fixture {
+	c, err := rpcclient.New(context.Background(), endpoint, rpcclient.Options{})
+	require.NoError(t, err)
+	defer c.Close()
+
+	cs := fetchContractState(t, c, hash)
+	if len(prefixes) == 0 {
+		prefixes = [][]byte{{}}
+	}
+	f := &Fixture{
+		NEF:      &cs.NEF,
+		Manifest: &cs.Manifest,
+	}
+	for _, prefix := range prefixes {
+		var start *int
+		for {
+			res, err := c.FindStorageByHash(hash, prefix, start)
+			require.NoError(t, err)
+			f.Storage = append(f.Storage, res.Results...)
+			if !res.Truncated {
+				break
+			}
+			start = &res.Next
+		}
+	}
+	return f
+}
+
+// fetchContractState returns the state of the contract with the given hash
+// without manifest groups.
+func fetchContractState(t testing.TB, c *rpcclient.Client, hash util.Uint160) *state.Contract {
+	cs, err := c.GetContractStateByHash(hash)
+	require.NoError(t, err)
+	cs.Manifest.Groups = []manifest.Group{}
+	return cs
+}
+
+// ReadFixture reads the fixture from the JSON file (see WriteFixture), it
+// allows to fetch the fixture from the network once and then use it in tests
+// without network access.
+func ReadFixture(t testing.TB, path string) *Fixture {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var aux fixtureAux
+	require.NoError(t, json.Unmarshal(data, &aux))
+	ne, err := nef.FileFromBytes(aux.NEF)
+	require.NoError(t, err)
+	return &Fixture{
+		NEF:      &ne,
+		Manifest: aux.Manifest,
+		Storage:  aux.Storage,
+	}
+}
+
+// WriteFixture saves the fixture to the JSON file.
+func WriteFixture(t testing.TB, path string, f *Fixture) {
+	nefBytes, err := f.NEF.Bytes()
+	require.NoError(t, err)
+
+	data, err := json.MarshalIndent(fixtureAux{
+		NEF:      nefBytes,
+		Manifest: f.Manifest,
+		Storage:  f.Storage,
+	}, "", "  ")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0644))
+}
+
+// DeployFixture deploys the fixture contract by the validator and puts
+// fixture storage items into its storage. Storage items are added by a
+// special loader contract that is deployed first and then updated to the
+// fixture contract, so the hash of the deployed contract is different from
+// the one the contract would have if deployed with DeployContract, it's
+// returned by this method. data is passed to `_deploy` method of the contract
+// which is called with isUpdate set to true.
+func (e *Executor) DeployFixture(t testing.TB, f *Fixture, data any) util.Uint160 {
+	setVersion()
+
+	script, updateOffset := fixtureLoaderScript()
+	ne, err := nef.NewFile(script)
+	require.NoError(t, err)
+
+	m := manifest.DefaultManifest(f.Manifest.Name)
+	m.ABI.Methods = []manifest.Method{
+		{
+			Name:       "load",
+			Offset:     0,
+			Parameters: []manifest.Parameter{manifest.NewParameter("items", smartcontract.ArrayType)},
+			ReturnType: smartcontract.VoidType,
+		},
+		{
+			Name:   "update",
+			Offset: updateOffset,
+			Parameters: []manifest.Parameter{
+				manifest.NewParameter("nef", smartcontract.ByteArrayType),
+				manifest.NewParameter("manifest", smartcontract.ByteArrayType),
+				manifest.NewParameter("data", smartcontract.AnyType),
+			},
+			ReturnType: smartcontract.VoidType,
+		},
+	}
+	loader := &Contract{
+		Hash:     state.CreateContractHash(e.Validator.ScriptHash(), ne.Checksum, m.Name),
+		NEF:      ne,
+		Manifest: m,
+	}
+	e.DeployContract(t, loader, nil)
+
+	inv := e.ValidatorInvoker(loader.Hash)
+	var (
+		batch []any
+		size  int
+	)
+	for i, kv := range f.Storage {
+		batch = append(batch, []any{kv.Key, kv.Value})
+		size += len(kv.Key) + len(kv.Value)
+		if len(batch) == fixtureBatchItems || size >= fixtureBatchSize || i == len(f.Storage)-1 {
+			inv.Invoke(t, nil, "load", batch)
+			batch, size = nil, 0
+		}
+	}
+
+	nefBytes, err := f.NEF.Bytes()
+	require.NoError(t, err)
+	manifestBytes, err := json.Marshal(f.Manifest)
+	require.NoError(t, err)
+	inv.Invoke(t, nil, "update", nefBytes, manifestBytes, data)
+	return loader.Hash
+}
+
+// fixtureLoaderScript returns the script of fixture loader contract and the
+// offset of its update method (load method starts at 0).
+func fixtureLoaderScript() ([]byte, int) {
+	w := io.NewBufBinWriter()
+
+	// load(items []any): puts [key, value] pairs into the storage.
+	emit.InitSlot(w.BinWriter, 1, 1)
+	emit.Opcodes(w.BinWriter, opcode.PUSH0, opcode.STLOC0)
+	loopStart := w.Len()
+	emit.Opcodes(w.BinWriter, opcode.LDLOC0, opcode.LDARG0, opcode.SIZE, opcode.LT)
+	jmpEnd := w.Len()
+	emit.Instruction(w.BinWriter, opcode.JMPIFNOT, []byte{0}) // Fixed below.
+	emit.Opcodes(w.BinWriter, opcode.LDARG0, opcode.LDLOC0, opcode.PICKITEM, opcode.UNPACK, opcode.DROP)
+	emit.Syscall(w.BinWriter, interopnames.SystemStorageGetContext)
+	emit.Syscall(w.BinWriter, interopnames.SystemStoragePut)
+	emit.Opcodes(w.BinWriter, opcode.LDLOC0, opcode.INC, opcode.STLOC0)
+	emit.Instruction(w.BinWriter, opcode.JMP, []byte{byte(int8(loopStart - w.Len()))})
+	end := w.Len()
+	emit.Opcodes(w.BinWriter, opcode.RET)
+
+	// update(nef, manifest []byte, data any): updates the contract.
+	updateOffset := w.Len()
+	emit.InitSlot(w.BinWriter, 0, 3)
+	emit.Opcodes(w.BinWriter, opcode.LDARG2, opcode.LDARG1, opcode.LDARG0, opcode.PUSH3, opcode.PACK)
+	emit.AppCallNoArgs(w.BinWriter, nativehashes.ContractManagement, "update", callflag.All)
+	emit.Opcodes(w.BinWriter, opcode.DROP, opcode.RET)
+
+	script := w.Bytes()
+	script[jmpEnd+1] = byte(end - jmpEnd)
+	return script, updateOffset
+}
@@ -0,0 +1,130 @@
+package neotest_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testContractSrc = "../../internal/basicchain/testdata/test_contract.go"
+	testContractCfg = "../../internal/basicchain/testdata/test_contract.yml"
+)
+
+func TestContractFromBytes(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	compiled := neotest.CompileFile(t, e.CommitteeHash, testContractSrc, testContractCfg)
+	nefBytes, err := compiled.NEF.Bytes()
+	require.NoError(t, err)
+	manifestBytes, err := json.Marshal(compiled.Manifest)
+	require.NoError(t, err)
+
+	c := neotest.ContractFromBytes(t, e.CommitteeHash, nefBytes, manifestBytes)
+	require.Equal(t, state.CreateContractHash(e.CommitteeHash, compiled.NEF.Checksum, compiled.Manifest.Name), c.Hash)
+	e.DeployContract(t, c, nil)
+	e.CommitteeInvoker(c.Hash).Invoke(t, "RUB", "symbol")
+}
+
+// newFixtureServer returns RPC server providing the given contract and
+// storage items (one per findstorage call).
+func newFixtureServer(t *testing.T, cs *state.Contract, items []result.KeyValue) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var r struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&r))
+
+		var res any
+		switch r.Method {
+		case "getcontractstate":
+			res = cs
+		case "findstorage":
+			var start int
+			require.Equal(t, 3, len(r.Params))
+			require.NoError(t, json.Unmarshal(r.Params[2], &start))
+			res = result.FindStorage{
+				Results:   items[start : start+1],
+				Next:      start + 1,
+				Truncated: start+1 < len(items),
+			}
+		default:
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+		resBytes, err := json.Marshal(res)
+		require.NoError(t, err)
+		resp, err := json.Marshal(map[string]json.RawMessage{
+			"jsonrpc": json.RawMessage(`"2.0"`),
+			"id":      r.ID,
+			"result":  resBytes,
+		})
+		require.NoError(t, err)
+		_, err = w.Write(resp)
+		require.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFixture(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	var (
+		compiled = neotest.CompileFile(t, e.CommitteeHash, testContractSrc, testContractCfg)
+		holder   = util.Uint160{4, 5, 6}
+		items    = []result.KeyValue{
+			{Key: holder.BytesBE(), Value: bigint.ToBytes(big.NewInt(42))},
+			{Key: []byte("key"), Value: []byte("value")},
+		}
+	)
+	srv := newFixtureServer(t, &state.Contract{
+		ContractBase: state.ContractBase{
+			ID:       7,
+			Hash:     compiled.Hash,
+			NEF:      *compiled.NEF,
+			Manifest: *compiled.Manifest,
+		},
+	}, items)
+
+	t.Run("contract", func(t *testing.T) {
+		c := neotest.FetchContract(t, e.CommitteeHash, srv.URL, compiled.Hash)
+		require.Equal(t, compiled.NEF, c.NEF)
+		e.DeployContract(t, c, nil)
+		e.CommitteeInvoker(c.Hash).Invoke(t, 0, "balanceOf", holder)
+	})
+
+	f := neotest.FetchFixture(t, srv.URL, compiled.Hash)
+	require.Equal(t, items, f.Storage)
+
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	neotest.WriteFixture(t, path, f)
+	f = neotest.ReadFixture(t, path)
+	require.Equal(t, items, f.Storage)
+
+	h := e.DeployFixture(t, f, nil)
+	cs := e.Chain.GetContractState(h)
+	require.NotNil(t, cs)
+	require.Equal(t, f.NEF.Checksum, cs.NEF.Checksum)
+	require.Equal(t, compiled.Manifest.Name, cs.Manifest.Name)
+	require.Equal(t, 1, int(cs.UpdateCounter))
+
+	inv := e.CommitteeInvoker(h)
+	inv.Invoke(t, 42, "balanceOf", holder)
+	inv.Invoke(t, "RUB", "symbol")
+	require.Equal(t, state.StorageItem("value"), e.Chain.GetStorageItem(cs.ID, []byte("key")))
+}
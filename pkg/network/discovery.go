@@ -29,6 +29,7 @@ type Discoverer interface {
 	PoolCount() int
 	RequestRemote(int)
 	RegisterSelf(AddressablePeer)
+	RegisterBanned(AddressablePeer, time.Time)
 	RegisterGood(AddressablePeer)
 	RegisterConnected(AddressablePeer)
 	UnregisterConnected(AddressablePeer, bool)
@@ -50,6 +51,7 @@ type DefaultDiscovery struct {
 	lock             sync.RWMutex
 	dialTimeout      time.Duration
 	badAddrs         map[string]bool
+	bannedAddrs      map[string]time.Time
 	connectedAddrs   map[string]bool
 	handshakedAddrs  map[string]bool
 	goodAddrs        map[string]capability.Capabilities
@@ -72,6 +74,7 @@ func NewDefaultDiscovery(addrs []string, dt time.Duration, ts Transporter) *Defa
 		transport:        ts,
 		dialTimeout:      dt,
 		badAddrs:         make(map[string]bool),
+		bannedAddrs:      make(map[string]time.Time),
 		connectedAddrs:   make(map[string]bool),
 		handshakedAddrs:  make(map[string]bool),
 		goodAddrs:        make(map[string]capability.Capabilities),
@@ -96,7 +99,7 @@ func (d *DefaultDiscovery) BackFill(addrs ...string) {
 
 func (d *DefaultDiscovery) backfill(addrs ...string) {
 	for _, addr := range addrs {
-		if d.badAddrs[addr] || d.connectedAddrs[addr] || d.handshakedAddrs[addr] ||
+		if d.badAddrs[addr] || d.isBanned(addr) || d.connectedAddrs[addr] || d.handshakedAddrs[addr] ||
 			d.unconnectedAddrs[addr] > 0 {
 			continue
 		}
@@ -132,7 +135,7 @@ func (d *DefaultDiscovery) RequestRemote(requested int) {
 		var nextAddr string
 		d.lock.Lock()
 		for addr := range d.unconnectedAddrs {
-			if !d.connectedAddrs[addr] && !d.handshakedAddrs[addr] && !d.attempted[addr] {
+			if !d.connectedAddrs[addr] && !d.handshakedAddrs[addr] && !d.attempted[addr] && !d.isBanned(addr) {
 				nextAddr = addr
 				break
 			}
@@ -141,7 +144,7 @@ func (d *DefaultDiscovery) RequestRemote(requested int) {
 		if nextAddr == "" {
 			// Empty pool, try seeds.
 			for addr, ip := range d.seeds {
-				if ip == "" && !d.attempted[addr] {
+				if ip == "" && !d.attempted[addr] && !d.isBanned(addr) {
 					nextAddr = addr
 					break
 				}
@@ -189,6 +192,46 @@ func (d *DefaultDiscovery) registerBad(addr string, force bool) {
 	d.updateNetSize()
 }
 
+// RegisterBanned registers the given Peer as a banned one, its addresses are
+// not tried until the given time.
+func (d *DefaultDiscovery) RegisterBanned(p AddressablePeer, until time.Time) {
+	var (
+		connaddr = p.ConnectionAddr()
+		peeraddr = p.PeerAddr().String()
+	)
+	d.lock.Lock()
+	delete(d.connectedAddrs, connaddr)
+	delete(d.handshakedAddrs, peeraddr)
+	for _, addr := range []string{connaddr, peeraddr} {
+		d.bannedAddrs[addr] = until
+		delete(d.unconnectedAddrs, addr)
+		if ip, ok := d.seeds[addr]; ok && ip != "forever" {
+			d.seeds[addr] = ""
+		}
+	}
+	for addr, ip := range d.seeds {
+		if ip == peeraddr {
+			d.seeds[addr] = ""
+		}
+	}
+	d.updateNetSize()
+	d.lock.Unlock()
+}
+
+// isBanned checks whether the given address is banned now, expired bans are
+// removed. Must be called under write lock.
+func (d *DefaultDiscovery) isBanned(addr string) bool {
+	until, ok := d.bannedAddrs[addr]
+	if !ok {
+		return false
+	}
+	if time.Now().Before(until) {
+		return true
+	}
+	delete(d.bannedAddrs, addr)
+	return false
+}
+
 // UnconnectedPeers returns all addresses of unconnected addrs.
 func (d *DefaultDiscovery) UnconnectedPeers() []string {
 	d.lock.RLock()
@@ -200,13 +243,20 @@ func (d *DefaultDiscovery) UnconnectedPeers() []string {
 	return addrs
 }
 
-// BadPeers returns all addresses of bad addrs.
+// BadPeers returns all addresses of bad addrs (including currently banned
+// ones).
 func (d *DefaultDiscovery) BadPeers() []string {
+	var now = time.Now()
 	d.lock.RLock()
-	addrs := make([]string, 0, len(d.badAddrs))
+	addrs := make([]string, 0, len(d.badAddrs)+len(d.bannedAddrs))
 	for addr := range d.badAddrs {
 		addrs = append(addrs, addr)
 	}
+	for addr, until := range d.bannedAddrs {
+		if !d.badAddrs[addr] && now.Before(until) {
+			addrs = append(addrs, addr)
+		}
+	}
 	d.lock.RUnlock()
 	return addrs
 }
@@ -232,3 +232,33 @@ func TestSeedDiscovery(t *testing.T) {
 		}
 	}
 }
+
+func TestDefaultDiscovererBanned(t *testing.T) {
+	ts := &fakeTransp{}
+	ts.dialCh = make(chan string)
+	d := NewDefaultDiscovery(nil, time.Second/16, ts)
+
+	const addr = "1.1.1.1:10333"
+	d.BackFill(addr)
+	require.Equal(t, 1, d.PoolCount())
+
+	until := time.Now().Add(100 * time.Millisecond)
+	d.RegisterBanned(&fakeAPeer{addr: addr, peer: addr}, until)
+	require.Equal(t, 0, d.PoolCount())
+	require.Equal(t, []string{addr}, d.BadPeers())
+
+	// Banned addresses are not accepted until the ban expires.
+	d.BackFill(addr)
+	require.Equal(t, 0, d.PoolCount())
+
+	require.Eventually(t, func() bool { return len(d.BadPeers()) == 0 }, time.Second, 10*time.Millisecond)
+	d.BackFill(addr)
+	require.Equal(t, 1, d.PoolCount())
+	d.RequestRemote(1)
+	select {
+	case a := <-ts.dialCh:
+		require.Equal(t, addr, a)
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timeout expecting for transport dial")
+	}
+}
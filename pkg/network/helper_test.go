@@ -24,6 +24,7 @@ type testDiscovery struct {
 	connected    []string
 	unregistered []string
 	backfill     []string
+	good         []AddressWithCapabilities
 }
 
 func newTestDiscovery([]string, time.Duration, Transporter) Discoverer { return new(testDiscovery) }
@@ -39,6 +40,11 @@ func (d *testDiscovery) RegisterSelf(p AddressablePeer) {
 	defer d.Unlock()
 	d.bad = append(d.bad, p.ConnectionAddr())
 }
+func (d *testDiscovery) RegisterBanned(p AddressablePeer, _ time.Time) {
+	d.Lock()
+	defer d.Unlock()
+	d.bad = append(d.bad, p.ConnectionAddr())
+}
 func (d *testDiscovery) GetFanOut() int {
 	d.Lock()
 	defer d.Unlock()
@@ -71,7 +77,11 @@ func (d *testDiscovery) BadPeers() []string {
 	defer d.Unlock()
 	return d.bad
 }
-func (d *testDiscovery) GoodPeers() []AddressWithCapabilities { return []AddressWithCapabilities{} }
+func (d *testDiscovery) GoodPeers() []AddressWithCapabilities {
+	d.Lock()
+	defer d.Unlock()
+	return append([]AddressWithCapabilities{}, d.good...)
+}
 
 var defaultMessageHandler = func(t *testing.T, msg *Message) {}
 
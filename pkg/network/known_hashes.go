@@ -0,0 +1,44 @@
+package network
+
+import (
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+const (
+	// defaultKnownTxCacheSize is the default number of transaction hashes
+	// remembered by the node.
+	defaultKnownTxCacheSize = 50000
+	// defaultKnownNotaryRequestCacheSize is the default number of P2P notary
+	// request hashes remembered by the node.
+	defaultKnownNotaryRequestCacheSize = 10000
+)
+
+// knownHashes is a cache of recently received inventory hashes used to avoid
+// requesting and processing the same item announced by several peers more
+// than once. It's a 2Q cache, so hashes seen repeatedly (announced by many
+// peers) are kept longer than the ones seen only once.
+type knownHashes struct {
+	cache *lru.TwoQueueCache[util.Uint256, struct{}]
+	typ   string
+}
+
+// newKnownHashes creates a cache of the given size for items of the given
+// type (used as a metric label).
+func newKnownHashes(size int, typ string) *knownHashes {
+	cache, _ := lru.New2Q[util.Uint256, struct{}](size) // Never errors for positive size.
+	return &knownHashes{cache: cache, typ: typ}
+}
+
+// Contains checks whether the given hash is known and updates hit/miss
+// metrics.
+func (k *knownHashes) Contains(h util.Uint256) bool {
+	_, ok := k.cache.Get(h)
+	updateKnownHashesMetric(k.typ, ok)
+	return ok
+}
+
+// Add remembers the given hash.
+func (k *knownHashes) Add(h util.Uint256) {
+	k.cache.Add(h, struct{}{})
+}
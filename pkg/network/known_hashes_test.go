@@ -0,0 +1,24 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKnownHashes(t *testing.T) {
+	k := newKnownHashes(4, "test")
+
+	require.False(t, k.Contains(util.Uint256{1}))
+	k.Add(util.Uint256{1})
+	require.True(t, k.Contains(util.Uint256{1}))
+
+	// Frequently seen hash survives eviction of the ones seen once.
+	for i := byte(2); i < 10; i++ {
+		k.Add(util.Uint256{i})
+	}
+	require.True(t, k.Contains(util.Uint256{1}))
+	require.False(t, k.Contains(util.Uint256{2}))
+	require.True(t, k.Contains(util.Uint256{9}))
+}
@@ -0,0 +1,77 @@
+package network
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/statesync"
+)
+
+// mptRequests tracks MPT nodes requests sent to peers during state
+// synchronisation. Every peer has at most one chunk of nodes requested at a
+// time, so chunks are spread among all peers and MPT is fetched from them in
+// parallel. Peer is considered to be ready for the next chunk once it replies
+// to the previous one or once the request times out (peer can have no nodes
+// requested and then it doesn't reply at all).
+type mptRequests struct {
+	lock  sync.Mutex
+	peers map[Peer]*mptPeerStats
+}
+
+// mptPeerStats contains MPT requests accounting data for a single peer.
+type mptPeerStats struct {
+	// sent is the time of the pending request, it's zero if there is none.
+	sent time.Time
+	// requested is the number of node hashes requested from the peer.
+	requested int
+	// received is the number of nodes received from the peer.
+	received int
+}
+
+func newMPTRequests() *mptRequests {
+	return &mptRequests{peers: make(map[Peer]*mptPeerStats)}
+}
+
+// canRequest checks whether the next chunk can be requested from the peer.
+func (r *mptRequests) canRequest(p Peer) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	st, ok := r.peers[p]
+	return !ok || st.sent.IsZero() || time.Since(st.sent) >= statesync.MPTRequestTimeout
+}
+
+// requestSent remembers the chunk of n nodes requested from the peer.
+func (r *mptRequests) requestSent(p Peer, n int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	st := r.peers[p]
+	if st == nil {
+		st = new(mptPeerStats)
+		r.peers[p] = st
+	}
+	st.sent = time.Now()
+	st.requested += n
+}
+
+// dataReceived completes the pending request to the peer.
+func (r *mptRequests) dataReceived(p Peer, n int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	st := r.peers[p]
+	if st == nil {
+		st = new(mptPeerStats)
+		r.peers[p] = st
+	}
+	st.sent = time.Time{}
+	st.received += n
+}
+
+// disconnected forgets about the peer and returns its stats (nil if nothing
+// was requested from it).
+func (r *mptRequests) disconnected(p Peer) *mptPeerStats {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	st := r.peers[p]
+	delete(r.peers, p)
+	return st
+}
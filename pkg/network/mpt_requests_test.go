@@ -0,0 +1,34 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/statesync"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMPTRequests(t *testing.T) {
+	var (
+		r  = newMPTRequests()
+		p1 = newLocalPeer(t, nil)
+		p2 = newLocalPeer(t, nil)
+	)
+	require.True(t, r.canRequest(p1))
+	require.Nil(t, r.disconnected(p1))
+
+	r.requestSent(p1, 10)
+	require.False(t, r.canRequest(p1))
+	require.True(t, r.canRequest(p2))
+
+	r.dataReceived(p1, 15)
+	require.True(t, r.canRequest(p1))
+
+	// Request can be repeated after timeout.
+	r.requestSent(p1, 5)
+	r.peers[p1].sent = time.Now().Add(-statesync.MPTRequestTimeout)
+	require.True(t, r.canRequest(p1))
+
+	require.Equal(t, &mptPeerStats{requested: 15, received: 15, sent: r.peers[p1].sent}, r.disconnected(p1))
+	require.True(t, r.canRequest(p1))
+}
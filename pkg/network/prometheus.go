@@ -49,6 +49,23 @@ var (
 	)
 	p2pCmds = make(map[CommandType]prometheus.Histogram)
 
+	knownHashesHits = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of announced inventory hashes found in the known hashes cache",
+			Name:      "known_hashes_hits_total",
+			Namespace: "neogo",
+		},
+		[]string{"type"},
+	)
+	knownHashesMisses = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of announced inventory hashes not found in the known hashes cache",
+			Name:      "known_hashes_misses_total",
+			Namespace: "neogo",
+		},
+		[]string{"type"},
+	)
+
 	// notarypoolUnsortedTx prometheus metric.
 	notarypoolUnsortedTx = prometheus.NewGauge(
 		prometheus.GaugeOpts{
@@ -67,6 +84,8 @@ func init() {
 		poolCount,
 		blockQueueLength,
 		notarypoolUnsortedTx,
+		knownHashesHits,
+		knownHashesMisses,
 	)
 	for _, cmd := range []CommandType{CMDVersion, CMDVerack, CMDGetAddr,
 		CMDAddr, CMDPing, CMDPong, CMDGetHeaders, CMDHeaders, CMDGetBlocks,
@@ -113,6 +132,16 @@ func addCmdTimeMetric(cmd CommandType, t time.Duration) {
 	p2pCmds[cmd].Observe(t.Seconds())
 }
 
+// updateKnownHashesMetric updates known hashes cache hit/miss metrics for the
+// given inventory type.
+func updateKnownHashesMetric(typ string, hit bool) {
+	if hit {
+		knownHashesHits.WithLabelValues(typ).Inc()
+	} else {
+		knownHashesMisses.WithLabelValues(typ).Inc()
+	}
+}
+
 // updateNotarypoolMetrics updates metric of the number of fallback txs inside
 // the notary request pool.
 func updateNotarypoolMetrics(unsortedTxnLen int) {
@@ -0,0 +1,124 @@
+package network
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"go.uber.org/zap"
+)
+
+// maxLocalTxs is the maximum number of locally originated transactions
+// tracked for rebroadcasting, it allows to announce all of them with a single
+// inventory message.
+const maxLocalTxs = payload.MaxHashesCount
+
+// localTxs keeps transactions that were relayed via this node (submitted via
+// RPC or created by node services) until they're included into a block or
+// expire. nil localTxs is valid and means that rebroadcasting is disabled.
+type localTxs struct {
+	lock sync.Mutex
+	txs  map[util.Uint256]*transaction.Transaction
+}
+
+// newLocalTxs creates a tracker for locally originated transactions. It
+// returns nil if the given rebroadcast interval is not positive.
+func newLocalTxs(interval time.Duration) *localTxs {
+	if interval <= 0 {
+		return nil
+	}
+	return &localTxs{txs: make(map[util.Uint256]*transaction.Transaction)}
+}
+
+// add starts tracking the given transaction. It returns false if the tracker
+// is full.
+func (l *localTxs) add(tx *transaction.Transaction) bool {
+	if l == nil {
+		return true
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if len(l.txs) >= maxLocalTxs {
+		return false
+	}
+	l.txs[tx.Hash()] = tx
+	return true
+}
+
+// remove stops tracking the given transactions.
+func (l *localTxs) remove(hs ...util.Uint256) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for _, h := range hs {
+		delete(l.txs, h)
+	}
+}
+
+// list returns all tracked transactions.
+func (l *localTxs) list() []*transaction.Transaction {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	res := make([]*transaction.Transaction, 0, len(l.txs))
+	for _, tx := range l.txs {
+		res = append(res, tx)
+	}
+	return res
+}
+
+// rebroadcastLoop periodically announces locally originated transactions
+// to peers.
+func (s *Server) rebroadcastLoop() {
+	defer close(s.rebroadcastFin)
+	if s.localTxs == nil {
+		return
+	}
+	ticker := time.NewTicker(s.TxRebroadcastInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.quit:
+			return
+		case <-ticker.C:
+			s.rebroadcastLocalTxs()
+		}
+	}
+}
+
+// rebroadcastLocalTxs announces all tracked transactions that are still in
+// the mempool and tries to add back the ones that were evicted from it.
+// Transactions are no longer tracked when they're expired or can't be pooled
+// (which is the case for transactions already included into a block).
+func (s *Server) rebroadcastLocalTxs() {
+	var (
+		height = s.chain.BlockHeight()
+		hashes []util.Uint256
+		done   []util.Uint256
+	)
+	for _, tx := range s.localTxs.list() {
+		h := tx.Hash()
+		if tx.ValidUntilBlock <= height {
+			done = append(done, h)
+			continue
+		}
+		if !s.mempool.ContainsKey(h) {
+			err := s.verifyAndPoolTX(tx)
+			if errors.Is(err, mempool.ErrOOM) {
+				continue // Pool is full, try again later.
+			}
+			if err != nil {
+				s.log.Debug("stop rebroadcasting transaction", zap.Stringer("hash", h), zap.Error(err))
+				done = append(done, h)
+				continue
+			}
+		}
+		hashes = append(hashes, h)
+	}
+	s.localTxs.remove(done...)
+	if len(hashes) > 0 {
+		s.broadcastTxHashes(hashes)
+	}
+}
@@ -0,0 +1,106 @@
+package network
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/internal/fakechain"
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newLocalTx(vub uint32) *transaction.Transaction {
+	tx := transaction.New(random.Bytes(10), 123)
+	tx.ValidUntilBlock = vub
+	tx.Signers = []transaction.Signer{{Account: random.Uint160()}}
+	tx.Scripts = []transaction.Witness{{InvocationScript: []byte{}, VerificationScript: []byte{}}}
+	return tx
+}
+
+func TestLocalTxsDisabled(t *testing.T) {
+	s := newTestServer(t, ServerConfig{})
+	require.Nil(t, s.localTxs)
+	require.NoError(t, s.RelayTxn(newLocalTx(100)))
+	// Make sure the loop exits immediately.
+	s.rebroadcastLoop()
+	<-s.rebroadcastFin
+}
+
+func TestLocalTxsLimit(t *testing.T) {
+	l := newLocalTxs(time.Second)
+	for range maxLocalTxs {
+		require.True(t, l.add(newLocalTx(100)))
+	}
+	require.False(t, l.add(newLocalTx(100)))
+	require.Len(t, l.list(), maxLocalTxs)
+}
+
+func TestRebroadcastLocalTxs(t *testing.T) {
+	s := newTestServer(t, ServerConfig{TxRebroadcastInterval: time.Hour})
+	chain := s.chain.(*fakechain.FakeChain)
+	chain.UtilityTokenBalance = big.NewInt(1000000)
+
+	var (
+		lock      sync.Mutex
+		announced []util.Uint256
+	)
+	p := newLocalPeer(t, s)
+	p.isFullNode = true
+	p.messageHandler = func(t *testing.T, msg *Message) {
+		if msg.Command == CMDInv {
+			lock.Lock()
+			announced = append(announced, msg.Payload.(*payload.Inventory).Hashes...)
+			lock.Unlock()
+		}
+	}
+	s.peers[p] = true
+
+	check := func(t *testing.T, expAnnounced, expTracked []util.Uint256) {
+		lock.Lock()
+		announced = nil
+		lock.Unlock()
+		s.rebroadcastLocalTxs()
+		lock.Lock()
+		require.ElementsMatch(t, expAnnounced, announced)
+		lock.Unlock()
+		var tracked []util.Uint256
+		for _, tx := range s.localTxs.list() {
+			tracked = append(tracked, tx.Hash())
+		}
+		require.ElementsMatch(t, expTracked, tracked)
+	}
+
+	pooled := newLocalTx(100)
+	evicted := newLocalTx(100)
+	expiring := newLocalTx(10)
+	for _, tx := range []*transaction.Transaction{pooled, evicted, expiring} {
+		require.NoError(t, s.RelayTxn(tx))
+	}
+	require.NoError(t, s.mempool.Add(pooled, chain))
+	require.NoError(t, s.mempool.Add(expiring, chain))
+
+	// Evicted transaction is pooled again and announced.
+	check(t, []util.Uint256{pooled.Hash(), evicted.Hash(), expiring.Hash()},
+		[]util.Uint256{pooled.Hash(), evicted.Hash(), expiring.Hash()})
+
+	// Expired transaction is not tracked anymore.
+	chain.Blockheight.Store(10)
+	check(t, []util.Uint256{pooled.Hash(), evicted.Hash()},
+		[]util.Uint256{pooled.Hash(), evicted.Hash()})
+
+	// Full mempool doesn't stop rebroadcasting.
+	chain.PoolTxF = func(*transaction.Transaction) error { return mempool.ErrOOM }
+	check(t, []util.Uint256{pooled.Hash()},
+		[]util.Uint256{pooled.Hash(), evicted.Hash()})
+
+	// Included (or invalid) transaction is not tracked anymore.
+	chain.PoolTxF = func(*transaction.Transaction) error { return errors.New("already exists") }
+	check(t, []util.Uint256{pooled.Hash()}, []util.Uint256{pooled.Hash()})
+}
@@ -0,0 +1,304 @@
+package network
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultBanDuration is used when ban duration is not configured.
+	defaultBanDuration = 24 * time.Hour
+
+	// maxPeerScore and minPeerScore are peer score bounds.
+	maxPeerScore = 100
+	minPeerScore = -100
+	// banPeerScore is the score peer is banned at.
+	banPeerScore = -50
+
+	// maxScoredPeers is the number of peer scores kept, the least recently
+	// updated ones are forgotten when it's exceeded.
+	maxScoredPeers = 10000
+
+	// fastPongTime and slowPongTime are ping/pong round trip time thresholds
+	// for latency scoring.
+	fastPongTime = time.Second
+	slowPongTime = 5 * time.Second
+
+	scoreFastPong     = 1
+	scoreSlowPong     = -2
+	scoreUsefulBlock  = 1
+	scoreDisconnect   = -5
+	scoreUnresponsive = -10
+	scoreViolation    = -25
+)
+
+var errPeerBanned = errors.New("peer is banned")
+
+// reputation tracks peer scores and bans. Scores are kept per peer address
+// (see peerKey), so reconnections don't reset them while different nodes
+// behind the same IP address we connect to are scored independently. Only
+// protocol violations can get the peer banned, other penalties just make it
+// less preferable. Peers with zero score are not tracked and the number of
+// tracked ones is limited. nil reputation is valid and means that tracking is
+// disabled, every peer is treated equally then.
+type reputation struct {
+	cfg config.PeerReputation
+	log *zap.Logger
+
+	lock   sync.Mutex
+	scores *lru.Cache[string, int]
+	pings  map[Peer]time.Time
+	// bans contains ban expiration times.
+	bans map[string]time.Time
+}
+
+// newReputation creates reputation tracker and loads the ban list if it's
+// configured. It returns nil if tracking is disabled.
+func newReputation(cfg config.PeerReputation, log *zap.Logger) (*reputation, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if cfg.BanDuration <= 0 {
+		cfg.BanDuration = defaultBanDuration
+	}
+	scores, _ := lru.New[string, int](maxScoredPeers) // Never errors for positive size.
+	r := &reputation{
+		cfg:    cfg,
+		log:    log,
+		scores: scores,
+		pings:  make(map[Peer]time.Time),
+		bans:   make(map[string]time.Time),
+	}
+	if cfg.BanListPath != "" {
+		data, err := os.ReadFile(cfg.BanListPath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read ban list: %w", err)
+		}
+		if len(data) != 0 {
+			if err := json.Unmarshal(data, &r.bans); err != nil {
+				return nil, fmt.Errorf("failed to decode ban list: %w", err)
+			}
+		}
+	}
+	return r, nil
+}
+
+// peerKey returns the key peer is tracked by. It's the address peer is
+// listening on for handshaked peers we've connected to (so the address is
+// known to be valid). Other peers (incoming connections and the ones that
+// haven't completed the handshake yet) are tracked by IP address only, since
+// the remote port of such connection is either ephemeral or not verified
+// (taken from the peer capabilities), so it can be easily changed.
+func peerKey(p Peer) string {
+	addr := p.PeerAddr().String()
+	if p.Handshaked() && addr == p.ConnectionAddr() {
+		return addr
+	}
+	host, _, err := net.SplitHostPort(p.RemoteAddr().String())
+	if err != nil {
+		return p.RemoteAddr().String()
+	}
+	return host
+}
+
+// isBanned checks whether the peer is banned now.
+func (r *reputation) isBanned(p Peer) bool {
+	if r == nil {
+		return false
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	_, ok := r.bannedUntil(peerKey(p))
+	return ok
+}
+
+// banExpiration returns the time the ban of the peer expires at if it's
+// banned now.
+func (r *reputation) banExpiration(p Peer) (time.Time, bool) {
+	if r == nil {
+		return time.Time{}, false
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.bannedUntil(peerKey(p))
+}
+
+func (r *reputation) bannedUntil(key string) (time.Time, bool) {
+	until, ok := r.bans[key]
+	if !ok {
+		return time.Time{}, false
+	}
+	if time.Now().Before(until) {
+		return until, true
+	}
+	delete(r.bans, key)
+	r.saveBans()
+	return time.Time{}, false
+}
+
+// score returns the current score of the peer.
+func (r *reputation) score(p Peer) int {
+	if r == nil {
+		return 0
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	score, _ := r.scores.Peek(peerKey(p))
+	return score
+}
+
+// update adds delta to the peer score and bans the peer if the score becomes
+// too low. It returns true if the peer is banned.
+func (r *reputation) update(p Peer, delta int) bool {
+	if r == nil {
+		return false
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.updateKey(peerKey(p), delta, true)
+}
+
+// updateKey adds delta to the score of the given peer. Unless canBan is set,
+// the score can't get to the ban threshold, so only protocol violations can
+// get the peer banned.
+func (r *reputation) updateKey(key string, delta int, canBan bool) bool {
+	if _, ok := r.bannedUntil(key); ok {
+		return true
+	}
+	old, _ := r.scores.Peek(key)
+	score := min(max(old+delta, minPeerScore), maxPeerScore)
+	if !canBan {
+		score = max(score, banPeerScore+1)
+	}
+	if score == 0 {
+		r.scores.Remove(key)
+		return false
+	}
+	if score > banPeerScore {
+		r.scores.Add(key, score)
+		return false
+	}
+	// The peer is given a fresh start after the ban expiration.
+	r.scores.Remove(key)
+	now := time.Now()
+	for k, until := range r.bans {
+		if !now.Before(until) {
+			delete(r.bans, k)
+		}
+	}
+	r.bans[key] = now.Add(r.cfg.BanDuration)
+	r.log.Warn("peer banned", zap.String("addr", key), zap.Duration("duration", r.cfg.BanDuration))
+	r.saveBans()
+	return true
+}
+
+// pingSent remembers ping sending time for the peer.
+func (r *reputation) pingSent(p Peer) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	r.pings[p] = time.Now()
+	r.lock.Unlock()
+}
+
+// pongReceived scores the peer by ping/pong round trip time. It returns true
+// if the peer is banned.
+func (r *reputation) pongReceived(p Peer) bool {
+	if r == nil {
+		return false
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	sent, ok := r.pings[p]
+	if !ok {
+		return false
+	}
+	delete(r.pings, p)
+	var delta int
+	switch rtt := time.Since(sent); {
+	case rtt <= fastPongTime:
+		delta = scoreFastPong
+	case rtt >= slowPongTime:
+		delta = scoreSlowPong
+	default:
+		return false
+	}
+	return r.updateKey(peerKey(p), delta, false)
+}
+
+// disconnected scores the peer by the disconnection reason and forgets about
+// the connection.
+func (r *reputation) disconnected(p Peer, reason error) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.pings, p)
+	var (
+		delta     int
+		violation bool
+	)
+	switch {
+	case reason == nil,
+		errors.Is(reason, errPeerBanned),
+		errors.Is(reason, errServerShutdown),
+		errors.Is(reason, errMaxPeers),
+		errors.Is(reason, errAlreadyConnected),
+		errors.Is(reason, errIdenticalID),
+		errors.Is(reason, errGone):
+		return
+	case errors.Is(reason, io.EOF),
+		errors.Is(reason, net.ErrClosed),
+		errors.Is(reason, errBlocksRequestFailed):
+		delta = scoreDisconnect
+	case errors.Is(reason, errPingPong):
+		delta = scoreUnresponsive
+	default:
+		// Everything else is an invalid network, protocol violation or
+		// payload decoding/handling failure.
+		delta, violation = scoreViolation, true
+	}
+	r.updateKey(peerKey(p), delta, violation)
+}
+
+// saveBans stores the ban list to the configured file. It must be called with
+// the lock held.
+func (r *reputation) saveBans() {
+	if r.cfg.BanListPath == "" {
+		return
+	}
+	err := writeJSONFile(r.cfg.BanListPath, r.bans)
+	if err != nil {
+		r.log.Error("failed to save ban list", zap.String("path", r.cfg.BanListPath), zap.Error(err))
+	}
+}
+
+// writeJSONFile atomically replaces the file at the given path with JSON
+// representation of v.
+func writeJSONFile(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
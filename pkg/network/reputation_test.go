@@ -0,0 +1,202 @@
+package network
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// newReputationPeer creates a handshaked peer we've connected to, so it's
+// tracked by its full address.
+func newReputationPeer(t *testing.T, ip string) *localPeer {
+	p := newLocalPeer(t, nil)
+	p.netaddr = net.TCPAddr{IP: net.ParseIP(ip), Port: 20333}
+	p.handshaked = 1
+	return p
+}
+
+func TestReputationDisabled(t *testing.T) {
+	r, err := newReputation(config.PeerReputation{}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	require.Nil(t, r)
+
+	p := newReputationPeer(t, "10.0.0.1")
+	r.pingSent(p)
+	require.False(t, r.pongReceived(p))
+	require.False(t, r.update(p, -1000))
+	r.disconnected(p, io.EOF)
+	require.False(t, r.isBanned(p))
+	require.Equal(t, 0, r.score(p))
+}
+
+func TestReputationScoring(t *testing.T) {
+	r, err := newReputation(config.PeerReputation{Enabled: true}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	p := newReputationPeer(t, "10.0.0.1")
+	other := newReputationPeer(t, "10.0.0.2")
+
+	t.Run("benign disconnect", func(t *testing.T) {
+		for _, reason := range []error{nil, errServerShutdown, errMaxPeers, errAlreadyConnected, errIdenticalID, errGone} {
+			r.disconnected(p, reason)
+		}
+		require.Equal(t, 0, r.score(p))
+	})
+	t.Run("latency", func(t *testing.T) {
+		require.False(t, r.pongReceived(p)) // No ping was sent.
+		require.Equal(t, 0, r.score(p))
+		r.pingSent(p)
+		require.False(t, r.pongReceived(p))
+		require.Equal(t, scoreFastPong, r.score(p))
+	})
+	t.Run("reconnection", func(t *testing.T) {
+		r.disconnected(p, io.EOF)
+		require.Equal(t, scoreFastPong+scoreDisconnect, r.score(p))
+
+		// Same peer, but a different connection.
+		again := newReputationPeer(t, "10.0.0.1")
+		require.Equal(t, r.score(p), r.score(again))
+		require.Equal(t, 0, r.score(other))
+
+		// Different peer behind the same IP.
+		neighbour := newReputationPeer(t, "10.0.0.1")
+		neighbour.netaddr.Port = 10333
+		require.Equal(t, 0, r.score(neighbour))
+	})
+	t.Run("unverified address", func(t *testing.T) {
+		// Not handshaked peers are tracked by IP, so changing port
+		// doesn't help.
+		in := newReputationPeer(t, "10.0.0.3")
+		in.handshaked = 0
+		r.disconnected(in, io.EOF)
+		in.netaddr.Port = 10333
+		require.Equal(t, scoreDisconnect, r.score(in))
+
+		// The same for handshaked incoming connections (remote address
+		// differs from the one from capabilities).
+		unverified := &unverifiedPeer{localPeer: newReputationPeer(t, "10.0.0.3")}
+		require.Equal(t, scoreDisconnect, r.score(unverified))
+		require.Equal(t, 0, r.score(newReputationPeer(t, "10.0.0.3")))
+	})
+	t.Run("zero score", func(t *testing.T) {
+		zero := newReputationPeer(t, "10.0.0.4")
+		r.update(zero, 1)
+		require.Equal(t, 1, r.score(zero))
+		require.True(t, r.scores.Contains(peerKey(zero)))
+		r.update(zero, -1)
+		require.False(t, r.scores.Contains(peerKey(zero)))
+	})
+	t.Run("limit", func(t *testing.T) {
+		r, err := newReputation(config.PeerReputation{Enabled: true}, zaptest.NewLogger(t))
+		require.NoError(t, err)
+		p := newReputationPeer(t, "10.0.0.1")
+		for i := range maxScoredPeers + 1 {
+			p.netaddr.Port = 1000 + i
+			r.update(p, -1)
+		}
+		require.Equal(t, maxScoredPeers, r.scores.Len())
+	})
+	t.Run("no ban without violations", func(t *testing.T) {
+		slow := newReputationPeer(t, "10.0.0.5")
+		for range -minPeerScore {
+			r.disconnected(slow, io.EOF)
+			r.disconnected(slow, errPingPong)
+		}
+		require.False(t, r.isBanned(slow))
+		require.Equal(t, banPeerScore+1, r.score(slow))
+		r.disconnected(slow, errInvalidNetwork)
+		require.True(t, r.isBanned(slow))
+	})
+	t.Run("bounds", func(t *testing.T) {
+		for range maxPeerScore + 10 {
+			r.update(other, 1)
+		}
+		require.Equal(t, maxPeerScore, r.score(other))
+	})
+	t.Run("ban", func(t *testing.T) {
+		r.disconnected(p, fmt.Errorf("handling %s message: %w", CMDInv, errInvalidInvType))
+		require.False(t, r.isBanned(p))
+		r.disconnected(p, errInvalidNetwork)
+		require.True(t, r.isBanned(p))
+		require.True(t, r.update(p, 1000))
+		require.False(t, r.isBanned(other))
+	})
+}
+
+func TestReputationBanList(t *testing.T) {
+	cfg := config.PeerReputation{
+		Enabled:     true,
+		BanListPath: filepath.Join(t.TempDir(), "bans", "banned.json"),
+	}
+	r, err := newReputation(cfg, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	p := newReputationPeer(t, "10.0.0.1")
+	require.True(t, r.update(p, banPeerScore))
+	require.True(t, r.isBanned(p))
+
+	// Bans survive restarts.
+	r, err = newReputation(cfg, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	require.True(t, r.isBanned(p))
+	require.False(t, r.isBanned(newReputationPeer(t, "10.0.0.2")))
+
+	t.Run("expiration", func(t *testing.T) {
+		cfg.BanDuration = time.Millisecond
+		r, err := newReputation(cfg, zaptest.NewLogger(t))
+		require.NoError(t, err)
+		p := newReputationPeer(t, "10.0.0.3")
+		require.True(t, r.update(p, banPeerScore))
+		require.Eventually(t, func() bool { return !r.isBanned(p) }, time.Second, time.Millisecond*10)
+		require.Equal(t, 0, r.score(p))
+	})
+}
+
+func TestServerBannedPeer(t *testing.T) {
+	s := newTestServer(t, ServerConfig{ReputationCfg: config.PeerReputation{Enabled: true}})
+	p := newLocalPeer(t, s)
+	p.version = &payload.Version{Nonce: 1, UserAgent: []byte("fake")}
+	require.True(t, s.reputation.update(p, banPeerScore))
+
+	startWithCleanup(t, s)
+
+	s.register <- p
+	require.Eventually(t, func() bool {
+		err, ok := p.droppedWith.Load().(error)
+		return ok && err == errPeerBanned
+	}, time.Second, time.Millisecond*10)
+	require.Eventually(t, func() bool { return len(s.discovery.BadPeers()) == 1 }, time.Second, time.Millisecond*10)
+	require.Equal(t, 0, s.PeerCount())
+
+	// Peers are also checked after handshake (when the peer address
+	// becomes known).
+	in := newLocalPeer(t, s)
+	in.netaddr = net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 10333}
+	in.version = p.version
+	s.register <- in
+	require.Eventually(t, func() bool { return s.PeerCount() == 1 }, time.Second, time.Millisecond*10)
+	require.True(t, s.reputation.update(in, banPeerScore))
+	s.handshake <- in
+	require.Eventually(t, func() bool {
+		err, ok := in.droppedWith.Load().(error)
+		return ok && err == errPeerBanned
+	}, time.Second, time.Millisecond*10)
+}
+
+// unverifiedPeer is a peer with remote address different from the listening
+// one (like incoming connections have).
+type unverifiedPeer struct {
+	*localPeer
+}
+
+func (p *unverifiedPeer) ConnectionAddr() string {
+	return net.JoinHostPort(p.netaddr.IP.String(), "55555")
+}
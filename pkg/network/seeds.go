@@ -0,0 +1,163 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// dnsSeedPrefix is the prefix of seed list entries that are resolved to
+	// peer addresses via DNS SRV records.
+	dnsSeedPrefix = "dns+srv://"
+	// defaultSeedRefreshInterval is used when seed refresh interval is not
+	// configured.
+	defaultSeedRefreshInterval = 10 * time.Minute
+	// dnsSeedTimeout is the maximum duration of a single DNS seed resolution.
+	dnsSeedTimeout = 10 * time.Second
+)
+
+// lookupSRV resolves SRV records, it's a variable to be replaced in tests.
+var lookupSRV = net.DefaultResolver.LookupSRV
+
+// splitSeeds separates regular seed addresses from DNS seed names.
+func splitSeeds(seeds []string) ([]string, []string, error) {
+	var addrs, names []string
+	for _, seed := range seeds {
+		name, ok := strings.CutPrefix(seed, dnsSeedPrefix)
+		if !ok {
+			addrs = append(addrs, seed)
+			continue
+		}
+		if name == "" {
+			return nil, nil, fmt.Errorf("empty DNS seed name in %q", seed)
+		}
+		names = append(names, name)
+	}
+	return addrs, names, nil
+}
+
+// resolveDNSSeed returns peer addresses from SRV records of the given DNS
+// name.
+func resolveDNSSeed(ctx context.Context, name string) ([]string, error) {
+	_, srvs, err := lookupSRV(ctx, "", "", name)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]string, 0, len(srvs))
+	for _, srv := range srvs {
+		host := strings.TrimSuffix(srv.Target, ".")
+		if host == "" {
+			continue
+		}
+		addrs = append(addrs, net.JoinHostPort(host, strconv.Itoa(int(srv.Port))))
+	}
+	return addrs, nil
+}
+
+// resolveDNSSeeds resolves all configured DNS seeds and adds peers found to
+// the discovery pool.
+func (s *Server) resolveDNSSeeds() {
+	for _, name := range s.dnsSeeds {
+		ctx, cancel := context.WithTimeout(context.Background(), dnsSeedTimeout)
+		addrs, err := resolveDNSSeed(ctx, name)
+		cancel()
+		if err != nil {
+			s.log.Warn("failed to resolve DNS seed", zap.String("seed", name), zap.Error(err))
+			continue
+		}
+		s.log.Debug("DNS seed resolved", zap.String("seed", name), zap.Strings("addresses", addrs))
+		s.resolvedSeedsLock.Lock()
+		for _, addr := range addrs {
+			s.resolvedSeeds[addr] = struct{}{}
+		}
+		s.resolvedSeedsLock.Unlock()
+		s.discovery.BackFill(addrs...)
+	}
+}
+
+// SeedAddresses returns regular seed addresses from the configured seed list
+// along with all addresses resolved from DNS seeds so far.
+func (s *Server) SeedAddresses() []string {
+	s.resolvedSeedsLock.RLock()
+	defer s.resolvedSeedsLock.RUnlock()
+
+	addrs := make([]string, 0, len(s.seedAddrs)+len(s.resolvedSeeds))
+	addrs = append(addrs, s.seedAddrs...)
+	for addr := range s.resolvedSeeds {
+		addrs = append(addrs, addr)
+	}
+	slices.Sort(addrs[len(s.seedAddrs):])
+	return addrs
+}
+
+// loadPeerList adds peers saved to the configured peer list file to the
+// discovery pool.
+func (s *Server) loadPeerList() {
+	if s.PeerListPath == "" {
+		return
+	}
+	data, err := os.ReadFile(s.PeerListPath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			s.log.Warn("failed to read peer list", zap.String("path", s.PeerListPath), zap.Error(err))
+		}
+		return
+	}
+	var addrs []string
+	if err := json.Unmarshal(data, &addrs); err != nil {
+		s.log.Warn("failed to decode peer list", zap.String("path", s.PeerListPath), zap.Error(err))
+		return
+	}
+	s.discovery.BackFill(addrs...)
+}
+
+// savePeerList stores addresses of known good peers to the configured peer
+// list file.
+func (s *Server) savePeerList() {
+	if s.PeerListPath == "" {
+		return
+	}
+	good := s.discovery.GoodPeers()
+	addrs := make([]string, 0, len(good))
+	for _, p := range good {
+		addrs = append(addrs, p.Address)
+	}
+	slices.Sort(addrs)
+	if err := writeJSONFile(s.PeerListPath, addrs); err != nil {
+		s.log.Error("failed to save peer list", zap.String("path", s.PeerListPath), zap.Error(err))
+	}
+}
+
+// seedLoop loads saved peers and resolves DNS seeds on start, then it
+// periodically refreshes DNS seeds and saves good peers until the server is
+// stopped.
+func (s *Server) seedLoop() {
+	defer close(s.seedFin)
+	if len(s.dnsSeeds) == 0 && s.PeerListPath == "" {
+		return
+	}
+	s.loadPeerList()
+	s.resolveDNSSeeds()
+	ticker := time.NewTicker(s.SeedRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.quit:
+			s.savePeerList()
+			return
+		case <-ticker.C:
+			s.resolveDNSSeeds()
+			s.savePeerList()
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func mockLookupSRV(t *testing.T, records map[string][]*net.SRV) {
+	old := lookupSRV
+	lookupSRV = func(_ context.Context, service, proto, name string) (string, []*net.SRV, error) {
+		require.Empty(t, service)
+		require.Empty(t, proto)
+		srvs, ok := records[name]
+		if !ok {
+			return "", nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+		}
+		return name, srvs, nil
+	}
+	t.Cleanup(func() { lookupSRV = old })
+}
+
+func TestSplitSeeds(t *testing.T) {
+	addrs, names, err := splitSeeds(nil)
+	require.NoError(t, err)
+	require.Nil(t, addrs)
+	require.Nil(t, names)
+
+	addrs, names, err = splitSeeds([]string{"127.0.0.1:20333", "dns+srv://_neo._tcp.example.com", "seed.example.com:20333"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"127.0.0.1:20333", "seed.example.com:20333"}, addrs)
+	require.Equal(t, []string{"_neo._tcp.example.com"}, names)
+
+	_, _, err = splitSeeds([]string{"dns+srv://"})
+	require.Error(t, err)
+}
+
+func TestResolveDNSSeed(t *testing.T) {
+	mockLookupSRV(t, map[string][]*net.SRV{
+		"_neo._tcp.example.com": {
+			{Target: "seed1.example.com.", Port: 20333},
+			{Target: ".", Port: 20333}, // Service is not available.
+			{Target: "::1", Port: 10333},
+		},
+	})
+
+	addrs, err := resolveDNSSeed(context.Background(), "_neo._tcp.example.com")
+	require.NoError(t, err)
+	require.Equal(t, []string{"seed1.example.com:20333", "[::1]:10333"}, addrs)
+
+	_, err = resolveDNSSeed(context.Background(), "_neo._tcp.unknown.com")
+	var dnsErr *net.DNSError
+	require.True(t, errors.As(err, &dnsErr))
+}
+
+func TestServerSeeds(t *testing.T) {
+	mockLookupSRV(t, map[string][]*net.SRV{
+		"_neo._tcp.example.com": {{Target: "seed1.example.com.", Port: 20333}},
+	})
+
+	t.Run("bad seed", func(t *testing.T) {
+		_, err := newServerFromConstructors(ServerConfig{Seeds: []string{"dns+srv://"}}, nil, nil, zaptest.NewLogger(t), newFakeTransp, newTestDiscovery)
+		require.ErrorContains(t, err, "empty DNS seed name")
+	})
+
+	peerList := filepath.Join(t.TempDir(), "peers", "peers.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(peerList), os.ModePerm))
+	require.NoError(t, os.WriteFile(peerList, []byte(`["10.0.0.1:20333"]`), 0o644))
+
+	s := newTestServer(t, ServerConfig{
+		Seeds:        []string{"dns+srv://_neo._tcp.example.com", "dns+srv://_neo._tcp.unknown.com", "127.0.0.1:20333"},
+		PeerListPath: peerList,
+	})
+	require.Equal(t, defaultSeedRefreshInterval, s.SeedRefreshInterval)
+	require.Equal(t, []string{"_neo._tcp.example.com", "_neo._tcp.unknown.com"}, s.dnsSeeds)
+	require.Equal(t, []string{"127.0.0.1:20333"}, s.SeedAddresses())
+
+	d := s.discovery.(*testDiscovery)
+	d.good = []AddressWithCapabilities{{Address: "10.0.0.3:20333"}, {Address: "10.0.0.2:20333"}}
+	close(s.quit)
+	s.seedLoop()
+	<-s.seedFin
+	require.Equal(t, []string{"10.0.0.1:20333", "seed1.example.com:20333"}, d.backfill)
+	require.Equal(t, []string{"127.0.0.1:20333", "seed1.example.com:20333"}, s.SeedAddresses())
+
+	data, err := os.ReadFile(peerList)
+	require.NoError(t, err)
+	var saved []string
+	require.NoError(t, json.Unmarshal(data, &saved))
+	require.Equal(t, []string{"10.0.0.2:20333", "10.0.0.3:20333"}, saved)
+}
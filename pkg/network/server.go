@@ -110,6 +110,16 @@ type (
 		extensiblePool    *extpool.Pool
 		notaryFeer        NotaryFeer
 		blockFetcher      *blockfetcher.Service
+		reputation        *reputation
+		mptRequests       *mptRequests
+		localTxs          *localTxs
+		// dnsSeeds contains DNS seed names from the seed list.
+		dnsSeeds []string
+		// seedAddrs contains regular seed addresses from the seed list.
+		seedAddrs []string
+		// resolvedSeeds contains all addresses ever resolved from dnsSeeds.
+		resolvedSeedsLock sync.RWMutex
+		resolvedSeeds     map[string]struct{}
 
 		serviceLock    sync.RWMutex
 		services       map[string]Service
@@ -117,9 +127,10 @@ type (
 		txCallback     func(*transaction.Transaction)
 		txCbList       atomic.Value
 
-		txInLock sync.RWMutex
-		txin     chan *transaction.Transaction
-		txInMap  map[util.Uint256]struct{}
+		txInLock            sync.Mutex
+		txin                chan *transaction.Transaction
+		knownTxs            *knownHashes
+		knownNotaryRequests *knownHashes
 
 		lock  sync.RWMutex
 		peers map[Peer]bool
@@ -137,6 +148,8 @@ type (
 		broadcastTxFin      chan struct{}
 		runProtoFin         chan struct{}
 		blockFetcherFin     chan struct{}
+		rebroadcastFin      chan struct{}
+		seedFin             chan struct{}
 
 		transactions chan *transaction.Transaction
 
@@ -184,6 +197,19 @@ func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSy
 		log.Info("ExtensiblePoolSize is not set or wrong, using default value",
 			zap.Int("ExtensiblePoolSize", config.ExtensiblePoolSize))
 	}
+	if config.KnownTxCacheSize <= 0 {
+		config.KnownTxCacheSize = defaultKnownTxCacheSize
+	}
+	if config.KnownNotaryRequestCacheSize <= 0 {
+		config.KnownNotaryRequestCacheSize = defaultKnownNotaryRequestCacheSize
+	}
+	if config.SeedRefreshInterval <= 0 {
+		config.SeedRefreshInterval = defaultSeedRefreshInterval
+	}
+	seeds, dnsSeeds, err := splitSeeds(config.Seeds)
+	if err != nil {
+		return nil, err
+	}
 
 	s := &Server{
 		ServerConfig:    config,
@@ -196,10 +222,12 @@ func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSy
 		broadcastTxFin:  make(chan struct{}),
 		runProtoFin:     make(chan struct{}),
 		blockFetcherFin: make(chan struct{}),
+		rebroadcastFin:  make(chan struct{}),
+		seedFin:         make(chan struct{}),
 		register:        make(chan Peer),
 		unregister:      make(chan peerDrop),
 		handshake:       make(chan Peer),
-		txInMap:         make(map[util.Uint256]struct{}),
+		knownTxs:        newKnownHashes(config.KnownTxCacheSize, "tx"),
 		peers:           make(map[Peer]bool),
 		mempool:         chain.GetMemPool(),
 		extensiblePool:  extpool.New(chain, config.ExtensiblePoolSize),
@@ -209,7 +237,13 @@ func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSy
 		services:        make(map[string]Service),
 		extensHandlers:  make(map[string]func(*payload.Extensible) error),
 		stateSync:       stSync,
+		localTxs:        newLocalTxs(config.TxRebroadcastInterval),
+		mptRequests:     newMPTRequests(),
+		dnsSeeds:        dnsSeeds,
+		seedAddrs:       seeds,
+		resolvedSeeds:   make(map[string]struct{}),
 	}
+	s.knownNotaryRequests = newKnownHashes(config.KnownNotaryRequestCacheSize, "notaryrequest")
 	if chain.P2PSigExtensionsEnabled() {
 		s.notaryFeer = NewNotaryFeer(chain)
 		s.notaryRequestPool = mempool.New(s.config.P2PNotaryRequestPayloadPoolSize, 1, true, updateNotarypoolMetrics)
@@ -228,7 +262,10 @@ func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSy
 		s.NeoFSBlockFetcherCfg.BQueueSize = blockfetcher.DefaultQueueCacheSize
 	}
 	s.bFetcherQueue = bqueue.New(chain, log, nil, s.NeoFSBlockFetcherCfg.BQueueSize, updateBlockQueueLenMetric, bqueue.Blocking)
-	var err error
+	s.reputation, err = newReputation(s.ReputationCfg, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize peer reputation: %w", err)
+	}
 	s.blockFetcher, err = blockfetcher.New(chain, s.NeoFSBlockFetcherCfg, log, s.bFetcherQueue.PutBlock,
 		sync.OnceFunc(func() { close(s.blockFetcherFin) }))
 	if err != nil {
@@ -272,7 +309,7 @@ func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSy
 	}
 	s.transports = transports
 	s.discovery = newDiscovery(
-		s.Seeds,
+		seeds,
 		s.DialTimeout,
 		// Here we need to pick up a single transporter, it will be used to
 		// dial, and it doesn't matter which one.
@@ -307,6 +344,8 @@ func (s *Server) Start() {
 		go s.txHandlerLoop()
 	}
 	go s.broadcastTxLoop()
+	go s.rebroadcastLoop()
+	go s.seedLoop()
 	go s.relayBlocksLoop()
 	go s.bQueue.Run()
 	go s.bSyncQueue.Run()
@@ -353,6 +392,8 @@ func (s *Server) Shutdown() {
 	}
 	close(s.quit)
 	<-s.broadcastTxFin
+	<-s.rebroadcastFin
+	<-s.seedFin
 	<-s.runProtoFin
 	<-s.relayFin
 	<-s.runFin
@@ -520,7 +561,10 @@ func (s *Server) run() {
 			s.lock.Unlock()
 			peerCount := s.PeerCount()
 			s.log.Info("new peer connected", zap.Stringer("addr", p.RemoteAddr()), zap.Int("peerCount", peerCount))
-			if peerCount > s.MaxPeers {
+			if s.reputation.isBanned(p) {
+				// It will send us unregister signal.
+				go p.Disconnect(errPeerBanned)
+			} else if peerCount > s.MaxPeers {
 				s.lock.RLock()
 				// Pick a random peer and drop connection to it.
 				for peer := range s.peers {
@@ -548,8 +592,17 @@ func (s *Server) run() {
 						zap.Error(drop.reason),
 						zap.Int("peerCount", s.PeerCount()))
 				}
+				s.reputation.disconnected(drop.peer, drop.reason)
+				if st := s.mptRequests.disconnected(drop.peer); st != nil {
+					s.log.Debug("MPT nodes exchange statistics",
+						zap.Stringer("addr", drop.peer.RemoteAddr()),
+						zap.Int("requested", st.requested),
+						zap.Int("received", st.received))
+				}
 				if errors.Is(drop.reason, errIdenticalID) {
 					s.discovery.RegisterSelf(drop.peer)
+				} else if until, ok := s.reputation.banExpiration(drop.peer); ok {
+					s.discovery.RegisterBanned(drop.peer, until)
 				} else {
 					s.discovery.UnregisterConnected(drop.peer, errors.Is(drop.reason, errAlreadyConnected))
 				}
@@ -568,6 +621,12 @@ func (s *Server) run() {
 				zap.Uint32("startHeight", p.LastBlockIndex()),
 				zap.Uint32("id", ver.Nonce))
 
+			// Incoming connections can only be checked after handshake
+			// since the peer address is not known before it.
+			if s.reputation.isBanned(p) {
+				go p.Disconnect(errPeerBanned)
+				continue
+			}
 			s.discovery.RegisterGood(p)
 
 			s.tryInitStateSync()
@@ -795,12 +854,23 @@ func (s *Server) handleBlockCmd(p Peer, block *block.Block) error {
 	if s.blockFetcher.IsActive() {
 		return nil
 	}
+	if block.Index > s.chain.BlockHeight() {
+		s.scorePeer(p, scoreUsefulBlock)
+	}
 	if s.stateSync.IsActive() {
 		return s.bSyncQueue.PutBlock(block)
 	}
 	return s.bQueue.PutBlock(block)
 }
 
+// scorePeer updates the peer score and disconnects it if it's banned as
+// a result.
+func (s *Server) scorePeer(p Peer, delta int) {
+	if s.reputation.update(p, delta) {
+		go p.Disconnect(errPeerBanned)
+	}
+}
+
 // handlePing processes a ping request.
 func (s *Server) handlePing(p Peer, ping *payload.Ping) error {
 	err := p.HandlePing(ping)
@@ -832,15 +902,15 @@ func (s *Server) requestBlocksOrHeaders(p Peer) error {
 		bq = s.stateSync
 		requestMPTNodes = s.stateSync.NeedMPTNodes()
 	}
-	if bq.BlockHeight() >= p.LastBlockIndex() {
+	if bq.BlockHeight() >= p.LastBlockIndex() || !s.preferredForBlocks(p) {
 		return nil
 	}
 	err := s.requestBlocks(bq, p)
 	if err != nil {
 		return fmt.Errorf("%w: %w", errBlocksRequestFailed, err)
 	}
-	if requestMPTNodes {
-		return s.requestMPTNodes(p, s.stateSync.GetUnknownMPTNodesBatch(payload.MaxMPTHashesCount))
+	if requestMPTNodes && s.mptRequests.canRequest(p) {
+		return s.requestMPTNodes(p, s.stateSync.RequestMPTNodesBatch(payload.MaxMPTHashesCount))
 	}
 	return nil
 }
@@ -857,18 +927,35 @@ func (s *Server) handlePong(p Peer, pong *payload.Ping) error {
 	if err != nil {
 		return err
 	}
+	if s.reputation.pongReceived(p) {
+		go p.Disconnect(errPeerBanned)
+		return nil
+	}
 	return s.requestBlocksOrHeaders(p)
 }
 
+// preferredForBlocks checks whether blocks can be requested from the given
+// peer. Peers with negative score are only used if there are no better peers
+// having at least the same height.
+func (s *Server) preferredForBlocks(p Peer) bool {
+	score := s.reputation.score(p)
+	if score >= 0 {
+		return true
+	}
+	height := p.LastBlockIndex()
+	better := s.getPeers(func(other Peer) bool {
+		return other != p && other.Handshaked() && other.LastBlockIndex() >= height &&
+			s.reputation.score(other) > score
+	})
+	return len(better) == 0
+}
+
 // handleInvCmd processes the received inventory.
 func (s *Server) handleInvCmd(p Peer, inv *payload.Inventory) error {
 	var reqHashes = inv.Hashes[:0]
 	var typExists = map[payload.InventoryType]func(util.Uint256) bool{
 		payload.TXType: func(h util.Uint256) bool {
-			s.txInLock.RLock()
-			_, ok := s.txInMap[h]
-			s.txInLock.RUnlock()
-			return ok || s.mempool.ContainsKey(h)
+			return s.knownTxs.Contains(h) || s.mempool.ContainsKey(h)
 		},
 		payload.BlockType: s.chain.HasBlock,
 		payload.ExtensibleType: func(h util.Uint256) bool {
@@ -876,7 +963,7 @@ func (s *Server) handleInvCmd(p Peer, inv *payload.Inventory) error {
 			return cp != nil
 		},
 		payload.P2PNotaryRequestType: func(h util.Uint256) bool {
-			return s.notaryRequestPool.ContainsKey(h)
+			return s.knownNotaryRequests.Contains(h) || s.notaryRequestPool.ContainsKey(h)
 		},
 	}
 	if exists := typExists[inv.Type]; exists != nil {
@@ -1034,11 +1121,19 @@ func (s *Server) handleMPTDataCmd(p Peer, data *payload.MPTData) error {
 	if !s.config.P2PStateExchangeExtensions {
 		return errors.New("MPTDataCMD was received, but P2PStateExchangeExtensions are disabled")
 	}
-	return s.stateSync.AddMPTNodes(data.Nodes)
+	s.mptRequests.dataReceived(p, len(data.Nodes))
+	err := s.stateSync.AddMPTNodes(data.Nodes)
+	if err != nil {
+		return err
+	}
+	// The peer is able to serve MPT nodes, so keep it busy with the next chunk.
+	if s.stateSync.NeedMPTNodes() {
+		return s.requestMPTNodes(p, s.stateSync.RequestMPTNodesBatch(payload.MaxMPTHashesCount))
+	}
+	return nil
 }
 
-// requestMPTNodes requests the specified MPT nodes from the peer or broadcasts
-// request if no peer is specified.
+// requestMPTNodes requests the specified MPT nodes from the peer.
 func (s *Server) requestMPTNodes(p Peer, itms []util.Uint256) error {
 	if len(itms) == 0 {
 		return nil
@@ -1046,6 +1141,7 @@ func (s *Server) requestMPTNodes(p Peer, itms []util.Uint256) error {
 	if len(itms) > payload.MaxMPTHashesCount {
 		itms = itms[:payload.MaxMPTHashesCount]
 	}
+	s.mptRequests.requestSent(p, len(itms))
 	pl := payload.NewMPTInventory(itms)
 	msg := NewMessage(CMDGetMPTData, pl)
 	return p.EnqueueP2PMessage(msg)
@@ -1178,18 +1274,18 @@ func (s *Server) advertiseExtensible(e *payload.Extensible) {
 	}
 }
 
-// handleTxCmd processes the received transaction.
-// It never returns an error.
+// handleTxCmd processes the received transaction. Transactions that are
+// already known (being processed or processed recently, successfully or not)
+// are ignored. It never returns an error.
 func (s *Server) handleTxCmd(tx *transaction.Transaction) error {
 	// It's OK for it to fail for various reasons like tx already existing
 	// in the pool.
 	s.txInLock.Lock()
-	_, ok := s.txInMap[tx.Hash()]
-	if ok || s.mempool.ContainsKey(tx.Hash()) {
+	if s.knownTxs.Contains(tx.Hash()) || s.mempool.ContainsKey(tx.Hash()) {
 		s.txInLock.Unlock()
 		return nil
 	}
-	s.txInMap[tx.Hash()] = struct{}{}
+	s.knownTxs.Add(tx.Hash())
 	s.txInLock.Unlock()
 	s.txin <- tx
 	return nil
@@ -1220,9 +1316,6 @@ txloop:
 			} else {
 				s.log.Debug("tx handler", zap.Error(err), zap.String("hash", tx.Hash().StringLE()))
 			}
-			s.txInLock.Lock()
-			delete(s.txInMap, tx.Hash())
-			s.txInLock.Unlock()
 		case <-s.quit:
 			break txloop
 		}
@@ -1244,6 +1337,10 @@ func (s *Server) handleP2PNotaryRequestCmd(r *payload.P2PNotaryRequest) error {
 	}
 	// It's OK for it to fail for various reasons like request already existing
 	// in the pool.
+	if s.knownNotaryRequests.Contains(r.Hash()) {
+		return nil
+	}
+	s.knownNotaryRequests.Add(r.Hash())
 	err := s.RelayP2PNotaryRequest(r)
 	if err != nil {
 		s.log.Debug("p2p notary request", zap.Error(err), zap.String("hash", r.Hash().StringLE()), zap.String("main", r.MainTransaction.Hash().StringLE()))
@@ -1526,9 +1623,7 @@ func (s *Server) RequestTx(hashes ...util.Uint256) {
 		return
 	}
 
-	var sorted = slices.Clone(hashes)
-	slices.SortFunc(sorted, util.Uint256.Compare)
-	s.txCbList.Store(sorted)
+	s.txCbList.Store(util.SortUnique(slices.Clone(hashes)))
 
 	for i := range len(hashes)/payload.MaxHashesCount + 1 {
 		start := i * payload.MaxHashesCount
@@ -1582,6 +1677,7 @@ func (s *Server) iteratePeersWithSendMsg(msg *Message, send func(Peer, context.C
 			}
 			if msg.Command == CMDPing {
 				p.SetPingTimer()
+				s.reputation.pingSent(p)
 			}
 			replies <- send(p, ctx, pkt)
 		}(peer, ctx, pkt)
@@ -1653,9 +1749,14 @@ func (s *Server) verifyAndPoolTX(t *transaction.Transaction) error {
 
 // RelayTxn a new transaction to the local node and the connected peers.
 // Reference: the method OnRelay in C#: https://github.com/neo-project/neo/blob/master/neo/Network/P2P/LocalNode.cs#L159
+// If TxRebroadcastInterval is set, the transaction is periodically announced
+// to peers until it's included into a block or expires.
 func (s *Server) RelayTxn(t *transaction.Transaction) error {
 	err := s.verifyAndPoolTX(t)
 	if err == nil {
+		if !s.localTxs.add(t) {
+			s.log.Debug("too many local transactions, not tracking for rebroadcast", zap.Stringer("hash", t.Hash()))
+		}
 		s.broadcastTX(t, nil)
 	}
 	return err
@@ -42,8 +42,16 @@ type (
 		Relay bool
 
 		// Seeds is a list of initial nodes used to establish connectivity.
+		// It can contain DNS SRV seeds in "dns+srv://name" form.
 		Seeds []string
 
+		// SeedRefreshInterval is the interval between DNS seed resolutions
+		// and peer list saves.
+		SeedRefreshInterval time.Duration
+
+		// PeerListPath is the file good peers are saved to and loaded from.
+		PeerListPath string
+
 		// Maximum duration a single dial may take.
 		DialTimeout time.Duration
 
@@ -74,10 +82,24 @@ type (
 		// ExtensiblePoolSize is the size of the pool for extensible payloads from a single sender.
 		ExtensiblePoolSize int
 
+		// KnownTxCacheSize is the number of remembered transaction hashes.
+		KnownTxCacheSize int
+
+		// KnownNotaryRequestCacheSize is the number of remembered P2P notary
+		// request hashes.
+		KnownNotaryRequestCacheSize int
+
 		// BroadcastFactor is the factor (0-100) for fan-out optimization.
 		BroadcastFactor int
 
 		NeoFSBlockFetcherCfg config.NeoFSBlockFetcher
+
+		// ReputationCfg is peer reputation tracking configuration.
+		ReputationCfg config.PeerReputation
+
+		// TxRebroadcastInterval is the interval between announcements of
+		// locally originated transactions, 0 disables rebroadcasting.
+		TxRebroadcastInterval time.Duration
 	}
 )
 
@@ -91,25 +113,31 @@ func NewServerConfig(cfg config.Config) (ServerConfig, error) {
 		return ServerConfig{}, fmt.Errorf("failed to parse addresses: %w", err)
 	}
 	c := ServerConfig{
-		UserAgent:            cfg.GenerateUserAgent(),
-		Addresses:            addrs,
-		Net:                  protoConfig.Magic,
-		Relay:                appConfig.Relay,
-		Seeds:                protoConfig.SeedList,
-		DialTimeout:          appConfig.P2P.DialTimeout,
-		ProtoTickInterval:    appConfig.P2P.ProtoTickInterval,
-		PingInterval:         appConfig.P2P.PingInterval,
-		PingTimeout:          appConfig.P2P.PingTimeout,
-		MaxPeers:             appConfig.P2P.MaxPeers,
-		AttemptConnPeers:     appConfig.P2P.AttemptConnPeers,
-		MinPeers:             appConfig.P2P.MinPeers,
-		TimePerBlock:         protoConfig.TimePerBlock,
-		OracleCfg:            appConfig.Oracle,
-		P2PNotaryCfg:         appConfig.P2PNotary,
-		StateRootCfg:         appConfig.StateRoot,
-		ExtensiblePoolSize:   appConfig.P2P.ExtensiblePoolSize,
-		BroadcastFactor:      appConfig.P2P.BroadcastFactor,
-		NeoFSBlockFetcherCfg: appConfig.NeoFSBlockFetcher,
+		UserAgent:                   cfg.GenerateUserAgent(),
+		Addresses:                   addrs,
+		Net:                         protoConfig.Magic,
+		Relay:                       appConfig.Relay,
+		Seeds:                       protoConfig.SeedList,
+		SeedRefreshInterval:         appConfig.P2P.SeedRefreshInterval,
+		PeerListPath:                appConfig.P2P.PeerListPath,
+		DialTimeout:                 appConfig.P2P.DialTimeout,
+		ProtoTickInterval:           appConfig.P2P.ProtoTickInterval,
+		PingInterval:                appConfig.P2P.PingInterval,
+		PingTimeout:                 appConfig.P2P.PingTimeout,
+		MaxPeers:                    appConfig.P2P.MaxPeers,
+		AttemptConnPeers:            appConfig.P2P.AttemptConnPeers,
+		MinPeers:                    appConfig.P2P.MinPeers,
+		TimePerBlock:                protoConfig.TimePerBlock,
+		OracleCfg:                   appConfig.Oracle,
+		P2PNotaryCfg:                appConfig.P2PNotary,
+		StateRootCfg:                appConfig.StateRoot,
+		ExtensiblePoolSize:          appConfig.P2P.ExtensiblePoolSize,
+		BroadcastFactor:             appConfig.P2P.BroadcastFactor,
+		KnownTxCacheSize:            appConfig.P2P.KnownTxCacheSize,
+		KnownNotaryRequestCacheSize: appConfig.P2P.KnownNotaryRequestCacheSize,
+		NeoFSBlockFetcherCfg:        appConfig.NeoFSBlockFetcher,
+		ReputationCfg:               appConfig.P2P.Reputation,
+		TxRebroadcastInterval:       appConfig.P2P.TxRebroadcastInterval,
 	}
 	return c, nil
 }
@@ -53,6 +53,7 @@ func (f *fakeConsensus) OnTransaction(tx *transaction.Transaction) {
 	f.txs = append(f.txs, tx)
 }
 func (f *fakeConsensus) GetPayload(h util.Uint256) *payload.Extensible { panic("implement me") }
+func (f *fakeConsensus) ReloadWallet(cfg config.Wallet) error          { panic("implement me") }
 
 func TestNewServer(t *testing.T) {
 	bc := &fakechain.FakeChain{Blockchain: config.Blockchain{
@@ -754,6 +755,16 @@ func TestInv(t *testing.T) {
 		})
 		require.Equal(t, []util.Uint256{hs[0], hs[2]}, actual)
 	})
+	t.Run("known transaction", func(t *testing.T) {
+		tx := newDummyTx()
+		require.NoError(t, s.handleTxCmd(tx))
+		hs := []util.Uint256{random.Uint256(), tx.Hash(), random.Uint256()}
+		s.testHandleMessage(t, p, CMDInv, &payload.Inventory{
+			Type:   payload.TXType,
+			Hashes: hs,
+		})
+		require.Equal(t, []util.Uint256{hs[0], hs[2]}, actual)
+	})
 	t.Run("extensible", func(t *testing.T) {
 		ep := payload.NewExtensible()
 		s.chain.(*fakechain.FakeChain).VerifyWitnessF = func() (int64, error) { return 0, nil }
@@ -873,6 +884,40 @@ func TestHandleMPTData(t *testing.T) {
 		})
 		require.NoError(t, s.handleMessage(p, msg))
 	})
+
+	t.Run("next chunk is requested", func(t *testing.T) {
+		s := newTestServer(t, ServerConfig{UserAgent: "/test/"})
+		s.config.P2PStateExchangeExtensions = true
+		next := []util.Uint256{random.Uint256(), random.Uint256()}
+		stSync := &fakechain.FakeStateSync{
+			AddMPTNodesFunc: func(nodes [][]byte) error { return nil },
+			RequestMPTNodesFunc: func(limit int) []util.Uint256 {
+				require.Equal(t, payload.MaxMPTHashesCount, limit)
+				return next
+			},
+		}
+		stSync.RequestMPTNodes.Store(true)
+		s.stateSync = stSync
+		startWithCleanup(t, s)
+
+		var actual []util.Uint256
+		p := newLocalPeer(t, s)
+		p.handshaked = 1
+		p.messageHandler = func(t *testing.T, msg *Message) {
+			if msg.Command == CMDGetMPTData {
+				actual = append(actual, msg.Payload.(*payload.MPTInventory).Hashes...)
+			}
+		}
+		s.mptRequests.requestSent(p, 1)
+		require.False(t, s.mptRequests.canRequest(p))
+
+		msg := NewMessage(CMDMPTData, &payload.MPTData{
+			Nodes: [][]byte{{1, 2, 3}},
+		})
+		require.NoError(t, s.handleMessage(p, msg))
+		require.Equal(t, next, actual)
+		require.False(t, s.mptRequests.canRequest(p))
+	})
 }
 
 func TestRequestMPTNodes(t *testing.T) {
@@ -13,8 +13,8 @@ type StateSync interface {
 	Init(currChainHeight uint32) error
 	IsActive() bool
 	IsInitialized() bool
-	GetUnknownMPTNodesBatch(limit int) []util.Uint256
 	NeedHeaders() bool
 	NeedMPTNodes() bool
+	RequestMPTNodesBatch(limit int) []util.Uint256
 	Traverse(root util.Uint256, process func(node mpt.Node, nodeBytes []byte) bool) error
 }
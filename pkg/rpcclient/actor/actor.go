@@ -110,6 +110,10 @@ type Options struct {
 	// awaiting behaviour. This option may be kept empty for default
 	// awaiting behaviour.
 	WaiterConfig waiter.Config
+	// FeeMargin is the percentage of GAS consumed by the test invocation
+	// that is added to the system fee suggested by EstimateFees. It's
+	// DefaultFeeMargin for default Options, zero value means no margin.
+	FeeMargin int
 }
 
 // New creates an Actor instance using the specified RPC interface and the set of
@@ -119,19 +123,9 @@ type Options struct {
 // it is cached forever (and used for internal purposes). The actor will use
 // default Options (which can be overridden using NewTuned).
 func New(ra RPCActor, signers []SignerAccount) (*Actor, error) {
-	if len(signers) < 1 {
-		return nil, errors.New("at least one signer (sender) is required")
-	}
-	invSigners := make([]transaction.Signer, len(signers))
-	for i := range signers {
-		if signers[i].Account.Contract == nil {
-			return nil, fmt.Errorf("empty contract for account %s", signers[i].Account.Address)
-		}
-		if !signers[i].Account.Contract.Deployed && signers[i].Account.Contract.ScriptHash() != signers[i].Signer.Account {
-			return nil, fmt.Errorf("signer account doesn't match script hash for signer %s", signers[i].Account.Address)
-		}
-
-		invSigners[i] = signers[i].Signer
+	invSigners, err := txSigners(signers)
+	if err != nil {
+		return nil, err
 	}
 	inv := invoker.New(ra, invSigners)
 	version, err := ra.GetVersion()
@@ -149,6 +143,26 @@ func New(ra RPCActor, signers []SignerAccount) (*Actor, error) {
 	}, nil
 }
 
+// txSigners checks the given set of signers and returns transaction signers
+// for it.
+func txSigners(signers []SignerAccount) ([]transaction.Signer, error) {
+	if len(signers) < 1 {
+		return nil, errors.New("at least one signer (sender) is required")
+	}
+	res := make([]transaction.Signer, len(signers))
+	for i := range signers {
+		if signers[i].Account.Contract == nil {
+			return nil, fmt.Errorf("empty contract for account %s", signers[i].Account.Address)
+		}
+		if !signers[i].Account.Contract.Deployed && signers[i].Account.Contract.ScriptHash() != signers[i].Signer.Account {
+			return nil, fmt.Errorf("signer account doesn't match script hash for signer %s", signers[i].Account.Address)
+		}
+
+		res[i] = signers[i].Signer
+	}
+	return res, nil
+}
+
 // NewSimple makes it easier to create an Actor for the most widespread case
 // when transactions have only one signer that uses CalledByEntry scope. When
 // other scopes or multiple signers are needed use New.
@@ -164,11 +178,13 @@ func NewSimple(ra RPCActor, acc *wallet.Account) (*Actor, error) {
 
 // NewDefaultOptions returns Options that have no attributes and use the default
 // TransactionCheckerModifier function (that checks for the invocation result to
-// be in HALT state) and TransactionModifier (that does nothing).
+// be in HALT state), TransactionModifier (that does nothing) and
+// DefaultFeeMargin.
 func NewDefaultOptions() Options {
 	return Options{
 		CheckerModifier: DefaultCheckerModifier,
 		Modifier:        DefaultModifier,
+		FeeMargin:       DefaultFeeMargin,
 	}
 }
 
@@ -181,6 +197,7 @@ func NewTuned(ra RPCActor, signers []SignerAccount, opts Options) (*Actor, error
 		return nil, err
 	}
 	a.opts.Attributes = opts.Attributes
+	a.opts.FeeMargin = opts.FeeMargin
 	if opts.CheckerModifier != nil {
 		a.opts.CheckerModifier = opts.CheckerModifier
 	}
@@ -337,3 +337,45 @@ func TestWaitSuccess(t *testing.T) {
 		Execution: ex,
 	}, res)
 }
+
+func TestNewSponsored(t *testing.T) {
+	client, acc := testRPCAndAccount(t)
+	sponsor, err := wallet.NewAccount()
+	require.NoError(t, err)
+
+	signers := []SignerAccount{{
+		Signer: transaction.Signer{
+			Account: acc.ScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		},
+		Account: acc,
+	}}
+
+	_, err = NewSponsored(client, sponsor, nil)
+	require.Error(t, err)
+
+	_, err = NewSponsored(client, nil, signers)
+	require.Error(t, err)
+
+	_, err = NewSponsored(client, acc, signers)
+	require.Error(t, err)
+
+	a, err := NewSponsored(client, sponsor, signers)
+	require.NoError(t, err)
+	require.Equal(t, sponsor.ScriptHash(), a.Sender())
+	require.Equal(t, append([]SignerAccount{{
+		Signer: transaction.Signer{
+			Account: sponsor.ScriptHash(),
+			Scopes:  transaction.None,
+		},
+		Account: sponsor,
+	}}, signers...), a.SignerAccounts())
+
+	client.invRes = &result.Invoke{State: "HALT", GasConsumed: 3, Script: []byte{1}}
+	client.netFee = 2
+	tx, err := a.MakeRun([]byte{1})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(tx.Signers))
+	require.Equal(t, 2, len(tx.Scripts))
+	require.Equal(t, sponsor.ScriptHash(), tx.Sender())
+}
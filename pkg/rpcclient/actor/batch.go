@@ -0,0 +1,154 @@
+package actor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+)
+
+// Batch collects state-changing calls made via contract wrappers (like nep17,
+// policy or generated RPC bindings) into a single script that is then executed
+// by one transaction with the signers of the parent Actor. It can be used
+// instead of Actor to create wrappers, in this case wrapper methods that
+// normally create or send transactions (Make*/Send* Actor methods) just append
+// their scripts to the batch, so for example
+//
+//	b := a.NewBatch()
+//	gasToken := nep17.New(b, gas.Hash)
+//	_, _, _ = gasToken.Transfer(a.Sender(), to1, amount1, nil)
+//	_, _, _ = gasToken.Transfer(a.Sender(), to2, amount2, nil)
+//	_, _, _ = policy.New(b).SetFeePerByte(1000)
+//	h, vub, err := b.Send()
+//
+// sends one transaction with both transfers and a policy change, fees are
+// calculated once for the whole script. Values returned by wrapper methods in
+// this case are always zero (or nil) and must be ignored, errors are returned
+// by the final Send, Transaction or Unsigned call. Read-only wrapper methods
+// are executed immediately via the Actor's Invoker as usual.
+//
+// Scripts are executed one after another, so results of calls that return
+// something remain on the stack (wrappers add ASSERTs for calls returning
+// Boolean success status, so any failing call fails the whole transaction).
+// The resulting script must fit into transaction.MaxScriptLength, see Len.
+// Batch is not thread-safe.
+type Batch struct {
+	*invoker.Invoker
+
+	actor  *Actor
+	script []byte
+	err    error
+}
+
+// NewBatch creates an empty Batch for this Actor.
+func (a *Actor) NewBatch() *Batch {
+	return &Batch{
+		Invoker: &a.Invoker,
+		actor:   a,
+	}
+}
+
+// MakeCall appends a call of the given method of the given contract with the
+// given parameters to the batch. It always returns nil transaction and nil
+// error, invalid parameters make Script fail.
+func (b *Batch) MakeCall(contract util.Uint160, method string, params ...any) (*transaction.Transaction, error) {
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, contract, method, callflag.All, params...)
+	if w.Err != nil {
+		if b.err == nil {
+			b.err = fmt.Errorf("failed to emit %s call: %w", method, w.Err)
+		}
+		return nil, nil
+	}
+	return b.MakeRun(w.Bytes())
+}
+
+// MakeRun appends the given script to the batch. It always returns nil
+// transaction and nil error.
+func (b *Batch) MakeRun(script []byte) (*transaction.Transaction, error) {
+	b.script = append(b.script, script...)
+	return nil, nil
+}
+
+// MakeUnsignedCall is the same as MakeCall, attributes are ignored.
+func (b *Batch) MakeUnsignedCall(contract util.Uint160, method string, _ []transaction.Attribute, params ...any) (*transaction.Transaction, error) {
+	return b.MakeCall(contract, method, params...)
+}
+
+// MakeUnsignedRun is the same as MakeRun, attributes are ignored.
+func (b *Batch) MakeUnsignedRun(script []byte, _ []transaction.Attribute) (*transaction.Transaction, error) {
+	return b.MakeRun(script)
+}
+
+// SendCall is the same as MakeCall, it returns zero hash and ValidUntilBlock
+// values.
+func (b *Batch) SendCall(contract util.Uint160, method string, params ...any) (util.Uint256, uint32, error) {
+	_, _ = b.MakeCall(contract, method, params...)
+	return util.Uint256{}, 0, nil
+}
+
+// SendRun is the same as MakeRun, it returns zero hash and ValidUntilBlock
+// values.
+func (b *Batch) SendRun(script []byte) (util.Uint256, uint32, error) {
+	_, _ = b.MakeRun(script)
+	return util.Uint256{}, 0, nil
+}
+
+// Len returns the current length of the batch script.
+func (b *Batch) Len() int {
+	return len(b.script)
+}
+
+// Script returns the batch script. It returns an error if any of the calls
+// couldn't be emitted (because of invalid parameters) or if the batch is
+// empty.
+func (b *Batch) Script() ([]byte, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if len(b.script) == 0 {
+		return nil, errors.New("empty batch")
+	}
+	return b.script, nil
+}
+
+// Reset removes all calls from the batch, it can be reused after that.
+func (b *Batch) Reset() {
+	b.script = nil
+	b.err = nil
+}
+
+// Send creates a transaction with the batch script (see Actor.MakeRun) and
+// sends it to the network.
+func (b *Batch) Send() (util.Uint256, uint32, error) {
+	script, err := b.Script()
+	if err != nil {
+		return util.Uint256{}, 0, err
+	}
+	return b.actor.SendRun(script)
+}
+
+// Transaction creates a signed transaction with the batch script (see
+// Actor.MakeRun), it's not sent to the network.
+func (b *Batch) Transaction() (*transaction.Transaction, error) {
+	script, err := b.Script()
+	if err != nil {
+		return nil, err
+	}
+	return b.actor.MakeRun(script)
+}
+
+// Unsigned creates an unsigned transaction with the batch script and the given
+// attributes (see Actor.MakeUnsignedRun).
+func (b *Batch) Unsigned(attrs []transaction.Attribute) (*transaction.Transaction, error) {
+	script, err := b.Script()
+	if err != nil {
+		return nil, err
+	}
+	return b.actor.MakeUnsignedRun(script, attrs)
+}
@@ -0,0 +1,86 @@
+package actor
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/nep17"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/policy"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatch(t *testing.T) {
+	client, acc := testRPCAndAccount(t)
+	a, err := NewSimple(client, acc)
+	require.NoError(t, err)
+
+	b := a.NewBatch()
+	_, err = b.Script()
+	require.Error(t, err)
+	_, _, err = b.Send()
+	require.Error(t, err)
+
+	var (
+		to1     = util.Uint160{1, 2, 3}
+		to2     = util.Uint160{4, 5, 6}
+		gasTok  = nep17.New(b, nativehashes.GasToken)
+		pol     = policy.New(b)
+		builder = smartcontract.NewBuilder()
+	)
+	h, vub, err := gasTok.Transfer(a.Sender(), to1, big.NewInt(1), nil)
+	require.NoError(t, err)
+	require.Equal(t, util.Uint256{}, h)
+	require.Zero(t, vub)
+	tx, err := gasTok.TransferUnsigned(a.Sender(), to2, big.NewInt(2), nil)
+	require.NoError(t, err)
+	require.Nil(t, tx)
+	_, _, err = pol.SetFeePerByte(1000)
+	require.NoError(t, err)
+
+	builder.InvokeWithAssert(nativehashes.GasToken, "transfer", a.Sender(), to1, big.NewInt(1), nil)
+	builder.InvokeWithAssert(nativehashes.GasToken, "transfer", a.Sender(), to2, big.NewInt(2), nil)
+	builder.InvokeMethod(nativehashes.PolicyContract, "setFeePerByte", int64(1000))
+	expected, err := builder.Script()
+	require.NoError(t, err)
+	script, err := b.Script()
+	require.NoError(t, err)
+	require.Equal(t, expected, script)
+	require.Equal(t, len(expected), b.Len())
+
+	client.invRes = &result.Invoke{State: "HALT", GasConsumed: 3, Script: script}
+	client.netFee = 42
+	client.bCount.Store(100500)
+	tx, err = b.Transaction()
+	require.NoError(t, err)
+	require.Equal(t, script, tx.Script)
+	require.EqualValues(t, 3, tx.SystemFee)
+	require.EqualValues(t, 42, tx.NetworkFee)
+	require.Equal(t, 1, len(tx.Scripts))
+	require.NotNil(t, tx.Scripts[0].InvocationScript)
+
+	tx, err = b.Unsigned(nil)
+	require.NoError(t, err)
+	require.Equal(t, script, tx.Script)
+	require.Nil(t, tx.Scripts[0].InvocationScript)
+
+	client.hash = util.Uint256{7, 8, 9}
+	h, _, err = b.Send()
+	require.NoError(t, err)
+	require.Equal(t, client.hash, h)
+
+	b.Reset()
+	require.Zero(t, b.Len())
+	_, err = b.MakeCall(nativehashes.GasToken, "transfer", struct{}{})
+	require.NoError(t, err)
+	_, err = b.Script()
+	require.Error(t, err)
+	b.Reset()
+	_, err = b.MakeCall(nativehashes.GasToken, "symbol")
+	require.NoError(t, err)
+	_, err = b.Script()
+	require.NoError(t, err)
+}
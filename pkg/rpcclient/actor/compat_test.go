@@ -10,4 +10,6 @@ import (
 func TestRPCActorRPCClientCompat(t *testing.T) {
 	_ = actor.RPCActor(&rpcclient.WSClient{})
 	_ = actor.RPCActor(&rpcclient.Client{})
+	_ = actor.RPCMempoolInfo(&rpcclient.WSClient{})
+	_ = actor.RPCMempoolInfo(&rpcclient.Client{})
 }
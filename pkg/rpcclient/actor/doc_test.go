@@ -3,12 +3,14 @@ package actor_test
 import (
 	"context"
 	"encoding/json"
+	"math/big"
 	"os"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/gas"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/neo"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/policy"
 	sccontext "github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
@@ -120,3 +122,29 @@ func ExampleActor() {
 
 	// Signature collection is out of scope, usually it's manual for cases like this.
 }
+
+func ExampleBatch() {
+	// No error checking done at all, intentionally.
+	w, _ := wallet.NewWalletFromFile("somewhere")
+	defer w.Close()
+
+	c, _ := rpcclient.New(context.Background(), "url", rpcclient.Options{})
+
+	a, _ := actor.NewSimple(c, w.Accounts[0])
+
+	// Wrappers created with a batch don't send anything, they only add their
+	// calls to the batch script.
+	b := a.NewBatch()
+	gasToken := gas.New(b)
+	customContract := util.Uint160{9, 8, 7}
+
+	// Return values of wrapper methods are meaningless in this case.
+	_, _, _ = gasToken.Transfer(a.Sender(), util.Uint160{1, 2, 3}, big.NewInt(100500), nil)
+	_, _, _ = gasToken.Transfer(a.Sender(), util.Uint160{4, 5, 6}, big.NewInt(42), nil)
+	_, _, _ = b.SendCall(customContract, "method", 1, 2, 3)
+
+	// All of the calls are performed in a single transaction with fees
+	// calculated for the whole script.
+	txid, vub, _ := b.Send()
+	_, _ = a.WaitSuccess(txid, vub, nil)
+}
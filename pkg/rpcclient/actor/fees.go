@@ -0,0 +1,164 @@
+package actor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+const (
+	// DefaultFeeMargin is the default percentage of GAS consumed by the test
+	// invocation added to the system fee suggested by EstimateFees.
+	DefaultFeeMargin = 10
+
+	// mempoolPressureThreshold is the memory pool fill percentage starting
+	// from which EstimateFees raises the network fee to outbid the cheapest
+	// pooled transactions.
+	mempoolPressureThreshold = 75
+
+	// signatureInvocationLen is the length of invocation script pushing a
+	// single signature.
+	signatureInvocationLen = 2 + keys.SignatureLen
+)
+
+// RPCMempoolInfo is an optional interface that can be implemented by RPCActor
+// to provide memory pool statistics used by EstimateFees to adjust the
+// network fee. It's only supported by NeoGo RPC servers.
+type RPCMempoolInfo interface {
+	GetMemPoolInfo() (*result.MempoolInfo, error)
+}
+
+// FeeEstimate contains fees suggested for the transaction by EstimateFees.
+type FeeEstimate struct {
+	// SystemFee is the suggested system fee, GasConsumed plus the margin
+	// configured via Options.
+	SystemFee int64
+	// NetworkFee is the suggested network fee, MinNetworkFee plus
+	// PriorityFee.
+	NetworkFee int64
+
+	// GasConsumed is the amount of GAS consumed by the test invocation.
+	GasConsumed int64
+	// MinNetworkFee is the minimal network fee required for the
+	// transaction to be valid.
+	MinNetworkFee int64
+	// PriorityFee is the part of network fee added because of the memory
+	// pool pressure, it's zero if memory pool statistics are not available
+	// (see RPCMempoolInfo) or the pool is not filled enough.
+	PriorityFee int64
+}
+
+// EstimateFees estimates fees for the transaction executing the given script
+// signed by the given set of signers (or Actor signers if nil). The script is
+// test-invoked and is expected to end up in HALT state, the system fee is
+// calculated from the GAS consumed by this invocation plus the margin
+// configured via Options. The network fee is calculated by the RPC server for
+// a transaction with these signers, if memory pool statistics are available
+// (see RPCMempoolInfo) and the pool is at least 75% full it's raised to make
+// the fee per byte of the transaction higher than the one of the cheapest
+// pooled transactions, since they're the first to be evicted from the pool and
+// the last to be included into a block. Notice that it's an estimation, the
+// final transaction size depends on witnesses (standard signatures are
+// accounted for, but contract-based witnesses may differ) and chain state
+// can change before the transaction is accepted.
+func (a *Actor) EstimateFees(script []byte, signers []SignerAccount) (*FeeEstimate, error) {
+	if len(script) == 0 {
+		return nil, errors.New("empty script")
+	}
+	if signers == nil {
+		signers = a.signers
+	}
+	invSigners, err := txSigners(signers)
+	if err != nil {
+		return nil, err
+	}
+	r, err := invoker.New(a.client, invSigners).Run(script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to test-invoke: %w", err)
+	}
+	err = DefaultCheckerModifier(r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := transaction.New(script, r.GasConsumed)
+	tx.Signers = invSigners
+	tx.Attributes = a.opts.Attributes
+	tx.ValidUntilBlock, err = a.CalculateValidUntilBlock()
+	if err != nil {
+		return nil, fmt.Errorf("calculating validUntilBlock: %w", err)
+	}
+	err = setWitnessTemplates(tx, signers)
+	if err != nil {
+		return nil, err
+	}
+	netFee, err := a.client.CalculateNetworkFee(tx)
+	if err != nil {
+		return nil, fmt.Errorf("calculating network fee: %w", err)
+	}
+
+	res := &FeeEstimate{
+		SystemFee:     r.GasConsumed + r.GasConsumed*int64(a.opts.FeeMargin)/100,
+		NetworkFee:    netFee,
+		GasConsumed:   r.GasConsumed,
+		MinNetworkFee: netFee,
+	}
+	mi, ok := a.client.(RPCMempoolInfo)
+	if !ok {
+		return res, nil
+	}
+	info, err := mi.GetMemPoolInfo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mempool info: %w", err)
+	}
+	feePerByte := pressureFeePerByte(info)
+	if feePerByte == 0 {
+		return res, nil
+	}
+	for i := range signers {
+		if !signers[i].Account.Contract.Deployed {
+			tx.Scripts[i].InvocationScript = make([]byte, signatureInvocationLen*sigParamsCount(signers[i].Account.Contract.Parameters))
+		}
+	}
+	if minFee := feePerByte * int64(tx.Size()); minFee > netFee {
+		res.PriorityFee = minFee - netFee
+		res.NetworkFee = minFee
+	}
+	return res, nil
+}
+
+// sigParamsCount returns the number of signature parameters.
+func sigParamsCount(params []wallet.ContractParam) int {
+	var n int
+	for _, p := range params {
+		if p.Type == smartcontract.SignatureType {
+			n++
+		}
+	}
+	return n
+}
+
+// pressureFeePerByte returns the fee per byte that is higher than the one of
+// the cheapest transactions in the pool if the pool is filled enough and zero
+// otherwise.
+func pressureFeePerByte(info *result.MempoolInfo) int64 {
+	if info.Capacity == 0 || info.Count*100 < info.Capacity*mempoolPressureThreshold {
+		return 0
+	}
+	for i, b := range info.FeeHistogram {
+		if b.Count == 0 {
+			continue
+		}
+		if i+1 < len(info.FeeHistogram) {
+			return info.FeeHistogram[i+1].MinFeePerByte
+		}
+		return max(2*b.MinFeePerByte, 1) // The last bucket has no upper bound.
+	}
+	return 0
+}
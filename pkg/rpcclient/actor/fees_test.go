@@ -0,0 +1,106 @@
+package actor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/stretchr/testify/require"
+)
+
+type mempoolRPCClient struct {
+	RPCClient
+
+	info    *result.MempoolInfo
+	infoErr error
+}
+
+func (r *mempoolRPCClient) GetMemPoolInfo() (*result.MempoolInfo, error) {
+	return r.info, r.infoErr
+}
+
+func TestEstimateFees(t *testing.T) {
+	client, acc := testRPCAndAccount(t)
+	a, err := NewSimple(client, acc)
+	require.NoError(t, err)
+
+	_, err = a.EstimateFees(nil, nil)
+	require.Error(t, err)
+
+	client.err = errors.New("")
+	_, err = a.EstimateFees([]byte{1, 2, 3}, nil)
+	require.Error(t, err)
+
+	client.err = nil
+	client.invRes = &result.Invoke{State: "FAULT", GasConsumed: 1000}
+	_, err = a.EstimateFees([]byte{1, 2, 3}, nil)
+	require.Error(t, err)
+
+	client.invRes = &result.Invoke{State: vmstate.Halt.String(), GasConsumed: 1000}
+	client.netFee = 500
+	fees, err := a.EstimateFees([]byte{1, 2, 3}, nil)
+	require.NoError(t, err)
+	require.Equal(t, &FeeEstimate{
+		SystemFee:     1100,
+		NetworkFee:    500,
+		GasConsumed:   1000,
+		MinNetworkFee: 500,
+	}, fees)
+
+	t.Run("no margin", func(t *testing.T) {
+		opts := NewDefaultOptions()
+		opts.FeeMargin = 0
+		a, err := NewTuned(client, a.SignerAccounts(), opts)
+		require.NoError(t, err)
+		fees, err := a.EstimateFees([]byte{1, 2, 3}, nil)
+		require.NoError(t, err)
+		require.Equal(t, int64(1000), fees.SystemFee)
+	})
+	t.Run("bad signers", func(t *testing.T) {
+		_, err := a.EstimateFees([]byte{1, 2, 3}, []SignerAccount{})
+		require.Error(t, err)
+	})
+	t.Run("mempool", func(t *testing.T) {
+		mc := &mempoolRPCClient{RPCClient: RPCClient{
+			invRes:  client.invRes,
+			netFee:  client.netFee,
+			version: client.version,
+		}}
+		a, err := NewSimple(mc, acc)
+		require.NoError(t, err)
+
+		mc.infoErr = errors.New("")
+		_, err = a.EstimateFees([]byte{1, 2, 3}, nil)
+		require.Error(t, err)
+
+		mc.infoErr = nil
+		mc.info = &result.MempoolInfo{
+			Count:    50,
+			Capacity: 100,
+			FeeHistogram: []result.MempoolFeeBucket{
+				{MinFeePerByte: 0},
+				{MinFeePerByte: 1000, Count: 50},
+				{MinFeePerByte: 2000},
+			},
+		}
+		fees, err := a.EstimateFees([]byte{1, 2, 3}, nil)
+		require.NoError(t, err)
+		require.Equal(t, int64(500), fees.NetworkFee)
+		require.Equal(t, int64(0), fees.PriorityFee)
+
+		mc.info.Count = 80
+		fees, err = a.EstimateFees([]byte{1, 2, 3}, nil)
+		require.NoError(t, err)
+		require.Equal(t, int64(500), fees.MinNetworkFee)
+		require.Equal(t, fees.MinNetworkFee+fees.PriorityFee, fees.NetworkFee)
+		// Script, signer and a standard witness with signature.
+		require.Less(t, int64(2000*(3+20+66+40)), fees.NetworkFee)
+
+		mc.info.FeeHistogram[1].Count = 0
+		mc.info.FeeHistogram[2].Count = 80
+		feesLast, err := a.EstimateFees([]byte{1, 2, 3}, nil)
+		require.NoError(t, err)
+		require.Equal(t, 2*fees.NetworkFee, feesLast.NetworkFee)
+	})
+}
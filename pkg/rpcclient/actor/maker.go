@@ -187,19 +187,9 @@ func (a *Actor) MakeUnsignedUncheckedRun(script []byte, sysFee int64, attrs []tr
 		return nil, fmt.Errorf("calculating validUntilBlock: %w", err)
 	}
 
-	tx.Scripts = make([]transaction.Witness, len(a.signers))
-	for i := range a.signers {
-		if !a.signers[i].Account.Contract.Deployed {
-			tx.Scripts[i].VerificationScript = a.signers[i].Account.Contract.Script
-			continue
-		}
-		if build := a.signers[i].Account.Contract.InvocationBuilder; build != nil {
-			invoc, err := build(tx)
-			if err != nil {
-				return nil, fmt.Errorf("building witness for contract signer: %w", err)
-			}
-			tx.Scripts[i].InvocationScript = invoc
-		}
+	err = setWitnessTemplates(tx, a.signers)
+	if err != nil {
+		return nil, err
 	}
 	// CalculateNetworkFee doesn't call Hash or Size, only serializes the
 	// transaction via Bytes, so it's safe wrt internal caching.
@@ -211,6 +201,27 @@ func (a *Actor) MakeUnsignedUncheckedRun(script []byte, sysFee int64, attrs []tr
 	return tx, nil
 }
 
+// setWitnessTemplates sets transaction witnesses required for network fee
+// calculation: verification scripts for standard accounts and invocation
+// scripts for contract-based ones (if they can be built).
+func setWitnessTemplates(tx *transaction.Transaction, signers []SignerAccount) error {
+	tx.Scripts = make([]transaction.Witness, len(signers))
+	for i := range signers {
+		if !signers[i].Account.Contract.Deployed {
+			tx.Scripts[i].VerificationScript = signers[i].Account.Contract.Script
+			continue
+		}
+		if build := signers[i].Account.Contract.InvocationBuilder; build != nil {
+			invoc, err := build(tx)
+			if err != nil {
+				return fmt.Errorf("building witness for contract signer: %w", err)
+			}
+			tx.Scripts[i].InvocationScript = invoc
+		}
+	}
+	return nil
+}
+
 // CalculateValidUntilBlock returns correct ValidUntilBlock value for a new
 // transaction relative to the current blockchain height. It uses "height +
 // number of validators + 1" formula suggesting shorter transaction lifetime
@@ -0,0 +1,67 @@
+package actor
+
+import (
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// SponsorSigner returns a SignerAccount for the given account that can be used
+// as a fee payer (sender) of transactions. It has None scope, so the account
+// only pays network and system fees and can't be used by any contract for
+// witness checks.
+func SponsorSigner(acc *wallet.Account) SignerAccount {
+	return SignerAccount{
+		Signer: transaction.Signer{
+			Account: acc.ScriptHash(),
+			Scopes:  transaction.None,
+		},
+		Account: acc,
+	}
+}
+
+// NewSponsored creates an Actor that makes transactions on behalf of the given
+// set of signers, but with all fees paid by the sponsor account. Sponsor is
+// added as the first signer (sender) of every transaction with None scope (see
+// SponsorSigner), signers follow it in the order given. Transactions created
+// by this Actor need to be witnessed by all of the signers, if some of them are
+// not available locally use MakeUnsigned* methods and collect the signatures
+// separately (or use notary-assisted transactions for that, see the notary
+// package). The actor will use default Options (which can be overridden using
+// NewTunedSponsored).
+func NewSponsored(ra RPCActor, sponsor *wallet.Account, signers []SignerAccount) (*Actor, error) {
+	return NewTunedSponsored(ra, sponsor, signers, NewDefaultOptions())
+}
+
+// NewTunedSponsored is the same as NewSponsored, but allows to specify
+// non-default Options (see NewTuned).
+func NewTunedSponsored(ra RPCActor, sponsor *wallet.Account, signers []SignerAccount, opts Options) (*Actor, error) {
+	sa, err := sponsoredSigners(sponsor, signers)
+	if err != nil {
+		return nil, err
+	}
+	return NewTuned(ra, sa, opts)
+}
+
+// sponsoredSigners prepends the list of signers with the sponsor.
+func sponsoredSigners(sponsor *wallet.Account, signers []SignerAccount) ([]SignerAccount, error) {
+	if sponsor == nil || sponsor.Contract == nil {
+		return nil, errors.New("bad sponsor account: no contract")
+	}
+	if len(signers) < 1 {
+		return nil, errors.New("at least one sponsored signer is required")
+	}
+	var (
+		sh  = sponsor.ScriptHash()
+		res = make([]SignerAccount, 0, len(signers)+1)
+	)
+	res = append(res, SponsorSigner(sponsor))
+	for i := range signers {
+		if signers[i].Signer.Account.Equals(sh) {
+			return nil, errors.New("sponsor can't be a sponsored signer at the same time")
+		}
+		res = append(res, signers[i])
+	}
+	return res, nil
+}
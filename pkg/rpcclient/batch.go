@@ -0,0 +1,104 @@
+package rpcclient
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+)
+
+// Batch is a set of calls that are sent to the server at once (in a single
+// JSON-RPC batch request). It's created with [Client.NewBatch], calls are
+// added to it with [BatchCall] and then it's executed with [Batch.Do].
+// WSClient doesn't support batch requests, so calls are performed one by
+// one for it. Batch is not thread-safe.
+type Batch struct {
+	c       *Client
+	reqs    []*neorpc.Request
+	results []func(*neorpc.Response, error)
+}
+
+// BatchResult is the result of a batched call, it's filled in by [Batch.Do].
+type BatchResult[T any] struct {
+	// Value is the call result, it's only valid if Err is nil.
+	Value T
+	// Err is the error returned by the server for this call (usually
+	// *neorpc.Error) or the error of the whole batch request.
+	Err error
+}
+
+// ErrNoBatchResponse is returned for batched calls the server didn't respond to.
+var ErrNoBatchResponse = errors.New("no response for batched call")
+
+// NewBatch creates a new empty Batch for the Client.
+func (c *Client) NewBatch() *Batch {
+	return &Batch{c: c}
+}
+
+// BatchCall adds a call of the given method with the given parameters to the
+// batch, its result is unmarshaled into the Value of the returned BatchResult
+// when the batch is executed. Calls are performed in the order they're added.
+func BatchCall[T any](b *Batch, method string, params ...any) *BatchResult[T] {
+	if params == nil {
+		params = []any{} // neo-project/neo-modules#742
+	}
+	res := new(BatchResult[T])
+	b.reqs = append(b.reqs, &neorpc.Request{
+		JSONRPC: neorpc.JSONRPCVersion,
+		Method:  method,
+		Params:  params,
+		ID:      b.c.getNextRequestID(),
+	})
+	b.results = append(b.results, func(raw *neorpc.Response, err error) {
+		res.Err = decodeResponse(raw, err, &res.Value)
+	})
+	return res
+}
+
+// Len returns the number of calls in the batch.
+func (b *Batch) Len() int {
+	return len(b.reqs)
+}
+
+// Do executes all calls of the batch and fills in their results. It returns
+// an error only if the batch request can't be performed at all (every call
+// gets this error then), errors of individual calls are returned in their
+// results. Batch is reset after execution, so it can be reused.
+func (b *Batch) Do() error {
+	reqs, results := b.reqs, b.results
+	b.reqs, b.results = nil, nil
+	if len(reqs) == 0 {
+		return nil
+	}
+	if b.c.cli == nil { // WSClient, calls are performed one by one.
+		for i := range reqs {
+			results[i](b.c.requestF(reqs[i]))
+		}
+		return nil
+	}
+
+	var resps []neorpc.Response
+	err := b.c.doHTTPRequest(reqs, &resps)
+	if err != nil {
+		for i := range results {
+			results[i](nil, err)
+		}
+		return err
+	}
+	var byID = make(map[uint64]*neorpc.Response, len(resps))
+	for i := range resps {
+		var id uint64
+		if json.Unmarshal(resps[i].ID, &id) == nil {
+			byID[id] = &resps[i]
+		}
+	}
+	for i := range reqs {
+		raw, ok := byID[reqs[i].ID]
+		if !ok {
+			results[i](nil, ErrNoBatchResponse)
+			continue
+		}
+		results[i](raw, nil)
+	}
+	return nil
+}
@@ -24,7 +24,8 @@ These layers are:
     but very convenient) and actor packages. These allow to perform test
     invocations with plain Go types, use historic states for these invocations,
     get the execution results from reader functions and create/send transactions
-    that change something on-chain.
+    that change something on-chain (including transactions combining calls made
+    via several contract wrappers, see actor.Batch).
 
   - Standard-specific wrappers that are implemented in nep11 and nep17 packages
     (with common methods in neptoken). They implement the respective NEP-11 and
@@ -52,6 +53,10 @@ These layers are:
 After creating a client instance with or without a ClientConfig
 you can interact with the NEO blockchain by its exposed methods.
 
+Several calls can be sent to the server in a single JSON-RPC batch request
+using Batch (see Client.NewBatch and BatchCall), every call gets its own typed
+result and error.
+
 Supported methods
 
 	calculatenetworkfee
@@ -2,11 +2,13 @@ package rpcclient_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 )
 
 func Example() {
@@ -43,3 +45,26 @@ func Example() {
 	fmt.Println(resp.Address)
 	fmt.Println(resp.Balances)
 }
+
+func ExampleBatchCall() {
+	endpoint := "https://rpc.t5.n3.nspcc.ru:20331"
+
+	c, err := rpcclient.New(context.TODO(), endpoint, rpcclient.Options{})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	b := c.NewBatch()
+	count := rpcclient.BatchCall[uint32](b, "getblockcount")
+	hash := rpcclient.BatchCall[util.Uint256](b, "getblockhash", 0)
+	if err := b.Do(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if count.Err != nil || hash.Err != nil {
+		fmt.Println(errors.Join(count.Err, hash.Err))
+		os.Exit(1)
+	}
+	fmt.Println(count.Value, hash.Value.StringLE())
+}
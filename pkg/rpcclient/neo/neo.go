@@ -81,6 +81,28 @@ type CommitteeChangedEvent struct {
 	New []keys.PublicKey
 }
 
+// CandidateVotesEvent represents a CandidateVotesChanged NEO event (emitted
+// only if GovernanceEvents extension is enabled).
+type CandidateVotesEvent struct {
+	Key   *keys.PublicKey
+	Delta *big.Int
+	Votes *big.Int
+}
+
+// CommitteeMemberEvent represents a CommitteeMemberChanged NEO event (emitted
+// only if GovernanceEvents extension is enabled).
+type CommitteeMemberEvent struct {
+	Key    *keys.PublicKey
+	Joined bool
+}
+
+// NextValidatorsChangedEvent represents a NextValidatorsChanged NEO event
+// (emitted only if GovernanceEvents extension is enabled).
+type NextValidatorsChangedEvent struct {
+	Old []keys.PublicKey
+	New []keys.PublicKey
+}
+
 // VoteEvent represents a Vote NEO event.
 type VoteEvent struct {
 	Account util.Uint160
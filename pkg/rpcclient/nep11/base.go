@@ -18,6 +18,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/neptoken"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -125,6 +126,15 @@ func (t *BaseReader) TokensExpanded(num int) ([][]byte, error) {
 	return unwrap.ArrayOfBytes(t.invoker.CallAndExpandIterator(t.hash, "tokens", num))
 }
 
+// TokensAll returns up to max tokens minted by the contract. It transparently
+// traverses session-based iterator if the server supports sessions and falls
+// back to TokensExpanded if the server expands iterators, but the result is
+// truncated. The method itself is optional per NEP-11 specification, so it
+// can fail.
+func (t *BaseReader) TokensAll(max int) ([][]byte, error) {
+	return itemsToBytes(traverseAll(t.invoker, t.hash, "tokens", max))
+}
+
 // TokensOf returns an iterator that allows to walk through all tokens owned by
 // the given account. It depends on the server to provide proper session-based
 // iterator, but can also work with expanded one.
@@ -145,6 +155,12 @@ func (t *BaseReader) TokensOfExpanded(account util.Uint160, num int) ([][]byte,
 	return unwrap.ArrayOfBytes(t.invoker.CallAndExpandIterator(t.hash, "tokensOf", num, account))
 }
 
+// TokensOfAll returns up to max tokens owned by the given account. It works
+// the same way TokensAll does, falling back to TokensOfExpanded if needed.
+func (t *BaseReader) TokensOfAll(account util.Uint160, max int) ([][]byte, error) {
+	return itemsToBytes(traverseAll(t.invoker, t.hash, "tokensOf", max, account))
+}
+
 // Transfer creates and sends a transaction that performs a `transfer` method
 // call using the given parameters and checks for this call result, failing the
 // transaction if it's not true. It works for divisible NFTs only when there is
@@ -188,12 +204,42 @@ func (t *BaseWriter) transferScript(params ...any) ([]byte, error) {
 	return smartcontract.CreateCallWithAssertScript(t.hash, "transfer", params...)
 }
 
-// Next returns the next set of elements from the iterator (up to num of them).
-// It can return less than num elements in case iterator doesn't have that many
-// or zero elements if the iterator has no more elements or the session is
-// expired.
-func (v *TokenIterator) Next(num int) ([][]byte, error) {
-	items, err := v.client.TraverseIterator(v.session, &v.iterator, num)
+// traverseAll calls the given method and retrieves up to max items from the
+// iterator it returns. Session-based iterator is traversed via
+// TraverseIterator (and the session is terminated then), iterator expanded by
+// the server is used as is unless it's truncated, in which case the iterator
+// is expanded by the script with CallAndExpandIterator.
+func traverseAll(inv Invoker, hash util.Uint160, method string, max int, params ...any) ([]stackitem.Item, error) {
+	if max <= 0 {
+		return nil, errors.New("invalid number of items")
+	}
+	sess, iter, err := unwrap.SessionIterator(inv.Call(hash, method, params...))
+	if err != nil {
+		return nil, err
+	}
+	if iter.ID == nil && iter.Truncated && len(iter.Values) < max {
+		return unwrap.Array(inv.CallAndExpandIterator(hash, method, max, params...))
+	}
+	var res []stackitem.Item
+	for len(res) < max {
+		items, err := inv.TraverseIterator(sess, &iter, min(max-len(res), invoker.DefaultIteratorResultItems))
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			break
+		}
+		res = append(res, items...)
+	}
+	if iter.ID != nil {
+		// Session expires anyway, so the error doesn't affect the result.
+		_ = inv.TerminateSession(sess)
+	}
+	return res, nil
+}
+
+// itemsToBytes converts stack items to byte slices.
+func itemsToBytes(items []stackitem.Item, err error) ([][]byte, error) {
 	if err != nil {
 		return nil, err
 	}
@@ -208,6 +254,14 @@ func (v *TokenIterator) Next(num int) ([][]byte, error) {
 	return res, nil
 }
 
+// Next returns the next set of elements from the iterator (up to num of them).
+// It can return less than num elements in case iterator doesn't have that many
+// or zero elements if the iterator has no more elements or the session is
+// expired.
+func (v *TokenIterator) Next(num int) ([][]byte, error) {
+	return itemsToBytes(v.client.TraverseIterator(v.session, &v.iterator, num))
+}
+
 // Terminate closes the iterator session used by TokenIterator (if it's
 // session-based).
 func (v *TokenIterator) Terminate() error {
@@ -139,6 +139,30 @@ func TestReaderTokensOfExpanded(t *testing.T) {
 	}
 }
 
+func TestReaderTokensAll(t *testing.T) {
+	iid := uuid.New()
+	ta := &iterAct{
+		iter:  result.Iterator{ID: &iid},
+		items: []stackitem.Item{stackitem.Make([]byte{1}), stackitem.Make([]byte{2})},
+	}
+	tr := NewBaseReader(ta, util.Uint160{1, 2, 3})
+
+	toks, err := tr.TokensAll(10)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{1}, {2}}, toks)
+	require.True(t, ta.terminated)
+
+	ta.iter = result.Iterator{Values: []stackitem.Item{stackitem.Make([]byte{3})}, Truncated: true}
+	ta.expanded = []stackitem.Item{stackitem.Make([]byte{3}), stackitem.Make([]byte{4})}
+	toks, err = tr.TokensOfAll(util.Uint160{3, 2, 1}, 10)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{3}, {4}}, toks)
+
+	ta.iter = result.Iterator{Values: []stackitem.Item{stackitem.Make([]stackitem.Item{})}}
+	_, err = tr.TokensOfAll(util.Uint160{3, 2, 1}, 10)
+	require.Error(t, err)
+}
+
 func TestReaderTokensOf(t *testing.T) {
 	ta := new(testAct)
 	tr := NewBaseReader(ta, util.Uint160{1, 2, 3})
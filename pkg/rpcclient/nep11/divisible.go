@@ -9,6 +9,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 )
 
 // DivisibleReader is a reader interface for divisible NEP-11 contract.
@@ -68,6 +69,14 @@ func (t *DivisibleReader) OwnerOfExpanded(token []byte, num int) ([]util.Uint160
 	return unwrap.ArrayOfUint160(t.invoker.CallAndExpandIterator(t.hash, "ownerOf", num, token))
 }
 
+// OwnerOfAll returns up to max owners of the given token. It transparently
+// traverses session-based iterator if the server supports sessions and falls
+// back to OwnerOfExpanded if the server expands iterators, but the result is
+// truncated.
+func (t *DivisibleReader) OwnerOfAll(token []byte, max int) ([]util.Uint160, error) {
+	return itemsToUint160s(traverseAll(t.invoker, t.hash, "ownerOf", max, token))
+}
+
 // BalanceOfD is a BalanceOf for divisible NFTs, it returns the amount of token
 // owned by a particular account.
 func (t *DivisibleReader) BalanceOfD(owner util.Uint160, token []byte) (*big.Int, error) {
@@ -118,7 +127,11 @@ func (t *DivisibleWriter) TransferDUnsigned(from util.Uint160, to util.Uint160,
 // or zero elements if the iterator has no more elements or the session is
 // expired.
 func (v *OwnerIterator) Next(num int) ([]util.Uint160, error) {
-	items, err := v.client.TraverseIterator(v.session, &v.iterator, num)
+	return itemsToUint160s(v.client.TraverseIterator(v.session, &v.iterator, num))
+}
+
+// itemsToUint160s converts stack items to Uint160 values.
+func itemsToUint160s(items []stackitem.Item, err error) ([]util.Uint160, error) {
 	if err != nil {
 		return nil, err
 	}
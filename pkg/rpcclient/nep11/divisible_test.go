@@ -236,3 +236,108 @@ func TestDivisibleTransferTransaction(t *testing.T) {
 		require.Error(t, err)
 	}
 }
+
+// iterAct emulates RPC server iterator handling for traverseAll tests.
+type iterAct struct {
+	testAct
+
+	iter       result.Iterator // Returned from Call.
+	items      []stackitem.Item
+	expanded   []stackitem.Item
+	expandNum  int
+	terminated bool
+}
+
+func (t *iterAct) Call(contract util.Uint160, operation string, params ...any) (*result.Invoke, error) {
+	var sess uuid.UUID
+	if t.iter.ID != nil {
+		sess = uuid.New()
+	}
+	return &result.Invoke{
+		State:   "HALT",
+		Session: sess,
+		Stack:   []stackitem.Item{stackitem.NewInterop(t.iter)},
+	}, nil
+}
+
+func (t *iterAct) CallAndExpandIterator(contract util.Uint160, method string, maxItems int, params ...any) (*result.Invoke, error) {
+	t.expandNum = maxItems
+	return &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{stackitem.NewArray(t.expanded[:min(maxItems, len(t.expanded))])},
+	}, nil
+}
+
+func (t *iterAct) TerminateSession(sessionID uuid.UUID) error {
+	t.terminated = true
+	return nil
+}
+
+func (t *iterAct) TraverseIterator(sessionID uuid.UUID, iterator *result.Iterator, num int) ([]stackitem.Item, error) {
+	if iterator.ID == nil {
+		num = min(num, len(iterator.Values))
+		items := iterator.Values[:num]
+		iterator.Values = iterator.Values[num:]
+		return items, nil
+	}
+	num = min(num, len(t.items))
+	items := t.items[:num]
+	t.items = t.items[num:]
+	return items, nil
+}
+
+func TestDivisibleOwnerOfAll(t *testing.T) {
+	owners := make([]util.Uint160, 250)
+	items := make([]stackitem.Item, len(owners))
+	for i := range owners {
+		owners[i] = util.Uint160{byte(i), byte(i >> 8)}
+		items[i] = stackitem.Make(owners[i].BytesBE())
+	}
+	ta := new(iterAct)
+	tr := NewDivisibleReader(ta, util.Uint160{1, 2, 3})
+
+	_, err := tr.OwnerOfAll([]byte{1}, 0)
+	require.Error(t, err)
+
+	t.Run("session", func(t *testing.T) {
+		iid := uuid.New()
+		*ta = iterAct{iter: result.Iterator{ID: &iid}, items: items}
+		res, err := tr.OwnerOfAll([]byte{1}, 1000)
+		require.NoError(t, err)
+		require.Equal(t, owners, res)
+		require.True(t, ta.terminated)
+
+		*ta = iterAct{iter: result.Iterator{ID: &iid}, items: items}
+		res, err = tr.OwnerOfAll([]byte{1}, 120)
+		require.NoError(t, err)
+		require.Equal(t, owners[:120], res)
+		require.Zero(t, ta.expandNum)
+	})
+	t.Run("expanded", func(t *testing.T) {
+		*ta = iterAct{iter: result.Iterator{Values: items}}
+		res, err := tr.OwnerOfAll([]byte{1}, 1000)
+		require.NoError(t, err)
+		require.Equal(t, owners, res)
+		require.False(t, ta.terminated)
+		require.Zero(t, ta.expandNum)
+	})
+	t.Run("truncated", func(t *testing.T) {
+		*ta = iterAct{iter: result.Iterator{Values: items[:100], Truncated: true}, expanded: items}
+		res, err := tr.OwnerOfAll([]byte{1}, 1000)
+		require.NoError(t, err)
+		require.Equal(t, owners, res)
+		require.Equal(t, 1000, ta.expandNum)
+
+		// Truncated server result is enough.
+		ta.expandNum = 0
+		res, err = tr.OwnerOfAll([]byte{1}, 50)
+		require.NoError(t, err)
+		require.Equal(t, owners[:50], res)
+		require.Zero(t, ta.expandNum)
+	})
+	t.Run("bad items", func(t *testing.T) {
+		*ta = iterAct{iter: result.Iterator{Values: []stackitem.Item{stackitem.Make("not uint160")}}}
+		_, err := tr.OwnerOfAll([]byte{1}, 10)
+		require.Error(t, err)
+	})
+}
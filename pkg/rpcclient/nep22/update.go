@@ -0,0 +1,85 @@
+/*
+Package nep22 provides RPC wrappers for NEP-22 contracts.
+
+NEP-22 defines the standard `update` method for contracts that can be
+updated, UpdateWriter allows to create transactions calling it.
+*/
+package nep22
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Actor is used by UpdateWriter to create and send transactions.
+type Actor interface {
+	MakeCall(contract util.Uint160, method string, params ...any) (*transaction.Transaction, error)
+	MakeUnsignedCall(contract util.Uint160, method string, attrs []transaction.Attribute, params ...any) (*transaction.Transaction, error)
+	SendCall(contract util.Uint160, method string, params ...any) (util.Uint256, uint32, error)
+}
+
+// UpdateWriter contains NEP-22 `update` method wrappers. Contracts usually
+// check for some witness in this method, so make sure the Actor used has
+// an appropriate signer.
+type UpdateWriter struct {
+	hash  util.Uint160
+	actor Actor
+}
+
+// NewUpdateWriter creates an instance of UpdateWriter for contract with the
+// given hash using the given Actor.
+func NewUpdateWriter(actor Actor, hash util.Uint160) *UpdateWriter {
+	return &UpdateWriter{hash, actor}
+}
+
+// Update creates and sends a transaction that updates the contract with the
+// given NEF and manifest, data is passed to contract's `_deploy` method. The
+// returned values are transaction hash, its ValidUntilBlock value and an error
+// if any.
+func (u *UpdateWriter) Update(exe *nef.File, manif *manifest.Manifest, data any) (util.Uint256, uint32, error) {
+	exeB, manifS, err := updateParams(exe, manif)
+	if err != nil {
+		return util.Uint256{}, 0, err
+	}
+	return u.actor.SendCall(u.hash, manifest.MethodUpdate, exeB, manifS, data)
+}
+
+// UpdateTransaction creates a transaction that updates the contract with the
+// given NEF and manifest, data is passed to contract's `_deploy` method. This
+// transaction is signed, but not sent to the network, instead it's returned to
+// the caller.
+func (u *UpdateWriter) UpdateTransaction(exe *nef.File, manif *manifest.Manifest, data any) (*transaction.Transaction, error) {
+	exeB, manifS, err := updateParams(exe, manif)
+	if err != nil {
+		return nil, err
+	}
+	return u.actor.MakeCall(u.hash, manifest.MethodUpdate, exeB, manifS, data)
+}
+
+// UpdateUnsigned creates a transaction that updates the contract with the
+// given NEF and manifest, data is passed to contract's `_deploy` method. This
+// transaction is not signed and just returned to the caller.
+func (u *UpdateWriter) UpdateUnsigned(exe *nef.File, manif *manifest.Manifest, data any) (*transaction.Transaction, error) {
+	exeB, manifS, err := updateParams(exe, manif)
+	if err != nil {
+		return nil, err
+	}
+	return u.actor.MakeUnsignedCall(u.hash, manifest.MethodUpdate, nil, exeB, manifS, data)
+}
+
+func updateParams(exe *nef.File, manif *manifest.Manifest) ([]byte, string, error) {
+	exeB, err := exe.Bytes()
+	if err != nil {
+		return nil, "", fmt.Errorf("bad NEF: %w", err)
+	}
+	manifB, err := json.Marshal(manif)
+	if err != nil {
+		return nil, "", fmt.Errorf("bad manifest: %w", err)
+	}
+	return exeB, string(manifB), nil
+}
@@ -0,0 +1,54 @@
+/*
+Package nep31 provides RPC wrappers for NEP-31 contracts.
+
+NEP-31 defines the standard `destroy` method for contracts that can be
+destroyed, DestroyWriter allows to create transactions calling it.
+*/
+package nep31
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Actor is used by DestroyWriter to create and send transactions.
+type Actor interface {
+	MakeCall(contract util.Uint160, method string, params ...any) (*transaction.Transaction, error)
+	MakeUnsignedCall(contract util.Uint160, method string, attrs []transaction.Attribute, params ...any) (*transaction.Transaction, error)
+	SendCall(contract util.Uint160, method string, params ...any) (util.Uint256, uint32, error)
+}
+
+// DestroyWriter contains NEP-31 `destroy` method wrappers. Contracts usually
+// check for some witness in this method, so make sure the Actor used has
+// an appropriate signer.
+type DestroyWriter struct {
+	hash  util.Uint160
+	actor Actor
+}
+
+// NewDestroyWriter creates an instance of DestroyWriter for contract with the
+// given hash using the given Actor.
+func NewDestroyWriter(actor Actor, hash util.Uint160) *DestroyWriter {
+	return &DestroyWriter{hash, actor}
+}
+
+// Destroy creates and sends a transaction that destroys the contract. The
+// returned values are transaction hash, its ValidUntilBlock value and an error
+// if any.
+func (d *DestroyWriter) Destroy() (util.Uint256, uint32, error) {
+	return d.actor.SendCall(d.hash, manifest.MethodDestroy)
+}
+
+// DestroyTransaction creates a transaction that destroys the contract. This
+// transaction is signed, but not sent to the network, instead it's returned to
+// the caller.
+func (d *DestroyWriter) DestroyTransaction() (*transaction.Transaction, error) {
+	return d.actor.MakeCall(d.hash, manifest.MethodDestroy)
+}
+
+// DestroyUnsigned creates a transaction that destroys the contract. This
+// transaction is not signed and just returned to the caller.
+func (d *DestroyWriter) DestroyUnsigned() (*transaction.Transaction, error) {
+	return d.actor.MakeUnsignedCall(d.hash, manifest.MethodDestroy, nil)
+}
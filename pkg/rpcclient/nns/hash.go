@@ -0,0 +1,85 @@
+package nns
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// CallInvoker is the minimal invoker interface required to resolve contract
+// hashes via NNS.
+type CallInvoker interface {
+	Call(contract util.Uint160, operation string, params ...any) (*result.Invoke, error)
+}
+
+// HashCache is a thread-safe cache of contract hashes resolved via NNS, it's
+// used by generated RPC bindings to resolve contract hash once and reuse it
+// afterwards. Zero value is ready to use.
+type HashCache struct {
+	lock   sync.RWMutex
+	hashes map[hashCacheKey]util.Uint160
+}
+
+type hashCacheKey struct {
+	nns  util.Uint160
+	name string
+}
+
+// ResolveHash resolves the given domain name into a contract hash using TXT
+// record of the NNS contract with the given hash. The record is expected to
+// contain either an address (like "NZo...") or a contract hash in LE hex form
+// (with or without "0x" prefix).
+func ResolveHash(inv CallInvoker, nnsHash util.Uint160, name string) (util.Uint160, error) {
+	s, err := unwrap.UTF8String(inv.Call(nnsHash, "resolve", name, int64(TXT)))
+	if err != nil {
+		return util.Uint160{}, fmt.Errorf("failed to resolve %s: %w", name, err)
+	}
+	h, err := address.StringToUint160(s)
+	if err == nil {
+		return h, nil
+	}
+	h, err = util.Uint160DecodeStringLE(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return util.Uint160{}, fmt.Errorf("bad %s TXT record %q: neither address nor hash", name, s)
+	}
+	return h, nil
+}
+
+// Resolve returns contract hash for the given domain name of the given NNS
+// contract. It's taken from the cache if resolved previously, otherwise
+// ResolveHash is used and its successful result is cached.
+func (c *HashCache) Resolve(inv CallInvoker, nnsHash util.Uint160, name string) (util.Uint160, error) {
+	var key = hashCacheKey{nns: nnsHash, name: name}
+
+	c.lock.RLock()
+	h, ok := c.hashes[key]
+	c.lock.RUnlock()
+	if ok {
+		return h, nil
+	}
+	h, err := ResolveHash(inv, nnsHash, name)
+	if err != nil {
+		return h, err
+	}
+	c.lock.Lock()
+	if c.hashes == nil {
+		c.hashes = make(map[hashCacheKey]util.Uint160)
+	}
+	c.hashes[key] = h
+	c.lock.Unlock()
+	return h, nil
+}
+
+// Reset drops all cached hashes, they will be resolved again on the next
+// Resolve call. It can be used when contracts are redeployed or NNS records are
+// changed.
+func (c *HashCache) Reset() {
+	c.lock.Lock()
+	c.hashes = nil
+	c.lock.Unlock()
+}
@@ -0,0 +1,87 @@
+package nns
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveHash(t *testing.T) {
+	var (
+		ta      = &testAct{}
+		nnsHash = util.Uint160{1, 2, 3}
+		h       = util.Uint160{3, 2, 1}
+	)
+
+	ta.err = errors.New("")
+	_, err := ResolveHash(ta, nnsHash, "contract.neo")
+	require.Error(t, err)
+
+	ta.err = nil
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.Make("some text"),
+		},
+	}
+	_, err = ResolveHash(ta, nnsHash, "contract.neo")
+	require.Error(t, err)
+
+	ta.res.Stack[0] = stackitem.Make(address.Uint160ToString(h))
+	actual, err := ResolveHash(ta, nnsHash, "contract.neo")
+	require.NoError(t, err)
+	require.Equal(t, h, actual)
+
+	ta.res.Stack[0] = stackitem.Make(h.StringLE())
+	actual, err = ResolveHash(ta, nnsHash, "contract.neo")
+	require.NoError(t, err)
+	require.Equal(t, h, actual)
+
+	ta.res.Stack[0] = stackitem.Make("0x" + h.StringLE())
+	actual, err = ResolveHash(ta, nnsHash, "contract.neo")
+	require.NoError(t, err)
+	require.Equal(t, h, actual)
+}
+
+func TestHashCache(t *testing.T) {
+	var (
+		c       HashCache
+		ta      = &testAct{}
+		nnsHash = util.Uint160{1, 2, 3}
+		h       = util.Uint160{3, 2, 1}
+	)
+
+	ta.err = errors.New("")
+	_, err := c.Resolve(ta, nnsHash, "contract.neo")
+	require.Error(t, err)
+
+	ta.err = nil
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.Make(h.StringLE()),
+		},
+	}
+	actual, err := c.Resolve(ta, nnsHash, "contract.neo")
+	require.NoError(t, err)
+	require.Equal(t, h, actual)
+
+	// Cached value is used.
+	ta.err = errors.New("")
+	actual, err = c.Resolve(ta, nnsHash, "contract.neo")
+	require.NoError(t, err)
+	require.Equal(t, h, actual)
+
+	// Other NNS contract.
+	_, err = c.Resolve(ta, util.Uint160{4, 5, 6}, "contract.neo")
+	require.Error(t, err)
+
+	c.Reset()
+	_, err = c.Resolve(ta, nnsHash, "contract.neo")
+	require.Error(t, err)
+}
@@ -137,6 +137,39 @@ func NewActor(c RPCActor, signers []actor.SignerAccount, simpleAcc *wallet.Accou
 	return newTunedActor(c, signers, simpleAcc, nil)
 }
 
+// NewSponsoredActor creates a new notary.Actor for transactions that are paid
+// for by the sponsor account, while the set of signers only authorizes the
+// actions performed. Sponsor is the first signer (sender) of main transactions
+// with the None scope (see [actor.SponsorSigner]), it's also used to sign
+// notary requests and it pays for the fallback transaction (it must have a
+// Notary deposit for that). Signers are not required to be available locally,
+// main transaction can be signed by the sponsor and sent to the network
+// in a notary request, other parties can then add their signatures with their
+// own requests, the notary service will complete the transaction when all of
+// them are collected (or fallback transaction is accepted when main
+// transaction's deadline is reached). Sponsor can't be present in the list of
+// signers.
+func NewSponsoredActor(c RPCActor, sponsor *wallet.Account, signers []actor.SignerAccount) (*Actor, error) {
+	if sponsor == nil {
+		return nil, errors.New("no sponsor account")
+	}
+	if sponsor.Contract == nil {
+		return nil, errors.New("bad sponsor account: no contract")
+	}
+	if len(signers) < 1 {
+		return nil, errors.New("at least one sponsored signer is required")
+	}
+	for _, sa := range signers {
+		if sa.Signer.Account.Equals(sponsor.ScriptHash()) {
+			return nil, fmt.Errorf("sponsor %s can't be a sponsored signer", sponsor.Address)
+		}
+	}
+	var mainSigners = make([]actor.SignerAccount, 0, len(signers)+1)
+	mainSigners = append(mainSigners, actor.SponsorSigner(sponsor))
+	mainSigners = append(mainSigners, signers...)
+	return newTunedActor(c, mainSigners, sponsor, nil)
+}
+
 // NewTunedActor is the same as NewActor, but allows to override the default
 // options (see ActorOptions for details). Use with care.
 func NewTunedActor(c RPCActor, signers []actor.SignerAccount, opts *ActorOptions) (*Actor, error) {
@@ -437,6 +437,63 @@ func TestSendRequest(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestNewSponsoredActor(t *testing.T) {
+	rc := &RPCClient{
+		version: &result.Version{
+			Protocol: result.Protocol{
+				Network:              netmode.UnitTestNet,
+				MillisecondsPerBlock: 1000,
+				ValidatorsCount:      7,
+			},
+		},
+	}
+
+	key0, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	key1, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	sponsor := wallet.NewAccountFromPrivateKey(key0)
+	facc1 := FakeSimpleAccount(key1.PublicKey())
+	signers := []actor.SignerAccount{{
+		Signer: transaction.Signer{
+			Account: facc1.Contract.ScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		},
+		Account: facc1,
+	}}
+
+	_, err = NewSponsoredActor(rc, nil, signers)
+	require.Error(t, err)
+
+	_, err = NewSponsoredActor(rc, &wallet.Account{Address: sponsor.Address}, signers)
+	require.ErrorContains(t, err, "no contract")
+
+	_, err = NewSponsoredActor(rc, sponsor, nil)
+	require.Error(t, err)
+
+	_, err = NewSponsoredActor(rc, sponsor, append(signers, actor.SignerAccount{
+		Signer: transaction.Signer{
+			Account: sponsor.ScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		},
+		Account: sponsor,
+	}))
+	require.Error(t, err)
+
+	act, err := NewSponsoredActor(rc, sponsor, signers)
+	require.NoError(t, err)
+	require.Equal(t, sponsor.ScriptHash(), act.Sender())
+	require.Equal(t, []actor.SignerAccount{actor.SponsorSigner(sponsor), signers[0], {
+		Signer: transaction.Signer{
+			Account: Hash,
+			Scopes:  transaction.None,
+		},
+		Account: FakeContractAccount(Hash),
+	}}, act.SignerAccounts())
+	require.Equal(t, sponsor.ScriptHash(), act.FbActor.SignerAccounts()[1].Signer.Account)
+}
+
 func TestNotarize(t *testing.T) {
 	rc := &RPCClient{
 		version: &result.Version{
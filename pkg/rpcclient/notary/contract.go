@@ -110,6 +110,56 @@ func (c *ContractReader) GetMaxNotValidBeforeDelta() (uint32, error) {
 	return uint32(ret), err
 }
 
+// Deposit creates and sends a transaction that transfers the given amount of
+// GAS from "from" account to the Notary contract making (or refilling) a
+// deposit for the account and lock time specified in data (data is mandatory,
+// notary contract rejects transfers without it). The return result from the
+// GAS "transfer" method is checked to be true, so transaction fails (with
+// FAULT state) if not successful. The returned values are transaction hash,
+// its ValidUntilBlock value and an error if any.
+func (c *Contract) Deposit(from util.Uint160, amount *big.Int, data *OnNEP17PaymentData) (util.Uint256, uint32, error) {
+	script, err := depositScript(from, amount, data)
+	if err != nil {
+		return util.Uint256{}, 0, err
+	}
+	return c.actor.SendRun(script)
+}
+
+// DepositTransaction creates a transaction that transfers the given amount of
+// GAS from "from" account to the Notary contract making (or refilling) a
+// deposit for the account and lock time specified in data. The return result
+// from the GAS "transfer" method is checked to be true, so transaction fails
+// (with FAULT state) if not successful. The transaction is signed, but not
+// sent to the network, instead it's returned to the caller.
+func (c *Contract) DepositTransaction(from util.Uint160, amount *big.Int, data *OnNEP17PaymentData) (*transaction.Transaction, error) {
+	script, err := depositScript(from, amount, data)
+	if err != nil {
+		return nil, err
+	}
+	return c.actor.MakeRun(script)
+}
+
+// DepositUnsigned creates a transaction that transfers the given amount of
+// GAS from "from" account to the Notary contract making (or refilling) a
+// deposit for the account and lock time specified in data. The return result
+// from the GAS "transfer" method is checked to be true, so transaction fails
+// (with FAULT state) if not successful. The transaction is not signed and
+// just returned to the caller.
+func (c *Contract) DepositUnsigned(from util.Uint160, amount *big.Int, data *OnNEP17PaymentData) (*transaction.Transaction, error) {
+	script, err := depositScript(from, amount, data)
+	if err != nil {
+		return nil, err
+	}
+	return c.actor.MakeUnsignedRun(script, nil)
+}
+
+func depositScript(from util.Uint160, amount *big.Int, data *OnNEP17PaymentData) ([]byte, error) {
+	if data == nil {
+		return nil, errors.New("deposit data is mandatory")
+	}
+	return smartcontract.CreateCallWithAssertScript(nativehashes.GasToken, "transfer", from.BytesBE(), Hash.BytesBE(), amount, data)
+}
+
 // LockDepositUntil creates and sends a transaction that extends the deposit lock
 // time for the given account. The return result from the "lockDepositUntil"
 // method is checked to be true, so transaction fails (with FAULT state) if not
@@ -108,6 +108,9 @@ func TestTxSenders(t *testing.T) {
 	ntr := New(ta)
 
 	for name, fun := range map[string]func() (util.Uint256, uint32, error){
+		"Deposit": func() (util.Uint256, uint32, error) {
+			return ntr.Deposit(util.Uint160{1, 2, 3}, big.NewInt(100500), &OnNEP17PaymentData{Till: 100500})
+		},
 		"LockDepositUntil": func() (util.Uint256, uint32, error) {
 			return ntr.LockDepositUntil(util.Uint160{1, 2, 3}, 100500)
 		},
@@ -139,6 +142,12 @@ func TestTxMakers(t *testing.T) {
 	ntr := New(ta)
 
 	for name, fun := range map[string]func() (*transaction.Transaction, error){
+		"DepositTransaction": func() (*transaction.Transaction, error) {
+			return ntr.DepositTransaction(util.Uint160{1, 2, 3}, big.NewInt(100500), &OnNEP17PaymentData{Till: 100500})
+		},
+		"DepositUnsigned": func() (*transaction.Transaction, error) {
+			return ntr.DepositUnsigned(util.Uint160{1, 2, 3}, big.NewInt(100500), &OnNEP17PaymentData{Till: 100500})
+		},
 		"LockDepositUntilTransaction": func() (*transaction.Transaction, error) {
 			return ntr.LockDepositUntilTransaction(util.Uint160{1, 2, 3}, 100500)
 		},
@@ -172,6 +181,17 @@ func TestTxMakers(t *testing.T) {
 	}
 }
 
+func TestDepositNoData(t *testing.T) {
+	ntr := New(new(testAct))
+
+	_, _, err := ntr.Deposit(util.Uint160{1, 2, 3}, big.NewInt(100500), nil)
+	require.Error(t, err)
+	_, err = ntr.DepositTransaction(util.Uint160{1, 2, 3}, big.NewInt(100500), nil)
+	require.Error(t, err)
+	_, err = ntr.DepositUnsigned(util.Uint160{1, 2, 3}, big.NewInt(100500), nil)
+	require.Error(t, err)
+}
+
 func TestOnNEP17PaymentData_Convertible(t *testing.T) {
 	t.Run("non-empty owner", func(t *testing.T) {
 		d := &OnNEP17PaymentData{
@@ -3,15 +3,12 @@ package notary_test
 import (
 	"context"
 	"math/big"
-	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
-	"github.com/nspcc-dev/neo-go/pkg/rpcclient/gas"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/notary"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/policy"
-	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 )
 
@@ -30,30 +27,19 @@ func ExampleActor() {
 	single, _ := actor.NewSimple(c, w.Accounts[0])
 
 	// Transfer some GAS to the Notary contract to be able to send notary requests
-	// from the first account.
-	gasSingle := gas.New(single)
-	txid, vub, _ := gasSingle.Transfer(single.Sender(), notary.Hash, big.NewInt(10_0000_0000), &notary.OnNEP17PaymentData{Till: 10000000})
-
-	var depositOK bool
-	// Wait for transaction to be persisted, either it gets in and we get
-	// an application log with some result or it expires.
-	for height, err := c.GetBlockCount(); err == nil && height <= vub; height, err = c.GetBlockCount() {
-		appLog, err := c.GetApplicationLog(txid, nil)
-		// We can't separate "application log missing" from other errors at the moment, see #2248.
-		if err != nil {
-			time.Sleep(5 * time.Second)
-			continue
-		}
-		if len(appLog.Executions) == 1 && appLog.Executions[0].VMState == vmstate.Halt {
-			depositOK = true
-		} else {
-			break
-		}
-	}
-	if !depositOK {
+	// from the first account and wait for the transaction to be accepted.
+	notarySingle := notary.New(single)
+	_, err := single.WaitSuccess(notarySingle.Deposit(single.Sender(), big.NewInt(10_0000_0000), &notary.OnNEP17PaymentData{Till: 10000000}))
+	if err != nil {
 		panic("deposit failed")
 	}
 
+	// Deposit state can be checked with the same Contract.
+	balance, _ := notarySingle.BalanceOf(single.Sender())
+	till, _ := notarySingle.ExpirationOf(single.Sender())
+	_ = balance
+	_ = till
+
 	var opts = new(notary.ActorOptions)
 	// Add high priority attribute, we gonna be making committee-signed transactions anyway.
 	opts.MainAttributes = []transaction.Attribute{{Type: transaction.HighPriority}}
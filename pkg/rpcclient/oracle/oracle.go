@@ -2,7 +2,9 @@
 Package oracle allows to work with the native OracleContract contract via RPC.
 
 Safe methods are encapsulated into ContractReader structure while Contract provides
-various methods to perform state-changing calls.
+various methods to perform state-changing calls. Responder allows oracle nodes
+(and custom oracle implementations) to sign and submit oracle responses via
+submitoracleresponse RPC.
 */
 package oracle
 
@@ -0,0 +1,93 @@
+package oracle
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+// ResponseSubmitter is used by Responder to send submitoracleresponse
+// requests, rpcclient.Client implements it.
+type ResponseSubmitter interface {
+	SubmitRawOracleResponse(ps []any) error
+}
+
+// Responder allows to sign oracle response transactions with the oracle node
+// key and to submit these signatures to oracle nodes via submitoracleresponse
+// RPC, which is what oracle service does. It can be used by custom oracle
+// implementations and tools that need to interact with the oracle subsystem.
+// Responses are submitted to the first node given and if that fails to the
+// next ones (backup nodes) until one of them accepts the response.
+type Responder struct {
+	key     *keys.PrivateKey
+	clients []ResponseSubmitter
+}
+
+// NewResponder creates a Responder using the given oracle node key and a list
+// of clients (at least one is required) to submit responses via.
+func NewResponder(key *keys.PrivateKey, clients ...ResponseSubmitter) (*Responder, error) {
+	if len(clients) == 0 {
+		return nil, errors.New("no clients")
+	}
+	return &Responder{key: key, clients: clients}, nil
+}
+
+// Submit submits the given oracle response transaction signature for the
+// request with the given ID. It tries every client in order until one
+// succeeds, all errors are returned if none does.
+func (r *Responder) Submit(reqID uint64, txSig []byte) error {
+	var (
+		params = NewResponseParams(r.key, reqID, txSig)
+		errs   []error
+	)
+	for i, c := range r.clients {
+		err := c.SubmitRawOracleResponse(params)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("node #%d: %w", i, err))
+	}
+	return fmt.Errorf("failed to submit oracle response: %w", errors.Join(errs...))
+}
+
+// SignAndSubmit signs the given oracle response transaction for the given
+// network and submits the signature (see Submit). Request ID is taken from
+// the OracleResponse attribute of the transaction.
+func (r *Responder) SignAndSubmit(net netmode.Magic, tx *transaction.Transaction) error {
+	attrs := tx.GetAttributes(transaction.OracleResponseT)
+	if len(attrs) != 1 {
+		return errors.New("not an oracle response transaction")
+	}
+	resp := attrs[0].Value.(*transaction.OracleResponse)
+	return r.Submit(resp.ID, r.key.SignHashable(uint32(net), tx))
+}
+
+// NewResponseParams returns submitoracleresponse RPC call parameters for the
+// given request ID and oracle response transaction signature signed with the
+// given oracle node key.
+func NewResponseParams(key *keys.PrivateKey, reqID uint64, txSig []byte) []any {
+	pubBytes := key.PublicKey().Bytes()
+	msgSig := key.Sign(NewResponseMessage(pubBytes, reqID, txSig))
+	return []any{
+		base64.StdEncoding.EncodeToString(pubBytes),
+		reqID,
+		base64.StdEncoding.EncodeToString(txSig),
+		base64.StdEncoding.EncodeToString(msgSig),
+	}
+}
+
+// NewResponseMessage returns data that is signed by the oracle node key in
+// submitoracleresponse RPC call, it consists of the node's public key bytes,
+// request ID and oracle response transaction signature.
+func NewResponseMessage(pubBytes []byte, reqID uint64, txSig []byte) []byte {
+	data := make([]byte, len(pubBytes)+8+len(txSig))
+	copy(data, pubBytes)
+	binary.LittleEndian.PutUint64(data[len(pubBytes):], reqID)
+	copy(data[len(pubBytes)+8:], txSig)
+	return data
+}
@@ -0,0 +1,79 @@
+package oracle
+
+import (
+	"crypto/elliptic"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/stretchr/testify/require"
+)
+
+type testSubmitter struct {
+	err    error
+	params []any
+}
+
+func (t *testSubmitter) SubmitRawOracleResponse(ps []any) error {
+	t.params = ps
+	return t.err
+}
+
+func checkResponseParams(t *testing.T, ps []any, reqID uint64, txSig []byte) {
+	require.Len(t, ps, 4)
+	pubBytes, err := base64.StdEncoding.DecodeString(ps[0].(string))
+	require.NoError(t, err)
+	pub, err := keys.NewPublicKeyFromBytes(pubBytes, elliptic.P256())
+	require.NoError(t, err)
+	require.Equal(t, reqID, ps[1])
+	require.Equal(t, base64.StdEncoding.EncodeToString(txSig), ps[2])
+	msgSig, err := base64.StdEncoding.DecodeString(ps[3].(string))
+	require.NoError(t, err)
+	require.True(t, pub.Verify(msgSig, hash.Sha256(NewResponseMessage(pubBytes, reqID, txSig)).BytesBE()))
+}
+
+func TestResponder(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	_, err = NewResponder(priv)
+	require.Error(t, err)
+
+	var (
+		primary = &testSubmitter{err: errors.New("primary")}
+		backup  = &testSubmitter{err: errors.New("backup")}
+		txSig   = []byte{1, 2, 3}
+	)
+	r, err := NewResponder(priv, primary, backup)
+	require.NoError(t, err)
+
+	err = r.Submit(42, txSig)
+	require.ErrorContains(t, err, "primary")
+	require.ErrorContains(t, err, "backup")
+
+	backup.err = nil
+	require.NoError(t, r.Submit(42, txSig))
+	checkResponseParams(t, backup.params, 42, txSig)
+
+	primary.err, backup.params = nil, nil
+	require.NoError(t, r.Submit(7, txSig))
+	checkResponseParams(t, primary.params, 7, txSig)
+	require.Nil(t, backup.params)
+
+	tx := transaction.New([]byte{1}, 0)
+	require.Error(t, r.SignAndSubmit(netmode.UnitTestNet, tx))
+
+	tx.Attributes = []transaction.Attribute{{
+		Type:  transaction.OracleResponseT,
+		Value: &transaction.OracleResponse{ID: 13},
+	}}
+	require.NoError(t, r.SignAndSubmit(netmode.UnitTestNet, tx))
+	sig, err := base64.StdEncoding.DecodeString(primary.params[2].(string))
+	require.NoError(t, err)
+	checkResponseParams(t, primary.params, 13, sig)
+	require.True(t, priv.PublicKey().VerifyHashable(sig, uint32(netmode.UnitTestNet), tx))
+}
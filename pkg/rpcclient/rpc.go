@@ -40,6 +40,23 @@ func (c *Client) CalculateNetworkFee(tx *transaction.Transaction) (int64, error)
 	return resp.Value, nil
 }
 
+// DevnetMine makes the node produce count blocks (one if count is zero) and
+// returns their hashes. It only works for nodes running consensus service in
+// devnet mode. It's a NeoGo extension.
+func (c *Client) DevnetMine(count int) ([]util.Uint256, error) {
+	var (
+		params []any
+		resp   []util.Uint256
+	)
+	if count != 0 {
+		params = []any{count}
+	}
+	if err := c.performRequest("devnet_mine", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetApplicationLog returns a contract log based on the specified txid.
 func (c *Client) GetApplicationLog(hash util.Uint256, trig *trigger.Type) (*result.ApplicationLog, error) {
 	var (
@@ -55,6 +72,30 @@ func (c *Client) GetApplicationLog(hash util.Uint256, trig *trigger.Type) (*resu
 	return resp, nil
 }
 
+// GetApplicationLogPage returns a contract log based on the specified txid
+// with at most limit notifications (starting from the offset given) in every
+// execution. Non-positive limit means no limit, the server default is used
+// then. Truncated flag of the result is set if there are more notifications
+// to fetch. This method is a NeoGo extension.
+func (c *Client) GetApplicationLogPage(hash util.Uint256, trig *trigger.Type, offset, limit int) (*result.ApplicationLog, error) {
+	var (
+		t      = trigger.All
+		params = []any{hash.StringLE()}
+		resp   = new(result.ApplicationLog)
+	)
+	if trig != nil {
+		t = *trig
+	}
+	params = append(params, t.String(), offset)
+	if limit > 0 {
+		params = append(params, limit)
+	}
+	if err := c.performRequest("getapplicationlog", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetBestBlockHash returns the hash of the tallest block in the blockchain.
 func (c *Client) GetBestBlockHash() (util.Uint256, error) {
 	var resp = util.Uint256{}
@@ -107,6 +148,61 @@ func (c *Client) getBlock(param any) (*block.Block, error) {
 	return b, nil
 }
 
+// GetBlocksRange returns up to count consecutive blocks starting from the given
+// index using a single request. Fewer blocks are returned if the chain is
+// shorter or if the server limits the response size, so the next range should
+// start from the index following the last returned block. count is limited to
+// 1000 by the server, zero means server's default (100). This method is
+// only supported by NeoGo servers. In-header stateroot option must be
+// initialized with Init before calling this method.
+func (c *Client) GetBlocksRange(start uint32, count int) ([]*block.Block, error) {
+	var (
+		params = []any{start}
+		resp   = new(result.BlocksRange)
+	)
+	if count != 0 {
+		params = append(params, count)
+	}
+	if err := c.performRequest("getblocksrange", params, resp); err != nil {
+		return nil, err
+	}
+	sr, err := c.stateRootInHeader()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		r   = io.NewBinReaderFromBuf(resp.Blocks)
+		res = make([]*block.Block, 0, resp.Count)
+	)
+	for range resp.Count {
+		size := r.ReadU32LE()
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		data := make([]byte, size)
+		r.ReadBytes(data)
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		var (
+			b  = block.New(sr)
+			br = io.NewBinReaderFromBuf(data)
+		)
+		b.DecodeBinary(br)
+		if br.Err == nil && br.Len() != 0 {
+			br.Err = errors.New("extra data")
+		}
+		if br.Err != nil {
+			return nil, fmt.Errorf("failed to decode block %d: %w", start+uint32(len(res)), br.Err)
+		}
+		res = append(res, b)
+	}
+	if r.Len() != 0 {
+		return nil, errors.New("unexpected data after the last block")
+	}
+	return res, nil
+}
+
 // GetBlockByIndexVerbose returns a block wrapper with additional metadata by
 // its height. In-header stateroot option must be initialized with Init before
 // calling this method.
@@ -213,6 +309,68 @@ func (c *Client) GetBlockSysFee(index uint32) (fixedn.Fixed8, error) {
 	return resp, nil
 }
 
+// GetBlockEconomicsByIndex returns GAS flows summary (minted and burned GAS,
+// fees and native contract rewards) of the block with the given index. It's a
+// NeoGo extension.
+func (c *Client) GetBlockEconomicsByIndex(index uint32) (*result.BlockEconomics, error) {
+	return c.getBlockEconomics(index)
+}
+
+// GetBlockEconomicsByHash returns GAS flows summary (minted and burned GAS,
+// fees and native contract rewards) of the block with the given hash. It's a
+// NeoGo extension.
+func (c *Client) GetBlockEconomicsByHash(hash util.Uint256) (*result.BlockEconomics, error) {
+	return c.getBlockEconomics(hash.StringLE())
+}
+
+func (c *Client) getBlockEconomics(param any) (*result.BlockEconomics, error) {
+	var resp = new(result.BlockEconomics)
+	if err := c.performRequest("getblockeconomics", []any{param}, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetBlockExecutionSummaryByIndex returns trimmed application logs (without
+// stacks and notification contents) of the block with the given index and
+// all of its transactions. It's a NeoGo extension.
+func (c *Client) GetBlockExecutionSummaryByIndex(index uint32) (*result.BlockExecutionSummary, error) {
+	return c.getBlockExecutionSummary(index)
+}
+
+// GetBlockExecutionSummaryByHash returns trimmed application logs (without
+// stacks and notification contents) of the block with the given hash and all
+// of its transactions. It's a NeoGo extension.
+func (c *Client) GetBlockExecutionSummaryByHash(hash util.Uint256) (*result.BlockExecutionSummary, error) {
+	return c.getBlockExecutionSummary(hash.StringLE())
+}
+
+func (c *Client) getBlockExecutionSummary(param any) (*result.BlockExecutionSummary, error) {
+	var resp = new(result.BlockExecutionSummary)
+	if err := c.performRequest("getblockexecutionsummary", []any{param}, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetBlockTimeStats returns the last block timestamp, recent block intervals
+// and block time statistics for the given windows (numbers of the most recent
+// blocks, the server uses a single window of 100 blocks if none are given).
+// It's a NeoGo extension.
+func (c *Client) GetBlockTimeStats(windows ...int) (*result.BlockTimeStats, error) {
+	var (
+		params = make([]any, 0, len(windows))
+		resp   = new(result.BlockTimeStats)
+	)
+	for _, w := range windows {
+		params = append(params, w)
+	}
+	if err := c.performRequest("getblocktimestats", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetConnectionCount returns the current number of the connections for the node.
 func (c *Client) GetConnectionCount() (int, error) {
 	var resp int
@@ -264,6 +422,35 @@ func (c *Client) getContractState(param any) (*state.Contract, error) {
 	return resp, nil
 }
 
+// GetContractStorageUsage returns storage usage statistics (number of items,
+// their size and the last modification block) of the contract with the given
+// hash. It's only supported by NeoGo servers with storage usage tracking
+// enabled.
+func (c *Client) GetContractStorageUsage(hash util.Uint160) (*result.ContractStorageUsage, error) {
+	var (
+		params = []any{hash.StringLE()}
+		resp   = new(result.ContractStorageUsage)
+	)
+	if err := c.performRequest("getcontractstorageusage", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetStorageItemsCount returns the number of storage items of the contract with
+// the given hash. It's only supported by NeoGo servers with storage usage
+// tracking enabled.
+func (c *Client) GetStorageItemsCount(hash util.Uint160) (uint64, error) {
+	var (
+		params = []any{hash.StringLE()}
+		resp   uint64
+	)
+	if err := c.performRequest("getstorageitemscount", params, &resp); err != nil {
+		return 0, err
+	}
+	return resp, nil
+}
+
 // GetNativeContracts queries information about native contracts.
 func (c *Client) GetNativeContracts() ([]state.Contract, error) {
 	var resp []state.Contract
@@ -389,6 +576,75 @@ func (c *Client) GetNEP17Transfers(address util.Uint160, start, stop *uint64, li
 	return resp, nil
 }
 
+// GetNEP11TransfersWithCursor is similar to GetNEP11Transfers, but uses
+// cursor-based paging that is stable against new transfers added between calls.
+// Pass an empty cursor to get the first page, the cursor for the next page is
+// returned in the Next field of the result (it's empty if there are no more
+// transfers within the time frame). It's only supported by NeoGo servers.
+func (c *Client) GetNEP11TransfersWithCursor(address util.Uint160, start, stop uint64, limit int, cursor string) (*result.NEP11Transfers, error) {
+	resp := new(result.NEP11Transfers)
+	if err := c.performRequest("getnep11transfers", packTransfersCursorParams(address, start, stop, limit, cursor), resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetNEP17TransfersWithCursor is similar to GetNEP17Transfers, but uses
+// cursor-based paging that is stable against new transfers added between calls.
+// Pass an empty cursor to get the first page, the cursor for the next page is
+// returned in the Next field of the result (it's empty if there are no more
+// transfers within the time frame). It's only supported by NeoGo servers.
+func (c *Client) GetNEP17TransfersWithCursor(address util.Uint160, start, stop uint64, limit int, cursor string) (*result.NEP17Transfers, error) {
+	resp := new(result.NEP17Transfers)
+	if err := c.performRequest("getnep17transfers", packTransfersCursorParams(address, start, stop, limit, cursor), resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func packTransfersCursorParams(address util.Uint160, start, stop uint64, limit int, cursor string) []any {
+	params := []any{address.StringLE(), start, stop, limit}
+	if cursor != "" {
+		params = append(params, 0, cursor)
+	}
+	return params
+}
+
+// GetNEP11TransfersFiltered is similar to GetNEP11TransfersWithCursor, but
+// only returns transfers of the given asset and/or with the given
+// counterparty (nil values match any asset or counterparty). It's only
+// supported by NeoGo servers.
+func (c *Client) GetNEP11TransfersFiltered(address util.Uint160, start, stop uint64, limit int, cursor string, asset, counterparty *util.Uint160) (*result.NEP11Transfers, error) {
+	resp := new(result.NEP11Transfers)
+	if err := c.performRequest("getnep11transfers", packTransfersFilterParams(address, start, stop, limit, cursor, asset, counterparty), resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetNEP17TransfersFiltered is similar to GetNEP17TransfersWithCursor, but
+// only returns transfers of the given asset and/or with the given
+// counterparty (nil values match any asset or counterparty). It's only
+// supported by NeoGo servers.
+func (c *Client) GetNEP17TransfersFiltered(address util.Uint160, start, stop uint64, limit int, cursor string, asset, counterparty *util.Uint160) (*result.NEP17Transfers, error) {
+	resp := new(result.NEP17Transfers)
+	if err := c.performRequest("getnep17transfers", packTransfersFilterParams(address, start, stop, limit, cursor, asset, counterparty), resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func packTransfersFilterParams(address util.Uint160, start, stop uint64, limit int, cursor string, asset, counterparty *util.Uint160) []any {
+	var a, cp string
+	if asset != nil {
+		a = asset.StringLE()
+	}
+	if counterparty != nil {
+		cp = counterparty.StringLE()
+	}
+	return []any{address.StringLE(), start, stop, limit, 0, cursor, a, cp}
+}
+
 // GetPeers returns a list of the nodes that the node is currently connected to/disconnected from.
 func (c *Client) GetPeers() (*result.GetPeers, error) {
 	var resp = &result.GetPeers{}
@@ -409,6 +665,18 @@ func (c *Client) GetRawMemPool() ([]util.Uint256, error) {
 	return *resp, nil
 }
 
+// GetMemPoolInfo returns memory pool statistics: the number, size and fee
+// histogram of pooled transactions along with per-sender transaction counts.
+// It's only supported by NeoGo servers.
+func (c *Client) GetMemPoolInfo() (*result.MempoolInfo, error) {
+	var resp = new(result.MempoolInfo)
+
+	if err := c.performRequest("getmempoolinfo", nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetRawTransaction returns a transaction by hash.
 func (c *Client) GetRawTransaction(hash util.Uint256) (*transaction.Transaction, error) {
 	var (
@@ -507,6 +775,26 @@ func (c *Client) FindStates(stateroot util.Uint256, historicalContractHash util.
 	return resp, nil
 }
 
+// FindStatesWithCursor is similar to FindStates, but it uses cursor-based
+// paging. Pass an empty cursor to get the first page, the cursor for the next
+// page is returned in the Next field of the result (it's empty if there are no
+// more items). Cursor is only valid for the same stateroot, contract and
+// prefix. It's only supported by NeoGo servers.
+func (c *Client) FindStatesWithCursor(stateroot util.Uint256, historicalContractHash util.Uint160, historicalPrefix []byte,
+	cursor string, maxCount *int) (result.FindStates, error) {
+	if historicalPrefix == nil {
+		historicalPrefix = []byte{}
+	}
+	var (
+		params = []any{stateroot.StringLE(), historicalContractHash.StringLE(), historicalPrefix, []byte{}, maxCount, cursor}
+		resp   result.FindStates
+	)
+	if err := c.performRequest("findstates", params, &resp); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
 // GetStateRootByHeight returns the state root for the specified height.
 func (c *Client) GetStateRootByHeight(height uint32) (*state.MPTRoot, error) {
 	return c.getStateRoot(height)
@@ -573,6 +861,43 @@ func (c *Client) getStorageHistoric(params []any) ([]byte, error) {
 	return resp, nil
 }
 
+// FindEventContainers returns transactions (and blocks) that emitted the given
+// contract's event from the oldest to the newest one starting from the block
+// with the given index. Pass an empty cursor to get the first page, the cursor
+// for the next page is returned in the Next field of the result (it's empty if
+// there are no more containers, the index is ignored if the cursor is not
+// empty). It's only supported by NeoGo servers with event container indexing
+// enabled.
+func (c *Client) FindEventContainers(contractHash util.Uint160, event string, index uint32, cursor string) (*result.FindEventContainers, error) {
+	var params = []any{contractHash.StringLE(), event, index}
+	if cursor != "" {
+		params = append(params, cursor)
+	}
+	resp := new(result.FindEventContainers)
+	if err := c.performRequest("findeventcontainers", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// FindNotifications returns notifications of the given contract's event having
+// the given value of the indexed parameter (see manifest.IndexedEventsField)
+// from the newest to the oldest one. Pass an empty cursor to get the first page,
+// the cursor for the next page is returned in the Next field of the result (it's
+// empty if there are no more notifications). It's only supported by NeoGo
+// servers with event indexing enabled.
+func (c *Client) FindNotifications(contractHash util.Uint160, event string, param string, value smartcontract.Parameter, cursor string) (*result.FindNotifications, error) {
+	var params = []any{contractHash.StringLE(), event, param, value}
+	if cursor != "" {
+		params = append(params, cursor)
+	}
+	resp := new(result.FindNotifications)
+	if err := c.performRequest("findnotifications", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // FindStorageByHash returns contract storage items by the given contract hash and prefix.
 // If `start` index is specified, items starting from `start` index are being returned
 // (including item located at the start index).
@@ -859,6 +1184,17 @@ func (c *Client) SubmitP2PNotaryRequest(req *payload.P2PNotaryRequest) (util.Uin
 	return resp.Hash, nil
 }
 
+// GetVerificationFailure returns witness check failure details from the error
+// returned by SendRawTransaction, SubmitBlock or SubmitP2PNotaryRequest. It
+// returns nil if the error has no such details (C# nodes never provide them).
+func GetVerificationFailure(err error) *neorpc.VerificationFailure {
+	var e *neorpc.Error
+	if errors.As(err, &e) {
+		return e.Verification
+	}
+	return nil
+}
+
 // ValidateAddress verifies that the address is a correct NEO address.
 // Consider using [address] package instead to do it locally.
 func (c *Client) ValidateAddress(address string) error {
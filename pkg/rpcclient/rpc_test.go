@@ -137,6 +137,32 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				}
 			},
 		},
+		{
+			name: "positive, paged",
+			invoke: func(c *Client) (any, error) {
+				return c.GetApplicationLogPage(util.Uint256{}, nil, 0, 1)
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"txid":"0x17145a039fca704fcdbeb46e6b210af98a1a9e5b9768e46ffc38f71c79ac2521","executions":[{"trigger":"Application","vmstate":"HALT","gasconsumed":"1","stack":[],"notifications":[]}],"truncated":true}}`,
+			result: func(c *Client) any {
+				txHash, err := util.Uint256DecodeStringLE("17145a039fca704fcdbeb46e6b210af98a1a9e5b9768e46ffc38f71c79ac2521")
+				if err != nil {
+					panic(err)
+				}
+				return &result.ApplicationLog{
+					Container: txHash,
+					Executions: []state.Execution{
+						{
+							Trigger:     trigger.Application,
+							VMState:     vmstate.Halt,
+							GasConsumed: 1,
+							Stack:       []stackitem.Item{},
+							Events:      []state.NotificationEvent{},
+						},
+					},
+					Truncated: true,
+				}
+			},
+		},
 	},
 	"getbestblockhash": {
 		{
@@ -287,6 +313,120 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getblockeconomics": {
+		{
+			name: "positive, by hash",
+			invoke: func(c *Client) (any, error) {
+				hash, err := util.Uint256DecodeStringLE("cbb73ed9e31dc41a8a222749de475e6ab2f9ec2fb9a6e0e52ead5ce6c1b3a67e")
+				if err != nil {
+					panic(err)
+				}
+				return c.GetBlockEconomicsByHash(hash)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"hash":"0xcbb73ed9e31dc41a8a222749de475e6ab2f9ec2fb9a6e0e52ead5ce6c1b3a67e","index":1,"sysfee":"997800","netfee":"1230610","minted":"51230610","burned":"2228410","burnedfees":"2228410","primaryreward":{"account":"0x1a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d","amount":"1230610"},"committeereward":{"account":"0x2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e","amount":"50000000"},"notaryrewards":[],"oraclerewards":[]}}`,
+			result: func(c *Client) any {
+				hash, err := util.Uint256DecodeStringLE("cbb73ed9e31dc41a8a222749de475e6ab2f9ec2fb9a6e0e52ead5ce6c1b3a67e")
+				if err != nil {
+					panic(err)
+				}
+				primary, err := util.Uint160DecodeStringLE("1a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d")
+				if err != nil {
+					panic(err)
+				}
+				committee, err := util.Uint160DecodeStringLE("2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e")
+				if err != nil {
+					panic(err)
+				}
+				return &result.BlockEconomics{
+					Hash:            hash,
+					Index:           1,
+					SystemFee:       997800,
+					NetworkFee:      1230610,
+					Minted:          51230610,
+					Burned:          2228410,
+					BurnedFees:      2228410,
+					PrimaryReward:   &result.GASPayout{Account: primary, Amount: 1230610},
+					CommitteeReward: &result.GASPayout{Account: committee, Amount: 50000000},
+					NotaryRewards:   []result.GASPayout{},
+					OracleRewards:   []result.GASPayout{},
+				}
+			},
+		},
+	},
+	"getblocktimestats": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.GetBlockTimeStats(3, 10)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"height":3,"timestamp":1700000045000,"intervals":[15000,14000,16000],"windows":[{"blocks":3,"count":3,"average":15000,"min":14000,"max":16000,"median":15000,"p90":16000,"p99":16000},{"blocks":10,"count":3,"average":15000,"min":14000,"max":16000,"median":15000,"p90":16000,"p99":16000}]}}`,
+			result: func(c *Client) any {
+				w := result.BlockTimeWindow{Blocks: 3, Count: 3, Average: 15000, Min: 14000, Max: 16000, Median: 15000, P90: 16000, P99: 16000}
+				w10 := w
+				w10.Blocks = 10
+				return &result.BlockTimeStats{
+					Height:    3,
+					Timestamp: 1700000045000,
+					Intervals: []uint64{15000, 14000, 16000},
+					Windows:   []result.BlockTimeWindow{w, w10},
+				}
+			},
+		},
+	},
+	"devnet_mine": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.DevnetMine(2)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":["0x773dd2dae4a9c9275290f89b56e67d7363ea4826dfd4fc13cc01cf73a44b0d0e","0xcbb73ed9e31dc41a8a222749de475e6ab2f9ec2fb9a6e0e52ead5ce6c1b3a67e"]}`,
+			result: func(c *Client) any {
+				h1, _ := util.Uint256DecodeStringLE("773dd2dae4a9c9275290f89b56e67d7363ea4826dfd4fc13cc01cf73a44b0d0e")
+				h2, _ := util.Uint256DecodeStringLE("cbb73ed9e31dc41a8a222749de475e6ab2f9ec2fb9a6e0e52ead5ce6c1b3a67e")
+				return []util.Uint256{h1, h2}
+			},
+		},
+	},
+	"getblockexecutionsummary": {
+		{
+			name: "positive, by index",
+			invoke: func(c *Client) (any, error) {
+				return c.GetBlockExecutionSummaryByIndex(1)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"hash":"0xcbb73ed9e31dc41a8a222749de475e6ab2f9ec2fb9a6e0e52ead5ce6c1b3a67e","index":1,"gasconsumed":"2046900","notifications":3,"executions":[{"trigger":"OnPersist","vmstate":"HALT","gasconsumed":"0","notifications":1},{"trigger":"PostPersist","vmstate":"HALT","gasconsumed":"0","notifications":1}],"transactions":[{"txid":"0xcb0a1d5ad4ba8a8e8e2e8e8bbf9e1f0a1b4c5d6e7f8091a2b3c4d5e6f7081920","trigger":"Application","vmstate":"FAULT","gasconsumed":"2046900","notifications":1,"exception":"ABORT"}]}}`,
+			result: func(c *Client) any {
+				exc := "ABORT"
+				blockHash, err := util.Uint256DecodeStringLE("cbb73ed9e31dc41a8a222749de475e6ab2f9ec2fb9a6e0e52ead5ce6c1b3a67e")
+				if err != nil {
+					panic(err)
+				}
+				txHash, err := util.Uint256DecodeStringLE("cb0a1d5ad4ba8a8e8e2e8e8bbf9e1f0a1b4c5d6e7f8091a2b3c4d5e6f7081920")
+				if err != nil {
+					panic(err)
+				}
+				return &result.BlockExecutionSummary{
+					Hash:          blockHash,
+					Index:         1,
+					GasConsumed:   2046900,
+					Notifications: 3,
+					Executions: []result.ExecutionSummary{
+						{Trigger: trigger.OnPersist, VMState: vmstate.Halt, Notifications: 1},
+						{Trigger: trigger.PostPersist, VMState: vmstate.Halt, Notifications: 1},
+					},
+					Transactions: []result.TransactionExecutionSummary{{
+						Hash: txHash,
+						ExecutionSummary: result.ExecutionSummary{
+							Trigger:        trigger.Application,
+							VMState:        vmstate.Fault,
+							GasConsumed:    2046900,
+							Notifications:  1,
+							FaultException: &exc,
+						},
+					}},
+				}
+			},
+		},
+	},
 	"getblocksysfee": {
 		{
 			name: "positive",
@@ -561,6 +701,44 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				}
 			},
 		},
+		{
+			name: "positive with cursor",
+			invoke: func(c *Client) (any, error) {
+				hash, err := address.StringToUint160("NcEkNmgWmf7HQVQvzhxpengpnt4DXjmZLe")
+				if err != nil {
+					panic(err)
+				}
+				return c.GetNEP17TransfersWithCursor(hash, 0, 1600094189000, 1, "AAABdI0CF2gBAAAA")
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"sent":[],"received":[],"address":"NcEkNmgWmf7HQVQvzhxpengpnt4DXjmZLe","next":"AAABdI0CF2gCAAAA"}}`,
+			result: func(c *Client) any {
+				return &result.NEP17Transfers{
+					Sent:     []result.NEP17Transfer{},
+					Received: []result.NEP17Transfer{},
+					Address:  "NcEkNmgWmf7HQVQvzhxpengpnt4DXjmZLe",
+					Next:     "AAABdI0CF2gCAAAA",
+				}
+			},
+		},
+		{
+			name: "positive with filter",
+			invoke: func(c *Client) (any, error) {
+				hash, err := address.StringToUint160("NcEkNmgWmf7HQVQvzhxpengpnt4DXjmZLe")
+				if err != nil {
+					panic(err)
+				}
+				asset := util.Uint160{1, 2, 3}
+				return c.GetNEP17TransfersFiltered(hash, 0, 1600094189000, 1, "", &asset, nil)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"sent":[],"received":[],"address":"NcEkNmgWmf7HQVQvzhxpengpnt4DXjmZLe"}}`,
+			result: func(c *Client) any {
+				return &result.NEP17Transfers{
+					Sent:     []result.NEP17Transfer{},
+					Received: []result.NEP17Transfer{},
+					Address:  "NcEkNmgWmf7HQVQvzhxpengpnt4DXjmZLe",
+				}
+			},
+		},
 	},
 	"getpeers": {
 		{
@@ -785,6 +963,22 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				}
 			},
 		},
+		{
+			name: "positive with cursor",
+			invoke: func(c *Client) (any, error) {
+				root, _ := util.Uint256DecodeStringLE("252e9d73d49c95c7618d40650da504e05183a1b2eed0685e42c360413c329170")
+				cHash, _ := util.Uint160DecodeStringLE("5c9e40a12055c6b9e3f72271c9779958c842135d")
+				return c.FindStatesWithCursor(root, cHash, []byte("aa"), "cursor", nil)
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"results":[{"key":"YWExMA==","value":"djI="}],"truncated":true,"next":"next"}}`,
+			result: func(c *Client) any {
+				return result.FindStates{
+					Results:   []result.KeyValue{{Key: []byte("aa10"), Value: []byte("v2")}},
+					Truncated: true,
+					Next:      "next",
+				}
+			},
+		},
 	},
 	"findstorage": {
 		{
@@ -0,0 +1,271 @@
+/*
+Package stateproof allows to get contract storage values from untrusted RPC
+servers with local verification of the data received.
+
+It uses getproof RPC method to get MPT proofs for contract storage items and
+verifies them locally against a trusted state root. State roots can be trusted
+either because they're obtained from some trusted source (and then proofs are
+checked against them directly) or because they're properly signed by the set
+of state validators known to the client (getstateroot RPC method is used to
+get them in this case and their witnesses are checked locally). This makes it
+possible for light clients to work with contract state without trusting RPC
+nodes they're connected to.
+*/
+package stateproof
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+const (
+	// managementContractID is the ID of ContractManagement native contract
+	// that stores contract states.
+	managementContractID = -1
+	// prefixContract is a ContractManagement storage prefix for contract
+	// states.
+	prefixContract = 8
+)
+
+// RPC is a set of methods required from RPC client to get and verify contract
+// state. It's implemented by rpcclient.Client.
+type RPC interface {
+	GetProof(stateroot util.Uint256, historicalContractHash util.Uint160, historicalKey []byte) (*result.ProofWithKey, error)
+	GetStateRootByHeight(height uint32) (*state.MPTRoot, error)
+}
+
+var (
+	// ErrInvalidProof is returned when proof received doesn't match the
+	// key requested or can't be verified against the state root.
+	ErrInvalidProof = errors.New("invalid proof")
+	// ErrInvalidStateRoot is returned when state root received can't be
+	// verified with the set of state validators known to the Verifier.
+	ErrInvalidStateRoot = errors.New("invalid state root")
+)
+
+// Verifier gets contract storage items and their proofs via RPC and checks
+// them locally.
+type Verifier struct {
+	rpc     RPC
+	network netmode.Magic
+	script  []byte
+	keys    keys.PublicKeys
+	m       int
+
+	idsLock sync.RWMutex
+	ids     map[util.Uint160]int32
+}
+
+// New creates a Verifier using the given RPC, network magic and the list of
+// state validators keys that are used to check state roots witnesses (the
+// same way as they're checked by nodes, default BFT multisignature threshold
+// is expected to be used). Validators can be nil if the Verifier is only going
+// to be used with roots obtained from trusted sources (see GetValue).
+func New(rpc RPC, network netmode.Magic, validators keys.PublicKeys) (*Verifier, error) {
+	var v = &Verifier{
+		rpc:     rpc,
+		network: network,
+		ids:     make(map[util.Uint160]int32),
+	}
+	if len(validators) == 0 {
+		return v, nil
+	}
+	// Keys are sorted by script creation function, so the order is the same.
+	v.keys = validators.Copy()
+	script, err := smartcontract.CreateDefaultMultiSigRedeemScript(v.keys)
+	if err != nil {
+		return nil, fmt.Errorf("bad validators list: %w", err)
+	}
+	v.script = script
+	v.m = smartcontract.GetDefaultHonestNodeCount(len(v.keys))
+	return v, nil
+}
+
+// VerifyStateRoot checks the given state root witness to be a correct
+// multisignature of state validators known to the Verifier.
+func (v *Verifier) VerifyStateRoot(r *state.MPTRoot) error {
+	if r == nil {
+		return fmt.Errorf("%w: no state root", ErrInvalidStateRoot)
+	}
+	if len(v.script) == 0 {
+		return fmt.Errorf("%w: no validators to check against", ErrInvalidStateRoot)
+	}
+	if len(r.Witness) != 1 {
+		return fmt.Errorf("%w: no witness", ErrInvalidStateRoot)
+	}
+	if !bytes.Equal(r.Witness[0].VerificationScript, v.script) {
+		return fmt.Errorf("%w: unexpected verification script", ErrInvalidStateRoot)
+	}
+	sigs, err := parseSignatures(r.Witness[0].InvocationScript)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidStateRoot, err)
+	}
+	if len(sigs) != v.m {
+		return fmt.Errorf("%w: %d signatures instead of %d", ErrInvalidStateRoot, len(sigs), v.m)
+	}
+	var k int
+	for _, sig := range sigs {
+		for k < len(v.keys) && !v.keys[k].VerifyHashable(sig, uint32(v.network), r) {
+			k++
+		}
+		if k == len(v.keys) {
+			return fmt.Errorf("%w: bad signature", ErrInvalidStateRoot)
+		}
+		k++
+	}
+	return nil
+}
+
+// GetStateRoot returns the state root for the given height after checking
+// its witness (see VerifyStateRoot).
+func (v *Verifier) GetStateRoot(height uint32) (*state.MPTRoot, error) {
+	r, err := v.rpc.GetStateRootByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, fmt.Errorf("%w: no state root for %d", ErrInvalidStateRoot, height)
+	}
+	if r.Index != height {
+		return nil, fmt.Errorf("%w: state root for %d received instead of %d", ErrInvalidStateRoot, r.Index, height)
+	}
+	err = v.VerifyStateRoot(r)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetValue returns the value of the given contract storage key for the given
+// trusted state root hash. The value is returned only if its proof is correct.
+// Contract ID that is a part of the proven key is also obtained via RPC and
+// checked with a proof of the contract state stored by ContractManagement.
+// Missing items can't be proven with MPT proofs, so any RPC error is returned
+// as is.
+func (v *Verifier) GetValue(root util.Uint256, contract util.Uint160, key []byte) ([]byte, error) {
+	if root.Equals(util.Uint256{}) {
+		return nil, fmt.Errorf("%w: empty state root", ErrInvalidStateRoot)
+	}
+	id, err := v.getContractID(root, contract)
+	if err != nil {
+		return nil, err
+	}
+	return v.getValue(root, id, contract, key)
+}
+
+// getValue gets the proof for the given key and checks it against the
+// given contract ID.
+func (v *Verifier) getValue(root util.Uint256, id int32, contract util.Uint160, key []byte) ([]byte, error) {
+	p, err := v.rpc.GetProof(root, contract, key)
+	if err != nil {
+		return nil, err
+	}
+	return VerifyProof(root, id, key, p)
+}
+
+// getContractID returns the ID of the given contract that is proven to be
+// correct for the given state root. IDs never change for deployed contracts,
+// so they're cached.
+func (v *Verifier) getContractID(root util.Uint256, contract util.Uint160) (int32, error) {
+	v.idsLock.RLock()
+	id, ok := v.ids[contract]
+	v.idsLock.RUnlock()
+	if ok {
+		return id, nil
+	}
+	val, err := v.getValue(root, managementContractID, nativehashes.ContractManagement,
+		append([]byte{prefixContract}, contract.BytesBE()...))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get contract state: %w", err)
+	}
+	var cs = new(state.Contract)
+	err = stackitem.DeserializeConvertible(val, cs)
+	if err != nil {
+		return 0, fmt.Errorf("%w: bad contract state: %w", ErrInvalidProof, err)
+	}
+	if !cs.Hash.Equals(contract) {
+		return 0, fmt.Errorf("%w: contract hash mismatch", ErrInvalidProof)
+	}
+	v.idsLock.Lock()
+	v.ids[contract] = cs.ID
+	v.idsLock.Unlock()
+	return cs.ID, nil
+}
+
+// GetValueAt is similar to GetValue, but it uses the state root for the given
+// height that is obtained via RPC and checked with VerifyStateRoot.
+func (v *Verifier) GetValueAt(height uint32, contract util.Uint160, key []byte) ([]byte, error) {
+	r, err := v.GetStateRoot(height)
+	if err != nil {
+		return nil, err
+	}
+	return v.GetValue(r.Root, contract, key)
+}
+
+// GetBigInt is a GetValue wrapper that returns storage item as an integer.
+func (v *Verifier) GetBigInt(root util.Uint256, contract util.Uint160, key []byte) (*big.Int, error) {
+	b, err := v.GetValue(root, contract, key)
+	if err != nil {
+		return nil, err
+	}
+	return bigint.FromBytes(b), nil
+}
+
+// GetUint160 is a GetValue wrapper that returns storage item as a Uint160 (it's
+// expected to be stored in a big-endian form, the same way contracts do it).
+func (v *Verifier) GetUint160(root util.Uint256, contract util.Uint160, key []byte) (util.Uint160, error) {
+	b, err := v.GetValue(root, contract, key)
+	if err != nil {
+		return util.Uint160{}, err
+	}
+	return util.Uint160DecodeBytesBE(b)
+}
+
+// VerifyProof checks that the proof given corresponds to the storage key of
+// the contract with the given ID and the state root hash specified and returns
+// the value proven.
+func VerifyProof(root util.Uint256, id int32, key []byte, p *result.ProofWithKey) ([]byte, error) {
+	if p == nil {
+		return nil, fmt.Errorf("%w: no proof", ErrInvalidProof)
+	}
+	var fullKey = make([]byte, 4+len(key))
+	binary.LittleEndian.PutUint32(fullKey, uint32(id))
+	copy(fullKey[4:], key)
+	if !bytes.Equal(p.Key, fullKey) {
+		return nil, fmt.Errorf("%w: key mismatch", ErrInvalidProof)
+	}
+	val, ok := mpt.VerifyProof(root, p.Key, p.Proof)
+	if !ok {
+		return nil, ErrInvalidProof
+	}
+	return val, nil
+}
+
+// parseSignatures returns signatures pushed by the standard invocation script.
+func parseSignatures(script []byte) ([][]byte, error) {
+	var sigs [][]byte
+	for len(script) > 0 {
+		if len(script) < 2+keys.SignatureLen || script[0] != byte(opcode.PUSHDATA1) || script[1] != keys.SignatureLen {
+			return nil, errors.New("non-standard invocation script")
+		}
+		sigs = append(sigs, script[2:2+keys.SignatureLen])
+		script = script[2+keys.SignatureLen:]
+	}
+	return sigs, nil
+}
@@ -0,0 +1,221 @@
+package stateproof
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testContract  = util.Uint160{1}
+	otherContract = util.Uint160{2}
+)
+
+type testRPC struct {
+	tr    *mpt.Trie
+	err   error
+	root  *state.MPTRoot
+	proof *result.ProofWithKey
+}
+
+func (r *testRPC) GetProof(stateroot util.Uint256, historicalContractHash util.Uint160, historicalKey []byte) (*result.ProofWithKey, error) {
+	if r.err != nil || r.proof != nil {
+		return r.proof, r.err
+	}
+	var id int32
+	switch historicalContractHash {
+	case nativehashes.ContractManagement:
+		id = -1
+	case testContract:
+		id = 1
+	case otherContract:
+		id = 2
+	}
+	key := storageKey(id, historicalKey...)
+	p, err := r.tr.GetProof(key)
+	if err != nil {
+		return nil, err
+	}
+	return &result.ProofWithKey{Key: key, Proof: p}, nil
+}
+
+func (r *testRPC) GetStateRootByHeight(height uint32) (*state.MPTRoot, error) {
+	return r.root, r.err
+}
+
+func storageKey(id int32, key ...byte) []byte {
+	var k = make([]byte, 4, 4+len(key))
+	binary.LittleEndian.PutUint32(k, uint32(id))
+	return append(k, key...)
+}
+
+func putContract(t *testing.T, tr *mpt.Trie, id int32, h util.Uint160) {
+	ne, err := nef.NewFile([]byte{byte(opcode.RET)})
+	require.NoError(t, err)
+	cs := &state.Contract{ContractBase: state.ContractBase{
+		ID:       id,
+		Hash:     h,
+		NEF:      *ne,
+		Manifest: *manifest.DefaultManifest("test"),
+	}}
+	val, err := stackitem.SerializeConvertible(cs)
+	require.NoError(t, err)
+	require.NoError(t, tr.Put(storageKey(-1, append([]byte{8}, h.BytesBE()...)...), val))
+}
+
+func getTestTrie(t *testing.T) *mpt.Trie {
+	tr := mpt.NewTrie(nil, mpt.ModeAll, storage.NewMemCachedStore(storage.NewMemoryStore()))
+	putContract(t, tr, 1, testContract)
+	putContract(t, tr, 2, otherContract)
+	require.NoError(t, tr.Put(storageKey(1, 0xaa), bigint.ToBytes(big.NewInt(42))))
+	require.NoError(t, tr.Put(storageKey(1, 0xbb), util.Uint160{1, 2, 3}.BytesBE()))
+	require.NoError(t, tr.Put(storageKey(2, 0xaa), []byte("other")))
+	return tr
+}
+
+func getProof(t *testing.T, tr *mpt.Trie, key []byte) *result.ProofWithKey {
+	p, err := tr.GetProof(key)
+	require.NoError(t, err)
+	return &result.ProofWithKey{Key: key, Proof: p}
+}
+
+func TestVerifyProof(t *testing.T) {
+	tr := getTestTrie(t)
+	root := tr.StateRoot()
+
+	p := getProof(t, tr, storageKey(1, 0xaa))
+	val, err := VerifyProof(root, 1, []byte{0xaa}, p)
+	require.NoError(t, err)
+	require.Equal(t, bigint.ToBytes(big.NewInt(42)), val)
+
+	_, err = VerifyProof(root, 1, []byte{0xbb}, p)
+	require.ErrorIs(t, err, ErrInvalidProof)
+
+	// Valid proof for the same key of another contract.
+	_, err = VerifyProof(root, 2, []byte{0xaa}, p)
+	require.ErrorIs(t, err, ErrInvalidProof)
+
+	_, err = VerifyProof(util.Uint256{1, 2, 3}, 1, []byte{0xaa}, p)
+	require.ErrorIs(t, err, ErrInvalidProof)
+
+	_, err = VerifyProof(root, 1, []byte{0xaa}, nil)
+	require.ErrorIs(t, err, ErrInvalidProof)
+}
+
+func TestVerifier(t *testing.T) {
+	var (
+		tr    = getTestTrie(t)
+		rpc   = &testRPC{tr: tr}
+		privs = make([]*keys.PrivateKey, 4)
+		pubs  = make(keys.PublicKeys, 4)
+	)
+	for i := range privs {
+		var err error
+		privs[i], err = keys.NewPrivateKey()
+		require.NoError(t, err)
+		pubs[i] = privs[i].PublicKey()
+	}
+	slices.SortFunc(privs, func(a, b *keys.PrivateKey) int { return a.PublicKey().Cmp(b.PublicKey()) })
+
+	v, err := New(rpc, netmode.UnitTestNet, nil)
+	require.NoError(t, err)
+
+	i, err := v.GetBigInt(tr.StateRoot(), testContract, []byte{0xaa})
+	require.NoError(t, err)
+	require.Equal(t, int64(42), i.Int64())
+
+	u, err := v.GetUint160(tr.StateRoot(), testContract, []byte{0xbb})
+	require.NoError(t, err)
+	require.Equal(t, util.Uint160{1, 2, 3}, u)
+
+	val, err := v.GetValue(tr.StateRoot(), otherContract, []byte{0xaa})
+	require.NoError(t, err)
+	require.Equal(t, []byte("other"), val)
+
+	// Valid proof for the same key of another contract.
+	rpc.proof = getProof(t, tr, storageKey(2, 0xaa))
+	_, err = v.GetValue(tr.StateRoot(), testContract, []byte{0xaa})
+	require.ErrorIs(t, err, ErrInvalidProof)
+	rpc.proof = nil
+
+	// Unknown contract.
+	_, err = v.GetValue(tr.StateRoot(), util.Uint160{3}, []byte{0xaa})
+	require.Error(t, err)
+
+	// Empty root.
+	_, err = v.GetValue(util.Uint256{}, testContract, []byte{0xaa})
+	require.ErrorIs(t, err, ErrInvalidStateRoot)
+
+	// No root.
+	_, err = v.GetValueAt(1, testContract, []byte{0xbb})
+	require.ErrorIs(t, err, ErrInvalidStateRoot)
+
+	// No validators.
+	rpc.root = &state.MPTRoot{Index: 1, Root: tr.StateRoot()}
+	_, err = v.GetValueAt(1, testContract, []byte{0xbb})
+	require.ErrorIs(t, err, ErrInvalidStateRoot)
+
+	v, err = New(rpc, netmode.UnitTestNet, pubs)
+	require.NoError(t, err)
+
+	script, err := smartcontract.CreateDefaultMultiSigRedeemScript(pubs.Copy())
+	require.NoError(t, err)
+	r := &state.MPTRoot{Index: 1, Root: tr.StateRoot()}
+	var inv []byte
+	for _, k := range privs[:3] {
+		inv = append(inv, byte(opcode.PUSHDATA1), keys.SignatureLen)
+		inv = append(inv, k.SignHashable(uint32(netmode.UnitTestNet), r)...)
+	}
+	r.Witness = []transaction.Witness{{InvocationScript: inv, VerificationScript: script}}
+	rpc.root = r
+
+	val, err = v.GetValueAt(1, testContract, []byte{0xbb})
+	require.NoError(t, err)
+	require.Equal(t, util.Uint160{1, 2, 3}.BytesBE(), val)
+
+	// Wrong height.
+	_, err = v.GetValueAt(2, testContract, []byte{0xbb})
+	require.ErrorIs(t, err, ErrInvalidStateRoot)
+
+	// Not enough signatures.
+	r.Witness[0].InvocationScript = inv[:2*(2+keys.SignatureLen)]
+	require.ErrorIs(t, v.VerifyStateRoot(r), ErrInvalidStateRoot)
+
+	// Wrong order.
+	r.Witness[0].InvocationScript = slices.Concat(inv[2+keys.SignatureLen:], inv[:2+keys.SignatureLen])
+	require.ErrorIs(t, v.VerifyStateRoot(r), ErrInvalidStateRoot)
+
+	// Different root.
+	r.Witness[0].InvocationScript = inv
+	r.Root = util.Uint256{1, 2, 3}
+	require.ErrorIs(t, v.VerifyStateRoot(r), ErrInvalidStateRoot)
+
+	// Bad verification script.
+	r.Root = tr.StateRoot()
+	r.Witness[0].VerificationScript = []byte{byte(opcode.RET)}
+	require.ErrorIs(t, v.VerifyStateRoot(r), ErrInvalidStateRoot)
+
+	// RPC error.
+	rpc.err = errors.New("")
+	_, err = v.GetValue(tr.StateRoot(), otherContract, []byte{0xbb})
+	require.Error(t, err)
+}
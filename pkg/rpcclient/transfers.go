@@ -0,0 +1,222 @@
+package rpcclient
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// DefaultTransfersPageSize is the default number of transfers requested by
+// TransferIterator in a single getnep17transfers/getnep11transfers request.
+const DefaultTransfersPageSize = 100
+
+// maxTransfersPageSize is the maximum number of transfers NeoGo servers return
+// in a single request.
+const maxTransfersPageSize = 1000
+
+// Transfer is a single NEP-17 or NEP-11 transfer returned by TransferIterator.
+type Transfer[T result.NEP17Transfer | result.NEP11Transfer] struct {
+	// Transfer is the transfer data as it's returned by the server.
+	Transfer T
+	// Sent is true for transfers sent from the address requested and false
+	// for transfers received by it.
+	Sent bool
+}
+
+// TransferIterator is a client-side iterator over token transfers of some
+// address. It requests transfers page by page (from the newest to the oldest
+// ones) moving the time window used for requests, so transfers added while
+// iterating don't affect the result. Transfers are deduplicated on page
+// boundaries and returned in order. Use NewNEP17TransferIterator or
+// NewNEP11TransferIterator to create it. It requires NeoGo RPC server since
+// limit and page parameters are used.
+type TransferIterator[T result.NEP17Transfer | result.NEP11Transfer] struct {
+	fetch func(start, stop uint64, limit, page int) ([]T, []T, error)
+	meta  func(*T) transferMeta
+
+	start uint64
+	stop  uint64
+	limit int
+	page  int
+
+	buf  []Transfer[T]
+	seen map[transferMeta]struct{}
+	done bool
+}
+
+// transferMeta is a set of transfer data used for ordering and deduplication.
+type transferMeta struct {
+	timestamp uint64
+	index     uint32
+	notify    uint32
+	tx        util.Uint256
+	asset     util.Uint160
+	id        string
+	sent      bool
+}
+
+// NewNEP17TransferIterator returns an iterator over NEP-17 transfers of the
+// given address that happened between start and stop timestamps (both
+// inclusive, in milliseconds, zero stop means current time). pageSize is the
+// number of transfers requested at once, DefaultTransfersPageSize is used if
+// it's not positive.
+func (c *Client) NewNEP17TransferIterator(address util.Uint160, start, stop uint64, pageSize int) *TransferIterator[result.NEP17Transfer] {
+	return newTransferIterator(func(start, stop uint64, limit, page int) ([]result.NEP17Transfer, []result.NEP17Transfer, error) {
+		res, err := c.GetNEP17Transfers(address, &start, &stop, &limit, &page)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Sent, res.Received, nil
+	}, func(t *result.NEP17Transfer) transferMeta {
+		return transferMeta{
+			timestamp: t.Timestamp,
+			index:     t.Index,
+			notify:    t.NotifyIndex,
+			tx:        t.TxHash,
+			asset:     t.Asset,
+		}
+	}, start, stop, pageSize)
+}
+
+// NewNEP11TransferIterator is the same as NewNEP17TransferIterator, but for
+// NEP-11 transfers.
+func (c *Client) NewNEP11TransferIterator(address util.Uint160, start, stop uint64, pageSize int) *TransferIterator[result.NEP11Transfer] {
+	return newTransferIterator(func(start, stop uint64, limit, page int) ([]result.NEP11Transfer, []result.NEP11Transfer, error) {
+		res, err := c.GetNEP11Transfers(address, &start, &stop, &limit, &page)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Sent, res.Received, nil
+	}, func(t *result.NEP11Transfer) transferMeta {
+		return transferMeta{
+			timestamp: t.Timestamp,
+			index:     t.Index,
+			notify:    t.NotifyIndex,
+			tx:        t.TxHash,
+			asset:     t.Asset,
+			id:        t.ID,
+		}
+	}, start, stop, pageSize)
+}
+
+func newTransferIterator[T result.NEP17Transfer | result.NEP11Transfer](fetch func(start, stop uint64, limit, page int) ([]T, []T, error),
+	meta func(*T) transferMeta, start, stop uint64, pageSize int) *TransferIterator[T] {
+	if pageSize <= 0 {
+		pageSize = DefaultTransfersPageSize
+	}
+	if pageSize > maxTransfersPageSize {
+		pageSize = maxTransfersPageSize
+	}
+	if stop == 0 {
+		stop = uint64(time.Now().UnixMilli())
+	}
+	return &TransferIterator[T]{
+		fetch: fetch,
+		meta:  meta,
+		start: start,
+		stop:  stop,
+		limit: pageSize,
+		seen:  make(map[transferMeta]struct{}),
+		done:  start > stop,
+	}
+}
+
+// Next returns the next transfer (the newest one first), it makes RPC requests
+// when needed. nil is returned (without error) when there are no more
+// transfers. In case of error iteration can be retried with the next call to
+// Next.
+func (it *TransferIterator[T]) Next() (*Transfer[T], error) {
+	for len(it.buf) == 0 {
+		if it.done {
+			return nil, nil
+		}
+		err := it.nextPage()
+		if err != nil {
+			return nil, err
+		}
+	}
+	var t = it.buf[0]
+	it.buf = it.buf[1:]
+	return &t, nil
+}
+
+// nextPage requests the next page of transfers and moves the time window.
+func (it *TransferIterator[T]) nextPage() error {
+	sent, received, err := it.fetch(it.start, it.stop, it.limit, it.page)
+	if err != nil {
+		return err
+	}
+	var (
+		total = len(sent) + len(received)
+		items = make([]Transfer[T], 0, total)
+	)
+	if total == 0 {
+		it.done = true
+		return nil
+	}
+	for i := range sent {
+		items = append(items, Transfer[T]{Transfer: sent[i], Sent: true})
+	}
+	for i := range received {
+		items = append(items, Transfer[T]{Transfer: received[i]})
+	}
+	slices.SortStableFunc(items, func(a, b Transfer[T]) int {
+		ma, mb := it.meta(&a.Transfer), it.meta(&b.Transfer)
+		if ma.timestamp != mb.timestamp {
+			return cmp.Compare(mb.timestamp, ma.timestamp)
+		}
+		return cmp.Compare(mb.index, ma.index)
+	})
+	var metas = make([]transferMeta, len(items))
+	for i := range items {
+		metas[i] = it.meta(&items[i].Transfer)
+		metas[i].sent = items[i].Sent
+		if _, ok := it.seen[metas[i]]; !ok {
+			it.buf = append(it.buf, items[i])
+		}
+	}
+	var last = metas[len(metas)-1].timestamp
+	switch {
+	case total < it.limit:
+		// The last page.
+		it.done = true
+	case last < it.stop:
+		// Move the window, transfers with the last timestamp will be
+		// returned again, so they're remembered.
+		it.stop = last
+		it.page = 0
+		clear(it.seen)
+		for i := range metas {
+			if metas[i].timestamp == last {
+				it.seen[metas[i]] = struct{}{}
+			}
+		}
+	default:
+		// The whole page has the same timestamp, the window can't be
+		// moved, so the next page is requested.
+		it.page++
+		for i := range metas {
+			it.seen[metas[i]] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// All returns all remaining transfers of the iterator. It's not recommended
+// to use it for addresses with long transfer history.
+func (it *TransferIterator[T]) All() ([]Transfer[T], error) {
+	var res []Transfer[T]
+	for {
+		t, err := it.Next()
+		if err != nil {
+			return res, err
+		}
+		if t == nil {
+			return res, nil
+		}
+		res = append(res, *t)
+	}
+}
@@ -0,0 +1,141 @@
+package rpcclient
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+// testTransferLog emulates NeoGo server transfer log handling, transfers are
+// stored from the newest to the oldest one.
+type testTransferLog struct {
+	transfers []Transfer[result.NEP17Transfer]
+	requests  int
+	err       error
+}
+
+func (l *testTransferLog) fetch(start, stop uint64, limit, page int) ([]result.NEP17Transfer, []result.NEP17Transfer, error) {
+	l.requests++
+	if l.err != nil {
+		return nil, nil, l.err
+	}
+	var (
+		sent, received []result.NEP17Transfer
+		frame, count   int
+	)
+	for _, t := range l.transfers {
+		if t.Transfer.Timestamp > stop {
+			continue
+		}
+		if t.Transfer.Timestamp < start {
+			break
+		}
+		frame++
+		if page*limit >= frame {
+			continue
+		}
+		if t.Sent {
+			sent = append(sent, t.Transfer)
+		} else {
+			received = append(received, t.Transfer)
+		}
+		count++
+		if count >= limit {
+			break
+		}
+	}
+	return sent, received, nil
+}
+
+func newTestTransfer(ts uint64, index uint32, sent bool) Transfer[result.NEP17Transfer] {
+	return Transfer[result.NEP17Transfer]{
+		Transfer: result.NEP17Transfer{
+			Timestamp: ts,
+			Index:     index,
+			Asset:     util.Uint160{1, 2, 3},
+			Amount:    strconv.Itoa(int(ts)),
+			TxHash:    util.Uint256{byte(ts), byte(index), byte(ts >> 8)},
+		},
+		Sent: sent,
+	}
+}
+
+func newTestTransferIterator(l *testTransferLog, start, stop uint64, pageSize int) *TransferIterator[result.NEP17Transfer] {
+	return newTransferIterator(l.fetch, func(t *result.NEP17Transfer) transferMeta {
+		return transferMeta{
+			timestamp: t.Timestamp,
+			index:     t.Index,
+			notify:    t.NotifyIndex,
+			tx:        t.TxHash,
+			asset:     t.Asset,
+		}
+	}, start, stop, pageSize)
+}
+
+func TestTransferIterator(t *testing.T) {
+	var l = new(testTransferLog)
+	for i := 50; i > 0; i-- {
+		if i%7 == 0 {
+			// A couple of transfers with the same timestamp.
+			l.transfers = append(l.transfers, newTestTransfer(uint64(i*10), uint32(i)+100, i%2 != 0))
+		}
+		l.transfers = append(l.transfers, newTestTransfer(uint64(i*10), uint32(i), i%2 == 0))
+	}
+
+	t.Run("all", func(t *testing.T) {
+		l.requests = 0
+		it := newTestTransferIterator(l, 0, 1000, 5)
+		res, err := it.All()
+		require.NoError(t, err)
+		require.Equal(t, l.transfers, res)
+		require.Greater(t, l.requests, len(l.transfers)/5)
+	})
+
+	t.Run("window", func(t *testing.T) {
+		it := newTestTransferIterator(l, 100, 200, 3)
+		res, err := it.All()
+		require.NoError(t, err)
+		require.Equal(t, 12, len(res)) // 11 blocks plus an additional transfer for 140.
+		require.Equal(t, uint64(200), res[0].Transfer.Timestamp)
+		require.Equal(t, uint64(100), res[len(res)-1].Transfer.Timestamp)
+	})
+
+	t.Run("same timestamp", func(t *testing.T) {
+		var sl = new(testTransferLog)
+		for i := range 10 {
+			sl.transfers = append(sl.transfers, newTestTransfer(100, uint32(10-i), true))
+		}
+		sl.transfers = append(sl.transfers, newTestTransfer(90, 0, false))
+		it := newTestTransferIterator(sl, 0, 100, 3)
+		res, err := it.All()
+		require.NoError(t, err)
+		require.Equal(t, sl.transfers, res)
+	})
+
+	t.Run("new transfers", func(t *testing.T) {
+		var nl = &testTransferLog{transfers: l.transfers}
+		it := newTestTransferIterator(nl, 0, 500, 4)
+		tr, err := it.Next()
+		require.NoError(t, err)
+		require.Equal(t, uint64(500), tr.Transfer.Timestamp)
+		nl.transfers = append([]Transfer[result.NEP17Transfer]{newTestTransfer(500, 200, true)}, nl.transfers...)
+		res, err := it.All()
+		require.NoError(t, err)
+		require.Equal(t, l.transfers[1:], res)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var el = &testTransferLog{transfers: l.transfers, err: errors.New("")}
+		it := newTestTransferIterator(el, 0, 1000, 0)
+		_, err := it.Next()
+		require.Error(t, err)
+		el.err = nil
+		res, err := it.All()
+		require.NoError(t, err)
+		require.Equal(t, l.transfers, res)
+	})
+}
@@ -0,0 +1,108 @@
+package rpcclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/gorilla/websocket"
+)
+
+// tlsConfig returns TLS configuration built from the options or nil if
+// no TLS-related options are set and the default one is to be used.
+func (o *Options) tlsConfig() (*tls.Config, error) {
+	if o.Cert == "" && o.Key == "" && o.CACert == "" && o.RootCAs == nil &&
+		len(o.Certificates) == 0 && o.TLSMinVersion == 0 {
+		return nil, nil
+	}
+	if (o.Cert == "") != (o.Key == "") {
+		return nil, errors.New("both client certificate and key must be specified")
+	}
+	cfg := &tls.Config{
+		RootCAs:      o.RootCAs,
+		Certificates: append([]tls.Certificate(nil), o.Certificates...),
+		MinVersion:   o.TLSMinVersion,
+	}
+	if o.Cert != "" {
+		cert, err := tls.LoadX509KeyPair(o.Cert, o.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		cfg.Certificates = append(cfg.Certificates, cert)
+	}
+	if o.CACert != "" {
+		data, err := os.ReadFile(o.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		if cfg.RootCAs != nil {
+			cfg.RootCAs = cfg.RootCAs.Clone()
+		} else {
+			cfg.RootCAs = x509.NewCertPool()
+		}
+		if !cfg.RootCAs.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no valid certificates found in %s", o.CACert)
+		}
+	}
+	return cfg, nil
+}
+
+// proxy returns proxy selection function for the configured proxy URL or
+// nil if no proxy is to be used.
+func (o *Options) proxy() (func(*http.Request) (*url.URL, error), error) {
+	if o.Proxy == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(o.Proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme: %q", u.Scheme)
+	}
+	return http.ProxyURL(u), nil
+}
+
+// newHTTPTransport creates HTTP transport for the given options.
+func newHTTPTransport(opts *Options) (*http.Transport, error) {
+	tlsCfg, err := opts.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	proxy, err := opts.proxy()
+	if err != nil {
+		return nil, err
+	}
+	return &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: opts.DialTimeout,
+		}).DialContext,
+		MaxConnsPerHost: opts.MaxConnsPerHost,
+		Proxy:           proxy,
+		TLSClientConfig: tlsCfg,
+	}, nil
+}
+
+// newWSDialer creates websocket dialer for the given options.
+func newWSDialer(opts *Options) (*websocket.Dialer, error) {
+	tlsCfg, err := opts.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	proxy, err := opts.proxy()
+	if err != nil {
+		return nil, err
+	}
+	return &websocket.Dialer{
+		HandshakeTimeout: opts.DialTimeout,
+		Proxy:            proxy,
+		TLSClientConfig:  tlsCfg,
+	}, nil
+}
@@ -0,0 +1,138 @@
+package rpcclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const blockCountResponse = `{"jsonrpc":"2.0","id":1,"result":42}`
+
+// writePEM stores PEM-encoded data of the given type to a new file.
+func writePEM(t *testing.T, name string, typ string, data []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: data}), 0o600))
+	return path
+}
+
+// newClientCert creates a self-signed client certificate and returns paths to
+// the certificate and key files.
+func newClientCert(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return writePEM(t, "client.crt", "CERTIFICATE", der), writePEM(t, "client.key", "EC PRIVATE KEY", keyDER)
+}
+
+func TestOptionsTransport(t *testing.T) {
+	tlsCfg, err := (&Options{}).tlsConfig()
+	require.NoError(t, err)
+	require.Nil(t, tlsCfg)
+	proxy, err := (&Options{}).proxy()
+	require.NoError(t, err)
+	require.Nil(t, proxy)
+
+	for name, opts := range map[string]Options{
+		"cert without key":  {Cert: "client.crt"},
+		"missing cert":      {Cert: "missing.crt", Key: "missing.key"},
+		"missing CA":        {CACert: "missing.crt"},
+		"bad CA":            {CACert: writePEM(t, "bad.crt", "CERTIFICATE", []byte{1, 2, 3})},
+		"bad proxy":         {Proxy: "http://[::1"},
+		"unsupported proxy": {Proxy: "ftp://proxy.example.com"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(context.Background(), "http://127.0.0.1:20332", opts)
+			require.Error(t, err)
+			_, err = NewWS(context.Background(), "ws://127.0.0.1:20332/ws", WSOptions{Options: opts})
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestClientTLS(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(blockCountResponse))
+	}))
+	srv.TLS = &tls.Config{
+		ClientAuth: tls.RequireAnyClientCert,
+		MaxVersion: tls.VersionTLS12,
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	caCert := writePEM(t, "ca.crt", "CERTIFICATE", srv.Certificate().Raw)
+	cert, key := newClientCert(t)
+
+	check := func(t *testing.T, opts Options, ok bool) {
+		c, err := New(context.Background(), srv.URL, opts)
+		require.NoError(t, err)
+		t.Cleanup(c.Close)
+		_, err = c.GetBlockCount()
+		if ok {
+			require.NoError(t, err)
+		} else {
+			require.Error(t, err)
+		}
+	}
+	t.Run("unknown CA", func(t *testing.T) {
+		check(t, Options{Cert: cert, Key: key}, false)
+	})
+	t.Run("no client certificate", func(t *testing.T) {
+		check(t, Options{CACert: caCert}, false)
+	})
+	t.Run("TLS version", func(t *testing.T) {
+		check(t, Options{Cert: cert, Key: key, CACert: caCert, TLSMinVersion: tls.VersionTLS13}, false)
+	})
+	t.Run("CA file", func(t *testing.T) {
+		check(t, Options{Cert: cert, Key: key, CACert: caCert}, true)
+	})
+	t.Run("CA pool and certificates", func(t *testing.T) {
+		pair, err := tls.LoadX509KeyPair(cert, key)
+		require.NoError(t, err)
+		pool := x509.NewCertPool()
+		pool.AddCert(srv.Certificate())
+		check(t, Options{RootCAs: pool, Certificates: []tls.Certificate{pair}}, true)
+	})
+}
+
+func TestClientProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		proxied = append(proxied, req.URL.String())
+		_, _ = w.Write([]byte(blockCountResponse))
+	}))
+	t.Cleanup(proxy.Close)
+
+	// The target host doesn't exist, so the request can only succeed via proxy.
+	c, err := New(context.Background(), "http://rpc.example.invalid:20332", Options{Proxy: proxy.URL})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	count, err := c.GetBlockCount()
+	require.NoError(t, err)
+	require.Equal(t, uint32(42), count)
+	require.Equal(t, []string{"http://rpc.example.invalid:20332/"}, proxied)
+}
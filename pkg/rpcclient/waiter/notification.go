@@ -0,0 +1,281 @@
+package waiter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/rpcevent"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+type (
+	// NotificationWaiter is an interface providing contract notification
+	// awaiting functionality, it's implemented by all Waiter implementations
+	// of this package.
+	NotificationWaiter interface {
+		// WaitNotification waits until a notification matching the given filter
+		// (nil filter matches any notification) and match function (nil
+		// function doesn't add any additional checks) is emitted by any
+		// execution (block or transaction) of blocks accepted after the call.
+		// Notifications are checked in their execution order. Awaiting is
+		// interrupted when the RPC client context or ctx is done, use
+		// [context.WithTimeout] for time-limited awaiting.
+		WaitNotification(ctx context.Context, flt *neorpc.NotificationFilter, match func(*state.ContainedNotificationEvent) bool) (*state.ContainedNotificationEvent, error)
+	}
+	// RPCNotificationPollingBased is an interface that enables notification
+	// awaiting functionality based on periodical BlockCount, Block and
+	// ApplicationLog polls.
+	RPCNotificationPollingBased interface {
+		RPCPollingBased
+
+		GetBlockByIndex(index uint32) (*block.Block, error)
+	}
+	// RPCNotificationEventBased is an interface that enables notification
+	// awaiting functionality based on web-socket execution notification
+	// events. It contains RPCNotificationPollingBased under the hood and
+	// falls back to polling when subscription-based awaiting fails.
+	RPCNotificationEventBased interface {
+		RPCNotificationPollingBased
+
+		ReceiveExecutionNotifications(flt *neorpc.NotificationFilter, rcvr chan<- *state.ContainedNotificationEvent) (string, error)
+		Unsubscribe(id string) error
+	}
+)
+
+// notificationComparator is an rpcevent.Comparator for NotificationFilter.
+type notificationComparator struct {
+	filter *neorpc.NotificationFilter
+}
+
+// notificationContainer is an rpcevent.Container for notification events.
+type notificationContainer struct {
+	ntf *state.ContainedNotificationEvent
+}
+
+// EventID implements rpcevent.Comparator interface.
+func (c notificationComparator) EventID() neorpc.EventID {
+	return neorpc.NotificationEventID
+}
+
+// Filter implements rpcevent.Comparator interface.
+func (c notificationComparator) Filter() neorpc.SubscriptionFilter {
+	if c.filter == nil {
+		return nil
+	}
+	return *c.filter
+}
+
+// EventID implements rpcevent.Container interface.
+func (c notificationContainer) EventID() neorpc.EventID {
+	return neorpc.NotificationEventID
+}
+
+// EventPayload implements rpcevent.Container interface.
+func (c notificationContainer) EventPayload() any {
+	return c.ntf
+}
+
+// WaitNotification implements NotificationWaiter interface.
+func (Null) WaitNotification(ctx context.Context, flt *neorpc.NotificationFilter, match func(*state.ContainedNotificationEvent) bool) (*state.ContainedNotificationEvent, error) {
+	return nil, ErrAwaitingNotSupported
+}
+
+// WaitNotification implements NotificationWaiter interface. It requires
+// RPC client to implement RPCNotificationPollingBased, ErrAwaitingNotSupported
+// is returned otherwise.
+func (w *PollingBased) WaitNotification(ctx context.Context, flt *neorpc.NotificationFilter, match func(*state.ContainedNotificationEvent) bool) (*state.ContainedNotificationEvent, error) {
+	p, ok := w.polling.(RPCNotificationPollingBased)
+	if !ok {
+		return nil, ErrAwaitingNotSupported
+	}
+	next, err := p.GetBlockCount()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve block count: %w", err)
+	}
+	return w.pollNotification(ctx, p, next, flt, match)
+}
+
+// pollNotification polls for notification starting from the block with the
+// given index.
+func (w *PollingBased) pollNotification(ctx context.Context, p RPCNotificationPollingBased, next uint32, flt *neorpc.NotificationFilter, match func(*state.ContainedNotificationEvent) bool) (*state.ContainedNotificationEvent, error) {
+	var failedAttempt int
+
+	timer := time.NewTicker(w.config.PollInterval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			ntf, err := findNotification(p, &next, flt, match)
+			if err != nil {
+				failedAttempt++
+				if failedAttempt > w.config.RetryCount {
+					return nil, err
+				}
+				continue
+			}
+			failedAttempt = 0
+			if ntf != nil {
+				return ntf, nil
+			}
+		case <-w.polling.Context().Done():
+			return nil, fmt.Errorf("%w: %w", ErrContextDone, w.polling.Context().Err())
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %w", ErrContextDone, ctx.Err())
+		}
+	}
+}
+
+// findNotification checks notifications of all blocks starting from the next
+// one up to the current height. next is updated to point to the first block
+// not yet checked.
+func findNotification(p RPCNotificationPollingBased, next *uint32, flt *neorpc.NotificationFilter, match func(*state.ContainedNotificationEvent) bool) (*state.ContainedNotificationEvent, error) {
+	count, err := p.GetBlockCount()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve block count: %w", err)
+	}
+	for ; *next < count; *next++ {
+		b, err := p.GetBlockByIndex(*next)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve block %d: %w", *next, err)
+		}
+		blockLog, err := p.GetApplicationLog(b.Hash(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve block %d application log: %w", *next, err)
+		}
+		if ntf := matchNotification(b.Hash(), blockLog.Executions, trigger.OnPersist, flt, match); ntf != nil {
+			return ntf, nil
+		}
+		trig := trigger.Application
+		for _, tx := range b.Transactions {
+			txLog, err := p.GetApplicationLog(tx.Hash(), &trig)
+			if err != nil {
+				return nil, fmt.Errorf("failed to retrieve transaction %s application log: %w", tx.Hash().StringLE(), err)
+			}
+			if ntf := matchNotification(tx.Hash(), txLog.Executions, trig, flt, match); ntf != nil {
+				return ntf, nil
+			}
+		}
+		if ntf := matchNotification(b.Hash(), blockLog.Executions, trigger.PostPersist, flt, match); ntf != nil {
+			return ntf, nil
+		}
+	}
+	return nil, nil
+}
+
+// matchNotification returns the first notification from executions with the
+// given trigger that passes the filter and match function.
+func matchNotification(container util.Uint256, execs []state.Execution, trig trigger.Type, flt *neorpc.NotificationFilter, match func(*state.ContainedNotificationEvent) bool) *state.ContainedNotificationEvent {
+	cmp := notificationComparator{filter: flt}
+	for _, e := range execs {
+		if e.Trigger != trig {
+			continue
+		}
+		for i := range e.Events {
+			ntf := &state.ContainedNotificationEvent{
+				Container:         container,
+				NotificationEvent: e.Events[i],
+			}
+			if rpcevent.Matches(cmp, notificationContainer{ntf: ntf}) && (match == nil || match(ntf)) {
+				return ntf
+			}
+		}
+	}
+	return nil
+}
+
+// WaitNotification implements NotificationWaiter interface. It requires
+// RPC client to implement RPCNotificationEventBased, otherwise it falls back
+// to polling (if supported by the client). Polling is also used if
+// subscription-based awaiting fails.
+func (w *EventBased) WaitNotification(ctx context.Context, flt *neorpc.NotificationFilter, match func(*state.ContainedNotificationEvent) bool) (res *state.ContainedNotificationEvent, waitErr error) {
+	polling, ok := w.polling.(*PollingBased)
+	if !ok {
+		return nil, ErrAwaitingNotSupported
+	}
+	ws, ok := w.ws.(RPCNotificationEventBased)
+	if !ok {
+		return polling.WaitNotification(ctx, flt, match)
+	}
+	// Remember the starting point to be able to continue with polling
+	// without missing anything.
+	start, err := ws.GetBlockCount()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve block count: %w", err)
+	}
+
+	var (
+		wsWaitErr error
+		rcvr      = make(chan *state.ContainedNotificationEvent, 16)
+	)
+	id, err := ws.ReceiveExecutionNotifications(flt, rcvr)
+	if err != nil {
+		wsWaitErr = fmt.Errorf("failed to subscribe for notifications: %w", err)
+	} else {
+	waitLoop:
+		for {
+			select {
+			case ntf, ok := <-rcvr:
+				if !ok {
+					// We're toast, retry with non-ws client.
+					rcvr = nil
+					wsWaitErr = ErrMissedEvent
+					break waitLoop
+				}
+				if match == nil || match(ntf) {
+					res = ntf
+					break waitLoop
+				}
+			case <-ws.Context().Done():
+				waitErr = fmt.Errorf("%w: %w", ErrContextDone, ws.Context().Err())
+				break waitLoop
+			case <-ctx.Done():
+				waitErr = fmt.Errorf("%w: %w", ErrContextDone, ctx.Err())
+				break waitLoop
+			}
+		}
+
+		if rcvr != nil {
+			unsubErr := make(chan error)
+			go func() {
+				unsubErr <- ws.Unsubscribe(id)
+			}()
+			// Drain receiver to avoid other notification receivers blocking.
+		drainLoop:
+			for {
+				select {
+				case _, ok := <-rcvr:
+					if !ok {
+						rcvr = nil
+					}
+				case err := <-unsubErr:
+					if err != nil {
+						err = fmt.Errorf("failed to unsubscribe from notifications (id: %s): %w", id, err)
+						if waitErr != nil {
+							err = fmt.Errorf("%w; unsubscription error: %w", waitErr, err)
+						}
+						waitErr = err
+					}
+					break drainLoop
+				}
+			}
+			if rcvr != nil {
+				close(rcvr)
+			}
+		}
+	}
+
+	// Rollback to a poll-based waiter if needed.
+	if wsWaitErr != nil && waitErr == nil {
+		res, waitErr = polling.pollNotification(ctx, ws, start, flt, match)
+		if waitErr != nil {
+			// Wrap the poll-based error, it's more important.
+			waitErr = fmt.Errorf("event-based error: %w; poll-based waiter error: %w", wsWaitErr, waitErr)
+		}
+	}
+	return
+}
@@ -0,0 +1,245 @@
+package waiter_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/waiter"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+type NotificationRPCClient struct {
+	RPCClient
+
+	lock   sync.RWMutex
+	blocks []*block.Block
+	logs   map[util.Uint256]*result.ApplicationLog
+}
+
+var _ = waiter.RPCNotificationPollingBased(&NotificationRPCClient{})
+
+func (c *NotificationRPCClient) GetBlockCount() (uint32, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return uint32(len(c.blocks)), nil
+}
+func (c *NotificationRPCClient) GetBlockByIndex(index uint32) (*block.Block, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if int(index) >= len(c.blocks) {
+		return nil, errors.New("not found")
+	}
+	return c.blocks[index], nil
+}
+func (c *NotificationRPCClient) GetApplicationLog(hash util.Uint256, trig *trigger.Type) (*result.ApplicationLog, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	l, ok := c.logs[hash]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return l, nil
+}
+
+// addBlock adds a block with a single transaction emitting the given
+// notifications.
+func (c *NotificationRPCClient) addBlock(events ...state.NotificationEvent) *transaction.Transaction {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.logs == nil {
+		c.logs = make(map[util.Uint256]*result.ApplicationLog)
+	}
+	tx := transaction.New([]byte{byte(len(c.blocks))}, 0)
+	b := &block.Block{
+		Header:       block.Header{Index: uint32(len(c.blocks))},
+		Transactions: []*transaction.Transaction{tx},
+	}
+	c.blocks = append(c.blocks, b)
+	c.logs[b.Hash()] = &result.ApplicationLog{
+		Container: b.Hash(),
+		Executions: []state.Execution{
+			{Trigger: trigger.OnPersist},
+			{Trigger: trigger.PostPersist},
+		},
+	}
+	c.logs[tx.Hash()] = &result.ApplicationLog{
+		Container:  tx.Hash(),
+		Executions: []state.Execution{{Trigger: trigger.Application, Events: events}},
+	}
+	return tx
+}
+
+type AwaitableNotificationRPCClient struct {
+	NotificationRPCClient
+
+	chLock   sync.RWMutex
+	subNtfCh chan<- *state.ContainedNotificationEvent
+}
+
+var (
+	_ = waiter.RPCEventBased(&AwaitableNotificationRPCClient{})
+	_ = waiter.RPCNotificationEventBased(&AwaitableNotificationRPCClient{})
+)
+
+func (c *AwaitableNotificationRPCClient) ReceiveExecutions(flt *neorpc.ExecutionFilter, rcvr chan<- *state.AppExecResult) (string, error) {
+	return "1", nil
+}
+func (c *AwaitableNotificationRPCClient) ReceiveHeadersOfAddedBlocks(flt *neorpc.BlockFilter, rcvr chan<- *block.Header) (string, error) {
+	return "2", nil
+}
+func (c *AwaitableNotificationRPCClient) ReceiveExecutionNotifications(flt *neorpc.NotificationFilter, rcvr chan<- *state.ContainedNotificationEvent) (string, error) {
+	c.chLock.Lock()
+	defer c.chLock.Unlock()
+	c.subNtfCh = rcvr
+	return "3", nil
+}
+func (c *AwaitableNotificationRPCClient) Unsubscribe(id string) error {
+	c.chLock.Lock()
+	defer c.chLock.Unlock()
+	c.subNtfCh = nil
+	return nil
+}
+
+// sendNotification sends the notification to the subscriber when it's ready.
+func (c *AwaitableNotificationRPCClient) sendNotification(t *testing.T, ntf *state.ContainedNotificationEvent) {
+	require.Eventually(t, func() bool {
+		c.chLock.RLock()
+		defer c.chLock.RUnlock()
+		return c.subNtfCh != nil
+	}, time.Second, time.Millisecond)
+	c.chLock.Lock()
+	defer c.chLock.Unlock()
+	if ntf == nil {
+		close(c.subNtfCh)
+		c.subNtfCh = nil
+		return
+	}
+	c.subNtfCh <- ntf
+}
+
+func newTestEvent(name string, v int64) state.NotificationEvent {
+	return state.NotificationEvent{
+		ScriptHash: util.Uint160{1, 2, 3},
+		Name:       name,
+		Item:       stackitem.NewArray([]stackitem.Item{stackitem.Make(v)}),
+	}
+}
+
+func TestPollingWaiter_WaitNotification(t *testing.T) {
+	var (
+		name = "Transfer"
+		flt  = &neorpc.NotificationFilter{Name: &name}
+		c    = &NotificationRPCClient{}
+		w    = waiter.New(c, &result.Version{Protocol: result.Protocol{MillisecondsPerBlock: 1}}) // reduce testing time.
+	)
+	_, ok := w.(*waiter.PollingBased)
+	require.True(t, ok)
+	nw, ok := w.(waiter.NotificationWaiter)
+	require.True(t, ok)
+
+	// Already accepted notifications are not returned.
+	c.addBlock(newTestEvent(name, 1))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := nw.WaitNotification(ctx, flt, nil)
+	require.ErrorIs(t, err, waiter.ErrContextDone)
+
+	// Filter and match function are applied.
+	var (
+		res   *state.ContainedNotificationEvent
+		errCh = make(chan error)
+	)
+	go func() {
+		var err error
+		res, err = nw.WaitNotification(context.Background(), flt, func(ntf *state.ContainedNotificationEvent) bool {
+			return ntf.Item.Value().([]stackitem.Item)[0].Equals(stackitem.Make(3))
+		})
+		errCh <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	c.addBlock(newTestEvent("Other", 3), newTestEvent(name, 2))
+	tx := c.addBlock(newTestEvent(name, 3))
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("failed to await notification")
+	}
+	require.Equal(t, tx.Hash(), res.Container)
+	require.Equal(t, newTestEvent(name, 3), res.NotificationEvent)
+
+	// Stub doesn't support awaiting.
+	_, err = waiter.NewNull().WaitNotification(context.Background(), flt, nil)
+	require.ErrorIs(t, err, waiter.ErrAwaitingNotSupported)
+}
+
+func TestWSWaiter_WaitNotification(t *testing.T) {
+	var (
+		name = "Transfer"
+		flt  = &neorpc.NotificationFilter{Name: &name}
+		c    = &AwaitableNotificationRPCClient{}
+		w    = waiter.New(c, &result.Version{Protocol: result.Protocol{MillisecondsPerBlock: 1}}) // reduce testing time.
+	)
+	_, ok := w.(*waiter.EventBased)
+	require.True(t, ok)
+	nw, ok := w.(waiter.NotificationWaiter)
+	require.True(t, ok)
+
+	type waitResult struct {
+		ntf *state.ContainedNotificationEvent
+		err error
+	}
+	wait := func(match func(*state.ContainedNotificationEvent) bool) chan waitResult {
+		ch := make(chan waitResult, 1)
+		go func() {
+			ntf, err := nw.WaitNotification(context.Background(), flt, match)
+			ch <- waitResult{ntf, err}
+		}()
+		return ch
+	}
+	get := func(t *testing.T, ch chan waitResult) waitResult {
+		select {
+		case res := <-ch:
+			return res
+		case <-time.After(time.Second):
+			t.Fatal("failed to await notification")
+		}
+		return waitResult{}
+	}
+
+	// Notification received via subscription.
+	expected := &state.ContainedNotificationEvent{Container: util.Uint256{1}, NotificationEvent: newTestEvent(name, 1)}
+	ch := wait(func(ntf *state.ContainedNotificationEvent) bool { return ntf.Container == expected.Container })
+	c.sendNotification(t, &state.ContainedNotificationEvent{Container: util.Uint256{2}, NotificationEvent: newTestEvent(name, 1)})
+	c.sendNotification(t, expected)
+	res := get(t, ch)
+	require.NoError(t, res.err)
+	require.Equal(t, expected, res.ntf)
+
+	// Missed event, fallback to polling.
+	ch = wait(nil)
+	c.sendNotification(t, nil)
+	tx := c.addBlock(newTestEvent(name, 5))
+	res = get(t, ch)
+	require.NoError(t, res.err)
+	require.Equal(t, tx.Hash(), res.ntf.Container)
+	require.Equal(t, newTestEvent(name, 5), res.ntf.NotificationEvent)
+}
+
+func TestRPCNotificationWaiterRPCClientCompat(t *testing.T) {
+	_ = waiter.RPCNotificationPollingBased(&rpcclient.Client{})
+	_ = waiter.RPCNotificationPollingBased(&rpcclient.WSClient{})
+	_ = waiter.RPCNotificationEventBased(&rpcclient.WSClient{})
+}
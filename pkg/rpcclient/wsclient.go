@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -57,22 +59,56 @@ import (
 // will also be closed on disconnection from server or on situation when it's
 // impossible to send a subsequent notification to the subscriber's channel and
 // CloseNotificationChannelIfFull option is on.
+//
+// If Reconnect option is set, the client doesn't close on disconnection, it
+// reconnects to the server instead (using exponential backoff) and restores
+// all active subscriptions (keeping their IDs), receiver channels are not
+// closed in this case. Any requests made while the connection is being
+// restored fail with ErrWSConnLost. Events emitted by the server while the
+// client was disconnected are lost, so a WSGap is delivered to the Gaps
+// channel (if set) after reconnection to allow receivers to fetch them. Receiver
+// channels are closed as usual if reconnection fails after MaxAttempts.
+//
+// If OrderEvents option is set, block-related notifications are delivered
+// strictly in the chain order with duplicates dropped, see WSOptions for
+// details.
 type WSClient struct {
 	Client
 
-	ws          *websocket.Conn
-	wsOpts      WSOptions
-	readerDone  chan struct{}
-	writerDone  chan struct{}
-	requests    chan *neorpc.Request
-	shutdown    chan struct{}
-	closeCalled atomic.Bool
+	endpoint string
+	// dialer is used for initial connection and reconnections.
+	dialer *websocket.Dialer
+	// connLock protects ws that is replaced on reconnection.
+	connLock sync.RWMutex
+	ws       *websocket.Conn
+	wsOpts   WSOptions
+	// connGen is incremented on every reconnection.
+	connGen atomic.Uint64
+	// lastBlock is the index of the latest block known to the client, it's
+	// only tracked if reconnection is enabled.
+	lastBlock      atomic.Uint32
+	lastBlockKnown atomic.Bool
+	// orderer is only used by wsReader, it's nil if OrderEvents is off.
+	orderer *eventOrderer
+	// orderSubID is the server-side ID of internal block subscription
+	// used by orderer.
+	orderSubLock sync.Mutex
+	orderSubID   string
+	readerDone   chan struct{}
+	writerDone   chan struct{}
+	requests     chan *neorpc.Request
+	shutdown     chan struct{}
+	closeCalled  atomic.Bool
 
 	closeErrLock sync.RWMutex
 	closeErr     error
 
 	subscriptionsLock sync.RWMutex
 	subscriptions     map[string]notificationReceiver
+	// serverIDs maps subscription IDs returned to the user to the
+	// server-side IDs of restored subscriptions. It must be accessed with
+	// subscriptionsLock taken.
+	serverIDs map[string]string
 	// receivers is a mapping from receiver channel to a set of corresponding subscription IDs.
 	// It must be accessed with subscriptionsLock taken. Its keys must be used to deliver
 	// notifications, if channel is not in the receivers list and corresponding subscription
@@ -96,6 +132,58 @@ type WSOptions struct {
 	// thus it's still the caller's duty to call Unsubscribe() for this
 	// subscription.
 	CloseNotificationChannelIfFull bool
+	// Reconnect enables automatic reconnection to the server with
+	// subscriptions restoration, nil (default) disables it.
+	Reconnect *WSReconnectOptions
+	// OrderEvents enables local ordering of block-related notifications
+	// (transactions, executions, execution notifications and headers).
+	// They're buffered until the block they belong to is received and then
+	// delivered in the chain order: OnPersist execution with its
+	// notifications, every transaction execution with its notifications
+	// followed by the transaction itself (in the block order), PostPersist
+	// execution with its notifications, the header and finally the block.
+	// Duplicate events are dropped. The client subscribes for blocks
+	// internally to do this (which increases traffic), so it must be
+	// initialized (see Init) before any subscription. Notifications of
+	// OnPersist and PostPersist scripts can only be told apart reliably if
+	// executions are received as well, notary request events are not
+	// affected.
+	OrderEvents bool
+}
+
+// WSReconnectOptions defines WSClient reconnection behaviour. Delay between
+// reconnection attempts starts with MinDelay and doubles after every failed
+// attempt up to MaxDelay.
+type WSReconnectOptions struct {
+	// MinDelay is the delay before the first reconnection attempt, 500ms
+	// is used by default.
+	MinDelay time.Duration
+	// MaxDelay is the maximum delay between attempts, 30s is used by
+	// default.
+	MaxDelay time.Duration
+	// MaxAttempts is the maximum number of consecutive failed reconnection
+	// attempts after which the client is closed, 0 (default) means no limit.
+	MaxAttempts int
+	// Gaps receives a WSGap after every successful reconnection. This
+	// channel is never closed by the client and must be read from (if set)
+	// to avoid blocking subscriptions restoration.
+	Gaps chan<- *WSGap
+}
+
+// WSGap is delivered by WSClient after reconnection, all active subscriptions
+// are restored by this moment. Events from blocks in (LastBlock, Height] range
+// could've been missed, so they should be fetched via regular RPC calls. Some
+// events from the Height block can also be delivered via subscriptions, so
+// receivers should be ready for duplicates.
+type WSGap struct {
+	// LastBlock is the index of the latest block known to the client before
+	// disconnection. It's the index of the latest received block (or header)
+	// event or the server's chain height at the moment of the first
+	// subscription (or previous reconnection) if it's higher.
+	LastBlock uint32
+	// Height is the server's chain height right after subscriptions
+	// restoration.
+	Height uint32
 }
 
 // notificationReceiver is an interface aimed to provide WS subscriber functionality
@@ -435,6 +523,12 @@ const (
 
 	// Write deadline.
 	wsWriteLimit = wsPingPeriod / 2
+
+	// Default minimum delay between reconnection attempts.
+	defaultWSReconnectMinDelay = 500 * time.Millisecond
+
+	// Default maximum delay between reconnection attempts.
+	defaultWSReconnectMaxDelay = 30 * time.Second
 )
 
 // ErrNilNotificationReceiver is returned when notification receiver channel is nil.
@@ -453,26 +547,33 @@ var errConnClosedByUser = errors.New("connection closed by user")
 // You should call Init method to initialize the network magic the client is
 // operating on.
 func NewWS(ctx context.Context, endpoint string, opts WSOptions) (*WSClient, error) {
-	dialer := websocket.Dialer{HandshakeTimeout: opts.DialTimeout}
-	ws, resp, err := dialer.DialContext(ctx, endpoint, nil)
-	if resp != nil && resp.Body != nil { // Can be non-nil even with error returned.
-		defer resp.Body.Close() // Not exactly required by websocket, but let's do this for bodyclose checker.
+	if opts.DialTimeout <= 0 {
+		opts.DialTimeout = defaultDialTimeout
 	}
+	dialer, err := newWSDialer(&opts.Options)
 	if err != nil {
-		if resp != nil && resp.Body != nil {
-			var srvErr neorpc.HeaderAndError
-
-			dec := json.NewDecoder(resp.Body)
-			decErr := dec.Decode(&srvErr)
-			if decErr == nil && srvErr.Error != nil {
-				err = srvErr.Error
-			}
-		}
 		return nil, err
 	}
+	ws, err := dialWS(ctx, dialer, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if r := opts.Reconnect; r != nil {
+		rCp := *r
+		if rCp.MinDelay <= 0 {
+			rCp.MinDelay = defaultWSReconnectMinDelay
+		}
+		if rCp.MaxDelay <= 0 {
+			rCp.MaxDelay = defaultWSReconnectMaxDelay
+		}
+		rCp.MaxDelay = max(rCp.MaxDelay, rCp.MinDelay)
+		opts.Reconnect = &rCp
+	}
 	wsc := &WSClient{
 		Client: Client{},
 
+		endpoint:      endpoint,
+		dialer:        dialer,
 		ws:            ws,
 		wsOpts:        opts,
 		shutdown:      make(chan struct{}),
@@ -481,6 +582,7 @@ func NewWS(ctx context.Context, endpoint string, opts WSOptions) (*WSClient, err
 		respChannels:  make(map[uint64]chan *neorpc.Response),
 		requests:      make(chan *neorpc.Request),
 		subscriptions: make(map[string]notificationReceiver),
+		serverIDs:     make(map[string]string),
 		receivers:     make(map[any][]string),
 	}
 
@@ -489,6 +591,9 @@ func NewWS(ctx context.Context, endpoint string, opts WSOptions) (*WSClient, err
 		return nil, err
 	}
 	wsc.Client.cli = nil
+	if opts.OrderEvents {
+		wsc.orderer = newEventOrderer()
+	}
 
 	go wsc.wsReader()
 	go wsc.wsWriter()
@@ -496,6 +601,34 @@ func NewWS(ctx context.Context, endpoint string, opts WSOptions) (*WSClient, err
 	return wsc, nil
 }
 
+// dialWS establishes a websocket connection to the given endpoint.
+func dialWS(ctx context.Context, dialer *websocket.Dialer, endpoint string) (*websocket.Conn, error) {
+	ws, resp, err := dialer.DialContext(ctx, endpoint, nil)
+	if resp != nil && resp.Body != nil { // Can be non-nil even with error returned.
+		defer resp.Body.Close() // Not exactly required by websocket, but let's do this for bodyclose checker.
+	}
+	if err != nil {
+		if resp != nil && resp.Body != nil {
+			var srvErr neorpc.HeaderAndError
+
+			dec := json.NewDecoder(resp.Body)
+			decErr := dec.Decode(&srvErr)
+			if decErr == nil && srvErr.Error != nil {
+				err = neorpc.MapError(srvErr.Error)
+			}
+		}
+		return nil, err
+	}
+	return ws, nil
+}
+
+// getWS returns the current websocket connection.
+func (c *WSClient) getWS() *websocket.Conn {
+	c.connLock.RLock()
+	defer c.connLock.RUnlock()
+	return c.ws
+}
+
 // Close closes connection to the remote side rendering this client instance
 // unusable.
 func (c *WSClient) Close() {
@@ -513,40 +646,63 @@ func (c *WSClient) Close() {
 }
 
 func (c *WSClient) wsReader() {
-	c.ws.SetReadLimit(wsReadLimit)
-	c.ws.SetPongHandler(func(string) error {
-		err := c.ws.SetReadDeadline(time.Now().Add(wsPongLimit))
+	var connCloseErr error
+	for {
+		connCloseErr = c.readLoop(c.getWS())
+		if c.wsOpts.Reconnect == nil || connCloseErr == nil || !c.reconnect() {
+			break
+		}
+	}
+	if connCloseErr != nil {
+		c.setCloseErr(connCloseErr)
+	}
+	close(c.readerDone)
+	c.respLock.Lock()
+	for _, ch := range c.respChannels {
+		close(ch)
+	}
+	c.respChannels = nil
+	c.respLock.Unlock()
+	c.subscriptionsLock.Lock()
+	for rcvrCh, ids := range c.receivers {
+		c.dropSubCh(rcvrCh, ids[0], true)
+	}
+	c.subscriptionsLock.Unlock()
+	c.Client.ctxCancel()
+}
+
+// readLoop reads messages from the given connection and dispatches them until
+// the connection is broken (the error is returned then) or the client is
+// closed (nil is returned).
+func (c *WSClient) readLoop(ws *websocket.Conn) error {
+	ws.SetReadLimit(wsReadLimit)
+	ws.SetPongHandler(func(string) error {
+		err := ws.SetReadDeadline(time.Now().Add(wsPongLimit))
 		if err != nil {
 			c.setCloseErr(fmt.Errorf("failed to set pong read deadline: %w", err))
 		}
 		return err
 	})
-	var connCloseErr error
-readloop:
 	for {
 		rr := new(requestResponse)
-		err := c.ws.SetReadDeadline(time.Now().Add(wsPongLimit))
+		err := ws.SetReadDeadline(time.Now().Add(wsPongLimit))
 		if err != nil {
-			connCloseErr = fmt.Errorf("failed to set response read deadline: %w", err)
-			break readloop
+			return fmt.Errorf("failed to set response read deadline: %w", err)
 		}
-		err = c.ws.ReadJSON(rr)
+		err = ws.ReadJSON(rr)
 		if err != nil {
 			// Timeout/connection loss/malformed response.
-			connCloseErr = fmt.Errorf("failed to read JSON response (timeout/connection loss/malformed response): %w", err)
-			break readloop
+			return fmt.Errorf("failed to read JSON response (timeout/connection loss/malformed response): %w", err)
 		}
 		if rr.ID == nil && rr.Method != "" {
 			event, err := neorpc.GetEventIDFromString(rr.Method)
 			if err != nil {
 				// Bad event received.
-				connCloseErr = fmt.Errorf("failed to perse event ID from string %s: %w", rr.Method, err)
-				break readloop
+				return fmt.Errorf("failed to perse event ID from string %s: %w", rr.Method, err)
 			}
 			if event != neorpc.MissedEventID && len(rr.RawParams) != 1 {
 				// Bad event received.
-				connCloseErr = fmt.Errorf("bad event received: %s / %d", event, len(rr.RawParams))
-				break readloop
+				return fmt.Errorf("bad event received: %s / %d", event, len(rr.RawParams))
 			}
 			ntf := Notification{Type: event}
 			switch event {
@@ -554,8 +710,7 @@ readloop:
 				sr, err := c.stateRootInHeader()
 				if err != nil {
 					// Client is not initialized.
-					connCloseErr = fmt.Errorf("failed to fetch StateRootInHeader: %w", err)
-					break readloop
+					return fmt.Errorf("failed to fetch StateRootInHeader: %w", err)
 				}
 				ntf.Value = block.New(sr)
 			case neorpc.TransactionEventID:
@@ -570,66 +725,216 @@ readloop:
 				sr, err := c.stateRootInHeader()
 				if err != nil {
 					// Client is not initialized.
-					connCloseErr = fmt.Errorf("failed to fetch StateRootInHeader: %w", err)
-					break readloop
+					return fmt.Errorf("failed to fetch StateRootInHeader: %w", err)
 				}
 				ntf.Value = &block.New(sr).Header
 			case neorpc.MissedEventID:
 				// No value.
 			default:
 				// Bad event received.
-				connCloseErr = fmt.Errorf("unknown event received: %d", event)
-				break readloop
+				return fmt.Errorf("unknown event received: %d", event)
 			}
 			if event != neorpc.MissedEventID {
 				err = json.Unmarshal(rr.RawParams[0], ntf.Value)
 				if err != nil {
 					// Bad event received.
-					connCloseErr = fmt.Errorf("failed to unmarshal event of type %s from JSON: %w", event, err)
-					break readloop
+					return fmt.Errorf("failed to unmarshal event of type %s from JSON: %w", event, err)
 				}
 			}
-			c.notifySubscribers(ntf)
+			if c.wsOpts.Reconnect != nil {
+				switch v := ntf.Value.(type) {
+				case *block.Block:
+					c.updateLastBlock(v.Index)
+				case *block.Header:
+					c.updateLastBlock(v.Index)
+				}
+			}
+			if c.orderer != nil {
+				for _, n := range c.orderer.add(ntf) {
+					c.notifySubscribers(n)
+				}
+			} else {
+				c.notifySubscribers(ntf)
+			}
 		} else if rr.ID != nil && (rr.Error != nil || rr.Result != nil) {
 			id, err := strconv.ParseUint(string(rr.ID), 10, 64)
 			if err != nil {
-				connCloseErr = fmt.Errorf("failed to retrieve response ID from string %s: %w", string(rr.ID), err)
-				break readloop // Malformed response (invalid response ID).
+				return fmt.Errorf("failed to retrieve response ID from string %s: %w", string(rr.ID), err)
 			}
 			ch := c.getResponseChannel(id)
 			if ch == nil {
-				connCloseErr = fmt.Errorf("unknown response channel for response %d", id)
-				break readloop // Unknown response (unexpected response ID).
+				if c.wsOpts.Reconnect != nil {
+					// The request could've been made before reconnection
+					// and its receiver is already gone.
+					continue
+				}
+				return fmt.Errorf("unknown response channel for response %d", id)
 			}
 			select {
 			case <-c.writerDone:
-				break readloop
+				return nil
 			case <-c.shutdown:
-				break readloop
+				return nil
 			case ch <- &rr.Response:
 			}
 		} else {
 			// Malformed response, neither valid request, nor valid response.
-			connCloseErr = fmt.Errorf("malformed response")
-			break readloop
+			return fmt.Errorf("malformed response")
 		}
 	}
-	if connCloseErr != nil {
-		c.setCloseErr(connCloseErr)
-	}
-	close(c.readerDone)
+}
+
+// reconnect fails all pending requests and tries to establish a new
+// connection to the server. If it succeeds, the new connection replaces the
+// old one and subscriptions are restored in a separate goroutine. It returns
+// false if the client is closed or if MaxAttempts is reached.
+func (c *WSClient) reconnect() bool {
+	c.getWS().Close()
 	c.respLock.Lock()
 	for _, ch := range c.respChannels {
 		close(ch)
 	}
 	c.respChannels = nil
 	c.respLock.Unlock()
-	c.subscriptionsLock.Lock()
-	for rcvrCh, ids := range c.receivers {
-		c.dropSubCh(rcvrCh, ids[0], true)
+
+	var (
+		opts  = c.wsOpts.Reconnect
+		delay = opts.MinDelay
+		timer = time.NewTimer(delay)
+	)
+	defer timer.Stop()
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-c.shutdown:
+			return false
+		case <-timer.C:
+		}
+		ws, err := dialWS(c.Client.ctx, c.dialer, c.endpoint)
+		if err == nil {
+			c.connLock.Lock()
+			c.ws = ws
+			c.connLock.Unlock()
+			select {
+			case <-c.shutdown:
+				// Writer could've missed the new connection.
+				ws.Close()
+				return false
+			default:
+			}
+			c.respLock.Lock()
+			c.respChannels = make(map[uint64]chan *neorpc.Response)
+			c.respLock.Unlock()
+			if c.orderer != nil {
+				// Some events are lost anyway.
+				c.orderer.reset()
+			}
+			go c.resubscribe(c.connGen.Add(1))
+			return true
+		}
+		if opts.MaxAttempts > 0 && attempt >= opts.MaxAttempts {
+			c.setCloseErr(fmt.Errorf("failed to reconnect after %d attempts: %w", attempt, err))
+			return false
+		}
+		delay = min(delay*2, opts.MaxDelay)
+		timer.Reset(delay)
+	}
+}
+
+// resubscribe restores all active subscriptions after reconnection and
+// delivers a WSGap. Subscriptions that can't be restored are dropped and their
+// receiver channels are closed (unless they're used by other subscriptions).
+// It does nothing if the connection is lost again while it works.
+func (c *WSClient) resubscribe(gen uint64) {
+	c.orderSubLock.Lock()
+	if c.orderSubID != "" {
+		var serverID string
+		err := c.performRequest("subscribe", []any{neorpc.BlockEventID.String()}, &serverID)
+		if err == nil {
+			c.orderSubID = serverID
+		}
+	}
+	c.orderSubLock.Unlock()
+	if c.connGen.Load() != gen {
+		return
+	}
+
+	c.subscriptionsLock.RLock()
+	subs := maps.Clone(c.subscriptions)
+	c.subscriptionsLock.RUnlock()
+
+	for id, rcvr := range subs {
+		params := []any{rcvr.EventID().String()}
+		if flt := rcvr.Filter(); flt != nil {
+			params = append(params, flt)
+		}
+		var serverID string
+		err := c.performRequest("subscribe", params, &serverID)
+		if c.connGen.Load() != gen {
+			return
+		}
+		if err != nil && errors.Is(err, ErrWSConnLost) {
+			return // Next reconnection will restore it.
+		}
+		c.subscriptionsLock.Lock()
+		if c.subscriptions[id] == rcvr {
+			if err == nil {
+				c.serverIDs[id] = serverID
+			} else {
+				c.dropSubscription(id, rcvr)
+			}
+		}
+		c.subscriptionsLock.Unlock()
+	}
+
+	count, err := c.GetBlockCount()
+	if err != nil || c.connGen.Load() != gen {
+		return
+	}
+	gap := &WSGap{
+		LastBlock: c.lastBlock.Load(),
+		Height:    count - 1,
+	}
+	c.updateLastBlock(gap.Height)
+	if c.wsOpts.Reconnect.Gaps != nil {
+		select {
+		case c.wsOpts.Reconnect.Gaps <- gap:
+		case <-c.shutdown:
+		}
+	}
+}
+
+// dropSubscription removes the subscription with the given ID and closes its
+// receiver channel if it's not used by any other subscription. It must be
+// called with subscriptionsLock taken.
+func (c *WSClient) dropSubscription(id string, rcvr notificationReceiver) {
+	delete(c.subscriptions, id)
+	delete(c.serverIDs, id)
+	ch := rcvr.Receiver()
+	ids, ok := c.receivers[ch]
+	if !ok {
+		return // Already closed.
+	}
+	ids = slices.DeleteFunc(ids, func(s string) bool { return s == id })
+	if len(ids) == 0 {
+		delete(c.receivers, ch)
+		rcvr.Close()
+	} else {
+		c.receivers[ch] = ids
+	}
+}
+
+// updateLastBlock updates the index of the latest block known to the client.
+func (c *WSClient) updateLastBlock(index uint32) {
+	for {
+		last := c.lastBlock.Load()
+		if c.lastBlockKnown.Load() && last >= index {
+			return
+		}
+		if c.lastBlock.CompareAndSwap(last, index) {
+			c.lastBlockKnown.Store(true)
+			return
+		}
 	}
-	c.subscriptionsLock.Unlock()
-	c.Client.ctxCancel()
 }
 
 // dropSubCh closes corresponding subscriber's channel and removes it from the
@@ -647,7 +952,7 @@ func (c *WSClient) dropSubCh(rcvrCh any, id string, ignoreCloseNotificationChann
 
 func (c *WSClient) wsWriter() {
 	pingTicker := time.NewTicker(wsPingPeriod)
-	defer c.ws.Close()
+	defer func() { c.getWS().Close() }()
 	defer pingTicker.Stop()
 	defer close(c.writerDone)
 	var connCloseErr error
@@ -662,21 +967,33 @@ writeloop:
 			if !ok {
 				return
 			}
-			if err := c.ws.SetWriteDeadline(time.Now().Add(c.opts.RequestTimeout)); err != nil {
+			ws := c.getWS()
+			if err := ws.SetWriteDeadline(time.Now().Add(c.opts.RequestTimeout)); err != nil {
 				connCloseErr = fmt.Errorf("failed to set request write deadline: %w", err)
-				break writeloop
-			}
-			if err := c.ws.WriteJSON(req); err != nil {
+			} else if err := ws.WriteJSON(req); err != nil {
 				connCloseErr = fmt.Errorf("failed to write JSON request (%s / %d): %w", req.Method, len(req.Params), err)
+			}
+			if connCloseErr != nil && c.wsOpts.Reconnect != nil {
+				// wsReader will notice it and reconnect.
+				ws.Close()
+				c.unregisterRespChannel(req.ID)
+				connCloseErr = nil
+			}
+			if connCloseErr != nil {
 				break writeloop
 			}
 		case <-pingTicker.C:
-			if err := c.ws.SetWriteDeadline(time.Now().Add(wsWriteLimit)); err != nil {
+			ws := c.getWS()
+			if err := ws.SetWriteDeadline(time.Now().Add(wsWriteLimit)); err != nil {
 				connCloseErr = fmt.Errorf("failed to set ping write deadline: %w", err)
-				break writeloop
-			}
-			if err := c.ws.WriteMessage(websocket.PingMessage, []byte{}); err != nil {
+			} else if err := ws.WriteMessage(websocket.PingMessage, []byte{}); err != nil {
 				connCloseErr = fmt.Errorf("failed to write ping message: %w", err)
+			}
+			if connCloseErr != nil && c.wsOpts.Reconnect != nil {
+				ws.Close()
+				connCloseErr = nil
+			}
+			if connCloseErr != nil {
 				break writeloop
 			}
 		}
@@ -745,6 +1062,11 @@ func (c *WSClient) makeWsRequest(r *neorpc.Request) (*neorpc.Response, error) {
 		c.respLock.Unlock()
 		return nil, fmt.Errorf("%w: before registering response channel", c.closeErrOrConnLost())
 	default:
+		if c.respChannels == nil {
+			// Reconnection is in progress.
+			c.respLock.Unlock()
+			return nil, fmt.Errorf("%w: before registering response channel", ErrWSConnLost)
+		}
 		c.respChannels[r.ID] = ch
 		c.respLock.Unlock()
 	}
@@ -777,6 +1099,19 @@ func (c *WSClient) performSubscription(params []any, rcvr notificationReceiver)
 			return "", err
 		}
 	}
+	if c.orderer != nil {
+		if err := c.subscribeOrdering(); err != nil {
+			return "", err
+		}
+	}
+	if c.wsOpts.Reconnect != nil && !c.lastBlockKnown.Load() {
+		// Gap notifications need some starting point.
+		count, err := c.GetBlockCount()
+		if err != nil {
+			return "", err
+		}
+		c.updateLastBlock(count - 1)
+	}
 	if err := c.performRequest("subscribe", params, &resp); err != nil {
 		return "", err
 	}
@@ -790,6 +1125,20 @@ func (c *WSClient) performSubscription(params []any, rcvr notificationReceiver)
 	return resp, nil
 }
 
+// subscribeOrdering makes an internal block subscription used for events
+// ordering if it's not done yet.
+func (c *WSClient) subscribeOrdering() error {
+	c.orderSubLock.Lock()
+	defer c.orderSubLock.Unlock()
+	if c.orderSubID != "" {
+		return nil
+	}
+	if !c.cache.initDone {
+		return errNetworkNotInitialized
+	}
+	return c.performRequest("subscribe", []any{neorpc.BlockEventID.String()}, &c.orderSubID)
+}
+
 // ReceiveBlocks registers provided channel as a receiver for the new block events.
 // Events can be filtered by the given BlockFilter, nil value doesn't add any filter.
 // See WSClient comments for generic Receive* behaviour details.
@@ -958,6 +1307,15 @@ func (c *WSClient) UnsubscribeAll() error {
 			resErr = fmt.Errorf(errFmt, errArgs...)
 		}
 	}
+	c.orderSubLock.Lock()
+	if c.orderSubID != "" {
+		var resp bool
+		// Not critical, it's just some extra traffic.
+		if c.performRequest("unsubscribe", []any{c.orderSubID}, &resp) == nil {
+			c.orderSubID = ""
+		}
+	}
+	c.orderSubLock.Unlock()
 	return resErr
 }
 
@@ -968,14 +1326,18 @@ func (c *WSClient) UnsubscribeAll() error {
 func (c *WSClient) performUnsubscription(id string) error {
 	c.subscriptionsLock.RLock()
 	rcvrWas, ok := c.subscriptions[id]
+	serverID, restored := c.serverIDs[id]
 	c.subscriptionsLock.RUnlock()
 
 	if !ok {
 		return errors.New("no subscription with this ID")
 	}
+	if !restored {
+		serverID = id
+	}
 
 	var resp bool
-	if err := c.performRequest("unsubscribe", []any{id}, &resp); err != nil {
+	if err := c.performRequest("unsubscribe", []any{serverID}, &resp); err != nil {
 		return err
 	}
 	if !resp {
@@ -1013,6 +1375,7 @@ func (c *WSClient) performUnsubscription(id string) error {
 	}
 	if cleanUpSubscriptions {
 		delete(c.subscriptions, id)
+		delete(c.serverIDs, id)
 	}
 	return nil
 }
@@ -960,3 +960,180 @@ func TestWSClient_ConnClosedError(t *testing.T) {
 		require.True(t, strings.Contains(err.Error(), "failed to read JSON response (timeout/connection loss/malformed response)"), err.Error())
 	})
 }
+
+func TestWSClientReconnect(t *testing.T) {
+	var (
+		conns       = make(chan *websocket.Conn, 2)
+		connCount   atomic.Int32
+		refuse      atomic.Bool
+		subCount    atomic.Int32
+		unsubscribe = make(chan string, 1)
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/ws" || req.Method != "GET" {
+			return
+		}
+		if refuse.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var upgrader = websocket.Upgrader{}
+		ws, err := upgrader.Upgrade(w, req, nil)
+		require.NoError(t, err)
+		n := connCount.Add(1)
+		conns <- ws
+		for {
+			_, p, err := ws.ReadMessage()
+			if err != nil {
+				break
+			}
+			r := params.NewIn()
+			require.NoError(t, json.Unmarshal(p, r))
+			var res string
+			switch r.Method {
+			case "getblockcount":
+				res = strconv.Itoa(int(n) * 10)
+			case "subscribe":
+				res = fmt.Sprintf(`"%d"`, subCount.Add(1)-1)
+			case "unsubscribe":
+				var id string
+				require.NoError(t, json.Unmarshal(r.RawParams[0].RawMessage, &id))
+				unsubscribe <- id
+				res = "true"
+			}
+			err = ws.WriteMessage(websocket.TextMessage, fmt.Appendf(nil, `{"jsonrpc":"2.0","id":%s,"result":%s}`, r.RawID, res))
+			if err != nil {
+				break
+			}
+			if r.Method == "getblockcount" && n == 2 {
+				err = ws.WriteMessage(websocket.TextMessage, fmt.Appendf(nil, `{"jsonrpc":"2.0","method":"block_added","params":[%s]}`, b1Verbose))
+				if err != nil {
+					break
+				}
+			}
+		}
+		ws.Close()
+	}))
+	t.Cleanup(srv.Close)
+
+	gaps := make(chan *WSGap, 1)
+	wsc, err := NewWS(context.TODO(), httpURLtoWS(srv.URL), WSOptions{
+		Reconnect: &WSReconnectOptions{
+			MinDelay:    10 * time.Millisecond,
+			MaxDelay:    20 * time.Millisecond,
+			MaxAttempts: 3,
+			Gaps:        gaps,
+		},
+	})
+	require.NoError(t, err)
+	t.Cleanup(wsc.Close)
+	wsc.cache.initDone = true
+
+	bCh := make(chan *block.Block, 1)
+	id, err := wsc.ReceiveBlocks(nil, bCh)
+	require.NoError(t, err)
+	require.Equal(t, "0", id)
+
+	// Drop the connection from the server side.
+	(<-conns).Close()
+
+	select {
+	case gap := <-gaps:
+		require.Equal(t, WSGap{LastBlock: 9, Height: 19}, *gap)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no gap notification")
+	}
+	select {
+	case b, ok := <-bCh:
+		require.True(t, ok)
+		require.Equal(t, uint32(1), b.Index)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no block received after reconnection")
+	}
+	require.NoError(t, wsc.GetError())
+
+	// Old ID is kept, but the restored server-side subscription is removed.
+	require.NoError(t, wsc.Unsubscribe(id))
+	require.Equal(t, "1", <-unsubscribe)
+
+	// Subscribe again and make reconnection fail.
+	_, err = wsc.ReceiveBlocks(nil, bCh)
+	require.NoError(t, err)
+	refuse.Store(true)
+	(<-conns).Close()
+
+	select {
+	case _, ok := <-bCh:
+		require.False(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("receiver channel is not closed")
+	}
+	require.ErrorContains(t, wsc.GetError(), "failed to reconnect after 3 attempts")
+	_, err = wsc.GetBlockCount()
+	require.Error(t, err)
+}
+
+func TestWSClientOrderEvents(t *testing.T) {
+	var (
+		subscriptions = make(chan string, 2)
+		startSending  = make(chan struct{})
+		ntfTmpl       = `{"jsonrpc":"2.0","method":"notification_from_execution","params":[{"container":"%s","contract":"0x1b4357bff5a01bdf2a6581247cf9ed1e24629176","eventname":"%s","state":{"type":"Array","value":[]}}]}`
+		events        = []string{
+			fmt.Sprintf(ntfTmpl, "0xced32af656e144f6be5d7172ed37747831456cb3eeaac4ee964d0b479b45d3a8", "second"),
+			fmt.Sprintf(ntfTmpl, "0xf01080c50f3198f5a539c4a06d024f1b8bdc2a360a215fa7e2488f79a56d501a", "first"),
+			fmt.Sprintf(`{"jsonrpc":"2.0","method":"block_added","params":[%s]}`, b1Verbose),
+		}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/ws" || req.Method != "GET" {
+			return
+		}
+		var upgrader = websocket.Upgrader{}
+		ws, err := upgrader.Upgrade(w, req, nil)
+		require.NoError(t, err)
+		defer ws.Close()
+		for i := 0; ; i++ {
+			_, p, err := ws.ReadMessage()
+			if err != nil {
+				return
+			}
+			r := params.NewIn()
+			require.NoError(t, json.Unmarshal(p, r))
+			var event string
+			require.NoError(t, json.Unmarshal(r.RawParams[0].RawMessage, &event))
+			subscriptions <- event
+			err = ws.WriteMessage(websocket.TextMessage, fmt.Appendf(nil, `{"jsonrpc":"2.0","id":%s,"result":"%d"}`, r.RawID, i))
+			require.NoError(t, err)
+			if r.Method == "subscribe" && event == "notification_from_execution" {
+				<-startSending
+				for _, e := range events {
+					require.NoError(t, ws.WriteMessage(websocket.TextMessage, []byte(e)))
+				}
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	wsc, err := NewWS(context.TODO(), httpURLtoWS(srv.URL), WSOptions{OrderEvents: true})
+	require.NoError(t, err)
+	t.Cleanup(wsc.Close)
+
+	ntfCh := make(chan *state.ContainedNotificationEvent)
+	_, err = wsc.ReceiveExecutionNotifications(nil, ntfCh)
+	require.ErrorIs(t, err, errNetworkNotInitialized)
+
+	wsc.cache.initDone = true
+	_, err = wsc.ReceiveExecutionNotifications(nil, ntfCh)
+	require.NoError(t, err)
+	require.Equal(t, "block_added", <-subscriptions)
+	require.Equal(t, "notification_from_execution", <-subscriptions)
+	close(startSending)
+	for _, name := range []string{"first", "second"} {
+		select {
+		case ntf := <-ntfCh:
+			require.Equal(t, name, ntf.Name)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no notification received")
+		}
+	}
+}
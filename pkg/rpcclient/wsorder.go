@@ -0,0 +1,256 @@
+package rpcclient
+
+import (
+	"slices"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// orderedBlocksHistory is the number of the latest delivered blocks which
+// containers are remembered by eventOrderer to suppress duplicates.
+const orderedBlocksHistory = 16
+
+// Positions of events within the container, in the order they're emitted by
+// the node.
+const (
+	orderExecution = iota
+	orderNotification
+	orderTransaction
+)
+
+// eventOrderer buffers block-related notifications (transactions, executions,
+// execution notifications and headers) until the block they belong to is
+// received and then releases them in the chain order: OnPersist execution
+// with its notifications, then every transaction execution with its
+// notifications followed by the transaction itself (in the block order),
+// PostPersist execution with its notifications, header and the block itself.
+// Duplicates are dropped. It's not thread-safe.
+type eventOrderer struct {
+	seq       uint64
+	flushSeq  uint64
+	pending   []pendingEvent
+	keys      map[string]struct{}
+	lastIndex uint32
+	lastKnown bool
+	// delivered is a ring of container hashes of the latest delivered blocks
+	// and deliveredSet is its union.
+	delivered    [][]util.Uint256
+	deliveredSet map[util.Uint256]int
+}
+
+// pendingEvent is a buffered notification.
+type pendingEvent struct {
+	ntf       Notification
+	seq       uint64
+	container util.Uint256
+}
+
+// orderedEvent is a notification with its (position in block, position in
+// container, arrival sequence number) sorting key.
+type orderedEvent struct {
+	key [3]uint64
+	ntf Notification
+}
+
+func newEventOrderer() *eventOrderer {
+	o := new(eventOrderer)
+	o.reset()
+	return o
+}
+
+// reset drops all buffered events, it's used when some events can be missed.
+func (o *eventOrderer) reset() {
+	o.pending = nil
+	o.keys = make(map[string]struct{})
+	o.delivered = nil
+	o.deliveredSet = make(map[util.Uint256]int)
+	o.lastKnown = false
+}
+
+// add accepts a new notification and returns notifications that are ready to
+// be delivered.
+func (o *eventOrderer) add(ntf Notification) []Notification {
+	o.seq++
+	var container util.Uint256
+	switch v := ntf.Value.(type) {
+	case *block.Block:
+		return o.flush(ntf, v)
+	case *block.Header:
+		container = v.Hash()
+	case *transaction.Transaction:
+		container = v.Hash()
+	case *state.AppExecResult:
+		container = v.Container
+	case *state.ContainedNotificationEvent:
+		container = v.Container
+	default:
+		if ntf.Type == neorpc.MissedEventID {
+			o.reset()
+		}
+		return []Notification{ntf}
+	}
+	if _, ok := o.deliveredSet[container]; ok {
+		return nil
+	}
+	key := eventKey(ntf, container)
+	if key != "" {
+		if _, ok := o.keys[key]; ok {
+			return nil
+		}
+		o.keys[key] = struct{}{}
+	}
+	o.pending = append(o.pending, pendingEvent{ntf: ntf, seq: o.seq, container: container})
+	return nil
+}
+
+// flush releases buffered events of the given block followed by the block
+// itself.
+func (o *eventOrderer) flush(ntf Notification, b *block.Block) []Notification {
+	if o.lastKnown && b.Index <= o.lastIndex {
+		return nil
+	}
+	var (
+		bHash     = b.Hash()
+		positions = make(map[util.Uint256]int, len(b.Transactions)+1)
+		events    []pendingEvent
+		rest      = o.pending[:0]
+	)
+	positions[bHash] = 0
+	for i, tx := range b.Transactions {
+		positions[tx.Hash()] = i + 1
+	}
+	for _, e := range o.pending {
+		if _, ok := positions[e.container]; ok {
+			events = append(events, e)
+			continue
+		}
+		if e.seq < o.flushSeq {
+			// Pending since before the previous block, its block is
+			// never going to be received.
+			delete(o.keys, eventKey(e.ntf, e.container))
+			continue
+		}
+		rest = append(rest, e)
+	}
+	clear(o.pending[len(rest):])
+	o.pending = rest
+	events = dropExtraNotifications(events)
+
+	var (
+		postPersist = len(b.Transactions) + 1
+		firstTx     = ^uint64(0)
+		lastExec    = make(map[util.Uint256]*state.AppExecResult)
+		sorted      = make([]orderedEvent, len(events))
+	)
+	for _, e := range events {
+		if positions[e.container] != 0 {
+			firstTx = min(firstTx, e.seq)
+		}
+	}
+	for i, e := range events {
+		pos, sub := positions[e.container], 0
+		switch v := e.ntf.Value.(type) {
+		case *block.Header:
+			pos = postPersist + 1
+		case *transaction.Transaction:
+			sub = orderTransaction
+		case *state.AppExecResult:
+			sub = orderExecution
+			if v.Trigger == trigger.PostPersist {
+				pos = postPersist
+			}
+			lastExec[e.container] = v
+		case *state.ContainedNotificationEvent:
+			sub = orderNotification
+			if pos == 0 {
+				// Notifications don't contain trigger, so the latest
+				// block execution is used to tell OnPersist and PostPersist
+				// ones apart, arrival order is used if there is none.
+				if aer, ok := lastExec[e.container]; ok {
+					if aer.Trigger == trigger.PostPersist {
+						pos = postPersist
+					}
+				} else if e.seq > firstTx {
+					pos = postPersist
+				}
+			}
+		}
+		sorted[i] = orderedEvent{key: [3]uint64{uint64(pos), uint64(sub), e.seq}, ntf: e.ntf}
+	}
+	slices.SortFunc(sorted, func(a, b orderedEvent) int {
+		return slices.Compare(a.key[:], b.key[:])
+	})
+	res := make([]Notification, 0, len(events)+1)
+	for _, e := range sorted {
+		res = append(res, e.ntf)
+	}
+	res = append(res, ntf)
+
+	containers := make([]util.Uint256, 0, len(positions))
+	for h := range positions {
+		containers = append(containers, h)
+		o.deliveredSet[h]++
+	}
+	o.delivered = append(o.delivered, containers)
+	if len(o.delivered) > orderedBlocksHistory {
+		for _, h := range o.delivered[0] {
+			if o.deliveredSet[h]--; o.deliveredSet[h] == 0 {
+				delete(o.deliveredSet, h)
+			}
+		}
+		o.delivered = o.delivered[1:]
+	}
+	for _, e := range events {
+		delete(o.keys, eventKey(e.ntf, e.container))
+	}
+	o.lastIndex, o.lastKnown = b.Index, true
+	o.flushSeq = o.seq
+	return res
+}
+
+// eventKey returns a key used to detect duplicates of the given event, it's
+// empty for execution notifications (several identical ones can be emitted by
+// the same container).
+func eventKey(ntf Notification, container util.Uint256) string {
+	switch v := ntf.Value.(type) {
+	case *block.Header:
+		return "h" + container.StringLE()
+	case *transaction.Transaction:
+		return "t" + container.StringLE()
+	case *state.AppExecResult:
+		return "e" + container.StringLE() + v.Trigger.String()
+	}
+	return ""
+}
+
+// dropExtraNotifications removes transaction notifications exceeding the
+// number of notifications in the corresponding execution (if it's received),
+// these are duplicates. The order of events is preserved.
+func dropExtraNotifications(events []pendingEvent) []pendingEvent {
+	var limits = make(map[util.Uint256]int)
+	for _, e := range events {
+		if aer, ok := e.ntf.Value.(*state.AppExecResult); ok && aer.Trigger == trigger.Application {
+			limits[e.container] = len(aer.Events)
+		}
+	}
+	if len(limits) == 0 {
+		return events
+	}
+	var counts = make(map[util.Uint256]int)
+	return slices.DeleteFunc(events, func(e pendingEvent) bool {
+		if _, ok := e.ntf.Value.(*state.ContainedNotificationEvent); !ok {
+			return false
+		}
+		limit, ok := limits[e.container]
+		if !ok {
+			return false
+		}
+		counts[e.container]++
+		return counts[e.container] > limit
+	})
+}
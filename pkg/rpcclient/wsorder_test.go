@@ -0,0 +1,141 @@
+package rpcclient
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventOrderer(t *testing.T) {
+	newTx := func(nonce uint32) *transaction.Transaction {
+		tx := transaction.New([]byte{1}, 0)
+		tx.Nonce = nonce
+		return tx
+	}
+	newBlock := func(index uint32, txs ...*transaction.Transaction) *block.Block {
+		return &block.Block{
+			Header:       block.Header{Index: index},
+			Transactions: txs,
+		}
+	}
+	var (
+		tx1 = newTx(1)
+		tx2 = newTx(2)
+		b   = newBlock(1, tx1, tx2)
+		bh  = b.Hash()
+
+		exec = func(container util.Uint256, trig trigger.Type, events int) Notification {
+			return Notification{Type: neorpc.ExecutionEventID, Value: &state.AppExecResult{
+				Container: container,
+				Execution: state.Execution{Trigger: trig, Events: make([]state.NotificationEvent, events)},
+			}}
+		}
+		ntf = func(container util.Uint256, name string) Notification {
+			return Notification{Type: neorpc.NotificationEventID, Value: &state.ContainedNotificationEvent{
+				Container:         container,
+				NotificationEvent: state.NotificationEvent{Name: name},
+			}}
+		}
+		txNtf = func(tx *transaction.Transaction) Notification {
+			return Notification{Type: neorpc.TransactionEventID, Value: tx}
+		}
+		hdr = Notification{Type: neorpc.HeaderOfAddedBlockEventID, Value: &b.Header}
+		blk = Notification{Type: neorpc.BlockEventID, Value: b}
+
+		onPersist   = exec(bh, trigger.OnPersist, 1)
+		onPersistN  = ntf(bh, "on")
+		tx1Exec     = exec(tx1.Hash(), trigger.Application, 2)
+		tx1N1       = ntf(tx1.Hash(), "first")
+		tx1N2       = ntf(tx1.Hash(), "second")
+		tx2Exec     = exec(tx2.Hash(), trigger.Application, 0)
+		postPersist = exec(bh, trigger.PostPersist, 1)
+		postN       = ntf(bh, "post")
+	)
+
+	o := newEventOrderer()
+	for _, n := range []Notification{
+		txNtf(tx2), tx2Exec, postPersist, postN,
+		tx1N1, tx1Exec, tx1N2, txNtf(tx1), hdr,
+		onPersist, onPersistN,
+		// Duplicates.
+		txNtf(tx1), tx2Exec, tx1N2,
+	} {
+		require.Nil(t, o.add(n))
+	}
+	// Notary requests are not delayed.
+	nr := Notification{Type: neorpc.NotaryRequestEventID, Value: &result.NotaryRequestEvent{}}
+	require.Equal(t, []Notification{nr}, o.add(nr))
+
+	require.Equal(t, []Notification{
+		onPersist, onPersistN,
+		tx1Exec, tx1N1, tx1N2, txNtf(tx1),
+		tx2Exec, txNtf(tx2),
+		postPersist, postN,
+		hdr, blk,
+	}, o.add(blk))
+
+	t.Run("duplicates of delivered block", func(t *testing.T) {
+		require.Nil(t, o.add(txNtf(tx1)))
+		require.Nil(t, o.add(postN))
+		require.Nil(t, o.add(blk))
+		require.Empty(t, o.pending)
+	})
+
+	t.Run("interleaved blocks", func(t *testing.T) {
+		var (
+			tx3 = newTx(3)
+			b2  = newBlock(2, tx3)
+			b3  = newBlock(3)
+			n3  = ntf(b3.Hash(), "b3")
+		)
+		require.Nil(t, o.add(n3))
+		require.Nil(t, o.add(txNtf(tx3)))
+		require.Equal(t, []Notification{txNtf(tx3), {Type: neorpc.BlockEventID, Value: b2}},
+			o.add(Notification{Type: neorpc.BlockEventID, Value: b2}))
+		require.Equal(t, []Notification{n3, {Type: neorpc.BlockEventID, Value: b3}},
+			o.add(Notification{Type: neorpc.BlockEventID, Value: b3}))
+	})
+
+	t.Run("notifications without executions", func(t *testing.T) {
+		var (
+			tx4 = newTx(4)
+			b4  = newBlock(4, tx4)
+			on  = ntf(b4.Hash(), "on")
+			tn  = ntf(tx4.Hash(), "tx")
+			pn  = ntf(b4.Hash(), "post")
+		)
+		require.Nil(t, o.add(tn))
+		require.Nil(t, o.add(on))
+		require.Nil(t, o.add(pn))
+		// "on" arrives after transaction notification, so it's considered
+		// to be PostPersist one.
+		require.Equal(t, []Notification{tn, on, pn, {Type: neorpc.BlockEventID, Value: b4}},
+			o.add(Notification{Type: neorpc.BlockEventID, Value: b4}))
+	})
+
+	t.Run("stale events", func(t *testing.T) {
+		require.Nil(t, o.add(txNtf(newTx(5))))
+		require.Len(t, o.pending, 1)
+		o.add(Notification{Type: neorpc.BlockEventID, Value: newBlock(5)})
+		require.Len(t, o.pending, 1)
+		o.add(Notification{Type: neorpc.BlockEventID, Value: newBlock(6)})
+		require.Empty(t, o.pending)
+		require.Empty(t, o.keys)
+	})
+
+	t.Run("missed event", func(t *testing.T) {
+		require.Nil(t, o.add(txNtf(newTx(7))))
+		missed := Notification{Type: neorpc.MissedEventID}
+		require.Equal(t, []Notification{missed}, o.add(missed))
+		require.Empty(t, o.pending)
+		// Any block is accepted after reset.
+		require.Len(t, o.add(blk), 1)
+	})
+}
@@ -0,0 +1,119 @@
+package blockuploader
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/services/helpers/neofs"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neofs-sdk-go/client"
+	cid "github.com/nspcc-dev/neofs-sdk-go/container/id"
+	"github.com/nspcc-dev/neofs-sdk-go/object"
+	oid "github.com/nspcc-dev/neofs-sdk-go/object/id"
+	"github.com/nspcc-dev/neofs-sdk-go/pool"
+	"github.com/nspcc-dev/neofs-sdk-go/user"
+)
+
+// objectStore is a NeoFS container the Service uploads objects to.
+type objectStore interface {
+	// dial establishes connection to NeoFS and checks that the container is
+	// suitable for the network.
+	dial(ctx context.Context) error
+	// search returns IDs of the objects matching the filters.
+	search(ctx context.Context, filters object.SearchFilters) ([]oid.ID, error)
+	// put creates an object with the given attributes, its payload is written
+	// by the provided function.
+	put(ctx context.Context, attrs []object.Attribute, payload func(io.Writer) error) (oid.ID, error)
+	// close releases all resources.
+	close()
+}
+
+// poolWrapper wraps a NeoFS pool to adapt its Close method to return an error.
+type poolWrapper struct {
+	*pool.Pool
+}
+
+// Close closes the pool and returns nil.
+func (p poolWrapper) Close() error {
+	p.Pool.Close()
+	return nil
+}
+
+// neofsStore is an objectStore backed by NeoFS pool.
+type neofsStore struct {
+	pool        poolWrapper
+	account     *wallet.Account
+	signer      user.Signer
+	containerID cid.ID
+	magic       netmode.Magic
+}
+
+func newNeoFSStore(addresses []string, account *wallet.Account, containerID cid.ID, magic netmode.Magic) (*neofsStore, error) {
+	params := pool.DefaultOptions()
+	params.SetHealthcheckTimeout(neofs.DefaultHealthcheckTimeout)
+	params.SetNodeDialTimeout(neofs.DefaultDialTimeout)
+	params.SetNodeStreamTimeout(neofs.DefaultStreamTimeout)
+	signer := user.NewAutoIDSignerRFC6979(account.PrivateKey().PrivateKey)
+	p, err := pool.New(pool.NewFlatNodeParams(addresses), signer, params)
+	if err != nil {
+		return nil, err
+	}
+	return &neofsStore{
+		pool:        poolWrapper{Pool: p},
+		account:     account,
+		signer:      signer,
+		containerID: containerID,
+		magic:       magic,
+	}, nil
+}
+
+func (s *neofsStore) dial(ctx context.Context) error {
+	if err := s.pool.Dial(ctx); err != nil {
+		return fmt.Errorf("failed to dial NeoFS pool: %w", err)
+	}
+	containerObj, err := s.pool.ContainerGet(ctx, s.containerID, client.PrmContainerGet{})
+	if err != nil {
+		return fmt.Errorf("failed to get container: %w", err)
+	}
+	containerMagic := containerObj.Attribute("Magic")
+	if containerMagic != strconv.Itoa(int(s.magic)) {
+		return fmt.Errorf("container magic mismatch: expected %d, got %s", s.magic, containerMagic)
+	}
+	return nil
+}
+
+func (s *neofsStore) search(ctx context.Context, filters object.SearchFilters) ([]oid.ID, error) {
+	prm := client.PrmObjectSearch{}
+	prm.SetFilters(filters)
+	return neofs.ObjectSearch(ctx, s.pool, s.account.PrivateKey(), s.containerID.String(), prm)
+}
+
+func (s *neofsStore) put(ctx context.Context, attrs []object.Attribute, payload func(io.Writer) error) (oid.ID, error) {
+	var hdr object.Object
+
+	hdr.SetContainerID(s.containerID)
+	hdr.SetOwner(s.signer.UserID())
+	hdr.SetAttributes(attrs...)
+
+	writer, err := s.pool.ObjectPutInit(ctx, hdr, s.signer, client.PrmObjectPutInit{})
+	if err != nil {
+		return oid.ID{}, fmt.Errorf("failed to initiate object upload: %w", err)
+	}
+	err = payload(writer)
+	if err != nil {
+		_ = writer.Close()
+		return oid.ID{}, fmt.Errorf("failed to write object data: %w", err)
+	}
+	err = writer.Close()
+	if err != nil {
+		return oid.ID{}, fmt.Errorf("failed to close object writer: %w", err)
+	}
+	return writer.GetResult().StoredObjectID(), nil
+}
+
+func (s *neofsStore) close() {
+	_ = s.pool.Close()
+}
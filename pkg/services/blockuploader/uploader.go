@@ -0,0 +1,529 @@
+package blockuploader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	gio "github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/services/helpers/neofs"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	cid "github.com/nspcc-dev/neofs-sdk-go/container/id"
+	"github.com/nspcc-dev/neofs-sdk-go/object"
+	oid "github.com/nspcc-dev/neofs-sdk-go/object/id"
+	"go.uber.org/zap"
+)
+
+// Ledger is an interface to Blockchain sufficient for Service.
+type Ledger interface {
+	GetConfig() config.Blockchain
+	BlockHeight() uint32
+	GetBlock(hash util.Uint256) (*block.Block, error)
+	GetHeaderHash(uint32) util.Uint256
+	GetStateModule() core.StateRoot
+	SubscribeForBlocks(ch chan *block.Block)
+	UnsubscribeFromBlocks(ch chan *block.Block)
+}
+
+// Service is a service that uploads persisted blocks (and, optionally,
+// periodic state dumps) to NeoFS container. Objects are stored in the same
+// format the `util upload-bin` command uses, so the container can be used by
+// the NeoFS BlockFetcher service. On start Service finds the first block
+// missing from the container and uploads all blocks starting from it, then it
+// uploads new blocks as they're added to the chain.
+type Service struct {
+	started atomic.Bool
+	log     *zap.Logger
+	cfg     config.NeoFSBlockUploader
+
+	chain Ledger
+	store objectStore
+
+	blockCh chan *block.Block
+	wakeCh  chan struct{}
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+	done      chan struct{}
+
+	// next is the index of the next block to upload.
+	next uint32
+	// stateNext is the lowest height state dump may be needed for.
+	stateNext uint32
+	// buf contains OIDs of the uploaded blocks of the current index file.
+	buf []byte
+}
+
+// New creates a new BlockUploader Service.
+func New(chain Ledger, cfg config.NeoFSBlockUploader, logger *zap.Logger) (*Service, error) {
+	var (
+		containerID cid.ID
+		account     *wallet.Account
+	)
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	bcCfg := chain.GetConfig()
+	if cfg.StateDumpInterval != 0 && (bcCfg.Ledger.KeepOnlyLatestState || bcCfg.Ledger.RemoveUntraceableBlocks) {
+		return nil, errors.New("state dumps require full MPT history (KeepOnlyLatestState and RemoveUntraceableBlocks disabled)")
+	}
+	if err := containerID.DecodeString(cfg.ContainerID); err != nil {
+		return nil, fmt.Errorf("invalid container ID: %w", err)
+	}
+	w, err := wallet.NewWalletFromFile(cfg.UnlockWallet.Path)
+	if err != nil {
+		return nil, err
+	}
+	for _, acc := range w.Accounts {
+		if err := acc.Decrypt(cfg.UnlockWallet.Password, w.Scrypt); err == nil {
+			account = acc
+			break
+		}
+	}
+	if account == nil {
+		return nil, errors.New("failed to decrypt any account in the wallet")
+	}
+	store, err := newNeoFSStore(cfg.Addresses, account, containerID, bcCfg.Magic)
+	if err != nil {
+		return nil, err
+	}
+	return newService(chain, cfg, logger, store), nil
+}
+
+// newService creates a Service using the given object store and fills
+// defaults for unset configuration values.
+func newService(chain Ledger, cfg config.NeoFSBlockUploader, logger *zap.Logger, store objectStore) *Service {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = neofs.DefaultTimeout
+	}
+	if cfg.WorkersCount <= 0 {
+		cfg.WorkersCount = neofs.DefaultUploaderWorkersCount
+	}
+	if cfg.IndexFileSize == 0 {
+		cfg.IndexFileSize = neofs.DefaultIndexFileSize
+	}
+	if cfg.BlockAttribute == "" {
+		cfg.BlockAttribute = neofs.DefaultBlockAttribute
+	}
+	if cfg.IndexFileAttribute == "" {
+		cfg.IndexFileAttribute = neofs.DefaultIndexFileAttribute
+	}
+	if cfg.StateAttribute == "" {
+		cfg.StateAttribute = neofs.DefaultStateAttribute
+	}
+	return &Service{
+		log:     logger,
+		cfg:     cfg,
+		chain:   chain,
+		store:   store,
+		blockCh: make(chan *block.Block),
+		wakeCh:  make(chan struct{}, 1),
+		done:    make(chan struct{}),
+		buf:     make([]byte, cfg.IndexFileSize*oid.Size),
+	}
+}
+
+// Name returns service name.
+func (s *Service) Name() string {
+	return "NeoFSBlockUploader"
+}
+
+// Start runs the NeoFS BlockUploader service. It returns immediately, all
+// uploads are performed in a separate routine.
+func (s *Service) Start() {
+	if !s.started.CompareAndSwap(false, true) {
+		return
+	}
+	s.log.Info("starting NeoFS BlockUploader service")
+	s.ctx, s.ctxCancel = context.WithCancel(context.Background())
+	s.chain.SubscribeForBlocks(s.blockCh)
+	go s.notifier()
+	go s.run()
+}
+
+// Shutdown stops the NeoFS BlockUploader service, it cancels all in-progress
+// uploads and waits until the service routine finishes its work.
+func (s *Service) Shutdown() {
+	if !s.started.CompareAndSwap(true, false) {
+		return
+	}
+	s.log.Info("shutting down NeoFS BlockUploader service")
+	s.ctxCancel()
+	s.chain.UnsubscribeFromBlocks(s.blockCh)
+	<-s.done
+	s.store.close()
+	_ = s.log.Sync()
+}
+
+// notifier turns block events into non-blocking wake-ups of the service
+// routine, so that the chain is never blocked by uploads.
+func (s *Service) notifier() {
+	for {
+		select {
+		case <-s.ctx.Done():
+			// Drain the channel until it's unsubscribed.
+			for {
+				select {
+				case <-s.blockCh:
+				default:
+					return
+				}
+			}
+		case <-s.blockCh:
+			select {
+			case s.wakeCh <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// run is the main service routine. It finds the position to continue
+// uploading from and uploads blocks until the service is stopped.
+func (s *Service) run() {
+	defer close(s.done)
+
+	var initialized bool
+	for {
+		var err error
+		if !initialized {
+			err = s.init()
+			initialized = err == nil
+		}
+		if initialized {
+			err = s.uploadUpTo(s.chain.BlockHeight())
+		}
+		if err != nil {
+			if s.ctx.Err() != nil {
+				return
+			}
+			s.log.Error("NeoFS BlockUploader service: upload failed, will retry", zap.Error(err))
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-time.After(neofs.MaxBackoff):
+			}
+			continue
+		}
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-s.wakeCh:
+		}
+	}
+}
+
+// init connects to NeoFS and searches for the first block missing from the
+// container (as well as for the last state dump made).
+func (s *Service) init() error {
+	err := s.retry(func() error {
+		ctx, cancel := context.WithTimeout(s.ctx, s.cfg.Timeout)
+		defer cancel()
+		return s.store.dial(ctx)
+	})
+	if err != nil {
+		return err
+	}
+
+	var (
+		size      = s.cfg.IndexFileSize
+		indexFile uint32
+	)
+	for ; ; indexFile++ {
+		filters := object.NewSearchFilters()
+		filters.AddFilter(s.cfg.IndexFileAttribute, strconv.FormatUint(uint64(indexFile), 10), object.MatchStringEqual)
+		filters.AddFilter("IndexSize", strconv.FormatUint(uint64(size), 10), object.MatchStringEqual)
+		ids, err := s.search(filters)
+		if err != nil {
+			return fmt.Errorf("failed to search for index file %d: %w", indexFile, err)
+		}
+		if len(ids) == 0 {
+			break
+		}
+	}
+
+	// Index file is not yet uploaded, so its blocks are searched one by one.
+	// Search stops at the first missing block, blocks uploaded after it (if
+	// any) will be uploaded again.
+	clear(s.buf)
+	s.next = indexFile * size
+	for end := s.next + size; s.next < end && s.next <= s.chain.BlockHeight(); {
+		var (
+			batch = min(uint32(s.cfg.WorkersCount), end-s.next)
+			found = make([]bool, batch)
+		)
+		err := s.parallel(s.next, s.next+batch-1, func(h uint32) error {
+			filters := object.NewSearchFilters()
+			filters.AddFilter(s.cfg.BlockAttribute, strconv.FormatUint(uint64(h), 10), object.MatchStringEqual)
+			ids, err := s.search(filters)
+			if err != nil {
+				return fmt.Errorf("failed to search for block %d: %w", h, err)
+			}
+			if len(ids) != 0 {
+				copy(s.buf[h%size*oid.Size:], ids[0][:])
+				found[h-s.next] = true
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		var cnt uint32
+		for cnt < batch && found[cnt] {
+			cnt++
+		}
+		s.next += cnt
+		if cnt < batch {
+			break
+		}
+	}
+
+	s.stateNext = s.next
+	if s.cfg.StateDumpInterval != 0 && s.next != 0 {
+		last := (s.next - 1) / s.cfg.StateDumpInterval * s.cfg.StateDumpInterval
+		filters := object.NewSearchFilters()
+		filters.AddFilter(s.cfg.StateAttribute, strconv.FormatUint(uint64(last), 10), object.MatchStringEqual)
+		ids, err := s.search(filters)
+		if err != nil {
+			return fmt.Errorf("failed to search for state dump %d: %w", last, err)
+		}
+		if len(ids) == 0 {
+			s.stateNext = last
+		}
+	}
+	s.log.Info("NeoFS BlockUploader service: initialized",
+		zap.Uint32("index files", indexFile),
+		zap.Uint32("next block", s.next))
+	return nil
+}
+
+// uploadUpTo uploads all blocks up to the given height (including it) along
+// with state dumps and index files.
+func (s *Service) uploadUpTo(height uint32) error {
+	size := s.cfg.IndexFileSize
+	for s.next <= height {
+		var (
+			indexFile = s.next / size
+			end       = min(indexFile*size+size-1, height)
+		)
+		err := s.parallel(s.next, end, s.uploadBlock)
+		if err != nil {
+			return err
+		}
+		err = s.uploadStates(end)
+		if err != nil {
+			return err
+		}
+		if (end+1)%size == 0 {
+			err = s.uploadIndexFile(indexFile)
+			if err != nil {
+				return err
+			}
+			clear(s.buf)
+		}
+		s.next = end + 1
+	}
+	return nil
+}
+
+// parallel runs f for every height in [from, to] range using WorkersCount
+// routines and returns the first error encountered.
+func (s *Service) parallel(from, to uint32, f func(h uint32) error) error {
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+		workers  = uint32(s.cfg.WorkersCount)
+	)
+	for i := range workers {
+		if from+i > to {
+			break
+		}
+		wg.Add(1)
+		go func(start uint32) {
+			defer wg.Done()
+			for h := start; h <= to; h += workers {
+				if err := f(h); err != nil {
+					errOnce.Do(func() { firstErr = err })
+					return
+				}
+				if h+workers < h { // Overflow.
+					return
+				}
+			}
+		}(from + i)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// uploadBlock uploads the block if it's not yet uploaded and stores its OID.
+func (s *Service) uploadBlock(h uint32) error {
+	pos := h % s.cfg.IndexFileSize * oid.Size
+	if !oid.ID(s.buf[pos : pos+oid.Size]).IsZero() {
+		return nil
+	}
+	b, err := s.chain.GetBlock(s.chain.GetHeaderHash(h))
+	if err != nil {
+		return fmt.Errorf("failed to get block %d: %w", h, err)
+	}
+	attrs := []object.Attribute{
+		*object.NewAttribute(s.cfg.BlockAttribute, strconv.FormatUint(uint64(b.Index), 10)),
+		*object.NewAttribute("Primary", strconv.Itoa(int(b.PrimaryIndex))),
+		*object.NewAttribute("Hash", b.Hash().StringLE()),
+		*object.NewAttribute("PrevHash", b.PrevHash.StringLE()),
+		*object.NewAttribute("BlockTime", strconv.FormatUint(b.Timestamp, 10)),
+		*object.NewAttribute("Timestamp", strconv.FormatInt(time.Now().Unix(), 10)),
+	}
+	id, err := s.put(attrs, func(w io.Writer) error {
+		bw := gio.NewBinWriterFromIO(w)
+		b.EncodeBinary(bw)
+		return bw.Err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload block %d: %w", h, err)
+	}
+	copy(s.buf[pos:], id[:])
+	return nil
+}
+
+// uploadStates uploads state dumps for all heights up to the given one.
+func (s *Service) uploadStates(height uint32) error {
+	interval := s.cfg.StateDumpInterval
+	if interval == 0 {
+		return nil
+	}
+	for h := (s.stateNext + interval - 1) / interval * interval; h >= s.stateNext && h <= height; h += interval {
+		err := s.uploadState(h)
+		if err != nil {
+			return err
+		}
+		s.stateNext = h + 1
+	}
+	s.stateNext = max(s.stateNext, height+1)
+	return nil
+}
+
+// uploadState uploads the dump of all contract storage items for the given
+// height. Dump is a sequence of key-value pairs (both are serialized as
+// var-bytes, key includes contract ID).
+func (s *Service) uploadState(h uint32) error {
+	stateModule := s.chain.GetStateModule()
+	root, err := stateModule.GetStateRoot(h)
+	if err != nil {
+		s.log.Warn("NeoFS BlockUploader service: can't get state root, skipping state dump",
+			zap.Uint32("height", h), zap.Error(err))
+		return nil
+	}
+	attrs := []object.Attribute{
+		*object.NewAttribute(s.cfg.StateAttribute, strconv.FormatUint(uint64(h), 10)),
+		*object.NewAttribute("StateRoot", root.Root.StringLE()),
+		*object.NewAttribute("Timestamp", strconv.FormatInt(time.Now().Unix(), 10)),
+	}
+	_, err = s.put(attrs, func(w io.Writer) error {
+		bw := gio.NewBinWriterFromIO(w)
+		stateModule.SeekStates(root.Root, nil, func(k, v []byte) bool {
+			bw.WriteVarBytes(k)
+			bw.WriteVarBytes(v)
+			return bw.Err == nil
+		})
+		return bw.Err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload state dump %d: %w", h, err)
+	}
+	return nil
+}
+
+// uploadIndexFile uploads index file with OIDs of the uploaded blocks.
+func (s *Service) uploadIndexFile(indexFile uint32) error {
+	attrs := []object.Attribute{
+		*object.NewAttribute(s.cfg.IndexFileAttribute, strconv.FormatUint(uint64(indexFile), 10)),
+		*object.NewAttribute("IndexSize", strconv.FormatUint(uint64(s.cfg.IndexFileSize), 10)),
+		*object.NewAttribute("Timestamp", strconv.FormatInt(time.Now().Unix(), 10)),
+	}
+	_, err := s.put(attrs, func(w io.Writer) error {
+		_, err := w.Write(s.buf)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload index file %d: %w", indexFile, err)
+	}
+	s.log.Info("NeoFS BlockUploader service: uploaded index file", zap.Uint32("index", indexFile))
+	return nil
+}
+
+func (s *Service) search(filters object.SearchFilters) ([]oid.ID, error) {
+	var (
+		ids []oid.ID
+		err error
+	)
+	err = s.retry(func() error {
+		ctx, cancel := context.WithTimeout(s.ctx, s.cfg.Timeout)
+		defer cancel()
+		ids, err = s.store.search(ctx, filters)
+		return err
+	})
+	return ids, err
+}
+
+func (s *Service) put(attrs []object.Attribute, payload func(io.Writer) error) (oid.ID, error) {
+	var (
+		id  oid.ID
+		err error
+	)
+	err = s.retry(func() error {
+		ctx, cancel := context.WithTimeout(s.ctx, s.cfg.Timeout)
+		defer cancel()
+		id, err = s.store.put(ctx, attrs, payload)
+		return err
+	})
+	return id, err
+}
+
+// retry function with exponential backoff.
+func (s *Service) retry(action func() error) error {
+	var (
+		err     error
+		backoff = neofs.InitialBackoff
+		timer   = time.NewTimer(0)
+	)
+	defer func() {
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+	}()
+
+	for i := range neofs.MaxRetries {
+		if err = action(); err == nil {
+			return nil
+		}
+		if i == neofs.MaxRetries-1 {
+			break
+		}
+		timer.Reset(backoff)
+
+		select {
+		case <-timer.C:
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		}
+		backoff *= time.Duration(neofs.BackoffFactor)
+		if backoff > neofs.MaxBackoff {
+			backoff = neofs.MaxBackoff
+		}
+	}
+	return err
+}
@@ -0,0 +1,210 @@
+package blockuploader
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	gio "github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neofs-sdk-go/object"
+	oid "github.com/nspcc-dev/neofs-sdk-go/object/id"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type memObject struct {
+	id      oid.ID
+	attrs   map[string]string
+	payload []byte
+}
+
+// memStore is an in-memory objectStore.
+type memStore struct {
+	lock    sync.Mutex
+	objects []memObject
+}
+
+func (m *memStore) dial(context.Context) error { return nil }
+
+func (m *memStore) search(_ context.Context, filters object.SearchFilters) ([]oid.ID, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var res []oid.ID
+	for _, obj := range m.objects {
+		var match = true
+		for _, f := range filters {
+			if f.Operation() != object.MatchStringEqual || obj.attrs[f.Header()] != f.Value() {
+				match = false
+				break
+			}
+		}
+		if match {
+			res = append(res, obj.id)
+		}
+	}
+	return res, nil
+}
+
+func (m *memStore) put(_ context.Context, attrs []object.Attribute, payload func(io.Writer) error) (oid.ID, error) {
+	buf := new(bytes.Buffer)
+	if err := payload(buf); err != nil {
+		return oid.ID{}, err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var (
+		obj = memObject{attrs: make(map[string]string), payload: buf.Bytes()}
+		n   [4]byte
+	)
+	binary.LittleEndian.PutUint32(n[:], uint32(len(m.objects)))
+	obj.id = sha256.Sum256(n[:])
+	for _, a := range attrs {
+		obj.attrs[a.Key()] = a.Value()
+	}
+	m.objects = append(m.objects, obj)
+	return obj.id, nil
+}
+
+func (m *memStore) close() {}
+
+func (m *memStore) find(key string, value uint32) []memObject {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var res []memObject
+	for _, obj := range m.objects {
+		if obj.attrs[key] == strconv.FormatUint(uint64(value), 10) {
+			res = append(res, obj)
+		}
+	}
+	return res
+}
+
+func TestNew(t *testing.T) {
+	bc, _ := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+		c.Ledger.KeepOnlyLatestState = true
+	})
+	cfg := config.NeoFSBlockUploader{
+		InternalService: config.InternalService{
+			Enabled:      true,
+			UnlockWallet: config.Wallet{Path: "wallet.json"},
+		},
+		ContainerID: "9iVfUg8aDHKjPC4LhQXEkVUM4HDkR7UCXYLs8NQwYfSG",
+		Addresses:   []string{"127.0.0.1"},
+	}
+	_, err := New(bc, config.NeoFSBlockUploader{
+		InternalService: config.InternalService{Enabled: true},
+	}, zaptest.NewLogger(t))
+	require.ErrorContains(t, err, "container ID is not set")
+
+	cfg.StateDumpInterval = 10
+	_, err = New(bc, cfg, zaptest.NewLogger(t))
+	require.ErrorContains(t, err, "state dumps require full MPT history")
+}
+
+func TestService(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	e.GenerateNewBlocks(t, 9)
+
+	var (
+		store = new(memStore)
+		cfg   = config.NeoFSBlockUploader{
+			IndexFileSize:     4,
+			WorkersCount:      3,
+			StateDumpInterval: 3,
+		}
+	)
+	checkUploaded := func(height uint32) {
+		require.Eventually(t, func() bool {
+			return len(store.find("Block", height)) != 0
+		}, 5*time.Second, 10*time.Millisecond)
+		// Let the service finish the iteration.
+		time.Sleep(50 * time.Millisecond)
+
+		for h := range height + 1 {
+			objs := store.find("Block", h)
+			require.Len(t, objs, 1, h)
+			b, err := bc.GetBlock(bc.GetHeaderHash(h))
+			require.NoError(t, err)
+			actual := block.New(false)
+			require.NoError(t, testserdes.DecodeBinary(objs[0].payload, actual))
+			require.Equal(t, b.Hash(), actual.Hash())
+			require.Equal(t, b.Hash().StringLE(), objs[0].attrs["Hash"])
+		}
+		for i := range (height + 1) / cfg.IndexFileSize {
+			objs := store.find("Index", i)
+			require.Len(t, objs, 1, i)
+			require.Equal(t, "4", objs[0].attrs["IndexSize"])
+			var expected []byte
+			for h := i * cfg.IndexFileSize; h < (i+1)*cfg.IndexFileSize; h++ {
+				id := store.find("Block", h)[0].id
+				expected = append(expected, id[:]...)
+			}
+			require.Equal(t, expected, objs[0].payload)
+		}
+		require.Empty(t, store.find("Index", (height+1)/cfg.IndexFileSize))
+		for h := range height + 1 {
+			objs := store.find("State", h)
+			if h%cfg.StateDumpInterval != 0 {
+				require.Empty(t, objs)
+				continue
+			}
+			require.Len(t, objs, 1, h)
+			root, err := bc.GetStateModule().GetStateRoot(h)
+			require.NoError(t, err)
+			require.Equal(t, root.Root.StringLE(), objs[0].attrs["StateRoot"])
+			require.Equal(t, countStates(bc.GetStateModule(), root), countDumpItems(t, objs[0].payload))
+		}
+	}
+
+	s := newService(bc, cfg, zaptest.NewLogger(t), store)
+	s.Start()
+	checkUploaded(9)
+
+	e.GenerateNewBlocks(t, 3)
+	checkUploaded(12)
+	s.Shutdown()
+
+	// Service restart continues uploading from the first missing block.
+	e.GenerateNewBlocks(t, 5)
+	s = newService(bc, cfg, zaptest.NewLogger(t), store)
+	s.Start()
+	t.Cleanup(s.Shutdown)
+	checkUploaded(17)
+}
+
+func countStates(sm core.StateRoot, root *state.MPTRoot) int {
+	var cnt int
+	sm.SeekStates(root.Root, nil, func(_, _ []byte) bool {
+		cnt++
+		return true
+	})
+	return cnt
+}
+
+func countDumpItems(t *testing.T, payload []byte) int {
+	var (
+		cnt int
+		r   = gio.NewBinReaderFromBuf(payload)
+	)
+	for r.Len() != 0 {
+		r.ReadVarBytes()
+		r.ReadVarBytes()
+		require.NoError(t, r.Err)
+		cnt++
+	}
+	return cnt
+}
@@ -0,0 +1,151 @@
+/*
+Package extsigner provides an interface for external signers that keep node
+keys outside of the node (HSMs, remote signing services) and an
+implementation of it working via a simple HTTP+JSON protocol.
+
+The remote signer receives POST requests with the following JSON body:
+
+	{"publicKey": "<hex-encoded compressed public key>", "hash": "<hex-encoded 32-byte hash>"}
+
+and must respond with a 200 status and the following JSON body:
+
+	{"signature": "<hex-encoded 64-byte signature>"}
+
+Any other status is treated as an error. The hash to be signed is the
+network-specific hash of the signed item (see hash.NetSha256), the signature
+is a standard Neo ECDSA (secp256r1, SHA256) signature of it.
+*/
+package extsigner
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Signer is an external signer. Implementations must be safe for concurrent
+// use. PKCS#11 or any other HSM backend can be used with services by
+// implementing this interface.
+type Signer interface {
+	// PublicKeys returns the list of keys that can be used for signing.
+	PublicKeys() keys.PublicKeys
+	// SignHash signs the given hash with the private key corresponding to
+	// the given public key and returns a 64-byte signature.
+	SignHash(pub *keys.PublicKey, h util.Uint256) ([]byte, error)
+}
+
+// defaultTimeout is the default timeout for a single signing request.
+const defaultTimeout = 5 * time.Second
+
+// maxResponseSize is the maximum size of the remote signer response.
+const maxResponseSize = 4096
+
+// ErrInvalidSignature is returned when the remote signer returns a signature
+// that can't be verified with the requested key.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// Remote is a Signer using the remote HTTP signing service.
+type Remote struct {
+	endpoint string
+	keys     keys.PublicKeys
+	timeout  time.Duration
+	client   *http.Client
+}
+
+type (
+	signRequest struct {
+		PublicKey string `json:"publicKey"`
+		Hash      string `json:"hash"`
+	}
+	signResponse struct {
+		Signature string `json:"signature"`
+	}
+)
+
+// NewRemote creates a new Remote signer from the given configuration.
+func NewRemote(cfg config.ExternalSigner) (*Remote, error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.New("no external signer endpoint")
+	}
+	if len(cfg.PublicKeys) == 0 {
+		return nil, errors.New("no external signer public keys")
+	}
+	pubs := make(keys.PublicKeys, 0, len(cfg.PublicKeys))
+	for i, s := range cfg.PublicKeys {
+		pub, err := keys.NewPublicKeyFromString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid external signer key #%d: %w", i, err)
+		}
+		pubs = append(pubs, pub)
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &Remote{
+		endpoint: cfg.Endpoint,
+		keys:     pubs,
+		timeout:  timeout,
+		client:   &http.Client{},
+	}, nil
+}
+
+// PublicKeys implements the Signer interface.
+func (r *Remote) PublicKeys() keys.PublicKeys {
+	return r.keys
+}
+
+// SignHash implements the Signer interface. The whole request (including
+// reading the response) is limited by the configured timeout. The signature
+// returned by the remote service is checked before returning.
+func (r *Remote) SignHash(pub *keys.PublicKey, h util.Uint256) ([]byte, error) {
+	body, err := json.Marshal(signRequest{
+		PublicKey: hex.EncodeToString(pub.Bytes()),
+		Hash:      hex.EncodeToString(h.BytesBE()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("external signer request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read external signer response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("external signer returned %s: %s", resp.Status, bytes.TrimSpace(data))
+	}
+	var res signResponse
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, fmt.Errorf("invalid external signer response: %w", err)
+	}
+	sig, err := hex.DecodeString(res.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid external signer response: %w", err)
+	}
+	if len(sig) != keys.SignatureLen || !pub.Verify(sig, h.BytesBE()) {
+		return nil, ErrInvalidSignature
+	}
+	return sig, nil
+}
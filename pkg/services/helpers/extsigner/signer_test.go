@@ -0,0 +1,114 @@
+package extsigner
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, priv *keys.PrivateKey, bad *bool) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req signRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.PublicKey != priv.PublicKey().StringCompressed() {
+			http.Error(w, "unknown key", http.StatusNotFound)
+			return
+		}
+		h, err := hex.DecodeString(req.Hash)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		u, err := util.Uint256DecodeBytesBE(h)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if *bad {
+			u = random.Uint256()
+		}
+		_ = json.NewEncoder(w).Encode(signResponse{Signature: hex.EncodeToString(priv.SignHash(u))})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRemote(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	other, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	var bad bool
+	srv := newTestServer(t, priv, &bad)
+
+	_, err = NewRemote(config.ExternalSigner{})
+	require.Error(t, err)
+	_, err = NewRemote(config.ExternalSigner{Endpoint: srv.URL})
+	require.Error(t, err)
+	_, err = NewRemote(config.ExternalSigner{Endpoint: srv.URL, PublicKeys: []string{"bad"}})
+	require.Error(t, err)
+
+	r, err := NewRemote(config.ExternalSigner{
+		Endpoint:   srv.URL,
+		PublicKeys: []string{priv.PublicKey().StringCompressed(), other.PublicKey().StringCompressed()},
+	})
+	require.NoError(t, err)
+	require.Equal(t, keys.PublicKeys{priv.PublicKey(), other.PublicKey()}, r.PublicKeys())
+
+	h := random.Uint256()
+	sig, err := r.SignHash(priv.PublicKey(), h)
+	require.NoError(t, err)
+	require.True(t, priv.PublicKey().Verify(sig, h.BytesBE()))
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := r.SignHash(other.PublicKey(), h)
+		require.ErrorContains(t, err, "unknown key")
+	})
+	t.Run("bad signature", func(t *testing.T) {
+		bad = true
+		t.Cleanup(func() { bad = false })
+		_, err := r.SignHash(priv.PublicKey(), h)
+		require.ErrorIs(t, err, ErrInvalidSignature)
+	})
+}
+
+func TestRemoteTimeout(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(release) })
+
+	r, err := NewRemote(config.ExternalSigner{
+		Endpoint:   srv.URL,
+		PublicKeys: []string{priv.PublicKey().StringCompressed()},
+		Timeout:    50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = r.SignHash(priv.PublicKey(), random.Uint256())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), time.Second)
+}
@@ -10,12 +10,16 @@ const (
 	DefaultTimeout = 10 * time.Minute
 	// DefaultDownloaderWorkersCount is the default number of workers downloading blocks.
 	DefaultDownloaderWorkersCount = 500
+	// DefaultUploaderWorkersCount is the default number of workers uploading blocks.
+	DefaultUploaderWorkersCount = 20
 	// DefaultIndexFileSize is the default size of the index file.
 	DefaultIndexFileSize = 128000
 	// DefaultBlockAttribute is the default attribute name for block objects.
 	DefaultBlockAttribute = "Block"
 	// DefaultIndexFileAttribute is the default attribute name for index file objects.
 	DefaultIndexFileAttribute = "Index"
+	// DefaultStateAttribute is the default attribute name for state dump objects.
+	DefaultStateAttribute = "State"
 
 	// DefaultSearchBatchSize is a number of objects to search in a batch. We need to
 	// search with EQ filter to avoid partially-completed SEARCH responses. If EQ search
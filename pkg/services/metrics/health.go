@@ -0,0 +1,157 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+)
+
+type (
+	// Ledger is the interface to the chain used by HealthChecker.
+	Ledger interface {
+		BlockHeight() uint32
+		HeaderHeight() uint32
+	}
+
+	// StateRoot is the interface to the state root module used by
+	// HealthChecker.
+	StateRoot interface {
+		CurrentLocalHeight() uint32
+		CurrentValidatedHeight() uint32
+	}
+
+	// Network is the interface to the P2P server used by HealthChecker.
+	Network interface {
+		HandshakedPeersCount() int
+		IsInSync() bool
+	}
+
+	// HealthChecker reports node liveness and readiness based on its chain,
+	// state root module and network state compared to the configured
+	// thresholds.
+	HealthChecker struct {
+		cfg   config.HealthCheck
+		chain Ledger
+		sr    StateRoot
+		net   Network
+
+		lock       sync.Mutex
+		lastHeight uint32
+		lastChange time.Time
+	}
+
+	// HealthStatus is the result of health or readiness check returned by
+	// /health and /ready endpoints in JSON.
+	HealthStatus struct {
+		// OK is true if the check passed.
+		OK                   bool     `json:"ok"`
+		BlockHeight          uint32   `json:"blockheight"`
+		HeaderHeight         uint32   `json:"headerheight"`
+		Peers                int      `json:"peers"`
+		Synchronized         bool     `json:"synchronized"`
+		LastBlockAge         int64    `json:"lastblockage"` // Milliseconds since the last block was accepted.
+		StateHeight          uint32   `json:"stateheight"`
+		ValidatedStateHeight uint32   `json:"validatedstateheight"`
+		Failures             []string `json:"failures,omitempty"`
+	}
+)
+
+// NewHealthChecker creates a HealthChecker with the given thresholds.
+func NewHealthChecker(cfg config.HealthCheck, chain Ledger, sr StateRoot, net Network) *HealthChecker {
+	return &HealthChecker{
+		cfg:        cfg,
+		chain:      chain,
+		sr:         sr,
+		net:        net,
+		lastHeight: chain.BlockHeight(),
+		lastChange: time.Now(),
+	}
+}
+
+// status returns the current node state without any checks performed.
+func (h *HealthChecker) status() HealthStatus {
+	var (
+		height = h.chain.BlockHeight()
+		now    = time.Now()
+	)
+	h.lock.Lock()
+	if height != h.lastHeight {
+		h.lastHeight, h.lastChange = height, now
+	}
+	age := now.Sub(h.lastChange)
+	h.lock.Unlock()
+	return HealthStatus{
+		OK:                   true,
+		BlockHeight:          height,
+		HeaderHeight:         h.chain.HeaderHeight(),
+		Peers:                h.net.HandshakedPeersCount(),
+		Synchronized:         h.net.IsInSync(),
+		LastBlockAge:         age.Milliseconds(),
+		StateHeight:          h.sr.CurrentLocalHeight(),
+		ValidatedStateHeight: h.sr.CurrentValidatedHeight(),
+	}
+}
+
+func (s *HealthStatus) fail(format string, args ...any) {
+	s.OK = false
+	s.Failures = append(s.Failures, fmt.Sprintf(format, args...))
+}
+
+// Health performs liveness check, the node is considered to be alive unless
+// its chain doesn't grow for longer than MaxBlockAge.
+func (h *HealthChecker) Health() HealthStatus {
+	s := h.status()
+	if h.cfg.MaxBlockAge > 0 && time.Duration(s.LastBlockAge)*time.Millisecond > h.cfg.MaxBlockAge {
+		s.fail("no new blocks for %s", time.Duration(s.LastBlockAge)*time.Millisecond)
+	}
+	return s
+}
+
+// Ready performs readiness check, the node is ready to serve clients if it's
+// alive, synchronized and conforms to header lag, peers number and state root
+// lag thresholds.
+func (h *HealthChecker) Ready() HealthStatus {
+	s := h.Health()
+	if !s.Synchronized {
+		s.fail("not synchronized")
+	}
+	if h.cfg.MaxHeaderLag > 0 && s.HeaderHeight > s.BlockHeight && s.HeaderHeight-s.BlockHeight > h.cfg.MaxHeaderLag {
+		s.fail("block height %d is behind header height %d", s.BlockHeight, s.HeaderHeight)
+	}
+	if s.Peers < h.cfg.MinPeers {
+		s.fail("%d peers connected, %d required", s.Peers, h.cfg.MinPeers)
+	}
+	if h.cfg.MaxStateRootLag > 0 && s.ValidatedStateHeight != 0 &&
+		s.StateHeight > s.ValidatedStateHeight && s.StateHeight-s.ValidatedStateHeight > h.cfg.MaxStateRootLag {
+		s.fail("validated state height %d is behind local state height %d", s.ValidatedStateHeight, s.StateHeight)
+	}
+	return s
+}
+
+// healthHandler returns an HTTP handler responding with the result of the
+// given check, 503 status code is used for failed checks and when the checker
+// is not set yet.
+func healthHandler(h func() *HealthChecker, check func(*HealthChecker) HealthStatus) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		var (
+			hc   = h()
+			s    HealthStatus
+			code = http.StatusOK
+		)
+		if hc == nil {
+			s.fail("node is not initialized")
+		} else {
+			s = check(hc)
+		}
+		if !s.OK {
+			code = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(s)
+	}
+}
@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type testNode struct {
+	height, headerHeight     uint32
+	stateHeight, validatedSt uint32
+	peers                    int
+	synced                   bool
+}
+
+func (n *testNode) BlockHeight() uint32            { return n.height }
+func (n *testNode) HeaderHeight() uint32           { return n.headerHeight }
+func (n *testNode) CurrentLocalHeight() uint32     { return n.stateHeight }
+func (n *testNode) CurrentValidatedHeight() uint32 { return n.validatedSt }
+func (n *testNode) HandshakedPeersCount() int      { return n.peers }
+func (n *testNode) IsInSync() bool                 { return n.synced }
+
+func TestHealthChecker(t *testing.T) {
+	n := &testNode{height: 10, headerHeight: 10, stateHeight: 10, validatedSt: 9, peers: 3, synced: true}
+	h := NewHealthChecker(config.HealthCheck{
+		MaxBlockAge:     time.Hour,
+		MaxHeaderLag:    5,
+		MinPeers:        2,
+		MaxStateRootLag: 2,
+	}, n, n, n)
+
+	s := h.Ready()
+	require.True(t, s.OK, s.Failures)
+	require.Equal(t, HealthStatus{
+		OK:                   true,
+		BlockHeight:          10,
+		HeaderHeight:         10,
+		Peers:                3,
+		Synchronized:         true,
+		LastBlockAge:         s.LastBlockAge,
+		StateHeight:          10,
+		ValidatedStateHeight: 9,
+	}, s)
+
+	n.headerHeight = 20
+	n.peers = 1
+	n.validatedSt = 5
+	n.synced = false
+	s = h.Ready()
+	require.False(t, s.OK)
+	require.Len(t, s.Failures, 4)
+	require.True(t, h.Health().OK)
+
+	// Validation is not enabled.
+	n.validatedSt = 0
+	n.headerHeight, n.peers, n.synced = 10, 2, true
+	require.True(t, h.Ready().OK)
+
+	h.cfg.MaxBlockAge = time.Millisecond
+	h.lastChange = time.Now().Add(-time.Second)
+	require.False(t, h.Health().OK)
+	require.False(t, h.Ready().OK)
+	n.height++
+	require.True(t, h.Health().OK)
+}
+
+func TestHealthEndpoints(t *testing.T) {
+	n := &testNode{height: 10, headerHeight: 10, peers: 1}
+	srv := NewPrometheusService(config.BasicService{Addresses: []string{"localhost:0"}}, zaptest.NewLogger(t))
+	check := func(path string, code int) HealthStatus {
+		rec := httptest.NewRecorder()
+		srv.http[0].Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, code, rec.Code)
+		var s HealthStatus
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &s))
+		return s
+	}
+	check("/health", http.StatusServiceUnavailable)
+
+	srv.SetHealthChecker(NewHealthChecker(config.HealthCheck{}, n, n, n))
+	require.True(t, check("/health", http.StatusOK).OK)
+	s := check("/ready", http.StatusServiceUnavailable)
+	require.Equal(t, []string{"not synchronized"}, s.Failures)
+	n.synced = true
+	require.True(t, check("/ready", http.StatusOK).OK)
+}
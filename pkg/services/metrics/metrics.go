@@ -19,6 +19,7 @@ type Service struct {
 	log         *zap.Logger
 	serviceType string
 	started     atomic.Bool
+	health      atomic.Pointer[HealthChecker]
 }
 
 // NewService configures logger and returns new service instance.
@@ -60,6 +61,12 @@ func (ms *Service) Start() error {
 	return nil
 }
 
+// SetHealthChecker sets the checker used by /health and /ready endpoints of
+// Prometheus service, they report failures until it's set.
+func (ms *Service) SetHealthChecker(h *HealthChecker) {
+	ms.health.Store(h)
+}
+
 // ShutDown stops the service.
 func (ms *Service) ShutDown() {
 	if !ms.config.Enabled {
@@ -12,18 +12,29 @@ import (
 type PrometheusService Service
 
 // NewPrometheusService creates a new service for gathering prometheus metrics.
+// Besides metrics, it serves /health and /ready endpoints (see
+// SetHealthChecker).
 func NewPrometheusService(cfg config.BasicService, log *zap.Logger) *Service {
 	if log == nil {
 		return nil
 	}
 
-	addrs := cfg.Addresses
-	srvs := make([]*http.Server, len(addrs))
+	var (
+		addrs = cfg.Addresses
+		srvs  = make([]*http.Server, len(addrs))
+		mux   = http.NewServeMux()
+		ms    *Service
+	)
+	getHealth := func() *HealthChecker { return ms.health.Load() }
+	mux.Handle("/", promhttp.Handler()) // share metrics between multiple prometheus handlers
+	mux.Handle("/health", healthHandler(getHealth, (*HealthChecker).Health))
+	mux.Handle("/ready", healthHandler(getHealth, (*HealthChecker).Ready))
 	for i, addr := range addrs {
 		srvs[i] = &http.Server{
 			Addr:    addr,
-			Handler: promhttp.Handler(), // share metrics between multiple prometheus handlers
+			Handler: mux,
 		}
 	}
-	return NewService("Prometheus", srvs, cfg, log)
+	ms = NewService("Prometheus", srvs, cfg, log)
+	return ms
 }
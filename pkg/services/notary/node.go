@@ -3,6 +3,8 @@ package notary
 import (
 	"slices"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -15,6 +17,10 @@ func (n *Notary) UpdateNotaryNodes(notaryNodes keys.PublicKeys) {
 	n.accMtx.Lock()
 	defer n.accMtx.Unlock()
 
+	if n.signer != nil {
+		n.updateSignerKey(notaryNodes)
+		return
+	}
 	if n.currAccount != nil && slices.ContainsFunc(notaryNodes, n.currAccount.PublicKey().Equal) {
 		return
 	}
@@ -39,14 +45,59 @@ func (n *Notary) UpdateNotaryNodes(notaryNodes keys.PublicKeys) {
 
 	n.currAccount = acc
 	if acc == nil {
-		n.reqMtx.Lock()
-		n.requests = make(map[util.Uint256]*request)
-		n.reqMtx.Unlock()
+		n.dropRequests()
+	}
+}
+
+// updateSignerKey picks the external signer key from the list of designated
+// notary nodes. It must be called with accMtx held.
+func (n *Notary) updateSignerKey(notaryNodes keys.PublicKeys) {
+	if n.currSignerKey != nil && slices.ContainsFunc(notaryNodes, n.currSignerKey.Equal) {
+		return
+	}
+
+	var available = n.signer.PublicKeys()
+
+	n.currSignerKey = nil
+	for _, node := range notaryNodes {
+		if slices.ContainsFunc(available, node.Equal) {
+			n.currSignerKey = node
+			break
+		}
+	}
+	if n.currSignerKey == nil {
+		n.dropRequests()
 	}
 }
 
-func (n *Notary) getAccount() *wallet.Account {
+func (n *Notary) dropRequests() {
+	n.reqMtx.Lock()
+	n.requests = make(map[util.Uint256]*request)
+	n.reqMtx.Unlock()
+}
+
+// signFunc produces notary signature for the given transaction.
+type signFunc func(tx *transaction.Transaction) ([]byte, error)
+
+// getSignFunc returns a function signing transactions with the current notary
+// key or nil if this node is not a designated notary node.
+func (n *Notary) getSignFunc() signFunc {
 	n.accMtx.RLock()
 	defer n.accMtx.RUnlock()
-	return n.currAccount
+	if n.signer != nil {
+		var pub = n.currSignerKey
+		if pub == nil {
+			return nil
+		}
+		return func(tx *transaction.Transaction) ([]byte, error) {
+			return n.signer.SignHash(pub, hash.NetSha256(uint32(n.Network), tx))
+		}
+	}
+	var acc = n.currAccount
+	if acc == nil {
+		return nil
+	}
+	return func(tx *transaction.Transaction) ([]byte, error) {
+		return acc.SignHashable(n.Network, tx), nil
+	}
 }
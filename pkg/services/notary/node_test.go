@@ -1,13 +1,17 @@
 package notary
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/fakechain"
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
@@ -74,3 +78,121 @@ func TestUpdateNotaryNodes(t *testing.T) {
 		require.Nil(t, ntr.currAccount)
 	})
 }
+
+// testSigner is an external signer holding private keys in memory.
+type testSigner []*keys.PrivateKey
+
+func (s testSigner) PublicKeys() keys.PublicKeys {
+	var res keys.PublicKeys
+	for _, k := range s {
+		res = append(res, k.PublicKey())
+	}
+	return res
+}
+
+func (s testSigner) SignHash(pub *keys.PublicKey, h util.Uint256) ([]byte, error) {
+	for _, k := range s {
+		if k.PublicKey().Equal(pub) {
+			return k.SignHash(h), nil
+		}
+	}
+	return nil, errors.New("unknown key")
+}
+
+func TestUpdateNotaryNodesExternalSigner(t *testing.T) {
+	k1, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	k2, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	randomKey, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	cfg := Config{
+		MainCfg: config.P2PNotary{Enabled: true},
+		Chain:   fakechain.NewFakeChain(),
+		Log:     zaptest.NewLogger(t),
+		Signer:  testSigner{k1, k2},
+	}
+	ntr, err := NewNotary(cfg, netmode.UnitTestNet, mempool.New(10, 1, true, nil), nil)
+	require.NoError(t, err)
+	require.Nil(t, ntr.wallet)
+	require.False(t, ntr.IsAuthorized())
+
+	ntr.UpdateNotaryNodes(keys.PublicKeys{randomKey.PublicKey(), k2.PublicKey()})
+	require.True(t, ntr.IsAuthorized())
+	require.Equal(t, k2.PublicKey(), ntr.currSignerKey)
+
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 1)
+	sig, err := ntr.getSignFunc()(tx)
+	require.NoError(t, err)
+	require.True(t, k2.PublicKey().VerifyHashable(sig, uint32(netmode.UnitTestNet), tx))
+
+	t.Run("key is already set", func(t *testing.T) {
+		ntr.UpdateNotaryNodes(keys.PublicKeys{k1.PublicKey(), k2.PublicKey()})
+		require.Equal(t, k2.PublicKey(), ntr.currSignerKey)
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		ntr.UpdateNotaryNodes(keys.PublicKeys{randomKey.PublicKey()})
+		require.Nil(t, ntr.currSignerKey)
+		require.False(t, ntr.IsAuthorized())
+	})
+	t.Run("invalid remote config", func(t *testing.T) {
+		cfg.Signer = nil
+		cfg.MainCfg.ExternalSigner = config.ExternalSigner{Endpoint: "http://localhost:0"}
+		_, err := NewNotary(cfg, netmode.UnitTestNet, mempool.New(10, 1, true, nil), nil)
+		require.Error(t, err)
+	})
+}
+
+// lockCheckingSigner is an external signer ensuring that notary requests are
+// not locked while signing.
+type lockCheckingSigner struct {
+	testSigner
+	ntr    *Notary
+	locked bool
+}
+
+func (s *lockCheckingSigner) SignHash(pub *keys.PublicKey, h util.Uint256) ([]byte, error) {
+	if s.ntr.reqMtx.TryLock() {
+		s.ntr.reqMtx.Unlock()
+	} else {
+		s.locked = true
+	}
+	return s.testSigner.SignHash(pub, h)
+}
+
+func TestPostPersistSignsUnlocked(t *testing.T) {
+	k, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	bc := fakechain.NewFakeChain()
+	bc.NotaryContractScriptHash = util.Uint160{2}
+	signer := &lockCheckingSigner{testSigner: testSigner{k}}
+	ntr, err := NewNotary(Config{
+		MainCfg: config.P2PNotary{Enabled: true},
+		Chain:   bc,
+		Log:     zaptest.NewLogger(t),
+		Signer:  signer,
+	}, netmode.UnitTestNet, mempool.New(10, 1, true, nil), nil)
+	require.NoError(t, err)
+	signer.ntr = ntr
+	ntr.UpdateNotaryNodes(keys.PublicKeys{k.PublicKey()})
+	ntr.started.Store(true)
+
+	main := transaction.New([]byte{byte(opcode.PUSH1)}, 1)
+	main.Signers = []transaction.Signer{{Account: util.Uint160{1}}, {Account: bc.GetNotaryContractScriptHash()}}
+	main.Scripts = make([]transaction.Witness, 2)
+	ntr.requests[main.Hash()] = &request{
+		main:              main,
+		minNotValidBefore: bc.BlockHeight() + 10,
+		witnessInfo:       []witnessInfo{{}},
+	}
+
+	ntr.PostPersist()
+	require.False(t, signer.locked)
+	require.Len(t, ntr.newTxs, 1)
+	completed := <-ntr.newTxs
+	require.Equal(t, main.Hash(), completed.mainHash)
+	require.NotEmpty(t, completed.tx.Scripts[1].InvocationScript)
+	// Request transaction is not changed.
+	require.Empty(t, main.Scripts[1].InvocationScript)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/services/helpers/extsigner"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
@@ -62,6 +63,10 @@ type (
 		accMtx      sync.RWMutex
 		currAccount *wallet.Account
 		wallet      *wallet.Wallet
+		// currSignerKey is the current notary key of the external signer,
+		// used instead of currAccount if signer is set.
+		currSignerKey *keys.PublicKey
+		signer        extsigner.Signer
 
 		mp *mempool.Pool
 		// requests channel
@@ -81,6 +86,10 @@ type (
 		MainCfg config.P2PNotary
 		Chain   Ledger
 		Log     *zap.Logger
+		// Signer is an optional external signer to be used instead of the
+		// wallet specified in MainCfg. If not set, but ExternalSigner is
+		// configured in MainCfg, the remote signer is created.
+		Signer extsigner.Signer
 	}
 )
 
@@ -131,17 +140,30 @@ func (r request) isMainCompleted() bool {
 
 // NewNotary returns a new Notary module.
 func NewNotary(cfg Config, net netmode.Magic, mp *mempool.Pool, onTransaction func(tx *transaction.Transaction) error) (*Notary, error) {
-	w := cfg.MainCfg.UnlockWallet
-	wall, err := wallet.NewWalletFromFile(w.Path)
-	if err != nil {
-		return nil, err
+	var (
+		signer = cfg.Signer
+		wall   *wallet.Wallet
+		err    error
+	)
+	if signer == nil && cfg.MainCfg.ExternalSigner.IsEnabled() {
+		signer, err = extsigner.NewRemote(cfg.MainCfg.ExternalSigner)
+		if err != nil {
+			return nil, err
+		}
 	}
+	if signer == nil {
+		w := cfg.MainCfg.UnlockWallet
+		wall, err = wallet.NewWalletFromFile(w.Path)
+		if err != nil {
+			return nil, err
+		}
 
-	var haveAccount = slices.ContainsFunc(wall.Accounts, func(acc *wallet.Account) bool {
-		return acc.Decrypt(w.Password, wall.Scrypt) == nil
-	})
-	if !haveAccount {
-		return nil, errors.New("no wallet account could be unlocked")
+		var haveAccount = slices.ContainsFunc(wall.Accounts, func(acc *wallet.Account) bool {
+			return acc.Decrypt(w.Password, wall.Scrypt) == nil
+		})
+		if !haveAccount {
+			return nil, errors.New("no wallet account could be unlocked")
+		}
 	}
 
 	return &Notary{
@@ -149,6 +171,7 @@ func NewNotary(cfg Config, net netmode.Magic, mp *mempool.Pool, onTransaction fu
 		Config:        cfg,
 		Network:       net,
 		wallet:        wall,
+		signer:        signer,
 		onTransaction: onTransaction,
 		newTxs:        make(chan txHashPair, defaultTxChannelCapacity),
 		mp:            mp,
@@ -223,15 +246,18 @@ func (n *Notary) Shutdown() {
 	n.Config.Log.Info("stopping notary service")
 	close(n.stopCh)
 	<-n.done
-	n.wallet.Close()
+	if n.wallet != nil {
+		n.wallet.Close()
+	}
 	_ = n.Config.Log.Sync()
 }
 
 // IsAuthorized returns whether Notary service currently is authorized to collect
 // signatures. It returnes true iff designated Notary node's account provided to
-// the Notary service in decrypted state.
+// the Notary service in decrypted state or the external signer has the key
+// of designated Notary node.
 func (n *Notary) IsAuthorized() bool {
-	return n.getAccount() != nil
+	return n.getSignFunc() != nil
 }
 
 // OnNewRequest is a callback method which is called after a new notary request is added to the notary request pool.
@@ -239,8 +265,8 @@ func (n *Notary) OnNewRequest(payload *payload.P2PNotaryRequest) {
 	if !n.started.Load() {
 		return
 	}
-	acc := n.getAccount()
-	if acc == nil {
+	sign := n.getSignFunc()
+	if sign == nil {
 		return
 	}
 
@@ -253,6 +279,19 @@ func (n *Notary) OnNewRequest(payload *payload.P2PNotaryRequest) {
 			zap.String("fallback hash", payload.FallbackTransaction.Hash().StringLE()),
 			zap.String("verification error", validationErr.Error()))
 	}
+	// Completed main transaction is finalized after reqMtx is released
+	// since signing may involve a remote signer.
+	var completed *transaction.Transaction
+	defer func() {
+		if completed == nil {
+			return
+		}
+		if err := n.finalize(sign, completed, payload.MainTransaction.Hash()); err != nil {
+			n.Config.Log.Error("failed to finalize main transaction",
+				zap.String("hash", completed.Hash().StringLE()),
+				zap.Error(err))
+		}
+	}()
 	n.reqMtx.Lock()
 	defer n.reqMtx.Unlock()
 	r, exists := n.requests[payload.MainTransaction.Hash()]
@@ -332,18 +371,14 @@ func (n *Notary) OnNewRequest(payload *payload.P2PNotaryRequest) {
 		}
 	}
 	if r.isMainCompleted() && r.minNotValidBefore > n.Config.Chain.BlockHeight() {
-		if err := n.finalize(acc, r.main, payload.MainTransaction.Hash()); err != nil {
-			n.Config.Log.Error("failed to finalize main transaction",
-				zap.String("hash", r.main.Hash().StringLE()),
-				zap.Error(err))
-		}
+		completed = r.main.Copy()
 	}
 }
 
 // OnRequestRemoval is a callback which is called after fallback transaction is removed
 // from the notary payload pool due to expiration, main tx appliance or any other reason.
 func (n *Notary) OnRequestRemoval(pld *payload.P2PNotaryRequest) {
-	if !n.started.Load() || n.getAccount() == nil {
+	if !n.started.Load() || n.getSignFunc() == nil {
 		return
 	}
 
@@ -370,36 +405,52 @@ func (n *Notary) PostPersist() {
 	if !n.started.Load() {
 		return
 	}
-	acc := n.getAccount()
-	if acc == nil {
+	sign := n.getSignFunc()
+	if sign == nil {
 		return
 	}
 
+	// Signing may involve a remote signer, so transactions are collected
+	// under the lock and finalized after it's released.
+	var (
+		mains     []txHashPair
+		fallbacks []txHashPair
+	)
 	n.reqMtx.Lock()
-	defer n.reqMtx.Unlock()
 	currHeight := n.Config.Chain.BlockHeight()
 	for h, r := range n.requests {
 		if !r.isSent && r.isMainCompleted() && r.minNotValidBefore > currHeight {
-			if err := n.finalize(acc, r.main, h); err != nil {
-				n.Config.Log.Error("failed to finalize main transaction", zap.Error(err))
-			}
+			mains = append(mains, txHashPair{r.main.Copy(), h})
 			continue
 		}
 		if r.minNotValidBefore <= currHeight { // then at least one of the fallbacks can already be sent.
 			for _, fb := range r.fallbacks {
 				if nvb := fb.GetAttributes(transaction.NotValidBeforeT)[0].Value.(*transaction.NotValidBefore).Height; nvb <= currHeight {
-					// Ignore the error, wait for the next block to resend them
-					_ = n.finalize(acc, fb, h)
+					fallbacks = append(fallbacks, txHashPair{fb.Copy(), h})
 				}
 			}
 		}
 	}
+	n.reqMtx.Unlock()
+	for _, p := range mains {
+		if err := n.finalize(sign, p.tx, p.mainHash); err != nil {
+			n.Config.Log.Error("failed to finalize main transaction", zap.Error(err))
+		}
+	}
+	for _, p := range fallbacks {
+		// Ignore the error, wait for the next block to resend them
+		_ = n.finalize(sign, p.tx, p.mainHash)
+	}
 }
 
 // finalize adds missing Notary witnesses to the transaction (main or fallback) and pushes it to the network.
-func (n *Notary) finalize(acc *wallet.Account, tx *transaction.Transaction, h util.Uint256) error {
+func (n *Notary) finalize(sign signFunc, tx *transaction.Transaction, h util.Uint256) error {
+	sig, err := sign(tx)
+	if err != nil {
+		return fmt.Errorf("failed to sign transaction: %w", err)
+	}
 	notaryWitness := transaction.Witness{
-		InvocationScript:   append([]byte{byte(opcode.PUSHDATA1), keys.SignatureLen}, acc.SignHashable(n.Network, tx)...),
+		InvocationScript:   append([]byte{byte(opcode.PUSHDATA1), keys.SignatureLen}, sig...),
 		VerificationScript: []byte{},
 	}
 	for i, signer := range tx.Signers {
@@ -515,7 +566,7 @@ func (n *Notary) verifyIncompleteWitnesses(tx *transaction.Transaction, nKeysExp
 			}
 			continue
 		}
-		if !tx.Signers[i].Account.Equals(hash.Hash160(w.VerificationScript)) { // https://github.com/nspcc-dev/neo-go/pull/1658#discussion_r564265987
+		if !tx.Signers[i].Account.ConstantTimeEquals(hash.Hash160(w.VerificationScript)) { // https://github.com/nspcc-dev/neo-go/pull/1658#discussion_r564265987
 			return nil, fmt.Errorf("transaction should have valid verification script for signer #%d", i)
 		}
 		// Each verification script is allowed to have either one signature or zero signatures. If signature is provided, then need to verify it.
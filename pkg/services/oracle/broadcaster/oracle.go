@@ -1,14 +1,13 @@
 package broadcaster
 
 import (
-	"encoding/base64"
-	"encoding/binary"
 	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/oracle"
 	"github.com/nspcc-dev/neo-go/pkg/services/helpers/rpcbroadcaster"
 	"go.uber.org/zap"
 )
@@ -41,23 +40,11 @@ func New(cfg config.OracleConfiguration, log *zap.Logger) *OracleBroadcaster {
 
 // SendResponse implements interfaces.Broadcaster.
 func (r *OracleBroadcaster) SendResponse(priv *keys.PrivateKey, resp *transaction.OracleResponse, txSig []byte) {
-	pub := priv.PublicKey()
-	data := GetMessage(pub.Bytes(), resp.ID, txSig)
-	msgSig := priv.Sign(data)
-	params := []any{
-		base64.StdEncoding.EncodeToString(pub.Bytes()),
-		resp.ID,
-		base64.StdEncoding.EncodeToString(txSig),
-		base64.StdEncoding.EncodeToString(msgSig),
-	}
-	r.SendParams(params)
+	r.SendParams(oracle.NewResponseParams(priv, resp.ID, txSig))
 }
 
-// GetMessage returns data which is signed upon sending response by RPC.
+// GetMessage returns data which is signed upon sending response by RPC, it's
+// the same as [oracle.NewResponseMessage].
 func GetMessage(pubBytes []byte, reqID uint64, txSig []byte) []byte {
-	data := make([]byte, len(pubBytes)+8+len(txSig))
-	copy(data, pubBytes)
-	binary.LittleEndian.PutUint64(data[len(pubBytes):], uint64(reqID))
-	copy(data[len(pubBytes)+8:], txSig)
-	return data
+	return oracle.NewResponseMessage(pubBytes, reqID, txSig)
 }
@@ -67,6 +67,10 @@ type (
 		responses map[uint64]*incompleteTx
 		// removed contains ids of requests which won't be processed further due to expiration.
 		removed map[uint64]bool
+		// policies are compiled response policies from the configuration.
+		policies []responsePolicy
+		// protocols are handlers for additional URL schemes.
+		protocols map[string]*protocol
 
 		wallet *wallet.Wallet
 	}
@@ -146,6 +150,12 @@ func NewOracle(cfg Config) (*Oracle, error) {
 	}
 
 	var err error
+	if o.policies, err = newPolicies(o.MainCfg.Policies); err != nil {
+		return nil, err
+	}
+	if o.protocols, err = newProtocols(o.MainCfg.Protocols); err != nil {
+		return nil, err
+	}
 	w := cfg.MainCfg.UnlockWallet
 	if o.wallet, err = wallet.NewWalletFromFile(w.Path); err != nil {
 		return nil, err
@@ -0,0 +1,91 @@
+package oracle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+)
+
+// responsePolicy is a compiled config.OraclePolicy.
+type responsePolicy struct {
+	config.OraclePolicy
+	pattern *regexp.Regexp
+}
+
+// emptyFilterResult is the result of filter that selects nothing.
+var emptyFilterResult = []byte("[]")
+
+// newPolicies compiles the given policies.
+func newPolicies(cfg []config.OraclePolicy) ([]responsePolicy, error) {
+	var res = make([]responsePolicy, 0, len(cfg))
+	for i, p := range cfg {
+		if err := p.Validate(); err != nil {
+			return nil, fmt.Errorf("policy #%d: %w", i, err)
+		}
+		res = append(res, responsePolicy{
+			OraclePolicy: p,
+			pattern:      regexp.MustCompile(p.URLPattern),
+		})
+	}
+	return res, nil
+}
+
+// getPolicy returns the first policy matching the given URL or nil if there
+// is none.
+func (o *Oracle) getPolicy(url string) *responsePolicy {
+	for i := range o.policies {
+		if o.policies[i].pattern.MatchString(url) {
+			return &o.policies[i]
+		}
+	}
+	return nil
+}
+
+// maxResponseSize returns the maximum allowed response size.
+func (p *responsePolicy) maxResponseSize() int {
+	if p == nil || p.MaxResponseSize == 0 || p.MaxResponseSize > transaction.MaxOracleResultSize {
+		return transaction.MaxOracleResultSize
+	}
+	return p.MaxResponseSize
+}
+
+// checkMediaType checks Content-Type header against the list of policy's
+// allowed types.
+func (p *responsePolicy) checkMediaType(hdr string) bool {
+	return p == nil || checkMediaType(hdr, p.AllowedContentTypes)
+}
+
+// checkRequest checks the request against the policy before its data is
+// fetched.
+func (p *responsePolicy) checkRequest(req *state.OracleRequest) error {
+	if p != nil && p.RequireFilter && req.Filter == nil {
+		return errors.New("filter is required")
+	}
+	return nil
+}
+
+// checkResponse checks the raw response data and the filtered result against
+// the policy.
+func (p *responsePolicy) checkResponse(data []byte, result []byte) error {
+	if p == nil {
+		return nil
+	}
+	if len(p.ValidationFilter) != 0 {
+		res, err := filter(data, p.ValidationFilter)
+		if err != nil {
+			return fmt.Errorf("validation filter failed: %w", err)
+		}
+		if bytes.Equal(res, emptyFilterResult) {
+			return errors.New("validation filter selected nothing")
+		}
+	}
+	if p.RequireFilter && bytes.Equal(result, emptyFilterResult) {
+		return errors.New("request filter selected nothing")
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package oracle
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponsePolicy(t *testing.T) {
+	_, err := newPolicies([]config.OraclePolicy{{URLPattern: "(bad"}})
+	require.Error(t, err)
+
+	policies, err := newPolicies([]config.OraclePolicy{
+		{
+			URLPattern:          `^https://api\.example\.com/`,
+			MaxResponseSize:     100,
+			AllowedContentTypes: []string{"application/json"},
+			RequireFilter:       true,
+			ValidationFilter:    "$.status",
+		},
+		{
+			URLPattern: `^https://`,
+		},
+	})
+	require.NoError(t, err)
+	o := &Oracle{policies: policies}
+
+	require.Nil(t, o.getPolicy("neofs:container/object"))
+	require.Equal(t, &o.policies[1], o.getPolicy("https://other.com/data"))
+
+	p := o.getPolicy("https://api.example.com/price")
+	require.Equal(t, &o.policies[0], p)
+	require.Equal(t, 100, p.maxResponseSize())
+	require.Equal(t, transaction.MaxOracleResultSize, o.policies[1].maxResponseSize())
+	require.Equal(t, transaction.MaxOracleResultSize, (*responsePolicy)(nil).maxResponseSize())
+
+	require.True(t, p.checkMediaType("application/json; charset=utf-8"))
+	require.False(t, p.checkMediaType("text/plain"))
+	require.True(t, o.policies[1].checkMediaType("text/plain"))
+	require.True(t, (*responsePolicy)(nil).checkMediaType("text/plain"))
+
+	flt := "$.value"
+	require.Error(t, p.checkRequest(&state.OracleRequest{}))
+	require.NoError(t, p.checkRequest(&state.OracleRequest{Filter: &flt}))
+	require.NoError(t, o.policies[1].checkRequest(&state.OracleRequest{}))
+	require.NoError(t, (*responsePolicy)(nil).checkRequest(&state.OracleRequest{}))
+
+	var data = []byte(`{"status":"ok","value":42}`)
+	require.NoError(t, p.checkResponse(data, []byte(`[42]`)))
+	require.ErrorContains(t, p.checkResponse(data, []byte(`[]`)), "request filter")
+	require.ErrorContains(t, p.checkResponse([]byte(`{"value":42}`), []byte(`[42]`)), "validation filter")
+	require.ErrorContains(t, p.checkResponse([]byte(`not a JSON`), []byte(`[42]`)), "validation filter")
+	require.NoError(t, o.policies[1].checkResponse([]byte(`not a JSON`), nil))
+	require.NoError(t, (*responsePolicy)(nil).checkResponse(nil, nil))
+}
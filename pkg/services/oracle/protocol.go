@@ -0,0 +1,202 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"go.uber.org/zap"
+)
+
+type (
+	// ProtocolHandler fetches data for oracle requests with some additional
+	// (not built-in) URL scheme.
+	ProtocolHandler interface {
+		// Fetch performs the request for the given URL. The data stream
+		// returned is read by the oracle service (with response size limits
+		// applied) if the code is Success, it's closed by the service in any
+		// case if not nil. The error is only used for logging.
+		Fetch(ctx context.Context, u *url.URL) (io.ReadCloser, transaction.OracleResponseCode, error)
+	}
+
+	// ContentTyper can be implemented by the data stream returned from
+	// ProtocolHandler to make the oracle service check its content type
+	// against the allowed ones the same way it's done for HTTPS responses.
+	ContentTyper interface {
+		// ContentType returns the value of Content-Type header (or its
+		// equivalent) of the response.
+		ContentType() string
+	}
+
+	// ProtocolHandlerFactory creates a ProtocolHandler from its configuration.
+	ProtocolHandlerFactory func(cfg config.OracleProtocol) (ProtocolHandler, error)
+
+	// protocol is a configured ProtocolHandler.
+	protocol struct {
+		handler ProtocolHandler
+		cfg     config.OracleProtocol
+	}
+
+	// gatewayHandler proxies requests to some HTTP(S) gateway.
+	gatewayHandler struct {
+		prefix string
+		client HTTPClient
+	}
+
+	// gatewayResponse is a gateway response body with its content type.
+	gatewayResponse struct {
+		io.ReadCloser
+		contentType string
+	}
+)
+
+// GatewayHandler is the name of the built-in handler that converts requests
+// to HTTP(S) ones sent to the configured gateway, like
+// ipfs://<CID>/path -> https://<gateway>/ipfs/<CID>/path.
+const GatewayHandler = "gateway"
+
+var (
+	handlersLock sync.RWMutex
+	handlers     = map[string]ProtocolHandlerFactory{
+		GatewayHandler: newGatewayHandler,
+	}
+)
+
+// RegisterProtocolHandler makes the protocol handler type available for
+// oracle configuration by the given name (see config.OracleProtocol). It's
+// supposed to be called from the init() function of the package providing the
+// handler, it panics if the name is empty or already registered.
+func RegisterProtocolHandler(name string, f ProtocolHandlerFactory) {
+	handlersLock.Lock()
+	defer handlersLock.Unlock()
+	if len(name) == 0 || f == nil {
+		panic("oracle: invalid protocol handler")
+	}
+	if _, ok := handlers[name]; ok {
+		panic("oracle: protocol handler " + name + " is already registered")
+	}
+	handlers[name] = f
+}
+
+// newProtocols creates handlers for all configured protocols.
+func newProtocols(cfg map[string]config.OracleProtocol) (map[string]*protocol, error) {
+	handlersLock.RLock()
+	defer handlersLock.RUnlock()
+
+	var res = make(map[string]*protocol, len(cfg))
+	for scheme, p := range cfg {
+		name := p.Handler
+		if len(name) == 0 {
+			name = GatewayHandler
+		}
+		f, ok := handlers[name]
+		if !ok {
+			return nil, fmt.Errorf("protocol %s: unknown handler %s", scheme, name)
+		}
+		h, err := f(p)
+		if err != nil {
+			return nil, fmt.Errorf("protocol %s: %w", scheme, err)
+		}
+		res[scheme] = &protocol{handler: h, cfg: p}
+	}
+	return res, nil
+}
+
+// fetchProtocol performs the request using an additional protocol handler.
+func (o *Oracle) fetchProtocol(p *protocol, u *url.URL, policy *responsePolicy) ([]byte, transaction.OracleResponseCode) {
+	limit := policy.maxResponseSize()
+	timeout := p.cfg.Timeout
+	if timeout == 0 {
+		timeout = o.MainCfg.RequestTimeout
+	}
+	if p.cfg.MaxResponseSize != 0 {
+		limit = min(limit, p.cfg.MaxResponseSize)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	rc, code, err := p.handler.Fetch(ctx, u)
+	if rc != nil {
+		defer rc.Close() // intentionally skip the closing error, make it unified with Oracle `https` protocol.
+	}
+	if err != nil || code != transaction.Success {
+		if code == transaction.Success {
+			code = transaction.Error
+		}
+		o.Log.Warn("oracle request failed", zap.String("url", u.String()), zap.Error(err), zap.Stringer("code", code))
+		return nil, code
+	}
+	if ct, ok := rc.(ContentTyper); ok {
+		if !checkMediaType(ct.ContentType(), o.MainCfg.AllowedContentTypes) ||
+			!policy.checkMediaType(ct.ContentType()) {
+			return nil, transaction.ContentTypeNotSupported
+		}
+	}
+	return o.readResponse(rc, u.String(), limit)
+}
+
+func newGatewayHandler(cfg config.OracleProtocol) (ProtocolHandler, error) {
+	if len(cfg.Gateway) == 0 {
+		return nil, errors.New("no gateway specified")
+	}
+	u, err := url.Parse(cfg.Gateway)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gateway: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid gateway: unsupported scheme %s", u.Scheme)
+	}
+	return &gatewayHandler{
+		prefix: cfg.Gateway,
+		client: getDefaultClient(config.OracleConfiguration{AllowPrivateHost: cfg.AllowPrivateHost}),
+	}, nil
+}
+
+// Fetch implements the ProtocolHandler interface.
+func (g *gatewayHandler) Fetch(ctx context.Context, u *url.URL) (io.ReadCloser, transaction.OracleResponseCode, error) {
+	path := strings.TrimPrefix(strings.TrimPrefix(u.String(), u.Scheme+":"), "//")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.prefix+path, nil)
+	if err != nil {
+		return nil, transaction.Error, err
+	}
+	req.Header.Set("User-Agent", "NeoOracleService/3.0")
+	r, err := g.client.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrRestrictedRedirect) {
+			return nil, transaction.Forbidden, err
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, transaction.Timeout, err
+		}
+		return nil, transaction.Error, err
+	}
+	return &gatewayResponse{ReadCloser: r.Body, contentType: r.Header.Get("Content-Type")}, codeFromHTTPStatus(r.StatusCode), nil
+}
+
+// ContentType implements the ContentTyper interface.
+func (r *gatewayResponse) ContentType() string {
+	return r.contentType
+}
+
+// codeFromHTTPStatus converts HTTP response status to the oracle response
+// code.
+func codeFromHTTPStatus(status int) transaction.OracleResponseCode {
+	switch status {
+	case http.StatusOK:
+		return transaction.Success
+	case http.StatusForbidden:
+		return transaction.Forbidden
+	case http.StatusNotFound:
+		return transaction.NotFound
+	case http.StatusRequestTimeout:
+		return transaction.Timeout
+	default:
+		return transaction.Error
+	}
+}
@@ -0,0 +1,148 @@
+package oracle
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type testProtocolHandler struct {
+	data        string
+	code        transaction.OracleResponseCode
+	contentType string
+}
+
+type testContentTyper struct {
+	io.ReadCloser
+	contentType string
+}
+
+func (h *testProtocolHandler) Fetch(_ context.Context, _ *url.URL) (io.ReadCloser, transaction.OracleResponseCode, error) {
+	rc := io.NopCloser(strings.NewReader(h.data))
+	if h.contentType != "" {
+		return testContentTyper{ReadCloser: rc, contentType: h.contentType}, h.code, nil
+	}
+	return rc, h.code, nil
+}
+
+func (r testContentTyper) ContentType() string {
+	return r.contentType
+}
+
+func TestRegisterProtocolHandler(t *testing.T) {
+	f := func(cfg config.OracleProtocol) (ProtocolHandler, error) {
+		return &testProtocolHandler{data: cfg.Parameters["data"]}, nil
+	}
+	require.Panics(t, func() { RegisterProtocolHandler("", f) })
+	require.Panics(t, func() { RegisterProtocolHandler(GatewayHandler, f) })
+
+	RegisterProtocolHandler("test", f)
+	t.Cleanup(func() {
+		handlersLock.Lock()
+		delete(handlers, "test")
+		handlersLock.Unlock()
+	})
+
+	_, err := newProtocols(map[string]config.OracleProtocol{"ftps": {Handler: "unknown"}})
+	require.ErrorContains(t, err, "protocol ftps: unknown handler unknown")
+
+	ps, err := newProtocols(map[string]config.OracleProtocol{
+		"ftps": {Handler: "test", Parameters: map[string]string{"data": "ok"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &testProtocolHandler{data: "ok"}, ps["ftps"].handler)
+}
+
+func TestFetchProtocol(t *testing.T) {
+	o := &Oracle{Config: Config{Log: zaptest.NewLogger(t)}}
+	o.MainCfg.RequestTimeout = time.Second
+	u, err := url.Parse("ftps://example.com/data")
+	require.NoError(t, err)
+
+	p := &protocol{handler: &testProtocolHandler{data: `{"value":42}`}}
+	res, code := o.fetchProtocol(p, u, nil)
+	require.Equal(t, transaction.Success, code)
+	require.Equal(t, []byte(`{"value":42}`), res)
+
+	p.cfg.MaxResponseSize = 5
+	_, code = o.fetchProtocol(p, u, nil)
+	require.Equal(t, transaction.ResponseTooLarge, code)
+
+	p = &protocol{handler: &testProtocolHandler{code: transaction.NotFound}}
+	res, code = o.fetchProtocol(p, u, nil)
+	require.Equal(t, transaction.NotFound, code)
+	require.Nil(t, res)
+
+	t.Run("content type", func(t *testing.T) {
+		o.MainCfg.AllowedContentTypes = []string{"application/json", "text/plain"}
+		policy := &responsePolicy{OraclePolicy: config.OraclePolicy{AllowedContentTypes: []string{"application/json"}}}
+
+		p := &protocol{handler: &testProtocolHandler{data: `{"value":42}`, contentType: "application/json"}}
+		res, code := o.fetchProtocol(p, u, policy)
+		require.Equal(t, transaction.Success, code)
+		require.Equal(t, []byte(`{"value":42}`), res)
+
+		p = &protocol{handler: &testProtocolHandler{data: `42`, contentType: "text/plain"}}
+		_, code = o.fetchProtocol(p, u, nil)
+		require.Equal(t, transaction.Success, code)
+		_, code = o.fetchProtocol(p, u, policy)
+		require.Equal(t, transaction.ContentTypeNotSupported, code)
+
+		p = &protocol{handler: &testProtocolHandler{data: `<html/>`, contentType: "text/html"}}
+		_, code = o.fetchProtocol(p, u, nil)
+		require.Equal(t, transaction.ContentTypeNotSupported, code)
+	})
+}
+
+func TestGatewayHandler(t *testing.T) {
+	_, err := newGatewayHandler(config.OracleProtocol{})
+	require.ErrorContains(t, err, "no gateway specified")
+	_, err = newGatewayHandler(config.OracleProtocol{Gateway: "ftp://gateway/"})
+	require.ErrorContains(t, err, "unsupported scheme")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ipfs/cid/file.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(r.URL.RawQuery))
+	}))
+	t.Cleanup(srv.Close)
+
+	check := func(t *testing.T, h ProtocolHandler, rawURL string, expCode transaction.OracleResponseCode, expData string) {
+		u, err := url.Parse(rawURL)
+		require.NoError(t, err)
+		rc, code, err := h.Fetch(context.Background(), u)
+		if rc != nil {
+			defer rc.Close()
+		}
+		require.Equal(t, expCode, code, err)
+		if expCode == transaction.Success {
+			require.Equal(t, "application/json", rc.(ContentTyper).ContentType())
+			data, err := io.ReadAll(rc)
+			require.NoError(t, err)
+			require.Equal(t, expData, string(data))
+		}
+	}
+
+	h, err := newGatewayHandler(config.OracleProtocol{Gateway: srv.URL + "/ipfs/"})
+	require.NoError(t, err)
+	check(t, h, "ipfs://cid/file.json", transaction.Forbidden, "")
+
+	h, err = newGatewayHandler(config.OracleProtocol{Gateway: srv.URL + "/ipfs/", AllowPrivateHost: true})
+	require.NoError(t, err)
+	check(t, h, "ipfs://cid/file.json?key=value", transaction.Success, "key=value")
+	check(t, h, "ipfs:cid/file.json", transaction.Success, "")
+	check(t, h, "ipfs://cid/missing.json", transaction.NotFound, "")
+}
@@ -114,10 +114,14 @@ func (o *Oracle) processRequest(priv *keys.PrivateKey, req request) error {
 		return nil
 	}
 	resp := &transaction.OracleResponse{ID: req.ID, Code: transaction.Success}
+	policy := o.getPolicy(req.Req.URL)
 	u, err := url.ParseRequestURI(req.Req.URL)
 	if err != nil {
 		o.Log.Warn("malformed oracle request", zap.String("url", req.Req.URL), zap.Error(err))
 		resp.Code = transaction.ProtocolNotSupported
+	} else if err = policy.checkRequest(req.Req); err != nil {
+		o.Log.Warn("oracle request rejected by policy", zap.String("url", req.Req.URL), zap.Error(err))
+		resp.Code = transaction.Error
 	} else {
 		switch u.Scheme {
 		case "https":
@@ -140,23 +144,16 @@ func (o *Oracle) processRequest(priv *keys.PrivateKey, req request) error {
 				break
 			}
 			defer r.Body.Close()
-			switch r.StatusCode {
-			case http.StatusOK:
-				if !checkMediaType(r.Header.Get("Content-Type"), o.MainCfg.AllowedContentTypes) {
-					resp.Code = transaction.ContentTypeNotSupported
-					break
-				}
-
-				resp.Result, resp.Code = o.readResponse(r.Body, req.Req.URL)
-			case http.StatusForbidden:
-				resp.Code = transaction.Forbidden
-			case http.StatusNotFound:
-				resp.Code = transaction.NotFound
-			case http.StatusRequestTimeout:
-				resp.Code = transaction.Timeout
-			default:
-				resp.Code = transaction.Error
+			if r.StatusCode != http.StatusOK {
+				resp.Code = codeFromHTTPStatus(r.StatusCode)
+				break
 			}
+			if !checkMediaType(r.Header.Get("Content-Type"), o.MainCfg.AllowedContentTypes) ||
+				!policy.checkMediaType(r.Header.Get("Content-Type")) {
+				resp.Code = transaction.ContentTypeNotSupported
+				break
+			}
+			resp.Result, resp.Code = o.readResponse(r.Body, req.Req.URL, policy.maxResponseSize())
 		case neofs.URIScheme:
 			if len(o.MainCfg.NeoFS.Nodes) == 0 {
 				o.Log.Warn("no NeoFS nodes configured", zap.String("url", req.Req.URL))
@@ -175,18 +172,28 @@ func (o *Oracle) processRequest(priv *keys.PrivateKey, req request) error {
 				}
 				break
 			}
-			resp.Result, resp.Code = o.readResponse(rc, req.Req.URL)
+			resp.Result, resp.Code = o.readResponse(rc, req.Req.URL, policy.maxResponseSize())
 			rc.Close() // intentionally skip the closing error, make it unified with Oracle `https` protocol.
 		default:
-			resp.Code = transaction.ProtocolNotSupported
-			o.Log.Warn("unknown oracle request scheme", zap.String("url", req.Req.URL))
+			p, ok := o.protocols[u.Scheme]
+			if !ok {
+				resp.Code = transaction.ProtocolNotSupported
+				o.Log.Warn("unknown oracle request scheme", zap.String("url", req.Req.URL))
+				break
+			}
+			resp.Result, resp.Code = o.fetchProtocol(p, u, policy)
 		}
 	}
 	if resp.Code == transaction.Success {
-		resp.Result, err = filterRequest(resp.Result, req.Req)
+		var data = resp.Result
+		resp.Result, err = filterRequest(data, req.Req)
+		if err == nil {
+			err = policy.checkResponse(data, resp.Result)
+		}
 		if err != nil {
 			o.Log.Warn("oracle filter failed", zap.Uint64("request", req.ID), zap.Error(err))
 			resp.Code = transaction.Error
+			resp.Result = nil
 		}
 	}
 	o.Log.Debug("oracle request processed", zap.String("url", req.Req.URL), zap.Int("code", int(resp.Code)), zap.String("result", string(resp.Result)))
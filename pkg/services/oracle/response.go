@@ -68,8 +68,7 @@ func (o *Oracle) AddResponse(pub *keys.PublicKey, reqID uint64, txSig []byte) {
 // ErrResponseTooLarge is returned when a response exceeds the max allowed size.
 var ErrResponseTooLarge = errors.New("too big response")
 
-func (o *Oracle) readResponse(rc gio.Reader, url string) ([]byte, transaction.OracleResponseCode) {
-	const limit = transaction.MaxOracleResultSize
+func (o *Oracle) readResponse(rc gio.Reader, url string, limit int) ([]byte, transaction.OracleResponseCode) {
 	buf := make([]byte, limit+1)
 	n, err := gio.ReadFull(rc, buf)
 	if errors.Is(err, gio.ErrUnexpectedEOF) && n <= limit {
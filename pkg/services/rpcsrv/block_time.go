@@ -0,0 +1,38 @@
+package rpcsrv
+
+import (
+	"slices"
+
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+)
+
+// newBlockTimeWindow calculates block time statistics for the given number of
+// the most recent intervals (ordered from the newest one).
+func newBlockTimeWindow(intervals []uint64, blocks int) result.BlockTimeWindow {
+	var (
+		res    = result.BlockTimeWindow{Blocks: blocks, Count: min(blocks, len(intervals))}
+		sorted = slices.Clone(intervals[:res.Count])
+		sum    uint64
+	)
+	if res.Count == 0 {
+		return res
+	}
+	slices.Sort(sorted)
+	for _, v := range sorted {
+		sum += v
+	}
+	res.Average = sum / uint64(res.Count)
+	res.Min = sorted[0]
+	res.Max = sorted[res.Count-1]
+	res.Median = percentile(sorted, 50)
+	res.P90 = percentile(sorted, 90)
+	res.P99 = percentile(sorted, 99)
+	return res
+}
+
+// percentile returns the p-th percentile of non-empty sorted values using
+// nearest-rank method.
+func percentile(sorted []uint64, p int) uint64 {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
@@ -0,0 +1,37 @@
+package rpcsrv
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBlockTimeWindow(t *testing.T) {
+	require.Equal(t, result.BlockTimeWindow{Blocks: 10}, newBlockTimeWindow(nil, 10))
+
+	intervals := make([]uint64, 0, 100)
+	for i := range 100 {
+		intervals = append(intervals, uint64(100-i)*10)
+	}
+	require.Equal(t, result.BlockTimeWindow{
+		Blocks:  200,
+		Count:   100,
+		Average: 505,
+		Min:     10,
+		Max:     1000,
+		Median:  500,
+		P90:     900,
+		P99:     990,
+	}, newBlockTimeWindow(intervals, 200))
+	require.Equal(t, result.BlockTimeWindow{
+		Blocks:  3,
+		Count:   3,
+		Average: 990,
+		Min:     980,
+		Max:     1000,
+		Median:  990,
+		P90:     1000,
+		P99:     1000,
+	}, newBlockTimeWindow(intervals, 3))
+}
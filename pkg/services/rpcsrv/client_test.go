@@ -20,6 +20,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/nspcc-dev/neo-go/internal/basicchain"
 	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
@@ -36,6 +37,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
 	"github.com/nspcc-dev/neo-go/pkg/network"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
@@ -334,6 +336,251 @@ func TestClientManagementContract(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, vmstate.Halt, appLog.Executions[0].VMState)
 	require.Equal(t, 1, len(appLog.Executions[0].Events))
+
+	// No limit.
+	appLog, err = c.GetApplicationLogPage(txdepl.Hash(), nil, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(appLog.Executions[0].Events))
+	require.False(t, appLog.Truncated)
+
+	appLog, err = c.GetApplicationLogPage(txdepl.Hash(), nil, 1, 0)
+	require.NoError(t, err)
+	require.Empty(t, appLog.Executions[0].Events)
+}
+
+func TestClientFindNotifications(t *testing.T) {
+	chain, _, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+		c.ApplicationConfiguration.IndexEvents = true
+		c.ApplicationConfiguration.RPC.MaxFindResultItems = 2
+	})
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+	}
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	act, err := actor.New(c, []actor.SignerAccount{{
+		Signer: transaction.Signer{
+			Account: testchain.CommitteeScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		},
+		Account: &wallet.Account{
+			Address: testchain.CommitteeAddress(),
+			Contract: &wallet.Contract{
+				Script: testchain.CommitteeVerificationScript(),
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	src := `package test
+		import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		func Emit(key string, value int) {
+			runtime.Notify("Event", key, value)
+		}`
+	ctr := neotest.CompileSource(t, testchain.CommitteeScriptHash(), strings.NewReader(src), &compiler.Options{
+		Name: "indexed",
+		ContractEvents: []compiler.HybridEvent{{
+			Name: "Event",
+			Parameters: []compiler.HybridParameter{
+				{Parameter: manifest.NewParameter("key", smartcontract.StringType), Indexed: true},
+				{Parameter: manifest.NewParameter("value", smartcontract.IntegerType)},
+			},
+		}},
+	})
+	txdepl, err := management.New(act).DeployUnsigned(ctr.NEF, ctr.Manifest, nil)
+	require.NoError(t, err)
+	txdepl.Scripts[0].InvocationScript = testchain.SignCommittee(txdepl)
+	require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, txdepl)))
+
+	var txs []*transaction.Transaction
+	for i := range 3 {
+		tx, err := act.MakeUnsignedCall(ctr.Hash, "emit", nil, "a", i)
+		require.NoError(t, err)
+		tx.Scripts[0].InvocationScript = testchain.SignCommittee(tx)
+		txs = append(txs, tx)
+	}
+	require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, txs...)))
+
+	aValue := smartcontract.Parameter{Type: smartcontract.StringType, Value: "a"}
+	res, err := c.FindNotifications(ctr.Hash, "Event", "key", aValue, "")
+	require.NoError(t, err)
+	require.Len(t, res.Notifications, 2)
+	require.NotEmpty(t, res.Next)
+	notifications := res.Notifications
+
+	res, err = c.FindNotifications(ctr.Hash, "Event", "key", aValue, res.Next)
+	require.NoError(t, err)
+	require.Len(t, res.Notifications, 1)
+	require.Empty(t, res.Next)
+	notifications = append(notifications, res.Notifications...)
+
+	// Notifications of the same block are ordered by container hash.
+	for _, ne := range notifications {
+		i := slices.IndexFunc(txs, func(tx *transaction.Transaction) bool { return tx.Hash() == ne.Container })
+		require.NotEqual(t, -1, i)
+		require.Equal(t, ctr.Hash, ne.ScriptHash)
+		require.Equal(t, "Event", ne.Name)
+		require.Equal(t, stackitem.NewArray([]stackitem.Item{
+			stackitem.NewByteArray([]byte("a")),
+			stackitem.NewBigInteger(big.NewInt(int64(i))),
+		}), ne.Item)
+	}
+	require.ElementsMatch(t, []util.Uint256{txs[0].Hash(), txs[1].Hash(), txs[2].Hash()},
+		[]util.Uint256{notifications[0].Container, notifications[1].Container, notifications[2].Container})
+
+	res, err = c.FindNotifications(ctr.Hash, "Event", "key", smartcontract.Parameter{Type: smartcontract.StringType, Value: "b"}, "")
+	require.NoError(t, err)
+	require.Empty(t, res.Notifications)
+
+	_, err = c.FindNotifications(ctr.Hash, "Event", "value", smartcontract.Parameter{Type: smartcontract.IntegerType, Value: big.NewInt(1)}, "")
+	require.ErrorContains(t, err, "parameter value of event Event is not indexed")
+
+	_, err = c.FindNotifications(ctr.Hash, "Event", "key", aValue, "bad cursor")
+	require.ErrorContains(t, err, "malformed cursor")
+}
+
+func TestClientGetNEP17TransfersCursor(t *testing.T) {
+	chain, _, httpSrv := initClearServerWithInMemoryChain(t)
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+	}
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	act, err := actor.New(c, []actor.SignerAccount{{
+		Signer: transaction.Signer{
+			Account: testchain.CommitteeScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		},
+		Account: &wallet.Account{
+			Address: testchain.CommitteeAddress(),
+			Contract: &wallet.Contract{
+				Script: testchain.CommitteeVerificationScript(),
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	// Several transfers with the same timestamp.
+	var (
+		gasprom = gas.New(act)
+		txs     []*transaction.Transaction
+	)
+	for i := range 10 {
+		tx, err := gasprom.TransferUnsigned(act.Sender(), util.Uint160{byte(i + 1)}, big.NewInt(int64(i+1)), nil)
+		require.NoError(t, err)
+		tx.Scripts[0].InvocationScript = testchain.SignCommittee(tx)
+		txs = append(txs, tx)
+	}
+	b := testchain.NewBlock(t, chain, 1, 0, txs...)
+	require.NoError(t, chain.AddBlock(b))
+
+	var (
+		stop = b.Timestamp
+		sent []result.NEP17Transfer
+		rcvd []result.NEP17Transfer
+		next string
+	)
+	all, err := c.GetNEP17TransfersWithCursor(act.Sender(), 0, stop, 1000, "")
+	require.NoError(t, err)
+	require.Empty(t, all.Next)
+	for {
+		res, err := c.GetNEP17TransfersWithCursor(act.Sender(), 0, stop, 3, next)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(res.Sent)+len(res.Received), 3)
+		sent = append(sent, res.Sent...)
+		rcvd = append(rcvd, res.Received...)
+		if res.Next == "" {
+			break
+		}
+		next = res.Next
+	}
+	require.Equal(t, all.Sent, sent)
+	require.Equal(t, all.Received, rcvd)
+	for _, tx := range txs {
+		require.True(t, slices.ContainsFunc(sent, func(tr result.NEP17Transfer) bool {
+			return tr.TxHash == tx.Hash() && tr.Asset.Equals(nativehashes.GasToken) && tr.Address != ""
+		}))
+	}
+}
+
+func TestClientGetContractStorageUsage(t *testing.T) {
+	chain, _, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+		c.ApplicationConfiguration.TrackStorageUsage = true
+	})
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+	}
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	for _, h := range []util.Uint160{testContractHash, nativehashes.GasToken} {
+		cs := chain.GetContractState(h)
+		require.NotNil(t, cs)
+
+		var expected state.StorageUsage
+		chain.SeekStorage(cs.ID, nil, func(k, v []byte) bool {
+			expected.Keys++
+			expected.KeysSize += uint64(len(k))
+			expected.ValuesSize += uint64(len(v))
+			return true
+		})
+		require.NotZero(t, expected.Keys)
+
+		res, err := c.GetContractStorageUsage(h)
+		require.NoError(t, err)
+		require.Equal(t, cs.ID, res.ID)
+		require.Equal(t, h, res.Hash)
+		require.Equal(t, expected.Keys, res.Keys)
+		require.Equal(t, expected.KeysSize, res.KeysSize)
+		require.Equal(t, expected.ValuesSize, res.ValuesSize)
+		require.NotZero(t, res.LastModified)
+		require.LessOrEqual(t, res.LastModified, chain.BlockHeight())
+
+		count, err := c.GetStorageItemsCount(h)
+		require.NoError(t, err)
+		require.Equal(t, expected.Keys, count)
+	}
+
+	_, err = c.GetContractStorageUsage(util.Uint160{1, 2, 3})
+	require.ErrorIs(t, err, neorpc.ErrUnknownContract)
+	_, err = c.GetStorageItemsCount(util.Uint160{1, 2, 3})
+	require.ErrorIs(t, err, neorpc.ErrUnknownContract)
+}
+
+func TestClient_GetBlocksRange(t *testing.T) {
+	chain, _, httpSrv := initServerWithInMemoryChain(t)
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	blocks, err := c.GetBlocksRange(1, 5)
+	require.NoError(t, err)
+	require.Len(t, blocks, 5)
+	for i, b := range blocks {
+		require.Equal(t, chain.GetHeaderHash(uint32(i)+1), b.Hash())
+	}
+
+	height := chain.BlockHeight()
+	blocks, err = c.GetBlocksRange(height-1, 0)
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	require.Equal(t, chain.CurrentBlockHash(), blocks[1].Hash())
+
+	_, err = c.GetBlocksRange(height+1, 0)
+	require.ErrorIs(t, err, neorpc.ErrUnknownHeight)
 }
 
 func TestClientNEOContract(t *testing.T) {
@@ -536,6 +783,20 @@ func TestClientNotary(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, uint32(1111), expir)
 
+	txDeposit, err := notaPriv.DepositTransaction(priv0Hash, big.NewInt(1_0000_0000), &notary.OnNEP17PaymentData{Till: 1200})
+	require.NoError(t, err)
+
+	bl = testchain.NewBlock(t, chain, 1, 0, txDeposit)
+	_, err = c.SubmitBlock(*bl)
+	require.NoError(t, err)
+
+	bal, err = notaReader.BalanceOf(priv0Hash)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(11_0000_0000), bal)
+	expir, err = notaReader.ExpirationOf(priv0Hash)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1200), expir)
+
 	_, err = notaPriv.WithdrawTransaction(priv0Hash, priv0Hash)
 	require.Error(t, err) // Can't be withdrawn until 1111.
 }
@@ -1030,6 +1291,48 @@ func TestPing(t *testing.T) {
 	require.Error(t, c.Ping())
 }
 
+func TestClient_VerificationFailure(t *testing.T) {
+	_, _, httpSrv := initServerWithInMemoryChain(t)
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	priv0 := testchain.PrivateKeyByID(0)
+	act, err := actor.NewSimple(c, wallet.NewAccountFromPrivateKey(priv0))
+	require.NoError(t, err)
+
+	tx, err := act.MakeRun([]byte{byte(opcode.PUSH1)})
+	require.NoError(t, err)
+	tx.Scripts[0].InvocationScript[10] ^= 0xff
+	_, err = c.SendRawTransaction(tx)
+	require.ErrorIs(t, err, neorpc.ErrInvalidSignature)
+	vf := rpcclient.GetVerificationFailure(err)
+	require.NotNil(t, vf)
+	require.Equal(t, neorpc.VerificationFailureInvalidSignature, vf.Reason)
+	require.Equal(t, 0, vf.Witness)
+	require.Equal(t, priv0.GetScriptHash(), vf.ScriptHash)
+	require.Positive(t, vf.GasConsumed)
+
+	tx, err = act.MakeRun([]byte{byte(opcode.PUSH1)})
+	require.NoError(t, err)
+	tx.Scripts[0].VerificationScript = []byte{byte(opcode.PUSH1)}
+	_, err = c.SendRawTransaction(tx)
+	require.Error(t, err)
+	vf = rpcclient.GetVerificationFailure(err)
+	require.NotNil(t, vf)
+	require.Equal(t, neorpc.VerificationFailureHashMismatch, vf.Reason)
+
+	// Errors unrelated to witnesses have no details.
+	tx, err = act.MakeRun([]byte{byte(opcode.PUSH1)})
+	require.NoError(t, err)
+	tx.ValidUntilBlock = 1
+	_, err = c.SendRawTransaction(tx)
+	require.ErrorIs(t, err, neorpc.ErrExpiredTransaction)
+	require.Nil(t, rpcclient.GetVerificationFailure(err))
+}
+
 func TestCreateNEP17TransferTx(t *testing.T) {
 	chain, _, httpSrv := initServerWithInMemoryChain(t)
 
@@ -1210,6 +1513,52 @@ func TestClient_GetNativeContracts(t *testing.T) {
 	require.Equal(t, chain.GetNatives(), cs)
 }
 
+func TestClient_Batch(t *testing.T) {
+	chain, _, httpSrv := initServerWithInMemoryChain(t)
+
+	check := func(t *testing.T, c *rpcclient.Client) {
+		b := c.NewBatch()
+		require.NoError(t, b.Do()) // Empty batch.
+
+		count := rpcclient.BatchCall[uint32](b, "getblockcount")
+		hash := rpcclient.BatchCall[util.Uint256](b, "getblockhash", 1)
+		bad := rpcclient.BatchCall[util.Uint256](b, "getblockhash", "notanumber")
+		natives := rpcclient.BatchCall[[]state.Contract](b, "getnativecontracts")
+		require.Equal(t, 4, b.Len())
+		require.NoError(t, b.Do())
+		require.Equal(t, 0, b.Len())
+
+		require.NoError(t, count.Err)
+		require.Equal(t, chain.BlockHeight()+1, count.Value)
+		require.NoError(t, hash.Err)
+		require.Equal(t, chain.GetHeaderHash(1), hash.Value)
+		require.ErrorIs(t, bad.Err, neorpc.ErrInvalidParams)
+		require.NoError(t, natives.Err)
+		require.Equal(t, chain.GetNatives(), natives.Value)
+	}
+	t.Run("http", func(t *testing.T) {
+		c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+		require.NoError(t, err)
+		t.Cleanup(c.Close)
+		check(t, c)
+
+		c, err = rpcclient.New(context.Background(), "http://127.0.0.1:1", rpcclient.Options{})
+		require.NoError(t, err)
+		t.Cleanup(c.Close)
+		b := c.NewBatch()
+		count := rpcclient.BatchCall[uint32](b, "getblockcount")
+		err = b.Do()
+		require.Error(t, err)
+		require.Equal(t, err, count.Err)
+	})
+	t.Run("websocket", func(t *testing.T) {
+		c, err := rpcclient.NewWS(context.Background(), "ws"+strings.TrimPrefix(httpSrv.URL, "http")+"/ws", rpcclient.WSOptions{})
+		require.NoError(t, err)
+		t.Cleanup(c.Close)
+		check(t, &c.Client)
+	})
+}
+
 func TestClient_NEP11_ND(t *testing.T) {
 	chain, _, httpSrv := initServerWithInMemoryChain(t)
 
@@ -1362,6 +1711,16 @@ func TestClient_NEP11_D(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, []util.Uint160{priv1, priv0}, b)
 	})
+	t.Run("OwnerOfAll", func(t *testing.T) {
+		b, err := n11.OwnerOfAll(token1ID, 10)
+		require.NoError(t, err)
+		require.Equal(t, []util.Uint160{priv1, priv0}, b)
+	})
+	t.Run("TokensOfAll", func(t *testing.T) {
+		toks, err := n11.TokensOfAll(priv0, 10)
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{token1ID}, toks)
+	})
 	t.Run("Properties", func(t *testing.T) {
 		p, err := n11.Properties(token1ID)
 		require.NoError(t, err)
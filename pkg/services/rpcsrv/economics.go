@@ -0,0 +1,140 @@
+package rpcsrv
+
+import (
+	"slices"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+)
+
+// blockRewardees contains accounts that can be rewarded by native contracts
+// in the block, they're used to attribute GAS mints.
+type blockRewardees struct {
+	// primary is the primary validator account, nil if it can't be derived
+	// from the block witness.
+	primary *util.Uint160
+	// notaries are the notary nodes designated at the block height.
+	notaries []util.Uint160
+	// oracles are the oracle nodes rewarded for responses included into the
+	// block.
+	oracles []util.Uint160
+}
+
+// newBlockEconomics builds block economics summary from the block and its
+// application execution results. GAS mints are attributed by their
+// recipients: OnPersist mint to the primary validator is the primary reward
+// and mints to notary nodes are notary rewards, PostPersist mints to oracle
+// nodes rewarded for the block responses are oracle rewards and the other
+// one is the committee reward. If the same account has two rewards of the
+// same trigger, the first mint is the validator (committee) one since GAS
+// (NEO) is executed before Notary (Oracle). Genesis block mints are not
+// treated as rewards.
+func newBlockEconomics(b *block.Block, aers []state.AppExecResult, r *blockRewardees) *result.BlockEconomics {
+	var res = &result.BlockEconomics{
+		Hash:          b.Hash(),
+		Index:         b.Index,
+		NotaryRewards: []result.GASPayout{},
+		OracleRewards: []result.GASPayout{},
+	}
+	for _, tx := range b.Transactions {
+		res.SystemFee += tx.SystemFee
+		res.NetworkFee += tx.NetworkFee
+	}
+	for i := range aers {
+		if aers[i].VMState != vmstate.Halt {
+			continue
+		}
+		var mints = make(map[util.Uint160]int)
+		if aers[i].Trigger == trigger.PostPersist {
+			for j := range aers[i].Events {
+				from, to, _, ok := parseGASTransfer(&aers[i].Events[j])
+				if ok && from == nil && to != nil {
+					mints[*to]++
+				}
+			}
+		}
+		for j := range aers[i].Events {
+			from, to, amount, ok := parseGASTransfer(&aers[i].Events[j])
+			if !ok {
+				continue
+			}
+			if from == nil && to != nil {
+				res.Minted += amount
+				if b.Index == 0 {
+					continue
+				}
+				p := result.GASPayout{Account: *to, Amount: amount}
+				switch aers[i].Trigger {
+				case trigger.OnPersist:
+					if res.PrimaryReward == nil && r.primary != nil && *to == *r.primary {
+						res.PrimaryReward = &p
+					} else if slices.Contains(r.notaries, *to) {
+						res.NotaryRewards = append(res.NotaryRewards, p)
+					}
+				case trigger.PostPersist:
+					// An oracle node that is also the committee member
+					// has two mints, the first one is committee reward.
+					if slices.Contains(r.oracles, *to) && mints[*to] == 1 {
+						res.OracleRewards = append(res.OracleRewards, p)
+					} else if res.CommitteeReward == nil {
+						res.CommitteeReward = &p
+					}
+					mints[*to]--
+				default:
+				}
+			} else if from != nil && to == nil {
+				res.Burned += amount
+				if aers[i].Trigger == trigger.OnPersist {
+					res.BurnedFees += amount
+				}
+			}
+		}
+	}
+	return res
+}
+
+// parseGASTransfer parses GAS Transfer notification, nil from (to) means
+// minting (burning).
+func parseGASTransfer(e *state.NotificationEvent) (*util.Uint160, *util.Uint160, int64, bool) {
+	if e.ScriptHash != nativehashes.GasToken || e.Name != "Transfer" {
+		return nil, nil, 0, false
+	}
+	arr, ok := e.Item.Value().([]stackitem.Item)
+	if !ok || len(arr) != 3 {
+		return nil, nil, 0, false
+	}
+	from, ok := parseTransferAddress(arr[0])
+	if !ok {
+		return nil, nil, 0, false
+	}
+	to, ok := parseTransferAddress(arr[1])
+	if !ok {
+		return nil, nil, 0, false
+	}
+	amount, err := arr[2].TryInteger()
+	if err != nil || !amount.IsInt64() {
+		return nil, nil, 0, false
+	}
+	return from, to, amount.Int64(), true
+}
+
+func parseTransferAddress(itm stackitem.Item) (*util.Uint160, bool) {
+	if _, ok := itm.(stackitem.Null); ok {
+		return nil, true
+	}
+	b, err := itm.TryBytes()
+	if err != nil {
+		return nil, false
+	}
+	u, err := util.Uint160DecodeBytesBE(b)
+	if err != nil {
+		return nil, false
+	}
+	return &u, true
+}
@@ -0,0 +1,80 @@
+package rpcsrv
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBlockEconomics(t *testing.T) {
+	var (
+		primary   = util.Uint160{1}
+		notary    = util.Uint160{2}
+		committee = util.Uint160{3}
+		oracle    = util.Uint160{4}
+	)
+	mint := func(to util.Uint160, amount int64) state.NotificationEvent {
+		return state.NotificationEvent{
+			ScriptHash: nativehashes.GasToken,
+			Name:       "Transfer",
+			Item: stackitem.NewArray([]stackitem.Item{
+				stackitem.Null{},
+				stackitem.NewByteArray(to.BytesBE()),
+				stackitem.NewBigInteger(big.NewInt(amount)),
+			}),
+		}
+	}
+	aer := func(trig trigger.Type, events ...state.NotificationEvent) state.AppExecResult {
+		return state.AppExecResult{Execution: state.Execution{
+			Trigger: trig,
+			VMState: vmstate.Halt,
+			Events:  events,
+		}}
+	}
+	b := &block.Block{Header: block.Header{Index: 5}}
+
+	t.Run("different accounts", func(t *testing.T) {
+		res := newBlockEconomics(b, []state.AppExecResult{
+			aer(trigger.OnPersist, mint(primary, 10), mint(notary, 2)),
+			aer(trigger.PostPersist, mint(committee, 50), mint(oracle, 3)),
+		}, &blockRewardees{primary: &primary, notaries: []util.Uint160{notary}, oracles: []util.Uint160{oracle}})
+		require.Equal(t, &result.GASPayout{Account: primary, Amount: 10}, res.PrimaryReward)
+		require.Equal(t, []result.GASPayout{{Account: notary, Amount: 2}}, res.NotaryRewards)
+		require.Equal(t, &result.GASPayout{Account: committee, Amount: 50}, res.CommitteeReward)
+		require.Equal(t, []result.GASPayout{{Account: oracle, Amount: 3}}, res.OracleRewards)
+		require.Equal(t, int64(65), res.Minted)
+	})
+	t.Run("no primary mint", func(t *testing.T) {
+		res := newBlockEconomics(b, []state.AppExecResult{
+			aer(trigger.OnPersist, mint(notary, 2)),
+		}, &blockRewardees{primary: &primary, notaries: []util.Uint160{notary}})
+		require.Nil(t, res.PrimaryReward)
+		require.Equal(t, []result.GASPayout{{Account: notary, Amount: 2}}, res.NotaryRewards)
+	})
+	t.Run("same accounts", func(t *testing.T) {
+		res := newBlockEconomics(b, []state.AppExecResult{
+			aer(trigger.OnPersist, mint(primary, 10), mint(notary, 2), mint(primary, 2)),
+			aer(trigger.PostPersist, mint(oracle, 3), mint(oracle, 50)),
+		}, &blockRewardees{primary: &primary, notaries: []util.Uint160{notary, primary}, oracles: []util.Uint160{oracle}})
+		require.Equal(t, &result.GASPayout{Account: primary, Amount: 10}, res.PrimaryReward)
+		require.Equal(t, []result.GASPayout{{Account: notary, Amount: 2}, {Account: primary, Amount: 2}}, res.NotaryRewards)
+		require.Equal(t, &result.GASPayout{Account: oracle, Amount: 3}, res.CommitteeReward)
+		require.Equal(t, []result.GASPayout{{Account: oracle, Amount: 50}}, res.OracleRewards)
+	})
+	t.Run("oracle node without oracle mint", func(t *testing.T) {
+		res := newBlockEconomics(b, []state.AppExecResult{
+			aer(trigger.PostPersist, mint(oracle, 50)),
+		}, &blockRewardees{})
+		require.Equal(t, &result.GASPayout{Account: oracle, Amount: 50}, res.CommitteeReward)
+		require.Empty(t, res.OracleRewards)
+	})
+}
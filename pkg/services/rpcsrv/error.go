@@ -14,8 +14,9 @@ type abstractResult interface {
 // representation.
 type abstract struct {
 	neorpc.Header
-	Error  *neorpc.Error `json:"error,omitempty"`
-	Result any           `json:"result,omitempty"`
+	Error     *neorpc.Error           `json:"error,omitempty"`
+	Result    any                     `json:"result,omitempty"`
+	Signature *neorpc.ResultSignature `json:"signature,omitempty"`
 }
 
 // RunForErrors implements abstractResult interface.
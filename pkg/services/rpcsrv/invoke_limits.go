@@ -0,0 +1,163 @@
+package rpcsrv
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+)
+
+// errInvokeTimeLimitExceeded is used when test invocation is stopped because
+// it takes more time than allowed by MaxInvokeTime.
+var errInvokeTimeLimitExceeded = errors.New("invocation time limit exceeded")
+
+// errUnknownAPIKey is returned for requests with API key not belonging to any
+// of configured invoke tiers.
+var errUnknownAPIKey = errors.New("unknown API key")
+
+// invokeLimits are test invocation limits applied to some request.
+type invokeLimits struct {
+	maxGas            int64
+	maxOpcodes        int64
+	maxTime           time.Duration
+	maxIteratorValues int
+}
+
+// invokeLimitsKey is a context key for invokeLimits of the request.
+type invokeLimitsKey struct{}
+
+// runtimeLimits are the Server settings that can be changed without restart
+// (see Reconfigure).
+type runtimeLimits struct {
+	// invoke are default test invocation limits, invokeTiers override
+	// them for clients with API keys.
+	invoke      invokeLimits
+	invokeTiers map[string]*invokeLimits
+
+	maxFindResultItems        int
+	maxFindStorageResultItems int
+	maxNEP11Tokens            int
+	maxRequestBodyBytes       int
+	maxWebSocketClients       int
+	redaction                 config.RPCRedaction
+}
+
+// newRuntimeLimits creates runtimeLimits from the RPC configuration with
+// defaults applied.
+func newRuntimeLimits(conf config.RPC) *runtimeLimits {
+	def, tiers := newInvokeLimits(conf)
+	return &runtimeLimits{
+		invoke:                    def,
+		invokeTiers:               tiers,
+		maxFindResultItems:        conf.MaxFindResultItems,
+		maxFindStorageResultItems: conf.MaxFindStorageResultItems,
+		maxNEP11Tokens:            conf.MaxNEP11Tokens,
+		maxRequestBodyBytes:       conf.MaxRequestBodyBytes,
+		maxWebSocketClients:       conf.MaxWebSocketClients,
+		redaction:                 conf.Redaction,
+	}
+}
+
+// withoutRuntimeLimits returns a copy of the given configuration with all
+// settings of runtimeLimits reset, so that configurations differing only in
+// these settings are equal.
+func withoutRuntimeLimits(conf config.RPC) config.RPC {
+	conf.InvokeTiers = nil
+	conf.MaxGasInvoke = 0
+	conf.MaxInvokeTime = 0
+	conf.MaxIteratorResultItems = 0
+	conf.MaxFindResultItems = 0
+	conf.MaxFindStorageResultItems = 0
+	conf.MaxNEP11Tokens = 0
+	conf.MaxOpcodesInvoke = 0
+	conf.MaxRequestBodyBytes = 0
+	conf.MaxWebSocketClients = 0
+	conf.Redaction = config.RPCRedaction{}
+	return conf
+}
+
+// Reconfigure applies the given configuration to the running Server if it
+// differs from the current one only in limits (MaxGasInvoke, MaxInvokeTime,
+// MaxOpcodesInvoke, MaxIteratorResultItems, InvokeTiers, MaxFindResultItems,
+// MaxFindStoragePageSize, MaxNEP11Tokens, MaxRequestBodyBytes,
+// MaxWebSocketClients) and Redaction settings. Requests being processed keep
+// using the old limits, established connections are not affected. It returns
+// false and changes nothing if other settings are different, the Server has
+// to be restarted to apply such a configuration.
+func (s *Server) Reconfigure(conf config.RPC) bool {
+	conf = applyDefaults(conf, s.chain.GetConfig().ProtocolConfiguration, s.log)
+	if !reflect.DeepEqual(withoutRuntimeLimits(s.config), withoutRuntimeLimits(conf)) {
+		return false
+	}
+	s.limits.Store(newRuntimeLimits(conf))
+	return true
+}
+
+// newInvokeLimits creates default invocation limits from the RPC configuration
+// and per-API-key overrides from its InvokeTiers.
+func newInvokeLimits(conf config.RPC) (invokeLimits, map[string]*invokeLimits) {
+	var (
+		def = invokeLimits{
+			maxGas:            int64(conf.MaxGasInvoke),
+			maxOpcodes:        conf.MaxOpcodesInvoke,
+			maxTime:           conf.MaxInvokeTime,
+			maxIteratorValues: conf.MaxIteratorResultItems,
+		}
+		tiers = make(map[string]*invokeLimits)
+	)
+	for _, t := range conf.InvokeTiers {
+		lim := def
+		if t.MaxGasInvoke != 0 {
+			lim.maxGas = int64(t.MaxGasInvoke)
+		}
+		if t.MaxOpcodesInvoke != 0 {
+			lim.maxOpcodes = t.MaxOpcodesInvoke
+		}
+		if t.MaxInvokeTime != 0 {
+			lim.maxTime = t.MaxInvokeTime
+		}
+		if t.MaxIteratorResultItems != 0 {
+			lim.maxIteratorValues = t.MaxIteratorResultItems
+		}
+		for _, k := range t.APIKeys {
+			tiers[k] = &lim
+		}
+	}
+	return def, tiers
+}
+
+// withInvokeLimits returns a context with invocation limits for the client
+// identified by API key from the "Authorization: Bearer <key>" header of the
+// given HTTP request. Requests without API key get default limits, requests
+// with unknown keys are rejected.
+func (s *Server) withInvokeLimits(ctx context.Context, r *http.Request) (context.Context, error) {
+	var (
+		auth  = r.Header.Get("Authorization")
+		tiers = s.limits.Load().invokeTiers
+	)
+	if auth == "" || len(tiers) == 0 {
+		return ctx, nil
+	}
+	key, ok := strings.CutPrefix(auth, "Bearer ")
+	if !ok {
+		return nil, errUnknownAPIKey
+	}
+	lim, ok := tiers[key]
+	if !ok {
+		return nil, errUnknownAPIKey
+	}
+	return context.WithValue(ctx, invokeLimitsKey{}, lim), nil
+}
+
+// getInvokeLimits returns invocation limits for the request with the given
+// context.
+func (s *Server) getInvokeLimits(ctx context.Context) *invokeLimits {
+	if lim, ok := ctx.Value(invokeLimitsKey{}).(*invokeLimits); ok {
+		return lim
+	}
+	return &s.limits.Load().invoke
+}
@@ -0,0 +1,173 @@
+package params
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Kind is a type of RPC method parameter used in Schema.
+type Kind byte
+
+// Parameter kinds, every kind corresponds to some Param getter that is used
+// to validate it.
+const (
+	// Any is a parameter of any type, it's not validated.
+	Any Kind = iota
+	// Bool is a boolean parameter, see [Param.GetBoolean].
+	Bool
+	// Int is an integer parameter, see [Param.GetInt].
+	Int
+	// Uint is a non-negative integer parameter, see [Param.GetInt].
+	Uint
+	// String is a string parameter, see [Param.GetString].
+	String
+	// Hash160 is a hex-encoded Uint160 parameter, see [Param.GetUint160FromHex].
+	Hash160
+	// Account is a Uint160 parameter passed either as an address or as a hex
+	// string, see [Param.GetUint160FromAddressOrHex].
+	Account
+	// Hash256 is a hex-encoded Uint256 parameter, see [Param.GetUint256].
+	Hash256
+	// BytesHex is a hex-encoded byte slice parameter, see [Param.GetBytesHex].
+	BytesHex
+	// BytesBase64 is a base64-encoded byte slice parameter, see
+	// [Param.GetBytesBase64].
+	BytesBase64
+	// UUID is a UUID parameter, see [Param.GetUUID].
+	UUID
+	// Array is an array parameter, see [Param.GetArray].
+	Array
+)
+
+// Spec describes a single RPC method parameter.
+type Spec struct {
+	// Name is a human-readable parameter name used in error messages and
+	// documentation.
+	Name string
+	// Kind is the expected type of the parameter.
+	Kind Kind
+	// Optional parameters can be omitted or passed as null (handlers treat
+	// both cases the same way), other values are validated.
+	Optional bool
+	// Max is the maximum allowed value for Int and Uint parameters, zero
+	// means no limit.
+	Max int
+}
+
+// Schema describes RPC method parameters in the order they're passed in.
+// Parameters not described by the schema are not checked, so that the same
+// schema can be used for methods accepting some extensions.
+type Schema []Spec
+
+var kindNames = map[Kind]string{
+	Any:         "any",
+	Bool:        "bool",
+	Int:         "int",
+	Uint:        "uint",
+	String:      "string",
+	Hash160:     "hash160",
+	Account:     "address or hash160",
+	Hash256:     "hash256",
+	BytesHex:    "hex bytes",
+	BytesBase64: "base64 bytes",
+	UUID:        "uuid",
+	Array:       "array",
+}
+
+// String implements the fmt.Stringer interface.
+func (k Kind) String() string {
+	if s, ok := kindNames[k]; ok {
+		return s
+	}
+	return fmt.Sprintf("unknown kind (%d)", byte(k))
+}
+
+// String returns the parameter description in "name: kind" format, optional
+// parameters are wrapped into square brackets.
+func (s Spec) String() string {
+	var res = s.Name + ": " + s.Kind.String()
+	if s.Max != 0 {
+		res += fmt.Sprintf(" (%d at max)", s.Max)
+	}
+	if s.Optional {
+		res = "[" + res + "]"
+	}
+	return res
+}
+
+// String returns a comma-separated description of all parameters.
+func (s Schema) String() string {
+	var res = make([]string, len(s))
+	for i := range s {
+		res[i] = s[i].String()
+	}
+	return strings.Join(res, ", ")
+}
+
+// Usage returns the description of the method call with the given name.
+func (s Schema) Usage(method string) string {
+	return method + "(" + s.String() + ")"
+}
+
+// Validate checks the given parameters against the schema. Handlers can rely
+// on successfully validated parameters to be parsed by the corresponding
+// getter without errors.
+func (s Schema) Validate(ps Params) error {
+	for i, spec := range s {
+		p := ps.Value(i)
+		if p == nil {
+			if spec.Optional {
+				// All subsequent parameters are missing too.
+				return nil
+			}
+			return fmt.Errorf("missing %s parameter (#%d)", spec.Name, i)
+		}
+		if spec.Optional && p.IsNull() {
+			continue
+		}
+		if err := spec.validate(p); err != nil {
+			return fmt.Errorf("invalid %s parameter (#%d): %w", spec.Name, i, err)
+		}
+	}
+	return nil
+}
+
+func (s Spec) validate(p *Param) error {
+	var err error
+	switch s.Kind {
+	case Any:
+	case Bool:
+		_, err = p.GetBoolean()
+	case Int, Uint:
+		var v int
+		v, err = p.GetInt()
+		if err != nil {
+			break
+		}
+		if s.Kind == Uint && v < 0 {
+			err = errors.New("negative value")
+		} else if s.Max != 0 && v > s.Max {
+			err = fmt.Errorf("%d is out of range (%d at max)", v, s.Max)
+		}
+	case String:
+		_, err = p.GetString()
+	case Hash160:
+		_, err = p.GetUint160FromHex()
+	case Account:
+		_, err = p.GetUint160FromAddressOrHex()
+	case Hash256:
+		_, err = p.GetUint256()
+	case BytesHex:
+		_, err = p.GetBytesHex()
+	case BytesBase64:
+		_, err = p.GetBytesBase64()
+	case UUID:
+		_, err = p.GetUUID()
+	case Array:
+		_, err = p.GetArray()
+	default:
+		err = fmt.Errorf("unknown parameter kind %d", s.Kind)
+	}
+	return err
+}
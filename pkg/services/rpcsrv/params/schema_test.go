@@ -0,0 +1,64 @@
+package params
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaValidate(t *testing.T) {
+	schema := Schema{
+		{Name: "hash", Kind: Hash256},
+		{Name: "account", Kind: Account},
+		{Name: "count", Kind: Uint, Max: 10},
+		{Name: "id", Kind: UUID, Optional: true},
+		{Name: "key", Kind: BytesBase64, Optional: true},
+	}
+	const (
+		hash = `"0xe1e7f6f37bf5d1b18a50f9ec2a1bd5c4bb0af5ebb1e1a5e4c8ee39f40fb8ff28"`
+		acc  = `"NbTiM6h8r99kpRtb428XcsUk1TzKed2gTc"`
+	)
+	testCases := []struct {
+		name   string
+		params string
+		err    string
+	}{
+		{"good", `[` + hash + `, ` + acc + `, 1]`, ""},
+		{"good, all", `[` + hash + `, ` + acc + `, 10, "6d1ed5c6-8e33-4ab5-9a0e-97a4b2b8dee5", "AQI="]`, ""},
+		{"extra parameters", `[` + hash + `, ` + acc + `, 1, "6d1ed5c6-8e33-4ab5-9a0e-97a4b2b8dee5", "AQI=", 42]`, ""},
+		{"missing", `[` + hash + `, ` + acc + `]`, "missing count parameter (#2)"},
+		{"bad hash", `["0x42", ` + acc + `, 1]`, "invalid hash parameter (#0)"},
+		{"bad account", `[` + hash + `, "NbTiM6h8r99kpRtb428XcsUk1TzKed2gTd", 1]`, "invalid account parameter (#1)"},
+		{"negative", `[` + hash + `, ` + acc + `, -1]`, "invalid count parameter (#2): negative value"},
+		{"too big", `[` + hash + `, ` + acc + `, 11]`, "invalid count parameter (#2): 11 is out of range (10 at max)"},
+		{"null optional", `[` + hash + `, ` + acc + `, 1, null]`, ""},
+		{"null optional, good next", `[` + hash + `, ` + acc + `, 1, null, "AQI="]`, ""},
+		{"null optional, bad next", `[` + hash + `, ` + acc + `, 1, null, "!"]`, "invalid key parameter (#4)"},
+		{"null required", `[` + hash + `, null, 1]`, "invalid account parameter (#1)"},
+		{"bad optional", `[` + hash + `, ` + acc + `, 1, "6d1ed5c6-8e33-4ab5-9a0e-97a4b2b8dee5", "!"]`, "invalid key parameter (#4)"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var ps Params
+			require.NoError(t, json.Unmarshal([]byte(tc.params), &ps))
+			err := schema.Validate(ps)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestSchemaUsage(t *testing.T) {
+	schema := Schema{
+		{Name: "hash", Kind: Hash256},
+		{Name: "count", Kind: Uint, Max: 10},
+		{Name: "verbose", Kind: Bool, Optional: true},
+	}
+	require.Equal(t, "method(hash: hash256, count: uint (10 at max), [verbose: bool])", schema.Usage("method"))
+	require.Equal(t, "method()", Schema{}.Usage("method"))
+	require.Equal(t, "unknown kind (255)", Kind(255).String())
+}
@@ -1,24 +1,105 @@
 package rpcsrv
 
 import (
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+// unknownMethodLabel is used instead of the method name for unsupported
+// methods to keep metrics cardinality bounded.
+const unknownMethodLabel = "unknown"
+
 // Metrics used in monitoring service.
 var (
 	rpcTimes = map[string]prometheus.Histogram{}
+
+	rpcRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Help:      "RPC request handling time",
+			Name:      "rpc_request_duration_seconds",
+			Namespace: "neogo",
+		},
+		[]string{"method"},
+	)
+	rpcRequestsInFlight = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Number of RPC requests being handled",
+			Name:      "rpc_requests_in_flight",
+			Namespace: "neogo",
+		},
+	)
+	rpcErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of RPC error responses",
+			Name:      "rpc_errors_total",
+			Namespace: "neogo",
+		},
+		[]string{"method", "code"},
+	)
+	rpcSubscribers = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Number of connected websocket and local RPC clients",
+			Name:      "rpc_subscribers",
+			Namespace: "neogo",
+		},
+	)
+	rpcSubscriptions = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Help:      "Number of active RPC event subscriptions",
+			Name:      "rpc_subscriptions",
+			Namespace: "neogo",
+		},
+		[]string{"event"},
+	)
+	rpcSessions = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Number of active RPC iterator sessions",
+			Name:      "rpc_iterator_sessions",
+			Namespace: "neogo",
+		},
+	)
 )
 
-func addReqTimeMetric(name string, t time.Duration) {
+// startReqMetric accounts for a new in-flight request and returns its start
+// time to be passed to finishReqMetric.
+func startReqMetric() time.Time {
+	rpcRequestsInFlight.Inc()
+	return time.Now()
+}
+
+// finishReqMetric updates request metrics after the request is handled.
+func finishReqMetric(name string, start time.Time, err *neorpc.Error) {
+	var t = time.Since(start)
+
+	rpcRequestsInFlight.Dec()
 	hist, ok := rpcTimes[name]
 	if ok {
 		hist.Observe(t.Seconds())
+	} else {
+		name = unknownMethodLabel
+	}
+	rpcRequestDuration.WithLabelValues(name).Observe(t.Seconds())
+	if err != nil {
+		rpcErrors.WithLabelValues(name, strconv.FormatInt(err.Code, 10)).Inc()
 	}
 }
 
+func updateSubscribersMetric(n int) {
+	rpcSubscribers.Set(float64(n))
+}
+
+func addSubscriptionsMetric(event neorpc.EventID, delta int) {
+	rpcSubscriptions.WithLabelValues(event.String()).Add(float64(delta))
+}
+
+func addSessionsMetric(delta int) {
+	rpcSessions.Add(float64(delta))
+}
+
 func regCounter(call string) {
 	rpcTimes[call] = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
@@ -31,9 +112,20 @@ func regCounter(call string) {
 }
 
 func init() {
+	prometheus.MustRegister(
+		rpcRequestDuration,
+		rpcRequestsInFlight,
+		rpcErrors,
+		rpcSubscribers,
+		rpcSubscriptions,
+		rpcSessions,
+	)
 	for call := range rpcHandlers {
 		regCounter(call)
 	}
+	for call := range rpcCtxHandlers {
+		regCounter(call)
+	}
 	for call := range rpcWsHandlers {
 		regCounter(call)
 	}
@@ -2,8 +2,10 @@ package rpcsrv
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"crypto/elliptic"
+	"encoding/base64"
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
@@ -13,6 +15,8 @@ import (
 	"math/big"
 	"net"
 	"net/http"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -26,12 +30,15 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/iterator"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempoolevent"
 	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
@@ -68,8 +75,10 @@ type (
 		CalculateClaimable(h util.Uint160, endHeight uint32) (*big.Int, error)
 		CurrentBlockHash() util.Uint256
 		FeePerByte() int64
-		ForEachNEP11Transfer(acc util.Uint160, newestTimestamp uint64, f func(*state.NEP11Transfer) (bool, error)) error
-		ForEachNEP17Transfer(acc util.Uint160, newestTimestamp uint64, f func(*state.NEP17Transfer) (bool, error)) error
+		ForEachEventContainer(contract util.Uint160, event string, index uint32, start []byte, f func(pos []byte, c *state.EventContainer) bool) error
+		ForEachIndexedNotification(contract util.Uint160, event string, param int, value []byte, start []byte, f func(pos []byte, ne *state.ContainedNotificationEvent) bool) error
+		ForEachNEP11TransferFiltered(acc util.Uint160, filter state.TransferFilter, newestTimestamp uint64, f func(*state.NEP11Transfer) (bool, error)) error
+		ForEachNEP17TransferFiltered(acc util.Uint160, filter state.TransferFilter, newestTimestamp uint64, f func(*state.NEP17Transfer) (bool, error)) error
 		GetAppExecResults(util.Uint256, trigger.Type) ([]state.AppExecResult, error)
 		GetBaseExecFee() int64
 		GetBlock(hash util.Uint256) (*block.Block, error)
@@ -91,7 +100,9 @@ type (
 		GetNotaryContractScriptHash() util.Uint160
 		GetStateModule() core.StateRoot
 		GetStorageItem(id int32, key []byte) state.StorageItem
+		GetStorageUsage(id int32) (*state.StorageUsage, error)
 		GetTestHistoricVM(t trigger.Type, tx *transaction.Transaction, nextBlockHeight uint32) (*interop.Context, error)
+		GetTestHistoricVMWithStore(t trigger.Type, tx *transaction.Transaction, nextBlockHeight uint32, mptStore storage.Store) (*interop.Context, error)
 		GetTestVM(t trigger.Type, tx *transaction.Transaction, b *block.Block) (*interop.Context, error)
 		GetTokenLastUpdated(acc util.Uint160) (map[int32]uint32, error)
 		GetTransaction(util.Uint256) (*transaction.Transaction, uint32, error)
@@ -125,6 +136,12 @@ type (
 		AddResponse(pub *keys.PublicKey, reqID uint64, txSig []byte)
 	}
 
+	// BlockMiner is the interface devnet block producer needs to provide for
+	// the Server.
+	BlockMiner interface {
+		Mine(count int) ([]util.Uint256, error)
+	}
+
 	// Server represents the JSON-RPC 2.0 server.
 	Server struct {
 		http  []*http.Server
@@ -139,10 +156,16 @@ type (
 		stateRootEnabled bool
 		coreServer       *network.Server
 		oracle           *atomic.Value
+		miner            atomic.Value
 		log              *zap.Logger
 		shutdown         chan struct{}
 		started          atomic.Bool
 		errChan          chan<- error
+		resultSigner     *resultSigner
+		stateArchive     *stateArchive
+		// limits are the settings that can be changed with Reconfigure,
+		// config has outdated values of these.
+		limits atomic.Pointer[runtimeLimits]
 
 		sessionsLock sync.Mutex
 		sessions     map[string]*session
@@ -205,60 +228,93 @@ const (
 
 	// defaultSessionPoolSize is the number of concurrently running iterator sessions.
 	defaultSessionPoolSize = 20
+
+	// Number of getmempoolinfo fee histogram buckets, minimum fee per byte
+	// doubles with every bucket starting from the policy FeePerByte.
+	mempoolFeeBuckets = 16
+
+	// Default and maximum getblocktimestats window sizes (in blocks) and the
+	// maximum number of windows per request.
+	defaultBlockTimeWindow = 100
+	maxBlockTimeWindow     = 10000
+	maxBlockTimeWindows    = 16
+
+	// Default and maximum number of blocks returned by getblocksrange and
+	// the size of the response (in bytes of serialized blocks) after which
+	// no more blocks are added to it.
+	defaultBlocksRangeCount = 100
+	maxBlocksRangeCount     = 1000
+	maxBlocksRangeSize      = 16 * 1024 * 1024
 )
 
 var rpcHandlers = map[string]func(*Server, params.Params) (any, *neorpc.Error){
-	"calculatenetworkfee":          (*Server).calculateNetworkFee,
+	"calculatenetworkfee":      (*Server).calculateNetworkFee,
+	"devnet_mine":              (*Server).devnetMine,
+	"findeventcontainers":      (*Server).findEventContainers,
+	"findnotifications":        (*Server).findNotifications,
+	"findstorage":              (*Server).findStorage,
+	"findstoragehistoric":      (*Server).findStorageHistoric,
+	"getapplicationlog":        (*Server).getApplicationLog,
+	"getbestblockhash":         (*Server).getBestBlockHash,
+	"getblock":                 (*Server).getBlock,
+	"getblockcount":            (*Server).getBlockCount,
+	"getblockeconomics":        (*Server).getBlockEconomics,
+	"getblockexecutionsummary": (*Server).getBlockExecutionSummary,
+	"getblockhash":             (*Server).getBlockHash,
+	"getblocktimestats":        (*Server).getBlockTimeStats,
+	"getblockheader":           (*Server).getBlockHeader,
+	"getblockheadercount":      (*Server).getBlockHeaderCount,
+	"getblocksrange":           (*Server).getBlocksRange,
+	"getblocksysfee":           (*Server).getBlockSysFee,
+	"getcandidates":            (*Server).getCandidates,
+	"getcommittee":             (*Server).getCommittee,
+	"getconnectioncount":       (*Server).getConnectionCount,
+	"getcontractstate":         (*Server).getContractState,
+	"getcontractstorageusage":  (*Server).getContractStorageUsage,
+	"getnativecontracts":       (*Server).getNativeContracts,
+	"getnep11balances":         (*Server).getNEP11Balances,
+	"getnep11properties":       (*Server).getNEP11Properties,
+	"getnep11transfers":        (*Server).getNEP11Transfers,
+	"getnep17balances":         (*Server).getNEP17Balances,
+	"getnep17transfers":        (*Server).getNEP17Transfers,
+	"getpeers":                 (*Server).getPeers,
+	"getproof":                 (*Server).getProof,
+	"getmempoolinfo":           (*Server).getMempoolInfo,
+	"getrawmempool":            (*Server).getRawMempool,
+	"getrawnotarypool":         (*Server).getRawNotaryPool,
+	"getrawnotarytransaction":  (*Server).getRawNotaryTransaction,
+	"getrawtransaction":        (*Server).getrawtransaction,
+	"getstate":                 (*Server).getState,
+	"getstateheight":           (*Server).getStateHeight,
+	"getstateroot":             (*Server).getStateRoot,
+	"getstorage":               (*Server).getStorage,
+	"getstoragehistoric":       (*Server).getStorageHistoric,
+	"getstorageitemscount":     (*Server).getStorageItemsCount,
+	"gettransactionheight":     (*Server).getTransactionHeight,
+	"getunclaimedgas":          (*Server).getUnclaimedGas,
+	"getnextblockvalidators":   (*Server).getNextBlockValidators,
+	"getversion":               (*Server).getVersion,
+	"sendrawtransaction":       (*Server).sendrawtransaction,
+	"submitblock":              (*Server).submitBlock,
+	"submitnotaryrequest":      (*Server).submitNotaryRequest,
+	"submitoracleresponse":     (*Server).submitOracleResponse,
+	"terminatesession":         (*Server).terminateSession,
+	"validateaddress":          (*Server).validateAddress,
+	"verifyproof":              (*Server).verifyProof,
+}
+
+// rpcCtxHandlers are handlers for potentially long-running requests, they're
+// provided with the request context and stop processing (releasing VM and DB
+// resources) when it's done, i.e. when the client is gone.
+var rpcCtxHandlers = map[string]func(*Server, context.Context, params.Params) (any, *neorpc.Error){
 	"findstates":                   (*Server).findStates,
-	"findstorage":                  (*Server).findStorage,
-	"findstoragehistoric":          (*Server).findStorageHistoric,
-	"getapplicationlog":            (*Server).getApplicationLog,
-	"getbestblockhash":             (*Server).getBestBlockHash,
-	"getblock":                     (*Server).getBlock,
-	"getblockcount":                (*Server).getBlockCount,
-	"getblockhash":                 (*Server).getBlockHash,
-	"getblockheader":               (*Server).getBlockHeader,
-	"getblockheadercount":          (*Server).getBlockHeaderCount,
-	"getblocksysfee":               (*Server).getBlockSysFee,
-	"getcandidates":                (*Server).getCandidates,
-	"getcommittee":                 (*Server).getCommittee,
-	"getconnectioncount":           (*Server).getConnectionCount,
-	"getcontractstate":             (*Server).getContractState,
-	"getnativecontracts":           (*Server).getNativeContracts,
-	"getnep11balances":             (*Server).getNEP11Balances,
-	"getnep11properties":           (*Server).getNEP11Properties,
-	"getnep11transfers":            (*Server).getNEP11Transfers,
-	"getnep17balances":             (*Server).getNEP17Balances,
-	"getnep17transfers":            (*Server).getNEP17Transfers,
-	"getpeers":                     (*Server).getPeers,
-	"getproof":                     (*Server).getProof,
-	"getrawmempool":                (*Server).getRawMempool,
-	"getrawnotarypool":             (*Server).getRawNotaryPool,
-	"getrawnotarytransaction":      (*Server).getRawNotaryTransaction,
-	"getrawtransaction":            (*Server).getrawtransaction,
-	"getstate":                     (*Server).getState,
-	"getstateheight":               (*Server).getStateHeight,
-	"getstateroot":                 (*Server).getStateRoot,
-	"getstorage":                   (*Server).getStorage,
-	"getstoragehistoric":           (*Server).getStorageHistoric,
-	"gettransactionheight":         (*Server).getTransactionHeight,
-	"getunclaimedgas":              (*Server).getUnclaimedGas,
-	"getnextblockvalidators":       (*Server).getNextBlockValidators,
-	"getversion":                   (*Server).getVersion,
 	"invokefunction":               (*Server).invokeFunction,
 	"invokefunctionhistoric":       (*Server).invokeFunctionHistoric,
 	"invokescript":                 (*Server).invokescript,
 	"invokescripthistoric":         (*Server).invokescripthistoric,
 	"invokecontractverify":         (*Server).invokeContractVerify,
 	"invokecontractverifyhistoric": (*Server).invokeContractVerifyHistoric,
-	"sendrawtransaction":           (*Server).sendrawtransaction,
-	"submitblock":                  (*Server).submitBlock,
-	"submitnotaryrequest":          (*Server).submitNotaryRequest,
-	"submitoracleresponse":         (*Server).submitOracleResponse,
-	"terminatesession":             (*Server).terminateSession,
 	"traverseiterator":             (*Server).traverseIterator,
-	"validateaddress":              (*Server).validateAddress,
-	"verifyproof":                  (*Server).verifyProof,
 }
 
 var rpcWsHandlers = map[string]func(*Server, params.Params, *subscriber) (any, *neorpc.Error){
@@ -266,49 +322,142 @@ var rpcWsHandlers = map[string]func(*Server, params.Params, *subscriber) (any, *
 	"unsubscribe": (*Server).unsubscribe,
 }
 
+// rpcRequirements are checks for RPC methods depending on optional node
+// features, they're performed before parameter validation.
+var rpcRequirements = map[string]func(*Server) *neorpc.Error{
+	"getproof":                (*Server).requireProofs,
+	"getrawnotarypool":        (*Server).requireP2PSigExtensions,
+	"getrawnotarytransaction": (*Server).requireP2PSigExtensions,
+	"submitnotaryrequest":     (*Server).requireP2PSigExtensions,
+	"terminatesession":        (*Server).requireSessions,
+	"traverseiterator":        (*Server).requireSessions,
+	"verifyproof":             (*Server).requireProofs,
+}
+
+// rpcSchemas are parameter schemas of RPC methods. Parameters are validated
+// against them before calling the handler, so handlers don't check parameters
+// described here and all of these methods return the same errors for the same
+// kind of bad parameter.
+var rpcSchemas = map[string]params.Schema{
+	"findstates": {
+		{Name: "root", Kind: params.Any},
+		{Name: "contract", Kind: params.Hash160},
+		{Name: "prefix", Kind: params.BytesBase64},
+		{Name: "start", Kind: params.BytesBase64, Optional: true},
+		{Name: "count", Kind: params.Int, Optional: true},
+	},
+	"findstorage": {
+		{Name: "contract", Kind: params.Any},
+		{Name: "prefix", Kind: params.BytesBase64},
+		{Name: "start", Kind: params.Int, Optional: true},
+	},
+	"findstoragehistoric": {
+		{Name: "root", Kind: params.Any},
+		{Name: "contract", Kind: params.Any},
+		{Name: "prefix", Kind: params.BytesBase64},
+		{Name: "start", Kind: params.Int, Optional: true},
+	},
+	"getapplicationlog": {
+		{Name: "hash", Kind: params.Hash256},
+		{Name: "trigger", Kind: params.String, Optional: true},
+		{Name: "offset", Kind: params.Uint, Optional: true},
+		{Name: "limit", Kind: params.Uint, Optional: true},
+	},
+	"getblock": {
+		{Name: "block", Kind: params.Any},
+		{Name: "verbose", Kind: params.Bool, Optional: true},
+	},
+	"getblockhash": {
+		{Name: "index", Kind: params.Int},
+	},
+	"getblockheader": {
+		{Name: "block", Kind: params.Any},
+		{Name: "verbose", Kind: params.Bool, Optional: true},
+	},
+	"getblocksysfee": {
+		{Name: "index", Kind: params.Int},
+	},
+	"getcontractstate": {
+		{Name: "contract", Kind: params.Any},
+	},
+	"getnep11balances": {
+		{Name: "account", Kind: params.Account},
+	},
+	"getnep11properties": {
+		{Name: "asset", Kind: params.Account},
+		{Name: "token", Kind: params.BytesHex},
+	},
+	"getnep17balances": {
+		{Name: "account", Kind: params.Account},
+	},
+	"getproof": {
+		{Name: "root", Kind: params.Hash256},
+		{Name: "contract", Kind: params.Hash160},
+		{Name: "key", Kind: params.BytesBase64},
+	},
+	"getrawmempool": {
+		{Name: "verbose", Kind: params.Bool, Optional: true},
+	},
+	"getrawnotarytransaction": {
+		{Name: "hash", Kind: params.Hash256},
+		{Name: "verbose", Kind: params.Bool, Optional: true},
+	},
+	"getrawtransaction": {
+		{Name: "hash", Kind: params.Hash256},
+		{Name: "verbose", Kind: params.Bool, Optional: true},
+	},
+	"getstate": {
+		{Name: "root", Kind: params.Any},
+		{Name: "contract", Kind: params.Hash160},
+		{Name: "key", Kind: params.BytesBase64},
+	},
+	"getstorage": {
+		{Name: "contract", Kind: params.Any},
+		{Name: "key", Kind: params.BytesBase64},
+	},
+	"getstoragehistoric": {
+		{Name: "root", Kind: params.Any},
+		{Name: "contract", Kind: params.Any},
+		{Name: "key", Kind: params.BytesBase64},
+	},
+	"gettransactionheight": {
+		{Name: "hash", Kind: params.Hash256},
+	},
+	"getunclaimedgas": {
+		{Name: "account", Kind: params.Account},
+	},
+	"sendrawtransaction": {
+		{Name: "tx", Kind: params.BytesBase64},
+	},
+	"submitblock": {
+		{Name: "block", Kind: params.BytesBase64},
+	},
+	"submitnotaryrequest": {
+		{Name: "payload", Kind: params.BytesBase64},
+	},
+	"terminatesession": {
+		{Name: "session", Kind: params.UUID},
+	},
+	"traverseiterator": {
+		{Name: "session", Kind: params.UUID},
+		{Name: "iterator", Kind: params.UUID},
+		{Name: "count", Kind: params.Uint, Max: math.MaxInt32},
+	},
+	"validateaddress": {
+		{Name: "address", Kind: params.String},
+	},
+	"verifyproof": {
+		{Name: "root", Kind: params.Hash256},
+		{Name: "proof", Kind: params.String},
+	},
+}
+
 // New creates a new Server struct. Pay attention that orc is expected to be either
 // untyped nil or non-nil structure implementing OracleHandler interface.
 func New(chain Ledger, conf config.RPC, coreServer *network.Server,
 	orc OracleHandler, log *zap.Logger, errChan chan<- error) *Server {
 	protoCfg := chain.GetConfig().ProtocolConfiguration
-	if conf.SessionEnabled {
-		if conf.SessionExpirationTime <= 0 {
-			conf.SessionExpirationTime = max(int(protoCfg.TimePerBlock/time.Second), 5)
-			log.Info("SessionExpirationTime is not set or wrong, setting default value", zap.Int("SessionExpirationTime", conf.SessionExpirationTime))
-		}
-		if conf.SessionPoolSize <= 0 {
-			conf.SessionPoolSize = defaultSessionPoolSize
-			log.Info("SessionPoolSize is not set or wrong, setting default value", zap.Int("SessionPoolSize", defaultSessionPoolSize))
-		}
-	}
-	if conf.MaxIteratorResultItems <= 0 {
-		conf.MaxIteratorResultItems = config.DefaultMaxIteratorResultItems
-		log.Info("MaxIteratorResultItems is not set or wrong, setting default value", zap.Int("MaxIteratorResultItems", config.DefaultMaxIteratorResultItems))
-	}
-	if conf.MaxFindResultItems <= 0 {
-		conf.MaxFindResultItems = config.DefaultMaxFindResultItems
-		log.Info("MaxFindResultItems is not set or wrong, setting default value", zap.Int("MaxFindResultItems", config.DefaultMaxFindResultItems))
-	}
-	if conf.MaxFindStorageResultItems <= 0 {
-		conf.MaxFindStorageResultItems = config.DefaultMaxFindStorageResultItems
-		log.Info("MaxFindStorageResultItems is not set or wrong, setting default value", zap.Int("MaxFindStorageResultItems", config.DefaultMaxFindStorageResultItems))
-	}
-	if conf.MaxNEP11Tokens <= 0 {
-		conf.MaxNEP11Tokens = config.DefaultMaxNEP11Tokens
-		log.Info("MaxNEP11Tokens is not set or wrong, setting default value", zap.Int("MaxNEP11Tokens", config.DefaultMaxNEP11Tokens))
-	}
-	if conf.MaxRequestBodyBytes <= 0 {
-		conf.MaxRequestBodyBytes = config.DefaultMaxRequestBodyBytes
-		log.Info("MaxRequestBodyBytes is not set or wong, setting default value", zap.Int("MaxRequestBodyBytes", config.DefaultMaxRequestBodyBytes))
-	}
-	if conf.MaxRequestHeaderBytes <= 0 {
-		conf.MaxRequestHeaderBytes = config.DefaultMaxRequestHeaderBytes
-		log.Info("MaxRequestHeaderBytes is not set or wong, setting default value", zap.Int("MaxRequestHeaderBytes", config.DefaultMaxRequestHeaderBytes))
-	}
-	if conf.MaxWebSocketClients == 0 {
-		conf.MaxWebSocketClients = defaultMaxWebSocketClients
-		log.Info("MaxWebSocketClients is not set or wrong, setting default value", zap.Int("MaxWebSocketClients", defaultMaxWebSocketClients))
-	}
+	conf = applyDefaults(conf, protoCfg, log)
 	var oracleWrapped = new(atomic.Value)
 	if orc != nil {
 		oracleWrapped.Store(orc)
@@ -339,7 +488,7 @@ func New(chain Ledger, conf config.RPC, coreServer *network.Server,
 		}
 	}
 
-	return &Server{
+	srv := &Server{
 		http:  httpServers,
 		https: tlsServers,
 
@@ -367,6 +516,52 @@ func New(chain Ledger, conf config.RPC, coreServer *network.Server,
 		blockHeaderCh:     make(chan *block.Header),
 		subEventsToExitCh: make(chan struct{}),
 	}
+	srv.limits.Store(newRuntimeLimits(conf))
+	return srv
+}
+
+// applyDefaults replaces unset or wrong RPC configuration values with the
+// default ones.
+func applyDefaults(conf config.RPC, protoCfg config.ProtocolConfiguration, log *zap.Logger) config.RPC {
+	if conf.SessionEnabled {
+		if conf.SessionExpirationTime <= 0 {
+			conf.SessionExpirationTime = max(int(protoCfg.TimePerBlock/time.Second), 5)
+			log.Info("SessionExpirationTime is not set or wrong, setting default value", zap.Int("SessionExpirationTime", conf.SessionExpirationTime))
+		}
+		if conf.SessionPoolSize <= 0 {
+			conf.SessionPoolSize = defaultSessionPoolSize
+			log.Info("SessionPoolSize is not set or wrong, setting default value", zap.Int("SessionPoolSize", defaultSessionPoolSize))
+		}
+	}
+	if conf.MaxIteratorResultItems <= 0 {
+		conf.MaxIteratorResultItems = config.DefaultMaxIteratorResultItems
+		log.Info("MaxIteratorResultItems is not set or wrong, setting default value", zap.Int("MaxIteratorResultItems", config.DefaultMaxIteratorResultItems))
+	}
+	if conf.MaxFindResultItems <= 0 {
+		conf.MaxFindResultItems = config.DefaultMaxFindResultItems
+		log.Info("MaxFindResultItems is not set or wrong, setting default value", zap.Int("MaxFindResultItems", config.DefaultMaxFindResultItems))
+	}
+	if conf.MaxFindStorageResultItems <= 0 {
+		conf.MaxFindStorageResultItems = config.DefaultMaxFindStorageResultItems
+		log.Info("MaxFindStorageResultItems is not set or wrong, setting default value", zap.Int("MaxFindStorageResultItems", config.DefaultMaxFindStorageResultItems))
+	}
+	if conf.MaxNEP11Tokens <= 0 {
+		conf.MaxNEP11Tokens = config.DefaultMaxNEP11Tokens
+		log.Info("MaxNEP11Tokens is not set or wrong, setting default value", zap.Int("MaxNEP11Tokens", config.DefaultMaxNEP11Tokens))
+	}
+	if conf.MaxRequestBodyBytes <= 0 {
+		conf.MaxRequestBodyBytes = config.DefaultMaxRequestBodyBytes
+		log.Info("MaxRequestBodyBytes is not set or wong, setting default value", zap.Int("MaxRequestBodyBytes", config.DefaultMaxRequestBodyBytes))
+	}
+	if conf.MaxRequestHeaderBytes <= 0 {
+		conf.MaxRequestHeaderBytes = config.DefaultMaxRequestHeaderBytes
+		log.Info("MaxRequestHeaderBytes is not set or wong, setting default value", zap.Int("MaxRequestHeaderBytes", config.DefaultMaxRequestHeaderBytes))
+	}
+	if conf.MaxWebSocketClients == 0 {
+		conf.MaxWebSocketClients = defaultMaxWebSocketClients
+		log.Info("MaxWebSocketClients is not set or wrong, setting default value", zap.Int("MaxWebSocketClients", defaultMaxWebSocketClients))
+	}
+	return conf
 }
 
 // Name returns service name.
@@ -382,10 +577,29 @@ func (s *Server) Start() {
 		s.log.Info("RPC server is not enabled")
 		return
 	}
+	if s.started.Load() {
+		s.log.Info("RPC server already started")
+		return
+	}
+	// The signer is initialized before the server is marked as started, so
+	// that it's never left in a half-started state on error.
+	signer, err := newResultSigner(s.config.ResultSigning)
+	if err != nil {
+		s.errChan <- fmt.Errorf("failed to initialize result signing: %w", err)
+		return
+	}
 	if !s.started.CompareAndSwap(false, true) {
+		signer.close()
 		s.log.Info("RPC server already started")
 		return
 	}
+	s.resultSigner = signer
+	archive, err := newStateArchive(s.config.StateArchive)
+	if err != nil {
+		s.errChan <- fmt.Errorf("failed to initialize state archive: %w", err)
+		return
+	}
+	s.stateArchive = archive
 
 	go s.handleSubEvents()
 
@@ -478,10 +692,16 @@ func (s *Server) Shutdown() {
 			}
 			session.iteratorsLock.Unlock()
 		}
+		addSessionsMetric(-len(s.sessions))
 		s.sessions = nil
 		s.sessionsLock.Unlock()
 	}
 
+	if s.stateArchive != nil {
+		s.stateArchive.close()
+	}
+	s.resultSigner.close()
+
 	// Wait for handleSubEvents to finish.
 	<-s.subEventsToExitCh
 	_ = s.log.Sync()
@@ -492,11 +712,23 @@ func (s *Server) SetOracleHandler(orc OracleHandler) {
 	s.oracle.Store(orc)
 }
 
+// SetBlockMiner allows to set devnet block producer used by the Server for
+// devnet_mine calls. It can be nil if devnet mode is disabled.
+func (s *Server) SetBlockMiner(m BlockMiner) {
+	s.miner.Store(&m)
+}
+
 func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Request) {
 	// Restrict request body before further processing.
-	httpRequest.Body = http.MaxBytesReader(w, httpRequest.Body, int64(s.config.MaxRequestBodyBytes))
+	httpRequest.Body = http.MaxBytesReader(w, httpRequest.Body, int64(s.limits.Load().maxRequestBodyBytes))
 	req := params.NewRequest()
 
+	reqCtx, err := s.withInvokeLimits(httpRequest.Context(), httpRequest)
+	if err != nil {
+		s.writeHTTPErrorResponse(params.NewIn(), w, neorpc.NewInvalidRequestError(err.Error()))
+		return
+	}
+
 	if httpRequest.URL.Path == "/ws" && httpRequest.Method == "GET" {
 		// Technically there is a race between this check and
 		// s.subscribers modification 20 lines below, but it's tiny
@@ -505,7 +737,7 @@ func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Requ
 		s.subsLock.RLock()
 		numOfSubs := len(s.subscribers)
 		s.subsLock.RUnlock()
-		if numOfSubs >= s.config.MaxWebSocketClients {
+		if numOfSubs >= s.limits.Load().maxWebSocketClients {
 			s.writeHTTPErrorResponse(
 				params.NewIn(),
 				w,
@@ -523,9 +755,17 @@ func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Requ
 		subscr := &subscriber{writer: subChan}
 		s.subsLock.Lock()
 		s.subscribers[subscr] = true
+		updateSubscribersMetric(len(s.subscribers))
 		s.subsLock.Unlock()
-		go s.handleWsWrites(ws, resChan, subChan)
-		s.handleWsReads(ws, resChan, subscr)
+		// Hijacked connection context is not canceled on disconnect, so
+		// rely on the writer that detects it via ping failures.
+		ctx, cancel := context.WithCancel(reqCtx)
+		go func() {
+			s.handleWsWrites(ws, resChan, subChan)
+			cancel()
+		}()
+		s.handleWsReads(ctx, ws, resChan, subscr)
+		cancel()
 		return
 	}
 
@@ -545,13 +785,13 @@ func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Requ
 		return
 	}
 
-	err := req.DecodeData(httpRequest.Body)
+	err = req.DecodeData(httpRequest.Body)
 	if err != nil {
 		s.writeHTTPErrorResponse(params.NewIn(), w, neorpc.NewParseError(err.Error()))
 		return
 	}
 
-	resp := s.handleRequest(req, nil)
+	resp := s.handleRequest(reqCtx, req, nil)
 	s.writeHTTPServerResponse(req, w, resp)
 }
 
@@ -561,28 +801,29 @@ func (s *Server) RegisterLocal(ctx context.Context, events chan<- neorpc.Notific
 	subscr := &subscriber{writer: subChan}
 	s.subsLock.Lock()
 	s.subscribers[subscr] = true
+	updateSubscribersMetric(len(s.subscribers))
 	s.subsLock.Unlock()
 	go s.handleLocalNotifications(ctx, events, subChan, subscr)
 	return func(req *neorpc.Request) (*neorpc.Response, error) {
-		return s.handleInternal(req, subscr)
+		return s.handleInternal(ctx, req, subscr)
 	}
 }
 
-func (s *Server) handleRequest(req *params.Request, sub *subscriber) abstractResult {
+func (s *Server) handleRequest(ctx context.Context, req *params.Request, sub *subscriber) abstractResult {
 	if req.In != nil {
 		req.In.Method = escapeForLog(req.In.Method) // No valid method name will be changed by it.
-		return s.handleIn(req.In, sub)
+		return s.handleIn(ctx, req.In, sub)
 	}
 	resp := make(abstractBatch, len(req.Batch))
 	for i, in := range req.Batch {
 		in.Method = escapeForLog(in.Method) // No valid method name will be changed by it.
-		resp[i] = s.handleIn(&in, sub)
+		resp[i] = s.handleIn(ctx, &in, sub)
 	}
 	return resp
 }
 
 // handleInternal is an experimental interface to handle client requests directly.
-func (s *Server) handleInternal(req *neorpc.Request, sub *subscriber) (*neorpc.Response, error) {
+func (s *Server) handleInternal(ctx context.Context, req *neorpc.Request, sub *subscriber) (*neorpc.Response, error) {
 	var (
 		res    any
 		rpcRes = &neorpc.Response{
@@ -603,13 +844,14 @@ func (s *Server) handleInternal(req *neorpc.Request, sub *subscriber) (*neorpc.R
 		zap.String("method", req.Method),
 		zap.Stringer("params", reqParams))
 
-	start := time.Now()
-	defer func() { addReqTimeMetric(req.Method, time.Since(start)) }()
+	start := startReqMetric()
+	defer func() { finishReqMetric(req.Method, start, rpcRes.Error) }()
 
 	rpcRes.Error = neorpc.NewMethodNotFoundError(fmt.Sprintf("method %q not supported", req.Method))
-	handler, ok := rpcHandlers[req.Method]
-	if ok {
+	if handler, ok := rpcHandlers[req.Method]; ok {
 		res, rpcRes.Error = handler(s, reqParams)
+	} else if handler, ok := rpcCtxHandlers[req.Method]; ok {
+		res, rpcRes.Error = handler(s, ctx, reqParams)
 	} else if sub != nil {
 		handler, ok := rpcWsHandlers[req.Method]
 		if ok {
@@ -626,7 +868,7 @@ func (s *Server) handleInternal(req *neorpc.Request, sub *subscriber) (*neorpc.R
 	return rpcRes, nil
 }
 
-func (s *Server) handleIn(req *params.In, sub *subscriber) abstract {
+func (s *Server) handleIn(ctx context.Context, req *params.In, sub *subscriber) abstract {
 	var res any
 	var resErr *neorpc.Error
 	if req.JSONRPC != neorpc.JSONRPCVersion {
@@ -639,20 +881,37 @@ func (s *Server) handleIn(req *params.In, sub *subscriber) abstract {
 		zap.String("method", req.Method),
 		zap.Stringer("params", reqParams))
 
-	start := time.Now()
-	defer func() { addReqTimeMetric(req.Method, time.Since(start)) }()
+	start := startReqMetric()
+	defer func() { finishReqMetric(req.Method, start, resErr) }()
 
 	resErr = neorpc.NewMethodNotFoundError(fmt.Sprintf("method %q not supported", req.Method))
-	handler, ok := rpcHandlers[req.Method]
-	if ok {
+	if check, ok := rpcRequirements[req.Method]; ok {
+		if resErr = check(s); resErr != nil {
+			return s.packResponse(req, nil, resErr)
+		}
+	}
+	if schema, ok := rpcSchemas[req.Method]; ok {
+		if err := schema.Validate(reqParams); err != nil {
+			resErr = neorpc.NewInvalidParamsError(fmt.Sprintf("%s, expected %s", err, schema.Usage(req.Method)))
+			return s.packResponse(req, nil, resErr)
+		}
+	}
+	if handler, ok := rpcHandlers[req.Method]; ok {
 		res, resErr = handler(s, reqParams)
+	} else if handler, ok := rpcCtxHandlers[req.Method]; ok {
+		res, resErr = handler(s, ctx, reqParams)
 	} else if sub != nil {
 		handler, ok := rpcWsHandlers[req.Method]
 		if ok {
 			res, resErr = handler(s, reqParams, sub)
 		}
 	}
-	return s.packResponse(req, res, resErr)
+	resp := s.packResponse(req, res, resErr)
+	if signErr := s.resultSigner.sign(req, s.chain.BlockHeight(), &resp); signErr != nil {
+		resErr = signErr
+		resp = s.packResponse(req, nil, resErr)
+	}
+	return resp
 }
 
 func (s *Server) handleLocalNotifications(ctx context.Context, events chan<- neorpc.Notification, subChan <-chan intEvent, subscr *subscriber) {
@@ -732,7 +991,7 @@ drainloop:
 	}
 }
 
-func (s *Server) handleWsReads(ws *websocket.Conn, resChan chan<- abstractResult, subscr *subscriber) {
+func (s *Server) handleWsReads(ctx context.Context, ws *websocket.Conn, resChan chan<- abstractResult, subscr *subscriber) {
 	ws.SetReadLimit(s.wsReadLimit)
 	err := ws.SetReadDeadline(time.Now().Add(wsPongLimit))
 	ws.SetPongHandler(func(string) error { return ws.SetReadDeadline(time.Now().Add(wsPongLimit)) })
@@ -743,7 +1002,7 @@ requestloop:
 		if err != nil {
 			break
 		}
-		res := s.handleRequest(req, subscr)
+		res := s.handleRequest(ctx, req, subscr)
 		res.RunForErrors(func(jsonErr *neorpc.Error) {
 			s.logRequestError(req, jsonErr)
 		})
@@ -761,6 +1020,7 @@ requestloop:
 func (s *Server) dropSubscriber(subscr *subscriber) {
 	s.subsLock.Lock()
 	delete(s.subscribers, subscr)
+	updateSubscribersMetric(len(s.subscribers))
 	s.subsLock.Unlock()
 	s.subsCounterLock.Lock()
 	for _, e := range subscr.feeds {
@@ -843,6 +1103,47 @@ func (s *Server) getBlock(reqParams params.Params) (any, *neorpc.Error) {
 	return writer.Bytes(), nil
 }
 
+// getBlocksRange returns a number of consecutive serialized blocks starting
+// from the given index. The number of blocks is limited by the chain height
+// and by the size of the response, but at least one block is returned.
+func (s *Server) getBlocksRange(reqParams params.Params) (any, *neorpc.Error) {
+	start, respErr := s.blockHeightFromParam(reqParams.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	count := uint32(defaultBlocksRangeCount)
+	if len(reqParams) > 1 {
+		c, err := reqParams[1].GetInt()
+		if err != nil || c <= 0 || c > maxBlocksRangeCount {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid count: should be an integer in [1, %d] range", maxBlocksRangeCount))
+		}
+		count = uint32(c)
+	}
+	count = min(count, s.chain.BlockHeight()-start+1)
+
+	var (
+		res = &result.BlocksRange{Start: start}
+		buf = io.NewBufBinWriter()
+		w   = io.NewBufBinWriter()
+	)
+	for ; res.Count < count; res.Count++ {
+		b, err := s.chain.GetBlock(s.chain.GetHeaderHash(start + res.Count))
+		if err != nil {
+			return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to get block %d: %s", start+res.Count, err))
+		}
+		buf.Reset()
+		b.EncodeBinary(buf.BinWriter)
+		if res.Count > 0 && w.Len()+4+buf.Len() > maxBlocksRangeSize {
+			break
+		}
+		bytes := buf.Bytes()
+		w.WriteU32LE(uint32(len(bytes)))
+		w.WriteBytes(bytes)
+	}
+	res.Blocks = w.Bytes()
+	return res, nil
+}
+
 func (s *Server) getBlockHash(reqParams params.Params) (any, *neorpc.Error) {
 	num, err := s.blockHeightFromParam(reqParams.Value(0))
 	if err != nil {
@@ -871,6 +1172,10 @@ func (s *Server) getVersion(_ params.Params) (any, *neorpc.Error) {
 	if err != nil {
 		return nil, neorpc.NewInternalServerError(fmt.Sprintf("cannot fetch standbyCommittee: %s", err))
 	}
+	seedList := cfg.SeedList
+	if s.limits.Load().redaction.SeedList {
+		seedList = []string{}
+	}
 	return &result.Version{
 		TCPPort:   port,
 		Nonce:     s.coreServer.ID(),
@@ -880,7 +1185,7 @@ func (s *Server) getVersion(_ params.Params) (any, *neorpc.Error) {
 			SessionEnabled:         s.config.SessionEnabled,
 		},
 		Protocol: result.Protocol{
-			AddressVersion:              address.NEO3Prefix,
+			AddressVersion:              cfg.GetAddressVersion(),
 			Network:                     cfg.Magic,
 			MillisecondsPerBlock:        int(cfg.TimePerBlock / time.Millisecond),
 			MaxTraceableBlocks:          cfg.MaxTraceableBlocks,
@@ -891,7 +1196,7 @@ func (s *Server) getVersion(_ params.Params) (any, *neorpc.Error) {
 			InitialGasDistribution:      cfg.InitialGASSupply,
 			Hardforks:                   hfs,
 			StandbyCommittee:            standbyCommittee,
-			SeedList:                    cfg.SeedList,
+			SeedList:                    seedList,
 
 			CommitteeHistory:  cfg.CommitteeHistory,
 			P2PSigExtensions:  cfg.P2PSigExtensions,
@@ -906,9 +1211,30 @@ func (s *Server) getPeers(_ params.Params) (any, *neorpc.Error) {
 	peers.AddUnconnected(s.coreServer.UnconnectedPeers())
 	peers.AddConnected(s.coreServer.ConnectedPeers())
 	peers.AddBad(s.coreServer.BadPeers())
+	redactPeers(&peers, s.limits.Load().redaction, s.coreServer.SeedAddresses())
 	return peers, nil
 }
 
+// redactPeers applies the given redaction settings to the getpeers result.
+// Seeds (including the ones resolved from DNS seeds) are dropped from all lists
+// if the seed list is to be hidden, otherwise they could be trivially recovered
+// from peer sessions.
+func redactPeers(peers *result.GetPeers, r config.RPCRedaction, seeds []string) {
+	for _, list := range []*result.Peers{&peers.Unconnected, &peers.Connected, &peers.Bad} {
+		if r.SeedList {
+			*list = slices.DeleteFunc(*list, func(p result.Peer) bool {
+				return slices.Contains(seeds, net.JoinHostPort(p.Address, strconv.FormatUint(uint64(p.Port), 10)))
+			})
+		}
+		if r.PeerAddresses {
+			for i := range *list {
+				(*list)[i].Address = ""
+				(*list)[i].Port = 0
+			}
+		}
+	}
+}
+
 func (s *Server) getRawMempool(reqParams params.Params) (any, *neorpc.Error) {
 	verbose, _ := reqParams.Value(0).GetBoolean()
 	mp := s.chain.GetMemPool()
@@ -927,12 +1253,54 @@ func (s *Server) getRawMempool(reqParams params.Params) (any, *neorpc.Error) {
 	}, nil
 }
 
-func (s *Server) validateAddress(reqParams params.Params) (any, *neorpc.Error) {
-	param, err := reqParams.Value(0).GetString()
-	if err != nil {
-		return nil, neorpc.ErrInvalidParams
+// getMempoolInfo returns mempool statistics.
+func (s *Server) getMempoolInfo(_ params.Params) (any, *neorpc.Error) {
+	var (
+		mp      = s.chain.GetMemPool()
+		base    = max(s.chain.FeePerByte(), 1)
+		senders = make(map[util.Uint160]int)
+		res     = &result.MempoolInfo{
+			Height:       s.chain.BlockHeight(),
+			Capacity:     mp.Capacity(),
+			FeeHistogram: make([]result.MempoolFeeBucket, mempoolFeeBuckets),
+			Senders:      []result.MempoolSender{},
+		}
+	)
+	for i := 1; i < mempoolFeeBuckets; i++ {
+		res.FeeHistogram[i].MinFeePerByte = base << (i - 1)
+	}
+	mp.IterateVerifiedTransactions(func(tx *transaction.Transaction, _ any) bool {
+		size := tx.Size()
+		res.Count++
+		res.Size += size
+		if tx.HasAttribute(transaction.ConflictsT) {
+			res.Conflicting++
+		}
+		i := sort.Search(mempoolFeeBuckets, func(i int) bool {
+			return res.FeeHistogram[i].MinFeePerByte > tx.FeePerByte()
+		}) - 1
+		res.FeeHistogram[i].Count++
+		res.FeeHistogram[i].Size += size
+		senders[tx.Sender()]++
+		return true
+	})
+	for acc, cnt := range senders {
+		res.Senders = append(res.Senders, result.MempoolSender{Account: acc, Count: cnt})
 	}
+	slices.SortFunc(res.Senders, func(a, b result.MempoolSender) int {
+		if a.Count != b.Count {
+			return cmp.Compare(b.Count, a.Count)
+		}
+		return a.Account.Compare(b.Account)
+	})
+	if s.chain.P2PSigExtensionsEnabled() {
+		res.NotaryRequests = s.coreServer.GetNotaryPool().Count()
+	}
+	return res, nil
+}
 
+func (s *Server) validateAddress(reqParams params.Params) (any, *neorpc.Error) {
+	param, _ := reqParams.Value(0).GetString()
 	return result.ValidateAddress{
 		Address: reqParams.Value(0),
 		IsValid: validateAddress(param),
@@ -960,7 +1328,7 @@ func (s *Server) calculateNetworkFee(reqParams params.Params) (any, *neorpc.Erro
 	var (
 		netFee int64
 		// Verification GAS cost can't exceed chin policy, but RPC config can limit it further.
-		gasLimit = min(s.chain.GetMaxVerificationGAS(), int64(s.config.MaxGasInvoke))
+		gasLimit = min(s.chain.GetMaxVerificationGAS(), s.limits.Load().invoke.maxGas)
 	)
 	for i, signer := range tx.Signers {
 		w := tx.Scripts[i]
@@ -1009,20 +1377,26 @@ func (s *Server) calculateNetworkFee(reqParams params.Params) (any, *neorpc.Erro
 
 // getApplicationLog returns the contract log based on the specified txid or blockid.
 func (s *Server) getApplicationLog(reqParams params.Params) (any, *neorpc.Error) {
-	hash, err := reqParams.Value(0).GetUint256()
-	if err != nil {
-		return nil, neorpc.ErrInvalidParams
-	}
+	hash, _ := reqParams.Value(0).GetUint256()
 
 	trig := trigger.All
-	if len(reqParams) > 1 {
-		trigString, err := reqParams.Value(1).GetString()
-		if err != nil {
-			return nil, neorpc.ErrInvalidParams
-		}
+	if len(reqParams) > 1 && !reqParams.Value(1).IsNull() {
+		var err error
+		trigString, _ := reqParams.Value(1).GetString()
 		trig, err = trigger.FromString(trigString)
 		if err != nil {
-			return nil, neorpc.ErrInvalidParams
+			return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("invalid trigger: %s", err))
+		}
+	}
+
+	var offset, limit int
+	if len(reqParams) > 2 && !reqParams.Value(2).IsNull() {
+		offset, _ = reqParams.Value(2).GetInt()
+	}
+	if len(reqParams) > 3 && !reqParams.Value(3).IsNull() {
+		limit, _ = reqParams.Value(3).GetInt()
+		if limit == 0 {
+			return nil, neorpc.NewInvalidParamsError("invalid notification limit")
 		}
 	}
 
@@ -1030,7 +1404,198 @@ func (s *Server) getApplicationLog(reqParams params.Params) (any, *neorpc.Error)
 	if err != nil {
 		return nil, neorpc.WrapErrorWithData(neorpc.ErrUnknownScriptContainer, fmt.Sprintf("failed to locate application log: %s", err))
 	}
-	return result.NewApplicationLog(hash, appExecResults, trig), nil
+	res := result.NewApplicationLog(hash, appExecResults, trig)
+	if offset != 0 || limit != 0 {
+		res.Page(offset, limit)
+	}
+	return res, nil
+}
+
+// getBlockExecutionSummary returns trimmed application logs of the block
+// specified by its hash or index and all of its transactions.
+func (s *Server) getBlockExecutionSummary(reqParams params.Params) (any, *neorpc.Error) {
+	hash, respErr := s.blockHashFromParam(reqParams.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	b, err := s.chain.GetBlock(hash)
+	if err != nil {
+		return nil, neorpc.ErrUnknownBlock
+	}
+	aers, err := s.chain.GetAppExecResults(hash, trigger.OnPersist|trigger.PostPersist)
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrUnknownScriptContainer, fmt.Sprintf("failed to locate block application log: %s", err))
+	}
+	res := &result.BlockExecutionSummary{
+		Hash:         hash,
+		Index:        b.Index,
+		Executions:   make([]result.ExecutionSummary, 0, len(aers)),
+		Transactions: make([]result.TransactionExecutionSummary, 0, len(b.Transactions)),
+	}
+	for i := range aers {
+		res.Add(&aers[i])
+	}
+	for _, tx := range b.Transactions {
+		txAers, err := s.chain.GetAppExecResults(tx.Hash(), trigger.Application)
+		if err != nil || len(txAers) == 0 {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrUnknownScriptContainer, fmt.Sprintf("failed to locate application log of %s: %v", tx.Hash().StringLE(), err))
+		}
+		res.Add(&txAers[0])
+	}
+	return res, nil
+}
+
+// getBlockEconomics returns GAS flows summary for the block specified by its
+// hash or index.
+func (s *Server) getBlockEconomics(reqParams params.Params) (any, *neorpc.Error) {
+	hash, respErr := s.blockHashFromParam(reqParams.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	b, err := s.chain.GetBlock(hash)
+	if err != nil {
+		return nil, neorpc.ErrUnknownBlock
+	}
+	aers, err := s.chain.GetAppExecResults(hash, trigger.OnPersist|trigger.PostPersist)
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrUnknownScriptContainer, fmt.Sprintf("failed to locate block application log: %s", err))
+	}
+	for _, tx := range b.Transactions {
+		txAers, err := s.chain.GetAppExecResults(tx.Hash(), trigger.Application)
+		if err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrUnknownScriptContainer, fmt.Sprintf("failed to locate application log of %s: %s", tx.Hash().StringLE(), err))
+		}
+		aers = append(aers, txAers...)
+	}
+	r, err := s.getBlockRewardees(b)
+	if err != nil {
+		return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to get block rewardees: %s", err))
+	}
+	return newBlockEconomics(b, aers, r), nil
+}
+
+// getBlockRewardees returns accounts that can be rewarded by native contracts
+// in the given block.
+func (s *Server) getBlockRewardees(b *block.Block) (*blockRewardees, error) {
+	var r = new(blockRewardees)
+	if b.Index == 0 {
+		return r, nil
+	}
+	// Validators are sorted the same way as keys of the multisignature
+	// block witness script.
+	var primary []byte
+	if _, pubs, ok := vm.ParseMultiSigContract(b.Script.VerificationScript); ok && int(b.PrimaryIndex) < len(pubs) {
+		primary = pubs[b.PrimaryIndex]
+	} else if pub, ok := vm.ParseSignatureContract(b.Script.VerificationScript); ok && b.PrimaryIndex == 0 {
+		primary = pub
+	}
+	if primary != nil {
+		pub, err := keys.NewPublicKeyFromBytes(primary, elliptic.P256())
+		if err != nil {
+			return nil, fmt.Errorf("invalid primary key: %w", err)
+		}
+		h := pub.GetScriptHash()
+		r.primary = &h
+	}
+	notaries, err := s.getDesignatedByRoleAt(noderoles.P2PNotary, b.Index)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range notaries {
+		r.notaries = append(r.notaries, n.GetScriptHash())
+	}
+	var oracles keys.PublicKeys
+	for _, tx := range b.Transactions {
+		attrs := tx.GetAttributes(transaction.OracleResponseT)
+		if len(attrs) == 0 {
+			continue
+		}
+		if oracles == nil {
+			// Designation made in this block is already effective in
+			// PostPersist.
+			oracles, err = s.getDesignatedByRoleAt(noderoles.Oracle, b.Index+1)
+			if err != nil {
+				return nil, err
+			}
+			if len(oracles) == 0 {
+				break
+			}
+		}
+		id := attrs[0].Value.(*transaction.OracleResponse).ID
+		h := oracles[id%uint64(len(oracles))].GetScriptHash()
+		if !slices.Contains(r.oracles, h) {
+			r.oracles = append(r.oracles, h)
+		}
+	}
+	return r, nil
+}
+
+// getDesignatedByRoleAt returns nodes designated for the role at the given
+// height.
+func (s *Server) getDesignatedByRoleAt(role noderoles.Role, index uint32) (keys.PublicKeys, error) {
+	cs := s.chain.GetContractState(nativehashes.RoleManagement)
+	if cs == nil {
+		return nil, errors.New("RoleManagement contract is not found")
+	}
+	var v []byte
+	s.chain.SeekStorage(cs.ID, []byte{byte(role)}, func(k, val []byte) bool {
+		if len(k) != 4 || binary.BigEndian.Uint32(k) > index {
+			return false
+		}
+		v = val
+		return true
+	})
+	if v == nil {
+		return nil, nil
+	}
+	var nodes native.NodeList
+	if err := stackitem.DeserializeConvertible(v, &nodes); err != nil {
+		return nil, err
+	}
+	return keys.PublicKeys(nodes), nil
+}
+
+func (s *Server) getBlockTimeStats(reqParams params.Params) (any, *neorpc.Error) {
+	if len(reqParams) > maxBlockTimeWindows {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("too many windows, at most %d are allowed", maxBlockTimeWindows))
+	}
+	var windows = []int{defaultBlockTimeWindow}
+	if len(reqParams) != 0 {
+		windows = make([]int, 0, len(reqParams))
+		for i := range reqParams {
+			w, err := reqParams[i].GetInt()
+			if err != nil || w <= 0 || w > maxBlockTimeWindow {
+				return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid window %d: should be an integer in [1, %d] range", i, maxBlockTimeWindow))
+			}
+			windows = append(windows, w)
+		}
+	}
+	var (
+		height = s.chain.BlockHeight()
+		n      = min(uint32(slices.Max(windows)), height)
+		ts     = make([]uint64, 0, n+1)
+	)
+	for i := height - n; i <= height; i++ {
+		h, err := s.chain.GetHeader(s.chain.GetHeaderHash(i))
+		if err != nil {
+			return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to get header %d: %s", i, err))
+		}
+		ts = append(ts, h.Timestamp)
+	}
+	var intervals = make([]uint64, 0, n)
+	for i := len(ts) - 1; i > 0; i-- {
+		intervals = append(intervals, ts[i]-ts[i-1])
+	}
+	res := &result.BlockTimeStats{
+		Height:    height,
+		Timestamp: ts[len(ts)-1],
+		Intervals: intervals[:min(slices.Min(windows), len(intervals))],
+		Windows:   make([]result.BlockTimeWindow, 0, len(windows)),
+	}
+	for _, w := range windows {
+		res.Windows = append(res.Windows, newBlockTimeWindow(intervals, w))
+	}
+	return res, nil
 }
 
 func (s *Server) getNEP11Tokens(h util.Uint160, acc util.Uint160, bw *io.BufBinWriter) ([]stackitem.Item, string, int, error) {
@@ -1042,7 +1607,7 @@ func (s *Server) getNEP11Tokens(h util.Uint160, acc util.Uint160, bw *io.BufBinW
 	if (items[0].Type() != stackitem.InteropT) || !iterator.IsIterator(items[0]) {
 		return nil, "", 0, fmt.Errorf("invalid `tokensOf` result type %s", items[0].String())
 	}
-	vals := iterator.Values(items[0], s.config.MaxNEP11Tokens)
+	vals := iterator.Values(items[0], s.limits.Load().maxNEP11Tokens)
 	sym, err := stackitem.ToString(items[1])
 	if err != nil {
 		return nil, "", 0, fmt.Errorf("`symbol` return value error: %w", err)
@@ -1058,10 +1623,7 @@ func (s *Server) getNEP11Tokens(h util.Uint160, acc util.Uint160, bw *io.BufBinW
 }
 
 func (s *Server) getNEP11Balances(ps params.Params) (any, *neorpc.Error) {
-	u, err := ps.Value(0).GetUint160FromAddressOrHex()
-	if err != nil {
-		return nil, neorpc.ErrInvalidParams
-	}
+	u, _ := ps.Value(0).GetUint160FromAddressOrHex()
 
 	bs := &result.NEP11Balances{
 		Address:  address.Uint160ToString(u),
@@ -1126,7 +1688,7 @@ contract_loop:
 				Amount:      amount,
 				LastUpdated: lub,
 			})
-			if count >= s.config.MaxNEP11Tokens {
+			if count >= s.limits.Load().maxNEP11Tokens {
 				break contract_loop
 			}
 		}
@@ -1147,14 +1709,8 @@ func (s *Server) invokeNEP11Properties(h util.Uint160, id []byte, bw *io.BufBinW
 }
 
 func (s *Server) getNEP11Properties(ps params.Params) (any, *neorpc.Error) {
-	asset, err := ps.Value(0).GetUint160FromAddressOrHex()
-	if err != nil {
-		return nil, neorpc.ErrInvalidParams
-	}
-	token, err := ps.Value(1).GetBytesHex()
-	if err != nil {
-		return nil, neorpc.ErrInvalidParams
-	}
+	asset, _ := ps.Value(0).GetUint160FromAddressOrHex()
+	token, _ := ps.Value(1).GetBytesHex()
 	props, err := s.invokeNEP11Properties(asset, token, nil)
 	if err != nil {
 		return nil, neorpc.WrapErrorWithData(neorpc.ErrExecutionFailed, fmt.Sprintf("Failed to get NEP-11 properties: %s", err.Error()))
@@ -1183,10 +1739,7 @@ func (s *Server) getNEP11Properties(ps params.Params) (any, *neorpc.Error) {
 }
 
 func (s *Server) getNEP17Balances(ps params.Params) (any, *neorpc.Error) {
-	u, err := ps.Value(0).GetUint160FromAddressOrHex()
-	if err != nil {
-		return nil, neorpc.ErrInvalidParams
-	}
+	u, _ := ps.Value(0).GetUint160FromAddressOrHex()
 
 	bs := &result.NEP17Balances{
 		Address:  address.Uint160ToString(u),
@@ -1379,6 +1932,27 @@ func (s *Server) getTokenTransfers(ps params.Params, isNEP11 bool) (any, *neorpc
 	if err != nil {
 		return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("malformed timestamps/limit: %s", err))
 	}
+	// cursor is the position of the last processed transfer, skip is the
+	// number of transfers to skip at the cursor position.
+	var cursor, skip = transfersCursor{Timestamp: end}, uint32(0)
+	if p := ps.Value(5); p != nil {
+		c, err := p.GetString()
+		if err == nil && c != "" {
+			if page != 0 {
+				return nil, neorpc.NewInvalidParamsError("page and cursor can't be used together")
+			}
+			cursor, err = parseTransfersCursor(c)
+			// Skipped transfers are counted by processed again.
+			end, skip, cursor.Skip = cursor.Timestamp, cursor.Skip, 0
+		}
+		if err != nil {
+			return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("malformed cursor: %s", err))
+		}
+	}
+	filter, respErr := s.getTransferFilter(ps, 6)
+	if respErr != nil {
+		return nil, respErr
+	}
 
 	bs := &tokenTransfers{
 		Address:  address.Uint160ToString(u),
@@ -1387,6 +1961,14 @@ func (s *Server) getTokenTransfers(ps params.Params, isNEP11 bool) (any, *neorpc
 	}
 	cache := make(map[int32]util.Uint160)
 	var resCount, frameCount int
+	// processed moves the cursor to the given transfer.
+	var processed = func(tr *state.NEP17Transfer) {
+		if tr.Timestamp == cursor.Timestamp {
+			cursor.Skip++
+		} else {
+			cursor = transfersCursor{Timestamp: tr.Timestamp, Skip: 1}
+		}
+	}
 	// handleTransfer returns items to be added into the received and sent arrays
 	// along with a continue flag and error.
 	var handleTransfer = func(tr *state.NEP17Transfer) (*result.NEP17Transfer, *result.NEP17Transfer, bool, error) {
@@ -1402,9 +1984,22 @@ func (s *Server) getTokenTransfers(ps params.Params, isNEP11 bool) (any, *neorpc
 		if tr.Timestamp < start {
 			return nil, nil, false, nil
 		}
+		// Already returned with the previous page.
+		if skip > 0 {
+			skip--
+			processed(tr)
+			return nil, nil, true, nil
+		}
+		// Limit is reached and there are more transfers within the time
+		// frame, so the next page can be requested from the cursor.
+		if limit != 0 && resCount >= limit {
+			bs.Next = cursor.String()
+			return nil, nil, false, nil
+		}
 		frameCount++
 		// Using limits, not yet reached required page.
 		if limit != 0 && page*limit >= frameCount {
+			processed(tr)
 			return nil, nil, true, nil
 		}
 
@@ -1433,11 +2028,11 @@ func (s *Server) getTokenTransfers(ps params.Params, isNEP11 bool) (any, *neorpc
 		}
 
 		resCount++
-		// Check limits for continue flag.
-		return received, sent, !(limit != 0 && resCount >= limit), nil
+		processed(tr)
+		return received, sent, true, nil
 	}
 	if !isNEP11 {
-		err = s.chain.ForEachNEP17Transfer(u, end, func(tr *state.NEP17Transfer) (bool, error) {
+		err = s.chain.ForEachNEP17TransferFiltered(u, filter, end, func(tr *state.NEP17Transfer) (bool, error) {
 			r, s, res, err := handleTransfer(tr)
 			if err == nil {
 				if r != nil {
@@ -1450,7 +2045,7 @@ func (s *Server) getTokenTransfers(ps params.Params, isNEP11 bool) (any, *neorpc
 			return res, err
 		})
 	} else {
-		err = s.chain.ForEachNEP11Transfer(u, end, func(tr *state.NEP11Transfer) (bool, error) {
+		err = s.chain.ForEachNEP11TransferFiltered(u, filter, end, func(tr *state.NEP11Transfer) (bool, error) {
 			r, s, res, err := handleTransfer(&tr.NEP17Transfer)
 			if err == nil {
 				id := hex.EncodeToString(tr.ID)
@@ -1470,6 +2065,35 @@ func (s *Server) getTokenTransfers(ps params.Params, isNEP11 bool) (any, *neorpc
 	return bs, nil
 }
 
+// getTransferFilter parses optional asset and counterparty transfer filter
+// parameters starting from the given index, empty strings mean no filter.
+func (s *Server) getTransferFilter(ps params.Params, index int) (state.TransferFilter, *neorpc.Error) {
+	var filter state.TransferFilter
+	if p := ps.Value(index); p != nil {
+		if str, err := p.GetString(); err != nil || str != "" {
+			h, err := p.GetUint160FromHex()
+			if err != nil {
+				return filter, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid asset: %s", err))
+			}
+			cs := s.chain.GetContractState(h)
+			if cs == nil {
+				return filter, neorpc.WrapErrorWithData(neorpc.ErrUnknownContract, h.StringLE())
+			}
+			filter.Asset = &cs.ID
+		}
+	}
+	if p := ps.Value(index + 1); p != nil {
+		if str, err := p.GetString(); err != nil || str != "" {
+			h, err := p.GetUint160FromAddressOrHex()
+			if err != nil {
+				return filter, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid counterparty: %s", err))
+			}
+			filter.Counterparty = &h
+		}
+	}
+	return filter, nil
+}
+
 // getHash returns the hash of the contract by its ID using cache.
 func (s *Server) getHash(contractID int32, cache map[int32]util.Uint160) (util.Uint160, error) {
 	if d, ok := cache[contractID]; ok {
@@ -1544,34 +2168,75 @@ func (s *Server) contractScriptHashFromParam(param *params.Param) (util.Uint160,
 	if err != nil {
 		return result, neorpc.ErrUnknownContract
 	}
-	return result, nil
+	return result, nil
+}
+
+func makeStorageKey(id int32, key []byte) []byte {
+	skey := make([]byte, 4+len(key))
+	binary.LittleEndian.PutUint32(skey, uint32(id))
+	copy(skey[4:], key)
+	return skey
+}
+
+var errKeepOnlyLatestState = errors.New("'KeepOnlyLatestState' setting is enabled")
+
+// isStateAvailable checks whether MPT state for the given root is stored by
+// the node with KeepOnlyLatestState setting enabled. It's only the current
+// state and KeepRecentStateRoots-1 previous ones.
+func (s *Server) isStateAvailable(root util.Uint256) (bool, error) {
+	var (
+		height = s.chain.BlockHeight()
+		n      = max(s.chain.GetConfig().Ledger.KeepRecentStateRoots, 1)
+	)
+	for i := uint32(0); i < n && i <= height; i++ {
+		sr, err := s.chain.GetStateModule().GetStateRoot(height - i)
+		if err != nil {
+			return false, err
+		}
+		if sr.Root.Equals(root) {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
-func makeStorageKey(id int32, key []byte) []byte {
-	skey := make([]byte, 4+len(key))
-	binary.LittleEndian.PutUint32(skey, uint32(id))
-	copy(skey[4:], key)
-	return skey
+// requireSessions checks that iterator sessions are enabled.
+func (s *Server) requireSessions() *neorpc.Error {
+	if !s.config.SessionEnabled {
+		return neorpc.ErrSessionsDisabled
+	}
+	return nil
 }
 
-var errKeepOnlyLatestState = errors.New("'KeepOnlyLatestState' setting is enabled")
+// requireP2PSigExtensions checks that P2PSigExtensions are enabled.
+func (s *Server) requireP2PSigExtensions() *neorpc.Error {
+	if !s.chain.P2PSigExtensionsEnabled() {
+		return neorpc.NewInternalServerError("P2PSignatureExtensions are disabled")
+	}
+	return nil
+}
+
+// requireProofs checks that the node keeps enough state to handle proofs.
+func (s *Server) requireProofs() *neorpc.Error {
+	if cfg := s.chain.GetConfig().Ledger; cfg.KeepOnlyLatestState && cfg.KeepRecentStateRoots == 0 {
+		return neorpc.WrapErrorWithData(neorpc.ErrUnsupportedState, fmt.Sprintf("proofs are not supported: %s", errKeepOnlyLatestState))
+	}
+	return nil
+}
 
 func (s *Server) getProof(ps params.Params) (any, *neorpc.Error) {
+	root, _ := ps.Value(0).GetUint256()
 	if s.chain.GetConfig().Ledger.KeepOnlyLatestState {
-		return nil, neorpc.WrapErrorWithData(neorpc.ErrUnsupportedState, fmt.Sprintf("'getproof' is not supported: %s", errKeepOnlyLatestState))
-	}
-	root, err := ps.Value(0).GetUint256()
-	if err != nil {
-		return nil, neorpc.ErrInvalidParams
-	}
-	sc, err := ps.Value(1).GetUint160FromHex()
-	if err != nil {
-		return nil, neorpc.ErrInvalidParams
-	}
-	key, err := ps.Value(2).GetBytesBase64()
-	if err != nil {
-		return nil, neorpc.ErrInvalidParams
+		ok, err := s.isStateAvailable(root)
+		if err != nil {
+			return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to get recent stateroots: %s", err))
+		}
+		if !ok {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrUnsupportedState, fmt.Sprintf("'getproof' is not supported for old states: %s", errKeepOnlyLatestState))
+		}
 	}
+	sc, _ := ps.Value(1).GetUint160FromHex()
+	key, _ := ps.Value(2).GetBytesBase64()
 	cs, respErr := s.getHistoricalContractState(root, sc)
 	if respErr != nil {
 		return nil, respErr
@@ -1591,20 +2256,11 @@ func (s *Server) getProof(ps params.Params) (any, *neorpc.Error) {
 }
 
 func (s *Server) verifyProof(ps params.Params) (any, *neorpc.Error) {
-	if s.chain.GetConfig().Ledger.KeepOnlyLatestState {
-		return nil, neorpc.WrapErrorWithData(neorpc.ErrUnsupportedState, fmt.Sprintf("'verifyproof' is not supported: %s", errKeepOnlyLatestState))
-	}
-	root, err := ps.Value(0).GetUint256()
-	if err != nil {
-		return nil, neorpc.ErrInvalidParams
-	}
-	proofStr, err := ps.Value(1).GetString()
-	if err != nil {
-		return nil, neorpc.ErrInvalidParams
-	}
+	root, _ := ps.Value(0).GetUint256()
+	proofStr, _ := ps.Value(1).GetString()
 	var p result.ProofWithKey
 	if err := p.FromString(proofStr); err != nil {
-		return nil, neorpc.ErrInvalidParams
+		return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("invalid proof: %s", err))
 	}
 	vp := new(result.VerifyProof)
 	val, ok := mpt.VerifyProof(root, p.Key, p.Proof)
@@ -1620,14 +2276,8 @@ func (s *Server) getState(ps params.Params) (any, *neorpc.Error) {
 	if respErr != nil {
 		return nil, respErr
 	}
-	csHash, err := ps.Value(1).GetUint160FromHex()
-	if err != nil {
-		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "invalid contract hash")
-	}
-	key, err := ps.Value(2).GetBytesBase64()
-	if err != nil {
-		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "invalid key")
-	}
+	csHash, _ := ps.Value(1).GetUint160FromHex()
+	key, _ := ps.Value(2).GetBytesBase64()
 	cs, respErr := s.getHistoricalContractState(root, csHash)
 	if respErr != nil {
 		return nil, respErr
@@ -1643,28 +2293,21 @@ func (s *Server) getState(ps params.Params) (any, *neorpc.Error) {
 	return res, nil
 }
 
-func (s *Server) findStates(ps params.Params) (any, *neorpc.Error) {
+func (s *Server) findStates(ctx context.Context, ps params.Params) (any, *neorpc.Error) {
 	root, respErr := s.getStateRootFromParam(ps.Value(0))
 	if respErr != nil {
 		return nil, respErr
 	}
-	csHash, err := ps.Value(1).GetUint160FromHex()
-	if err != nil {
-		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid contract hash: %s", err))
-	}
-	prefix, err := ps.Value(2).GetBytesBase64()
-	if err != nil {
-		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid prefix: %s", err))
-	}
+	csHash, _ := ps.Value(1).GetUint160FromHex()
+	prefix, _ := ps.Value(2).GetBytesBase64()
 	var (
-		key   []byte
-		count = s.config.MaxFindResultItems
+		key        []byte
+		count      = s.limits.Load().maxFindResultItems
+		fromCursor bool
+		err        error
 	)
-	if len(ps) > 3 {
-		key, err = ps.Value(3).GetBytesBase64()
-		if err != nil {
-			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid key: %s", err))
-		}
+	if len(ps) > 3 && !ps.Value(3).IsNull() {
+		key, _ = ps.Value(3).GetBytesBase64()
 		if len(key) > 0 {
 			if !bytes.HasPrefix(key, prefix) {
 				return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "key doesn't match prefix")
@@ -1675,26 +2318,74 @@ func (s *Server) findStates(ps params.Params) (any, *neorpc.Error) {
 			key = nil
 		}
 	}
-	if len(ps) > 4 {
+	if len(ps) > 4 && !ps.Value(4).IsNull() {
 		count, err = ps.Value(4).GetInt()
 		if err != nil {
 			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid count: %s", err))
 		}
-		count = min(count, s.config.MaxFindResultItems)
+		count = min(count, s.limits.Load().maxFindResultItems)
+	}
+	if p := ps.Value(5); p != nil {
+		c, err := p.GetString()
+		if err == nil && c != "" {
+			if key != nil {
+				return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "key and cursor can't be used together")
+			}
+			var cursor findStatesCursor
+			cursor, err = parseFindStatesCursor(c)
+			// Cursor never points to the item with the key equal to the
+			// prefix since it's the last one.
+			if err == nil {
+				if cursor.Root != root || cursor.Contract != csHash ||
+					len(cursor.Key) <= len(prefix) || !bytes.HasPrefix(cursor.Key, prefix) {
+					err = errors.New("cursor doesn't match request")
+				} else {
+					key, fromCursor = cursor.Key[len(prefix):], true
+				}
+			}
+		}
+		if err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("malformed cursor: %s", err))
+		}
 	}
 	cs, respErr := s.getHistoricalContractState(root, csHash)
 	if respErr != nil {
 		return nil, respErr
 	}
 	pKey := makeStorageKey(cs.ID, prefix)
+	if respErr = checkRequestContext(ctx); respErr != nil {
+		return nil, respErr
+	}
 	kvs, err := s.chain.GetStateModule().FindStates(root, pKey, key, count+1) // +1 to define result truncation
 	if err != nil && !errors.Is(err, mpt.ErrNotFound) {
 		return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to find state items: %s", err))
 	}
+	if fromCursor && len(kvs) < count+1 {
+		// The item with the key equal to the prefix is the last one in
+		// the MPT traversal order, but it's never returned for non-empty
+		// start key, so it's added explicitly to be returned with the
+		// last page.
+		v, err := s.chain.GetStateModule().GetState(root, pKey)
+		if err == nil {
+			kvs = append(kvs, storage.KeyValue{Key: pKey, Value: v})
+		} else if !errors.Is(err, mpt.ErrNotFound) {
+			return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to get state item: %s", err))
+		}
+	}
+	if respErr = checkRequestContext(ctx); respErr != nil {
+		return nil, respErr
+	}
 	res := result.FindStates{}
 	if len(kvs) == count+1 {
 		res.Truncated = true
 		kvs = kvs[:len(kvs)-1]
+		if len(kvs) > 0 {
+			res.Next = findStatesCursor{
+				Root:     root,
+				Contract: csHash,
+				Key:      kvs[len(kvs)-1].Key[4:], // Without contract ID.
+			}.String()
+		}
 	}
 	if len(kvs) > 0 {
 		proof, err := s.chain.GetStateModule().GetStateProof(root, kvs[0].Key)
@@ -1707,6 +2398,9 @@ func (s *Server) findStates(ps params.Params) (any, *neorpc.Error) {
 		}
 	}
 	if len(kvs) > 1 {
+		if respErr = checkRequestContext(ctx); respErr != nil {
+			return nil, respErr
+		}
 		proof, err := s.chain.GetStateModule().GetStateProof(root, kvs[len(kvs)-1].Key)
 		if err != nil {
 			return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to get last proof: %s", err))
@@ -1735,11 +2429,11 @@ func (s *Server) getStateRootFromParam(p *params.Param) (util.Uint256, *neorpc.E
 		return util.Uint256{}, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "invalid stateroot")
 	}
 	if s.chain.GetConfig().Ledger.KeepOnlyLatestState {
-		curr, err := s.chain.GetStateModule().GetStateRoot(s.chain.BlockHeight())
+		ok, err := s.isStateAvailable(root)
 		if err != nil {
 			return util.Uint256{}, neorpc.NewInternalServerError(fmt.Sprintf("failed to get current stateroot: %s", err))
 		}
-		if !curr.Root.Equals(root) {
+		if !ok {
 			return util.Uint256{}, neorpc.WrapErrorWithData(neorpc.ErrUnsupportedState, fmt.Sprintf("state-based methods are not supported for old states: %s", errKeepOnlyLatestState))
 		}
 	}
@@ -1781,14 +2475,141 @@ func (s *Server) findStorageInternal(id int32, prefix []byte, start, take int, s
 	return res, nil
 }
 
-func (s *Server) findStorageHistoric(reqParams params.Params) (any, *neorpc.Error) {
-	root, respErr := s.getStateRootFromParam(reqParams.Value(0))
+func (s *Server) findEventContainers(reqParams params.Params) (any, *neorpc.Error) {
+	if !s.chain.GetConfig().Ledger.IndexEventContainers {
+		return nil, neorpc.NewInternalServerError("event container index is disabled")
+	}
+	if len(reqParams) < 2 {
+		return nil, neorpc.ErrInvalidParams
+	}
+	h, respErr := s.contractScriptHashFromParam(reqParams.Value(0))
 	if respErr != nil {
 		return nil, respErr
 	}
-	if len(reqParams) < 2 {
+	name, err := reqParams.Value(1).GetString()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid event name: %s", err))
+	}
+	var index uint32
+	if len(reqParams) > 2 {
+		index, respErr = s.blockHeightFromParam(reqParams.Value(2))
+		if respErr != nil {
+			return nil, respErr
+		}
+	}
+	var start []byte
+	if len(reqParams) > 3 {
+		c, err := reqParams.Value(3).GetString()
+		if err == nil {
+			start, err = base64.RawURLEncoding.DecodeString(c)
+		}
+		if err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("malformed cursor: %s", err))
+		}
+	}
+
+	res := &result.FindEventContainers{Containers: make([]state.EventContainer, 0)}
+	err = s.chain.ForEachEventContainer(h, name, index, start, func(p []byte, c *state.EventContainer) bool {
+		if len(res.Containers) >= s.limits.Load().maxFindResultItems {
+			res.Next = base64.RawURLEncoding.EncodeToString(start)
+			return false
+		}
+		res.Containers = append(res.Containers, *c)
+		start = p
+		return true
+	})
+	if err != nil {
+		if errors.Is(err, dao.ErrInvalidEventIndexPosition) {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("malformed cursor: %s", err))
+		}
+		return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to find event containers: %s", err))
+	}
+	return res, nil
+}
+
+func (s *Server) findNotifications(reqParams params.Params) (any, *neorpc.Error) {
+	if !s.chain.GetConfig().Ledger.IndexEvents {
+		return nil, neorpc.NewInternalServerError("event index is disabled")
+	}
+	if len(reqParams) < 4 {
 		return nil, neorpc.ErrInvalidParams
 	}
+	h, respErr := s.contractScriptHashFromParam(reqParams.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	cs := s.chain.GetContractState(h)
+	if cs == nil {
+		return nil, neorpc.ErrUnknownContract
+	}
+	name, err := reqParams.Value(1).GetString()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid event name: %s", err))
+	}
+	paramName, err := reqParams.Value(2).GetString()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid parameter name: %s", err))
+	}
+	indexed, err := cs.Manifest.IndexedEvents()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("contract has invalid indexed events: %s", err))
+	}
+	var pos = -1
+	if e := cs.Manifest.ABI.GetEvent(name); e != nil {
+		pos = slices.IndexFunc(e.Parameters, func(p manifest.Parameter) bool { return p.Name == paramName })
+	}
+	if pos < 0 || !slices.Contains(indexed[name], pos) {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("parameter %s of event %s is not indexed", paramName, name))
+	}
+	var param smartcontract.Parameter
+	err = json.Unmarshal(reqParams.Value(3).RawMessage, &param)
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid parameter value: %s", err))
+	}
+	var value []byte
+	item, err := param.ToStackItem()
+	if err == nil {
+		value, err = item.TryBytes()
+	}
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid parameter value: %s", err))
+	}
+
+	var start []byte
+	if len(reqParams) > 4 {
+		c, err := reqParams.Value(4).GetString()
+		if err == nil {
+			start, err = base64.RawURLEncoding.DecodeString(c)
+		}
+		if err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("malformed cursor: %s", err))
+		}
+	}
+
+	res := &result.FindNotifications{Notifications: make([]state.ContainedNotificationEvent, 0)}
+	err = s.chain.ForEachIndexedNotification(h, name, pos, value, start, func(p []byte, ne *state.ContainedNotificationEvent) bool {
+		if len(res.Notifications) >= s.limits.Load().maxFindResultItems {
+			res.Next = base64.RawURLEncoding.EncodeToString(start)
+			return false
+		}
+		res.Notifications = append(res.Notifications, *ne)
+		start = p
+		return true
+	})
+	if err != nil {
+		if errors.Is(err, dao.ErrInvalidEventIndexPosition) {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("malformed cursor: %s", err))
+		}
+		return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to find notifications: %s", err))
+	}
+	return res, nil
+}
+
+func (s *Server) findStorageHistoric(reqParams params.Params) (any, *neorpc.Error) {
+	root, respErr := s.getStateRootFromParam(reqParams.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
 	id, prefix, start, take, respErr := s.getFindStorageParams(reqParams[1:], root)
 	if respErr != nil {
 		return nil, respErr
@@ -1812,27 +2633,18 @@ func (s mptStorageSeeker) SeekStorage(id int32, prefix []byte, cont func(k, v []
 }
 
 func (s *Server) getFindStorageParams(reqParams params.Params, root ...util.Uint256) (int32, []byte, int, int, *neorpc.Error) {
-	if len(reqParams) < 2 {
-		return 0, nil, 0, 0, neorpc.ErrInvalidParams
-	}
 	id, respErr := s.contractIDFromParam(reqParams.Value(0), root...)
 	if respErr != nil {
 		return 0, nil, 0, 0, respErr
 	}
 
-	prefix, err := reqParams.Value(1).GetBytesBase64()
-	if err != nil {
-		return 0, nil, 0, 0, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid prefix: %s", err))
-	}
+	prefix, _ := reqParams.Value(1).GetBytesBase64()
 
 	var skip int
-	if len(reqParams) > 2 {
-		skip, err = reqParams.Value(2).GetInt()
-		if err != nil {
-			return 0, nil, 0, 0, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid start: %s", err))
-		}
+	if len(reqParams) > 2 && !reqParams.Value(2).IsNull() {
+		skip, _ = reqParams.Value(2).GetInt()
 	}
-	return id, prefix, skip, s.config.MaxFindStorageResultItems, nil
+	return id, prefix, skip, s.limits.Load().maxFindStorageResultItems, nil
 }
 
 func (s *Server) getHistoricalContractState(root util.Uint256, csHash util.Uint160) (*state.Contract, *neorpc.Error) {
@@ -1893,10 +2705,7 @@ func (s *Server) getStorage(ps params.Params) (any, *neorpc.Error) {
 		return nil, rErr
 	}
 
-	key, err := ps.Value(1).GetBytesBase64()
-	if err != nil {
-		return nil, neorpc.ErrInvalidParams
-	}
+	key, _ := ps.Value(1).GetBytesBase64()
 
 	item := s.chain.GetStorageItem(id, key)
 	if item == nil {
@@ -1911,18 +2720,11 @@ func (s *Server) getStorageHistoric(ps params.Params) (any, *neorpc.Error) {
 	if respErr != nil {
 		return nil, respErr
 	}
-	if len(ps) < 2 {
-		return nil, neorpc.ErrInvalidParams
-	}
-
 	id, rErr := s.contractIDFromParam(ps.Value(1), root)
 	if rErr != nil {
 		return nil, rErr
 	}
-	key, err := ps.Value(2).GetBytesBase64()
-	if err != nil {
-		return nil, neorpc.ErrInvalidParams
-	}
+	key, _ := ps.Value(2).GetBytesBase64()
 	pKey := makeStorageKey(id, key)
 
 	v, err := s.chain.GetStateModule().GetState(root, pKey)
@@ -1937,10 +2739,7 @@ func (s *Server) getStorageHistoric(ps params.Params) (any, *neorpc.Error) {
 }
 
 func (s *Server) getrawtransaction(reqParams params.Params) (any, *neorpc.Error) {
-	txHash, err := reqParams.Value(0).GetUint256()
-	if err != nil {
-		return nil, neorpc.ErrInvalidParams
-	}
+	txHash, _ := reqParams.Value(0).GetUint256()
 	tx, height, err := s.chain.GetTransaction(txHash)
 	if err != nil {
 		return nil, neorpc.ErrUnknownTransaction
@@ -1976,11 +2775,7 @@ func (s *Server) getrawtransaction(reqParams params.Params) (any, *neorpc.Error)
 }
 
 func (s *Server) getTransactionHeight(ps params.Params) (any, *neorpc.Error) {
-	h, err := ps.Value(0).GetUint256()
-	if err != nil {
-		return nil, neorpc.ErrInvalidParams
-	}
-
+	h, _ := ps.Value(0).GetUint256()
 	_, height, err := s.chain.GetTransaction(h)
 	if err != nil || height == math.MaxUint32 {
 		return nil, neorpc.ErrUnknownTransaction
@@ -2003,6 +2798,49 @@ func (s *Server) getContractState(reqParams params.Params) (any, *neorpc.Error)
 	return cs, nil
 }
 
+// getContractStorageUsage returns storage usage statistics of the contract.
+func (s *Server) getContractStorageUsage(reqParams params.Params) (any, *neorpc.Error) {
+	cs, u, respErr := s.storageUsageFromParam(reqParams.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	return &result.ContractStorageUsage{
+		ID:           cs.ID,
+		Hash:         cs.Hash,
+		StorageUsage: *u,
+	}, nil
+}
+
+// getStorageItemsCount returns the number of storage items of the contract.
+func (s *Server) getStorageItemsCount(reqParams params.Params) (any, *neorpc.Error) {
+	_, u, respErr := s.storageUsageFromParam(reqParams.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	return u.Keys, nil
+}
+
+// storageUsageFromParam returns the contract specified by the given parameter
+// and its storage usage statistics.
+func (s *Server) storageUsageFromParam(param *params.Param) (*state.Contract, *state.StorageUsage, *neorpc.Error) {
+	if !s.chain.GetConfig().Ledger.TrackStorageUsage {
+		return nil, nil, neorpc.NewInternalServerError("storage usage tracking is disabled")
+	}
+	scriptHash, respErr := s.contractScriptHashFromParam(param)
+	if respErr != nil {
+		return nil, nil, respErr
+	}
+	cs := s.chain.GetContractState(scriptHash)
+	if cs == nil {
+		return nil, nil, neorpc.ErrUnknownContract
+	}
+	u, err := s.chain.GetStorageUsage(cs.ID)
+	if err != nil {
+		return nil, nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to get storage usage: %s", err))
+	}
+	return cs, u, nil
+}
+
 func (s *Server) getNativeContracts(_ params.Params) (any, *neorpc.Error) {
 	return s.chain.GetNatives(), nil
 }
@@ -2060,10 +2898,7 @@ func (s *Server) getBlockHeader(reqParams params.Params) (any, *neorpc.Error) {
 
 // getUnclaimedGas returns unclaimed GAS amount of the specified address.
 func (s *Server) getUnclaimedGas(ps params.Params) (any, *neorpc.Error) {
-	u, err := ps.Value(0).GetUint160FromAddressOrHex()
-	if err != nil {
-		return nil, neorpc.ErrInvalidParams
-	}
+	u, _ := ps.Value(0).GetUint160FromAddressOrHex()
 
 	neo, _ := s.chain.GetGoverningTokenBalance(u)
 	if neo.Sign() == 0 {
@@ -2139,16 +2974,16 @@ func (s *Server) getCommittee(_ params.Params) (any, *neorpc.Error) {
 }
 
 // invokeFunction implements the `invokeFunction` RPC call.
-func (s *Server) invokeFunction(reqParams params.Params) (any, *neorpc.Error) {
+func (s *Server) invokeFunction(ctx context.Context, reqParams params.Params) (any, *neorpc.Error) {
 	tx, verbose, respErr := s.getInvokeFunctionParams(reqParams)
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, nil, verbose)
+	return s.runScriptInVM(ctx, trigger.Application, tx.Script, util.Uint160{}, tx, nil, verbose)
 }
 
 // invokeFunctionHistoric implements the `invokeFunctionHistoric` RPC call.
-func (s *Server) invokeFunctionHistoric(reqParams params.Params) (any, *neorpc.Error) {
+func (s *Server) invokeFunctionHistoric(ctx context.Context, reqParams params.Params) (any, *neorpc.Error) {
 	nextH, respErr := s.getHistoricParams(reqParams)
 	if respErr != nil {
 		return nil, respErr
@@ -2160,7 +2995,7 @@ func (s *Server) invokeFunctionHistoric(reqParams params.Params) (any, *neorpc.E
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, &nextH, verbose)
+	return s.runScriptInVM(ctx, trigger.Application, tx.Script, util.Uint160{}, tx, &nextH, verbose)
 }
 
 func (s *Server) getInvokeFunctionParams(reqParams params.Params) (*transaction.Transaction, bool, *neorpc.Error) {
@@ -2206,16 +3041,16 @@ func (s *Server) getInvokeFunctionParams(reqParams params.Params) (*transaction.
 }
 
 // invokescript implements the `invokescript` RPC call.
-func (s *Server) invokescript(reqParams params.Params) (any, *neorpc.Error) {
+func (s *Server) invokescript(ctx context.Context, reqParams params.Params) (any, *neorpc.Error) {
 	tx, verbose, respErr := s.getInvokeScriptParams(reqParams)
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, nil, verbose)
+	return s.runScriptInVM(ctx, trigger.Application, tx.Script, util.Uint160{}, tx, nil, verbose)
 }
 
 // invokescripthistoric implements the `invokescripthistoric` RPC call.
-func (s *Server) invokescripthistoric(reqParams params.Params) (any, *neorpc.Error) {
+func (s *Server) invokescripthistoric(ctx context.Context, reqParams params.Params) (any, *neorpc.Error) {
 	nextH, respErr := s.getHistoricParams(reqParams)
 	if respErr != nil {
 		return nil, respErr
@@ -2227,7 +3062,7 @@ func (s *Server) invokescripthistoric(reqParams params.Params) (any, *neorpc.Err
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, &nextH, verbose)
+	return s.runScriptInVM(ctx, trigger.Application, tx.Script, util.Uint160{}, tx, &nextH, verbose)
 }
 
 func (s *Server) getInvokeScriptParams(reqParams params.Params) (*transaction.Transaction, bool, *neorpc.Error) {
@@ -2260,16 +3095,16 @@ func (s *Server) getInvokeScriptParams(reqParams params.Params) (*transaction.Tr
 }
 
 // invokeContractVerify implements the `invokecontractverify` RPC call.
-func (s *Server) invokeContractVerify(reqParams params.Params) (any, *neorpc.Error) {
+func (s *Server) invokeContractVerify(ctx context.Context, reqParams params.Params) (any, *neorpc.Error) {
 	scriptHash, tx, invocationScript, respErr := s.getInvokeContractVerifyParams(reqParams)
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx, nil, false)
+	return s.runScriptInVM(ctx, trigger.Verification, invocationScript, scriptHash, tx, nil, false)
 }
 
 // invokeContractVerifyHistoric implements the `invokecontractverifyhistoric` RPC call.
-func (s *Server) invokeContractVerifyHistoric(reqParams params.Params) (any, *neorpc.Error) {
+func (s *Server) invokeContractVerifyHistoric(ctx context.Context, reqParams params.Params) (any, *neorpc.Error) {
 	nextH, respErr := s.getHistoricParams(reqParams)
 	if respErr != nil {
 		return nil, respErr
@@ -2281,7 +3116,7 @@ func (s *Server) invokeContractVerifyHistoric(reqParams params.Params) (any, *ne
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx, &nextH, false)
+	return s.runScriptInVM(ctx, trigger.Verification, invocationScript, scriptHash, tx, &nextH, false)
 }
 
 func (s *Server) getInvokeContractVerifyParams(reqParams params.Params) (util.Uint160, *transaction.Transaction, []byte, *neorpc.Error) {
@@ -2325,7 +3160,8 @@ func (s *Server) getInvokeContractVerifyParams(reqParams params.Params) (util.Ui
 // specified stateroot is stored at the specified height for further request
 // handling consistency.
 func (s *Server) getHistoricParams(reqParams params.Params) (uint32, *neorpc.Error) {
-	if s.chain.GetConfig().Ledger.KeepOnlyLatestState {
+	var cfg = s.chain.GetConfig().Ledger
+	if cfg.KeepOnlyLatestState && cfg.KeepRecentStateRoots == 0 {
 		return 0, neorpc.WrapErrorWithData(neorpc.ErrUnsupportedState, fmt.Sprintf("only latest state is supported: %s", errKeepOnlyLatestState))
 	}
 	if len(reqParams) < 1 {
@@ -2348,10 +3184,13 @@ func (s *Server) getHistoricParams(reqParams params.Params) (uint32, *neorpc.Err
 			height = b.Index
 		}
 	}
+	if cfg.KeepOnlyLatestState && height+cfg.KeepRecentStateRoots <= s.chain.BlockHeight() {
+		return 0, neorpc.WrapErrorWithData(neorpc.ErrUnsupportedState, fmt.Sprintf("only %d latest states are supported: %s", cfg.KeepRecentStateRoots, errKeepOnlyLatestState))
+	}
 	return height + 1, nil
 }
 
-func (s *Server) prepareInvocationContext(t trigger.Type, script []byte, contractScriptHash util.Uint160, tx *transaction.Transaction, nextH *uint32, verbose bool) (*interop.Context, *neorpc.Error) {
+func (s *Server) prepareInvocationContext(t trigger.Type, script []byte, contractScriptHash util.Uint160, tx *transaction.Transaction, nextH *uint32, verbose bool, lim *invokeLimits) (*interop.Context, *neorpc.Error) {
 	var (
 		err error
 		ic  *interop.Context
@@ -2363,6 +3202,9 @@ func (s *Server) prepareInvocationContext(t trigger.Type, script []byte, contrac
 		}
 	} else {
 		ic, err = s.chain.GetTestHistoricVM(t, tx, *nextH)
+		if errors.Is(err, core.ErrStateRemoved) && s.stateArchive != nil {
+			ic, err = s.getArchivedHistoricVM(t, tx, *nextH)
+		}
 		if err != nil {
 			return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to create historic VM: %s", err))
 		}
@@ -2370,7 +3212,8 @@ func (s *Server) prepareInvocationContext(t trigger.Type, script []byte, contrac
 	if verbose {
 		ic.VM.EnableInvocationTree()
 	}
-	ic.VM.GasLimit = int64(s.config.MaxGasInvoke)
+	ic.VM.GasLimit = lim.maxGas
+	ic.VM.OpcodeLimit = lim.maxOpcodes
 	if t == trigger.Verification {
 		// We need this special case because witnesses verification is not the simple System.Contract.Call,
 		// and we need to define exactly the amount of gas consumed for a contract witness verification.
@@ -2393,23 +3236,54 @@ func (s *Server) prepareInvocationContext(t trigger.Type, script []byte, contrac
 	return ic, nil
 }
 
+// getArchivedHistoricVM returns an interop context for a historic invocation
+// based on the state that is removed from the node DB, this state is restored
+// from the state archive.
+func (s *Server) getArchivedHistoricVM(t trigger.Type, tx *transaction.Transaction, nextH uint32) (*interop.Context, error) {
+	// State for the block N is based on the stateroot of N-1.
+	sr, err := s.chain.GetStateModule().GetStateRoot(nextH - 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve stateroot for height %d: %w", nextH-1, err)
+	}
+	st, err := s.stateArchive.getStore(nextH-1, sr.Root)
+	if err != nil {
+		return nil, err
+	}
+	return s.chain.GetTestHistoricVMWithStore(t, tx, nextH, st)
+}
+
 // runScriptInVM runs the given script in a new test VM and returns the invocation
 // result. The script is either a simple script in case of `application` trigger,
 // witness invocation script in case of `verification` trigger (it pushes `verify`
 // arguments on stack before verification). In case of contract verification
-// contractScriptHash should be specified.
-func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash util.Uint160, tx *transaction.Transaction, nextH *uint32, verbose bool) (*result.Invoke, *neorpc.Error) {
-	ic, respErr := s.prepareInvocationContext(t, script, contractScriptHash, tx, nextH, verbose)
+// contractScriptHash should be specified. Execution is aborted as soon as the
+// given context is done, it also FAULTs if it takes more time than allowed by
+// the invocation limits of the request.
+func (s *Server) runScriptInVM(ctx context.Context, t trigger.Type, script []byte, contractScriptHash util.Uint160, tx *transaction.Transaction, nextH *uint32, verbose bool) (*result.Invoke, *neorpc.Error) {
+	lim := s.getInvokeLimits(ctx)
+	ic, respErr := s.prepareInvocationContext(t, script, contractScriptHash, tx, nextH, verbose, lim)
 	if respErr != nil {
 		return nil, respErr
 	}
+	var runCtx = ctx
+	if lim.maxTime > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeoutCause(ctx, lim.maxTime, errInvokeTimeLimitExceeded)
+		defer cancel()
+	}
+	stop := interruptOnDone(runCtx, ic)
 	err := ic.VM.Run()
+	stop()
+	if respErr = checkRequestContext(ctx); respErr != nil {
+		ic.Finalize()
+		return nil, respErr
+	}
 	var faultException string
 	if err != nil {
 		faultException = err.Error()
 	}
 	items := ic.VM.Estack().ToArray()
-	sess := s.postProcessExecStack(items)
+	sess := s.postProcessExecStack(items, lim.maxIteratorValues)
 	var id uuid.UUID
 
 	if sess != nil {
@@ -2418,7 +3292,7 @@ func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash
 		if s.config.SessionBackedByMPT && nextH == nil {
 			ic.Finalize()
 			// Rerun with MPT-backed storage.
-			return s.runScriptInVM(t, script, contractScriptHash, tx, &ic.Block.Index, verbose)
+			return s.runScriptInVM(ctx, t, script, contractScriptHash, tx, &ic.Block.Index, verbose)
 		}
 		id = uuid.New()
 		sessionID := id.String()
@@ -2436,6 +3310,7 @@ func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash
 			sess.iteratorsLock.Lock()
 			sess.finalize()
 			delete(s.sessions, sessionID)
+			addSessionsMetric(-1)
 			sess.iteratorsLock.Unlock()
 		})
 		s.sessionsLock.Lock()
@@ -2445,6 +3320,7 @@ func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash
 			return nil, neorpc.NewInternalServerError("max session capacity reached")
 		}
 		s.sessions[sessionID] = sess
+		addSessionsMetric(1)
 		s.sessionsLock.Unlock()
 	} else {
 		ic.Finalize()
@@ -2475,15 +3351,49 @@ func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash
 	return res, nil
 }
 
-// postProcessExecStack changes iterator interop items according to the server configuration.
-// It does modifications in-place, but it returns a session if any iterator was registered.
-func (s *Server) postProcessExecStack(stack []stackitem.Item) *session {
+// errRequestAborted is used when request processing is stopped because the
+// client has gone away.
+var errRequestAborted = errors.New("request aborted")
+
+// interruptOnDone makes the VM of the given interop context fail on the next
+// instruction after ctx is done. The returned function must be called when
+// the execution is finished to release associated resources.
+func interruptOnDone(ctx context.Context, ic *interop.Context) func() bool {
+	if ctx.Done() == nil { // Can't be canceled.
+		return func() bool { return true }
+	}
+	var done atomic.Bool
+	ic.VM.SetPriceGetter(func(op opcode.Opcode, param []byte) int64 {
+		if done.Load() {
+			if errors.Is(context.Cause(ctx), errInvokeTimeLimitExceeded) {
+				panic(errInvokeTimeLimitExceeded)
+			}
+			panic(errRequestAborted)
+		}
+		return ic.GetPrice(op, param)
+	})
+	return context.AfterFunc(ctx, func() { done.Store(true) })
+}
+
+// checkRequestContext returns an error if the request context is done, so
+// that the rest of the request processing can be skipped.
+func checkRequestContext(ctx context.Context) *neorpc.Error {
+	if err := ctx.Err(); err != nil {
+		return neorpc.NewInternalServerError(fmt.Sprintf("%s: %s", errRequestAborted, err))
+	}
+	return nil
+}
+
+// postProcessExecStack changes iterator interop items according to the server configuration
+// (maxValues iterator values are returned when sessions are disabled). It does
+// modifications in-place, but it returns a session if any iterator was registered.
+func (s *Server) postProcessExecStack(stack []stackitem.Item, maxValues int) *session {
 	var sess session
 
 	for i, v := range stack {
 		var id uuid.UUID
 
-		stack[i], id = s.registerOrDumpIterator(v)
+		stack[i], id = s.registerOrDumpIterator(v, maxValues)
 		if id != (uuid.UUID{}) {
 			sess.iteratorIdentifiers = append(sess.iteratorIdentifiers, &iteratorIdentifier{
 				ID:   id.String(),
@@ -2500,7 +3410,7 @@ func (s *Server) postProcessExecStack(stack []stackitem.Item) *session {
 // registerOrDumpIterator changes iterator interop stack items into result.Iterator
 // interop stack items and returns a uuid for it if sessions are enabled. All the other stack
 // items are not changed.
-func (s *Server) registerOrDumpIterator(item stackitem.Item) (stackitem.Item, uuid.UUID) {
+func (s *Server) registerOrDumpIterator(item stackitem.Item, maxValues int) (stackitem.Item, uuid.UUID) {
 	var iterID uuid.UUID
 
 	if (item.Type() != stackitem.InteropT) || !iterator.IsIterator(item) {
@@ -2512,32 +3422,17 @@ func (s *Server) registerOrDumpIterator(item stackitem.Item) (stackitem.Item, uu
 		iterID = uuid.New()
 		resIterator.ID = &iterID
 	} else {
-		resIterator.Values, resIterator.Truncated = iterator.ValuesTruncated(item, s.config.MaxIteratorResultItems)
+		resIterator.Values, resIterator.Truncated = iterator.ValuesTruncated(item, maxValues)
 	}
 	return stackitem.NewInterop(resIterator), iterID
 }
 
-func (s *Server) traverseIterator(reqParams params.Params) (any, *neorpc.Error) {
-	if !s.config.SessionEnabled {
-		return nil, neorpc.ErrSessionsDisabled
-	}
-	sID, err := reqParams.Value(0).GetUUID()
-	if err != nil {
-		return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("invalid session ID: %s", err))
-	}
-	iID, err := reqParams.Value(1).GetUUID()
-	if err != nil {
-		return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("invalid iterator ID: %s", err))
-	}
-	count, err := reqParams.Value(2).GetInt()
-	if err != nil {
-		return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("invalid iterator items count: %s", err))
-	}
-	if err := checkInt32(count); err != nil {
-		return nil, neorpc.NewInvalidParamsError("invalid iterator items count: not an int32")
-	}
-	if count > s.config.MaxIteratorResultItems {
-		return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("iterator items count (%d) is out of range (%d at max)", count, s.config.MaxIteratorResultItems))
+func (s *Server) traverseIterator(ctx context.Context, reqParams params.Params) (any, *neorpc.Error) {
+	sID, _ := reqParams.Value(0).GetUUID()
+	iID, _ := reqParams.Value(1).GetUUID()
+	count, _ := reqParams.Value(2).GetInt()
+	if maxCount := s.getInvokeLimits(ctx).maxIteratorValues; count > maxCount {
+		return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("iterator items count (%d) is out of range (%d at max)", count, maxCount))
 	}
 
 	s.sessionsLock.Lock()
@@ -2569,7 +3464,10 @@ func (s *Server) traverseIterator(reqParams params.Params) (any, *neorpc.Error)
 		return nil, neorpc.ErrUnknownIterator
 	}
 
-	result := make([]json.RawMessage, len(iVals))
+	var (
+		err    error
+		result = make([]json.RawMessage, len(iVals))
+	)
 	for j := range iVals {
 		result[j], err = stackitem.ToJSONWithTypes(iVals[j])
 		if err != nil {
@@ -2580,13 +3478,7 @@ func (s *Server) traverseIterator(reqParams params.Params) (any, *neorpc.Error)
 }
 
 func (s *Server) terminateSession(reqParams params.Params) (any, *neorpc.Error) {
-	if !s.config.SessionEnabled {
-		return nil, neorpc.ErrSessionsDisabled
-	}
-	sID, err := reqParams.Value(0).GetUUID()
-	if err != nil {
-		return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("invalid session ID: %s", err))
-	}
+	sID, _ := reqParams.Value(0).GetUUID()
 	strSID := sID.String()
 	s.sessionsLock.Lock()
 	defer s.sessionsLock.Unlock()
@@ -2605,16 +3497,14 @@ func (s *Server) terminateSession(reqParams params.Params) (any, *neorpc.Error)
 		}
 	}
 	delete(s.sessions, strSID)
+	addSessionsMetric(-1)
 	session.iteratorsLock.Unlock()
 	return ok, nil
 }
 
 // submitBlock broadcasts a raw block over the Neo network.
 func (s *Server) submitBlock(reqParams params.Params) (any, *neorpc.Error) {
-	blockBytes, err := reqParams.Value(0).GetBytesBase64()
-	if err != nil {
-		return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("missing parameter or not a base64: %s", err))
-	}
+	blockBytes, _ := reqParams.Value(0).GetBytesBase64()
 	b := block.New(s.stateRootEnabled)
 	r := io.NewBinReaderFromBuf(blockBytes)
 	b.DecodeBinary(r)
@@ -2626,14 +3516,7 @@ func (s *Server) submitBlock(reqParams params.Params) (any, *neorpc.Error) {
 
 // submitNotaryRequest broadcasts P2PNotaryRequest over the Neo network.
 func (s *Server) submitNotaryRequest(ps params.Params) (any, *neorpc.Error) {
-	if !s.chain.P2PSigExtensionsEnabled() {
-		return nil, neorpc.NewInternalServerError("P2PSignatureExtensions are disabled")
-	}
-
-	bytePayload, err := ps.Value(0).GetBytesBase64()
-	if err != nil {
-		return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("not a base64: %s", err))
-	}
+	bytePayload, _ := ps.Value(0).GetBytesBase64()
 	r, err := payload.NewP2PNotaryRequestFromBytes(bytePayload)
 	if err != nil {
 		return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("can't decode notary payload: %s", err))
@@ -2643,35 +3526,75 @@ func (s *Server) submitNotaryRequest(ps params.Params) (any, *neorpc.Error) {
 
 // getRelayResult returns successful relay result or an error.
 func getRelayResult(err error, hash util.Uint256) (any, *neorpc.Error) {
+	var e *neorpc.Error
 	switch {
 	case err == nil:
 		return result.RelayResult{
 			Hash: hash,
 		}, nil
 	case errors.Is(err, core.ErrTxExpired):
-		return nil, neorpc.WrapErrorWithData(neorpc.ErrExpiredTransaction, err.Error())
+		e = neorpc.ErrExpiredTransaction
 	case errors.Is(err, core.ErrAlreadyExists) || errors.Is(err, core.ErrInvalidBlockIndex):
-		return nil, neorpc.WrapErrorWithData(neorpc.ErrAlreadyExists, err.Error())
+		e = neorpc.ErrAlreadyExists
 	case errors.Is(err, core.ErrAlreadyInPool):
-		return nil, neorpc.WrapErrorWithData(neorpc.ErrAlreadyInPool, err.Error())
+		e = neorpc.ErrAlreadyInPool
 	case errors.Is(err, core.ErrOOM):
-		return nil, neorpc.WrapErrorWithData(neorpc.ErrMempoolCapReached, err.Error())
+		e = neorpc.ErrMempoolCapReached
 	case errors.Is(err, core.ErrPolicy):
-		return nil, neorpc.WrapErrorWithData(neorpc.ErrPolicyFailed, err.Error())
+		e = neorpc.ErrPolicyFailed
 	case errors.Is(err, core.ErrInvalidScript):
-		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidScript, err.Error())
+		e = neorpc.ErrInvalidScript
 	case errors.Is(err, core.ErrTxTooBig):
-		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidSize, err.Error())
+		e = neorpc.ErrInvalidSize
 	case errors.Is(err, core.ErrTxSmallNetworkFee):
-		return nil, neorpc.WrapErrorWithData(neorpc.ErrInsufficientNetworkFee, err.Error())
+		e = neorpc.ErrInsufficientNetworkFee
 	case errors.Is(err, core.ErrInvalidAttribute):
-		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidAttribute, err.Error())
+		e = neorpc.ErrInvalidAttribute
 	case errors.Is(err, core.ErrInsufficientFunds), errors.Is(err, core.ErrMemPoolConflict):
-		return nil, neorpc.WrapErrorWithData(neorpc.ErrInsufficientFunds, err.Error())
+		e = neorpc.ErrInsufficientFunds
+	case errors.Is(err, core.ErrInvalidSignature):
+		e = neorpc.ErrInvalidSignature
+	default:
+		e = neorpc.ErrVerificationFailed
+	}
+	return nil, neorpc.WrapErrorWithVerification(e, err.Error(), getVerificationFailure(err))
+}
+
+// getVerificationFailure returns witness check failure details if the error
+// is caused by some witness.
+func getVerificationFailure(err error) *neorpc.VerificationFailure {
+	var we *core.WitnessVerificationError
+	if !errors.As(err, &we) {
+		return nil
+	}
+	var reason neorpc.VerificationFailureReason
+	switch {
+	case errors.Is(err, core.ErrWitnessHashMismatch):
+		reason = neorpc.VerificationFailureHashMismatch
+	case errors.Is(err, core.ErrNativeContractWitness):
+		reason = neorpc.VerificationFailureNativeContract
+	case errors.Is(err, core.ErrInvalidInvocationScript):
+		reason = neorpc.VerificationFailureInvalidInvocationScript
+	case errors.Is(err, core.ErrInvalidVerificationScript):
+		reason = neorpc.VerificationFailureInvalidVerificationScript
+	case errors.Is(err, core.ErrUnknownVerificationContract):
+		reason = neorpc.VerificationFailureUnknownContract
+	case errors.Is(err, core.ErrInvalidVerificationContract):
+		reason = neorpc.VerificationFailureInvalidContract
+	case errors.Is(err, core.ErrVerificationScriptFailed):
+		reason = neorpc.VerificationFailureExecutionFailed
+	case errors.Is(err, core.ErrInvalidVerificationResult):
+		reason = neorpc.VerificationFailureInvalidResult
 	case errors.Is(err, core.ErrInvalidSignature):
-		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidSignature, err.Error())
+		reason = neorpc.VerificationFailureInvalidSignature
 	default:
-		return nil, neorpc.WrapErrorWithData(neorpc.ErrVerificationFailed, err.Error())
+		reason = neorpc.VerificationFailureUnknown
+	}
+	return &neorpc.VerificationFailure{
+		Reason:      reason,
+		Witness:     we.Index,
+		ScriptHash:  we.ScriptHash,
+		GasConsumed: we.GasConsumed,
 	}
 }
 
@@ -2709,14 +3632,33 @@ func (s *Server) submitOracleResponse(ps params.Params) (any, *neorpc.Error) {
 	return json.RawMessage([]byte("{}")), nil
 }
 
-func (s *Server) sendrawtransaction(reqParams params.Params) (any, *neorpc.Error) {
-	if len(reqParams) < 1 {
-		return nil, neorpc.NewInvalidParamsError("not enough parameters")
+// devnetMine produces the requested number of blocks (1 by default) in devnet
+// mode and returns their hashes.
+func (s *Server) devnetMine(ps params.Params) (any, *neorpc.Error) {
+	var miner BlockMiner
+	if m, ok := s.miner.Load().(*BlockMiner); ok {
+		miner = *m
+	}
+	if miner == nil {
+		return nil, neorpc.NewInvalidRequestError("devnet mode is not enabled")
+	}
+	count := 1
+	if len(ps) > 0 {
+		var err error
+		count, err = ps[0].GetInt()
+		if err != nil || count <= 0 {
+			return nil, neorpc.NewInvalidParamsError("invalid number of blocks")
+		}
 	}
-	byteTx, err := reqParams[0].GetBytesBase64()
+	hashes, err := miner.Mine(count)
 	if err != nil {
-		return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("not a base64: %s", err))
+		return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to produce blocks: %s", err))
 	}
+	return hashes, nil
+}
+
+func (s *Server) sendrawtransaction(reqParams params.Params) (any, *neorpc.Error) {
+	byteTx, _ := reqParams.Value(0).GetBytesBase64()
 	tx, err := transaction.NewTransactionFromBytes(byteTx)
 	if err != nil {
 		return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("can't decode transaction: %s", err))
@@ -2808,6 +3750,7 @@ func (s *Server) subscribe(reqParams params.Params, sub *subscriber) (any, *neor
 // it's not yet subscribed for them. It's supposed to be called with s.subsCounterLock
 // taken by the caller.
 func (s *Server) subscribeToChannel(event neorpc.EventID) {
+	addSubscriptionsMetric(event, 1)
 	switch event {
 	case neorpc.BlockEventID:
 		if s.blockSubs == 0 {
@@ -2869,6 +3812,7 @@ func (s *Server) unsubscribe(reqParams params.Params, sub *subscriber) (any, *ne
 // if there are no other subscribers for it. It must be called with s.subsConutersLock
 // holding by the caller.
 func (s *Server) unsubscribeFromChannel(event neorpc.EventID) {
+	addSubscriptionsMetric(event, -1)
 	switch event {
 	case neorpc.BlockEventID:
 		s.blockSubs--
@@ -3153,9 +4097,6 @@ func (s *Server) Addresses() []string {
 }
 
 func (s *Server) getRawNotaryPool(_ params.Params) (any, *neorpc.Error) {
-	if !s.chain.P2PSigExtensionsEnabled() {
-		return nil, neorpc.NewInternalServerError("P2PSignatureExtensions are disabled")
-	}
 	nrp := s.coreServer.GetNotaryPool()
 	res := &result.RawNotaryPool{Hashes: make(map[util.Uint256][]util.Uint256)}
 	nrp.IterateVerifiedTransactions(func(tx *transaction.Transaction, data any) bool {
@@ -3171,14 +4112,8 @@ func (s *Server) getRawNotaryPool(_ params.Params) (any, *neorpc.Error) {
 }
 
 func (s *Server) getRawNotaryTransaction(reqParams params.Params) (any, *neorpc.Error) {
-	if !s.chain.P2PSigExtensionsEnabled() {
-		return nil, neorpc.NewInternalServerError("P2PSignatureExtensions are disabled")
-	}
 
-	txHash, err := reqParams.Value(0).GetUint256()
-	if err != nil {
-		return nil, neorpc.ErrInvalidParams
-	}
+	txHash, _ := reqParams.Value(0).GetUint256()
 	nrp := s.coreServer.GetNotaryPool()
 	// Try to find fallback transaction.
 	tx, ok := nrp.TryGetValue(txHash)
@@ -176,3 +176,25 @@ func (fs *FeerStub) GetUtilityTokenBalance(acc util.Uint160) *big.Int {
 func (fs FeerStub) GetBaseExecFee() int64 {
 	return interop.DefaultBaseExecFee
 }
+
+func TestRPCSchemas(t *testing.T) {
+	isKnown := func(method string) bool {
+		_, ok := rpcHandlers[method]
+		if !ok {
+			_, ok = rpcCtxHandlers[method]
+		}
+		return ok
+	}
+	for method, schema := range rpcSchemas {
+		require.True(t, isKnown(method), method)
+		for i, spec := range schema {
+			require.NotEmpty(t, spec.Name, method)
+			if i > 0 && schema[i-1].Optional {
+				require.True(t, spec.Optional, "%s: required %s parameter after an optional one", method, spec.Name)
+			}
+		}
+	}
+	for method := range rpcRequirements {
+		require.True(t, isKnown(method), method)
+	}
+}
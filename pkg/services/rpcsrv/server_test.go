@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	gio "io"
 	"math"
@@ -31,6 +32,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage/dboper"
@@ -38,16 +40,20 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/network"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/oracle"
 	rpc2 "github.com/nspcc-dev/neo-go/pkg/services/oracle/broadcaster"
 	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv/params"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/invocations"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
@@ -56,6 +62,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -171,7 +178,7 @@ var rpcFunctionsWithUnsupportedStatesTestCases = map[string][]rpcTestCase{
 	"findstates": {
 		{
 			name:    "unsupported state",
-			params:  `["` + block20StateRootLE + `", "0xabcdef"]`,
+			params:  `["` + block20StateRootLE + `", "` + testContractHashLE + `", "QQ=="]`,
 			fail:    true,
 			errCode: neorpc.ErrUnsupportedStateCode,
 		},
@@ -179,7 +186,7 @@ var rpcFunctionsWithUnsupportedStatesTestCases = map[string][]rpcTestCase{
 	"findstoragehistoric": {
 		{
 			name:    "unsupported state",
-			params:  `["` + block20StateRootLE + `", "0xabcdef"]`,
+			params:  `["` + block20StateRootLE + `", "` + testContractHashLE + `", "QQ=="]`,
 			fail:    true,
 			errCode: neorpc.ErrUnsupportedStateCode,
 		},
@@ -211,6 +218,17 @@ var rpcTestCases = map[string][]rpcTestCase{
 				assert.Equal(t, vmstate.Halt, res.Executions[0].VMState)
 			},
 		},
+		{
+			name:   "positive, null optional parameters",
+			params: `["` + deploymentTxHash + `", null, null, null]`,
+			result: func(e *executor) any { return &result.ApplicationLog{} },
+			check: func(t *testing.T, e *executor, acc any) {
+				res, ok := acc.(*result.ApplicationLog)
+				require.True(t, ok)
+				assert.Equal(t, 1, len(res.Executions))
+				assert.Equal(t, trigger.Application, res.Executions[0].Trigger)
+			},
+		},
 		{
 			name:   "positive, genesis block",
 			params: `["` + genesisBlockHash + `"]`,
@@ -251,6 +269,39 @@ var rpcTestCases = map[string][]rpcTestCase{
 				assert.Equal(t, vmstate.Halt, res.Executions[0].VMState)
 			},
 		},
+		{
+			name:   "positive, genesis block, paged",
+			params: `["` + genesisBlockHash + `", "All", 1, 1]`,
+			result: func(e *executor) any { return &result.ApplicationLog{} },
+			check: func(t *testing.T, e *executor, acc any) {
+				res, ok := acc.(*result.ApplicationLog)
+				require.True(t, ok)
+				h, err := util.Uint256DecodeStringLE(genesisBlockHash)
+				require.NoError(t, err)
+				aers, err := e.chain.GetAppExecResults(h, trigger.All)
+				require.NoError(t, err)
+				require.Equal(t, len(aers), len(res.Executions))
+				var truncated bool
+				for i := range aers {
+					events := aers[i].Events
+					require.Equal(t, events[min(1, len(events)):min(2, len(events))], res.Executions[i].Events)
+					truncated = truncated || len(events) > 2
+				}
+				require.Equal(t, truncated, res.Truncated)
+			},
+		},
+		{
+			name:    "invalid offset",
+			params:  `["` + genesisBlockHash + `", "All", -1]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "invalid limit",
+			params:  `["` + genesisBlockHash + `", "All", 0, 0]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
 		{
 			name:    "invalid trigger (not a string)",
 			params:  `["` + genesisBlockHash + `", 1]`,
@@ -411,6 +462,38 @@ var rpcTestCases = map[string][]rpcTestCase{
 			},
 		},
 	},
+	"getcontractstorageusage": {
+		{
+			name:    "tracking disabled",
+			params:  `["` + testContractHashLE + `"]`,
+			fail:    true,
+			errCode: neorpc.InternalServerErrorCode,
+		},
+	},
+	"getstorageitemscount": {
+		{
+			name:    "tracking disabled",
+			params:  `["` + testContractHashLE + `"]`,
+			fail:    true,
+			errCode: neorpc.InternalServerErrorCode,
+		},
+	},
+	"findeventcontainers": {
+		{
+			name:    "index disabled",
+			params:  `["` + testContractHashLE + `", "Transfer"]`,
+			fail:    true,
+			errCode: neorpc.InternalServerErrorCode,
+		},
+	},
+	"findnotifications": {
+		{
+			name:    "index disabled",
+			params:  `["` + testContractHashLE + `", "Transfer", "from", {"type": "Hash160", "value": "` + testContractHashLE + `"}]`,
+			fail:    true,
+			errCode: neorpc.InternalServerErrorCode,
+		},
+	},
 	"getnep11transfers": {
 		{
 			name:    "no params",
@@ -524,6 +607,36 @@ var rpcTestCases = map[string][]rpcTestCase{
 			fail:    true,
 			errCode: neorpc.InvalidParamsCode,
 		},
+		{
+			name:    "malformed cursor",
+			params:  `["` + testchain.PrivateKeyByID(0).Address() + `", "1", "2", "3", "0", "notacursor"]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "page and cursor",
+			params:  `["` + testchain.PrivateKeyByID(0).Address() + `", "1", "2", "3", "1", "AAAAAAAAAAAAAAAA"]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "invalid asset",
+			params:  `["` + testchain.PrivateKeyByID(0).Address() + `", "1", "2", "3", "0", "", "notahash"]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "unknown asset",
+			params:  `["` + testchain.PrivateKeyByID(0).Address() + `", "1", "2", "3", "0", "", "` + util.Uint160{1, 2, 3}.StringLE() + `"]`,
+			fail:    true,
+			errCode: neorpc.ErrUnknownContractCode,
+		},
+		{
+			name:    "invalid counterparty",
+			params:  `["` + testchain.PrivateKeyByID(0).Address() + `", "1", "2", "3", "0", "", "", "notanaddress"]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
 		{
 			name:   "positive",
 			params: `["` + testchain.PrivateKeyByID(0).Address() + `", 0]`,
@@ -638,6 +751,24 @@ var rpcTestCases = map[string][]rpcTestCase{
 			fail:    true,
 			errCode: neorpc.ErrUnknownContractCode,
 		},
+		{
+			name:    "malformed cursor",
+			params:  `["` + block20StateRootLE + `", "` + testContractHashLE + `", "QQ==", "", null, "notabase64%"]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "short cursor",
+			params:  `["` + block20StateRootLE + `", "` + testContractHashLE + `", "QQ==", "", null, "QUJD"]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "key and cursor",
+			params:  `["` + block20StateRootLE + `", "` + testContractHashLE + `", "QQ==", "QQ==", null, "QUJD"]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
 	},
 	"getstateheight": {
 		{
@@ -1121,6 +1252,282 @@ var rpcTestCases = map[string][]rpcTestCase{
 			},
 		},
 	},
+	"getblockeconomics": {
+		{
+			name:   "positive, by index",
+			params: "[1]",
+			result: func(e *executor) any { return &result.BlockEconomics{} },
+			check: func(t *testing.T, e *executor, acc any) {
+				res, ok := acc.(*result.BlockEconomics)
+				require.True(t, ok)
+				b, err := e.chain.GetBlock(e.chain.GetHeaderHash(1))
+				require.NoError(t, err)
+				require.Equal(t, b.Hash(), res.Hash)
+				require.Equal(t, uint32(1), res.Index)
+				var sysFee, netFee int64
+				for _, tx := range b.Transactions {
+					sysFee += tx.SystemFee
+					netFee += tx.NetworkFee
+				}
+				require.Equal(t, sysFee, res.SystemFee)
+				require.Equal(t, netFee, res.NetworkFee)
+				require.Equal(t, sysFee+netFee, res.BurnedFees)
+				require.LessOrEqual(t, res.BurnedFees, res.Burned)
+
+				require.NotNil(t, res.CommitteeReward)
+				committee, err := e.chain.GetCommittee()
+				require.NoError(t, err)
+				require.True(t, slices.ContainsFunc(committee, func(k *keys.PublicKey) bool {
+					return k.GetScriptHash() == res.CommitteeReward.Account
+				}))
+				require.Equal(t, int64(50000000), res.CommitteeReward.Amount) // 10% of default 5 GAS per block.
+				require.NotNil(t, res.PrimaryReward)
+				validators, err := e.chain.GetNextBlockValidators()
+				require.NoError(t, err)
+				require.Equal(t, validators[b.PrimaryIndex].GetScriptHash(), res.PrimaryReward.Account)
+				require.Equal(t, netFee, res.PrimaryReward.Amount)
+				require.Empty(t, res.NotaryRewards)
+				require.Empty(t, res.OracleRewards)
+				require.LessOrEqual(t, res.CommitteeReward.Amount+res.PrimaryReward.Amount, res.Minted)
+			},
+		},
+		{
+			name:   "positive, genesis block by hash",
+			params: `["` + genesisBlockHash + `"]`,
+			result: func(e *executor) any { return &result.BlockEconomics{} },
+			check: func(t *testing.T, e *executor, acc any) {
+				res, ok := acc.(*result.BlockEconomics)
+				require.True(t, ok)
+				require.Equal(t, genesisBlockHash, res.Hash.StringLE())
+				require.Equal(t, int64(0), res.SystemFee)
+				require.Equal(t, int64(0), res.BurnedFees)
+				require.Positive(t, res.Minted)
+				require.Nil(t, res.PrimaryReward)
+				require.Nil(t, res.CommitteeReward)
+			},
+		},
+		{
+			name:    "no params",
+			params:  `[]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "unknown block",
+			params:  `["` + util.Uint256{1, 2, 3}.StringLE() + `"]`,
+			fail:    true,
+			errCode: neorpc.ErrUnknownBlockCode,
+		},
+	},
+	"getblocksrange": {
+		{
+			name:   "positive, count",
+			params: "[2, 3]",
+			result: func(e *executor) any { return &result.BlocksRange{} },
+			check: func(t *testing.T, e *executor, acc any) {
+				res, ok := acc.(*result.BlocksRange)
+				require.True(t, ok)
+				require.EqualValues(t, 2, res.Start)
+				require.EqualValues(t, 3, res.Count)
+				r := io.NewBinReaderFromBuf(res.Blocks)
+				for i := range uint32(3) {
+					expected, err := e.chain.GetBlock(e.chain.GetHeaderHash(2 + i))
+					require.NoError(t, err)
+					data := make([]byte, r.ReadU32LE())
+					r.ReadBytes(data)
+					require.NoError(t, r.Err)
+					w := io.NewBufBinWriter()
+					expected.EncodeBinary(w.BinWriter)
+					require.Equal(t, w.Bytes(), data)
+				}
+				require.Zero(t, r.Len())
+			},
+		},
+		{
+			name:   "positive, clipped by height",
+			params: "[1]",
+			result: func(e *executor) any { return &result.BlocksRange{} },
+			check: func(t *testing.T, e *executor, acc any) {
+				res, ok := acc.(*result.BlocksRange)
+				require.True(t, ok)
+				require.EqualValues(t, 1, res.Start)
+				require.Equal(t, min(e.chain.BlockHeight(), defaultBlocksRangeCount), res.Count)
+			},
+		},
+		{
+			name:    "no params",
+			params:  "[]",
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "unknown height",
+			params:  "[100500]",
+			fail:    true,
+			errCode: neorpc.ErrUnknownHeightCode,
+		},
+		{
+			name:    "zero count",
+			params:  "[1, 0]",
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "too big count",
+			params:  "[1, 1001]",
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+	},
+	"getblocktimestats": {
+		{
+			name:   "positive, default window",
+			params: "[]",
+			result: func(e *executor) any { return &result.BlockTimeStats{} },
+			check: func(t *testing.T, e *executor, acc any) {
+				res, ok := acc.(*result.BlockTimeStats)
+				require.True(t, ok)
+				height := e.chain.BlockHeight()
+				require.Equal(t, height, res.Height)
+				last, err := e.chain.GetHeader(e.chain.CurrentBlockHash())
+				require.NoError(t, err)
+				require.Equal(t, last.Timestamp, res.Timestamp)
+				count := min(int(height), 100)
+				require.Equal(t, count, len(res.Intervals))
+				prev, err := e.chain.GetHeader(e.chain.GetHeaderHash(height - 1))
+				require.NoError(t, err)
+				require.Equal(t, last.Timestamp-prev.Timestamp, res.Intervals[0])
+				require.Equal(t, 1, len(res.Windows))
+				w := res.Windows[0]
+				require.Equal(t, 100, w.Blocks)
+				require.Equal(t, count, w.Count)
+				require.Equal(t, slices.Min(res.Intervals), w.Min)
+				require.Equal(t, slices.Max(res.Intervals), w.Max)
+				require.True(t, w.Min <= w.Median && w.Median <= w.P90 && w.P90 <= w.P99 && w.P99 <= w.Max)
+				require.True(t, w.Min <= w.Average && w.Average <= w.Max)
+			},
+		},
+		{
+			name:   "positive, several windows",
+			params: "[5, 1]",
+			result: func(e *executor) any { return &result.BlockTimeStats{} },
+			check: func(t *testing.T, e *executor, acc any) {
+				res, ok := acc.(*result.BlockTimeStats)
+				require.True(t, ok)
+				require.Equal(t, 1, len(res.Intervals))
+				require.Equal(t, 2, len(res.Windows))
+				require.Equal(t, 5, res.Windows[0].Blocks)
+				require.Equal(t, 5, res.Windows[0].Count)
+				require.Equal(t, result.BlockTimeWindow{
+					Blocks:  1,
+					Count:   1,
+					Average: res.Intervals[0],
+					Min:     res.Intervals[0],
+					Max:     res.Intervals[0],
+					Median:  res.Intervals[0],
+					P90:     res.Intervals[0],
+					P99:     res.Intervals[0],
+				}, res.Windows[1])
+			},
+		},
+		{
+			name:    "zero window",
+			params:  "[0]",
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "too big window",
+			params:  "[10001]",
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "invalid window",
+			params:  `["one"]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "too many windows",
+			params:  "[1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17]",
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+	},
+	"getblockexecutionsummary": {
+		{
+			name:   "positive, by index",
+			params: "[1]",
+			result: func(e *executor) any { return &result.BlockExecutionSummary{} },
+			check: func(t *testing.T, e *executor, acc any) {
+				res, ok := acc.(*result.BlockExecutionSummary)
+				require.True(t, ok)
+				b, err := e.chain.GetBlock(e.chain.GetHeaderHash(1))
+				require.NoError(t, err)
+				require.Equal(t, b.Hash(), res.Hash)
+				require.Equal(t, uint32(1), res.Index)
+				require.Equal(t, 2, len(res.Executions))
+				require.Equal(t, trigger.OnPersist, res.Executions[0].Trigger)
+				require.Equal(t, trigger.PostPersist, res.Executions[1].Trigger)
+				require.Equal(t, len(b.Transactions), len(res.Transactions))
+
+				var (
+					gas    int64
+					events int
+				)
+				for _, ex := range res.Executions {
+					gas += ex.GasConsumed
+					events += ex.Notifications
+				}
+				for i, tx := range b.Transactions {
+					aers, err := e.chain.GetAppExecResults(tx.Hash(), trigger.Application)
+					require.NoError(t, err)
+					require.Equal(t, tx.Hash(), res.Transactions[i].Hash)
+					require.Equal(t, trigger.Application, res.Transactions[i].Trigger)
+					require.Equal(t, aers[0].VMState, res.Transactions[i].VMState)
+					require.Equal(t, aers[0].GasConsumed, res.Transactions[i].GasConsumed)
+					require.Equal(t, len(aers[0].Events), res.Transactions[i].Notifications)
+					gas += res.Transactions[i].GasConsumed
+					events += res.Transactions[i].Notifications
+				}
+				require.Equal(t, gas, res.GasConsumed)
+				require.Equal(t, events, res.Notifications)
+			},
+		},
+		{
+			name:   "positive, genesis block by hash",
+			params: `["` + genesisBlockHash + `"]`,
+			result: func(e *executor) any { return &result.BlockExecutionSummary{} },
+			check: func(t *testing.T, e *executor, acc any) {
+				res, ok := acc.(*result.BlockExecutionSummary)
+				require.True(t, ok)
+				require.Equal(t, genesisBlockHash, res.Hash.StringLE())
+				require.Equal(t, uint32(0), res.Index)
+				require.Equal(t, 2, len(res.Executions))
+				require.Equal(t, vmstate.Halt, res.Executions[0].VMState)
+				require.Equal(t, 0, len(res.Transactions))
+			},
+		},
+		{
+			name:    "no params",
+			params:  `[]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "unknown block",
+			params:  `["` + util.Uint256{1, 2, 3}.StringLE() + `"]`,
+			fail:    true,
+			errCode: neorpc.ErrUnknownBlockCode,
+		},
+		{
+			name:    "invalid height",
+			params:  `[-2]`,
+			fail:    true,
+			errCode: neorpc.ErrUnknownHeightCode,
+		},
+	},
 	"getblockhash": {
 		{
 			params: "[1]",
@@ -1261,6 +1668,18 @@ var rpcTestCases = map[string][]rpcTestCase{
 		},
 	},
 	"getrawtransaction": {
+		{
+			name:   "positive, null verbose",
+			params: `["` + deploymentTxHash + `", null]`,
+			result: func(e *executor) any { return new([]byte) },
+			check: func(t *testing.T, e *executor, res any) {
+				raw, ok := res.(*[]byte)
+				require.True(t, ok)
+				tx, err := transaction.NewTransactionFromBytes(*raw)
+				require.NoError(t, err)
+				require.Equal(t, deploymentTxHash, tx.Hash().StringLE())
+			},
+		},
 		{
 			name:    "no params",
 			params:  `[]`,
@@ -2325,6 +2744,75 @@ func TestSubmitOracle(t *testing.T) {
 	msg := rpc2.GetMessage(priv.PublicKey().Bytes(), 1, txSig)
 	msgSigStr := `"` + base64.StdEncoding.EncodeToString(priv.Sign(msg)) + `"`
 	t.Run("Valid", runCase(t, false, 0, pubStr, `1`, txSigStr, msgSigStr))
+
+	t.Run("Responder", func(t *testing.T) {
+		bad, err := rpcclient.New(context.Background(), "http://127.0.0.1:1", rpcclient.Options{})
+		require.NoError(t, err)
+		good, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+		require.NoError(t, err)
+
+		r, err := oracle.NewResponder(priv, bad, good)
+		require.NoError(t, err)
+		require.NoError(t, r.Submit(1, txSig))
+
+		r, err = oracle.NewResponder(priv, bad)
+		require.NoError(t, err)
+		require.Error(t, r.Submit(1, txSig))
+	})
+}
+
+type testMiner struct {
+	counts []int
+	err    error
+}
+
+func (m *testMiner) Mine(count int) ([]util.Uint256, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.counts = append(m.counts, count)
+	res := make([]util.Uint256, count)
+	for i := range res {
+		res[i] = util.Uint256{byte(i + 1)}
+	}
+	return res, nil
+}
+
+func TestDevnetMine(t *testing.T) {
+	rpc := `{"jsonrpc": "2.0", "id": 1, "method": "devnet_mine", "params": %s}`
+	_, rpcSrv, httpSrv := initClearServerWithServices(t, false, false, false)
+
+	runCase := func(t *testing.T, fail bool, errCode int64, params string) json.RawMessage {
+		body := doRPCCallOverHTTP(fmt.Sprintf(rpc, params), httpSrv.URL, t)
+		return checkErrGetResult(t, body, fail, errCode)
+	}
+	t.Run("disabled", func(t *testing.T) {
+		runCase(t, true, neorpc.InvalidRequestCode, `[]`)
+		rpcSrv.SetBlockMiner(nil)
+		runCase(t, true, neorpc.InvalidRequestCode, `[]`)
+	})
+
+	m := &testMiner{}
+	rpcSrv.SetBlockMiner(m)
+	t.Run("invalid count", func(t *testing.T) {
+		runCase(t, true, neorpc.InvalidParamsCode, `[0]`)
+		runCase(t, true, neorpc.InvalidParamsCode, `["one"]`)
+	})
+	t.Run("default count", func(t *testing.T) {
+		var hashes []util.Uint256
+		require.NoError(t, json.Unmarshal(runCase(t, false, 0, `[]`), &hashes))
+		require.Equal(t, []util.Uint256{{1}}, hashes)
+	})
+	t.Run("positive", func(t *testing.T) {
+		var hashes []util.Uint256
+		require.NoError(t, json.Unmarshal(runCase(t, false, 0, `[3]`), &hashes))
+		require.Equal(t, []util.Uint256{{1}, {2}, {3}}, hashes)
+	})
+	require.Equal(t, []int{1, 3}, m.counts)
+	t.Run("miner failure", func(t *testing.T) {
+		m.err = errors.New("not enough keys")
+		runCase(t, true, neorpc.InternalServerErrorCode, `[1]`)
+	})
 }
 
 func TestNotaryRequestRPC(t *testing.T) {
@@ -2916,6 +3404,44 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 				Truncated: true,
 			})
 		})
+		t.Run("good: cursor", func(t *testing.T) {
+			// pairs for this test where put to the contract storage at block #16
+			root, err := e.chain.GetStateModule().GetStateRoot(16)
+			require.NoError(t, err)
+			prefix := base64.StdEncoding.EncodeToString([]byte("aa"))
+			var (
+				cursor string
+				pages  int
+				actual []result.KeyValue
+			)
+			for {
+				params := fmt.Sprintf(`"%s", "%s", "%s", "", 1, "%s"`, root.Root.StringLE(), testContractHashLE, prefix, cursor)
+				rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "findstates", "params": [%s]}`, params)
+				body := doRPCCall(rpc, httpSrv.URL, t)
+				var res result.FindStates
+				require.NoError(t, json.Unmarshal(checkErrGetResult(t, body, false, 0), &res))
+				require.Equal(t, res.Truncated, res.Next != "")
+				actual = append(actual, res.Results...)
+				pages++
+				if res.Next == "" {
+					break
+				}
+				cursor = res.Next
+			}
+			require.Equal(t, 3, pages)
+			require.Equal(t, []result.KeyValue{
+				{Key: []byte("aa10"), Value: []byte("v2")},
+				{Key: []byte("aa50"), Value: []byte("v3")},
+				{Key: []byte("aa"), Value: []byte("v1")},
+			}, actual)
+
+			t.Run("mismatch", func(t *testing.T) {
+				params := fmt.Sprintf(`"%s", "%s", "%s", "", 1, "%s"`, root.Root.StringLE(), testContractHashLE, base64.StdEncoding.EncodeToString([]byte("ab")), cursor)
+				rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "findstates", "params": [%s]}`, params)
+				body := doRPCCall(rpc, httpSrv.URL, t)
+				checkErrGetResult(t, body, true, neorpc.InvalidParamsCode, "cursor doesn't match request")
+			})
+		})
 	})
 
 	t.Run("getrawtransaction", func(t *testing.T) {
@@ -3036,6 +3562,57 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 		assert.ElementsMatch(t, expected, actual)
 	})
 
+	t.Run("getmempoolinfo", func(t *testing.T) {
+		mp := chain.GetMemPool()
+		var (
+			count   = mp.Count()
+			size    int
+			sender  = util.Uint160{4, 5, 6}
+			feeBase = chain.FeePerByte()
+		)
+		for _, tx := range mp.GetVerifiedTransactions() {
+			size += tx.Size()
+		}
+		for i := range 3 {
+			tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+			tx.Nonce = uint32(i)
+			tx.Signers = []transaction.Signer{{Account: sender}}
+			tx.NetworkFee = int64(tx.Size()) * feeBase * 5
+			if i == 0 {
+				tx.Attributes = []transaction.Attribute{{Type: transaction.ConflictsT, Value: &transaction.Conflicts{Hash: util.Uint256{1}}}}
+				tx.NetworkFee = int64(tx.Size()) * feeBase * 5
+			}
+			require.NoError(t, mp.Add(tx, &FeerStub{}))
+			size += tx.Size()
+		}
+
+		rpc := `{"jsonrpc": "2.0", "id": 1, "method": "getmempoolinfo", "params": []}`
+		body := doRPCCall(rpc, httpSrv.URL, t)
+		res := checkErrGetResult(t, body, false, 0)
+
+		var actual result.MempoolInfo
+		require.NoErrorf(t, json.Unmarshal(res, &actual), "could not parse response: %s", res)
+		require.Equal(t, chain.BlockHeight(), actual.Height)
+		require.Equal(t, count+3, actual.Count)
+		require.Equal(t, mp.Capacity(), actual.Capacity)
+		require.Equal(t, size, actual.Size)
+		require.Equal(t, 1, actual.Conflicting)
+		require.Equal(t, 0, actual.NotaryRequests)
+		require.Contains(t, actual.Senders, result.MempoolSender{Account: sender, Count: 3})
+		require.True(t, slices.IsSortedFunc(actual.Senders, func(a, b result.MempoolSender) int { return b.Count - a.Count }))
+		require.Len(t, actual.FeeHistogram, 16)
+		var histCount int
+		for i, b := range actual.FeeHistogram {
+			histCount += b.Count
+			if i > 0 {
+				require.Equal(t, feeBase<<(i-1), b.MinFeePerByte)
+			}
+		}
+		require.Equal(t, count+3, histCount)
+		// 5*FeePerByte is in [4*FeePerByte, 8*FeePerByte) bucket.
+		require.Equal(t, 3, actual.FeeHistogram[3].Count)
+	})
+
 	t.Run("getnep17transfers", func(t *testing.T) {
 		testNEP17T := func(t *testing.T, start, stop, limit, page int, sent, rcvd []int) {
 			ps := []string{`"` + testchain.PrivateKeyByID(0).Address() + `"`}
@@ -3079,6 +3656,82 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 		t.Run("limit 2", func(t *testing.T) { testNEP17T(t, 4, 5, 2, 0, []int{19}, []int{3}) })
 		t.Run("limit with page", func(t *testing.T) { testNEP17T(t, 1, 7, 3, 1, []int{18, 19}, []int{3}) })
 		t.Run("limit with page 2", func(t *testing.T) { testNEP17T(t, 1, 7, 3, 2, []int{20, 21}, []int{4}) })
+		t.Run("cursor", func(t *testing.T) {
+			var (
+				addr   = testchain.PrivateKeyByID(0).Address()
+				cursor string
+				sent   []result.NEP17Transfer
+				rcvd   []result.NEP17Transfer
+			)
+			for {
+				ps := fmt.Sprintf(`"%s", 0, %d, 3`, addr, uint64(time.Now().UnixNano()/1_000_000))
+				if cursor != "" {
+					ps += fmt.Sprintf(`, 0, "%s"`, cursor)
+				}
+				rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "getnep17transfers", "params": [%s]}`, ps)
+				body := doRPCCall(rpc, httpSrv.URL, t)
+				res := checkErrGetResult(t, body, false, 0)
+				actual := new(result.NEP17Transfers)
+				require.NoError(t, json.Unmarshal(res, actual))
+				require.LessOrEqual(t, len(actual.Sent)+len(actual.Received), 3)
+				sent = append(sent, actual.Sent...)
+				rcvd = append(rcvd, actual.Received...)
+				if actual.Next == "" {
+					break
+				}
+				cursor = actual.Next
+			}
+			checkNep17Transfers(t, e, &result.NEP17Transfers{Address: addr, Sent: sent, Received: rcvd})
+		})
+		t.Run("filter", func(t *testing.T) {
+			var (
+				addr = testchain.PrivateKeyByID(0).Address()
+				now  = uint64(time.Now().UnixNano() / 1_000_000)
+			)
+			getTransfers := func(t *testing.T, asset, counterparty string) *result.NEP17Transfers {
+				rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "getnep17transfers", "params": ["%s", 0, %d, 1000, 0, "", "%s", "%s"]}`,
+					addr, now, asset, counterparty)
+				body := doRPCCall(rpc, httpSrv.URL, t)
+				res := checkErrGetResult(t, body, false, 0)
+				actual := new(result.NEP17Transfers)
+				require.NoError(t, json.Unmarshal(res, actual))
+				return actual
+			}
+			filter := func(trs []result.NEP17Transfer, f func(tr result.NEP17Transfer) bool) []result.NEP17Transfer {
+				var res = []result.NEP17Transfer{}
+				for _, tr := range trs {
+					if f(tr) {
+						res = append(res, tr)
+					}
+				}
+				return res
+			}
+			all := getTransfers(t, "", "")
+			require.NotEmpty(t, all.Sent)
+
+			byAsset := getTransfers(t, nativehashes.GasToken.StringLE(), "")
+			isGAS := func(tr result.NEP17Transfer) bool { return tr.Asset.Equals(nativehashes.GasToken) }
+			require.Equal(t, filter(all.Sent, isGAS), byAsset.Sent)
+			require.Equal(t, filter(all.Received, isGAS), byAsset.Received)
+
+			var cp string
+			for _, tr := range all.Sent {
+				if tr.Address != "" && !tr.Asset.Equals(nativehashes.GasToken) {
+					cp = tr.Address
+					break
+				}
+			}
+			require.NotEmpty(t, cp)
+			byCounterparty := getTransfers(t, "", cp)
+			isCP := func(tr result.NEP17Transfer) bool { return tr.Address == cp }
+			require.Equal(t, filter(all.Sent, isCP), byCounterparty.Sent)
+			require.Equal(t, filter(all.Received, isCP), byCounterparty.Received)
+
+			both := getTransfers(t, nativehashes.GasToken.StringLE(), cp)
+			isBoth := func(tr result.NEP17Transfer) bool { return isGAS(tr) && isCP(tr) }
+			require.Equal(t, filter(all.Sent, isBoth), both.Sent)
+			require.Equal(t, filter(all.Received, isBoth), both.Received)
+		})
 	})
 
 	prepareIteratorSession := func(t *testing.T) (uuid.UUID, uuid.UUID) {
@@ -3118,25 +3771,25 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 			_, iID := prepareIteratorSession(t)
 			rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "traverseiterator", "params": ["not-a-uuid", "%s", %d]}"`, iID.String(), 1)
 			body := doRPCCall(rpc, httpSrv.URL, t)
-			checkErrGetResult(t, body, true, neorpc.InvalidParamsCode, "invalid session ID: not a valid UUID")
+			checkErrGetResult(t, body, true, neorpc.InvalidParamsCode, "invalid session parameter (#0): not a valid UUID")
 		})
 		t.Run("invalid iterator id", func(t *testing.T) {
 			sID, _ := prepareIteratorSession(t)
 			rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "traverseiterator", "params": ["%s", "not-a-uuid", %d]}"`, sID.String(), 1)
 			body := doRPCCall(rpc, httpSrv.URL, t)
-			checkErrGetResult(t, body, true, neorpc.InvalidParamsCode, "invalid iterator ID: not a valid UUID")
+			checkErrGetResult(t, body, true, neorpc.InvalidParamsCode, "invalid iterator parameter (#1): not a valid UUID")
 		})
 		t.Run("invalid items count", func(t *testing.T) {
 			sID, iID := prepareIteratorSession(t)
 			rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "traverseiterator", "params": ["%s", "%s"]}"`, sID.String(), iID.String())
 			body := doRPCCall(rpc, httpSrv.URL, t)
-			checkErrGetResult(t, body, true, neorpc.InvalidParamsCode, "invalid iterator items count")
+			checkErrGetResult(t, body, true, neorpc.InvalidParamsCode, "missing count parameter (#2)")
 		})
 		t.Run("items count is not an int32", func(t *testing.T) {
 			sID, iID := prepareIteratorSession(t)
 			rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "traverseiterator", "params": ["%s", "%s", %d]}"`, sID.String(), iID.String(), math.MaxInt32+1)
 			body := doRPCCall(rpc, httpSrv.URL, t)
-			checkErrGetResult(t, body, true, neorpc.InvalidParamsCode, "invalid iterator items count: not an int32")
+			checkErrGetResult(t, body, true, neorpc.InvalidParamsCode, "invalid count parameter (#2): 2147483648 is out of range (2147483647 at max)")
 		})
 		t.Run("count is out of range", func(t *testing.T) {
 			sID, iID := prepareIteratorSession(t)
@@ -3428,6 +4081,36 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 			runTestCasesWithExecutor(t, e, rpc, method, cases, doRPCCall, checkErrGetResult)
 		}
 	})
+	t.Run("test functions with recent states", func(t *testing.T) {
+		chain, _, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+			c.ApplicationConfiguration.Ledger.KeepOnlyLatestState = true
+			c.ApplicationConfiguration.Ledger.KeepRecentStateRoots = 2
+		})
+		for _, b := range getTestBlocks(t) {
+			require.NoError(t, chain.AddBlock(b))
+		}
+
+		rpc := `{"jsonrpc": "2.0", "id": 1, "method": "%s", "params": %s}`
+		// Current, previous and outdated states.
+		for i, outdated := range []bool{false, false, true} {
+			sr, err := chain.GetStateModule().GetStateRoot(chain.BlockHeight() - uint32(i))
+			require.NoError(t, err)
+			var code int64
+			if outdated {
+				code = neorpc.ErrUnsupportedStateCode
+			}
+			params := fmt.Sprintf(`["%s", "%s", "Dg=="]`, sr.Root.StringLE(), nativehashes.NeoToken.StringLE())
+			body := doRPCCall(fmt.Sprintf(rpc, "getstate", params), httpSrv.URL, t)
+			checkErrGetResult(t, body, outdated, code)
+
+			body = doRPCCall(fmt.Sprintf(rpc, "getproof", params), httpSrv.URL, t)
+			proof := checkErrGetResult(t, body, outdated, code)
+			if !outdated {
+				body = doRPCCall(fmt.Sprintf(rpc, "verifyproof", fmt.Sprintf(`["%s", %s]`, sr.Root.StringLE(), proof)), httpSrv.URL, t)
+				checkErrGetResult(t, body, false, 0)
+			}
+		}
+	})
 }
 
 func (e *executor) getHeader(s string) *block.Header {
@@ -4191,7 +4874,7 @@ func BenchmarkHandleIn(b *testing.B) {
 				b.FailNow()
 			}
 
-			res := rpcServer.handleIn(in, nil)
+			res := rpcServer.handleIn(context.Background(), in, nil)
 			if res.Error != nil {
 				b.FailNow()
 			}
@@ -4211,6 +4894,36 @@ func BenchmarkHandleIn(b *testing.B) {
 	})
 }
 
+func TestHandleInAborted(t *testing.T) {
+	_, srv, _ := initClearServerWithCustomConfig(t, func(c *config.Config) {
+		c.ApplicationConfiguration.RPC.MaxGasInvoke = fixedn.Fixed8FromInt64(1_000_000_000)
+	})
+	loop := base64.StdEncoding.EncodeToString([]byte{byte(opcode.JMP), 0}) // Infinite loop.
+
+	do := func(t *testing.T, ctx context.Context, req string) *neorpc.Error {
+		in := new(params.In)
+		require.NoError(t, json.Unmarshal([]byte(req), in))
+		return srv.handleIn(ctx, in, nil).Error
+	}
+
+	t.Run("invokescript", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(100*time.Millisecond, cancel)
+		respErr := do(t, ctx, `{"jsonrpc":"2.0","id":1,"method":"invokescript","params":["`+loop+`"]}`)
+		require.NotNil(t, respErr)
+		require.ErrorContains(t, respErr, "request aborted")
+	})
+	t.Run("findstates", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		root := srv.chain.GetStateModule().CurrentLocalStateRoot()
+		respErr := do(t, ctx, fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"findstates","params":["%s","%s",""]}`,
+			root.StringLE(), nativehashes.NeoToken.StringLE()))
+		require.NotNil(t, respErr)
+		require.ErrorContains(t, respErr, "request aborted")
+	})
+}
+
 func TestFailedPreconditionShutdown(t *testing.T) {
 	_, srv, _ := initClearServerWithCustomConfig(t, func(c *config.Config) {
 		c.ApplicationConfiguration.RPC.Addresses = []string{"not an address"}
@@ -4249,3 +4962,247 @@ func TestErrorResponseContentType(t *testing.T) {
 	contentType := resp.Header.Get("Content-Type")
 	require.Equal(t, expectedContentType, contentType)
 }
+
+func TestRedaction(t *testing.T) {
+	const req = `{"jsonrpc": "2.0", "id": 1, "method": "getversion", "params": []}`
+	seeds := []string{"127.0.0.1:20333", "dns+srv://_neo._tcp.example.com"}
+
+	check := func(t *testing.T, redact bool, expected []string) {
+		_, _, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+			c.ProtocolConfiguration.SeedList = seeds
+			c.ApplicationConfiguration.RPC.Redaction.SeedList = redact
+		})
+		body := doRPCCallOverHTTP(req, httpSrv.URL, t)
+		res := checkErrGetResult(t, body, false, 0)
+		var ver result.Version
+		require.NoError(t, json.Unmarshal(res, &ver))
+		require.Equal(t, expected, ver.Protocol.SeedList)
+	}
+	t.Run("disabled", func(t *testing.T) { check(t, false, seeds) })
+	t.Run("seed list", func(t *testing.T) { check(t, true, []string{}) })
+}
+
+func TestRedactPeers(t *testing.T) {
+	seeds := []string{"127.0.0.1:20333"}
+	newPeers := func() result.GetPeers {
+		peers := result.NewGetPeers()
+		peers.AddUnconnected([]string{"127.0.0.1:20333", "127.0.0.2:20333"})
+		peers.AddConnected([]network.PeerInfo{
+			{Address: "127.0.0.1:20333", UserAgent: "/seed/", Height: 10},
+			{Address: "127.0.0.3:20333", UserAgent: "/peer/", Height: 5},
+		})
+		peers.AddBad([]string{"127.0.0.4:20333"})
+		return peers
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		peers := newPeers()
+		redactPeers(&peers, config.RPCRedaction{}, seeds)
+		require.Equal(t, newPeers(), peers)
+	})
+	t.Run("peer addresses", func(t *testing.T) {
+		peers := newPeers()
+		redactPeers(&peers, config.RPCRedaction{PeerAddresses: true}, seeds)
+		require.Equal(t, result.Peers{{}, {}}, peers.Unconnected)
+		require.Equal(t, result.Peers{{UserAgent: "/seed/", LastKnownHeight: 10}, {UserAgent: "/peer/", LastKnownHeight: 5}}, peers.Connected)
+		require.Equal(t, result.Peers{{}}, peers.Bad)
+	})
+	t.Run("seed list", func(t *testing.T) {
+		peers := newPeers()
+		redactPeers(&peers, config.RPCRedaction{SeedList: true}, seeds)
+		require.Equal(t, result.Peers{{Address: "127.0.0.2", Port: 20333}}, peers.Unconnected)
+		require.Equal(t, result.Peers{{Address: "127.0.0.3", Port: 20333, UserAgent: "/peer/", LastKnownHeight: 5}}, peers.Connected)
+		require.Equal(t, result.Peers{{Address: "127.0.0.4", Port: 20333}}, peers.Bad)
+	})
+	t.Run("both", func(t *testing.T) {
+		peers := newPeers()
+		redactPeers(&peers, config.RPCRedaction{PeerAddresses: true, SeedList: true}, seeds)
+		require.Equal(t, result.Peers{{}}, peers.Unconnected)
+		require.Equal(t, result.Peers{{UserAgent: "/peer/", LastKnownHeight: 5}}, peers.Connected)
+		require.Equal(t, result.Peers{{}}, peers.Bad)
+	})
+}
+
+func TestRPCSchemaErrors(t *testing.T) {
+	_, _, httpSrv := initClearServerWithInMemoryChain(t)
+
+	testCases := []struct {
+		method string
+		params string
+		err    string
+	}{
+		{"getstorage", `["` + testContractHashLE + `"]`, "missing key parameter (#1), expected getstorage(contract: any, key: base64 bytes)"},
+		{"sendrawtransaction", `["!"]`, "invalid tx parameter (#0)"},
+		{"submitblock", `[]`, "missing block parameter (#0)"},
+		{"getblock", `[1, []]`, "invalid verbose parameter (#1)"},
+		{"findstorage", `["` + testContractHashLE + `", "QQ==", "first"]`, "invalid start parameter (#2)"},
+		{"findstates", `["` + block20StateRootLE + `", "0xabcdef"]`, "invalid contract parameter (#1)"},
+		{"findstates", `["` + block20StateRootLE + `", "` + testContractHashLE + `", "QQ==", null, "many"]`, "invalid count parameter (#4)"},
+		{"getapplicationlog", `["` + deploymentTxHash + `", null, -1]`, "invalid offset parameter (#2): negative value"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.method, func(t *testing.T) {
+			body := doRPCCallOverHTTP(fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "%s", "params": %s}`, tc.method, tc.params), httpSrv.URL, t)
+			checkErrGetResult(t, body, true, neorpc.InvalidParamsCode, tc.err)
+		})
+	}
+}
+
+func TestResultSigning(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+		c.ApplicationConfiguration.RPC.ResultSigning = config.RPCSigning{
+			Methods:      []string{"getblockcount", "getblockhash"},
+			UnlockWallet: config.Wallet{Path: notaryPath, Password: notaryPass},
+		}
+	})
+	require.NotNil(t, rpcSrv.resultSigner)
+
+	call := func(t *testing.T, req *neorpc.Request) neorpc.Response {
+		data, err := json.Marshal(req)
+		require.NoError(t, err)
+		body := doRPCCallOverHTTP(string(data), httpSrv.URL, t)
+		var resp neorpc.Response
+		require.NoError(t, json.Unmarshal(body, &resp))
+		require.Nil(t, resp.Error)
+		return resp
+	}
+	t.Run("signed", func(t *testing.T) {
+		req := &neorpc.Request{JSONRPC: neorpc.JSONRPCVersion, Method: "getblockcount", ID: 1}
+		resp := call(t, req)
+		require.NotNil(t, resp.Signature)
+		require.Equal(t, rpcSrv.resultSigner.key.PublicKey(), resp.Signature.PublicKey)
+		require.Equal(t, chain.BlockHeight(), resp.Signature.Height)
+		require.True(t, resp.Signature.Verify(req, resp.Result))
+		require.False(t, resp.Signature.Verify(req, json.RawMessage(`100500`)))
+
+		// Signature is bound to the request and height.
+		require.False(t, resp.Signature.Verify(&neorpc.Request{Method: "getblockcount", ID: 2}, resp.Result))
+		require.False(t, resp.Signature.Verify(&neorpc.Request{Method: "getconnectioncount", ID: 1}, resp.Result))
+		require.False(t, resp.Signature.Verify(&neorpc.Request{Method: "getblockcount", ID: 1, Params: []any{1}}, resp.Result))
+		sig := *resp.Signature
+		sig.Height++
+		require.False(t, sig.Verify(req, resp.Result))
+	})
+	t.Run("signed with params", func(t *testing.T) {
+		req := &neorpc.Request{JSONRPC: neorpc.JSONRPCVersion, Method: "getblockhash", Params: []any{0}, ID: 3}
+		resp := call(t, req)
+		require.NotNil(t, resp.Signature)
+		require.True(t, resp.Signature.Verify(req, resp.Result))
+		require.False(t, resp.Signature.Verify(&neorpc.Request{Method: "getblockhash", Params: []any{1}, ID: 3}, resp.Result))
+	})
+	t.Run("not signed", func(t *testing.T) {
+		resp := call(t, &neorpc.Request{JSONRPC: neorpc.JSONRPCVersion, Method: "getversion", ID: 1})
+		require.Nil(t, resp.Signature)
+	})
+	t.Run("unknown method", func(t *testing.T) {
+		_, err := newResultSigner(config.RPCSigning{Methods: []string{"getsomething"}})
+		require.ErrorContains(t, err, "unknown method")
+	})
+	t.Run("bad password", func(t *testing.T) {
+		_, err := newResultSigner(config.RPCSigning{
+			Methods:      []string{"getblockcount"},
+			UnlockWallet: config.Wallet{Path: notaryPath, Password: "wrong"},
+		})
+		require.Error(t, err)
+	})
+	t.Run("start failure", func(t *testing.T) {
+		cfg := rpcSrv.config
+		cfg.ResultSigning.UnlockWallet.Password = "wrong"
+		errCh := make(chan error, 1)
+		srv := New(chain, cfg, nil, nil, zap.NewNop(), errCh)
+		srv.Start()
+		require.Error(t, <-errCh)
+		require.False(t, srv.started.Load())
+		require.Nil(t, srv.resultSigner)
+	})
+}
+
+func TestMaxOpcodesInvoke(t *testing.T) {
+	_, _, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+		c.ApplicationConfiguration.RPC.MaxOpcodesInvoke = 100
+	})
+	script := base64.StdEncoding.EncodeToString([]byte{byte(opcode.JMP), 0}) // Infinite loop.
+	body := doRPCCallOverHTTP(fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "invokescript", "params": ["%s"]}`, script), httpSrv.URL, t)
+	res := checkErrGetResult(t, body, false, 0)
+	var inv result.Invoke
+	require.NoError(t, json.Unmarshal(res, &inv))
+	require.Equal(t, vmstate.Fault.String(), inv.State)
+	require.Contains(t, inv.FaultException, vm.ErrOpcodeLimitExceeded.Error())
+}
+
+func TestInvokeTiers(t *testing.T) {
+	_, _, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+		c.ApplicationConfiguration.RPC.MaxOpcodesInvoke = 100
+		c.ApplicationConfiguration.RPC.MaxGasInvoke = fixedn.Fixed8FromInt64(1_000_000_000)
+		c.ApplicationConfiguration.RPC.InvokeTiers = []config.RPCInvokeTier{{
+			Name:             "timed",
+			APIKeys:          []string{"timed-key"},
+			MaxInvokeTime:    50 * time.Millisecond,
+			MaxOpcodesInvoke: math.MaxInt64,
+		}}
+	})
+	script := base64.StdEncoding.EncodeToString([]byte{byte(opcode.JMP), 0}) // Infinite loop.
+	rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "invokescript", "params": ["%s"]}`, script)
+	doCall := func(t *testing.T, auth string) []byte {
+		req, err := http.NewRequest(http.MethodPost, httpSrv.URL, strings.NewReader(rpc))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := gio.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return bytes.TrimSpace(body)
+	}
+	checkFault := func(t *testing.T, body []byte, exception string) {
+		var inv result.Invoke
+		require.NoError(t, json.Unmarshal(checkErrGetResult(t, body, false, 0), &inv))
+		require.Equal(t, vmstate.Fault.String(), inv.State)
+		require.Contains(t, inv.FaultException, exception)
+	}
+	t.Run("default", func(t *testing.T) {
+		checkFault(t, doCall(t, ""), vm.ErrOpcodeLimitExceeded.Error())
+	})
+	t.Run("tier", func(t *testing.T) {
+		checkFault(t, doCall(t, "Bearer timed-key"), errInvokeTimeLimitExceeded.Error())
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		checkErrGetResult(t, doCall(t, "Bearer unknown"), true, neorpc.InvalidRequestCode, errUnknownAPIKey.Error())
+		checkErrGetResult(t, doCall(t, "timed-key"), true, neorpc.InvalidRequestCode, errUnknownAPIKey.Error())
+	})
+}
+
+func TestReconfigure(t *testing.T) {
+	_, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+		c.ApplicationConfiguration.RPC.MaxOpcodesInvoke = 100
+	})
+	script := base64.StdEncoding.EncodeToString([]byte{byte(opcode.PUSH1), byte(opcode.DROP), byte(opcode.JMP), 0xfe}) // Infinite loop.
+	rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "invokescript", "params": ["%s"]}`, script)
+	invoke := func(t *testing.T) *result.Invoke {
+		var inv result.Invoke
+		require.NoError(t, json.Unmarshal(checkErrGetResult(t, doRPCCallOverHTTP(rpc, httpSrv.URL, t), false, 0), &inv))
+		require.Equal(t, vmstate.Fault.String(), inv.State)
+		return &inv
+	}
+	require.Contains(t, invoke(t).FaultException, vm.ErrOpcodeLimitExceeded.Error())
+
+	conf := rpcSrv.config
+	conf.MaxOpcodesInvoke = 0
+	conf.MaxGasInvoke = fixedn.Fixed8FromInt64(1)
+	conf.Redaction.SeedList = true
+	require.True(t, rpcSrv.Reconfigure(conf))
+	require.Contains(t, invoke(t).FaultException, "gas limit is exceeded")
+
+	var ver result.Version
+	require.NoError(t, json.Unmarshal(checkErrGetResult(t, doRPCCallOverHTTP(`{"jsonrpc": "2.0", "id": 1, "method": "getversion", "params": []}`, httpSrv.URL, t), false, 0), &ver))
+	require.Empty(t, ver.Protocol.SeedList)
+
+	conf.SessionEnabled = !conf.SessionEnabled
+	require.False(t, rpcSrv.Reconfigure(conf))
+	conf.SessionEnabled = !conf.SessionEnabled
+	conf.Addresses = append(conf.Addresses, "localhost:0")
+	require.False(t, rpcSrv.Reconfigure(conf))
+}
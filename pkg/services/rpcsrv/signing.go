@@ -0,0 +1,85 @@
+package rpcsrv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv/params"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// resultSigner signs results of the configured set of methods.
+type resultSigner struct {
+	wallet  *wallet.Wallet
+	key     *keys.PrivateKey
+	methods map[string]struct{}
+}
+
+// newResultSigner creates a resultSigner from the given configuration, it
+// returns nil if no methods are to be signed.
+func newResultSigner(cfg config.RPCSigning) (*resultSigner, error) {
+	if len(cfg.Methods) == 0 {
+		return nil, nil
+	}
+	methods := make(map[string]struct{}, len(cfg.Methods))
+	for _, m := range cfg.Methods {
+		if rpcHandlers[m] == nil && rpcCtxHandlers[m] == nil && rpcWsHandlers[m] == nil {
+			return nil, fmt.Errorf("unknown method %q", m)
+		}
+		methods[m] = struct{}{}
+	}
+	w, err := wallet.NewWalletFromFile(cfg.UnlockWallet.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open wallet: %w", err)
+	}
+	for _, acc := range w.Accounts {
+		if acc.Decrypt(cfg.UnlockWallet.Password, w.Scrypt) == nil {
+			return &resultSigner{wallet: w, key: acc.PrivateKey(), methods: methods}, nil
+		}
+	}
+	w.Close()
+	return nil, errors.New("no wallet account could be unlocked")
+}
+
+// sign adds the signature to the response if its method is to be signed. The
+// result is replaced with its JSON representation, so that exactly the signed
+// data is sent to the client. The signature covers the request (method,
+// parameters and ID), the given chain height and the result.
+func (r *resultSigner) sign(req *params.In, height uint32, resp *abstract) *neorpc.Error {
+	if r == nil || resp.Error != nil {
+		return nil
+	}
+	if _, ok := r.methods[req.Method]; !ok {
+		return nil
+	}
+	res, err := json.Marshal(resp.Result)
+	if err != nil {
+		return neorpc.NewInternalServerError(fmt.Sprintf("failed to marshal result: %s", err))
+	}
+	ps, err := json.Marshal(req.RawParams)
+	if err != nil {
+		return neorpc.NewInternalServerError(fmt.Sprintf("failed to marshal params: %s", err))
+	}
+	digest, err := neorpc.ResultSignatureHash(req.Method, ps, req.RawID, height, res)
+	if err != nil {
+		return neorpc.NewInternalServerError(fmt.Sprintf("failed to hash response: %s", err))
+	}
+	resp.Result = json.RawMessage(res)
+	resp.Signature = &neorpc.ResultSignature{
+		PublicKey: r.key.PublicKey(),
+		Height:    height,
+		Signature: r.key.SignHash(digest),
+	}
+	return nil
+}
+
+// close releases the signing key.
+func (r *resultSigner) close() {
+	if r != nil {
+		r.wallet.Close()
+	}
+}
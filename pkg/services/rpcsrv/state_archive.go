@@ -0,0 +1,223 @@
+package rpcsrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	gio "github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/services/helpers/neofs"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neofs-sdk-go/client"
+	cid "github.com/nspcc-dev/neofs-sdk-go/container/id"
+	"github.com/nspcc-dev/neofs-sdk-go/object"
+	oid "github.com/nspcc-dev/neofs-sdk-go/object/id"
+	"github.com/nspcc-dev/neofs-sdk-go/pool"
+	"github.com/nspcc-dev/neofs-sdk-go/user"
+)
+
+// defaultStateArchiveCacheSize is the default number of reconstructed states
+// kept in memory.
+const defaultStateArchiveCacheSize = 2
+
+// dumpSource provides state dumps made by NeoFS BlockUploader service.
+type dumpSource interface {
+	// getDump returns the payload of the state dump for the given height.
+	getDump(ctx context.Context, height uint32) (io.ReadCloser, error)
+	close()
+}
+
+// stateArchive reconstructs historic states removed from the node DB using
+// state dumps, each dump contains all contract storage items for some height.
+// Reconstructed states are cached, reconstruction itself is memory-heavy, so
+// only one state is reconstructed at a time.
+type stateArchive struct {
+	timeout time.Duration
+	source  dumpSource
+
+	lock  sync.Mutex
+	cache *lru.Cache[util.Uint256, storage.Store]
+}
+
+// neofsDumpSource fetches state dumps from NeoFS container.
+type neofsDumpSource struct {
+	pool        *pool.Pool
+	signer      user.Signer
+	containerID cid.ID
+	attribute   string
+	dialed      bool
+}
+
+// newStateArchive creates a stateArchive from the given configuration, it
+// returns nil if archive is disabled.
+func newStateArchive(cfg config.RPCStateArchive) (*stateArchive, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if cfg.StateAttribute == "" {
+		cfg.StateAttribute = neofs.DefaultStateAttribute
+	}
+	var containerID cid.ID
+	if err := containerID.DecodeString(cfg.ContainerID); err != nil {
+		return nil, fmt.Errorf("%w: %w", neofs.ErrInvalidContainer, err)
+	}
+	acc, err := wallet.NewAccount()
+	if err != nil {
+		return nil, err
+	}
+	signer := user.NewAutoIDSignerRFC6979(acc.PrivateKey().PrivateKey)
+	params := pool.DefaultOptions()
+	params.SetHealthcheckTimeout(neofs.DefaultHealthcheckTimeout)
+	params.SetNodeDialTimeout(neofs.DefaultDialTimeout)
+	params.SetNodeStreamTimeout(neofs.DefaultStreamTimeout)
+	p, err := pool.New(pool.NewFlatNodeParams(cfg.Addresses), signer, params)
+	if err != nil {
+		return nil, err
+	}
+	return newStateArchiveWithSource(cfg, &neofsDumpSource{
+		pool:        p,
+		signer:      signer,
+		containerID: containerID,
+		attribute:   cfg.StateAttribute,
+	}), nil
+}
+
+func newStateArchiveWithSource(cfg config.RPCStateArchive, src dumpSource) *stateArchive {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = neofs.DefaultTimeout
+	}
+	if cfg.CacheSize <= 0 {
+		cfg.CacheSize = defaultStateArchiveCacheSize
+	}
+	cache, _ := lru.New[util.Uint256, storage.Store](cfg.CacheSize) // Never errors for positive size.
+	return &stateArchive{
+		timeout: cfg.Timeout,
+		source:  src,
+		cache:   cache,
+	}
+}
+
+// getStore returns a store with all MPT nodes of the state with the given root
+// for the given height.
+func (a *stateArchive) getStore(height uint32, root util.Uint256) (storage.Store, error) {
+	if st, ok := a.cache.Get(root); ok {
+		return st, nil
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	// It could've been reconstructed while we were waiting.
+	if st, ok := a.cache.Get(root); ok {
+		return st, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), a.timeout)
+	defer cancel()
+	r, err := a.source.getDump(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state dump for height %d: %w", height, err)
+	}
+	defer r.Close()
+	st, err := restoreState(r, root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore state for height %d: %w", height, err)
+	}
+	a.cache.Add(root, st)
+	return st, nil
+}
+
+// close releases resources used by the archive.
+func (a *stateArchive) close() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.source.close()
+	a.cache.Purge()
+}
+
+// restoreState builds MPT from the state dump and checks that its root matches
+// the expected one.
+func restoreState(r io.Reader, root util.Uint256) (storage.Store, error) {
+	var (
+		store = storage.NewMemoryStore()
+		cache = storage.NewMemCachedStore(store)
+		tr    = mpt.NewTrie(nil, mpt.ModeAll, cache)
+		br    = gio.NewBinReaderFromIO(r)
+	)
+	for {
+		k := br.ReadVarBytes(mpt.MaxKeyLength)
+		if br.Err != nil {
+			if errors.Is(br.Err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("invalid dump: %w", br.Err)
+		}
+		v := br.ReadVarBytes(mpt.MaxValueLength)
+		if br.Err != nil {
+			return nil, fmt.Errorf("invalid dump: %w", br.Err)
+		}
+		if err := tr.Put(k, v); err != nil {
+			return nil, fmt.Errorf("failed to put item: %w", err)
+		}
+	}
+	tr.Flush(0)
+	if actual := tr.StateRoot(); !actual.Equals(root) {
+		return nil, fmt.Errorf("state root mismatch: expected %s, got %s", root.StringLE(), actual.StringLE())
+	}
+	_, err := cache.Persist()
+	if err != nil {
+		return nil, fmt.Errorf("failed to persist state: %w", err)
+	}
+	return store, nil
+}
+
+// getDump implements the dumpSource interface.
+func (s *neofsDumpSource) getDump(ctx context.Context, height uint32) (io.ReadCloser, error) {
+	if !s.dialed {
+		if err := s.pool.Dial(ctx); err != nil {
+			return nil, fmt.Errorf("failed to dial NeoFS pool: %w", err)
+		}
+		s.dialed = true
+	}
+	prm := client.PrmObjectSearch{}
+	filters := object.NewSearchFilters()
+	filters.AddFilter(s.attribute, strconv.FormatUint(uint64(height), 10), object.MatchStringEqual)
+	prm.SetFilters(filters)
+	reader, err := s.pool.ObjectSearchInit(ctx, s.containerID, s.signer, prm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate object search: %w", err)
+	}
+	var (
+		found bool
+		id    oid.ID
+	)
+	err = reader.Iterate(func(o oid.ID) bool {
+		id, found = o, true
+		return true
+	})
+	reader.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error during object IDs iteration: %w", err)
+	}
+	if !found {
+		return nil, errors.New("no state dump found")
+	}
+	_, rc, err := s.pool.ObjectGetInit(ctx, s.containerID, id, s.signer, client.PrmObjectGet{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object %s: %w", id, err)
+	}
+	return rc, nil
+}
+
+// close implements the dumpSource interface.
+func (s *neofsDumpSource) close() {
+	if s.dialed {
+		s.pool.Close()
+	}
+}
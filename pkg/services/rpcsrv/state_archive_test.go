@@ -0,0 +1,87 @@
+package rpcsrv
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	gio "github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+type testDumpSource struct {
+	dumps map[uint32][]byte
+	calls int
+}
+
+func (s *testDumpSource) getDump(_ context.Context, height uint32) (io.ReadCloser, error) {
+	s.calls++
+	d, ok := s.dumps[height]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return io.NopCloser(bytes.NewReader(d)), nil
+}
+
+func (s *testDumpSource) close() {}
+
+func TestStateArchive(t *testing.T) {
+	bc, _ := chain.NewSingle(t)
+	sm := bc.GetStateModule()
+	h := bc.BlockHeight()
+	sr, err := sm.GetStateRoot(h)
+	require.NoError(t, err)
+
+	var (
+		buf   = gio.NewBufBinWriter()
+		items = make(map[string][]byte)
+	)
+	sm.SeekStates(sr.Root, nil, func(k, v []byte) bool {
+		buf.WriteVarBytes(k)
+		buf.WriteVarBytes(v)
+		items[string(k)] = bytes.Clone(v)
+		return true
+	})
+	require.NoError(t, buf.Err)
+	require.NotEmpty(t, items)
+
+	src := &testDumpSource{dumps: map[uint32][]byte{h: buf.Bytes()}}
+	a := newStateArchiveWithSource(config.RPCStateArchive{}, src)
+
+	t.Run("missing dump", func(t *testing.T) {
+		_, err := a.getStore(h+1, sr.Root)
+		require.Error(t, err)
+	})
+	t.Run("root mismatch", func(t *testing.T) {
+		_, err := a.getStore(h, util.Uint256{1, 2, 3})
+		require.ErrorContains(t, err, "state root mismatch")
+	})
+
+	src.calls = 0
+	st, err := a.getStore(h, sr.Root)
+	require.NoError(t, err)
+	ts := mpt.NewTrieStore(sr.Root, mpt.ModeAll, storage.NewMemCachedStore(st))
+	for k, v := range items {
+		actual, err := ts.Get(append([]byte{byte(storage.STStorage)}, k...))
+		require.NoError(t, err)
+		require.Equal(t, v, actual)
+	}
+
+	// Cached.
+	st2, err := a.getStore(h, sr.Root)
+	require.NoError(t, err)
+	require.Equal(t, st, st2)
+	require.Equal(t, 1, src.calls)
+
+	t.Run("invalid dump", func(t *testing.T) {
+		_, err := restoreState(bytes.NewReader([]byte{1, 2}), sr.Root)
+		require.ErrorContains(t, err, "invalid dump")
+	})
+}
@@ -0,0 +1,48 @@
+package rpcsrv
+
+import (
+	"encoding/base64"
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// findStatesCursor is a position in the historical contract storage: the
+// state root and contract the request is made for and the key of the last
+// returned item (without contract ID, but with prefix).
+type findStatesCursor struct {
+	Root     util.Uint256
+	Contract util.Uint160
+	Key      []byte
+}
+
+// String returns an opaque string representation of the cursor.
+func (c findStatesCursor) String() string {
+	b := make([]byte, 0, util.Uint256Size+util.Uint160Size+len(c.Key))
+	b = append(b, c.Root.BytesBE()...)
+	b = append(b, c.Contract.BytesBE()...)
+	b = append(b, c.Key...)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// parseFindStatesCursor decodes cursor from its string representation.
+func parseFindStatesCursor(s string) (findStatesCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return findStatesCursor{}, err
+	}
+	if len(b) < util.Uint256Size+util.Uint160Size {
+		return findStatesCursor{}, errors.New("invalid cursor length")
+	}
+	var c findStatesCursor
+	c.Root, err = util.Uint256DecodeBytesBE(b[:util.Uint256Size])
+	if err != nil {
+		return findStatesCursor{}, err
+	}
+	c.Contract, err = util.Uint160DecodeBytesBE(b[util.Uint256Size : util.Uint256Size+util.Uint160Size])
+	if err != nil {
+		return findStatesCursor{}, err
+	}
+	c.Key = b[util.Uint256Size+util.Uint160Size:]
+	return c, nil
+}
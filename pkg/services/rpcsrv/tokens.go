@@ -1,6 +1,10 @@
 package rpcsrv
 
 import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 )
 
@@ -9,6 +13,39 @@ type tokenTransfers struct {
 	Sent     []any  `json:"sent"`
 	Received []any  `json:"received"`
 	Address  string `json:"address"`
+	Next     string `json:"next,omitempty"`
+}
+
+// transfersCursor is a position in the transfer log: the timestamp of the
+// last returned transfer and the number of transfers with this timestamp
+// that were already processed (transfer log is iterated from the newest
+// transfer to the oldest one).
+type transfersCursor struct {
+	Timestamp uint64
+	Skip      uint32
+}
+
+// String returns an opaque string representation of the cursor.
+func (c transfersCursor) String() string {
+	var b [12]byte
+	binary.BigEndian.PutUint64(b[:], c.Timestamp)
+	binary.BigEndian.PutUint32(b[8:], c.Skip)
+	return base64.RawURLEncoding.EncodeToString(b[:])
+}
+
+// parseTransfersCursor decodes cursor from its string representation.
+func parseTransfersCursor(s string) (transfersCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return transfersCursor{}, err
+	}
+	if len(b) != 12 {
+		return transfersCursor{}, errors.New("invalid cursor length")
+	}
+	return transfersCursor{
+		Timestamp: binary.BigEndian.Uint64(b),
+		Skip:      binary.BigEndian.Uint32(b[8:]),
+	}, nil
 }
 
 // nep17TransferToNEP11 adds an ID to the provided NEP-17 transfer and returns a new
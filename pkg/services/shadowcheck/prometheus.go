@@ -0,0 +1,37 @@
+package shadowcheck
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	// checkedHeight prometheus metric.
+	checkedHeight = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Latest height state is known to match the reference node state at",
+			Name:      "shadow_validation_checked_height",
+			Namespace: "neogo",
+		},
+	)
+	// divergedHeight prometheus metric.
+	divergedHeight = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Height state diverged from the reference node state at (0 if no divergence is detected)",
+			Name:      "shadow_validation_diverged_height",
+			Namespace: "neogo",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		checkedHeight,
+		divergedHeight,
+	)
+}
+
+func updateCheckedHeightMetric(h uint32) {
+	checkedHeight.Set(float64(h))
+}
+
+func updateDivergedHeightMetric(h uint32) {
+	divergedHeight.Set(float64(h))
+}
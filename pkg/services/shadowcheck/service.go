@@ -0,0 +1,399 @@
+/*
+Package shadowcheck implements shadow validation service that compares local
+state with the state of a trusted reference node to detect state divergence
+as early as possible.
+*/
+package shadowcheck
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"go.uber.org/zap"
+)
+
+// Default configuration values.
+const (
+	DefaultInterval = 1000
+	DefaultTimeout  = 10 * time.Second
+	DefaultSamples  = 100
+)
+
+// retryInterval is the delay before the next check if the reference node
+// can't provide the required data.
+const retryInterval = 5 * time.Second
+
+type (
+	// Ledger is an interface to Blockchain sufficient for Service.
+	Ledger interface {
+		BlockHeight() uint32
+		GetAppExecResults(util.Uint256, trigger.Type) ([]state.AppExecResult, error)
+		GetBlock(hash util.Uint256) (*block.Block, error)
+		GetContractState(hash util.Uint160) *state.Contract
+		GetHeaderHash(uint32) util.Uint256
+		GetStateModule() core.StateRoot
+		SubscribeForBlocks(ch chan *block.Block)
+		UnsubscribeFromBlocks(ch chan *block.Block)
+	}
+
+	// Reference is an interface to the reference node RPC sufficient for
+	// Service, it's implemented by rpcclient.Client.
+	Reference interface {
+		GetStateRootByHeight(height uint32) (*state.MPTRoot, error)
+		FindStates(stateroot util.Uint256, historicalContractHash util.Uint160, historicalPrefix []byte,
+			start []byte, maxCount *int) (result.FindStates, error)
+	}
+)
+
+// Service periodically compares local state roots with the ones of the
+// reference node. When they don't match, it finds the first diverging block
+// and compares storage items of contracts involved in this block to find the
+// difference. Divergence is reported via log and metrics, no checks are made
+// after it's detected.
+type Service struct {
+	started atomic.Bool
+	log     *zap.Logger
+	cfg     config.ShadowValidation
+
+	chain Ledger
+	ref   Reference
+
+	blockCh chan *block.Block
+	wakeCh  chan struct{}
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+	wg        sync.WaitGroup
+
+	// checked is the latest height state roots are known to match at.
+	checked uint32
+	// next is the next height to check.
+	next uint32
+}
+
+// New creates a new shadow validation Service with the reference node RPC
+// client.
+func New(chain Ledger, cfg config.ShadowValidation, logger *zap.Logger) (*Service, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	c, err := rpcclient.New(context.Background(), cfg.ReferenceRPC, rpcclient.Options{RequestTimeout: cfg.Timeout})
+	if err != nil {
+		return nil, fmt.Errorf("can't create reference RPC client: %w", err)
+	}
+	return newService(chain, cfg, logger, c), nil
+}
+
+// newService creates a Service using the given Reference and fills defaults
+// for unset configuration values.
+func newService(chain Ledger, cfg config.ShadowValidation, logger *zap.Logger, ref Reference) *Service {
+	if cfg.Interval == 0 {
+		cfg.Interval = DefaultInterval
+	}
+	if cfg.Samples == 0 {
+		cfg.Samples = DefaultSamples
+	}
+	return &Service{
+		log:     logger,
+		cfg:     cfg,
+		chain:   chain,
+		ref:     ref,
+		blockCh: make(chan *block.Block),
+		wakeCh:  make(chan struct{}, 1),
+	}
+}
+
+// Name returns service name.
+func (s *Service) Name() string {
+	return "ShadowValidation"
+}
+
+// Start runs the shadow validation service. It returns immediately, all
+// checks are performed in a separate routine.
+func (s *Service) Start() {
+	if !s.started.CompareAndSwap(false, true) {
+		return
+	}
+	s.log.Info("starting shadow validation service", zap.String("reference", s.cfg.ReferenceRPC))
+	h := s.chain.BlockHeight()
+	s.next = max(s.cfg.Interval, h-h%s.cfg.Interval)
+	s.ctx, s.ctxCancel = context.WithCancel(context.Background())
+	s.chain.SubscribeForBlocks(s.blockCh)
+	s.wg.Add(2)
+	go s.notifier()
+	go s.run()
+}
+
+// Shutdown stops the shadow validation service and waits until the service
+// routines finish their work.
+func (s *Service) Shutdown() {
+	if !s.started.CompareAndSwap(true, false) {
+		return
+	}
+	s.log.Info("shutting down shadow validation service")
+	s.ctxCancel()
+	s.chain.UnsubscribeFromBlocks(s.blockCh)
+	s.wg.Wait()
+	_ = s.log.Sync()
+}
+
+// notifier turns block events into non-blocking wake-ups of the service
+// routine, so that the chain is never blocked by checks.
+func (s *Service) notifier() {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-s.ctx.Done():
+			// Drain the channel until it's unsubscribed.
+			for {
+				select {
+				case <-s.blockCh:
+				default:
+					return
+				}
+			}
+		case b := <-s.blockCh:
+			if b.Index%s.cfg.Interval != 0 {
+				continue
+			}
+			select {
+			case s.wakeCh <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// run is the main service routine.
+func (s *Service) run() {
+	defer s.wg.Done()
+	for {
+		var retry <-chan time.Time
+		diverged, err := s.checkUpTo(s.chain.BlockHeight())
+		if diverged {
+			return
+		}
+		if err != nil {
+			s.log.Warn("shadow validation check failed, will retry",
+				zap.Uint32("height", s.next), zap.Error(err))
+			retry = time.After(retryInterval)
+		}
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-s.wakeCh:
+		case <-retry:
+		}
+	}
+}
+
+// checkUpTo checks all pending heights not exceeding the given one, it
+// returns true if divergence is detected.
+func (s *Service) checkUpTo(height uint32) (bool, error) {
+	for s.next <= height {
+		if s.ctx.Err() != nil {
+			return false, nil
+		}
+		ok, local, ref, err := s.compareRoots(s.next)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			s.report(local, ref)
+			return true, nil
+		}
+		s.log.Debug("state roots match", zap.Uint32("height", s.next), zap.Stringer("root", local.Root))
+		s.checked = s.next
+		updateCheckedHeightMetric(s.checked)
+		s.next += s.cfg.Interval
+	}
+	return false, nil
+}
+
+// compareRoots returns local and reference state roots for the given height
+// and whether they match.
+func (s *Service) compareRoots(height uint32) (bool, *state.MPTRoot, *state.MPTRoot, error) {
+	local, err := s.chain.GetStateModule().GetStateRoot(height)
+	if err != nil {
+		return false, nil, nil, fmt.Errorf("failed to get local state root: %w", err)
+	}
+	ref, err := s.ref.GetStateRootByHeight(height)
+	if err != nil {
+		return false, nil, nil, fmt.Errorf("failed to get reference state root: %w", err)
+	}
+	return local.Root.Equals(ref.Root), local, ref, nil
+}
+
+// report finds the first diverging block (starting from the given mismatched
+// state roots) and logs the results of its storage comparison.
+func (s *Service) report(local, ref *state.MPTRoot) {
+	lo, hi := s.checked, local.Index
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		ok, l, r, err := s.compareRoots(mid)
+		if err != nil {
+			s.log.Warn("failed to find the first diverging block", zap.Error(err))
+			break
+		}
+		if ok {
+			lo = mid
+		} else {
+			hi, local, ref = mid, l, r
+		}
+	}
+	defer updateDivergedHeightMetric(local.Index)
+	s.log.Error("state divergence detected",
+		zap.Uint32("height", local.Index),
+		zap.Uint32("last matching height", lo),
+		zap.Stringer("local root", local.Root),
+		zap.Stringer("reference root", ref.Root))
+	if hi-lo > 1 {
+		return // Exact block is unknown.
+	}
+	diffs, err := s.compareStorage(local, ref)
+	if err != nil {
+		s.log.Warn("failed to compare storage", zap.Uint32("height", local.Index), zap.Error(err))
+	}
+	for _, d := range diffs {
+		s.log.Error("storage item mismatch",
+			zap.Uint32("height", local.Index),
+			zap.String("contract", d.contract.StringLE()),
+			zap.String("key", base64.StdEncoding.EncodeToString(d.key)),
+			zap.String("local", formatValue(d.local)),
+			zap.String("reference", formatValue(d.ref)))
+	}
+	if len(diffs) == 0 && err == nil {
+		s.log.Warn("no difference found in sampled storage items", zap.Uint32("height", local.Index))
+	}
+}
+
+// storageDiff is a storage item with different local and reference values
+// (nil if the item is missing).
+type storageDiff struct {
+	contract util.Uint160
+	key      []byte
+	local    []byte
+	ref      []byte
+}
+
+func formatValue(v []byte) string {
+	if v == nil {
+		return "<missing>"
+	}
+	return base64.StdEncoding.EncodeToString(v)
+}
+
+// compareStorage compares storage items of contracts involved into the block
+// with the given state roots and returns the first difference for every
+// contract.
+func (s *Service) compareStorage(local, ref *state.MPTRoot) ([]storageDiff, error) {
+	contracts, err := s.blockContracts(local.Index)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		res     []storageDiff
+		samples = s.cfg.Samples
+	)
+	for _, h := range contracts {
+		cs := s.chain.GetContractState(h)
+		if cs == nil {
+			continue
+		}
+		prefix := make([]byte, 4)
+		binary.LittleEndian.PutUint32(prefix, uint32(cs.ID))
+		lkv, err := s.chain.GetStateModule().FindStates(local.Root, prefix, nil, samples)
+		if err != nil && !errors.Is(err, mpt.ErrNotFound) {
+			return res, fmt.Errorf("failed to find local states of %s: %w", h.StringLE(), err)
+		}
+		rkv, err := s.ref.FindStates(ref.Root, h, nil, nil, &samples)
+		if err != nil {
+			return res, fmt.Errorf("failed to find reference states of %s: %w", h.StringLE(), err)
+		}
+		var (
+			n        = min(len(lkv), len(rkv.Results))
+			diff     *storageDiff
+			localAll = len(lkv) < samples
+		)
+		for i := range n {
+			lk, rk := lkv[i].Key[4:], rkv.Results[i].Key
+			switch c := bytes.Compare(lk, rk); {
+			case c < 0:
+				diff = &storageDiff{contract: h, key: lk, local: lkv[i].Value}
+			case c > 0:
+				diff = &storageDiff{contract: h, key: rk, ref: rkv.Results[i].Value}
+			case !bytes.Equal(lkv[i].Value, rkv.Results[i].Value):
+				diff = &storageDiff{contract: h, key: lk, local: lkv[i].Value, ref: rkv.Results[i].Value}
+			}
+			if diff != nil {
+				break
+			}
+		}
+		if diff == nil && len(lkv) > n && !rkv.Truncated {
+			diff = &storageDiff{contract: h, key: lkv[n].Key[4:], local: lkv[n].Value}
+		}
+		if diff == nil && len(rkv.Results) > n && localAll {
+			diff = &storageDiff{contract: h, key: rkv.Results[n].Key, ref: rkv.Results[n].Value}
+		}
+		if diff != nil {
+			res = append(res, *diff)
+		}
+	}
+	return res, nil
+}
+
+// blockContracts returns NEO, GAS and contracts emitting notifications in the
+// block with the given index.
+func (s *Service) blockContracts(index uint32) ([]util.Uint160, error) {
+	b, err := s.chain.GetBlock(s.chain.GetHeaderHash(index))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block: %w", err)
+	}
+	var (
+		seen = map[util.Uint160]struct{}{nativehashes.NeoToken: {}, nativehashes.GasToken: {}}
+		res  = []util.Uint160{nativehashes.NeoToken, nativehashes.GasToken}
+	)
+	add := func(aers []state.AppExecResult) {
+		for _, aer := range aers {
+			for _, ntf := range aer.Events {
+				if _, ok := seen[ntf.ScriptHash]; !ok {
+					seen[ntf.ScriptHash] = struct{}{}
+					res = append(res, ntf.ScriptHash)
+				}
+			}
+		}
+	}
+	aers, err := s.chain.GetAppExecResults(b.Hash(), trigger.All)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block executions: %w", err)
+	}
+	add(aers)
+	for _, tx := range b.Transactions {
+		aers, err = s.chain.GetAppExecResults(tx.Hash(), trigger.Application)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction execution: %w", err)
+		}
+		add(aers)
+	}
+	return res, nil
+}
@@ -0,0 +1,129 @@
+package shadowcheck
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// fakeReference is a Reference backed by the local chain, it returns
+// modified state roots and GAS storage starting from divergeAt height (if
+// it's not zero).
+type fakeReference struct {
+	bc        *core.Blockchain
+	divergeAt uint32
+	// roots maps modified state roots to the real ones.
+	roots map[util.Uint256]util.Uint256
+}
+
+func (f *fakeReference) GetStateRootByHeight(height uint32) (*state.MPTRoot, error) {
+	if height > f.bc.BlockHeight() {
+		return nil, errors.New("unknown state root")
+	}
+	r, err := f.bc.GetStateModule().GetStateRoot(height)
+	if err != nil {
+		return nil, err
+	}
+	if f.divergeAt == 0 || height < f.divergeAt {
+		return r, nil
+	}
+	res := *r
+	res.Root[0] ^= 0xff
+	f.roots[res.Root] = r.Root
+	return &res, nil
+}
+
+func (f *fakeReference) FindStates(root util.Uint256, h util.Uint160, prefix []byte, start []byte, maxCount *int) (result.FindStates, error) {
+	var modified bool
+	if orig, ok := f.roots[root]; ok {
+		root, modified = orig, true
+	}
+	cs := f.bc.GetContractState(h)
+	if cs == nil {
+		return result.FindStates{}, errors.New("unknown contract")
+	}
+	pKey := append([]byte{byte(cs.ID), byte(cs.ID >> 8), byte(cs.ID >> 16), byte(cs.ID >> 24)}, prefix...)
+	kvs, err := f.bc.GetStateModule().FindStates(root, pKey, start, *maxCount)
+	if err != nil {
+		return result.FindStates{}, err
+	}
+	res := result.FindStates{Results: make([]result.KeyValue, len(kvs))}
+	for i, kv := range kvs {
+		res.Results[i] = result.KeyValue{Key: kv.Key[4:], Value: kv.Value}
+	}
+	if modified && h.Equals(nativehashes.GasToken) {
+		res.Results[0].Value = append([]byte{}, res.Results[0].Value...)
+		res.Results[0].Value[0] ^= 0xff
+	}
+	return res, nil
+}
+
+func newTestService(t *testing.T, divergeAt uint32, blocks int) (*Service, *neotest.Executor, *observer.ObservedLogs) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	gas := e.ValidatorInvoker(nativehashes.GasToken)
+	for range blocks {
+		gas.Invoke(t, true, "transfer", acc.ScriptHash(), util.Uint160{1}, 1, nil)
+	}
+
+	obsCore, logs := observer.New(zapcore.DebugLevel)
+	ref := &fakeReference{bc: bc, divergeAt: divergeAt, roots: make(map[util.Uint256]util.Uint256)}
+	s := newService(bc, config.ShadowValidation{
+		Enabled:      true,
+		ReferenceRPC: "http://localhost",
+		Interval:     4,
+	}, zap.New(obsCore), ref)
+	checkedHeight.Set(0)
+	divergedHeight.Set(0)
+	s.Start()
+	t.Cleanup(s.Shutdown)
+	return s, e, logs
+}
+
+func TestService(t *testing.T) {
+	_, e, logs := newTestService(t, 0, 0)
+	for range 9 {
+		e.AddNewBlock(t)
+	}
+	require.Eventually(t, func() bool { return testutil.ToFloat64(checkedHeight) == 8 }, 5*time.Second, 10*time.Millisecond)
+	require.Zero(t, testutil.ToFloat64(divergedHeight))
+	require.Zero(t, logs.FilterLevelExact(zapcore.ErrorLevel).Len())
+}
+
+func TestServiceDivergence(t *testing.T) {
+	_, _, logs := newTestService(t, 5, 10)
+	require.Eventually(t, func() bool { return testutil.ToFloat64(divergedHeight) == 5 }, 5*time.Second, 10*time.Millisecond)
+
+	entries := logs.FilterMessage("state divergence detected").All()
+	require.Len(t, entries, 1)
+	require.EqualValues(t, 5, entries[0].ContextMap()["height"])
+	require.EqualValues(t, 4, entries[0].ContextMap()["last matching height"])
+
+	entries = logs.FilterMessage("storage item mismatch").All()
+	require.Len(t, entries, 1)
+	require.Equal(t, nativehashes.GasToken.StringLE(), entries[0].ContextMap()["contract"])
+}
+
+func TestServiceRestart(t *testing.T) {
+	s, e, _ := newTestService(t, 0, 0)
+	s.Shutdown()
+	s.Start()
+	for range 4 {
+		e.AddNewBlock(t)
+	}
+	require.Eventually(t, func() bool { return testutil.ToFloat64(checkedHeight) == 4 }, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,140 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"go.uber.org/zap"
+)
+
+// HTTP headers set for every webhook request.
+const (
+	// EventHeader contains the event type.
+	EventHeader = "X-Neo-Event"
+	// SignatureHeader contains "sha256=" followed by hex-encoded
+	// HMAC-SHA256 of the request body, it's only set if the endpoint has a
+	// secret configured.
+	SignatureHeader = "X-Neo-Signature"
+)
+
+// delivery is a single event to be delivered.
+type delivery struct {
+	typ  string
+	body []byte
+}
+
+// sender delivers events to a single endpoint.
+type sender struct {
+	log    *zap.Logger
+	url    string
+	secret []byte
+	client http.Client
+
+	maxRetries    int
+	retryInterval time.Duration
+	queue         chan delivery
+}
+
+func newSender(e config.WebhookEndpoint, cfg config.Webhook, log *zap.Logger) *sender {
+	var secret []byte
+	if e.Secret != "" {
+		secret = []byte(e.Secret)
+	}
+	return &sender{
+		log:           log.With(zap.String("endpoint", e.URL)),
+		url:           e.URL,
+		secret:        secret,
+		client:        http.Client{Timeout: cfg.Timeout},
+		maxRetries:    cfg.MaxRetries,
+		retryInterval: cfg.RetryInterval,
+		queue:         make(chan delivery, cfg.QueueSize),
+	}
+}
+
+// enqueue adds the event to the delivery queue, it's dropped if the queue is
+// full.
+func (s *sender) enqueue(typ string, body []byte) {
+	select {
+	case s.queue <- delivery{typ: typ, body: body}:
+	default:
+		s.log.Warn("webhook queue is full, event dropped", zap.String("type", typ))
+	}
+}
+
+// run delivers queued events until the context is canceled.
+func (s *sender) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case d := <-s.queue:
+			s.deliver(ctx, d)
+		}
+	}
+}
+
+// deliver posts the event retrying failed requests with exponential backoff.
+func (s *sender) deliver(ctx context.Context, d delivery) {
+	var (
+		err     error
+		backoff = s.retryInterval
+	)
+	for attempt := 0; attempt <= s.maxRetries; attempt++ {
+		if attempt != 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+		err = s.post(ctx, d)
+		if err == nil {
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		s.log.Debug("webhook delivery failed", zap.String("type", d.typ), zap.Int("attempt", attempt), zap.Error(err))
+	}
+	s.log.Warn("webhook delivery failed, event dropped", zap.String("type", d.typ), zap.Error(err))
+}
+
+// post makes a single delivery attempt.
+func (s *sender) post(ctx context.Context, d delivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(d.body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, d.typ)
+	if s.secret != nil {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(s.secret, d.body))
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns hex-encoded HMAC-SHA256 of the body with the given secret, it
+// can be used by receivers to check SignatureHeader value.
+func Sign(secret []byte, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,368 @@
+/*
+Package webhook implements a service posting chain and memory pool events
+related to the configured accounts and contracts to HTTP endpoints.
+*/
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"go.uber.org/zap"
+)
+
+// Default configuration values.
+const (
+	DefaultMempoolPollInterval = time.Second
+	DefaultTimeout             = 5 * time.Second
+	DefaultMaxRetries          = 3
+	DefaultRetryInterval       = time.Second
+	DefaultQueueSize           = 1024
+)
+
+// Event types.
+const (
+	// MempoolEvent is sent for a watched transaction added to the memory
+	// pool.
+	MempoolEvent = "mempool"
+	// TransactionEvent is sent for a watched transaction included into a
+	// block.
+	TransactionEvent = "transaction"
+	// NotificationEvent is sent for a watched notification emitted during
+	// block processing.
+	NotificationEvent = "notification"
+)
+
+// Ledger is an interface to Blockchain sufficient for Service.
+type Ledger interface {
+	GetAppExecResults(util.Uint256, trigger.Type) ([]state.AppExecResult, error)
+	GetMemPool() *mempool.Pool
+	SubscribeForBlocks(ch chan *block.Block)
+	UnsubscribeFromBlocks(ch chan *block.Block)
+}
+
+// Payload is the JSON body of webhook request.
+type Payload struct {
+	Type string `json:"type"`
+	// Timestamp is the event creation time in milliseconds, it's covered by
+	// the signature and can be used to reject replayed requests.
+	Timestamp int64 `json:"timestamp"`
+	// BlockIndex and BlockHash are set for transaction and notification
+	// events.
+	BlockIndex *uint32       `json:"blockindex,omitempty"`
+	BlockHash  *util.Uint256 `json:"blockhash,omitempty"`
+	// Container is the hash of transaction or block the event belongs to.
+	Container    util.Uint256             `json:"container"`
+	VMState      string                   `json:"vmstate,omitempty"`
+	Transaction  *transaction.Transaction `json:"transaction,omitempty"`
+	Notification *state.NotificationEvent `json:"notification,omitempty"`
+}
+
+// Service watches blocks and memory pool for transactions and notifications
+// related to the configured accounts and contracts and posts them to all
+// configured endpoints.
+type Service struct {
+	started atomic.Bool
+	log     *zap.Logger
+	cfg     config.Webhook
+
+	chain     Ledger
+	accounts  map[util.Uint160]struct{}
+	contracts map[util.Uint160]struct{}
+	senders   []*sender
+
+	blockCh chan *block.Block
+	// seen is the set of memory pool transactions known after the last check.
+	seen map[util.Uint256]struct{}
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+// New creates a new webhook Service.
+func New(chain Ledger, cfg config.Webhook, logger *zap.Logger) (*Service, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.MempoolPollInterval == 0 {
+		cfg.MempoolPollInterval = DefaultMempoolPollInterval
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = DefaultMaxRetries
+	}
+	if cfg.RetryInterval == 0 {
+		cfg.RetryInterval = DefaultRetryInterval
+	}
+	if cfg.QueueSize == 0 {
+		cfg.QueueSize = DefaultQueueSize
+	}
+	s := &Service{
+		log:       logger,
+		cfg:       cfg,
+		chain:     chain,
+		accounts:  make(map[util.Uint160]struct{}, len(cfg.Addresses)),
+		contracts: make(map[util.Uint160]struct{}, len(cfg.Contracts)),
+		blockCh:   make(chan *block.Block),
+	}
+	for _, a := range cfg.Addresses {
+		u, err := address.StringToUint160(a)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q: %w", a, err)
+		}
+		s.accounts[u] = struct{}{}
+	}
+	for _, c := range cfg.Contracts {
+		u, err := util.Uint160DecodeStringLE(c)
+		if err != nil {
+			return nil, fmt.Errorf("invalid contract hash %q: %w", c, err)
+		}
+		s.contracts[u] = struct{}{}
+	}
+	for _, e := range cfg.Endpoints {
+		s.senders = append(s.senders, newSender(e, cfg, logger))
+	}
+	return s, nil
+}
+
+// Name returns service name.
+func (s *Service) Name() string {
+	return "webhook"
+}
+
+// Start runs the webhook service. It returns immediately, all events are
+// processed and delivered in separate routines.
+func (s *Service) Start() {
+	if !s.started.CompareAndSwap(false, true) {
+		return
+	}
+	s.log.Info("starting webhook service")
+	s.ctx, s.ctxCancel = context.WithCancel(context.Background())
+	for _, snd := range s.senders {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			snd.run(s.ctx)
+		}()
+	}
+	if s.cfg.Mempool && len(s.accounts) != 0 {
+		s.seen = s.mempoolSnapshot()
+		s.wg.Add(1)
+		go s.pollMempool()
+	}
+	s.chain.SubscribeForBlocks(s.blockCh)
+	s.wg.Add(1)
+	go s.run()
+}
+
+// Shutdown stops the webhook service, undelivered events are dropped.
+func (s *Service) Shutdown() {
+	if !s.started.CompareAndSwap(true, false) {
+		return
+	}
+	s.log.Info("shutting down webhook service")
+	s.ctxCancel()
+	s.chain.UnsubscribeFromBlocks(s.blockCh)
+	s.wg.Wait()
+	_ = s.log.Sync()
+}
+
+// run processes new blocks until the service is stopped.
+func (s *Service) run() {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-s.ctx.Done():
+			// Drain the channel until it's unsubscribed.
+			for {
+				select {
+				case <-s.blockCh:
+				default:
+					return
+				}
+			}
+		case b := <-s.blockCh:
+			s.handleBlock(b)
+		}
+	}
+}
+
+// pollMempool periodically checks memory pool for new watched transactions.
+func (s *Service) pollMempool() {
+	defer s.wg.Done()
+	t := time.NewTicker(s.cfg.MempoolPollInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-t.C:
+			s.checkMempool()
+		}
+	}
+}
+
+// mempoolSnapshot returns the set of verified memory pool transactions.
+func (s *Service) mempoolSnapshot() map[util.Uint256]struct{} {
+	txes := s.chain.GetMemPool().GetVerifiedTransactions()
+	res := make(map[util.Uint256]struct{}, len(txes))
+	for _, tx := range txes {
+		res[tx.Hash()] = struct{}{}
+	}
+	return res
+}
+
+// checkMempool sends events for watched transactions added to the memory
+// pool since the previous check.
+func (s *Service) checkMempool() {
+	txes := s.chain.GetMemPool().GetVerifiedTransactions()
+	seen := make(map[util.Uint256]struct{}, len(txes))
+	for _, tx := range txes {
+		h := tx.Hash()
+		seen[h] = struct{}{}
+		if _, ok := s.seen[h]; ok || !s.signedByWatched(tx) {
+			continue
+		}
+		s.send(&Payload{
+			Type:        MempoolEvent,
+			Container:   h,
+			Transaction: tx,
+		})
+	}
+	s.seen = seen
+}
+
+// handleBlock sends events for watched transactions and notifications of the
+// given block.
+func (s *Service) handleBlock(b *block.Block) {
+	var (
+		index = b.Index
+		hash  = b.Hash()
+	)
+	aers, err := s.chain.GetAppExecResults(hash, trigger.All)
+	if err != nil {
+		s.log.Warn("failed to get block execution results", zap.Uint32("index", index), zap.Error(err))
+	}
+	var onPersist, postPersist []state.AppExecResult
+	for _, aer := range aers {
+		if aer.Trigger == trigger.OnPersist {
+			onPersist = append(onPersist, aer)
+		} else {
+			postPersist = append(postPersist, aer)
+		}
+	}
+	s.handleNotifications(index, hash, onPersist)
+	for _, tx := range b.Transactions {
+		h := tx.Hash()
+		txAers, err := s.chain.GetAppExecResults(h, trigger.Application)
+		if err != nil {
+			s.log.Warn("failed to get transaction execution result", zap.Stringer("tx", h), zap.Error(err))
+		}
+		if s.signedByWatched(tx) {
+			p := &Payload{
+				Type:        TransactionEvent,
+				BlockIndex:  &index,
+				BlockHash:   &hash,
+				Container:   h,
+				Transaction: tx,
+			}
+			if len(txAers) != 0 {
+				p.VMState = txAers[0].VMState.String()
+			}
+			s.send(p)
+		}
+		s.handleNotifications(index, hash, txAers)
+	}
+	s.handleNotifications(index, hash, postPersist)
+}
+
+// handleNotifications sends events for watched notifications of the given
+// executions.
+func (s *Service) handleNotifications(index uint32, hash util.Uint256, aers []state.AppExecResult) {
+	for _, aer := range aers {
+		for i := range aer.Events {
+			ntf := &aer.Events[i]
+			if !s.isWatchedNotification(ntf) {
+				continue
+			}
+			s.send(&Payload{
+				Type:         NotificationEvent,
+				BlockIndex:   &index,
+				BlockHash:    &hash,
+				Container:    aer.Container,
+				VMState:      aer.VMState.String(),
+				Notification: ntf,
+			})
+		}
+	}
+}
+
+// signedByWatched checks whether the transaction is signed by any of the
+// watched accounts.
+func (s *Service) signedByWatched(tx *transaction.Transaction) bool {
+	for _, signer := range tx.Signers {
+		if _, ok := s.accounts[signer.Account]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isWatchedNotification checks whether the notification is emitted by one of
+// the watched contracts or it's a NEP-11/NEP-17 transfer involving one of the
+// watched accounts.
+func (s *Service) isWatchedNotification(ntf *state.NotificationEvent) bool {
+	if _, ok := s.contracts[ntf.ScriptHash]; ok {
+		return true
+	}
+	if len(s.accounts) == 0 || ntf.Name != "Transfer" || ntf.Item == nil {
+		return false
+	}
+	arr := ntf.Item.Value().([]stackitem.Item)
+	if len(arr) < 3 {
+		return false
+	}
+	for _, item := range arr[:2] {
+		if _, ok := item.(stackitem.Null); ok {
+			continue
+		}
+		b, err := item.TryBytes()
+		if err != nil || len(b) != util.Uint160Size {
+			continue
+		}
+		u, _ := util.Uint160DecodeBytesBE(b)
+		if _, ok := s.accounts[u]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// send queues the event for delivery to all endpoints.
+func (s *Service) send(p *Payload) {
+	p.Timestamp = time.Now().UnixMilli()
+	body, err := json.Marshal(p)
+	if err != nil {
+		s.log.Error("failed to marshal webhook payload", zap.String("type", p.Type), zap.Error(err))
+		return
+	}
+	for _, snd := range s.senders {
+		snd.enqueue(p.Type, body)
+	}
+}
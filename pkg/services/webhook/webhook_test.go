@@ -0,0 +1,189 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type request struct {
+	event     string
+	signature string
+	payload   Payload
+	body      []byte
+}
+
+// newReceiver starts an HTTP server passing received requests to the returned
+// channel.
+func newReceiver(t *testing.T, handler func(w http.ResponseWriter)) (*httptest.Server, chan request) {
+	ch := make(chan request, 16)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var p Payload
+		require.NoError(t, json.Unmarshal(body, &p))
+		ch <- request{
+			event:     r.Header.Get(EventHeader),
+			signature: r.Header.Get(SignatureHeader),
+			payload:   p,
+			body:      body,
+		}
+		if handler != nil {
+			handler(w)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, ch
+}
+
+func receive(t *testing.T, ch chan request) request {
+	select {
+	case r := <-ch:
+		return r
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no webhook request received")
+	}
+	return request{}
+}
+
+func TestService(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	srv, ch := newReceiver(t, nil)
+
+	var (
+		receiver = util.Uint160{1, 2, 3}
+		secret   = "top secret"
+	)
+	s, err := New(bc, config.Webhook{
+		Enabled:             true,
+		Addresses:           []string{address.Uint160ToString(acc.ScriptHash()), address.Uint160ToString(receiver)},
+		Mempool:             true,
+		MempoolPollInterval: 10 * time.Millisecond,
+		Endpoints:           []config.WebhookEndpoint{{URL: srv.URL, Secret: secret}},
+	}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	s.Start()
+	t.Cleanup(s.Shutdown)
+
+	gasHash := e.NativeHash(t, nativenames.Gas)
+	tx := e.NewTx(t, []neotest.Signer{acc}, gasHash, "transfer", acc.ScriptHash(), receiver, 1, nil)
+	require.NoError(t, bc.PoolTx(tx))
+
+	r := receive(t, ch)
+	require.Equal(t, MempoolEvent, r.event)
+	require.Equal(t, "sha256="+Sign([]byte(secret), r.body), r.signature)
+	require.Equal(t, tx.Hash(), r.payload.Container)
+	require.Nil(t, r.payload.BlockIndex)
+	require.Equal(t, tx.Hash(), r.payload.Transaction.Hash())
+
+	b := e.AddNewBlock(t, tx)
+	var (
+		txEvent  bool
+		transfer bool
+	)
+	for !txEvent || !transfer {
+		r = receive(t, ch)
+		require.NotNil(t, r.payload.BlockIndex)
+		require.Equal(t, b.Index, *r.payload.BlockIndex)
+		require.Equal(t, b.Hash(), *r.payload.BlockHash)
+		switch r.event {
+		case TransactionEvent:
+			require.Equal(t, tx.Hash(), r.payload.Container)
+			require.Equal(t, vmstate.Halt.String(), r.payload.VMState)
+			txEvent = true
+		case NotificationEvent:
+			require.Equal(t, gasHash, r.payload.Notification.ScriptHash)
+			require.Equal(t, "Transfer", r.payload.Notification.Name)
+			if r.payload.Container == tx.Hash() {
+				transfer = true
+			}
+		default:
+			require.FailNow(t, "unexpected event", r.event)
+		}
+	}
+}
+
+func TestServiceContracts(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	srv, ch := newReceiver(t, nil)
+
+	neoHash := e.NativeHash(t, nativenames.Neo)
+	s, err := New(bc, config.Webhook{
+		Enabled:   true,
+		Contracts: []string{neoHash.StringLE()},
+		Endpoints: []config.WebhookEndpoint{{URL: srv.URL}},
+	}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	s.Start()
+	t.Cleanup(s.Shutdown)
+
+	// GAS transfers are not watched.
+	e.ValidatorInvoker(e.NativeHash(t, nativenames.Gas)).Invoke(t, true, "transfer", acc.ScriptHash(), util.Uint160{1}, 1, nil)
+	h := e.ValidatorInvoker(neoHash).Invoke(t, true, "transfer", acc.ScriptHash(), util.Uint160{1}, 1, nil)
+
+	r := receive(t, ch)
+	require.Equal(t, NotificationEvent, r.event)
+	require.Empty(t, r.signature)
+	require.Equal(t, h, r.payload.Container)
+	require.Equal(t, neoHash, r.payload.Notification.ScriptHash)
+}
+
+func TestServiceRetry(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	var attempts atomic.Int32
+	srv, ch := newReceiver(t, func(w http.ResponseWriter) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+	s, err := New(bc, config.Webhook{
+		Enabled:       true,
+		Contracts:     []string{e.NativeHash(t, nativenames.Neo).StringLE()},
+		Endpoints:     []config.WebhookEndpoint{{URL: srv.URL}},
+		MaxRetries:    1,
+		RetryInterval: 10 * time.Millisecond,
+	}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	s.Start()
+	t.Cleanup(s.Shutdown)
+
+	e.ValidatorInvoker(e.NativeHash(t, nativenames.Neo)).Invoke(t, true, "transfer", acc.ScriptHash(), util.Uint160{1}, 1, nil)
+	first := receive(t, ch)
+	second := receive(t, ch)
+	require.Equal(t, first.body, second.body)
+	require.EqualValues(t, 2, attempts.Load())
+}
+
+func TestNewInvalid(t *testing.T) {
+	bc, _ := chain.NewSingle(t)
+	cfg := config.Webhook{
+		Enabled:   true,
+		Addresses: []string{"bad"},
+		Endpoints: []config.WebhookEndpoint{{URL: "http://localhost"}},
+	}
+	_, err := New(bc, cfg, zaptest.NewLogger(t))
+	require.ErrorContains(t, err, "invalid address")
+
+	cfg.Addresses = nil
+	cfg.Contracts = []string{"bad"}
+	_, err = New(bc, cfg, zaptest.NewLogger(t))
+	require.ErrorContains(t, err, "invalid contract hash")
+}
@@ -84,7 +84,11 @@ type (
 		Manifest *manifest.Manifest `yaml:"-"`
 		// Hash denotes the contract hash and is allowed to be empty for RPC bindings
 		// generation (if not provided by the user).
-		Hash      util.Uint160                 `yaml:"hash,omitempty"`
+		Hash util.Uint160 `yaml:"hash,omitempty"`
+		// NNSName is the NNS domain name that can be used by RPC bindings to
+		// resolve contract hash at runtime (via TXT record). It can only be
+		// used when Hash is not set.
+		NNSName   string                       `yaml:"nnsname,omitempty"`
 		Overrides map[string]Override          `yaml:"overrides,omitempty"`
 		CallFlags map[string]callflag.CallFlag `yaml:"callflags,omitempty"`
 		// NamedTypes contains exported structured types that have some name (even
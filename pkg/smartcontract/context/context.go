@@ -12,6 +12,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -162,6 +163,17 @@ func (c *ParameterContext) AddSignature(h util.Uint160, ctr *wallet.Contract, pu
 	return nil
 }
 
+// AddContract creates an empty item for the specified contract if there is
+// none yet. Items are created automatically when signatures are added, but
+// adding them in advance allows to keep verification scripts of all signers in
+// the context and to create witnesses for deployed contracts that don't need
+// any signatures.
+func (c *ParameterContext) AddContract(h util.Uint160, ctr *wallet.Contract) {
+	if _, ok := c.Items[h]; !ok {
+		c.getItemForContract(h, ctr)
+	}
+}
+
 func (c *ParameterContext) getItemForContract(h util.Uint160, ctr *wallet.Contract) *Item {
 	item, ok := c.Items[ctr.ScriptHash()]
 	if ok {
@@ -250,3 +262,70 @@ func (c *ParameterContext) UnmarshalJSON(data []byte) error {
 	c.Items = items
 	return nil
 }
+
+// Merge adds signatures from the other context to c. Both contexts must refer
+// to the same verifiable item in the same network. Signatures are checked
+// against the item hash before being added, parameters that are already set
+// in c are not overwritten. It returns the number of signatures added.
+func (c *ParameterContext) Merge(other *ParameterContext) (int, error) {
+	if c.Type != other.Type || c.Network != other.Network {
+		return 0, errors.New("context type or network mismatch")
+	}
+	if !c.Verifiable.Hash().Equals(other.Verifiable.Hash()) {
+		return 0, errors.New("verifiable item mismatch")
+	}
+	var added int
+	for h, oItem := range other.Items {
+		if len(oItem.Script) != 0 && hash.Hash160(oItem.Script) != h {
+			return added, fmt.Errorf("script hash mismatch for %s", h.StringLE())
+		}
+		item, ok := c.Items[h]
+		if !ok {
+			item = &Item{
+				Script:     oItem.Script,
+				Parameters: make([]smartcontract.Parameter, len(oItem.Parameters)),
+				Signatures: make(map[string][]byte),
+			}
+			for i := range oItem.Parameters {
+				item.Parameters[i].Type = oItem.Parameters[i].Type
+			}
+			c.Items[h] = item
+		}
+		if _, _, ok := vm.ParseMultiSigContract(item.Script); ok {
+			ctr := &wallet.Contract{
+				Script:     item.Script,
+				Parameters: make([]wallet.ContractParam, len(item.Parameters)),
+			}
+			for i := range item.Parameters {
+				ctr.Parameters[i].Type = item.Parameters[i].Type
+			}
+			for pubHex, sig := range oItem.Signatures {
+				pub, err := keys.NewPublicKeyFromString(pubHex)
+				if err != nil {
+					return added, fmt.Errorf("invalid public key %s: %w", pubHex, err)
+				}
+				if item.GetSignature(pub) != nil {
+					continue
+				}
+				if !pub.VerifyHashable(sig, uint32(c.Network), c.Verifiable) {
+					return added, fmt.Errorf("invalid signature for %s", pubHex)
+				}
+				if err := c.AddSignature(h, ctr, pub, sig); err != nil {
+					return added, fmt.Errorf("can't add signature for %s: %w", pubHex, err)
+				}
+				added++
+			}
+			continue
+		}
+		if len(item.Parameters) != len(oItem.Parameters) {
+			return added, fmt.Errorf("parameter count mismatch for %s", h.StringLE())
+		}
+		for i := range oItem.Parameters {
+			if item.Parameters[i].Value == nil && oItem.Parameters[i].Value != nil {
+				item.Parameters[i] = oItem.Parameters[i]
+				added++
+			}
+		}
+	}
+	return added, nil
+}
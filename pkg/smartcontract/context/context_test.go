@@ -1,6 +1,7 @@
 package context
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"testing"
 
@@ -80,6 +81,36 @@ func TestParameterContext_AddSignatureSimpleContract(t *testing.T) {
 	})
 }
 
+func TestParameterContext_AddContract(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	pub := priv.PublicKey()
+	ctr := &wallet.Contract{
+		Script:     pub.GetVerificationScript(),
+		Parameters: []wallet.ContractParam{newParam(smartcontract.SignatureType, "parameter0")},
+	}
+	deployed := &wallet.Contract{Deployed: true}
+	deployedHash := util.Uint160{1, 2, 3}
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	tx.Signers = []transaction.Signer{{Account: ctr.ScriptHash()}, {Account: deployedHash}}
+
+	c := NewParameterContext(TransactionType, netmode.UnitTestNet, tx)
+	c.AddContract(ctr.ScriptHash(), ctr)
+	c.AddContract(deployedHash, deployed)
+	require.Equal(t, ctr.Script, c.Items[ctr.ScriptHash()].Script)
+	_, err = c.GetCompleteTransaction()
+	require.Error(t, err)
+
+	sig := priv.SignHashable(uint32(netmode.UnitTestNet), tx)
+	require.NoError(t, c.AddSignature(ctr.ScriptHash(), ctr, pub, sig))
+	c.AddContract(ctr.ScriptHash(), ctr) // Doesn't overwrite the signature.
+	res, err := c.GetCompleteTransaction()
+	require.NoError(t, err)
+	require.Equal(t, ctr.Script, res.Scripts[0].VerificationScript)
+	require.Empty(t, res.Scripts[1].InvocationScript)
+	require.Empty(t, res.Scripts[1].VerificationScript)
+}
+
 func TestGetCompleteTransactionForNonTx(t *testing.T) {
 	c := NewParameterContext("Neo.Network.P2P.Payloads.Block", netmode.UnitTestNet, verifStub{})
 	_, err := c.GetCompleteTransaction()
@@ -157,6 +188,71 @@ func TestParameterContext_AddSignatureMultisig(t *testing.T) {
 	})
 }
 
+func TestParameterContext_Merge(t *testing.T) {
+	privs, pubs := getPrivateKeys(t, 3)
+	script, err := smartcontract.CreateMultiSigRedeemScript(2, keys.PublicKeys(pubs).Copy())
+	require.NoError(t, err)
+
+	ctr := &wallet.Contract{
+		Script: script,
+		Parameters: []wallet.ContractParam{
+			newParam(smartcontract.SignatureType, "parameter0"),
+			newParam(smartcontract.SignatureType, "parameter1"),
+		},
+	}
+	tx := getContractTx(ctr.ScriptHash())
+	c1 := NewParameterContext(TransactionType, netmode.UnitTestNet, tx)
+	c2 := NewParameterContext(TransactionType, netmode.UnitTestNet, tx)
+	require.NoError(t, c1.AddSignature(ctr.ScriptHash(), ctr, pubs[0], privs[0].SignHashable(uint32(c1.Network), tx)))
+	require.NoError(t, c2.AddSignature(ctr.ScriptHash(), ctr, pubs[2], privs[2].SignHashable(uint32(c2.Network), tx)))
+
+	t.Run("network mismatch", func(t *testing.T) {
+		_, err := c1.Merge(NewParameterContext(TransactionType, netmode.MainNet, tx))
+		require.Error(t, err)
+	})
+	t.Run("verifiable mismatch", func(t *testing.T) {
+		_, err := c1.Merge(NewParameterContext(TransactionType, netmode.UnitTestNet, getContractTx(util.Uint160{1, 2, 3})))
+		require.Error(t, err)
+	})
+	t.Run("bad signature", func(t *testing.T) {
+		bad := NewParameterContext(TransactionType, netmode.UnitTestNet, tx)
+		require.NoError(t, bad.AddSignature(ctr.ScriptHash(), ctr, pubs[1], privs[0].SignHashable(uint32(bad.Network), tx)))
+		_, err := c1.Merge(bad)
+		require.Error(t, err)
+	})
+	t.Run("script hash mismatch", func(t *testing.T) {
+		other, err := smartcontract.CreateMultiSigRedeemScript(1, keys.PublicKeys(pubs).Copy())
+		require.NoError(t, err)
+		bad := NewParameterContext(TransactionType, netmode.UnitTestNet, tx)
+		bad.Items[ctr.ScriptHash()] = &Item{
+			Script:     other,
+			Parameters: []smartcontract.Parameter{{Type: smartcontract.SignatureType}},
+			Signatures: map[string][]byte{
+				hex.EncodeToString(pubs[1].Bytes()): privs[1].SignHashable(uint32(bad.Network), tx),
+			},
+		}
+		_, err = c1.Merge(bad)
+		require.ErrorContains(t, err, "script hash mismatch")
+	})
+
+	_, err = c1.GetCompleteTransaction()
+	require.Error(t, err)
+
+	n, err := c1.Merge(c2)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	// Merging the same data again is a no-op.
+	n, err = c1.Merge(c2)
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+
+	w, err := c1.GetWitness(ctr.ScriptHash())
+	require.NoError(t, err)
+	v := newTestVM(w, tx)
+	require.NoError(t, v.Run())
+	require.Equal(t, true, v.Estack().Pop().Value())
+}
+
 func newTestVM(w *transaction.Witness, tx *transaction.Transaction) *vm.VM {
 	ic := &interop.Context{Network: uint32(netmode.UnitTestNet), Container: tx, Functions: crypto.Interops}
 	v := ic.SpawnVM()
@@ -18,6 +18,12 @@ const (
 	// MethodVerify is a name for default verification method.
 	MethodVerify = "verify"
 
+	// MethodUpdate is a name for contract update method (NEP-22).
+	MethodUpdate = "update"
+
+	// MethodDestroy is a name for contract destruction method (NEP-31).
+	MethodDestroy = "destroy"
+
 	// MethodOnNEP17Payment is the name of the method which is called when contract receives NEP-17 tokens.
 	MethodOnNEP17Payment = "onNEP17Payment"
 
@@ -0,0 +1,98 @@
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+)
+
+// IndexedEventsField is the name of the manifest Extra field that lists
+// indexed event parameters. It's a NeoGo extension, the field contains a JSON
+// object mapping event names to arrays of their parameter names, like
+//
+//	"extra": {"indexedEvents": {"Transfer": ["from", "to"]}}
+//
+// Nodes with event indexing enabled build an index over values of these
+// parameters for every notification emitted by the contract, which allows to
+// query notifications by parameter value without scanning the whole chain.
+const IndexedEventsField = "indexedEvents"
+
+// IndexedEvents returns positions of indexed parameters for every event listed
+// in the IndexedEventsField of the manifest's Extra. nil map is returned if
+// Extra is not a JSON object or doesn't have this field. An error is returned
+// if the field is malformed or refers to unknown events or parameters or to
+// parameters of non-primitive types that can't be indexed.
+func (m *Manifest) IndexedEvents() (map[string][]int, error) {
+	var extra map[string]json.RawMessage
+	if json.Unmarshal(m.Extra, &extra) != nil || extra[IndexedEventsField] == nil {
+		return nil, nil
+	}
+	var names map[string][]string
+	if err := json.Unmarshal(extra[IndexedEventsField], &names); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", IndexedEventsField, err)
+	}
+	res := make(map[string][]int, len(names))
+	for name, params := range names {
+		e := m.ABI.GetEvent(name)
+		if e == nil {
+			return nil, fmt.Errorf("indexed event %s is not declared", name)
+		}
+		if len(params) == 0 {
+			return nil, fmt.Errorf("event %s has no indexed parameters", name)
+		}
+		positions := make([]int, 0, len(params))
+		for _, p := range params {
+			i := slices.IndexFunc(e.Parameters, func(ep Parameter) bool { return ep.Name == p })
+			if i < 0 {
+				return nil, fmt.Errorf("event %s has no parameter %s", name, p)
+			}
+			if slices.Contains(positions, i) {
+				return nil, fmt.Errorf("event %s parameter %s is indexed twice", name, p)
+			}
+			if !IsIndexable(e.Parameters[i].Type) {
+				return nil, fmt.Errorf("event %s parameter %s of %s type can't be indexed", name, p, e.Parameters[i].Type)
+			}
+			positions = append(positions, i)
+		}
+		res[name] = positions
+	}
+	return res, nil
+}
+
+// SetIndexedEvents sets the IndexedEventsField of the manifest's Extra to the
+// given event to parameter names mapping, other Extra fields are preserved.
+// Extra must either be empty (null) or contain a JSON object.
+func (m *Manifest) SetIndexedEvents(events map[string][]string) error {
+	var extra map[string]any
+	if len(m.Extra) != 0 {
+		if err := json.Unmarshal(m.Extra, &extra); err != nil {
+			return errors.New("extra is not a JSON object")
+		}
+	}
+	if extra == nil {
+		extra = make(map[string]any)
+	}
+	extra[IndexedEventsField] = events
+	raw, err := json.Marshal(extra)
+	if err != nil {
+		return err
+	}
+	m.Extra = raw
+	return nil
+}
+
+// IsIndexable checks whether event parameters of the given type can be
+// indexed. Only primitive types are supported.
+func IsIndexable(t smartcontract.ParamType) bool {
+	switch t {
+	case smartcontract.BoolType, smartcontract.IntegerType, smartcontract.ByteArrayType,
+		smartcontract.StringType, smartcontract.Hash160Type, smartcontract.Hash256Type,
+		smartcontract.PublicKeyType, smartcontract.SignatureType:
+		return true
+	default:
+		return false
+	}
+}
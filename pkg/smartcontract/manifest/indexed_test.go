@@ -0,0 +1,68 @@
+package manifest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifest_IndexedEvents(t *testing.T) {
+	m := NewManifest("Test")
+	m.ABI.Events = []Event{{
+		Name: "Transfer",
+		Parameters: []Parameter{
+			NewParameter("from", smartcontract.Hash160Type),
+			NewParameter("to", smartcontract.Hash160Type),
+			NewParameter("amount", smartcontract.IntegerType),
+			NewParameter("data", smartcontract.ArrayType),
+		},
+	}}
+
+	t.Run("no extra", func(t *testing.T) {
+		res, err := m.IndexedEvents()
+		require.NoError(t, err)
+		require.Nil(t, res)
+	})
+	t.Run("not an object", func(t *testing.T) {
+		m.Extra = json.RawMessage(`"some string"`)
+		res, err := m.IndexedEvents()
+		require.NoError(t, err)
+		require.Nil(t, res)
+	})
+	t.Run("good", func(t *testing.T) {
+		m.Extra = json.RawMessage(`{"Author":"me","indexedEvents":{"Transfer":["to","from"]}}`)
+		res, err := m.IndexedEvents()
+		require.NoError(t, err)
+		require.Equal(t, map[string][]int{"Transfer": {1, 0}}, res)
+	})
+	for name, extra := range map[string]string{
+		"malformed":      `{"indexedEvents":["Transfer"]}`,
+		"unknown event":  `{"indexedEvents":{"Burn":["from"]}}`,
+		"no parameters":  `{"indexedEvents":{"Transfer":[]}}`,
+		"unknown param":  `{"indexedEvents":{"Transfer":["owner"]}}`,
+		"duplicate":      `{"indexedEvents":{"Transfer":["to","to"]}}`,
+		"non-primitive":  `{"indexedEvents":{"Transfer":["data"]}}`,
+		"null parameter": `{"indexedEvents":{"Transfer":null}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			m.Extra = json.RawMessage(extra)
+			_, err := m.IndexedEvents()
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestManifest_SetIndexedEvents(t *testing.T) {
+	m := NewManifest("Test")
+	require.NoError(t, m.SetIndexedEvents(map[string][]string{"Transfer": {"to"}}))
+	require.JSONEq(t, `{"indexedEvents":{"Transfer":["to"]}}`, string(m.Extra))
+
+	m.Extra = json.RawMessage(`{"Author":"me"}`)
+	require.NoError(t, m.SetIndexedEvents(map[string][]string{"Transfer": {"to"}}))
+	require.JSONEq(t, `{"Author":"me","indexedEvents":{"Transfer":["to"]}}`, string(m.Extra))
+
+	m.Extra = json.RawMessage(`"some string"`)
+	require.Error(t, m.SetIndexedEvents(map[string][]string{"Transfer": {"to"}}))
+}
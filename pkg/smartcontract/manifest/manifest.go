@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	ojson "github.com/nspcc-dev/go-ordered-json"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 )
@@ -22,6 +23,9 @@ const (
 	NEP11StandardName = "NEP-11"
 	// NEP17StandardName represents the name of NEP-17 smartcontract standard.
 	NEP17StandardName = "NEP-17"
+	// NEP22StandardName represents the name of NEP-22 smartcontract standard
+	// (contract update method).
+	NEP22StandardName = "NEP-22"
 	// NEP24StandardName represents the name of the NEP-24 smart contract standard for NFT royalties.
 	NEP24StandardName = "NEP-24"
 	// NEP24Payable represents the name of the contract interface for handling royalty payments in accordance
@@ -31,6 +35,12 @@ const (
 	NEP26StandardName = "NEP-26"
 	// NEP27StandardName represents the name of NEP-27 smartcontract standard.
 	NEP27StandardName = "NEP-27"
+	// NEP29StandardName represents the name of NEP-29 smartcontract standard
+	// (contract deployment callback).
+	NEP29StandardName = "NEP-29"
+	// NEP31StandardName represents the name of NEP-31 smartcontract standard
+	// (contract destruction method).
+	NEP31StandardName = "NEP-31"
 
 	emptyFeatures = "{}"
 )
@@ -157,6 +167,26 @@ func (m *Manifest) IsValid(hash util.Uint160, checkSize bool) error {
 	return nil
 }
 
+// AddGroup adds the group with the public key of the given private key to the
+// manifest, signing the given contract hash with it. The hash is expected to be
+// the one the contract has (or will have after deployment, see
+// state.CreateContractHash). If the manifest already has the group with the
+// same public key, only its signature is updated.
+func (m *Manifest) AddGroup(h util.Uint160, priv *keys.PrivateKey) {
+	g := Group{
+		PublicKey: priv.PublicKey(),
+		Signature: priv.Sign(h.BytesBE()),
+	}
+	i := slices.IndexFunc(m.Groups, func(gr Group) bool {
+		return gr.PublicKey.Equal(g.PublicKey)
+	})
+	if i >= 0 {
+		m.Groups[i] = g
+	} else {
+		m.Groups = append(m.Groups, g)
+	}
+}
+
 // IsStandardSupported denotes whether the specified standard is supported by the contract.
 func (m *Manifest) IsStandardSupported(standard string) bool {
 	return slices.Contains(m.SupportedStandards, standard)
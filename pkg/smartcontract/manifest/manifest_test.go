@@ -504,3 +504,24 @@ func TestManifest_IsStandardSupported(t *testing.T) {
 	require.False(t, m.IsStandardSupported(""))
 	require.False(t, m.IsStandardSupported("unknown standard"))
 }
+
+func TestManifest_AddGroup(t *testing.T) {
+	m := DefaultManifest("Test")
+	h := random.Uint160()
+	k1, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	k2, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	m.AddGroup(h, k1)
+	m.AddGroup(h, k2)
+	require.Equal(t, 2, len(m.Groups))
+	require.NoError(t, Groups(m.Groups).AreValid(h))
+
+	other := random.Uint160()
+	m.AddGroup(other, k1)
+	require.Equal(t, 2, len(m.Groups))
+	require.True(t, m.Groups[0].PublicKey.Equal(k1.PublicKey()))
+	require.NoError(t, m.Groups[0].IsValid(other))
+	require.Error(t, Groups(m.Groups).AreValid(other)) // k2 signature is for h.
+}
@@ -141,6 +141,25 @@ func TestCheck(t *testing.T) {
 	require.NoError(t, CheckABI(m, manifest.NEP17StandardName))
 }
 
+func TestCheckLifecycleStandards(t *testing.T) {
+	for name, st := range map[string]*Standard{
+		manifest.NEP22StandardName: Nep22,
+		manifest.NEP29StandardName: Nep29,
+		manifest.NEP31StandardName: Nep31,
+	} {
+		t.Run(name, func(t *testing.T) {
+			m := manifest.NewManifest("Test")
+			require.ErrorIs(t, Check(m, name), ErrMethodMissing)
+
+			m.ABI.Methods = append(m.ABI.Methods, st.ABI.Methods...)
+			require.NoError(t, Check(m, name))
+
+			m.ABI.Methods[0].ReturnType = smartcontract.BoolType
+			require.ErrorIs(t, Check(m, name), ErrInvalidReturnType)
+		})
+	}
+}
+
 func TestOptional(t *testing.T) {
 	var m Standard
 	m.Optional = []manifest.Method{{
@@ -0,0 +1,18 @@
+package standard
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+)
+
+// Nep31 is a NEP-31 Standard (contract destruction method).
+var Nep31 = &Standard{
+	Manifest: manifest.Manifest{
+		ABI: manifest.ABI{
+			Methods: []manifest.Method{{
+				Name:       manifest.MethodDestroy,
+				ReturnType: smartcontract.VoidType,
+			}},
+		},
+	},
+}
@@ -2,6 +2,7 @@ package rpcbinding
 
 import (
 	"cmp"
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
@@ -125,6 +126,12 @@ import (
 // Hash contains contract hash.
 var Hash = {{ .Hash }}
 {{end -}}
+{{if len .NNSName}}
+// NNSName contains NNS domain name used to resolve contract hash.
+const NNSName = {{ printf "%q" .NNSName }}
+
+var hashCache nns.HashCache
+{{end -}}
 {{- range $index, $typ := .NamedTypes }}
 // {{toTypeName $typ.Name}} is a contract-specific {{$typ.Name}} type used by its methods.
 type {{toTypeName $typ.Name}} struct {
@@ -140,7 +147,7 @@ type Invoker interface {
 {{if or .IsNep11D .IsNep11ND}}	nep11.Invoker
 {{else -}}
 {{ if .IsNep17}}	nep17.Invoker
-{{else if len .SafeMethods}}	Call(contract util.Uint160, operation string, params ...any) (*result.Invoke, error)
+{{else if or (len .SafeMethods) (len .NNSName)}}	Call(contract util.Uint160, operation string, params ...any) (*result.Invoke, error)
 {{end -}}
 {{if .HasIterator}}	CallAndExpandIterator(contract util.Uint160, method string, maxItems int, params ...any) (*result.Invoke, error)
 	TerminateSession(sessionID uuid.UUID) error
@@ -160,7 +167,7 @@ type Actor interface {
 {{else if .IsNep17}}
 	nep17.Actor
 {{end}}
-{{- if len .Methods}}
+{{- if or (len .Methods) .IsNep22 .IsNep31}}
 	MakeCall(contract util.Uint160, method string, params ...any) (*transaction.Transaction, error)
 	MakeRun(script []byte) (*transaction.Transaction, error)
 	MakeUnsignedCall(contract util.Uint160, method string, attrs []transaction.Attribute, params ...any) (*transaction.Transaction, error)
@@ -196,6 +203,10 @@ type Contract struct {
 	{{end -}}
 	{{if .IsNep17}}nep17.TokenWriter
 	{{end -}}
+	{{if .IsNep22}}nep22.UpdateWriter
+	{{end -}}
+	{{if .IsNep31}}nep31.DestroyWriter
+	{{end -}}
 	actor Actor
 	hash util.Uint160
 }
@@ -213,6 +224,19 @@ func NewReader(invoker Invoker{{- if not (len .Hash) -}}, hash util.Uint160{{- e
 		{{- if .IsNep24}}*nep24.NewRoyaltyReader(invoker, hash), {{end -}}
 		invoker, hash}
 }
+{{- if len .NNSName}}
+
+// NewReaderFromNNS creates an instance of ContractReader using contract hash
+// resolved via NNS contract with the given hash (see ResolveHash) and the given
+// Invoker.
+func NewReaderFromNNS(invoker Invoker, nnsHash util.Uint160) (*ContractReader, error) {
+	hash, err := ResolveHash(invoker, nnsHash)
+	if err != nil {
+		return nil, err
+	}
+	return NewReader(invoker, hash), nil
+}
+{{- end}}
 {{end -}}
 {{- if .HasWriter}}
 // New creates an instance of Contract using {{if len .Hash -}}Hash{{- else -}}provided contract hash{{- end}} and the given Actor.
@@ -238,8 +262,31 @@ func New(actor Actor{{- if not (len .Hash) -}}, hash util.Uint160{{- end -}}) *C
 		{{- if .IsNep11D}}nep11dt.DivisibleWriter, {{end -}}
 		{{- if .IsNep11ND}}nep11ndt.BaseWriter, {{end -}}
 		{{- if .IsNep17}}nep17t.TokenWriter, {{end -}}
+		{{- if .IsNep22}}*nep22.NewUpdateWriter(actor, hash), {{end -}}
+		{{- if .IsNep31}}*nep31.NewDestroyWriter(actor, hash), {{end -}}
 		actor, hash}
 }
+{{- if len .NNSName}}
+
+// NewFromNNS creates an instance of Contract using contract hash resolved via
+// NNS contract with the given hash (see ResolveHash) and the given Actor.
+func NewFromNNS(actor Actor, nnsHash util.Uint160) (*Contract, error) {
+	hash, err := ResolveHash(actor, nnsHash)
+	if err != nil {
+		return nil, err
+	}
+	return New(actor, hash), nil
+}
+{{- end}}
+{{end -}}
+{{- if len .NNSName}}
+
+// ResolveHash resolves contract hash using NNSName TXT record of the NNS
+// contract with the given hash. Resolved hashes are cached per NNS contract,
+// so subsequent calls do not perform any invocations.
+func ResolveHash(invoker nns.CallInvoker, nnsHash util.Uint160) (util.Uint160, error) {
+	return hashCache.Resolve(invoker, nnsHash, NNSName)
+}
 {{end -}}
 {{- range $m := .SafeMethods }}{{template "SAFEMETHOD" $m }}{{ end -}}
 {{- range $m := .Methods -}}{{template "METHOD" $m }}{{ end -}}
@@ -365,6 +412,36 @@ func {{$e.Name}}sFromApplicationLog(log *result.ApplicationLog) ([]*{{$e.Name}},
 	return res, nil
 }
 
+// {{$e.Name}}FromNotification converts the provided [state.NotificationEvent]
+// to {{$e.Name}} or returns an error if it's not a "{{$e.ManifestName}}" event
+// or it can't be converted. Notification sender is not checked.
+func {{$e.Name}}FromNotification(ne state.NotificationEvent) (*{{$e.Name}}, error) {
+	if ne.Name != "{{$e.ManifestName}}" {
+		return nil, fmt.Errorf("unexpected event name: %s", ne.Name)
+	}
+	res := new({{$e.Name}})
+	err := res.FromStackItem(ne.Item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize {{$e.Name}} from stackitem: %w", err)
+	}
+	return res, nil
+}
+
+// New{{$e.Name}}Filter creates a notification filter for "{{$e.ManifestName}}"
+// events emitted by the contract with {{if len $.Hash -}}Hash{{- else -}}the given hash{{- end}} that can be used
+// for WSClient.ReceiveExecutionNotifications subscription.
+func New{{$e.Name}}Filter({{if not (len $.Hash)}}hash util.Uint160{{end}}) *neorpc.NotificationFilter {
+	{{- if len $.Hash}}
+	var (
+		hash = Hash
+		name = "{{$e.ManifestName}}"
+	)
+	{{- else}}
+	var name = "{{$e.ManifestName}}"
+	{{- end}}
+	return &neorpc.NotificationFilter{Contract: &hash, Name: &name}
+}
+
 // FromStackItem converts provided [stackitem.Array] to {{$e.Name}} or
 // returns an error if it's not possible to do to so.
 func (e *{{$e.Name}}) FromStackItem(item *stackitem.Array) error {
@@ -412,8 +489,12 @@ type (
 		IsNep11D       bool
 		IsNep11ND      bool
 		IsNep17        bool
+		IsNep22        bool
 		IsNep24        bool
 		IsNep24Payable bool
+		IsNep31        bool
+
+		NNSName string
 
 		HasReader   bool
 		HasWriter   bool
@@ -456,6 +537,9 @@ func NewConfig() binding.Config {
 // It doesn't check manifest from Config for validity, incorrect manifest can
 // lead to unexpected results.
 func Generate(cfg binding.Config) error {
+	if len(cfg.NNSName) != 0 && !cfg.Hash.Equals(util.Uint160{}) {
+		return errors.New("contract hash and NNS name can't be used together")
+	}
 	// Avoid changing *cfg.Manifest.
 	mfst := *cfg.Manifest
 	mfst.ABI.Methods = slices.Clone(mfst.ABI.Methods)
@@ -479,6 +563,11 @@ func Generate(cfg binding.Config) error {
 				imports["github.com/nspcc-dev/neo-go/pkg/rpcclient/nep17"] = struct{}{}
 				ctr.IsNep17 = true
 			}
+		case manifest.NEP22StandardName:
+			if standard.ComplyABI(cfg.Manifest, standard.Nep22) == nil {
+				imports["github.com/nspcc-dev/neo-go/pkg/rpcclient/nep22"] = struct{}{}
+				ctr.IsNep22 = true
+			}
 		case manifest.NEP24StandardName:
 			if standard.ComplyABI(cfg.Manifest, standard.Nep24) == nil {
 				imports["github.com/nspcc-dev/neo-go/pkg/rpcclient/nep24"] = struct{}{}
@@ -488,6 +577,11 @@ func Generate(cfg binding.Config) error {
 			if standard.ComplyABI(cfg.Manifest, standard.Nep24Payable) == nil {
 				ctr.IsNep24Payable = true
 			}
+		case manifest.NEP31StandardName:
+			if standard.ComplyABI(cfg.Manifest, standard.Nep31) == nil {
+				imports["github.com/nspcc-dev/neo-go/pkg/rpcclient/nep31"] = struct{}{}
+				ctr.IsNep31 = true
+			}
 		}
 	}
 
@@ -511,6 +605,12 @@ func Generate(cfg binding.Config) error {
 	if ctr.IsNep24Payable {
 		mfst.ABI.Events = dropStdEvents(mfst.ABI.Events, standard.Nep24Payable)
 	}
+	if ctr.IsNep22 {
+		mfst.ABI.Methods = dropStdMethods(mfst.ABI.Methods, standard.Nep22)
+	}
+	if ctr.IsNep31 {
+		mfst.ABI.Methods = dropStdMethods(mfst.ABI.Methods, standard.Nep31)
+	}
 
 	// OnNepXXPayment handlers normally can't be called directly.
 	if standard.ComplyABI(cfg.Manifest, standard.Nep26) == nil {
@@ -984,6 +1084,12 @@ func scTemplateToRPC(cfg binding.Config, ctr ContractTmpl, imports map[string]st
 	}
 	if !cfg.Hash.Equals(util.Uint160{}) {
 		ctr.Hash = fmt.Sprintf("%#v", cfg.Hash)
+	} else if len(cfg.NNSName) != 0 {
+		ctr.NNSName = cfg.NNSName
+		imports["github.com/nspcc-dev/neo-go/pkg/rpcclient/nns"] = struct{}{}
+		if !(ctr.IsNep17 || ctr.IsNep11D || ctr.IsNep11ND) {
+			imports["github.com/nspcc-dev/neo-go/pkg/neorpc/result"] = struct{}{}
+		}
 	}
 	for i := 0; i < len(ctr.Methods); i++ {
 		abim := cfg.Manifest.ABI.GetMethod(ctr.Methods[i].NameABI, len(ctr.Methods[i].Arguments))
@@ -1048,6 +1154,8 @@ func scTemplateToRPC(cfg binding.Config, ctr ContractTmpl, imports map[string]st
 	}
 
 	if len(ctr.CustomEvents) > 0 {
+		imports["github.com/nspcc-dev/neo-go/pkg/core/state"] = struct{}{}
+		imports["github.com/nspcc-dev/neo-go/pkg/neorpc"] = struct{}{}
 		imports["github.com/nspcc-dev/neo-go/pkg/neorpc/result"] = struct{}{}
 		imports["github.com/nspcc-dev/neo-go/pkg/vm/stackitem"] = struct{}{}
 		imports["fmt"] = struct{}{}
@@ -1117,13 +1225,13 @@ func scTemplateToRPC(cfg binding.Config, ctr ContractTmpl, imports map[string]st
 			imports["github.com/nspcc-dev/neo-go/pkg/neorpc/result"] = struct{}{}
 		}
 	}
-	if len(ctr.Methods) > 0 {
+	if len(ctr.Methods) > 0 || ctr.IsNep22 || ctr.IsNep31 {
 		imports["github.com/nspcc-dev/neo-go/pkg/core/transaction"] = struct{}{}
 	}
-	if len(ctr.Methods) > 0 || ctr.IsNep17 || ctr.IsNep11D || ctr.IsNep11ND {
+	if len(ctr.Methods) > 0 || ctr.IsNep17 || ctr.IsNep11D || ctr.IsNep11ND || ctr.IsNep22 || ctr.IsNep31 {
 		ctr.HasWriter = true
 	}
-	if len(ctr.SafeMethods) > 0 || ctr.IsNep17 || ctr.IsNep11D || ctr.IsNep11ND || ctr.IsNep24 {
+	if len(ctr.SafeMethods) > 0 || ctr.IsNep17 || ctr.IsNep11D || ctr.IsNep11ND || ctr.IsNep24 || len(ctr.NNSName) > 0 {
 		ctr.HasReader = true
 	}
 	ctr.Imports = ctr.Imports[:0]
@@ -0,0 +1,46 @@
+package util
+
+import "slices"
+
+// Hash is a type constraint for hash types (Uint160 and Uint256) used by
+// generic helpers of this package.
+type Hash[T any] interface {
+	Uint160 | Uint256
+	Compare(T) int
+}
+
+// SortUnique sorts the given hashes in ascending order and removes duplicates
+// from them. The slice is modified in place and the resulting (possibly
+// shorter) slice is returned.
+func SortUnique[T Hash[T]](hashes []T) []T {
+	slices.SortFunc(hashes, func(a, b T) int { return a.Compare(b) })
+	return slices.Compact(hashes)
+}
+
+// ContainsAll checks whether hashes contain every element of sub, the order
+// of elements and duplicates don't matter.
+func ContainsAll[T Hash[T]](hashes []T, sub []T) bool {
+	if len(sub) == 0 {
+		return true
+	}
+	set := make(map[T]struct{}, len(hashes))
+	for _, h := range hashes {
+		set[h] = struct{}{}
+	}
+	for _, h := range sub {
+		if _, ok := set[h]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// MapKeys returns keys of the given map sorted in ascending order.
+func MapKeys[T Hash[T], V any](m map[T]V) []T {
+	res := make([]T, 0, len(m))
+	for h := range m {
+		res = append(res, h)
+	}
+	slices.SortFunc(res, func(a, b T) int { return a.Compare(b) })
+	return res
+}
@@ -0,0 +1,40 @@
+package util_test
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortUnique(t *testing.T) {
+	require.Empty(t, util.SortUnique[util.Uint160](nil))
+
+	var (
+		a = util.Uint160{1}
+		b = util.Uint160{2}
+		c = util.Uint160{3}
+	)
+	require.Equal(t, []util.Uint160{a, b, c}, util.SortUnique([]util.Uint160{c, a, b, a, c}))
+	require.Equal(t, []util.Uint256{{1}, {2}}, util.SortUnique([]util.Uint256{{2}, {1}, {2}}))
+}
+
+func TestContainsAll(t *testing.T) {
+	hashes := []util.Uint256{{1}, {2}, {3}}
+	require.True(t, util.ContainsAll(hashes, nil))
+	require.True(t, util.ContainsAll(hashes, []util.Uint256{{3}, {1}, {3}}))
+	require.False(t, util.ContainsAll(hashes, []util.Uint256{{1}, {4}}))
+	require.False(t, util.ContainsAll(nil, []util.Uint256{{1}}))
+}
+
+func TestMapKeys(t *testing.T) {
+	require.Empty(t, util.MapKeys(map[util.Uint160]bool{}))
+	require.Equal(t, []util.Uint160{{1}, {2}, {3}}, util.MapKeys(map[util.Uint160]int{{3}: 0, {1}: 1, {2}: 2}))
+}
+
+func TestConstantTimeEquals(t *testing.T) {
+	require.True(t, util.Uint160{1, 2}.ConstantTimeEquals(util.Uint160{1, 2}))
+	require.False(t, util.Uint160{1, 2}.ConstantTimeEquals(util.Uint160{1, 3}))
+	require.True(t, util.Uint256{1, 2}.ConstantTimeEquals(util.Uint256{1, 2}))
+	require.False(t, util.Uint256{1, 2}.ConstantTimeEquals(util.Uint256{2, 2}))
+}
@@ -2,6 +2,7 @@ package util
 
 import (
 	"bytes"
+	"crypto/subtle"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -108,6 +109,13 @@ func (u Uint160) Equals(other Uint160) bool {
 	return u == other
 }
 
+// ConstantTimeEquals is similar to Equals, but it takes the same time to
+// compare any two Uint160 values. It's intended for witness checks and other
+// cases where comparison timing must not leak anything.
+func (u Uint160) ConstantTimeEquals(other Uint160) bool {
+	return subtle.ConstantTimeCompare(u[:], other[:]) == 1
+}
+
 // Less returns true if this value is less than the given Uint160 value. It's
 // primarily intended to be used for sorting purposes.
 func (u Uint160) Less(other Uint160) bool {
@@ -2,6 +2,7 @@ package util
 
 import (
 	"bytes"
+	"crypto/subtle"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -85,6 +86,13 @@ func (u Uint256) Equals(other Uint256) bool {
 	return u == other
 }
 
+// ConstantTimeEquals is similar to Equals, but it takes the same time to
+// compare any two Uint256 values. It's intended for witness checks and other
+// cases where comparison timing must not leak anything.
+func (u Uint256) ConstantTimeEquals(other Uint256) bool {
+	return subtle.ConstantTimeCompare(u[:], other[:]) == 1
+}
+
 // String implements the stringer interface.
 func (u Uint256) String() string {
 	return u.StringBE()
@@ -0,0 +1,385 @@
+package vm
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// ScriptIssueType is a type of problem found by AnalyzeScript.
+type ScriptIssueType byte
+
+const (
+	// UnreachableCode is reported for instructions that can't be reached
+	// from any entry point of the script.
+	UnreachableCode ScriptIssueType = iota
+	// InvalidJump is reported for jumps, calls and exception handler
+	// offsets pointing outside of the script or not to an instruction
+	// boundary.
+	InvalidJump
+	// StackImbalance is reported when the same instruction can be reached
+	// with different evaluation stack depths or when a function returns
+	// different number of items from different RET instructions.
+	StackImbalance
+)
+
+// ScriptIssue is a single problem found by AnalyzeScript.
+type ScriptIssue struct {
+	Type    ScriptIssueType
+	Offset  int
+	Message string
+}
+
+// instrInfo is a parsed script instruction used for analysis.
+type instrInfo struct {
+	op      opcode.Opcode
+	param   []byte
+	next    int
+	targets []int
+}
+
+// stackDepth is a relative evaluation stack depth, it can be unknown if it
+// depends on the values used by the script (PACK or SYSCALL for example).
+type stackDepth struct {
+	depth int
+	known bool
+}
+
+// funcInfo is the result of a single function analysis.
+type funcInfo struct {
+	done    bool
+	retSet  bool
+	retDiff stackDepth
+}
+
+type analyzer struct {
+	script  []byte
+	instrs  map[int]*instrInfo
+	funcs   map[int]*funcInfo
+	visited map[int]bool
+	issues  []ScriptIssue
+}
+
+// String implements fmt.Stringer interface.
+func (t ScriptIssueType) String() string {
+	switch t {
+	case UnreachableCode:
+		return "unreachable code"
+	case InvalidJump:
+		return "invalid jump"
+	case StackImbalance:
+		return "stack imbalance"
+	default:
+		return fmt.Sprintf("unknown issue (%d)", byte(t))
+	}
+}
+
+// String implements fmt.Stringer interface.
+func (i ScriptIssue) String() string {
+	return fmt.Sprintf("%d: %s: %s", i.Offset, i.Type, i.Message)
+}
+
+// AnalyzeScript performs static checks of the script that can catch some
+// compiler or hand-written script bugs before it's deployed or executed. Script
+// is traversed starting from offset 0 and all entries given (contract methods
+// offsets should be passed here), every called function is analyzed separately,
+// evaluation stack depth is tracked symbolically (relative to the function
+// start) where possible. Unreachable instructions, invalid jumps and stack depth
+// mismatches are reported. This check is not a replacement for the proper
+// testing, it can't detect problems that depend on the data processed (stack
+// depth is considered to be unknown after PACK, SYSCALL and similar
+// instructions and in exception handlers). Missing entries lead to unreachable
+// code reports for methods not called from the script itself. An error is
+// returned if the script can't be parsed. Issues are sorted by offset.
+func AnalyzeScript(script []byte, entries ...int) ([]ScriptIssue, error) {
+	a := &analyzer{
+		script:  script,
+		instrs:  make(map[int]*instrInfo),
+		funcs:   make(map[int]*funcInfo),
+		visited: make(map[int]bool),
+	}
+	err := a.parse()
+	if err != nil {
+		return nil, err
+	}
+	if len(script) == 0 {
+		return nil, nil
+	}
+	for _, e := range append([]int{0}, entries...) {
+		if a.instrs[e] == nil {
+			a.report(InvalidJump, e, "entry point is not an instruction boundary")
+			continue
+		}
+		a.analyzeFunc(e)
+	}
+	for off := 0; off < len(script); off = a.instrs[off].next {
+		if a.visited[off] {
+			continue
+		}
+		var end = off
+		for a.instrs[end].next < len(script) && !a.visited[a.instrs[end].next] {
+			end = a.instrs[end].next
+		}
+		a.report(UnreachableCode, off, fmt.Sprintf("instructions %d-%d are not reachable", off, end))
+		off = end
+	}
+	slices.SortStableFunc(a.issues, func(x, y ScriptIssue) int {
+		return cmp.Compare(x.Offset, y.Offset)
+	})
+	return a.issues, nil
+}
+
+// parse splits the script into instructions and calculates jump targets.
+func (a *analyzer) parse() error {
+	ctx := NewContext(a.script)
+	for ctx.nextip < len(a.script) {
+		op, param, err := ctx.Next()
+		if err != nil {
+			return err
+		}
+		var (
+			instr   = &instrInfo{op: op, param: param, next: ctx.nextip}
+			targets [][]byte
+		)
+		switch op {
+		case opcode.JMP, opcode.JMPIF, opcode.JMPIFNOT, opcode.JMPEQ, opcode.JMPNE,
+			opcode.JMPGT, opcode.JMPGE, opcode.JMPLT, opcode.JMPLE,
+			opcode.CALL, opcode.ENDTRY, opcode.JMPL, opcode.JMPIFL,
+			opcode.JMPIFNOTL, opcode.JMPEQL, opcode.JMPNEL,
+			opcode.JMPGTL, opcode.JMPGEL, opcode.JMPLTL, opcode.JMPLEL,
+			opcode.ENDTRYL, opcode.CALLL, opcode.PUSHA:
+			targets = [][]byte{param}
+		case opcode.TRY, opcode.TRYL:
+			catchP, finallyP := getTryParams(op, param)
+			targets = [][]byte{catchP, finallyP}
+		}
+		for _, p := range targets {
+			off, rel, err := calcJumpOffset(ctx, p)
+			if err != nil {
+				a.report(InvalidJump, ctx.ip, err.Error())
+				off = -1
+			} else if rel == 0 && (op == opcode.TRY || op == opcode.TRYL) {
+				// No catch or finally block.
+				off = -1
+			} else if off == len(a.script) {
+				a.report(InvalidJump, ctx.ip, fmt.Sprintf("%s target is at the end of the script", op))
+				off = -1
+			}
+			instr.targets = append(instr.targets, off)
+		}
+		a.instrs[ctx.ip] = instr
+	}
+	// Check instruction boundaries now that all instructions are known.
+	for off := 0; off < len(a.script); off = a.instrs[off].next {
+		instr := a.instrs[off]
+		for i, t := range instr.targets {
+			if t >= 0 && a.instrs[t] == nil {
+				a.report(InvalidJump, off, fmt.Sprintf("%s target %d is not an instruction boundary", instr.op, t))
+				instr.targets[i] = -1
+			}
+		}
+	}
+	return nil
+}
+
+// report adds an issue to the list.
+func (a *analyzer) report(t ScriptIssueType, off int, msg string) {
+	a.issues = append(a.issues, ScriptIssue{Type: t, Offset: off, Message: msg})
+}
+
+// analyzeFunc analyzes the function starting at the given offset (if it's not
+// yet analyzed) and returns its information.
+func (a *analyzer) analyzeFunc(start int) *funcInfo {
+	if f, ok := a.funcs[start]; ok {
+		return f
+	}
+	var (
+		f      = new(funcInfo)
+		states = map[int]stackDepth{start: {known: true}}
+		queue  = []int{start}
+		nested []int
+	)
+	a.funcs[start] = f
+	for len(queue) > 0 {
+		var (
+			off   = queue[len(queue)-1]
+			state = states[off]
+			instr = a.instrs[off]
+		)
+		queue = queue[:len(queue)-1]
+		a.visited[off] = true
+
+		var next = a.applyEffect(instr, state, &nested)
+		for _, s := range a.successors(instr) {
+			var st = next
+			if s.handler {
+				st = stackDepth{}
+			}
+			old, ok := states[s.off]
+			switch {
+			case !ok:
+			case !old.known:
+				continue
+			case !st.known:
+			case old.depth != st.depth:
+				a.report(StackImbalance, s.off, fmt.Sprintf("instruction is reached with stack depths %d and %d", old.depth, st.depth))
+				st = stackDepth{}
+			default:
+				continue
+			}
+			states[s.off] = st
+			queue = append(queue, s.off)
+		}
+		if instr.op == opcode.RET {
+			if f.retSet && f.retDiff.known && next.known && f.retDiff.depth != next.depth {
+				a.report(StackImbalance, off, fmt.Sprintf("function at %d returns with stack depths %d and %d", start, f.retDiff.depth, next.depth))
+				next = stackDepth{}
+			}
+			if !f.retSet || f.retDiff.known {
+				f.retDiff = next
+			}
+			f.retSet = true
+		}
+	}
+	f.done = true
+	for _, n := range nested {
+		a.analyzeFunc(n)
+	}
+	return f
+}
+
+type successor struct {
+	off     int
+	handler bool
+}
+
+// successors returns instructions that can be executed after the given one.
+func (a *analyzer) successors(instr *instrInfo) []successor {
+	var res []successor
+	switch instr.op {
+	case opcode.JMP, opcode.JMPL, opcode.ENDTRY, opcode.ENDTRYL:
+		if instr.targets[0] >= 0 {
+			res = append(res, successor{off: instr.targets[0]})
+		}
+		return res
+	case opcode.RET, opcode.THROW, opcode.ABORT, opcode.ABORTMSG, opcode.ENDFINALLY:
+		return nil
+	case opcode.JMPIF, opcode.JMPIFL, opcode.JMPIFNOT, opcode.JMPIFNOTL,
+		opcode.JMPEQ, opcode.JMPEQL, opcode.JMPNE, opcode.JMPNEL,
+		opcode.JMPGT, opcode.JMPGTL, opcode.JMPGE, opcode.JMPGEL,
+		opcode.JMPLT, opcode.JMPLTL, opcode.JMPLE, opcode.JMPLEL:
+		if instr.targets[0] >= 0 {
+			res = append(res, successor{off: instr.targets[0]})
+		}
+	case opcode.TRY, opcode.TRYL:
+		// Exception handlers are entered with unknown stack depth.
+		for _, t := range instr.targets {
+			if t >= 0 {
+				res = append(res, successor{off: t, handler: true})
+			}
+		}
+	}
+	if instr.next < len(a.script) {
+		res = append(res, successor{off: instr.next})
+	}
+	return res
+}
+
+// applyEffect returns the stack depth after the instruction execution.
+// Functions called are analyzed if needed, PUSHA targets are remembered to be
+// analyzed later.
+func (a *analyzer) applyEffect(instr *instrInfo, s stackDepth, nested *[]int) stackDepth {
+	switch instr.op {
+	case opcode.CALL, opcode.CALLL:
+		if instr.targets[0] < 0 || !s.known {
+			return stackDepth{}
+		}
+		f := a.analyzeFunc(instr.targets[0])
+		if !f.done || !f.retSet || !f.retDiff.known {
+			// Recursive call or function that never returns.
+			return stackDepth{}
+		}
+		return stackDepth{depth: s.depth + f.retDiff.depth, known: true}
+	case opcode.PUSHA:
+		if instr.targets[0] >= 0 {
+			*nested = append(*nested, instr.targets[0])
+		}
+	}
+	pop, push, ok := stackEffect(instr.op, instr.param)
+	if !ok || !s.known {
+		return stackDepth{}
+	}
+	return stackDepth{depth: s.depth - pop + push, known: true}
+}
+
+// stackEffect returns the number of items popped and pushed by the instruction.
+// ok is false if it depends on the data processed. Some instructions (like
+// PICK) access deeper items, but only the net effect matters here.
+func stackEffect(op opcode.Opcode, param []byte) (pop int, push int, ok bool) {
+	switch {
+	case op <= opcode.PUSH16:
+		return 0, 1, true
+	case opcode.LDSFLD0 <= op && op <= opcode.LDSFLD,
+		opcode.LDLOC0 <= op && op <= opcode.LDLOC,
+		opcode.LDARG0 <= op && op <= opcode.LDARG:
+		return 0, 1, true
+	case opcode.STSFLD0 <= op && op <= opcode.STSFLD,
+		opcode.STLOC0 <= op && op <= opcode.STLOC,
+		opcode.STARG0 <= op && op <= opcode.STARG:
+		return 1, 0, true
+	}
+	switch op {
+	case opcode.NOP, opcode.JMP, opcode.JMPL, opcode.TRY, opcode.TRYL,
+		opcode.ENDTRY, opcode.ENDTRYL, opcode.ENDFINALLY, opcode.RET,
+		opcode.ABORT, opcode.INITSSLOT:
+		return 0, 0, true
+	case opcode.INITSLOT:
+		return int(param[1]), 0, true
+	case opcode.JMPIF, opcode.JMPIFL, opcode.JMPIFNOT, opcode.JMPIFNOTL,
+		opcode.ASSERT, opcode.THROW, opcode.ABORTMSG, opcode.DROP,
+		opcode.ROLL, opcode.REVERSEN, opcode.REVERSEITEMS, opcode.CLEARITEMS:
+		return 1, 0, true
+	case opcode.JMPEQ, opcode.JMPEQL, opcode.JMPNE, opcode.JMPNEL,
+		opcode.JMPGT, opcode.JMPGTL, opcode.JMPGE, opcode.JMPGEL,
+		opcode.JMPLT, opcode.JMPLTL, opcode.JMPLE, opcode.JMPLEL,
+		opcode.ASSERTMSG, opcode.APPEND, opcode.REMOVE, opcode.XDROP:
+		return 2, 0, true
+	case opcode.SETITEM:
+		return 3, 0, true
+	case opcode.MEMCPY:
+		return 5, 0, true
+	case opcode.DEPTH, opcode.NEWARRAY0, opcode.NEWSTRUCT0, opcode.NEWMAP:
+		return 0, 1, true
+	case opcode.PICK, opcode.NEWBUFFER, opcode.INVERT, opcode.SIGN, opcode.ABS,
+		opcode.NEGATE, opcode.INC, opcode.DEC, opcode.SQRT, opcode.NOT, opcode.NZ,
+		opcode.NEWARRAY, opcode.NEWARRAYT, opcode.NEWSTRUCT, opcode.SIZE,
+		opcode.KEYS, opcode.VALUES, opcode.POPITEM, opcode.ISNULL,
+		opcode.ISTYPE, opcode.CONVERT:
+		return 1, 1, true
+	case opcode.DUP:
+		return 1, 2, true
+	case opcode.NIP, opcode.CAT, opcode.LEFT, opcode.RIGHT, opcode.AND,
+		opcode.OR, opcode.XOR, opcode.EQUAL, opcode.NOTEQUAL, opcode.ADD,
+		opcode.SUB, opcode.MUL, opcode.DIV, opcode.MOD, opcode.POW,
+		opcode.SHL, opcode.SHR, opcode.BOOLAND, opcode.BOOLOR,
+		opcode.NUMEQUAL, opcode.NUMNOTEQUAL, opcode.LT, opcode.LE,
+		opcode.GT, opcode.GE, opcode.MIN, opcode.MAX, opcode.HASKEY,
+		opcode.PICKITEM:
+		return 2, 1, true
+	case opcode.SWAP:
+		return 2, 2, true
+	case opcode.OVER, opcode.TUCK:
+		return 2, 3, true
+	case opcode.SUBSTR, opcode.MODMUL, opcode.MODPOW, opcode.WITHIN:
+		return 3, 1, true
+	case opcode.ROT, opcode.REVERSE3:
+		return 3, 3, true
+	case opcode.REVERSE4:
+		return 4, 4, true
+	default:
+		// CALLA, CALLT, SYSCALL, CLEAR, PACK*, UNPACK.
+		return 0, 0, false
+	}
+}
@@ -0,0 +1,127 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func issueTypes(issues []ScriptIssue) []ScriptIssueType {
+	var res []ScriptIssueType
+	for _, i := range issues {
+		res = append(res, i.Type)
+	}
+	return res
+}
+
+func TestAnalyzeScript(t *testing.T) {
+	t.Run("good", func(t *testing.T) {
+		issues, err := AnalyzeScript([]byte{byte(opcode.PUSH1), byte(opcode.PUSH2), byte(opcode.ADD), byte(opcode.RET)})
+		require.NoError(t, err)
+		require.Empty(t, issues)
+	})
+	t.Run("empty", func(t *testing.T) {
+		issues, err := AnalyzeScript(nil)
+		require.NoError(t, err)
+		require.Empty(t, issues)
+	})
+	t.Run("bad opcode", func(t *testing.T) {
+		_, err := AnalyzeScript([]byte{0xff})
+		require.Error(t, err)
+	})
+	t.Run("unreachable", func(t *testing.T) {
+		script := []byte{byte(opcode.PUSH1), byte(opcode.RET), byte(opcode.PUSH2), byte(opcode.RET)}
+		issues, err := AnalyzeScript(script)
+		require.NoError(t, err)
+		require.Equal(t, []ScriptIssue{{Type: UnreachableCode, Offset: 2, Message: "instructions 2-3 are not reachable"}}, issues)
+
+		issues, err = AnalyzeScript(script, 2)
+		require.NoError(t, err)
+		require.Empty(t, issues)
+
+		issues, err = AnalyzeScript(script, 5)
+		require.NoError(t, err)
+		require.Equal(t, []ScriptIssueType{UnreachableCode, InvalidJump}, issueTypes(issues))
+	})
+	t.Run("jump to the middle of instruction", func(t *testing.T) {
+		script := []byte{byte(opcode.JMP), 3, byte(opcode.PUSHINT16), 1, 2, byte(opcode.RET)}
+		issues, err := AnalyzeScript(script)
+		require.NoError(t, err)
+		require.Equal(t, []ScriptIssueType{InvalidJump, UnreachableCode}, issueTypes(issues))
+		require.Equal(t, 0, issues[0].Offset)
+		require.Equal(t, 2, issues[1].Offset)
+	})
+	t.Run("jump out of script", func(t *testing.T) {
+		script := []byte{byte(opcode.JMP), 0x7f, byte(opcode.RET)}
+		issues, err := AnalyzeScript(script)
+		require.NoError(t, err)
+		require.Equal(t, []ScriptIssueType{InvalidJump, UnreachableCode}, issueTypes(issues))
+	})
+	t.Run("stack imbalance", func(t *testing.T) {
+		script := []byte{
+			byte(opcode.PUSHT),
+			byte(opcode.JMPIF), 4,
+			byte(opcode.PUSH1),
+			byte(opcode.NOP),
+			byte(opcode.RET),
+		}
+		issues, err := AnalyzeScript(script)
+		require.NoError(t, err)
+		require.Equal(t, []ScriptIssueType{StackImbalance}, issueTypes(issues))
+		require.Equal(t, 5, issues[0].Offset)
+	})
+	t.Run("unknown depth", func(t *testing.T) {
+		script := []byte{
+			byte(opcode.PUSHT),
+			byte(opcode.JMPIF), 7,
+			byte(opcode.SYSCALL), 1, 2, 3, 4,
+			byte(opcode.RET),
+		}
+		issues, err := AnalyzeScript(script)
+		require.NoError(t, err)
+		require.Empty(t, issues)
+	})
+	t.Run("call", func(t *testing.T) {
+		script := []byte{
+			byte(opcode.PUSHT),
+			byte(opcode.JMPIF), 4,
+			byte(opcode.CALL), 3,
+			byte(opcode.RET),
+			byte(opcode.PUSH1),
+			byte(opcode.RET),
+		}
+		// The function called returns an item, so RET at 5 is reached
+		// with different stack depths.
+		issues, err := AnalyzeScript(script)
+		require.NoError(t, err)
+		require.Equal(t, []ScriptIssueType{StackImbalance}, issueTypes(issues))
+		require.Equal(t, 5, issues[0].Offset)
+	})
+	t.Run("unbalanced returns", func(t *testing.T) {
+		script := []byte{
+			byte(opcode.CALL), 3,
+			byte(opcode.RET),
+			byte(opcode.PUSHT),
+			byte(opcode.JMPIF), 3,
+			byte(opcode.RET),
+			byte(opcode.PUSH1),
+			byte(opcode.RET),
+		}
+		issues, err := AnalyzeScript(script)
+		require.NoError(t, err)
+		require.Equal(t, []ScriptIssueType{StackImbalance}, issueTypes(issues))
+	})
+	t.Run("try", func(t *testing.T) {
+		script := []byte{
+			byte(opcode.TRY), 5, 0,
+			byte(opcode.ENDTRY), 5,
+			byte(opcode.DROP),
+			byte(opcode.ENDTRY), 2,
+			byte(opcode.RET),
+		}
+		issues, err := AnalyzeScript(script)
+		require.NoError(t, err)
+		require.Empty(t, issues)
+	})
+}
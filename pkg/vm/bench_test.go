@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/stretchr/testify/require"
 )
@@ -49,6 +51,79 @@ func BenchmarkScriptPushPop(t *testing.B) {
 	}
 }
 
+// nep17TransferScript returns a script making n transfers with the logic
+// of a typical NEP-17 contract (argument checks, balance updates and a
+// result), storage is emulated with a map in a static field.
+func nep17TransferScript(n int) []byte {
+	var (
+		w     = io.NewBufBinWriter()
+		from  = make([]byte, 20)
+		to    = make([]byte, 20)
+		jumps = make(map[int]string) // Offset of the jump operand -> label.
+		marks = make(map[string]int) // Label -> offset.
+	)
+	to[0] = 1
+	jmp := func(op opcode.Opcode, label string) {
+		jumps[w.Len()+1] = label
+		emit.Instruction(w.BinWriter, op, []byte{0})
+	}
+	mark := func(label string) { marks[label] = w.Len() }
+
+	// Main: initialize storage and make n transfers.
+	emit.Instruction(w.BinWriter, opcode.INITSSLOT, []byte{1})
+	emit.InitSlot(w.BinWriter, 1, 0)
+	emit.Opcodes(w.BinWriter, opcode.NEWMAP, opcode.STSFLD0, opcode.LDSFLD0)
+	emit.Bytes(w.BinWriter, from)
+	emit.Int(w.BinWriter, int64(n))
+	emit.Opcodes(w.BinWriter, opcode.SETITEM)
+	emit.Int(w.BinWriter, int64(n))
+	emit.Opcodes(w.BinWriter, opcode.STLOC0)
+	mark("loop")
+	emit.Opcodes(w.BinWriter, opcode.PUSHNULL, opcode.PUSH1)
+	emit.Bytes(w.BinWriter, to)
+	emit.Bytes(w.BinWriter, from)
+	jmp(opcode.CALL, "transfer")
+	emit.Opcodes(w.BinWriter, opcode.ASSERT, opcode.LDLOC0, opcode.DEC, opcode.DUP, opcode.STLOC0, opcode.PUSH0, opcode.GT)
+	jmp(opcode.JMPIF, "loop")
+	emit.Opcodes(w.BinWriter, opcode.RET)
+
+	// transfer(from, to, amount, data) bool.
+	mark("transfer")
+	emit.InitSlot(w.BinWriter, 2, 4)
+	emit.Opcodes(w.BinWriter, opcode.LDARG0, opcode.SIZE, opcode.PUSH16, opcode.PUSH4, opcode.ADD, opcode.NUMEQUAL, opcode.ASSERT,
+		opcode.LDARG1, opcode.SIZE, opcode.PUSH16, opcode.PUSH4, opcode.ADD, opcode.NUMEQUAL, opcode.ASSERT,
+		opcode.LDARG2, opcode.PUSH0, opcode.GE, opcode.ASSERT,
+		opcode.LDSFLD0, opcode.LDARG0, opcode.PICKITEM, opcode.STLOC0,
+		opcode.LDLOC0, opcode.LDARG2, opcode.LT)
+	jmp(opcode.JMPIFNOT, "enough")
+	emit.Opcodes(w.BinWriter, opcode.PUSHF, opcode.RET)
+	mark("enough")
+	emit.Opcodes(w.BinWriter, opcode.LDSFLD0, opcode.LDARG0, opcode.LDLOC0, opcode.LDARG2, opcode.SUB, opcode.SETITEM,
+		opcode.LDSFLD0, opcode.LDARG1, opcode.HASKEY)
+	jmp(opcode.JMPIF, "hasTo")
+	emit.Opcodes(w.BinWriter, opcode.PUSH0)
+	jmp(opcode.JMP, "toLoaded")
+	mark("hasTo")
+	emit.Opcodes(w.BinWriter, opcode.LDSFLD0, opcode.LDARG1, opcode.PICKITEM)
+	mark("toLoaded")
+	emit.Opcodes(w.BinWriter, opcode.STLOC1, opcode.LDSFLD0, opcode.LDARG1, opcode.LDLOC1, opcode.LDARG2, opcode.ADD, opcode.SETITEM,
+		opcode.PUSHT, opcode.RET)
+
+	script := w.Bytes()
+	for off, label := range jumps {
+		script[off] = byte(int8(marks[label] - (off - 1)))
+	}
+	return script
+}
+
+func BenchmarkScriptNEP17Transfer(t *testing.B) {
+	for _, n := range []int{1, 16, 128} {
+		t.Run(strconv.Itoa(n), func(t *testing.B) {
+			benchScript(t, nep17TransferScript(n))
+		})
+	}
+}
+
 func BenchmarkIsSignatureContract(t *testing.B) {
 	b64script := "DCED2eixa9myLTNF1tTN4xvhw+HRYVMuPQzOy5Xs4utYM25BVuezJw=="
 	script, err := base64.StdEncoding.DecodeString(b64script)
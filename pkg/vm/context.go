@@ -53,6 +53,9 @@ type scriptContext struct {
 	// onUnload is a callback that should be called after current context unloading
 	// if no exception occurs.
 	onUnload ContextUnloadCallback
+	// feeDiscount is the percentage of GAS that is not charged for execution
+	// of this context and contexts loaded from it.
+	feeDiscount uint8
 }
 
 // Context represents the current execution context of the VM.
@@ -126,6 +129,33 @@ func (c *Context) Jump(pos int) {
 	c.nextip = pos
 }
 
+// operandSizes contains the sizes of fixed-size instruction operands indexed
+// by opcode (PUSHDATA* operands have variable size, they're decoded by Next).
+var operandSizes = func() [256]byte {
+	var sizes [256]byte
+	for op := opcode.PUSHINT8; op <= opcode.PUSHINT256; op++ {
+		sizes[op] = 1 << op
+	}
+	for _, op := range []opcode.Opcode{opcode.JMP, opcode.JMPIF, opcode.JMPIFNOT, opcode.JMPEQ, opcode.JMPNE,
+		opcode.JMPGT, opcode.JMPGE, opcode.JMPLT, opcode.JMPLE,
+		opcode.CALL, opcode.ISTYPE, opcode.CONVERT, opcode.NEWARRAYT,
+		opcode.ENDTRY,
+		opcode.INITSSLOT, opcode.LDSFLD, opcode.STSFLD, opcode.LDARG, opcode.STARG, opcode.LDLOC, opcode.STLOC} {
+		sizes[op] = 1
+	}
+	for _, op := range []opcode.Opcode{opcode.INITSLOT, opcode.TRY, opcode.CALLT} {
+		sizes[op] = 2
+	}
+	for _, op := range []opcode.Opcode{opcode.JMPL, opcode.JMPIFL, opcode.JMPIFNOTL, opcode.JMPEQL, opcode.JMPNEL,
+		opcode.JMPGTL, opcode.JMPGEL, opcode.JMPLTL, opcode.JMPLEL,
+		opcode.ENDTRYL,
+		opcode.CALLL, opcode.SYSCALL, opcode.PUSHA} {
+		sizes[op] = 4
+	}
+	sizes[opcode.TRYL] = 8
+	return sizes
+}()
+
 // Next returns the next instruction to execute with its parameter if any.
 // The parameter is not copied and shouldn't be written to. After its invocation,
 // the instruction pointer points to the instruction returned.
@@ -172,25 +202,9 @@ func (c *Context) Next() (opcode.Opcode, []byte, error) {
 			numtoread = int(n)
 			c.nextip += 4
 		}
-	case opcode.JMP, opcode.JMPIF, opcode.JMPIFNOT, opcode.JMPEQ, opcode.JMPNE,
-		opcode.JMPGT, opcode.JMPGE, opcode.JMPLT, opcode.JMPLE,
-		opcode.CALL, opcode.ISTYPE, opcode.CONVERT, opcode.NEWARRAYT,
-		opcode.ENDTRY,
-		opcode.INITSSLOT, opcode.LDSFLD, opcode.STSFLD, opcode.LDARG, opcode.STARG, opcode.LDLOC, opcode.STLOC:
-		numtoread = 1
-	case opcode.INITSLOT, opcode.TRY, opcode.CALLT:
-		numtoread = 2
-	case opcode.JMPL, opcode.JMPIFL, opcode.JMPIFNOTL, opcode.JMPEQL, opcode.JMPNEL,
-		opcode.JMPGTL, opcode.JMPGEL, opcode.JMPLTL, opcode.JMPLEL,
-		opcode.ENDTRYL,
-		opcode.CALLL, opcode.SYSCALL, opcode.PUSHA:
-		numtoread = 4
-	case opcode.TRYL:
-		numtoread = 8
 	default:
-		if instr <= opcode.PUSHINT256 {
-			numtoread = 1 << instr
-		} else {
+		numtoread = int(operandSizes[instr])
+		if numtoread == 0 {
 			// No parameters, can just return.
 			return instr, nil, nil
 		}
@@ -258,6 +272,19 @@ func (c *Context) GetManifest() *manifest.Manifest {
 	return c.sc.Manifest
 }
 
+// FeeDiscount returns the percentage of GAS that is not charged for execution
+// of this context.
+func (c *Context) FeeDiscount() uint8 {
+	return c.sc.feeDiscount
+}
+
+// SetFeeDiscount sets the percentage of GAS that is not charged for execution
+// of this context and all contexts loaded from it (unless they have their own
+// discount set), it can't exceed MaxFeeDiscount.
+func (c *Context) SetFeeDiscount(percent uint8) {
+	c.sc.feeDiscount = min(percent, MaxFeeDiscount)
+}
+
 // NumOfReturnVals returns the number of return values expected from this context.
 func (c *Context) NumOfReturnVals() int {
 	return c.retCount
@@ -314,6 +341,15 @@ func (v *VM) getContextScriptHash(n int) util.Uint160 {
 	return v.istack[len(v.istack)-1-n].ScriptHash()
 }
 
+// discountGas returns the amount of GAS to be charged for the given price
+// in this context.
+func (c *Context) discountGas(gas int64) int64 {
+	if c.sc.feeDiscount == 0 {
+		return gas
+	}
+	return gas - gas*int64(c.sc.feeDiscount)/100
+}
+
 // IsCalledByEntry checks parent script contexts and return true if the current one
 // is an entry script (the first loaded into the VM) or one called by it.
 func (c *Context) IsCalledByEntry() bool {
@@ -0,0 +1,192 @@
+package vm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"slices"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+var contractCallInteropID = interopnames.ToID([]byte(interopnames.SystemContractCall))
+
+// ContractCall is a contract method invocation found by FindContractCalls.
+type ContractCall struct {
+	// Offset is the offset of SYSCALL or CALLT instruction.
+	Offset int
+	// Hash is the called contract hash, nil if it can't be determined
+	// statically.
+	Hash *util.Uint160
+	// Method is the called method name, empty if it can't be determined
+	// statically.
+	Method string
+}
+
+// FindContractCalls returns all contract invocations done by the script via
+// System.Contract.Call syscall or CALLT instruction (method tokens are used to
+// resolve the latter). Arguments of System.Contract.Call are traced through
+// simple stack manipulations and static fields that are set once to a
+// constant, so contract hash and method are only known for calls where they're
+// constant, other calls have nil Hash and/or empty Method. An error is returned
+// if the script can't be parsed or if it refers to a missing method token.
+// Calls are sorted by offset.
+func FindContractCalls(script []byte, tokens []nef.MethodToken) ([]ContractCall, error) {
+	a := &analyzer{
+		script: script,
+		instrs: make(map[int]*instrInfo),
+	}
+	err := a.parse()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		calls   []ContractCall
+		targets = make(map[int]bool)
+		statics = make(map[int][]byte)
+		stores  = make(map[int]int)
+		prev    *instrInfo
+	)
+	for off := 0; off < len(script); off = a.instrs[off].next {
+		instr := a.instrs[off]
+		for _, t := range instr.targets {
+			targets[t] = true
+		}
+	}
+	// Static fields are usually initialized with constants in _initialize.
+	for off := 0; off < len(script); off = a.instrs[off].next {
+		instr := a.instrs[off]
+		if n, ok := slotIndex(instr, opcode.STSFLD0, opcode.STSFLD); ok {
+			stores[n]++
+			if prev != nil && isPushData(prev.op) && !targets[off] {
+				statics[n] = prev.param
+			}
+		}
+		prev = instr
+	}
+	for n, cnt := range stores {
+		if cnt != 1 {
+			delete(statics, n)
+		}
+	}
+
+	var stack [][]byte // Known values, nil is used for unknown ones.
+	pop := func() []byte {
+		if len(stack) == 0 {
+			return nil
+		}
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		return v
+	}
+	for off := 0; off < len(script); off = a.instrs[off].next {
+		instr := a.instrs[off]
+		if targets[off] {
+			stack = stack[:0]
+		}
+		if n, ok := slotIndex(instr, opcode.LDSFLD0, opcode.LDSFLD); ok {
+			stack = append(stack, statics[n])
+			continue
+		}
+		switch op := instr.op; {
+		case isPushData(op):
+			stack = append(stack, instr.param)
+		case op == opcode.DUP:
+			stack = grow(stack, 1)
+			stack = append(stack, stack[len(stack)-1])
+		case op == opcode.OVER:
+			stack = grow(stack, 2)
+			stack = append(stack, stack[len(stack)-2])
+		case op == opcode.TUCK:
+			stack = grow(stack, 2)
+			stack = slices.Insert(stack, len(stack)-2, stack[len(stack)-1])
+		case op == opcode.NIP:
+			stack = grow(stack, 2)
+			stack = slices.Delete(stack, len(stack)-2, len(stack)-1)
+		case op == opcode.SWAP:
+			stack = grow(stack, 2)
+			slices.Reverse(stack[len(stack)-2:])
+		case op == opcode.REVERSE3:
+			stack = grow(stack, 3)
+			slices.Reverse(stack[len(stack)-3:])
+		case op == opcode.REVERSE4:
+			stack = grow(stack, 4)
+			slices.Reverse(stack[len(stack)-4:])
+		case op == opcode.ROT:
+			stack = grow(stack, 3)
+			stack = append(stack[:len(stack)-3], stack[len(stack)-2], stack[len(stack)-1], stack[len(stack)-3])
+		case op == opcode.SYSCALL && GetInteropID(instr.param) == contractCallInteropID:
+			var c = ContractCall{Offset: off}
+			if h := pop(); len(h) == util.Uint160Size {
+				u, _ := util.Uint160DecodeBytesBE(h)
+				c.Hash = &u
+			}
+			if m := pop(); m != nil {
+				c.Method = string(m)
+			}
+			pop() // Call flags.
+			pop() // Arguments.
+			calls = append(calls, c)
+			stack = append(stack, nil)
+		case op == opcode.CALLT:
+			n := int(binary.LittleEndian.Uint16(instr.param))
+			if n >= len(tokens) {
+				return nil, fmt.Errorf("CALLT at %d refers to missing method token %d", off, n)
+			}
+			var (
+				t = tokens[n]
+				h = t.Hash
+			)
+			calls = append(calls, ContractCall{Offset: off, Hash: &h, Method: t.Method})
+			for range t.ParamCount {
+				pop()
+			}
+			if t.HasReturn {
+				stack = append(stack, nil)
+			}
+		case op == opcode.ROLL || op == opcode.XDROP || op == opcode.REVERSEN:
+			stack = stack[:0]
+		default:
+			npop, npush, ok := stackEffect(op, instr.param)
+			if !ok || len(instr.targets) != 0 {
+				stack = stack[:0]
+				continue
+			}
+			for range npop {
+				pop()
+			}
+			for range npush {
+				stack = append(stack, nil)
+			}
+		}
+	}
+	return calls, nil
+}
+
+// grow adds unknown items to the bottom of the stack to have at least n of them.
+func grow(stack [][]byte, n int) [][]byte {
+	if len(stack) >= n {
+		return stack
+	}
+	return append(make([][]byte, n-len(stack)), stack...)
+}
+
+// isPushData checks whether the opcode is one of PUSHDATA* instructions.
+func isPushData(op opcode.Opcode) bool {
+	return op == opcode.PUSHDATA1 || op == opcode.PUSHDATA2 || op == opcode.PUSHDATA4
+}
+
+// slotIndex returns the slot index for the instruction from the [first, last]
+// opcode range, where last is the instruction with an explicit parameter.
+func slotIndex(instr *instrInfo, first, last opcode.Opcode) (int, bool) {
+	switch {
+	case instr.op == last:
+		return int(instr.param[0]), true
+	case first <= instr.op && instr.op < last:
+		return int(instr.op - first), true
+	default:
+		return 0, false
+	}
+}
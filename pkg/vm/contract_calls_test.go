@@ -0,0 +1,122 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindContractCalls(t *testing.T) {
+	h1 := util.Uint160{1, 2, 3}
+	h2 := util.Uint160{4, 5, 6}
+
+	t.Run("bad script", func(t *testing.T) {
+		_, err := FindContractCalls([]byte{0xff}, nil)
+		require.Error(t, err)
+	})
+	t.Run("emitted call", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.AppCall(w.BinWriter, h1, "transfer", callflag.All, 1, 2)
+		emit.Opcodes(w.BinWriter, opcode.DROP)
+		emit.AppCallNoArgs(w.BinWriter, h2, "balanceOf", callflag.ReadOnly)
+		require.NoError(t, w.Err)
+
+		calls, err := FindContractCalls(w.Bytes(), nil)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(calls))
+		require.Equal(t, h1, *calls[0].Hash)
+		require.Equal(t, "transfer", calls[0].Method)
+		require.Equal(t, h2, *calls[1].Hash)
+		require.Equal(t, "balanceOf", calls[1].Method)
+	})
+	t.Run("static field and reverse", func(t *testing.T) {
+		// Mimics the code generated by the compiler for a global hash.
+		w := io.NewBufBinWriter()
+		emit.Instruction(w.BinWriter, opcode.INITSSLOT, []byte{1})
+		emit.Bytes(w.BinWriter, h1.BytesBE())
+		emit.Opcodes(w.BinWriter, opcode.STSFLD0, opcode.RET)
+		emit.Instruction(w.BinWriter, opcode.INITSLOT, []byte{1, 0})
+		emit.Opcodes(w.BinWriter, opcode.PUSH0, opcode.PACK, opcode.STLOC0, opcode.LDSFLD0)
+		emit.String(w.BinWriter, "update")
+		emit.Opcodes(w.BinWriter, opcode.PUSH15, opcode.LDLOC0, opcode.REVERSE4)
+		emit.Syscall(w.BinWriter, interopnames.SystemContractCall)
+		require.NoError(t, w.Err)
+
+		calls, err := FindContractCalls(w.Bytes(), nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(calls))
+		require.Equal(t, h1, *calls[0].Hash)
+		require.Equal(t, "update", calls[0].Method)
+	})
+	t.Run("stack manipulations", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.Bytes(w.BinWriter, h2.BytesBE())
+		emit.String(w.BinWriter, "update")
+		emit.Opcodes(w.BinWriter, opcode.SWAP, opcode.PUSH15, opcode.SWAP, opcode.NEWARRAY0,
+			opcode.REVERSE4, opcode.ROT, opcode.DUP, opcode.NIP)
+		emit.Syscall(w.BinWriter, interopnames.SystemContractCall)
+		require.NoError(t, w.Err)
+
+		calls, err := FindContractCalls(w.Bytes(), nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(calls))
+		require.Equal(t, h2, *calls[0].Hash)
+		require.Equal(t, "update", calls[0].Method)
+	})
+	t.Run("dynamic", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.Instruction(w.BinWriter, opcode.INITSLOT, []byte{0, 2})
+		emit.Opcodes(w.BinWriter, opcode.NEWARRAY0, opcode.PUSH15)
+		emit.String(w.BinWriter, "update")
+		emit.Opcodes(w.BinWriter, opcode.LDARG0)
+		emit.Syscall(w.BinWriter, interopnames.SystemContractCall)
+		emit.Opcodes(w.BinWriter, opcode.NEWARRAY0, opcode.PUSH15, opcode.LDARG1)
+		emit.Bytes(w.BinWriter, h2.BytesBE())
+		emit.Syscall(w.BinWriter, interopnames.SystemContractCall)
+		require.NoError(t, w.Err)
+
+		calls, err := FindContractCalls(w.Bytes(), nil)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(calls))
+		require.Nil(t, calls[0].Hash)
+		require.Equal(t, "update", calls[0].Method)
+		require.Equal(t, h2, *calls[1].Hash)
+		require.Equal(t, "", calls[1].Method)
+	})
+	t.Run("jump target", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.Opcodes(w.BinWriter, opcode.NEWARRAY0, opcode.PUSH15)
+		emit.String(w.BinWriter, "update")
+		emit.Instruction(w.BinWriter, opcode.JMP, []byte{2})
+		emit.Bytes(w.BinWriter, h1.BytesBE())
+		emit.Syscall(w.BinWriter, interopnames.SystemContractCall)
+		require.NoError(t, w.Err)
+
+		// Hash is known, but the other values are lost at the JMP target.
+		calls, err := FindContractCalls(w.Bytes(), nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(calls))
+		require.Equal(t, h1, *calls[0].Hash)
+		require.Equal(t, "", calls[0].Method)
+	})
+	t.Run("CALLT", func(t *testing.T) {
+		tokens := []nef.MethodToken{{Hash: h1, Method: "symbol", HasReturn: true}, {Hash: h2, Method: "transfer", ParamCount: 4}}
+		script := []byte{byte(opcode.CALLT), 1, 0, byte(opcode.CALLT), 0, 0, byte(opcode.RET)}
+		calls, err := FindContractCalls(script, tokens)
+		require.NoError(t, err)
+		require.Equal(t, []ContractCall{
+			{Offset: 0, Hash: &h2, Method: "transfer"},
+			{Offset: 3, Hash: &h1, Method: "symbol"},
+		}, calls)
+
+		_, err = FindContractCalls(script, tokens[:1])
+		require.Error(t, err)
+	})
+}
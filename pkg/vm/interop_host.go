@@ -0,0 +1,106 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+)
+
+// Interop is a custom syscall that can be registered in InteropHost.
+type Interop struct {
+	// Name is the syscall name, its ID is calculated the same way as for
+	// standard syscalls (see emit.Syscall).
+	Name string
+	// Func is the syscall handler, it gets its parameters from the evaluation
+	// stack and pushes results there.
+	Func func(v *VM) error
+	// Price is the amount of GAS (in fractions) consumed by the syscall
+	// invocation, it's checked against VM GasLimit (which is zero by default,
+	// so it should be set to -1 for unlimited execution).
+	Price int64
+	// RequiredFlags is a set of flags that must be set for the calling
+	// context, no flags are required by default.
+	RequiredFlags callflag.CallFlag
+}
+
+// InteropHost is a set of custom syscalls for VM used without the full node
+// (simulation tools, research projects, etc.), it allows to extend the set of
+// syscalls available to scripts without node's interop context. Its
+// SyscallHandler method can be used as VM SyscallHandler. InteropHost is not
+// safe for concurrent modification, all syscalls are expected to be
+// registered before VM is started. Zero value is a valid empty host.
+type InteropHost struct {
+	// Fallback handles syscalls that are not registered in the host (it can be
+	// a syscall handler of node's interop context, for example). If it's nil,
+	// unknown syscalls fail.
+	Fallback SyscallHandler
+
+	interops map[uint32]Interop
+}
+
+// ErrSyscallNotFound is returned by InteropHost for unknown syscalls if no
+// Fallback is set.
+var ErrSyscallNotFound = errors.New("syscall not found")
+
+// NewInteropHost returns a new InteropHost with the given syscalls registered.
+func NewInteropHost(interops ...Interop) (*InteropHost, error) {
+	h := new(InteropHost)
+	for _, i := range interops {
+		if err := h.Register(i); err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// Register adds the given syscall to the host. It returns an error if the
+// syscall is invalid or its ID (calculated from the name) is already taken.
+func (h *InteropHost) Register(i Interop) error {
+	if len(i.Name) == 0 {
+		return errors.New("empty syscall name")
+	}
+	if i.Func == nil {
+		return fmt.Errorf("nil handler for %s syscall", i.Name)
+	}
+	if i.Price < 0 {
+		return fmt.Errorf("negative price for %s syscall", i.Name)
+	}
+	if i.RequiredFlags&^callflag.All != 0 {
+		return fmt.Errorf("invalid call flags for %s syscall", i.Name)
+	}
+	id := interopnames.ToID([]byte(i.Name))
+	if old, ok := h.interops[id]; ok {
+		return fmt.Errorf("%s syscall ID conflicts with %s", i.Name, old.Name)
+	}
+	if h.interops == nil {
+		h.interops = make(map[uint32]Interop)
+	}
+	h.interops[id] = i
+	return nil
+}
+
+// SyscallHandler implements SyscallHandler, it checks call flags of the
+// current context and consumes syscall price before invoking it.
+func (h *InteropHost) SyscallHandler(v *VM, id uint32) error {
+	i, ok := h.interops[id]
+	if !ok {
+		if h.Fallback != nil {
+			return h.Fallback(v, id)
+		}
+		return ErrSyscallNotFound
+	}
+	cf := v.Context().GetCallFlags()
+	if !cf.Has(i.RequiredFlags) {
+		return fmt.Errorf("%s: missing call flags: %05b vs %05b", i.Name, cf, i.RequiredFlags)
+	}
+	if !v.AddGas(i.Price) {
+		return fmt.Errorf("%s: insufficient amount of gas", i.Name)
+	}
+	err := i.Func(v)
+	if err != nil {
+		return fmt.Errorf("%s: %w", i.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package vm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInteropHost_Register(t *testing.T) {
+	f := func(*VM) error { return nil }
+
+	_, err := NewInteropHost(Interop{Name: "", Func: f})
+	require.Error(t, err)
+	_, err = NewInteropHost(Interop{Name: "Custom.Nil"})
+	require.Error(t, err)
+	_, err = NewInteropHost(Interop{Name: "Custom.Price", Func: f, Price: -1})
+	require.Error(t, err)
+	_, err = NewInteropHost(Interop{Name: "Custom.Flags", Func: f, RequiredFlags: 0x80})
+	require.Error(t, err)
+	_, err = NewInteropHost(Interop{Name: "Custom.Dup", Func: f}, Interop{Name: "Custom.Dup", Func: f})
+	require.ErrorContains(t, err, "conflicts")
+
+	var h InteropHost
+	require.NoError(t, h.Register(Interop{Name: "Custom.Zero", Func: f}))
+}
+
+func TestInteropHost_SyscallHandler(t *testing.T) {
+	h, err := NewInteropHost(
+		Interop{
+			Name: "Custom.Double",
+			Func: func(v *VM) error {
+				n := v.Estack().Pop().BigInt().Int64()
+				v.Estack().PushVal(n * 2)
+				return nil
+			},
+			Price: 100,
+		},
+		Interop{
+			Name:          "Custom.Write",
+			Func:          func(*VM) error { return nil },
+			RequiredFlags: callflag.WriteStates,
+		},
+		Interop{
+			Name: "Custom.Fail",
+			Func: func(*VM) error { return errors.New("oops") },
+		},
+	)
+	require.NoError(t, err)
+
+	newVM := func(t *testing.T, f callflag.CallFlag, syscalls ...string) *VM {
+		w := io.NewBufBinWriter()
+		emit.Int(w.BinWriter, 21)
+		for _, s := range syscalls {
+			emit.Syscall(w.BinWriter, s)
+		}
+		require.NoError(t, w.Err)
+
+		v := New()
+		v.GasLimit = -1
+		v.SyscallHandler = h.SyscallHandler
+		v.LoadWithFlags(w.Bytes(), f)
+		return v
+	}
+
+	t.Run("good", func(t *testing.T) {
+		v := newVM(t, callflag.All, "Custom.Double", "Custom.Write")
+		require.NoError(t, v.Run())
+		require.Equal(t, vmstate.Halt, v.State())
+		require.Equal(t, int64(42), v.Estack().Pop().BigInt().Int64())
+		require.Equal(t, int64(100), v.GasConsumed())
+	})
+	t.Run("missing flags", func(t *testing.T) {
+		v := newVM(t, callflag.ReadOnly, "Custom.Write")
+		require.ErrorContains(t, v.Run(), "missing call flags")
+	})
+	t.Run("gas limit", func(t *testing.T) {
+		v := newVM(t, callflag.All, "Custom.Double")
+		v.GasLimit = 99
+		require.ErrorContains(t, v.Run(), "insufficient amount of gas")
+	})
+	t.Run("handler error", func(t *testing.T) {
+		v := newVM(t, callflag.All, "Custom.Fail")
+		require.ErrorContains(t, v.Run(), "Custom.Fail: oops")
+	})
+	t.Run("unknown", func(t *testing.T) {
+		v := newVM(t, callflag.All, "Custom.Unknown")
+		require.ErrorContains(t, v.Run(), ErrSyscallNotFound.Error())
+	})
+	t.Run("fallback", func(t *testing.T) {
+		h.Fallback = func(v *VM, id uint32) error {
+			if id != interopnames.ToID([]byte("Custom.Unknown")) {
+				return ErrSyscallNotFound
+			}
+			v.Estack().PushVal(1)
+			return nil
+		}
+		t.Cleanup(func() { h.Fallback = nil })
+
+		v := newVM(t, callflag.All, "Custom.Unknown", "Custom.Double")
+		require.NoError(t, v.Run())
+		require.Equal(t, int64(2), v.Estack().Pop().BigInt().Int64())
+	})
+}
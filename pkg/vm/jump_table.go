@@ -0,0 +1,220 @@
+package vm
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// opHandler executes a single instruction with the given (already decoded)
+// parameter. Errors are reported via panics recovered in execute.
+type opHandler func(v *VM, ctx *Context, op opcode.Opcode, parameter []byte)
+
+// opHandlers is the instruction dispatch table, it's indexed by opcode and
+// contains nil for invalid opcodes.
+var opHandlers [256]opHandler
+
+func init() {
+	opHandlers = [256]opHandler{
+		opcode.PUSHINT8:   (*VM).opPUSHINT,
+		opcode.PUSHINT16:  (*VM).opPUSHINT,
+		opcode.PUSHINT32:  (*VM).opPUSHINT,
+		opcode.PUSHINT64:  (*VM).opPUSHINT,
+		opcode.PUSHINT128: (*VM).opPUSHBIGINT,
+		opcode.PUSHINT256: (*VM).opPUSHBIGINT,
+		opcode.PUSHM1:     (*VM).opPUSH,
+		opcode.PUSH0:      (*VM).opPUSH,
+		opcode.PUSH1:      (*VM).opPUSH,
+		opcode.PUSH2:      (*VM).opPUSH,
+		opcode.PUSH3:      (*VM).opPUSH,
+		opcode.PUSH4:      (*VM).opPUSH,
+		opcode.PUSH5:      (*VM).opPUSH,
+		opcode.PUSH6:      (*VM).opPUSH,
+		opcode.PUSH7:      (*VM).opPUSH,
+		opcode.PUSH8:      (*VM).opPUSH,
+		opcode.PUSH9:      (*VM).opPUSH,
+		opcode.PUSH10:     (*VM).opPUSH,
+		opcode.PUSH11:     (*VM).opPUSH,
+		opcode.PUSH12:     (*VM).opPUSH,
+		opcode.PUSH13:     (*VM).opPUSH,
+		opcode.PUSH14:     (*VM).opPUSH,
+		opcode.PUSH15:     (*VM).opPUSH,
+		opcode.PUSH16:     (*VM).opPUSH,
+		opcode.PUSHDATA1:  (*VM).opPUSHDATA,
+		opcode.PUSHDATA2:  (*VM).opPUSHDATA,
+		opcode.PUSHDATA4:  (*VM).opPUSHDATA,
+		opcode.PUSHT:      (*VM).opPUSHBOOL,
+		opcode.PUSHF:      (*VM).opPUSHBOOL,
+		opcode.PUSHA:      (*VM).opPUSHA,
+		opcode.PUSHNULL:   (*VM).opPUSHNULL,
+		opcode.ISNULL:     (*VM).opISNULL,
+		opcode.ISTYPE:     (*VM).opISTYPE,
+		opcode.CONVERT:    (*VM).opCONVERT,
+		opcode.INITSSLOT:  (*VM).opINITSSLOT,
+		opcode.INITSLOT:   (*VM).opINITSLOT,
+		opcode.LDSFLD0:    (*VM).opLDSFLDn,
+		opcode.LDSFLD1:    (*VM).opLDSFLDn,
+		opcode.LDSFLD2:    (*VM).opLDSFLDn,
+		opcode.LDSFLD3:    (*VM).opLDSFLDn,
+		opcode.LDSFLD4:    (*VM).opLDSFLDn,
+		opcode.LDSFLD5:    (*VM).opLDSFLDn,
+		opcode.LDSFLD6:    (*VM).opLDSFLDn,
+		opcode.LDSFLD:     (*VM).opLDSFLD,
+		opcode.STSFLD0:    (*VM).opSTSFLDn,
+		opcode.STSFLD1:    (*VM).opSTSFLDn,
+		opcode.STSFLD2:    (*VM).opSTSFLDn,
+		opcode.STSFLD3:    (*VM).opSTSFLDn,
+		opcode.STSFLD4:    (*VM).opSTSFLDn,
+		opcode.STSFLD5:    (*VM).opSTSFLDn,
+		opcode.STSFLD6:    (*VM).opSTSFLDn,
+		opcode.STSFLD:     (*VM).opSTSFLD,
+		opcode.LDLOC0:     (*VM).opLDLOCn,
+		opcode.LDLOC1:     (*VM).opLDLOCn,
+		opcode.LDLOC2:     (*VM).opLDLOCn,
+		opcode.LDLOC3:     (*VM).opLDLOCn,
+		opcode.LDLOC4:     (*VM).opLDLOCn,
+		opcode.LDLOC5:     (*VM).opLDLOCn,
+		opcode.LDLOC6:     (*VM).opLDLOCn,
+		opcode.LDLOC:      (*VM).opLDLOC,
+		opcode.STLOC0:     (*VM).opSTLOCn,
+		opcode.STLOC1:     (*VM).opSTLOCn,
+		opcode.STLOC2:     (*VM).opSTLOCn,
+		opcode.STLOC3:     (*VM).opSTLOCn,
+		opcode.STLOC4:     (*VM).opSTLOCn,
+		opcode.STLOC5:     (*VM).opSTLOCn,
+		opcode.STLOC6:     (*VM).opSTLOCn,
+		opcode.STLOC:      (*VM).opSTLOC,
+		opcode.LDARG0:     (*VM).opLDARGn,
+		opcode.LDARG1:     (*VM).opLDARGn,
+		opcode.LDARG2:     (*VM).opLDARGn,
+		opcode.LDARG3:     (*VM).opLDARGn,
+		opcode.LDARG4:     (*VM).opLDARGn,
+		opcode.LDARG5:     (*VM).opLDARGn,
+		opcode.LDARG6:     (*VM).opLDARGn,
+		opcode.LDARG:      (*VM).opLDARG,
+		opcode.STARG0:     (*VM).opSTARGn,
+		opcode.STARG1:     (*VM).opSTARGn,
+		opcode.STARG2:     (*VM).opSTARGn,
+		opcode.STARG3:     (*VM).opSTARGn,
+		opcode.STARG4:     (*VM).opSTARGn,
+		opcode.STARG5:     (*VM).opSTARGn,
+		opcode.STARG6:     (*VM).opSTARGn,
+		opcode.STARG:      (*VM).opSTARG,
+		opcode.NEWBUFFER:  (*VM).opNEWBUFFER,
+		opcode.MEMCPY:     (*VM).opMEMCPY,
+		opcode.CAT:        (*VM).opCAT,
+		opcode.SUBSTR:     (*VM).opSUBSTR,
+		opcode.LEFT:       (*VM).opLEFT,
+		opcode.RIGHT:      (*VM).opRIGHT,
+		opcode.DEPTH:      (*VM).opDEPTH,
+		opcode.DROP:       (*VM).opDROP,
+		opcode.NIP:        (*VM).opNIP,
+		opcode.XDROP:      (*VM).opXDROP,
+		opcode.CLEAR:      (*VM).opCLEAR,
+		opcode.DUP:        (*VM).opDUP,
+		opcode.OVER:       (*VM).opOVER,
+		opcode.PICK:       (*VM).opPICK,
+		opcode.TUCK:       (*VM).opTUCK,
+		opcode.SWAP:       (*VM).opSWAP,
+		opcode.ROT:        (*VM).opROT,
+		opcode.ROLL:       (*VM).opROLL,
+		opcode.REVERSE3:   (*VM).opREVERSE,
+		opcode.REVERSE4:   (*VM).opREVERSE,
+		opcode.REVERSEN:   (*VM).opREVERSE,
+
+		// Bit operations.
+		opcode.INVERT:   (*VM).opINVERT,
+		opcode.AND:      (*VM).opAND,
+		opcode.OR:       (*VM).opOR,
+		opcode.XOR:      (*VM).opXOR,
+		opcode.EQUAL:    (*VM).opEQUAL,
+		opcode.NOTEQUAL: (*VM).opEQUAL,
+
+		// Numeric operations.
+		opcode.SIGN:        (*VM).opSIGN,
+		opcode.ABS:         (*VM).opABS,
+		opcode.NEGATE:      (*VM).opNEGATE,
+		opcode.INC:         (*VM).opINC,
+		opcode.DEC:         (*VM).opDEC,
+		opcode.ADD:         (*VM).opADD,
+		opcode.SUB:         (*VM).opSUB,
+		opcode.MUL:         (*VM).opMUL,
+		opcode.DIV:         (*VM).opDIV,
+		opcode.MOD:         (*VM).opMOD,
+		opcode.POW:         (*VM).opPOW,
+		opcode.SQRT:        (*VM).opSQRT,
+		opcode.MODMUL:      (*VM).opMODMUL,
+		opcode.MODPOW:      (*VM).opMODPOW,
+		opcode.SHL:         (*VM).opSHIFT,
+		opcode.SHR:         (*VM).opSHIFT,
+		opcode.NOT:         (*VM).opNOT,
+		opcode.BOOLAND:     (*VM).opBOOLAND,
+		opcode.BOOLOR:      (*VM).opBOOLOR,
+		opcode.NZ:          (*VM).opNZ,
+		opcode.NUMEQUAL:    (*VM).opNUMEQUAL,
+		opcode.NUMNOTEQUAL: (*VM).opNUMNOTEQUAL,
+		opcode.LT:          (*VM).opCOMPARE,
+		opcode.LE:          (*VM).opCOMPARE,
+		opcode.GT:          (*VM).opCOMPARE,
+		opcode.GE:          (*VM).opCOMPARE,
+		opcode.MIN:         (*VM).opMIN,
+		opcode.MAX:         (*VM).opMAX,
+		opcode.WITHIN:      (*VM).opWITHIN,
+
+		// Object operations
+		opcode.NEWARRAY0:    (*VM).opNEWARRAY0,
+		opcode.NEWARRAY:     (*VM).opNEWARRAY,
+		opcode.NEWARRAYT:    (*VM).opNEWARRAY,
+		opcode.NEWSTRUCT:    (*VM).opNEWARRAY,
+		opcode.NEWSTRUCT0:   (*VM).opNEWSTRUCT0,
+		opcode.APPEND:       (*VM).opAPPEND,
+		opcode.PACKMAP:      (*VM).opPACKMAP,
+		opcode.PACKSTRUCT:   (*VM).opPACK,
+		opcode.PACK:         (*VM).opPACK,
+		opcode.UNPACK:       (*VM).opUNPACK,
+		opcode.PICKITEM:     (*VM).opPICKITEM,
+		opcode.SETITEM:      (*VM).opSETITEM,
+		opcode.REVERSEITEMS: (*VM).opREVERSEITEMS,
+		opcode.REMOVE:       (*VM).opREMOVE,
+		opcode.CLEARITEMS:   (*VM).opCLEARITEMS,
+		opcode.POPITEM:      (*VM).opPOPITEM,
+		opcode.SIZE:         (*VM).opSIZE,
+		opcode.JMP:          (*VM).opJMP,
+		opcode.JMPL:         (*VM).opJMP,
+		opcode.JMPIF:        (*VM).opJMP,
+		opcode.JMPIFL:       (*VM).opJMP,
+		opcode.JMPIFNOT:     (*VM).opJMP,
+		opcode.JMPIFNOTL:    (*VM).opJMP,
+		opcode.JMPEQ:        (*VM).opJMP,
+		opcode.JMPEQL:       (*VM).opJMP,
+		opcode.JMPNE:        (*VM).opJMP,
+		opcode.JMPNEL:       (*VM).opJMP,
+		opcode.JMPGT:        (*VM).opJMP,
+		opcode.JMPGTL:       (*VM).opJMP,
+		opcode.JMPGE:        (*VM).opJMP,
+		opcode.JMPGEL:       (*VM).opJMP,
+		opcode.JMPLT:        (*VM).opJMP,
+		opcode.JMPLTL:       (*VM).opJMP,
+		opcode.JMPLE:        (*VM).opJMP,
+		opcode.JMPLEL:       (*VM).opJMP,
+		opcode.CALL:         (*VM).opCALL,
+		opcode.CALLL:        (*VM).opCALL,
+		opcode.CALLA:        (*VM).opCALLA,
+		opcode.CALLT:        (*VM).opCALLT,
+		opcode.SYSCALL:      (*VM).opSYSCALL,
+		opcode.RET:          (*VM).opRET,
+		opcode.NEWMAP:       (*VM).opNEWMAP,
+		opcode.KEYS:         (*VM).opKEYS,
+		opcode.VALUES:       (*VM).opVALUES,
+		opcode.HASKEY:       (*VM).opHASKEY,
+		opcode.NOP:          (*VM).opNOP,
+		opcode.THROW:        (*VM).opTHROW,
+		opcode.ABORT:        (*VM).opABORT,
+		opcode.ABORTMSG:     (*VM).opABORTMSG,
+		opcode.ASSERT:       (*VM).opASSERT,
+		opcode.ASSERTMSG:    (*VM).opASSERTMSG,
+		opcode.TRY:          (*VM).opTRY,
+		opcode.TRYL:         (*VM).opTRY,
+		opcode.ENDTRY:       (*VM).opENDTRY,
+		opcode.ENDTRYL:      (*VM).opENDTRY,
+		opcode.ENDFINALLY:   (*VM).opENDFINALLY,
+	}
+}
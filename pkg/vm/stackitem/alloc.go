@@ -0,0 +1,57 @@
+package stackitem
+
+import (
+	"math/big"
+)
+
+// allocChunkSize is the number of items allocated by Allocator at once.
+const allocChunkSize = 64
+
+// Allocator is a chunked allocator for the most common stack items: Integer
+// and ByteArray. It takes memory for many items with a single heap allocation
+// which reduces the number of allocations (and GC pressure) in VM hot paths.
+// Items are never reused, so they can outlive the VM (be stored in
+// notifications or results), but keep in mind that the chunk is only
+// collected when all of its items are unreachable. The zero value is ready to
+// use, Allocator is not thread-safe.
+type Allocator struct {
+	ints  []big.Int
+	words []big.Word
+	bytes []ByteArray
+}
+
+// Int returns a new zero big.Int.
+func (a *Allocator) Int() *big.Int {
+	if len(a.ints) == 0 {
+		a.ints = make([]big.Int, allocChunkSize)
+	}
+	i := &a.ints[0]
+	a.ints = a.ints[1:]
+	return i
+}
+
+// NewBigInteger returns a new BigInteger item holding the given value. It
+// doesn't need any additional allocations for the value itself.
+func (a *Allocator) NewBigInteger(v int64) *BigInteger {
+	i := a.Int()
+	if v != 0 { // Keep zero value canonical (nil abs), like big.NewInt(0).
+		if len(a.words) == 0 {
+			a.words = make([]big.Word, allocChunkSize)
+		}
+		i.SetBits(a.words[:0:1])
+		a.words = a.words[1:]
+	}
+	return (*BigInteger)(i.SetInt64(v))
+}
+
+// NewByteArray returns a new ByteArray item, it's an allocation-efficient
+// equivalent of the package-level NewByteArray.
+func (a *Allocator) NewByteArray(b []byte) *ByteArray {
+	if len(a.bytes) == 0 {
+		a.bytes = make([]ByteArray, allocChunkSize)
+	}
+	i := &a.bytes[0]
+	*i = b
+	a.bytes = a.bytes[1:]
+	return i
+}
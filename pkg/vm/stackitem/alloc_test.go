@@ -0,0 +1,71 @@
+package stackitem
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocator(t *testing.T) {
+	var a Allocator
+
+	t.Run("Integer", func(t *testing.T) {
+		items := make([]*BigInteger, 0, 3*allocChunkSize)
+		for i := range 3 * allocChunkSize {
+			items = append(items, a.NewBigInteger(int64(i-allocChunkSize)))
+		}
+		// Values are independent even when they don't fit into a single word.
+		x := a.Int().Lsh(big.NewInt(math.MaxInt64), 100)
+		y := a.Int().SetInt64(-1)
+		require.Zero(t, new(big.Int).Lsh(big.NewInt(math.MaxInt64), 100).Cmp(x))
+		require.Equal(t, int64(-1), y.Int64())
+		for i, it := range items {
+			require.Equal(t, int64(i-allocChunkSize), it.Big().Int64())
+		}
+	})
+	t.Run("ByteArray", func(t *testing.T) {
+		items := make([]*ByteArray, 0, 2*allocChunkSize)
+		for i := range 2 * allocChunkSize {
+			items = append(items, a.NewByteArray([]byte{byte(i)}))
+		}
+		for i, it := range items {
+			require.Equal(t, NewByteArray([]byte{byte(i)}), it)
+		}
+	})
+}
+
+func BenchmarkAllocator(b *testing.B) {
+	b.Run("Integer", func(b *testing.B) {
+		b.Run("new", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := range b.N {
+				_ = NewBigInteger(big.NewInt(int64(i)))
+			}
+		})
+		b.Run("allocator", func(b *testing.B) {
+			var a Allocator
+			b.ReportAllocs()
+			for i := range b.N {
+				_ = a.NewBigInteger(int64(i))
+			}
+		})
+	})
+	b.Run("ByteArray", func(b *testing.B) {
+		var data = []byte{1, 2, 3}
+		b.Run("new", func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				_ = NewByteArray(data)
+			}
+		})
+		b.Run("allocator", func(b *testing.B) {
+			var a Allocator
+			b.ReportAllocs()
+			for range b.N {
+				_ = a.NewByteArray(data)
+			}
+		})
+	})
+}
@@ -39,6 +39,12 @@ func (e *errorAtInstruct) Error() string {
 	return fmt.Sprintf("at instruction %d (%s): %s", e.ip, e.op, e.err)
 }
 
+// Unwrap returns the underlying error if it's an error.
+func (e *errorAtInstruct) Unwrap() error {
+	err, _ := e.err.(error)
+	return err
+}
+
 func newError(ip int, op opcode.Opcode, err any) *errorAtInstruct {
 	return &errorAtInstruct{ip: ip, op: op, err: err}
 }
@@ -58,6 +64,11 @@ const (
 	// on all stacks at once.
 	MaxStackSize = 2 * 1024
 
+	// MaxFeeDiscount is the maximum fee discount (in percents) that can be
+	// set for a context. It's less than 100, so that any execution is still
+	// bounded by the gas limit.
+	MaxFeeDiscount = 99
+
 	maxSHLArg = stackitem.MaxBigIntegerSizeBits
 )
 
@@ -87,9 +98,17 @@ type VM struct {
 
 	refs refCounter
 
+	// alloc is used for the most common stack items allocation.
+	alloc stackitem.Allocator
+
 	gasConsumed int64
 	GasLimit    int64
 
+	// OpcodeLimit is the maximum number of instructions that can be executed
+	// (in addition to GasLimit), zero means no limit.
+	OpcodeLimit int64
+	opcodeCount int64
+
 	// SyscallHandler handles SYSCALL opcode.
 	SyscallHandler func(v *VM, id uint32) error
 
@@ -105,6 +124,10 @@ type VM struct {
 	hooks hooks
 }
 
+// ErrOpcodeLimitExceeded is returned (wrapped) when the VM executes more
+// instructions than allowed by OpcodeLimit.
+var ErrOpcodeLimitExceeded = errors.New("opcode limit exceeded")
+
 var (
 	bigMinusOne = big.NewInt(-1)
 	bigZero     = big.NewInt(0)
@@ -157,6 +180,8 @@ func (v *VM) Reset(t trigger.Type) {
 	v.refs = 0
 	v.gasConsumed = 0
 	v.GasLimit = 0
+	v.OpcodeLimit = 0
+	v.opcodeCount = 0
 	v.SyscallHandler = nil
 	v.LoadToken = nil
 	v.trigger = t
@@ -168,8 +193,12 @@ func (v *VM) GasConsumed() int64 {
 	return v.gasConsumed
 }
 
-// AddGas consumes the specified amount of gas. It returns true if gas limit wasn't exceeded.
+// AddGas consumes the specified amount of gas (taking fee discount of the
+// current context into account). It returns true if gas limit wasn't exceeded.
 func (v *VM) AddGas(gas int64) bool {
+	if ctx := v.Context(); ctx != nil {
+		gas = ctx.discountGas(gas)
+	}
 	v.gasConsumed += gas
 	return v.GasLimit < 0 || v.gasConsumed <= v.GasLimit
 }
@@ -383,6 +412,7 @@ func (v *VM) loadScriptWithCallingHash(b []byte, exe *nef.File, manifest *manife
 	parent := v.Context()
 	if parent != nil {
 		ctx.sc.callingContext = parent.sc
+		ctx.sc.feeDiscount = parent.sc.feeDiscount
 		parent.sc.estack = v.estack
 	}
 	if rvcount != -1 || v.estack.Len() != 0 {
@@ -505,10 +535,9 @@ func (v *VM) Step() error {
 // step executes one instruction in the given context.
 func (v *VM) step(ctx *Context) error {
 	ip := ctx.nextip
-	scriptHash := v.GetCurrentScriptHash()
 	op, param, err := ctx.Next()
 	if v.hooks.onExec != nil {
-		v.hooks.onExec(scriptHash, ip, op)
+		v.hooks.onExec(ctx.ScriptHash(), ip, op)
 	}
 	if err != nil {
 		v.state = vmstate.Fault
@@ -634,1068 +663,1173 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 	}()
 
 	if v.getPrice != nil && ctx.ip < len(ctx.sc.prog) {
-		v.gasConsumed += v.getPrice(op, parameter)
+		v.gasConsumed += ctx.discountGas(v.getPrice(op, parameter))
 		if v.GasLimit >= 0 && v.gasConsumed > v.GasLimit {
 			panic("gas limit is exceeded")
 		}
 	}
+	if v.OpcodeLimit > 0 {
+		v.opcodeCount++
+		if v.opcodeCount > v.OpcodeLimit {
+			panic(ErrOpcodeLimitExceeded)
+		}
+	}
 
-	if op <= opcode.PUSHINT256 {
-		v.estack.PushItem(stackitem.NewBigInteger(bigint.FromBytes(parameter)))
-		return
+	h := opHandlers[op]
+	if h == nil {
+		panic(fmt.Sprintf("unknown opcode %s", op.String()))
 	}
+	h(v, ctx, op, parameter)
+	return
+}
 
-	switch op {
-	case opcode.PUSHM1, opcode.PUSH0, opcode.PUSH1, opcode.PUSH2, opcode.PUSH3,
-		opcode.PUSH4, opcode.PUSH5, opcode.PUSH6, opcode.PUSH7,
-		opcode.PUSH8, opcode.PUSH9, opcode.PUSH10, opcode.PUSH11,
-		opcode.PUSH12, opcode.PUSH13, opcode.PUSH14, opcode.PUSH15,
-		opcode.PUSH16:
-		val := int(op) - int(opcode.PUSH0)
-		v.estack.PushItem(stackitem.NewBigInteger(big.NewInt(int64(val))))
-
-	case opcode.PUSHDATA1, opcode.PUSHDATA2, opcode.PUSHDATA4:
-		v.estack.PushItem(stackitem.NewByteArray(parameter))
-
-	case opcode.PUSHT, opcode.PUSHF:
-		v.estack.PushItem(stackitem.NewBool(op == opcode.PUSHT))
-
-	case opcode.PUSHA:
-		n := getJumpOffset(ctx, parameter)
-		ptr := stackitem.NewPointerWithHash(n, ctx.sc.prog, ctx.ScriptHash())
-		v.estack.PushItem(ptr)
-
-	case opcode.PUSHNULL:
-		v.estack.PushItem(stackitem.Null{})
-
-	case opcode.ISNULL:
-		_, ok := v.estack.Pop().value.(stackitem.Null)
-		v.estack.PushItem(stackitem.Bool(ok))
-
-	case opcode.ISTYPE:
-		res := v.estack.Pop().Item()
-		v.estack.PushItem(stackitem.Bool(res.Type() == stackitem.Type(parameter[0])))
-
-	case opcode.CONVERT:
-		typ := stackitem.Type(parameter[0])
-		item := v.estack.Pop().Item()
-		result, err := item.Convert(typ)
-		if err != nil {
-			panic(err)
-		}
-		v.estack.PushItem(result)
+func (v *VM) opPUSHINT(ctx *Context, op opcode.Opcode, parameter []byte) {
+	v.estack.PushItem(v.alloc.NewBigInteger(int64FromBytes(parameter)))
+}
 
-	case opcode.INITSSLOT:
-		if parameter[0] == 0 {
-			panic("zero argument")
-		}
-		ctx.sc.static.init(int(parameter[0]), &v.refs)
+func (v *VM) opPUSHBIGINT(ctx *Context, op opcode.Opcode, parameter []byte) {
+	v.estack.PushItem(stackitem.NewBigInteger(bigint.FromBytes(parameter)))
+}
 
-	case opcode.INITSLOT:
-		if ctx.local != nil || ctx.arguments != nil {
-			panic("already initialized")
-		}
-		if parameter[0] == 0 && parameter[1] == 0 {
-			panic("zero argument")
-		}
-		if parameter[0] > 0 {
-			ctx.local.init(int(parameter[0]), &v.refs)
-		}
-		if parameter[1] > 0 {
-			sz := int(parameter[1])
-			ctx.arguments.init(sz, &v.refs)
-			for i := range sz {
-				ctx.arguments.set(i, v.estack.Pop().Item(), &v.refs)
-			}
-		}
+func (v *VM) opPUSH(ctx *Context, op opcode.Opcode, parameter []byte) {
+	val := int(op) - int(opcode.PUSH0)
+	v.estack.PushItem(v.alloc.NewBigInteger(int64(val)))
+}
 
-	case opcode.LDSFLD0, opcode.LDSFLD1, opcode.LDSFLD2, opcode.LDSFLD3, opcode.LDSFLD4, opcode.LDSFLD5, opcode.LDSFLD6:
-		item := ctx.sc.static.Get(int(op - opcode.LDSFLD0))
-		v.estack.PushItem(item)
+func (v *VM) opPUSHDATA(ctx *Context, op opcode.Opcode, parameter []byte) {
+	v.estack.PushItem(v.alloc.NewByteArray(parameter))
+}
 
-	case opcode.LDSFLD:
-		item := ctx.sc.static.Get(int(parameter[0]))
-		v.estack.PushItem(item)
+func (v *VM) opPUSHBOOL(ctx *Context, op opcode.Opcode, parameter []byte) {
+	v.estack.PushItem(stackitem.NewBool(op == opcode.PUSHT))
+}
 
-	case opcode.STSFLD0, opcode.STSFLD1, opcode.STSFLD2, opcode.STSFLD3, opcode.STSFLD4, opcode.STSFLD5, opcode.STSFLD6:
-		item := v.estack.Pop().Item()
-		ctx.sc.static.set(int(op-opcode.STSFLD0), item, &v.refs)
+func (v *VM) opPUSHA(ctx *Context, op opcode.Opcode, parameter []byte) {
+	n := getJumpOffset(ctx, parameter)
+	ptr := stackitem.NewPointerWithHash(n, ctx.sc.prog, ctx.ScriptHash())
+	v.estack.PushItem(ptr)
+}
 
-	case opcode.STSFLD:
-		item := v.estack.Pop().Item()
-		ctx.sc.static.set(int(parameter[0]), item, &v.refs)
+func (v *VM) opPUSHNULL(ctx *Context, op opcode.Opcode, parameter []byte) {
+	v.estack.PushItem(stackitem.Null{})
+}
 
-	case opcode.LDLOC0, opcode.LDLOC1, opcode.LDLOC2, opcode.LDLOC3, opcode.LDLOC4, opcode.LDLOC5, opcode.LDLOC6:
-		item := ctx.local.Get(int(op - opcode.LDLOC0))
-		v.estack.PushItem(item)
+func (v *VM) opISNULL(ctx *Context, op opcode.Opcode, parameter []byte) {
+	_, ok := v.estack.Pop().value.(stackitem.Null)
+	v.estack.PushItem(stackitem.Bool(ok))
+}
 
-	case opcode.LDLOC:
-		item := ctx.local.Get(int(parameter[0]))
-		v.estack.PushItem(item)
+func (v *VM) opISTYPE(ctx *Context, op opcode.Opcode, parameter []byte) {
+	res := v.estack.Pop().Item()
+	v.estack.PushItem(stackitem.Bool(res.Type() == stackitem.Type(parameter[0])))
+}
 
-	case opcode.STLOC0, opcode.STLOC1, opcode.STLOC2, opcode.STLOC3, opcode.STLOC4, opcode.STLOC5, opcode.STLOC6:
-		item := v.estack.Pop().Item()
-		ctx.local.set(int(op-opcode.STLOC0), item, &v.refs)
+func (v *VM) opCONVERT(ctx *Context, op opcode.Opcode, parameter []byte) {
+	typ := stackitem.Type(parameter[0])
+	item := v.estack.Pop().Item()
+	result, err := item.Convert(typ)
+	if err != nil {
+		panic(err)
+	}
+	v.estack.PushItem(result)
+}
 
-	case opcode.STLOC:
-		item := v.estack.Pop().Item()
-		ctx.local.set(int(parameter[0]), item, &v.refs)
+func (v *VM) opINITSSLOT(ctx *Context, op opcode.Opcode, parameter []byte) {
+	if parameter[0] == 0 {
+		panic("zero argument")
+	}
+	ctx.sc.static.init(int(parameter[0]), &v.refs)
+}
 
-	case opcode.LDARG0, opcode.LDARG1, opcode.LDARG2, opcode.LDARG3, opcode.LDARG4, opcode.LDARG5, opcode.LDARG6:
-		item := ctx.arguments.Get(int(op - opcode.LDARG0))
-		v.estack.PushItem(item)
+func (v *VM) opINITSLOT(ctx *Context, op opcode.Opcode, parameter []byte) {
+	if ctx.local != nil || ctx.arguments != nil {
+		panic("already initialized")
+	}
+	if parameter[0] == 0 && parameter[1] == 0 {
+		panic("zero argument")
+	}
+	if parameter[0] > 0 {
+		ctx.local.init(int(parameter[0]), &v.refs)
+	}
+	if parameter[1] > 0 {
+		sz := int(parameter[1])
+		ctx.arguments.init(sz, &v.refs)
+		for i := range sz {
+			ctx.arguments.set(i, v.estack.Pop().Item(), &v.refs)
+		}
+	}
+}
 
-	case opcode.LDARG:
-		item := ctx.arguments.Get(int(parameter[0]))
-		v.estack.PushItem(item)
+func (v *VM) opLDSFLDn(ctx *Context, op opcode.Opcode, parameter []byte) {
+	item := ctx.sc.static.Get(int(op - opcode.LDSFLD0))
+	v.estack.PushItem(item)
+}
 
-	case opcode.STARG0, opcode.STARG1, opcode.STARG2, opcode.STARG3, opcode.STARG4, opcode.STARG5, opcode.STARG6:
-		item := v.estack.Pop().Item()
-		ctx.arguments.set(int(op-opcode.STARG0), item, &v.refs)
+func (v *VM) opLDSFLD(ctx *Context, op opcode.Opcode, parameter []byte) {
+	item := ctx.sc.static.Get(int(parameter[0]))
+	v.estack.PushItem(item)
+}
 
-	case opcode.STARG:
-		item := v.estack.Pop().Item()
-		ctx.arguments.set(int(parameter[0]), item, &v.refs)
+func (v *VM) opSTSFLDn(ctx *Context, op opcode.Opcode, parameter []byte) {
+	item := v.estack.Pop().Item()
+	ctx.sc.static.set(int(op-opcode.STSFLD0), item, &v.refs)
+}
 
-	case opcode.NEWBUFFER:
-		n := toInt(v.estack.Pop().BigInt())
-		if n < 0 || n > stackitem.MaxSize {
-			panic("invalid size")
-		}
-		v.estack.PushItem(stackitem.NewBuffer(make([]byte, n)))
+func (v *VM) opSTSFLD(ctx *Context, op opcode.Opcode, parameter []byte) {
+	item := v.estack.Pop().Item()
+	ctx.sc.static.set(int(parameter[0]), item, &v.refs)
+}
 
-	case opcode.MEMCPY:
-		n := toInt(v.estack.Pop().BigInt())
-		if n < 0 {
-			panic("invalid size")
-		}
-		si := toInt(v.estack.Pop().BigInt())
-		if si < 0 {
-			panic("invalid source index")
-		}
-		src := v.estack.Pop().Bytes()
-		if sum := si + n; sum < 0 || sum > len(src) {
-			panic("size is too big")
-		}
-		di := toInt(v.estack.Pop().BigInt())
-		if di < 0 {
-			panic("invalid destination index")
-		}
-		dst := v.estack.Pop().value.(*stackitem.Buffer).Value().([]byte)
-		if sum := di + n; sum < 0 || sum > len(dst) {
-			panic("size is too big")
-		}
-		copy(dst[di:], src[si:si+n])
-
-	case opcode.CAT:
-		b := v.estack.Pop().Bytes()
-		a := v.estack.Pop().Bytes()
-		l := len(a) + len(b)
-		if l > stackitem.MaxSize {
-			panic(fmt.Sprintf("too big item: %d", l))
-		}
-		ab := make([]byte, l)
-		copy(ab, a)
-		copy(ab[len(a):], b)
-		v.estack.PushItem(stackitem.NewBuffer(ab))
-
-	case opcode.SUBSTR:
-		l := toInt(v.estack.Pop().BigInt())
-		if l < 0 {
-			panic("negative length")
-		}
-		o := toInt(v.estack.Pop().BigInt())
-		if o < 0 {
-			panic("negative index")
-		}
-		s := v.estack.Pop().Bytes()
-		last := l + o
-		if last > len(s) {
-			panic("invalid offset")
-		}
-		res := make([]byte, l)
-		copy(res, s[o:last])
-		v.estack.PushItem(stackitem.NewBuffer(res))
-
-	case opcode.LEFT:
-		l := toInt(v.estack.Pop().BigInt())
-		if l < 0 {
-			panic("negative length")
-		}
-		s := v.estack.Pop().Bytes()
-		if t := len(s); l > t {
-			panic("size is too big")
-		}
-		res := make([]byte, l)
-		copy(res, s[:l])
-		v.estack.PushItem(stackitem.NewBuffer(res))
-
-	case opcode.RIGHT:
-		l := toInt(v.estack.Pop().BigInt())
-		if l < 0 {
-			panic("negative length")
-		}
-		s := v.estack.Pop().Bytes()
-		res := make([]byte, l)
-		copy(res, s[len(s)-l:])
-		v.estack.PushItem(stackitem.NewBuffer(res))
+func (v *VM) opLDLOCn(ctx *Context, op opcode.Opcode, parameter []byte) {
+	item := ctx.local.Get(int(op - opcode.LDLOC0))
+	v.estack.PushItem(item)
+}
 
-	case opcode.DEPTH:
-		v.estack.PushItem(stackitem.NewBigInteger(big.NewInt(int64(v.estack.Len()))))
+func (v *VM) opLDLOC(ctx *Context, op opcode.Opcode, parameter []byte) {
+	item := ctx.local.Get(int(parameter[0]))
+	v.estack.PushItem(item)
+}
 
-	case opcode.DROP:
-		if v.estack.Len() < 1 {
-			panic("stack is too small")
-		}
-		v.estack.Pop()
+func (v *VM) opSTLOCn(ctx *Context, op opcode.Opcode, parameter []byte) {
+	item := v.estack.Pop().Item()
+	ctx.local.set(int(op-opcode.STLOC0), item, &v.refs)
+}
 
-	case opcode.NIP:
-		if v.estack.Len() < 2 {
-			panic("no second element found")
-		}
-		_ = v.estack.RemoveAt(1)
+func (v *VM) opSTLOC(ctx *Context, op opcode.Opcode, parameter []byte) {
+	item := v.estack.Pop().Item()
+	ctx.local.set(int(parameter[0]), item, &v.refs)
+}
 
-	case opcode.XDROP:
-		n := toInt(v.estack.Pop().BigInt())
-		if n < 0 {
-			panic("invalid length")
-		}
-		if v.estack.Len() < n+1 {
-			panic("bad index")
-		}
-		_ = v.estack.RemoveAt(n)
+func (v *VM) opLDARGn(ctx *Context, op opcode.Opcode, parameter []byte) {
+	item := ctx.arguments.Get(int(op - opcode.LDARG0))
+	v.estack.PushItem(item)
+}
 
-	case opcode.CLEAR:
-		v.estack.Clear()
+func (v *VM) opLDARG(ctx *Context, op opcode.Opcode, parameter []byte) {
+	item := ctx.arguments.Get(int(parameter[0]))
+	v.estack.PushItem(item)
+}
 
-	case opcode.DUP:
-		v.estack.Push(v.estack.Dup(0))
+func (v *VM) opSTARGn(ctx *Context, op opcode.Opcode, parameter []byte) {
+	item := v.estack.Pop().Item()
+	ctx.arguments.set(int(op-opcode.STARG0), item, &v.refs)
+}
 
-	case opcode.OVER:
-		if v.estack.Len() < 2 {
-			panic("no second element found")
-		}
-		a := v.estack.Dup(1)
-		v.estack.Push(a)
+func (v *VM) opSTARG(ctx *Context, op opcode.Opcode, parameter []byte) {
+	item := v.estack.Pop().Item()
+	ctx.arguments.set(int(parameter[0]), item, &v.refs)
+}
 
-	case opcode.PICK:
-		n := toInt(v.estack.Pop().BigInt())
-		if n < 0 {
-			panic("negative stack item returned")
-		}
-		if v.estack.Len() < n+1 {
-			panic("no nth element found")
-		}
-		a := v.estack.Dup(n)
-		v.estack.Push(a)
+func (v *VM) opNEWBUFFER(ctx *Context, op opcode.Opcode, parameter []byte) {
+	n := toInt(v.estack.Pop().BigInt())
+	if n < 0 || n > stackitem.MaxSize {
+		panic("invalid size")
+	}
+	v.estack.PushItem(stackitem.NewBuffer(make([]byte, n)))
+}
 
-	case opcode.TUCK:
-		if v.estack.Len() < 2 {
-			panic("too short stack to TUCK")
-		}
-		a := v.estack.Dup(0)
-		v.estack.InsertAt(a, 2)
+func (v *VM) opMEMCPY(ctx *Context, op opcode.Opcode, parameter []byte) {
+	n := toInt(v.estack.Pop().BigInt())
+	if n < 0 {
+		panic("invalid size")
+	}
+	si := toInt(v.estack.Pop().BigInt())
+	if si < 0 {
+		panic("invalid source index")
+	}
+	src := v.estack.Pop().Bytes()
+	if sum := si + n; sum < 0 || sum > len(src) {
+		panic("size is too big")
+	}
+	di := toInt(v.estack.Pop().BigInt())
+	if di < 0 {
+		panic("invalid destination index")
+	}
+	dst := v.estack.Pop().value.(*stackitem.Buffer).Value().([]byte)
+	if sum := di + n; sum < 0 || sum > len(dst) {
+		panic("size is too big")
+	}
+	copy(dst[di:], src[si:si+n])
+}
 
-	case opcode.SWAP:
-		err := v.estack.Swap(1, 0)
-		if err != nil {
-			panic(err.Error())
-		}
+func (v *VM) opCAT(ctx *Context, op opcode.Opcode, parameter []byte) {
+	b := v.estack.Pop().Bytes()
+	a := v.estack.Pop().Bytes()
+	l := len(a) + len(b)
+	if l > stackitem.MaxSize {
+		panic(fmt.Sprintf("too big item: %d", l))
+	}
+	ab := make([]byte, l)
+	copy(ab, a)
+	copy(ab[len(a):], b)
+	v.estack.PushItem(stackitem.NewBuffer(ab))
+}
 
-	case opcode.ROT:
-		err := v.estack.Roll(2)
-		if err != nil {
-			panic(err.Error())
-		}
+func (v *VM) opSUBSTR(ctx *Context, op opcode.Opcode, parameter []byte) {
+	l := toInt(v.estack.Pop().BigInt())
+	if l < 0 {
+		panic("negative length")
+	}
+	o := toInt(v.estack.Pop().BigInt())
+	if o < 0 {
+		panic("negative index")
+	}
+	s := v.estack.Pop().Bytes()
+	last := l + o
+	if last > len(s) {
+		panic("invalid offset")
+	}
+	res := make([]byte, l)
+	copy(res, s[o:last])
+	v.estack.PushItem(stackitem.NewBuffer(res))
+}
 
-	case opcode.ROLL:
-		n := toInt(v.estack.Pop().BigInt())
-		err := v.estack.Roll(n)
-		if err != nil {
-			panic(err.Error())
-		}
+func (v *VM) opLEFT(ctx *Context, op opcode.Opcode, parameter []byte) {
+	l := toInt(v.estack.Pop().BigInt())
+	if l < 0 {
+		panic("negative length")
+	}
+	s := v.estack.Pop().Bytes()
+	if t := len(s); l > t {
+		panic("size is too big")
+	}
+	res := make([]byte, l)
+	copy(res, s[:l])
+	v.estack.PushItem(stackitem.NewBuffer(res))
+}
 
-	case opcode.REVERSE3, opcode.REVERSE4, opcode.REVERSEN:
-		n := 3
-		switch op {
-		case opcode.REVERSE4:
-			n = 4
-		case opcode.REVERSEN:
-			n = toInt(v.estack.Pop().BigInt())
-		default:
-		}
-		if err := v.estack.ReverseTop(n); err != nil {
-			panic(err.Error())
-		}
+func (v *VM) opRIGHT(ctx *Context, op opcode.Opcode, parameter []byte) {
+	l := toInt(v.estack.Pop().BigInt())
+	if l < 0 {
+		panic("negative length")
+	}
+	s := v.estack.Pop().Bytes()
+	res := make([]byte, l)
+	copy(res, s[len(s)-l:])
+	v.estack.PushItem(stackitem.NewBuffer(res))
+}
 
-	// Bit operations.
-	case opcode.INVERT:
-		i := v.estack.Pop().BigInt()
-		v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Not(i)))
+func (v *VM) opDEPTH(ctx *Context, op opcode.Opcode, parameter []byte) {
+	v.estack.PushItem(v.alloc.NewBigInteger(int64(v.estack.Len())))
+}
 
-	case opcode.AND:
-		b := v.estack.Pop().BigInt()
-		a := v.estack.Pop().BigInt()
-		v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).And(b, a)))
+func (v *VM) opDROP(ctx *Context, op opcode.Opcode, parameter []byte) {
+	if v.estack.Len() < 1 {
+		panic("stack is too small")
+	}
+	v.estack.Pop()
+}
 
-	case opcode.OR:
-		b := v.estack.Pop().BigInt()
-		a := v.estack.Pop().BigInt()
-		v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Or(b, a)))
+func (v *VM) opNIP(ctx *Context, op opcode.Opcode, parameter []byte) {
+	if v.estack.Len() < 2 {
+		panic("no second element found")
+	}
+	_ = v.estack.RemoveAt(1)
+}
 
-	case opcode.XOR:
-		b := v.estack.Pop().BigInt()
-		a := v.estack.Pop().BigInt()
-		v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Xor(b, a)))
+func (v *VM) opXDROP(ctx *Context, op opcode.Opcode, parameter []byte) {
+	n := toInt(v.estack.Pop().BigInt())
+	if n < 0 {
+		panic("invalid length")
+	}
+	if v.estack.Len() < n+1 {
+		panic("bad index")
+	}
+	_ = v.estack.RemoveAt(n)
+}
 
-	case opcode.EQUAL, opcode.NOTEQUAL:
-		if v.estack.Len() < 2 {
-			panic("need a pair of elements on the stack")
-		}
-		b := v.estack.Pop()
-		a := v.estack.Pop()
-		res := stackitem.Bool(a.value.Equals(b.value) == (op == opcode.EQUAL))
-		v.estack.PushItem(res)
-
-	// Numeric operations.
-	case opcode.SIGN:
-		x := v.estack.Pop().BigInt()
-		v.estack.PushItem(stackitem.NewBigInteger(big.NewInt(int64(x.Sign()))))
-
-	case opcode.ABS:
-		x := v.estack.Pop().BigInt()
-		v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Abs(x)))
-
-	case opcode.NEGATE:
-		x := v.estack.Pop().BigInt()
-		v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Neg(x)))
-
-	case opcode.INC:
-		x := v.estack.Pop().BigInt()
-		a := new(big.Int).Add(x, bigOne)
-		v.estack.PushItem(stackitem.NewBigInteger(a))
-
-	case opcode.DEC:
-		x := v.estack.Pop().BigInt()
-		a := new(big.Int).Sub(x, bigOne)
-		v.estack.PushItem(stackitem.NewBigInteger(a))
-
-	case opcode.ADD:
-		a := v.estack.Pop().BigInt()
-		b := v.estack.Pop().BigInt()
+func (v *VM) opCLEAR(ctx *Context, op opcode.Opcode, parameter []byte) {
+	v.estack.Clear()
+}
 
-		c := new(big.Int).Add(a, b)
-		v.estack.PushItem(stackitem.NewBigInteger(c))
+func (v *VM) opDUP(ctx *Context, op opcode.Opcode, parameter []byte) {
+	v.estack.Push(v.estack.Dup(0))
+}
 
-	case opcode.SUB:
-		b := v.estack.Pop().BigInt()
-		a := v.estack.Pop().BigInt()
+func (v *VM) opOVER(ctx *Context, op opcode.Opcode, parameter []byte) {
+	if v.estack.Len() < 2 {
+		panic("no second element found")
+	}
+	a := v.estack.Dup(1)
+	v.estack.Push(a)
+}
 
-		c := new(big.Int).Sub(a, b)
-		v.estack.PushItem(stackitem.NewBigInteger(c))
+func (v *VM) opPICK(ctx *Context, op opcode.Opcode, parameter []byte) {
+	n := toInt(v.estack.Pop().BigInt())
+	if n < 0 {
+		panic("negative stack item returned")
+	}
+	if v.estack.Len() < n+1 {
+		panic("no nth element found")
+	}
+	a := v.estack.Dup(n)
+	v.estack.Push(a)
+}
 
-	case opcode.MUL:
-		a := v.estack.Pop().BigInt()
-		b := v.estack.Pop().BigInt()
+func (v *VM) opTUCK(ctx *Context, op opcode.Opcode, parameter []byte) {
+	if v.estack.Len() < 2 {
+		panic("too short stack to TUCK")
+	}
+	a := v.estack.Dup(0)
+	v.estack.InsertAt(a, 2)
+}
 
-		c := new(big.Int).Mul(a, b)
-		v.estack.PushItem(stackitem.NewBigInteger(c))
+func (v *VM) opSWAP(ctx *Context, op opcode.Opcode, parameter []byte) {
+	err := v.estack.Swap(1, 0)
+	if err != nil {
+		panic(err.Error())
+	}
+}
 
-	case opcode.DIV:
-		b := v.estack.Pop().BigInt()
-		a := v.estack.Pop().BigInt()
+func (v *VM) opROT(ctx *Context, op opcode.Opcode, parameter []byte) {
+	err := v.estack.Roll(2)
+	if err != nil {
+		panic(err.Error())
+	}
+}
 
-		v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Quo(a, b)))
+func (v *VM) opROLL(ctx *Context, op opcode.Opcode, parameter []byte) {
+	n := toInt(v.estack.Pop().BigInt())
+	err := v.estack.Roll(n)
+	if err != nil {
+		panic(err.Error())
+	}
+}
 
-	case opcode.MOD:
-		b := v.estack.Pop().BigInt()
-		a := v.estack.Pop().BigInt()
+func (v *VM) opREVERSE(ctx *Context, op opcode.Opcode, parameter []byte) {
+	n := 3
+	switch op {
+	case opcode.REVERSE4:
+		n = 4
+	case opcode.REVERSEN:
+		n = toInt(v.estack.Pop().BigInt())
+	default:
+	}
+	if err := v.estack.ReverseTop(n); err != nil {
+		panic(err.Error())
+	}
+}
 
-		v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Rem(a, b)))
+func (v *VM) opINVERT(ctx *Context, op opcode.Opcode, parameter []byte) {
+	i := v.estack.Pop().BigInt()
+	v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Not(i)))
+}
 
-	case opcode.POW:
-		exp := v.estack.Pop().BigInt()
-		a := v.estack.Pop().BigInt()
-		if ei := exp.Uint64(); !exp.IsUint64() || ei > maxSHLArg {
-			panic("invalid exponent")
-		}
-		v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Exp(a, exp, nil)))
+func (v *VM) opAND(ctx *Context, op opcode.Opcode, parameter []byte) {
+	b := v.estack.Pop().BigInt()
+	a := v.estack.Pop().BigInt()
+	v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).And(b, a)))
+}
 
-	case opcode.SQRT:
-		a := v.estack.Pop().BigInt()
-		if a.Sign() == -1 {
-			panic("negative value")
-		}
+func (v *VM) opOR(ctx *Context, op opcode.Opcode, parameter []byte) {
+	b := v.estack.Pop().BigInt()
+	a := v.estack.Pop().BigInt()
+	v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Or(b, a)))
+}
 
-		v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Sqrt(a)))
+func (v *VM) opXOR(ctx *Context, op opcode.Opcode, parameter []byte) {
+	b := v.estack.Pop().BigInt()
+	a := v.estack.Pop().BigInt()
+	v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Xor(b, a)))
+}
 
-	case opcode.MODMUL:
-		modulus := v.estack.Pop().BigInt()
-		if modulus.Sign() == 0 {
-			panic("zero modulus")
-		}
-		x2 := v.estack.Pop().BigInt()
-		x1 := v.estack.Pop().BigInt()
-
-		res := new(big.Int).Mul(x1, x2)
-		v.estack.PushItem(stackitem.NewBigInteger(res.Rem(res, modulus)))
-
-	case opcode.MODPOW:
-		modulus := v.estack.Pop().BigInt()
-		exponent := v.estack.Pop().BigInt()
-		base := v.estack.Pop().BigInt()
-		res := new(big.Int)
-		switch exponent.Cmp(bigMinusOne) {
-		case -1:
-			panic("exponent should be >= -1")
-		case 0:
-			if base.Cmp(bigZero) <= 0 {
-				panic("invalid base")
-			}
-			if modulus.Cmp(bigTwo) < 0 {
-				panic("invalid modulus")
-			}
-			if res.ModInverse(base, modulus) == nil {
-				panic("base and modulus are not relatively prime")
-			}
-		case 1:
-			if modulus.Sign() == 0 {
-				panic("zero modulus") // https://docs.microsoft.com/en-us/dotnet/api/system.numerics.biginteger.modpow?view=net-6.0#exceptions
-			}
-			res.Exp(base, exponent, modulus)
+func (v *VM) opEQUAL(ctx *Context, op opcode.Opcode, parameter []byte) {
+	if v.estack.Len() < 2 {
+		panic("need a pair of elements on the stack")
+	}
+	b := v.estack.Pop()
+	a := v.estack.Pop()
+	res := stackitem.Bool(a.value.Equals(b.value) == (op == opcode.EQUAL))
+	v.estack.PushItem(res)
+}
 
-			// https://github.com/nspcc-dev/neo-go/issues/3612
-			if base.Sign() < 0 && exponent.Bit(0) == 1 && res.Sign() != 0 {
-				absModulus := new(big.Int).Abs(modulus)
-				res.Sub(res, absModulus)
-			}
-		}
+func (v *VM) opSIGN(ctx *Context, op opcode.Opcode, parameter []byte) {
+	x := v.estack.Pop().BigInt()
+	v.estack.PushItem(v.alloc.NewBigInteger(int64(x.Sign())))
+}
 
-		v.estack.PushItem(stackitem.NewBigInteger(res))
+func (v *VM) opABS(ctx *Context, op opcode.Opcode, parameter []byte) {
+	x := v.estack.Pop().BigInt()
+	v.estack.PushItem(stackitem.NewBigInteger(v.alloc.Int().Abs(x)))
+}
 
-	case opcode.SHL, opcode.SHR:
-		b := toInt(v.estack.Pop().BigInt())
-		if b == 0 {
-			return
-		} else if b < 0 || b > maxSHLArg {
-			panic(fmt.Sprintf("operand must be between %d and %d", 0, maxSHLArg))
-		}
-		a := v.estack.Pop().BigInt()
+func (v *VM) opNEGATE(ctx *Context, op opcode.Opcode, parameter []byte) {
+	x := v.estack.Pop().BigInt()
+	v.estack.PushItem(stackitem.NewBigInteger(v.alloc.Int().Neg(x)))
+}
 
-		var item big.Int
-		if op == opcode.SHL {
-			item.Lsh(a, uint(b))
-		} else {
-			item.Rsh(a, uint(b))
-		}
+func (v *VM) opINC(ctx *Context, op opcode.Opcode, parameter []byte) {
+	x := v.estack.Pop().BigInt()
+	a := v.alloc.Int().Add(x, bigOne)
+	v.estack.PushItem(stackitem.NewBigInteger(a))
+}
 
-		v.estack.PushItem(stackitem.NewBigInteger(&item))
+func (v *VM) opDEC(ctx *Context, op opcode.Opcode, parameter []byte) {
+	x := v.estack.Pop().BigInt()
+	a := v.alloc.Int().Sub(x, bigOne)
+	v.estack.PushItem(stackitem.NewBigInteger(a))
+}
 
-	case opcode.NOT:
-		x := v.estack.Pop().Bool()
-		v.estack.PushItem(stackitem.Bool(!x))
+func (v *VM) opADD(ctx *Context, op opcode.Opcode, parameter []byte) {
+	a := v.estack.Pop().BigInt()
+	b := v.estack.Pop().BigInt()
 
-	case opcode.BOOLAND:
-		b := v.estack.Pop().Bool()
-		a := v.estack.Pop().Bool()
-		v.estack.PushItem(stackitem.Bool(a && b))
+	c := v.alloc.Int().Add(a, b)
+	v.estack.PushItem(stackitem.NewBigInteger(c))
+}
 
-	case opcode.BOOLOR:
-		b := v.estack.Pop().Bool()
-		a := v.estack.Pop().Bool()
-		v.estack.PushItem(stackitem.Bool(a || b))
+func (v *VM) opSUB(ctx *Context, op opcode.Opcode, parameter []byte) {
+	b := v.estack.Pop().BigInt()
+	a := v.estack.Pop().BigInt()
 
-	case opcode.NZ:
-		x := v.estack.Pop().BigInt()
-		v.estack.PushItem(stackitem.Bool(x.Sign() != 0))
+	c := v.alloc.Int().Sub(a, b)
+	v.estack.PushItem(stackitem.NewBigInteger(c))
+}
 
-	case opcode.NUMEQUAL:
-		b := v.estack.Pop().BigInt()
-		a := v.estack.Pop().BigInt()
-		v.estack.PushItem(stackitem.Bool(a.Cmp(b) == 0))
+func (v *VM) opMUL(ctx *Context, op opcode.Opcode, parameter []byte) {
+	a := v.estack.Pop().BigInt()
+	b := v.estack.Pop().BigInt()
 
-	case opcode.NUMNOTEQUAL:
-		b := v.estack.Pop().BigInt()
-		a := v.estack.Pop().BigInt()
-		v.estack.PushItem(stackitem.Bool(a.Cmp(b) != 0))
-
-	case opcode.LT, opcode.LE, opcode.GT, opcode.GE:
-		eb := v.estack.Pop()
-		ea := v.estack.Pop()
-		_, aNil := ea.Item().(stackitem.Null)
-		_, bNil := eb.Item().(stackitem.Null)
-
-		res := !aNil && !bNil
-		if res {
-			cmp := ea.BigInt().Cmp(eb.BigInt())
-			switch op {
-			case opcode.LT:
-				res = cmp == -1
-			case opcode.LE:
-				res = cmp <= 0
-			case opcode.GT:
-				res = cmp == 1
-			case opcode.GE:
-				res = cmp >= 0
-			default:
-			}
-		}
-		v.estack.PushItem(stackitem.Bool(res))
+	c := v.alloc.Int().Mul(a, b)
+	v.estack.PushItem(stackitem.NewBigInteger(c))
+}
 
-	case opcode.MIN:
-		b := v.estack.Pop().BigInt()
-		a := v.estack.Pop().BigInt()
-		val := a
-		if a.Cmp(b) == 1 {
-			val = b
-		}
-		v.estack.PushItem(stackitem.NewBigInteger(val))
+func (v *VM) opDIV(ctx *Context, op opcode.Opcode, parameter []byte) {
+	b := v.estack.Pop().BigInt()
+	a := v.estack.Pop().BigInt()
 
-	case opcode.MAX:
-		b := v.estack.Pop().BigInt()
-		a := v.estack.Pop().BigInt()
-		val := a
-		if a.Cmp(b) == -1 {
-			val = b
-		}
-		v.estack.PushItem(stackitem.NewBigInteger(val))
+	v.estack.PushItem(stackitem.NewBigInteger(v.alloc.Int().Quo(a, b)))
+}
 
-	case opcode.WITHIN:
-		b := v.estack.Pop().BigInt()
-		a := v.estack.Pop().BigInt()
-		x := v.estack.Pop().BigInt()
-		v.estack.PushItem(stackitem.Bool(a.Cmp(x) <= 0 && x.Cmp(b) == -1))
+func (v *VM) opMOD(ctx *Context, op opcode.Opcode, parameter []byte) {
+	b := v.estack.Pop().BigInt()
+	a := v.estack.Pop().BigInt()
+
+	v.estack.PushItem(stackitem.NewBigInteger(v.alloc.Int().Rem(a, b)))
+}
+
+func (v *VM) opPOW(ctx *Context, op opcode.Opcode, parameter []byte) {
+	exp := v.estack.Pop().BigInt()
+	a := v.estack.Pop().BigInt()
+	if ei := exp.Uint64(); !exp.IsUint64() || ei > maxSHLArg {
+		panic("invalid exponent")
+	}
+	v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Exp(a, exp, nil)))
+}
 
-	// Object operations
-	case opcode.NEWARRAY0:
-		v.estack.PushItem(stackitem.NewArray([]stackitem.Item{}))
+func (v *VM) opSQRT(ctx *Context, op opcode.Opcode, parameter []byte) {
+	a := v.estack.Pop().BigInt()
+	if a.Sign() == -1 {
+		panic("negative value")
+	}
+
+	v.estack.PushItem(stackitem.NewBigInteger(new(big.Int).Sqrt(a)))
+}
+
+func (v *VM) opMODMUL(ctx *Context, op opcode.Opcode, parameter []byte) {
+	modulus := v.estack.Pop().BigInt()
+	if modulus.Sign() == 0 {
+		panic("zero modulus")
+	}
+	x2 := v.estack.Pop().BigInt()
+	x1 := v.estack.Pop().BigInt()
+
+	res := new(big.Int).Mul(x1, x2)
+	v.estack.PushItem(stackitem.NewBigInteger(res.Rem(res, modulus)))
+}
 
-	case opcode.NEWARRAY, opcode.NEWARRAYT, opcode.NEWSTRUCT:
-		n := toInt(v.estack.Pop().BigInt())
-		if n < 0 || n > MaxStackSize {
-			panic("wrong number of elements")
+func (v *VM) opMODPOW(ctx *Context, op opcode.Opcode, parameter []byte) {
+	modulus := v.estack.Pop().BigInt()
+	exponent := v.estack.Pop().BigInt()
+	base := v.estack.Pop().BigInt()
+	res := new(big.Int)
+	switch exponent.Cmp(bigMinusOne) {
+	case -1:
+		panic("exponent should be >= -1")
+	case 0:
+		if base.Cmp(bigZero) <= 0 {
+			panic("invalid base")
 		}
-		typ := stackitem.AnyT
-		if op == opcode.NEWARRAYT {
-			typ = stackitem.Type(parameter[0])
+		if modulus.Cmp(bigTwo) < 0 {
+			panic("invalid modulus")
 		}
-		items := makeArrayOfType(int(n), typ)
-		var res stackitem.Item
-		if op == opcode.NEWSTRUCT {
-			res = stackitem.NewStruct(items)
-		} else {
-			res = stackitem.NewArray(items)
+		if res.ModInverse(base, modulus) == nil {
+			panic("base and modulus are not relatively prime")
+		}
+	case 1:
+		if modulus.Sign() == 0 {
+			panic("zero modulus") // https://docs.microsoft.com/en-us/dotnet/api/system.numerics.biginteger.modpow?view=net-6.0#exceptions
 		}
-		v.estack.PushItem(res)
+		res.Exp(base, exponent, modulus)
 
-	case opcode.NEWSTRUCT0:
-		v.estack.PushItem(stackitem.NewStruct([]stackitem.Item{}))
+		// https://github.com/nspcc-dev/neo-go/issues/3612
+		if base.Sign() < 0 && exponent.Bit(0) == 1 && res.Sign() != 0 {
+			absModulus := new(big.Int).Abs(modulus)
+			res.Sub(res, absModulus)
+		}
+	}
 
-	case opcode.APPEND:
-		itemElem := v.estack.Pop()
-		arrElem := v.estack.Pop()
+	v.estack.PushItem(stackitem.NewBigInteger(res))
+}
 
-		val := cloneIfStruct(itemElem.value)
+func (v *VM) opSHIFT(ctx *Context, op opcode.Opcode, parameter []byte) {
+	b := toInt(v.estack.Pop().BigInt())
+	if b == 0 {
+		return
+	} else if b < 0 || b > maxSHLArg {
+		panic(fmt.Sprintf("operand must be between %d and %d", 0, maxSHLArg))
+	}
+	a := v.estack.Pop().BigInt()
 
-		switch t := arrElem.value.(type) {
-		case *stackitem.Array:
-			t.Append(val)
-		case *stackitem.Struct:
-			t.Append(val)
-		default:
-			panic("APPEND: not of underlying type Array")
-		}
+	var item big.Int
+	if op == opcode.SHL {
+		item.Lsh(a, uint(b))
+	} else {
+		item.Rsh(a, uint(b))
+	}
 
-		v.refs.Add(val)
+	v.estack.PushItem(stackitem.NewBigInteger(&item))
+}
 
-	case opcode.PACKMAP:
-		n := toInt(v.estack.Pop().BigInt())
-		if n < 0 || n*2 > v.estack.Len() {
-			panic("invalid length")
-		}
+func (v *VM) opNOT(ctx *Context, op opcode.Opcode, parameter []byte) {
+	x := v.estack.Pop().Bool()
+	v.estack.PushItem(stackitem.Bool(!x))
+}
 
-		m := stackitem.NewMap()
-		for range n {
-			key := v.estack.Pop()
-			val := v.estack.Pop().value
-			if key.Item() == nil {
-				panic("no key found")
-			}
-			m.Add(key.value, val)
-		}
-		v.estack.PushItem(m)
+func (v *VM) opBOOLAND(ctx *Context, op opcode.Opcode, parameter []byte) {
+	b := v.estack.Pop().Bool()
+	a := v.estack.Pop().Bool()
+	v.estack.PushItem(stackitem.Bool(a && b))
+}
 
-	case opcode.PACKSTRUCT, opcode.PACK:
-		n := toInt(v.estack.Pop().BigInt())
-		if n < 0 || n > v.estack.Len() {
-			panic("OPACK: invalid length")
-		}
+func (v *VM) opBOOLOR(ctx *Context, op opcode.Opcode, parameter []byte) {
+	b := v.estack.Pop().Bool()
+	a := v.estack.Pop().Bool()
+	v.estack.PushItem(stackitem.Bool(a || b))
+}
 
-		items := make([]stackitem.Item, n)
-		for i := range n {
-			items[i] = v.estack.Pop().value
-		}
+func (v *VM) opNZ(ctx *Context, op opcode.Opcode, parameter []byte) {
+	x := v.estack.Pop().BigInt()
+	v.estack.PushItem(stackitem.Bool(x.Sign() != 0))
+}
 
-		var res stackitem.Item
-		if op == opcode.PACK {
-			res = stackitem.NewArray(items)
-		} else {
-			res = stackitem.NewStruct(items)
-		}
-		v.estack.PushItem(res)
-
-	case opcode.UNPACK:
-		e := v.estack.Pop()
-		var arr []stackitem.Item
-		var l int
-
-		switch t := e.value.(type) {
-		case *stackitem.Array:
-			arr = t.Value().([]stackitem.Item)
-		case *stackitem.Struct:
-			arr = t.Value().([]stackitem.Item)
-		case *stackitem.Map:
-			m := t.Value().([]stackitem.MapElement)
-			l = len(m)
-			for i := l - 1; i >= 0; i-- {
-				v.estack.PushItem(m[i].Value)
-				v.estack.PushItem(m[i].Key)
-			}
+func (v *VM) opNUMEQUAL(ctx *Context, op opcode.Opcode, parameter []byte) {
+	b := v.estack.Pop().BigInt()
+	a := v.estack.Pop().BigInt()
+	v.estack.PushItem(stackitem.Bool(a.Cmp(b) == 0))
+}
+
+func (v *VM) opNUMNOTEQUAL(ctx *Context, op opcode.Opcode, parameter []byte) {
+	b := v.estack.Pop().BigInt()
+	a := v.estack.Pop().BigInt()
+	v.estack.PushItem(stackitem.Bool(a.Cmp(b) != 0))
+}
+
+func (v *VM) opCOMPARE(ctx *Context, op opcode.Opcode, parameter []byte) {
+	eb := v.estack.Pop()
+	ea := v.estack.Pop()
+	_, aNil := ea.Item().(stackitem.Null)
+	_, bNil := eb.Item().(stackitem.Null)
+
+	res := !aNil && !bNil
+	if res {
+		cmp := ea.BigInt().Cmp(eb.BigInt())
+		switch op {
+		case opcode.LT:
+			res = cmp == -1
+		case opcode.LE:
+			res = cmp <= 0
+		case opcode.GT:
+			res = cmp == 1
+		case opcode.GE:
+			res = cmp >= 0
 		default:
-			panic("element is not an array/struct/map")
-		}
-		if arr != nil {
-			l = len(arr)
-			for i := l - 1; i >= 0; i-- {
-				v.estack.PushItem(arr[i])
-			}
 		}
-		v.estack.PushItem(stackitem.NewBigInteger(big.NewInt(int64(l))))
+	}
+	v.estack.PushItem(stackitem.Bool(res))
+}
+
+func (v *VM) opMIN(ctx *Context, op opcode.Opcode, parameter []byte) {
+	b := v.estack.Pop().BigInt()
+	a := v.estack.Pop().BigInt()
+	val := a
+	if a.Cmp(b) == 1 {
+		val = b
+	}
+	v.estack.PushItem(stackitem.NewBigInteger(val))
+}
+
+func (v *VM) opMAX(ctx *Context, op opcode.Opcode, parameter []byte) {
+	b := v.estack.Pop().BigInt()
+	a := v.estack.Pop().BigInt()
+	val := a
+	if a.Cmp(b) == -1 {
+		val = b
+	}
+	v.estack.PushItem(stackitem.NewBigInteger(val))
+}
+
+func (v *VM) opWITHIN(ctx *Context, op opcode.Opcode, parameter []byte) {
+	b := v.estack.Pop().BigInt()
+	a := v.estack.Pop().BigInt()
+	x := v.estack.Pop().BigInt()
+	v.estack.PushItem(stackitem.Bool(a.Cmp(x) <= 0 && x.Cmp(b) == -1))
+}
+
+func (v *VM) opNEWARRAY0(ctx *Context, op opcode.Opcode, parameter []byte) {
+	v.estack.PushItem(stackitem.NewArray([]stackitem.Item{}))
+}
 
-	case opcode.PICKITEM:
+func (v *VM) opNEWARRAY(ctx *Context, op opcode.Opcode, parameter []byte) {
+	n := toInt(v.estack.Pop().BigInt())
+	if n < 0 || n > MaxStackSize {
+		panic("wrong number of elements")
+	}
+	typ := stackitem.AnyT
+	if op == opcode.NEWARRAYT {
+		typ = stackitem.Type(parameter[0])
+	}
+	items := makeArrayOfType(int(n), typ)
+	var res stackitem.Item
+	if op == opcode.NEWSTRUCT {
+		res = stackitem.NewStruct(items)
+	} else {
+		res = stackitem.NewArray(items)
+	}
+	v.estack.PushItem(res)
+}
+
+func (v *VM) opNEWSTRUCT0(ctx *Context, op opcode.Opcode, parameter []byte) {
+	v.estack.PushItem(stackitem.NewStruct([]stackitem.Item{}))
+}
+
+func (v *VM) opAPPEND(ctx *Context, op opcode.Opcode, parameter []byte) {
+	itemElem := v.estack.Pop()
+	arrElem := v.estack.Pop()
+
+	val := cloneIfStruct(itemElem.value)
+
+	switch t := arrElem.value.(type) {
+	case *stackitem.Array:
+		t.Append(val)
+	case *stackitem.Struct:
+		t.Append(val)
+	default:
+		panic("APPEND: not of underlying type Array")
+	}
+
+	v.refs.Add(val)
+}
+
+func (v *VM) opPACKMAP(ctx *Context, op opcode.Opcode, parameter []byte) {
+	n := toInt(v.estack.Pop().BigInt())
+	if n < 0 || n*2 > v.estack.Len() {
+		panic("invalid length")
+	}
+
+	m := stackitem.NewMap()
+	for range n {
 		key := v.estack.Pop()
-		validateMapKey(key)
-
-		obj := v.estack.Pop()
-
-		switch t := obj.value.(type) {
-		// Struct and Array items have their underlying value as []Item.
-		case *stackitem.Array, *stackitem.Struct:
-			index := toInt(key.BigInt())
-			arr := t.Value().([]stackitem.Item)
-			if index < 0 || index >= len(arr) {
-				msg := fmt.Sprintf("The value %d is out of range.", index)
-				v.throw(stackitem.NewByteArray([]byte(msg)))
-				return
-			}
-			item := arr[index].Dup()
-			v.estack.PushItem(item)
-		case *stackitem.Map:
-			index := t.Index(key.Item())
-			if index < 0 {
-				v.throw(stackitem.NewByteArray([]byte("Key not found in Map")))
-				return
-			}
-			v.estack.PushItem(t.Value().([]stackitem.MapElement)[index].Value.Dup())
-		default:
-			index := toInt(key.BigInt())
-			arr := obj.Bytes()
-			if index < 0 || index >= len(arr) {
-				msg := fmt.Sprintf("The value %d is out of range.", index)
-				v.throw(stackitem.NewByteArray([]byte(msg)))
-				return
-			}
-			item := arr[index]
-			v.estack.PushItem(stackitem.NewBigInteger(big.NewInt(int64(item))))
+		val := v.estack.Pop().value
+		if key.Item() == nil {
+			panic("no key found")
 		}
+		m.Add(key.value, val)
+	}
+	v.estack.PushItem(m)
+}
 
-	case opcode.SETITEM:
-		item := v.estack.Pop().value
-		key := v.estack.Pop()
-		validateMapKey(key)
-
-		obj := v.estack.Pop()
-
-		switch t := obj.value.(type) {
-		// Struct and Array items have their underlying value as []Item.
-		case *stackitem.Array, *stackitem.Struct:
-			arr := t.Value().([]stackitem.Item)
-			index := toInt(key.BigInt())
-			if index < 0 || index >= len(arr) {
-				msg := fmt.Sprintf("The value %d is out of range.", index)
-				v.throw(stackitem.NewByteArray([]byte(msg)))
-				return
-			}
-			if t.(stackitem.Immutable).IsReadOnly() {
-				panic(stackitem.ErrReadOnly)
-			}
-			v.refs.Remove(arr[index])
-			arr[index] = item
-			v.refs.Add(arr[index])
-		case *stackitem.Map:
-			if t.IsReadOnly() {
-				panic(stackitem.ErrReadOnly)
-			}
-			if i := t.Index(key.value); i >= 0 {
-				v.refs.Remove(t.Value().([]stackitem.MapElement)[i].Value)
-			} else {
-				v.refs.Add(key.value)
-			}
-			t.Add(key.value, item)
-			v.refs.Add(item)
-
-		case *stackitem.Buffer:
-			index := toInt(key.BigInt())
-			if index < 0 || index >= t.Len() {
-				msg := fmt.Sprintf("The value %d is out of range.", index)
-				v.throw(stackitem.NewByteArray([]byte(msg)))
-				return
-			}
-			bi, err := item.TryInteger()
-			b := toInt(bi)
-			if err != nil || b < math.MinInt8 || b > math.MaxUint8 {
-				panic("invalid value")
-			}
-			t.Value().([]byte)[index] = byte(b)
+func (v *VM) opPACK(ctx *Context, op opcode.Opcode, parameter []byte) {
+	n := toInt(v.estack.Pop().BigInt())
+	if n < 0 || n > v.estack.Len() {
+		panic("OPACK: invalid length")
+	}
 
-		default:
-			panic(fmt.Sprintf("SETITEM: invalid item type %s", t))
-		}
+	items := make([]stackitem.Item, n)
+	for i := range n {
+		items[i] = v.estack.Pop().value
+	}
 
-	case opcode.REVERSEITEMS:
-		item := v.estack.Pop()
-		switch t := item.value.(type) {
-		case *stackitem.Array, *stackitem.Struct:
-			if t.(stackitem.Immutable).IsReadOnly() {
-				panic(stackitem.ErrReadOnly)
-			}
-			slices.Reverse(t.Value().([]stackitem.Item))
-		case *stackitem.Buffer:
-			b := t.Value().([]byte)
-			slices.Reverse(b)
-		default:
-			panic(fmt.Sprintf("invalid item type %s", t))
+	var res stackitem.Item
+	if op == opcode.PACK {
+		res = stackitem.NewArray(items)
+	} else {
+		res = stackitem.NewStruct(items)
+	}
+	v.estack.PushItem(res)
+}
+
+func (v *VM) opUNPACK(ctx *Context, op opcode.Opcode, parameter []byte) {
+	e := v.estack.Pop()
+	var arr []stackitem.Item
+	var l int
+
+	switch t := e.value.(type) {
+	case *stackitem.Array:
+		arr = t.Value().([]stackitem.Item)
+	case *stackitem.Struct:
+		arr = t.Value().([]stackitem.Item)
+	case *stackitem.Map:
+		m := t.Value().([]stackitem.MapElement)
+		l = len(m)
+		for i := l - 1; i >= 0; i-- {
+			v.estack.PushItem(m[i].Value)
+			v.estack.PushItem(m[i].Key)
 		}
-	case opcode.REMOVE:
-		key := v.estack.Pop()
-		validateMapKey(key)
-
-		elem := v.estack.Pop()
-		switch t := elem.value.(type) {
-		case *stackitem.Array:
-			a := t.Value().([]stackitem.Item)
-			k := toInt(key.BigInt())
-			if k < 0 || k >= len(a) {
-				panic("REMOVE: invalid index")
-			}
-			toRemove := a[k]
-			t.Remove(k)
-			v.refs.Remove(toRemove)
-		case *stackitem.Struct:
-			a := t.Value().([]stackitem.Item)
-			k := toInt(key.BigInt())
-			if k < 0 || k >= len(a) {
-				panic("REMOVE: invalid index")
-			}
-			toRemove := a[k]
-			t.Remove(k)
-			v.refs.Remove(toRemove)
-		case *stackitem.Map:
-			index := t.Index(key.Item())
-			// No error on missing key.
-			if index >= 0 {
-				elems := t.Value().([]stackitem.MapElement)
-				key := elems[index].Key
-				val := elems[index].Value
-				t.Drop(index)
-				v.refs.Remove(key)
-				v.refs.Remove(val)
-			}
-		default:
-			panic("REMOVE: invalid type")
+	default:
+		panic("element is not an array/struct/map")
+	}
+	if arr != nil {
+		l = len(arr)
+		for i := l - 1; i >= 0; i-- {
+			v.estack.PushItem(arr[i])
 		}
+	}
+	v.estack.PushItem(v.alloc.NewBigInteger(int64(l)))
+}
 
-	case opcode.CLEARITEMS:
-		elem := v.estack.Pop()
-		switch t := elem.value.(type) {
-		case *stackitem.Array:
-			if t.IsReadOnly() {
-				panic(stackitem.ErrReadOnly)
-			}
-			for _, item := range t.Value().([]stackitem.Item) {
-				v.refs.Remove(item)
-			}
-			t.Clear()
-		case *stackitem.Struct:
-			if t.IsReadOnly() {
-				panic(stackitem.ErrReadOnly)
-			}
-			for _, item := range t.Value().([]stackitem.Item) {
-				v.refs.Remove(item)
-			}
-			t.Clear()
-		case *stackitem.Map:
-			if t.IsReadOnly() {
-				panic(stackitem.ErrReadOnly)
-			}
-			elems := t.Value().([]stackitem.MapElement)
-			for i := range elems {
-				v.refs.Remove(elems[i].Key)
-				v.refs.Remove(elems[i].Value)
-			}
-			t.Clear()
-		default:
-			panic("CLEARITEMS: invalid type")
-		}
+func (v *VM) opPICKITEM(ctx *Context, op opcode.Opcode, parameter []byte) {
+	key := v.estack.Pop()
+	validateMapKey(key)
 
-	case opcode.POPITEM:
-		arr := v.estack.Pop().Item()
-		elems := arr.Value().([]stackitem.Item)
-		index := len(elems) - 1
-		elem := elems[index]
-		v.estack.PushItem(elem) // push item on stack firstly, to match the reference behaviour.
-		switch item := arr.(type) {
-		case *stackitem.Array:
-			item.Remove(index)
-		case *stackitem.Struct:
-			item.Remove(index)
+	obj := v.estack.Pop()
+
+	switch t := obj.value.(type) {
+	// Struct and Array items have their underlying value as []Item.
+	case *stackitem.Array, *stackitem.Struct:
+		index := toInt(key.BigInt())
+		arr := t.Value().([]stackitem.Item)
+		if index < 0 || index >= len(arr) {
+			msg := fmt.Sprintf("The value %d is out of range.", index)
+			v.throw(stackitem.NewByteArray([]byte(msg)))
+			return
 		}
+		item := arr[index].Dup()
+		v.estack.PushItem(item)
+	case *stackitem.Map:
+		index := t.Index(key.Item())
+		if index < 0 {
+			v.throw(stackitem.NewByteArray([]byte("Key not found in Map")))
+			return
+		}
+		v.estack.PushItem(t.Value().([]stackitem.MapElement)[index].Value.Dup())
+	default:
+		index := toInt(key.BigInt())
+		arr := obj.Bytes()
+		if index < 0 || index >= len(arr) {
+			msg := fmt.Sprintf("The value %d is out of range.", index)
+			v.throw(stackitem.NewByteArray([]byte(msg)))
+			return
+		}
+		item := arr[index]
+		v.estack.PushItem(v.alloc.NewBigInteger(int64(item)))
+	}
+}
 
-	case opcode.SIZE:
-		elem := v.estack.Pop()
-		var res int
-		// Cause there is no native (byte) item type here, we need to check
-		// the type of the item for array size operations.
-		switch t := elem.Value().(type) {
-		case []stackitem.Item:
-			res = len(t)
-		case []stackitem.MapElement:
-			res = len(t)
-		default:
-			res = len(elem.Bytes())
+func (v *VM) opSETITEM(ctx *Context, op opcode.Opcode, parameter []byte) {
+	item := v.estack.Pop().value
+	key := v.estack.Pop()
+	validateMapKey(key)
+
+	obj := v.estack.Pop()
+
+	switch t := obj.value.(type) {
+	// Struct and Array items have their underlying value as []Item.
+	case *stackitem.Array, *stackitem.Struct:
+		arr := t.Value().([]stackitem.Item)
+		index := toInt(key.BigInt())
+		if index < 0 || index >= len(arr) {
+			msg := fmt.Sprintf("The value %d is out of range.", index)
+			v.throw(stackitem.NewByteArray([]byte(msg)))
+			return
 		}
-		v.estack.PushItem(stackitem.NewBigInteger(big.NewInt(int64(res))))
-
-	case opcode.JMP, opcode.JMPL, opcode.JMPIF, opcode.JMPIFL, opcode.JMPIFNOT, opcode.JMPIFNOTL,
-		opcode.JMPEQ, opcode.JMPEQL, opcode.JMPNE, opcode.JMPNEL,
-		opcode.JMPGT, opcode.JMPGTL, opcode.JMPGE, opcode.JMPGEL,
-		opcode.JMPLT, opcode.JMPLTL, opcode.JMPLE, opcode.JMPLEL:
-		offset := getJumpOffset(ctx, parameter)
-		cond := true
-		switch op {
-		case opcode.JMP, opcode.JMPL:
-		case opcode.JMPIF, opcode.JMPIFL, opcode.JMPIFNOT, opcode.JMPIFNOTL:
-			cond = v.estack.Pop().Bool() == (op == opcode.JMPIF || op == opcode.JMPIFL)
-		default:
-			b := v.estack.Pop().BigInt()
-			a := v.estack.Pop().BigInt()
-			cond = getJumpCondition(op, a, b)
+		if t.(stackitem.Immutable).IsReadOnly() {
+			panic(stackitem.ErrReadOnly)
+		}
+		v.refs.Remove(arr[index])
+		arr[index] = item
+		v.refs.Add(arr[index])
+	case *stackitem.Map:
+		if t.IsReadOnly() {
+			panic(stackitem.ErrReadOnly)
 		}
+		if i := t.Index(key.value); i >= 0 {
+			v.refs.Remove(t.Value().([]stackitem.MapElement)[i].Value)
+		} else {
+			v.refs.Add(key.value)
+		}
+		t.Add(key.value, item)
+		v.refs.Add(item)
 
-		if cond {
-			ctx.Jump(offset)
+	case *stackitem.Buffer:
+		index := toInt(key.BigInt())
+		if index < 0 || index >= t.Len() {
+			msg := fmt.Sprintf("The value %d is out of range.", index)
+			v.throw(stackitem.NewByteArray([]byte(msg)))
+			return
+		}
+		bi, err := item.TryInteger()
+		b := toInt(bi)
+		if err != nil || b < math.MinInt8 || b > math.MaxUint8 {
+			panic("invalid value")
 		}
+		t.Value().([]byte)[index] = byte(b)
 
-	case opcode.CALL, opcode.CALLL:
-		// Note: jump offset must be calculated regarding the new context,
-		// but it is cloned and thus has the same script and instruction pointer.
-		v.call(ctx, getJumpOffset(ctx, parameter))
+	default:
+		panic(fmt.Sprintf("SETITEM: invalid item type %s", t))
+	}
+}
 
-	case opcode.CALLA:
-		ptr := v.estack.Pop().Item().(*stackitem.Pointer)
-		if ptr.ScriptHash() != ctx.ScriptHash() {
-			panic("invalid script in pointer")
+func (v *VM) opREVERSEITEMS(ctx *Context, op opcode.Opcode, parameter []byte) {
+	item := v.estack.Pop()
+	switch t := item.value.(type) {
+	case *stackitem.Array, *stackitem.Struct:
+		if t.(stackitem.Immutable).IsReadOnly() {
+			panic(stackitem.ErrReadOnly)
 		}
+		slices.Reverse(t.Value().([]stackitem.Item))
+	case *stackitem.Buffer:
+		b := t.Value().([]byte)
+		slices.Reverse(b)
+	default:
+		panic(fmt.Sprintf("invalid item type %s", t))
+	}
+}
 
-		v.call(ctx, ptr.Position())
+func (v *VM) opREMOVE(ctx *Context, op opcode.Opcode, parameter []byte) {
+	key := v.estack.Pop()
+	validateMapKey(key)
 
-	case opcode.CALLT:
-		id := int32(binary.LittleEndian.Uint16(parameter))
-		if err := v.LoadToken(id); err != nil {
-			panic(err)
+	elem := v.estack.Pop()
+	switch t := elem.value.(type) {
+	case *stackitem.Array:
+		a := t.Value().([]stackitem.Item)
+		k := toInt(key.BigInt())
+		if k < 0 || k >= len(a) {
+			panic("REMOVE: invalid index")
 		}
+		toRemove := a[k]
+		t.Remove(k)
+		v.refs.Remove(toRemove)
+	case *stackitem.Struct:
+		a := t.Value().([]stackitem.Item)
+		k := toInt(key.BigInt())
+		if k < 0 || k >= len(a) {
+			panic("REMOVE: invalid index")
+		}
+		toRemove := a[k]
+		t.Remove(k)
+		v.refs.Remove(toRemove)
+	case *stackitem.Map:
+		index := t.Index(key.Item())
+		// No error on missing key.
+		if index >= 0 {
+			elems := t.Value().([]stackitem.MapElement)
+			key := elems[index].Key
+			val := elems[index].Value
+			t.Drop(index)
+			v.refs.Remove(key)
+			v.refs.Remove(val)
+		}
+	default:
+		panic("REMOVE: invalid type")
+	}
+}
 
-	case opcode.SYSCALL:
-		interopID := GetInteropID(parameter)
-		if v.SyscallHandler == nil {
-			panic("vm's SyscallHandler is not initialized")
+func (v *VM) opCLEARITEMS(ctx *Context, op opcode.Opcode, parameter []byte) {
+	elem := v.estack.Pop()
+	switch t := elem.value.(type) {
+	case *stackitem.Array:
+		if t.IsReadOnly() {
+			panic(stackitem.ErrReadOnly)
 		}
-		err := v.SyscallHandler(v, interopID)
-		if err != nil {
-			iName, iErr := interopnames.FromID(interopID)
-			if iErr == nil {
-				panic(fmt.Sprintf("%s failed: %s", iName, err))
-			}
-			panic(fmt.Sprintf("%d failed: %s", interopID, err))
+		for _, item := range t.Value().([]stackitem.Item) {
+			v.refs.Remove(item)
+		}
+		t.Clear()
+	case *stackitem.Struct:
+		if t.IsReadOnly() {
+			panic(stackitem.ErrReadOnly)
+		}
+		for _, item := range t.Value().([]stackitem.Item) {
+			v.refs.Remove(item)
 		}
+		t.Clear()
+	case *stackitem.Map:
+		if t.IsReadOnly() {
+			panic(stackitem.ErrReadOnly)
+		}
+		elems := t.Value().([]stackitem.MapElement)
+		for i := range elems {
+			v.refs.Remove(elems[i].Key)
+			v.refs.Remove(elems[i].Value)
+		}
+		t.Clear()
+	default:
+		panic("CLEARITEMS: invalid type")
+	}
+}
 
-	case opcode.RET:
-		oldCtx := v.istack[len(v.istack)-1]
-		v.istack = v.istack[:len(v.istack)-1]
-		oldEstack := v.estack
+func (v *VM) opPOPITEM(ctx *Context, op opcode.Opcode, parameter []byte) {
+	arr := v.estack.Pop().Item()
+	elems := arr.Value().([]stackitem.Item)
+	index := len(elems) - 1
+	elem := elems[index]
+	v.estack.PushItem(elem) // push item on stack firstly, to match the reference behaviour.
+	switch item := arr.(type) {
+	case *stackitem.Array:
+		item.Remove(index)
+	case *stackitem.Struct:
+		item.Remove(index)
+	}
+}
 
-		v.unloadContext(oldCtx)
-		if len(v.istack) == 0 {
-			v.state = vmstate.Halt
-			break
-		}
+func (v *VM) opSIZE(ctx *Context, op opcode.Opcode, parameter []byte) {
+	elem := v.estack.Pop()
+	var res int
+	// Cause there is no native (byte) item type here, we need to check
+	// the type of the item for array size operations.
+	switch t := elem.Value().(type) {
+	case []stackitem.Item:
+		res = len(t)
+	case []stackitem.MapElement:
+		res = len(t)
+	default:
+		res = len(elem.Bytes())
+	}
+	v.estack.PushItem(v.alloc.NewBigInteger(int64(res)))
+}
 
-		newEstack := v.Context().sc.estack
-		if oldEstack != newEstack {
-			if oldCtx.retCount >= 0 && oldEstack.Len() != oldCtx.retCount {
-				panic(fmt.Errorf("invalid return values count: expected %d, got %d",
-					oldCtx.retCount, oldEstack.Len()))
-			}
-			rvcount := oldEstack.Len()
-			for i := rvcount; i > 0; i-- {
-				elem := oldEstack.RemoveAt(i - 1)
-				newEstack.Push(elem)
-			}
-			v.estack = newEstack
-		}
+func (v *VM) opJMP(ctx *Context, op opcode.Opcode, parameter []byte) {
+	offset := getJumpOffset(ctx, parameter)
+	cond := true
+	switch op {
+	case opcode.JMP, opcode.JMPL:
+	case opcode.JMPIF, opcode.JMPIFL, opcode.JMPIFNOT, opcode.JMPIFNOTL:
+		cond = v.estack.Pop().Bool() == (op == opcode.JMPIF || op == opcode.JMPIFL)
+	default:
+		b := v.estack.Pop().BigInt()
+		a := v.estack.Pop().BigInt()
+		cond = getJumpCondition(op, a, b)
+	}
 
-	case opcode.NEWMAP:
-		v.estack.PushItem(stackitem.NewMap())
+	if cond {
+		ctx.Jump(offset)
+	}
+}
 
-	case opcode.KEYS:
-		if v.estack.Len() == 0 {
-			panic("no argument")
-		}
-		item := v.estack.Pop()
+func (v *VM) opCALL(ctx *Context, op opcode.Opcode, parameter []byte) {
+	// Note: jump offset must be calculated regarding the new context,
+	// but it is cloned and thus has the same script and instruction pointer.
+	v.call(ctx, getJumpOffset(ctx, parameter))
+}
 
-		m, ok := item.value.(*stackitem.Map)
-		if !ok {
-			panic("not a Map")
-		}
+func (v *VM) opCALLA(ctx *Context, op opcode.Opcode, parameter []byte) {
+	ptr := v.estack.Pop().Item().(*stackitem.Pointer)
+	if ptr.ScriptHash() != ctx.ScriptHash() {
+		panic("invalid script in pointer")
+	}
 
-		arr := make([]stackitem.Item, 0, m.Len())
-		for k := range m.Value().([]stackitem.MapElement) {
-			arr = append(arr, m.Value().([]stackitem.MapElement)[k].Key.Dup())
-		}
-		v.estack.PushItem(stackitem.NewArray(arr))
+	v.call(ctx, ptr.Position())
+}
 
-	case opcode.VALUES:
-		if v.estack.Len() == 0 {
-			panic("no argument")
-		}
-		item := v.estack.Pop()
-
-		var arr []stackitem.Item
-		switch t := item.value.(type) {
-		case *stackitem.Array, *stackitem.Struct:
-			src := t.Value().([]stackitem.Item)
-			arr = make([]stackitem.Item, len(src))
-			for i := range src {
-				arr[i] = cloneIfStruct(src[i])
-			}
-		case *stackitem.Map:
-			arr = make([]stackitem.Item, 0, t.Len())
-			for k := range t.Value().([]stackitem.MapElement) {
-				arr = append(arr, cloneIfStruct(t.Value().([]stackitem.MapElement)[k].Value))
-			}
-		default:
-			panic("not a Map, Array or Struct")
+func (v *VM) opCALLT(ctx *Context, op opcode.Opcode, parameter []byte) {
+	id := int32(binary.LittleEndian.Uint16(parameter))
+	if err := v.LoadToken(id); err != nil {
+		panic(err)
+	}
+}
+
+func (v *VM) opSYSCALL(ctx *Context, op opcode.Opcode, parameter []byte) {
+	interopID := GetInteropID(parameter)
+	if v.SyscallHandler == nil {
+		panic("vm's SyscallHandler is not initialized")
+	}
+	err := v.SyscallHandler(v, interopID)
+	if err != nil {
+		iName, iErr := interopnames.FromID(interopID)
+		if iErr == nil {
+			panic(fmt.Sprintf("%s failed: %s", iName, err))
 		}
+		panic(fmt.Sprintf("%d failed: %s", interopID, err))
+	}
+}
+
+func (v *VM) opRET(ctx *Context, op opcode.Opcode, parameter []byte) {
+	oldCtx := v.istack[len(v.istack)-1]
+	v.istack = v.istack[:len(v.istack)-1]
+	oldEstack := v.estack
 
-		v.estack.PushItem(stackitem.NewArray(arr))
+	v.unloadContext(oldCtx)
+	if len(v.istack) == 0 {
+		v.state = vmstate.Halt
+		return
+	}
 
-	case opcode.HASKEY:
-		if v.estack.Len() < 2 {
-			panic("not enough arguments")
+	newEstack := v.Context().sc.estack
+	if oldEstack != newEstack {
+		if oldCtx.retCount >= 0 && oldEstack.Len() != oldCtx.retCount {
+			panic(fmt.Errorf("invalid return values count: expected %d, got %d",
+				oldCtx.retCount, oldEstack.Len()))
 		}
-		key := v.estack.Pop()
-		validateMapKey(key)
-
-		c := v.estack.Pop()
-		var res bool
-		switch t := c.value.(type) {
-		case *stackitem.Array, *stackitem.Struct:
-			index := toInt(key.BigInt())
-			if index < 0 {
-				panic("negative index")
-			}
-			res = index < len(c.Array())
-		case *stackitem.Map:
-			res = t.Has(key.Item())
-		case *stackitem.Buffer, *stackitem.ByteArray:
-			index := toInt(key.BigInt())
-			if index < 0 {
-				panic("negative index")
-			}
-			res = index < len(t.Value().([]byte))
-		default:
-			panic("wrong collection type")
+		rvcount := oldEstack.Len()
+		for i := rvcount; i > 0; i-- {
+			elem := oldEstack.RemoveAt(i - 1)
+			newEstack.Push(elem)
 		}
-		v.estack.PushItem(stackitem.Bool(res))
-
-	case opcode.NOP:
-		// unlucky ^^
+		v.estack = newEstack
+	}
+}
 
-	case opcode.THROW:
-		v.throw(v.estack.Pop().Item())
+func (v *VM) opNEWMAP(ctx *Context, op opcode.Opcode, parameter []byte) {
+	v.estack.PushItem(stackitem.NewMap())
+}
 
-	case opcode.ABORT:
-		panic("ABORT")
+func (v *VM) opKEYS(ctx *Context, op opcode.Opcode, parameter []byte) {
+	if v.estack.Len() == 0 {
+		panic("no argument")
+	}
+	item := v.estack.Pop()
 
-	case opcode.ABORTMSG:
-		msg := v.estack.Pop().Bytes()
-		panic(fmt.Sprintf("%s is executed. Reason: %s", op, string(msg)))
+	m, ok := item.value.(*stackitem.Map)
+	if !ok {
+		panic("not a Map")
+	}
 
-	case opcode.ASSERT:
-		if !v.estack.Pop().Bool() {
-			panic("ASSERT failed")
-		}
+	arr := make([]stackitem.Item, 0, m.Len())
+	for k := range m.Value().([]stackitem.MapElement) {
+		arr = append(arr, m.Value().([]stackitem.MapElement)[k].Key.Dup())
+	}
+	v.estack.PushItem(stackitem.NewArray(arr))
+}
 
-	case opcode.ASSERTMSG:
-		msg := v.estack.Pop().Bytes()
-		if !v.estack.Pop().Bool() {
-			panic(fmt.Sprintf("%s is executed with false result. Reason: %s", op, msg))
-		}
+func (v *VM) opVALUES(ctx *Context, op opcode.Opcode, parameter []byte) {
+	if v.estack.Len() == 0 {
+		panic("no argument")
+	}
+	item := v.estack.Pop()
 
-	case opcode.TRY, opcode.TRYL:
-		catchP, finallyP := getTryParams(op, parameter)
-		if ctx.tryStack.Len() >= MaxTryNestingDepth {
-			panic("maximum TRY depth exceeded")
+	var arr []stackitem.Item
+	switch t := item.value.(type) {
+	case *stackitem.Array, *stackitem.Struct:
+		src := t.Value().([]stackitem.Item)
+		arr = make([]stackitem.Item, len(src))
+		for i := range src {
+			arr[i] = cloneIfStruct(src[i])
 		}
-		cOffset := getJumpOffset(ctx, catchP)
-		fOffset := getJumpOffset(ctx, finallyP)
-		if cOffset == ctx.ip && fOffset == ctx.ip {
-			panic("invalid offset for TRY*")
-		} else if cOffset == ctx.ip {
-			cOffset = -1
-		} else if fOffset == ctx.ip {
-			fOffset = -1
+	case *stackitem.Map:
+		arr = make([]stackitem.Item, 0, t.Len())
+		for k := range t.Value().([]stackitem.MapElement) {
+			arr = append(arr, cloneIfStruct(t.Value().([]stackitem.MapElement)[k].Value))
 		}
-		eCtx := newExceptionHandlingContext(cOffset, fOffset)
-		ctx.tryStack.PushItem(eCtx)
+	default:
+		panic("not a Map, Array or Struct")
+	}
+
+	v.estack.PushItem(stackitem.NewArray(arr))
+}
 
-	case opcode.ENDTRY, opcode.ENDTRYL:
-		eCtx := ctx.tryStack.Peek(0).Value().(*exceptionHandlingContext)
-		if eCtx.State == eFinally {
-			panic("invalid exception handling state during ENDTRY*")
+func (v *VM) opHASKEY(ctx *Context, op opcode.Opcode, parameter []byte) {
+	if v.estack.Len() < 2 {
+		panic("not enough arguments")
+	}
+	key := v.estack.Pop()
+	validateMapKey(key)
+
+	c := v.estack.Pop()
+	var res bool
+	switch t := c.value.(type) {
+	case *stackitem.Array, *stackitem.Struct:
+		index := toInt(key.BigInt())
+		if index < 0 {
+			panic("negative index")
 		}
-		eOffset := getJumpOffset(ctx, parameter)
-		if eCtx.HasFinally() {
-			eCtx.State = eFinally
-			eCtx.EndOffset = eOffset
-			eOffset = eCtx.FinallyOffset
-		} else {
-			ctx.tryStack.Pop()
+		res = index < len(c.Array())
+	case *stackitem.Map:
+		res = t.Has(key.Item())
+	case *stackitem.Buffer, *stackitem.ByteArray:
+		index := toInt(key.BigInt())
+		if index < 0 {
+			panic("negative index")
 		}
-		ctx.Jump(eOffset)
+		res = index < len(t.Value().([]byte))
+	default:
+		panic("wrong collection type")
+	}
+	v.estack.PushItem(stackitem.Bool(res))
+}
 
-	case opcode.ENDFINALLY:
-		if v.uncaughtException != nil {
-			v.handleException()
-			return
-		}
-		eCtx := ctx.tryStack.Pop().Value().(*exceptionHandlingContext)
-		ctx.Jump(eCtx.EndOffset)
+func (v *VM) opNOP(ctx *Context, op opcode.Opcode, parameter []byte) {
+	// unlucky ^^
+}
 
-	default:
-		panic(fmt.Sprintf("unknown opcode %s", op.String()))
+func (v *VM) opTHROW(ctx *Context, op opcode.Opcode, parameter []byte) {
+	v.throw(v.estack.Pop().Item())
+}
+
+func (v *VM) opABORT(ctx *Context, op opcode.Opcode, parameter []byte) {
+	panic("ABORT")
+}
+
+func (v *VM) opABORTMSG(ctx *Context, op opcode.Opcode, parameter []byte) {
+	msg := v.estack.Pop().Bytes()
+	panic(fmt.Sprintf("%s is executed. Reason: %s", op, string(msg)))
+}
+
+func (v *VM) opASSERT(ctx *Context, op opcode.Opcode, parameter []byte) {
+	if !v.estack.Pop().Bool() {
+		panic("ASSERT failed")
 	}
-	return
+}
+
+func (v *VM) opASSERTMSG(ctx *Context, op opcode.Opcode, parameter []byte) {
+	msg := v.estack.Pop().Bytes()
+	if !v.estack.Pop().Bool() {
+		panic(fmt.Sprintf("%s is executed with false result. Reason: %s", op, msg))
+	}
+}
+
+func (v *VM) opTRY(ctx *Context, op opcode.Opcode, parameter []byte) {
+	catchP, finallyP := getTryParams(op, parameter)
+	if ctx.tryStack.Len() >= MaxTryNestingDepth {
+		panic("maximum TRY depth exceeded")
+	}
+	cOffset := getJumpOffset(ctx, catchP)
+	fOffset := getJumpOffset(ctx, finallyP)
+	if cOffset == ctx.ip && fOffset == ctx.ip {
+		panic("invalid offset for TRY*")
+	} else if cOffset == ctx.ip {
+		cOffset = -1
+	} else if fOffset == ctx.ip {
+		fOffset = -1
+	}
+	eCtx := newExceptionHandlingContext(cOffset, fOffset)
+	ctx.tryStack.PushItem(eCtx)
+}
+
+func (v *VM) opENDTRY(ctx *Context, op opcode.Opcode, parameter []byte) {
+	eCtx := ctx.tryStack.Peek(0).Value().(*exceptionHandlingContext)
+	if eCtx.State == eFinally {
+		panic("invalid exception handling state during ENDTRY*")
+	}
+	eOffset := getJumpOffset(ctx, parameter)
+	if eCtx.HasFinally() {
+		eCtx.State = eFinally
+		eCtx.EndOffset = eOffset
+		eOffset = eCtx.FinallyOffset
+	} else {
+		ctx.tryStack.Pop()
+	}
+	ctx.Jump(eOffset)
+}
+
+func (v *VM) opENDFINALLY(ctx *Context, op opcode.Opcode, parameter []byte) {
+	if v.uncaughtException != nil {
+		v.handleException()
+		return
+	}
+	eCtx := ctx.tryStack.Pop().Value().(*exceptionHandlingContext)
+	ctx.Jump(eCtx.EndOffset)
 }
 
 func (v *VM) unloadContext(ctx *Context) {
@@ -2056,6 +2190,20 @@ func (v *VM) GetCurrentScriptHash() util.Uint160 {
 	return v.getContextScriptHash(0)
 }
 
+// int64FromBytes decodes little-endian two's complement integer of up to 8
+// bytes (PUSHINT8-PUSHINT64 parameter).
+func int64FromBytes(b []byte) int64 {
+	var n uint64
+	for i := len(b) - 1; i >= 0; i-- {
+		n = n<<8 | uint64(b[i])
+	}
+	if l := len(b); l > 0 && l < 8 {
+		shift := 64 - 8*uint(l)
+		return int64(n<<shift) >> shift
+	}
+	return int64(n)
+}
+
 // toInt converts an item to a 32-bit int.
 func toInt(i *big.Int) int {
 	if !i.IsInt64() {
@@ -98,6 +98,37 @@ func TestVM_SetPriceGetter(t *testing.T) {
 	})
 }
 
+func TestOpcodeLimit(t *testing.T) {
+	prog := []byte{byte(opcode.PUSH1), byte(opcode.PUSH2), byte(opcode.ADD), byte(opcode.RET)}
+
+	t.Run("sufficient", func(t *testing.T) {
+		v := load(prog)
+		v.OpcodeLimit = 4
+		runVM(t, v)
+	})
+	t.Run("exceeded", func(t *testing.T) {
+		v := load(prog)
+		v.OpcodeLimit = 3
+		err := v.Run()
+		require.ErrorIs(t, err, ErrOpcodeLimitExceeded)
+		require.True(t, v.HasFailed())
+	})
+	t.Run("reset", func(t *testing.T) {
+		v := load(prog)
+		v.OpcodeLimit = 3
+		v.Reset(trigger.Application)
+		v.LoadScript(prog)
+		runVM(t, v)
+	})
+}
+
+func TestOpHandlers(t *testing.T) {
+	for i := range opHandlers {
+		op := opcode.Opcode(i)
+		require.Equal(t, opcode.IsValid(op), opHandlers[op] != nil, op.String())
+	}
+}
+
 func TestAddGas(t *testing.T) {
 	v := newTestVM()
 	v.GasLimit = 10
@@ -106,6 +137,24 @@ func TestAddGas(t *testing.T) {
 	require.False(t, v.AddGas(5))
 }
 
+func TestFeeDiscount(t *testing.T) {
+	prog := []byte{byte(opcode.PUSH1), byte(opcode.PUSH2), byte(opcode.ADD), byte(opcode.RET)}
+	v := newTestVM()
+	v.SetPriceGetter(func(opcode.Opcode, []byte) int64 { return 10 })
+
+	v.Load(prog)
+	v.Context().SetFeeDiscount(50)
+	require.True(t, v.AddGas(10))
+	runVM(t, v)
+	require.EqualValues(t, 25, v.GasConsumed())
+
+	v.Load(prog)
+	v.Context().SetFeeDiscount(200)
+	require.EqualValues(t, MaxFeeDiscount, v.Context().FeeDiscount())
+	runVM(t, v)
+	require.EqualValues(t, 4, v.GasConsumed())
+}
+
 func TestPushBytes1to75(t *testing.T) {
 	buf := io.NewBufBinWriter()
 	for i := 1; i <= 75; i++ {
@@ -173,6 +222,23 @@ func TestPUSHINT(t *testing.T) {
 			runWithArgs(t, prog, bigint.FromBytes(buf))
 		})
 	}
+	t.Run("bounds", func(t *testing.T) {
+		for _, n := range []int64{0, -1, math.MinInt8, math.MaxInt8, math.MinInt16, math.MaxInt16,
+			math.MinInt32, math.MaxInt32, math.MinInt64, math.MaxInt64} {
+			for i := range byte(4) {
+				buf := bigint.ToBytes(big.NewInt(n))
+				size := 1 << i
+				if len(buf) > size {
+					continue
+				}
+				for len(buf) < size { // Sign-extend.
+					buf = append(buf, byte(n>>63))
+				}
+				prog := append([]byte{byte(opcode.PUSHINT8 + opcode.Opcode(i))}, buf...)
+				runWithArgs(t, prog, n)
+			}
+		}
+	})
 }
 
 func TestPUSHNULL(t *testing.T) {
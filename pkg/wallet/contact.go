@@ -0,0 +1,25 @@
+package wallet
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Contact is an address book entry, a named external address.
+type Contact struct {
+	Name string       `json:"name"`
+	Hash util.Uint160 `json:"script_hash"`
+}
+
+// NewContact returns the new address book entry.
+func NewContact(name string, h util.Uint160) *Contact {
+	return &Contact{
+		Name: name,
+		Hash: h,
+	}
+}
+
+// Address returns contact address from hash.
+func (c *Contact) Address() string {
+	return address.Uint160ToString(c.Hash)
+}
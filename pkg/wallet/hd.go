@@ -0,0 +1,93 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/hd"
+)
+
+// HDKey is an extended key HD (hierarchical deterministic) accounts of the
+// wallet are derived from. It's the node of the derivation tree, so that the
+// i-th account key is its i-th (non-hardened) child.
+type HDKey struct {
+	// Path is the derivation path of this key from the master key.
+	Path string `json:"path"`
+	// Key is the NEP-2 encrypted private key of this node.
+	Key string `json:"key"`
+	// ChainCode is the hex-encoded chain code of this node.
+	ChainCode string `json:"chaincode"`
+}
+
+// InitHD sets up HD account derivation for the wallet from the given BIP-39
+// mnemonic (without additional BIP-39 passphrase) using hd.DefaultPath. The
+// derived key is stored encrypted with the given passphrase, it's the same
+// one that is used for the derived accounts.
+func (w *Wallet) InitHD(mnemonic, passphrase string) error {
+	if w.Extra.HD != nil {
+		return errors.New("HD key is already set")
+	}
+	seed, err := hd.NewSeedFromMnemonic(mnemonic, "")
+	if err != nil {
+		return err
+	}
+	defer clear(seed)
+	master, err := hd.NewMasterKey(seed)
+	if err != nil {
+		return err
+	}
+	path, err := hd.ParsePath(hd.DefaultPath)
+	if err != nil {
+		return err
+	}
+	k, err := master.Derive(path)
+	if err != nil {
+		return err
+	}
+	encrypted, err := keys.NEP2Encrypt(k.PrivateKey, passphrase, w.Scrypt)
+	if err != nil {
+		return err
+	}
+	w.Extra.HD = &HDKey{
+		Path:      hd.DefaultPath,
+		Key:       encrypted,
+		ChainCode: hex.EncodeToString(k.ChainCode),
+	}
+	return nil
+}
+
+// DeriveAccount derives the account with the given index from the wallet HD
+// key (see InitHD) and encrypts it with the given passphrase which also must
+// be the one HD key is encrypted with. The account is not added to the wallet.
+func (w *Wallet) DeriveAccount(index uint32, passphrase string) (*Account, error) {
+	if w.Extra.HD == nil {
+		return nil, errors.New("wallet has no HD key")
+	}
+	if index >= hd.HardenedOffset {
+		return nil, fmt.Errorf("invalid account index: %d", index)
+	}
+	priv, err := keys.NEP2Decrypt(w.Extra.HD.Key, passphrase, w.Scrypt)
+	if err != nil {
+		return nil, fmt.Errorf("can't decrypt HD key: %w", err)
+	}
+	defer priv.Destroy()
+	chainCode, err := hex.DecodeString(w.Extra.HD.ChainCode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HD key chain code: %w", err)
+	}
+	parent, err := hd.NewExtendedKey(priv, chainCode)
+	if err != nil {
+		return nil, err
+	}
+	k, err := parent.Child(index)
+	if err != nil {
+		return nil, err
+	}
+	acc := NewAccountFromPrivateKey(k.PrivateKey)
+	if err := acc.Encrypt(passphrase, w.Scrypt); err != nil {
+		return nil, err
+	}
+	return acc, nil
+}
@@ -0,0 +1,158 @@
+/*
+Package hd implements hierarchical deterministic key derivation for Neo
+accounts. Keys are derived from BIP-39 mnemonic seeds following SLIP-10 for
+the secp256r1 (NIST P-256) curve along BIP-44 paths with the Neo coin type
+(m/44'/888'/0'/0/i for the i-th account by default), which makes them
+compatible with other Neo wallets implementing HD derivation.
+*/
+package hd
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+const (
+	// HardenedOffset is the first index of hardened child keys.
+	HardenedOffset uint32 = 0x80000000
+	// NeoCoinType is the SLIP-44 coin type registered for Neo.
+	NeoCoinType uint32 = 888
+	// DefaultPath is the default BIP-44 path of the node account keys are
+	// derived from, the i-th account key is derived as its i-th child.
+	DefaultPath = "m/44'/888'/0'/0"
+)
+
+// masterSecret is the SLIP-10 HMAC key for the secp256r1 curve.
+var masterSecret = []byte("Nist256p1 seed")
+
+// ErrInvalidPath is returned when the derivation path can't be parsed.
+var ErrInvalidPath = errors.New("invalid derivation path")
+
+// ExtendedKey is a private key with a chain code that allows to derive child
+// keys from it.
+type ExtendedKey struct {
+	// PrivateKey is the private key of this node.
+	PrivateKey *keys.PrivateKey
+	// ChainCode is the chain code of this node.
+	ChainCode []byte
+}
+
+// NewMasterKey creates a master (root) extended key from the given seed (see
+// NewSeedFromMnemonic).
+func NewMasterKey(seed []byte) (*ExtendedKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("invalid seed length: %d", len(seed))
+	}
+	var (
+		n = elliptic.P256().Params().N
+		i = hmacSHA512(masterSecret, seed)
+	)
+	for {
+		k := new(big.Int).SetBytes(i[:32])
+		if k.Sign() != 0 && k.Cmp(n) < 0 {
+			return newExtendedKey(k, i[32:])
+		}
+		i = hmacSHA512(masterSecret, i)
+	}
+}
+
+// NewExtendedKey creates an extended key from the given private key and chain
+// code.
+func NewExtendedKey(priv *keys.PrivateKey, chainCode []byte) (*ExtendedKey, error) {
+	if len(chainCode) != 32 {
+		return nil, fmt.Errorf("invalid chain code length: %d", len(chainCode))
+	}
+	return &ExtendedKey{PrivateKey: priv, ChainCode: chainCode}, nil
+}
+
+func newExtendedKey(k *big.Int, chainCode []byte) (*ExtendedKey, error) {
+	b := make([]byte, 32)
+	k.FillBytes(b)
+	defer clear(b)
+	priv, err := keys.NewPrivateKeyFromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	return NewExtendedKey(priv, chainCode)
+}
+
+// Child derives the child key with the given index, indexes starting from
+// HardenedOffset denote hardened keys.
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	var (
+		n    = elliptic.P256().Params().N
+		data = make([]byte, 0, 37)
+	)
+	if index >= HardenedOffset {
+		data = append(data, 0)
+		data = append(data, k.PrivateKey.Bytes()...)
+	} else {
+		data = append(data, k.PrivateKey.PublicKey().Bytes()...)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+	defer clear(data)
+	for {
+		i := hmacSHA512(k.ChainCode, data)
+		il := new(big.Int).SetBytes(i[:32])
+		if il.Cmp(n) < 0 {
+			il.Add(il, k.PrivateKey.D)
+			il.Mod(il, n)
+			if il.Sign() != 0 {
+				return newExtendedKey(il, i[32:])
+			}
+		}
+		data = append(append(data[:0], 1), i[32:]...)
+		data = binary.BigEndian.AppendUint32(data, index)
+	}
+}
+
+// Derive derives the key along the given path (relative to k) of child
+// indexes.
+func (k *ExtendedKey) Derive(path []uint32) (*ExtendedKey, error) {
+	var err error
+	for _, i := range path {
+		k, err = k.Child(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// ParsePath parses a derivation path in the "m/44'/888'/0'/0" format (hardened
+// indexes can also be denoted with "h" or "H" suffix) into a list of child
+// indexes.
+func ParsePath(path string) ([]uint32, error) {
+	elems := strings.Split(path, "/")
+	if elems[0] != "m" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPath, path)
+	}
+	res := make([]uint32, 0, len(elems)-1)
+	for _, e := range elems[1:] {
+		var offset uint32
+		if l := len(e) - 1; l > 0 && (e[l] == '\'' || e[l] == 'h' || e[l] == 'H') {
+			e, offset = e[:l], HardenedOffset
+		}
+		i, err := strconv.ParseUint(e, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPath, path)
+		}
+		res = append(res, uint32(i)+offset)
+	}
+	return res, nil
+}
+
+func hmacSHA512(key, data []byte) []byte {
+	h := hmac.New(sha512.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
@@ -0,0 +1,50 @@
+package hd
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtendedKey(t *testing.T) {
+	// Test vector 1 for nist256p1 from https://github.com/satoshilabs/slips/blob/master/slip-0010.md.
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+	var testCases = []struct {
+		path      string
+		chainCode string
+		private   string
+		public    string
+	}{
+		{
+			path:      "m",
+			chainCode: "beeb672fe4621673f722f38529c07392fecaa61015c80c34f29ce8b41b3cb6ea",
+			private:   "612091aaa12e22dd2abef664f8a01a82cae99ad7441b7ef8110424915c268bc2",
+			public:    "0266874dc6ade47b3ecd096745ca09bcd29638dd52c2c12117b11ed3e458cfa9e8",
+		},
+		{
+			path:      "m/0'",
+			chainCode: "3460cea53e6a6bb5fb391eeef3237ffd8724bf0a40e94943c98b83825342ee11",
+			private:   "6939694369114c67917a182c59ddb8cafc3004e63ca5d3b84403ba8613debc0c",
+			public:    "0384610f5ecffe8fda089363a41f56a5c7ffc1d81b59a612d0d649b2d22355590c",
+		},
+		{
+			path:      "m/0H/1",
+			chainCode: "4187afff1aafa8445010097fb99d23aee9f599450c7bd140b6826ac22ba21d0c",
+			private:   "284e9d38d07d21e4e281b645089a94f4cf5a5a81369acf151a1c3a57f18b2129",
+			public:    "03526c63f8d0b4bbbf9c80df553fe66742df4676b241dabefdef67733e070f6844",
+		},
+	}
+	master, err := NewMasterKey(seed)
+	require.NoError(t, err)
+	for _, tc := range testCases {
+		path, err := ParsePath(tc.path)
+		require.NoError(t, err)
+		k, err := master.Derive(path)
+		require.NoError(t, err)
+		require.Equal(t, tc.chainCode, hex.EncodeToString(k.ChainCode), tc.path)
+		require.Equal(t, tc.private, hex.EncodeToString(k.PrivateKey.Bytes()), tc.path)
+		require.Equal(t, tc.public, hex.EncodeToString(k.PrivateKey.PublicKey().Bytes()), tc.path)
+	}
+}
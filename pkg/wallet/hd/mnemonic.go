@@ -0,0 +1,124 @@
+package hd
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// DefaultEntropyBits is the default mnemonic entropy size used by
+// NewMnemonic, it corresponds to 24-word mnemonic.
+const DefaultEntropyBits = 256
+
+// seedIterations is the number of PBKDF2 iterations for mnemonic seed
+// generation.
+const seedIterations = 2048
+
+// ErrInvalidMnemonic is returned when the mnemonic has wrong number of words,
+// unknown words or invalid checksum.
+var ErrInvalidMnemonic = errors.New("invalid mnemonic")
+
+//go:embed english.txt
+var english string
+
+var (
+	// wordList is the BIP-39 English word list.
+	wordList = strings.Fields(english)
+	// wordIndex maps words from wordList to their indexes.
+	wordIndex = func() map[string]int {
+		m := make(map[string]int, len(wordList))
+		for i, w := range wordList {
+			m[w] = i
+		}
+		return m
+	}()
+)
+
+// NewMnemonic generates a new random BIP-39 mnemonic (using English word
+// list) with the given entropy size in bits which must be a multiple of 32
+// in the [128, 256] range.
+func NewMnemonic(bits int) (string, error) {
+	if bits%32 != 0 || bits < 128 || bits > 256 {
+		return "", fmt.Errorf("invalid entropy size: %d", bits)
+	}
+	entropy := make([]byte, bits/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+	return NewMnemonicFromEntropy(entropy)
+}
+
+// NewMnemonicFromEntropy returns a BIP-39 mnemonic (using English word list)
+// for the given entropy which must be 16, 20, 24, 28 or 32 bytes long.
+func NewMnemonicFromEntropy(entropy []byte) (string, error) {
+	var bits = len(entropy) * 8
+	if bits%32 != 0 || bits < 128 || bits > 256 {
+		return "", fmt.Errorf("invalid entropy size: %d", bits)
+	}
+	var (
+		csBits = bits / 32
+		h      = sha256.Sum256(entropy)
+		b      = new(big.Int).SetBytes(entropy)
+		words  = make([]string, (bits+csBits)/11)
+		mask   = big.NewInt(2047)
+		idx    = new(big.Int)
+	)
+	b.Lsh(b, uint(csBits))
+	b.Or(b, big.NewInt(int64(h[0]>>(8-csBits))))
+	for i := len(words) - 1; i >= 0; i-- {
+		idx.And(b, mask)
+		words[i] = wordList[idx.Int64()]
+		b.Rsh(b, 11)
+	}
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicToEntropy checks the given BIP-39 mnemonic (using English word
+// list) and returns the entropy it encodes.
+func MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	if len(words)%3 != 0 || len(words) < 12 || len(words) > 24 {
+		return nil, fmt.Errorf("%w: wrong number of words %d", ErrInvalidMnemonic, len(words))
+	}
+	var b = new(big.Int)
+	for _, w := range words {
+		i, ok := wordIndex[w]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown word %q", ErrInvalidMnemonic, w)
+		}
+		b.Lsh(b, 11)
+		b.Or(b, big.NewInt(int64(i)))
+	}
+	var (
+		csBits  = len(words) / 3
+		cs      = byte(b.Int64() & (1<<csBits - 1))
+		entropy = make([]byte, (len(words)*11-csBits)/8)
+	)
+	b.Rsh(b, uint(csBits))
+	b.FillBytes(entropy)
+	if h := sha256.Sum256(entropy); h[0]>>(8-csBits) != cs {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidMnemonic)
+	}
+	return entropy, nil
+}
+
+// NewSeedFromMnemonic checks the given BIP-39 mnemonic and returns a 64-byte
+// seed for it protected by the given (optional, can be empty) passphrase.
+func NewSeedFromMnemonic(mnemonic, passphrase string) ([]byte, error) {
+	if _, err := MnemonicToEntropy(mnemonic); err != nil {
+		return nil, err
+	}
+	var (
+		password = norm.NFKD.String(strings.Join(strings.Fields(mnemonic), " "))
+		salt     = norm.NFKD.String("mnemonic" + passphrase)
+	)
+	return pbkdf2.Key([]byte(password), []byte(salt), seedIterations, 64, sha512.New), nil
+}
@@ -0,0 +1,83 @@
+package hd
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMnemonic(t *testing.T) {
+	// Test vectors from https://github.com/trezor/python-mnemonic/blob/master/vectors.json.
+	var testCases = []struct {
+		entropy  string
+		mnemonic string
+		seed     string
+	}{
+		{
+			entropy:  "00000000000000000000000000000000",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			seed:     "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+			seed:     "2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			entropy:  "ffffffffffffffffffffffffffffffff",
+			mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+			seed:     "ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13332572917f0f8e5a589620c6f15b11c61dee327651a14c34e18231052e48c069",
+		},
+		{
+			entropy:  "0000000000000000000000000000000000000000000000000000000000000000",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			seed:     "bda85446c68413707090a52022edd26a1c9462295029f2e60cd7c4f2bbd3097170af7a4d73245cafa9c3cca8d561a7c3de6f5d4a10be8ed2a5e608d68f92fcc8",
+		},
+	}
+	for _, tc := range testCases {
+		entropy, err := hex.DecodeString(tc.entropy)
+		require.NoError(t, err)
+		m, err := NewMnemonicFromEntropy(entropy)
+		require.NoError(t, err)
+		require.Equal(t, tc.mnemonic, m)
+
+		actual, err := MnemonicToEntropy(m)
+		require.NoError(t, err)
+		require.Equal(t, entropy, actual)
+
+		seed, err := NewSeedFromMnemonic(m, "TREZOR")
+		require.NoError(t, err)
+		require.Equal(t, tc.seed, hex.EncodeToString(seed))
+	}
+}
+
+func TestNewMnemonic(t *testing.T) {
+	for _, bits := range []int{128, 160, 192, 224, 256} {
+		m, err := NewMnemonic(bits)
+		require.NoError(t, err)
+		require.Len(t, strings.Fields(m), bits*33/32/11)
+		entropy, err := MnemonicToEntropy(m)
+		require.NoError(t, err)
+		require.Len(t, entropy, bits/8)
+	}
+	for _, bits := range []int{0, 96, 130, 288} {
+		_, err := NewMnemonic(bits)
+		require.Error(t, err)
+	}
+}
+
+func TestMnemonicToEntropy_Invalid(t *testing.T) {
+	for _, m := range []string{
+		"",
+		"abandon abandon abandon",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon neo",
+	} {
+		_, err := MnemonicToEntropy(m)
+		require.ErrorIs(t, err, ErrInvalidMnemonic, m)
+		_, err = NewSeedFromMnemonic(m, "")
+		require.ErrorIs(t, err, ErrInvalidMnemonic, m)
+	}
+}
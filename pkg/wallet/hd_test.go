@@ -0,0 +1,54 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/hd"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWallet_HD(t *testing.T) {
+	const (
+		mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+		pass     = "pass"
+	)
+	w := NewInMemoryWallet()
+	w.Scrypt = keys.ScryptParams{N: 2, R: 1, P: 1}
+
+	_, err := w.DeriveAccount(0, pass)
+	require.Error(t, err)
+	require.ErrorIs(t, w.InitHD("abandon abandon abandon", pass), hd.ErrInvalidMnemonic)
+	require.NoError(t, w.InitHD(mnemonic, pass))
+	require.Error(t, w.InitHD(mnemonic, pass))
+	require.Equal(t, hd.DefaultPath, w.Extra.HD.Path)
+
+	seed, err := hd.NewSeedFromMnemonic(mnemonic, "")
+	require.NoError(t, err)
+	master, err := hd.NewMasterKey(seed)
+	require.NoError(t, err)
+	path, err := hd.ParsePath(hd.DefaultPath)
+	require.NoError(t, err)
+
+	// Check that wallet serialization doesn't affect derivation.
+	b, err := w.JSON()
+	require.NoError(t, err)
+	w, err = NewWalletFromBytes(b)
+	require.NoError(t, err)
+
+	for _, i := range []uint32{0, 1, 100} {
+		acc, err := w.DeriveAccount(i, pass)
+		require.NoError(t, err)
+		k, err := master.Derive(append(path, i))
+		require.NoError(t, err)
+		require.Equal(t, k.PrivateKey.Address(), acc.Address)
+
+		require.NoError(t, acc.Decrypt(pass, w.Scrypt))
+		require.Equal(t, k.PrivateKey.Bytes(), acc.PrivateKey().Bytes())
+	}
+
+	_, err = w.DeriveAccount(0, "wrong")
+	require.Error(t, err)
+	_, err = w.DeriveAccount(hd.HardenedOffset, pass)
+	require.Error(t, err)
+}
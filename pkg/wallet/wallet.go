@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
@@ -45,10 +46,18 @@ type Wallet struct {
 	path string
 }
 
-// Extra stores imported token contracts.
+// Extra stores imported token contracts, HD key, address book and account
+// tags.
 type Extra struct {
 	// Tokens is a list of imported token contracts.
 	Tokens []*Token
+	// HD is the key HD accounts are derived from, it's nil for non-HD
+	// wallets.
+	HD *HDKey `json:",omitempty"`
+	// Contacts is an address book, a list of named external addresses.
+	Contacts []*Contact `json:",omitempty"`
+	// Tags maps account addresses to the lists of tags assigned to them.
+	Tags map[string][]string `json:",omitempty"`
 }
 
 // NewWallet creates a new NEO wallet at the given location.
@@ -138,6 +147,7 @@ func (w *Wallet) RemoveAccount(addr string) error {
 		if acc.Address == addr {
 			copy(w.Accounts[i:], w.Accounts[i+1:])
 			w.Accounts = w.Accounts[:len(w.Accounts)-1]
+			delete(w.Extra.Tags, addr)
 			return nil
 		}
 	}
@@ -161,6 +171,76 @@ func (w *Wallet) RemoveToken(h util.Uint160) error {
 	return errors.New("token wasn't found")
 }
 
+// AddContact adds a new address book entry to the wallet. Contact names are
+// unique, an error is returned if the name is empty or already used.
+func (w *Wallet) AddContact(c *Contact) error {
+	if c.Name == "" {
+		return errors.New("empty contact name")
+	}
+	if w.GetContact(c.Name) != nil {
+		return fmt.Errorf("contact %q already exists", c.Name)
+	}
+	w.Extra.Contacts = append(w.Extra.Contacts, c)
+	return nil
+}
+
+// RemoveContact removes the address book entry with the specified name from
+// the wallet.
+func (w *Wallet) RemoveContact(name string) error {
+	for i, c := range w.Extra.Contacts {
+		if c.Name == name {
+			w.Extra.Contacts = slices.Delete(w.Extra.Contacts, i, i+1)
+			return nil
+		}
+	}
+	return errors.New("contact wasn't found")
+}
+
+// GetContact returns the address book entry with the specified name or nil if
+// there is none.
+func (w *Wallet) GetContact(name string) *Contact {
+	for _, c := range w.Extra.Contacts {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// SetAccountTags replaces the list of tags of the account with the specified
+// address, empty list removes all tags. The account must belong to the wallet.
+func (w *Wallet) SetAccountTags(addr string, tags []string) error {
+	if !slices.ContainsFunc(w.Accounts, func(acc *Account) bool { return acc.Address == addr }) {
+		return errors.New("account wasn't found")
+	}
+	if len(tags) == 0 {
+		delete(w.Extra.Tags, addr)
+		return nil
+	}
+	if w.Extra.Tags == nil {
+		w.Extra.Tags = make(map[string][]string)
+	}
+	w.Extra.Tags[addr] = tags
+	return nil
+}
+
+// GetAccountTags returns the list of tags of the account with the specified
+// address.
+func (w *Wallet) GetAccountTags(addr string) []string {
+	return w.Extra.Tags[addr]
+}
+
+// GetAccountsByTag returns all wallet accounts that have the specified tag.
+func (w *Wallet) GetAccountsByTag(tag string) []*Account {
+	var res []*Account
+	for _, acc := range w.Accounts {
+		if slices.Contains(w.Extra.Tags[acc.Address], tag) {
+			res = append(res, acc)
+		}
+	}
+	return res
+}
+
 // Path returns the location of the wallet on the filesystem.
 func (w *Wallet) Path() string {
 	return w.path
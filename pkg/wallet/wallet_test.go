@@ -159,6 +159,54 @@ func TestWallet_AddToken(t *testing.T) {
 	require.Equal(t, 0, len(w.Extra.Tokens))
 }
 
+func TestWallet_Contacts(t *testing.T) {
+	w := checkWalletConstructor(t)
+	c := NewContact("friend:alice", util.Uint160{1, 2, 3})
+	require.Error(t, w.AddContact(NewContact("", util.Uint160{1})))
+	require.NoError(t, w.AddContact(c))
+	require.Error(t, w.AddContact(NewContact("friend:alice", util.Uint160{4, 5, 6})))
+	require.Equal(t, 1, len(w.Extra.Contacts))
+	require.Equal(t, c, w.GetContact("friend:alice"))
+	require.Nil(t, w.GetContact("bob"))
+
+	require.NoError(t, w.Save())
+	w2, err := NewWalletFromFile(w.Path())
+	require.NoError(t, err)
+	require.Equal(t, w.Extra.Contacts, w2.Extra.Contacts)
+
+	require.Error(t, w.RemoveContact("bob"))
+	require.NoError(t, w.RemoveContact("friend:alice"))
+	require.Equal(t, 0, len(w.Extra.Contacts))
+}
+
+func TestWallet_AccountTags(t *testing.T) {
+	w := checkWalletConstructor(t)
+	acc1, err := NewAccount()
+	require.NoError(t, err)
+	acc2, err := NewAccount()
+	require.NoError(t, err)
+	w.AddAccount(acc1)
+	w.AddAccount(acc2)
+
+	require.Error(t, w.SetAccountTags("NcqKahsZ93ZyYS5bep8G2TY1zRB7tfUPdK", []string{"cold"}))
+	require.NoError(t, w.SetAccountTags(acc1.Address, []string{"cold", "savings"}))
+	require.NoError(t, w.SetAccountTags(acc2.Address, []string{"savings"}))
+	require.Equal(t, []string{"cold", "savings"}, w.GetAccountTags(acc1.Address))
+	require.Equal(t, []*Account{acc1}, w.GetAccountsByTag("cold"))
+	require.Equal(t, []*Account{acc1, acc2}, w.GetAccountsByTag("savings"))
+	require.Empty(t, w.GetAccountsByTag("hot"))
+
+	require.NoError(t, w.Save())
+	w2, err := NewWalletFromFile(w.Path())
+	require.NoError(t, err)
+	require.Equal(t, w.Extra.Tags, w2.Extra.Tags)
+
+	require.NoError(t, w.SetAccountTags(acc1.Address, nil))
+	require.Empty(t, w.GetAccountTags(acc1.Address))
+	require.NoError(t, w.RemoveAccount(acc2.Address))
+	require.Empty(t, w.Extra.Tags)
+}
+
 func TestWallet_GetAccount(t *testing.T) {
 	wallet := checkWalletConstructor(t)
 	accounts := []*Account{